@@ -0,0 +1,66 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"log"
+
+	"github.com/22smeargle/winkr-backend/internal/infrastructure/database/postgres"
+	"github.com/22smeargle/winkr-backend/internal/infrastructure/database/postgres/repositories"
+	"github.com/22smeargle/winkr-backend/internal/infrastructure/database/seed"
+	"github.com/22smeargle/winkr-backend/pkg/config"
+	"github.com/22smeargle/winkr-backend/pkg/logger"
+)
+
+// seed populates a development database with demo users, swipes, matches and messages.
+// Runs with the same flags are reproducible and only create what is missing.
+func main() {
+	defaults := seed.DefaultOptions()
+	var opts seed.Options
+	flag.Int64Var(&opts.Seed, "seed", defaults.Seed, "random seed; the same seed always produces the same data")
+	flag.IntVar(&opts.Users, "users", defaults.Users, "number of users")
+	flag.IntVar(&opts.Swipes, "swipes", defaults.Swipes, "total number of swipes, including the mutual likes of matches")
+	flag.IntVar(&opts.Matches, "matches", defaults.Matches, "number of matches")
+	flag.IntVar(&opts.MessagesPerMatch, "messages-per-match", defaults.MessagesPerMatch, "number of messages in each match's conversation")
+	flag.Parse()
+
+	// Load configuration
+	cfg, err := config.Load()
+	if err != nil {
+		log.Fatalf("Failed to load configuration: %v", err)
+	}
+
+	// Initialize logger
+	logger.Init(cfg.App.Env)
+
+	// Refuse before connecting so production is never touched
+	if err := seed.CheckEnvironment(&cfg.App); err != nil {
+		log.Fatalf("Refusing to seed: %v (app.env=%q)", err, cfg.App.Env)
+	}
+
+	// Initialize database connection
+	db, err := postgres.NewConnection(&cfg.Database)
+	if err != nil {
+		logger.Fatal("Failed to connect to database: %v", err)
+	}
+	defer func() {
+		if sqlDB, err := db.DB(); err == nil {
+			sqlDB.Close()
+		}
+	}()
+
+	seeder := seed.NewSeeder(
+		&cfg.App,
+		repositories.NewUserRepository(db),
+		repositories.NewMatchRepository(db),
+		repositories.NewMessageRepository(db),
+	)
+
+	result, err := seeder.Run(context.Background(), opts)
+	if err != nil {
+		logger.Fatal("Failed to seed demo data: %v", err)
+	}
+
+	log.Printf("Seeded %d users, %d swipes, %d matches, %d conversations and %d messages (seed %d, password %q)",
+		result.Users, result.Swipes, result.Matches, result.Conversations, result.Messages, opts.Seed, seed.DemoPassword)
+}
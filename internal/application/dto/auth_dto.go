@@ -211,6 +211,8 @@ type SessionDTO struct {
 	CreatedAt    string `json:"created_at"`
 	ExpiresAt    string `json:"expires_at"`
 	IsActive     bool   `json:"is_active"`
+	UserAgent    string `json:"user_agent,omitempty"`
+	IsCurrent    bool   `json:"is_current"` // The session the request was made from
 }
 
 // DeviceInfoDTO represents device information DTO
@@ -228,6 +230,14 @@ type SessionsResponseDTO struct {
 	Error   *ErrorDTO     `json:"error,omitempty"`
 }
 
+// RevokeSessionResponseDTO represents revoke session response DTO
+type RevokeSessionResponseDTO struct {
+	Success   bool      `json:"success"`
+	Message   string    `json:"message"`
+	IsCurrent bool      `json:"is_current"`
+	Error     *ErrorDTO `json:"error,omitempty"`
+}
+
 // UserProfileResponseDTO represents user profile response DTO
 type UserProfileResponseDTO struct {
 	Success bool        `json:"success"`
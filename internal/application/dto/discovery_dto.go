@@ -12,18 +12,35 @@ import (
 type DiscoveryUser struct {
 	ID               uuid.UUID  `json:"id"`
 	FirstName         string     `json:"first_name"`
-	Age              int        `json:"age"`
+	Age              int        `json:"age,omitempty"`
 	Bio              *string    `json:"bio"`
 	Location         *Location  `json:"location,omitempty"`
-	Distance         float64    `json:"distance"` // in kilometers
+	DistanceKm       *float64   `json:"distance_km"` // Null when either user has no location, or the user hides their distance
+	HeightCm         *int       `json:"height_cm,omitempty"`
+	Smoking          *string    `json:"smoking,omitempty"`
+	Drinking         *string    `json:"drinking,omitempty"`
+	Interests        []string   `json:"interests,omitempty"`
+	CommonInterests  []string   `json:"common_interests,omitempty"` // Interests shared with the requesting user
+	InterestScore    float64    `json:"interest_score"`             // Jaccard similarity of both users' interests, from 0 to 1
+	Score            *MatchScore `json:"score,omitempty"`           // Breakdown of the ranking score, only in debug mode
 	IsVerified       bool        `json:"is_verified"`
 	VerificationLevel int         `json:"verification_level"`
 	IsPremium        bool        `json:"is_premium"`
 	Photos           []*Photo    `json:"photos"`
-	LastActive       *time.Time  `json:"last_active"`
+	LastActive       *time.Time  `json:"last_active,omitempty"`
 	CreatedAt        time.Time   `json:"created_at"`
 }
 
+// MatchScore breaks down the score a discovery page was ranked by. Each part runs from 0 to 1, and the
+// total is their average weighted by the match scoring weights in effect.
+type MatchScore struct {
+	Total             float64 `json:"total"`
+	Distance          float64 `json:"distance"`
+	SharedInterests   float64 `json:"shared_interests"`
+	ActivityRecency   float64 `json:"activity_recency"`
+	ProfileCompletion float64 `json:"profile_completion"`
+}
+
 // Location represents location information
 type Location struct {
 	Lat     float64 `json:"lat"`
@@ -58,6 +75,35 @@ type MatchWithDetails struct {
 	HasConversation bool       `json:"has_conversation"`
 }
 
+// MatchCompatibility breaks down how well the two users of a match fit together. Parts that would
+// give away what either user hid from their profile are left out.
+type MatchCompatibility struct {
+	MatchID         uuid.UUID              `json:"match_id"`
+	Score           float64                `json:"score"` // 0-100, over the parts that could be compared
+	SharedInterests *InterestCompatibility `json:"shared_interests"`
+	Distance        *DistanceCompatibility `json:"distance,omitempty"`
+	AgeProximity    *AgeCompatibility      `json:"age_proximity,omitempty"`
+	CalculatedAt    time.Time              `json:"calculated_at"`
+}
+
+// InterestCompatibility represents the interests both users list
+type InterestCompatibility struct {
+	Interests []string `json:"interests"`
+	Score     float64  `json:"score"` // 0-100, Jaccard similarity of both users' interests
+}
+
+// DistanceCompatibility represents how far apart both users are
+type DistanceCompatibility struct {
+	DistanceKm float64 `json:"distance_km"`
+	Score      float64 `json:"score"` // 0-100, closer is better
+}
+
+// AgeCompatibility represents how close in age both users are
+type AgeCompatibility struct {
+	AgeDifference int     `json:"age_difference"` // in years
+	Score         float64 `json:"score"`          // 0-100, smaller difference is better
+}
+
 // Message represents a message in match details
 type Message struct {
 	ID           uuid.UUID `json:"id"`
@@ -103,8 +149,9 @@ type DiscoveryStats struct {
 	GeneratedAt       time.Time `json:"generated_at"`
 }
 
-// NewDiscoveryUser creates a new DiscoveryUser from entities
-func NewDiscoveryUser(user *entities.User, photos []*entities.Photo, distance float64) *DiscoveryUser {
+// NewDiscoveryUser creates a new DiscoveryUser from entities, omitting the fields the user has hidden.
+// distanceKm is nil when the distance to the user is not known.
+func NewDiscoveryUser(user *entities.User, photos []*entities.Photo, distanceKm *float64, preferences *entities.UserPreferences) *DiscoveryUser {
 	discoveryPhotos := make([]*Photo, 0, len(photos))
 	for _, photo := range photos {
 		discoveryPhotos = append(discoveryPhotos, &Photo{
@@ -126,13 +173,17 @@ func NewDiscoveryUser(user *entities.User, photos []*entities.Photo, distance fl
 		}
 	}
 
-	return &DiscoveryUser{
+	discoveryUser := &DiscoveryUser{
 		ID:               user.ID,
 		FirstName:         user.FirstName,
 		Age:              user.GetAge(),
 		Bio:              user.Bio,
 		Location:         location,
-		Distance:         distance,
+		DistanceKm:       distanceKm,
+		HeightCm:         user.HeightCm,
+		Smoking:          user.Smoking,
+		Drinking:         user.Drinking,
+		Interests:        user.Interests,
 		IsVerified:       user.IsVerified,
 		VerificationLevel: int(user.VerificationLevel),
 		IsPremium:        user.IsPremium,
@@ -140,6 +191,36 @@ func NewDiscoveryUser(user *entities.User, photos []*entities.Photo, distance fl
 		LastActive:       user.LastActive,
 		CreatedAt:        user.CreatedAt,
 	}
+
+	hidden := func(field string) bool {
+		return preferences.IsProfileFieldHidden(field, user.IsPremium)
+	}
+	if hidden(entities.ProfileFieldAge) {
+		discoveryUser.Age = 0
+	}
+	if hidden(entities.ProfileFieldBio) {
+		discoveryUser.Bio = nil
+	}
+	if hidden(entities.ProfileFieldLocation) {
+		discoveryUser.Location = nil
+	}
+	if hidden(entities.ProfileFieldDistance) {
+		discoveryUser.DistanceKm = nil
+	}
+	if hidden(entities.ProfileFieldHeight) {
+		discoveryUser.HeightCm = nil
+	}
+	if hidden(entities.ProfileFieldSmoking) {
+		discoveryUser.Smoking = nil
+	}
+	if hidden(entities.ProfileFieldDrinking) {
+		discoveryUser.Drinking = nil
+	}
+	if hidden(entities.ProfileFieldLastActive) {
+		discoveryUser.LastActive = nil
+	}
+
+	return discoveryUser
 }
 
 // NewMatch creates a new Match from entities
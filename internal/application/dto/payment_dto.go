@@ -0,0 +1,16 @@
+package dto
+
+import "time"
+
+// PaymentDTOs contain all payment related data transfer objects
+
+// SubscriptionChangePreviewDTO represents the cost of switching a subscription to another price
+type SubscriptionChangePreviewDTO struct {
+	SubscriptionID  string    `json:"subscription_id"`
+	PlanID          string    `json:"plan_id"`
+	PriceID         string    `json:"price_id"`
+	ProrationAmount int64     `json:"proration_amount"`
+	AmountDue       int64     `json:"amount_due"`
+	Currency        string    `json:"currency"`
+	NextBillingDate time.Time `json:"next_billing_date"`
+}
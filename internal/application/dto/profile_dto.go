@@ -12,6 +12,15 @@ type UpdateProfileRequestDTO struct {
 	LastName     *string       `json:"last_name" validate:"omitempty,min=2,max=100"`
 	Bio          *string       `json:"bio" validate:"omitempty,max=500"`
 	InterestedIn []string      `json:"interested_in" validate:"omitempty,min=1,dive,oneof=male female other"`
+	Interests    []string      `json:"interests" validate:"omitempty,max=10,dive,max=30"`
+	HeightCm     *int          `json:"height_cm" validate:"omitempty,min=120,max=230"`
+	Smoking      *string       `json:"smoking" validate:"omitempty,oneof=never socially regularly"`
+	Drinking     *string       `json:"drinking" validate:"omitempty,oneof=never socially regularly"`
+	Locale       *string       `json:"locale" validate:"omitempty,max=35"`
+	Timezone     *string       `json:"timezone" validate:"omitempty,max=64"`
+	AutoTranslateMessages *bool `json:"auto_translate_messages"`
+	SendReadReceipts *bool      `json:"send_read_receipts"`
+	AutoIntro    *string       `json:"auto_intro" validate:"omitempty,max=300"`
 	Preferences  *PreferencesDTO `json:"preferences"`
 }
 
@@ -36,6 +45,7 @@ type PreferencesDTO struct {
 	AgeMax      int `json:"age_max" validate:"omitempty,min=18,max=100"`
 	MaxDistance int `json:"max_distance" validate:"omitempty,min=1,max=500"`
 	ShowMe      bool `json:"show_me"`
+	HiddenFields []string `json:"hidden_fields" validate:"omitempty,dive,required"`
 }
 
 // ProfileResponseDTO represents profile response DTO
@@ -98,6 +108,11 @@ type UnblockUserRequestDTO struct {
 	UserID string `json:"user_id" validate:"required,uuid"`
 }
 
+// BlockContactsRequestDTO represents block contacts request DTO
+type BlockContactsRequestDTO struct {
+	ContactHashes []string `json:"contact_hashes" validate:"required,min=1"`
+}
+
 // ReportUserRequestDTO represents report user request DTO
 type ReportUserRequestDTO struct {
 	UserID      string `json:"user_id" validate:"required,uuid"`
@@ -0,0 +1,194 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/22smeargle/winkr-backend/internal/domain/entities"
+	"github.com/22smeargle/winkr-backend/internal/infrastructure/external/stripe"
+	"github.com/22smeargle/winkr-backend/pkg/config"
+	"github.com/22smeargle/winkr-backend/pkg/logger"
+)
+
+// ErrBillingCleanupFailed is returned when billing could not be stopped for a deleted account
+var ErrBillingCleanupFailed = errors.New("failed to stop billing for account")
+
+// StripeBillingClient defines the Stripe operations needed to stop billing a deleted account
+type StripeBillingClient interface {
+	CancelSubscription(ctx context.Context, subscriptionID string, cancelAtPeriodEnd bool) (*stripe.Subscription, error)
+	DeleteCustomer(ctx context.Context, customerID string) error
+	HasOpenInvoices(ctx context.Context, customerID string) (bool, error)
+}
+
+// AccountSubscriptionStore defines the subscription persistence needed during account deletion
+type AccountSubscriptionStore interface {
+	GetUserSubscriptionHistory(ctx context.Context, userID uuid.UUID, limit, offset int) ([]*entities.Subscription, error)
+	Update(ctx context.Context, subscription *entities.Subscription) error
+}
+
+// AccountBillingService stops Stripe billing when a user account is deleted
+type AccountBillingService struct {
+	subscriptionStore AccountSubscriptionStore
+	stripeClient      StripeBillingClient
+	config            *config.StripeAccountDeletionConfig
+}
+
+// NewAccountBillingService creates a new AccountBillingService
+func NewAccountBillingService(
+	subscriptionStore AccountSubscriptionStore,
+	stripeClient StripeBillingClient,
+	cfg *config.StripeAccountDeletionConfig,
+) *AccountBillingService {
+	return &AccountBillingService{
+		subscriptionStore: subscriptionStore,
+		stripeClient:      stripeClient,
+		config:            cfg,
+	}
+}
+
+// BillingCleanupResult records what happened to a user's billing during account deletion
+type BillingCleanupResult struct {
+	UserID              uuid.UUID `json:"user_id"`
+	CanceledImmediately []string  `json:"canceled_immediately,omitempty"`
+	CanceledAtPeriodEnd []string  `json:"canceled_at_period_end,omitempty"`
+	DeletedCustomers    []string  `json:"deleted_customers,omitempty"`
+	RetainedCustomers   []string  `json:"retained_customers,omitempty"`
+	Outcome             string    `json:"outcome"` // 'succeeded', 'failed', 'no_billing'
+	Error               string    `json:"error,omitempty"`
+	CompletedAt         time.Time `json:"completed_at"`
+}
+
+// maxSubscriptionsPerAccount bounds the subscription history scanned for billable subscriptions
+const maxSubscriptionsPerAccount = 100
+
+// CleanupOnAccountDeletion cancels every billable subscription and removes the Stripe customer.
+// Any failure to reach Stripe is returned so the caller can abort the deletion instead of
+// leaving a deleted user billable.
+func (s *AccountBillingService) CleanupOnAccountDeletion(ctx context.Context, userID uuid.UUID, reason string) (*BillingCleanupResult, error) {
+	result := &BillingCleanupResult{UserID: userID}
+
+	err := s.cleanup(ctx, userID, result)
+	result.CompletedAt = time.Now()
+	if err != nil {
+		result.Outcome = "failed"
+		result.Error = err.Error()
+	} else if len(result.CanceledImmediately)+len(result.CanceledAtPeriodEnd)+len(result.DeletedCustomers) == 0 {
+		result.Outcome = "no_billing"
+	} else {
+		result.Outcome = "succeeded"
+	}
+
+	s.logAccountAction(userID, "account_deletion_billing_cleanup", reason, result)
+
+	if err != nil {
+		return result, fmt.Errorf("%w: %v", ErrBillingCleanupFailed, err)
+	}
+	return result, nil
+}
+
+// CancelAccountBilling runs the deletion cleanup and reports only whether it succeeded.
+// It lets the profile use cases depend on billing without importing this package.
+func (s *AccountBillingService) CancelAccountBilling(ctx context.Context, userID uuid.UUID, reason string) error {
+	_, err := s.CleanupOnAccountDeletion(ctx, userID, reason)
+	return err
+}
+
+func (s *AccountBillingService) cleanup(ctx context.Context, userID uuid.UUID, result *BillingCleanupResult) error {
+	subscriptions, err := s.subscriptionStore.GetUserSubscriptionHistory(ctx, userID, maxSubscriptionsPerAccount, 0)
+	if err != nil {
+		return fmt.Errorf("failed to get user subscriptions: %w", err)
+	}
+
+	// Customers can only be deleted once none of their subscriptions are left running to period end
+	customers := make(map[string]bool)
+	openInvoices := make(map[string]bool)
+
+	for _, subscription := range subscriptions {
+		customerID := ""
+		if subscription.StripeCustomerID != nil {
+			customerID = *subscription.StripeCustomerID
+			if _, seen := customers[customerID]; !seen {
+				customers[customerID] = true
+				hasOpen, err := s.stripeClient.HasOpenInvoices(ctx, customerID)
+				if err != nil {
+					return fmt.Errorf("failed to check open invoices for customer %s: %w", customerID, err)
+				}
+				openInvoices[customerID] = hasOpen
+			}
+		}
+
+		if !isBillableSubscription(subscription) {
+			continue
+		}
+
+		cancelAtPeriodEnd := s.config.CancelAtPeriodEnd
+		if openInvoices[customerID] {
+			cancelAtPeriodEnd = s.config.CancelAtPeriodEndWithOpenInvoices
+		}
+
+		if subscription.StripeSubscriptionID != nil {
+			stripeSubscriptionID := *subscription.StripeSubscriptionID
+			if _, err := s.stripeClient.CancelSubscription(ctx, stripeSubscriptionID, cancelAtPeriodEnd); err != nil {
+				return fmt.Errorf("failed to cancel Stripe subscription %s: %w", stripeSubscriptionID, err)
+			}
+		}
+
+		if cancelAtPeriodEnd {
+			subscription.SetCancelAtPeriodEnd(true)
+			result.CanceledAtPeriodEnd = append(result.CanceledAtPeriodEnd, subscription.ID.String())
+			if customerID != "" {
+				customers[customerID] = false
+			}
+		} else {
+			subscription.Cancel()
+			result.CanceledImmediately = append(result.CanceledImmediately, subscription.ID.String())
+		}
+
+		if err := s.subscriptionStore.Update(ctx, subscription); err != nil {
+			return fmt.Errorf("failed to update subscription %s: %w", subscription.ID, err)
+		}
+	}
+
+	for customerID, deletable := range customers {
+		if !deletable || !s.config.DeleteCustomer {
+			result.RetainedCustomers = append(result.RetainedCustomers, customerID)
+			continue
+		}
+
+		if err := s.stripeClient.DeleteCustomer(ctx, customerID); err != nil {
+			return fmt.Errorf("failed to delete Stripe customer %s: %w", customerID, err)
+		}
+		result.DeletedCustomers = append(result.DeletedCustomers, customerID)
+	}
+
+	return nil
+}
+
+// isBillableSubscription returns true if Stripe may still charge for the subscription
+func isBillableSubscription(subscription *entities.Subscription) bool {
+	if subscription.WillCancelAtPeriodEnd() {
+		return false
+	}
+
+	switch subscription.Status {
+	case "active", "trialing", "past_due", "unpaid":
+		return true
+	default:
+		return false
+	}
+}
+
+// logAccountAction logs an account lifecycle action for audit purposes
+func (s *AccountBillingService) logAccountAction(userID uuid.UUID, action, reason string, result *BillingCleanupResult) {
+	logger.Info("Account action logged",
+		"user_id", userID,
+		"action", action,
+		"reason", reason,
+		"outcome", result.Outcome,
+		"metadata", result,
+		"timestamp", result.CompletedAt,
+	)
+}
@@ -0,0 +1,161 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/22smeargle/winkr-backend/internal/domain/entities"
+	"github.com/22smeargle/winkr-backend/internal/infrastructure/external/stripe"
+	"github.com/22smeargle/winkr-backend/pkg/config"
+)
+
+// MockStripeBillingClient is a mock implementation of StripeBillingClient
+type MockStripeBillingClient struct {
+	mock.Mock
+}
+
+func (m *MockStripeBillingClient) CancelSubscription(ctx context.Context, subscriptionID string, cancelAtPeriodEnd bool) (*stripe.Subscription, error) {
+	args := m.Called(ctx, subscriptionID, cancelAtPeriodEnd)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*stripe.Subscription), args.Error(1)
+}
+
+func (m *MockStripeBillingClient) DeleteCustomer(ctx context.Context, customerID string) error {
+	args := m.Called(ctx, customerID)
+	return args.Error(0)
+}
+
+func (m *MockStripeBillingClient) HasOpenInvoices(ctx context.Context, customerID string) (bool, error) {
+	args := m.Called(ctx, customerID)
+	return args.Bool(0), args.Error(1)
+}
+
+// MockAccountSubscriptionStore is a mock implementation of AccountSubscriptionStore
+type MockAccountSubscriptionStore struct {
+	mock.Mock
+}
+
+func (m *MockAccountSubscriptionStore) GetUserSubscriptionHistory(ctx context.Context, userID uuid.UUID, limit, offset int) ([]*entities.Subscription, error) {
+	args := m.Called(ctx, userID, limit, offset)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*entities.Subscription), args.Error(1)
+}
+
+func (m *MockAccountSubscriptionStore) Update(ctx context.Context, subscription *entities.Subscription) error {
+	args := m.Called(ctx, subscription)
+	return args.Error(0)
+}
+
+func billableSubscription(userID uuid.UUID, stripeSubscriptionID, customerID string) *entities.Subscription {
+	return &entities.Subscription{
+		ID:                   uuid.New(),
+		UserID:               userID,
+		StripeSubscriptionID: &stripeSubscriptionID,
+		StripeCustomerID:     &customerID,
+		PlanType:             "premium",
+		Status:               "active",
+	}
+}
+
+func defaultAccountDeletionConfig() *config.StripeAccountDeletionConfig {
+	return &config.StripeAccountDeletionConfig{
+		CancelAtPeriodEnd:                 false,
+		CancelAtPeriodEndWithOpenInvoices: true,
+		DeleteCustomer:                    true,
+	}
+}
+
+func TestAccountBillingService_CleanupOnAccountDeletion_CancelsAndDeletesCustomer(t *testing.T) {
+	ctx := context.Background()
+	userID := uuid.New()
+	subscription := billableSubscription(userID, "sub_123", "cus_123")
+
+	store := new(MockAccountSubscriptionStore)
+	client := new(MockStripeBillingClient)
+	store.On("GetUserSubscriptionHistory", ctx, userID, maxSubscriptionsPerAccount, 0).Return([]*entities.Subscription{subscription}, nil)
+	store.On("Update", ctx, subscription).Return(nil)
+	client.On("HasOpenInvoices", ctx, "cus_123").Return(false, nil)
+	client.On("CancelSubscription", ctx, "sub_123", false).Return(&stripe.Subscription{ID: "sub_123", Status: "canceled"}, nil)
+	client.On("DeleteCustomer", ctx, "cus_123").Return(nil)
+
+	service := NewAccountBillingService(store, client, defaultAccountDeletionConfig())
+	result, err := service.CleanupOnAccountDeletion(ctx, userID, "no longer needed")
+
+	assert.NoError(t, err)
+	assert.Equal(t, "succeeded", result.Outcome)
+	assert.Equal(t, []string{subscription.ID.String()}, result.CanceledImmediately)
+	assert.Equal(t, []string{"cus_123"}, result.DeletedCustomers)
+	assert.True(t, subscription.IsCanceled())
+	store.AssertExpectations(t)
+	client.AssertExpectations(t)
+}
+
+func TestAccountBillingService_CleanupOnAccountDeletion_OpenInvoicesCancelAtPeriodEnd(t *testing.T) {
+	ctx := context.Background()
+	userID := uuid.New()
+	subscription := billableSubscription(userID, "sub_123", "cus_123")
+
+	store := new(MockAccountSubscriptionStore)
+	client := new(MockStripeBillingClient)
+	store.On("GetUserSubscriptionHistory", ctx, userID, maxSubscriptionsPerAccount, 0).Return([]*entities.Subscription{subscription}, nil)
+	store.On("Update", ctx, subscription).Return(nil)
+	client.On("HasOpenInvoices", ctx, "cus_123").Return(true, nil)
+	client.On("CancelSubscription", ctx, "sub_123", true).Return(&stripe.Subscription{ID: "sub_123", CancelAtPeriodEnd: true}, nil)
+
+	service := NewAccountBillingService(store, client, defaultAccountDeletionConfig())
+	result, err := service.CleanupOnAccountDeletion(ctx, userID, "")
+
+	assert.NoError(t, err)
+	assert.Equal(t, "succeeded", result.Outcome)
+	assert.Equal(t, []string{subscription.ID.String()}, result.CanceledAtPeriodEnd)
+	assert.Equal(t, []string{"cus_123"}, result.RetainedCustomers)
+	assert.Empty(t, result.DeletedCustomers)
+	assert.True(t, subscription.WillCancelAtPeriodEnd())
+	client.AssertNotCalled(t, "DeleteCustomer", mock.Anything, mock.Anything)
+}
+
+func TestAccountBillingService_CleanupOnAccountDeletion_StripeFailure(t *testing.T) {
+	ctx := context.Background()
+	userID := uuid.New()
+	subscription := billableSubscription(userID, "sub_123", "cus_123")
+
+	store := new(MockAccountSubscriptionStore)
+	client := new(MockStripeBillingClient)
+	store.On("GetUserSubscriptionHistory", ctx, userID, maxSubscriptionsPerAccount, 0).Return([]*entities.Subscription{subscription}, nil)
+	client.On("HasOpenInvoices", ctx, "cus_123").Return(false, nil)
+	client.On("CancelSubscription", ctx, "sub_123", false).Return(nil, errors.New("stripe unavailable"))
+
+	service := NewAccountBillingService(store, client, defaultAccountDeletionConfig())
+	result, err := service.CleanupOnAccountDeletion(ctx, userID, "")
+
+	assert.Error(t, err)
+	assert.True(t, errors.Is(err, ErrBillingCleanupFailed))
+	assert.Equal(t, "failed", result.Outcome)
+	assert.True(t, subscription.IsActive())
+	store.AssertNotCalled(t, "Update", mock.Anything, mock.Anything)
+	client.AssertNotCalled(t, "DeleteCustomer", mock.Anything, mock.Anything)
+}
+
+func TestAccountBillingService_CleanupOnAccountDeletion_NoBilling(t *testing.T) {
+	ctx := context.Background()
+	userID := uuid.New()
+
+	store := new(MockAccountSubscriptionStore)
+	client := new(MockStripeBillingClient)
+	store.On("GetUserSubscriptionHistory", ctx, userID, maxSubscriptionsPerAccount, 0).Return([]*entities.Subscription{}, nil)
+
+	service := NewAccountBillingService(store, client, defaultAccountDeletionConfig())
+	result, err := service.CleanupOnAccountDeletion(ctx, userID, "")
+
+	assert.NoError(t, err)
+	assert.Equal(t, "no_billing", result.Outcome)
+	client.AssertNotCalled(t, "CancelSubscription", mock.Anything, mock.Anything, mock.Anything)
+}
@@ -0,0 +1,122 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/22smeargle/winkr-backend/internal/infrastructure/database/redis"
+	"github.com/22smeargle/winkr-backend/pkg/config"
+	"github.com/22smeargle/winkr-backend/pkg/errors"
+)
+
+// recordFailedLoginScript counts a failed login and locks the account once the attempts reach the
+// maximum. Each lockout doubles the previous one up to the maximum duration, and the lockout count
+// is forgotten once the account stays unlocked for the reset period. It returns the lockout in
+// milliseconds, or 0 if the account was not locked.
+//
+// KEYS[1] failed attempts, KEYS[2] lockout count, KEYS[3] lock
+// ARGV[1] max failed attempts, ARGV[2] failed attempts window, ARGV[3] first lockout,
+// ARGV[4] longest lockout, ARGV[5] reset period, all durations in milliseconds
+const recordFailedLoginScript = `
+	local failures = redis.call('INCR', KEYS[1])
+	if failures == 1 then
+		redis.call('PEXPIRE', KEYS[1], ARGV[2])
+	end
+	if failures < tonumber(ARGV[1]) then
+		return 0
+	end
+
+	redis.call('DEL', KEYS[1])
+	local lockouts = redis.call('INCR', KEYS[2])
+	local duration = tonumber(ARGV[3]) * 2 ^ (lockouts - 1)
+	if duration > tonumber(ARGV[4]) then
+		duration = tonumber(ARGV[4])
+	end
+
+	redis.call('SET', KEYS[3], lockouts, 'PX', duration)
+	redis.call('PEXPIRE', KEYS[2], duration + tonumber(ARGV[5]))
+	return duration
+`
+
+// AccountLockoutService locks accounts after too many failed logins. Counters live in Redis so
+// every instance sees the same attempts, and repeated lockouts back off exponentially.
+type AccountLockoutService struct {
+	redisClient *redis.RedisClient
+	config      *config.SecurityConfig
+	prefix      string
+	now         func() time.Time
+}
+
+// NewAccountLockoutService creates a new AccountLockoutService
+func NewAccountLockoutService(redisClient *redis.RedisClient, cfg *config.SecurityConfig) *AccountLockoutService {
+	return &AccountLockoutService{
+		redisClient: redisClient,
+		config:      cfg,
+		prefix:      "auth:lockout:",
+		now:         time.Now,
+	}
+}
+
+// CheckLocked returns an AccountLockedError with the remaining lockout if the account is locked
+func (s *AccountLockoutService) CheckLocked(ctx context.Context, email string) error {
+	if !s.config.AccountLockoutEnabled {
+		return nil
+	}
+
+	remaining, err := s.redisClient.GetClient().PTTL(ctx, s.key(email, "locked")).Result()
+	if err != nil {
+		return fmt.Errorf("failed to check account lockout: %w", err)
+	}
+	// Missing keys have a negative TTL
+	if remaining <= 0 {
+		return nil
+	}
+	return errors.NewAccountLockedError(remaining, s.now())
+}
+
+// RecordFailedAttempt counts a failed login. It returns an AccountLockedError if the attempt
+// locked the account.
+func (s *AccountLockoutService) RecordFailedAttempt(ctx context.Context, email string) error {
+	if !s.config.AccountLockoutEnabled || s.config.MaxFailedAttempts <= 0 {
+		return nil
+	}
+
+	maxDuration := s.config.AccountLockoutMaxDuration
+	if maxDuration < s.config.AccountLockoutDuration {
+		maxDuration = s.config.AccountLockoutDuration
+	}
+
+	result, err := s.redisClient.GetClient().Eval(ctx, recordFailedLoginScript,
+		[]string{s.key(email, "failures"), s.key(email, "lockouts"), s.key(email, "locked")},
+		s.config.MaxFailedAttempts,
+		s.config.AccountLockoutDuration.Milliseconds(),
+		s.config.AccountLockoutDuration.Milliseconds(),
+		maxDuration.Milliseconds(),
+		s.config.AccountLockoutResetAfter.Milliseconds(),
+	).Int64()
+	if err != nil {
+		return fmt.Errorf("failed to record failed login: %w", err)
+	}
+
+	if result == 0 {
+		return nil
+	}
+	return errors.NewAccountLockedError(time.Duration(result)*time.Millisecond, s.now())
+}
+
+// Reset forgets the failed attempts and previous lockouts of the account, after a successful login
+func (s *AccountLockoutService) Reset(ctx context.Context, email string) error {
+	err := s.redisClient.GetClient().Del(ctx, s.key(email, "failures"), s.key(email, "lockouts"), s.key(email, "locked")).Err()
+	if err != nil {
+		return fmt.Errorf("failed to reset account lockout: %w", err)
+	}
+	return nil
+}
+
+// key returns the key of the account's lockout state. The email is hash tagged so the keys of an
+// account stay in one Redis Cluster slot, as the script needs.
+func (s *AccountLockoutService) key(email, name string) string {
+	return fmt.Sprintf("%s{%s}:%s", s.prefix, strings.ToLower(strings.TrimSpace(email)), name)
+}
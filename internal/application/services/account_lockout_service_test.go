@@ -0,0 +1,107 @@
+package services
+
+import (
+	"context"
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	goredis "github.com/go-redis/redis/v8"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/22smeargle/winkr-backend/internal/infrastructure/database/redis"
+	"github.com/22smeargle/winkr-backend/pkg/config"
+	"github.com/22smeargle/winkr-backend/pkg/errors"
+)
+
+// newTestAccountLockoutService creates a lockout service on TEST_REDIS_ADDR, or a local Redis,
+// skipping the test when none is reachable
+func newTestAccountLockoutService(t *testing.T, cfg *config.SecurityConfig) *AccountLockoutService {
+	addr := os.Getenv("TEST_REDIS_ADDR")
+	if addr == "" {
+		addr = "localhost:6379"
+	}
+	client := goredis.NewClient(&goredis.Options{Addr: addr, DB: 15})
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+	if err := client.Ping(ctx).Err(); err != nil {
+		t.Skipf("Redis not available at %s: %v", addr, err)
+	}
+	t.Cleanup(func() { client.Close() })
+	return NewAccountLockoutService(&redis.RedisClient{Client: client}, cfg)
+}
+
+func testLockoutConfig() *config.SecurityConfig {
+	return &config.SecurityConfig{
+		AccountLockoutEnabled:     true,
+		MaxFailedAttempts:         3,
+		AccountLockoutDuration:    time.Minute,
+		AccountLockoutMaxDuration: 3 * time.Minute,
+		AccountLockoutResetAfter:  time.Hour,
+	}
+}
+
+// failUntilLocked records failed attempts until the account locks and returns the lockout
+func failUntilLocked(t *testing.T, s *AccountLockoutService, email string) *errors.AccountLockedError {
+	ctx := context.Background()
+	for i := 1; i < s.config.MaxFailedAttempts; i++ {
+		require.NoError(t, s.RecordFailedAttempt(ctx, email))
+	}
+
+	lockedErr, ok := errors.GetAccountLockedError(s.RecordFailedAttempt(ctx, email))
+	require.True(t, ok, "the last allowed attempt locks the account")
+	return lockedErr
+}
+
+func TestAccountLockoutService_LocksAfterMaxFailedAttempts(t *testing.T) {
+	s := newTestAccountLockoutService(t, testLockoutConfig())
+	ctx := context.Background()
+	email := uuid.New().String() + "@example.com"
+
+	require.NoError(t, s.CheckLocked(ctx, email))
+	assert.Equal(t, time.Minute, failUntilLocked(t, s, email).Remaining)
+
+	// The lock is shared by the email in any case
+	lockedErr, ok := errors.GetAccountLockedError(s.CheckLocked(ctx, " "+strings.ToUpper(email)))
+	require.True(t, ok)
+	assert.InDelta(t, time.Minute, lockedErr.Remaining, float64(time.Second))
+	assert.ErrorIs(t, lockedErr, errors.ErrAccountLocked)
+}
+
+func TestAccountLockoutService_BacksOffExponentially(t *testing.T) {
+	s := newTestAccountLockoutService(t, testLockoutConfig())
+	email := uuid.New().String() + "@example.com"
+
+	assert.Equal(t, time.Minute, failUntilLocked(t, s, email).Remaining)
+	assert.Equal(t, 2*time.Minute, failUntilLocked(t, s, email).Remaining)
+	assert.Equal(t, 3*time.Minute, failUntilLocked(t, s, email).Remaining, "capped at the longest lockout")
+	assert.Equal(t, 3*time.Minute, failUntilLocked(t, s, email).Remaining)
+}
+
+func TestAccountLockoutService_ResetStartsOver(t *testing.T) {
+	s := newTestAccountLockoutService(t, testLockoutConfig())
+	ctx := context.Background()
+	email := uuid.New().String() + "@example.com"
+
+	failUntilLocked(t, s, email)
+	failUntilLocked(t, s, email)
+	require.NoError(t, s.Reset(ctx, email))
+
+	require.NoError(t, s.CheckLocked(ctx, email))
+	assert.Equal(t, time.Minute, failUntilLocked(t, s, email).Remaining)
+}
+
+func TestAccountLockoutService_Disabled(t *testing.T) {
+	cfg := testLockoutConfig()
+	cfg.AccountLockoutEnabled = false
+	s := newTestAccountLockoutService(t, cfg)
+	ctx := context.Background()
+	email := uuid.New().String() + "@example.com"
+
+	for i := 0; i < 2*cfg.MaxFailedAttempts; i++ {
+		require.NoError(t, s.RecordFailedAttempt(ctx, email))
+	}
+	assert.NoError(t, s.CheckLocked(ctx, email))
+}
@@ -58,14 +58,15 @@ func NewAdminService(
 	paymentRepo repositories.PaymentRepository,
 	subscriptionRepo repositories.SubscriptionRepository,
 	verificationRepo repositories.VerificationRepository,
+	banCascader admin.UserBanCascader,
 ) *AdminService {
 	return &AdminService{
 		// Initialize use cases
 		getUsersUseCase:         admin.NewGetUsersUseCase(userRepo),
 		getUserDetailsUseCase:   admin.NewGetUserDetailsUseCase(userRepo, photoRepo, matchRepo, reportRepo),
-		updateUserUseCase:       admin.NewUpdateUserUseCase(userRepo),
+		updateUserUseCase:       admin.NewUpdateUserUseCase(userRepo, banCascader),
 		deleteUserUseCase:       admin.NewDeleteUserUseCase(userRepo, photoRepo, messageRepo, matchRepo, reportRepo),
-		suspendUserUseCase:      admin.NewSuspendUserUseCase(userRepo),
+		suspendUserUseCase:      admin.NewSuspendUserUseCase(userRepo, banCascader),
 
 		getPlatformStatsUseCase: admin.NewGetPlatformStatsUseCase(userRepo, matchRepo, messageRepo, paymentRepo, subscriptionRepo),
 		getUserStatsUseCase:     admin.NewGetUserStatsUseCase(userRepo),
@@ -0,0 +1,300 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/22smeargle/winkr-backend/pkg/config"
+	"github.com/22smeargle/winkr-backend/pkg/logger"
+)
+
+// Names of the rules built from the alerting thresholds. A configured rule with the same name
+// replaces the built-in one, which is how a threshold gets a duration or another severity.
+const (
+	AlertRuleHighErrorRate   = "high_error_rate"
+	AlertRuleSlowResponses   = "slow_responses"
+	AlertRuleHighCPUUsage    = "high_cpu_usage"
+	AlertRuleHighMemoryUsage = "high_memory_usage"
+	AlertRuleHighDiskUsage   = "high_disk_usage"
+)
+
+// alertOperators are the comparisons a rule condition can use
+var alertOperators = map[string]func(value, threshold float64) bool{
+	">":  func(value, threshold float64) bool { return value > threshold },
+	">=": func(value, threshold float64) bool { return value >= threshold },
+	"<":  func(value, threshold float64) bool { return value < threshold },
+	"<=": func(value, threshold float64) bool { return value <= threshold },
+	"==": func(value, threshold float64) bool { return value == threshold },
+}
+
+// AlertSink records the alerts fired and resolved by the evaluator and notifies about them
+type AlertSink interface {
+	AddAlert(ctx context.Context, alert *Alert) error
+	SendNotification(ctx context.Context, alert *Alert) error
+}
+
+// evaluatedRule is a rule checked by the evaluator, along with its firing state
+type evaluatedRule struct {
+	name        string
+	description string
+	alertType   AlertType
+	severity    AlertSeverity
+	metric      string
+	operator    string
+	threshold   float64
+	duration    time.Duration
+	labels      map[string]string
+	annotations map[string]string
+
+	// pendingSince is when the condition started holding, or zero when it doesn't hold
+	pendingSince time.Time
+	// alertID and firedAt identify the active alert while the rule is firing
+	alertID string
+	firedAt time.Time
+}
+
+// AlertEvaluator checks the alerting thresholds and rules against the current metrics. A rule
+// fires once its condition has held for the rule's duration, and is announced once rather than
+// on every evaluation. When the condition clears, the alert is resolved and a resolution is sent.
+type AlertEvaluator struct {
+	config *config.AlertingConfig
+	sink   AlertSink
+	now    func() time.Time
+
+	mu    sync.Mutex
+	rules []*evaluatedRule
+}
+
+// NewAlertEvaluator creates an evaluator for the configured thresholds and rules. Rules with an
+// unreadable condition are logged and skipped.
+func NewAlertEvaluator(cfg *config.AlertingConfig, sink AlertSink) *AlertEvaluator {
+	rules := make(map[string]*evaluatedRule)
+
+	// Thresholds of 0 are disabled
+	builtins := []struct {
+		name      string
+		alertType AlertType
+		severity  AlertSeverity
+		metric    string
+		threshold float64
+		desc      string
+	}{
+		{AlertRuleHighErrorRate, AlertTypeErrorRate, AlertSeverityCritical, "http_error_rate", cfg.ErrorRateThreshold, "HTTP error rate is above the threshold"},
+		{AlertRuleSlowResponses, AlertTypePerformance, AlertSeverityWarning, "http_response_time", float64(cfg.ResponseTimeThreshold), "Average response time is above the threshold"},
+		{AlertRuleHighCPUUsage, AlertTypeResource, AlertSeverityWarning, "system_cpu_usage", cfg.CPUUsageThreshold, "CPU usage is above the threshold"},
+		{AlertRuleHighMemoryUsage, AlertTypeResource, AlertSeverityWarning, "system_memory_usage", cfg.MemoryUsageThreshold, "Memory usage is above the threshold"},
+		{AlertRuleHighDiskUsage, AlertTypeResource, AlertSeverityCritical, "system_disk_usage", cfg.DiskUsageThreshold, "Disk usage is above the threshold"},
+	}
+	for _, builtin := range builtins {
+		if builtin.threshold <= 0 {
+			continue
+		}
+		rules[builtin.name] = &evaluatedRule{
+			name:        builtin.name,
+			description: builtin.desc,
+			alertType:   builtin.alertType,
+			severity:    builtin.severity,
+			metric:      builtin.metric,
+			operator:    ">",
+			threshold:   builtin.threshold,
+		}
+	}
+
+	for _, rule := range cfg.Rules {
+		if !rule.Enabled {
+			delete(rules, rule.Name)
+			continue
+		}
+
+		metric, operator, err := parseAlertCondition(rule.Condition)
+		if err != nil {
+			logger.Warn("Skipping alert rule with invalid condition", map[string]interface{}{
+				"rule":      rule.Name,
+				"condition": rule.Condition,
+				"error":     err.Error(),
+			})
+			continue
+		}
+
+		severity := AlertSeverity(rule.Severity)
+		if severity == "" {
+			severity = AlertSeverityWarning
+		}
+		alertType := AlertTypeThreshold
+		if builtin, ok := rules[rule.Name]; ok {
+			alertType = builtin.alertType
+		}
+
+		rules[rule.Name] = &evaluatedRule{
+			name:        rule.Name,
+			description: rule.Description,
+			alertType:   alertType,
+			severity:    severity,
+			metric:      metric,
+			operator:    operator,
+			threshold:   rule.Threshold,
+			duration:    rule.Duration,
+			labels:      rule.Labels,
+			annotations: rule.Annotations,
+		}
+	}
+
+	// Evaluate in a stable order so notifications are sent in the same order every cycle
+	evaluator := &AlertEvaluator{config: cfg, sink: sink, now: time.Now}
+	for _, rule := range rules {
+		evaluator.rules = append(evaluator.rules, rule)
+	}
+	sort.Slice(evaluator.rules, func(i, j int) bool {
+		return evaluator.rules[i].name < evaluator.rules[j].name
+	})
+	return evaluator
+}
+
+// parseAlertCondition reads a condition of the form "<metric> <operator>", such as
+// "db_slow_queries >=". Without an operator the metric must exceed the threshold.
+func parseAlertCondition(condition string) (string, string, error) {
+	fields := strings.Fields(condition)
+	switch len(fields) {
+	case 1:
+		return fields[0], ">", nil
+	case 2:
+		if _, ok := alertOperators[fields[1]]; !ok {
+			return "", "", fmt.Errorf("unknown operator %q", fields[1])
+		}
+		return fields[0], fields[1], nil
+	default:
+		return "", "", fmt.Errorf("condition must be a metric followed by an operator")
+	}
+}
+
+// Evaluate checks every rule against the metrics, firing and resolving alerts as conditions start
+// and stop holding. A rule whose metric is missing keeps its state until the metric is reported.
+func (e *AlertEvaluator) Evaluate(ctx context.Context, metrics map[string]interface{}) {
+	if !e.config.Enabled {
+		return
+	}
+
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	now := e.now()
+	for _, rule := range e.rules {
+		value, ok := alertMetricValue(metrics[rule.metric])
+		if !ok {
+			continue
+		}
+
+		if !alertOperators[rule.operator](value, rule.threshold) {
+			rule.pendingSince = time.Time{}
+			if rule.alertID != "" {
+				e.resolve(ctx, rule, value, now)
+			}
+			continue
+		}
+
+		if rule.pendingSince.IsZero() {
+			rule.pendingSince = now
+		}
+		// Already announced, so don't repeat it every cycle
+		if rule.alertID != "" {
+			continue
+		}
+		if now.Sub(rule.pendingSince) >= rule.duration {
+			e.fire(ctx, rule, value, now)
+		}
+	}
+}
+
+// fire raises and announces an alert for the rule
+func (e *AlertEvaluator) fire(ctx context.Context, rule *evaluatedRule, value float64, now time.Time) {
+	rule.alertID = uuid.New().String()
+	rule.firedAt = now
+
+	alert := e.newAlert(rule, value)
+	alert.Status = AlertStatusActive
+	alert.Message = fmt.Sprintf("Alert '%s' triggered: %s %s %g (value %g)", rule.name, rule.metric, rule.operator, rule.threshold, value)
+	alert.TriggeredAt = now
+
+	logger.Warn("Alert triggered", map[string]interface{}{
+		"rule":      rule.name,
+		"metric":    rule.metric,
+		"value":     value,
+		"threshold": rule.threshold,
+		"severity":  rule.severity,
+	})
+	e.record(ctx, alert)
+}
+
+// resolve resolves the rule's active alert and announces the resolution
+func (e *AlertEvaluator) resolve(ctx context.Context, rule *evaluatedRule, value float64, now time.Time) {
+	alert := e.newAlert(rule, value)
+	alert.ID = rule.alertID
+	alert.Status = AlertStatusResolved
+	alert.Message = fmt.Sprintf("Alert '%s' resolved: %s is %g", rule.name, rule.metric, value)
+	alert.TriggeredAt = rule.firedAt
+	alert.ResolvedAt = &now
+	alert.Duration = now.Sub(rule.firedAt)
+
+	rule.alertID = ""
+	rule.firedAt = time.Time{}
+
+	logger.Info("Alert resolved", map[string]interface{}{
+		"rule":   rule.name,
+		"metric": rule.metric,
+		"value":  value,
+	})
+	e.record(ctx, alert)
+}
+
+// newAlert builds the alert for a rule
+func (e *AlertEvaluator) newAlert(rule *evaluatedRule, value float64) *Alert {
+	return &Alert{
+		ID:          rule.alertID,
+		Name:        rule.name,
+		Type:        rule.alertType,
+		Severity:    rule.severity,
+		Description: rule.description,
+		Labels:      rule.labels,
+		Annotations: rule.annotations,
+		Source:      rule.metric,
+		Value:       value,
+		Threshold:   rule.threshold,
+		Condition:   rule.metric + " " + rule.operator,
+		RuleID:      rule.name,
+	}
+}
+
+// record stores the alert and sends it to the notification channels
+func (e *AlertEvaluator) record(ctx context.Context, alert *Alert) {
+	if err := e.sink.AddAlert(ctx, alert); err != nil {
+		logger.Error("Failed to store alert", err)
+	}
+	if err := e.sink.SendNotification(ctx, alert); err != nil {
+		logger.Error("Failed to send alert notification", err)
+	}
+}
+
+// alertMetricValue converts a metric to a number a rule can compare
+func alertMetricValue(value interface{}) (float64, bool) {
+	switch v := value.(type) {
+	case float64:
+		return v, true
+	case float32:
+		return float64(v), true
+	case int:
+		return float64(v), true
+	case int64:
+		return float64(v), true
+	case uint64:
+		return float64(v), true
+	case time.Duration:
+		return float64(v.Milliseconds()), true
+	default:
+		return 0, false
+	}
+}
@@ -0,0 +1,158 @@
+package services
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/22smeargle/winkr-backend/pkg/config"
+)
+
+// recordingAlertSink keeps the alerts it is sent
+type recordingAlertSink struct {
+	added    []*Alert
+	notified []*Alert
+}
+
+func (s *recordingAlertSink) AddAlert(ctx context.Context, alert *Alert) error {
+	s.added = append(s.added, alert)
+	return nil
+}
+
+func (s *recordingAlertSink) SendNotification(ctx context.Context, alert *Alert) error {
+	s.notified = append(s.notified, alert)
+	return nil
+}
+
+// newTestAlertEvaluator returns an evaluator whose clock the test advances
+func newTestAlertEvaluator(cfg *config.AlertingConfig) (*AlertEvaluator, *recordingAlertSink, *time.Time) {
+	sink := &recordingAlertSink{}
+	evaluator := NewAlertEvaluator(cfg, sink)
+	now := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	evaluator.now = func() time.Time { return now }
+	return evaluator, sink, &now
+}
+
+func TestAlertEvaluator_FiresOnceAndResolves(t *testing.T) {
+	evaluator, sink, now := newTestAlertEvaluator(&config.AlertingConfig{
+		Enabled:           true,
+		CPUUsageThreshold: 80,
+	})
+	ctx := context.Background()
+
+	evaluator.Evaluate(ctx, map[string]interface{}{"system_cpu_usage": 50.0})
+	assert.Empty(t, sink.notified)
+
+	evaluator.Evaluate(ctx, map[string]interface{}{"system_cpu_usage": 95.0})
+	require.Len(t, sink.notified, 1)
+	fired := sink.notified[0]
+	assert.Equal(t, AlertRuleHighCPUUsage, fired.Name)
+	assert.Equal(t, AlertStatusActive, fired.Status)
+	assert.Equal(t, AlertTypeResource, fired.Type)
+	assert.Equal(t, 95.0, fired.Value)
+	assert.Equal(t, 80.0, fired.Threshold)
+
+	// Test the alert is debounced while the condition holds
+	*now = now.Add(time.Minute)
+	evaluator.Evaluate(ctx, map[string]interface{}{"system_cpu_usage": 97.0})
+	assert.Len(t, sink.notified, 1)
+
+	// Test a resolution is sent when the condition clears
+	*now = now.Add(time.Minute)
+	evaluator.Evaluate(ctx, map[string]interface{}{"system_cpu_usage": 40.0})
+	require.Len(t, sink.notified, 2)
+	resolved := sink.notified[1]
+	assert.Equal(t, fired.ID, resolved.ID)
+	assert.Equal(t, AlertStatusResolved, resolved.Status)
+	require.NotNil(t, resolved.ResolvedAt)
+	assert.Equal(t, 2*time.Minute, resolved.Duration)
+	assert.Len(t, sink.added, 2)
+
+	// Test a later breach fires a new alert
+	evaluator.Evaluate(ctx, map[string]interface{}{"system_cpu_usage": 90.0})
+	require.Len(t, sink.notified, 3)
+	assert.NotEqual(t, fired.ID, sink.notified[2].ID)
+}
+
+func TestAlertEvaluator_WaitsForRuleDuration(t *testing.T) {
+	evaluator, sink, now := newTestAlertEvaluator(&config.AlertingConfig{
+		Enabled: true,
+		Rules: []config.AlertRule{{
+			Name:      "slow_queries",
+			Enabled:   true,
+			Condition: "db_slow_queries >=",
+			Threshold: 10,
+			Duration:  5 * time.Minute,
+			Severity:  "critical",
+		}},
+	})
+	ctx := context.Background()
+
+	evaluator.Evaluate(ctx, map[string]interface{}{"db_slow_queries": int64(10)})
+	*now = now.Add(4 * time.Minute)
+	evaluator.Evaluate(ctx, map[string]interface{}{"db_slow_queries": int64(12)})
+	assert.Empty(t, sink.notified, "the condition hasn't held for the duration yet")
+
+	// Test a dip below the threshold restarts the wait
+	*now = now.Add(time.Minute)
+	evaluator.Evaluate(ctx, map[string]interface{}{"db_slow_queries": int64(3)})
+	*now = now.Add(time.Minute)
+	evaluator.Evaluate(ctx, map[string]interface{}{"db_slow_queries": int64(11)})
+	*now = now.Add(4 * time.Minute)
+	evaluator.Evaluate(ctx, map[string]interface{}{"db_slow_queries": int64(11)})
+	assert.Empty(t, sink.notified)
+
+	*now = now.Add(time.Minute)
+	evaluator.Evaluate(ctx, map[string]interface{}{"db_slow_queries": int64(11)})
+	require.Len(t, sink.notified, 1)
+	assert.Equal(t, "slow_queries", sink.notified[0].Name)
+	assert.Equal(t, AlertSeverityCritical, sink.notified[0].Severity)
+}
+
+func TestAlertEvaluator_ConfiguredRuleOverridesThreshold(t *testing.T) {
+	evaluator, sink, now := newTestAlertEvaluator(&config.AlertingConfig{
+		Enabled:            true,
+		ErrorRateThreshold: 5,
+		DiskUsageThreshold: 90,
+		Rules: []config.AlertRule{
+			{Name: AlertRuleHighErrorRate, Enabled: true, Condition: "http_error_rate", Threshold: 5, Duration: 2 * time.Minute},
+			{Name: AlertRuleHighDiskUsage, Enabled: false},
+			{Name: "broken", Enabled: true, Condition: "http_error_rate !="},
+		},
+	})
+	ctx := context.Background()
+
+	metrics := map[string]interface{}{"http_error_rate": 12.0, "system_disk_usage": 99.0}
+	evaluator.Evaluate(ctx, metrics)
+	assert.Empty(t, sink.notified, "the overriding rule waits, the disabled one never fires")
+
+	*now = now.Add(2 * time.Minute)
+	evaluator.Evaluate(ctx, metrics)
+	require.Len(t, sink.notified, 1)
+	assert.Equal(t, AlertRuleHighErrorRate, sink.notified[0].Name)
+	assert.Equal(t, AlertTypeErrorRate, sink.notified[0].Type)
+}
+
+func TestAlertEvaluator_IgnoresMissingMetricsAndDisabledAlerting(t *testing.T) {
+	evaluator, sink, _ := newTestAlertEvaluator(&config.AlertingConfig{
+		Enabled:               true,
+		ResponseTimeThreshold: 1000,
+	})
+	ctx := context.Background()
+
+	evaluator.Evaluate(ctx, map[string]interface{}{"http_response_time": int64(1500)})
+	require.Len(t, sink.notified, 1)
+
+	// A missing metric neither fires nor resolves
+	evaluator.Evaluate(ctx, map[string]interface{}{})
+	assert.Len(t, sink.notified, 1)
+
+	disabled, disabledSink, _ := newTestAlertEvaluator(&config.AlertingConfig{
+		Enabled:               false,
+		ResponseTimeThreshold: 1000,
+	})
+	disabled.Evaluate(ctx, map[string]interface{}{"http_response_time": int64(1500)})
+	assert.Empty(t, disabledSink.notified)
+}
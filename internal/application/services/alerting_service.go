@@ -3,16 +3,26 @@ package services
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"sort"
 	"sync"
 	"time"
 
 	"github.com/google/uuid"
 	"github.com/22smeargle/winkr-backend/internal/infrastructure/cache"
+	"github.com/22smeargle/winkr-backend/internal/infrastructure/external/webhook"
 	"github.com/22smeargle/winkr-backend/pkg/config"
 	"github.com/22smeargle/winkr-backend/pkg/logger"
 )
 
+var (
+	// ErrNotificationChannelNotFound is returned for an unknown notification channel
+	ErrNotificationChannelNotFound = errors.New("notification channel not found")
+	// ErrNotificationChannelDisabled is returned when notifying through a disabled channel
+	ErrNotificationChannelDisabled = errors.New("notification channel is disabled")
+)
+
 // AlertSeverity represents the severity of an alert
 type AlertSeverity string
 
@@ -87,9 +97,10 @@ type AlertRule struct {
 
 // NotificationChannel represents a notification channel
 type NotificationChannel struct {
+	ID       string                 `json:"id"`
 	Type     string                 `json:"type"`
 	Enabled  bool                   `json:"enabled"`
-	Config   map[string]interface{} `json:"config"`
+	Config   map[string]interface{} `json:"-"` // Holds webhook URLs and secrets
 }
 
 // AlertingService provides alerting functionality
@@ -100,6 +111,7 @@ type AlertingService struct {
 	alerts         map[string]*Alert
 	rules          map[string]*AlertRule
 	channels       map[string]*NotificationChannel
+	dispatcher     *webhook.Dispatcher
 	lastEvaluation  time.Time
 }
 
@@ -108,14 +120,32 @@ func NewAlertingService(
 	cfg *config.Config,
 	cacheService *cache.CacheService,
 ) *AlertingService {
-	return &AlertingService{
+	service := &AlertingService{
 		config:       cfg,
 		cacheService: cacheService,
 		alerts:      make(map[string]*Alert),
 		rules:       make(map[string]*AlertRule),
 		channels:    make(map[string]*NotificationChannel),
+		dispatcher: webhook.NewDispatcher(webhook.DispatchConfig{
+			Timeout:      cfg.Monitoring.Alerting.NotificationTimeout,
+			MaxRetries:   cfg.Monitoring.Alerting.NotificationRetries,
+			RetryBackoff: cfg.Monitoring.Alerting.NotificationRetryBackoff,
+		}),
 		lastEvaluation: time.Now(),
 	}
+
+	// Register the configured notification channels, identified by type and position
+	for i, channel := range cfg.Monitoring.Alerting.NotificationChannels {
+		id := fmt.Sprintf("%s-%d", channel.Type, i)
+		service.channels[id] = &NotificationChannel{
+			ID:      id,
+			Type:    channel.Type,
+			Enabled: channel.Enabled,
+			Config:  channel.Config,
+		}
+	}
+
+	return service
 }
 
 // AddAlert adds a new alert
@@ -409,36 +439,77 @@ func (a *AlertingService) EvaluateRules(ctx context.Context, metrics map[string]
 	return nil
 }
 
-// SendNotification sends a notification for an alert
+// SendNotification sends a notification for an alert to every enabled channel. Every channel is
+// tried even if one fails.
 func (a *AlertingService) SendNotification(ctx context.Context, alert *Alert) error {
 	// Get notification channels for this alert type
 	channels := a.getNotificationChannels(alert.Severity)
 	
+	var attempted, failed int
 	for _, channel := range channels {
 		if !channel.Enabled {
 			continue
 		}
 
-		// Send notification based on channel type
-		switch channel.Type {
-		case "email":
-			if err := a.sendEmailNotification(ctx, alert, channel.Config); err != nil {
-				logger.Error("Failed to send email notification", err)
-			}
-		case "slack":
-			if err := a.sendSlackNotification(ctx, alert, channel.Config); err != nil {
-				logger.Error("Failed to send Slack notification", err)
-			}
-		case "webhook":
-			if err := a.sendWebhookNotification(ctx, alert, channel.Config); err != nil {
-				logger.Error("Failed to send webhook notification", err)
-			}
+		attempted++
+		if err := a.dispatch(ctx, channel, alert); err != nil {
+			logger.Error("Failed to send alert notification", err, map[string]interface{}{
+				"alert_id": alert.ID,
+				"channel":  channel.ID,
+			})
+			failed++
 		}
 	}
 
+	if failed > 0 {
+		return fmt.Errorf("alert notification failed for %d of %d channels", failed, attempted)
+	}
 	return nil
 }
 
+// ListNotificationChannels returns the configured notification channels
+func (a *AlertingService) ListNotificationChannels() []*NotificationChannel {
+	channels := make([]*NotificationChannel, 0, len(a.channels))
+	for _, channel := range a.channels {
+		channels = append(channels, channel)
+	}
+	sort.Slice(channels, func(i, j int) bool {
+		return channels[i].ID < channels[j].ID
+	})
+	return channels
+}
+
+// SendTestNotification sends a test alert through one channel, so admins can check its
+// configuration. The delivery error is returned as is.
+func (a *AlertingService) SendTestNotification(ctx context.Context, channelID string, adminID string) error {
+	channel, exists := a.channels[channelID]
+	if !exists {
+		return ErrNotificationChannelNotFound
+	}
+	if !channel.Enabled {
+		return ErrNotificationChannelDisabled
+	}
+
+	alert := &Alert{
+		ID:          uuid.New().String(),
+		Name:        "test_notification",
+		Type:        AlertTypeExternal,
+		Severity:    AlertSeverityInfo,
+		Status:      AlertStatusActive,
+		Message:     fmt.Sprintf("Test notification for channel %s", channel.ID),
+		Description: "Sent from the admin API to check the channel configuration",
+		Annotations: map[string]string{"requested_by": adminID},
+		TriggeredAt: time.Now(),
+		Source:      "admin",
+	}
+
+	logger.Info("Sending test notification", map[string]interface{}{
+		"channel":  channel.ID,
+		"admin_id": adminID,
+	})
+	return a.dispatch(ctx, channel, alert)
+}
+
 // CleanupOldAlerts removes old alerts based on retention policy
 func (a *AlertingService) CleanupOldAlerts(ctx context.Context) error {
 	if !a.config.Monitoring.Alerting.Enabled {
@@ -483,6 +554,20 @@ func (a *AlertingService) storeAlertRule(ctx context.Context, rule *AlertRule) e
 	return a.cacheService.Set(ctx, key, rule, a.config.Monitoring.Storage.AlertRetention)
 }
 
+// dispatch delivers an alert through a channel of any type
+func (a *AlertingService) dispatch(ctx context.Context, channel *NotificationChannel, alert *Alert) error {
+	switch channel.Type {
+	case "email":
+		return a.sendEmailNotification(ctx, alert, channel.Config)
+	case "slack":
+		return a.sendSlackNotification(ctx, alert, channel.Config)
+	case "webhook":
+		return a.sendWebhookNotification(ctx, alert, channel.Config)
+	default:
+		return fmt.Errorf("unsupported notification channel type: %s", channel.Type)
+	}
+}
+
 func (a *AlertingService) getNotificationChannels(severity AlertSeverity) []*NotificationChannel {
 	var channels []*NotificationChannel
 	
@@ -574,26 +659,59 @@ func (a *AlertingService) sendEmailNotification(ctx context.Context, alert *Aler
 	// This is a placeholder - in production, you'd implement actual email sending
 	logger.Info("Sending email notification", map[string]interface{}{
 		"alert_id": alert.ID,
+		"status":   alert.Status,
 		"to":       config["to"],
 	})
 	return nil
 }
 
+// sendSlackNotification posts the alert to a Slack incoming webhook
 func (a *AlertingService) sendSlackNotification(ctx context.Context, alert *Alert, config map[string]interface{}) error {
-	// This is a placeholder - in production, you'd implement actual Slack integration
-	logger.Info("Sending Slack notification", map[string]interface{}{
-		"alert_id": alert.ID,
-		"webhook":  config["webhook"],
-	})
-	return nil
+	webhookURL, _ := config["webhook_url"].(string)
+	return a.dispatcher.Post(ctx, webhookURL, slackAlertMessage(alert), "")
 }
 
+// sendWebhookNotification posts the alert as JSON, signed if the channel has a secret
 func (a *AlertingService) sendWebhookNotification(ctx context.Context, alert *Alert, config map[string]interface{}) error {
-	// This is a placeholder - in production, you'd implement actual webhook sending
-	logger.Info("Sending webhook notification", map[string]interface{}{
-		"alert_id": alert.ID,
-		"url":      config["url"],
-	})
-	return nil
+	url, _ := config["url"].(string)
+	secret, _ := config["secret"].(string)
+
+	eventType := "alert.triggered"
+	if alert.Status == AlertStatusResolved {
+		eventType = "alert.resolved"
+	}
+	return a.dispatcher.Post(ctx, url, webhook.Event{
+		ID:        uuid.New().String(),
+		Type:      eventType,
+		CreatedAt: time.Now().UTC(),
+		Data:      alert,
+	}, secret)
+}
+
+// slackAlertMessage formats an alert as a Slack message, colored by severity
+func slackAlertMessage(alert *Alert) map[string]interface{} {
+	color := "#439FE0"
+	switch {
+	case alert.Status == AlertStatusResolved:
+		color = "good"
+	case alert.Severity == AlertSeverityCritical:
+		color = "danger"
+	case alert.Severity == AlertSeverityWarning:
+		color = "warning"
+	}
+
+	title := fmt.Sprintf("[%s] %s", alert.Severity, alert.Name)
+	if alert.Status == AlertStatusResolved {
+		title = fmt.Sprintf("[resolved] %s", alert.Name)
+	}
+
+	return map[string]interface{}{
+		"text": alert.Message,
+		"attachments": []map[string]interface{}{{
+			"color": color,
+			"title": title,
+			"text":  alert.Description,
+			"ts":    alert.TriggeredAt.Unix(),
+		}},
+	}
 }
-		
\ No newline at end of file
@@ -0,0 +1,116 @@
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/22smeargle/winkr-backend/internal/infrastructure/external/webhook"
+	"github.com/22smeargle/winkr-backend/pkg/config"
+)
+
+// newAlertReceiver records the JSON bodies and signatures it receives
+func newAlertReceiver(t *testing.T) (*httptest.Server, *[]map[string]interface{}, *[]string) {
+	var bodies []map[string]interface{}
+	var signatures []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var body map[string]interface{}
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+		bodies = append(bodies, body)
+		signatures = append(signatures, r.Header.Get(webhook.SignatureHeader))
+	}))
+	t.Cleanup(server.Close)
+	return server, &bodies, &signatures
+}
+
+func newTestAlertingService(channels ...config.NotificationChannel) *AlertingService {
+	return NewAlertingService(&config.Config{
+		Monitoring: config.MonitoringConfig{
+			Alerting: config.AlertingConfig{
+				Enabled:                  true,
+				NotificationChannels:     channels,
+				NotificationTimeout:      time.Second,
+				NotificationRetryBackoff: time.Millisecond,
+			},
+		},
+	}, nil)
+}
+
+func TestAlertingService_SendNotification(t *testing.T) {
+	slack, slackBodies, _ := newAlertReceiver(t)
+	receiver, webhookBodies, signatures := newAlertReceiver(t)
+	disabled, disabledBodies, _ := newAlertReceiver(t)
+
+	service := newTestAlertingService(
+		config.NotificationChannel{Type: "slack", Enabled: true, Config: map[string]interface{}{"webhook_url": slack.URL}},
+		config.NotificationChannel{Type: "webhook", Enabled: true, Config: map[string]interface{}{"url": receiver.URL, "secret": "s3cret"}},
+		config.NotificationChannel{Type: "webhook", Enabled: false, Config: map[string]interface{}{"url": disabled.URL}},
+	)
+
+	alert := &Alert{
+		ID:       "alert-1",
+		Name:     AlertRuleHighCPUUsage,
+		Severity: AlertSeverityCritical,
+		Status:   AlertStatusResolved,
+		Message:  "CPU usage is back to normal",
+	}
+	require.NoError(t, service.SendNotification(context.Background(), alert))
+
+	require.Len(t, *slackBodies, 1)
+	assert.Equal(t, "CPU usage is back to normal", (*slackBodies)[0]["text"])
+	attachment := (*slackBodies)[0]["attachments"].([]interface{})[0].(map[string]interface{})
+	assert.Equal(t, "good", attachment["color"])
+
+	require.Len(t, *webhookBodies, 1)
+	assert.Equal(t, "alert.resolved", (*webhookBodies)[0]["type"])
+	assert.Equal(t, "alert-1", (*webhookBodies)[0]["data"].(map[string]interface{})["id"])
+	assert.NotEmpty(t, (*signatures)[0])
+
+	assert.Empty(t, *disabledBodies, "disabled channels are skipped")
+}
+
+func TestAlertingService_SendNotificationReportsFailures(t *testing.T) {
+	failing := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusBadRequest)
+	}))
+	defer failing.Close()
+	slack, slackBodies, _ := newAlertReceiver(t)
+
+	service := newTestAlertingService(
+		config.NotificationChannel{Type: "webhook", Enabled: true, Config: map[string]interface{}{"url": failing.URL}},
+		config.NotificationChannel{Type: "slack", Enabled: true, Config: map[string]interface{}{"webhook_url": slack.URL}},
+	)
+
+	err := service.SendNotification(context.Background(), &Alert{ID: "alert-1", Status: AlertStatusActive})
+	assert.EqualError(t, err, "alert notification failed for 1 of 2 channels")
+	assert.Len(t, *slackBodies, 1, "a failing channel doesn't stop the others")
+}
+
+func TestAlertingService_SendTestNotification(t *testing.T) {
+	slack, slackBodies, _ := newAlertReceiver(t)
+
+	service := newTestAlertingService(
+		config.NotificationChannel{Type: "slack", Enabled: true, Config: map[string]interface{}{"webhook_url": slack.URL}},
+		config.NotificationChannel{Type: "webhook", Enabled: false, Config: map[string]interface{}{"url": slack.URL}},
+		config.NotificationChannel{Type: "webhook", Enabled: true, Config: map[string]interface{}{}},
+	)
+
+	channels := service.ListNotificationChannels()
+	require.Len(t, channels, 3)
+	assert.Equal(t, "slack-0", channels[0].ID)
+	assert.Equal(t, "webhook-1", channels[1].ID)
+
+	require.NoError(t, service.SendTestNotification(context.Background(), "slack-0", "admin-1"))
+	require.Len(t, *slackBodies, 1)
+	assert.Contains(t, (*slackBodies)[0]["text"], "slack-0")
+
+	assert.ErrorIs(t, service.SendTestNotification(context.Background(), "email-9", "admin-1"), ErrNotificationChannelNotFound)
+	assert.ErrorIs(t, service.SendTestNotification(context.Background(), "webhook-1", "admin-1"), ErrNotificationChannelDisabled)
+	assert.Error(t, service.SendTestNotification(context.Background(), "webhook-2", "admin-1"), "a channel without a URL fails")
+	assert.Len(t, *slackBodies, 1)
+}
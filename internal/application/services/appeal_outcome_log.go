@@ -0,0 +1,91 @@
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strconv"
+
+	goredis "github.com/go-redis/redis/v8"
+	"github.com/22smeargle/winkr-backend/internal/domain/entities"
+	"github.com/22smeargle/winkr-backend/internal/infrastructure/database/redis"
+	"github.com/22smeargle/winkr-backend/pkg/config"
+)
+
+// RedisAppealOutcomeLog keeps recently decided appeals in a Redis list and counts appeal outcomes
+// per day, for moderation analytics
+type RedisAppealOutcomeLog struct {
+	redisClient *redis.RedisClient
+	config      *config.ModerationAnalyticsConfig
+	prefix      string
+	maxOutcomes int64 // Recent outcomes kept
+}
+
+// NewRedisAppealOutcomeLog creates a new RedisAppealOutcomeLog
+func NewRedisAppealOutcomeLog(redisClient *redis.RedisClient, cfg *config.ModerationAnalyticsConfig) *RedisAppealOutcomeLog {
+	return &RedisAppealOutcomeLog{
+		redisClient: redisClient,
+		config:      cfg,
+		prefix:      "moderation:appeal_outcomes:",
+		maxOutcomes: 1000,
+	}
+}
+
+// RecordAppealOutcome adds the outcome to the recent outcomes and daily counts. Daily counts hold
+// approved, rejected, auto_reviewed and review_seconds, the total time appeals waited for a decision.
+// Nothing is recorded unless analytics tracks appeals.
+func (l *RedisAppealOutcomeLog) RecordAppealOutcome(ctx context.Context, outcome *entities.AppealOutcome) error {
+	if !l.config.Enabled || !l.config.TrackAppeals {
+		return nil
+	}
+
+	data, err := json.Marshal(outcome)
+	if err != nil {
+		return fmt.Errorf("failed to marshal appeal outcome: %w", err)
+	}
+
+	decision := "rejected"
+	if outcome.Approved {
+		decision = "approved"
+	}
+
+	recentKey := l.prefix + "recent"
+	countsKey := l.prefix + outcome.ReviewedAt.UTC().Format("2006-01-02")
+
+	_, err = l.redisClient.GetClient().TxPipelined(ctx, func(pipe goredis.Pipeliner) error {
+		pipe.LPush(ctx, recentKey, data)
+		pipe.LTrim(ctx, recentKey, 0, l.maxOutcomes-1)
+		pipe.HIncrBy(ctx, countsKey, decision, 1)
+		if outcome.AutoReviewed {
+			pipe.HIncrBy(ctx, countsKey, "auto_reviewed", 1)
+		}
+		pipe.HIncrBy(ctx, countsKey, "review_seconds", int64(outcome.ReviewDuration().Seconds()))
+		if l.config.RetentionPeriod > 0 {
+			pipe.Expire(ctx, countsKey, l.config.RetentionPeriod)
+		}
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("failed to record appeal outcome: %w", err)
+	}
+	return nil
+}
+
+// GetAppealOutcomeCounts returns the appeal outcome counts recorded on the day, formatted as
+// 2006-01-02
+func (l *RedisAppealOutcomeLog) GetAppealOutcomeCounts(ctx context.Context, day string) (map[string]int64, error) {
+	values, err := l.redisClient.GetClient().HGetAll(ctx, l.prefix+day).Result()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get appeal outcome counts: %w", err)
+	}
+
+	counts := make(map[string]int64, len(values))
+	for field, value := range values {
+		count, err := strconv.ParseInt(value, 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("invalid appeal outcome count %q: %w", field, err)
+		}
+		counts[field] = count
+	}
+	return counts, nil
+}
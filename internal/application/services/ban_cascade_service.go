@@ -0,0 +1,121 @@
+package services
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/22smeargle/winkr-backend/internal/domain/entities"
+	"github.com/22smeargle/winkr-backend/pkg/config"
+	"github.com/22smeargle/winkr-backend/pkg/logger"
+)
+
+// BanCascadeMatchStore defines the match operations needed to unmatch a banned user
+type BanCascadeMatchStore interface {
+	GetActiveMatches(ctx context.Context, userID uuid.UUID, limit, offset int) ([]*entities.Match, error)
+	UpdateMatch(ctx context.Context, match *entities.Match) error
+}
+
+// BanCascadeConversationStore defines the conversation operations needed to close a banned user's chats
+type BanCascadeConversationStore interface {
+	GetConversationByMatchID(ctx context.Context, matchID uuid.UUID) (*entities.Conversation, error)
+	UpdateConversation(ctx context.Context, conversation *entities.Conversation) error
+}
+
+// BanCascadeNotifier notifies users that a match has ended
+type BanCascadeNotifier interface {
+	SendNotification(ctx context.Context, userID uuid.UUID, notificationType string, data map[string]interface{}) error
+}
+
+// banCascadeBatchSize is the number of matches processed per query
+const banCascadeBatchSize = 100
+
+// BanCascadeService ends the matches and conversations of banned users
+// so the people they matched with are not left waiting on a banned account
+type BanCascadeService struct {
+	matchStore        BanCascadeMatchStore
+	conversationStore BanCascadeConversationStore
+	notifier          BanCascadeNotifier
+	config            *config.ModerationRulesConfig
+}
+
+// NewBanCascadeService creates a new BanCascadeService.
+// The notifier may be nil, in which case no notifications are sent.
+func NewBanCascadeService(
+	matchStore BanCascadeMatchStore,
+	conversationStore BanCascadeConversationStore,
+	notifier BanCascadeNotifier,
+	cfg *config.ModerationRulesConfig,
+) *BanCascadeService {
+	return &BanCascadeService{
+		matchStore:        matchStore,
+		conversationStore: conversationStore,
+		notifier:          notifier,
+		config:            cfg,
+	}
+}
+
+// OnUserBanned deactivates all active matches of the banned user, closes their conversations
+// and, when enabled, notifies the other participants. It is safe to call more than once.
+func (s *BanCascadeService) OnUserBanned(ctx context.Context, userID uuid.UUID) error {
+	if !s.config.UnmatchOnBan {
+		return nil
+	}
+
+	deactivated := 0
+	for {
+		// Deactivated matches drop out of the active set, so always read the first page
+		matches, err := s.matchStore.GetActiveMatches(ctx, userID, banCascadeBatchSize, 0)
+		if err != nil {
+			return fmt.Errorf("failed to get active matches: %w", err)
+		}
+
+		for _, match := range matches {
+			if err := s.endMatch(ctx, userID, match); err != nil {
+				return err
+			}
+			deactivated++
+		}
+
+		if len(matches) < banCascadeBatchSize {
+			break
+		}
+	}
+
+	logger.Info("Ended matches of banned user", "user_id", userID, "matches", deactivated)
+	return nil
+}
+
+// endMatch deactivates a single match, closes its conversation and notifies the other participant
+func (s *BanCascadeService) endMatch(ctx context.Context, bannedUserID uuid.UUID, match *entities.Match) error {
+	match.Deactivate()
+	if err := s.matchStore.UpdateMatch(ctx, match); err != nil {
+		return fmt.Errorf("failed to deactivate match %s: %w", match.ID, err)
+	}
+
+	var conversationID *uuid.UUID
+	conversation, err := s.conversationStore.GetConversationByMatchID(ctx, match.ID)
+	if err == nil && conversation != nil {
+		conversation.Close()
+		if err := s.conversationStore.UpdateConversation(ctx, conversation); err != nil {
+			return fmt.Errorf("failed to close conversation %s: %w", conversation.ID, err)
+		}
+		conversationID = &conversation.ID
+	}
+
+	otherUserID, ok := match.GetOtherUserID(bannedUserID)
+	if !ok || !s.config.NotifyMatchesOnBan || s.notifier == nil {
+		return nil
+	}
+
+	// The reason is deliberately left out; the other user only learns the match has ended
+	data := map[string]interface{}{
+		"match_id":        match.ID,
+		"conversation_id": conversationID,
+	}
+	if err := s.notifier.SendNotification(ctx, otherUserID, "match_ended", data); err != nil {
+		logger.Warn("Failed to notify user about ended match", err, "user_id", otherUserID, "match_id", match.ID)
+	}
+
+	return nil
+}
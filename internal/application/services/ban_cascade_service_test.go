@@ -0,0 +1,170 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/22smeargle/winkr-backend/internal/domain/entities"
+	"github.com/22smeargle/winkr-backend/pkg/config"
+)
+
+// inMemoryBanCascadeStore is an in-memory match and conversation store for tests
+type inMemoryBanCascadeStore struct {
+	matches       []*entities.Match
+	conversations map[uuid.UUID]*entities.Conversation
+	updateErr     error
+}
+
+func newInMemoryBanCascadeStore() *inMemoryBanCascadeStore {
+	return &inMemoryBanCascadeStore{conversations: make(map[uuid.UUID]*entities.Conversation)}
+}
+
+func (s *inMemoryBanCascadeStore) addMatch(user1ID, user2ID uuid.UUID, withConversation bool) *entities.Match {
+	match := &entities.Match{ID: uuid.New(), User1ID: user1ID, User2ID: user2ID, IsActive: true}
+	s.matches = append(s.matches, match)
+	if withConversation {
+		s.conversations[match.ID] = &entities.Conversation{ID: uuid.New(), MatchID: match.ID}
+	}
+	return match
+}
+
+func (s *inMemoryBanCascadeStore) GetActiveMatches(ctx context.Context, userID uuid.UUID, limit, offset int) ([]*entities.Match, error) {
+	var result []*entities.Match
+	for _, match := range s.matches {
+		if match.IsActive && match.IsUserInMatch(userID) {
+			result = append(result, match)
+		}
+	}
+	if offset >= len(result) {
+		return nil, nil
+	}
+	result = result[offset:]
+	if len(result) > limit {
+		result = result[:limit]
+	}
+	return result, nil
+}
+
+func (s *inMemoryBanCascadeStore) UpdateMatch(ctx context.Context, match *entities.Match) error {
+	return s.updateErr
+}
+
+func (s *inMemoryBanCascadeStore) GetConversationByMatchID(ctx context.Context, matchID uuid.UUID) (*entities.Conversation, error) {
+	conversation, ok := s.conversations[matchID]
+	if !ok {
+		return nil, errors.New("conversation not found")
+	}
+	return conversation, nil
+}
+
+func (s *inMemoryBanCascadeStore) UpdateConversation(ctx context.Context, conversation *entities.Conversation) error {
+	return nil
+}
+
+// recordingNotifier records the notifications it is asked to send
+type recordingNotifier struct {
+	notified []uuid.UUID
+	types    []string
+}
+
+func (n *recordingNotifier) SendNotification(ctx context.Context, userID uuid.UUID, notificationType string, data map[string]interface{}) error {
+	n.notified = append(n.notified, userID)
+	n.types = append(n.types, notificationType)
+	return nil
+}
+
+func testBanCascadeConfig() *config.ModerationRulesConfig {
+	return &config.ModerationRulesConfig{
+		UnmatchOnBan:       true,
+		NotifyMatchesOnBan: true,
+	}
+}
+
+func TestBanCascadeService_EndsMatchesAndClosesConversations(t *testing.T) {
+	store := newInMemoryBanCascadeStore()
+	notifier := &recordingNotifier{}
+	service := NewBanCascadeService(store, store, notifier, testBanCascadeConfig())
+
+	bannedID := uuid.New()
+	victimA, victimB := uuid.New(), uuid.New()
+	withChat := store.addMatch(bannedID, victimA, true)
+	withoutChat := store.addMatch(victimB, bannedID, false)
+	unrelated := store.addMatch(victimA, victimB, true)
+
+	require.NoError(t, service.OnUserBanned(context.Background(), bannedID))
+
+	assert.False(t, withChat.IsActive)
+	assert.False(t, withoutChat.IsActive)
+	assert.True(t, store.conversations[withChat.ID].IsClosed())
+
+	assert.True(t, unrelated.IsActive)
+	assert.False(t, store.conversations[unrelated.ID].IsClosed())
+
+	assert.ElementsMatch(t, []uuid.UUID{victimA, victimB}, notifier.notified)
+	assert.Equal(t, []string{"match_ended", "match_ended"}, notifier.types)
+}
+
+func TestBanCascadeService_ProcessesAllBatches(t *testing.T) {
+	store := newInMemoryBanCascadeStore()
+	service := NewBanCascadeService(store, store, nil, testBanCascadeConfig())
+
+	bannedID := uuid.New()
+	for i := 0; i < banCascadeBatchSize*2+5; i++ {
+		store.addMatch(bannedID, uuid.New(), false)
+	}
+
+	require.NoError(t, service.OnUserBanned(context.Background(), bannedID))
+
+	for _, match := range store.matches {
+		assert.False(t, match.IsActive)
+	}
+}
+
+func TestBanCascadeService_NotificationsDisabled(t *testing.T) {
+	cfg := testBanCascadeConfig()
+	cfg.NotifyMatchesOnBan = false
+	store := newInMemoryBanCascadeStore()
+	notifier := &recordingNotifier{}
+	service := NewBanCascadeService(store, store, notifier, cfg)
+
+	bannedID := uuid.New()
+	match := store.addMatch(bannedID, uuid.New(), true)
+
+	require.NoError(t, service.OnUserBanned(context.Background(), bannedID))
+
+	assert.False(t, match.IsActive)
+	assert.True(t, store.conversations[match.ID].IsClosed())
+	assert.Empty(t, notifier.notified)
+}
+
+func TestBanCascadeService_UnmatchDisabled(t *testing.T) {
+	cfg := testBanCascadeConfig()
+	cfg.UnmatchOnBan = false
+	store := newInMemoryBanCascadeStore()
+	notifier := &recordingNotifier{}
+	service := NewBanCascadeService(store, store, notifier, cfg)
+
+	bannedID := uuid.New()
+	match := store.addMatch(bannedID, uuid.New(), true)
+
+	require.NoError(t, service.OnUserBanned(context.Background(), bannedID))
+
+	assert.True(t, match.IsActive)
+	assert.False(t, store.conversations[match.ID].IsClosed())
+	assert.Empty(t, notifier.notified)
+}
+
+func TestBanCascadeService_MatchUpdateFailure(t *testing.T) {
+	store := newInMemoryBanCascadeStore()
+	store.updateErr = errors.New("db down")
+	service := NewBanCascadeService(store, store, nil, testBanCascadeConfig())
+
+	bannedID := uuid.New()
+	store.addMatch(bannedID, uuid.New(), true)
+
+	assert.Error(t, service.OnUserBanned(context.Background(), bannedID))
+}
@@ -0,0 +1,126 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/22smeargle/winkr-backend/internal/application/usecases/profile"
+	"github.com/22smeargle/winkr-backend/internal/infrastructure/external"
+	"github.com/22smeargle/winkr-backend/pkg/config"
+	"github.com/22smeargle/winkr-backend/pkg/logger"
+)
+
+// Actions taken on a bio that clearly violates the content rules
+const (
+	BioModerationActionReject = "reject"
+	BioModerationActionFlag   = "flag"
+)
+
+// TextContentAnalyzer runs the content filter, PII detection and link analysis on text
+type TextContentAnalyzer interface {
+	AnalyzeContent(ctx context.Context, req ContentRequest) (*ContentAnalysisResponse, error)
+}
+
+// AITextModerator runs text through AI moderation
+type AITextModerator interface {
+	AnalyzeContent(ctx context.Context, req external.ContentAnalysisRequest) (*external.ContentAnalysisResult, error)
+}
+
+// BioModerationService checks profile bios with the same filters as messages before they go live.
+// Clear violations get the configured action, borderline content is flagged for review.
+type BioModerationService struct {
+	analyzer    TextContentAnalyzer
+	aiModerator AITextModerator
+	config      *config.ProfileModerationConfig
+}
+
+// NewBioModerationService creates a new BioModerationService
+func NewBioModerationService(
+	analyzer TextContentAnalyzer,
+	aiModerator AITextModerator,
+	cfg *config.ProfileModerationConfig,
+) *BioModerationService {
+	return &BioModerationService{
+		analyzer:    analyzer,
+		aiModerator: aiModerator,
+		config:      cfg,
+	}
+}
+
+// ModerateBio decides if a bio may be saved as is, must be reviewed first or is rejected
+func (s *BioModerationService) ModerateBio(ctx context.Context, userID uuid.UUID, bio string) (*profile.BioModerationResult, error) {
+	result := &profile.BioModerationResult{
+		Decision: profile.BioApproved,
+		Reasons:  []string{},
+	}
+	if !s.config.Enabled {
+		return result, nil
+	}
+
+	analysis, err := s.analyzer.AnalyzeContent(ctx, ContentRequest{
+		ID:        "bio_" + userID.String(),
+		Type:      "text",
+		Content:   bio,
+		UserID:    userID.String(),
+		Timestamp: time.Now(),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to analyze bio: %w", err)
+	}
+
+	severe, borderline := false, false
+	for _, violation := range analysis.Violations {
+		// The type only, descriptions can repeat the offending text
+		result.Reasons = append(result.Reasons, violation.Type)
+		if isSevereBioViolation(violation) {
+			severe = true
+		} else {
+			borderline = true
+		}
+	}
+
+	if s.config.AIModeration && s.aiModerator != nil {
+		aiResult, err := s.aiModerator.AnalyzeContent(ctx, external.ContentAnalysisRequest{
+			ContentID:   "bio_" + userID.String(),
+			ContentType: "text",
+			ContentURL:  bio, // Text moderation reads the text from this field
+			UserID:      userID.String(),
+		})
+		if err != nil {
+			return nil, fmt.Errorf("failed to moderate bio: %w", err)
+		}
+
+		if !aiResult.IsApproved || aiResult.RequiresReview {
+			for _, label := range aiResult.Labels {
+				result.Reasons = append(result.Reasons, label.Name)
+			}
+			if aiResult.RiskLevel == "high" || aiResult.RiskLevel == "critical" {
+				severe = true
+			} else {
+				borderline = true
+			}
+		}
+	}
+
+	switch {
+	case severe && s.config.Action == BioModerationActionFlag:
+		result.Decision = profile.BioFlagged
+	case severe:
+		result.Decision = profile.BioRejected
+	case borderline:
+		result.Decision = profile.BioFlagged
+	}
+
+	if result.Decision != profile.BioApproved {
+		logger.Info("Bio failed moderation", "user_id", userID, "decision", result.Decision, "reasons", result.Reasons)
+	}
+
+	return result, nil
+}
+
+// isSevereBioViolation reports if a violation clearly breaks the rules rather than needing a closer look
+func isSevereBioViolation(violation ContentViolation) bool {
+	return violation.Type == "profanity" || violation.Severity == "high" || violation.Severity == "critical"
+}
@@ -0,0 +1,118 @@
+package services
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/22smeargle/winkr-backend/internal/application/usecases/profile"
+	"github.com/22smeargle/winkr-backend/internal/domain/entities"
+	"github.com/22smeargle/winkr-backend/internal/infrastructure/external"
+	"github.com/22smeargle/winkr-backend/pkg/config"
+)
+
+// fakeTextAnalyzer returns the configured violations for a text
+type fakeTextAnalyzer struct {
+	violations map[string][]ContentViolation
+}
+
+func (a *fakeTextAnalyzer) AnalyzeContent(ctx context.Context, req ContentRequest) (*ContentAnalysisResponse, error) {
+	violations := a.violations[req.Content]
+	return &ContentAnalysisResponse{
+		RequestID:  req.ID,
+		IsApproved: len(violations) == 0,
+		Violations: violations,
+	}, nil
+}
+
+// fakeAITextModerator returns the configured AI result for a text and approves everything else
+type fakeAITextModerator struct {
+	results map[string]*external.ContentAnalysisResult
+}
+
+func (m *fakeAITextModerator) AnalyzeContent(ctx context.Context, req external.ContentAnalysisRequest) (*external.ContentAnalysisResult, error) {
+	if result, ok := m.results[req.ContentURL]; ok {
+		return result, nil
+	}
+	return &external.ContentAnalysisResult{ContentID: req.ContentID, IsApproved: true, RiskLevel: "low"}, nil
+}
+
+const (
+	bannedBio     = "call me at 555 0100, no profanity1 allowed"
+	borderlineBio = "not looking for anything serious, hate long chats"
+)
+
+func newTestBioModerationService(action string) *BioModerationService {
+	analyzer := &fakeTextAnalyzer{violations: map[string][]ContentViolation{
+		bannedBio: {
+			{Type: "profanity", Severity: "medium", Description: "profanity1"},
+			{Type: "pii", Severity: "high", Description: "PII detected: phone"},
+		},
+	}}
+	aiModerator := &fakeAITextModerator{results: map[string]*external.ContentAnalysisResult{
+		borderlineBio: {
+			IsApproved:     false,
+			RiskLevel:      "medium",
+			RequiresReview: true,
+			Labels:         []external.ModerationLabel{{Name: "Inappropriate Text", Confidence: 80}},
+		},
+	}}
+
+	return NewBioModerationService(analyzer, aiModerator, &config.ProfileModerationConfig{
+		Enabled:      true,
+		Action:       action,
+		AIModeration: true,
+	})
+}
+
+func TestBioModerationService_RejectsBannedContent(t *testing.T) {
+	service := newTestBioModerationService(BioModerationActionReject)
+
+	result, err := service.ModerateBio(context.Background(), uuid.New(), bannedBio)
+	require.NoError(t, err)
+
+	assert.Equal(t, profile.BioRejected, result.Decision)
+	assert.Equal(t, []string{"profanity", "pii"}, result.Reasons)
+
+	clean, err := service.ModerateBio(context.Background(), uuid.New(), "Coffee, climbing and long walks")
+	require.NoError(t, err)
+	assert.Equal(t, profile.BioApproved, clean.Decision)
+	assert.Empty(t, clean.Reasons)
+}
+
+func TestBioModerationService_FlagsBorderlineContent(t *testing.T) {
+	service := newTestBioModerationService(BioModerationActionReject)
+
+	result, err := service.ModerateBio(context.Background(), uuid.New(), borderlineBio)
+	require.NoError(t, err)
+
+	assert.Equal(t, profile.BioFlagged, result.Decision)
+	assert.Equal(t, []string{"Inappropriate Text"}, result.Reasons)
+
+	// Flagged profiles are left out of discovery until reviewed
+	viewer := &entities.User{ID: uuid.New(), InterestedIn: []string{"female"}}
+	candidate := &entities.User{
+		ID:          uuid.New(),
+		Gender:      "female",
+		DateOfBirth: time.Now().AddDate(-30, 0, 0),
+		IsActive:    true,
+	}
+	matching := &MatchingAlgorithmService{}
+	assert.True(t, matching.meetsBasicCriteria(viewer, candidate))
+
+	candidate.ProfileUnderReview = true
+	assert.False(t, matching.meetsBasicCriteria(viewer, candidate))
+}
+
+func TestBioModerationService_FlagAction(t *testing.T) {
+	service := newTestBioModerationService(BioModerationActionFlag)
+
+	result, err := service.ModerateBio(context.Background(), uuid.New(), bannedBio)
+	require.NoError(t, err)
+
+	// Clear violations are held for review instead of being refused
+	assert.Equal(t, profile.BioFlagged, result.Decision)
+}
@@ -3,6 +3,7 @@ package services
 import (
 	"context"
 	"fmt"
+	"strconv"
 	"time"
 
 	"github.com/google/uuid"
@@ -35,10 +36,37 @@ type CacheService interface {
 	GetSwipeStats(ctx context.Context, key string) (*SwipeStatsCache, error)
 	SetSwipeStats(ctx context.Context, key string, stats *SwipeStatsCache, ttl time.Duration) error
 
+	// Swipe preference vectors
+	GetPreferenceVector(ctx context.Context, key string) (*PreferenceVector, error)
+	SetPreferenceVector(ctx context.Context, key string, vector *PreferenceVector, ttl time.Duration) error
+
+	// Discovery exposure operations
+	IncrementExposure(ctx context.Context, key string, ttl time.Duration) (int64, error)
+	MarkOverExposed(ctx context.Context, key string, userID uuid.UUID, ttl time.Duration) error
+	GetOverExposed(ctx context.Context, key string) ([]uuid.UUID, error)
+
+	// Discovery throttle operations
+	IncrementThrottleCounter(ctx context.Context, key string, ttl time.Duration) (int64, error)
+	SetThrottleBlock(ctx context.Context, key string, until time.Time, ttl time.Duration) error
+	GetThrottleBlock(ctx context.Context, key string) (time.Time, error)
+
+	// Discovery boost operations
+	SetBoost(ctx context.Context, key string, boost *DiscoveryBoost, ttl time.Duration) error
+	GetBoost(ctx context.Context, key string) (*DiscoveryBoost, error)
+	IncrementBoostCount(ctx context.Context, key string, ttl time.Duration) (int64, error)
+
+	// Super like quota operations
+	IncrementSuperLikeCount(ctx context.Context, key string, ttl time.Duration) (int64, error)
+	DecrementSuperLikeCount(ctx context.Context, key string) error
+
 	// Discovery stats caching
 	GetDiscoveryStats(ctx context.Context, key string) (*dto.GetDiscoveryStatsResponse, error)
 	SetDiscoveryStats(ctx context.Context, key string, response *dto.GetDiscoveryStatsResponse, ttl time.Duration) error
 
+	// Match compatibility caching
+	GetMatchCompatibility(ctx context.Context, key string) (*dto.MatchCompatibility, error)
+	SetMatchCompatibility(ctx context.Context, key string, compatibility *dto.MatchCompatibility, ttl time.Duration) error
+
 	// Generic cache operations
 	Get(ctx context.Context, key string) (interface{}, error)
 	Set(ctx context.Context, key string, value interface{}, ttl time.Duration) error
@@ -169,6 +197,113 @@ func (r *RedisCacheService) SetSwipeStats(ctx context.Context, key string, stats
 	return r.client.SetJSON(ctx, key, stats, ttl)
 }
 
+// GetPreferenceVector gets a swipe preference vector from cache
+func (r *RedisCacheService) GetPreferenceVector(ctx context.Context, key string) (*PreferenceVector, error) {
+	var vector PreferenceVector
+	err := r.client.GetJSON(ctx, key, &vector)
+	if err != nil {
+		return nil, err
+	}
+	return &vector, nil
+}
+
+// SetPreferenceVector sets a swipe preference vector in cache
+func (r *RedisCacheService) SetPreferenceVector(ctx context.Context, key string, vector *PreferenceVector, ttl time.Duration) error {
+	return r.client.SetJSON(ctx, key, vector, ttl)
+}
+
+// IncrementExposure increments a discovery exposure counter, starting its TTL on the first increment
+func (r *RedisCacheService) IncrementExposure(ctx context.Context, key string, ttl time.Duration) (int64, error) {
+	count, err := r.client.Incr(ctx, key)
+	if err != nil {
+		return 0, err
+	}
+	if count == 1 {
+		if err := r.client.Expire(ctx, key, ttl); err != nil {
+			return count, err
+		}
+	}
+	return count, nil
+}
+
+// MarkOverExposed adds a user to a set of over-exposed profiles
+func (r *RedisCacheService) MarkOverExposed(ctx context.Context, key string, userID uuid.UUID, ttl time.Duration) error {
+	if err := r.client.SAdd(ctx, key, userID.String()); err != nil {
+		return err
+	}
+	return r.client.Expire(ctx, key, ttl)
+}
+
+// GetOverExposed gets a set of over-exposed profiles
+func (r *RedisCacheService) GetOverExposed(ctx context.Context, key string) ([]uuid.UUID, error) {
+	members, err := r.client.SMembers(ctx, key)
+	if err != nil {
+		return nil, err
+	}
+
+	userIDs := make([]uuid.UUID, 0, len(members))
+	for _, member := range members {
+		if userID, err := uuid.Parse(member); err == nil {
+			userIDs = append(userIDs, userID)
+		}
+	}
+	return userIDs, nil
+}
+
+// IncrementThrottleCounter increments a discovery throttle counter, starting its TTL on the first increment
+func (r *RedisCacheService) IncrementThrottleCounter(ctx context.Context, key string, ttl time.Duration) (int64, error) {
+	return r.IncrementExposure(ctx, key, ttl)
+}
+
+// IncrementSuperLikeCount increments a daily super like counter, starting its TTL on the first increment
+func (r *RedisCacheService) IncrementSuperLikeCount(ctx context.Context, key string, ttl time.Duration) (int64, error) {
+	return r.IncrementExposure(ctx, key, ttl)
+}
+
+// DecrementSuperLikeCount gives back a super like counted against the daily limit
+func (r *RedisCacheService) DecrementSuperLikeCount(ctx context.Context, key string) error {
+	_, err := r.client.Decr(ctx, key)
+	return err
+}
+
+// SetThrottleBlock stores when a discovery throttle block ends
+func (r *RedisCacheService) SetThrottleBlock(ctx context.Context, key string, until time.Time, ttl time.Duration) error {
+	return r.client.Set(ctx, key, until.Unix(), ttl)
+}
+
+// GetThrottleBlock gets when a discovery throttle block ends. A missing block is returned as the zero time.
+func (r *RedisCacheService) GetThrottleBlock(ctx context.Context, key string) (time.Time, error) {
+	value, err := r.client.Get(ctx, key)
+	if err != nil || value == nil {
+		return time.Time{}, nil
+	}
+
+	until, err := strconv.ParseInt(fmt.Sprint(value), 10, 64)
+	if err != nil {
+		return time.Time{}, err
+	}
+	return time.Unix(until, 0), nil
+}
+
+// SetBoost stores a discovery boost until its TTL ends
+func (r *RedisCacheService) SetBoost(ctx context.Context, key string, boost *DiscoveryBoost, ttl time.Duration) error {
+	return r.client.SetJSON(ctx, key, boost, ttl)
+}
+
+// GetBoost gets a discovery boost. A missing or expired boost is returned as nil.
+func (r *RedisCacheService) GetBoost(ctx context.Context, key string) (*DiscoveryBoost, error) {
+	var boost DiscoveryBoost
+	if err := r.client.GetJSON(ctx, key, &boost); err != nil {
+		return nil, nil
+	}
+	return &boost, nil
+}
+
+// IncrementBoostCount increments a daily boost counter, starting its TTL on the first increment
+func (r *RedisCacheService) IncrementBoostCount(ctx context.Context, key string, ttl time.Duration) (int64, error) {
+	return r.IncrementExposure(ctx, key, ttl)
+}
+
 // GetDiscoveryStats gets discovery stats from cache
 func (r *RedisCacheService) GetDiscoveryStats(ctx context.Context, key string) (*dto.GetDiscoveryStatsResponse, error) {
 	var response dto.GetDiscoveryStatsResponse
@@ -184,6 +319,21 @@ func (r *RedisCacheService) SetDiscoveryStats(ctx context.Context, key string, r
 	return r.client.SetJSON(ctx, key, response, ttl)
 }
 
+// GetMatchCompatibility gets a match compatibility breakdown from cache
+func (r *RedisCacheService) GetMatchCompatibility(ctx context.Context, key string) (*dto.MatchCompatibility, error) {
+	var compatibility dto.MatchCompatibility
+	err := r.client.GetJSON(ctx, key, &compatibility)
+	if err != nil {
+		return nil, err
+	}
+	return &compatibility, nil
+}
+
+// SetMatchCompatibility sets a match compatibility breakdown in cache
+func (r *RedisCacheService) SetMatchCompatibility(ctx context.Context, key string, compatibility *dto.MatchCompatibility, ttl time.Duration) error {
+	return r.client.SetJSON(ctx, key, compatibility, ttl)
+}
+
 // Get gets generic value from cache
 func (r *RedisCacheService) Get(ctx context.Context, key string) (interface{}, error) {
 	return r.client.Get(ctx, key)
@@ -212,6 +362,11 @@ type RedisClient interface {
 	SetJSON(ctx context.Context, key string, value interface{}, ttl time.Duration) error
 	Delete(ctx context.Context, key string) error
 	DeletePattern(ctx context.Context, pattern string) error
+	Incr(ctx context.Context, key string) (int64, error)
+	Decr(ctx context.Context, key string) (int64, error)
+	Expire(ctx context.Context, key string, ttl time.Duration) error
+	SAdd(ctx context.Context, key string, members ...interface{}) error
+	SMembers(ctx context.Context, key string) ([]string, error)
 }
 
 // SwipeStatsCache represents cached swipe statistics
@@ -2,6 +2,8 @@ package services
 
 import (
 	"context"
+	"crypto/sha256"
+	"encoding/hex"
 	"encoding/json"
 	"fmt"
 	"time"
@@ -45,6 +47,8 @@ const (
 	MessageKey             CacheKey = "message:%s"
 	MessageAnalyticsKey    CacheKey = "message:analytics:%s"
 	MessageSecurityKey    CacheKey = "message:security:%s"
+	MessageTranslationKey CacheKey = "message:translation:%s:%s"
+	LinkPreviewKey        CacheKey = "link_preview:%s"
 	
 	// System keys
 	OnlineUsersKey         CacheKey = "system:online_users"
@@ -257,6 +261,50 @@ func (s *ChatCacheService) GetMessage(ctx context.Context, messageID uuid.UUID)
 	return message, nil
 }
 
+// CacheMessageTranslation caches a message's translation into a language
+func (s *ChatCacheService) CacheMessageTranslation(ctx context.Context, messageID uuid.UUID, language string, translation *entities.MessageTranslation, ttl time.Duration) error {
+	key := fmt.Sprintf(string(MessageTranslationKey), messageID.String(), language)
+	return s.cache.Set(ctx, key, translation, ttl)
+}
+
+// GetMessageTranslation retrieves a message's cached translation into a language
+func (s *ChatCacheService) GetMessageTranslation(ctx context.Context, messageID uuid.UUID, language string) (*entities.MessageTranslation, error) {
+	key := fmt.Sprintf(string(MessageTranslationKey), messageID.String(), language)
+	
+	var translation *entities.MessageTranslation
+	err := s.cache.Get(ctx, key, &translation)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get message translation: %w", err)
+	}
+	
+	return translation, nil
+}
+
+// CacheLinkPreview caches the preview of a link, keyed by a hash of the link
+func (s *ChatCacheService) CacheLinkPreview(ctx context.Context, link string, preview *LinkPreview, ttl time.Duration) error {
+	key := fmt.Sprintf(string(LinkPreviewKey), linkHash(link))
+	return s.cache.Set(ctx, key, preview, ttl)
+}
+
+// GetLinkPreview retrieves the cached preview of a link
+func (s *ChatCacheService) GetLinkPreview(ctx context.Context, link string) (*LinkPreview, error) {
+	key := fmt.Sprintf(string(LinkPreviewKey), linkHash(link))
+	
+	var preview *LinkPreview
+	err := s.cache.Get(ctx, key, &preview)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get link preview: %w", err)
+	}
+	
+	return preview, nil
+}
+
+// linkHash keeps cache keys short however long the link is
+func linkHash(link string) string {
+	sum := sha256.Sum256([]byte(link))
+	return hex.EncodeToString(sum[:])
+}
+
 // DeleteMessage removes a message from cache
 func (s *ChatCacheService) DeleteMessage(ctx context.Context, messageID uuid.UUID) error {
 	key := fmt.Sprintf(string(MessageKey), messageID.String())
@@ -23,7 +23,7 @@ type ChatSecurityService struct {
 	messageRepo   repositories.MessageRepository
 	cache         *cache.CacheService
 	rateLimiter   *cache.RateLimiter
-	blockedUsers  map[string]bool // User ID -> IsBlocked
+	blockedUsers  map[string]bool // "blockerID:blockedID" -> IsBlocked
 	spamKeywords  []string
 }
 
@@ -218,7 +218,7 @@ func (s *ChatSecurityService) DecryptMessage(encryptedContent, keyData string) (
 // BlockUser blocks a user from sending messages
 func (s *ChatSecurityService) BlockUser(ctx context.Context, blockerID, blockedID string) error {
 	// Add to blocked users map
-	s.blockedUsers[blockedID] = true
+	s.blockedUsers[blockerID+":"+blockedID] = true
 
 	// Cache block relationship
 	blockKey := fmt.Sprintf("blocked:%s:%s", blockerID, blockedID)
@@ -238,7 +238,7 @@ func (s *ChatSecurityService) BlockUser(ctx context.Context, blockerID, blockedI
 // UnblockUser unblocks a user
 func (s *ChatSecurityService) UnblockUser(ctx context.Context, blockerID, blockedID string) error {
 	// Remove from blocked users map
-	delete(s.blockedUsers, blockedID)
+	delete(s.blockedUsers, blockerID+":"+blockedID)
 
 	// Remove from cache
 	blockKey := fmt.Sprintf("blocked:%s:%s", blockerID, blockedID)
@@ -257,8 +257,8 @@ func (s *ChatSecurityService) UnblockUser(ctx context.Context, blockerID, blocke
 
 // IsUserBlocked checks if a user is blocked
 func (s *ChatSecurityService) IsUserBlocked(ctx context.Context, blockerID, blockedID string) (bool, error) {
-	// Check in-memory map
-	if s.blockedUsers[blockedID] {
+	// Check in-memory map, which only holds blocks made by this instance
+	if s.blockedUsers[blockerID+":"+blockedID] {
 		return true, nil
 	}
 
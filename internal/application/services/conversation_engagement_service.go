@@ -0,0 +1,117 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/22smeargle/winkr-backend/internal/domain/entities"
+	"github.com/22smeargle/winkr-backend/internal/domain/repositories"
+	"github.com/22smeargle/winkr-backend/pkg/config"
+	"github.com/22smeargle/winkr-backend/pkg/logger"
+)
+
+// ConversationMilestoneNotifier tells both participants of a match about a milestone
+type ConversationMilestoneNotifier interface {
+	NotifyMilestone(ctx context.Context, match *entities.Match, milestone *entities.ConversationMilestone) error
+}
+
+// ConversationEngagementService tracks conversation streaks and match anniversaries as messages are
+// sent and celebrates the configured milestones. Anniversaries are celebrated with the first message
+// sent on or after the anniversary, within the month that follows it.
+type ConversationEngagementService struct {
+	messageRepo repositories.MessageRepository
+	matchRepo   repositories.MatchRepository
+	notifier    ConversationMilestoneNotifier
+	config      *config.ConversationEngagementConfig
+	now         func() time.Time
+}
+
+// NewConversationEngagementService creates a new ConversationEngagementService
+func NewConversationEngagementService(
+	messageRepo repositories.MessageRepository,
+	matchRepo repositories.MatchRepository,
+	notifier ConversationMilestoneNotifier,
+	cfg *config.ConversationEngagementConfig,
+) *ConversationEngagementService {
+	return &ConversationEngagementService{
+		messageRepo: messageRepo,
+		matchRepo:   matchRepo,
+		notifier:    notifier,
+		config:      cfg,
+		now:         time.Now,
+	}
+}
+
+// Enabled returns true if streaks and anniversaries are tracked
+func (s *ConversationEngagementService) Enabled() bool {
+	return s != nil && s.config != nil && s.config.Enabled
+}
+
+// RecordMessage updates the streak and anniversary of the message's conversation and notifies the
+// participants of the milestones it reached, which are returned
+func (s *ConversationEngagementService) RecordMessage(ctx context.Context, message *entities.Message) ([]*entities.ConversationMilestone, error) {
+	if !s.Enabled() {
+		return nil, nil
+	}
+
+	conversation, err := s.messageRepo.GetConversation(ctx, message.ConversationID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get conversation: %w", err)
+	}
+
+	match, err := s.matchRepo.GetByID(ctx, conversation.MatchID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get match: %w", err)
+	}
+
+	now := s.now()
+	var milestones []*entities.ConversationMilestone
+
+	if conversation.RecordStreakMessage(message.SenderID, now) && isMilestone(s.config.StreakMilestones, conversation.StreakCount) {
+		milestones = append(milestones, &entities.ConversationMilestone{
+			ConversationID: conversation.ID,
+			MatchID:        match.ID,
+			Type:           entities.MilestoneTypeStreak,
+			Value:          conversation.StreakCount,
+		})
+	}
+
+	if months := match.MonthsSinceMatch(now); months > conversation.AnniversaryMonths {
+		if isMilestone(s.config.AnniversaryMonths, months) {
+			milestones = append(milestones, &entities.ConversationMilestone{
+				ConversationID: conversation.ID,
+				MatchID:        match.ID,
+				Type:           entities.MilestoneTypeAnniversary,
+				Value:          months,
+			})
+		}
+		conversation.AnniversaryMonths = months
+	}
+
+	if err := s.messageRepo.UpdateConversation(ctx, conversation); err != nil {
+		return nil, fmt.Errorf("failed to update conversation streak: %w", err)
+	}
+
+	for _, milestone := range milestones {
+		if err := s.notifier.NotifyMilestone(ctx, match, milestone); err != nil {
+			logger.Error("Failed to notify conversation milestone", err,
+				"conversation_id", conversation.ID,
+				"milestone_type", milestone.Type,
+				"milestone_value", milestone.Value,
+			)
+		}
+	}
+
+	return milestones, nil
+}
+
+// isMilestone returns true if the value is one of the configured milestones
+func isMilestone(milestones []int, value int) bool {
+	for _, milestone := range milestones {
+		if milestone == value {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,181 @@
+package services
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/22smeargle/winkr-backend/internal/domain/entities"
+	"github.com/22smeargle/winkr-backend/internal/domain/repositories"
+	"github.com/22smeargle/winkr-backend/pkg/config"
+)
+
+// inMemoryConversationRepository stores a single conversation in memory
+type inMemoryConversationRepository struct {
+	repositories.MessageRepository
+	conversation *entities.Conversation
+	updates      int
+}
+
+func (r *inMemoryConversationRepository) GetConversation(ctx context.Context, conversationID uuid.UUID) (*entities.Conversation, error) {
+	copied := *r.conversation
+	return &copied, nil
+}
+
+func (r *inMemoryConversationRepository) UpdateConversation(ctx context.Context, conversation *entities.Conversation) error {
+	copied := *conversation
+	r.conversation = &copied
+	r.updates++
+	return nil
+}
+
+// singleMatchRepository returns the same match for any ID
+type singleMatchRepository struct {
+	repositories.MatchRepository
+	match *entities.Match
+}
+
+func (r *singleMatchRepository) GetByID(ctx context.Context, id uuid.UUID) (*entities.Match, error) {
+	return r.match, nil
+}
+
+// recordingMilestoneNotifier records the milestones it is asked to deliver
+type recordingMilestoneNotifier struct {
+	milestones []*entities.ConversationMilestone
+}
+
+func (n *recordingMilestoneNotifier) NotifyMilestone(ctx context.Context, match *entities.Match, milestone *entities.ConversationMilestone) error {
+	n.milestones = append(n.milestones, milestone)
+	return nil
+}
+
+type engagementFixture struct {
+	service       *ConversationEngagementService
+	conversations *inMemoryConversationRepository
+	notifier      *recordingMilestoneNotifier
+	match         *entities.Match
+	now           time.Time
+}
+
+func newEngagementFixture(matchedAt time.Time) *engagementFixture {
+	match := &entities.Match{ID: uuid.New(), User1ID: uuid.New(), User2ID: uuid.New(), MatchedAt: matchedAt, IsActive: true}
+	f := &engagementFixture{
+		conversations: &inMemoryConversationRepository{conversation: &entities.Conversation{ID: uuid.New(), MatchID: match.ID}},
+		notifier:      &recordingMilestoneNotifier{},
+		match:         match,
+		now:           matchedAt,
+	}
+	f.service = NewConversationEngagementService(f.conversations, &singleMatchRepository{match: match}, f.notifier,
+		&config.ConversationEngagementConfig{Enabled: true, StreakMilestones: []int{3, 7}, AnniversaryMonths: []int{1, 6}})
+	f.service.now = func() time.Time { return f.now }
+	return f
+}
+
+// send records a message from the user at the fixture's current time
+func (f *engagementFixture) send(t *testing.T, senderID uuid.UUID) []*entities.ConversationMilestone {
+	t.Helper()
+	milestones, err := f.service.RecordMessage(context.Background(), &entities.Message{
+		ID:             uuid.New(),
+		ConversationID: f.conversations.conversation.ID,
+		SenderID:       senderID,
+	})
+	require.NoError(t, err)
+	return milestones
+}
+
+// chatOn has both participants message on the given day after the match
+func (f *engagementFixture) chatOn(t *testing.T, day int) []*entities.ConversationMilestone {
+	t.Helper()
+	f.now = f.match.MatchedAt.AddDate(0, 0, day)
+	milestones := f.send(t, f.match.User1ID)
+	f.now = f.now.Add(2 * time.Hour)
+	return append(milestones, f.send(t, f.match.User2ID)...)
+}
+
+func (f *engagementFixture) streak() int {
+	return f.conversations.conversation.CurrentStreak(f.now)
+}
+
+func TestConversationEngagementService_StreakGrowsOnConsecutiveDays(t *testing.T) {
+	f := newEngagementFixture(time.Date(2025, 3, 10, 9, 0, 0, 0, time.UTC))
+
+	// A day only counts once both participants have messaged
+	f.now = f.match.MatchedAt.Add(time.Hour)
+	assert.Empty(t, f.send(t, f.match.User1ID))
+	assert.Empty(t, f.send(t, f.match.User1ID))
+	assert.Equal(t, 0, f.streak())
+	assert.Empty(t, f.send(t, f.match.User2ID))
+	assert.Equal(t, 1, f.streak())
+
+	// More messages on the same day do not count again
+	assert.Empty(t, f.send(t, f.match.User1ID))
+	assert.Equal(t, 1, f.streak())
+
+	assert.Empty(t, f.chatOn(t, 1))
+	assert.Equal(t, 2, f.streak())
+
+	milestones := f.chatOn(t, 2)
+	assert.Equal(t, 3, f.streak())
+	require.Len(t, milestones, 1)
+	assert.Equal(t, entities.MilestoneTypeStreak, milestones[0].Type)
+	assert.Equal(t, 3, milestones[0].Value)
+	assert.Equal(t, milestones, f.notifier.milestones)
+
+	// The streak is kept through the next day, until it is missed
+	f.now = f.now.AddDate(0, 0, 1)
+	assert.Equal(t, 3, f.streak())
+}
+
+func TestConversationEngagementService_StreakResetsAfterMissedDay(t *testing.T) {
+	f := newEngagementFixture(time.Date(2025, 3, 10, 9, 0, 0, 0, time.UTC))
+
+	f.chatOn(t, 0)
+	f.chatOn(t, 1)
+	assert.Equal(t, 2, f.streak())
+
+	// Only one participant messages on day 2, so the streak is broken by day 3
+	f.now = f.match.MatchedAt.AddDate(0, 0, 2)
+	f.send(t, f.match.User1ID)
+	f.now = f.now.AddDate(0, 0, 1)
+	assert.Equal(t, 0, f.streak())
+
+	// The other participant replying on a later day does not complete the missed day
+	f.send(t, f.match.User2ID)
+	assert.Equal(t, 0, f.streak())
+
+	f.now = f.now.Add(time.Hour)
+	f.send(t, f.match.User1ID)
+	assert.Equal(t, 1, f.streak(), "the streak starts again from 1")
+	assert.Empty(t, f.notifier.milestones)
+}
+
+func TestConversationEngagementService_AnniversaryCelebratedOnce(t *testing.T) {
+	f := newEngagementFixture(time.Date(2025, 1, 31, 20, 0, 0, 0, time.UTC))
+
+	f.now = time.Date(2025, 2, 28, 12, 0, 0, 0, time.UTC)
+	assert.Empty(t, f.send(t, f.match.User1ID), "not a full month yet")
+
+	f.now = time.Date(2025, 3, 2, 12, 0, 0, 0, time.UTC)
+	milestones := f.send(t, f.match.User1ID)
+	require.Len(t, milestones, 1)
+	assert.Equal(t, entities.MilestoneTypeAnniversary, milestones[0].Type)
+	assert.Equal(t, 1, milestones[0].Value)
+
+	f.now = f.now.Add(time.Hour)
+	assert.Empty(t, f.send(t, f.match.User2ID), "each anniversary is celebrated once")
+
+	// Months that are not milestones pass without a celebration
+	f.now = time.Date(2025, 4, 15, 12, 0, 0, 0, time.UTC)
+	assert.Empty(t, f.send(t, f.match.User1ID))
+	assert.Len(t, f.notifier.milestones, 1)
+
+	// Nothing is tracked when the feature is disabled
+	f.service.config.Enabled = false
+	f.now = time.Date(2025, 7, 31, 12, 0, 0, 0, time.UTC)
+	updates := f.conversations.updates
+	assert.Empty(t, f.send(t, f.match.User1ID))
+	assert.Equal(t, updates, f.conversations.updates)
+}
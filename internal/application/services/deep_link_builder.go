@@ -0,0 +1,270 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/google/uuid"
+	"github.com/22smeargle/winkr-backend/internal/domain/entities"
+	"github.com/22smeargle/winkr-backend/pkg/config"
+	"github.com/22smeargle/winkr-backend/pkg/errors"
+	"github.com/22smeargle/winkr-backend/pkg/logger"
+)
+
+// Targets a deep link can point to
+const (
+	DeepLinkTargetMatch        = "match"
+	DeepLinkTargetConversation = "conversation"
+	DeepLinkTargetProfile      = "profile"
+)
+
+// deepLinkPaths maps each target to its path in the app
+var deepLinkPaths = map[string]string{
+	DeepLinkTargetMatch:        "matches",
+	DeepLinkTargetConversation: "conversations",
+	DeepLinkTargetProfile:      "profiles",
+}
+
+// DeepLinkMatchStore defines the match lookup needed to authorize match links
+type DeepLinkMatchStore interface {
+	GetMatchByID(ctx context.Context, id uuid.UUID) (*entities.Match, error)
+}
+
+// DeepLinkConversationStore defines the conversation access check needed to authorize conversation links
+type DeepLinkConversationStore interface {
+	UserCanAccessConversation(ctx context.Context, userID, conversationID uuid.UUID) (bool, error)
+}
+
+// DeepLinkUserStore defines the user lookup needed to authorize profile links
+type DeepLinkUserStore interface {
+	GetByID(ctx context.Context, id uuid.UUID) (*entities.User, error)
+}
+
+// DeepLink is a signed link into the app
+type DeepLink struct {
+	URL       string    `json:"url,omitempty"`
+	Target    string    `json:"target"`
+	TargetID  uuid.UUID `json:"target_id"`
+	ExpiresAt time.Time `json:"expires_at"`
+}
+
+// DeepLinkClaims are the claims signed into a deep link token.
+// The subject is the user the link was issued to.
+type DeepLinkClaims struct {
+	Target   string `json:"target"`
+	TargetID string `json:"target_id"`
+	jwt.RegisteredClaims
+}
+
+// DeepLinkBuilder generates signed, expiring deep links for notifications
+// and validates them when the app opens one
+type DeepLinkBuilder struct {
+	matchStore        DeepLinkMatchStore
+	conversationStore DeepLinkConversationStore
+	userStore         DeepLinkUserStore
+	config            *config.DeepLinkConfig
+	now               func() time.Time
+}
+
+// NewDeepLinkBuilder creates a new DeepLinkBuilder
+func NewDeepLinkBuilder(
+	matchStore DeepLinkMatchStore,
+	conversationStore DeepLinkConversationStore,
+	userStore DeepLinkUserStore,
+	cfg *config.DeepLinkConfig,
+) *DeepLinkBuilder {
+	return &DeepLinkBuilder{
+		matchStore:        matchStore,
+		conversationStore: conversationStore,
+		userStore:         userStore,
+		config:            cfg,
+		now:               time.Now,
+	}
+}
+
+// Build generates a deep link to the target for the user who will receive it
+func (b *DeepLinkBuilder) Build(userID uuid.UUID, target string, targetID uuid.UUID) (*DeepLink, error) {
+	path, ok := deepLinkPaths[target]
+	if !ok {
+		return nil, fmt.Errorf("unknown deep link target: %s", target)
+	}
+
+	now := b.now()
+	expiresAt := now.Add(b.config.TTL)
+	claims := DeepLinkClaims{
+		Target:   target,
+		TargetID: targetID.String(),
+		RegisteredClaims: jwt.RegisteredClaims{
+			Subject:   userID.String(),
+			IssuedAt:  jwt.NewNumericDate(now),
+			ExpiresAt: jwt.NewNumericDate(expiresAt),
+			Issuer:    "winkr-backend",
+		},
+	}
+
+	token, err := jwt.NewWithClaims(jwt.SigningMethodHS256, claims).SignedString([]byte(b.config.Secret))
+	if err != nil {
+		return nil, fmt.Errorf("failed to sign deep link: %w", err)
+	}
+
+	link := url.URL{
+		Scheme:   b.config.Scheme,
+		Host:     b.config.Host,
+		Path:     fmt.Sprintf("/%s/%s", path, targetID),
+		RawQuery: url.Values{"token": []string{token}}.Encode(),
+	}
+
+	return &DeepLink{
+		URL:       link.String(),
+		Target:    target,
+		TargetID:  targetID,
+		ExpiresAt: expiresAt,
+	}, nil
+}
+
+// Resolve validates a deep link token opened by the user and returns the link it points to.
+// The link must not have expired, must have been issued to the user and the user must
+// still be allowed to see the target.
+func (b *DeepLinkBuilder) Resolve(ctx context.Context, userID uuid.UUID, tokenString string) (*DeepLink, error) {
+	claims := &DeepLinkClaims{}
+	// Expiry is checked below so an expired link gets its own error
+	_, err := jwt.ParseWithClaims(tokenString, claims, func(token *jwt.Token) (interface{}, error) {
+		if _, ok := token.Method.(*jwt.SigningMethodHMAC); !ok {
+			return nil, fmt.Errorf("unexpected signing method: %v", token.Header["alg"])
+		}
+		return []byte(b.config.Secret), nil
+	}, jwt.WithoutClaimsValidation())
+	if err != nil {
+		return nil, errors.ErrDeepLinkInvalid
+	}
+
+	targetID, err := uuid.Parse(claims.TargetID)
+	if _, ok := deepLinkPaths[claims.Target]; !ok || err != nil || claims.ExpiresAt == nil {
+		return nil, errors.ErrDeepLinkInvalid
+	}
+
+	if !b.now().Before(claims.ExpiresAt.Time) {
+		return nil, errors.ErrDeepLinkExpired
+	}
+
+	if claims.Subject != userID.String() {
+		return nil, errors.NewForbiddenError("This link was sent to another account")
+	}
+
+	allowed, err := b.canAccess(ctx, userID, claims.Target, targetID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to authorize deep link: %w", err)
+	}
+	if !allowed {
+		return nil, errors.NewForbiddenError("You no longer have access to this content")
+	}
+
+	return &DeepLink{
+		Target:    claims.Target,
+		TargetID:  targetID,
+		ExpiresAt: claims.ExpiresAt.Time,
+	}, nil
+}
+
+// canAccess checks if the user may still open the target of a link.
+// Matches and profiles that can no longer be loaded, e.g. because they were deleted, are denied.
+func (b *DeepLinkBuilder) canAccess(ctx context.Context, userID uuid.UUID, target string, targetID uuid.UUID) (bool, error) {
+	switch target {
+	case DeepLinkTargetMatch:
+		match, err := b.matchStore.GetMatchByID(ctx, targetID)
+		if err != nil {
+			logger.Warn("Failed to load deep link match", err, "match_id", targetID)
+			return false, nil
+		}
+		return match != nil && match.IsActive && match.IsUserInMatch(userID), nil
+	case DeepLinkTargetConversation:
+		return b.conversationStore.UserCanAccessConversation(ctx, userID, targetID)
+	case DeepLinkTargetProfile:
+		user, err := b.userStore.GetByID(ctx, targetID)
+		if err != nil {
+			logger.Warn("Failed to load deep link profile", err, "user_id", targetID)
+			return false, nil
+		}
+		return user != nil && user.IsActive && !user.IsBanned, nil
+	}
+	return false, nil
+}
+
+// NotificationSender sends a push or email notification to a user
+type NotificationSender interface {
+	SendNotification(ctx context.Context, userID uuid.UUID, notificationType string, data map[string]interface{}) error
+}
+
+// deepLinkDataKeys maps notification data keys to the target they link to, most specific first
+var deepLinkDataKeys = []struct {
+	key    string
+	target string
+}{
+	{"conversation_id", DeepLinkTargetConversation},
+	{"match_id", DeepLinkTargetMatch},
+	{"profile_id", DeepLinkTargetProfile},
+}
+
+// DeepLinkNotifier wraps a notification dispatcher and adds a deep link to every notification
+// about a conversation, match or profile
+type DeepLinkNotifier struct {
+	next    NotificationSender
+	builder *DeepLinkBuilder
+}
+
+// NewDeepLinkNotifier creates a new DeepLinkNotifier
+func NewDeepLinkNotifier(next NotificationSender, builder *DeepLinkBuilder) *DeepLinkNotifier {
+	return &DeepLinkNotifier{
+		next:    next,
+		builder: builder,
+	}
+}
+
+// SendNotification adds "deep_link" and "deep_link_expires_at" to the data and passes the notification on.
+// Notifications are still sent without a link if one cannot be built.
+func (n *DeepLinkNotifier) SendNotification(ctx context.Context, userID uuid.UUID, notificationType string, data map[string]interface{}) error {
+	target, targetID, ok := deepLinkTarget(data)
+	if !ok {
+		return n.next.SendNotification(ctx, userID, notificationType, data)
+	}
+
+	link, err := n.builder.Build(userID, target, targetID)
+	if err != nil {
+		logger.Warn("Failed to build notification deep link", err, "user_id", userID, "notification_type", notificationType)
+		return n.next.SendNotification(ctx, userID, notificationType, data)
+	}
+
+	// Copy so the caller's data is left untouched
+	linked := make(map[string]interface{}, len(data)+2)
+	for key, value := range data {
+		linked[key] = value
+	}
+	linked["deep_link"] = link.URL
+	linked["deep_link_expires_at"] = link.ExpiresAt
+
+	return n.next.SendNotification(ctx, userID, notificationType, linked)
+}
+
+// deepLinkTarget finds the target a notification should link to
+func deepLinkTarget(data map[string]interface{}) (string, uuid.UUID, bool) {
+	for _, candidate := range deepLinkDataKeys {
+		switch id := data[candidate.key].(type) {
+		case uuid.UUID:
+			if id != uuid.Nil {
+				return candidate.target, id, true
+			}
+		case *uuid.UUID:
+			if id != nil && *id != uuid.Nil {
+				return candidate.target, *id, true
+			}
+		case string:
+			if parsed, err := uuid.Parse(id); err == nil {
+				return candidate.target, parsed, true
+			}
+		}
+	}
+	return "", uuid.Nil, false
+}
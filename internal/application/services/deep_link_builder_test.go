@@ -0,0 +1,191 @@
+package services
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/22smeargle/winkr-backend/internal/domain/entities"
+	"github.com/22smeargle/winkr-backend/pkg/config"
+	"github.com/22smeargle/winkr-backend/pkg/errors"
+)
+
+// deepLinkTestStore is an in-memory store for the match, conversation and user lookups of deep links
+type deepLinkTestStore struct {
+	matches       map[uuid.UUID]*entities.Match
+	conversations map[uuid.UUID][]uuid.UUID // conversation ID -> participant IDs
+	users         map[uuid.UUID]*entities.User
+}
+
+func newDeepLinkTestStore() *deepLinkTestStore {
+	return &deepLinkTestStore{
+		matches:       make(map[uuid.UUID]*entities.Match),
+		conversations: make(map[uuid.UUID][]uuid.UUID),
+		users:         make(map[uuid.UUID]*entities.User),
+	}
+}
+
+func (s *deepLinkTestStore) GetMatchByID(ctx context.Context, id uuid.UUID) (*entities.Match, error) {
+	match, ok := s.matches[id]
+	if !ok {
+		return nil, errors.ErrMatchNotFound
+	}
+	return match, nil
+}
+
+func (s *deepLinkTestStore) UserCanAccessConversation(ctx context.Context, userID, conversationID uuid.UUID) (bool, error) {
+	for _, participantID := range s.conversations[conversationID] {
+		if participantID == userID {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+func (s *deepLinkTestStore) GetByID(ctx context.Context, id uuid.UUID) (*entities.User, error) {
+	user, ok := s.users[id]
+	if !ok {
+		return nil, errors.ErrUserNotFound
+	}
+	return user, nil
+}
+
+// recordingSender records the notifications passed on by a DeepLinkNotifier
+type recordingSender struct {
+	data []map[string]interface{}
+}
+
+func (r *recordingSender) SendNotification(ctx context.Context, userID uuid.UUID, notificationType string, data map[string]interface{}) error {
+	r.data = append(r.data, data)
+	return nil
+}
+
+func newTestDeepLinkBuilder(store *deepLinkTestStore) *DeepLinkBuilder {
+	return NewDeepLinkBuilder(store, store, store, &config.DeepLinkConfig{
+		Scheme: "winkr",
+		Host:   "app",
+		Secret: "test-deep-link-secret",
+		TTL:    time.Hour,
+	})
+}
+
+func statusCode(t *testing.T, err error) int {
+	appErr := errors.GetAppError(err)
+	require.NotNil(t, appErr, "expected an AppError, got %v", err)
+	return appErr.StatusCode()
+}
+
+func TestDeepLinkBuilder_BuildAndResolve(t *testing.T) {
+	ctx := context.Background()
+	store := newDeepLinkTestStore()
+	userID := uuid.New()
+	conversationID := uuid.New()
+	store.conversations[conversationID] = []uuid.UUID{userID, uuid.New()}
+	builder := newTestDeepLinkBuilder(store)
+
+	link, err := builder.Build(userID, DeepLinkTargetConversation, conversationID)
+	require.NoError(t, err)
+
+	parsed, err := url.Parse(link.URL)
+	require.NoError(t, err)
+	assert.Equal(t, "winkr", parsed.Scheme)
+	assert.Equal(t, "app", parsed.Host)
+	assert.Equal(t, "/conversations/"+conversationID.String(), parsed.Path)
+	token := parsed.Query().Get("token")
+	require.NotEmpty(t, token)
+
+	resolved, err := builder.Resolve(ctx, userID, token)
+	require.NoError(t, err)
+	assert.Equal(t, DeepLinkTargetConversation, resolved.Target)
+	assert.Equal(t, conversationID, resolved.TargetID)
+	assert.WithinDuration(t, link.ExpiresAt, resolved.ExpiresAt, time.Second)
+}
+
+func TestDeepLinkBuilder_UnknownTarget(t *testing.T) {
+	builder := newTestDeepLinkBuilder(newDeepLinkTestStore())
+
+	_, err := builder.Build(uuid.New(), "photo", uuid.New())
+	assert.Error(t, err)
+}
+
+func TestDeepLinkBuilder_ExpiredLink(t *testing.T) {
+	store := newDeepLinkTestStore()
+	userID := uuid.New()
+	profileID := uuid.New()
+	store.users[profileID] = &entities.User{ID: profileID, IsActive: true}
+	builder := newTestDeepLinkBuilder(store)
+
+	link, err := builder.Build(userID, DeepLinkTargetProfile, profileID)
+	require.NoError(t, err)
+	parsed, err := url.Parse(link.URL)
+	require.NoError(t, err)
+
+	builder.now = func() time.Time { return time.Now().Add(2 * time.Hour) }
+
+	_, err = builder.Resolve(context.Background(), userID, parsed.Query().Get("token"))
+	require.Error(t, err)
+	assert.Equal(t, http.StatusGone, statusCode(t, err))
+}
+
+func TestDeepLinkBuilder_Authorization(t *testing.T) {
+	ctx := context.Background()
+	store := newDeepLinkTestStore()
+	userID := uuid.New()
+	otherUserID := uuid.New()
+	match := &entities.Match{ID: uuid.New(), User1ID: userID, User2ID: otherUserID, IsActive: true}
+	store.matches[match.ID] = match
+	builder := newTestDeepLinkBuilder(store)
+
+	link, err := builder.Build(userID, DeepLinkTargetMatch, match.ID)
+	require.NoError(t, err)
+	parsed, err := url.Parse(link.URL)
+	require.NoError(t, err)
+	token := parsed.Query().Get("token")
+
+	// Links only open for the user they were sent to
+	_, err = builder.Resolve(ctx, otherUserID, token)
+	require.Error(t, err)
+	assert.Equal(t, http.StatusForbidden, statusCode(t, err))
+
+	_, err = builder.Resolve(ctx, userID, token)
+	require.NoError(t, err)
+
+	// A match that has ended can no longer be opened
+	match.Deactivate()
+	_, err = builder.Resolve(ctx, userID, token)
+	require.Error(t, err)
+	assert.Equal(t, http.StatusForbidden, statusCode(t, err))
+
+	// Tampered tokens are rejected
+	_, err = builder.Resolve(ctx, userID, token+"x")
+	require.Error(t, err)
+	assert.Equal(t, http.StatusBadRequest, statusCode(t, err))
+}
+
+func TestDeepLinkNotifier_AttachesLink(t *testing.T) {
+	userID := uuid.New()
+	matchID := uuid.New()
+	conversationID := uuid.New()
+	sender := &recordingSender{}
+	notifier := NewDeepLinkNotifier(sender, newTestDeepLinkBuilder(newDeepLinkTestStore()))
+
+	data := map[string]interface{}{
+		"match_id":        matchID,
+		"conversation_id": &conversationID,
+	}
+	require.NoError(t, notifier.SendNotification(context.Background(), userID, "match_ended", data))
+
+	// Notifications without a target are passed on unchanged
+	require.NoError(t, notifier.SendNotification(context.Background(), userID, "user_banned", map[string]interface{}{"reason": "spam"}))
+
+	require.Len(t, sender.data, 2)
+	assert.Contains(t, sender.data[0]["deep_link"], "winkr://app/conversations/"+conversationID.String())
+	assert.NotNil(t, sender.data[0]["deep_link_expires_at"])
+	assert.NotContains(t, data, "deep_link")
+	assert.NotContains(t, sender.data[1], "deep_link")
+}
@@ -0,0 +1,48 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// DiscoveryBoostStore defines the storage for discovery boosts. Boosts are stored with a TTL
+// ending with the boost, so expired boosts disappear on their own.
+type DiscoveryBoostStore interface {
+	SetBoost(ctx context.Context, key string, boost *DiscoveryBoost, ttl time.Duration) error
+	GetBoost(ctx context.Context, key string) (*DiscoveryBoost, error)
+	IncrementBoostCount(ctx context.Context, key string, ttl time.Duration) (int64, error)
+}
+
+// DiscoveryBoost is a premium user's window of showing first in others' discovery feeds
+type DiscoveryBoost struct {
+	UserID     uuid.UUID `json:"user_id"`
+	StartsAt   time.Time `json:"starts_at"`
+	EndsAt     time.Time `json:"ends_at"`
+	Multiplier float64   `json:"multiplier"`
+}
+
+// IsActive returns true if the boost is running at the given time
+func (b *DiscoveryBoost) IsActive(now time.Time) bool {
+	return b != nil && !now.Before(b.StartsAt) && now.Before(b.EndsAt)
+}
+
+// Remaining returns how long the boost still runs, zero once it ended
+func (b *DiscoveryBoost) Remaining(now time.Time) time.Duration {
+	if !b.IsActive(now) {
+		return 0
+	}
+	return b.EndsAt.Sub(now)
+}
+
+// DiscoveryBoostKey returns the key of a user's boost
+func DiscoveryBoostKey(userID uuid.UUID) string {
+	return fmt.Sprintf("discovery_boost:%s", userID)
+}
+
+// DiscoveryBoostCountKey returns the key counting the boosts a user started on the UTC day of now
+func DiscoveryBoostCountKey(userID uuid.UUID, now time.Time) string {
+	return fmt.Sprintf("discovery_boost_count:%s:%s", userID, now.UTC().Format("2006-01-02"))
+}
@@ -0,0 +1,114 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/22smeargle/winkr-backend/internal/domain/entities"
+	"github.com/22smeargle/winkr-backend/pkg/config"
+	"github.com/22smeargle/winkr-backend/pkg/logger"
+)
+
+// ExposureStore defines the storage needed to track daily discovery exposure
+type ExposureStore interface {
+	IncrementExposure(ctx context.Context, key string, ttl time.Duration) (int64, error)
+	MarkOverExposed(ctx context.Context, key string, userID uuid.UUID, ttl time.Duration) error
+	GetOverExposed(ctx context.Context, key string) ([]uuid.UUID, error)
+}
+
+// exposureKeyTTL keeps a day's counters around a little longer than the day itself
+const exposureKeyTTL = 48 * time.Hour
+
+// DiscoveryExposureService counts how often each profile is shown in discovery per UTC day
+// and pushes profiles that reached the daily cap behind everyone else, so a few popular
+// profiles cannot dominate every deck
+type DiscoveryExposureService struct {
+	store  ExposureStore
+	config *config.MatchingFairnessConfig
+	now    func() time.Time
+}
+
+// NewDiscoveryExposureService creates a new DiscoveryExposureService
+func NewDiscoveryExposureService(store ExposureStore, cfg *config.MatchingFairnessConfig) *DiscoveryExposureService {
+	return &DiscoveryExposureService{
+		store:  store,
+		config: cfg,
+		now:    time.Now,
+	}
+}
+
+// RecordImpressions counts one impression for each profile shown. Failures are logged and
+// ignored so discovery keeps working when Redis is unavailable.
+func (s *DiscoveryExposureService) RecordImpressions(ctx context.Context, users []*entities.User) {
+	if !s.isActive() {
+		return
+	}
+
+	day := s.day()
+	for _, user := range users {
+		count, err := s.store.IncrementExposure(ctx, exposureCountKey(day, user.ID), exposureKeyTTL)
+		if err != nil {
+			logger.Warn("Failed to record discovery impression", err, "user_id", user.ID)
+			continue
+		}
+
+		// Marking is idempotent, so retry on every impression past the cap in case an earlier mark failed
+		if count >= int64(s.config.DailyExposureCap) {
+			if err := s.store.MarkOverExposed(ctx, overExposedKey(day), user.ID, exposureKeyTTL); err != nil {
+				logger.Warn("Failed to mark profile as over-exposed", err, "user_id", user.ID)
+			}
+		}
+	}
+}
+
+// Deprioritize moves profiles that reached today's exposure cap behind the others,
+// keeping the existing order within both groups
+func (s *DiscoveryExposureService) Deprioritize(ctx context.Context, scored []*UserScore) []*UserScore {
+	if !s.isActive() || len(scored) == 0 {
+		return scored
+	}
+
+	capped, err := s.store.GetOverExposed(ctx, overExposedKey(s.day()))
+	if err != nil || len(capped) == 0 {
+		return scored
+	}
+
+	overExposed := make(map[uuid.UUID]bool, len(capped))
+	for _, userID := range capped {
+		overExposed[userID] = true
+	}
+
+	result := make([]*UserScore, 0, len(scored))
+	var deprioritized []*UserScore
+	for _, score := range scored {
+		if overExposed[score.User.ID] {
+			deprioritized = append(deprioritized, score)
+			continue
+		}
+		result = append(result, score)
+	}
+
+	return append(result, deprioritized...)
+}
+
+// isActive reports whether exposure capping is enabled with a usable cap
+func (s *DiscoveryExposureService) isActive() bool {
+	return s.config.Enabled && s.config.DailyExposureCap > 0
+}
+
+// day returns the current UTC day that exposure is counted against
+func (s *DiscoveryExposureService) day() string {
+	return s.now().UTC().Format("2006-01-02")
+}
+
+// exposureCountKey returns the cache key of a profile's impression count for a day
+func exposureCountKey(day string, userID uuid.UUID) string {
+	return fmt.Sprintf("exposure:%s:%s", day, userID.String())
+}
+
+// overExposedKey returns the cache key of the set of profiles that reached the cap on a day
+func overExposedKey(day string) string {
+	return fmt.Sprintf("exposure:%s:capped", day)
+}
@@ -0,0 +1,133 @@
+package services
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/22smeargle/winkr-backend/internal/domain/entities"
+	"github.com/22smeargle/winkr-backend/pkg/config"
+)
+
+// inMemoryExposureStore is an in-memory ExposureStore for tests
+type inMemoryExposureStore struct {
+	counts      map[string]int64
+	overExposed map[string]map[uuid.UUID]bool
+}
+
+func newInMemoryExposureStore() *inMemoryExposureStore {
+	return &inMemoryExposureStore{
+		counts:      make(map[string]int64),
+		overExposed: make(map[string]map[uuid.UUID]bool),
+	}
+}
+
+func (s *inMemoryExposureStore) IncrementExposure(ctx context.Context, key string, ttl time.Duration) (int64, error) {
+	s.counts[key]++
+	return s.counts[key], nil
+}
+
+func (s *inMemoryExposureStore) MarkOverExposed(ctx context.Context, key string, userID uuid.UUID, ttl time.Duration) error {
+	if s.overExposed[key] == nil {
+		s.overExposed[key] = make(map[uuid.UUID]bool)
+	}
+	s.overExposed[key][userID] = true
+	return nil
+}
+
+func (s *inMemoryExposureStore) GetOverExposed(ctx context.Context, key string) ([]uuid.UUID, error) {
+	var userIDs []uuid.UUID
+	for userID := range s.overExposed[key] {
+		userIDs = append(userIDs, userID)
+	}
+	return userIDs, nil
+}
+
+func newTestExposureService(store ExposureStore, now *time.Time) *DiscoveryExposureService {
+	service := NewDiscoveryExposureService(store, &config.MatchingFairnessConfig{
+		Enabled:          true,
+		DailyExposureCap: 3,
+	})
+	service.now = func() time.Time { return *now }
+	return service
+}
+
+// deck returns scored profiles in descending score order
+func deck(users ...*entities.User) []*UserScore {
+	scored := make([]*UserScore, len(users))
+	for i, user := range users {
+		scored[i] = &UserScore{User: user, Score: float64(100 - i)}
+	}
+	return scored
+}
+
+func deckOrder(scored []*UserScore) []uuid.UUID {
+	ids := make([]uuid.UUID, len(scored))
+	for i, score := range scored {
+		ids[i] = score.User.ID
+	}
+	return ids
+}
+
+func TestDiscoveryExposureService_DeprioritizesProfileBeyondCap(t *testing.T) {
+	ctx := context.Background()
+	now := time.Date(2026, 3, 14, 10, 0, 0, 0, time.UTC)
+	service := newTestExposureService(newInMemoryExposureStore(), &now)
+
+	popular := &entities.User{ID: uuid.New()}
+	second := &entities.User{ID: uuid.New()}
+	third := &entities.User{ID: uuid.New()}
+
+	// Below the cap the deck keeps its score order
+	for i := 0; i < 2; i++ {
+		service.RecordImpressions(ctx, []*entities.User{popular})
+	}
+	assert.Equal(t, deckOrder(deck(popular, second, third)), deckOrder(service.Deprioritize(ctx, deck(popular, second, third))))
+
+	// Reaching the cap pushes the profile behind everyone else, keeping the others in order
+	service.RecordImpressions(ctx, []*entities.User{popular})
+	assert.Equal(t,
+		[]uuid.UUID{second.ID, third.ID, popular.ID},
+		deckOrder(service.Deprioritize(ctx, deck(popular, second, third))),
+	)
+}
+
+func TestDiscoveryExposureService_CapResetsNextDay(t *testing.T) {
+	ctx := context.Background()
+	now := time.Date(2026, 3, 14, 23, 30, 0, 0, time.UTC)
+	service := newTestExposureService(newInMemoryExposureStore(), &now)
+
+	popular := &entities.User{ID: uuid.New()}
+	other := &entities.User{ID: uuid.New()}
+
+	for i := 0; i < 5; i++ {
+		service.RecordImpressions(ctx, []*entities.User{popular})
+	}
+	assert.Equal(t, []uuid.UUID{other.ID, popular.ID}, deckOrder(service.Deprioritize(ctx, deck(popular, other))))
+
+	// Past midnight UTC the profile starts the day with a clean slate
+	now = now.Add(time.Hour)
+	assert.Equal(t, []uuid.UUID{popular.ID, other.ID}, deckOrder(service.Deprioritize(ctx, deck(popular, other))))
+
+	service.RecordImpressions(ctx, []*entities.User{popular})
+	assert.Equal(t, []uuid.UUID{popular.ID, other.ID}, deckOrder(service.Deprioritize(ctx, deck(popular, other))))
+}
+
+func TestDiscoveryExposureService_Disabled(t *testing.T) {
+	ctx := context.Background()
+	store := newInMemoryExposureStore()
+	service := NewDiscoveryExposureService(store, &config.MatchingFairnessConfig{
+		Enabled:          false,
+		DailyExposureCap: 1,
+	})
+
+	popular := &entities.User{ID: uuid.New()}
+	other := &entities.User{ID: uuid.New()}
+
+	service.RecordImpressions(ctx, []*entities.User{popular, popular})
+
+	assert.Empty(t, store.counts)
+	assert.Equal(t, []uuid.UUID{popular.ID, other.ID}, deckOrder(service.Deprioritize(ctx, deck(popular, other))))
+}
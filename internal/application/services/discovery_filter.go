@@ -0,0 +1,119 @@
+package services
+
+import (
+	"fmt"
+	"net/http"
+	"sort"
+	"strings"
+
+	"github.com/22smeargle/winkr-backend/internal/domain/entities"
+	"github.com/22smeargle/winkr-backend/pkg/config"
+	"github.com/22smeargle/winkr-backend/pkg/errors"
+)
+
+// AttributeFilters narrows discovery by structured profile attributes.
+// Candidates who have not answered a filtered attribute are excluded, since they cannot be known to match.
+type AttributeFilters struct {
+	MinHeightCm *int     `json:"min_height,omitempty"`
+	MaxHeightCm *int     `json:"max_height,omitempty"`
+	Smoking     []string `json:"smoking,omitempty"`  // Any of entities.LifestyleHabits
+	Drinking    []string `json:"drinking,omitempty"` // Any of entities.LifestyleHabits
+}
+
+// HasLifestyle returns true if the smoking or drinking filters are set
+func (f *AttributeFilters) HasLifestyle() bool {
+	return len(f.Smoking) > 0 || len(f.Drinking) > 0
+}
+
+// Validate checks the filters against the allowed values. Lifestyle filters need a premium plan
+// when the config says so.
+func (f *AttributeFilters) Validate(user *entities.User, cfg *config.MatchingFiltersConfig) error {
+	heightRange := fmt.Sprintf("must be between %d and %d cm", entities.MinHeightCm, entities.MaxHeightCm)
+	if f.MinHeightCm != nil && !entities.IsValidHeightCm(*f.MinHeightCm) {
+		return errors.NewValidationError("min_height", heightRange)
+	}
+	if f.MaxHeightCm != nil && !entities.IsValidHeightCm(*f.MaxHeightCm) {
+		return errors.NewValidationError("max_height", heightRange)
+	}
+	if f.MinHeightCm != nil && f.MaxHeightCm != nil && *f.MinHeightCm > *f.MaxHeightCm {
+		return errors.NewValidationError("min_height", "must not be greater than max_height")
+	}
+
+	allowedHabits := "must be one of " + strings.Join(entities.LifestyleHabits, ", ")
+	for _, habit := range f.Smoking {
+		if !entities.IsValidLifestyleHabit(habit) {
+			return errors.NewValidationError("smoking", allowedHabits)
+		}
+	}
+	for _, habit := range f.Drinking {
+		if !entities.IsValidLifestyleHabit(habit) {
+			return errors.NewValidationError("drinking", allowedHabits)
+		}
+	}
+
+	if f.HasLifestyle() && cfg != nil && cfg.PremiumLifestyle && !user.IsPremium {
+		return errors.NewAppError(http.StatusPaymentRequired, "Subscription required", "Smoking and drinking filters require a premium plan")
+	}
+
+	return nil
+}
+
+// Matches returns true if the candidate meets every filter that is set
+func (f *AttributeFilters) Matches(candidate *entities.User) bool {
+	if f.MinHeightCm != nil || f.MaxHeightCm != nil {
+		if candidate.HeightCm == nil {
+			return false
+		}
+		if f.MinHeightCm != nil && *candidate.HeightCm < *f.MinHeightCm {
+			return false
+		}
+		if f.MaxHeightCm != nil && *candidate.HeightCm > *f.MaxHeightCm {
+			return false
+		}
+	}
+
+	return matchesHabit(f.Smoking, candidate.Smoking) && matchesHabit(f.Drinking, candidate.Drinking)
+}
+
+// Key returns a stable representation of the filters for cache keys
+func (f *AttributeFilters) Key() string {
+	height := func(cm *int) string {
+		if cm == nil {
+			return ""
+		}
+		return fmt.Sprint(*cm)
+	}
+	sorted := func(values []string) string {
+		copied := append([]string(nil), values...)
+		sort.Strings(copied)
+		return strings.Join(copied, ",")
+	}
+	return fmt.Sprintf("%s-%s:%s:%s", height(f.MinHeightCm), height(f.MaxHeightCm), sorted(f.Smoking), sorted(f.Drinking))
+}
+
+// filterCandidates returns the candidates that meet the attribute filters
+func filterCandidates(candidates []*entities.User, filters *AttributeFilters) []*entities.User {
+	filtered := make([]*entities.User, 0, len(candidates))
+	for _, candidate := range candidates {
+		if filters.Matches(candidate) {
+			filtered = append(filtered, candidate)
+		}
+	}
+	return filtered
+}
+
+// matchesHabit returns true if no habits are filtered or the answer is one of them
+func matchesHabit(allowed []string, habit *string) bool {
+	if len(allowed) == 0 {
+		return true
+	}
+	if habit == nil {
+		return false
+	}
+	for _, value := range allowed {
+		if *habit == value {
+			return true
+		}
+	}
+	return false
+}
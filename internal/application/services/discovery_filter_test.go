@@ -0,0 +1,166 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/22smeargle/winkr-backend/internal/domain/entities"
+	"github.com/22smeargle/winkr-backend/internal/domain/repositories"
+	"github.com/22smeargle/winkr-backend/pkg/config"
+	"github.com/22smeargle/winkr-backend/pkg/errors"
+)
+
+// candidateUserRepository returns a fixed set of candidates for the location query
+type candidateUserRepository struct {
+	repositories.UserRepository
+	candidates []*entities.User
+}
+
+func (r *candidateUserRepository) GetByLocation(ctx context.Context, lat, lng float64, radiusKm int, limit, offset int) ([]*entities.User, error) {
+	return r.candidates, nil
+}
+
+// uncachedMatchesCache never has potential matches cached
+type uncachedMatchesCache struct {
+	CacheService
+}
+
+func (c *uncachedMatchesCache) GetPotentialMatches(ctx context.Context, key string) (*PotentialMatchesCache, error) {
+	return nil, fmt.Errorf("cache miss")
+}
+
+func (c *uncachedMatchesCache) SetPotentialMatches(ctx context.Context, key string, cache *PotentialMatchesCache, ttl time.Duration) error {
+	return nil
+}
+
+func intPtr(v int) *int {
+	return &v
+}
+
+func stringPtr(v string) *string {
+	return &v
+}
+
+// candidateWith returns an active adult user with the given height and smoking answer
+func candidateWith(heightCm *int, smoking *string) *entities.User {
+	lat, lng := 52.52, 13.40
+	return &entities.User{
+		ID:          uuid.New(),
+		DateOfBirth: time.Now().AddDate(-30, 0, 0),
+		Gender:      "female",
+		LocationLat: &lat,
+		LocationLng: &lng,
+		IsActive:    true,
+		HeightCm:    heightCm,
+		Smoking:     smoking,
+	}
+}
+
+func TestMatchingAlgorithm_HeightRangeExcludesOutOfRangeCandidates(t *testing.T) {
+	short := candidateWith(intPtr(158), nil)
+	lowerBound := candidateWith(intPtr(165), nil)
+	inRange := candidateWith(intPtr(172), nil)
+	upperBound := candidateWith(intPtr(180), nil)
+	tall := candidateWith(intPtr(193), nil)
+	unknown := candidateWith(nil, nil)
+
+	service := NewMatchingAlgorithmService(
+		&candidateUserRepository{candidates: []*entities.User{short, lowerBound, inRange, upperBound, tall, unknown}},
+		nil, nil, &uncachedMatchesCache{}, nil, nil, nil,
+	)
+
+	currentUser := candidateWith(intPtr(170), nil)
+	filter := &MatchingFilter{
+		UserID:      currentUser.ID,
+		MaxDistance: 50,
+		Attributes: AttributeFilters{
+			MinHeightCm: intPtr(165),
+			MaxHeightCm: intPtr(180),
+		},
+	}
+
+	users, total, err := service.GetPotentialMatches(context.Background(), currentUser, filter, nil, 10, 0)
+	require.NoError(t, err)
+
+	assert.Equal(t, int64(3), total)
+	assert.ElementsMatch(t, []uuid.UUID{lowerBound.ID, inRange.ID, upperBound.ID}, userIDs(users))
+}
+
+func TestMatchingAlgorithm_HidesShadowbannedCandidates(t *testing.T) {
+	visible := candidateWith(nil, nil)
+	shadowbanned := candidateWith(nil, nil)
+	shadowbanned.Shadowbanned = true
+
+	service := NewMatchingAlgorithmService(
+		&candidateUserRepository{candidates: []*entities.User{visible, shadowbanned}},
+		nil, nil, &uncachedMatchesCache{}, nil, nil, nil,
+	)
+
+	currentUser := candidateWith(nil, nil)
+	filter := &MatchingFilter{UserID: currentUser.ID, MaxDistance: 50}
+
+	users, _, err := service.GetPotentialMatches(context.Background(), currentUser, filter, nil, 10, 0)
+	require.NoError(t, err)
+
+	assert.Equal(t, []uuid.UUID{visible.ID}, userIDs(users))
+}
+
+func TestAttributeFilters_MatchesLifestyle(t *testing.T) {
+	filters := &AttributeFilters{
+		MaxHeightCm: intPtr(185),
+		Smoking:     []string{entities.LifestyleHabitNever, entities.LifestyleHabitSocially},
+	}
+
+	assert.True(t, filters.Matches(candidateWith(intPtr(170), stringPtr(entities.LifestyleHabitNever))))
+	assert.True(t, filters.Matches(candidateWith(intPtr(185), stringPtr(entities.LifestyleHabitSocially))))
+	assert.False(t, filters.Matches(candidateWith(intPtr(170), stringPtr(entities.LifestyleHabitRegularly))))
+	assert.False(t, filters.Matches(candidateWith(intPtr(170), nil)), "unanswered habits do not match")
+	assert.False(t, filters.Matches(candidateWith(intPtr(186), stringPtr(entities.LifestyleHabitNever))))
+
+	// Without filters everyone matches, including users who answered nothing
+	assert.True(t, (&AttributeFilters{}).Matches(candidateWith(nil, nil)))
+}
+
+func TestAttributeFilters_Validate(t *testing.T) {
+	cfg := &config.MatchingFiltersConfig{PremiumLifestyle: true}
+	freeUser := &entities.User{ID: uuid.New()}
+	premiumUser := &entities.User{ID: uuid.New(), IsPremium: true}
+
+	invalid := []AttributeFilters{
+		{MinHeightCm: intPtr(90)},
+		{MaxHeightCm: intPtr(260)},
+		{MinHeightCm: intPtr(180), MaxHeightCm: intPtr(170)},
+		{Smoking: []string{"chain"}},
+		{Drinking: []string{entities.LifestyleHabitNever, "daily"}},
+	}
+	for _, filters := range invalid {
+		err := filters.Validate(premiumUser, cfg)
+		require.Error(t, err, "%+v", filters)
+		assert.Equal(t, http.StatusBadRequest, err.(*errors.AppError).StatusCode())
+	}
+
+	// Height filters are free, lifestyle filters need a premium plan
+	heightOnly := AttributeFilters{MinHeightCm: intPtr(160), MaxHeightCm: intPtr(190)}
+	assert.NoError(t, heightOnly.Validate(freeUser, cfg))
+
+	lifestyle := AttributeFilters{Drinking: []string{entities.LifestyleHabitNever}}
+	err := lifestyle.Validate(freeUser, cfg)
+	require.Error(t, err)
+	assert.Equal(t, http.StatusPaymentRequired, err.(*errors.AppError).StatusCode())
+	assert.NoError(t, lifestyle.Validate(premiumUser, cfg))
+	assert.NoError(t, lifestyle.Validate(freeUser, &config.MatchingFiltersConfig{PremiumLifestyle: false}))
+}
+
+func userIDs(users []*entities.User) []uuid.UUID {
+	ids := make([]uuid.UUID, len(users))
+	for i, user := range users {
+		ids[i] = user.ID
+	}
+	return ids
+}
@@ -0,0 +1,217 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/22smeargle/winkr-backend/internal/application/dto"
+	"github.com/22smeargle/winkr-backend/pkg/config"
+	"github.com/22smeargle/winkr-backend/pkg/logger"
+)
+
+// DiscoveryThrottleStore defines the storage needed to throttle discovery requests
+type DiscoveryThrottleStore interface {
+	IncrementThrottleCounter(ctx context.Context, key string, ttl time.Duration) (int64, error)
+	SetThrottleBlock(ctx context.Context, key string, until time.Time, ttl time.Duration) error
+	GetThrottleBlock(ctx context.Context, key string) (time.Time, error)
+}
+
+// degradedDistanceStep is the granularity distances are shown at in degraded results
+const degradedDistanceStep = 5.0
+
+// DiscoveryThrottleDecision is the outcome of checking a discovery request
+type DiscoveryThrottleDecision struct {
+	Allowed    bool
+	RetryAfter time.Duration // Set when the request is not allowed
+	Degraded   bool          // Results should be served with reduced detail
+}
+
+// DiscoveryThrottleService detects clients requesting discovery far more often than a person
+// swiping would, and slows them down. Users and IPs over their limits are blocked with a backoff
+// that doubles on every repeat, and heavy users below the limits get results with less detail.
+type DiscoveryThrottleService struct {
+	store  DiscoveryThrottleStore
+	config *config.RateLimitConfig
+	now    func() time.Time
+}
+
+// NewDiscoveryThrottleService creates a new DiscoveryThrottleService
+func NewDiscoveryThrottleService(store DiscoveryThrottleStore, cfg *config.RateLimitConfig) *DiscoveryThrottleService {
+	return &DiscoveryThrottleService{
+		store:  store,
+		config: cfg,
+		now:    time.Now,
+	}
+}
+
+// throttleSubject is a user or IP that discovery requests are counted against
+type throttleSubject struct {
+	kind string
+	id   string
+}
+
+// Check counts a discovery request and decides whether it may be served. Failures are logged
+// and the request is allowed so discovery keeps working when Redis is unavailable.
+func (s *DiscoveryThrottleService) Check(ctx context.Context, userID uuid.UUID, ip string) *DiscoveryThrottleDecision {
+	throttle := s.config.DiscoveryThrottle
+	if !throttle.Enabled {
+		return &DiscoveryThrottleDecision{Allowed: true}
+	}
+
+	now := s.now()
+	user := throttleSubject{kind: "user", id: userID.String()}
+	subjects := []throttleSubject{user}
+	if ip != "" {
+		subjects = append(subjects, throttleSubject{kind: "ip", id: ip})
+	}
+
+	// Blocked requests are not counted, so waiting out a block is enough to recover
+	for _, subject := range subjects {
+		until, err := s.store.GetThrottleBlock(ctx, throttleBlockKey(subject))
+		if err != nil {
+			logger.Warn("Failed to read discovery throttle block", err, "subject", subject.kind, "user_id", userID)
+			continue
+		}
+		if until.After(now) {
+			return &DiscoveryThrottleDecision{RetryAfter: until.Sub(now)}
+		}
+	}
+
+	hour := now.UTC().Format("2006010215")
+	day := now.UTC().Format("20060102")
+
+	hourly, err := s.store.IncrementThrottleCounter(ctx, throttleCountKey(user, hour), time.Hour)
+	if err != nil {
+		logger.Warn("Failed to count discovery request", err, "user_id", userID)
+		return &DiscoveryThrottleDecision{Allowed: true}
+	}
+	if s.config.DiscoveryPerHour > 0 && hourly > int64(s.config.DiscoveryPerHour) {
+		return s.block(ctx, user, userID, ip, hourly, s.config.DiscoveryPerHour, "hour")
+	}
+
+	daily, err := s.store.IncrementThrottleCounter(ctx, throttleCountKey(user, day), 24*time.Hour)
+	if err != nil {
+		logger.Warn("Failed to count discovery request", err, "user_id", userID)
+		return &DiscoveryThrottleDecision{Allowed: true}
+	}
+	if s.config.DiscoveryPerDay > 0 && daily > int64(s.config.DiscoveryPerDay) {
+		return s.block(ctx, user, userID, ip, daily, s.config.DiscoveryPerDay, "day")
+	}
+
+	if ip != "" {
+		address := throttleSubject{kind: "ip", id: ip}
+		perIP, err := s.store.IncrementThrottleCounter(ctx, throttleCountKey(address, hour), time.Hour)
+		if err != nil {
+			logger.Warn("Failed to count discovery request", err, "user_id", userID)
+		} else if throttle.IPPerHour > 0 && perIP > int64(throttle.IPPerHour) {
+			return s.block(ctx, address, userID, ip, perIP, throttle.IPPerHour, "hour")
+		}
+	}
+
+	return &DiscoveryThrottleDecision{
+		Allowed:  true,
+		Degraded: throttle.DegradeAfter > 0 && hourly > int64(throttle.DegradeAfter),
+	}
+}
+
+// block records a strike against a subject and blocks it for a backoff that doubles with every
+// strike inside the strike window
+func (s *DiscoveryThrottleService) block(ctx context.Context, subject throttleSubject, userID uuid.UUID, ip string, requests int64, limit int, period string) *DiscoveryThrottleDecision {
+	throttle := s.config.DiscoveryThrottle
+
+	strikes, err := s.store.IncrementThrottleCounter(ctx, throttleStrikeKey(subject), throttle.StrikeWindow)
+	if err != nil {
+		logger.Warn("Failed to record discovery throttle strike", err, "subject", subject.kind, "user_id", userID)
+		strikes = 1
+	}
+
+	backoff := throttle.BaseBackoff
+	for i := int64(1); i < strikes && backoff < throttle.MaxBackoff; i++ {
+		backoff *= 2
+	}
+	if throttle.MaxBackoff > 0 && backoff > throttle.MaxBackoff {
+		backoff = throttle.MaxBackoff
+	}
+
+	if backoff > 0 {
+		if err := s.store.SetThrottleBlock(ctx, throttleBlockKey(subject), s.now().Add(backoff), backoff); err != nil {
+			logger.Warn("Failed to store discovery throttle block", err, "subject", subject.kind, "user_id", userID)
+		}
+	}
+
+	logger.Warn("Suspected discovery scraping",
+		"subject", subject.kind,
+		"user_id", userID,
+		"ip", ip,
+		"requests", requests,
+		"limit", limit,
+		"period", period,
+		"strikes", strikes,
+		"backoff", backoff,
+	)
+
+	return &DiscoveryThrottleDecision{RetryAfter: backoff}
+}
+
+// Degrade reduces discovery results for a client that requests them unusually often. Only the
+// configured number of profiles is kept, each with its primary photo, no bio, location or last
+// active time, and the distance rounded up to the next 5 km.
+func (s *DiscoveryThrottleService) Degrade(users []*dto.DiscoveryUser) []*dto.DiscoveryUser {
+	limit := s.config.DiscoveryThrottle.DegradedLimit
+	if limit > 0 && len(users) > limit {
+		users = users[:limit]
+	}
+
+	reduced := make([]*dto.DiscoveryUser, 0, len(users))
+	for _, user := range users {
+		var distanceKm *float64
+		if user.DistanceKm != nil {
+			rounded := math.Ceil(*user.DistanceKm/degradedDistanceStep) * degradedDistanceStep
+			distanceKm = &rounded
+		}
+
+		reduced = append(reduced, &dto.DiscoveryUser{
+			ID:                user.ID,
+			FirstName:         user.FirstName,
+			Age:               user.Age,
+			DistanceKm:        distanceKm,
+			IsVerified:        user.IsVerified,
+			VerificationLevel: user.VerificationLevel,
+			IsPremium:         user.IsPremium,
+			Photos:            primaryPhoto(user.Photos),
+			CreatedAt:         user.CreatedAt,
+		})
+	}
+	return reduced
+}
+
+// primaryPhoto returns just the primary photo, or the first one when none is marked primary
+func primaryPhoto(photos []*dto.Photo) []*dto.Photo {
+	if len(photos) == 0 {
+		return photos
+	}
+	for _, photo := range photos {
+		if photo.IsPrimary {
+			return []*dto.Photo{photo}
+		}
+	}
+	return photos[:1]
+}
+
+// throttleCountKey returns the cache key of a subject's request count for a time bucket
+func throttleCountKey(subject throttleSubject, bucket string) string {
+	return fmt.Sprintf("discovery_throttle:%s:%s:%s", subject.kind, subject.id, bucket)
+}
+
+// throttleStrikeKey returns the cache key of a subject's strike count
+func throttleStrikeKey(subject throttleSubject) string {
+	return fmt.Sprintf("discovery_throttle:%s:%s:strikes", subject.kind, subject.id)
+}
+
+// throttleBlockKey returns the cache key of a subject's block
+func throttleBlockKey(subject throttleSubject) string {
+	return fmt.Sprintf("discovery_throttle:%s:%s:blocked", subject.kind, subject.id)
+}
@@ -0,0 +1,202 @@
+package services
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/22smeargle/winkr-backend/internal/application/dto"
+	"github.com/22smeargle/winkr-backend/pkg/config"
+)
+
+// inMemoryThrottleStore is an in-memory DiscoveryThrottleStore for tests
+type inMemoryThrottleStore struct {
+	counts map[string]int64
+	blocks map[string]time.Time
+}
+
+func newInMemoryThrottleStore() *inMemoryThrottleStore {
+	return &inMemoryThrottleStore{
+		counts: make(map[string]int64),
+		blocks: make(map[string]time.Time),
+	}
+}
+
+func (s *inMemoryThrottleStore) IncrementThrottleCounter(ctx context.Context, key string, ttl time.Duration) (int64, error) {
+	s.counts[key]++
+	return s.counts[key], nil
+}
+
+func (s *inMemoryThrottleStore) SetThrottleBlock(ctx context.Context, key string, until time.Time, ttl time.Duration) error {
+	s.blocks[key] = until
+	return nil
+}
+
+func (s *inMemoryThrottleStore) GetThrottleBlock(ctx context.Context, key string) (time.Time, error) {
+	return s.blocks[key], nil
+}
+
+func newTestThrottleService(store DiscoveryThrottleStore, now *time.Time) *DiscoveryThrottleService {
+	service := NewDiscoveryThrottleService(store, &config.RateLimitConfig{
+		DiscoveryPerHour: 10,
+		DiscoveryPerDay:  100,
+		DiscoveryThrottle: config.DiscoveryThrottleConfig{
+			Enabled:       true,
+			IPPerHour:     20,
+			DegradeAfter:  5,
+			DegradedLimit: 2,
+			BaseBackoff:   time.Minute,
+			MaxBackoff:    5 * time.Minute,
+			StrikeWindow:  24 * time.Hour,
+		},
+	})
+	service.now = func() time.Time { return *now }
+	return service
+}
+
+// checkTimes runs n checks and returns the last decision
+func checkTimes(t *testing.T, service *DiscoveryThrottleService, userID uuid.UUID, ip string, n int) *DiscoveryThrottleDecision {
+	t.Helper()
+	var decision *DiscoveryThrottleDecision
+	for i := 0; i < n; i++ {
+		decision = service.Check(context.Background(), userID, ip)
+	}
+	return decision
+}
+
+func TestDiscoveryThrottle_ExceedingHourlyRateBlocksWithEscalatingBackoff(t *testing.T) {
+	now := time.Date(2026, 10, 17, 12, 0, 0, 0, time.UTC)
+	service := newTestThrottleService(newInMemoryThrottleStore(), &now)
+	userID := uuid.New()
+
+	decision := checkTimes(t, service, userID, "203.0.113.7", 10)
+	assert.True(t, decision.Allowed)
+
+	decision = service.Check(context.Background(), userID, "203.0.113.7")
+	assert.False(t, decision.Allowed)
+	assert.Equal(t, time.Minute, decision.RetryAfter)
+
+	// Still blocked until the backoff has passed
+	now = now.Add(30 * time.Second)
+	decision = service.Check(context.Background(), userID, "203.0.113.7")
+	assert.False(t, decision.Allowed)
+	assert.Equal(t, 30*time.Second, decision.RetryAfter)
+
+	// Going on after the block doubles the backoff
+	now = now.Add(31 * time.Second)
+	decision = service.Check(context.Background(), userID, "203.0.113.7")
+	assert.False(t, decision.Allowed)
+	assert.Equal(t, 2*time.Minute, decision.RetryAfter)
+
+	now = now.Add(2 * time.Minute)
+	decision = service.Check(context.Background(), userID, "203.0.113.7")
+	assert.Equal(t, 4*time.Minute, decision.RetryAfter)
+
+	// Capped at the maximum backoff
+	now = now.Add(4 * time.Minute)
+	decision = service.Check(context.Background(), userID, "203.0.113.7")
+	assert.Equal(t, 5*time.Minute, decision.RetryAfter)
+
+	// Another user is not affected
+	decision = service.Check(context.Background(), uuid.New(), "198.51.100.1")
+	assert.True(t, decision.Allowed)
+}
+
+func TestDiscoveryThrottle_ExceedingIPRateBlocksTheIP(t *testing.T) {
+	now := time.Date(2026, 10, 17, 12, 0, 0, 0, time.UTC)
+	service := newTestThrottleService(newInMemoryThrottleStore(), &now)
+
+	// Many accounts behind one IP, each under its own limit
+	for i := 0; i < 4; i++ {
+		decision := checkTimes(t, service, uuid.New(), "203.0.113.7", 5)
+		require.True(t, decision.Allowed)
+	}
+
+	decision := service.Check(context.Background(), uuid.New(), "203.0.113.7")
+	assert.False(t, decision.Allowed)
+	assert.Equal(t, time.Minute, decision.RetryAfter)
+
+	decision = service.Check(context.Background(), uuid.New(), "203.0.113.7")
+	assert.False(t, decision.Allowed)
+
+	decision = service.Check(context.Background(), uuid.New(), "198.51.100.1")
+	assert.True(t, decision.Allowed)
+}
+
+func TestDiscoveryThrottle_DegradesAfterThreshold(t *testing.T) {
+	now := time.Date(2026, 10, 17, 12, 0, 0, 0, time.UTC)
+	service := newTestThrottleService(newInMemoryThrottleStore(), &now)
+	userID := uuid.New()
+
+	decision := checkTimes(t, service, userID, "203.0.113.7", 5)
+	assert.True(t, decision.Allowed)
+	assert.False(t, decision.Degraded)
+
+	decision = service.Check(context.Background(), userID, "203.0.113.7")
+	assert.True(t, decision.Allowed)
+	assert.True(t, decision.Degraded)
+
+	// The count starts over in the next hour
+	now = now.Add(time.Hour)
+	decision = service.Check(context.Background(), userID, "203.0.113.7")
+	assert.False(t, decision.Degraded)
+}
+
+func TestDiscoveryThrottle_DegradeReducesDetail(t *testing.T) {
+	now := time.Date(2026, 10, 17, 12, 0, 0, 0, time.UTC)
+	service := newTestThrottleService(newInMemoryThrottleStore(), &now)
+
+	bio := "Hiking and coffee"
+	lastActive := now.Add(-time.Minute)
+	primary := &dto.Photo{ID: uuid.New(), URL: "https://cdn.example.com/2.jpg", IsPrimary: true}
+	near, far, farther := 3.2, 11.7, 20.0
+	users := []*dto.DiscoveryUser{
+		{
+			ID:         uuid.New(),
+			FirstName:  "Alex",
+			Age:        29,
+			Bio:        &bio,
+			Location:   &dto.Location{Lat: 52.52, Lng: 13.405},
+			DistanceKm: &near,
+			IsVerified: true,
+			Photos: []*dto.Photo{
+				{ID: uuid.New(), URL: "https://cdn.example.com/1.jpg"},
+				primary,
+			},
+			LastActive: &lastActive,
+		},
+		{ID: uuid.New(), FirstName: "Sam", DistanceKm: &far},
+		{ID: uuid.New(), FirstName: "Kim", DistanceKm: &farther},
+	}
+
+	reduced := service.Degrade(users)
+
+	require.Len(t, reduced, 2)
+	assert.Equal(t, users[0].ID, reduced[0].ID)
+	assert.Equal(t, "Alex", reduced[0].FirstName)
+	assert.Equal(t, 29, reduced[0].Age)
+	assert.True(t, reduced[0].IsVerified)
+	assert.Nil(t, reduced[0].Bio)
+	assert.Nil(t, reduced[0].Location)
+	assert.Nil(t, reduced[0].LastActive)
+	assert.Equal(t, []*dto.Photo{primary}, reduced[0].Photos)
+	assert.Equal(t, 5.0, *reduced[0].DistanceKm)
+	assert.Equal(t, 15.0, *reduced[1].DistanceKm)
+
+	// The original results are left untouched
+	assert.NotNil(t, users[0].Bio)
+	assert.Len(t, users[0].Photos, 2)
+}
+
+func TestDiscoveryThrottle_DisabledAllowsEverything(t *testing.T) {
+	now := time.Date(2026, 10, 17, 12, 0, 0, 0, time.UTC)
+	service := newTestThrottleService(newInMemoryThrottleStore(), &now)
+	service.config.DiscoveryThrottle.Enabled = false
+
+	decision := checkTimes(t, service, uuid.New(), "203.0.113.7", 50)
+	assert.True(t, decision.Allowed)
+	assert.False(t, decision.Degraded)
+}
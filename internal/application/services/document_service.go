@@ -12,21 +12,51 @@ import (
 	"github.com/google/uuid"
 	"github.com/22smeargle/winkr-backend/internal/domain/entities"
 	"github.com/22smeargle/winkr-backend/internal/infrastructure/external"
+	"github.com/22smeargle/winkr-backend/pkg/config"
 	"github.com/22smeargle/winkr-backend/pkg/logger"
 )
 
 // DocumentService handles document processing and OCR
 type DocumentService struct {
 	aiService external.AIService
+	config    *config.DocumentProcessingConfig
 }
 
 // NewDocumentService creates a new document service
-func NewDocumentService(aiService external.AIService) *DocumentService {
+func NewDocumentService(aiService external.AIService, cfg *config.DocumentProcessingConfig) *DocumentService {
 	return &DocumentService{
 		aiService: aiService,
+		config:    cfg,
 	}
 }
 
+// IsSupportedDocumentType returns true if the document type is accepted for verification
+func (ds *DocumentService) IsSupportedDocumentType(documentType string) bool {
+	if ds.config == nil || len(ds.config.SupportedDocumentTypes) == 0 {
+		return true
+	}
+
+	for _, supported := range ds.config.SupportedDocumentTypes {
+		if supported == documentType {
+			return true
+		}
+	}
+	return false
+}
+
+// RequiredDocumentTypes returns the document types a verification session must pass for a region.
+// An empty result means every submitted document must pass.
+func (ds *DocumentService) RequiredDocumentTypes(region string) []string {
+	if ds.config == nil {
+		return nil
+	}
+
+	if required, exists := ds.config.RequiredDocumentsByRegion[strings.ToUpper(strings.TrimSpace(region))]; exists {
+		return required
+	}
+	return ds.config.DefaultRequiredDocuments
+}
+
 // ProcessDocument processes a document image and extracts information
 func (ds *DocumentService) ProcessDocument(ctx context.Context, imageKey string) (*DocumentProcessingResult, error) {
 	logger.Info("Processing document", "image_key", imageKey)
@@ -71,6 +101,8 @@ func (ds *DocumentService) ExtractDocumentData(documentType string, text string)
 		return ds.extractIDCardData(text), nil
 	case "driver_license":
 		return ds.extractDriverLicenseData(text), nil
+	case "proof_of_address":
+		return ds.extractProofOfAddressData(text), nil
 	default:
 		return map[string]interface{}{}, fmt.Errorf("unsupported document type: %s", documentType)
 	}
@@ -283,6 +315,27 @@ func (ds *DocumentService) extractDriverLicenseData(text string) map[string]inte
 	return data
 }
 
+func (ds *DocumentService) extractProofOfAddressData(text string) map[string]interface{} {
+	data := make(map[string]interface{})
+	
+	// Extract full name
+	if name := ds.extractFullName(text); name != "" {
+		data["full_name"] = name
+	}
+	
+	// Extract address
+	if address := ds.extractAddress(text); address != "" {
+		data["address"] = address
+	}
+	
+	// Extract issue date
+	if issueDate := ds.extractIssueDate(text); issueDate != "" {
+		data["issue_date"] = issueDate
+	}
+	
+	return data
+}
+
 // Validation methods
 
 func (ds *DocumentService) validateDocumentFields(documentType string, fields map[string]interface{}) []string {
@@ -295,6 +348,8 @@ func (ds *DocumentService) validateDocumentFields(documentType string, fields ma
 		errors = ds.validateIDCardFields(fields)
 	case "driver_license":
 		errors = ds.validateDriverLicenseFields(fields)
+	case "proof_of_address":
+		errors = ds.validateProofOfAddressFields(fields)
 	default:
 		errors = append(errors, "unknown document type")
 	}
@@ -358,6 +413,20 @@ func (ds *DocumentService) validateIDCardFields(fields map[string]interface{}) [
 	return errors
 }
 
+func (ds *DocumentService) validateProofOfAddressFields(fields map[string]interface{}) []string {
+	var errors []string
+	
+	// Check required fields
+	requiredFields := []string{"full_name", "address", "issue_date"}
+	for _, field := range requiredFields {
+		if _, exists := fields[field]; !exists {
+			errors = append(errors, fmt.Sprintf("missing required field: %s", field))
+		}
+	}
+	
+	return errors
+}
+
 func (ds *DocumentService) validateDriverLicenseFields(fields map[string]interface{}) []string {
 	var errors []string
 	
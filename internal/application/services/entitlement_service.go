@@ -0,0 +1,168 @@
+package services
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/22smeargle/winkr-backend/pkg/config"
+	"github.com/22smeargle/winkr-backend/pkg/logger"
+)
+
+// EntitlementBalances counts the super likes, rewinds and boosts of a user
+type EntitlementBalances struct {
+	SuperLikes int `json:"super_likes"`
+	Rewinds    int `json:"rewinds"`
+	Boosts     int `json:"boosts"`
+}
+
+// Entitlements is what a user has left in the current billing period, and what was granted for it
+type Entitlements struct {
+	Balances  EntitlementBalances `json:"balances"`
+	Granted   EntitlementBalances `json:"granted"`    // Granted since the last renewal, including upgrades
+	PlanType  string              `json:"plan_type"`  // Plan of the last grant
+	InvoiceID string              `json:"invoice_id"` // Stripe invoice of the last renewal
+}
+
+// EntitlementStore defines the storage of entitlement balances
+type EntitlementStore interface {
+	// GetEntitlements returns nil when nothing was ever granted to the user
+	GetEntitlements(ctx context.Context, userID uuid.UUID) (*Entitlements, error)
+	// ResetEntitlements replaces everything stored for the user
+	ResetEntitlements(ctx context.Context, userID uuid.UUID, entitlements *Entitlements) error
+	// AddEntitlements adds to the user's balances and records the new grant and plan
+	AddEntitlements(ctx context.Context, userID uuid.UUID, delta, granted EntitlementBalances, planType string) error
+}
+
+// EntitlementService grants the super likes, rewinds and boosts that come with a subscription plan.
+// Every paid renewal resets the balances to the plan's allotment, and an upgrade in the middle of
+// a billing period adds the difference to the higher plan straight away.
+type EntitlementService struct {
+	store  EntitlementStore
+	config *config.EntitlementsConfig
+}
+
+// NewEntitlementService creates a new EntitlementService
+func NewEntitlementService(store EntitlementStore, cfg *config.EntitlementsConfig) *EntitlementService {
+	return &EntitlementService{
+		store:  store,
+		config: cfg,
+	}
+}
+
+// PlanGrant returns what a plan grants every billing period. Unknown plans grant nothing.
+func (s *EntitlementService) PlanGrant(planType string) EntitlementBalances {
+	grant, ok := s.config.Plans[planType]
+	if !ok {
+		return EntitlementBalances{}
+	}
+	return EntitlementBalances{
+		SuperLikes: grant.SuperLikes,
+		Rewinds:    grant.Rewinds,
+		Boosts:     grant.Boosts,
+	}
+}
+
+// GrantRenewal resets a user's balances to the plan's allotment for a paid billing period.
+// Unused allotments do not carry over. An invoice that was already granted is skipped, so
+// redelivered webhooks grant nothing.
+func (s *EntitlementService) GrantRenewal(ctx context.Context, userID uuid.UUID, planType, invoiceID string) (*Entitlements, error) {
+	current, err := s.store.GetEntitlements(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get entitlements: %w", err)
+	}
+	if current != nil && invoiceID != "" && current.InvoiceID == invoiceID {
+		return current, nil
+	}
+
+	grant := s.PlanGrant(planType)
+	entitlements := &Entitlements{
+		Balances:  grant,
+		Granted:   grant,
+		PlanType:  planType,
+		InvoiceID: invoiceID,
+	}
+	if err := s.store.ResetEntitlements(ctx, userID, entitlements); err != nil {
+		return nil, fmt.Errorf("failed to reset entitlements: %w", err)
+	}
+
+	logger.Info("Entitlements granted for renewal",
+		"user_id", userID,
+		"plan_type", planType,
+		"invoice_id", invoiceID,
+		"super_likes", grant.SuperLikes,
+		"rewinds", grant.Rewinds,
+		"boosts", grant.Boosts,
+	)
+
+	return entitlements, nil
+}
+
+// GrantUpgrade grants the difference between what the new plan allots and what was already
+// granted this billing period. Only what the period has not been granted yet is added, so a
+// downgrade grants nothing and upgrading again after one grants nothing twice.
+func (s *EntitlementService) GrantUpgrade(ctx context.Context, userID uuid.UUID, planType string) (*Entitlements, error) {
+	current, err := s.store.GetEntitlements(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get entitlements: %w", err)
+	}
+	if current == nil {
+		current = &Entitlements{}
+	}
+
+	grant := s.PlanGrant(planType)
+	delta := EntitlementBalances{
+		SuperLikes: positiveDifference(grant.SuperLikes, current.Granted.SuperLikes),
+		Rewinds:    positiveDifference(grant.Rewinds, current.Granted.Rewinds),
+		Boosts:     positiveDifference(grant.Boosts, current.Granted.Boosts),
+	}
+	granted := EntitlementBalances{
+		SuperLikes: current.Granted.SuperLikes + delta.SuperLikes,
+		Rewinds:    current.Granted.Rewinds + delta.Rewinds,
+		Boosts:     current.Granted.Boosts + delta.Boosts,
+	}
+
+	if err := s.store.AddEntitlements(ctx, userID, delta, granted, planType); err != nil {
+		return nil, fmt.Errorf("failed to add entitlements: %w", err)
+	}
+
+	logger.Info("Entitlements granted for plan change",
+		"user_id", userID,
+		"old_plan_type", current.PlanType,
+		"plan_type", planType,
+		"super_likes", delta.SuperLikes,
+		"rewinds", delta.Rewinds,
+		"boosts", delta.Boosts,
+	)
+
+	return &Entitlements{
+		Balances: EntitlementBalances{
+			SuperLikes: current.Balances.SuperLikes + delta.SuperLikes,
+			Rewinds:    current.Balances.Rewinds + delta.Rewinds,
+			Boosts:     current.Balances.Boosts + delta.Boosts,
+		},
+		Granted:   granted,
+		PlanType:  planType,
+		InvoiceID: current.InvoiceID,
+	}, nil
+}
+
+// GetBalances returns what a user has left in the current billing period
+func (s *EntitlementService) GetBalances(ctx context.Context, userID uuid.UUID) (*EntitlementBalances, error) {
+	entitlements, err := s.store.GetEntitlements(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get entitlements: %w", err)
+	}
+	if entitlements == nil {
+		return &EntitlementBalances{}, nil
+	}
+	return &entitlements.Balances, nil
+}
+
+// positiveDifference returns how much more a is than b, or zero
+func positiveDifference(a, b int) int {
+	if a > b {
+		return a - b
+	}
+	return 0
+}
@@ -0,0 +1,157 @@
+package services
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/22smeargle/winkr-backend/pkg/config"
+)
+
+// inMemoryEntitlementStore is an in-memory EntitlementStore for tests
+type inMemoryEntitlementStore struct {
+	entitlements map[uuid.UUID]*Entitlements
+}
+
+func newInMemoryEntitlementStore() *inMemoryEntitlementStore {
+	return &inMemoryEntitlementStore{entitlements: make(map[uuid.UUID]*Entitlements)}
+}
+
+func (s *inMemoryEntitlementStore) GetEntitlements(ctx context.Context, userID uuid.UUID) (*Entitlements, error) {
+	entitlements, ok := s.entitlements[userID]
+	if !ok {
+		return nil, nil
+	}
+	copied := *entitlements
+	return &copied, nil
+}
+
+func (s *inMemoryEntitlementStore) ResetEntitlements(ctx context.Context, userID uuid.UUID, entitlements *Entitlements) error {
+	copied := *entitlements
+	s.entitlements[userID] = &copied
+	return nil
+}
+
+func (s *inMemoryEntitlementStore) AddEntitlements(ctx context.Context, userID uuid.UUID, delta, granted EntitlementBalances, planType string) error {
+	entitlements, ok := s.entitlements[userID]
+	if !ok {
+		entitlements = &Entitlements{}
+		s.entitlements[userID] = entitlements
+	}
+	entitlements.Balances.SuperLikes += delta.SuperLikes
+	entitlements.Balances.Rewinds += delta.Rewinds
+	entitlements.Balances.Boosts += delta.Boosts
+	entitlements.Granted = granted
+	entitlements.PlanType = planType
+	return nil
+}
+
+// spend uses up some of a user's balances, as swiping would
+func (s *inMemoryEntitlementStore) spend(userID uuid.UUID, superLikes, rewinds int) {
+	s.entitlements[userID].Balances.SuperLikes -= superLikes
+	s.entitlements[userID].Balances.Rewinds -= rewinds
+}
+
+func newTestEntitlementService(store EntitlementStore) *EntitlementService {
+	return NewEntitlementService(store, &config.EntitlementsConfig{
+		Plans: map[string]config.PlanGrantConfig{
+			"basic":    {},
+			"premium":  {SuperLikes: 150, Rewinds: 30, Boosts: 1},
+			"platinum": {SuperLikes: 500, Rewinds: 100, Boosts: 4},
+		},
+	})
+}
+
+func TestEntitlements_RenewalResetsQuotaToPlanGrant(t *testing.T) {
+	store := newInMemoryEntitlementStore()
+	service := newTestEntitlementService(store)
+	userID := uuid.New()
+
+	_, err := service.GrantRenewal(context.Background(), userID, "premium", "in_1")
+	require.NoError(t, err)
+
+	// Unused super likes do not carry over into the next period
+	store.spend(userID, 40, 5)
+
+	_, err = service.GrantRenewal(context.Background(), userID, "premium", "in_2")
+	require.NoError(t, err)
+
+	balances, err := service.GetBalances(context.Background(), userID)
+	require.NoError(t, err)
+	assert.Equal(t, EntitlementBalances{SuperLikes: 150, Rewinds: 30, Boosts: 1}, *balances)
+}
+
+func TestEntitlements_RedeliveredInvoiceGrantsNothing(t *testing.T) {
+	store := newInMemoryEntitlementStore()
+	service := newTestEntitlementService(store)
+	userID := uuid.New()
+
+	_, err := service.GrantRenewal(context.Background(), userID, "premium", "in_1")
+	require.NoError(t, err)
+	store.spend(userID, 10, 0)
+
+	_, err = service.GrantRenewal(context.Background(), userID, "premium", "in_1")
+	require.NoError(t, err)
+
+	balances, err := service.GetBalances(context.Background(), userID)
+	require.NoError(t, err)
+	assert.Equal(t, 140, balances.SuperLikes)
+}
+
+func TestEntitlements_UpgradeGrantsDeltaImmediately(t *testing.T) {
+	store := newInMemoryEntitlementStore()
+	service := newTestEntitlementService(store)
+	userID := uuid.New()
+
+	_, err := service.GrantRenewal(context.Background(), userID, "premium", "in_1")
+	require.NoError(t, err)
+	store.spend(userID, 100, 10)
+
+	entitlements, err := service.GrantUpgrade(context.Background(), userID, "platinum")
+	require.NoError(t, err)
+
+	// What was spent stays spent, the difference between the plans is added on top
+	expected := EntitlementBalances{SuperLikes: 50 + 350, Rewinds: 20 + 70, Boosts: 1 + 3}
+	assert.Equal(t, expected, entitlements.Balances)
+	assert.Equal(t, EntitlementBalances{SuperLikes: 500, Rewinds: 100, Boosts: 4}, entitlements.Granted)
+
+	balances, err := service.GetBalances(context.Background(), userID)
+	require.NoError(t, err)
+	assert.Equal(t, expected, *balances)
+}
+
+func TestEntitlements_DowngradeAndUpgradeAgainGrantsNothing(t *testing.T) {
+	store := newInMemoryEntitlementStore()
+	service := newTestEntitlementService(store)
+	userID := uuid.New()
+
+	_, err := service.GrantRenewal(context.Background(), userID, "platinum", "in_1")
+	require.NoError(t, err)
+
+	_, err = service.GrantUpgrade(context.Background(), userID, "premium")
+	require.NoError(t, err)
+	_, err = service.GrantUpgrade(context.Background(), userID, "platinum")
+	require.NoError(t, err)
+
+	balances, err := service.GetBalances(context.Background(), userID)
+	require.NoError(t, err)
+	assert.Equal(t, EntitlementBalances{SuperLikes: 500, Rewinds: 100, Boosts: 4}, *balances)
+
+	// The next renewal grants the plan the user is on now
+	_, err = service.GrantRenewal(context.Background(), userID, "premium", "in_2")
+	require.NoError(t, err)
+	balances, err = service.GetBalances(context.Background(), userID)
+	require.NoError(t, err)
+	assert.Equal(t, EntitlementBalances{SuperLikes: 150, Rewinds: 30, Boosts: 1}, *balances)
+}
+
+func TestEntitlements_UnknownPlanGrantsNothing(t *testing.T) {
+	service := newTestEntitlementService(newInMemoryEntitlementStore())
+	userID := uuid.New()
+
+	entitlements, err := service.GrantRenewal(context.Background(), userID, "legacy", "in_1")
+	require.NoError(t, err)
+	assert.Equal(t, EntitlementBalances{}, entitlements.Balances)
+}
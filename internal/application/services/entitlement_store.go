@@ -0,0 +1,116 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+
+	goredis "github.com/go-redis/redis/v8"
+	"github.com/google/uuid"
+	"github.com/22smeargle/winkr-backend/internal/infrastructure/database/redis"
+)
+
+// Fields of the entitlements hash
+const (
+	entitlementSuperLikesField        = "super_likes"
+	entitlementRewindsField           = "rewinds"
+	entitlementBoostsField            = "boosts"
+	entitlementGrantedSuperLikesField = "granted_super_likes"
+	entitlementGrantedRewindsField    = "granted_rewinds"
+	entitlementGrantedBoostsField     = "granted_boosts"
+	entitlementPlanTypeField          = "plan_type"
+	entitlementInvoiceIDField         = "invoice_id"
+)
+
+// RedisEntitlementStore stores entitlements in one Redis hash per user. Balances are changed with
+// HINCRBY so spending and granting never overwrite each other.
+type RedisEntitlementStore struct {
+	redisClient *redis.RedisClient
+	prefix      string
+}
+
+// NewRedisEntitlementStore creates a new RedisEntitlementStore
+func NewRedisEntitlementStore(redisClient *redis.RedisClient) *RedisEntitlementStore {
+	return &RedisEntitlementStore{
+		redisClient: redisClient,
+		prefix:      "entitlements:",
+	}
+}
+
+// GetEntitlements gets a user's entitlements
+func (s *RedisEntitlementStore) GetEntitlements(ctx context.Context, userID uuid.UUID) (*Entitlements, error) {
+	fields, err := s.redisClient.HGetAll(ctx, s.key(userID))
+	if err != nil {
+		return nil, err
+	}
+	if len(fields) == 0 {
+		return nil, nil
+	}
+
+	return &Entitlements{
+		Balances: EntitlementBalances{
+			SuperLikes: entitlementField(fields, entitlementSuperLikesField),
+			Rewinds:    entitlementField(fields, entitlementRewindsField),
+			Boosts:     entitlementField(fields, entitlementBoostsField),
+		},
+		Granted: EntitlementBalances{
+			SuperLikes: entitlementField(fields, entitlementGrantedSuperLikesField),
+			Rewinds:    entitlementField(fields, entitlementGrantedRewindsField),
+			Boosts:     entitlementField(fields, entitlementGrantedBoostsField),
+		},
+		PlanType:  fields[entitlementPlanTypeField],
+		InvoiceID: fields[entitlementInvoiceIDField],
+	}, nil
+}
+
+// ResetEntitlements replaces a user's entitlements in one transaction
+func (s *RedisEntitlementStore) ResetEntitlements(ctx context.Context, userID uuid.UUID, entitlements *Entitlements) error {
+	key := s.key(userID)
+	_, err := s.redisClient.GetClient().TxPipelined(ctx, func(pipe goredis.Pipeliner) error {
+		pipe.Del(ctx, key)
+		pipe.HSet(ctx, key, map[string]interface{}{
+			entitlementSuperLikesField:        entitlements.Balances.SuperLikes,
+			entitlementRewindsField:           entitlements.Balances.Rewinds,
+			entitlementBoostsField:            entitlements.Balances.Boosts,
+			entitlementGrantedSuperLikesField: entitlements.Granted.SuperLikes,
+			entitlementGrantedRewindsField:    entitlements.Granted.Rewinds,
+			entitlementGrantedBoostsField:     entitlements.Granted.Boosts,
+			entitlementPlanTypeField:          entitlements.PlanType,
+			entitlementInvoiceIDField:         entitlements.InvoiceID,
+		})
+		return nil
+	})
+	return err
+}
+
+// AddEntitlements adds to a user's balances and records the new grant in one transaction
+func (s *RedisEntitlementStore) AddEntitlements(ctx context.Context, userID uuid.UUID, delta, granted EntitlementBalances, planType string) error {
+	key := s.key(userID)
+	_, err := s.redisClient.GetClient().TxPipelined(ctx, func(pipe goredis.Pipeliner) error {
+		pipe.HIncrBy(ctx, key, entitlementSuperLikesField, int64(delta.SuperLikes))
+		pipe.HIncrBy(ctx, key, entitlementRewindsField, int64(delta.Rewinds))
+		pipe.HIncrBy(ctx, key, entitlementBoostsField, int64(delta.Boosts))
+		pipe.HSet(ctx, key, map[string]interface{}{
+			entitlementGrantedSuperLikesField: granted.SuperLikes,
+			entitlementGrantedRewindsField:    granted.Rewinds,
+			entitlementGrantedBoostsField:     granted.Boosts,
+			entitlementPlanTypeField:          planType,
+		})
+		return nil
+	})
+	return err
+}
+
+// key returns the hash key of a user's entitlements
+func (s *RedisEntitlementStore) key(userID uuid.UUID) string {
+	return fmt.Sprintf("%s%s", s.prefix, userID.String())
+}
+
+// entitlementField parses a count from the entitlements hash, treating a missing field as zero
+func entitlementField(fields map[string]string, field string) int {
+	value, err := strconv.Atoi(fields[field])
+	if err != nil {
+		return 0
+	}
+	return value
+}
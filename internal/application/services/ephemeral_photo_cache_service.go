@@ -25,6 +25,10 @@ type EphemeralPhotoCacheService interface {
 	GetCachedPhotoStatus(ctx context.Context, photoID uuid.UUID) (string, error)
 	InvalidatePhotoStatus(ctx context.Context, photoID uuid.UUID) error
 	
+	// Watermark caching
+	CacheWatermarkedFileKey(ctx context.Context, photoID uuid.UUID, viewerKey string, fileKey string, ttl time.Duration) error
+	GetCachedWatermarkedFileKey(ctx context.Context, photoID uuid.UUID, viewerKey string) (string, error)
+	
 	// View tracking caching
 	CacheViewCount(ctx context.Context, photoID uuid.UUID, count int, ttl time.Duration) error
 	GetCachedViewCount(ctx context.Context, photoID uuid.UUID) (int, error)
@@ -223,6 +227,34 @@ func (s *EphemeralPhotoCacheServiceImpl) GetCachedPhotoStatus(ctx context.Contex
 	return status, nil
 }
 
+// CacheWatermarkedFileKey caches the storage key of a photo's watermarked variant for a viewer
+func (s *EphemeralPhotoCacheServiceImpl) CacheWatermarkedFileKey(ctx context.Context, photoID uuid.UUID, viewerKey string, fileKey string, ttl time.Duration) error {
+	key := s.getWatermarkCacheKey(photoID, viewerKey)
+	
+	if err := s.cacheService.Set(ctx, key, fileKey, ttl); err != nil {
+		logger.Error("Failed to cache watermarked file key", err)
+		return fmt.Errorf("failed to cache watermarked file key: %w", err)
+	}
+	
+	return nil
+}
+
+// GetCachedWatermarkedFileKey gets the storage key of a photo's watermarked variant for a viewer
+func (s *EphemeralPhotoCacheServiceImpl) GetCachedWatermarkedFileKey(ctx context.Context, photoID uuid.UUID, viewerKey string) (string, error) {
+	key := s.getWatermarkCacheKey(photoID, viewerKey)
+	
+	fileKey, err := s.cacheService.Get(ctx, key)
+	if err != nil {
+		return "", fmt.Errorf("failed to get cached watermarked file key: %w", err)
+	}
+	
+	if fileKey == "" {
+		return "", fmt.Errorf("watermarked file key not found in cache")
+	}
+	
+	return fileKey, nil
+}
+
 // InvalidatePhotoStatus invalidates cached photo status
 func (s *EphemeralPhotoCacheServiceImpl) InvalidatePhotoStatus(ctx context.Context, photoID uuid.UUID) error {
 	key := s.getPhotoStatusCacheKey(photoID)
@@ -425,6 +457,11 @@ func (s *EphemeralPhotoCacheServiceImpl) getPhotoStatusCacheKey(photoID uuid.UUI
 	return fmt.Sprintf("ephemeral_photo_status:%s", photoID.String())
 }
 
+// getWatermarkCacheKey generates cache key for a photo's watermarked variant
+func (s *EphemeralPhotoCacheServiceImpl) getWatermarkCacheKey(photoID uuid.UUID, viewerKey string) string {
+	return fmt.Sprintf("ephemeral_photo_watermark:%s:%s", photoID.String(), viewerKey)
+}
+
 // getViewCountCacheKey generates cache key for view count
 func (s *EphemeralPhotoCacheServiceImpl) getViewCountCacheKey(photoID uuid.UUID) string {
 	return fmt.Sprintf("ephemeral_photo_views:%s", photoID.String())
@@ -0,0 +1,233 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/22smeargle/winkr-backend/internal/domain/entities"
+	"github.com/22smeargle/winkr-backend/pkg/config"
+	"github.com/22smeargle/winkr-backend/pkg/logger"
+)
+
+// EphemeralPhotoCleanupJobName is the job label the cleanup job's metrics are published under
+const EphemeralPhotoCleanupJobName = "ephemeral_photo_cleanup"
+
+// ephemeralCleanupGracePeriod is how long a photo must have been untouched before it is cleaned up,
+// so a photo is never deleted while it is still being viewed
+const ephemeralCleanupGracePeriod = time.Hour
+
+// EphemeralPhotoCleanupRepository loads the photos due for cleanup and deletes their records
+type EphemeralPhotoCleanupRepository interface {
+	GetPhotosForCleanup(ctx context.Context, olderThan time.Time, limit int) ([]*entities.EphemeralPhoto, error)
+	BatchSoftDelete(ctx context.Context, photoIDs []uuid.UUID) error
+}
+
+// EphemeralPhotoFileRemover deletes a photo's files from storage
+type EphemeralPhotoFileRemover interface {
+	DeleteEphemeralPhoto(ctx context.Context, fileKey string, thumbnailKey string) error
+}
+
+// JobMetricsRecorder publishes the outcome of a background job run
+type JobMetricsRecorder interface {
+	RecordJobRun(job string, counts map[string]int, duration time.Duration)
+}
+
+// EphemeralPhotoCleanupJob deletes the files and records of expired and viewed ephemeral photos every
+// JobInterval. Each run is recorded for the admin stats endpoint and published as metrics. With
+// EnableJobRetry set, failed deletions are retried with exponential backoff, and photos that still
+// fail are dead-lettered so later runs skip them.
+type EphemeralPhotoCleanupJob struct {
+	repo    EphemeralPhotoCleanupRepository
+	files   EphemeralPhotoFileRemover
+	store   EphemeralCleanupStore
+	metrics JobMetricsRecorder
+	config  *config.EphemeralPhotoConfig
+
+	sleep func(ctx context.Context, d time.Duration) error
+}
+
+// NewEphemeralPhotoCleanupJob creates a new ephemeral photo cleanup job
+func NewEphemeralPhotoCleanupJob(
+	repo EphemeralPhotoCleanupRepository,
+	files EphemeralPhotoFileRemover,
+	store EphemeralCleanupStore,
+	metrics JobMetricsRecorder,
+	cfg *config.EphemeralPhotoConfig,
+) *EphemeralPhotoCleanupJob {
+	return &EphemeralPhotoCleanupJob{
+		repo:    repo,
+		files:   files,
+		store:   store,
+		metrics: metrics,
+		config:  cfg,
+		sleep:   sleepContext,
+	}
+}
+
+// Start runs the job every JobInterval until the context is cancelled
+func (j *EphemeralPhotoCleanupJob) Start(ctx context.Context) {
+	ticker := time.NewTicker(j.config.JobInterval)
+	defer ticker.Stop()
+
+	logger.Info("Ephemeral photo cleanup job started", map[string]interface{}{
+		"interval": j.config.JobInterval.String(),
+	})
+
+	for {
+		select {
+		case <-ctx.Done():
+			logger.Info("Ephemeral photo cleanup job stopped", nil)
+			return
+		case <-ticker.C:
+			j.Run(ctx)
+		}
+	}
+}
+
+// Run cleans up one batch of photos, then records and publishes what it did
+func (j *EphemeralPhotoCleanupJob) Run(ctx context.Context) *EphemeralCleanupRun {
+	run := &EphemeralCleanupRun{StartedAt: time.Now()}
+
+	if err := j.cleanup(ctx, run); err != nil {
+		logger.Error("Ephemeral photo cleanup run failed", err)
+		run.Error = err.Error()
+	}
+
+	duration := time.Since(run.StartedAt)
+	run.DurationMs = duration.Milliseconds()
+
+	if j.metrics != nil {
+		j.metrics.RecordJobRun(EphemeralPhotoCleanupJobName, map[string]int{
+			"scanned":       run.Scanned,
+			"expired":       run.Expired,
+			"deleted":       run.Deleted,
+			"retries":       run.Retries,
+			"dead_lettered": run.DeadLettered,
+			"skipped":       run.Skipped,
+		}, duration)
+	}
+
+	if err := j.store.RecordRun(ctx, run, j.config.JobRunHistory); err != nil {
+		logger.Error("Failed to record ephemeral photo cleanup run", err)
+	}
+
+	logger.Info("Ephemeral photo cleanup run completed", map[string]interface{}{
+		"scanned":       run.Scanned,
+		"deleted":       run.Deleted,
+		"retries":       run.Retries,
+		"dead_lettered": run.DeadLettered,
+		"duration_ms":   run.DurationMs,
+	})
+
+	return run
+}
+
+// RecentRuns returns up to limit of the most recent runs, newest first
+func (j *EphemeralPhotoCleanupJob) RecentRuns(ctx context.Context, limit int) ([]*EphemeralCleanupRun, error) {
+	return j.store.RecentRuns(ctx, limit)
+}
+
+// DeadLetterCount returns how many photos the job has given up deleting
+func (j *EphemeralPhotoCleanupJob) DeadLetterCount(ctx context.Context) (int64, error) {
+	return j.store.DeadLetterCount(ctx)
+}
+
+// cleanup deletes the photos of one batch, counting them in the run
+func (j *EphemeralPhotoCleanupJob) cleanup(ctx context.Context, run *EphemeralCleanupRun) error {
+	photos, err := j.repo.GetPhotosForCleanup(ctx, time.Now().Add(-ephemeralCleanupGracePeriod), j.config.JobBatchSize)
+	if err != nil {
+		return fmt.Errorf("failed to get photos for cleanup: %w", err)
+	}
+
+	for _, photo := range photos {
+		deadLettered, err := j.store.IsDeadLettered(ctx, photo.ID)
+		if err != nil {
+			logger.Error("Failed to check ephemeral photo dead letter", err)
+		}
+		if deadLettered {
+			run.Skipped++
+			continue
+		}
+
+		run.Scanned++
+		if photo.IsExpired || photo.IsExpiredByTime() {
+			run.Expired++
+		}
+
+		attempts, err := j.deleteWithRetry(ctx, photo)
+		run.Retries += attempts - 1
+		if err == nil {
+			run.Deleted++
+			continue
+		}
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+
+		logger.Error("Failed to clean up ephemeral photo", err, map[string]interface{}{
+			"photo_id": photo.ID,
+			"attempts": attempts,
+		})
+		if !j.config.EnableJobRetry {
+			continue
+		}
+
+		run.DeadLettered++
+		if err := j.store.AddDeadLetter(ctx, &EphemeralCleanupDeadLetter{
+			PhotoID:      photo.ID,
+			FileKey:      photo.FileKey,
+			ThumbnailKey: photo.ThumbnailKey,
+			Attempts:     attempts,
+			Error:        err.Error(),
+			FailedAt:     time.Now(),
+		}); err != nil {
+			logger.Error("Failed to dead-letter ephemeral photo", err)
+		}
+	}
+
+	return nil
+}
+
+// deleteWithRetry deletes the photo, retrying up to MaxJobRetries times when retries are enabled.
+// It returns how many attempts were made and the last error.
+func (j *EphemeralPhotoCleanupJob) deleteWithRetry(ctx context.Context, photo *entities.EphemeralPhoto) (int, error) {
+	maxAttempts := 1
+	if j.config.EnableJobRetry && j.config.MaxJobRetries > 0 {
+		maxAttempts += j.config.MaxJobRetries
+	}
+
+	var err error
+	delay := j.config.JobRetryDelay
+	for attempt := 1; ; attempt++ {
+		if err = j.delete(ctx, photo); err == nil || attempt == maxAttempts {
+			return attempt, err
+		}
+		if sleepErr := j.sleep(ctx, delay); sleepErr != nil {
+			return attempt, err
+		}
+		delay *= 2
+	}
+}
+
+// delete removes the photo's files, then its record
+func (j *EphemeralPhotoCleanupJob) delete(ctx context.Context, photo *entities.EphemeralPhoto) error {
+	if err := j.files.DeleteEphemeralPhoto(ctx, photo.FileKey, photo.ThumbnailKey); err != nil {
+		return err
+	}
+	return j.repo.BatchSoftDelete(ctx, []uuid.UUID{photo.ID})
+}
+
+// sleepContext waits for the duration, returning early if the context is cancelled
+func sleepContext(ctx context.Context, d time.Duration) error {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
@@ -0,0 +1,209 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/22smeargle/winkr-backend/internal/domain/entities"
+	"github.com/22smeargle/winkr-backend/pkg/config"
+)
+
+// inMemoryCleanupRepository is an in-memory EphemeralPhotoCleanupRepository for tests
+type inMemoryCleanupRepository struct {
+	photos []*entities.EphemeralPhoto
+}
+
+func (r *inMemoryCleanupRepository) GetPhotosForCleanup(ctx context.Context, olderThan time.Time, limit int) ([]*entities.EphemeralPhoto, error) {
+	var photos []*entities.EphemeralPhoto
+	for _, photo := range r.photos {
+		if !photo.IsDeleted && len(photos) < limit {
+			photos = append(photos, photo)
+		}
+	}
+	return photos, nil
+}
+
+func (r *inMemoryCleanupRepository) BatchSoftDelete(ctx context.Context, photoIDs []uuid.UUID) error {
+	for _, photo := range r.photos {
+		for _, id := range photoIDs {
+			if photo.ID == id {
+				photo.IsDeleted = true
+			}
+		}
+	}
+	return nil
+}
+
+// flakyFileRemover fails to delete each file key as many times as configured
+type flakyFileRemover struct {
+	failures map[string]int
+	attempts map[string]int
+}
+
+func (r *flakyFileRemover) DeleteEphemeralPhoto(ctx context.Context, fileKey string, thumbnailKey string) error {
+	r.attempts[fileKey]++
+	if r.failures[fileKey] < 0 || r.attempts[fileKey] <= r.failures[fileKey] {
+		return errors.New("storage unavailable")
+	}
+	return nil
+}
+
+// inMemoryCleanupStore is an in-memory EphemeralCleanupStore for tests
+type inMemoryCleanupStore struct {
+	runs        []*EphemeralCleanupRun
+	deadLetters map[uuid.UUID]*EphemeralCleanupDeadLetter
+}
+
+func (s *inMemoryCleanupStore) RecordRun(ctx context.Context, run *EphemeralCleanupRun, keep int) error {
+	s.runs = append([]*EphemeralCleanupRun{run}, s.runs...)
+	if len(s.runs) > keep {
+		s.runs = s.runs[:keep]
+	}
+	return nil
+}
+
+func (s *inMemoryCleanupStore) RecentRuns(ctx context.Context, limit int) ([]*EphemeralCleanupRun, error) {
+	if len(s.runs) < limit {
+		return s.runs, nil
+	}
+	return s.runs[:limit], nil
+}
+
+func (s *inMemoryCleanupStore) AddDeadLetter(ctx context.Context, deadLetter *EphemeralCleanupDeadLetter) error {
+	s.deadLetters[deadLetter.PhotoID] = deadLetter
+	return nil
+}
+
+func (s *inMemoryCleanupStore) IsDeadLettered(ctx context.Context, photoID uuid.UUID) (bool, error) {
+	_, ok := s.deadLetters[photoID]
+	return ok, nil
+}
+
+func (s *inMemoryCleanupStore) DeadLetterCount(ctx context.Context) (int64, error) {
+	return int64(len(s.deadLetters)), nil
+}
+
+// recordedJobMetrics keeps the counts of the last RecordJobRun call
+type recordedJobMetrics struct {
+	job    string
+	counts map[string]int
+}
+
+func (m *recordedJobMetrics) RecordJobRun(job string, counts map[string]int, duration time.Duration) {
+	m.job = job
+	m.counts = counts
+}
+
+type cleanupJobFixture struct {
+	job     *EphemeralPhotoCleanupJob
+	repo    *inMemoryCleanupRepository
+	files   *flakyFileRemover
+	store   *inMemoryCleanupStore
+	metrics *recordedJobMetrics
+	sleeps  []time.Duration
+}
+
+// newCleanupJobFixture returns a job over one photo per file key, failing each key's deletion as
+// many times as given, forever when negative. The first photo has expired, the rest were viewed.
+func newCleanupJobFixture(cfg *config.EphemeralPhotoConfig, failures map[string]int, fileKeys ...string) *cleanupJobFixture {
+	f := &cleanupJobFixture{
+		repo:    &inMemoryCleanupRepository{},
+		files:   &flakyFileRemover{failures: failures, attempts: make(map[string]int)},
+		store:   &inMemoryCleanupStore{deadLetters: make(map[uuid.UUID]*EphemeralCleanupDeadLetter)},
+		metrics: &recordedJobMetrics{},
+	}
+	for i, fileKey := range fileKeys {
+		photo := &entities.EphemeralPhoto{ID: uuid.New(), FileKey: fileKey, ExpiresAt: time.Now().Add(-2 * time.Hour)}
+		if i > 0 {
+			photo.IsViewed = true
+			photo.ExpiresAt = time.Now().Add(time.Hour)
+		}
+		f.repo.photos = append(f.repo.photos, photo)
+	}
+
+	cfg.JobBatchSize = 100
+	cfg.JobRunHistory = 10
+	cfg.JobRetryDelay = 100 * time.Millisecond
+	f.job = NewEphemeralPhotoCleanupJob(f.repo, f.files, f.store, f.metrics, cfg)
+	f.job.sleep = func(ctx context.Context, d time.Duration) error {
+		f.sleeps = append(f.sleeps, d)
+		return nil
+	}
+	return f
+}
+
+func TestEphemeralPhotoCleanupJob_DeletesAndPublishesMetrics(t *testing.T) {
+	ctx := context.Background()
+	f := newCleanupJobFixture(&config.EphemeralPhotoConfig{}, nil, "expired", "viewed-1", "viewed-2")
+
+	run := f.job.Run(ctx)
+
+	assert.Equal(t, 3, run.Scanned)
+	assert.Equal(t, 1, run.Expired)
+	assert.Equal(t, 3, run.Deleted)
+	assert.Zero(t, run.Retries)
+	for _, photo := range f.repo.photos {
+		assert.True(t, photo.IsDeleted)
+	}
+
+	assert.Equal(t, EphemeralPhotoCleanupJobName, f.metrics.job)
+	assert.Equal(t, 3, f.metrics.counts["scanned"])
+	assert.Equal(t, 3, f.metrics.counts["deleted"])
+
+	runs, err := f.job.RecentRuns(ctx, 5)
+	require.NoError(t, err)
+	require.Len(t, runs, 1)
+	assert.Equal(t, run, runs[0])
+
+	// Deleted photos are not scanned again
+	assert.Zero(t, f.job.Run(ctx).Scanned)
+}
+
+func TestEphemeralPhotoCleanupJob_RetriesWithBackoffThenDeadLetters(t *testing.T) {
+	ctx := context.Background()
+	cfg := &config.EphemeralPhotoConfig{EnableJobRetry: true, MaxJobRetries: 2}
+	f := newCleanupJobFixture(cfg, map[string]int{"flaky": 1, "broken": -1}, "flaky", "broken")
+
+	run := f.job.Run(ctx)
+
+	assert.Equal(t, 2, run.Scanned)
+	assert.Equal(t, 1, run.Deleted)
+	assert.Equal(t, 3, run.Retries)
+	assert.Equal(t, 1, run.DeadLettered)
+	assert.Equal(t, 3, f.files.attempts["broken"])
+	assert.Equal(t, []time.Duration{100 * time.Millisecond, 100 * time.Millisecond, 200 * time.Millisecond}, f.sleeps)
+
+	broken := f.repo.photos[1]
+	require.Contains(t, f.store.deadLetters, broken.ID)
+	assert.Equal(t, 3, f.store.deadLetters[broken.ID].Attempts)
+	assert.Equal(t, "broken", f.store.deadLetters[broken.ID].FileKey)
+	assert.False(t, broken.IsDeleted)
+
+	// Later runs leave dead-lettered photos alone
+	next := f.job.Run(ctx)
+	assert.Zero(t, next.Scanned)
+	assert.Equal(t, 1, next.Skipped)
+	assert.Equal(t, 3, f.files.attempts["broken"])
+}
+
+func TestEphemeralPhotoCleanupJob_NoRetryWhenDisabled(t *testing.T) {
+	ctx := context.Background()
+	f := newCleanupJobFixture(&config.EphemeralPhotoConfig{MaxJobRetries: 3}, map[string]int{"broken": -1}, "broken")
+
+	run := f.job.Run(ctx)
+
+	assert.Zero(t, run.Deleted)
+	assert.Zero(t, run.Retries)
+	assert.Zero(t, run.DeadLettered)
+	assert.Equal(t, 1, f.files.attempts["broken"])
+	assert.Empty(t, f.store.deadLetters)
+
+	// The photo is tried again on the next run
+	f.job.Run(ctx)
+	assert.Equal(t, 2, f.files.attempts["broken"])
+}
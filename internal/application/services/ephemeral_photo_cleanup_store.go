@@ -0,0 +1,133 @@
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/22smeargle/winkr-backend/internal/infrastructure/database/redis"
+)
+
+// EphemeralCleanupRun is what one run of the ephemeral photo cleanup job did
+type EphemeralCleanupRun struct {
+	StartedAt    time.Time `json:"started_at"`
+	DurationMs   int64     `json:"duration_ms"`
+	Scanned      int       `json:"scanned"`       // Photos due for cleanup that were loaded
+	Expired      int       `json:"expired"`       // Scanned photos that expired, the rest were viewed
+	Deleted      int       `json:"deleted"`       // Photos whose files and records were deleted
+	Retries      int       `json:"retries"`       // Deletion attempts after the first one
+	DeadLettered int       `json:"dead_lettered"` // Photos that could not be deleted and were set aside
+	Skipped      int       `json:"skipped"`       // Photos set aside by an earlier run
+	Error        string    `json:"error,omitempty"`
+}
+
+// EphemeralCleanupDeadLetter is a photo the cleanup job gave up deleting
+type EphemeralCleanupDeadLetter struct {
+	PhotoID      uuid.UUID `json:"photo_id"`
+	FileKey      string    `json:"file_key"`
+	ThumbnailKey string    `json:"thumbnail_key"`
+	Attempts     int       `json:"attempts"`
+	Error        string    `json:"error"`
+	FailedAt     time.Time `json:"failed_at"`
+}
+
+// EphemeralCleanupStore keeps the history of cleanup runs and the photos that could not be deleted
+type EphemeralCleanupStore interface {
+	// RecordRun adds the run to the history, keeping the most recent keep runs
+	RecordRun(ctx context.Context, run *EphemeralCleanupRun, keep int) error
+	// RecentRuns returns up to limit runs, newest first
+	RecentRuns(ctx context.Context, limit int) ([]*EphemeralCleanupRun, error)
+	// AddDeadLetter sets the photo aside so later runs skip it
+	AddDeadLetter(ctx context.Context, deadLetter *EphemeralCleanupDeadLetter) error
+	// IsDeadLettered reports whether the photo was set aside
+	IsDeadLettered(ctx context.Context, photoID uuid.UUID) (bool, error)
+	// DeadLetterCount returns how many photos are set aside
+	DeadLetterCount(ctx context.Context) (int64, error)
+}
+
+// RedisEphemeralCleanupStore keeps cleanup runs in a capped Redis list. Dead-lettered photo IDs are
+// kept in a set, with the details of each failure in a hash keyed by photo ID, for inspection.
+type RedisEphemeralCleanupStore struct {
+	redisClient *redis.RedisClient
+	prefix      string
+}
+
+// NewRedisEphemeralCleanupStore creates a new RedisEphemeralCleanupStore
+func NewRedisEphemeralCleanupStore(redisClient *redis.RedisClient) *RedisEphemeralCleanupStore {
+	return &RedisEphemeralCleanupStore{
+		redisClient: redisClient,
+		prefix:      "ephemeral_cleanup:",
+	}
+}
+
+// RecordRun adds the run to the history, keeping the most recent keep runs
+func (s *RedisEphemeralCleanupStore) RecordRun(ctx context.Context, run *EphemeralCleanupRun, keep int) error {
+	data, err := json.Marshal(run)
+	if err != nil {
+		return fmt.Errorf("failed to marshal cleanup run: %w", err)
+	}
+
+	if err := s.redisClient.LPush(ctx, s.runsKey(), data); err != nil {
+		return fmt.Errorf("failed to record cleanup run: %w", err)
+	}
+	return s.redisClient.GetClient().LTrim(ctx, s.runsKey(), 0, int64(keep-1)).Err()
+}
+
+// RecentRuns returns up to limit runs, newest first
+func (s *RedisEphemeralCleanupStore) RecentRuns(ctx context.Context, limit int) ([]*EphemeralCleanupRun, error) {
+	values, err := s.redisClient.LRange(ctx, s.runsKey(), 0, int64(limit-1))
+	if err != nil {
+		return nil, fmt.Errorf("failed to get cleanup runs: %w", err)
+	}
+
+	runs := make([]*EphemeralCleanupRun, 0, len(values))
+	for _, value := range values {
+		var run EphemeralCleanupRun
+		if err := json.Unmarshal([]byte(value), &run); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal cleanup run: %w", err)
+		}
+		runs = append(runs, &run)
+	}
+	return runs, nil
+}
+
+// AddDeadLetter sets the photo aside so later runs skip it
+func (s *RedisEphemeralCleanupStore) AddDeadLetter(ctx context.Context, deadLetter *EphemeralCleanupDeadLetter) error {
+	data, err := json.Marshal(deadLetter)
+	if err != nil {
+		return fmt.Errorf("failed to marshal dead letter: %w", err)
+	}
+
+	photoID := deadLetter.PhotoID.String()
+	if err := s.redisClient.HSet(ctx, s.deadLetterDetailsKey(), photoID, data); err != nil {
+		return fmt.Errorf("failed to record dead letter: %w", err)
+	}
+	return s.redisClient.SAdd(ctx, s.deadLetterKey(), photoID)
+}
+
+// IsDeadLettered reports whether the photo was set aside
+func (s *RedisEphemeralCleanupStore) IsDeadLettered(ctx context.Context, photoID uuid.UUID) (bool, error) {
+	return s.redisClient.SIsMember(ctx, s.deadLetterKey(), photoID.String())
+}
+
+// DeadLetterCount returns how many photos are set aside
+func (s *RedisEphemeralCleanupStore) DeadLetterCount(ctx context.Context) (int64, error) {
+	return s.redisClient.GetClient().SCard(ctx, s.deadLetterKey()).Result()
+}
+
+// runsKey is the list of recent runs
+func (s *RedisEphemeralCleanupStore) runsKey() string {
+	return s.prefix + "runs"
+}
+
+// deadLetterKey is the set of dead-lettered photo IDs
+func (s *RedisEphemeralCleanupStore) deadLetterKey() string {
+	return s.prefix + "dead_letter"
+}
+
+// deadLetterDetailsKey is the hash of dead-lettered photo IDs to their failures
+func (s *RedisEphemeralCleanupStore) deadLetterDetailsKey() string {
+	return s.prefix + "dead_letter:details"
+}
@@ -0,0 +1,143 @@
+package services
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+
+	"github.com/google/uuid"
+	"github.com/22smeargle/winkr-backend/internal/domain/entities"
+	"github.com/22smeargle/winkr-backend/pkg/config"
+	"github.com/22smeargle/winkr-backend/pkg/logger"
+)
+
+// anonymousViewerKey is the watermark cache key of viewers who are not signed in
+const anonymousViewerKey = "anonymous"
+
+// EphemeralPhotoFileStore reads original photos and stores their watermarked variants
+type EphemeralPhotoFileStore interface {
+	GetFile(ctx context.Context, key string) (io.ReadCloser, int64, string, error)
+	UploadFile(ctx context.Context, file io.Reader, key string, contentType string) (string, error)
+}
+
+// EphemeralPhotoWatermarkService renders the configured watermark onto ephemeral photos when they are
+// viewed. Each viewer gets their own variant, stamped with their user ID when enabled, so a leaked copy
+// can be traced back to whoever viewed it.
+type EphemeralPhotoWatermarkService struct {
+	files  EphemeralPhotoFileStore
+	images ImageProcessingService
+	cache  EphemeralPhotoCacheService
+	config *config.EphemeralPhotoConfig
+}
+
+// NewEphemeralPhotoWatermarkService creates a new ephemeral photo watermark service
+func NewEphemeralPhotoWatermarkService(
+	files EphemeralPhotoFileStore,
+	images ImageProcessingService,
+	cache EphemeralPhotoCacheService,
+	cfg *config.EphemeralPhotoConfig,
+) *EphemeralPhotoWatermarkService {
+	return &EphemeralPhotoWatermarkService{
+		files:  files,
+		images: images,
+		cache:  cache,
+		config: cfg,
+	}
+}
+
+// ViewFileKey returns the storage key of the file the viewer should be shown, and whether it is
+// watermarked. Variants are rendered once per (photo, viewer) and cached until the photo expires.
+// When watermarking is disabled or the photo can't be watermarked, the original's key is returned.
+func (s *EphemeralPhotoWatermarkService) ViewFileKey(ctx context.Context, photo *entities.EphemeralPhoto, viewerID *uuid.UUID) (string, bool) {
+	if s == nil || s.config == nil || !s.config.EnableWatermark {
+		return photo.FileKey, false
+	}
+
+	viewerKey := anonymousViewerKey
+	if viewerID != nil {
+		viewerKey = viewerID.String()
+	}
+
+	if fileKey, err := s.cache.GetCachedWatermarkedFileKey(ctx, photo.ID, viewerKey); err == nil {
+		return fileKey, true
+	}
+
+	fileKey, err := s.render(ctx, photo, viewerID, viewerKey)
+	if err != nil {
+		logger.Error("Failed to watermark ephemeral photo", err, map[string]interface{}{
+			"photo_id": photo.ID,
+			"viewer":   viewerKey,
+		})
+		return photo.FileKey, false
+	}
+
+	ttl := photo.GetRemainingTime()
+	if ttl <= 0 {
+		ttl = s.config.CacheTTL
+	}
+	if err := s.cache.CacheWatermarkedFileKey(ctx, photo.ID, viewerKey, fileKey, ttl); err != nil {
+		logger.Warn("Failed to cache watermarked ephemeral photo", map[string]interface{}{
+			"photo_id": photo.ID,
+			"viewer":   viewerKey,
+			"error":    err.Error(),
+		})
+	}
+
+	return fileKey, true
+}
+
+// render watermarks the photo for the viewer and uploads the result
+func (s *EphemeralPhotoWatermarkService) render(ctx context.Context, photo *entities.EphemeralPhoto, viewerID *uuid.UUID, viewerKey string) (string, error) {
+	file, _, contentType, err := s.files.GetFile(ctx, photo.FileKey)
+	if err != nil {
+		return "", fmt.Errorf("failed to read photo: %w", err)
+	}
+	defer file.Close()
+
+	original, err := io.ReadAll(file)
+	if err != nil {
+		return "", fmt.Errorf("failed to read photo: %w", err)
+	}
+
+	if contentType == "" || contentType == "application/octet-stream" {
+		contentType = http.DetectContentType(original)
+	}
+	if !s.allowedType(contentType) {
+		return "", fmt.Errorf("unsupported content type: %s", contentType)
+	}
+
+	watermarked, err := s.images.AddWatermark(ctx, bytes.NewReader(original), s.watermarkText(viewerID))
+	if err != nil {
+		return "", err
+	}
+
+	fileKey := fmt.Sprintf("ephemeral/watermarked/%s/%s", photo.ID, viewerKey)
+	if _, err := s.files.UploadFile(ctx, bytes.NewReader(watermarked), fileKey, http.DetectContentType(watermarked)); err != nil {
+		return "", fmt.Errorf("failed to upload watermarked photo: %w", err)
+	}
+
+	return fileKey, nil
+}
+
+// watermarkText is the configured text, followed by the viewer's ID on its own line when enabled
+func (s *EphemeralPhotoWatermarkService) watermarkText(viewerID *uuid.UUID) string {
+	text := s.config.WatermarkText
+	if s.config.WatermarkViewerID && viewerID != nil {
+		text += "\n" + viewerID.String()
+	}
+	return text
+}
+
+// allowedType reports whether ephemeral photos of the content type may be uploaded, and so watermarked
+func (s *EphemeralPhotoWatermarkService) allowedType(contentType string) bool {
+	mediaType := strings.TrimSpace(strings.SplitN(contentType, ";", 2)[0])
+	for _, allowed := range s.config.AllowedTypes {
+		if strings.EqualFold(allowed, mediaType) {
+			return true
+		}
+	}
+	return false
+}
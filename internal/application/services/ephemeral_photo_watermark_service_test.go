@@ -0,0 +1,163 @@
+package services
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"image"
+	"image/color"
+	"image/png"
+	"io"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/22smeargle/winkr-backend/internal/domain/entities"
+	"github.com/22smeargle/winkr-backend/pkg/config"
+)
+
+// storedFile is a file in an inMemoryFileStore
+type storedFile struct {
+	data        []byte
+	contentType string
+}
+
+// inMemoryFileStore is an in-memory EphemeralPhotoFileStore for tests
+type inMemoryFileStore struct {
+	files   map[string]storedFile
+	uploads int
+}
+
+func (s *inMemoryFileStore) GetFile(ctx context.Context, key string) (io.ReadCloser, int64, string, error) {
+	file, ok := s.files[key]
+	if !ok {
+		return nil, 0, "", fmt.Errorf("file not found: %s", key)
+	}
+	return io.NopCloser(bytes.NewReader(file.data)), int64(len(file.data)), file.contentType, nil
+}
+
+func (s *inMemoryFileStore) UploadFile(ctx context.Context, file io.Reader, key string, contentType string) (string, error) {
+	data, err := io.ReadAll(file)
+	if err != nil {
+		return "", err
+	}
+	s.files[key] = storedFile{data: data, contentType: contentType}
+	s.uploads++
+	return key, nil
+}
+
+// inMemoryWatermarkCache keeps watermarked file keys in memory, the rest of the cache is unused
+type inMemoryWatermarkCache struct {
+	EphemeralPhotoCacheService
+	keys map[string]string
+}
+
+func (c *inMemoryWatermarkCache) CacheWatermarkedFileKey(ctx context.Context, photoID uuid.UUID, viewerKey string, fileKey string, ttl time.Duration) error {
+	c.keys[photoID.String()+":"+viewerKey] = fileKey
+	return nil
+}
+
+func (c *inMemoryWatermarkCache) GetCachedWatermarkedFileKey(ctx context.Context, photoID uuid.UUID, viewerKey string) (string, error) {
+	fileKey, ok := c.keys[photoID.String()+":"+viewerKey]
+	if !ok {
+		return "", errors.New("cache miss")
+	}
+	return fileKey, nil
+}
+
+func newWatermarkFixture(t *testing.T, original []byte, contentType string) (*EphemeralPhotoWatermarkService, *inMemoryFileStore, *entities.EphemeralPhoto) {
+	photo := &entities.EphemeralPhoto{
+		ID:        uuid.New(),
+		FileKey:   "ephemeral/original.png",
+		ExpiresAt: time.Now().Add(time.Minute),
+	}
+	files := &inMemoryFileStore{files: map[string]storedFile{
+		photo.FileKey: {data: original, contentType: contentType},
+	}}
+	service := NewEphemeralPhotoWatermarkService(
+		files,
+		NewImageProcessor(&config.StorageConfig{}),
+		&inMemoryWatermarkCache{keys: make(map[string]string)},
+		&config.EphemeralPhotoConfig{
+			AllowedTypes:      []string{"image/jpeg", "image/png", "image/webp"},
+			EnableWatermark:   true,
+			WatermarkText:     "Ephemeral",
+			WatermarkViewerID: true,
+			CacheTTL:          time.Hour,
+		},
+	)
+	return service, files, photo
+}
+
+func testPNG(t *testing.T) []byte {
+	img := image.NewRGBA(image.Rect(0, 0, 240, 160))
+	for y := 0; y < 160; y++ {
+		for x := 0; x < 240; x++ {
+			img.Set(x, y, color.RGBA{R: 40, G: 160, B: 90, A: 255})
+		}
+	}
+	var buf bytes.Buffer
+	require.NoError(t, png.Encode(&buf, img))
+	return buf.Bytes()
+}
+
+func TestEphemeralPhotoWatermarkService_RendersOncePerViewer(t *testing.T) {
+	ctx := context.Background()
+	original := testPNG(t)
+	service, files, photo := newWatermarkFixture(t, original, "image/png")
+	viewerID := uuid.New()
+
+	fileKey, watermarked := service.ViewFileKey(ctx, photo, &viewerID)
+	require.True(t, watermarked)
+	assert.Equal(t, "ephemeral/watermarked/"+photo.ID.String()+"/"+viewerID.String(), fileKey)
+
+	variant := files.files[fileKey]
+	assert.Equal(t, "image/png", variant.contentType)
+	assert.NotEqual(t, original, variant.data)
+	_, _, err := image.Decode(bytes.NewReader(variant.data))
+	require.NoError(t, err)
+
+	// Repeated views by the same viewer reuse the variant
+	again, _ := service.ViewFileKey(ctx, photo, &viewerID)
+	assert.Equal(t, fileKey, again)
+	assert.Equal(t, 1, files.uploads)
+
+	// Other viewers get a variant of their own
+	otherViewerID := uuid.New()
+	other, watermarked := service.ViewFileKey(ctx, photo, &otherViewerID)
+	require.True(t, watermarked)
+	assert.NotEqual(t, fileKey, other)
+	assert.NotEqual(t, variant.data, files.files[other].data)
+	assert.Equal(t, 2, files.uploads)
+}
+
+func TestEphemeralPhotoWatermarkService_FallsBackToOriginal(t *testing.T) {
+	ctx := context.Background()
+
+	tests := []struct {
+		name        string
+		data        []byte
+		contentType string
+		disabled    bool
+	}{
+		{name: "undecodable image", data: []byte("definitely not a jpeg"), contentType: "image/jpeg"},
+		{name: "type not allowed", data: testPNG(t), contentType: "image/gif"},
+		{name: "watermarking disabled", data: testPNG(t), contentType: "image/png", disabled: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			service, files, photo := newWatermarkFixture(t, tt.data, tt.contentType)
+			service.config.EnableWatermark = !tt.disabled
+
+			fileKey, watermarked := service.ViewFileKey(ctx, photo, nil)
+
+			assert.False(t, watermarked)
+			assert.Equal(t, photo.FileKey, fileKey)
+			assert.Zero(t, files.uploads)
+		})
+	}
+}
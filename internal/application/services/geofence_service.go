@@ -0,0 +1,132 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/google/uuid"
+	"github.com/22smeargle/winkr-backend/internal/domain/entities"
+	"github.com/22smeargle/winkr-backend/pkg/config"
+	"github.com/22smeargle/winkr-backend/pkg/logger"
+)
+
+// GeofenceUserStore defines the user lookup needed to apply profile-based geofencing
+type GeofenceUserStore interface {
+	GetByID(ctx context.Context, id uuid.UUID) (*entities.User, error)
+}
+
+// GeofenceRequest describes where a request comes from
+type GeofenceRequest struct {
+	UserID    *uuid.UUID // Nil for anonymous requests such as signup
+	IPCountry string     // ISO 3166-1 alpha-2 code resolved from the client IP
+	IPRegion  string     // ISO 3166-2 code resolved from the client IP
+}
+
+// GeofenceDecision is the outcome of a geofence check
+type GeofenceDecision struct {
+	Allowed    bool   `json:"allowed"`
+	Source     string `json:"source,omitempty"`   // 'ip' or 'profile'
+	Location   string `json:"location,omitempty"` // The restricted country or region that matched
+	Overridden bool   `json:"overridden"`         // True when an admin override let the request through
+}
+
+// GeofenceService rejects requests from restricted countries and regions
+type GeofenceService struct {
+	userStore           GeofenceUserStore
+	enabled             bool
+	restrictedCountries map[string]bool
+	restrictedRegions   map[string]bool
+	allowedUsers        map[uuid.UUID]bool
+}
+
+// NewGeofenceService creates a new GeofenceService
+func NewGeofenceService(userStore GeofenceUserStore, cfg *config.GeofencingConfig) *GeofenceService {
+	s := &GeofenceService{
+		userStore:           userStore,
+		enabled:             cfg.Enabled,
+		restrictedCountries: make(map[string]bool),
+		restrictedRegions:   make(map[string]bool),
+		allowedUsers:        make(map[uuid.UUID]bool),
+	}
+
+	for _, country := range cfg.RestrictedCountries {
+		if code := normalizeGeoCode(country); code != "" {
+			s.restrictedCountries[code] = true
+		}
+	}
+	for _, region := range cfg.RestrictedRegions {
+		if code := normalizeGeoCode(region); code != "" {
+			s.restrictedRegions[code] = true
+		}
+	}
+	for _, id := range cfg.AllowedUserIDs {
+		userID, err := uuid.Parse(strings.TrimSpace(id))
+		if err != nil {
+			logger.Warn("Ignoring invalid geofencing allowlist entry", "value", id)
+			continue
+		}
+		s.allowedUsers[userID] = true
+	}
+
+	return s
+}
+
+// Check decides whether a request may be served.
+// The client IP is checked first, then the profile location of an authenticated user.
+// Accounts on the configured allowlist or flagged as exempt by an admin always pass.
+func (s *GeofenceService) Check(ctx context.Context, req *GeofenceRequest) (*GeofenceDecision, error) {
+	if !s.enabled || (len(s.restrictedCountries) == 0 && len(s.restrictedRegions) == 0) {
+		return &GeofenceDecision{Allowed: true}, nil
+	}
+
+	var user *entities.User
+	if req.UserID != nil {
+		if s.allowedUsers[*req.UserID] {
+			return &GeofenceDecision{Allowed: true, Overridden: true}, nil
+		}
+
+		var err error
+		user, err = s.userStore.GetByID(ctx, *req.UserID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get user for geofence check: %w", err)
+		}
+		if user != nil && user.GeofenceExempt {
+			return &GeofenceDecision{Allowed: true, Overridden: true}, nil
+		}
+	}
+
+	if location := s.restrictedLocation(req.IPCountry, req.IPRegion); location != "" {
+		return &GeofenceDecision{Allowed: false, Source: "ip", Location: location}, nil
+	}
+
+	if user != nil && user.LocationCountry != nil {
+		if location := s.restrictedLocation(*user.LocationCountry, ""); location != "" {
+			return &GeofenceDecision{Allowed: false, Source: "profile", Location: location}, nil
+		}
+	}
+
+	return &GeofenceDecision{Allowed: true}, nil
+}
+
+// restrictedLocation returns the matching restricted code, or "" if the location is allowed
+func (s *GeofenceService) restrictedLocation(country, region string) string {
+	if code := normalizeGeoCode(country); code != "" && s.restrictedCountries[code] {
+		return code
+	}
+	if code := normalizeGeoCode(region); code != "" {
+		if s.restrictedRegions[code] {
+			return code
+		}
+		// A region code carries its country as the prefix, e.g. "UA-43"
+		if prefix, _, found := strings.Cut(code, "-"); found && s.restrictedCountries[prefix] {
+			return prefix
+		}
+	}
+	return ""
+}
+
+// normalizeGeoCode upper-cases and trims a country or region code
+func normalizeGeoCode(code string) string {
+	return strings.ToUpper(strings.TrimSpace(code))
+}
@@ -0,0 +1,138 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/22smeargle/winkr-backend/internal/domain/entities"
+	"github.com/22smeargle/winkr-backend/pkg/config"
+)
+
+// MockGeofenceUserStore is a mock implementation of GeofenceUserStore
+type MockGeofenceUserStore struct {
+	mock.Mock
+}
+
+func (m *MockGeofenceUserStore) GetByID(ctx context.Context, id uuid.UUID) (*entities.User, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entities.User), args.Error(1)
+}
+
+func testGeofencingConfig() *config.GeofencingConfig {
+	return &config.GeofencingConfig{
+		Enabled:             true,
+		RestrictedCountries: []string{"KP", " ir "},
+		RestrictedRegions:   []string{"UA-43"},
+	}
+}
+
+func TestGeofenceService_Check_BlocksRestrictedIP(t *testing.T) {
+	service := NewGeofenceService(new(MockGeofenceUserStore), testGeofencingConfig())
+
+	tests := []struct {
+		name     string
+		country  string
+		region   string
+		location string
+	}{
+		{name: "restricted country", country: "kp", location: "KP"},
+		{name: "restricted region", country: "UA", region: "UA-43", location: "UA-43"},
+		{name: "region of restricted country", region: "IR-23", location: "IR"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			decision, err := service.Check(context.Background(), &GeofenceRequest{IPCountry: tt.country, IPRegion: tt.region})
+
+			assert.NoError(t, err)
+			assert.False(t, decision.Allowed)
+			assert.Equal(t, "ip", decision.Source)
+			assert.Equal(t, tt.location, decision.Location)
+		})
+	}
+}
+
+func TestGeofenceService_Check_AllowsUnrestrictedIP(t *testing.T) {
+	service := NewGeofenceService(new(MockGeofenceUserStore), testGeofencingConfig())
+
+	decision, err := service.Check(context.Background(), &GeofenceRequest{IPCountry: "UA", IPRegion: "UA-30"})
+
+	assert.NoError(t, err)
+	assert.True(t, decision.Allowed)
+}
+
+func TestGeofenceService_Check_BlocksRestrictedProfileLocation(t *testing.T) {
+	userStore := new(MockGeofenceUserStore)
+	service := NewGeofenceService(userStore, testGeofencingConfig())
+
+	userID := uuid.New()
+	country := "IR"
+	userStore.On("GetByID", mock.Anything, userID).Return(&entities.User{ID: userID, LocationCountry: &country}, nil)
+
+	decision, err := service.Check(context.Background(), &GeofenceRequest{UserID: &userID, IPCountry: "DE"})
+
+	assert.NoError(t, err)
+	assert.False(t, decision.Allowed)
+	assert.Equal(t, "profile", decision.Source)
+	userStore.AssertExpectations(t)
+}
+
+func TestGeofenceService_Check_AllowlistedAccountBypasses(t *testing.T) {
+	userID := uuid.New()
+	cfg := testGeofencingConfig()
+	cfg.AllowedUserIDs = []string{userID.String()}
+	userStore := new(MockGeofenceUserStore)
+	service := NewGeofenceService(userStore, cfg)
+
+	decision, err := service.Check(context.Background(), &GeofenceRequest{UserID: &userID, IPCountry: "KP"})
+
+	assert.NoError(t, err)
+	assert.True(t, decision.Allowed)
+	assert.True(t, decision.Overridden)
+	userStore.AssertNotCalled(t, "GetByID", mock.Anything, mock.Anything)
+}
+
+func TestGeofenceService_Check_ExemptAccountBypasses(t *testing.T) {
+	userStore := new(MockGeofenceUserStore)
+	service := NewGeofenceService(userStore, testGeofencingConfig())
+
+	userID := uuid.New()
+	userStore.On("GetByID", mock.Anything, userID).Return(&entities.User{ID: userID, GeofenceExempt: true}, nil)
+
+	decision, err := service.Check(context.Background(), &GeofenceRequest{UserID: &userID, IPCountry: "KP"})
+
+	assert.NoError(t, err)
+	assert.True(t, decision.Allowed)
+	assert.True(t, decision.Overridden)
+}
+
+func TestGeofenceService_Check_UserLookupFailure(t *testing.T) {
+	userStore := new(MockGeofenceUserStore)
+	service := NewGeofenceService(userStore, testGeofencingConfig())
+
+	userID := uuid.New()
+	userStore.On("GetByID", mock.Anything, userID).Return(nil, errors.New("db down"))
+
+	decision, err := service.Check(context.Background(), &GeofenceRequest{UserID: &userID, IPCountry: "DE"})
+
+	assert.Error(t, err)
+	assert.Nil(t, decision)
+}
+
+func TestGeofenceService_Check_Disabled(t *testing.T) {
+	cfg := testGeofencingConfig()
+	cfg.Enabled = false
+	service := NewGeofenceService(new(MockGeofenceUserStore), cfg)
+
+	decision, err := service.Check(context.Background(), &GeofenceRequest{IPCountry: "KP"})
+
+	assert.NoError(t, err)
+	assert.True(t, decision.Allowed)
+}
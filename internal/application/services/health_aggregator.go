@@ -0,0 +1,162 @@
+package services
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/22smeargle/winkr-backend/pkg/config"
+)
+
+// defaultHealthCheckTimeout bounds a component check when no timeout is configured
+const defaultHealthCheckTimeout = 5 * time.Second
+
+// HealthProbe checks that a dependency is reachable, returning an error if it isn't
+type HealthProbe func(ctx context.Context) error
+
+// ComponentHealthReport is the result of checking one dependency
+type ComponentHealthReport struct {
+	Status      HealthStatus `json:"status"`
+	LatencyMs   float64      `json:"latency_ms"`
+	ThresholdMs int64        `json:"threshold_ms,omitempty"`
+	Error       string       `json:"error,omitempty"`
+}
+
+// HealthReport is the aggregated health of the app's dependencies
+type HealthReport struct {
+	Status        HealthStatus                     `json:"status"`
+	Timestamp     time.Time                        `json:"timestamp"`
+	UptimeSeconds int64                            `json:"uptime_seconds"`
+	Components    map[string]ComponentHealthReport `json:"components"`
+	// ReadOnly reports whether the API is rejecting writes, which doesn't affect readiness
+	ReadOnly bool `json:"read_only"`
+}
+
+// Ready reports whether the app can serve traffic. Degraded dependencies are slow but working, so
+// only an unhealthy one makes the app unready.
+func (r *HealthReport) Ready() bool {
+	return r.Status != HealthStatusUnhealthy
+}
+
+// healthComponent is a dependency checked by the aggregator
+type healthComponent struct {
+	name      string
+	probe     HealthProbe
+	threshold time.Duration
+}
+
+// HealthAggregator checks the app's dependencies together for the health endpoints. Each component
+// is probed concurrently with the configured timeout. A component whose probe fails or times out is
+// unhealthy. One that answers slower than its threshold is degraded. The overall status is the
+// worst of the components.
+type HealthAggregator struct {
+	config     *config.HealthCheckConfig
+	components []healthComponent
+	startTime  time.Time
+	readOnly   func(ctx context.Context) bool
+}
+
+// NewHealthAggregator creates a new HealthAggregator without components
+func NewHealthAggregator(cfg *config.HealthCheckConfig) *HealthAggregator {
+	return &HealthAggregator{
+		config:    cfg,
+		startTime: time.Now(),
+	}
+}
+
+// AddComponent registers a dependency to check. A threshold of 0 never marks it degraded.
+func (a *HealthAggregator) AddComponent(name string, threshold time.Duration, probe HealthProbe) {
+	a.components = append(a.components, healthComponent{name: name, probe: probe, threshold: threshold})
+}
+
+// SetReadOnlyCheck sets how the reports learn whether the API is in read-only mode
+func (a *HealthAggregator) SetReadOnlyCheck(check func(ctx context.Context) bool) {
+	a.readOnly = check
+}
+
+// Uptime returns how long the process has been running
+func (a *HealthAggregator) Uptime() time.Duration {
+	return time.Since(a.startTime)
+}
+
+// Check probes every component and aggregates their status
+func (a *HealthAggregator) Check(ctx context.Context) *HealthReport {
+	results := make([]ComponentHealthReport, len(a.components))
+
+	var wg sync.WaitGroup
+	for i, component := range a.components {
+		wg.Add(1)
+		go func(i int, component healthComponent) {
+			defer wg.Done()
+			results[i] = a.checkComponent(ctx, component)
+		}(i, component)
+	}
+	wg.Wait()
+
+	report := &HealthReport{
+		Status:        HealthStatusHealthy,
+		Timestamp:     time.Now(),
+		UptimeSeconds: int64(a.Uptime().Seconds()),
+		Components:    make(map[string]ComponentHealthReport, len(a.components)),
+	}
+	if a.readOnly != nil {
+		report.ReadOnly = a.readOnly(ctx)
+	}
+	for i, component := range a.components {
+		report.Components[component.name] = results[i]
+		report.Status = worseHealthStatus(report.Status, results[i].Status)
+	}
+	return report
+}
+
+// checkComponent runs a component's probe within the timeout. The probe runs in its own goroutine,
+// so one that ignores its context still can't hold up the report.
+func (a *HealthAggregator) checkComponent(ctx context.Context, component healthComponent) ComponentHealthReport {
+	timeout := a.config.Timeout
+	if timeout <= 0 {
+		timeout = defaultHealthCheckTimeout
+	}
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	start := time.Now()
+	done := make(chan error, 1)
+	go func() {
+		done <- component.probe(ctx)
+	}()
+
+	var err error
+	select {
+	case err = <-done:
+	case <-ctx.Done():
+		err = ctx.Err()
+	}
+	latency := time.Since(start)
+
+	result := ComponentHealthReport{
+		Status:      HealthStatusHealthy,
+		LatencyMs:   float64(latency.Microseconds()) / 1000,
+		ThresholdMs: component.threshold.Milliseconds(),
+	}
+	switch {
+	case err != nil:
+		result.Status = HealthStatusUnhealthy
+		result.Error = err.Error()
+	case component.threshold > 0 && latency > component.threshold:
+		result.Status = HealthStatusDegraded
+	}
+	return result
+}
+
+// worseHealthStatus returns the more severe of two statuses
+func worseHealthStatus(a, b HealthStatus) HealthStatus {
+	severity := map[HealthStatus]int{
+		HealthStatusHealthy:   0,
+		HealthStatusDegraded:  1,
+		HealthStatusUnhealthy: 2,
+	}
+	if severity[b] > severity[a] {
+		return b
+	}
+	return a
+}
@@ -0,0 +1,98 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/22smeargle/winkr-backend/pkg/config"
+)
+
+func healthyProbe(ctx context.Context) error {
+	return nil
+}
+
+func slowProbe(delay time.Duration) HealthProbe {
+	return func(ctx context.Context) error {
+		time.Sleep(delay)
+		return nil
+	}
+}
+
+func TestHealthAggregator_AllHealthy(t *testing.T) {
+	aggregator := NewHealthAggregator(&config.HealthCheckConfig{Timeout: time.Second})
+	aggregator.AddComponent("database", time.Second, healthyProbe)
+	aggregator.AddComponent("redis", time.Second, healthyProbe)
+
+	report := aggregator.Check(context.Background())
+
+	assert.Equal(t, HealthStatusHealthy, report.Status)
+	assert.True(t, report.Ready())
+	require.Len(t, report.Components, 2)
+	assert.Equal(t, HealthStatusHealthy, report.Components["database"].Status)
+	assert.Equal(t, int64(1000), report.Components["redis"].ThresholdMs)
+}
+
+func TestHealthAggregator_SlowComponentIsDegraded(t *testing.T) {
+	aggregator := NewHealthAggregator(&config.HealthCheckConfig{Timeout: time.Second})
+	aggregator.AddComponent("database", time.Second, healthyProbe)
+	aggregator.AddComponent("storage", time.Millisecond, slowProbe(20*time.Millisecond))
+
+	report := aggregator.Check(context.Background())
+
+	assert.Equal(t, HealthStatusDegraded, report.Status)
+	assert.True(t, report.Ready(), "degraded dependencies still serve traffic")
+	assert.Equal(t, HealthStatusDegraded, report.Components["storage"].Status)
+	assert.Empty(t, report.Components["storage"].Error)
+	assert.GreaterOrEqual(t, report.Components["storage"].LatencyMs, float64(20))
+}
+
+func TestHealthAggregator_FailingComponentIsUnhealthy(t *testing.T) {
+	aggregator := NewHealthAggregator(&config.HealthCheckConfig{Timeout: time.Second})
+	aggregator.AddComponent("database", time.Millisecond, slowProbe(20*time.Millisecond))
+	aggregator.AddComponent("redis", time.Second, func(ctx context.Context) error {
+		return fmt.Errorf("connection refused")
+	})
+
+	report := aggregator.Check(context.Background())
+
+	assert.Equal(t, HealthStatusUnhealthy, report.Status, "the worst component wins")
+	assert.False(t, report.Ready())
+	assert.Equal(t, HealthStatusDegraded, report.Components["database"].Status)
+	assert.Equal(t, HealthStatusUnhealthy, report.Components["redis"].Status)
+	assert.Equal(t, "connection refused", report.Components["redis"].Error)
+}
+
+func TestHealthAggregator_TimesOutHungProbes(t *testing.T) {
+	aggregator := NewHealthAggregator(&config.HealthCheckConfig{Timeout: 20 * time.Millisecond})
+	hang := make(chan struct{})
+	defer close(hang)
+	aggregator.AddComponent("storage", 0, func(ctx context.Context) error {
+		// Ignores its context, as a misbehaving client might
+		<-hang
+		return nil
+	})
+
+	start := time.Now()
+	report := aggregator.Check(context.Background())
+
+	assert.Less(t, time.Since(start), time.Second)
+	assert.Equal(t, HealthStatusUnhealthy, report.Components["storage"].Status)
+	assert.Equal(t, context.DeadlineExceeded.Error(), report.Components["storage"].Error)
+}
+
+func TestHealthAggregator_ReportsReadOnlyMode(t *testing.T) {
+	aggregator := NewHealthAggregator(&config.HealthCheckConfig{Timeout: time.Second})
+	aggregator.AddComponent("database", time.Second, healthyProbe)
+
+	assert.False(t, aggregator.Check(context.Background()).ReadOnly)
+
+	aggregator.SetReadOnlyCheck(func(ctx context.Context) bool { return true })
+	report := aggregator.Check(context.Background())
+
+	assert.True(t, report.ReadOnly)
+	assert.True(t, report.Ready(), "a read-only API still serves reads")
+}
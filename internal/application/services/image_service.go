@@ -5,6 +5,8 @@ import (
 	"context"
 	"fmt"
 	"image"
+	"image/color"
+	"image/draw"
 	"image/jpeg"
 	"image/png"
 	"io"
@@ -17,7 +19,11 @@ import (
 	"github.com/google/uuid"
 	"github.com/22smeargle/winkr-backend/pkg/config"
 	"github.com/22smeargle/winkr-backend/pkg/logger"
-	"golang.org/x/image/webp"
+	xdraw "golang.org/x/image/draw"
+	"golang.org/x/image/font"
+	"golang.org/x/image/font/basicfont"
+	"golang.org/x/image/math/fixed"
+	_ "golang.org/x/image/webp"
 )
 
 // ImageProcessingService defines interface for image processing operations
@@ -462,20 +468,72 @@ func (p *ImageProcessor) resizeImage(img image.Image, width, height int) image.I
 	return imaging.Resize(img, width, height, imaging.Lanczos)
 }
 
-// addWatermark adds a text watermark to an image
+// addWatermark stamps the text onto opposite corners of the image, so cropping one corner away still
+// leaves a copy. Each line of the text is a line of the stamp, which is scaled with the image.
 func (p *ImageProcessor) addWatermark(img image.Image, text string) image.Image {
-	// This is a simplified watermark implementation
-	// In production, you might want to use a more sophisticated approach
+	lines := strings.Split(strings.TrimSpace(text), "\n")
+	if len(lines) == 0 || lines[0] == "" {
+		return imaging.Clone(img)
+	}
+
 	bounds := img.Bounds()
-	
-	// Create a new image with watermark
-	watermarked := imaging.Clone(img)
-	
-	// For now, just return the original image
-	// TODO: Implement proper watermarking
+	watermarked := image.NewRGBA(image.Rect(0, 0, bounds.Dx(), bounds.Dy()))
+	draw.Draw(watermarked, watermarked.Bounds(), img, bounds.Min, draw.Src)
+
+	stamp := renderWatermarkStamp(lines)
+
+	// The stamp takes up about a third of the image's width, but is never shrunk below its font size
+	scale := float64(watermarked.Bounds().Dx()) / 3 / float64(stamp.Bounds().Dx())
+	if scale < 1 {
+		scale = 1
+	}
+	width := int(float64(stamp.Bounds().Dx()) * scale)
+	height := int(float64(stamp.Bounds().Dy()) * scale)
+	margin := int(watermarkMargin * scale)
+
+	corners := []image.Rectangle{
+		image.Rect(margin, margin, margin+width, margin+height),
+		image.Rect(bounds.Dx()-margin-width, bounds.Dy()-margin-height, bounds.Dx()-margin, bounds.Dy()-margin),
+	}
+	for _, corner := range corners {
+		xdraw.NearestNeighbor.Scale(watermarked, corner, stamp, stamp.Bounds(), draw.Over, nil)
+	}
+
 	return watermarked
 }
 
+// watermarkMargin is the space between a watermark stamp and the image's edges, in font pixels
+const watermarkMargin = 4
+
+// renderWatermarkStamp renders the lines as light text on a translucent dark background
+func renderWatermarkStamp(lines []string) *image.RGBA {
+	face := basicfont.Face7x13
+	lineHeight := face.Metrics().Height.Ceil()
+	padding := 3
+
+	width := 0
+	for _, line := range lines {
+		if lineWidth := font.MeasureString(face, line).Ceil(); lineWidth > width {
+			width = lineWidth
+		}
+	}
+
+	stamp := image.NewRGBA(image.Rect(0, 0, width+2*padding, len(lines)*lineHeight+2*padding))
+	draw.Draw(stamp, stamp.Bounds(), image.NewUniform(color.NRGBA{A: 96}), image.Point{}, draw.Src)
+
+	drawer := &font.Drawer{
+		Dst:  stamp,
+		Src:  image.NewUniform(color.NRGBA{R: 255, G: 255, B: 255, A: 192}),
+		Face: face,
+	}
+	for i, line := range lines {
+		drawer.Dot = fixed.P(padding, padding+i*lineHeight+face.Metrics().Ascent.Ceil())
+		drawer.DrawString(line)
+	}
+
+	return stamp
+}
+
 // encodeImage encodes an image to the specified format
 func (p *ImageProcessor) encodeImage(w io.Writer, img image.Image, format string, quality int) error {
 	switch strings.ToLower(format) {
@@ -484,7 +542,8 @@ func (p *ImageProcessor) encodeImage(w io.Writer, img image.Image, format string
 	case "png":
 		return png.Encode(w, img)
 	case "webp":
-		return webp.Encode(w, img, &webp.Options{Quality: float32(quality)})
+		// x/image/webp only decodes, so WebP images are re-encoded as JPEG
+		return jpeg.Encode(w, img, &jpeg.Options{Quality: quality})
 	default:
 		// Default to JPEG
 		return jpeg.Encode(w, img, &jpeg.Options{Quality: quality})
@@ -0,0 +1,144 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/22smeargle/winkr-backend/internal/domain/entities"
+	"github.com/22smeargle/winkr-backend/internal/domain/repositories"
+	"github.com/22smeargle/winkr-backend/pkg/config"
+	"github.com/22smeargle/winkr-backend/pkg/errors"
+)
+
+// Actions that can be gated behind acknowledging the required legal notices
+const (
+	NoticeActionMessaging = "messaging"
+	NoticeActionDiscovery = "discovery"
+)
+
+// LegalNoticeService tracks which community guideline and safety notices users have acknowledged
+// and whether gated actions are allowed yet
+type LegalNoticeService struct {
+	ackRepo repositories.NoticeAcknowledgementRepository
+	config  *config.LegalConfig
+}
+
+// NewLegalNoticeService creates a new LegalNoticeService
+func NewLegalNoticeService(ackRepo repositories.NoticeAcknowledgementRepository, cfg *config.LegalConfig) *LegalNoticeService {
+	return &LegalNoticeService{
+		ackRepo: ackRepo,
+		config:  cfg,
+	}
+}
+
+// GetPendingNotices returns the notices whose current version the user has not acknowledged yet
+func (s *LegalNoticeService) GetPendingNotices(ctx context.Context, userID uuid.UUID) ([]*entities.LegalNotice, error) {
+	acknowledgements, err := s.ackRepo.GetByUserID(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get notice acknowledgements: %w", err)
+	}
+
+	pending := make([]*entities.LegalNotice, 0)
+	for _, notice := range s.notices() {
+		if !isAcknowledged(notice, acknowledgements) {
+			pending = append(pending, notice)
+		}
+	}
+
+	return pending, nil
+}
+
+// Acknowledge records that the user acknowledged a notice. The version must be the current one,
+// so users cannot acknowledge text they were not shown.
+func (s *LegalNoticeService) Acknowledge(ctx context.Context, userID uuid.UUID, noticeID string, version int) (*entities.NoticeAcknowledgement, error) {
+	notice := s.getNotice(noticeID)
+	if notice == nil {
+		return nil, errors.NewNotFoundError("Notice")
+	}
+
+	if version != notice.Version {
+		return nil, errors.NewConflictError(fmt.Sprintf("Notice %s is at version %d, please review the latest version", notice.ID, notice.Version))
+	}
+
+	acknowledgement := &entities.NoticeAcknowledgement{
+		ID:             uuid.New(),
+		UserID:         userID,
+		NoticeID:       notice.ID,
+		Version:        notice.Version,
+		AcknowledgedAt: time.Now(),
+	}
+
+	if err := s.ackRepo.Create(ctx, acknowledgement); err != nil {
+		return nil, fmt.Errorf("failed to save notice acknowledgement: %w", err)
+	}
+
+	return acknowledgement, nil
+}
+
+// IsGated reports whether an action waits for the required notices to be acknowledged
+func (s *LegalNoticeService) IsGated(action string) bool {
+	for _, gated := range s.config.GatedActions {
+		if gated == action {
+			return true
+		}
+	}
+	return false
+}
+
+// CanPerform reports whether the user may perform an action, i.e. the action is not gated
+// or the user acknowledged the current version of every required notice
+func (s *LegalNoticeService) CanPerform(ctx context.Context, userID uuid.UUID, action string) (bool, error) {
+	if !s.IsGated(action) {
+		return true, nil
+	}
+
+	pending, err := s.GetPendingNotices(ctx, userID)
+	if err != nil {
+		return false, err
+	}
+
+	for _, notice := range pending {
+		if notice.Required {
+			return false, nil
+		}
+	}
+
+	return true, nil
+}
+
+// notices returns the configured notices
+func (s *LegalNoticeService) notices() []*entities.LegalNotice {
+	notices := make([]*entities.LegalNotice, len(s.config.Notices))
+	for i, notice := range s.config.Notices {
+		notices[i] = &entities.LegalNotice{
+			ID:       notice.ID,
+			Version:  notice.Version,
+			Title:    notice.Title,
+			URL:      notice.URL,
+			Required: notice.Required,
+		}
+	}
+	return notices
+}
+
+// getNotice returns the configured notice with the given ID, or nil if there is none
+func (s *LegalNoticeService) getNotice(noticeID string) *entities.LegalNotice {
+	for _, notice := range s.notices() {
+		if notice.ID == noticeID {
+			return notice
+		}
+	}
+	return nil
+}
+
+// isAcknowledged checks whether any of the acknowledgements covers the notice's current version
+func isAcknowledged(notice *entities.LegalNotice, acknowledgements []*entities.NoticeAcknowledgement) bool {
+	for _, acknowledgement := range acknowledgements {
+		if acknowledgement.Covers(notice) {
+			return true
+		}
+	}
+	return false
+}
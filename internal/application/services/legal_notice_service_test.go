@@ -0,0 +1,121 @@
+package services
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/22smeargle/winkr-backend/internal/domain/entities"
+	"github.com/22smeargle/winkr-backend/pkg/config"
+)
+
+// inMemoryNoticeAcknowledgementRepository is an in-memory NoticeAcknowledgementRepository for tests
+type inMemoryNoticeAcknowledgementRepository struct {
+	acknowledgements []*entities.NoticeAcknowledgement
+}
+
+func (r *inMemoryNoticeAcknowledgementRepository) Create(ctx context.Context, acknowledgement *entities.NoticeAcknowledgement) error {
+	r.acknowledgements = append(r.acknowledgements, acknowledgement)
+	return nil
+}
+
+func (r *inMemoryNoticeAcknowledgementRepository) GetByUserID(ctx context.Context, userID uuid.UUID) ([]*entities.NoticeAcknowledgement, error) {
+	var result []*entities.NoticeAcknowledgement
+	for _, acknowledgement := range r.acknowledgements {
+		if acknowledgement.UserID == userID {
+			result = append(result, acknowledgement)
+		}
+	}
+	return result, nil
+}
+
+func testLegalConfig() *config.LegalConfig {
+	return &config.LegalConfig{
+		Notices: []config.LegalNoticeConfig{
+			{ID: "community_guidelines", Version: 1, Title: "Community Guidelines", Required: true},
+			{ID: "safety_tips", Version: 1, Title: "Safety Tips", Required: false},
+		},
+		GatedActions: []string{NoticeActionMessaging, NoticeActionDiscovery},
+	}
+}
+
+func TestLegalNoticeService_GatesUntilAcknowledged(t *testing.T) {
+	ctx := context.Background()
+	userID := uuid.New()
+	service := NewLegalNoticeService(&inMemoryNoticeAcknowledgementRepository{}, testLegalConfig())
+
+	pending, err := service.GetPendingNotices(ctx, userID)
+	require.NoError(t, err)
+	assert.Len(t, pending, 2)
+
+	for _, action := range []string{NoticeActionMessaging, NoticeActionDiscovery} {
+		allowed, err := service.CanPerform(ctx, userID, action)
+		require.NoError(t, err)
+		assert.False(t, allowed, action)
+	}
+
+	_, err = service.Acknowledge(ctx, userID, "community_guidelines", 1)
+	require.NoError(t, err)
+
+	// The optional safety tips stay pending but do not gate anything
+	pending, err = service.GetPendingNotices(ctx, userID)
+	require.NoError(t, err)
+	require.Len(t, pending, 1)
+	assert.Equal(t, "safety_tips", pending[0].ID)
+
+	for _, action := range []string{NoticeActionMessaging, NoticeActionDiscovery} {
+		allowed, err := service.CanPerform(ctx, userID, action)
+		require.NoError(t, err)
+		assert.True(t, allowed, action)
+	}
+}
+
+func TestLegalNoticeService_VersionBumpPromptsAgain(t *testing.T) {
+	ctx := context.Background()
+	userID := uuid.New()
+	cfg := testLegalConfig()
+	service := NewLegalNoticeService(&inMemoryNoticeAcknowledgementRepository{}, cfg)
+
+	_, err := service.Acknowledge(ctx, userID, "community_guidelines", 1)
+	require.NoError(t, err)
+
+	cfg.Notices[0].Version = 2
+
+	pending, err := service.GetPendingNotices(ctx, userID)
+	require.NoError(t, err)
+	require.Len(t, pending, 2)
+	assert.Equal(t, "community_guidelines", pending[0].ID)
+	assert.Equal(t, 2, pending[0].Version)
+
+	allowed, err := service.CanPerform(ctx, userID, NoticeActionMessaging)
+	require.NoError(t, err)
+	assert.False(t, allowed)
+
+	// The old version can no longer be acknowledged
+	_, err = service.Acknowledge(ctx, userID, "community_guidelines", 1)
+	assert.Error(t, err)
+
+	_, err = service.Acknowledge(ctx, userID, "community_guidelines", 2)
+	require.NoError(t, err)
+
+	allowed, err = service.CanPerform(ctx, userID, NoticeActionMessaging)
+	require.NoError(t, err)
+	assert.True(t, allowed)
+}
+
+func TestLegalNoticeService_UnknownNotice(t *testing.T) {
+	service := NewLegalNoticeService(&inMemoryNoticeAcknowledgementRepository{}, testLegalConfig())
+
+	_, err := service.Acknowledge(context.Background(), uuid.New(), "privacy_policy", 1)
+	assert.Error(t, err)
+}
+
+func TestLegalNoticeService_UngatedActionAllowed(t *testing.T) {
+	service := NewLegalNoticeService(&inMemoryNoticeAcknowledgementRepository{}, testLegalConfig())
+
+	allowed, err := service.CanPerform(context.Background(), uuid.New(), "profile")
+	require.NoError(t, err)
+	assert.True(t, allowed)
+}
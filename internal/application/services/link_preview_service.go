@@ -0,0 +1,418 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"html"
+	"io"
+	"net"
+	"net/http"
+	"net/url"
+	"regexp"
+	"strings"
+	"syscall"
+	"time"
+	"unicode/utf8"
+
+	"github.com/google/uuid"
+	"github.com/22smeargle/winkr-backend/internal/domain/entities"
+	"github.com/22smeargle/winkr-backend/pkg/config"
+	"github.com/22smeargle/winkr-backend/pkg/logger"
+)
+
+// LinkPreviewCache stores fetched previews so each link is fetched at most once per TTL
+type LinkPreviewCache interface {
+	GetLinkPreview(ctx context.Context, link string) (*LinkPreview, error)
+	CacheLinkPreview(ctx context.Context, link string, preview *LinkPreview, ttl time.Duration) error
+}
+
+// LinkPreviewNotifier delivers the previews of a message that were fetched after it was sent
+type LinkPreviewNotifier interface {
+	NotifyLinkPreviews(ctx context.Context, conversationID, messageID uuid.UUID, previews []LinkPreview) error
+}
+
+const (
+	// defaultLinkPreviewTimeout bounds a page fetch when no timeout is configured
+	defaultLinkPreviewTimeout = 3 * time.Second
+	// maxLinkPreviewsPerMessage bounds how many links of one message are previewed
+	maxLinkPreviewsPerMessage = 3
+	// maxLinkPreviewRedirects bounds how many redirects a page fetch follows
+	maxLinkPreviewRedirects = 3
+	// maxLinkPreviewBodySize bounds how much of a page is read looking for its tags
+	maxLinkPreviewBodySize = 512 << 10
+
+	maxLinkPreviewTitleLength       = 200
+	maxLinkPreviewDescriptionLength = 500
+)
+
+var (
+	errLinkPreviewNotAllowed = errors.New("link is not allowed for previews")
+	errLinkPreviewPrivateIP  = errors.New("link resolves to a private address")
+
+	linkPattern      = regexp.MustCompile(`https?://[^\s<>"']+`)
+	metaTagPattern   = regexp.MustCompile(`(?is)<meta\s[^>]*>`)
+	tagAttrPattern   = regexp.MustCompile(`(?is)([a-z:_-]+)\s*=\s*(?:"([^"]*)"|'([^']*)')`)
+	titleTagPattern  = regexp.MustCompile(`(?is)<title[^>]*>(.*?)</title>`)
+	sharedAddressNet = &net.IPNet{IP: net.IPv4(100, 64, 0, 0), Mask: net.CIDRMask(10, 32)}
+)
+
+// LinkPreviewService builds previews of the links in chat messages from their OpenGraph tags.
+// Pages are only fetched from allowed, public hosts: blocked domains are skipped, and neither the
+// link nor any redirect may connect to a private address. Previews are best effort, sending a
+// message never waits for a page to be fetched.
+type LinkPreviewService struct {
+	cache    LinkPreviewCache
+	notifier LinkPreviewNotifier
+	security *config.ChatSecurityConfig
+	cacheTTL time.Duration
+	client   *http.Client
+
+	// isBlockedIP reports whether pages may not be fetched from an address
+	isBlockedIP func(ip net.IP) bool
+}
+
+// NewLinkPreviewService creates a new LinkPreviewService
+func NewLinkPreviewService(
+	cache LinkPreviewCache,
+	notifier LinkPreviewNotifier,
+	security *config.ChatSecurityConfig,
+	cacheConfig *config.ChatCacheConfig,
+) *LinkPreviewService {
+	s := &LinkPreviewService{
+		cache:       cache,
+		notifier:    notifier,
+		security:    security,
+		cacheTTL:    cacheConfig.LinkPreviewTTL,
+		isBlockedIP: isPrivateIP,
+	}
+
+	timeout := security.LinkPreviewTimeout
+	if timeout <= 0 {
+		timeout = defaultLinkPreviewTimeout
+	}
+
+	// Addresses are checked once resolved, so neither DNS nor a redirect can reach a private host
+	dialer := &net.Dialer{
+		Timeout: timeout,
+		Control: s.checkAddress,
+	}
+	s.client = &http.Client{
+		Timeout: timeout,
+		Transport: &http.Transport{
+			Proxy:                 nil,
+			DialContext:           dialer.DialContext,
+			TLSHandshakeTimeout:   timeout,
+			ResponseHeaderTimeout: timeout,
+			MaxIdleConns:          10,
+			IdleConnTimeout:       time.Minute,
+		},
+		CheckRedirect: s.checkRedirect,
+	}
+
+	return s
+}
+
+// Enabled reports whether link previews are turned on
+func (s *LinkPreviewService) Enabled() bool {
+	return s != nil && s.security != nil && s.security.LinkPreviewEnabled
+}
+
+// Generate returns the previews of the message's links that are already cached. Links that are
+// not are fetched in the background, and once they are, all of the message's previews are pushed
+// to the conversation.
+func (s *LinkPreviewService) Generate(ctx context.Context, message *entities.Message) []LinkPreview {
+	if !s.Enabled() || message.MessageType != "text" || message.IsDeleted {
+		return nil
+	}
+
+	links := s.previewLinks(message.Content)
+	if len(links) == 0 {
+		return nil
+	}
+
+	previews := make([]LinkPreview, 0, len(links))
+	fetched := true
+	for _, link := range links {
+		preview, ok := s.cached(ctx, link)
+		if !ok {
+			fetched = false
+			continue
+		}
+		if preview != nil {
+			previews = append(previews, *preview)
+		}
+	}
+
+	if !fetched {
+		go s.fetchAndNotify(logger.DetachContext(ctx), message.ConversationID, message.ID, links)
+	}
+
+	return previews
+}
+
+// fetchAndNotify fetches the previews of the links and pushes them to the conversation if any
+// were found. The context must not be the request's, which is cancelled once the message is sent.
+func (s *LinkPreviewService) fetchAndNotify(ctx context.Context, conversationID, messageID uuid.UUID, links []string) {
+	previews := make([]LinkPreview, 0, len(links))
+	for _, link := range links {
+		if preview := s.preview(ctx, link); preview != nil {
+			previews = append(previews, *preview)
+		}
+	}
+
+	if len(previews) == 0 || s.notifier == nil {
+		return
+	}
+
+	if err := s.notifier.NotifyLinkPreviews(ctx, conversationID, messageID, previews); err != nil {
+		logger.WarnContext(ctx, "Failed to push link previews", "message_id", messageID, "error", err)
+	}
+}
+
+// preview returns the link's preview from the cache or by fetching the page, or nil if the page
+// has none or cannot be fetched
+func (s *LinkPreviewService) preview(ctx context.Context, link string) *LinkPreview {
+	if preview, ok := s.cached(ctx, link); ok {
+		return preview
+	}
+
+	preview, err := s.fetch(ctx, link)
+	if err != nil {
+		logger.WarnContext(ctx, "Failed to fetch link preview", "link", link, "error", err)
+		return nil
+	}
+
+	// Pages without a preview are cached too, so they are not fetched again
+	s.store(ctx, link, preview)
+	if preview.Title == "" {
+		return nil
+	}
+	return preview
+}
+
+// fetch downloads the page and builds its preview. The preview has no title if the page has none.
+func (s *LinkPreviewService) fetch(ctx context.Context, link string) (*LinkPreview, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, link, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("Accept", "text/html")
+	req.Header.Set("User-Agent", "WinkrLinkPreview/1.0")
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return nil, fmt.Errorf("unexpected status %d", resp.StatusCode)
+	}
+	if !strings.Contains(strings.ToLower(resp.Header.Get("Content-Type")), "text/html") {
+		return &LinkPreview{URL: link}, nil
+	}
+
+	body, err := io.ReadAll(io.LimitReader(resp.Body, maxLinkPreviewBodySize))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read page: %w", err)
+	}
+
+	preview := parseLinkPreview(string(body), resp.Request.URL)
+	preview.URL = link
+	return preview, nil
+}
+
+// previewLinks returns the distinct links in the content that may be previewed, in order
+func (s *LinkPreviewService) previewLinks(content string) []string {
+	var links []string
+	seen := make(map[string]bool)
+
+	for _, match := range linkPattern.FindAllString(content, -1) {
+		link := strings.TrimRight(match, ".,;:!?)]}")
+		if seen[link] {
+			continue
+		}
+		seen[link] = true
+
+		parsed, err := url.Parse(link)
+		if err != nil || !s.allowedURL(parsed) {
+			continue
+		}
+
+		links = append(links, link)
+		if len(links) == maxLinkPreviewsPerMessage {
+			break
+		}
+	}
+
+	return links
+}
+
+// allowedURL reports whether pages may be fetched from the URL's host. Blocked domains and their
+// subdomains never are. When allowed domains are configured, only they and their subdomains are.
+func (s *LinkPreviewService) allowedURL(u *url.URL) bool {
+	if u.Scheme != "http" && u.Scheme != "https" {
+		return false
+	}
+
+	host := strings.ToLower(u.Hostname())
+	if host == "" {
+		return false
+	}
+	if ip := net.ParseIP(host); ip != nil && s.isBlockedIP(ip) {
+		return false
+	}
+
+	if matchesLinkDomain(host, s.security.BlockedLinkDomains) {
+		return false
+	}
+	if len(s.security.AllowedLinkDomains) > 0 && !matchesLinkDomain(host, s.security.AllowedLinkDomains) {
+		return false
+	}
+
+	return true
+}
+
+// checkRedirect only follows a few redirects, and only to links that may be previewed
+func (s *LinkPreviewService) checkRedirect(req *http.Request, via []*http.Request) error {
+	if len(via) >= maxLinkPreviewRedirects {
+		return fmt.Errorf("stopped after %d redirects", maxLinkPreviewRedirects)
+	}
+	if !s.allowedURL(req.URL) {
+		return errLinkPreviewNotAllowed
+	}
+	return nil
+}
+
+// checkAddress refuses connections to private addresses, after the host name is resolved
+func (s *LinkPreviewService) checkAddress(network, address string, _ syscall.RawConn) error {
+	host, _, err := net.SplitHostPort(address)
+	if err != nil {
+		return err
+	}
+
+	ip := net.ParseIP(host)
+	if ip == nil || s.isBlockedIP(ip) {
+		return errLinkPreviewPrivateIP
+	}
+	return nil
+}
+
+// cached returns a cached preview. A cached preview without a title means the page has none.
+func (s *LinkPreviewService) cached(ctx context.Context, link string) (*LinkPreview, bool) {
+	if s.cache == nil {
+		return nil, false
+	}
+
+	preview, err := s.cache.GetLinkPreview(ctx, link)
+	if err != nil || preview == nil {
+		return nil, false
+	}
+	if preview.Title == "" {
+		return nil, true
+	}
+	return preview, true
+}
+
+// store caches a preview, logging rather than failing on errors
+func (s *LinkPreviewService) store(ctx context.Context, link string, preview *LinkPreview) {
+	if s.cache == nil {
+		return
+	}
+
+	if err := s.cache.CacheLinkPreview(ctx, link, preview, s.cacheTTL); err != nil {
+		logger.Warn("Failed to cache link preview", "link", link, "error", err)
+	}
+}
+
+// parseLinkPreview builds a preview from the page's OpenGraph tags, falling back to its title and
+// description meta tags. Relative image URLs are resolved against the page's URL.
+func parseLinkPreview(page string, pageURL *url.URL) *LinkPreview {
+	tags := make(map[string]string)
+	for _, tag := range metaTagPattern.FindAllString(page, -1) {
+		attrs := make(map[string]string)
+		for _, attr := range tagAttrPattern.FindAllStringSubmatch(tag, -1) {
+			attrs[strings.ToLower(attr[1])] = attr[2] + attr[3]
+		}
+
+		name := attrs["property"]
+		if name == "" {
+			name = attrs["name"]
+		}
+		name = strings.ToLower(strings.TrimSpace(name))
+		content := strings.TrimSpace(html.UnescapeString(attrs["content"]))
+		if name == "" || content == "" {
+			continue
+		}
+		if _, exists := tags[name]; !exists {
+			tags[name] = content
+		}
+	}
+
+	preview := &LinkPreview{
+		Title:       firstNonEmpty(tags["og:title"], tags["twitter:title"]),
+		Description: firstNonEmpty(tags["og:description"], tags["twitter:description"], tags["description"]),
+		SiteName:    tags["og:site_name"],
+	}
+
+	if preview.Title == "" {
+		if match := titleTagPattern.FindStringSubmatch(page); match != nil {
+			preview.Title = strings.Join(strings.Fields(html.UnescapeString(match[1])), " ")
+		}
+	}
+	preview.Title = truncateRunes(preview.Title, maxLinkPreviewTitleLength)
+	preview.Description = truncateRunes(preview.Description, maxLinkPreviewDescriptionLength)
+
+	if image := firstNonEmpty(tags["og:image"], tags["og:image:url"], tags["twitter:image"]); image != "" {
+		if imageURL, err := pageURL.Parse(image); err == nil && (imageURL.Scheme == "http" || imageURL.Scheme == "https") {
+			preview.Image = imageURL.String()
+		}
+	}
+
+	return preview
+}
+
+// matchesLinkDomain reports whether the host is one of the domains or a subdomain of one
+func matchesLinkDomain(host string, domains []string) bool {
+	for _, domain := range domains {
+		domain = strings.TrimPrefix(strings.ToLower(strings.TrimSpace(domain)), ".")
+		if domain == "" {
+			continue
+		}
+		if host == domain || strings.HasSuffix(host, "."+domain) {
+			return true
+		}
+	}
+	return false
+}
+
+// isPrivateIP reports whether the address is not on the public internet
+func isPrivateIP(ip net.IP) bool {
+	return ip.IsLoopback() ||
+		ip.IsPrivate() ||
+		ip.IsUnspecified() ||
+		ip.IsLinkLocalUnicast() ||
+		ip.IsLinkLocalMulticast() ||
+		ip.IsInterfaceLocalMulticast() ||
+		ip.IsMulticast() ||
+		sharedAddressNet.Contains(ip)
+}
+
+// firstNonEmpty returns the first of the values that is not empty
+func firstNonEmpty(values ...string) string {
+	for _, value := range values {
+		if value != "" {
+			return value
+		}
+	}
+	return ""
+}
+
+// truncateRunes shortens the text to at most limit characters
+func truncateRunes(text string, limit int) string {
+	if utf8.RuneCountInString(text) <= limit {
+		return text
+	}
+	return string([]rune(text)[:limit])
+}
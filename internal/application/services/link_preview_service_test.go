@@ -0,0 +1,206 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/22smeargle/winkr-backend/internal/domain/entities"
+	"github.com/22smeargle/winkr-backend/pkg/config"
+)
+
+// inMemoryLinkPreviewCache is an in-memory LinkPreviewCache for tests
+type inMemoryLinkPreviewCache struct {
+	mu       sync.Mutex
+	previews map[string]*LinkPreview
+}
+
+func (c *inMemoryLinkPreviewCache) GetLinkPreview(ctx context.Context, link string) (*LinkPreview, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	preview, ok := c.previews[link]
+	if !ok {
+		return nil, errors.New("cache miss")
+	}
+	copied := *preview
+	return &copied, nil
+}
+
+func (c *inMemoryLinkPreviewCache) CacheLinkPreview(ctx context.Context, link string, preview *LinkPreview, ttl time.Duration) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.previews[link] = preview
+	return nil
+}
+
+// linkPreviewPush is one NotifyLinkPreviews call
+type linkPreviewPush struct {
+	conversationID uuid.UUID
+	messageID      uuid.UUID
+	previews       []LinkPreview
+}
+
+// channelLinkPreviewNotifier sends every push to a channel
+type channelLinkPreviewNotifier chan linkPreviewPush
+
+func (n channelLinkPreviewNotifier) NotifyLinkPreviews(ctx context.Context, conversationID, messageID uuid.UUID, previews []LinkPreview) error {
+	n <- linkPreviewPush{conversationID: conversationID, messageID: messageID, previews: previews}
+	return nil
+}
+
+const ogPage = `<!DOCTYPE html>
+<html><head>
+<title>Fallback title</title>
+<meta property="og:title" content="Tacos &amp; Tequila">
+<meta property='og:description' content='The best tacos in town'>
+<meta content="/img/cover.jpg" property="og:image">
+<meta property="og:site_name" content="Eat Out">
+</head><body>Menu</body></html>`
+
+// newLinkPreviewFixture returns a service that may fetch from loopback addresses, so it can reach
+// test servers, and a server serving pages that counts its requests
+func newLinkPreviewFixture(t *testing.T, security *config.ChatSecurityConfig, handler http.HandlerFunc) (*LinkPreviewService, *httptest.Server, *int32) {
+	var hits int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&hits, 1)
+		handler(w, r)
+	}))
+	t.Cleanup(server.Close)
+
+	security.LinkPreviewEnabled = true
+	service := NewLinkPreviewService(
+		&inMemoryLinkPreviewCache{previews: make(map[string]*LinkPreview)},
+		nil,
+		security,
+		&config.ChatCacheConfig{LinkPreviewTTL: time.Hour},
+	)
+	service.isBlockedIP = func(ip net.IP) bool {
+		return !ip.IsLoopback() && isPrivateIP(ip)
+	}
+	return service, server, &hits
+}
+
+func servePage(page string) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/html; charset=utf-8")
+		fmt.Fprint(w, page)
+	}
+}
+
+func TestLinkPreviewService_ParsesOpenGraphTagsAndCaches(t *testing.T) {
+	ctx := context.Background()
+	service, server, hits := newLinkPreviewFixture(t, &config.ChatSecurityConfig{}, servePage(ogPage))
+
+	preview := service.preview(ctx, server.URL+"/menu")
+	require.NotNil(t, preview)
+	assert.Equal(t, LinkPreview{
+		URL:         server.URL + "/menu",
+		Title:       "Tacos & Tequila",
+		Description: "The best tacos in town",
+		Image:       server.URL + "/img/cover.jpg",
+		SiteName:    "Eat Out",
+	}, *preview)
+
+	// The second preview of the link comes from the cache
+	assert.Equal(t, preview, service.preview(ctx, server.URL+"/menu"))
+	assert.Equal(t, int32(1), atomic.LoadInt32(hits))
+}
+
+func TestLinkPreviewService_PreviewLinksFollowsDomainLists(t *testing.T) {
+	service := NewLinkPreviewService(nil, nil, &config.ChatSecurityConfig{
+		LinkPreviewEnabled: true,
+		AllowedLinkDomains: []string{"example.com"},
+		BlockedLinkDomains: []string{"ads.example.com"},
+	}, &config.ChatCacheConfig{})
+
+	links := service.previewLinks("https://example.com/a. https://www.example.com/b " +
+		"https://ads.example.com/c https://notexample.com/d http://10.0.0.5/admin https://example.com/a")
+
+	assert.Equal(t, []string{"https://example.com/a", "https://www.example.com/b"}, links)
+}
+
+func TestLinkPreviewService_RefusesPrivateAddresses(t *testing.T) {
+	var hits int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&hits, 1)
+		servePage(ogPage)(w, r)
+	}))
+	defer server.Close()
+
+	service := NewLinkPreviewService(nil, nil, &config.ChatSecurityConfig{LinkPreviewEnabled: true}, &config.ChatCacheConfig{})
+
+	// Literal private addresses are not previewed at all
+	assert.Empty(t, service.previewLinks(server.URL+"/menu http://169.254.169.254/latest/meta-data"))
+
+	// Host names are checked once resolved
+	_, port, err := net.SplitHostPort(server.Listener.Addr().String())
+	require.NoError(t, err)
+	_, err = service.fetch(context.Background(), "http://localhost:"+port+"/menu")
+
+	assert.ErrorIs(t, err, errLinkPreviewPrivateIP)
+	assert.Zero(t, atomic.LoadInt32(&hits))
+}
+
+func TestLinkPreviewService_RefusesRedirectsToPrivateOrBlockedHosts(t *testing.T) {
+	service, server, _ := newLinkPreviewFixture(t, &config.ChatSecurityConfig{
+		BlockedLinkDomains: []string{"blocked.example"},
+	}, func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/metadata":
+			http.Redirect(w, r, "http://169.254.169.254/latest/meta-data", http.StatusFound)
+		case "/blocked":
+			http.Redirect(w, r, "https://blocked.example/page", http.StatusFound)
+		}
+	})
+
+	for _, path := range []string{"/metadata", "/blocked"} {
+		_, err := service.fetch(context.Background(), server.URL+path)
+		assert.ErrorIs(t, err, errLinkPreviewNotAllowed, path)
+		assert.Nil(t, service.preview(context.Background(), server.URL+path), path)
+	}
+}
+
+func TestLinkPreviewService_GenerateAttachesCachedAndPushesFetched(t *testing.T) {
+	ctx := context.Background()
+	service, server, _ := newLinkPreviewFixture(t, &config.ChatSecurityConfig{}, servePage(ogPage))
+	notifier := make(channelLinkPreviewNotifier, 1)
+	service.notifier = notifier
+
+	message := &entities.Message{
+		ID:             uuid.New(),
+		ConversationID: uuid.New(),
+		MessageType:    "text",
+		Content:        "dinner here? " + server.URL + "/menu",
+	}
+
+	// Sending does not wait for the page, the preview is pushed once fetched
+	assert.Empty(t, service.Generate(ctx, message))
+
+	select {
+	case push := <-notifier:
+		assert.Equal(t, message.ConversationID, push.conversationID)
+		assert.Equal(t, message.ID, push.messageID)
+		require.Len(t, push.previews, 1)
+		assert.Equal(t, "Tacos & Tequila", push.previews[0].Title)
+	case <-time.After(5 * time.Second):
+		t.Fatal("link preview was not pushed")
+	}
+
+	// Once cached, the preview goes out with the message and nothing is pushed
+	previews := service.Generate(ctx, message)
+	require.Len(t, previews, 1)
+	assert.Equal(t, "Tacos & Tequila", previews[0].Title)
+	assert.Empty(t, notifier)
+}
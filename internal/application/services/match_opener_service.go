@@ -0,0 +1,167 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/22smeargle/winkr-backend/internal/domain/entities"
+	"github.com/22smeargle/winkr-backend/internal/domain/repositories"
+	"github.com/22smeargle/winkr-backend/pkg/config"
+	"github.com/22smeargle/winkr-backend/pkg/logger"
+)
+
+// MatchOpenerNotifier tells both users of a new match how their conversation was opened
+type MatchOpenerNotifier interface {
+	NotifyMatchOpened(ctx context.Context, match *entities.Match, opening *MatchOpening) error
+}
+
+// MatchOpening is how the conversation of a new match was opened
+type MatchOpening struct {
+	Conversation *entities.Conversation `json:"conversation"`
+	Prompt       string                 `json:"prompt,omitempty"`
+	Intros       []*entities.Message    `json:"intros,omitempty"` // Auto-intros sent as first messages, in the order sent
+}
+
+// MatchOpenerService opens the conversation of a new match. Both users are shown the configured prompt,
+// and users who set up an auto-intro have it sent as their first message once it passes the content filter.
+type MatchOpenerService struct {
+	messageRepo repositories.MessageRepository
+	userRepo    repositories.UserRepository
+	analyzer    TextContentAnalyzer
+	notifier    MatchOpenerNotifier
+	config      *config.MatchOpenerConfig
+	now         func() time.Time
+}
+
+// NewMatchOpenerService creates a new MatchOpenerService
+func NewMatchOpenerService(
+	messageRepo repositories.MessageRepository,
+	userRepo repositories.UserRepository,
+	analyzer TextContentAnalyzer,
+	notifier MatchOpenerNotifier,
+	cfg *config.MatchOpenerConfig,
+) *MatchOpenerService {
+	return &MatchOpenerService{
+		messageRepo: messageRepo,
+		userRepo:    userRepo,
+		analyzer:    analyzer,
+		notifier:    notifier,
+		config:      cfg,
+		now:         time.Now,
+	}
+}
+
+// Enabled returns true if conversations of new matches are opened
+func (s *MatchOpenerService) Enabled() bool {
+	return s != nil && s.config != nil && s.config.Enabled
+}
+
+// OpenMatch opens the conversation of a new match and notifies both users. It returns nil when the
+// feature is off.
+func (s *MatchOpenerService) OpenMatch(ctx context.Context, match *entities.Match) (*MatchOpening, error) {
+	if !s.Enabled() {
+		return nil, nil
+	}
+
+	conversation, err := s.getOrCreateConversation(ctx, match)
+	if err != nil {
+		return nil, err
+	}
+
+	opening := &MatchOpening{
+		Conversation: conversation,
+		Prompt:       s.config.SystemPrompt,
+	}
+
+	if s.config.AutoIntros {
+		for _, userID := range []uuid.UUID{match.User1ID, match.User2ID} {
+			intro, err := s.sendAutoIntro(ctx, conversation, userID)
+			if err != nil {
+				return nil, err
+			}
+			if intro != nil {
+				opening.Intros = append(opening.Intros, intro)
+			}
+		}
+	}
+
+	if s.notifier != nil {
+		if err := s.notifier.NotifyMatchOpened(ctx, match, opening); err != nil {
+			logger.Error("Failed to notify match opening", err, "match_id", match.ID)
+		}
+	}
+
+	return opening, nil
+}
+
+// getOrCreateConversation returns the match's conversation, creating it if there is none yet
+func (s *MatchOpenerService) getOrCreateConversation(ctx context.Context, match *entities.Match) (*entities.Conversation, error) {
+	conversation, err := s.messageRepo.GetConversationByMatchID(ctx, match.ID)
+	if err == nil && conversation != nil {
+		return conversation, nil
+	}
+
+	now := s.now()
+	conversation = &entities.Conversation{
+		ID:        uuid.New(),
+		MatchID:   match.ID,
+		CreatedAt: now,
+		UpdatedAt: now,
+	}
+	if err := s.messageRepo.CreateConversation(ctx, conversation); err != nil {
+		return nil, fmt.Errorf("failed to create conversation: %w", err)
+	}
+
+	return conversation, nil
+}
+
+// sendAutoIntro sends the user's auto-intro as their first message. It returns nil if the user has
+// none, or if it no longer passes the content filter, in which case it is skipped rather than held.
+func (s *MatchOpenerService) sendAutoIntro(ctx context.Context, conversation *entities.Conversation, userID uuid.UUID) (*entities.Message, error) {
+	user, err := s.userRepo.GetByID(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get user: %w", err)
+	}
+
+	if user.AutoIntro == nil || *user.AutoIntro == "" {
+		return nil, nil
+	}
+
+	if s.analyzer != nil {
+		analysis, err := s.analyzer.AnalyzeContent(ctx, ContentRequest{
+			ID:        "auto_intro_" + userID.String(),
+			Type:      "text",
+			Content:   *user.AutoIntro,
+			UserID:    userID.String(),
+			Timestamp: s.now(),
+		})
+		if err != nil {
+			return nil, fmt.Errorf("failed to analyze auto-intro: %w", err)
+		}
+
+		if !analysis.IsApproved || len(analysis.Violations) > 0 {
+			logger.Info("Auto-intro failed content filter, not sent",
+				"user_id", userID,
+				"conversation_id", conversation.ID,
+				"violations", len(analysis.Violations),
+			)
+			return nil, nil
+		}
+	}
+
+	message := &entities.Message{
+		ID:             uuid.New(),
+		ConversationID: conversation.ID,
+		SenderID:       userID,
+		Content:        *user.AutoIntro,
+		MessageType:    "text",
+		CreatedAt:      s.now(),
+	}
+	if err := s.messageRepo.Create(ctx, message); err != nil {
+		return nil, fmt.Errorf("failed to send auto-intro: %w", err)
+	}
+
+	return message, nil
+}
@@ -0,0 +1,179 @@
+package services
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/22smeargle/winkr-backend/internal/domain/entities"
+	"github.com/22smeargle/winkr-backend/internal/domain/repositories"
+	"github.com/22smeargle/winkr-backend/pkg/config"
+)
+
+// openerMessageRepository keeps conversations and messages in memory
+type openerMessageRepository struct {
+	repositories.MessageRepository
+	conversations []*entities.Conversation
+	messages      []*entities.Message
+}
+
+func (r *openerMessageRepository) GetConversationByMatchID(ctx context.Context, matchID uuid.UUID) (*entities.Conversation, error) {
+	for _, conversation := range r.conversations {
+		if conversation.MatchID == matchID {
+			return conversation, nil
+		}
+	}
+	return nil, repositories.ErrNotFound
+}
+
+func (r *openerMessageRepository) CreateConversation(ctx context.Context, conversation *entities.Conversation) error {
+	r.conversations = append(r.conversations, conversation)
+	return nil
+}
+
+func (r *openerMessageRepository) Create(ctx context.Context, message *entities.Message) error {
+	r.messages = append(r.messages, message)
+	return nil
+}
+
+// openerUserRepository serves users from memory
+type openerUserRepository struct {
+	repositories.UserRepository
+	users map[uuid.UUID]*entities.User
+}
+
+func (r *openerUserRepository) GetByID(ctx context.Context, id uuid.UUID) (*entities.User, error) {
+	return r.users[id], nil
+}
+
+// recordingOpenerNotifier records the openings it is asked to deliver
+type recordingOpenerNotifier struct {
+	openings []*MatchOpening
+}
+
+func (n *recordingOpenerNotifier) NotifyMatchOpened(ctx context.Context, match *entities.Match, opening *MatchOpening) error {
+	n.openings = append(n.openings, opening)
+	return nil
+}
+
+type openerFixture struct {
+	service      *MatchOpenerService
+	messages     *openerMessageRepository
+	notifier     *recordingOpenerNotifier
+	analyzer     *fakeTextAnalyzer
+	match        *entities.Match
+	withIntro    *entities.User
+	withoutIntro *entities.User
+}
+
+const testOpenerPrompt = "It's a match! Say hi."
+
+func newOpenerFixture() *openerFixture {
+	intro := "Hi! Up for a hike this weekend?"
+	withIntro := &entities.User{ID: uuid.New(), FirstName: "Ana", AutoIntro: &intro}
+	withoutIntro := &entities.User{ID: uuid.New(), FirstName: "Ben"}
+	f := &openerFixture{
+		messages:     &openerMessageRepository{},
+		notifier:     &recordingOpenerNotifier{},
+		analyzer:     &fakeTextAnalyzer{},
+		match:        &entities.Match{ID: uuid.New(), User1ID: withoutIntro.ID, User2ID: withIntro.ID, IsActive: true},
+		withIntro:    withIntro,
+		withoutIntro: withoutIntro,
+	}
+	users := &openerUserRepository{users: map[uuid.UUID]*entities.User{withIntro.ID: withIntro, withoutIntro.ID: withoutIntro}}
+	f.service = NewMatchOpenerService(f.messages, users, f.analyzer, f.notifier, &config.MatchOpenerConfig{
+		Enabled:      true,
+		SystemPrompt: testOpenerPrompt,
+		AutoIntros:   true,
+	})
+	return f
+}
+
+func (f *openerFixture) open(t *testing.T) *MatchOpening {
+	t.Helper()
+	opening, err := f.service.OpenMatch(context.Background(), f.match)
+	require.NoError(t, err)
+	return opening
+}
+
+func TestMatchOpenerService_DeliversAutoIntro(t *testing.T) {
+	f := newOpenerFixture()
+
+	opening := f.open(t)
+
+	require.Len(t, f.messages.conversations, 1)
+	assert.Equal(t, f.match.ID, opening.Conversation.MatchID)
+	assert.Equal(t, testOpenerPrompt, opening.Prompt)
+
+	// Only the user who set up an auto-intro has a first message sent for them
+	require.Len(t, f.messages.messages, 1)
+	message := f.messages.messages[0]
+	assert.Equal(t, f.withIntro.ID, message.SenderID)
+	assert.Equal(t, opening.Conversation.ID, message.ConversationID)
+	assert.Equal(t, *f.withIntro.AutoIntro, message.Content)
+	assert.Equal(t, []*entities.Message{message}, opening.Intros)
+
+	require.Len(t, f.notifier.openings, 1)
+	assert.Same(t, opening, f.notifier.openings[0])
+}
+
+func TestMatchOpenerService_PromptOnlyWithoutAutoIntro(t *testing.T) {
+	f := newOpenerFixture()
+	f.withIntro.AutoIntro = nil
+
+	opening := f.open(t)
+
+	assert.Equal(t, testOpenerPrompt, opening.Prompt)
+	assert.Empty(t, opening.Intros)
+	assert.Empty(t, f.messages.messages)
+	require.Len(t, f.notifier.openings, 1)
+}
+
+func TestMatchOpenerService_FiltersAutoIntro(t *testing.T) {
+	f := newOpenerFixture()
+	f.analyzer.violations = map[string][]ContentViolation{
+		*f.withIntro.AutoIntro: {{Type: "pii", Severity: "medium"}},
+	}
+
+	opening := f.open(t)
+
+	assert.Empty(t, opening.Intros, "an auto-intro that fails the content filter is not sent")
+	assert.Empty(t, f.messages.messages)
+	assert.Equal(t, testOpenerPrompt, opening.Prompt)
+}
+
+func TestMatchOpenerService_OptIn(t *testing.T) {
+	t.Run("disabled", func(t *testing.T) {
+		f := newOpenerFixture()
+		f.service.config.Enabled = false
+
+		opening := f.open(t)
+
+		assert.Nil(t, opening)
+		assert.Empty(t, f.messages.conversations)
+		assert.Empty(t, f.notifier.openings)
+	})
+
+	t.Run("auto-intros disabled", func(t *testing.T) {
+		f := newOpenerFixture()
+		f.service.config.AutoIntros = false
+
+		opening := f.open(t)
+
+		assert.Equal(t, testOpenerPrompt, opening.Prompt)
+		assert.Empty(t, f.messages.messages)
+	})
+
+	t.Run("existing conversation is reused", func(t *testing.T) {
+		f := newOpenerFixture()
+		existing := &entities.Conversation{ID: uuid.New(), MatchID: f.match.ID}
+		f.messages.conversations = []*entities.Conversation{existing}
+
+		opening := f.open(t)
+
+		assert.Same(t, existing, opening.Conversation)
+		assert.Len(t, f.messages.conversations, 1)
+	})
+}
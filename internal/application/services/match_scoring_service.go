@@ -0,0 +1,152 @@
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/22smeargle/winkr-backend/pkg/config"
+	"github.com/22smeargle/winkr-backend/pkg/errors"
+	"github.com/22smeargle/winkr-backend/pkg/logger"
+)
+
+// matchScoringStateKey is the system state key of the match scoring weights
+const matchScoringStateKey = "match_scoring_weights"
+
+// matchScoringRefreshInterval is how long an instance trusts its copy of the weights before reading
+// the store again, which bounds how long instances rank differently after a change
+const matchScoringRefreshInterval = 30 * time.Second
+
+// MatchScoringWeights weigh the parts of the score discovery ranks users by. Only their ratios
+// matter: the score is the weighted average of the parts, each from 0 to 1.
+type MatchScoringWeights struct {
+	Distance          float64    `json:"distance"`
+	SharedInterests   float64    `json:"shared_interests"`
+	ActivityRecency   float64    `json:"activity_recency"`
+	ProfileCompletion float64    `json:"profile_completion"`
+	UpdatedBy         string     `json:"updated_by,omitempty"`
+	UpdatedAt         *time.Time `json:"updated_at,omitempty"`
+}
+
+// MatchScoringWeightsFromConfig returns the configured default weights
+func MatchScoringWeightsFromConfig(cfg *config.MatchScoringConfig) MatchScoringWeights {
+	return MatchScoringWeights{
+		Distance:          cfg.DistanceWeight,
+		SharedInterests:   cfg.SharedInterestsWeight,
+		ActivityRecency:   cfg.ActivityRecencyWeight,
+		ProfileCompletion: cfg.ProfileCompletionWeight,
+	}
+}
+
+// Total returns the sum of the weights
+func (w MatchScoringWeights) Total() float64 {
+	return w.Distance + w.SharedInterests + w.ActivityRecency + w.ProfileCompletion
+}
+
+// Key identifies the weights in cache keys, so cached rankings are not served after they change
+func (w MatchScoringWeights) Key() string {
+	return fmt.Sprintf("%g,%g,%g,%g", w.Distance, w.SharedInterests, w.ActivityRecency, w.ProfileCompletion)
+}
+
+// Validate checks that every weight is between 0 and 1 and at least one is above 0
+func (w MatchScoringWeights) Validate() error {
+	weights := map[string]float64{
+		"distance":           w.Distance,
+		"shared_interests":   w.SharedInterests,
+		"activity_recency":   w.ActivityRecency,
+		"profile_completion": w.ProfileCompletion,
+	}
+	for field, weight := range weights {
+		if weight < 0 || weight > 1 {
+			return errors.NewValidationError(field, "must be between 0 and 1")
+		}
+	}
+	if w.Total() == 0 {
+		return errors.NewValidationError("weights", "at least one weight must be above 0")
+	}
+	return nil
+}
+
+// MatchScoringService holds the weights discovery ranks users by. The configured defaults apply
+// until an admin sets weights, which are then shared by every instance.
+type MatchScoringService struct {
+	store SystemStateStore
+	now   func() time.Time
+
+	mu        sync.Mutex
+	weights   MatchScoringWeights
+	checkedAt time.Time
+}
+
+// NewMatchScoringService creates a match scoring service starting from the configured weights
+func NewMatchScoringService(cfg *config.MatchScoringConfig, store SystemStateStore) *MatchScoringService {
+	return &MatchScoringService{
+		store:   store,
+		now:     time.Now,
+		weights: MatchScoringWeightsFromConfig(cfg),
+	}
+}
+
+// Weights returns the weights, read from the store at most once per refresh interval. If the store
+// can't be read, the last known weights are kept.
+func (s *MatchScoringService) Weights(ctx context.Context) MatchScoringWeights {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := s.now()
+	if now.Sub(s.checkedAt) < matchScoringRefreshInterval {
+		return s.weights
+	}
+	s.checkedAt = now
+
+	value, err := s.store.Get(ctx, matchScoringStateKey)
+	if err != nil {
+		logger.Error("Failed to read match scoring weights", err)
+		return s.weights
+	}
+	if value == "" {
+		return s.weights
+	}
+
+	var stored MatchScoringWeights
+	if err := json.Unmarshal([]byte(value), &stored); err != nil {
+		logger.Error("Failed to decode match scoring weights", err)
+		return s.weights
+	}
+	s.weights = stored
+	return s.weights
+}
+
+// SetWeights changes the weights for every instance
+func (s *MatchScoringService) SetWeights(ctx context.Context, weights MatchScoringWeights, adminID string) (MatchScoringWeights, error) {
+	if err := weights.Validate(); err != nil {
+		return MatchScoringWeights{}, err
+	}
+
+	now := s.now()
+	weights.UpdatedBy = adminID
+	weights.UpdatedAt = &now
+	value, err := json.Marshal(weights)
+	if err != nil {
+		return MatchScoringWeights{}, fmt.Errorf("failed to encode match scoring weights: %w", err)
+	}
+	if err := s.store.Set(ctx, matchScoringStateKey, string(value)); err != nil {
+		return MatchScoringWeights{}, err
+	}
+
+	s.mu.Lock()
+	s.weights = weights
+	s.checkedAt = now
+	s.mu.Unlock()
+
+	logger.Info("Match scoring weights changed",
+		"distance", weights.Distance,
+		"shared_interests", weights.SharedInterests,
+		"activity_recency", weights.ActivityRecency,
+		"profile_completion", weights.ProfileCompletion,
+		"admin_id", adminID,
+	)
+	return weights, nil
+}
@@ -0,0 +1,70 @@
+package services
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/22smeargle/winkr-backend/pkg/config"
+	"github.com/22smeargle/winkr-backend/pkg/errors"
+)
+
+var testMatchScoringConfig = config.MatchScoringConfig{
+	DistanceWeight:          0.35,
+	SharedInterestsWeight:   0.35,
+	ActivityRecencyWeight:   0.15,
+	ProfileCompletionWeight: 0.15,
+}
+
+// newTestMatchScoringService returns a service whose clock the test advances
+func newTestMatchScoringService(store SystemStateStore) (*MatchScoringService, *time.Time) {
+	cfg := testMatchScoringConfig
+	service := NewMatchScoringService(&cfg, store)
+	now := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	service.now = func() time.Time { return now }
+	return service, &now
+}
+
+func TestMatchScoringService_SharedBetweenInstances(t *testing.T) {
+	ctx := context.Background()
+	store := newMemorySystemStateStore()
+	first, _ := newTestMatchScoringService(store)
+	second, secondNow := newTestMatchScoringService(store)
+
+	assert.Equal(t, MatchScoringWeightsFromConfig(&testMatchScoringConfig), second.Weights(ctx))
+
+	weights, err := first.SetWeights(ctx, MatchScoringWeights{Distance: 0.2, SharedInterests: 0.6, ActivityRecency: 0.2}, "admin-1")
+	require.NoError(t, err)
+	assert.Equal(t, "admin-1", weights.UpdatedBy)
+	require.NotNil(t, weights.UpdatedAt)
+	assert.Equal(t, weights, first.Weights(ctx))
+
+	// Test the other instance picks the change up once its copy is stale
+	assert.Equal(t, 0.35, second.Weights(ctx).Distance)
+	*secondNow = secondNow.Add(matchScoringRefreshInterval)
+	got := second.Weights(ctx)
+	assert.Equal(t, 0.2, got.Distance)
+	assert.Equal(t, 0.6, got.SharedInterests)
+	assert.Zero(t, got.ProfileCompletion)
+}
+
+func TestMatchScoringService_RejectsInvalidWeights(t *testing.T) {
+	ctx := context.Background()
+	service, _ := newTestMatchScoringService(newMemorySystemStateStore())
+
+	for _, weights := range []MatchScoringWeights{
+		{Distance: -0.1, SharedInterests: 0.5},
+		{Distance: 1.5},
+		{},
+	} {
+		_, err := service.SetWeights(ctx, weights, "admin-1")
+		var appErr *errors.AppError
+		require.ErrorAs(t, err, &appErr)
+		assert.Equal(t, http.StatusBadRequest, appErr.StatusCode())
+	}
+
+	assert.Equal(t, MatchScoringWeightsFromConfig(&testMatchScoringConfig), service.Weights(ctx))
+}
@@ -8,6 +8,7 @@ import (
 	"github.com/google/uuid"
 	"github.com/22smeargle/winkr-backend/internal/domain/entities"
 	"github.com/22smeargle/winkr-backend/internal/domain/repositories"
+	"github.com/22smeargle/winkr-backend/pkg/logger"
 )
 
 // MatchService handles match operations
@@ -16,6 +17,7 @@ type MatchService struct {
 	matchRepo    repositories.MatchRepository
 	swipeRepo    repositories.SwipeRepository
 	cacheService CacheService
+	opener       *MatchOpenerService
 }
 
 // NewMatchService creates a new MatchService
@@ -24,12 +26,14 @@ func NewMatchService(
 	matchRepo repositories.MatchRepository,
 	swipeRepo repositories.SwipeRepository,
 	cacheService CacheService,
+	opener *MatchOpenerService,
 ) *MatchService {
 	return &MatchService{
 		userRepo:     userRepo,
 		matchRepo:    matchRepo,
 		swipeRepo:    swipeRepo,
 		cacheService: cacheService,
+		opener:       opener,
 	}
 }
 
@@ -54,6 +58,8 @@ func (s *MatchService) CreateMatch(ctx context.Context, match *entities.Match) e
 	// Invalidate relevant caches
 	s.invalidateMatchCaches(ctx, match.User1ID, match.User2ID)
 
+	s.openMatch(ctx, match)
+
 	return nil
 }
 
@@ -100,6 +106,8 @@ func (s *MatchService) CheckForMatch(ctx context.Context, user1ID, user2ID uuid.
 		// Invalidate caches
 		s.invalidateMatchCaches(ctx, user1ID, user2ID)
 
+		s.openMatch(ctx, newMatch)
+
 		return true, newMatch, nil
 	}
 
@@ -244,7 +252,7 @@ func (s *MatchService) calculateDistance(user1, user2 *entities.User) float64 {
 	lat2, lng2, _ := user2.GetLocation()
 
 	// Use matching algorithm service's distance calculation
-	matchingService := NewMatchingAlgorithmService(s.userRepo, nil, s.matchRepo, s.cacheService)
+	matchingService := NewMatchingAlgorithmService(s.userRepo, nil, s.matchRepo, s.cacheService, nil, nil, nil)
 	return matchingService.calculateDistance(user1, user2)
 }
 
@@ -384,4 +392,11 @@ type MatchQuality struct {
 type UserMatchesCache struct {
 	Matches []*entities.Match `json:"matches"`
 	Total    int64            `json:"total"`
-}
\ No newline at end of file
+}
+
+// openMatch opens the conversation of a new match. The match stands even if this fails.
+func (s *MatchService) openMatch(ctx context.Context, match *entities.Match) {
+	if _, err := s.opener.OpenMatch(ctx, match); err != nil {
+		logger.Error("Failed to open match conversation", err, "match_id", match.ID)
+	}
+}
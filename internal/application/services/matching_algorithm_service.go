@@ -18,6 +18,9 @@ type MatchingAlgorithmService struct {
 	photoRepo    repositories.PhotoRepository
 	matchRepo    repositories.MatchRepository
 	cacheService CacheService
+	preferenceService *SwipePreferenceService
+	exposureService *DiscoveryExposureService
+	photoRequirement *PhotoRequirementService
 }
 
 // NewMatchingAlgorithmService creates a new MatchingAlgorithmService
@@ -26,12 +29,18 @@ func NewMatchingAlgorithmService(
 	photoRepo repositories.PhotoRepository,
 	matchRepo repositories.MatchRepository,
 	cacheService CacheService,
+	preferenceService *SwipePreferenceService,
+	exposureService *DiscoveryExposureService,
+	photoRequirement *PhotoRequirementService,
 ) *MatchingAlgorithmService {
 	return &MatchingAlgorithmService{
 		userRepo:    userRepo,
 		photoRepo:    photoRepo,
 		matchRepo:    matchRepo,
 		cacheService: cacheService,
+		preferenceService: preferenceService,
+		exposureService: exposureService,
+		photoRequirement: photoRequirement,
 	}
 }
 
@@ -45,6 +54,8 @@ type MatchingFilter struct {
 	InterestedIn   []string    `json:"interested_in"`
 	Verified       *bool       `json:"verified,omitempty"`
 	HasPhotos      *bool       `json:"has_photos,omitempty"`
+	Attributes     AttributeFilters `json:"attributes"`
+	ActiveWithin   time.Duration    `json:"active_within,omitempty"` // Only users active this recently, 0 for everyone
 	ExcludeUserIDs []uuid.UUID `json:"exclude_user_ids"`
 }
 
@@ -57,6 +68,7 @@ type UserScore struct {
 	Completion float64        `json:"completion"`
 	Verification float64      `json:"verification"`
 	Premium    float64        `json:"premium"`
+	Personalization float64   `json:"personalization"`
 }
 
 // GetPotentialMatches gets potential matches for a user
@@ -68,8 +80,9 @@ func (s *MatchingAlgorithmService) GetPotentialMatches(
 	limit, offset int,
 ) ([]*entities.User, int64, error) {
 	// Check cache first
-	cacheKey := s.generateCacheKey(user.ID, filter, excludeUserIDs, limit, offset)
+	cacheKey := s.generateCacheKey(user, filter, excludeUserIDs, limit, offset)
 	if cached, err := s.cacheService.GetPotentialMatches(ctx, cacheKey); err == nil && cached != nil {
+		s.recordImpressions(ctx, cached.Users)
 		return cached.Users, cached.Total, nil
 	}
 
@@ -79,6 +92,17 @@ func (s *MatchingAlgorithmService) GetPotentialMatches(
 		return nil, 0, fmt.Errorf("failed to get base candidates: %w", err)
 	}
 
+	// Narrow down by the height and lifestyle filters
+	candidates = filterCandidates(candidates, &filter.Attributes)
+
+	// Profiles without enough approved photos are not shown to anyone
+	if s.photoRequirement != nil {
+		candidates, err = s.photoRequirement.FilterEligible(ctx, candidates)
+		if err != nil {
+			return nil, 0, fmt.Errorf("failed to check candidate photos: %w", err)
+		}
+	}
+
 	// Score candidates
 	scoredUsers := s.scoreCandidates(ctx, user, candidates)
 
@@ -87,6 +111,11 @@ func (s *MatchingAlgorithmService) GetPotentialMatches(
 		return scoredUsers[i].Score > scoredUsers[j].Score
 	})
 
+	// Profiles that were already shown too often today go after everyone else
+	if s.exposureService != nil {
+		scoredUsers = s.exposureService.Deprioritize(ctx, scoredUsers)
+	}
+
 	// Apply pagination
 	start := offset
 	if start > len(scoredUsers) {
@@ -110,9 +139,18 @@ func (s *MatchingAlgorithmService) GetPotentialMatches(
 		Total: int64(len(candidates)),
 	}, 5*time.Minute)
 
+	s.recordImpressions(ctx, result)
+
 	return result, int64(len(candidates)), nil
 }
 
+// recordImpressions counts the returned profiles towards their daily exposure
+func (s *MatchingAlgorithmService) recordImpressions(ctx context.Context, users []*entities.User) {
+	if s.exposureService != nil {
+		s.exposureService.RecordImpressions(ctx, users)
+	}
+}
+
 // getBaseCandidates gets base candidates using database queries
 func (s *MatchingAlgorithmService) getBaseCandidates(
 	ctx context.Context,
@@ -123,32 +161,56 @@ func (s *MatchingAlgorithmService) getBaseCandidates(
 	// Combine exclude user IDs
 	allExcludes := append(filter.ExcludeUserIDs, excludeUserIDs...)
 
-	// Get candidates by location if user has location
+	// Get candidates by location if user has location, up to 1000 of them
 	if user.HasLocation() {
 		lat, lng, _ := user.GetLocation()
-		return s.userRepo.GetByLocation(ctx, lat, lng, filter.MaxDistance, 1000, 0) // Get up to 1000 candidates
+		if filter.ActiveWithin > 0 {
+			return s.userRepo.GetActiveByLocation(ctx, lat, lng, filter.MaxDistance, time.Now().Add(-filter.ActiveWithin), 1000, 0)
+		}
+		return s.userRepo.GetByLocation(ctx, lat, lng, filter.MaxDistance, 1000, 0)
 	}
 
 	// Fallback to preference-based search
-	return s.userRepo.GetUsersByPreferences(ctx, user.ID, &entities.UserPreferences{
+	candidates, err := s.userRepo.GetUsersByPreferences(ctx, user.ID, &entities.UserPreferences{
 		AgeMin:      filter.AgeMin,
 		AgeMax:      filter.AgeMax,
 		MaxDistance:  filter.MaxDistance,
 		ShowMe:      true,
 	}, 1000, 0)
+	if err != nil || filter.ActiveWithin <= 0 {
+		return candidates, err
+	}
+	return activeSince(candidates, time.Now().Add(-filter.ActiveWithin)), nil
+}
+
+// activeSince returns the candidates who were active at or after the given time
+func activeSince(candidates []*entities.User, since time.Time) []*entities.User {
+	active := make([]*entities.User, 0, len(candidates))
+	for _, candidate := range candidates {
+		if candidate.LastActive != nil && !candidate.LastActive.Before(since) {
+			active = append(active, candidate)
+		}
+	}
+	return active
 }
 
 // scoreCandidates scores candidates based on various factors
 func (s *MatchingAlgorithmService) scoreCandidates(ctx context.Context, currentUser *entities.User, candidates []*entities.User) []*UserScore {
 	scoredUsers := make([]*UserScore, 0, len(candidates))
 
+	// Load the learned swipe preferences once for the whole batch
+	var preferences *PreferenceVector
+	if s.preferenceService != nil {
+		preferences = s.preferenceService.GetPreferenceVector(ctx, currentUser.ID)
+	}
+
 	for _, candidate := range candidates {
 		// Skip if candidate doesn't meet basic criteria
 		if !s.meetsBasicCriteria(currentUser, candidate) {
 			continue
 		}
 
-		score := s.calculateScore(currentUser, candidate)
+		score := s.calculateScore(currentUser, candidate, preferences)
 		scoredUsers = append(scoredUsers, score)
 	}
 
@@ -162,8 +224,8 @@ func (s *MatchingAlgorithmService) meetsBasicCriteria(currentUser, candidate *en
 		return false
 	}
 
-	// Skip inactive/banned users
-	if !candidate.IsActive || candidate.IsBanned {
+	// Skip inactive/banned users, profiles held for moderation review and shadowbanned users
+	if !candidate.IsActive || candidate.IsBanned || candidate.ProfileUnderReview || candidate.Shadowbanned {
 		return false
 	}
 
@@ -192,7 +254,7 @@ func (s *MatchingAlgorithmService) meetsBasicCriteria(currentUser, candidate *en
 }
 
 // calculateScore calculates matching score for a candidate
-func (s *MatchingAlgorithmService) calculateScore(currentUser, candidate *entities.User) *UserScore {
+func (s *MatchingAlgorithmService) calculateScore(currentUser, candidate *entities.User, preferences *PreferenceVector) *UserScore {
 	distance := s.calculateDistance(currentUser, candidate)
 	recency := s.calculateRecencyScore(candidate)
 	completion := s.calculateCompletionScore(candidate)
@@ -209,6 +271,14 @@ func (s *MatchingAlgorithmService) calculateScore(currentUser, candidate *entiti
 	distanceScore := s.calculateDistanceScore(distance)
 	totalScore := (distanceScore * 0.30) + (recency * 0.20) + (completion * 0.20) + (verification * 0.15) + (premium * 0.15)
 
+	// Blend in the swipe preference vector once the user has swiped enough
+	personalization := 50.0
+	if s.preferenceService != nil && s.preferenceService.IsActive(preferences) {
+		personalization = s.preferenceService.Score(preferences, candidate)
+		weight := s.preferenceService.Weight()
+		totalScore = totalScore*(1-weight) + personalization*weight
+	}
+
 	return &UserScore{
 		User:        candidate,
 		Score:       totalScore,
@@ -217,6 +287,7 @@ func (s *MatchingAlgorithmService) calculateScore(currentUser, candidate *entiti
 		Completion:  completion,
 		Verification: verification,
 		Premium:     premium,
+		Personalization: personalization,
 	}
 }
 
@@ -300,7 +371,7 @@ func (s *MatchingAlgorithmService) calculateCompletionScore(user *entities.User)
 		score += 20
 	}
 
-	return score
+	return float64(score)
 }
 
 // calculateVerificationScore calculates score based on verification level
@@ -327,19 +398,28 @@ func (s *MatchingAlgorithmService) calculatePremiumScore(user *entities.User) fl
 
 // generateCacheKey generates cache key for potential matches
 func (s *MatchingAlgorithmService) generateCacheKey(
-	userID uuid.UUID,
+	user *entities.User,
 	filter *MatchingFilter,
 	excludeUserIDs []uuid.UUID,
 	limit, offset int,
 ) string {
-	return fmt.Sprintf("potential_matches:%s:%d:%d:%d:%s:%t:%t",
-		userID.String(),
+	// The location is part of the key as premium users can browse from somewhere else
+	location := "none"
+	if lat, lng, ok := user.GetLocation(); ok {
+		location = fmt.Sprintf("%.4f,%.4f", lat, lng)
+	}
+
+	return fmt.Sprintf("potential_matches:%s:%s:%d:%d:%d:%s:%t:%t:%s:%s",
+		user.ID.String(),
+		location,
 		filter.AgeMin,
 		filter.AgeMax,
 		filter.MaxDistance,
 		filter.Gender,
 		filter.Verified,
 		filter.HasPhotos,
+		filter.Attributes.Key(),
+		filter.ActiveWithin,
 	)
 }
 
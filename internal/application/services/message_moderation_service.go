@@ -0,0 +1,222 @@
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/22smeargle/winkr-backend/internal/domain/entities"
+	"github.com/22smeargle/winkr-backend/internal/infrastructure/database/redis"
+	"github.com/22smeargle/winkr-backend/pkg/config"
+	"github.com/22smeargle/winkr-backend/pkg/logger"
+)
+
+// Message moderation rules, returned to the client so it can show guidance
+const (
+	MessageModerationRuleBannedWord    = "banned_word"
+	MessageModerationRuleBannedPattern = "banned_pattern"
+	MessageModerationRulePII           = "pii"
+)
+
+// Message moderation actions
+const (
+	MessageModerationActionBlock = "block" // The message is rejected
+	MessageModerationActionFlag  = "flag"  // The message is sent and queued for review
+)
+
+// MessageModerationResult is why a message was blocked or flagged
+type MessageModerationResult struct {
+	Action   string `json:"action"`
+	Rule     string `json:"rule"`
+	Severity string `json:"severity"`
+	Guidance string `json:"guidance"`
+	pattern  string
+}
+
+// FlaggedMessage is a sent message waiting for a moderator to review it
+type FlaggedMessage struct {
+	MessageID      uuid.UUID `json:"message_id"`
+	ConversationID uuid.UUID `json:"conversation_id"`
+	SenderID       uuid.UUID `json:"sender_id"`
+	Rule           string    `json:"rule"`
+	Severity       string    `json:"severity"`
+	Pattern        string    `json:"pattern"` // The configured pattern that matched
+	FlaggedAt      time.Time `json:"flagged_at"`
+}
+
+// FlaggedMessageQueue holds flagged messages until they are reviewed
+type FlaggedMessageQueue interface {
+	Enqueue(ctx context.Context, flagged *FlaggedMessage) error
+}
+
+// moderationRule is a compiled pattern and what matching it means
+type moderationRule struct {
+	rule    string
+	pattern string
+	regex   *regexp.Regexp
+}
+
+// MessageModerationService screens chat messages against the configured banned words, banned
+// patterns and PII patterns before they are sent. Banned words and patterns are severe and block the
+// message; PII is borderline, so the message is sent and flagged for review. All patterns are
+// compiled once when the service is created.
+type MessageModerationService struct {
+	blockRules []moderationRule
+	flagRules  []moderationRule
+	queue      FlaggedMessageQueue
+}
+
+// NewMessageModerationService creates a new message moderation service. Patterns that don't compile
+// are logged and skipped.
+func NewMessageModerationService(queue FlaggedMessageQueue, cfg *config.ChatSecurityConfig) *MessageModerationService {
+	s := &MessageModerationService{queue: queue}
+	if cfg == nil || !cfg.ContentFilteringEnabled {
+		return s
+	}
+
+	if words := bannedWordsPattern(cfg.BannedWords); words != "" {
+		s.blockRules = appendModerationRule(s.blockRules, MessageModerationRuleBannedWord, words)
+	}
+	for _, pattern := range cfg.BannedPatterns {
+		s.blockRules = appendModerationRule(s.blockRules, MessageModerationRuleBannedPattern, pattern)
+	}
+
+	if cfg.PIIDetectionEnabled {
+		for _, pattern := range cfg.PIIPatterns {
+			s.flagRules = appendModerationRule(s.flagRules, MessageModerationRulePII, pattern)
+		}
+	}
+
+	return s
+}
+
+// Check screens the content of a message of the given type, returning nil if it may be sent as is.
+// Only text messages are screened.
+func (s *MessageModerationService) Check(content, messageType string) *MessageModerationResult {
+	if s == nil || messageType != "text" {
+		return nil
+	}
+
+	for _, rule := range s.blockRules {
+		if rule.regex.MatchString(content) {
+			return &MessageModerationResult{
+				Action:   MessageModerationActionBlock,
+				Rule:     rule.rule,
+				Severity: "high",
+				Guidance: messageModerationGuidance(rule.rule),
+				pattern:  rule.pattern,
+			}
+		}
+	}
+
+	for _, rule := range s.flagRules {
+		if rule.regex.MatchString(content) {
+			return &MessageModerationResult{
+				Action:   MessageModerationActionFlag,
+				Rule:     rule.rule,
+				Severity: "medium",
+				Guidance: messageModerationGuidance(rule.rule),
+				pattern:  rule.pattern,
+			}
+		}
+	}
+
+	return nil
+}
+
+// Flag queues a sent message for review
+func (s *MessageModerationService) Flag(ctx context.Context, message *entities.Message, result *MessageModerationResult) error {
+	if s == nil || s.queue == nil {
+		return nil
+	}
+
+	logger.Info("Message flagged for review", map[string]interface{}{
+		"message_id":      message.ID,
+		"conversation_id": message.ConversationID,
+		"sender_id":       message.SenderID,
+		"rule":            result.Rule,
+	})
+
+	return s.queue.Enqueue(ctx, &FlaggedMessage{
+		MessageID:      message.ID,
+		ConversationID: message.ConversationID,
+		SenderID:       message.SenderID,
+		Rule:           result.Rule,
+		Severity:       result.Severity,
+		Pattern:        result.pattern,
+		FlaggedAt:      time.Now(),
+	})
+}
+
+// bannedWordsPattern returns a case-insensitive pattern matching any of the words as a whole word,
+// or an empty string if there are none
+func bannedWordsPattern(words []string) string {
+	quoted := make([]string, 0, len(words))
+	for _, word := range words {
+		if word = strings.TrimSpace(word); word != "" {
+			quoted = append(quoted, regexp.QuoteMeta(word))
+		}
+	}
+	if len(quoted) == 0 {
+		return ""
+	}
+	return `(?i)\b(?:` + strings.Join(quoted, "|") + `)\b`
+}
+
+// appendModerationRule compiles the pattern and appends it to the rules
+func appendModerationRule(rules []moderationRule, rule, pattern string) []moderationRule {
+	regex, err := regexp.Compile(pattern)
+	if err != nil {
+		logger.Error("Invalid message moderation pattern", err, map[string]interface{}{
+			"rule":    rule,
+			"pattern": pattern,
+		})
+		return rules
+	}
+	return append(rules, moderationRule{rule: rule, pattern: pattern, regex: regex})
+}
+
+// messageModerationGuidance returns what the client tells the sender about the rule
+func messageModerationGuidance(rule string) string {
+	switch rule {
+	case MessageModerationRuleBannedWord:
+		return "Your message contains language that isn't allowed. Please rephrase it."
+	case MessageModerationRuleBannedPattern:
+		return "Your message contains content that isn't allowed."
+	case MessageModerationRulePII:
+		return "For your safety, avoid sharing personal details like phone numbers, emails or card numbers."
+	default:
+		return "Your message doesn't follow the community guidelines."
+	}
+}
+
+// RedisFlaggedMessageQueue keeps flagged messages in a Redis list, newest first
+type RedisFlaggedMessageQueue struct {
+	redisClient *redis.RedisClient
+	key         string
+}
+
+// NewRedisFlaggedMessageQueue creates a new RedisFlaggedMessageQueue
+func NewRedisFlaggedMessageQueue(redisClient *redis.RedisClient) *RedisFlaggedMessageQueue {
+	return &RedisFlaggedMessageQueue{
+		redisClient: redisClient,
+		key:         "chat_moderation:flagged",
+	}
+}
+
+// Enqueue adds the flagged message to the queue
+func (q *RedisFlaggedMessageQueue) Enqueue(ctx context.Context, flagged *FlaggedMessage) error {
+	data, err := json.Marshal(flagged)
+	if err != nil {
+		return fmt.Errorf("failed to marshal flagged message: %w", err)
+	}
+
+	if err := q.redisClient.LPush(ctx, q.key, data); err != nil {
+		return fmt.Errorf("failed to queue flagged message: %w", err)
+	}
+	return nil
+}
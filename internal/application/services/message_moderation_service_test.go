@@ -0,0 +1,92 @@
+package services
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/22smeargle/winkr-backend/internal/domain/entities"
+	"github.com/22smeargle/winkr-backend/pkg/config"
+)
+
+// inMemoryFlaggedMessageQueue is an in-memory FlaggedMessageQueue for tests
+type inMemoryFlaggedMessageQueue struct {
+	flagged []*FlaggedMessage
+}
+
+func (q *inMemoryFlaggedMessageQueue) Enqueue(ctx context.Context, flagged *FlaggedMessage) error {
+	q.flagged = append(q.flagged, flagged)
+	return nil
+}
+
+func newTestMessageModerationService(queue FlaggedMessageQueue) *MessageModerationService {
+	return NewMessageModerationService(queue, &config.ChatSecurityConfig{
+		ContentFilteringEnabled: true,
+		BannedWords:             []string{"scam", "wire money"},
+		BannedPatterns:          []string{`(?i)cash\s*app`, `[invalid`},
+		PIIDetectionEnabled:     true,
+		PIIPatterns:             []string{`\b\d{3}[-. ]?\d{3}[-. ]?\d{4}\b`},
+	})
+}
+
+func TestMessageModerationService_Check(t *testing.T) {
+	s := newTestMessageModerationService(nil)
+
+	tests := []struct {
+		name        string
+		content     string
+		messageType string
+		action      string
+		rule        string
+	}{
+		{"clean", "Want to grab coffee on Sunday?", "text", "", ""},
+		{"banned word in any case", "Not a Scam, promise", "text", MessageModerationActionBlock, MessageModerationRuleBannedWord},
+		{"banned phrase", "please wire money to me", "text", MessageModerationActionBlock, MessageModerationRuleBannedWord},
+		{"banned word inside another word", "scampi for dinner?", "text", "", ""},
+		{"banned pattern", "send it on CashApp", "text", MessageModerationActionBlock, MessageModerationRuleBannedPattern},
+		{"pii", "text me at 555-123-4567", "text", MessageModerationActionFlag, MessageModerationRulePII},
+		{"blocking wins over flagging", "scam me at 555 123 4567", "text", MessageModerationActionBlock, MessageModerationRuleBannedWord},
+		{"non-text messages are not screened", "scam", "location", "", ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := s.Check(tt.content, tt.messageType)
+			if tt.action == "" {
+				assert.Nil(t, result)
+				return
+			}
+
+			require.NotNil(t, result)
+			assert.Equal(t, tt.action, result.Action)
+			assert.Equal(t, tt.rule, result.Rule)
+			assert.NotEmpty(t, result.Guidance)
+		})
+	}
+}
+
+func TestMessageModerationService_DisabledOrNil(t *testing.T) {
+	disabled := NewMessageModerationService(nil, &config.ChatSecurityConfig{BannedWords: []string{"scam"}})
+	assert.Nil(t, disabled.Check("scam", "text"))
+
+	var unset *MessageModerationService
+	assert.Nil(t, unset.Check("scam", "text"))
+}
+
+func TestMessageModerationService_FlagQueuesMessage(t *testing.T) {
+	queue := &inMemoryFlaggedMessageQueue{}
+	s := newTestMessageModerationService(queue)
+	message := &entities.Message{ID: uuid.New(), ConversationID: uuid.New(), SenderID: uuid.New(), Content: "call 555.123.4567"}
+
+	result := s.Check(message.Content, "text")
+	require.NotNil(t, result)
+	require.NoError(t, s.Flag(context.Background(), message, result))
+
+	require.Len(t, queue.flagged, 1)
+	assert.Equal(t, message.ID, queue.flagged[0].MessageID)
+	assert.Equal(t, message.SenderID, queue.flagged[0].SenderID)
+	assert.Equal(t, MessageModerationRulePII, queue.flagged[0].Rule)
+	assert.Equal(t, `\b\d{3}[-. ]?\d{3}[-. ]?\d{4}\b`, queue.flagged[0].Pattern)
+}
@@ -0,0 +1,140 @@
+package services
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"fmt"
+	"regexp"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/22smeargle/winkr-backend/internal/domain/entities"
+	"github.com/22smeargle/winkr-backend/pkg/config"
+	"github.com/22smeargle/winkr-backend/pkg/logger"
+)
+
+// MessagePIIRedactor masks personal information in text messages before they are stored, so
+// phone numbers, emails, card numbers and the like never reach the database or cache in the
+// clear. Every character of a match is replaced by an asterisk, keeping the message's length and
+// layout. With message encryption enabled, the original content is kept encrypted alongside the
+// redacted message for moderators.
+type MessagePIIRedactor struct {
+	patterns []*regexp.Regexp
+	// cipher encrypts originals, nil unless message encryption is enabled
+	cipher cipher.AEAD
+}
+
+// NewMessagePIIRedactor creates a new PII redactor from the chat PII patterns. Patterns that don't
+// compile are logged and skipped. Originals are only kept when encryption is enabled with a key.
+func NewMessagePIIRedactor(security *config.ChatSecurityConfig, messages *config.MessageConfig) *MessagePIIRedactor {
+	r := &MessagePIIRedactor{}
+	if security == nil || !security.PIIDetectionEnabled {
+		return r
+	}
+
+	for _, pattern := range security.PIIPatterns {
+		regex, err := regexp.Compile(pattern)
+		if err != nil {
+			logger.Error("Invalid PII pattern", err, map[string]interface{}{
+				"pattern": pattern,
+			})
+			continue
+		}
+		r.patterns = append(r.patterns, regex)
+	}
+
+	if messages != nil && messages.EncryptionEnabled {
+		if messages.EncryptionKey == "" {
+			logger.Warn("Message encryption is enabled without a key, redacted PII is not kept")
+			return r
+		}
+
+		// The configured key can be any string, so it is hashed to an AES-256 key
+		key := sha256.Sum256([]byte(messages.EncryptionKey))
+		block, err := aes.NewCipher(key[:])
+		if err != nil {
+			logger.Error("Failed to create message cipher", err)
+			return r
+		}
+		if r.cipher, err = cipher.NewGCM(block); err != nil {
+			logger.Error("Failed to create message cipher", err)
+		}
+	}
+
+	return r
+}
+
+// Redact masks PII in the content of a text message and reports whether any was found. The
+// original content is encrypted into the message's EncryptedOriginal when originals are kept.
+func (r *MessagePIIRedactor) Redact(message *entities.Message) bool {
+	if r == nil || len(r.patterns) == 0 || message.MessageType != "text" {
+		return false
+	}
+
+	redacted := message.Content
+	for _, pattern := range r.patterns {
+		redacted = pattern.ReplaceAllStringFunc(redacted, maskPII)
+	}
+	if redacted == message.Content {
+		return false
+	}
+
+	if r.cipher != nil {
+		original, err := r.encrypt(message.Content)
+		if err != nil {
+			// The redacted message is still stored, only the original is lost
+			logger.Error("Failed to encrypt original message content", err, "message_id", message.ID)
+		} else {
+			message.EncryptedOriginal = &original
+		}
+	}
+
+	message.Content = redacted
+	return true
+}
+
+// Original decrypts the original content of a redacted message, for moderators. Messages without
+// PII return their content.
+func (r *MessagePIIRedactor) Original(message *entities.Message) (string, error) {
+	if message.EncryptedOriginal == nil {
+		return message.Content, nil
+	}
+	if r == nil || r.cipher == nil {
+		return "", fmt.Errorf("message encryption is not configured")
+	}
+
+	data, err := base64.StdEncoding.DecodeString(*message.EncryptedOriginal)
+	if err != nil {
+		return "", fmt.Errorf("failed to decode original content: %w", err)
+	}
+
+	nonceSize := r.cipher.NonceSize()
+	if len(data) < nonceSize {
+		return "", fmt.Errorf("original content is too short")
+	}
+
+	original, err := r.cipher.Open(nil, data[:nonceSize], data[nonceSize:], nil)
+	if err != nil {
+		return "", fmt.Errorf("failed to decrypt original content: %w", err)
+	}
+	return string(original), nil
+}
+
+// encrypt seals the content with a random nonce, returning the nonce and ciphertext base64 encoded
+func (r *MessagePIIRedactor) encrypt(content string) (string, error) {
+	nonce := make([]byte, r.cipher.NonceSize())
+	if _, err := rand.Read(nonce); err != nil {
+		return "", fmt.Errorf("failed to generate nonce: %w", err)
+	}
+
+	sealed := r.cipher.Seal(nonce, nonce, []byte(content), nil)
+	return base64.StdEncoding.EncodeToString(sealed), nil
+}
+
+// maskPII replaces every character of the match with an asterisk
+func maskPII(match string) string {
+	return strings.Repeat("*", utf8.RuneCountInString(match))
+}
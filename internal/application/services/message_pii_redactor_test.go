@@ -0,0 +1,85 @@
+package services
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/22smeargle/winkr-backend/internal/domain/entities"
+	"github.com/22smeargle/winkr-backend/pkg/config"
+)
+
+func testPIISecurityConfig() *config.ChatSecurityConfig {
+	return &config.ChatSecurityConfig{
+		PIIDetectionEnabled: true,
+		PIIPatterns: []string{
+			`\b[A-Za-z0-9._%+-]+@[A-Za-z0-9.-]+\.[A-Za-z]{2,}\b`,
+			`\b\d{3}[-. ]?\d{3}[-. ]?\d{4}\b`,
+			`[invalid`,
+		},
+	}
+}
+
+func TestMessagePIIRedactor_Redact(t *testing.T) {
+	r := NewMessagePIIRedactor(testPIISecurityConfig(), &config.MessageConfig{})
+
+	tests := []struct {
+		name        string
+		content     string
+		messageType string
+		expected    string
+	}{
+		{"clean", "Want to grab coffee on Sunday?", "text", "Want to grab coffee on Sunday?"},
+		{"phone number", "text me at 555-123-4567 later", "text", "text me at ************ later"},
+		{"several matches", "jo@example.com or 555 123 4567", "text", "************** or ************"},
+		{"non-text messages are left alone", "555-123-4567", "image", "555-123-4567"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			message := &entities.Message{Content: tt.content, MessageType: tt.messageType}
+
+			redacted := r.Redact(message)
+
+			assert.Equal(t, tt.expected != tt.content, redacted)
+			assert.Equal(t, tt.expected, message.Content)
+			assert.Len(t, message.Content, len(tt.content))
+			assert.Nil(t, message.EncryptedOriginal)
+		})
+	}
+}
+
+func TestMessagePIIRedactor_KeepsEncryptedOriginal(t *testing.T) {
+	r := NewMessagePIIRedactor(testPIISecurityConfig(), &config.MessageConfig{
+		EncryptionEnabled: true,
+		EncryptionKey:     "test-key",
+	})
+	message := &entities.Message{Content: "call 555-123-4567", MessageType: "text"}
+
+	require.True(t, r.Redact(message))
+	assert.Equal(t, "call ************", message.Content)
+	require.NotNil(t, message.EncryptedOriginal)
+	assert.NotContains(t, *message.EncryptedOriginal, "555")
+
+	original, err := r.Original(message)
+	require.NoError(t, err)
+	assert.Equal(t, "call 555-123-4567", original)
+
+	// Another key cannot read it
+	other := NewMessagePIIRedactor(testPIISecurityConfig(), &config.MessageConfig{
+		EncryptionEnabled: true,
+		EncryptionKey:     "other-key",
+	})
+	_, err = other.Original(message)
+	assert.Error(t, err)
+}
+
+func TestMessagePIIRedactor_Disabled(t *testing.T) {
+	security := testPIISecurityConfig()
+	security.PIIDetectionEnabled = false
+	message := &entities.Message{Content: "call 555-123-4567", MessageType: "text"}
+
+	assert.False(t, NewMessagePIIRedactor(security, &config.MessageConfig{}).Redact(message))
+	assert.False(t, (*MessagePIIRedactor)(nil).Redact(message))
+	assert.Equal(t, "call 555-123-4567", message.Content)
+}
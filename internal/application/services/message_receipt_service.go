@@ -0,0 +1,164 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/22smeargle/winkr-backend/internal/domain/entities"
+)
+
+// MessageStatusStore defines the persistence needed to record message delivery status
+type MessageStatusStore interface {
+	CreateMessageStatus(ctx context.Context, status *entities.MessageStatus) error
+	GetMessageStatuses(ctx context.Context, messageIDs []uuid.UUID) ([]*entities.MessageStatus, error)
+}
+
+// MessageReceiptService records sent, delivered and read transitions of messages
+// so reconnecting clients can reconcile their state from message history
+type MessageReceiptService struct {
+	statusStore MessageStatusStore
+}
+
+// NewMessageReceiptService creates a new MessageReceiptService
+func NewMessageReceiptService(statusStore MessageStatusStore) *MessageReceiptService {
+	return &MessageReceiptService{
+		statusStore: statusStore,
+	}
+}
+
+// RecordSent records that a message was sent by its sender
+func (s *MessageReceiptService) RecordSent(ctx context.Context, message *entities.Message) (*entities.MessageReceipt, error) {
+	return s.advance(ctx, message, message.SenderID, entities.MessageStatusSent)
+}
+
+// RecordDelivered records that a message reached the recipient's device.
+// It is a no-op when the message was already delivered or read.
+func (s *MessageReceiptService) RecordDelivered(ctx context.Context, message *entities.Message, recipientID uuid.UUID) (*entities.MessageReceipt, error) {
+	return s.advance(ctx, message, recipientID, entities.MessageStatusDelivered)
+}
+
+// RecordRead records that the recipient read a message.
+// A read message is also delivered, so a missing delivered transition is recorded first.
+func (s *MessageReceiptService) RecordRead(ctx context.Context, message *entities.Message, recipientID uuid.UUID) (*entities.MessageReceipt, error) {
+	return s.advance(ctx, message, recipientID, entities.MessageStatusRead)
+}
+
+// RecordReadAll records that the recipient read all the messages, loading their current statuses at once
+func (s *MessageReceiptService) RecordReadAll(ctx context.Context, messages []*entities.Message, recipientID uuid.UUID) error {
+	return s.advanceAll(ctx, messages, recipientID, entities.MessageStatusRead)
+}
+
+// RecordDeliveredAll records that all the messages reached the recipient, loading their current statuses at once
+func (s *MessageReceiptService) RecordDeliveredAll(ctx context.Context, messages []*entities.Message, recipientID uuid.UUID) error {
+	return s.advanceAll(ctx, messages, recipientID, entities.MessageStatusDelivered)
+}
+
+// advanceAll moves all the messages forward to the target status
+func (s *MessageReceiptService) advanceAll(ctx context.Context, messages []*entities.Message, recipientID uuid.UUID, target string) error {
+	if len(messages) == 0 {
+		return nil
+	}
+
+	messageIDs := make([]uuid.UUID, len(messages))
+	for i, message := range messages {
+		messageIDs[i] = message.ID
+	}
+
+	statuses, err := s.statusStore.GetMessageStatuses(ctx, messageIDs)
+	if err != nil {
+		return fmt.Errorf("failed to get message statuses: %w", err)
+	}
+
+	byMessage := make(map[uuid.UUID][]*entities.MessageStatus, len(messages))
+	for _, status := range statuses {
+		byMessage[status.MessageID] = append(byMessage[status.MessageID], status)
+	}
+
+	for _, message := range messages {
+		if _, err := s.advanceFrom(ctx, message, recipientID, target, byMessage[message.ID]); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// AttachReceipts loads the delivery state of the given messages and sets their Receipt
+func (s *MessageReceiptService) AttachReceipts(ctx context.Context, messages []*entities.Message) error {
+	if len(messages) == 0 {
+		return nil
+	}
+
+	messageIDs := make([]uuid.UUID, len(messages))
+	for i, message := range messages {
+		messageIDs[i] = message.ID
+	}
+
+	statuses, err := s.statusStore.GetMessageStatuses(ctx, messageIDs)
+	if err != nil {
+		return fmt.Errorf("failed to get message statuses: %w", err)
+	}
+
+	byMessage := make(map[uuid.UUID][]*entities.MessageStatus, len(messages))
+	for _, status := range statuses {
+		byMessage[status.MessageID] = append(byMessage[status.MessageID], status)
+	}
+
+	for _, message := range messages {
+		message.Receipt = entities.NewMessageReceipt(byMessage[message.ID])
+	}
+
+	return nil
+}
+
+// advance moves a message forward to the target status, recording every skipped transition.
+// Statuses never move backwards, and the sender's own delivery or read events are ignored.
+func (s *MessageReceiptService) advance(ctx context.Context, message *entities.Message, userID uuid.UUID, target string) (*entities.MessageReceipt, error) {
+	statuses, err := s.statusStore.GetMessageStatuses(ctx, []uuid.UUID{message.ID})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get message statuses: %w", err)
+	}
+
+	return s.advanceFrom(ctx, message, userID, target, statuses)
+}
+
+// advanceFrom is advance for a message whose statuses are already loaded
+func (s *MessageReceiptService) advanceFrom(ctx context.Context, message *entities.Message, userID uuid.UUID, target string, statuses []*entities.MessageStatus) (*entities.MessageReceipt, error) {
+	receipt := entities.NewMessageReceipt(statuses)
+	if target != entities.MessageStatusSent && userID == message.SenderID {
+		return receipt, nil
+	}
+
+	now := time.Now()
+	for _, status := range []string{entities.MessageStatusSent, entities.MessageStatusDelivered, entities.MessageStatusRead} {
+		rank := entities.MessageStatusRank(status)
+		if rank <= entities.MessageStatusRank(receipt.Status) {
+			continue
+		}
+		if rank > entities.MessageStatusRank(target) {
+			break
+		}
+
+		transition := &entities.MessageStatus{
+			ID:        uuid.New(),
+			MessageID: message.ID,
+			UserID:    userID,
+			Status:    status,
+			CreatedAt: now,
+		}
+		if status == entities.MessageStatusSent {
+			transition.UserID = message.SenderID
+			if !message.CreatedAt.IsZero() {
+				transition.CreatedAt = message.CreatedAt
+			}
+		}
+		if err := s.statusStore.CreateMessageStatus(ctx, transition); err != nil {
+			return nil, fmt.Errorf("failed to record %s status: %w", status, err)
+		}
+		statuses = append(statuses, transition)
+	}
+
+	return entities.NewMessageReceipt(statuses), nil
+}
@@ -0,0 +1,168 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/22smeargle/winkr-backend/internal/domain/entities"
+)
+
+// inMemoryMessageStatusStore is an in-memory MessageStatusStore for tests
+type inMemoryMessageStatusStore struct {
+	statuses  []*entities.MessageStatus
+	createErr error
+}
+
+func (s *inMemoryMessageStatusStore) CreateMessageStatus(ctx context.Context, status *entities.MessageStatus) error {
+	if s.createErr != nil {
+		return s.createErr
+	}
+	s.statuses = append(s.statuses, status)
+	return nil
+}
+
+func (s *inMemoryMessageStatusStore) GetMessageStatuses(ctx context.Context, messageIDs []uuid.UUID) ([]*entities.MessageStatus, error) {
+	wanted := make(map[uuid.UUID]bool, len(messageIDs))
+	for _, id := range messageIDs {
+		wanted[id] = true
+	}
+
+	var result []*entities.MessageStatus
+	for _, status := range s.statuses {
+		if wanted[status.MessageID] {
+			result = append(result, status)
+		}
+	}
+	return result, nil
+}
+
+func (s *inMemoryMessageStatusStore) recorded(messageID uuid.UUID) []string {
+	var result []string
+	for _, status := range s.statuses {
+		if status.MessageID == messageID {
+			result = append(result, status.Status)
+		}
+	}
+	return result
+}
+
+func newTestMessage() *entities.Message {
+	return &entities.Message{
+		ID:             uuid.New(),
+		ConversationID: uuid.New(),
+		SenderID:       uuid.New(),
+		Content:        "hello",
+		MessageType:    "text",
+		CreatedAt:      time.Now().Add(-time.Minute),
+	}
+}
+
+func TestMessageReceiptService_SentDeliveredRead(t *testing.T) {
+	store := &inMemoryMessageStatusStore{}
+	service := NewMessageReceiptService(store)
+	ctx := context.Background()
+	message := newTestMessage()
+	recipientID := uuid.New()
+
+	receipt, err := service.RecordSent(ctx, message)
+	require.NoError(t, err)
+	assert.Equal(t, entities.MessageStatusSent, receipt.Status)
+	require.NotNil(t, receipt.SentAt)
+	assert.True(t, receipt.SentAt.Equal(message.CreatedAt))
+	assert.Nil(t, receipt.DeliveredAt)
+
+	receipt, err = service.RecordDelivered(ctx, message, recipientID)
+	require.NoError(t, err)
+	assert.Equal(t, entities.MessageStatusDelivered, receipt.Status)
+	assert.NotNil(t, receipt.DeliveredAt)
+	assert.Nil(t, receipt.ReadAt)
+
+	receipt, err = service.RecordRead(ctx, message, recipientID)
+	require.NoError(t, err)
+	assert.Equal(t, entities.MessageStatusRead, receipt.Status)
+	assert.NotNil(t, receipt.ReadAt)
+
+	assert.Equal(t, []string{"sent", "delivered", "read"}, store.recorded(message.ID))
+	assert.Equal(t, message.SenderID, store.statuses[0].UserID)
+	assert.Equal(t, recipientID, store.statuses[1].UserID)
+	assert.Equal(t, recipientID, store.statuses[2].UserID)
+
+	// History returned to a reconnecting client carries the full receipt
+	history := []*entities.Message{{ID: message.ID, SenderID: message.SenderID}}
+	require.NoError(t, service.AttachReceipts(ctx, history))
+	require.NotNil(t, history[0].Receipt)
+	assert.Equal(t, entities.MessageStatusRead, history[0].Receipt.Status)
+	assert.NotNil(t, history[0].Receipt.SentAt)
+	assert.NotNil(t, history[0].Receipt.DeliveredAt)
+	assert.NotNil(t, history[0].Receipt.ReadAt)
+	assert.False(t, history[0].Receipt.ReadAt.Before(*history[0].Receipt.DeliveredAt))
+}
+
+func TestMessageReceiptService_ReadBackfillsDelivered(t *testing.T) {
+	store := &inMemoryMessageStatusStore{}
+	service := NewMessageReceiptService(store)
+	ctx := context.Background()
+	message := newTestMessage()
+
+	_, err := service.RecordSent(ctx, message)
+	require.NoError(t, err)
+
+	receipt, err := service.RecordRead(ctx, message, uuid.New())
+	require.NoError(t, err)
+
+	assert.Equal(t, entities.MessageStatusRead, receipt.Status)
+	assert.NotNil(t, receipt.DeliveredAt)
+	assert.Equal(t, []string{"sent", "delivered", "read"}, store.recorded(message.ID))
+}
+
+func TestMessageReceiptService_StatusNeverMovesBackwards(t *testing.T) {
+	store := &inMemoryMessageStatusStore{}
+	service := NewMessageReceiptService(store)
+	ctx := context.Background()
+	message := newTestMessage()
+	recipientID := uuid.New()
+
+	_, err := service.RecordRead(ctx, message, recipientID)
+	require.NoError(t, err)
+
+	receipt, err := service.RecordDelivered(ctx, message, recipientID)
+	require.NoError(t, err)
+	assert.Equal(t, entities.MessageStatusRead, receipt.Status)
+
+	receipt, err = service.RecordSent(ctx, message)
+	require.NoError(t, err)
+	assert.Equal(t, entities.MessageStatusRead, receipt.Status)
+
+	assert.Equal(t, []string{"sent", "delivered", "read"}, store.recorded(message.ID))
+}
+
+func TestMessageReceiptService_IgnoresSenderReadingOwnMessage(t *testing.T) {
+	store := &inMemoryMessageStatusStore{}
+	service := NewMessageReceiptService(store)
+	ctx := context.Background()
+	message := newTestMessage()
+
+	_, err := service.RecordSent(ctx, message)
+	require.NoError(t, err)
+
+	receipt, err := service.RecordRead(ctx, message, message.SenderID)
+	require.NoError(t, err)
+
+	assert.Equal(t, entities.MessageStatusSent, receipt.Status)
+	assert.Equal(t, []string{"sent"}, store.recorded(message.ID))
+}
+
+func TestMessageReceiptService_StoreFailure(t *testing.T) {
+	store := &inMemoryMessageStatusStore{createErr: errors.New("db down")}
+	service := NewMessageReceiptService(store)
+
+	receipt, err := service.RecordSent(context.Background(), newTestMessage())
+
+	assert.Error(t, err)
+	assert.Nil(t, receipt)
+}
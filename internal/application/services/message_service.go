@@ -51,13 +51,12 @@ type MessageValidationResult struct {
 // MessageProcessingOptions represents options for message processing
 type MessageProcessingOptions struct {
 	EnableContentFilter bool `json:"enable_content_filter"`
-	EnableLinkPreview  bool `json:"enable_link_preview"`
 	EnableEncryption   bool `json:"enable_encryption"`
 	EnableTranslation  bool `json:"enable_translation"`
 	TargetLanguage     string `json:"target_language,omitempty"`
 }
 
-// LinkPreview represents a link preview
+// LinkPreview is a preview of a link in a message, built from the page's OpenGraph tags
 type LinkPreview struct {
 	URL         string `json:"url"`
 	Title       string `json:"title"`
@@ -139,16 +138,6 @@ func (s *MessageService) ProcessMessage(ctx context.Context, message *entities.M
 		}
 	}
 
-	// Link preview generation
-	if options != nil && options.EnableLinkPreview && message.MessageType == "text" {
-		previews, err := s.generateLinkPreviews(message.Content)
-		if err != nil {
-			logger.Warn("Failed to generate link previews", err)
-		} else {
-			processed.LinkPreviews = previews
-		}
-	}
-
 	// Encryption for sensitive content
 	if options != nil && options.EnableEncryption {
 		if err := s.encryptSensitiveContent(processed); err != nil {
@@ -354,32 +343,6 @@ func (s *MessageService) filterContent(processed *ProcessedMessage) error {
 	return nil
 }
 
-// generateLinkPreviews generates link previews for URLs in message
-func (s *MessageService) generateLinkPreviews(content string) ([]LinkPreview, error) {
-	// Extract URLs from content
-	urlRegex := regexp.MustCompile(`https?://[^\s]+`)
-	urls := urlRegex.FindAllString(content, -1)
-	
-	if len(urls) == 0 {
-		return nil, nil
-	}
-	
-	previews := make([]LinkPreview, 0, len(urls))
-	
-	for _, url := range urls {
-		// In a real implementation, this would fetch URL metadata
-		// For now, return basic preview
-		preview := LinkPreview{
-			URL:         url,
-			Title:       "Link Preview",
-			Description: "Click to view link",
-		}
-		previews = append(previews, preview)
-	}
-	
-	return previews, nil
-}
-
 // encryptSensitiveContent encrypts sensitive information in message
 func (s *MessageService) encryptSensitiveContent(processed *ProcessedMessage) error {
 	// Implement encryption for sensitive content
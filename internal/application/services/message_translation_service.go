@@ -0,0 +1,212 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/22smeargle/winkr-backend/internal/domain/entities"
+	"github.com/22smeargle/winkr-backend/internal/domain/repositories"
+	"github.com/22smeargle/winkr-backend/pkg/config"
+	"github.com/22smeargle/winkr-backend/pkg/logger"
+)
+
+// TranslationService is a pluggable machine translation provider
+type TranslationService interface {
+	// DetectLanguage returns the language code of the text, e.g. "es"
+	DetectLanguage(ctx context.Context, text string) (string, error)
+	// Translate translates the text from the source language into the target language
+	Translate(ctx context.Context, text, sourceLanguage, targetLanguage string) (string, error)
+}
+
+// TranslationCache stores translations so each message is translated into a language only once
+type TranslationCache interface {
+	GetMessageTranslation(ctx context.Context, messageID uuid.UUID, language string) (*entities.MessageTranslation, error)
+	CacheMessageTranslation(ctx context.Context, messageID uuid.UUID, language string, translation *entities.MessageTranslation, ttl time.Duration) error
+}
+
+// defaultTranslationTimeout bounds provider calls when no timeout is configured
+const defaultTranslationTimeout = 2 * time.Second
+
+// MessageTranslationService translates messages into the language of recipients who opted in.
+// Translation is best effort: when it is disabled, slow or failing, messages are delivered as written.
+type MessageTranslationService struct {
+	provider TranslationService
+	cache    TranslationCache
+	userRepo repositories.UserRepository
+	config   *config.MessageTranslationConfig
+}
+
+// NewMessageTranslationService creates a new MessageTranslationService
+func NewMessageTranslationService(
+	provider TranslationService,
+	cache TranslationCache,
+	userRepo repositories.UserRepository,
+	cfg *config.MessageTranslationConfig,
+) *MessageTranslationService {
+	return &MessageTranslationService{
+		provider: provider,
+		cache:    cache,
+		userRepo: userRepo,
+		config:   cfg,
+	}
+}
+
+// Enabled reports whether automatic translation is turned on
+func (s *MessageTranslationService) Enabled() bool {
+	return s != nil && s.provider != nil && s.config != nil && s.config.Enabled
+}
+
+// Translate returns the message translated into the recipient's language, or nil when the recipient
+// did not opt in, the message is already in their language, or the provider fails or times out
+func (s *MessageTranslationService) Translate(ctx context.Context, message *entities.Message, recipient *entities.User) *entities.MessageTranslation {
+	if !s.Enabled() || recipient == nil || !recipient.AutoTranslateMessages {
+		return nil
+	}
+	if message.SenderID == recipient.ID || message.MessageType != "text" || message.Content == "" || message.IsDeleted {
+		return nil
+	}
+
+	target := recipient.PreferredLanguage()
+	if target == "" {
+		return nil
+	}
+
+	if translation, ok := s.cached(ctx, message.ID, cacheLanguage(message, target)); ok {
+		return translation
+	}
+
+	translation, err := s.translate(ctx, message.Content, target)
+	if err != nil {
+		logger.Warn("Message translation failed, delivering original", "message_id", message.ID, "language", target, "error", err)
+		return nil
+	}
+
+	// Messages already in the recipient's language are cached too, so detection is not repeated
+	s.store(ctx, message.ID, cacheLanguage(message, target), translation)
+	if translation.Content == "" {
+		return nil
+	}
+	return translation
+}
+
+// TranslateForUser loads the recipient and translates the message for them
+func (s *MessageTranslationService) TranslateForUser(ctx context.Context, message *entities.Message, recipientID uuid.UUID) (*entities.MessageTranslation, error) {
+	if !s.Enabled() {
+		return nil, nil
+	}
+
+	recipient, err := s.userRepo.GetByID(ctx, recipientID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get recipient: %w", err)
+	}
+
+	return s.Translate(ctx, message, recipient), nil
+}
+
+// AttachTranslations sets the Translation of the messages the recipient received
+func (s *MessageTranslationService) AttachTranslations(ctx context.Context, messages []*entities.Message, recipientID uuid.UUID) error {
+	if !s.Enabled() || len(messages) == 0 {
+		return nil
+	}
+
+	recipient, err := s.userRepo.GetByID(ctx, recipientID)
+	if err != nil {
+		return fmt.Errorf("failed to get recipient: %w", err)
+	}
+	if !recipient.AutoTranslateMessages {
+		return nil
+	}
+
+	for _, message := range messages {
+		message.Translation = s.Translate(ctx, message, recipient)
+	}
+
+	return nil
+}
+
+// translate detects the content's language and translates it into the target language within the
+// configured timeout. The returned translation has no content when no translation is needed.
+func (s *MessageTranslationService) translate(ctx context.Context, content, target string) (*entities.MessageTranslation, error) {
+	timeout := s.config.Timeout
+	if timeout <= 0 {
+		timeout = defaultTranslationTimeout
+	}
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	type result struct {
+		translation *entities.MessageTranslation
+		err         error
+	}
+	done := make(chan result, 1)
+
+	// Providers that ignore the context still cannot hold up delivery
+	go func() {
+		source, err := s.provider.DetectLanguage(ctx, content)
+		if err != nil {
+			done <- result{err: fmt.Errorf("failed to detect language: %w", err)}
+			return
+		}
+
+		translation := &entities.MessageTranslation{
+			SourceLanguage: entities.LanguageOf(source),
+			Language:       target,
+		}
+		if translation.SourceLanguage == target {
+			done <- result{translation: translation}
+			return
+		}
+
+		translation.Content, err = s.provider.Translate(ctx, content, translation.SourceLanguage, target)
+		if err != nil {
+			done <- result{err: fmt.Errorf("failed to translate: %w", err)}
+			return
+		}
+		done <- result{translation: translation}
+	}()
+
+	select {
+	case r := <-done:
+		return r.translation, r.err
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+}
+
+// cached returns a cached translation. A cached entry without content means no translation is needed.
+func (s *MessageTranslationService) cached(ctx context.Context, messageID uuid.UUID, language string) (*entities.MessageTranslation, bool) {
+	if s.cache == nil {
+		return nil, false
+	}
+
+	translation, err := s.cache.GetMessageTranslation(ctx, messageID, language)
+	if err != nil || translation == nil {
+		return nil, false
+	}
+	if translation.Content == "" {
+		return nil, true
+	}
+	return translation, true
+}
+
+// cacheLanguage is the language a message's translation is cached under. Edited messages are cached
+// per edit, so a translation of the old content is never returned for the new one.
+func cacheLanguage(message *entities.Message, language string) string {
+	if message.EditedAt == nil {
+		return language
+	}
+	return fmt.Sprintf("%s@%d", language, message.EditedAt.UnixNano())
+}
+
+// store caches a translation, logging rather than failing on errors
+func (s *MessageTranslationService) store(ctx context.Context, messageID uuid.UUID, language string, translation *entities.MessageTranslation) {
+	if s.cache == nil {
+		return
+	}
+
+	if err := s.cache.CacheMessageTranslation(ctx, messageID, language, translation, s.config.CacheTTL); err != nil {
+		logger.Warn("Failed to cache message translation", "message_id", messageID, "error", err)
+	}
+}
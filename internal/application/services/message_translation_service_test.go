@@ -0,0 +1,145 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/22smeargle/winkr-backend/internal/domain/entities"
+	"github.com/22smeargle/winkr-backend/internal/domain/repositories"
+	"github.com/22smeargle/winkr-backend/internal/infrastructure/external"
+	"github.com/22smeargle/winkr-backend/pkg/config"
+)
+
+// inMemoryTranslationCache is an in-memory TranslationCache for tests
+type inMemoryTranslationCache struct {
+	translations map[string]*entities.MessageTranslation
+}
+
+func (c *inMemoryTranslationCache) GetMessageTranslation(ctx context.Context, messageID uuid.UUID, language string) (*entities.MessageTranslation, error) {
+	translation, ok := c.translations[messageID.String()+":"+language]
+	if !ok {
+		return nil, errors.New("cache miss")
+	}
+	copied := *translation
+	return &copied, nil
+}
+
+func (c *inMemoryTranslationCache) CacheMessageTranslation(ctx context.Context, messageID uuid.UUID, language string, translation *entities.MessageTranslation, ttl time.Duration) error {
+	c.translations[messageID.String()+":"+language] = translation
+	return nil
+}
+
+// usersByID looks up users from an in-memory map
+type usersByID struct {
+	repositories.UserRepository
+	users map[uuid.UUID]*entities.User
+}
+
+func (r *usersByID) GetByID(ctx context.Context, id uuid.UUID) (*entities.User, error) {
+	user, ok := r.users[id]
+	if !ok {
+		return nil, errors.New("user not found")
+	}
+	return user, nil
+}
+
+func newTranslationFixture(provider *external.MockTranslationService, enabled bool) (*MessageTranslationService, *entities.User, *entities.Message) {
+	locale := "es-MX"
+	recipient := &entities.User{ID: uuid.New(), Locale: &locale, AutoTranslateMessages: true}
+	message := &entities.Message{ID: uuid.New(), SenderID: uuid.New(), Content: "See you tonight", MessageType: "text"}
+
+	service := NewMessageTranslationService(
+		provider,
+		&inMemoryTranslationCache{translations: make(map[string]*entities.MessageTranslation)},
+		&usersByID{users: map[uuid.UUID]*entities.User{recipient.ID: recipient}},
+		&config.MessageTranslationConfig{Enabled: enabled, Timeout: 50 * time.Millisecond, CacheTTL: time.Hour},
+	)
+	return service, recipient, message
+}
+
+func TestMessageTranslation_TranslatesAndCaches(t *testing.T) {
+	ctx := context.Background()
+	provider := external.NewMockTranslationService("en")
+	service, recipient, message := newTranslationFixture(provider, true)
+
+	messages := []*entities.Message{message}
+	require.NoError(t, service.AttachTranslations(ctx, messages, recipient.ID))
+
+	require.NotNil(t, message.Translation)
+	assert.Equal(t, "See you tonight", message.Content, "original is preserved")
+	assert.Equal(t, "[es] See you tonight", message.Translation.Content)
+	assert.Equal(t, "en", message.Translation.SourceLanguage)
+	assert.Equal(t, "es", message.Translation.Language)
+
+	// A second delivery is served from the cache
+	translation, err := service.TranslateForUser(ctx, message, recipient.ID)
+	require.NoError(t, err)
+	assert.Equal(t, message.Translation, translation)
+	assert.Equal(t, 1, provider.DetectCalls)
+	assert.Equal(t, 1, provider.TranslateCalls)
+
+	// The sender's own messages are never translated for them
+	message.SenderID = recipient.ID
+	assert.Nil(t, service.Translate(ctx, message, recipient))
+}
+
+func TestMessageTranslation_SkipsWhenNotNeeded(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("disabled", func(t *testing.T) {
+		provider := external.NewMockTranslationService("en")
+		service, recipient, message := newTranslationFixture(provider, false)
+
+		assert.Nil(t, service.Translate(ctx, message, recipient))
+		assert.Zero(t, provider.DetectCalls)
+	})
+
+	t.Run("recipient not opted in", func(t *testing.T) {
+		provider := external.NewMockTranslationService("en")
+		service, recipient, message := newTranslationFixture(provider, true)
+		recipient.AutoTranslateMessages = false
+
+		require.NoError(t, service.AttachTranslations(ctx, []*entities.Message{message}, recipient.ID))
+		assert.Nil(t, message.Translation)
+		assert.Zero(t, provider.DetectCalls)
+	})
+
+	t.Run("same language", func(t *testing.T) {
+		provider := external.NewMockTranslationService("es")
+		service, recipient, message := newTranslationFixture(provider, true)
+
+		assert.Nil(t, service.Translate(ctx, message, recipient))
+		assert.Nil(t, service.Translate(ctx, message, recipient))
+		assert.Equal(t, 1, provider.DetectCalls, "detection result is cached")
+		assert.Zero(t, provider.TranslateCalls)
+	})
+}
+
+func TestMessageTranslation_FallsBackToOriginal(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("provider error", func(t *testing.T) {
+		provider := external.NewMockTranslationService("en")
+		provider.TranslateError = errors.New("quota exceeded")
+		service, recipient, message := newTranslationFixture(provider, true)
+
+		require.NoError(t, service.AttachTranslations(ctx, []*entities.Message{message}, recipient.ID))
+		assert.Nil(t, message.Translation)
+		assert.Equal(t, "See you tonight", message.Content)
+	})
+
+	t.Run("provider timeout", func(t *testing.T) {
+		provider := external.NewMockTranslationService("en")
+		provider.Delay = time.Second
+		service, recipient, message := newTranslationFixture(provider, true)
+
+		start := time.Now()
+		assert.Nil(t, service.Translate(ctx, message, recipient))
+		assert.Less(t, time.Since(start), 500*time.Millisecond)
+	})
+}
@@ -332,6 +332,42 @@ func (m *MetricsService) RecordBusinessMetric(metric string, value float64) {
 	})
 }
 
+// RecordJobRun records the outcome of a background job run, one counter per counted item kind
+func (m *MetricsService) RecordJobRun(job string, counts map[string]int, duration time.Duration) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	now := time.Now()
+	for item, count := range counts {
+		m.addMetric(Metric{
+			Name:      "background_job_items_total",
+			Type:      MetricTypeCounter,
+			Value:     float64(count),
+			Labels:    map[string]string{"job": job, "item": item},
+			Timestamp: now,
+			Help:      "Items handled by background job runs",
+		})
+	}
+
+	m.addMetric(Metric{
+		Name:      "background_job_runs_total",
+		Type:      MetricTypeCounter,
+		Value:     1,
+		Labels:    map[string]string{"job": job},
+		Timestamp: now,
+		Help:      "Background job runs",
+	})
+
+	m.addMetric(Metric{
+		Name:      "background_job_duration_seconds",
+		Type:      MetricTypeGauge,
+		Value:     duration.Seconds(),
+		Labels:    map[string]string{"job": job},
+		Timestamp: now,
+		Help:      "Duration of the last background job run",
+	})
+}
+
 // CollectSystemMetrics collects system resource metrics
 func (m *MetricsService) CollectSystemMetrics(ctx context.Context) {
 	m.mu.Lock()
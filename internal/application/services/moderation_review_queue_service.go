@@ -0,0 +1,377 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/22smeargle/winkr-backend/pkg/config"
+	"github.com/22smeargle/winkr-backend/pkg/logger"
+)
+
+// Ways a moderator can resolve a claimed review queue item
+const (
+	ModerationReviewApprove  = "approve"
+	ModerationReviewReject   = "reject"
+	ModerationReviewEscalate = "escalate"
+)
+
+var (
+	// ErrInvalidModerationPriority is returned for priorities not in the queue's PriorityLevels
+	ErrInvalidModerationPriority = errors.New("invalid moderation queue priority")
+	// ErrModerationQueueFull is returned when MaxQueueSize items are already waiting
+	ErrModerationQueueFull = errors.New("moderation queue is full")
+	// ErrModerationQueueEmpty is returned when there is no item to auto-assign
+	ErrModerationQueueEmpty = errors.New("no items waiting for review")
+	// ErrModerationAutoAssignDisabled is returned when claiming the next item without auto-assign
+	ErrModerationAutoAssignDisabled = errors.New("moderation queue auto-assign is disabled")
+	// ErrModerationItemNotFound is returned for items that are not in the queue
+	ErrModerationItemNotFound = errors.New("moderation queue item not found")
+	// ErrModerationItemClaimed is returned when claiming an item that is not waiting for review
+	ErrModerationItemClaimed = errors.New("moderation queue item is already claimed")
+	// ErrModerationClaimNotHeld is returned when resolving an item without a live claim on it
+	ErrModerationClaimNotHeld = errors.New("moderation queue item is not claimed by this moderator")
+	// ErrInvalidModerationResolution is returned for resolutions other than approve, reject or escalate
+	ErrInvalidModerationResolution = errors.New("invalid moderation queue resolution")
+)
+
+// ModerationReviewQueueService runs the moderator review queue. Items wait in the queue of their
+// priority until a moderator claims one, either a specific item or, with auto-assign, the oldest item
+// of the highest priority. Claims last AssignmentTimeout; items whose claims expire go back to the
+// queue for another moderator. Escalated items go back to the queue one priority level higher.
+type ModerationReviewQueueService struct {
+	store    ModerationReviewStore
+	recorder ModeratorActionRecorder
+	config   *config.ModerationQueueConfig
+	// claimCandidates is how many waiting items of a priority are tried when auto-assigning
+	claimCandidates int
+}
+
+// NewModerationReviewQueueService creates a new moderation review queue service
+func NewModerationReviewQueueService(
+	store ModerationReviewStore,
+	recorder ModeratorActionRecorder,
+	cfg *config.ModerationQueueConfig,
+) *ModerationReviewQueueService {
+	return &ModerationReviewQueueService{
+		store:           store,
+		recorder:        recorder,
+		config:          cfg,
+		claimCandidates: 10,
+	}
+}
+
+// Start returns items with expired claims to the queue every ProcessingInterval until the context
+// is cancelled
+func (s *ModerationReviewQueueService) Start(ctx context.Context) {
+	logger.Info("Starting moderation review queue", "interval", s.config.ProcessingInterval)
+
+	go func() {
+		ticker := time.NewTicker(s.config.ProcessingInterval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ctx.Done():
+				logger.Info("Moderation review queue stopped")
+				return
+			case <-ticker.C:
+				s.ReassignExpiredClaims(ctx)
+			}
+		}
+	}()
+}
+
+// Enqueue adds an item for review at the priority, or the DefaultPriority if none is given
+func (s *ModerationReviewQueueService) Enqueue(ctx context.Context, itemType string, itemID uuid.UUID, userID *uuid.UUID, priority string) (*ModerationReviewItem, error) {
+	if priority == "" {
+		priority = s.config.DefaultPriority
+	}
+	if s.priorityRank(priority) < 0 {
+		return nil, ErrInvalidModerationPriority
+	}
+
+	if s.config.MaxQueueSize > 0 {
+		waiting := 0
+		for _, level := range s.config.PriorityLevels {
+			count, err := s.store.CountPending(ctx, level)
+			if err != nil {
+				return nil, err
+			}
+			waiting += count
+		}
+		if waiting >= s.config.MaxQueueSize {
+			return nil, ErrModerationQueueFull
+		}
+	}
+
+	now := time.Now()
+	item := &ModerationReviewItem{
+		ID:        uuid.New(),
+		ItemType:  itemType,
+		ItemID:    itemID,
+		UserID:    userID,
+		Priority:  priority,
+		Status:    ModerationReviewPending,
+		CreatedAt: now,
+		UpdatedAt: now,
+	}
+	if err := s.store.Push(ctx, item); err != nil {
+		return nil, err
+	}
+
+	logger.Info("Item queued for moderator review", "queue_item_id", item.ID, "item_type", itemType, "item_id", itemID, "priority", priority)
+	return item, nil
+}
+
+// List returns up to limit items waiting for review at the priority, oldest first. Without a
+// priority, items of higher priorities come first.
+func (s *ModerationReviewQueueService) List(ctx context.Context, priority string, limit int) ([]*ModerationReviewItem, error) {
+	if priority != "" {
+		if s.priorityRank(priority) < 0 {
+			return nil, ErrInvalidModerationPriority
+		}
+		return s.store.Pending(ctx, priority, limit)
+	}
+
+	items := make([]*ModerationReviewItem, 0, limit)
+	for i := len(s.config.PriorityLevels) - 1; i >= 0 && len(items) < limit; i-- {
+		pending, err := s.store.Pending(ctx, s.config.PriorityLevels[i], limit-len(items))
+		if err != nil {
+			return nil, err
+		}
+		items = append(items, pending...)
+	}
+	return items, nil
+}
+
+// Claim assigns the item to the moderator until AssignmentTimeout passes. Without an item ID the
+// oldest item of the highest priority is assigned, if AutoAssignEnabled.
+func (s *ModerationReviewQueueService) Claim(ctx context.Context, moderatorID, itemID uuid.UUID) (*ModerationReviewItem, error) {
+	if itemID == uuid.Nil {
+		return s.claimNext(ctx, moderatorID)
+	}
+
+	item, err := s.store.Get(ctx, itemID)
+	if err != nil {
+		return nil, err
+	}
+	if item == nil {
+		return nil, ErrModerationItemNotFound
+	}
+	if item.Status != ModerationReviewPending && item.Status != ModerationReviewEscalated {
+		return nil, ErrModerationItemClaimed
+	}
+
+	claimed, err := s.claim(ctx, moderatorID, item)
+	if err != nil {
+		return nil, err
+	}
+	if !claimed {
+		return nil, ErrModerationItemClaimed
+	}
+	return item, nil
+}
+
+// claimNext claims the oldest waiting item of the highest priority another moderator doesn't claim
+// first
+func (s *ModerationReviewQueueService) claimNext(ctx context.Context, moderatorID uuid.UUID) (*ModerationReviewItem, error) {
+	if !s.config.AutoAssignEnabled {
+		return nil, ErrModerationAutoAssignDisabled
+	}
+
+	for i := len(s.config.PriorityLevels) - 1; i >= 0; i-- {
+		pending, err := s.store.Pending(ctx, s.config.PriorityLevels[i], s.claimCandidates)
+		if err != nil {
+			return nil, err
+		}
+		for _, item := range pending {
+			claimed, err := s.claim(ctx, moderatorID, item)
+			if err != nil {
+				return nil, err
+			}
+			if claimed {
+				return item, nil
+			}
+		}
+	}
+	return nil, ErrModerationQueueEmpty
+}
+
+// claim assigns the item to the moderator, returning false if it was claimed by someone else first
+func (s *ModerationReviewQueueService) claim(ctx context.Context, moderatorID uuid.UUID, item *ModerationReviewItem) (bool, error) {
+	now := time.Now()
+	expiresAt := now.Add(s.config.AssignmentTimeout)
+	item.Status = ModerationReviewInProgress
+	item.AssignedTo = &moderatorID
+	item.AssignedAt = &now
+	item.ClaimExpiresAt = &expiresAt
+	item.UpdatedAt = now
+
+	claimed, err := s.store.Claim(ctx, item)
+	if err != nil || !claimed {
+		return false, err
+	}
+
+	logger.Info("Moderation queue item claimed", "queue_item_id", item.ID, "moderator_id", moderatorID, "expires_at", expiresAt)
+	s.record(ctx, moderatorID, item, "claim", 0)
+	return true, nil
+}
+
+// Resolve approves, rejects or escalates an item the moderator holds a live claim on
+func (s *ModerationReviewQueueService) Resolve(ctx context.Context, moderatorID, itemID uuid.UUID, resolution, notes string) (*ModerationReviewItem, error) {
+	if resolution != ModerationReviewApprove && resolution != ModerationReviewReject && resolution != ModerationReviewEscalate {
+		return nil, ErrInvalidModerationResolution
+	}
+
+	item, err := s.store.Get(ctx, itemID)
+	if err != nil {
+		return nil, err
+	}
+	if item == nil {
+		return nil, ErrModerationItemNotFound
+	}
+
+	now := time.Now()
+	if item.Status != ModerationReviewInProgress || item.AssignedTo == nil || *item.AssignedTo != moderatorID ||
+		item.ClaimExpiresAt == nil || now.After(*item.ClaimExpiresAt) {
+		return nil, ErrModerationClaimNotHeld
+	}
+
+	// The claim may have expired and been handed back to the queue since the item was read
+	unclaimed, err := s.store.Unclaim(ctx, item.ID)
+	if err != nil {
+		return nil, err
+	}
+	if !unclaimed {
+		return nil, ErrModerationClaimNotHeld
+	}
+
+	handlingTime := now.Sub(*item.AssignedAt)
+	item.Notes = notes
+	item.UpdatedAt = now
+
+	switch resolution {
+	case ModerationReviewEscalate:
+		item.Status = ModerationReviewEscalated
+		item.Priority = s.escalatedPriority(item.Priority)
+		s.release(item)
+		err = s.store.Push(ctx, item)
+	case ModerationReviewApprove:
+		item.Status = ModerationReviewCompleted
+		item.Resolution = "approved"
+		item.ResolvedAt = &now
+		err = s.store.Save(ctx, item)
+	case ModerationReviewReject:
+		item.Status = ModerationReviewCompleted
+		item.Resolution = "rejected"
+		item.ResolvedAt = &now
+		err = s.store.Save(ctx, item)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	logger.Info("Moderation queue item resolved", "queue_item_id", item.ID, "moderator_id", moderatorID, "resolution", resolution, "priority", item.Priority)
+	s.record(ctx, moderatorID, item, resolution, handlingTime)
+	return item, nil
+}
+
+// ReassignExpiredClaims returns items whose claims expired to the queue so other moderators can
+// claim them, and returns how many were returned
+func (s *ModerationReviewQueueService) ReassignExpiredClaims(ctx context.Context) int {
+	ids, err := s.store.ExpiredClaims(ctx, time.Now(), 100)
+	if err != nil {
+		logger.Error("Failed to get expired moderation queue claims", err)
+		return 0
+	}
+
+	reassigned := 0
+	for _, id := range ids {
+		if err := s.reassign(ctx, id); err != nil {
+			logger.Error("Failed to return expired moderation queue claim", err, "queue_item_id", id)
+			continue
+		}
+		reassigned++
+	}
+	return reassigned
+}
+
+// reassign puts an item whose claim expired back in the queue, unless the moderator resolved it or
+// another poll returned it first
+func (s *ModerationReviewQueueService) reassign(ctx context.Context, id uuid.UUID) error {
+	unclaimed, err := s.store.Unclaim(ctx, id)
+	if err != nil || !unclaimed {
+		return err
+	}
+
+	item, err := s.store.Get(ctx, id)
+	if err != nil {
+		return err
+	}
+	if item == nil || item.Status != ModerationReviewInProgress {
+		return nil
+	}
+
+	moderatorID := *item.AssignedTo
+	handlingTime := time.Since(*item.AssignedAt)
+	item.Status = ModerationReviewPending
+	item.UpdatedAt = time.Now()
+	s.release(item)
+	if err := s.store.Push(ctx, item); err != nil {
+		return fmt.Errorf("failed to requeue moderation queue item: %w", err)
+	}
+
+	logger.Info("Expired moderation queue claim returned to the queue", "queue_item_id", item.ID, "moderator_id", moderatorID)
+	s.record(ctx, moderatorID, item, "claim_expired", handlingTime)
+	return nil
+}
+
+// release clears the item's assignment
+func (s *ModerationReviewQueueService) release(item *ModerationReviewItem) {
+	item.AssignedTo = nil
+	item.AssignedAt = nil
+	item.ClaimExpiresAt = nil
+}
+
+// record passes the moderator's action to the analytics recorder. Failures are logged so they don't
+// undo the action.
+func (s *ModerationReviewQueueService) record(ctx context.Context, moderatorID uuid.UUID, item *ModerationReviewItem, action string, duration time.Duration) {
+	if s.recorder == nil {
+		return
+	}
+
+	err := s.recorder.RecordModeratorAction(ctx, &ModeratorQueueAction{
+		ModeratorID: moderatorID,
+		ItemID:      item.ID,
+		ItemType:    item.ItemType,
+		Priority:    item.Priority,
+		Action:      action,
+		Duration:    duration,
+		CreatedAt:   time.Now(),
+	})
+	if err != nil {
+		logger.Error("Failed to record moderator action", err, "moderator_id", moderatorID, "action", action)
+	}
+}
+
+// priorityRank returns the position of the priority in PriorityLevels, lowest first, or -1
+func (s *ModerationReviewQueueService) priorityRank(priority string) int {
+	for i, level := range s.config.PriorityLevels {
+		if level == priority {
+			return i
+		}
+	}
+	return -1
+}
+
+// escalatedPriority returns the priority level above the priority, or the priority if it is the highest
+func (s *ModerationReviewQueueService) escalatedPriority(priority string) string {
+	rank := s.priorityRank(priority)
+	if rank < 0 || rank+1 >= len(s.config.PriorityLevels) {
+		return priority
+	}
+	return s.config.PriorityLevels[rank+1]
+}
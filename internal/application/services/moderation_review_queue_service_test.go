@@ -0,0 +1,274 @@
+package services
+
+import (
+	"context"
+	"sort"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/22smeargle/winkr-backend/pkg/config"
+)
+
+// inMemoryModerationReviewStore is an in-memory ModerationReviewStore for tests
+type inMemoryModerationReviewStore struct {
+	items   map[uuid.UUID]ModerationReviewItem
+	pending map[string][]uuid.UUID
+	claims  map[uuid.UUID]time.Time
+}
+
+func newInMemoryModerationReviewStore() *inMemoryModerationReviewStore {
+	return &inMemoryModerationReviewStore{
+		items:   make(map[uuid.UUID]ModerationReviewItem),
+		pending: make(map[string][]uuid.UUID),
+		claims:  make(map[uuid.UUID]time.Time),
+	}
+}
+
+func (s *inMemoryModerationReviewStore) Get(ctx context.Context, id uuid.UUID) (*ModerationReviewItem, error) {
+	item, ok := s.items[id]
+	if !ok {
+		return nil, nil
+	}
+	return &item, nil
+}
+
+func (s *inMemoryModerationReviewStore) Save(ctx context.Context, item *ModerationReviewItem) error {
+	s.items[item.ID] = *item
+	return nil
+}
+
+func (s *inMemoryModerationReviewStore) Push(ctx context.Context, item *ModerationReviewItem) error {
+	s.items[item.ID] = *item
+	ids := append(s.pending[item.Priority], item.ID)
+	sort.SliceStable(ids, func(i, j int) bool {
+		return s.items[ids[i]].CreatedAt.Before(s.items[ids[j]].CreatedAt)
+	})
+	s.pending[item.Priority] = ids
+	return nil
+}
+
+func (s *inMemoryModerationReviewStore) Pending(ctx context.Context, priority string, limit int) ([]*ModerationReviewItem, error) {
+	var items []*ModerationReviewItem
+	for _, id := range s.pending[priority] {
+		if len(items) == limit {
+			break
+		}
+		item := s.items[id]
+		items = append(items, &item)
+	}
+	return items, nil
+}
+
+func (s *inMemoryModerationReviewStore) CountPending(ctx context.Context, priority string) (int, error) {
+	return len(s.pending[priority]), nil
+}
+
+func (s *inMemoryModerationReviewStore) Claim(ctx context.Context, item *ModerationReviewItem) (bool, error) {
+	ids := s.pending[item.Priority]
+	for i, id := range ids {
+		if id == item.ID {
+			s.pending[item.Priority] = append(ids[:i:i], ids[i+1:]...)
+			s.items[item.ID] = *item
+			s.claims[item.ID] = *item.ClaimExpiresAt
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+func (s *inMemoryModerationReviewStore) Unclaim(ctx context.Context, id uuid.UUID) (bool, error) {
+	_, ok := s.claims[id]
+	delete(s.claims, id)
+	return ok, nil
+}
+
+func (s *inMemoryModerationReviewStore) ExpiredClaims(ctx context.Context, before time.Time, limit int) ([]uuid.UUID, error) {
+	var ids []uuid.UUID
+	for id, expiresAt := range s.claims {
+		if expiresAt.Before(before) && len(ids) < limit {
+			ids = append(ids, id)
+		}
+	}
+	return ids, nil
+}
+
+// expire makes the claim on the item expire
+func (s *inMemoryModerationReviewStore) expire(id uuid.UUID) {
+	expired := time.Now().Add(-time.Minute)
+	item := s.items[id]
+	item.ClaimExpiresAt = &expired
+	s.items[id] = item
+	s.claims[id] = expired
+}
+
+// recordedModeratorActions keeps the actions recorded for analytics
+type recordedModeratorActions struct {
+	actions []*ModeratorQueueAction
+}
+
+func (r *recordedModeratorActions) RecordModeratorAction(ctx context.Context, action *ModeratorQueueAction) error {
+	r.actions = append(r.actions, action)
+	return nil
+}
+
+func (r *recordedModeratorActions) names() []string {
+	names := make([]string, 0, len(r.actions))
+	for _, action := range r.actions {
+		names = append(names, action.Action)
+	}
+	return names
+}
+
+func newTestModerationReviewQueue() (*ModerationReviewQueueService, *inMemoryModerationReviewStore, *recordedModeratorActions) {
+	store := newInMemoryModerationReviewStore()
+	recorder := &recordedModeratorActions{}
+	service := NewModerationReviewQueueService(store, recorder, &config.ModerationQueueConfig{
+		Enabled:           true,
+		MaxQueueSize:      3,
+		PriorityLevels:    []string{"low", "medium", "high", "critical"},
+		DefaultPriority:   "medium",
+		AutoAssignEnabled: true,
+		AssignmentTimeout: 30 * time.Minute,
+	})
+	return service, store, recorder
+}
+
+func enqueueReviewItem(t *testing.T, service *ModerationReviewQueueService, priority string) *ModerationReviewItem {
+	t.Helper()
+	item, err := service.Enqueue(context.Background(), "report", uuid.New(), nil, priority)
+	require.NoError(t, err)
+	return item
+}
+
+func TestModerationReviewQueue_ListsAndAutoAssignsByPriority(t *testing.T) {
+	service, _, _ := newTestModerationReviewQueue()
+	ctx := context.Background()
+	low := enqueueReviewItem(t, service, "low")
+	medium := enqueueReviewItem(t, service, "")
+	critical := enqueueReviewItem(t, service, "critical")
+
+	items, err := service.List(ctx, "", 10)
+	require.NoError(t, err)
+	require.Len(t, items, 3)
+	assert.Equal(t, []uuid.UUID{critical.ID, medium.ID, low.ID}, []uuid.UUID{items[0].ID, items[1].ID, items[2].ID})
+	assert.Equal(t, "medium", items[1].Priority)
+
+	items, err = service.List(ctx, "low", 10)
+	require.NoError(t, err)
+	require.Len(t, items, 1)
+	assert.Equal(t, low.ID, items[0].ID)
+
+	_, err = service.List(ctx, "urgent", 10)
+	assert.ErrorIs(t, err, ErrInvalidModerationPriority)
+	_, err = service.Enqueue(ctx, "report", uuid.New(), nil, "low")
+	assert.ErrorIs(t, err, ErrModerationQueueFull)
+
+	moderatorID := uuid.New()
+	claimed, err := service.Claim(ctx, moderatorID, uuid.Nil)
+	require.NoError(t, err)
+	assert.Equal(t, critical.ID, claimed.ID)
+	assert.Equal(t, ModerationReviewInProgress, claimed.Status)
+	assert.Equal(t, moderatorID, *claimed.AssignedTo)
+
+	items, err = service.List(ctx, "", 10)
+	require.NoError(t, err)
+	assert.Len(t, items, 2)
+}
+
+func TestModerationReviewQueue_ClaimedItemsCannotBeTakenOrResolvedByOthers(t *testing.T) {
+	service, _, _ := newTestModerationReviewQueue()
+	ctx := context.Background()
+	item := enqueueReviewItem(t, service, "high")
+	first, second := uuid.New(), uuid.New()
+
+	_, err := service.Claim(ctx, first, item.ID)
+	require.NoError(t, err)
+
+	_, err = service.Claim(ctx, second, item.ID)
+	assert.ErrorIs(t, err, ErrModerationItemClaimed)
+	_, err = service.Resolve(ctx, second, item.ID, ModerationReviewApprove, "")
+	assert.ErrorIs(t, err, ErrModerationClaimNotHeld)
+	_, err = service.Resolve(ctx, first, item.ID, "ignore", "")
+	assert.ErrorIs(t, err, ErrInvalidModerationResolution)
+
+	resolved, err := service.Resolve(ctx, first, item.ID, ModerationReviewReject, "Spam")
+	require.NoError(t, err)
+	assert.Equal(t, ModerationReviewCompleted, resolved.Status)
+	assert.Equal(t, "rejected", resolved.Resolution)
+	assert.NotNil(t, resolved.ResolvedAt)
+
+	_, err = service.Claim(ctx, second, item.ID)
+	assert.ErrorIs(t, err, ErrModerationItemClaimed)
+	_, err = service.Claim(ctx, second, uuid.Nil)
+	assert.ErrorIs(t, err, ErrModerationQueueEmpty)
+}
+
+func TestModerationReviewQueue_EscalateRequeuesAtHigherPriority(t *testing.T) {
+	service, _, recorder := newTestModerationReviewQueue()
+	ctx := context.Background()
+	item := enqueueReviewItem(t, service, "high")
+	moderatorID := uuid.New()
+
+	_, err := service.Claim(ctx, moderatorID, item.ID)
+	require.NoError(t, err)
+	escalated, err := service.Resolve(ctx, moderatorID, item.ID, ModerationReviewEscalate, "Needs a senior moderator")
+	require.NoError(t, err)
+	assert.Equal(t, ModerationReviewEscalated, escalated.Status)
+	assert.Equal(t, "critical", escalated.Priority)
+	assert.Nil(t, escalated.AssignedTo)
+
+	items, err := service.List(ctx, "critical", 10)
+	require.NoError(t, err)
+	require.Len(t, items, 1)
+	assert.Equal(t, item.ID, items[0].ID)
+
+	// Escalated items can be claimed again
+	_, err = service.Claim(ctx, uuid.New(), item.ID)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"claim", "escalate", "claim"}, recorder.names())
+	assert.Equal(t, moderatorID, recorder.actions[1].ModeratorID)
+}
+
+func TestModerationReviewQueue_ReassignsExpiredClaims(t *testing.T) {
+	service, store, recorder := newTestModerationReviewQueue()
+	ctx := context.Background()
+	item := enqueueReviewItem(t, service, "medium")
+	slow, other := uuid.New(), uuid.New()
+
+	_, err := service.Claim(ctx, slow, item.ID)
+	require.NoError(t, err)
+	assert.Zero(t, service.ReassignExpiredClaims(ctx))
+
+	store.expire(item.ID)
+	_, err = service.Resolve(ctx, slow, item.ID, ModerationReviewApprove, "")
+	assert.ErrorIs(t, err, ErrModerationClaimNotHeld)
+
+	assert.Equal(t, 1, service.ReassignExpiredClaims(ctx))
+	requeued, err := store.Get(ctx, item.ID)
+	require.NoError(t, err)
+	assert.Equal(t, ModerationReviewPending, requeued.Status)
+	assert.Nil(t, requeued.AssignedTo)
+
+	claimed, err := service.Claim(ctx, other, uuid.Nil)
+	require.NoError(t, err)
+	assert.Equal(t, item.ID, claimed.ID)
+	_, err = service.Resolve(ctx, other, item.ID, ModerationReviewApprove, "")
+	require.NoError(t, err)
+
+	assert.Equal(t, []string{"claim", "claim_expired", "claim", "approve"}, recorder.names())
+	assert.Equal(t, slow, recorder.actions[1].ModeratorID)
+}
+
+func TestModerationReviewQueue_AutoAssignDisabled(t *testing.T) {
+	service, _, _ := newTestModerationReviewQueue()
+	service.config.AutoAssignEnabled = false
+	item := enqueueReviewItem(t, service, "low")
+
+	_, err := service.Claim(context.Background(), uuid.New(), uuid.Nil)
+	assert.ErrorIs(t, err, ErrModerationAutoAssignDisabled)
+	_, err = service.Claim(context.Background(), uuid.New(), item.ID)
+	assert.NoError(t, err)
+}
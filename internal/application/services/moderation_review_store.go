@@ -0,0 +1,302 @@
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	goredis "github.com/go-redis/redis/v8"
+	"github.com/google/uuid"
+	"github.com/22smeargle/winkr-backend/internal/infrastructure/database/redis"
+	"github.com/22smeargle/winkr-backend/pkg/logger"
+)
+
+// Statuses of an item in the moderator review queue
+const (
+	ModerationReviewPending    = "pending"
+	ModerationReviewInProgress = "in_progress"
+	ModerationReviewCompleted  = "completed"
+	ModerationReviewEscalated  = "escalated"
+)
+
+// ModerationReviewItem is a report, appeal or piece of content waiting for a moderator
+type ModerationReviewItem struct {
+	ID             uuid.UUID  `json:"id"`
+	ItemType       string     `json:"item_type"` // "report", "appeal" or "content"
+	ItemID         uuid.UUID  `json:"item_id"`
+	UserID         *uuid.UUID `json:"user_id,omitempty"` // The user the item is about
+	Priority       string     `json:"priority"`          // One of the queue's PriorityLevels
+	Status         string     `json:"status"`
+	AssignedTo     *uuid.UUID `json:"assigned_to,omitempty"`
+	AssignedAt     *time.Time `json:"assigned_at,omitempty"`
+	ClaimExpiresAt *time.Time `json:"claim_expires_at,omitempty"` // The item returns to the queue after this
+	Resolution     string     `json:"resolution,omitempty"`       // "approved" or "rejected" once completed
+	Notes          string     `json:"notes,omitempty"`
+	CreatedAt      time.Time  `json:"created_at"`
+	UpdatedAt      time.Time  `json:"updated_at"`
+	ResolvedAt     *time.Time `json:"resolved_at,omitempty"`
+}
+
+// ModerationReviewStore keeps review queue items, the items waiting in each priority level oldest
+// first, and the claims moderators hold on items
+type ModerationReviewStore interface {
+	Get(ctx context.Context, id uuid.UUID) (*ModerationReviewItem, error)
+	Save(ctx context.Context, item *ModerationReviewItem) error
+	// Push saves the item and puts it in the queue of its priority
+	Push(ctx context.Context, item *ModerationReviewItem) error
+	// Pending returns up to limit items waiting in the queue of the priority, oldest first
+	Pending(ctx context.Context, priority string, limit int) ([]*ModerationReviewItem, error)
+	CountPending(ctx context.Context, priority string) (int, error)
+	// Claim takes the item out of the queue of its priority and saves it with its claim. It returns
+	// false when the item was no longer waiting, e.g. because another moderator claimed it first.
+	Claim(ctx context.Context, item *ModerationReviewItem) (bool, error)
+	// Unclaim drops the claim on the item, returning false when there was none
+	Unclaim(ctx context.Context, id uuid.UUID) (bool, error)
+	// ExpiredClaims returns up to limit items whose claims expired before the time
+	ExpiredClaims(ctx context.Context, before time.Time, limit int) ([]uuid.UUID, error)
+}
+
+// RedisModerationReviewStore keeps review queue items in a Redis hash, the items waiting in each
+// priority level in a sorted set scored by creation time, and claims in a sorted set scored by expiry
+type RedisModerationReviewStore struct {
+	redisClient *redis.RedisClient
+	prefix      string
+}
+
+// NewRedisModerationReviewStore creates a new RedisModerationReviewStore
+func NewRedisModerationReviewStore(redisClient *redis.RedisClient) *RedisModerationReviewStore {
+	return &RedisModerationReviewStore{
+		redisClient: redisClient,
+		prefix:      "moderation_review:",
+	}
+}
+
+func (s *RedisModerationReviewStore) itemsKey() string {
+	return s.prefix + "items"
+}
+
+func (s *RedisModerationReviewStore) pendingKey(priority string) string {
+	return s.prefix + "pending:" + priority
+}
+
+func (s *RedisModerationReviewStore) claimsKey() string {
+	return s.prefix + "claims"
+}
+
+// Get returns the item, or nil if there is none
+func (s *RedisModerationReviewStore) Get(ctx context.Context, id uuid.UUID) (*ModerationReviewItem, error) {
+	data, err := s.redisClient.GetClient().HGet(ctx, s.itemsKey(), id.String()).Result()
+	if err == goredis.Nil {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get moderation review item: %w", err)
+	}
+
+	var item ModerationReviewItem
+	if err := json.Unmarshal([]byte(data), &item); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal moderation review item: %w", err)
+	}
+	return &item, nil
+}
+
+// Save stores the item
+func (s *RedisModerationReviewStore) Save(ctx context.Context, item *ModerationReviewItem) error {
+	data, err := json.Marshal(item)
+	if err != nil {
+		return fmt.Errorf("failed to marshal moderation review item: %w", err)
+	}
+
+	if err := s.redisClient.HSet(ctx, s.itemsKey(), item.ID.String(), data); err != nil {
+		return fmt.Errorf("failed to save moderation review item: %w", err)
+	}
+	return nil
+}
+
+// Push saves the item and adds it to the queue of its priority
+func (s *RedisModerationReviewStore) Push(ctx context.Context, item *ModerationReviewItem) error {
+	data, err := json.Marshal(item)
+	if err != nil {
+		return fmt.Errorf("failed to marshal moderation review item: %w", err)
+	}
+
+	_, err = s.redisClient.GetClient().TxPipelined(ctx, func(pipe goredis.Pipeliner) error {
+		pipe.HSet(ctx, s.itemsKey(), item.ID.String(), data)
+		pipe.ZAdd(ctx, s.pendingKey(item.Priority), &goredis.Z{
+			Score:  float64(item.CreatedAt.UnixMilli()),
+			Member: item.ID.String(),
+		})
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("failed to queue moderation review item: %w", err)
+	}
+	return nil
+}
+
+// Pending returns up to limit items waiting in the queue of the priority, oldest first. Items that
+// can't be read are logged and skipped.
+func (s *RedisModerationReviewStore) Pending(ctx context.Context, priority string, limit int) ([]*ModerationReviewItem, error) {
+	client := s.redisClient.GetClient()
+	ids, err := client.ZRange(ctx, s.pendingKey(priority), 0, int64(limit-1)).Result()
+	if err != nil {
+		return nil, fmt.Errorf("failed to list pending moderation review items: %w", err)
+	}
+	if len(ids) == 0 {
+		return nil, nil
+	}
+
+	values, err := client.HMGet(ctx, s.itemsKey(), ids...).Result()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get pending moderation review items: %w", err)
+	}
+
+	items := make([]*ModerationReviewItem, 0, len(values))
+	for i, value := range values {
+		data, ok := value.(string)
+		if !ok {
+			logger.Warn("Pending moderation review item not found", "item_id", ids[i])
+			continue
+		}
+		var item ModerationReviewItem
+		if err := json.Unmarshal([]byte(data), &item); err != nil {
+			logger.Error("Failed to unmarshal moderation review item", err, "item_id", ids[i])
+			continue
+		}
+		items = append(items, &item)
+	}
+	return items, nil
+}
+
+// CountPending returns how many items wait in the queue of the priority
+func (s *RedisModerationReviewStore) CountPending(ctx context.Context, priority string) (int, error) {
+	count, err := s.redisClient.GetClient().ZCard(ctx, s.pendingKey(priority)).Result()
+	if err != nil {
+		return 0, fmt.Errorf("failed to count pending moderation review items: %w", err)
+	}
+	return int(count), nil
+}
+
+// Claim removes the item from the queue of its priority and, if it was still there, saves it and
+// records its claim
+func (s *RedisModerationReviewStore) Claim(ctx context.Context, item *ModerationReviewItem) (bool, error) {
+	client := s.redisClient.GetClient()
+
+	// Only one moderator can remove the item from the queue, so whoever does holds the claim
+	removed, err := client.ZRem(ctx, s.pendingKey(item.Priority), item.ID.String()).Result()
+	if err != nil {
+		return false, fmt.Errorf("failed to claim moderation review item: %w", err)
+	}
+	if removed == 0 {
+		return false, nil
+	}
+
+	data, err := json.Marshal(item)
+	if err != nil {
+		return false, fmt.Errorf("failed to marshal moderation review item: %w", err)
+	}
+
+	_, err = client.TxPipelined(ctx, func(pipe goredis.Pipeliner) error {
+		pipe.HSet(ctx, s.itemsKey(), item.ID.String(), data)
+		pipe.ZAdd(ctx, s.claimsKey(), &goredis.Z{
+			Score:  float64(item.ClaimExpiresAt.Unix()),
+			Member: item.ID.String(),
+		})
+		return nil
+	})
+	if err != nil {
+		return false, fmt.Errorf("failed to save moderation review claim: %w", err)
+	}
+	return true, nil
+}
+
+// Unclaim drops the claim on the item
+func (s *RedisModerationReviewStore) Unclaim(ctx context.Context, id uuid.UUID) (bool, error) {
+	removed, err := s.redisClient.GetClient().ZRem(ctx, s.claimsKey(), id.String()).Result()
+	if err != nil {
+		return false, fmt.Errorf("failed to drop moderation review claim: %w", err)
+	}
+	return removed > 0, nil
+}
+
+// ExpiredClaims returns up to limit items whose claims expired before the time
+func (s *RedisModerationReviewStore) ExpiredClaims(ctx context.Context, before time.Time, limit int) ([]uuid.UUID, error) {
+	members, err := s.redisClient.GetClient().ZRangeByScore(ctx, s.claimsKey(), &goredis.ZRangeBy{
+		Min:   "-inf",
+		Max:   fmt.Sprintf("%d", before.Unix()),
+		Count: int64(limit),
+	}).Result()
+	if err != nil {
+		return nil, fmt.Errorf("failed to list expired moderation review claims: %w", err)
+	}
+
+	ids := make([]uuid.UUID, 0, len(members))
+	for _, member := range members {
+		id, err := uuid.Parse(member)
+		if err != nil {
+			logger.Error("Invalid moderation review claim", err, "member", member)
+			continue
+		}
+		ids = append(ids, id)
+	}
+	return ids, nil
+}
+
+// ModeratorQueueAction is something a moderator did to an item in the review queue
+type ModeratorQueueAction struct {
+	ModeratorID uuid.UUID     `json:"moderator_id"`
+	ItemID      uuid.UUID     `json:"item_id"`
+	ItemType    string        `json:"item_type"`
+	Priority    string        `json:"priority"`
+	Action      string        `json:"action"`             // "claim", "approve", "reject", "escalate" or "claim_expired"
+	Duration    time.Duration `json:"duration,omitempty"` // Time from claiming the item to the action
+	CreatedAt   time.Time     `json:"created_at"`
+}
+
+// ModeratorActionRecorder records moderator actions for moderation analytics
+type ModeratorActionRecorder interface {
+	RecordModeratorAction(ctx context.Context, action *ModeratorQueueAction) error
+}
+
+// RedisModeratorActionLog keeps each moderator's recent queue actions in a Redis list and counts
+// their actions per day
+type RedisModeratorActionLog struct {
+	redisClient *redis.RedisClient
+	prefix      string
+	maxActions  int64         // Recent actions kept per moderator
+	countsTTL   time.Duration // How long daily counts are kept
+}
+
+// NewRedisModeratorActionLog creates a new RedisModeratorActionLog
+func NewRedisModeratorActionLog(redisClient *redis.RedisClient) *RedisModeratorActionLog {
+	return &RedisModeratorActionLog{
+		redisClient: redisClient,
+		prefix:      "moderation:moderator_actions:",
+		maxActions:  1000,
+		countsTTL:   90 * 24 * time.Hour,
+	}
+}
+
+// RecordModeratorAction adds the action to the moderator's recent actions and daily counts
+func (l *RedisModeratorActionLog) RecordModeratorAction(ctx context.Context, action *ModeratorQueueAction) error {
+	data, err := json.Marshal(action)
+	if err != nil {
+		return fmt.Errorf("failed to marshal moderator action: %w", err)
+	}
+
+	actionsKey := l.prefix + action.ModeratorID.String()
+	countsKey := fmt.Sprintf("%s%s:%s", l.prefix, action.ModeratorID, action.CreatedAt.UTC().Format("2006-01-02"))
+
+	_, err = l.redisClient.GetClient().TxPipelined(ctx, func(pipe goredis.Pipeliner) error {
+		pipe.LPush(ctx, actionsKey, data)
+		pipe.LTrim(ctx, actionsKey, 0, l.maxActions-1)
+		pipe.HIncrBy(ctx, countsKey, action.Action, 1)
+		pipe.Expire(ctx, countsKey, l.countsTTL)
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("failed to record moderator action: %w", err)
+	}
+	return nil
+}
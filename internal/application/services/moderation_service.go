@@ -20,6 +20,8 @@ type ModerationService struct {
 	contentAnalysisService *ContentAnalysisService
 	cacheService       CacheService
 	notificationService NotificationService
+	banCascade        *BanCascadeService
+	reviewQueue       *ModerationReviewQueueService
 	config            ModerationConfig
 }
 
@@ -133,6 +135,8 @@ func NewModerationService(
 	contentAnalysisService *ContentAnalysisService,
 	cacheService CacheService,
 	notificationService NotificationService,
+	banCascade *BanCascadeService,
+	reviewQueue *ModerationReviewQueueService,
 	config ModerationConfig,
 ) *ModerationService {
 	return &ModerationService{
@@ -142,6 +146,8 @@ func NewModerationService(
 		contentAnalysisService:  contentAnalysisService,
 		cacheService:          cacheService,
 		notificationService:    notificationService,
+		banCascade:            banCascade,
+		reviewQueue:           reviewQueue,
 		config:                config,
 	}
 }
@@ -505,6 +511,13 @@ func (s *ModerationService) banUser(ctx context.Context, userID uuid.UUID, actio
 		return fmt.Errorf("failed to ban user: %w", err)
 	}
 	
+	// End the banned user's matches and conversations
+	if s.banCascade != nil {
+		if err := s.banCascade.OnUserBanned(ctx, userID); err != nil {
+			return fmt.Errorf("failed to end matches of banned user: %w", err)
+		}
+	}
+	
 	// Log action
 	if err := s.logModerationAction(ctx, userID, action); err != nil {
 		logger.Error("Failed to log moderation action", err, "user_id", userID)
@@ -652,9 +665,35 @@ func (s *ModerationService) calculateReputationChange(action ModerationAction) f
 // Additional helper methods would be implemented here
 // For brevity, I'm including method signatures only
 
+// addToModerationQueue queues the item for moderator review. Numeric priorities (1=high, 2=medium,
+// 3=low) are mapped to the review queue's priority levels.
 func (s *ModerationService) addToModerationQueue(ctx context.Context, itemType, itemID, userID string, data map[string]interface{}) error {
-	// TODO: Implement queue addition
-	return nil
+	if s.reviewQueue == nil {
+		return nil
+	}
+
+	id, err := uuid.Parse(itemID)
+	if err != nil {
+		return fmt.Errorf("invalid item ID: %w", err)
+	}
+
+	var subject *uuid.UUID
+	if parsed, err := uuid.Parse(userID); err == nil {
+		subject = &parsed
+	}
+
+	priority := ""
+	switch data["priority"] {
+	case 1:
+		priority = "high"
+	case 2:
+		priority = "medium"
+	case 3:
+		priority = "low"
+	}
+
+	_, err = s.reviewQueue.Enqueue(ctx, itemType, id, subject, priority)
+	return err
 }
 
 func (s *ModerationService) getQueueItems(ctx context.Context, priority string, limit int) ([]ModerationQueue, error) {
@@ -17,6 +17,7 @@ type MonitoringJobsService struct {
 	healthCheckService *HealthCheckService
 	metricsService    *MetricsService
 	alertingService  *AlertingService
+	alertEvaluator   *AlertEvaluator
 	mu                sync.RWMutex
 	running           bool
 	stopChan          chan struct{}
@@ -30,7 +31,7 @@ func NewMonitoringJobsService(
 	metricsService *MetricsService,
 	alertingService *AlertingService,
 ) *MonitoringJobsService {
-	return &MonitoringJobsService{
+	service := &MonitoringJobsService{
 		config:             cfg,
 		cacheService:       cacheService,
 		healthCheckService: healthCheckService,
@@ -39,6 +40,13 @@ func NewMonitoringJobsService(
 		running:           false,
 		stopChan:          make(chan struct{}),
 	}
+
+	// Check the configured thresholds and rules, alerting through the alerting service
+	if alertingService != nil {
+		service.alertEvaluator = NewAlertEvaluator(&cfg.Monitoring.Alerting, alertingService)
+	}
+
+	return service
 }
 
 // Start starts all monitoring background jobs
@@ -393,6 +401,11 @@ func (m *MonitoringJobsService) performExternalServiceCheck(ctx context.Context)
 		"timestamp":           time.Now(),
 	}
 	
+	// Evaluate the configured thresholds and rules
+	if m.alertEvaluator != nil {
+		m.alertEvaluator.Evaluate(ctx, metrics)
+	}
+
 	// Evaluate alert rules
 	if m.alertingService != nil {
 		if err := m.alertingService.EvaluateRules(ctx, metrics); err != nil {
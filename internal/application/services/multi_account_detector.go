@@ -0,0 +1,207 @@
+package services
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/22smeargle/winkr-backend/internal/domain/entities"
+	"github.com/22smeargle/winkr-backend/internal/domain/repositories"
+	"github.com/22smeargle/winkr-backend/pkg/config"
+	"github.com/22smeargle/winkr-backend/pkg/logger"
+)
+
+// MultiAccountUserStore defines the user operations needed to check and restrict linked accounts
+type MultiAccountUserStore interface {
+	GetByID(ctx context.Context, id uuid.UUID) (*entities.User, error)
+	Update(ctx context.Context, user *entities.User) error
+}
+
+// MultiAccountDetector links accounts that are used from the same device or IP address
+// and flags them for review when one person appears to run many accounts, e.g. to evade a ban
+type MultiAccountDetector struct {
+	signalRepo repositories.AccountSignalRepository
+	userStore  MultiAccountUserStore
+	config     *config.MultiAccountConfig
+	tracking   *config.VerificationSecurityConfig
+	now        func() time.Time
+}
+
+// NewMultiAccountDetector creates a new MultiAccountDetector.
+// Devices and IP addresses are only recorded while the matching tracking flag is enabled.
+func NewMultiAccountDetector(
+	signalRepo repositories.AccountSignalRepository,
+	userStore MultiAccountUserStore,
+	cfg *config.MultiAccountConfig,
+	tracking *config.VerificationSecurityConfig,
+) *MultiAccountDetector {
+	return &MultiAccountDetector{
+		signalRepo: signalRepo,
+		userStore:  userStore,
+		config:     cfg,
+		tracking:   tracking,
+		now:        time.Now,
+	}
+}
+
+// RecordLogin records the device and IP address an account was used from and flags them when
+// they are shared by too many accounts. It returns true if the account was restricted because
+// it is a new account on the device of a banned account.
+func (d *MultiAccountDetector) RecordLogin(ctx context.Context, userID uuid.UUID, deviceFingerprint, ipAddress string) (bool, error) {
+	if d == nil || !d.config.Enabled {
+		return false, nil
+	}
+
+	restricted := false
+	if d.tracking.DeviceTrackingEnabled && deviceFingerprint != "" {
+		value, err := d.recordSignal(ctx, userID, entities.AccountSignalDevice, deviceFingerprint, d.config.MaxAccountsPerDevice)
+		if err != nil {
+			return false, err
+		}
+
+		if d.config.RestrictBannedDevices {
+			restricted, err = d.restrictIfSharesBannedDevice(ctx, userID, value)
+			if err != nil {
+				return false, err
+			}
+		}
+	}
+
+	if d.tracking.IPTrackingEnabled && ipAddress != "" {
+		if _, err := d.recordSignal(ctx, userID, entities.AccountSignalIP, ipAddress, d.config.MaxAccountsPerIP); err != nil {
+			return false, err
+		}
+	}
+
+	return restricted, nil
+}
+
+// GetFlaggedClusters returns the flagged devices and IP addresses with the accounts that share them
+func (d *MultiAccountDetector) GetFlaggedClusters(ctx context.Context, limit, offset int) ([]*entities.LinkedAccountCluster, int64, error) {
+	flags, total, err := d.signalRepo.ListLinkedAccountFlags(ctx, limit, offset)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to list linked account flags: %w", err)
+	}
+
+	clusters := make([]*entities.LinkedAccountCluster, len(flags))
+	for i, flag := range flags {
+		userIDs, err := d.signalRepo.GetUserIDsBySignal(ctx, flag.SignalType, flag.Value, time.Time{})
+		if err != nil {
+			return nil, 0, fmt.Errorf("failed to get linked accounts: %w", err)
+		}
+		clusters[i] = &entities.LinkedAccountCluster{
+			LinkedAccountFlag: flag,
+			UserIDs:           userIDs,
+		}
+	}
+
+	return clusters, total, nil
+}
+
+// recordSignal stores a signal of the account and flags it if more than maxAccounts accounts
+// were seen with it within the window. It returns the stored value.
+func (d *MultiAccountDetector) recordSignal(ctx context.Context, userID uuid.UUID, signalType, raw string, maxAccounts int) (string, error) {
+	now := d.now()
+	value := hashSignal(raw)
+
+	err := d.signalRepo.RecordSignal(ctx, &entities.AccountSignal{
+		UserID:      userID,
+		SignalType:  signalType,
+		Value:       value,
+		FirstSeenAt: now,
+		LastSeenAt:  now,
+	})
+	if err != nil {
+		return "", fmt.Errorf("failed to record %s signal: %w", signalType, err)
+	}
+
+	if maxAccounts <= 0 {
+		return value, nil
+	}
+
+	userIDs, err := d.signalRepo.GetUserIDsBySignal(ctx, signalType, value, now.Add(-d.config.Window))
+	if err != nil {
+		return "", fmt.Errorf("failed to get accounts by %s: %w", signalType, err)
+	}
+
+	if len(userIDs) > maxAccounts {
+		err := d.signalRepo.SaveLinkedAccountFlag(ctx, &entities.LinkedAccountFlag{
+			SignalType:   signalType,
+			Value:        value,
+			Reason:       entities.LinkedAccountReasonTooManyAccounts,
+			AccountCount: len(userIDs),
+		})
+		if err != nil {
+			return "", fmt.Errorf("failed to flag linked accounts: %w", err)
+		}
+	}
+
+	return value, nil
+}
+
+// restrictIfSharesBannedDevice deactivates a new account used on the same device as a banned account
+// and flags the device. Accounts older than the window are left to moderators.
+func (d *MultiAccountDetector) restrictIfSharesBannedDevice(ctx context.Context, userID uuid.UUID, value string) (bool, error) {
+	user, err := d.userStore.GetByID(ctx, userID)
+	if err != nil {
+		return false, fmt.Errorf("failed to get user: %w", err)
+	}
+	if !user.IsActive || user.CreatedAt.Before(d.now().Add(-d.config.Window)) {
+		return false, nil
+	}
+
+	// Banned accounts cannot sign in, so look at every account ever seen on the device
+	userIDs, err := d.signalRepo.GetUserIDsBySignal(ctx, entities.AccountSignalDevice, value, time.Time{})
+	if err != nil {
+		return false, fmt.Errorf("failed to get accounts by device: %w", err)
+	}
+
+	bannedUserID := uuid.Nil
+	for _, otherUserID := range userIDs {
+		if otherUserID == userID {
+			continue
+		}
+		other, err := d.userStore.GetByID(ctx, otherUserID)
+		if err != nil {
+			logger.Warn("Failed to get linked account", err, "user_id", otherUserID)
+			continue
+		}
+		if other.IsBanned {
+			bannedUserID = other.ID
+			break
+		}
+	}
+	if bannedUserID == uuid.Nil {
+		return false, nil
+	}
+
+	err = d.signalRepo.SaveLinkedAccountFlag(ctx, &entities.LinkedAccountFlag{
+		SignalType:   entities.AccountSignalDevice,
+		Value:        value,
+		Reason:       entities.LinkedAccountReasonBannedAccount,
+		AccountCount: len(userIDs),
+	})
+	if err != nil {
+		return false, fmt.Errorf("failed to flag linked accounts: %w", err)
+	}
+
+	user.IsActive = false
+	if err := d.userStore.Update(ctx, user); err != nil {
+		return false, fmt.Errorf("failed to restrict user: %w", err)
+	}
+
+	logger.Info("Restricted new account sharing a device with a banned account",
+		"user_id", userID,
+		"banned_user_id", bannedUserID,
+	)
+	return true, nil
+}
+
+// hashSignal hashes a device fingerprint or IP address so the raw value is not stored
+func hashSignal(raw string) string {
+	sum := sha256.Sum256([]byte(raw))
+	return hex.EncodeToString(sum[:])
+}
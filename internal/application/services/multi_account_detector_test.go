@@ -0,0 +1,191 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/22smeargle/winkr-backend/internal/domain/entities"
+	"github.com/22smeargle/winkr-backend/pkg/config"
+)
+
+// inMemoryAccountSignalRepository is an in-memory AccountSignalRepository for tests
+type inMemoryAccountSignalRepository struct {
+	signals []*entities.AccountSignal
+	flags   []*entities.LinkedAccountFlag
+}
+
+func (r *inMemoryAccountSignalRepository) RecordSignal(ctx context.Context, signal *entities.AccountSignal) error {
+	for _, existing := range r.signals {
+		if existing.UserID == signal.UserID && existing.SignalType == signal.SignalType && existing.Value == signal.Value {
+			existing.LastSeenAt = signal.LastSeenAt
+			return nil
+		}
+	}
+	r.signals = append(r.signals, signal)
+	return nil
+}
+
+func (r *inMemoryAccountSignalRepository) GetUserIDsBySignal(ctx context.Context, signalType, value string, since time.Time) ([]uuid.UUID, error) {
+	var userIDs []uuid.UUID
+	for _, signal := range r.signals {
+		if signal.SignalType == signalType && signal.Value == value && !signal.LastSeenAt.Before(since) {
+			userIDs = append(userIDs, signal.UserID)
+		}
+	}
+	return userIDs, nil
+}
+
+func (r *inMemoryAccountSignalRepository) SaveLinkedAccountFlag(ctx context.Context, flag *entities.LinkedAccountFlag) error {
+	for _, existing := range r.flags {
+		if existing.SignalType == flag.SignalType && existing.Value == flag.Value {
+			existing.Reason = flag.Reason
+			existing.AccountCount = flag.AccountCount
+			return nil
+		}
+	}
+	r.flags = append(r.flags, flag)
+	return nil
+}
+
+func (r *inMemoryAccountSignalRepository) ListLinkedAccountFlags(ctx context.Context, limit, offset int) ([]*entities.LinkedAccountFlag, int64, error) {
+	return r.flags, int64(len(r.flags)), nil
+}
+
+// inMemoryMultiAccountUserStore is an in-memory MultiAccountUserStore for tests
+type inMemoryMultiAccountUserStore struct {
+	users map[uuid.UUID]*entities.User
+}
+
+func (s *inMemoryMultiAccountUserStore) GetByID(ctx context.Context, id uuid.UUID) (*entities.User, error) {
+	user, ok := s.users[id]
+	if !ok {
+		return nil, fmt.Errorf("user not found")
+	}
+	return user, nil
+}
+
+func (s *inMemoryMultiAccountUserStore) Update(ctx context.Context, user *entities.User) error {
+	s.users[user.ID] = user
+	return nil
+}
+
+func (s *inMemoryMultiAccountUserStore) addUser(banned bool) uuid.UUID {
+	user := &entities.User{ID: uuid.New(), IsActive: true, IsBanned: banned, CreatedAt: time.Now()}
+	s.users[user.ID] = user
+	return user.ID
+}
+
+func testMultiAccountConfig() *config.MultiAccountConfig {
+	return &config.MultiAccountConfig{
+		Enabled:              true,
+		MaxAccountsPerDevice: 3,
+		MaxAccountsPerIP:     10,
+		Window:               30 * 24 * time.Hour,
+	}
+}
+
+func testTrackingConfig() *config.VerificationSecurityConfig {
+	return &config.VerificationSecurityConfig{
+		IPTrackingEnabled:     true,
+		DeviceTrackingEnabled: true,
+	}
+}
+
+func TestMultiAccountDetector_ManyAccountsOneDevice(t *testing.T) {
+	ctx := context.Background()
+	signalRepo := &inMemoryAccountSignalRepository{}
+	userStore := &inMemoryMultiAccountUserStore{users: make(map[uuid.UUID]*entities.User)}
+	detector := NewMultiAccountDetector(signalRepo, userStore, testMultiAccountConfig(), testTrackingConfig())
+
+	var userIDs []uuid.UUID
+	for i := 0; i < 3; i++ {
+		userID := userStore.addUser(false)
+		userIDs = append(userIDs, userID)
+		_, err := detector.RecordLogin(ctx, userID, "device-1", fmt.Sprintf("10.0.0.%d", i))
+		require.NoError(t, err)
+	}
+
+	// Up to the limit, accounts on one device are not flagged
+	assert.Empty(t, signalRepo.flags)
+
+	userID := userStore.addUser(false)
+	userIDs = append(userIDs, userID)
+	restricted, err := detector.RecordLogin(ctx, userID, "device-1", "10.0.0.9")
+	require.NoError(t, err)
+	assert.False(t, restricted)
+
+	clusters, total, err := detector.GetFlaggedClusters(ctx, 20, 0)
+	require.NoError(t, err)
+	require.Equal(t, int64(1), total)
+	require.Len(t, clusters, 1)
+	assert.Equal(t, entities.AccountSignalDevice, clusters[0].SignalType)
+	assert.Equal(t, entities.LinkedAccountReasonTooManyAccounts, clusters[0].Reason)
+	assert.Equal(t, 4, clusters[0].AccountCount)
+	assert.ElementsMatch(t, userIDs, clusters[0].UserIDs)
+
+	// Signing in again from the same device does not count as another account
+	_, err = detector.RecordLogin(ctx, userID, "device-1", "10.0.0.9")
+	require.NoError(t, err)
+	require.Len(t, signalRepo.flags, 1)
+	assert.Equal(t, 4, signalRepo.flags[0].AccountCount)
+
+	// The raw fingerprint is never stored
+	assert.NotEqual(t, "device-1", clusters[0].Value)
+}
+
+func TestMultiAccountDetector_RespectsTrackingFlags(t *testing.T) {
+	signalRepo := &inMemoryAccountSignalRepository{}
+	userStore := &inMemoryMultiAccountUserStore{users: make(map[uuid.UUID]*entities.User)}
+	detector := NewMultiAccountDetector(signalRepo, userStore, testMultiAccountConfig(), &config.VerificationSecurityConfig{
+		IPTrackingEnabled:     true,
+		DeviceTrackingEnabled: false,
+	})
+
+	for i := 0; i < 5; i++ {
+		_, err := detector.RecordLogin(context.Background(), userStore.addUser(false), "device-1", "10.0.0.1")
+		require.NoError(t, err)
+	}
+
+	assert.Empty(t, signalRepo.flags)
+	for _, signal := range signalRepo.signals {
+		assert.Equal(t, entities.AccountSignalIP, signal.SignalType)
+	}
+}
+
+func TestMultiAccountDetector_RestrictsNewAccountOnBannedDevice(t *testing.T) {
+	ctx := context.Background()
+	signalRepo := &inMemoryAccountSignalRepository{}
+	userStore := &inMemoryMultiAccountUserStore{users: make(map[uuid.UUID]*entities.User)}
+	cfg := testMultiAccountConfig()
+	cfg.RestrictBannedDevices = true
+	detector := NewMultiAccountDetector(signalRepo, userStore, cfg, testTrackingConfig())
+
+	bannedUserID := userStore.addUser(false)
+	_, err := detector.RecordLogin(ctx, bannedUserID, "device-1", "10.0.0.1")
+	require.NoError(t, err)
+	userStore.users[bannedUserID].IsBanned = true
+
+	// The banned account was last seen long ago, the device still links the new account to it
+	signalRepo.signals[0].LastSeenAt = time.Now().Add(-90 * 24 * time.Hour)
+
+	newUserID := userStore.addUser(false)
+	restricted, err := detector.RecordLogin(ctx, newUserID, "device-1", "10.0.0.2")
+	require.NoError(t, err)
+	assert.True(t, restricted)
+	assert.False(t, userStore.users[newUserID].IsActive)
+
+	require.Len(t, signalRepo.flags, 1)
+	assert.Equal(t, entities.LinkedAccountReasonBannedAccount, signalRepo.flags[0].Reason)
+
+	// Accounts on other devices are not affected
+	otherUserID := userStore.addUser(false)
+	restricted, err = detector.RecordLogin(ctx, otherUserID, "device-2", "10.0.0.3")
+	require.NoError(t, err)
+	assert.False(t, restricted)
+	assert.True(t, userStore.users[otherUserID].IsActive)
+}
@@ -0,0 +1,202 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/22smeargle/winkr-backend/internal/domain/entities"
+	"github.com/22smeargle/winkr-backend/internal/domain/repositories"
+	"github.com/22smeargle/winkr-backend/pkg/config"
+	"github.com/22smeargle/winkr-backend/pkg/logger"
+)
+
+// defaultPhotoReorderBatchSize is how many opted-in users are loaded per query when none is configured
+const defaultPhotoReorderBatchSize = 100
+
+// PhotoEngagementService tracks which photos people were shown before liking their owner, and
+// reorders the photos of users who opted in so the photo viewers like most comes first
+type PhotoEngagementService struct {
+	engagementRepo repositories.PhotoEngagementRepository
+	photoRepo      repositories.PhotoRepository
+	config         *config.MatchingPhotoReorderConfig
+	now            func() time.Time
+}
+
+// NewPhotoEngagementService creates a new PhotoEngagementService
+func NewPhotoEngagementService(
+	engagementRepo repositories.PhotoEngagementRepository,
+	photoRepo repositories.PhotoRepository,
+	cfg *config.MatchingPhotoReorderConfig,
+) *PhotoEngagementService {
+	return &PhotoEngagementService{
+		engagementRepo: engagementRepo,
+		photoRepo:      photoRepo,
+		config:         cfg,
+		now:            time.Now,
+	}
+}
+
+// RecordImpression records that the viewer was shown the photo. Owners looking at their own photos
+// are not counted.
+func (s *PhotoEngagementService) RecordImpression(ctx context.Context, photo *entities.Photo, viewerID uuid.UUID) error {
+	if photo.UserID == viewerID {
+		return nil
+	}
+
+	return s.engagementRepo.RecordImpression(ctx, &entities.PhotoImpression{
+		PhotoID:  photo.ID,
+		UserID:   photo.UserID,
+		ViewerID: viewerID,
+		ViewedAt: s.now(),
+	})
+}
+
+// RecordLike credits a like to every photo of the owner the liker was shown
+func (s *PhotoEngagementService) RecordLike(ctx context.Context, ownerID, likerID uuid.UUID) error {
+	return s.engagementRepo.MarkLiked(ctx, ownerID, likerID, s.now())
+}
+
+// ReorderUserPhotos puts the user's photos in order of performance, making the best one primary
+// unless the owner locked their primary photo. It reports whether the order changed.
+func (s *PhotoEngagementService) ReorderUserPhotos(ctx context.Context, userID uuid.UUID) (bool, error) {
+	photos, err := s.photoRepo.GetUserPhotos(ctx, userID, false)
+	if err != nil {
+		return false, fmt.Errorf("failed to get user photos: %w", err)
+	}
+
+	performance, err := s.engagementRepo.GetPerformanceByUserID(ctx, userID)
+	if err != nil {
+		return false, fmt.Errorf("failed to get photo performance: %w", err)
+	}
+
+	ranked := rankPhotosByEngagement(photos, performance, s.config.MinImpressions)
+	if ranked == nil {
+		return false, nil
+	}
+
+	photoIDs := make([]uuid.UUID, len(ranked))
+	unchanged := true
+	for i, photo := range ranked {
+		photoIDs[i] = photo.ID
+		if photo != photos[i] || photo.Position != i || photo.IsPrimary != (i == 0) {
+			unchanged = false
+		}
+	}
+	if unchanged {
+		return false, nil
+	}
+
+	if err := s.photoRepo.ReorderPhotos(ctx, userID, photoIDs); err != nil {
+		return false, err
+	}
+
+	return true, nil
+}
+
+// ReorderOptedInPhotos reorders the photos of every user who opted in. It returns how many users'
+// photos changed order.
+func (s *PhotoEngagementService) ReorderOptedInPhotos(ctx context.Context) (int, error) {
+	if !s.config.Enabled {
+		return 0, nil
+	}
+
+	batchSize := s.config.BatchSize
+	if batchSize <= 0 {
+		batchSize = defaultPhotoReorderBatchSize
+	}
+
+	reordered := 0
+	afterID := uuid.Nil
+	for {
+		userIDs, err := s.engagementRepo.GetAutoReorderUserIDs(ctx, afterID, batchSize)
+		if err != nil {
+			return reordered, fmt.Errorf("failed to get auto reorder users: %w", err)
+		}
+
+		for _, userID := range userIDs {
+			changed, err := s.ReorderUserPhotos(ctx, userID)
+			if err != nil {
+				logger.Error("Failed to reorder photos", err, "user_id", userID)
+				continue
+			}
+			if changed {
+				reordered++
+			}
+		}
+
+		if len(userIDs) < batchSize {
+			break
+		}
+		afterID = userIDs[len(userIDs)-1]
+	}
+
+	if reordered > 0 {
+		logger.Info("Photos reordered by engagement", "count", reordered)
+	}
+
+	return reordered, nil
+}
+
+// StartReorderScheduler periodically reorders the photos of users who opted in
+func (s *PhotoEngagementService) StartReorderScheduler(ctx context.Context, interval time.Duration) {
+	logger.Info("Starting photo reorder scheduler", "interval", interval)
+
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ctx.Done():
+				logger.Info("Photo reorder scheduler stopped")
+				return
+			case <-ticker.C:
+				if _, err := s.ReorderOptedInPhotos(ctx); err != nil {
+					logger.Error("Scheduled photo reorder run failed", err)
+				}
+			}
+		}
+	}()
+}
+
+// rankPhotosByEngagement orders photos by like rate. Only approved, visible photos shown to at least
+// minImpressions viewers are ranked; the others keep their order behind them. A locked primary photo
+// stays first. It returns nil when no photo can be ranked yet.
+func rankPhotosByEngagement(photos []*entities.Photo, performance map[uuid.UUID]*entities.PhotoPerformance, minImpressions int) []*entities.Photo {
+	var locked *entities.Photo
+	var ranked, unranked []*entities.Photo
+	for _, photo := range photos {
+		if photo.IsPrimary && photo.PrimaryLocked {
+			locked = photo
+			continue
+		}
+		stats, ok := performance[photo.ID]
+		if ok && stats.Impressions >= int64(minImpressions) && photo.IsVerified() && photo.IsVisible() {
+			ranked = append(ranked, photo)
+			continue
+		}
+		unranked = append(unranked, photo)
+	}
+
+	if len(ranked) == 0 {
+		return nil
+	}
+
+	sort.SliceStable(ranked, func(i, j int) bool {
+		a, b := performance[ranked[i].ID], performance[ranked[j].ID]
+		if a.LikeRate() != b.LikeRate() {
+			return a.LikeRate() > b.LikeRate()
+		}
+		return a.Likes > b.Likes
+	})
+
+	ordered := make([]*entities.Photo, 0, len(photos))
+	if locked != nil {
+		ordered = append(ordered, locked)
+	}
+	ordered = append(ordered, ranked...)
+	return append(ordered, unranked...)
+}
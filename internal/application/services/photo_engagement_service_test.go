@@ -0,0 +1,263 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/22smeargle/winkr-backend/internal/domain/entities"
+	"github.com/22smeargle/winkr-backend/pkg/config"
+)
+
+// inMemoryPhotoEngagementRepository keeps photo impressions in memory
+type inMemoryPhotoEngagementRepository struct {
+	impressions []*entities.PhotoImpression
+	optedIn     []uuid.UUID
+}
+
+func (r *inMemoryPhotoEngagementRepository) RecordImpression(ctx context.Context, impression *entities.PhotoImpression) error {
+	for _, existing := range r.impressions {
+		if existing.PhotoID == impression.PhotoID && existing.ViewerID == impression.ViewerID {
+			return nil
+		}
+	}
+	copied := *impression
+	r.impressions = append(r.impressions, &copied)
+	return nil
+}
+
+func (r *inMemoryPhotoEngagementRepository) MarkLiked(ctx context.Context, ownerID, viewerID uuid.UUID, likedAt time.Time) error {
+	for _, impression := range r.impressions {
+		if impression.UserID == ownerID && impression.ViewerID == viewerID && !impression.Liked {
+			impression.Liked = true
+			impression.LikedAt = &likedAt
+		}
+	}
+	return nil
+}
+
+func (r *inMemoryPhotoEngagementRepository) GetPerformanceByUserID(ctx context.Context, userID uuid.UUID) (map[uuid.UUID]*entities.PhotoPerformance, error) {
+	performance := make(map[uuid.UUID]*entities.PhotoPerformance)
+	for _, impression := range r.impressions {
+		if impression.UserID != userID {
+			continue
+		}
+		stats, ok := performance[impression.PhotoID]
+		if !ok {
+			stats = &entities.PhotoPerformance{PhotoID: impression.PhotoID}
+			performance[impression.PhotoID] = stats
+		}
+		stats.Impressions++
+		if impression.Liked {
+			stats.Likes++
+		}
+	}
+	return performance, nil
+}
+
+func (r *inMemoryPhotoEngagementRepository) GetAutoReorderUserIDs(ctx context.Context, afterID uuid.UUID, limit int) ([]uuid.UUID, error) {
+	userIDs := append([]uuid.UUID(nil), r.optedIn...)
+	sort.Slice(userIDs, func(i, j int) bool { return userIDs[i].String() < userIDs[j].String() })
+
+	var page []uuid.UUID
+	for _, userID := range userIDs {
+		if userID.String() > afterID.String() && len(page) < limit {
+			page = append(page, userID)
+		}
+	}
+	return page, nil
+}
+
+// GetUserPhotos returns copies of the user's photos, primary first and then in display order
+func (r *inMemoryPhotoRepository) GetUserPhotos(ctx context.Context, userID uuid.UUID, includeDeleted bool) ([]*entities.Photo, error) {
+	var photos []*entities.Photo
+	for _, photo := range r.photos {
+		if photo.UserID == userID && (includeDeleted || !photo.IsDeleted) {
+			copied := *photo
+			photos = append(photos, &copied)
+		}
+	}
+	sort.SliceStable(photos, func(i, j int) bool {
+		if photos[i].IsPrimary != photos[j].IsPrimary {
+			return photos[i].IsPrimary
+		}
+		return photos[i].Position < photos[j].Position
+	})
+	return photos, nil
+}
+
+func (r *inMemoryPhotoRepository) ReorderPhotos(ctx context.Context, userID uuid.UUID, photoIDs []uuid.UUID) error {
+	for _, photo := range r.photos {
+		if photo.UserID == userID && photo.IsPrimary && photo.PrimaryLocked && photo.ID != photoIDs[0] {
+			return fmt.Errorf("primary photo is locked")
+		}
+	}
+	for i, photoID := range photoIDs {
+		for _, photo := range r.photos {
+			if photo.ID == photoID && photo.UserID == userID {
+				photo.Position = i
+				photo.IsPrimary = i == 0
+			}
+		}
+	}
+	return nil
+}
+
+type photoEngagementFixture struct {
+	service    *PhotoEngagementService
+	photos     *inMemoryPhotoRepository
+	engagement *inMemoryPhotoEngagementRepository
+	config     *config.MatchingPhotoReorderConfig
+	owner      uuid.UUID
+}
+
+func newPhotoEngagementFixture() *photoEngagementFixture {
+	f := &photoEngagementFixture{
+		photos:     &inMemoryPhotoRepository{},
+		engagement: &inMemoryPhotoEngagementRepository{},
+		config:     &config.MatchingPhotoReorderConfig{Enabled: true, MinImpressions: 5, BatchSize: 1},
+		owner:      uuid.New(),
+	}
+	f.service = NewPhotoEngagementService(f.engagement, f.photos, f.config)
+	return f
+}
+
+// addPhoto adds an approved photo of the given user at the next position
+func (f *photoEngagementFixture) addPhoto(userID uuid.UUID) *entities.Photo {
+	photo := f.photos.add(userID, "approved")
+	for _, other := range f.photos.photos {
+		if other.UserID == userID && other != photo {
+			photo.Position++
+		}
+	}
+	photo.IsPrimary = photo.Position == 0
+	return photo
+}
+
+// show shows the photo to new viewers, the first likes of whom go on to like its owner
+func (f *photoEngagementFixture) show(t *testing.T, photo *entities.Photo, viewers, likes int) {
+	ctx := context.Background()
+	for i := 0; i < viewers; i++ {
+		viewerID := uuid.New()
+		require.NoError(t, f.service.RecordImpression(ctx, photo, viewerID))
+		if i < likes {
+			require.NoError(t, f.service.RecordLike(ctx, photo.UserID, viewerID))
+		}
+	}
+}
+
+// order returns the user's photos in display order
+func (f *photoEngagementFixture) order(userID uuid.UUID) []uuid.UUID {
+	photos, _ := f.photos.GetUserPhotos(context.Background(), userID, false)
+	order := make([]uuid.UUID, len(photos))
+	for i, photo := range photos {
+		order[i] = photo.ID
+	}
+	return order
+}
+
+func TestPhotoEngagementService_TracksImpressionsAndLikes(t *testing.T) {
+	f := newPhotoEngagementFixture()
+	ctx := context.Background()
+	first := f.addPhoto(f.owner)
+	second := f.addPhoto(f.owner)
+	viewer := uuid.New()
+
+	// Seen twice, counted once
+	require.NoError(t, f.service.RecordImpression(ctx, first, viewer))
+	require.NoError(t, f.service.RecordImpression(ctx, first, viewer))
+	require.NoError(t, f.service.RecordImpression(ctx, second, viewer))
+	// The owner looking at their own photo is not counted
+	require.NoError(t, f.service.RecordImpression(ctx, first, f.owner))
+	// Shown the first photo only, and passed
+	require.NoError(t, f.service.RecordImpression(ctx, first, uuid.New()))
+
+	require.NoError(t, f.service.RecordLike(ctx, f.owner, viewer))
+	// A like without any photo shown credits nothing
+	require.NoError(t, f.service.RecordLike(ctx, f.owner, uuid.New()))
+
+	performance, err := f.engagement.GetPerformanceByUserID(ctx, f.owner)
+	require.NoError(t, err)
+	assert.Equal(t, int64(2), performance[first.ID].Impressions)
+	assert.Equal(t, int64(1), performance[first.ID].Likes)
+	assert.Equal(t, 0.5, performance[first.ID].LikeRate())
+	assert.Equal(t, int64(1), performance[second.ID].Impressions)
+	assert.Equal(t, int64(1), performance[second.ID].Likes)
+}
+
+func TestPhotoEngagementService_PromotesBestPhoto(t *testing.T) {
+	f := newPhotoEngagementFixture()
+	primary := f.addPhoto(f.owner)
+	best := f.addPhoto(f.owner)
+	fewViews := f.addPhoto(f.owner)
+	pending := f.addPhoto(f.owner)
+	pending.VerificationStatus = "pending"
+
+	f.show(t, primary, 10, 1)
+	f.show(t, best, 10, 6)
+	// Liked by everyone who saw them, but not ranked: too few viewers, or not approved
+	f.show(t, fewViews, 4, 4)
+	f.show(t, pending, 10, 10)
+
+	changed, err := f.service.ReorderUserPhotos(context.Background(), f.owner)
+
+	require.NoError(t, err)
+	assert.True(t, changed)
+	assert.Equal(t, []uuid.UUID{best.ID, primary.ID, fewViews.ID, pending.ID}, f.order(f.owner))
+	assert.True(t, best.IsPrimary)
+	assert.False(t, primary.IsPrimary)
+
+	changed, err = f.service.ReorderUserPhotos(context.Background(), f.owner)
+	require.NoError(t, err)
+	assert.False(t, changed, "an order that is already right is left alone")
+}
+
+func TestPhotoEngagementService_KeepsLockedPrimary(t *testing.T) {
+	f := newPhotoEngagementFixture()
+	locked := f.addPhoto(f.owner)
+	locked.PrimaryLocked = true
+	worse := f.addPhoto(f.owner)
+	better := f.addPhoto(f.owner)
+
+	f.show(t, locked, 10, 0)
+	f.show(t, worse, 10, 2)
+	f.show(t, better, 10, 8)
+
+	changed, err := f.service.ReorderUserPhotos(context.Background(), f.owner)
+
+	require.NoError(t, err)
+	assert.True(t, changed)
+	assert.Equal(t, []uuid.UUID{locked.ID, better.ID, worse.ID}, f.order(f.owner))
+	assert.True(t, locked.IsPrimary)
+	assert.True(t, locked.PrimaryLocked)
+	assert.False(t, better.IsPrimary)
+}
+
+func TestPhotoEngagementService_ReordersOptedInUsersOnly(t *testing.T) {
+	f := newPhotoEngagementFixture()
+	optedOut := uuid.New()
+	users := []uuid.UUID{f.owner, uuid.New(), optedOut}
+	for _, userID := range users {
+		f.addPhoto(userID)
+		f.show(t, f.addPhoto(userID), 10, 5)
+	}
+	f.engagement.optedIn = users[:2]
+	optedOutOrder := f.order(optedOut)
+
+	reordered, err := f.service.ReorderOptedInPhotos(context.Background())
+
+	require.NoError(t, err)
+	assert.Equal(t, 2, reordered, "every opted-in user is reordered, across batches")
+	assert.Equal(t, optedOutOrder, f.order(optedOut))
+
+	f.config.Enabled = false
+	f.show(t, f.addPhoto(f.owner), 10, 10)
+	reordered, err = f.service.ReorderOptedInPhotos(context.Background())
+	require.NoError(t, err)
+	assert.Zero(t, reordered)
+}
@@ -0,0 +1,89 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/22smeargle/winkr-backend/internal/domain/repositories"
+	"github.com/22smeargle/winkr-backend/pkg/config"
+	"github.com/22smeargle/winkr-backend/pkg/errors"
+)
+
+// Photo types with their own allotment
+const (
+	PhotoTypeProfile   = "profile"
+	PhotoTypeEphemeral = "ephemeral"
+)
+
+// PhotoLimitService enforces how many profile and ephemeral photos a user can have on their plan
+type PhotoLimitService struct {
+	photoRepo          repositories.PhotoRepository
+	ephemeralPhotoRepo repositories.EphemeralPhotoRepository
+	subscriptionRepo   repositories.SubscriptionRepository
+	storageConfig      *config.StorageConfig
+	ephemeralConfig    *config.EphemeralPhotoConfig
+}
+
+// NewPhotoLimitService creates a new PhotoLimitService
+func NewPhotoLimitService(
+	photoRepo repositories.PhotoRepository,
+	ephemeralPhotoRepo repositories.EphemeralPhotoRepository,
+	subscriptionRepo repositories.SubscriptionRepository,
+	storageConfig *config.StorageConfig,
+	ephemeralConfig *config.EphemeralPhotoConfig,
+) *PhotoLimitService {
+	return &PhotoLimitService{
+		photoRepo:          photoRepo,
+		ephemeralPhotoRepo: ephemeralPhotoRepo,
+		subscriptionRepo:   subscriptionRepo,
+		storageConfig:      storageConfig,
+		ephemeralConfig:    ephemeralConfig,
+	}
+}
+
+// CheckProfilePhotoLimit returns a *errors.PhotoLimitError when the user cannot add another profile photo
+func (s *PhotoLimitService) CheckProfilePhotoLimit(ctx context.Context, userID uuid.UUID) error {
+	max := s.limit(ctx, userID, s.storageConfig.MaxPhotosPerUser, s.storageConfig.MaxPhotosPerUserPaid)
+	if max <= 0 {
+		return nil
+	}
+
+	count, err := s.photoRepo.GetUserPhotoCount(ctx, userID)
+	if err != nil {
+		return fmt.Errorf("failed to get user photo count: %w", err)
+	}
+	if count >= max {
+		return errors.NewPhotoLimitError(PhotoTypeProfile, count, max)
+	}
+	return nil
+}
+
+// CheckEphemeralPhotoLimit returns a *errors.PhotoLimitError when the user cannot add another ephemeral photo.
+// Only photos that have not expired or been viewed count towards the limit.
+func (s *PhotoLimitService) CheckEphemeralPhotoLimit(ctx context.Context, userID uuid.UUID) error {
+	max := s.limit(ctx, userID, s.ephemeralConfig.MaxPhotosPerUser, s.ephemeralConfig.MaxPhotosPerUserPaid)
+	if max <= 0 {
+		return nil
+	}
+
+	photos, err := s.ephemeralPhotoRepo.GetUserActiveEphemeralPhotos(ctx, userID)
+	if err != nil {
+		return fmt.Errorf("failed to get active ephemeral photos: %w", err)
+	}
+	if len(photos) >= max {
+		return errors.NewPhotoLimitError(PhotoTypeEphemeral, len(photos), max)
+	}
+	return nil
+}
+
+// limit returns the limit for the user's plan. Users without an active paid subscription, or one in its grace
+// period after a failed payment, are on the free plan.
+func (s *PhotoLimitService) limit(ctx context.Context, userID uuid.UUID, free, paid int) int {
+	subscription, err := s.subscriptionRepo.GetActiveUserSubscription(ctx, userID)
+	if err != nil || subscription == nil || !subscription.HasPremiumAccess(time.Now()) || !subscription.IsPaidPlan() {
+		return free
+	}
+	return paid
+}
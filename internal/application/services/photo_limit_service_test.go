@@ -0,0 +1,154 @@
+package services
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/22smeargle/winkr-backend/internal/domain/entities"
+	"github.com/22smeargle/winkr-backend/internal/domain/repositories"
+	"github.com/22smeargle/winkr-backend/pkg/config"
+	"github.com/22smeargle/winkr-backend/pkg/errors"
+)
+
+func (r *inMemoryPhotoRepository) GetUserPhotoCount(ctx context.Context, userID uuid.UUID) (int, error) {
+	count := 0
+	for _, photo := range r.photos {
+		if photo.UserID == userID && !photo.IsDeleted {
+			count++
+		}
+	}
+	return count, nil
+}
+
+// inMemoryEphemeralPhotoRepository serves ephemeral photos from memory
+type inMemoryEphemeralPhotoRepository struct {
+	repositories.EphemeralPhotoRepository
+	photos []*entities.EphemeralPhoto
+}
+
+func (r *inMemoryEphemeralPhotoRepository) add(userID uuid.UUID, expiresIn time.Duration) {
+	r.photos = append(r.photos, &entities.EphemeralPhoto{ID: uuid.New(), UserID: userID, ExpiresAt: time.Now().Add(expiresIn)})
+}
+
+func (r *inMemoryEphemeralPhotoRepository) GetUserActiveEphemeralPhotos(ctx context.Context, userID uuid.UUID) ([]*entities.EphemeralPhoto, error) {
+	var active []*entities.EphemeralPhoto
+	for _, photo := range r.photos {
+		if photo.UserID == userID && photo.ExpiresAt.After(time.Now()) {
+			active = append(active, photo)
+		}
+	}
+	return active, nil
+}
+
+// planSubscriptionRepository returns the subscription set for the user, if any
+type planSubscriptionRepository struct {
+	repositories.SubscriptionRepository
+	subscription *entities.Subscription
+}
+
+func (r *planSubscriptionRepository) GetActiveUserSubscription(ctx context.Context, userID uuid.UUID) (*entities.Subscription, error) {
+	return r.subscription, nil
+}
+
+type photoLimitFixture struct {
+	service       *PhotoLimitService
+	photos        *inMemoryPhotoRepository
+	ephemeral     *inMemoryEphemeralPhotoRepository
+	subscriptions *planSubscriptionRepository
+	userID        uuid.UUID
+}
+
+func newPhotoLimitFixture() *photoLimitFixture {
+	f := &photoLimitFixture{
+		photos:        &inMemoryPhotoRepository{},
+		ephemeral:     &inMemoryEphemeralPhotoRepository{},
+		subscriptions: &planSubscriptionRepository{},
+		userID:        uuid.New(),
+	}
+	f.service = NewPhotoLimitService(f.photos, f.ephemeral, f.subscriptions,
+		&config.StorageConfig{MaxPhotosPerUser: 3, MaxPhotosPerUserPaid: 5},
+		&config.EphemeralPhotoConfig{MaxPhotosPerUser: 2, MaxPhotosPerUserPaid: 4},
+	)
+	return f
+}
+
+func assertPhotoLimitError(t *testing.T, err error, photoType string, current, max int) {
+	t.Helper()
+	require.Error(t, err)
+
+	limitErr, ok := errors.GetPhotoLimitError(err)
+	require.True(t, ok, "expected a photo limit error, got %v", err)
+	assert.Equal(t, photoType, limitErr.PhotoType)
+	assert.Equal(t, current, limitErr.Current)
+	assert.Equal(t, max, limitErr.Max)
+
+	// Handlers that only know AppError still respond with the photo limit status
+	assert.Equal(t, http.StatusUnprocessableEntity, errors.GetAppError(err).Code)
+}
+
+func TestPhotoLimitService_ProfilePhotos(t *testing.T) {
+	ctx := context.Background()
+	f := newPhotoLimitFixture()
+
+	for i := 0; i < 3; i++ {
+		require.NoError(t, f.service.CheckProfilePhotoLimit(ctx, f.userID))
+		f.photos.add(f.userID, "approved")
+	}
+	assertPhotoLimitError(t, f.service.CheckProfilePhotoLimit(ctx, f.userID), PhotoTypeProfile, 3, 3)
+
+	// Deleted photos free up room
+	f.photos.photos[0].IsDeleted = true
+	require.NoError(t, f.service.CheckProfilePhotoLimit(ctx, f.userID))
+	f.photos.photos[0].IsDeleted = false
+
+	// Paid plans get a larger allotment
+	f.subscriptions.subscription = &entities.Subscription{UserID: f.userID, PlanType: "premium", Status: "active"}
+	require.NoError(t, f.service.CheckProfilePhotoLimit(ctx, f.userID))
+	f.photos.add(f.userID, "pending")
+	f.photos.add(f.userID, "pending")
+	assertPhotoLimitError(t, f.service.CheckProfilePhotoLimit(ctx, f.userID), PhotoTypeProfile, 5, 5)
+
+	// A lapsed subscription falls back to the free limit
+	f.subscriptions.subscription.Status = "past_due"
+	assertPhotoLimitError(t, f.service.CheckProfilePhotoLimit(ctx, f.userID), PhotoTypeProfile, 5, 3)
+}
+
+func TestPhotoLimitService_EphemeralPhotos(t *testing.T) {
+	ctx := context.Background()
+	f := newPhotoLimitFixture()
+
+	f.ephemeral.add(f.userID, time.Minute)
+	require.NoError(t, f.service.CheckEphemeralPhotoLimit(ctx, f.userID))
+	f.ephemeral.add(f.userID, time.Minute)
+	assertPhotoLimitError(t, f.service.CheckEphemeralPhotoLimit(ctx, f.userID), PhotoTypeEphemeral, 2, 2)
+
+	// Expired photos do not count
+	f.ephemeral.photos[0].ExpiresAt = time.Now().Add(-time.Second)
+	require.NoError(t, f.service.CheckEphemeralPhotoLimit(ctx, f.userID))
+
+	// Profile photos have a separate allotment
+	f.ephemeral.add(f.userID, time.Minute)
+	require.NoError(t, f.service.CheckProfilePhotoLimit(ctx, f.userID))
+
+	f.subscriptions.subscription = &entities.Subscription{UserID: f.userID, PlanType: "platinum", Status: "active"}
+	require.NoError(t, f.service.CheckEphemeralPhotoLimit(ctx, f.userID))
+	f.ephemeral.add(f.userID, time.Minute)
+	f.ephemeral.add(f.userID, time.Minute)
+	assertPhotoLimitError(t, f.service.CheckEphemeralPhotoLimit(ctx, f.userID), PhotoTypeEphemeral, 4, 4)
+}
+
+func TestPhotoLimitService_ZeroMeansNoLimit(t *testing.T) {
+	ctx := context.Background()
+	f := newPhotoLimitFixture()
+	f.service.storageConfig.MaxPhotosPerUser = 0
+
+	for i := 0; i < 10; i++ {
+		f.photos.add(f.userID, "approved")
+	}
+	assert.NoError(t, f.service.CheckProfilePhotoLimit(ctx, f.userID))
+}
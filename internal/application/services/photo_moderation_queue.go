@@ -0,0 +1,82 @@
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	goredis "github.com/go-redis/redis/v8"
+	"github.com/google/uuid"
+	"github.com/22smeargle/winkr-backend/internal/infrastructure/database/redis"
+	"github.com/22smeargle/winkr-backend/pkg/logger"
+)
+
+// PhotoModerationJob is an uploaded photo waiting to be screened
+type PhotoModerationJob struct {
+	PhotoID     uuid.UUID `json:"photo_id"`
+	UserID      uuid.UUID `json:"user_id"`
+	FileKey     string    `json:"file_key"`     // The S3 object key of the photo
+	MakePrimary bool      `json:"make_primary"` // The photo becomes primary once approved
+	Attempts    int       `json:"attempts"`     // Failed moderation attempts so far
+	EnqueuedAt  time.Time `json:"enqueued_at"`
+}
+
+// PhotoModerationQueue holds photos until the moderation worker screens them, oldest first
+type PhotoModerationQueue interface {
+	Enqueue(ctx context.Context, job *PhotoModerationJob) error
+	// Dequeue removes and returns up to limit jobs
+	Dequeue(ctx context.Context, limit int) ([]*PhotoModerationJob, error)
+}
+
+// RedisPhotoModerationQueue keeps photo moderation jobs in a Redis list
+type RedisPhotoModerationQueue struct {
+	redisClient *redis.RedisClient
+	key         string
+}
+
+// NewRedisPhotoModerationQueue creates a new RedisPhotoModerationQueue
+func NewRedisPhotoModerationQueue(redisClient *redis.RedisClient) *RedisPhotoModerationQueue {
+	return &RedisPhotoModerationQueue{
+		redisClient: redisClient,
+		key:         "photo_moderation:queue",
+	}
+}
+
+// Enqueue adds the job to the end of the queue
+func (q *RedisPhotoModerationQueue) Enqueue(ctx context.Context, job *PhotoModerationJob) error {
+	data, err := json.Marshal(job)
+	if err != nil {
+		return fmt.Errorf("failed to marshal photo moderation job: %w", err)
+	}
+
+	if err := q.redisClient.RPush(ctx, q.key, data); err != nil {
+		return fmt.Errorf("failed to queue photo moderation job: %w", err)
+	}
+	return nil
+}
+
+// Dequeue removes and returns up to limit jobs from the front of the queue. Jobs that can't be read
+// are logged and dropped.
+func (q *RedisPhotoModerationQueue) Dequeue(ctx context.Context, limit int) ([]*PhotoModerationJob, error) {
+	var values *goredis.StringSliceCmd
+	_, err := q.redisClient.GetClient().TxPipelined(ctx, func(pipe goredis.Pipeliner) error {
+		values = pipe.LRange(ctx, q.key, 0, int64(limit-1))
+		pipe.LTrim(ctx, q.key, int64(limit), -1)
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to dequeue photo moderation jobs: %w", err)
+	}
+
+	jobs := make([]*PhotoModerationJob, 0, len(values.Val()))
+	for _, value := range values.Val() {
+		var job PhotoModerationJob
+		if err := json.Unmarshal([]byte(value), &job); err != nil {
+			logger.Error("Failed to unmarshal photo moderation job", err)
+			continue
+		}
+		jobs = append(jobs, &job)
+	}
+	return jobs, nil
+}
@@ -0,0 +1,209 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/22smeargle/winkr-backend/internal/domain/entities"
+	"github.com/22smeargle/winkr-backend/internal/infrastructure/external"
+	"github.com/22smeargle/winkr-backend/pkg/config"
+	"github.com/22smeargle/winkr-backend/pkg/logger"
+)
+
+// Outcomes of screening a photo
+const (
+	PhotoModerationApproved      = "approved"
+	PhotoModerationRejected      = "rejected"
+	PhotoModerationPendingReview = "pending_review"
+)
+
+// PhotoModerationRepository reads photos and records moderation decisions
+type PhotoModerationRepository interface {
+	GetByID(ctx context.Context, id uuid.UUID) (*entities.Photo, error)
+	UpdateVerificationStatus(ctx context.Context, photoID uuid.UUID, status string, reason *string) error
+	RequestReview(ctx context.Context, photoID uuid.UUID, hide bool) error
+	SetPrimaryPhoto(ctx context.Context, userID, photoID uuid.UUID) error
+}
+
+// ImageModerator scores an image stored under a key. It is implemented by Rekognition and, for the
+// "mock" provider, by external.MockImageModerator.
+type ImageModerator interface {
+	ModerateImage(ctx context.Context, key string) (*external.ImageModerationScores, error)
+}
+
+// PhotoModerationNotifier tells users about photos moderation rejected
+type PhotoModerationNotifier interface {
+	NotifyPhotoRejected(ctx context.Context, photo *entities.Photo, reason string) error
+}
+
+// PhotoModerationWorker screens queued photos for NSFW, violent and adult content. Photos scoring at
+// or above a category's threshold are rejected, photos at or above ReviewThreshold go to human review
+// hidden, and the rest are approved and go live. Photos that fail to be screened are retried on later
+// polls, up to MaxRetries attempts, then sent to human review.
+type PhotoModerationWorker struct {
+	repo      PhotoModerationRepository
+	moderator ImageModerator
+	queue     PhotoModerationQueue
+	notifier   PhotoModerationNotifier
+	reputation *ReputationService
+	config     *config.AIModerationConfig
+}
+
+// NewPhotoModerationWorker creates a new photo moderation worker
+func NewPhotoModerationWorker(
+	repo PhotoModerationRepository,
+	moderator ImageModerator,
+	queue PhotoModerationQueue,
+	notifier PhotoModerationNotifier,
+	reputation *ReputationService,
+	cfg *config.AIModerationConfig,
+) *PhotoModerationWorker {
+	return &PhotoModerationWorker{
+		repo:       repo,
+		moderator:  moderator,
+		queue:      queue,
+		notifier:   notifier,
+		reputation: reputation,
+		config:     cfg,
+	}
+}
+
+// Start processes the queue every PollInterval until the context is cancelled
+func (w *PhotoModerationWorker) Start(ctx context.Context) {
+	logger.Info("Starting photo moderation worker", "interval", w.config.PollInterval)
+
+	go func() {
+		ticker := time.NewTicker(w.config.PollInterval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ctx.Done():
+				logger.Info("Photo moderation worker stopped")
+				return
+			case <-ticker.C:
+				w.ProcessQueue(ctx)
+			}
+		}
+	}()
+}
+
+// ProcessQueue screens up to BatchSize queued photos and returns how many were decided
+func (w *PhotoModerationWorker) ProcessQueue(ctx context.Context) int {
+	jobs, err := w.queue.Dequeue(ctx, w.config.BatchSize)
+	if err != nil {
+		logger.Error("Failed to dequeue photo moderation jobs", err)
+		return 0
+	}
+
+	decided := 0
+	for _, job := range jobs {
+		outcome, err := w.moderate(ctx, job)
+		if err != nil {
+			w.retry(ctx, job, err)
+			continue
+		}
+		if outcome != "" {
+			decided++
+		}
+	}
+	return decided
+}
+
+// moderate screens the photo and records the outcome. It returns no outcome for photos that were
+// deleted or decided by a moderator since they were queued.
+func (w *PhotoModerationWorker) moderate(ctx context.Context, job *PhotoModerationJob) (string, error) {
+	photo, err := w.repo.GetByID(ctx, job.PhotoID)
+	if err != nil {
+		return "", fmt.Errorf("failed to get photo: %w", err)
+	}
+	if photo == nil || photo.IsDeleted || !photo.IsPending() {
+		return "", nil
+	}
+
+	scores, err := w.moderator.ModerateImage(ctx, job.FileKey)
+	if err != nil {
+		return "", fmt.Errorf("failed to moderate photo: %w", err)
+	}
+
+	outcome, reason := w.decide(scores)
+	switch outcome {
+	case PhotoModerationApproved:
+		if err := w.repo.UpdateVerificationStatus(ctx, photo.ID, "approved", nil); err != nil {
+			return "", err
+		}
+		if job.MakePrimary {
+			if err := w.repo.SetPrimaryPhoto(ctx, photo.UserID, photo.ID); err != nil {
+				logger.Error("Failed to make approved photo primary", err, "photo_id", photo.ID)
+			}
+		}
+	case PhotoModerationRejected:
+		// The photo never became primary, so the user's current photos stay as they are
+		if err := w.repo.UpdateVerificationStatus(ctx, photo.ID, "rejected", &reason); err != nil {
+			return "", err
+		}
+		if w.notifier != nil {
+			if err := w.notifier.NotifyPhotoRejected(ctx, photo, reason); err != nil {
+				logger.Error("Failed to notify user of rejected photo", err, "photo_id", photo.ID)
+			}
+		}
+		if w.reputation != nil {
+			if _, err := w.reputation.RecordViolation(ctx, photo.UserID, photo.ID); err != nil {
+				logger.Error("Failed to lower reputation for rejected photo", err, "photo_id", photo.ID)
+			}
+		}
+	case PhotoModerationPendingReview:
+		if err := w.repo.RequestReview(ctx, photo.ID, true); err != nil {
+			return "", err
+		}
+	}
+
+	logger.Info("Photo moderated",
+		"photo_id", photo.ID,
+		"user_id", photo.UserID,
+		"outcome", outcome,
+		"nsfw", scores.NSFW,
+		"violence", scores.Violence,
+		"adult", scores.Adult,
+	)
+	return outcome, nil
+}
+
+// decide returns the outcome for the scores, with the reason for a rejection
+func (w *PhotoModerationWorker) decide(scores *external.ImageModerationScores) (string, string) {
+	switch {
+	case scores.NSFW >= w.config.NSFWThreshold:
+		return PhotoModerationRejected, "Photo contains nudity or sexual content"
+	case scores.Violence >= w.config.ViolenceThreshold:
+		return PhotoModerationRejected, "Photo contains violent content"
+	case scores.Adult >= w.config.AdultThreshold:
+		return PhotoModerationRejected, "Photo contains adult content"
+	}
+
+	review := w.config.ReviewThreshold
+	if review > 0 && (scores.NSFW >= review || scores.Violence >= review || scores.Adult >= review) {
+		return PhotoModerationPendingReview, ""
+	}
+	return PhotoModerationApproved, ""
+}
+
+// retry puts a job that failed back in the queue, or sends the photo to human review once it has
+// failed MaxRetries times
+func (w *PhotoModerationWorker) retry(ctx context.Context, job *PhotoModerationJob, cause error) {
+	job.Attempts++
+	logger.Error("Photo moderation failed", cause, "photo_id", job.PhotoID, "attempts", job.Attempts)
+
+	if job.Attempts < w.config.MaxRetries {
+		err := w.queue.Enqueue(ctx, job)
+		if err == nil {
+			return
+		}
+		logger.Error("Failed to requeue photo moderation job", err, "photo_id", job.PhotoID)
+	}
+
+	if err := w.repo.RequestReview(ctx, job.PhotoID, true); err != nil {
+		logger.Error("Failed to send photo to human review", err, "photo_id", job.PhotoID)
+	}
+}
@@ -0,0 +1,187 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/22smeargle/winkr-backend/internal/domain/entities"
+	"github.com/22smeargle/winkr-backend/internal/infrastructure/external"
+	"github.com/22smeargle/winkr-backend/pkg/config"
+)
+
+// inMemoryPhotoModerationQueue is an in-memory PhotoModerationQueue for tests
+type inMemoryPhotoModerationQueue struct {
+	jobs []*PhotoModerationJob
+}
+
+func (q *inMemoryPhotoModerationQueue) Enqueue(ctx context.Context, job *PhotoModerationJob) error {
+	q.jobs = append(q.jobs, job)
+	return nil
+}
+
+func (q *inMemoryPhotoModerationQueue) Dequeue(ctx context.Context, limit int) ([]*PhotoModerationJob, error) {
+	if len(q.jobs) < limit {
+		limit = len(q.jobs)
+	}
+	jobs := q.jobs[:limit]
+	q.jobs = q.jobs[limit:]
+	return jobs, nil
+}
+
+// inMemoryPhotoModerationRepository is an in-memory PhotoModerationRepository for tests
+type inMemoryPhotoModerationRepository struct {
+	photos map[uuid.UUID]*entities.Photo
+}
+
+func (r *inMemoryPhotoModerationRepository) GetByID(ctx context.Context, id uuid.UUID) (*entities.Photo, error) {
+	photo, ok := r.photos[id]
+	if !ok {
+		return nil, errors.New("photo not found")
+	}
+	return photo, nil
+}
+
+func (r *inMemoryPhotoModerationRepository) UpdateVerificationStatus(ctx context.Context, photoID uuid.UUID, status string, reason *string) error {
+	if status == "approved" {
+		r.photos[photoID].Approve()
+	} else {
+		r.photos[photoID].Reject(*reason)
+	}
+	return nil
+}
+
+func (r *inMemoryPhotoModerationRepository) RequestReview(ctx context.Context, photoID uuid.UUID, hide bool) error {
+	r.photos[photoID].RequestReview(hide)
+	return nil
+}
+
+func (r *inMemoryPhotoModerationRepository) SetPrimaryPhoto(ctx context.Context, userID, photoID uuid.UUID) error {
+	for _, photo := range r.photos {
+		if photo.UserID == userID {
+			photo.IsPrimary = photo.ID == photoID
+		}
+	}
+	return nil
+}
+
+// recordedPhotoRejections keeps the reasons of rejected photos by photo ID
+type recordedPhotoRejections map[uuid.UUID]string
+
+func (n recordedPhotoRejections) NotifyPhotoRejected(ctx context.Context, photo *entities.Photo, reason string) error {
+	n[photo.ID] = reason
+	return nil
+}
+
+type photoModerationFixture struct {
+	worker    *PhotoModerationWorker
+	repo      *inMemoryPhotoModerationRepository
+	queue     *inMemoryPhotoModerationQueue
+	moderator *external.MockImageModerator
+	rejected  recordedPhotoRejections
+	userID    uuid.UUID
+	primary   *entities.Photo
+}
+
+func newPhotoModerationFixture() *photoModerationFixture {
+	f := &photoModerationFixture{
+		repo:      &inMemoryPhotoModerationRepository{photos: make(map[uuid.UUID]*entities.Photo)},
+		queue:     &inMemoryPhotoModerationQueue{},
+		moderator: external.NewMockImageModerator(),
+		rejected:  make(recordedPhotoRejections),
+		userID:    uuid.New(),
+	}
+	f.primary = &entities.Photo{ID: uuid.New(), UserID: f.userID, IsPrimary: true, VerificationStatus: "approved"}
+	f.repo.photos[f.primary.ID] = f.primary
+
+	f.worker = NewPhotoModerationWorker(f.repo, f.moderator, f.queue, f.rejected, nil, &config.AIModerationConfig{
+		NSFWThreshold:     0.70,
+		ViolenceThreshold: 0.80,
+		AdultThreshold:    0.75,
+		ReviewThreshold:   0.50,
+		BatchSize:         10,
+		MaxRetries:        2,
+	})
+	return f
+}
+
+// upload adds a hidden, pending photo to be made primary once approved, and queues it
+func (f *photoModerationFixture) upload(fileKey string) *entities.Photo {
+	photo := &entities.Photo{ID: uuid.New(), UserID: f.userID, FileKey: fileKey, VerificationStatus: "pending", IsHidden: true}
+	f.repo.photos[photo.ID] = photo
+	f.queue.jobs = append(f.queue.jobs, &PhotoModerationJob{PhotoID: photo.ID, UserID: f.userID, FileKey: fileKey, MakePrimary: true})
+	return photo
+}
+
+func TestPhotoModerationWorker_DecidesByThreshold(t *testing.T) {
+	f := newPhotoModerationFixture()
+	clean := f.upload("clean.jpg")
+	nsfw := f.upload("nsfw.jpg")
+	violent := f.upload("violent.jpg")
+	borderline := f.upload("borderline.jpg")
+	f.moderator.SetScores("nsfw.jpg", &external.ImageModerationScores{NSFW: 0.92})
+	f.moderator.SetScores("violent.jpg", &external.ImageModerationScores{Violence: 0.85, Adult: 0.40})
+	f.moderator.SetScores("borderline.jpg", &external.ImageModerationScores{Adult: 0.60})
+
+	assert.Equal(t, 4, f.worker.ProcessQueue(context.Background()))
+	assert.Empty(t, f.queue.jobs)
+
+	assert.True(t, clean.IsVerified())
+	assert.True(t, clean.IsVisible())
+
+	assert.True(t, nsfw.IsRejected())
+	assert.Equal(t, "Photo contains nudity or sexual content", f.rejected[nsfw.ID])
+	assert.True(t, violent.IsRejected())
+	assert.Equal(t, "Photo contains violent content", f.rejected[violent.ID])
+
+	assert.True(t, borderline.IsPending())
+	assert.NotNil(t, borderline.ReviewRequestedAt)
+	assert.False(t, borderline.IsVisible())
+	assert.NotContains(t, f.rejected, borderline.ID)
+}
+
+func TestPhotoModerationWorker_RejectionKeepsPrimaryPhoto(t *testing.T) {
+	f := newPhotoModerationFixture()
+	rejected := f.upload("nsfw.jpg")
+	f.moderator.SetScores("nsfw.jpg", &external.ImageModerationScores{NSFW: 0.99})
+
+	f.worker.ProcessQueue(context.Background())
+	assert.True(t, f.primary.IsPrimary)
+	assert.False(t, rejected.IsPrimary)
+
+	approved := f.upload("clean.jpg")
+	f.worker.ProcessQueue(context.Background())
+	assert.True(t, approved.IsPrimary)
+	assert.False(t, f.primary.IsPrimary)
+}
+
+func TestPhotoModerationWorker_RetriesThenSendsToReview(t *testing.T) {
+	f := newPhotoModerationFixture()
+	photo := f.upload("flaky.jpg")
+	f.moderator.SetError("flaky.jpg", errors.New("throttled"))
+
+	assert.Zero(t, f.worker.ProcessQueue(context.Background()))
+	require.Len(t, f.queue.jobs, 1)
+	assert.Equal(t, 1, f.queue.jobs[0].Attempts)
+	assert.Nil(t, photo.ReviewRequestedAt)
+
+	assert.Zero(t, f.worker.ProcessQueue(context.Background()))
+	assert.Empty(t, f.queue.jobs)
+	assert.True(t, photo.IsPending())
+	assert.NotNil(t, photo.ReviewRequestedAt)
+	assert.True(t, photo.IsHidden)
+}
+
+func TestPhotoModerationWorker_SkipsPhotosDecidedSinceQueued(t *testing.T) {
+	f := newPhotoModerationFixture()
+	photo := f.upload("nsfw.jpg")
+	f.moderator.SetScores("nsfw.jpg", &external.ImageModerationScores{NSFW: 0.99})
+	photo.Approve()
+
+	assert.Zero(t, f.worker.ProcessQueue(context.Background()))
+	assert.True(t, photo.IsVerified())
+	assert.Empty(t, f.rejected)
+}
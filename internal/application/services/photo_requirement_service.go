@@ -0,0 +1,79 @@
+package services
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/22smeargle/winkr-backend/internal/domain/entities"
+	"github.com/22smeargle/winkr-backend/internal/domain/repositories"
+	"github.com/22smeargle/winkr-backend/pkg/config"
+)
+
+// PhotoRequirementService keeps users without enough moderation-approved photos out of discovery,
+// both as swipers and as candidates in other users' decks
+type PhotoRequirementService struct {
+	photoRepo repositories.PhotoRepository
+	config    *config.MatchingPhotosConfig
+}
+
+// NewPhotoRequirementService creates a new PhotoRequirementService
+func NewPhotoRequirementService(photoRepo repositories.PhotoRepository, cfg *config.MatchingPhotosConfig) *PhotoRequirementService {
+	return &PhotoRequirementService{
+		photoRepo: photoRepo,
+		config:    cfg,
+	}
+}
+
+// Enabled reports whether users need approved photos to take part in discovery
+func (s *PhotoRequirementService) Enabled() bool {
+	return s.config != nil && s.config.RequireApproved && s.config.MinApproved > 0
+}
+
+// MinApproved returns the number of approved photos a user needs, or 0 when there is no requirement
+func (s *PhotoRequirementService) MinApproved() int {
+	if !s.Enabled() {
+		return 0
+	}
+	return s.config.MinApproved
+}
+
+// MeetsRequirement reports whether the user has enough approved photos to discover and swipe
+func (s *PhotoRequirementService) MeetsRequirement(ctx context.Context, userID uuid.UUID) (bool, error) {
+	if !s.Enabled() {
+		return true, nil
+	}
+
+	counts, err := s.photoRepo.CountApprovedByUserIDs(ctx, []uuid.UUID{userID})
+	if err != nil {
+		return false, fmt.Errorf("failed to count approved photos: %w", err)
+	}
+
+	return counts[userID] >= s.config.MinApproved, nil
+}
+
+// FilterEligible returns the users that have enough approved photos to be shown in discovery
+func (s *PhotoRequirementService) FilterEligible(ctx context.Context, users []*entities.User) ([]*entities.User, error) {
+	if !s.Enabled() || len(users) == 0 {
+		return users, nil
+	}
+
+	userIDs := make([]uuid.UUID, len(users))
+	for i, user := range users {
+		userIDs[i] = user.ID
+	}
+
+	counts, err := s.photoRepo.CountApprovedByUserIDs(ctx, userIDs)
+	if err != nil {
+		return nil, fmt.Errorf("failed to count approved photos: %w", err)
+	}
+
+	eligible := make([]*entities.User, 0, len(users))
+	for _, user := range users {
+		if counts[user.ID] >= s.config.MinApproved {
+			eligible = append(eligible, user)
+		}
+	}
+
+	return eligible, nil
+}
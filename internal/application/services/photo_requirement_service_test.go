@@ -0,0 +1,116 @@
+package services
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/22smeargle/winkr-backend/internal/domain/entities"
+	"github.com/22smeargle/winkr-backend/internal/domain/repositories"
+	"github.com/22smeargle/winkr-backend/pkg/config"
+)
+
+// inMemoryPhotoRepository counts approved photos from an in-memory list
+type inMemoryPhotoRepository struct {
+	repositories.PhotoRepository
+	photos []*entities.Photo
+}
+
+func (r *inMemoryPhotoRepository) add(userID uuid.UUID, status string) *entities.Photo {
+	photo := &entities.Photo{ID: uuid.New(), UserID: userID, VerificationStatus: status}
+	r.photos = append(r.photos, photo)
+	return photo
+}
+
+func (r *inMemoryPhotoRepository) CountApprovedByUserIDs(ctx context.Context, userIDs []uuid.UUID) (map[uuid.UUID]int, error) {
+	counts := make(map[uuid.UUID]int)
+	for _, photo := range r.photos {
+		if !photo.IsVerified() || photo.IsDeleted {
+			continue
+		}
+		for _, userID := range userIDs {
+			if photo.UserID == userID {
+				counts[userID]++
+			}
+		}
+	}
+	return counts, nil
+}
+
+func testPhotosConfig() *config.MatchingPhotosConfig {
+	return &config.MatchingPhotosConfig{RequireApproved: true, MinApproved: 1}
+}
+
+func TestPhotoRequirementService_BlocksUntilPhotoApproved(t *testing.T) {
+	ctx := context.Background()
+	photos := &inMemoryPhotoRepository{}
+	service := NewPhotoRequirementService(photos, testPhotosConfig())
+	userID := uuid.New()
+
+	allowed, err := service.MeetsRequirement(ctx, userID)
+	require.NoError(t, err)
+	assert.False(t, allowed, "users without photos are blocked")
+
+	// Photos awaiting or failing moderation do not count
+	pending := photos.add(userID, "pending")
+	photos.add(userID, "rejected")
+	allowed, err = service.MeetsRequirement(ctx, userID)
+	require.NoError(t, err)
+	assert.False(t, allowed)
+
+	pending.Approve()
+	allowed, err = service.MeetsRequirement(ctx, userID)
+	require.NoError(t, err)
+	assert.True(t, allowed)
+
+	// Deleting the only approved photo blocks the user again
+	pending.SoftDelete()
+	allowed, err = service.MeetsRequirement(ctx, userID)
+	require.NoError(t, err)
+	assert.False(t, allowed)
+}
+
+func TestPhotoRequirementService_Disabled(t *testing.T) {
+	service := NewPhotoRequirementService(&inMemoryPhotoRepository{}, &config.MatchingPhotosConfig{RequireApproved: false, MinApproved: 1})
+
+	allowed, err := service.MeetsRequirement(context.Background(), uuid.New())
+	require.NoError(t, err)
+	assert.True(t, allowed)
+	assert.Equal(t, 0, service.MinApproved())
+}
+
+func TestMatchingAlgorithm_ExcludesCandidatesWithoutApprovedPhotos(t *testing.T) {
+	ctx := context.Background()
+	photos := &inMemoryPhotoRepository{}
+
+	withPhoto := candidateWith(nil, nil)
+	photos.add(withPhoto.ID, "approved")
+	photoless := candidateWith(nil, nil)
+	pendingOnly := candidateWith(nil, nil)
+	pendingPhoto := photos.add(pendingOnly.ID, "pending")
+
+	service := NewMatchingAlgorithmService(
+		&candidateUserRepository{candidates: []*entities.User{withPhoto, photoless, pendingOnly}},
+		nil, nil, &uncachedMatchesCache{}, nil, nil,
+		NewPhotoRequirementService(photos, testPhotosConfig()),
+	)
+
+	currentUser := candidateWith(nil, nil)
+	filter := &MatchingFilter{UserID: currentUser.ID, MaxDistance: 50}
+
+	users, total, err := service.GetPotentialMatches(ctx, currentUser, filter, nil, 10, 0)
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), total)
+	assert.Equal(t, []uuid.UUID{withPhoto.ID}, userIDs(users))
+
+	// Candidates show up once a photo has been approved
+	photos.add(photoless.ID, "approved")
+	pendingPhoto.Approve()
+
+	users, total, err = service.GetPotentialMatches(ctx, currentUser, filter, nil, 10, 0)
+	require.NoError(t, err)
+	assert.Equal(t, int64(3), total)
+	assert.ElementsMatch(t, []uuid.UUID{withPhoto.ID, photoless.ID, pendingOnly.ID}, userIDs(users))
+}
@@ -33,6 +33,9 @@ type ProfileCacheService interface {
 	SetUserStats(ctx context.Context, userID uuid.UUID, stats *profile.ProfileStats, ttl time.Duration) error
 	DeleteUserStats(ctx context.Context, userID uuid.UUID) error
 	
+	GetPerformance(ctx context.Context, userID uuid.UUID) (*profile.ProfilePerformanceResponse, error)
+	SetPerformance(ctx context.Context, userID uuid.UUID, performance *profile.ProfilePerformanceResponse, ttl time.Duration) error
+	
 	InvalidateUserCache(ctx context.Context, userID uuid.UUID) error
 	WarmProfileCache(ctx context.Context, userID uuid.UUID) error
 }
@@ -227,6 +230,37 @@ func (s *RedisProfileCacheService) DeleteUserStats(ctx context.Context, userID u
 	return s.redisClient.Delete(ctx, key)
 }
 
+// GetPerformance gets profile performance from cache
+func (s *RedisProfileCacheService) GetPerformance(ctx context.Context, userID uuid.UUID) (*profile.ProfilePerformanceResponse, error) {
+	key := s.keyPrefix + "performance:" + userID.String()
+	data, err := s.redisClient.Get(ctx, key)
+	if err != nil {
+		return nil, err
+	}
+	
+	if data == "" {
+		return nil, errors.ErrCacheMiss
+	}
+	
+	var performance profile.ProfilePerformanceResponse
+	if err := json.Unmarshal([]byte(data), &performance); err != nil {
+		return nil, errors.WrapError(err, "Failed to unmarshal performance from cache")
+	}
+	
+	return &performance, nil
+}
+
+// SetPerformance sets profile performance in cache
+func (s *RedisProfileCacheService) SetPerformance(ctx context.Context, userID uuid.UUID, performance *profile.ProfilePerformanceResponse, ttl time.Duration) error {
+	key := s.keyPrefix + "performance:" + userID.String()
+	data, err := json.Marshal(performance)
+	if err != nil {
+		return errors.WrapError(err, "Failed to marshal performance for cache")
+	}
+	
+	return s.redisClient.Set(ctx, key, string(data), ttl)
+}
+
 // InvalidateUserCache invalidates all cache entries for a user
 func (s *RedisProfileCacheService) InvalidateUserCache(ctx context.Context, userID uuid.UUID) error {
 	userIDStr := userID.String()
@@ -236,6 +270,7 @@ func (s *RedisProfileCacheService) InvalidateUserCache(ctx context.Context, user
 		s.generateProfileKey(userID),
 		s.keyPrefix + "location:" + userIDStr,
 		s.keyPrefix + "stats:" + userIDStr,
+		s.keyPrefix + "performance:" + userIDStr,
 	}
 	
 	// Delete matches with pattern
@@ -2,6 +2,7 @@ package services
 
 import (
 	"context"
+	"fmt"
 	"regexp"
 	"strings"
 	"time"
@@ -71,11 +72,53 @@ func (s *ProfileService) ValidateProfileUpdate(user *entities.User, req interfac
 		}
 	}
 
+	// Validate interests, which discovery ranks candidates by
+	if updateReq.Interests != nil {
+		if err := s.validateInterests(updateReq.Interests); err != nil {
+			return err
+		}
+	}
+
+	// Validate height and lifestyle answers, which discovery can filter by
+	if updateReq.HeightCm != nil && !entities.IsValidHeightCm(*updateReq.HeightCm) {
+		return errors.NewValidationError("height_cm", fmt.Sprintf("must be between %d and %d cm", entities.MinHeightCm, entities.MaxHeightCm))
+	}
+	if updateReq.Smoking != nil && !entities.IsValidLifestyleHabit(*updateReq.Smoking) {
+		return errors.NewValidationError("smoking", "must be one of "+strings.Join(entities.LifestyleHabits, ", "))
+	}
+	if updateReq.Drinking != nil && !entities.IsValidLifestyleHabit(*updateReq.Drinking) {
+		return errors.NewValidationError("drinking", "must be one of "+strings.Join(entities.LifestyleHabits, ", "))
+	}
+
+	// Validate the locale received messages are translated into
+	if updateReq.Locale != nil && !entities.IsValidLocale(*updateReq.Locale) {
+		return errors.NewValidationError("locale", "must be a language tag such as en or pt-BR")
+	}
+
+	// Validate the timezone daily limits reset in
+	if updateReq.Timezone != nil && !entities.IsValidTimezone(*updateReq.Timezone) {
+		return errors.NewValidationError("timezone", "must be an IANA timezone such as Europe/Berlin")
+	}
+
+	// Validate the opener sent when the user matches, an empty one turns it off
+	if updateReq.AutoIntro != nil {
+		autoIntro := strings.TrimSpace(*updateReq.AutoIntro)
+		if len([]rune(autoIntro)) > entities.MaxAutoIntroLength {
+			return errors.NewValidationError("auto_intro", fmt.Sprintf("must be at most %d characters", entities.MaxAutoIntroLength))
+		}
+		if s.containsInappropriateContent(autoIntro) {
+			return errors.NewValidationError("auto_intro", "contains inappropriate content")
+		}
+	}
+
 	// Validate preferences
 	if updateReq.Preferences != nil {
 		if err := s.validatePreferences(updateReq.Preferences); err != nil {
 			return err
 		}
+		if err := s.validateHiddenFields(user, updateReq.Preferences.HiddenFields); err != nil {
+			return err
+		}
 	}
 
 	return nil
@@ -137,6 +180,25 @@ func (s *ProfileService) validateBio(bio string) error {
 	return nil
 }
 
+// validateInterests validates the interests listed on the profile
+func (s *ProfileService) validateInterests(interests []string) error {
+	normalized := entities.NormalizeInterests(interests)
+	if len(normalized) > entities.MaxInterests {
+		return errors.NewValidationError("interests", fmt.Sprintf("at most %d interests are allowed", entities.MaxInterests))
+	}
+
+	for _, interest := range normalized {
+		if len([]rune(interest)) > entities.MaxInterestLength {
+			return errors.NewValidationError("interests", fmt.Sprintf("each interest must be at most %d characters", entities.MaxInterestLength))
+		}
+		if s.containsInappropriateContent(interest) {
+			return errors.NewValidationError("interests", "contains inappropriate content")
+		}
+	}
+
+	return nil
+}
+
 // validateInterestedIn validates interested in preferences
 func (s *ProfileService) validateInterestedIn(interestedIn []string) error {
 	if len(interestedIn) == 0 {
@@ -179,6 +241,24 @@ func (s *ProfileService) validatePreferences(prefs *profile.Preferences) error {
 	return nil
 }
 
+// validateHiddenFields validates the profile fields the user wants to hide from other users
+func (s *ProfileService) validateHiddenFields(user *entities.User, fields []string) error {
+	for _, field := range fields {
+		if entities.IsMandatoryProfileField(field) {
+			return errors.NewValidationError("hidden_fields", field+" is always shown and cannot be hidden")
+		}
+		if entities.IsHideableProfileField(field, user.IsPremium) {
+			continue
+		}
+		if entities.IsPremiumHideableProfileField(field) {
+			return errors.NewForbiddenError("Hiding " + field + " requires a premium subscription")
+		}
+		return errors.NewValidationError("hidden_fields", "unknown profile field "+field)
+	}
+
+	return nil
+}
+
 // containsInappropriateContent checks for inappropriate content in text
 func (s *ProfileService) containsInappropriateContent(text string) bool {
 	// List of inappropriate words (simplified for example)
@@ -6,6 +6,7 @@ import (
 	"time"
 
 	"github.com/google/uuid"
+	"github.com/22smeargle/winkr-backend/internal/domain/repositories"
 )
 
 // RateLimiter handles rate limiting for discovery and matching operations
@@ -13,8 +14,10 @@ type RateLimiter interface {
 	// Swipe rate limiting
 	AllowSwipe(ctx context.Context, userID uuid.UUID) (bool, error)
 	AllowSuperLike(ctx context.Context, userID uuid.UUID) (bool, error)
+	AllowUndo(ctx context.Context, userID uuid.UUID) (bool, error)
 	GetSwipeCount(ctx context.Context, userID uuid.UUID, window time.Duration) (int, error)
 	GetSuperLikeCount(ctx context.Context, userID uuid.UUID, window time.Duration) (int, error)
+	GetSwipeQuota(ctx context.Context, userID uuid.UUID) (*SwipeQuota, error)
 
 	// Discovery rate limiting
 	AllowDiscovery(ctx context.Context, userID uuid.UUID) (bool, error)
@@ -27,16 +30,29 @@ type RateLimiter interface {
 
 // RedisRateLimiter implements RateLimiter using Redis
 type RedisRateLimiter struct {
-	client RedisClient
-	config RateLimitConfig
+	client   RedisClient
+	config   RateLimitConfig
+	userRepo repositories.UserRepository // Looks up the timezone for local midnight resets
+	now      func() time.Time
 }
 
+// ResetStrategy decides when the daily swipe and super like limits start over
+type ResetStrategy string
+
+const (
+	// ResetRolling starts a daily limit over a day window after it was last counted against
+	ResetRolling ResetStrategy = "rolling"
+	// ResetLocalMidnight starts the daily limits over at midnight in the user's timezone
+	ResetLocalMidnight ResetStrategy = "local_midnight"
+)
+
 // RateLimitConfig contains rate limiting configuration
 type RateLimitConfig struct {
 	// Swipe limits
 	SwipesPerHour    int           `json:"swipes_per_hour"`
 	SwipesPerDay     int           `json:"swipes_per_day"`
 	SuperLikesPerDay  int           `json:"super_likes_per_day"`
+	UndosPerDay      int           `json:"undos_per_day"`
 
 	// Discovery limits
 	DiscoveryPerHour int           `json:"discovery_per_hour"`
@@ -45,6 +61,9 @@ type RateLimitConfig struct {
 	// Windows
 	HourWindow time.Duration `json:"hour_window"`
 	DayWindow  time.Duration `json:"day_window"`
+
+	// When SwipesPerDay and SuperLikesPerDay start over, rolling if unset
+	ResetStrategy ResetStrategy `json:"reset_strategy"`
 }
 
 // DefaultRateLimitConfig returns default rate limit configuration
@@ -53,22 +72,26 @@ func DefaultRateLimitConfig() RateLimitConfig {
 		SwipesPerHour:   100,
 		SwipesPerDay:    1000,
 		SuperLikesPerDay: 5,
+		UndosPerDay:     10,
 		DiscoveryPerHour: 50,
 		DiscoveryPerDay:  500,
 		HourWindow:      time.Hour,
 		DayWindow:       24 * time.Hour,
+		ResetStrategy:   ResetRolling,
 	}
 }
 
 // NewRedisRateLimiter creates a new RedisRateLimiter
-func NewRedisRateLimiter(client RedisClient, config RateLimitConfig) *RedisRateLimiter {
+func NewRedisRateLimiter(client RedisClient, config RateLimitConfig, userRepo repositories.UserRepository) *RedisRateLimiter {
 	if config.SwipesPerHour == 0 {
 		config = DefaultRateLimitConfig()
 	}
 
 	return &RedisRateLimiter{
-		client: client,
-		config: config,
+		client:   client,
+		config:   config,
+		userRepo: userRepo,
+		now:      time.Now,
 	}
 }
 
@@ -86,7 +109,12 @@ func (r *RedisRateLimiter) AllowSwipe(ctx context.Context, userID uuid.UUID) (bo
 	}
 
 	// Check daily limit
-	dailyKey := fmt.Sprintf("swipes:day:%s", userID.String())
+	day, err := r.swipeDay(ctx, userID)
+	if err != nil {
+		return false, err
+	}
+
+	dailyKey := day.key("swipes", userID)
 	dailyCount, err := r.getCount(ctx, dailyKey)
 	if err != nil {
 		return false, fmt.Errorf("failed to get daily swipe count: %w", err)
@@ -103,7 +131,7 @@ func (r *RedisRateLimiter) AllowSwipe(ctx context.Context, userID uuid.UUID) (bo
 	}
 
 	// Increment daily counter
-	err = r.incrementCount(ctx, dailyKey, r.config.DayWindow)
+	err = r.incrementCount(ctx, dailyKey, day.ttl)
 	if err != nil {
 		return false, fmt.Errorf("failed to increment daily counter: %w", err)
 	}
@@ -111,10 +139,16 @@ func (r *RedisRateLimiter) AllowSwipe(ctx context.Context, userID uuid.UUID) (bo
 	return true, nil
 }
 
-// AllowSuperLike checks if user is allowed to super like
+// AllowSuperLike checks if user is allowed to super like. Super likes are counted under their own
+// quota key, which expires when the daily limits start over, instead of a key per day.
 func (r *RedisRateLimiter) AllowSuperLike(ctx context.Context, userID uuid.UUID) (bool, error) {
 	// Check daily super like limit
-	dailyKey := fmt.Sprintf("super_likes:day:%s", userID.String())
+	day, err := r.swipeDay(ctx, userID)
+	if err != nil {
+		return false, err
+	}
+
+	dailyKey := SuperLikeQuotaKey(userID)
 	dailyCount, err := r.getCount(ctx, dailyKey)
 	if err != nil {
 		return false, fmt.Errorf("failed to get daily super like count: %w", err)
@@ -125,7 +159,7 @@ func (r *RedisRateLimiter) AllowSuperLike(ctx context.Context, userID uuid.UUID)
 	}
 
 	// Increment daily counter
-	err = r.incrementCount(ctx, dailyKey, r.config.DayWindow)
+	err = r.incrementCount(ctx, dailyKey, day.ttl)
 	if err != nil {
 		return false, fmt.Errorf("failed to increment super like counter: %w", err)
 	}
@@ -133,6 +167,28 @@ func (r *RedisRateLimiter) AllowSuperLike(ctx context.Context, userID uuid.UUID)
 	return true, nil
 }
 
+// AllowUndo checks if user is allowed to undo another swipe today
+func (r *RedisRateLimiter) AllowUndo(ctx context.Context, userID uuid.UUID) (bool, error) {
+	// Check daily undo limit
+	dailyKey := fmt.Sprintf("undos:day:%s", userID.String())
+	dailyCount, err := r.getCount(ctx, dailyKey)
+	if err != nil {
+		return false, fmt.Errorf("failed to get daily undo count: %w", err)
+	}
+
+	if dailyCount >= r.config.UndosPerDay {
+		return false, nil
+	}
+
+	// Increment daily counter
+	err = r.incrementCount(ctx, dailyKey, r.config.DayWindow)
+	if err != nil {
+		return false, fmt.Errorf("failed to increment undo counter: %w", err)
+	}
+
+	return true, nil
+}
+
 // GetSwipeCount gets swipe count for a user within a time window
 func (r *RedisRateLimiter) GetSwipeCount(ctx context.Context, userID uuid.UUID, window time.Duration) (int, error) {
 	var key string
@@ -140,7 +196,11 @@ func (r *RedisRateLimiter) GetSwipeCount(ctx context.Context, userID uuid.UUID,
 	case r.config.HourWindow:
 		key = fmt.Sprintf("swipes:hour:%s", userID.String())
 	case r.config.DayWindow:
-		key = fmt.Sprintf("swipes:day:%s", userID.String())
+		day, err := r.swipeDay(ctx, userID)
+		if err != nil {
+			return 0, err
+		}
+		key = day.key("swipes", userID)
 	default:
 		return 0, fmt.Errorf("unsupported time window: %v", window)
 	}
@@ -154,8 +214,102 @@ func (r *RedisRateLimiter) GetSuperLikeCount(ctx context.Context, userID uuid.UU
 		return 0, fmt.Errorf("super likes only tracked daily")
 	}
 
-	key := fmt.Sprintf("super_likes:day:%s", userID.String())
-	return r.getCount(ctx, key)
+	return r.getCount(ctx, SuperLikeQuotaKey(userID))
+}
+
+// SwipeQuota contains what is left of a user's daily swipe limits
+type SwipeQuota struct {
+	RemainingSwipes     int    `json:"remaining_swipes"`
+	RemainingSuperLikes int    `json:"remaining_super_likes"`
+	ResetsAt            string `json:"resets_at,omitempty"` // RFC3339 time the daily limits start over
+}
+
+// GetSwipeQuota gets the swipes and super likes a user has left today
+func (r *RedisRateLimiter) GetSwipeQuota(ctx context.Context, userID uuid.UUID) (*SwipeQuota, error) {
+	day, err := r.swipeDay(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	swipes, err := r.getCount(ctx, day.key("swipes", userID))
+	if err != nil {
+		return nil, fmt.Errorf("failed to get daily swipe count: %w", err)
+	}
+
+	superLikes, err := r.getCount(ctx, SuperLikeQuotaKey(userID))
+	if err != nil {
+		return nil, fmt.Errorf("failed to get daily super like count: %w", err)
+	}
+
+	return &SwipeQuota{
+		RemainingSwipes:     remainingQuota(r.config.SwipesPerDay, swipes),
+		RemainingSuperLikes: remainingQuota(r.config.SuperLikesPerDay, superLikes),
+		ResetsAt:            day.resetAt.Format(time.RFC3339),
+	}, nil
+}
+
+// swipeDay is the day a user's daily swipe counters belong to, and when the daily limits start over
+type swipeDay struct {
+	date    string        // Local date the swipe counters are kept under, empty for rolling limits
+	resetAt time.Time     // When the counters start over
+	ttl     time.Duration // How long a counter written now has to live
+}
+
+// key returns the counter key of a daily limit for the day
+func (d swipeDay) key(prefix string, userID uuid.UUID) string {
+	if d.date == "" {
+		return fmt.Sprintf("%s:day:%s", prefix, userID.String())
+	}
+	return fmt.Sprintf("%s:day:%s:%s", prefix, userID.String(), d.date)
+}
+
+// swipeDay returns the current day of the user's daily limits. With local midnight resets, the
+// counters are kept per local date, so users in different timezones start over at their own midnight.
+func (r *RedisRateLimiter) swipeDay(ctx context.Context, userID uuid.UUID) (swipeDay, error) {
+	now := r.now()
+	if r.config.ResetStrategy != ResetLocalMidnight {
+		return swipeDay{resetAt: now.Add(r.config.DayWindow), ttl: r.config.DayWindow}, nil
+	}
+
+	location, err := r.userLocation(ctx, userID)
+	if err != nil {
+		return swipeDay{}, err
+	}
+
+	local := now.In(location)
+	resetAt := nextLocalMidnight(local)
+	return swipeDay{
+		date:    local.Format("2006-01-02"),
+		resetAt: resetAt,
+		ttl:     resetAt.Sub(local),
+	}, nil
+}
+
+// userLocation returns the location of the user's timezone, UTC if they have none
+func (r *RedisRateLimiter) userLocation(ctx context.Context, userID uuid.UUID) (*time.Location, error) {
+	if r.userRepo == nil {
+		return time.UTC, nil
+	}
+
+	user, err := r.userRepo.GetByID(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get user timezone: %w", err)
+	}
+	return user.TimeLocation(), nil
+}
+
+// nextLocalMidnight returns the first midnight after t in t's location. On days a DST change
+// skips midnight, that is the first moment of the next day.
+func nextLocalMidnight(t time.Time) time.Time {
+	return time.Date(t.Year(), t.Month(), t.Day()+1, 0, 0, 0, 0, t.Location())
+}
+
+// remainingQuota returns what is left of a limit, never below zero
+func remainingQuota(limit, count int) int {
+	if count >= limit {
+		return 0
+	}
+	return limit - count
 }
 
 // AllowDiscovery checks if user is allowed to make discovery requests
@@ -286,6 +440,7 @@ func (r *RedisRateLimiter) GetRateLimitInfo(ctx context.Context, userID uuid.UUI
 	var limit int
 	var window time.Duration
 	var key string
+	var resetTime time.Time
 
 	switch operation {
 	case "swipe":
@@ -293,9 +448,14 @@ func (r *RedisRateLimiter) GetRateLimitInfo(ctx context.Context, userID uuid.UUI
 		window = r.config.HourWindow
 		key = fmt.Sprintf("swipes:hour:%s", userID.String())
 	case "super_like":
+		day, err := r.swipeDay(ctx, userID)
+		if err != nil {
+			return nil, err
+		}
 		limit = r.config.SuperLikesPerDay
 		window = r.config.DayWindow
-		key = fmt.Sprintf("super_likes:day:%s", userID.String())
+		key = SuperLikeQuotaKey(userID)
+		resetTime = day.resetAt
 	case "discovery":
 		limit = r.config.DiscoveryPerHour
 		window = r.config.HourWindow
@@ -315,7 +475,9 @@ func (r *RedisRateLimiter) GetRateLimitInfo(ctx context.Context, userID uuid.UUI
 	}
 
 	// Calculate reset time (simplified - would need to get TTL from Redis)
-	resetTime := time.Now().Add(window)
+	if resetTime.IsZero() {
+		resetTime = time.Now().Add(window)
+	}
 
 	return &RateLimitInfo{
 		Limit:     limit,
@@ -0,0 +1,158 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/22smeargle/winkr-backend/internal/domain/entities"
+)
+
+// memoryRedisClient keeps counters in memory, expiring them on its clock, and records the TTL
+// they were last written with
+type memoryRedisClient struct {
+	RedisClient
+	values  map[string]interface{}
+	ttls    map[string]time.Duration
+	expires map[string]time.Time
+	now     func() time.Time
+}
+
+func newMemoryRedisClient() *memoryRedisClient {
+	return &memoryRedisClient{
+		values:  make(map[string]interface{}),
+		ttls:    make(map[string]time.Duration),
+		expires: make(map[string]time.Time),
+		now:     time.Now,
+	}
+}
+
+func (c *memoryRedisClient) Get(ctx context.Context, key string) (interface{}, error) {
+	value, ok := c.values[key]
+	if !ok || !c.now().Before(c.expires[key]) {
+		return nil, fmt.Errorf("key not found")
+	}
+	return value, nil
+}
+
+func (c *memoryRedisClient) Set(ctx context.Context, key string, value interface{}, ttl time.Duration) error {
+	c.values[key] = value
+	c.ttls[key] = ttl
+	c.expires[key] = c.now().Add(ttl)
+	return nil
+}
+
+type midnightFixture struct {
+	limiter *RedisRateLimiter
+	redis   *memoryRedisClient
+	now     time.Time
+	tokyo   *entities.User
+	newYork *entities.User
+	noZone  *entities.User
+}
+
+func newMidnightFixture(now time.Time) *midnightFixture {
+	tokyo, newYork := "Asia/Tokyo", "America/New_York"
+	f := &midnightFixture{
+		redis:   newMemoryRedisClient(),
+		now:     now,
+		tokyo:   &entities.User{ID: uuid.New(), Timezone: &tokyo},
+		newYork: &entities.User{ID: uuid.New(), Timezone: &newYork},
+		noZone:  &entities.User{ID: uuid.New()},
+	}
+
+	config := DefaultRateLimitConfig()
+	config.SuperLikesPerDay = 2
+	config.ResetStrategy = ResetLocalMidnight
+	users := &openerUserRepository{users: map[uuid.UUID]*entities.User{
+		f.tokyo.ID:   f.tokyo,
+		f.newYork.ID: f.newYork,
+		f.noZone.ID:  f.noZone,
+	}}
+	f.limiter = NewRedisRateLimiter(f.redis, config, users)
+	f.limiter.now = func() time.Time { return f.now }
+	f.redis.now = f.limiter.now
+	return f
+}
+
+func (f *midnightFixture) superLike(t *testing.T, user *entities.User) bool {
+	t.Helper()
+	allowed, err := f.limiter.AllowSuperLike(context.Background(), user.ID)
+	require.NoError(t, err)
+	return allowed
+}
+
+func (f *midnightFixture) quota(t *testing.T, user *entities.User) *SwipeQuota {
+	t.Helper()
+	quota, err := f.limiter.GetSwipeQuota(context.Background(), user.ID)
+	require.NoError(t, err)
+	return quota
+}
+
+func TestNextLocalMidnight(t *testing.T) {
+	berlin, err := time.LoadLocation("Europe/Berlin")
+	require.NoError(t, err)
+
+	assert.Equal(t, time.Date(2026, 10, 18, 0, 0, 0, 0, berlin), nextLocalMidnight(time.Date(2026, 10, 17, 23, 59, 0, 0, berlin)))
+	assert.Equal(t, time.Date(2026, 10, 18, 0, 0, 0, 0, berlin), nextLocalMidnight(time.Date(2026, 10, 17, 0, 0, 0, 0, berlin)))
+	assert.Equal(t, time.Date(2027, 1, 1, 0, 0, 0, 0, berlin), nextLocalMidnight(time.Date(2026, 12, 31, 12, 0, 0, 0, berlin)))
+
+	// The day clocks go back is 25 hours long, so from 1am there are 24 hours left rather than 23
+	night := time.Date(2026, 10, 25, 1, 0, 0, 0, berlin)
+	assert.Equal(t, 24*time.Hour, nextLocalMidnight(night).Sub(night))
+}
+
+func TestRedisRateLimiter_LocalMidnightReset(t *testing.T) {
+	// 23:30 in Tokyo, 10:30 in New York
+	f := newMidnightFixture(time.Date(2026, 10, 17, 14, 30, 0, 0, time.UTC))
+
+	assert.True(t, f.superLike(t, f.tokyo))
+	assert.True(t, f.superLike(t, f.tokyo))
+	assert.False(t, f.superLike(t, f.tokyo), "the daily limit is used up")
+	assert.True(t, f.superLike(t, f.newYork))
+
+	quota := f.quota(t, f.tokyo)
+	assert.Equal(t, 0, quota.RemainingSuperLikes)
+	assert.Equal(t, "2026-10-18T00:00:00+09:00", quota.ResetsAt)
+	assert.Equal(t, 30*time.Minute, f.redis.ttls[SuperLikeQuotaKey(f.tokyo.ID)])
+
+	// An hour later it is a new day in Tokyo and the super like counter expired, but it is still the
+	// same day in New York
+	f.now = f.now.Add(time.Hour)
+
+	quota = f.quota(t, f.tokyo)
+	assert.Equal(t, 2, quota.RemainingSuperLikes)
+	assert.Equal(t, "2026-10-19T00:00:00+09:00", quota.ResetsAt)
+	assert.True(t, f.superLike(t, f.tokyo))
+
+	quota = f.quota(t, f.newYork)
+	assert.Equal(t, 1, quota.RemainingSuperLikes)
+	assert.Equal(t, "2026-10-18T00:00:00-04:00", quota.ResetsAt)
+}
+
+func TestRedisRateLimiter_LocalMidnightResetWithoutTimezone(t *testing.T) {
+	f := newMidnightFixture(time.Date(2026, 10, 17, 14, 30, 0, 0, time.UTC))
+
+	allowed, err := f.limiter.AllowSwipe(context.Background(), f.noZone.ID)
+	require.NoError(t, err)
+	assert.True(t, allowed)
+
+	// Users without a timezone start over at midnight UTC
+	quota := f.quota(t, f.noZone)
+	assert.Equal(t, DefaultRateLimitConfig().SwipesPerDay-1, quota.RemainingSwipes)
+	assert.Equal(t, "2026-10-18T00:00:00Z", quota.ResetsAt)
+}
+
+func TestRedisRateLimiter_RollingReset(t *testing.T) {
+	f := newMidnightFixture(time.Date(2026, 10, 17, 14, 30, 0, 0, time.UTC))
+	f.limiter.config.ResetStrategy = ResetRolling
+
+	assert.True(t, f.superLike(t, f.tokyo))
+
+	assert.Equal(t, 24*time.Hour, f.redis.ttls[SuperLikeQuotaKey(f.tokyo.ID)])
+	assert.Equal(t, "2026-10-18T14:30:00Z", f.quota(t, f.tokyo).ResetsAt)
+}
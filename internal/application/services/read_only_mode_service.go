@@ -0,0 +1,129 @@
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/22smeargle/winkr-backend/pkg/config"
+	"github.com/22smeargle/winkr-backend/pkg/logger"
+)
+
+// readOnlyStateKey is the system state key of the read-only toggle
+const readOnlyStateKey = "read_only_mode"
+
+// readOnlyRefreshInterval is how long an instance trusts its copy of the toggle before reading the
+// store again, which bounds how long instances disagree after a change
+const readOnlyRefreshInterval = 5 * time.Second
+
+// ReadOnlyState is the read-only toggle and who last changed it
+type ReadOnlyState struct {
+	Enabled   bool       `json:"enabled"`
+	Reason    string     `json:"reason,omitempty"`
+	UpdatedBy string     `json:"updated_by,omitempty"`
+	UpdatedAt *time.Time `json:"updated_at,omitempty"`
+}
+
+// SystemStateStore keeps system-wide settings shared by every instance of the API
+type SystemStateStore interface {
+	// Get returns the value of key, or an empty string if it was never set
+	Get(ctx context.Context, key string) (string, error)
+	Set(ctx context.Context, key, value string) error
+}
+
+type readOnlyContextKey struct{}
+
+// ContextWithReadOnly marks ctx as serving a request while the API is read-only
+func ContextWithReadOnly(ctx context.Context) context.Context {
+	return context.WithValue(ctx, readOnlyContextKey{}, true)
+}
+
+// IsReadOnlyContext reports whether ctx serves a request while the API is read-only. Reads use it
+// to skip their side-effect writes, such as marking messages read.
+func IsReadOnlyContext(ctx context.Context) bool {
+	readOnly, _ := ctx.Value(readOnlyContextKey{}).(bool)
+	return readOnly
+}
+
+// ReadOnlyModeService holds the read-only toggle used during database maintenance. The configured
+// default applies until an admin sets the toggle, which is then shared by every instance.
+type ReadOnlyModeService struct {
+	store SystemStateStore
+	now   func() time.Time
+
+	mu        sync.Mutex
+	state     ReadOnlyState
+	checkedAt time.Time
+}
+
+// NewReadOnlyModeService creates a read-only mode service starting from the configured default
+func NewReadOnlyModeService(cfg *config.AppConfig, store SystemStateStore) *ReadOnlyModeService {
+	return &ReadOnlyModeService{
+		store: store,
+		now:   time.Now,
+		state: ReadOnlyState{Enabled: cfg.ReadOnlyMode},
+	}
+}
+
+// State returns the toggle, read from the store at most once per refresh interval. If the store
+// can't be read, the last known state is kept rather than flipping writes back on.
+func (s *ReadOnlyModeService) State(ctx context.Context) ReadOnlyState {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := s.now()
+	if now.Sub(s.checkedAt) < readOnlyRefreshInterval {
+		return s.state
+	}
+	s.checkedAt = now
+
+	value, err := s.store.Get(ctx, readOnlyStateKey)
+	if err != nil {
+		logger.Error("Failed to read read-only mode state", err)
+		return s.state
+	}
+	if value == "" {
+		return s.state
+	}
+
+	var stored ReadOnlyState
+	if err := json.Unmarshal([]byte(value), &stored); err != nil {
+		logger.Error("Failed to decode read-only mode state", err)
+		return s.state
+	}
+	s.state = stored
+	return s.state
+}
+
+// IsReadOnly reports whether writes are currently rejected
+func (s *ReadOnlyModeService) IsReadOnly(ctx context.Context) bool {
+	return s.State(ctx).Enabled
+}
+
+// SetReadOnly turns read-only mode on or off for every instance
+func (s *ReadOnlyModeService) SetReadOnly(ctx context.Context, enabled bool, reason, adminID string) (ReadOnlyState, error) {
+	now := s.now()
+	state := ReadOnlyState{
+		Enabled:   enabled,
+		Reason:    reason,
+		UpdatedBy: adminID,
+		UpdatedAt: &now,
+	}
+	value, err := json.Marshal(state)
+	if err != nil {
+		return ReadOnlyState{}, fmt.Errorf("failed to encode read-only mode state: %w", err)
+	}
+	if err := s.store.Set(ctx, readOnlyStateKey, string(value)); err != nil {
+		return ReadOnlyState{}, err
+	}
+
+	s.mu.Lock()
+	s.state = state
+	s.checkedAt = now
+	s.mu.Unlock()
+
+	logger.Warn("Read-only mode changed", "enabled", enabled, "reason", reason, "admin_id", adminID)
+	return state, nil
+}
@@ -0,0 +1,118 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/22smeargle/winkr-backend/pkg/config"
+)
+
+// memorySystemStateStore keeps system state in memory, standing in for Redis
+type memorySystemStateStore struct {
+	mu     sync.Mutex
+	values map[string]string
+	err    error
+}
+
+func newMemorySystemStateStore() *memorySystemStateStore {
+	return &memorySystemStateStore{values: make(map[string]string)}
+}
+
+func (s *memorySystemStateStore) Get(ctx context.Context, key string) (string, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.err != nil {
+		return "", s.err
+	}
+	return s.values[key], nil
+}
+
+func (s *memorySystemStateStore) Set(ctx context.Context, key, value string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.err != nil {
+		return s.err
+	}
+	s.values[key] = value
+	return nil
+}
+
+func (s *memorySystemStateStore) fail(err error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.err = err
+}
+
+// newTestReadOnlyModeService returns a service whose clock the test advances
+func newTestReadOnlyModeService(defaultEnabled bool, store SystemStateStore) (*ReadOnlyModeService, *time.Time) {
+	service := NewReadOnlyModeService(&config.AppConfig{ReadOnlyMode: defaultEnabled}, store)
+	now := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	service.now = func() time.Time { return now }
+	return service, &now
+}
+
+func TestReadOnlyModeService_DefaultsToConfig(t *testing.T) {
+	ctx := context.Background()
+
+	service, _ := newTestReadOnlyModeService(false, newMemorySystemStateStore())
+	assert.False(t, service.IsReadOnly(ctx))
+
+	service, _ = newTestReadOnlyModeService(true, newMemorySystemStateStore())
+	assert.True(t, service.IsReadOnly(ctx))
+}
+
+func TestReadOnlyModeService_SharedBetweenInstances(t *testing.T) {
+	ctx := context.Background()
+	store := newMemorySystemStateStore()
+	first, _ := newTestReadOnlyModeService(false, store)
+	second, secondNow := newTestReadOnlyModeService(false, store)
+
+	assert.False(t, second.IsReadOnly(ctx))
+
+	state, err := first.SetReadOnly(ctx, true, "Database upgrade", "admin-1")
+	require.NoError(t, err)
+	assert.True(t, state.Enabled)
+	assert.Equal(t, "admin-1", state.UpdatedBy)
+	require.NotNil(t, state.UpdatedAt)
+	assert.True(t, first.IsReadOnly(ctx))
+
+	// Test the other instance picks the change up once its copy is stale
+	assert.False(t, second.IsReadOnly(ctx))
+	*secondNow = secondNow.Add(readOnlyRefreshInterval)
+	got := second.State(ctx)
+	assert.True(t, got.Enabled)
+	assert.Equal(t, "Database upgrade", got.Reason)
+
+	_, err = first.SetReadOnly(ctx, false, "", "admin-1")
+	require.NoError(t, err)
+	*secondNow = secondNow.Add(readOnlyRefreshInterval)
+	assert.False(t, second.IsReadOnly(ctx))
+}
+
+func TestReadOnlyModeService_KeepsLastStateWhenStoreFails(t *testing.T) {
+	ctx := context.Background()
+	store := newMemorySystemStateStore()
+	service, now := newTestReadOnlyModeService(false, store)
+
+	_, err := service.SetReadOnly(ctx, true, "maintenance", "admin-1")
+	require.NoError(t, err)
+
+	store.fail(errors.New("redis unavailable"))
+	*now = now.Add(readOnlyRefreshInterval)
+	assert.True(t, service.IsReadOnly(ctx), "an unreadable store doesn't turn writes back on")
+
+	_, err = service.SetReadOnly(ctx, false, "", "admin-1")
+	assert.Error(t, err)
+	assert.True(t, service.IsReadOnly(ctx))
+}
+
+func TestReadOnlyContext(t *testing.T) {
+	ctx := context.Background()
+	assert.False(t, IsReadOnlyContext(ctx))
+	assert.True(t, IsReadOnlyContext(ContextWithReadOnly(ctx)))
+}
@@ -0,0 +1,209 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/22smeargle/winkr-backend/internal/domain/entities"
+	"github.com/22smeargle/winkr-backend/pkg/logger"
+)
+
+var (
+	// ErrReadConversationAccessDenied is returned when the reader is not a participant in the conversation
+	ErrReadConversationAccessDenied = errors.New("user cannot access this conversation")
+	// ErrReadMessageNotInConversation is returned when the message read up to belongs to another conversation
+	ErrReadMessageNotInConversation = errors.New("message does not belong to this conversation")
+)
+
+// ReadMessageStore defines the message persistence needed to mark messages as read
+type ReadMessageStore interface {
+	UserCanAccessConversation(ctx context.Context, userID, conversationID uuid.UUID) (bool, error)
+	GetByID(ctx context.Context, id uuid.UUID) (*entities.Message, error)
+	GetUnreadMessages(ctx context.Context, userID uuid.UUID) ([]*entities.Message, error)
+	BatchMarkAsRead(ctx context.Context, messageIDs []uuid.UUID) error
+}
+
+// ReadReceiptUserStore defines the user lookup needed to check the read receipt setting
+type ReadReceiptUserStore interface {
+	GetByID(ctx context.Context, id uuid.UUID) (*entities.User, error)
+}
+
+// ReadReceiptNotifier delivers read receipts and unread counts to connected clients
+type ReadReceiptNotifier interface {
+	// NotifyReadReceipt tells the sender that the reader read their messages up to the given one
+	NotifyReadReceipt(ctx context.Context, senderID, readerID, conversationID, upToMessageID uuid.UUID, messageIDs []uuid.UUID, readAt time.Time) error
+	// NotifyUnreadCount tells the user their unread count in a conversation
+	NotifyUnreadCount(ctx context.Context, userID, conversationID uuid.UUID, count int) error
+}
+
+// ReadUnreadCountCache caches a user's unread count per conversation
+type ReadUnreadCountCache interface {
+	CacheUserUnreadCount(ctx context.Context, userID, conversationID string, count int) error
+}
+
+// MarkMessagesReadResult is the outcome of marking a conversation read up to a message
+type MarkMessagesReadResult struct {
+	MarkedCount int  `json:"marked_count"`
+	UnreadCount int  `json:"unread_count"`
+	ReceiptSent bool `json:"receipt_sent"`
+}
+
+// ReadReceiptService marks messages as read and tells their senders, unless the reader turned read
+// receipts off. Readers always get their own unread counts cleared.
+type ReadReceiptService struct {
+	messageStore   ReadMessageStore
+	userStore      ReadReceiptUserStore
+	receiptService *MessageReceiptService
+	notifier       ReadReceiptNotifier
+	unreadCache    ReadUnreadCountCache
+}
+
+// NewReadReceiptService creates a new ReadReceiptService
+func NewReadReceiptService(
+	messageStore ReadMessageStore,
+	userStore ReadReceiptUserStore,
+	receiptService *MessageReceiptService,
+	notifier ReadReceiptNotifier,
+	unreadCache ReadUnreadCountCache,
+) *ReadReceiptService {
+	return &ReadReceiptService{
+		messageStore:   messageStore,
+		userStore:      userStore,
+		receiptService: receiptService,
+		notifier:       notifier,
+		unreadCache:    unreadCache,
+	}
+}
+
+// SendsReadReceipts reports whether the user lets senders know they read their messages. A user
+// who cannot be loaded counts as not sending them, so a lookup failure never leaks a read.
+func (s *ReadReceiptService) SendsReadReceipts(ctx context.Context, userID uuid.UUID) bool {
+	if s == nil {
+		return true
+	}
+
+	user, err := s.userStore.GetByID(ctx, userID)
+	if err != nil {
+		logger.Warn("Failed to get user for read receipt setting", "user_id", userID, "error", err)
+		return false
+	}
+
+	return user.SendReadReceipts
+}
+
+// MarkMessagesRead marks every message the user received in the conversation up to and including
+// upToMessageID as read, and resets their unread count to what arrived after it. Each sender gets one
+// read receipt for their messages. Users with read receipts off only have the messages recorded as
+// delivered, and no receipt is sent.
+func (s *ReadReceiptService) MarkMessagesRead(ctx context.Context, conversationID, upToMessageID, userID uuid.UUID) (*MarkMessagesReadResult, error) {
+	canAccess, err := s.messageStore.UserCanAccessConversation(ctx, userID, conversationID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to check conversation access: %w", err)
+	}
+	if !canAccess {
+		return nil, ErrReadConversationAccessDenied
+	}
+
+	upTo, err := s.messageStore.GetByID(ctx, upToMessageID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get message: %w", err)
+	}
+	if upTo.ConversationID != conversationID {
+		return nil, ErrReadMessageNotInConversation
+	}
+
+	unread, err := s.messageStore.GetUnreadMessages(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get unread messages: %w", err)
+	}
+
+	var messages []*entities.Message
+	var messageIDs []uuid.UUID
+	remaining := 0
+	for _, message := range unread {
+		if message.ConversationID != conversationID || message.SenderID == userID {
+			continue
+		}
+		if message.CreatedAt.After(upTo.CreatedAt) {
+			remaining++
+			continue
+		}
+		messages = append(messages, message)
+		messageIDs = append(messageIDs, message.ID)
+	}
+
+	result := &MarkMessagesReadResult{UnreadCount: remaining}
+	if len(messages) == 0 {
+		return result, nil
+	}
+
+	if err := s.messageStore.BatchMarkAsRead(ctx, messageIDs); err != nil {
+		return nil, fmt.Errorf("failed to mark messages as read: %w", err)
+	}
+	result.MarkedCount = len(messages)
+
+	sendReceipts := s.SendsReadReceipts(ctx, userID)
+	if s.receiptService != nil {
+		record := s.receiptService.RecordDeliveredAll
+		if sendReceipts {
+			record = s.receiptService.RecordReadAll
+		}
+		if err := record(ctx, messages, userID); err != nil {
+			logger.Error("Failed to record message read statuses", err, "conversation_id", conversationID)
+		}
+	}
+
+	s.resetUnreadCount(ctx, userID, conversationID, remaining)
+	if sendReceipts {
+		result.ReceiptSent = s.sendReceipts(ctx, userID, conversationID, upToMessageID, messages, time.Now())
+	}
+
+	return result, nil
+}
+
+// resetUnreadCount caches the user's new unread count for the conversation and tells their clients
+func (s *ReadReceiptService) resetUnreadCount(ctx context.Context, userID, conversationID uuid.UUID, count int) {
+	if s.unreadCache != nil {
+		if err := s.unreadCache.CacheUserUnreadCount(ctx, userID.String(), conversationID.String(), count); err != nil {
+			logger.Error("Failed to cache unread count", err, "conversation_id", conversationID)
+		}
+	}
+	if s.notifier != nil {
+		if err := s.notifier.NotifyUnreadCount(ctx, userID, conversationID, count); err != nil {
+			logger.Error("Failed to notify unread count", err, "conversation_id", conversationID)
+		}
+	}
+}
+
+// sendReceipts sends each sender one read receipt covering their messages, reporting whether any was sent
+func (s *ReadReceiptService) sendReceipts(ctx context.Context, readerID, conversationID, upToMessageID uuid.UUID, messages []*entities.Message, readAt time.Time) bool {
+	if s.notifier == nil {
+		return false
+	}
+
+	var senderIDs []uuid.UUID
+	bySender := make(map[uuid.UUID][]uuid.UUID)
+	for _, message := range messages {
+		if _, seen := bySender[message.SenderID]; !seen {
+			senderIDs = append(senderIDs, message.SenderID)
+		}
+		bySender[message.SenderID] = append(bySender[message.SenderID], message.ID)
+	}
+
+	sent := false
+	for _, senderID := range senderIDs {
+		if err := s.notifier.NotifyReadReceipt(ctx, senderID, readerID, conversationID, upToMessageID, bySender[senderID], readAt); err != nil {
+			logger.Error("Failed to send read receipt", err,
+				"conversation_id", conversationID,
+				"sender_id", senderID,
+			)
+			continue
+		}
+		sent = true
+	}
+
+	return sent
+}
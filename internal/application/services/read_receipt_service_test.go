@@ -0,0 +1,191 @@
+package services
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/22smeargle/winkr-backend/internal/domain/entities"
+)
+
+// inMemoryReadMessageStore keeps one conversation's messages in memory
+type inMemoryReadMessageStore struct {
+	conversationID uuid.UUID
+	participants   map[uuid.UUID]bool
+	messages       []*entities.Message
+}
+
+func (s *inMemoryReadMessageStore) UserCanAccessConversation(ctx context.Context, userID, conversationID uuid.UUID) (bool, error) {
+	return conversationID == s.conversationID && s.participants[userID], nil
+}
+
+func (s *inMemoryReadMessageStore) GetByID(ctx context.Context, id uuid.UUID) (*entities.Message, error) {
+	for _, message := range s.messages {
+		if message.ID == id {
+			return message, nil
+		}
+	}
+	return nil, assert.AnError
+}
+
+func (s *inMemoryReadMessageStore) GetUnreadMessages(ctx context.Context, userID uuid.UUID) ([]*entities.Message, error) {
+	var unread []*entities.Message
+	for _, message := range s.messages {
+		if message.SenderID != userID && !message.IsRead {
+			unread = append(unread, message)
+		}
+	}
+	return unread, nil
+}
+
+func (s *inMemoryReadMessageStore) BatchMarkAsRead(ctx context.Context, messageIDs []uuid.UUID) error {
+	for _, id := range messageIDs {
+		message, _ := s.GetByID(ctx, id)
+		message.IsRead = true
+	}
+	return nil
+}
+
+type sentReadReceipt struct {
+	senderID      uuid.UUID
+	upToMessageID uuid.UUID
+	messageIDs    []uuid.UUID
+}
+
+// recordingReadReceiptNotifier records read receipts and the latest unread count
+type recordingReadReceiptNotifier struct {
+	receipts []sentReadReceipt
+	unread   map[uuid.UUID]int
+}
+
+func (n *recordingReadReceiptNotifier) NotifyReadReceipt(ctx context.Context, senderID, readerID, conversationID, upToMessageID uuid.UUID, messageIDs []uuid.UUID, readAt time.Time) error {
+	n.receipts = append(n.receipts, sentReadReceipt{senderID: senderID, upToMessageID: upToMessageID, messageIDs: messageIDs})
+	return nil
+}
+
+func (n *recordingReadReceiptNotifier) NotifyUnreadCount(ctx context.Context, userID, conversationID uuid.UUID, count int) error {
+	n.unread[userID] = count
+	return nil
+}
+
+type readReceiptFixture struct {
+	store    *inMemoryReadMessageStore
+	statuses *inMemoryMessageStatusStore
+	notifier *recordingReadReceiptNotifier
+	reader   *entities.User
+	sender   *entities.User
+	service  *ReadReceiptService
+}
+
+// newReadReceiptFixture sets up a conversation where the sender sent the reader count messages, a minute apart
+func newReadReceiptFixture(count int, sendReadReceipts bool) *readReceiptFixture {
+	reader := &entities.User{ID: uuid.New(), SendReadReceipts: sendReadReceipts}
+	sender := &entities.User{ID: uuid.New(), SendReadReceipts: true}
+	store := &inMemoryReadMessageStore{
+		conversationID: uuid.New(),
+		participants:   map[uuid.UUID]bool{reader.ID: true, sender.ID: true},
+	}
+
+	start := time.Now().Add(-time.Hour)
+	for i := 0; i < count; i++ {
+		store.messages = append(store.messages, &entities.Message{
+			ID:             uuid.New(),
+			ConversationID: store.conversationID,
+			SenderID:       sender.ID,
+			Content:        "hey",
+			MessageType:    "text",
+			CreatedAt:      start.Add(time.Duration(i) * time.Minute),
+		})
+	}
+
+	statuses := &inMemoryMessageStatusStore{}
+	notifier := &recordingReadReceiptNotifier{unread: map[uuid.UUID]int{}}
+	users := &usersByID{users: map[uuid.UUID]*entities.User{reader.ID: reader, sender.ID: sender}}
+
+	return &readReceiptFixture{
+		store:    store,
+		statuses: statuses,
+		notifier: notifier,
+		reader:   reader,
+		sender:   sender,
+		service:  NewReadReceiptService(store, users, NewMessageReceiptService(statuses), notifier, nil),
+	}
+}
+
+func TestReadReceiptService_MarksReadUpToMessage(t *testing.T) {
+	f := newReadReceiptFixture(4, true)
+	upTo := f.store.messages[2]
+
+	result, err := f.service.MarkMessagesRead(context.Background(), f.store.conversationID, upTo.ID, f.reader.ID)
+
+	require.NoError(t, err)
+	assert.Equal(t, &MarkMessagesReadResult{MarkedCount: 3, UnreadCount: 1, ReceiptSent: true}, result)
+	assert.Equal(t, 1, f.notifier.unread[f.reader.ID])
+
+	for i, message := range f.store.messages {
+		if i <= 2 {
+			assert.True(t, message.IsRead)
+			assert.Equal(t, []string{entities.MessageStatusSent, entities.MessageStatusDelivered, entities.MessageStatusRead}, f.statuses.recorded(message.ID))
+		} else {
+			assert.False(t, message.IsRead, "messages after the one read up to stay unread")
+			assert.Empty(t, f.statuses.recorded(message.ID))
+		}
+	}
+
+	// The sender gets one receipt for the three messages
+	require.Len(t, f.notifier.receipts, 1)
+	receipt := f.notifier.receipts[0]
+	assert.Equal(t, f.sender.ID, receipt.senderID)
+	assert.Equal(t, upTo.ID, receipt.upToMessageID)
+	assert.Equal(t, []uuid.UUID{f.store.messages[0].ID, f.store.messages[1].ID, upTo.ID}, receipt.messageIDs)
+}
+
+func TestReadReceiptService_ReceiptsOffStillClearUnreadCount(t *testing.T) {
+	f := newReadReceiptFixture(2, false)
+	upTo := f.store.messages[1]
+
+	result, err := f.service.MarkMessagesRead(context.Background(), f.store.conversationID, upTo.ID, f.reader.ID)
+
+	require.NoError(t, err)
+	assert.Equal(t, 2, result.MarkedCount)
+	assert.False(t, result.ReceiptSent)
+	assert.Empty(t, f.notifier.receipts)
+
+	// The reader's own unread count is cleared, but the sender only ever sees the messages delivered
+	count, notified := f.notifier.unread[f.reader.ID]
+	assert.True(t, notified)
+	assert.Equal(t, 0, count)
+	for _, message := range f.store.messages {
+		assert.True(t, message.IsRead)
+		assert.Equal(t, []string{entities.MessageStatusSent, entities.MessageStatusDelivered}, f.statuses.recorded(message.ID))
+	}
+}
+
+func TestReadReceiptService_RejectsOtherConversations(t *testing.T) {
+	f := newReadReceiptFixture(1, true)
+	other := newReadReceiptFixture(1, true)
+	f.store.messages = append(f.store.messages, other.store.messages...)
+
+	_, err := f.service.MarkMessagesRead(context.Background(), f.store.conversationID, other.store.messages[0].ID, f.reader.ID)
+	assert.ErrorIs(t, err, ErrReadMessageNotInConversation)
+
+	_, err = f.service.MarkMessagesRead(context.Background(), f.store.conversationID, f.store.messages[0].ID, uuid.New())
+	assert.ErrorIs(t, err, ErrReadConversationAccessDenied)
+
+	assert.Empty(t, f.notifier.receipts)
+	assert.False(t, f.store.messages[0].IsRead)
+}
+
+func TestReadReceiptService_SendersOwnMessagesAreNotRead(t *testing.T) {
+	f := newReadReceiptFixture(1, true)
+
+	result, err := f.service.MarkMessagesRead(context.Background(), f.store.conversationID, f.store.messages[0].ID, f.sender.ID)
+
+	require.NoError(t, err)
+	assert.Zero(t, result.MarkedCount)
+	assert.Empty(t, f.notifier.receipts)
+	assert.False(t, f.store.messages[0].IsRead)
+}
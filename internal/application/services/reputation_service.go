@@ -0,0 +1,257 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/22smeargle/winkr-backend/internal/domain/entities"
+	"github.com/22smeargle/winkr-backend/internal/domain/repositories"
+	"github.com/22smeargle/winkr-backend/pkg/config"
+	"github.com/22smeargle/winkr-backend/pkg/logger"
+)
+
+// ErrReputationConflict is returned when a reputation change keeps racing other changes
+var ErrReputationConflict = errors.New("reputation changed concurrently, try again")
+
+// ReputationUserStore reads and suspends users whose reputation drops too low
+type ReputationUserStore interface {
+	GetByID(ctx context.Context, id uuid.UUID) (*entities.User, error)
+	Update(ctx context.Context, user *entities.User) error
+}
+
+// ReputationService scores users' standing with moderation. Users start at InitialReputation, lose
+// reputation when reports against them are confirmed or their content is removed, and regain
+// ReputationRecoveryPoints every ReputationRecoveryInterval without changes, up to
+// InitialReputation. Scores stay between MinReputation and MaxReputation and every change is
+// recorded. Users dropping below ReputationSuspendThreshold are suspended if AutoSuspendEnabled.
+type ReputationService struct {
+	repo     repositories.ReputationRepository
+	users    ReputationUserStore
+	config   *config.ModerationRulesConfig
+	// pollInterval is how often reputations are checked for recovery
+	pollInterval time.Duration
+	// maxAttempts is how often a change is retried when it races another change
+	maxAttempts int
+	now         func() time.Time
+}
+
+// NewReputationService creates a new reputation service
+func NewReputationService(
+	repo repositories.ReputationRepository,
+	users ReputationUserStore,
+	cfg *config.ModerationRulesConfig,
+) *ReputationService {
+	return &ReputationService{
+		repo:         repo,
+		users:        users,
+		config:       cfg,
+		pollInterval: time.Hour,
+		maxAttempts:  3,
+		now:          time.Now,
+	}
+}
+
+// Start recovers reputations every poll interval until the context is cancelled
+func (s *ReputationService) Start(ctx context.Context) {
+	if !s.config.ReputationEnabled {
+		return
+	}
+
+	logger.Info("Starting reputation recovery", "interval", s.pollInterval)
+
+	go func() {
+		ticker := time.NewTicker(s.pollInterval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ctx.Done():
+				logger.Info("Reputation recovery stopped")
+				return
+			case <-ticker.C:
+				s.RecoverReputations(ctx)
+			}
+		}
+	}()
+}
+
+// GetScore returns the user's reputation score
+func (s *ReputationService) GetScore(ctx context.Context, userID uuid.UUID) (int, error) {
+	reputation, err := s.repo.GetByUserID(ctx, userID)
+	if err != nil {
+		return 0, err
+	}
+	if reputation == nil {
+		return s.config.InitialReputation, nil
+	}
+	return reputation.Score, nil
+}
+
+// LowReputationUserIDs returns which of the users score below ReputationDiscoveryThreshold, for
+// discovery to show them last
+func (s *ReputationService) LowReputationUserIDs(ctx context.Context, userIDs []uuid.UUID) (map[uuid.UUID]bool, error) {
+	low := make(map[uuid.UUID]bool)
+	threshold := s.config.ReputationDiscoveryThreshold
+	if !s.config.ReputationEnabled || threshold <= 0 || s.config.InitialReputation < threshold {
+		return low, nil
+	}
+
+	// Users without a score have the initial reputation, which is not below the threshold
+	scores, err := s.repo.GetScores(ctx, userIDs)
+	if err != nil {
+		return nil, err
+	}
+	for userID, score := range scores {
+		if score < threshold {
+			low[userID] = true
+		}
+	}
+	return low, nil
+}
+
+// GetHistory returns the user's most recent reputation changes, newest first
+func (s *ReputationService) GetHistory(ctx context.Context, userID uuid.UUID, limit int) ([]*entities.ReputationChange, error) {
+	return s.repo.GetChanges(ctx, userID, limit)
+}
+
+// RecordReportConfirmed lowers the reputation of a user a confirmed report was about
+func (s *ReputationService) RecordReportConfirmed(ctx context.Context, userID, reportID uuid.UUID, moderatorID *uuid.UUID) (*entities.ReputationChange, error) {
+	return s.Adjust(ctx, userID, -s.config.ReputationReportPenalty, entities.ReputationReasonReportConfirmed, &reportID, moderatorID)
+}
+
+// RecordViolation lowers the reputation of a user whose content moderation removed
+func (s *ReputationService) RecordViolation(ctx context.Context, userID, contentID uuid.UUID) (*entities.ReputationChange, error) {
+	return s.Adjust(ctx, userID, -s.config.ReputationViolationPenalty, entities.ReputationReasonViolation, &contentID, nil)
+}
+
+// Adjust changes the user's reputation by delta, clamped between MinReputation and MaxReputation,
+// and records the change. It returns no change when reputation is disabled or the score is already
+// at the bound.
+func (s *ReputationService) Adjust(ctx context.Context, userID uuid.UUID, delta int, reason string, referenceID, actorID *uuid.UUID) (*entities.ReputationChange, error) {
+	if !s.config.ReputationEnabled || delta == 0 {
+		return nil, nil
+	}
+
+	for attempt := 0; attempt < s.maxAttempts; attempt++ {
+		previous, err := s.GetScore(ctx, userID)
+		if err != nil {
+			return nil, err
+		}
+
+		score := s.clamp(previous + delta)
+		if score == previous {
+			return nil, nil
+		}
+
+		change, err := s.apply(ctx, userID, previous, score, reason, referenceID, actorID)
+		if err != nil || change != nil {
+			return change, err
+		}
+		// The score changed since it was read, so the change is worked out again
+	}
+
+	return nil, ErrReputationConflict
+}
+
+// RecoverReputations gives ReputationRecoveryPoints back to users below InitialReputation whose
+// reputation did not change for ReputationRecoveryInterval, and returns how many recovered
+func (s *ReputationService) RecoverReputations(ctx context.Context) int {
+	points, interval := s.config.ReputationRecoveryPoints, s.config.ReputationRecoveryInterval
+	if !s.config.ReputationEnabled || points <= 0 || interval <= 0 {
+		return 0
+	}
+
+	reputations, err := s.repo.GetRecoverable(ctx, s.config.InitialReputation, s.now().Add(-interval), 100)
+	if err != nil {
+		logger.Error("Failed to get recoverable reputations", err)
+		return 0
+	}
+
+	recovered := 0
+	for _, reputation := range reputations {
+		score := reputation.Score + points
+		if score > s.config.InitialReputation {
+			score = s.config.InitialReputation
+		}
+
+		// A reputation that changed since it was read is left for the next poll
+		change, err := s.apply(ctx, reputation.UserID, reputation.Score, s.clamp(score), entities.ReputationReasonRecovery, nil, nil)
+		if err != nil {
+			logger.Error("Failed to recover reputation", err, "user_id", reputation.UserID)
+			continue
+		}
+		if change != nil {
+			recovered++
+		}
+	}
+	return recovered
+}
+
+// apply saves the change from the previous to the new score. It returns no change when the score
+// stays the same or changed since previous was read.
+func (s *ReputationService) apply(ctx context.Context, userID uuid.UUID, previous, score int, reason string, referenceID, actorID *uuid.UUID) (*entities.ReputationChange, error) {
+	if score == previous {
+		return nil, nil
+	}
+
+	change := &entities.ReputationChange{
+		UserID:        userID,
+		Delta:         score - previous,
+		PreviousScore: previous,
+		NewScore:      score,
+		Reason:        reason,
+		ReferenceID:   referenceID,
+		ActorID:       actorID,
+		CreatedAt:     s.now(),
+	}
+	applied, err := s.repo.ApplyChange(ctx, change, s.config.InitialReputation)
+	if err != nil || !applied {
+		return nil, err
+	}
+
+	logger.Info("Reputation changed", "user_id", userID, "reason", reason, "previous_score", previous, "score", score)
+	s.suspendIfTooLow(ctx, change)
+	return change, nil
+}
+
+// suspendIfTooLow suspends a user whose reputation just dropped below ReputationSuspendThreshold.
+// Failures are logged so the change itself stands.
+func (s *ReputationService) suspendIfTooLow(ctx context.Context, change *entities.ReputationChange) {
+	threshold := s.config.ReputationSuspendThreshold
+	if !s.config.AutoSuspendEnabled || s.users == nil || threshold <= 0 {
+		return
+	}
+	if change.NewScore >= threshold || change.PreviousScore < threshold {
+		return
+	}
+
+	user, err := s.users.GetByID(ctx, change.UserID)
+	if err != nil {
+		logger.Error("Failed to get user to suspend for low reputation", err, "user_id", change.UserID)
+		return
+	}
+	if user == nil || !user.IsActive || user.IsBanned {
+		return
+	}
+
+	user.IsActive = false
+	if err := s.users.Update(ctx, user); err != nil {
+		logger.Error("Failed to suspend user for low reputation", err, "user_id", change.UserID)
+		return
+	}
+
+	logger.Warn("User suspended for low reputation", "user_id", change.UserID, "score", change.NewScore, "threshold", threshold)
+}
+
+// clamp keeps the score between MinReputation and MaxReputation
+func (s *ReputationService) clamp(score int) int {
+	if score < s.config.MinReputation {
+		return s.config.MinReputation
+	}
+	if s.config.MaxReputation > 0 && score > s.config.MaxReputation {
+		return s.config.MaxReputation
+	}
+	return score
+}
@@ -0,0 +1,273 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/22smeargle/winkr-backend/internal/domain/entities"
+	"github.com/22smeargle/winkr-backend/pkg/config"
+)
+
+// inMemoryReputationRepository is an in-memory ReputationRepository for tests
+type inMemoryReputationRepository struct {
+	reputations map[uuid.UUID]*entities.UserReputation
+	changes     []*entities.ReputationChange
+	// interfere changes the stored score before the next change is applied, as a concurrent change would
+	interfere func()
+}
+
+func newInMemoryReputationRepository() *inMemoryReputationRepository {
+	return &inMemoryReputationRepository{reputations: make(map[uuid.UUID]*entities.UserReputation)}
+}
+
+func (r *inMemoryReputationRepository) GetByUserID(ctx context.Context, userID uuid.UUID) (*entities.UserReputation, error) {
+	reputation, ok := r.reputations[userID]
+	if !ok {
+		return nil, nil
+	}
+	copied := *reputation
+	return &copied, nil
+}
+
+func (r *inMemoryReputationRepository) GetScores(ctx context.Context, userIDs []uuid.UUID) (map[uuid.UUID]int, error) {
+	scores := make(map[uuid.UUID]int)
+	for _, userID := range userIDs {
+		if reputation, ok := r.reputations[userID]; ok {
+			scores[userID] = reputation.Score
+		}
+	}
+	return scores, nil
+}
+
+func (r *inMemoryReputationRepository) ApplyChange(ctx context.Context, change *entities.ReputationChange, initialScore int) (bool, error) {
+	if r.interfere != nil {
+		r.interfere()
+		r.interfere = nil
+	}
+
+	current := initialScore
+	if reputation, ok := r.reputations[change.UserID]; ok {
+		current = reputation.Score
+	}
+	if current != change.PreviousScore {
+		return false, nil
+	}
+
+	changedAt := change.CreatedAt
+	r.reputations[change.UserID] = &entities.UserReputation{UserID: change.UserID, Score: change.NewScore, LastScoreChange: &changedAt}
+	change.ID = uuid.New()
+	r.changes = append(r.changes, change)
+	return true, nil
+}
+
+func (r *inMemoryReputationRepository) IncrementReportsReceived(ctx context.Context, userID uuid.UUID, initialScore int) (int, error) {
+	reputation, ok := r.reputations[userID]
+	if !ok {
+		reputation = &entities.UserReputation{UserID: userID, Score: initialScore}
+		r.reputations[userID] = reputation
+	}
+	reputation.ReportsReceived++
+	return reputation.ReportsReceived, nil
+}
+
+func (r *inMemoryReputationRepository) GetRecoverable(ctx context.Context, below int, unchangedSince time.Time, limit int) ([]*entities.UserReputation, error) {
+	var reputations []*entities.UserReputation
+	for _, reputation := range r.reputations {
+		if reputation.Score < below && reputation.LastScoreChange.Before(unchangedSince) && len(reputations) < limit {
+			copied := *reputation
+			reputations = append(reputations, &copied)
+		}
+	}
+	return reputations, nil
+}
+
+func (r *inMemoryReputationRepository) GetChanges(ctx context.Context, userID uuid.UUID, limit int) ([]*entities.ReputationChange, error) {
+	var changes []*entities.ReputationChange
+	for i := len(r.changes) - 1; i >= 0 && len(changes) < limit; i-- {
+		if r.changes[i].UserID == userID {
+			changes = append(changes, r.changes[i])
+		}
+	}
+	return changes, nil
+}
+
+// inMemoryReputationUsers is an in-memory ReputationUserStore for tests
+type inMemoryReputationUsers map[uuid.UUID]*entities.User
+
+func (u inMemoryReputationUsers) GetByID(ctx context.Context, id uuid.UUID) (*entities.User, error) {
+	user, ok := u[id]
+	if !ok {
+		return nil, errors.New("user not found")
+	}
+	return user, nil
+}
+
+func (u inMemoryReputationUsers) Update(ctx context.Context, user *entities.User) error {
+	u[user.ID] = user
+	return nil
+}
+
+func newTestReputationService() (*ReputationService, *inMemoryReputationRepository, inMemoryReputationUsers) {
+	repo := newInMemoryReputationRepository()
+	users := make(inMemoryReputationUsers)
+	service := NewReputationService(repo, users, &config.ModerationRulesConfig{
+		AutoSuspendEnabled:           true,
+		ReputationEnabled:            true,
+		InitialReputation:            100,
+		MinReputation:                0,
+		MaxReputation:                1000,
+		ReputationReportPenalty:      10,
+		ReputationViolationPenalty:   25,
+		ReputationRecoveryPoints:     5,
+		ReputationRecoveryInterval:   7 * 24 * time.Hour,
+		ReputationSuspendThreshold:   25,
+		ReputationDiscoveryThreshold: 50,
+	})
+	return service, repo, users
+}
+
+func TestReputationService_PenalizesAndRecordsChanges(t *testing.T) {
+	service, _, _ := newTestReputationService()
+	ctx := context.Background()
+	userID, reportID, photoID, moderatorID := uuid.New(), uuid.New(), uuid.New(), uuid.New()
+
+	score, err := service.GetScore(ctx, userID)
+	require.NoError(t, err)
+	assert.Equal(t, 100, score)
+
+	change, err := service.RecordReportConfirmed(ctx, userID, reportID, &moderatorID)
+	require.NoError(t, err)
+	assert.Equal(t, -10, change.Delta)
+	assert.Equal(t, 90, change.NewScore)
+	assert.Equal(t, reportID, *change.ReferenceID)
+	assert.Equal(t, moderatorID, *change.ActorID)
+
+	_, err = service.RecordViolation(ctx, userID, photoID)
+	require.NoError(t, err)
+
+	score, err = service.GetScore(ctx, userID)
+	require.NoError(t, err)
+	assert.Equal(t, 65, score)
+
+	history, err := service.GetHistory(ctx, userID, 10)
+	require.NoError(t, err)
+	require.Len(t, history, 2)
+	assert.Equal(t, entities.ReputationReasonViolation, history[0].Reason)
+	assert.Equal(t, 90, history[0].PreviousScore)
+	assert.Equal(t, entities.ReputationReasonReportConfirmed, history[1].Reason)
+}
+
+func TestReputationService_ClampsScores(t *testing.T) {
+	service, repo, _ := newTestReputationService()
+	ctx := context.Background()
+	userID := uuid.New()
+
+	change, err := service.Adjust(ctx, userID, -500, entities.ReputationReasonAdjustment, nil, nil)
+	require.NoError(t, err)
+	assert.Equal(t, 0, change.NewScore)
+	assert.Equal(t, -100, change.Delta)
+
+	// Already at the minimum, so nothing changes and nothing is recorded
+	change, err = service.Adjust(ctx, userID, -10, entities.ReputationReasonAdjustment, nil, nil)
+	require.NoError(t, err)
+	assert.Nil(t, change)
+	assert.Len(t, repo.changes, 1)
+
+	change, err = service.Adjust(ctx, userID, 5000, entities.ReputationReasonAdjustment, nil, nil)
+	require.NoError(t, err)
+	assert.Equal(t, 1000, change.NewScore)
+}
+
+func TestReputationService_RetriesConcurrentChanges(t *testing.T) {
+	service, repo, _ := newTestReputationService()
+	ctx := context.Background()
+	userID := uuid.New()
+
+	repo.interfere = func() {
+		repo.reputations[userID] = &entities.UserReputation{UserID: userID, Score: 80}
+	}
+
+	change, err := service.RecordReportConfirmed(ctx, userID, uuid.New(), nil)
+	require.NoError(t, err)
+	assert.Equal(t, 80, change.PreviousScore)
+	assert.Equal(t, 70, change.NewScore)
+}
+
+func TestReputationService_SuspendsBelowThreshold(t *testing.T) {
+	service, _, users := newTestReputationService()
+	ctx := context.Background()
+	user := &entities.User{ID: uuid.New(), IsActive: true}
+	users[user.ID] = user
+
+	for i := 0; i < 3; i++ {
+		_, err := service.RecordViolation(ctx, user.ID, uuid.New())
+		require.NoError(t, err)
+	}
+	assert.True(t, user.IsActive, "score of 25 is not below the threshold")
+
+	_, err := service.RecordReportConfirmed(ctx, user.ID, uuid.New(), nil)
+	require.NoError(t, err)
+	assert.False(t, user.IsActive)
+	assert.False(t, user.IsBanned)
+
+	// Users are only suspended when they cross the threshold
+	user.IsActive = true
+	_, err = service.RecordReportConfirmed(ctx, user.ID, uuid.New(), nil)
+	require.NoError(t, err)
+	assert.True(t, user.IsActive)
+
+	service.config.AutoSuspendEnabled = false
+	other := &entities.User{ID: uuid.New(), IsActive: true}
+	users[other.ID] = other
+	_, err = service.Adjust(ctx, other.ID, -90, entities.ReputationReasonAdjustment, nil, nil)
+	require.NoError(t, err)
+	assert.True(t, other.IsActive)
+}
+
+func TestReputationService_RecoversUpToInitialReputation(t *testing.T) {
+	service, repo, _ := newTestReputationService()
+	ctx := context.Background()
+	now := time.Now()
+	longAgo := now.Add(-8 * 24 * time.Hour)
+	recently := now.Add(-time.Hour)
+
+	recovering, nearlyRecovered, penalizedRecently := uuid.New(), uuid.New(), uuid.New()
+	repo.reputations[recovering] = &entities.UserReputation{UserID: recovering, Score: 60, LastScoreChange: &longAgo}
+	repo.reputations[nearlyRecovered] = &entities.UserReputation{UserID: nearlyRecovered, Score: 98, LastScoreChange: &longAgo}
+	repo.reputations[penalizedRecently] = &entities.UserReputation{UserID: penalizedRecently, Score: 60, LastScoreChange: &recently}
+
+	assert.Equal(t, 2, service.RecoverReputations(ctx))
+	assert.Equal(t, 65, repo.reputations[recovering].Score)
+	assert.Equal(t, 100, repo.reputations[nearlyRecovered].Score)
+	assert.Equal(t, 60, repo.reputations[penalizedRecently].Score)
+
+	// Each recovery starts a new interval
+	assert.Zero(t, service.RecoverReputations(ctx))
+
+	service.now = func() time.Time { return now.Add(8 * 24 * time.Hour) }
+	assert.Equal(t, 2, service.RecoverReputations(ctx))
+	assert.Equal(t, 70, repo.reputations[recovering].Score)
+	assert.Equal(t, 65, repo.reputations[penalizedRecently].Score)
+}
+
+func TestReputationService_LowReputationUserIDs(t *testing.T) {
+	service, repo, _ := newTestReputationService()
+	ctx := context.Background()
+	low, borderline, unscored := uuid.New(), uuid.New(), uuid.New()
+	repo.reputations[low] = &entities.UserReputation{UserID: low, Score: 49}
+	repo.reputations[borderline] = &entities.UserReputation{UserID: borderline, Score: 50}
+
+	ids, err := service.LowReputationUserIDs(ctx, []uuid.UUID{low, borderline, unscored})
+	require.NoError(t, err)
+	assert.Equal(t, map[uuid.UUID]bool{low: true}, ids)
+
+	service.config.ReputationEnabled = false
+	ids, err = service.LowReputationUserIDs(ctx, []uuid.UUID{low})
+	require.NoError(t, err)
+	assert.Empty(t, ids)
+}
@@ -0,0 +1,253 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/22smeargle/winkr-backend/internal/domain/entities"
+	"github.com/22smeargle/winkr-backend/internal/domain/repositories"
+	"github.com/22smeargle/winkr-backend/pkg/config"
+	"github.com/22smeargle/winkr-backend/pkg/errors"
+	"github.com/22smeargle/winkr-backend/pkg/logger"
+)
+
+// missedCheckInBatchSize is how many missed check-ins are alerted per run
+const missedCheckInBatchSize = 100
+
+// SafetyCheckInNotifier delivers the alerts of missed check-ins
+type SafetyCheckInNotifier interface {
+	// NotifyUserMissed alerts the user by push and email
+	NotifyUserMissed(ctx context.Context, user *entities.User, checkIn *entities.SafetyCheckIn) error
+	// NotifyContactMissed alerts the user's trusted contact
+	NotifyContactMissed(ctx context.Context, user *entities.User, checkIn *entities.SafetyCheckIn) error
+	// NotifyContactSafe tells a trusted contact who was alerted that the user checked in after all
+	NotifyContactSafe(ctx context.Context, user *entities.User, checkIn *entities.SafetyCheckIn) error
+}
+
+// ScheduleCheckInInput is what a user provides to schedule a check-in
+type ScheduleCheckInInput struct {
+	DueAt        time.Time
+	Note         string
+	ContactName  string
+	ContactEmail string
+	AlertContact bool // The user consents to alerting the contact if they miss the check-in
+}
+
+// SafetyCheckInService lets users schedule a check-in before meeting someone. A check-in that is
+// not confirmed by its due time plus the grace period alerts the user and, if they consented,
+// their trusted contact.
+type SafetyCheckInService struct {
+	checkInRepo repositories.SafetyCheckInRepository
+	userRepo    repositories.UserRepository
+	notifier    SafetyCheckInNotifier
+	config      *config.SafetyCheckInConfig
+	now         func() time.Time
+}
+
+// NewSafetyCheckInService creates a new SafetyCheckInService
+func NewSafetyCheckInService(
+	checkInRepo repositories.SafetyCheckInRepository,
+	userRepo repositories.UserRepository,
+	notifier SafetyCheckInNotifier,
+	cfg *config.SafetyCheckInConfig,
+) *SafetyCheckInService {
+	return &SafetyCheckInService{
+		checkInRepo: checkInRepo,
+		userRepo:    userRepo,
+		notifier:    notifier,
+		config:      cfg,
+		now:         time.Now,
+	}
+}
+
+// Schedule schedules a check-in for a user
+func (s *SafetyCheckInService) Schedule(ctx context.Context, userID uuid.UUID, input ScheduleCheckInInput) (*entities.SafetyCheckIn, error) {
+	if !s.config.Enabled {
+		return nil, errors.NewAppError(errors.ErrServiceUnavailable.Code, errors.ErrServiceUnavailable.Message, "Safety check-ins are not available")
+	}
+
+	now := s.now()
+	if input.DueAt.Before(now.Add(s.config.MinLeadTime)) {
+		return nil, errors.NewValidationError("due_at", fmt.Sprintf("Check-in must be due at least %s from now", s.config.MinLeadTime))
+	}
+	if s.config.MaxLeadTime > 0 && input.DueAt.After(now.Add(s.config.MaxLeadTime)) {
+		return nil, errors.NewValidationError("due_at", fmt.Sprintf("Check-in must be due within %s from now", s.config.MaxLeadTime))
+	}
+
+	checkIn := &entities.SafetyCheckIn{
+		UserID: userID,
+		DueAt:  input.DueAt.UTC(),
+		Status: entities.SafetyCheckInStatusScheduled,
+	}
+	if note := strings.TrimSpace(input.Note); note != "" {
+		checkIn.Note = &note
+	}
+
+	// A contact's details are only kept when the user agreed to have them alerted
+	name := strings.TrimSpace(input.ContactName)
+	email := strings.TrimSpace(input.ContactEmail)
+	if (name != "" || email != "") && !input.AlertContact {
+		return nil, errors.NewValidationError("trusted_contact", "Consent is required to alert a trusted contact")
+	}
+	if input.AlertContact {
+		if name == "" || email == "" {
+			return nil, errors.NewValidationError("trusted_contact", "A name and email are required to alert a trusted contact")
+		}
+		checkIn.ContactName = &name
+		checkIn.ContactEmail = &email
+		checkIn.ContactConsentAt = &now
+	}
+
+	if s.config.MaxScheduled > 0 {
+		count, err := s.checkInRepo.CountScheduledByUserID(ctx, userID)
+		if err != nil {
+			return nil, errors.WrapError(err, "Failed to count check-ins")
+		}
+		if count >= int64(s.config.MaxScheduled) {
+			return nil, errors.NewAppError(errors.ErrInvalidOperation.Code, errors.ErrInvalidOperation.Message,
+				fmt.Sprintf("You can have up to %d check-ins scheduled at once", s.config.MaxScheduled))
+		}
+	}
+
+	if err := s.checkInRepo.Create(ctx, checkIn); err != nil {
+		return nil, errors.WrapError(err, "Failed to schedule check-in")
+	}
+
+	logger.Info("Safety check-in scheduled",
+		"user_id", userID,
+		"check_in_id", checkIn.ID,
+		"due_at", checkIn.DueAt,
+		"alert_contact", input.AlertContact,
+	)
+
+	return checkIn, nil
+}
+
+// Confirm marks a user's check-in as safe. A missed check-in can still be confirmed, in which case
+// a trusted contact who was already alerted is told the user is safe. Confirming twice is a no-op.
+func (s *SafetyCheckInService) Confirm(ctx context.Context, userID, checkInID uuid.UUID) (*entities.SafetyCheckIn, error) {
+	checkIn, err := s.checkInRepo.GetByID(ctx, checkInID)
+	if err != nil || checkIn.UserID != userID {
+		return nil, errors.NewNotFoundError("Check-in")
+	}
+	if checkIn.Status == entities.SafetyCheckInStatusConfirmed {
+		return checkIn, nil
+	}
+
+	now := s.now()
+	confirmed, err := s.checkInRepo.MarkConfirmed(ctx, checkIn.ID, now)
+	if err != nil {
+		return nil, errors.WrapError(err, "Failed to confirm check-in")
+	}
+	if !confirmed {
+		// Confirmed concurrently
+		return s.checkInRepo.GetByID(ctx, checkIn.ID)
+	}
+
+	wasMissed := checkIn.Status == entities.SafetyCheckInStatusMissed
+	checkIn.Status = entities.SafetyCheckInStatusConfirmed
+	checkIn.ConfirmedAt = &now
+
+	logger.Info("Safety check-in confirmed", "user_id", userID, "check_in_id", checkIn.ID, "late", wasMissed)
+
+	if wasMissed && checkIn.ContactAlertedAt != nil {
+		if user, err := s.userRepo.GetByID(ctx, userID); err == nil {
+			if err := s.notifier.NotifyContactSafe(ctx, user, checkIn); err != nil {
+				logger.Error("Failed to tell trusted contact the user is safe", err, "check_in_id", checkIn.ID)
+			}
+		}
+	}
+
+	return checkIn, nil
+}
+
+// AlertMissedCheckIns marks check-ins that were not confirmed within the grace period as missed
+// and sends their alerts. It returns how many check-ins were alerted.
+func (s *SafetyCheckInService) AlertMissedCheckIns(ctx context.Context) (int, error) {
+	now := s.now()
+	checkIns, err := s.checkInRepo.GetScheduledDueBefore(ctx, now.Add(-s.config.GracePeriod), missedCheckInBatchSize)
+	if err != nil {
+		return 0, fmt.Errorf("failed to get due check-ins: %w", err)
+	}
+
+	alerted := 0
+	for _, checkIn := range checkIns {
+		if !checkIn.IsOverdue(now, s.config.GracePeriod) {
+			continue
+		}
+
+		// Only the run that marks the check-in missed sends its alerts
+		missed, err := s.checkInRepo.MarkMissed(ctx, checkIn.ID, now)
+		if err != nil {
+			logger.Error("Failed to mark check-in missed", err, "check_in_id", checkIn.ID)
+			continue
+		}
+		if !missed {
+			continue
+		}
+		checkIn.Status = entities.SafetyCheckInStatusMissed
+		checkIn.MissedAt = &now
+
+		s.sendMissedAlerts(ctx, checkIn)
+		alerted++
+	}
+
+	if alerted > 0 {
+		logger.Info("Missed safety check-ins alerted", "count", alerted)
+	}
+
+	return alerted, nil
+}
+
+// sendMissedAlerts alerts the user and, if they consented, their trusted contact
+func (s *SafetyCheckInService) sendMissedAlerts(ctx context.Context, checkIn *entities.SafetyCheckIn) {
+	user, err := s.userRepo.GetByID(ctx, checkIn.UserID)
+	if err != nil {
+		logger.Error("Failed to get user of missed check-in", err, "check_in_id", checkIn.ID)
+		return
+	}
+
+	if err := s.notifier.NotifyUserMissed(ctx, user, checkIn); err != nil {
+		logger.Error("Failed to alert user of missed check-in", err, "check_in_id", checkIn.ID)
+	}
+
+	if !checkIn.CanAlertContact() {
+		return
+	}
+
+	if err := s.notifier.NotifyContactMissed(ctx, user, checkIn); err != nil {
+		logger.Error("Failed to alert trusted contact of missed check-in", err, "check_in_id", checkIn.ID)
+		return
+	}
+
+	alertedAt := s.now()
+	if err := s.checkInRepo.MarkContactAlerted(ctx, checkIn.ID, alertedAt); err != nil {
+		logger.Error("Failed to record trusted contact alert", err, "check_in_id", checkIn.ID)
+	}
+	checkIn.ContactAlertedAt = &alertedAt
+}
+
+// StartAlertScheduler periodically alerts missed check-ins
+func (s *SafetyCheckInService) StartAlertScheduler(ctx context.Context, interval time.Duration) {
+	logger.Info("Starting safety check-in alert scheduler", "interval", interval)
+
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ctx.Done():
+				logger.Info("Safety check-in alert scheduler stopped")
+				return
+			case <-ticker.C:
+				if _, err := s.AlertMissedCheckIns(ctx); err != nil {
+					logger.Error("Scheduled safety check-in alert run failed", err)
+				}
+			}
+		}
+	}()
+}
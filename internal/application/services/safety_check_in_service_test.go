@@ -0,0 +1,293 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/22smeargle/winkr-backend/internal/domain/entities"
+	"github.com/22smeargle/winkr-backend/internal/domain/repositories"
+	"github.com/22smeargle/winkr-backend/pkg/config"
+	"github.com/22smeargle/winkr-backend/pkg/errors"
+)
+
+// inMemorySafetyCheckInRepository is an in-memory SafetyCheckInRepository for tests
+type inMemorySafetyCheckInRepository struct {
+	checkIns map[uuid.UUID]*entities.SafetyCheckIn
+}
+
+func newInMemorySafetyCheckInRepository() *inMemorySafetyCheckInRepository {
+	return &inMemorySafetyCheckInRepository{checkIns: make(map[uuid.UUID]*entities.SafetyCheckIn)}
+}
+
+func (r *inMemorySafetyCheckInRepository) Create(ctx context.Context, checkIn *entities.SafetyCheckIn) error {
+	checkIn.ID = uuid.New()
+	copied := *checkIn
+	r.checkIns[checkIn.ID] = &copied
+	return nil
+}
+
+func (r *inMemorySafetyCheckInRepository) GetByID(ctx context.Context, id uuid.UUID) (*entities.SafetyCheckIn, error) {
+	checkIn, ok := r.checkIns[id]
+	if !ok {
+		return nil, fmt.Errorf("safety check-in not found")
+	}
+	copied := *checkIn
+	return &copied, nil
+}
+
+func (r *inMemorySafetyCheckInRepository) CountScheduledByUserID(ctx context.Context, userID uuid.UUID) (int64, error) {
+	var count int64
+	for _, checkIn := range r.checkIns {
+		if checkIn.UserID == userID && checkIn.Status == entities.SafetyCheckInStatusScheduled {
+			count++
+		}
+	}
+	return count, nil
+}
+
+func (r *inMemorySafetyCheckInRepository) GetScheduledDueBefore(ctx context.Context, before time.Time, limit int) ([]*entities.SafetyCheckIn, error) {
+	var checkIns []*entities.SafetyCheckIn
+	for _, checkIn := range r.checkIns {
+		if checkIn.Status == entities.SafetyCheckInStatusScheduled && checkIn.DueAt.Before(before) {
+			copied := *checkIn
+			checkIns = append(checkIns, &copied)
+		}
+	}
+	return checkIns, nil
+}
+
+func (r *inMemorySafetyCheckInRepository) MarkConfirmed(ctx context.Context, id uuid.UUID, confirmedAt time.Time) (bool, error) {
+	checkIn := r.checkIns[id]
+	if checkIn.Status == entities.SafetyCheckInStatusConfirmed {
+		return false, nil
+	}
+	checkIn.Status = entities.SafetyCheckInStatusConfirmed
+	checkIn.ConfirmedAt = &confirmedAt
+	return true, nil
+}
+
+func (r *inMemorySafetyCheckInRepository) MarkMissed(ctx context.Context, id uuid.UUID, missedAt time.Time) (bool, error) {
+	checkIn := r.checkIns[id]
+	if checkIn.Status != entities.SafetyCheckInStatusScheduled {
+		return false, nil
+	}
+	checkIn.Status = entities.SafetyCheckInStatusMissed
+	checkIn.MissedAt = &missedAt
+	return true, nil
+}
+
+func (r *inMemorySafetyCheckInRepository) MarkContactAlerted(ctx context.Context, id uuid.UUID, alertedAt time.Time) error {
+	r.checkIns[id].ContactAlertedAt = &alertedAt
+	return nil
+}
+
+// safetyCheckInUserRepository implements the user lookup used by safety check-ins
+type safetyCheckInUserRepository struct {
+	repositories.UserRepository
+	users map[uuid.UUID]*entities.User
+}
+
+func (r *safetyCheckInUserRepository) GetByID(ctx context.Context, id uuid.UUID) (*entities.User, error) {
+	user, ok := r.users[id]
+	if !ok {
+		return nil, fmt.Errorf("user not found")
+	}
+	return user, nil
+}
+
+// recordingSafetyNotifier records every alert sent
+type recordingSafetyNotifier struct {
+	userAlerts    []uuid.UUID
+	contactAlerts []string
+	contactSafe   []string
+}
+
+func (n *recordingSafetyNotifier) NotifyUserMissed(ctx context.Context, user *entities.User, checkIn *entities.SafetyCheckIn) error {
+	n.userAlerts = append(n.userAlerts, user.ID)
+	return nil
+}
+
+func (n *recordingSafetyNotifier) NotifyContactMissed(ctx context.Context, user *entities.User, checkIn *entities.SafetyCheckIn) error {
+	n.contactAlerts = append(n.contactAlerts, *checkIn.ContactEmail)
+	return nil
+}
+
+func (n *recordingSafetyNotifier) NotifyContactSafe(ctx context.Context, user *entities.User, checkIn *entities.SafetyCheckIn) error {
+	n.contactSafe = append(n.contactSafe, *checkIn.ContactEmail)
+	return nil
+}
+
+type safetyCheckInFixture struct {
+	service  *SafetyCheckInService
+	repo     *inMemorySafetyCheckInRepository
+	notifier *recordingSafetyNotifier
+	now      *time.Time
+	userID   uuid.UUID
+}
+
+func newSafetyCheckInFixture() *safetyCheckInFixture {
+	now := time.Date(2026, 10, 17, 19, 0, 0, 0, time.UTC)
+	user := &entities.User{ID: uuid.New(), FirstName: "Ana", Email: "ana@example.com"}
+
+	f := &safetyCheckInFixture{
+		repo:     newInMemorySafetyCheckInRepository(),
+		notifier: &recordingSafetyNotifier{},
+		now:      &now,
+		userID:   user.ID,
+	}
+	f.service = NewSafetyCheckInService(
+		f.repo,
+		&safetyCheckInUserRepository{users: map[uuid.UUID]*entities.User{user.ID: user}},
+		f.notifier,
+		&config.SafetyCheckInConfig{
+			Enabled:      true,
+			MinLeadTime:  15 * time.Minute,
+			MaxLeadTime:  24 * time.Hour,
+			GracePeriod:  10 * time.Minute,
+			MaxScheduled: 3,
+		},
+	)
+	f.service.now = func() time.Time { return *f.now }
+	return f
+}
+
+// advance moves the clock forward
+func (f *safetyCheckInFixture) advance(d time.Duration) {
+	*f.now = f.now.Add(d)
+}
+
+// scheduleWithContact schedules a check-in due in two hours with a consenting trusted contact
+func (f *safetyCheckInFixture) scheduleWithContact(t *testing.T) *entities.SafetyCheckIn {
+	t.Helper()
+	checkIn, err := f.service.Schedule(context.Background(), f.userID, ScheduleCheckInInput{
+		DueAt:        f.now.Add(2 * time.Hour),
+		Note:         "Dinner at Luigi's, Main St",
+		ContactName:  "Sam",
+		ContactEmail: "sam@example.com",
+		AlertContact: true,
+	})
+	require.NoError(t, err)
+	return checkIn
+}
+
+func TestSafetyCheckIn_MissedCheckInAlertsUserAndContact(t *testing.T) {
+	ctx := context.Background()
+	f := newSafetyCheckInFixture()
+	checkIn := f.scheduleWithContact(t)
+
+	// Not alerted while the grace period lasts
+	f.advance(2*time.Hour + 5*time.Minute)
+	alerted, err := f.service.AlertMissedCheckIns(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, 0, alerted)
+	assert.Empty(t, f.notifier.userAlerts)
+
+	f.advance(10 * time.Minute)
+	alerted, err = f.service.AlertMissedCheckIns(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, 1, alerted)
+	assert.Equal(t, []uuid.UUID{f.userID}, f.notifier.userAlerts)
+	assert.Equal(t, []string{"sam@example.com"}, f.notifier.contactAlerts)
+
+	stored := f.repo.checkIns[checkIn.ID]
+	assert.Equal(t, entities.SafetyCheckInStatusMissed, stored.Status)
+	assert.NotNil(t, stored.ContactAlertedAt)
+
+	// Alerts are sent once
+	alerted, err = f.service.AlertMissedCheckIns(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, 0, alerted)
+	assert.Len(t, f.notifier.userAlerts, 1)
+	assert.Len(t, f.notifier.contactAlerts, 1)
+}
+
+func TestSafetyCheckIn_ConfirmedCheckInDoesNotAlert(t *testing.T) {
+	ctx := context.Background()
+	f := newSafetyCheckInFixture()
+	checkIn := f.scheduleWithContact(t)
+
+	f.advance(time.Hour)
+	confirmed, err := f.service.Confirm(ctx, f.userID, checkIn.ID)
+	require.NoError(t, err)
+	assert.Equal(t, entities.SafetyCheckInStatusConfirmed, confirmed.Status)
+
+	f.advance(3 * time.Hour)
+	alerted, err := f.service.AlertMissedCheckIns(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, 0, alerted)
+	assert.Empty(t, f.notifier.userAlerts)
+	assert.Empty(t, f.notifier.contactAlerts)
+
+	// Confirming again is a no-op
+	_, err = f.service.Confirm(ctx, f.userID, checkIn.ID)
+	require.NoError(t, err)
+	assert.Empty(t, f.notifier.contactSafe)
+}
+
+func TestSafetyCheckIn_LateConfirmationTellsContactUserIsSafe(t *testing.T) {
+	ctx := context.Background()
+	f := newSafetyCheckInFixture()
+	checkIn := f.scheduleWithContact(t)
+
+	f.advance(3 * time.Hour)
+	_, err := f.service.AlertMissedCheckIns(ctx)
+	require.NoError(t, err)
+
+	confirmed, err := f.service.Confirm(ctx, f.userID, checkIn.ID)
+	require.NoError(t, err)
+	assert.Equal(t, entities.SafetyCheckInStatusConfirmed, confirmed.Status)
+	assert.Equal(t, []string{"sam@example.com"}, f.notifier.contactSafe)
+}
+
+func TestSafetyCheckIn_ContactIsOnlyAlertedWithConsent(t *testing.T) {
+	ctx := context.Background()
+	f := newSafetyCheckInFixture()
+
+	_, err := f.service.Schedule(ctx, f.userID, ScheduleCheckInInput{
+		DueAt:        f.now.Add(time.Hour),
+		ContactName:  "Sam",
+		ContactEmail: "sam@example.com",
+	})
+	require.Error(t, err)
+	assert.Equal(t, errors.ErrValidationFailed.Code, err.(*errors.AppError).Code)
+
+	checkIn, err := f.service.Schedule(ctx, f.userID, ScheduleCheckInInput{DueAt: f.now.Add(time.Hour)})
+	require.NoError(t, err)
+	assert.Nil(t, checkIn.ContactEmail)
+
+	f.advance(2 * time.Hour)
+	alerted, err := f.service.AlertMissedCheckIns(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, 1, alerted)
+	assert.Equal(t, []uuid.UUID{f.userID}, f.notifier.userAlerts)
+	assert.Empty(t, f.notifier.contactAlerts)
+}
+
+func TestSafetyCheckIn_ScheduleValidatesDueTimeAndLimit(t *testing.T) {
+	ctx := context.Background()
+	f := newSafetyCheckInFixture()
+
+	_, err := f.service.Schedule(ctx, f.userID, ScheduleCheckInInput{DueAt: f.now.Add(5 * time.Minute)})
+	assert.Error(t, err, "due too soon")
+	_, err = f.service.Schedule(ctx, f.userID, ScheduleCheckInInput{DueAt: f.now.Add(48 * time.Hour)})
+	assert.Error(t, err, "due too far ahead")
+
+	checkIn := f.scheduleWithContact(t)
+	for i := 0; i < 2; i++ {
+		_, err := f.service.Schedule(ctx, f.userID, ScheduleCheckInInput{DueAt: f.now.Add(time.Hour)})
+		require.NoError(t, err)
+	}
+	_, err = f.service.Schedule(ctx, f.userID, ScheduleCheckInInput{DueAt: f.now.Add(time.Hour)})
+	require.Error(t, err)
+	assert.Equal(t, errors.ErrInvalidOperation.Code, err.(*errors.AppError).Code)
+
+	// Another user's check-in cannot be confirmed
+	_, err = f.service.Confirm(ctx, uuid.New(), checkIn.ID)
+	require.Error(t, err)
+	assert.Equal(t, errors.ErrNotFound.Code, err.(*errors.AppError).Code)
+}
@@ -0,0 +1,65 @@
+package services
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/22smeargle/winkr-backend/internal/domain/entities"
+	"github.com/22smeargle/winkr-backend/internal/domain/repositories"
+	"github.com/22smeargle/winkr-backend/pkg/logger"
+)
+
+// ShadowbanService keeps the messages of shadowbanned users from reaching anyone else. Shadowbanned
+// users are not told: they keep seeing their own messages as sent.
+type ShadowbanService struct {
+	userRepo repositories.UserRepository
+}
+
+// NewShadowbanService creates a new ShadowbanService
+func NewShadowbanService(userRepo repositories.UserRepository) *ShadowbanService {
+	return &ShadowbanService{
+		userRepo: userRepo,
+	}
+}
+
+// IsShadowbanned reports whether the user is shadowbanned. A user who cannot be loaded counts as
+// not shadowbanned, so a lookup failure never hides anyone's messages.
+func (s *ShadowbanService) IsShadowbanned(ctx context.Context, userID uuid.UUID) bool {
+	if s == nil {
+		return false
+	}
+
+	user, err := s.userRepo.GetByID(ctx, userID)
+	if err != nil {
+		logger.Warn("Failed to get user for shadowban check", "user_id", userID, "error", err)
+		return false
+	}
+
+	return user.Shadowbanned
+}
+
+// VisibleMessages returns the messages the viewer may see, leaving out those sent by other users who
+// are shadowbanned. Each sender is looked up once.
+func (s *ShadowbanService) VisibleMessages(ctx context.Context, messages []*entities.Message, viewerID uuid.UUID) []*entities.Message {
+	if s == nil || len(messages) == 0 {
+		return messages
+	}
+
+	shadowbanned := make(map[uuid.UUID]bool)
+	visible := make([]*entities.Message, 0, len(messages))
+	for _, message := range messages {
+		if message.SenderID != viewerID {
+			hidden, checked := shadowbanned[message.SenderID]
+			if !checked {
+				hidden = s.IsShadowbanned(ctx, message.SenderID)
+				shadowbanned[message.SenderID] = hidden
+			}
+			if hidden {
+				continue
+			}
+		}
+		visible = append(visible, message)
+	}
+
+	return visible
+}
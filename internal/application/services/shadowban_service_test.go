@@ -0,0 +1,59 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/22smeargle/winkr-backend/internal/domain/entities"
+	"github.com/22smeargle/winkr-backend/internal/domain/repositories"
+)
+
+// shadowbanUserRepository counts the user lookups of the shadowban checks
+type shadowbanUserRepository struct {
+	repositories.UserRepository
+	users   map[uuid.UUID]*entities.User
+	lookups int
+}
+
+func (r *shadowbanUserRepository) GetByID(ctx context.Context, id uuid.UUID) (*entities.User, error) {
+	r.lookups++
+	user, ok := r.users[id]
+	if !ok {
+		return nil, fmt.Errorf("user not found")
+	}
+	return user, nil
+}
+
+func TestShadowbanService_VisibleMessages(t *testing.T) {
+	viewer := &entities.User{ID: uuid.New()}
+	partner := &entities.User{ID: uuid.New()}
+	banned := &entities.User{ID: uuid.New(), Shadowbanned: true}
+	users := &shadowbanUserRepository{users: map[uuid.UUID]*entities.User{
+		viewer.ID:  viewer,
+		partner.ID: partner,
+		banned.ID:  banned,
+	}}
+	service := NewShadowbanService(users)
+
+	unknownSender := uuid.New()
+	messages := []*entities.Message{
+		{ID: uuid.New(), SenderID: banned.ID},
+		{ID: uuid.New(), SenderID: viewer.ID},
+		{ID: uuid.New(), SenderID: partner.ID},
+		{ID: uuid.New(), SenderID: banned.ID},
+		{ID: uuid.New(), SenderID: unknownSender},
+	}
+
+	visible := service.VisibleMessages(context.Background(), messages, viewer.ID)
+
+	// A sender who cannot be loaded is not hidden
+	assert.Equal(t, []*entities.Message{messages[1], messages[2], messages[4]}, visible)
+	assert.Equal(t, 3, users.lookups, "each other sender is looked up once")
+
+	// The shadowbanned user still sees their own messages
+	visible = service.VisibleMessages(context.Background(), messages, banned.ID)
+	assert.Equal(t, []*entities.Message{messages[0], messages[1], messages[2], messages[3], messages[4]}, visible)
+}
@@ -0,0 +1,32 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// SuperLikeQuotaStore defines the storage of the daily super like counters. They are kept apart
+// from the swipe counters, so a burst of likes never blocks super likes.
+type SuperLikeQuotaStore interface {
+	IncrementSuperLikeCount(ctx context.Context, key string, ttl time.Duration) (int64, error)
+	DecrementSuperLikeCount(ctx context.Context, key string) error
+}
+
+// SuperLikeQuotaKey returns the key counting the super likes a user used today
+func SuperLikeQuotaKey(userID uuid.UUID) string {
+	return fmt.Sprintf("superlike:quota:%s", userID)
+}
+
+// SuperLikeQuotaTTL returns how long a super like counter started now lives: a day window for
+// rolling limits, or until the next midnight in the user's location for local midnight resets
+func SuperLikeQuotaTTL(strategy ResetStrategy, location *time.Location, now time.Time) time.Duration {
+	if strategy != ResetLocalMidnight {
+		return 24 * time.Hour
+	}
+
+	local := now.In(location)
+	return nextLocalMidnight(local).Sub(local)
+}
@@ -0,0 +1,153 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/22smeargle/winkr-backend/internal/domain/entities"
+	"github.com/22smeargle/winkr-backend/pkg/config"
+)
+
+// PreferenceVectorStore defines the storage needed for swipe preference vectors
+type PreferenceVectorStore interface {
+	GetPreferenceVector(ctx context.Context, key string) (*PreferenceVector, error)
+	SetPreferenceVector(ctx context.Context, key string, vector *PreferenceVector, ttl time.Duration) error
+}
+
+// PreferenceVector holds what a user tends to like (positive weight) or pass (negative weight),
+// keyed by profile attribute such as "gender:female" or "age:25-29"
+type PreferenceVector struct {
+	UserID    uuid.UUID          `json:"user_id"`
+	Weights   map[string]float64 `json:"weights"`
+	Swipes    int                `json:"swipes"`
+	UpdatedAt time.Time          `json:"updated_at"`
+}
+
+// minPreferenceWeight is the magnitude below which a decayed weight is dropped
+const minPreferenceWeight = 0.001
+
+// SwipePreferenceService learns a per-user preference vector from swipe outcomes
+type SwipePreferenceService struct {
+	store  PreferenceVectorStore
+	config *config.MatchingPersonalizationConfig
+}
+
+// NewSwipePreferenceService creates a new SwipePreferenceService
+func NewSwipePreferenceService(store PreferenceVectorStore, cfg *config.MatchingPersonalizationConfig) *SwipePreferenceService {
+	return &SwipePreferenceService{
+		store:  store,
+		config: cfg,
+	}
+}
+
+// RecordSwipe updates the swiper's preference vector with the attributes of the swiped profile.
+// Existing weights decay first, then each attribute of the profile moves towards +1 on a like
+// or -1 on a pass by the configured learning rate.
+func (s *SwipePreferenceService) RecordSwipe(ctx context.Context, swiperID uuid.UUID, swiped *entities.User, isLike bool) error {
+	if !s.config.Enabled || swiped == nil {
+		return nil
+	}
+
+	vector := s.GetPreferenceVector(ctx, swiperID)
+
+	for feature, weight := range vector.Weights {
+		weight *= 1 - s.config.Decay
+		if math.Abs(weight) < minPreferenceWeight {
+			delete(vector.Weights, feature)
+			continue
+		}
+		vector.Weights[feature] = weight
+	}
+
+	target := -1.0
+	if isLike {
+		target = 1.0
+	}
+	for _, feature := range profileFeatures(swiped) {
+		weight := vector.Weights[feature]
+		vector.Weights[feature] = weight + s.config.LearningRate*(target-weight)
+	}
+
+	vector.Swipes++
+	vector.UpdatedAt = time.Now()
+
+	if err := s.store.SetPreferenceVector(ctx, preferenceVectorKey(swiperID), vector, s.config.VectorTTL); err != nil {
+		return fmt.Errorf("failed to save preference vector: %w", err)
+	}
+
+	return nil
+}
+
+// GetPreferenceVector returns the user's preference vector, or an empty one if none is stored yet
+func (s *SwipePreferenceService) GetPreferenceVector(ctx context.Context, userID uuid.UUID) *PreferenceVector {
+	vector, err := s.store.GetPreferenceVector(ctx, preferenceVectorKey(userID))
+	if err != nil || vector == nil {
+		// Cache miss: start learning from scratch
+		return &PreferenceVector{UserID: userID, Weights: make(map[string]float64)}
+	}
+	if vector.Weights == nil {
+		vector.Weights = make(map[string]float64)
+	}
+	return vector
+}
+
+// IsActive reports whether a vector has seen enough swipes to influence scoring
+func (s *SwipePreferenceService) IsActive(vector *PreferenceVector) bool {
+	return s.config.Enabled && vector != nil && vector.Swipes >= s.config.MinSwipes
+}
+
+// Weight returns the share of the compatibility score taken by the preference vector
+func (s *SwipePreferenceService) Weight() float64 {
+	return s.config.Weight
+}
+
+// Score rates how well a candidate fits the learned preferences (0-100), from 0 (always passed) to 100 (always liked).
+// Candidates with no learned attributes score a neutral 50.
+func (s *SwipePreferenceService) Score(vector *PreferenceVector, candidate *entities.User) float64 {
+	features := profileFeatures(candidate)
+	if vector == nil || len(features) == 0 {
+		return 50
+	}
+
+	var total float64
+	for _, feature := range features {
+		total += vector.Weights[feature]
+	}
+
+	return (total/float64(len(features)) + 1) * 50
+}
+
+// profileFeatures lists the attributes of a profile that preferences are learned over
+func profileFeatures(user *entities.User) []string {
+	features := []string{
+		"gender:" + strings.ToLower(user.Gender),
+		"age:" + ageBand(user.GetAge()),
+		fmt.Sprintf("verification:%d", user.VerificationLevel),
+	}
+
+	if user.Bio != nil && strings.TrimSpace(*user.Bio) != "" {
+		features = append(features, "bio:yes")
+	} else {
+		features = append(features, "bio:no")
+	}
+	if user.LocationCity != nil && *user.LocationCity != "" {
+		features = append(features, "city:"+strings.ToLower(strings.TrimSpace(*user.LocationCity)))
+	}
+
+	return features
+}
+
+// ageBand buckets an age into five-year bands, e.g. "25-29"
+func ageBand(age int) string {
+	start := age - age%5
+	return fmt.Sprintf("%d-%d", start, start+4)
+}
+
+// preferenceVectorKey returns the cache key of a user's preference vector
+func preferenceVectorKey(userID uuid.UUID) string {
+	return fmt.Sprintf("preference_vector:%s", userID.String())
+}
@@ -0,0 +1,147 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/22smeargle/winkr-backend/internal/domain/entities"
+	"github.com/22smeargle/winkr-backend/pkg/config"
+)
+
+// inMemoryPreferenceVectorStore is an in-memory PreferenceVectorStore for tests
+type inMemoryPreferenceVectorStore struct {
+	vectors map[string]*PreferenceVector
+	setErr  error
+}
+
+func newInMemoryPreferenceVectorStore() *inMemoryPreferenceVectorStore {
+	return &inMemoryPreferenceVectorStore{vectors: make(map[string]*PreferenceVector)}
+}
+
+func (s *inMemoryPreferenceVectorStore) GetPreferenceVector(ctx context.Context, key string) (*PreferenceVector, error) {
+	vector, ok := s.vectors[key]
+	if !ok {
+		return nil, errors.New("cache miss")
+	}
+	return vector, nil
+}
+
+func (s *inMemoryPreferenceVectorStore) SetPreferenceVector(ctx context.Context, key string, vector *PreferenceVector, ttl time.Duration) error {
+	if s.setErr != nil {
+		return s.setErr
+	}
+	s.vectors[key] = vector
+	return nil
+}
+
+func testPersonalizationConfig() *config.MatchingPersonalizationConfig {
+	return &config.MatchingPersonalizationConfig{
+		Enabled:      true,
+		LearningRate: 0.2,
+		Decay:        0.01,
+		Weight:       0.25,
+		MinSwipes:    3,
+		VectorTTL:    time.Hour,
+	}
+}
+
+func newTestCandidate(gender string, age int, city string) *entities.User {
+	return &entities.User{
+		ID:           uuid.New(),
+		Gender:       gender,
+		DateOfBirth:  time.Now().AddDate(-age, 0, -1),
+		IsActive:     true,
+		LocationCity: &city,
+	}
+}
+
+func TestSwipePreferenceService_LikedAttributeScoresHigher(t *testing.T) {
+	store := newInMemoryPreferenceVectorStore()
+	preferences := NewSwipePreferenceService(store, testPersonalizationConfig())
+	matching := NewMatchingAlgorithmService(nil, nil, nil, nil, preferences, nil, nil)
+	ctx := context.Background()
+	swiperID := uuid.New()
+	currentUser := &entities.User{ID: swiperID}
+
+	// Like several profiles that share a city, pass on profiles elsewhere
+	for i := 0; i < 4; i++ {
+		require.NoError(t, preferences.RecordSwipe(ctx, swiperID, newTestCandidate("female", 25+i*6, "Lisbon"), true))
+		require.NoError(t, preferences.RecordSwipe(ctx, swiperID, newTestCandidate("female", 25+i*6, "Porto"), false))
+	}
+
+	vector := preferences.GetPreferenceVector(ctx, swiperID)
+	assert.Equal(t, 8, vector.Swipes)
+	assert.Greater(t, vector.Weights["city:lisbon"], 0.0)
+	assert.Less(t, vector.Weights["city:porto"], 0.0)
+
+	// Otherwise identical candidates differ only by the learned attribute
+	liked := matching.calculateScore(currentUser, newTestCandidate("female", 30, "Lisbon"), vector)
+	passed := matching.calculateScore(currentUser, newTestCandidate("female", 30, "Porto"), vector)
+	unknown := matching.calculateScore(currentUser, newTestCandidate("female", 30, "Faro"), vector)
+
+	assert.Greater(t, liked.Score, unknown.Score)
+	assert.Greater(t, unknown.Score, passed.Score)
+	assert.Greater(t, liked.Personalization, 50.0)
+}
+
+func TestSwipePreferenceService_InactiveBelowMinSwipes(t *testing.T) {
+	store := newInMemoryPreferenceVectorStore()
+	preferences := NewSwipePreferenceService(store, testPersonalizationConfig())
+	matching := NewMatchingAlgorithmService(nil, nil, nil, nil, preferences, nil, nil)
+	ctx := context.Background()
+	swiperID := uuid.New()
+	currentUser := &entities.User{ID: swiperID}
+
+	require.NoError(t, preferences.RecordSwipe(ctx, swiperID, newTestCandidate("female", 30, "Lisbon"), true))
+	vector := preferences.GetPreferenceVector(ctx, swiperID)
+	assert.False(t, preferences.IsActive(vector))
+
+	liked := matching.calculateScore(currentUser, newTestCandidate("female", 30, "Lisbon"), vector)
+	other := matching.calculateScore(currentUser, newTestCandidate("female", 30, "Porto"), vector)
+
+	assert.Equal(t, other.Score, liked.Score)
+	assert.Equal(t, 50.0, liked.Personalization)
+}
+
+func TestSwipePreferenceService_DecayFadesOldPreferences(t *testing.T) {
+	cfg := testPersonalizationConfig()
+	cfg.Decay = 0.5
+	store := newInMemoryPreferenceVectorStore()
+	preferences := NewSwipePreferenceService(store, cfg)
+	ctx := context.Background()
+	swiperID := uuid.New()
+
+	require.NoError(t, preferences.RecordSwipe(ctx, swiperID, newTestCandidate("female", 30, "Lisbon"), true))
+	initial := preferences.GetPreferenceVector(ctx, swiperID).Weights["city:lisbon"]
+
+	require.NoError(t, preferences.RecordSwipe(ctx, swiperID, newTestCandidate("male", 40, "Porto"), true))
+	decayed := preferences.GetPreferenceVector(ctx, swiperID).Weights["city:lisbon"]
+
+	assert.InDelta(t, initial*0.5, decayed, 1e-9)
+}
+
+func TestSwipePreferenceService_Disabled(t *testing.T) {
+	cfg := testPersonalizationConfig()
+	cfg.Enabled = false
+	store := newInMemoryPreferenceVectorStore()
+	preferences := NewSwipePreferenceService(store, cfg)
+
+	require.NoError(t, preferences.RecordSwipe(context.Background(), uuid.New(), newTestCandidate("female", 30, "Lisbon"), true))
+
+	assert.Empty(t, store.vectors)
+}
+
+func TestSwipePreferenceService_StoreFailure(t *testing.T) {
+	store := newInMemoryPreferenceVectorStore()
+	store.setErr = errors.New("redis down")
+	preferences := NewSwipePreferenceService(store, testPersonalizationConfig())
+
+	err := preferences.RecordSwipe(context.Background(), uuid.New(), newTestCandidate("female", 30, "Lisbon"), true)
+
+	assert.Error(t, err)
+}
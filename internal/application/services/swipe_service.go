@@ -8,6 +8,7 @@ import (
 	"github.com/google/uuid"
 	"github.com/22smeargle/winkr-backend/internal/domain/entities"
 	"github.com/22smeargle/winkr-backend/internal/domain/repositories"
+	"github.com/22smeargle/winkr-backend/pkg/logger"
 )
 
 // SwipeService handles swipe operations
@@ -17,6 +18,8 @@ type SwipeService struct {
 	swipeRepo    repositories.SwipeRepository
 	cacheService CacheService
 	rateLimiter  RateLimiter
+	preferenceService *SwipePreferenceService
+	photoEngagementService *PhotoEngagementService
 }
 
 // NewSwipeService creates a new SwipeService
@@ -26,6 +29,8 @@ func NewSwipeService(
 	swipeRepo repositories.SwipeRepository,
 	cacheService CacheService,
 	rateLimiter RateLimiter,
+	preferenceService *SwipePreferenceService,
+	photoEngagementService *PhotoEngagementService,
 ) *SwipeService {
 	return &SwipeService{
 		userRepo:     userRepo,
@@ -33,6 +38,8 @@ func NewSwipeService(
 		swipeRepo:    swipeRepo,
 		cacheService: cacheService,
 		rateLimiter:  rateLimiter,
+		preferenceService: preferenceService,
+		photoEngagementService: photoEngagementService,
 	}
 }
 
@@ -48,39 +55,81 @@ func (s *SwipeService) CreateSwipe(ctx context.Context, swipe *entities.Swipe) e
 		return fmt.Errorf("swipe rate limit exceeded")
 	}
 
+	return s.storeSwipe(ctx, swipe)
+}
+
+// storeSwipe stores a swipe that passed its limits and records what follows from it
+func (s *SwipeService) storeSwipe(ctx context.Context, swipe *entities.Swipe) error {
 	// Create swipe
-	err = s.swipeRepo.CreateSwipe(ctx, swipe)
+	err := s.swipeRepo.CreateSwipe(ctx, swipe)
 	if err != nil {
 		return fmt.Errorf("failed to create swipe: %w", err)
 	}
 
+	s.recordStoredSwipe(ctx, swipe)
+
+	return nil
+}
+
+// RecordStoredSwipes records what follows from swipes stored together, e.g. from a batch of swipes
+// queued offline. The caller checks the limits and stores the swipes first.
+func (s *SwipeService) RecordStoredSwipes(ctx context.Context, swipes []*entities.Swipe) {
+	for _, swipe := range swipes {
+		s.recordStoredSwipe(ctx, swipe)
+	}
+}
+
+// recordStoredSwipe records what follows from a stored swipe
+func (s *SwipeService) recordStoredSwipe(ctx context.Context, swipe *entities.Swipe) {
 	// Update user's last active time
-	err = s.userRepo.UpdateLastActive(ctx, swipe.SwiperID)
+	err := s.userRepo.UpdateLastActive(ctx, swipe.SwiperID)
 	if err != nil {
 		// Log error but don't fail the operation
 		// This is a non-critical operation
 	}
 
+	// Feed the swipe back into the swiper's preference vector (non-critical)
+	s.recordSwipePreference(ctx, swipe)
+
+	// Credit the like to the photos the swiper was shown (non-critical)
+	s.recordPhotoLike(ctx, swipe)
+
 	// Invalidate relevant caches
 	s.invalidateSwipeCaches(ctx, swipe.SwiperID, swipe.SwipedID)
-
-	return nil
 }
 
-// CreateSuperLike creates a super like swipe with additional validation
-func (s *SwipeService) CreateSuperLike(ctx context.Context, swipe *entities.Swipe) error {
-	// Check rate limit for super likes
-	allowed, err := s.rateLimiter.AllowSuperLike(ctx, swipe.SwiperID)
+// recordSwipePreference updates the swiper's learned preferences with the swiped profile
+func (s *SwipeService) recordSwipePreference(ctx context.Context, swipe *entities.Swipe) {
+	if s.preferenceService == nil {
+		return
+	}
+
+	swiped, err := s.userRepo.GetByID(ctx, swipe.SwipedID)
 	if err != nil {
-		return fmt.Errorf("failed to check super like rate limit: %w", err)
+		logger.Warn("Failed to load swiped user for preference update", err, "swiped_id", swipe.SwipedID)
+		return
 	}
 
-	if !allowed {
-		return fmt.Errorf("daily super like limit exceeded")
+	if err := s.preferenceService.RecordSwipe(ctx, swipe.SwiperID, swiped, swipe.IsLike); err != nil {
+		logger.Warn("Failed to update swipe preferences", err, "swiper_id", swipe.SwiperID)
+	}
+}
+
+// recordPhotoLike credits a like to the swiped user's photos the swiper was shown
+func (s *SwipeService) recordPhotoLike(ctx context.Context, swipe *entities.Swipe) {
+	if s.photoEngagementService == nil || !swipe.IsLike {
+		return
+	}
+
+	if err := s.photoEngagementService.RecordLike(ctx, swipe.SwipedID, swipe.SwiperID); err != nil {
+		logger.Warn("Failed to record photo like", "swiped_id", swipe.SwipedID, "error", err)
 	}
+}
 
-	// Create the swipe
-	return s.CreateSwipe(ctx, swipe)
+// CreateSuperLike creates a super like swipe. Super likes are not counted against the swipe
+// limits: the caller checks them against the super like quota first.
+func (s *SwipeService) CreateSuperLike(ctx context.Context, swipe *entities.Swipe) error {
+	return s.storeSwipe(ctx, swipe)
 }
 
 // HasSwiped checks if user has already swiped on another user
@@ -147,11 +196,9 @@ func (s *SwipeService) GetSwipeStats(ctx context.Context, userID uuid.UUID) (*re
 	return stats, nil
 }
 
-// CheckSuperLikeLimit checks if user is within daily super like limit
-func (s *SwipeService) CheckSuperLikeLimit(ctx context.Context, userID uuid.UUID) (bool, error) {
-	// This would typically check against a daily counter in Redis or database
-	// For now, we'll use the rate limiter
-	return s.rateLimiter.AllowSuperLike(ctx, userID)
+// GetSwipeQuota gets the swipes and super likes a user has left today
+func (s *SwipeService) GetSwipeQuota(ctx context.Context, userID uuid.UUID) (*SwipeQuota, error) {
+	return s.rateLimiter.GetSwipeQuota(ctx, userID)
 }
 
 // AnalyzeSwipePattern analyzes swipe patterns for bot detection
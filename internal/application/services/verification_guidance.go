@@ -0,0 +1,90 @@
+package services
+
+import (
+	"strings"
+
+	"github.com/22smeargle/winkr-backend/internal/domain/entities"
+)
+
+// What went wrong with a failed verification, as told to the user
+const (
+	VerificationFailureNoFace        = "no_face"
+	VerificationFailureNotLive       = "not_live"
+	VerificationFailureInappropriate = "inappropriate"
+	VerificationFailureBlurry        = "blurry"
+	VerificationFailureLighting      = "lighting"
+	VerificationFailureUnclear       = "unclear"
+)
+
+// VerificationGuidance tells a user why their verification failed and how to pass next time.
+// It is derived from the rejection reason, which is never shown as is since review notes may
+// contain personal details.
+type VerificationGuidance struct {
+	Reason  string `json:"reason"`
+	Message string `json:"message"`
+}
+
+// NewVerificationGuidance returns the guidance for a verification rejected with the given reason
+func NewVerificationGuidance(vType entities.VerificationType, rejectionReason string) *VerificationGuidance {
+	reason := classifyVerificationFailure(vType, strings.ToLower(rejectionReason))
+	return &VerificationGuidance{
+		Reason:  reason,
+		Message: verificationGuidanceMessage(vType, reason),
+	}
+}
+
+// classifyVerificationFailure matches the reasons the AI checks give, and the usual wording of
+// reviewers, to what the user can do about them
+func classifyVerificationFailure(vType entities.VerificationType, reason string) string {
+	switch {
+	case strings.Contains(reason, "no face"):
+		return VerificationFailureNoFace
+	case strings.Contains(reason, "spoof"), strings.Contains(reason, "not live"):
+		return VerificationFailureNotLive
+	case strings.Contains(reason, "inappropriate"):
+		return VerificationFailureInappropriate
+	case strings.Contains(reason, "blur"), strings.Contains(reason, "focus"), strings.Contains(reason, "unreadable"):
+		return VerificationFailureBlurry
+	case strings.Contains(reason, "light"), strings.Contains(reason, "dark"), strings.Contains(reason, "glare"), strings.Contains(reason, "shadow"):
+		return VerificationFailureLighting
+	case strings.Contains(reason, "low confidence"):
+		// A document the AI could not read is usually out of focus, a selfie that did not match is usually badly lit
+		if vType == entities.VerificationTypeDocument {
+			return VerificationFailureBlurry
+		}
+		return VerificationFailureLighting
+	default:
+		return VerificationFailureUnclear
+	}
+}
+
+func verificationGuidanceMessage(vType entities.VerificationType, reason string) string {
+	document := vType == entities.VerificationTypeDocument
+
+	switch reason {
+	case VerificationFailureNoFace:
+		return "We couldn't find your face. Hold the camera at eye level with your whole face in the frame and nothing covering it."
+	case VerificationFailureNotLive:
+		return "Take the selfie live with your camera rather than photographing another photo or a screen."
+	case VerificationFailureInappropriate:
+		if document {
+			return "Photograph only your document against a plain background."
+		}
+		return "Take a selfie of just your face against a plain background."
+	case VerificationFailureBlurry:
+		if document {
+			return "Your document was blurry. Place it on a flat surface, hold your phone steady and make sure all the text is sharp."
+		}
+		return "Your selfie was blurry. Hold your phone steady and wait for the camera to focus before taking it."
+	case VerificationFailureLighting:
+		if document {
+			return "Your document was hard to read. Use even lighting and avoid glare or shadows across it."
+		}
+		return "Your selfie was too dark or unevenly lit. Face a window or a lamp and avoid bright light behind you."
+	default:
+		if document {
+			return "We couldn't verify your document. Make sure it is valid, fully in the frame and clearly readable."
+		}
+		return "We couldn't verify your selfie. Make sure it is a clear, well lit photo of your face."
+	}
+}
@@ -12,9 +12,61 @@ import (
 	"github.com/22smeargle/winkr-backend/internal/domain/repositories"
 	"github.com/22smeargle/winkr-backend/internal/domain/valueobjects"
 	"github.com/22smeargle/winkr-backend/internal/infrastructure/external"
+	"github.com/22smeargle/winkr-backend/pkg/config"
+	"github.com/22smeargle/winkr-backend/pkg/errors"
 	"github.com/22smeargle/winkr-backend/pkg/logger"
 )
 
+// VerificationStatusChangedEventType is published whenever a user's verification level changes
+const VerificationStatusChangedEventType = "verification.status_changed"
+
+// Sources of a verification level change
+const (
+	VerificationChangeSourceAIApproval    = "ai_approval"
+	VerificationChangeSourceManualReview  = "manual_review"
+	VerificationChangeSourceAdminOverride = "admin_override"
+	VerificationChangeSourceExpiry        = "expiry"
+)
+
+// Reasons given with a verification level change. They are fixed codes so free text
+// such as rejection notes, which may contain personal details, never leaves the service.
+const (
+	VerificationChangeReasonSelfieApproved   = "selfie_approved"
+	VerificationChangeReasonDocumentApproved = "document_approved"
+	VerificationChangeReasonReviewApproved   = "review_approved"
+	VerificationChangeReasonReviewRejected   = "review_rejected"
+	VerificationChangeReasonAdminOverride    = "admin_override"
+	VerificationChangeReasonBadgeExpired     = "badge_expired"
+)
+
+// VerificationStatusChangedEvent is sent to webhooks and analytics when a verification level changes.
+// It only identifies the user by ID and carries no photos, documents or review notes.
+type VerificationStatusChangedEvent struct {
+	UserID     uuid.UUID                  `json:"user_id"`
+	OldLevel   entities.VerificationLevel `json:"old_level"`
+	NewLevel   entities.VerificationLevel `json:"new_level"`
+	Source     string                     `json:"source"`
+	Reason     string                     `json:"reason"`
+	OccurredAt time.Time                  `json:"occurred_at"`
+}
+
+// VerificationEventPublisher delivers verification events to outbound webhooks and analytics
+type VerificationEventPublisher interface {
+	Publish(ctx context.Context, eventType string, data interface{}) error
+}
+
+// Why a verification request was turned away
+const (
+	VerificationCooldownReasonCooldown     = "cooldown"
+	VerificationCooldownReasonDailyLimit   = "daily_limit"
+	VerificationCooldownReasonMonthlyLimit = "monthly_limit"
+)
+
+// VerificationFailureNotifier tells users their verification failed and what to do differently
+type VerificationFailureNotifier interface {
+	NotifyVerificationFailed(ctx context.Context, userID uuid.UUID, vType entities.VerificationType, guidance *VerificationGuidance, retryAt time.Time) error
+}
+
 // VerificationWorkflowService handles verification workflow management
 type VerificationWorkflowService struct {
 	verificationRepo repositories.VerificationRepository
@@ -22,6 +74,10 @@ type VerificationWorkflowService struct {
 	aiService        external.AIService
 	documentService  *DocumentService
 	storageService   StorageService
+	limits           *config.VerificationLimitsConfig
+	failureNotifier  VerificationFailureNotifier
+	eventPublisher   VerificationEventPublisher
+	now              func() time.Time
 }
 
 // NewVerificationWorkflowService creates a new verification workflow service
@@ -31,6 +87,9 @@ func NewVerificationWorkflowService(
 	aiService external.AIService,
 	documentService *DocumentService,
 	storageService StorageService,
+	limits *config.VerificationLimitsConfig,
+	failureNotifier VerificationFailureNotifier,
+	eventPublisher VerificationEventPublisher,
 ) *VerificationWorkflowService {
 	return &VerificationWorkflowService{
 		verificationRepo: verificationRepo,
@@ -38,6 +97,10 @@ func NewVerificationWorkflowService(
 		aiService:        aiService,
 		documentService:  documentService,
 		storageService:   storageService,
+		limits:           limits,
+		failureNotifier:  failureNotifier,
+		eventPublisher:   eventPublisher,
+		now:              time.Now,
 	}
 }
 
@@ -83,15 +146,10 @@ func (vws *VerificationWorkflowService) RequestSelfieVerification(ctx context.Co
 		return nil, fmt.Errorf("selfie verification already in progress")
 	}
 
-	// Check verification attempt limits
-	attempts, err := vws.checkVerificationAttempts(ctx, userID, entities.VerificationTypeSelfie, ipAddress)
+	// Check the cooldown after a failure and the attempt limits
+	err = vws.checkVerificationAttempts(ctx, userID, entities.VerificationTypeSelfie, existing)
 	if err != nil {
-		return nil, fmt.Errorf("failed to check verification attempts: %w", err)
-	}
-
-	if len(attempts) >= DefaultVerificationConfig().MaxVerificationAttempts {
-		logger.Warn("Too many verification attempts", "user_id", userID, "attempts", len(attempts))
-		return nil, fmt.Errorf("too many verification attempts, please try again later")
+		return nil, err
 	}
 
 	// Create verification attempt record
@@ -168,6 +226,7 @@ func (vws *VerificationWorkflowService) SubmitSelfieVerification(ctx context.Con
 		reason := "AI processing failed"
 		verification.RejectionReason = &reason
 		vws.verificationRepo.UpdateVerification(ctx, verification)
+		vws.notifyVerificationFailed(ctx, verification)
 		return nil, fmt.Errorf("AI processing failed: %w", err)
 	}
 
@@ -178,6 +237,16 @@ func (vws *VerificationWorkflowService) SubmitSelfieVerification(ctx context.Con
 		return nil, fmt.Errorf("failed to update verification: %w", err)
 	}
 
+	err = vws.completeAIApproval(ctx, verification)
+	if err != nil {
+		logger.Error("Failed to complete AI approval", err, "verification_id", verificationID)
+		return nil, fmt.Errorf("failed to complete AI approval: %w", err)
+	}
+
+	if verification.Status.IsRejected() {
+		vws.notifyVerificationFailed(ctx, verification)
+	}
+
 	logger.Info("Selfie verification processed", "verification_id", verificationID, "status", verification.Status, "ai_score", verification.AIScore)
 	return verification, nil
 }
@@ -198,15 +267,10 @@ func (vws *VerificationWorkflowService) RequestDocumentVerification(ctx context.
 		return nil, fmt.Errorf("document verification already in progress")
 	}
 
-	// Check verification attempt limits
-	attempts, err := vws.checkVerificationAttempts(ctx, userID, entities.VerificationTypeDocument, ipAddress)
+	// Check the cooldown after a failure and the attempt limits
+	err = vws.checkVerificationAttempts(ctx, userID, entities.VerificationTypeDocument, existing)
 	if err != nil {
-		return nil, fmt.Errorf("failed to check verification attempts: %w", err)
-	}
-
-	if len(attempts) >= DefaultVerificationConfig().MaxVerificationAttempts {
-		logger.Warn("Too many verification attempts", "user_id", userID, "attempts", len(attempts))
-		return nil, fmt.Errorf("too many verification attempts, please try again later")
+		return nil, err
 	}
 
 	// Create verification attempt record
@@ -283,6 +347,7 @@ func (vws *VerificationWorkflowService) SubmitDocumentVerification(ctx context.C
 		reason := "Document processing failed"
 		verification.RejectionReason = &reason
 		vws.verificationRepo.UpdateVerification(ctx, verification)
+		vws.notifyVerificationFailed(ctx, verification)
 		return nil, fmt.Errorf("document processing failed: %w", err)
 	}
 
@@ -293,6 +358,16 @@ func (vws *VerificationWorkflowService) SubmitDocumentVerification(ctx context.C
 		return nil, fmt.Errorf("failed to update verification: %w", err)
 	}
 
+	err = vws.completeAIApproval(ctx, verification)
+	if err != nil {
+		logger.Error("Failed to complete AI approval", err, "verification_id", verificationID)
+		return nil, fmt.Errorf("failed to complete AI approval: %w", err)
+	}
+
+	if verification.Status.IsRejected() {
+		vws.notifyVerificationFailed(ctx, verification)
+	}
+
 	logger.Info("Document verification processed", "verification_id", verificationID, "status", verification.Status, "ai_score", verification.AIScore)
 	return verification, nil
 }
@@ -321,6 +396,14 @@ func (vws *VerificationWorkflowService) GetVerificationStatus(ctx context.Contex
 		result.HasVerification = true
 		result.LastVerification = verification
 		result.CanRequest = verification.Status.IsRejected() || verification.IsExpired()
+
+		if verification.Status.IsRejected() {
+			result.Guidance = NewVerificationGuidance(vType, rejectionReason(verification))
+			if retryAt := vws.cooldownEnd(verification); vws.now().Before(retryAt) {
+				result.CanRequest = false
+				result.RetryAt = &retryAt
+			}
+		}
 	}
 
 	// Get user's verification level
@@ -371,16 +454,92 @@ func (vws *VerificationWorkflowService) ProcessVerificationResult(ctx context.Co
 	}
 
 	// Update user verification level
-	err = vws.updateUserVerificationLevel(ctx, verification.UserID)
+	changeReason := VerificationChangeReasonReviewRejected
+	if approved {
+		changeReason = VerificationChangeReasonReviewApproved
+	}
+	err = vws.updateUserVerificationLevel(ctx, verification.UserID, VerificationChangeSourceManualReview, changeReason)
 	if err != nil {
 		logger.Error("Failed to update user verification level", err, "user_id", verification.UserID)
 		return fmt.Errorf("failed to update user verification level: %w", err)
 	}
 
+	if !approved {
+		vws.notifyVerificationFailed(ctx, verification)
+	}
+
 	logger.Info("Verification result processed", "verification_id", verificationID, "approved", approved, "status", verification.Status)
 	return nil
 }
 
+// OverrideVerificationLevel sets a user's verification level regardless of their badges (admin only)
+func (vws *VerificationWorkflowService) OverrideVerificationLevel(ctx context.Context, userID uuid.UUID, level entities.VerificationLevel, note string, adminID uuid.UUID) error {
+	logger.Info("Overriding user verification level", "user_id", userID, "level", level, "admin_id", adminID, "note", note)
+
+	if level < entities.VerificationLevelNone || level > entities.VerificationLevelDocument {
+		return fmt.Errorf("invalid verification level: %d", level)
+	}
+
+	err := vws.setUserVerificationLevel(ctx, userID, level, VerificationChangeSourceAdminOverride, VerificationChangeReasonAdminOverride)
+	if err != nil {
+		logger.Error("Failed to override user verification level", err, "user_id", userID)
+		return err
+	}
+
+	return nil
+}
+
+// DowngradeExpiredVerifications lowers the level of users whose badges expired and removes those badges.
+// It returns the number of users checked.
+func (vws *VerificationWorkflowService) DowngradeExpiredVerifications(ctx context.Context) (int, error) {
+	userIDs, err := vws.verificationRepo.GetUsersWithExpiredBadges(ctx, time.Now())
+	if err != nil {
+		logger.Error("Failed to get users with expired badges", err)
+		return 0, fmt.Errorf("failed to get users with expired badges: %w", err)
+	}
+
+	for _, userID := range userIDs {
+		err = vws.updateUserVerificationLevel(ctx, userID, VerificationChangeSourceExpiry, VerificationChangeReasonBadgeExpired)
+		if err != nil {
+			logger.Error("Failed to downgrade expired verification", err, "user_id", userID)
+			return 0, fmt.Errorf("failed to downgrade expired verification: %w", err)
+		}
+	}
+
+	err = vws.verificationRepo.DeleteExpiredBadges(ctx)
+	if err != nil {
+		logger.Error("Failed to delete expired badges", err)
+		return 0, fmt.Errorf("failed to delete expired badges: %w", err)
+	}
+
+	if len(userIDs) > 0 {
+		logger.Info("Expired verifications downgraded", "users", len(userIDs))
+	}
+	return len(userIDs), nil
+}
+
+// StartExpiryScheduler periodically downgrades users whose verification badges expired
+func (vws *VerificationWorkflowService) StartExpiryScheduler(ctx context.Context, interval time.Duration) {
+	logger.Info("Starting verification expiry scheduler", "interval", interval)
+
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ctx.Done():
+				logger.Info("Verification expiry scheduler stopped")
+				return
+			case <-ticker.C:
+				if _, err := vws.DowngradeExpiredVerifications(ctx); err != nil {
+					logger.Error("Scheduled verification expiry check failed", err)
+				}
+			}
+		}
+	}()
+}
+
 // GetPendingVerifications gets verifications pending review
 func (vws *VerificationWorkflowService) GetPendingVerifications(ctx context.Context, limit, offset int) ([]*entities.Verification, error) {
 	logger.Info("Getting pending verifications", "limit", limit, "offset", offset)
@@ -395,11 +554,312 @@ func (vws *VerificationWorkflowService) GetPendingVerifications(ctx context.Cont
 	return verifications, nil
 }
 
+// DocumentSubmission represents a single document submitted within a verification session
+type DocumentSubmission struct {
+	DocumentType string `json:"document_type" validate:"required"`
+	PhotoKey     string `json:"photo_key" validate:"required"`
+	PhotoURL     string `json:"photo_url" validate:"required"`
+}
+
+// DocumentSessionResult represents the outcome of a multi-document verification session
+type DocumentSessionResult struct {
+	Verification      *entities.Verification           `json:"verification"`
+	Documents         []*entities.VerificationDocument `json:"documents"`
+	RequiredDocuments []string                         `json:"required_documents"`
+	MissingDocuments  []string                         `json:"missing_documents,omitempty"`
+}
+
+// SubmitDocumentSession processes several documents submitted for one document verification.
+// Each document is validated and scored on its own; the session is approved only when every
+// document required for the region has passed.
+func (vws *VerificationWorkflowService) SubmitDocumentSession(ctx context.Context, verificationID uuid.UUID, region string, submissions []DocumentSubmission) (*DocumentSessionResult, error) {
+	logger.Info("Processing document verification session", "verification_id", verificationID, "region", region, "documents", len(submissions))
+
+	if len(submissions) == 0 {
+		return nil, fmt.Errorf("at least one document is required")
+	}
+
+	// Get verification record
+	verification, err := vws.verificationRepo.GetVerificationByID(ctx, verificationID)
+	if err != nil {
+		logger.Error("Failed to get verification", err, "verification_id", verificationID)
+		return nil, fmt.Errorf("failed to get verification: %w", err)
+	}
+
+	if verification == nil {
+		return nil, fmt.Errorf("verification not found")
+	}
+
+	if verification.Type != entities.VerificationTypeDocument {
+		return nil, fmt.Errorf("verification is not for document")
+	}
+
+	if !verification.Status.IsPending() {
+		return nil, fmt.Errorf("verification is not pending")
+	}
+
+	// Reject unsupported document types before doing any processing
+	for _, submission := range submissions {
+		if !vws.documentService.IsSupportedDocumentType(submission.DocumentType) {
+			return nil, fmt.Errorf("unsupported document type: %s", submission.DocumentType)
+		}
+	}
+
+	for _, submission := range submissions {
+		document := &entities.VerificationDocument{
+			ID:             uuid.New(),
+			VerificationID: verification.ID,
+			UserID:         verification.UserID,
+			DocumentType:   submission.DocumentType,
+			Status:         valueobjects.VerificationStatusPending,
+			PhotoURL:       submission.PhotoURL,
+			PhotoKey:       submission.PhotoKey,
+			CreatedAt:      time.Now(),
+			UpdatedAt:      time.Now(),
+		}
+
+		if err := vws.processSessionDocument(ctx, document); err != nil {
+			logger.Error("Failed to process session document", err, "verification_id", verificationID, "document_type", submission.DocumentType)
+			document.Reject("Document processing failed")
+		}
+
+		err = vws.verificationRepo.CreateVerificationDocument(ctx, document)
+		if err != nil {
+			logger.Error("Failed to create verification document", err, "verification_id", verificationID)
+			return nil, fmt.Errorf("failed to record verification document: %w", err)
+		}
+	}
+
+	// Evaluate the session against every document submitted so far
+	documents, err := vws.verificationRepo.GetVerificationDocuments(ctx, verification.ID)
+	if err != nil {
+		logger.Error("Failed to get verification documents", err, "verification_id", verificationID)
+		return nil, fmt.Errorf("failed to get verification documents: %w", err)
+	}
+
+	required := vws.documentService.RequiredDocumentTypes(region)
+	status, missing := EvaluateDocumentSession(required, documents)
+
+	verification.Status = status
+	switch {
+	case status.IsRejected():
+		reason := "One or more required documents failed verification"
+		verification.RejectionReason = &reason
+	case status.IsPending():
+		reason := "Awaiting required documents or manual review"
+		verification.RejectionReason = &reason
+	default:
+		verification.RejectionReason = nil
+	}
+
+	err = vws.verificationRepo.UpdateVerification(ctx, verification)
+	if err != nil {
+		logger.Error("Failed to update verification", err, "verification_id", verificationID)
+		return nil, fmt.Errorf("failed to update verification: %w", err)
+	}
+
+	err = vws.completeAIApproval(ctx, verification)
+	if err != nil {
+		logger.Error("Failed to complete AI approval", err, "verification_id", verificationID)
+		return nil, fmt.Errorf("failed to complete AI approval: %w", err)
+	}
+
+	if verification.Status.IsRejected() {
+		vws.notifyVerificationFailed(ctx, verification)
+	}
+
+	logger.Info("Document verification session processed", "verification_id", verificationID, "status", status, "missing", missing)
+	return &DocumentSessionResult{
+		Verification:      verification,
+		Documents:         documents,
+		RequiredDocuments: required,
+		MissingDocuments:  missing,
+	}, nil
+}
+
+// EvaluateDocumentSession derives the overall session status from its documents.
+// A required type passes when any document of that type is approved; the session is
+// rejected when a required type has only rejected documents, and stays pending while
+// a required type is missing or awaiting review. With no required types, every
+// submitted document must pass. It also returns the required types without a document.
+func EvaluateDocumentSession(required []string, documents []*entities.VerificationDocument) (valueobjects.VerificationStatus, []string) {
+	if len(required) == 0 {
+		for _, document := range documents {
+			required = append(required, document.DocumentType)
+		}
+	}
+
+	var missing []string
+	status := valueobjects.VerificationStatusApproved
+
+	for _, documentType := range required {
+		submitted, approved, pending := false, false, false
+		for _, document := range documents {
+			if document.DocumentType != documentType {
+				continue
+			}
+			submitted = true
+			approved = approved || document.Status.IsApproved()
+			pending = pending || document.Status.IsPending()
+		}
+
+		switch {
+		case approved:
+			continue
+		case !submitted:
+			missing = append(missing, documentType)
+			if !status.IsRejected() {
+				status = valueobjects.VerificationStatusPending
+			}
+		case pending:
+			if !status.IsRejected() {
+				status = valueobjects.VerificationStatusPending
+			}
+		default:
+			status = valueobjects.VerificationStatusRejected
+		}
+	}
+
+	if len(documents) == 0 {
+		status = valueobjects.VerificationStatusPending
+	}
+
+	return status, missing
+}
+
 // Helper methods
 
-func (vws *VerificationWorkflowService) checkVerificationAttempts(ctx context.Context, userID uuid.UUID, vType entities.VerificationType, ipAddress string) ([]*entities.VerificationAttempt, error) {
-	since := time.Now().Add(-time.Duration(DefaultVerificationConfig().AttemptCooldownMinutes) * time.Minute)
-	return vws.verificationRepo.GetVerificationAttemptsByUser(ctx, userID, vType, since)
+func (vws *VerificationWorkflowService) processSessionDocument(ctx context.Context, document *entities.VerificationDocument) error {
+	analysis, err := vws.documentService.ProcessDocument(ctx, document.PhotoKey)
+	if err != nil {
+		return fmt.Errorf("failed to analyze document: %w", err)
+	}
+
+	moderationResult, err := vws.aiService.DetectModerationLabels(ctx, document.PhotoKey)
+	if err != nil {
+		return fmt.Errorf("failed to detect inappropriate content: %w", err)
+	}
+
+	if !moderationResult.IsAppropriate {
+		document.Reject("Inappropriate content detected in document")
+		return nil
+	}
+
+	confidence := analysis.Confidence
+	data := vws.marshalDocumentFields(analysis.Fields)
+	document.Confidence = &confidence
+	document.DocumentData = &data
+
+	// Determine document status based on confidence
+	config := DefaultVerificationConfig()
+	if analysis.DocumentType != "" && analysis.DocumentType != document.DocumentType {
+		document.Reject(fmt.Sprintf("Document does not match declared type %s", document.DocumentType))
+	} else if analysis.IsValid && confidence >= config.DocumentConfidenceThreshold {
+		document.Approve()
+	} else if confidence < config.ManualReviewThreshold {
+		document.Reject(fmt.Sprintf("Low confidence score: %.2f%%", confidence*100))
+	} else {
+		reason := "Requires manual review"
+		document.RejectionReason = &reason
+	}
+
+	return nil
+}
+
+// checkVerificationAttempts returns a VerificationCooldownError while the cooldown after the user's last
+// failed verification runs, or when they used up their daily or monthly attempts. Limits set to zero are not enforced.
+func (vws *VerificationWorkflowService) checkVerificationAttempts(ctx context.Context, userID uuid.UUID, vType entities.VerificationType, last *entities.Verification) error {
+	if vws.limits == nil {
+		return nil
+	}
+
+	now := vws.now()
+	if last != nil && last.Status.IsRejected() {
+		if retryAt := vws.cooldownEnd(last); now.Before(retryAt) {
+			logger.Warn("Verification requested during cooldown", "user_id", userID, "type", vType, "retry_at", retryAt)
+			return errors.NewVerificationCooldownError(VerificationCooldownReasonCooldown, retryAt, now)
+		}
+	}
+
+	if vws.limits.MaxAttemptsPerMonth <= 0 && vws.limits.MaxAttemptsPerDay <= 0 {
+		return nil
+	}
+
+	monthAgo := now.AddDate(0, -1, 0)
+	attempts, err := vws.verificationRepo.GetVerificationAttemptsByUser(ctx, userID, vType, monthAgo)
+	if err != nil {
+		logger.Error("Failed to get verification attempts", err, "user_id", userID)
+		return fmt.Errorf("failed to check verification attempts: %w", err)
+	}
+
+	dayAgo := now.Add(-24 * time.Hour)
+	var today []*entities.VerificationAttempt
+	for _, attempt := range attempts {
+		if attempt.CreatedAt.After(dayAgo) {
+			today = append(today, attempt)
+		}
+	}
+
+	// Attempts free up as the oldest one in the window leaves it
+	if vws.limits.MaxAttemptsPerDay > 0 && len(today) >= vws.limits.MaxAttemptsPerDay {
+		logger.Warn("Daily verification attempts used up", "user_id", userID, "type", vType, "attempts", len(today))
+		retryAt := oldestAttempt(today).Add(24 * time.Hour)
+		return errors.NewVerificationCooldownError(VerificationCooldownReasonDailyLimit, retryAt, now)
+	}
+	if vws.limits.MaxAttemptsPerMonth > 0 && len(attempts) >= vws.limits.MaxAttemptsPerMonth {
+		logger.Warn("Monthly verification attempts used up", "user_id", userID, "type", vType, "attempts", len(attempts))
+		retryAt := oldestAttempt(attempts).AddDate(0, 1, 0)
+		return errors.NewVerificationCooldownError(VerificationCooldownReasonMonthlyLimit, retryAt, now)
+	}
+
+	return nil
+}
+
+// cooldownEnd returns when the user may try again after a failed verification
+func (vws *VerificationWorkflowService) cooldownEnd(verification *entities.Verification) time.Time {
+	failedAt := verification.UpdatedAt
+	if verification.ReviewedAt != nil {
+		failedAt = *verification.ReviewedAt
+	}
+	return failedAt.Add(vws.cooldownPeriod())
+}
+
+func (vws *VerificationWorkflowService) cooldownPeriod() time.Duration {
+	if vws.limits == nil {
+		return 0
+	}
+	return vws.limits.CooldownPeriod
+}
+
+// notifyVerificationFailed sends the user guidance for the verification that just failed.
+// Failing to notify does not fail the verification.
+func (vws *VerificationWorkflowService) notifyVerificationFailed(ctx context.Context, verification *entities.Verification) {
+	if vws.failureNotifier == nil {
+		return
+	}
+
+	guidance := NewVerificationGuidance(verification.Type, rejectionReason(verification))
+	err := vws.failureNotifier.NotifyVerificationFailed(ctx, verification.UserID, verification.Type, guidance, vws.now().Add(vws.cooldownPeriod()))
+	if err != nil {
+		logger.Warn("Failed to notify verification failure", "error", err, "user_id", verification.UserID, "verification_id", verification.ID)
+	}
+}
+
+func rejectionReason(verification *entities.Verification) string {
+	if verification.RejectionReason == nil {
+		return ""
+	}
+	return *verification.RejectionReason
+}
+
+func oldestAttempt(attempts []*entities.VerificationAttempt) time.Time {
+	oldest := attempts[0].CreatedAt
+	for _, attempt := range attempts[1:] {
+		if attempt.CreatedAt.Before(oldest) {
+			oldest = attempt.CreatedAt
+		}
+	}
+	return oldest
 }
 
 func (vws *VerificationWorkflowService) processSelfieWithAI(ctx context.Context, verification *entities.Verification) error {
@@ -542,6 +1002,26 @@ func (vws *VerificationWorkflowService) processDocumentWithAI(ctx context.Contex
 	return nil
 }
 
+// completeAIApproval awards the badge for a verification the AI approved without manual review
+func (vws *VerificationWorkflowService) completeAIApproval(ctx context.Context, verification *entities.Verification) error {
+	if !verification.Status.IsApproved() {
+		return nil
+	}
+
+	err := vws.awardVerificationBadge(ctx, verification.UserID, verification.Type)
+	if err != nil {
+		return fmt.Errorf("failed to award badge: %w", err)
+	}
+
+	reason := VerificationChangeReasonSelfieApproved
+	if verification.Type == entities.VerificationTypeDocument {
+		reason = VerificationChangeReasonDocumentApproved
+	}
+
+	return vws.updateUserVerificationLevel(ctx, verification.UserID, VerificationChangeSourceAIApproval, reason)
+}
+
+// awardVerificationBadge creates the badge for a verification type, the caller updates the user's level afterwards
 func (vws *VerificationWorkflowService) awardVerificationBadge(ctx context.Context, userID uuid.UUID, vType entities.VerificationType) error {
 	logger.Info("Awarding verification badge", "user_id", userID, "type", vType)
 
@@ -589,17 +1069,12 @@ func (vws *VerificationWorkflowService) awardVerificationBadge(ctx context.Conte
 		return fmt.Errorf("failed to create badge: %w", err)
 	}
 
-	// Update user verification level
-	err = vws.updateUserVerificationLevel(ctx, userID)
-	if err != nil {
-		return fmt.Errorf("failed to update user verification level: %w", err)
-	}
-
 	logger.Info("Verification badge awarded", "user_id", userID, "badge_type", badgeType, "level", level)
 	return nil
 }
 
-func (vws *VerificationWorkflowService) updateUserVerificationLevel(ctx context.Context, userID uuid.UUID) error {
+// updateUserVerificationLevel sets the user's level to their highest active badge
+func (vws *VerificationWorkflowService) updateUserVerificationLevel(ctx context.Context, userID uuid.UUID, source, reason string) error {
 	// Get user's active badges
 	badges, err := vws.verificationRepo.GetActiveBadgesByUser(ctx, userID)
 	if err != nil {
@@ -614,8 +1089,47 @@ func (vws *VerificationWorkflowService) updateUserVerificationLevel(ctx context.
 		}
 	}
 
+	return vws.setUserVerificationLevel(ctx, userID, level, source, reason)
+}
+
+// setUserVerificationLevel stores a user's verification level and publishes the change.
+// Every level change goes through here so each one is published exactly once.
+func (vws *VerificationWorkflowService) setUserVerificationLevel(ctx context.Context, userID uuid.UUID, level entities.VerificationLevel, source, reason string) error {
+	oldLevel, err := vws.verificationRepo.GetUserVerificationLevel(ctx, userID)
+	if err != nil {
+		return fmt.Errorf("failed to get user verification level: %w", err)
+	}
+
+	if oldLevel == level {
+		return nil
+	}
+
 	// Update user verification level
-	return vws.verificationRepo.UpdateUserVerificationLevel(ctx, userID, level)
+	err = vws.verificationRepo.UpdateUserVerificationLevel(ctx, userID, level)
+	if err != nil {
+		return fmt.Errorf("failed to update user verification level: %w", err)
+	}
+
+	logger.Info("User verification level changed", "user_id", userID, "old_level", oldLevel, "new_level", level, "source", source)
+
+	if vws.eventPublisher == nil {
+		return nil
+	}
+
+	// The level is already stored, a failed delivery must not undo it
+	err = vws.eventPublisher.Publish(ctx, VerificationStatusChangedEventType, &VerificationStatusChangedEvent{
+		UserID:     userID,
+		OldLevel:   oldLevel,
+		NewLevel:   level,
+		Source:     source,
+		Reason:     reason,
+		OccurredAt: time.Now(),
+	})
+	if err != nil {
+		logger.Warn("Failed to publish verification status change", err, "user_id", userID)
+	}
+
+	return nil
 }
 
 func (vws *VerificationWorkflowService) marshalDocumentFields(fields map[string]interface{}) string {
@@ -635,6 +1149,8 @@ type VerificationStatusResult struct {
 	CanRequest      bool                         `json:"can_request"`
 	LastVerification *entities.Verification           `json:"last_verification,omitempty"`
 	VerificationLevel entities.VerificationLevel       `json:"verification_level"`
+	Guidance         *VerificationGuidance             `json:"guidance,omitempty"` // Set when the last verification failed
+	RetryAt          *time.Time                        `json:"retry_at,omitempty"` // Set while the cooldown after a failure runs
 }
 
 // StorageService defines interface for storage operations
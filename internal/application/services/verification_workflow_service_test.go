@@ -0,0 +1,585 @@
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/22smeargle/winkr-backend/internal/domain/entities"
+	"github.com/22smeargle/winkr-backend/internal/domain/repositories"
+	"github.com/22smeargle/winkr-backend/internal/domain/valueobjects"
+	"github.com/22smeargle/winkr-backend/pkg/config"
+	"github.com/22smeargle/winkr-backend/pkg/errors"
+)
+
+func sessionDocument(documentType string, status valueobjects.VerificationStatus) *entities.VerificationDocument {
+	return &entities.VerificationDocument{
+		DocumentType: documentType,
+		Status:       status,
+	}
+}
+
+func TestEvaluateDocumentSession(t *testing.T) {
+	required := []string{"id_card", "proof_of_address"}
+
+	tests := []struct {
+		name            string
+		required        []string
+		documents       []*entities.VerificationDocument
+		expectedStatus  valueobjects.VerificationStatus
+		expectedMissing []string
+	}{
+		{
+			name:     "all required documents pass",
+			required: required,
+			documents: []*entities.VerificationDocument{
+				sessionDocument("id_card", valueobjects.VerificationStatusApproved),
+				sessionDocument("proof_of_address", valueobjects.VerificationStatusApproved),
+			},
+			expectedStatus: valueobjects.VerificationStatusApproved,
+		},
+		{
+			name:     "one passing and one failing document fails the session",
+			required: required,
+			documents: []*entities.VerificationDocument{
+				sessionDocument("id_card", valueobjects.VerificationStatusApproved),
+				sessionDocument("proof_of_address", valueobjects.VerificationStatusRejected),
+			},
+			expectedStatus: valueobjects.VerificationStatusRejected,
+		},
+		{
+			name:     "one passing and one awaiting review keeps the session pending",
+			required: required,
+			documents: []*entities.VerificationDocument{
+				sessionDocument("id_card", valueobjects.VerificationStatusApproved),
+				sessionDocument("proof_of_address", valueobjects.VerificationStatusPending),
+			},
+			expectedStatus: valueobjects.VerificationStatusPending,
+		},
+		{
+			name:     "missing required document keeps the session pending",
+			required: required,
+			documents: []*entities.VerificationDocument{
+				sessionDocument("id_card", valueobjects.VerificationStatusApproved),
+			},
+			expectedStatus:  valueobjects.VerificationStatusPending,
+			expectedMissing: []string{"proof_of_address"},
+		},
+		{
+			name:     "resubmitted document replaces an earlier failure",
+			required: required,
+			documents: []*entities.VerificationDocument{
+				sessionDocument("id_card", valueobjects.VerificationStatusApproved),
+				sessionDocument("proof_of_address", valueobjects.VerificationStatusRejected),
+				sessionDocument("proof_of_address", valueobjects.VerificationStatusApproved),
+			},
+			expectedStatus: valueobjects.VerificationStatusApproved,
+		},
+		{
+			name: "without a required set every document must pass",
+			documents: []*entities.VerificationDocument{
+				sessionDocument("passport", valueobjects.VerificationStatusApproved),
+				sessionDocument("driver_license", valueobjects.VerificationStatusRejected),
+			},
+			expectedStatus: valueobjects.VerificationStatusRejected,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			status, missing := EvaluateDocumentSession(tt.required, tt.documents)
+			assert.Equal(t, tt.expectedStatus, status)
+			assert.Equal(t, tt.expectedMissing, missing)
+		})
+	}
+}
+
+func TestDocumentService_RequiredDocumentTypes(t *testing.T) {
+	ds := NewDocumentService(nil, &config.DocumentProcessingConfig{
+		SupportedDocumentTypes:   []string{"id_card", "passport", "proof_of_address"},
+		DefaultRequiredDocuments: []string{"id_card"},
+		RequiredDocumentsByRegion: map[string][]string{
+			"DE": {"id_card", "proof_of_address"},
+		},
+	})
+
+	assert.Equal(t, []string{"id_card", "proof_of_address"}, ds.RequiredDocumentTypes("de"))
+	assert.Equal(t, []string{"id_card"}, ds.RequiredDocumentTypes("US"))
+	assert.True(t, ds.IsSupportedDocumentType("proof_of_address"))
+	assert.False(t, ds.IsSupportedDocumentType("library_card"))
+}
+
+// inMemoryVerificationRepository keeps the verifications, badges and levels the workflow changes
+type inMemoryVerificationRepository struct {
+	repositories.VerificationRepository
+	verifications map[uuid.UUID]*entities.Verification
+	badges        map[uuid.UUID][]*entities.VerificationBadge
+	levels        map[uuid.UUID]entities.VerificationLevel
+	attempts      []*entities.VerificationAttempt
+}
+
+func newInMemoryVerificationRepository() *inMemoryVerificationRepository {
+	return &inMemoryVerificationRepository{
+		verifications: make(map[uuid.UUID]*entities.Verification),
+		badges:        make(map[uuid.UUID][]*entities.VerificationBadge),
+		levels:        make(map[uuid.UUID]entities.VerificationLevel),
+	}
+}
+
+func (r *inMemoryVerificationRepository) GetVerificationByID(ctx context.Context, id uuid.UUID) (*entities.Verification, error) {
+	return r.verifications[id], nil
+}
+
+func (r *inMemoryVerificationRepository) CreateVerification(ctx context.Context, verification *entities.Verification) error {
+	r.verifications[verification.ID] = verification
+	return nil
+}
+
+func (r *inMemoryVerificationRepository) GetVerificationByUserAndType(ctx context.Context, userID uuid.UUID, vType entities.VerificationType) (*entities.Verification, error) {
+	var latest *entities.Verification
+	for _, verification := range r.verifications {
+		if verification.UserID == userID && verification.Type == vType && (latest == nil || verification.CreatedAt.After(latest.CreatedAt)) {
+			latest = verification
+		}
+	}
+	return latest, nil
+}
+
+func (r *inMemoryVerificationRepository) CreateVerificationAttempt(ctx context.Context, attempt *entities.VerificationAttempt) error {
+	r.attempts = append(r.attempts, attempt)
+	return nil
+}
+
+func (r *inMemoryVerificationRepository) GetVerificationAttemptsByUser(ctx context.Context, userID uuid.UUID, vType entities.VerificationType, since time.Time) ([]*entities.VerificationAttempt, error) {
+	var found []*entities.VerificationAttempt
+	for _, attempt := range r.attempts {
+		if attempt.UserID == userID && attempt.Type == vType && !attempt.CreatedAt.Before(since) {
+			found = append(found, attempt)
+		}
+	}
+	return found, nil
+}
+
+func (r *inMemoryVerificationRepository) UpdateVerification(ctx context.Context, verification *entities.Verification) error {
+	r.verifications[verification.ID] = verification
+	return nil
+}
+
+func (r *inMemoryVerificationRepository) CreateVerificationBadge(ctx context.Context, badge *entities.VerificationBadge) error {
+	r.badges[badge.UserID] = append(r.badges[badge.UserID], badge)
+	return nil
+}
+
+func (r *inMemoryVerificationRepository) GetActiveBadgesByUser(ctx context.Context, userID uuid.UUID) ([]*entities.VerificationBadge, error) {
+	var active []*entities.VerificationBadge
+	for _, badge := range r.badges[userID] {
+		if badge.IsActive() {
+			active = append(active, badge)
+		}
+	}
+	return active, nil
+}
+
+func (r *inMemoryVerificationRepository) GetBadgeByUserAndType(ctx context.Context, userID uuid.UUID, badgeType string) (*entities.VerificationBadge, error) {
+	for _, badge := range r.badges[userID] {
+		if badge.BadgeType == badgeType {
+			return badge, nil
+		}
+	}
+	return nil, nil
+}
+
+func (r *inMemoryVerificationRepository) GetUsersWithExpiredBadges(ctx context.Context, before time.Time) ([]uuid.UUID, error) {
+	var userIDs []uuid.UUID
+	for userID, badges := range r.badges {
+		for _, badge := range badges {
+			if !badge.IsRevoked && badge.ExpiresAt != nil && badge.ExpiresAt.Before(before) {
+				userIDs = append(userIDs, userID)
+				break
+			}
+		}
+	}
+	return userIDs, nil
+}
+
+func (r *inMemoryVerificationRepository) DeleteExpiredBadges(ctx context.Context) error {
+	for userID, badges := range r.badges {
+		var valid []*entities.VerificationBadge
+		for _, badge := range badges {
+			if !badge.IsExpired() {
+				valid = append(valid, badge)
+			}
+		}
+		r.badges[userID] = valid
+	}
+	return nil
+}
+
+func (r *inMemoryVerificationRepository) GetUserVerificationLevel(ctx context.Context, userID uuid.UUID) (entities.VerificationLevel, error) {
+	return r.levels[userID], nil
+}
+
+func (r *inMemoryVerificationRepository) UpdateUserVerificationLevel(ctx context.Context, userID uuid.UUID, level entities.VerificationLevel) error {
+	r.levels[userID] = level
+	return nil
+}
+
+func (r *inMemoryVerificationRepository) addBadge(userID uuid.UUID, level entities.VerificationLevel, badgeType string, expiresAt time.Time) {
+	r.badges[userID] = append(r.badges[userID], &entities.VerificationBadge{
+		ID:        uuid.New(),
+		UserID:    userID,
+		Level:     level,
+		BadgeType: badgeType,
+		ExpiresAt: &expiresAt,
+	})
+}
+
+// recordingEventPublisher records every published event
+type recordingEventPublisher struct {
+	eventTypes []string
+	events     []*VerificationStatusChangedEvent
+}
+
+func (p *recordingEventPublisher) Publish(ctx context.Context, eventType string, data interface{}) error {
+	p.eventTypes = append(p.eventTypes, eventType)
+	p.events = append(p.events, data.(*VerificationStatusChangedEvent))
+	return nil
+}
+
+func newTestVerificationWorkflow() (*VerificationWorkflowService, *inMemoryVerificationRepository, *recordingEventPublisher) {
+	repo := newInMemoryVerificationRepository()
+	publisher := &recordingEventPublisher{}
+	return NewVerificationWorkflowService(repo, nil, nil, nil, nil, nil, nil, publisher), repo, publisher
+}
+
+func assertSingleLevelChange(t *testing.T, publisher *recordingEventPublisher, userID uuid.UUID, oldLevel, newLevel entities.VerificationLevel, source string) {
+	t.Helper()
+	require.Len(t, publisher.events, 1)
+	assert.Equal(t, []string{VerificationStatusChangedEventType}, publisher.eventTypes)
+	assert.Equal(t, userID, publisher.events[0].UserID)
+	assert.Equal(t, oldLevel, publisher.events[0].OldLevel)
+	assert.Equal(t, newLevel, publisher.events[0].NewLevel)
+	assert.Equal(t, source, publisher.events[0].Source)
+}
+
+func TestVerificationWorkflow_AIApprovalPublishesLevelChange(t *testing.T) {
+	workflow, repo, publisher := newTestVerificationWorkflow()
+	userID := uuid.New()
+
+	verification := &entities.Verification{
+		ID:     uuid.New(),
+		UserID: userID,
+		Type:   entities.VerificationTypeSelfie,
+		Status: valueobjects.VerificationStatusApproved,
+	}
+	require.NoError(t, workflow.completeAIApproval(context.Background(), verification))
+
+	assertSingleLevelChange(t, publisher, userID, entities.VerificationLevelNone, entities.VerificationLevelSelfie, VerificationChangeSourceAIApproval)
+	assert.Equal(t, VerificationChangeReasonSelfieApproved, publisher.events[0].Reason)
+	assert.Equal(t, entities.VerificationLevelSelfie, repo.levels[userID])
+
+	// Verifications the AI left for review do not change the level
+	pending := &entities.Verification{
+		ID:     uuid.New(),
+		UserID: uuid.New(),
+		Type:   entities.VerificationTypeDocument,
+		Status: valueobjects.VerificationStatusPending,
+	}
+	require.NoError(t, workflow.completeAIApproval(context.Background(), pending))
+	assert.Len(t, publisher.events, 1)
+
+	// Only the level change leaves the service, nothing about the verification itself
+	body, err := json.Marshal(publisher.events[0])
+	require.NoError(t, err)
+	var fields map[string]interface{}
+	require.NoError(t, json.Unmarshal(body, &fields))
+	assert.ElementsMatch(t, []string{"user_id", "old_level", "new_level", "source", "reason", "occurred_at"}, mapKeys(fields))
+}
+
+func TestVerificationWorkflow_ManualReviewPublishesLevelChange(t *testing.T) {
+	ctx := context.Background()
+	workflow, repo, publisher := newTestVerificationWorkflow()
+	userID := uuid.New()
+	repo.levels[userID] = entities.VerificationLevelSelfie
+	repo.addBadge(userID, entities.VerificationLevelSelfie, "selfie_verified", time.Now().AddDate(1, 0, 0))
+
+	verification := &entities.Verification{
+		ID:     uuid.New(),
+		UserID: userID,
+		Type:   entities.VerificationTypeDocument,
+		Status: valueobjects.VerificationStatusPending,
+	}
+	repo.verifications[verification.ID] = verification
+
+	require.NoError(t, workflow.ProcessVerificationResult(ctx, verification.ID, true, "", uuid.New()))
+
+	assertSingleLevelChange(t, publisher, userID, entities.VerificationLevelSelfie, entities.VerificationLevelDocument, VerificationChangeSourceManualReview)
+	assert.Equal(t, VerificationChangeReasonReviewApproved, publisher.events[0].Reason)
+
+	// A rejection that leaves the level as it is publishes nothing
+	rejected := &entities.Verification{
+		ID:     uuid.New(),
+		UserID: userID,
+		Type:   entities.VerificationTypeSelfie,
+		Status: valueobjects.VerificationStatusPending,
+	}
+	repo.verifications[rejected.ID] = rejected
+
+	require.NoError(t, workflow.ProcessVerificationResult(ctx, rejected.ID, false, "Blurry photo of John Doe", uuid.New()))
+	assert.Len(t, publisher.events, 1)
+}
+
+func TestVerificationWorkflow_AdminOverridePublishesLevelChange(t *testing.T) {
+	ctx := context.Background()
+	workflow, repo, publisher := newTestVerificationWorkflow()
+	userID := uuid.New()
+	repo.levels[userID] = entities.VerificationLevelDocument
+
+	require.NoError(t, workflow.OverrideVerificationLevel(ctx, userID, entities.VerificationLevelNone, "Document was forged", uuid.New()))
+
+	assertSingleLevelChange(t, publisher, userID, entities.VerificationLevelDocument, entities.VerificationLevelNone, VerificationChangeSourceAdminOverride)
+	assert.Equal(t, VerificationChangeReasonAdminOverride, publisher.events[0].Reason)
+	assert.Equal(t, entities.VerificationLevelNone, repo.levels[userID])
+
+	// Setting the level the user already has is not a change
+	require.NoError(t, workflow.OverrideVerificationLevel(ctx, userID, entities.VerificationLevelNone, "Double check", uuid.New()))
+	assert.Len(t, publisher.events, 1)
+
+	assert.Error(t, workflow.OverrideVerificationLevel(ctx, userID, entities.VerificationLevel(5), "Invalid", uuid.New()))
+	assert.Len(t, publisher.events, 1)
+}
+
+func TestVerificationWorkflow_ExpiryPublishesLevelChange(t *testing.T) {
+	ctx := context.Background()
+	workflow, repo, publisher := newTestVerificationWorkflow()
+
+	userID := uuid.New()
+	repo.levels[userID] = entities.VerificationLevelDocument
+	repo.addBadge(userID, entities.VerificationLevelSelfie, "selfie_verified", time.Now().AddDate(0, 6, 0))
+	repo.addBadge(userID, entities.VerificationLevelDocument, "document_verified", time.Now().Add(-time.Hour))
+
+	// A user with only current badges keeps their level
+	activeUserID := uuid.New()
+	repo.levels[activeUserID] = entities.VerificationLevelSelfie
+	repo.addBadge(activeUserID, entities.VerificationLevelSelfie, "selfie_verified", time.Now().AddDate(0, 6, 0))
+
+	checked, err := workflow.DowngradeExpiredVerifications(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, 1, checked)
+
+	assertSingleLevelChange(t, publisher, userID, entities.VerificationLevelDocument, entities.VerificationLevelSelfie, VerificationChangeSourceExpiry)
+	assert.Equal(t, VerificationChangeReasonBadgeExpired, publisher.events[0].Reason)
+	assert.Equal(t, entities.VerificationLevelSelfie, repo.levels[activeUserID])
+
+	// The expired badge is gone, a second run changes nothing
+	checked, err = workflow.DowngradeExpiredVerifications(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, 0, checked)
+	assert.Len(t, publisher.events, 1)
+}
+
+// recordingFailureNotifier records the guidance sent for failed verifications
+type recordingFailureNotifier struct {
+	guidance []*VerificationGuidance
+	retryAts []time.Time
+}
+
+func (n *recordingFailureNotifier) NotifyVerificationFailed(ctx context.Context, userID uuid.UUID, vType entities.VerificationType, guidance *VerificationGuidance, retryAt time.Time) error {
+	n.guidance = append(n.guidance, guidance)
+	n.retryAts = append(n.retryAts, retryAt)
+	return nil
+}
+
+func newLimitedVerificationWorkflow(now time.Time) (*VerificationWorkflowService, *inMemoryVerificationRepository, *recordingFailureNotifier) {
+	repo := newInMemoryVerificationRepository()
+	notifier := &recordingFailureNotifier{}
+	limits := &config.VerificationLimitsConfig{
+		MaxAttemptsPerDay:   3,
+		MaxAttemptsPerMonth: 10,
+		CooldownPeriod:      24 * time.Hour,
+	}
+	workflow := NewVerificationWorkflowService(repo, nil, nil, nil, nil, limits, notifier, &recordingEventPublisher{})
+	workflow.now = func() time.Time { return now }
+	return workflow, repo, notifier
+}
+
+func TestVerificationWorkflow_RetryDuringCooldownIsBlocked(t *testing.T) {
+	ctx := context.Background()
+	now := time.Date(2026, 3, 10, 12, 0, 0, 0, time.UTC)
+	workflow, repo, _ := newLimitedVerificationWorkflow(now)
+	userID := uuid.New()
+
+	reason := "No face detected in photo"
+	failed := &entities.Verification{
+		ID:              uuid.New(),
+		UserID:          userID,
+		Type:            entities.VerificationTypeSelfie,
+		Status:          valueobjects.VerificationStatusRejected,
+		RejectionReason: &reason,
+		CreatedAt:       now.Add(-3 * time.Hour),
+		UpdatedAt:       now.Add(-2 * time.Hour),
+	}
+	repo.verifications[failed.ID] = failed
+
+	_, err := workflow.RequestSelfieVerification(ctx, userID, "203.0.113.7", "test")
+
+	cooldownErr, ok := errors.GetVerificationCooldownError(err)
+	require.True(t, ok, "expected a cooldown error, got %v", err)
+	assert.Equal(t, VerificationCooldownReasonCooldown, cooldownErr.Reason)
+	assert.Equal(t, 22*time.Hour, cooldownErr.Remaining)
+	assert.Equal(t, now.Add(22*time.Hour), cooldownErr.RetryAt)
+	assert.Equal(t, errors.ErrVerificationCooldown.Code, errors.GetAppError(err).Code)
+	assert.Empty(t, repo.attempts, "a blocked request is not an attempt")
+
+	// The status shows the guidance for the failure and when the user may retry
+	status, err := workflow.GetVerificationStatus(ctx, userID, entities.VerificationTypeSelfie)
+	require.NoError(t, err)
+	assert.False(t, status.CanRequest)
+	require.NotNil(t, status.RetryAt)
+	assert.Equal(t, now.Add(22*time.Hour), *status.RetryAt)
+	require.NotNil(t, status.Guidance)
+	assert.Equal(t, VerificationFailureNoFace, status.Guidance.Reason)
+
+	// A selfie attempt does not block documents
+	_, err = workflow.RequestDocumentVerification(ctx, userID, "203.0.113.7", "test")
+	assert.NoError(t, err)
+
+	// Once the cooldown is over the user can try again
+	workflow.now = func() time.Time { return now.Add(22 * time.Hour) }
+	verification, err := workflow.RequestSelfieVerification(ctx, userID, "203.0.113.7", "test")
+	require.NoError(t, err)
+	assert.True(t, verification.Status.IsPending())
+}
+
+func TestVerificationWorkflow_AttemptLimits(t *testing.T) {
+	ctx := context.Background()
+	now := time.Date(2026, 3, 10, 12, 0, 0, 0, time.UTC)
+
+	addAttempts := func(repo *inMemoryVerificationRepository, userID uuid.UUID, ages ...time.Duration) {
+		for _, age := range ages {
+			repo.attempts = append(repo.attempts, &entities.VerificationAttempt{
+				ID:        uuid.New(),
+				UserID:    userID,
+				Type:      entities.VerificationTypeSelfie,
+				Status:    "success",
+				CreatedAt: now.Add(-age),
+			})
+		}
+	}
+
+	t.Run("daily limit", func(t *testing.T) {
+		workflow, repo, _ := newLimitedVerificationWorkflow(now)
+		userID := uuid.New()
+		addAttempts(repo, userID, 20*time.Hour, 5*time.Hour, time.Hour)
+
+		_, err := workflow.RequestSelfieVerification(ctx, userID, "203.0.113.7", "test")
+
+		cooldownErr, ok := errors.GetVerificationCooldownError(err)
+		require.True(t, ok, "expected a cooldown error, got %v", err)
+		assert.Equal(t, VerificationCooldownReasonDailyLimit, cooldownErr.Reason)
+		assert.Equal(t, 4*time.Hour, cooldownErr.Remaining, "the oldest attempt today leaves the window first")
+	})
+
+	t.Run("monthly limit", func(t *testing.T) {
+		workflow, repo, _ := newLimitedVerificationWorkflow(now)
+		userID := uuid.New()
+		for day := 2; day <= 11; day++ {
+			addAttempts(repo, userID, time.Duration(day)*24*time.Hour)
+		}
+
+		_, err := workflow.RequestSelfieVerification(ctx, userID, "203.0.113.7", "test")
+
+		cooldownErr, ok := errors.GetVerificationCooldownError(err)
+		require.True(t, ok, "expected a cooldown error, got %v", err)
+		assert.Equal(t, VerificationCooldownReasonMonthlyLimit, cooldownErr.Reason)
+		assert.Equal(t, now.Add(-11*24*time.Hour).AddDate(0, 1, 0), cooldownErr.RetryAt)
+	})
+
+	t.Run("within limits", func(t *testing.T) {
+		workflow, repo, _ := newLimitedVerificationWorkflow(now)
+		userID := uuid.New()
+		addAttempts(repo, userID, 26*time.Hour, 2*time.Hour)
+
+		_, err := workflow.RequestSelfieVerification(ctx, userID, "203.0.113.7", "test")
+
+		require.NoError(t, err)
+		assert.Len(t, repo.attempts, 3)
+	})
+}
+
+func TestVerificationWorkflow_RejectionSendsGuidance(t *testing.T) {
+	ctx := context.Background()
+	now := time.Now()
+	workflow, repo, notifier := newLimitedVerificationWorkflow(now)
+
+	verification := &entities.Verification{
+		ID:     uuid.New(),
+		UserID: uuid.New(),
+		Type:   entities.VerificationTypeDocument,
+		Status: valueobjects.VerificationStatusPending,
+	}
+	repo.verifications[verification.ID] = verification
+
+	require.NoError(t, workflow.ProcessVerificationResult(ctx, verification.ID, false, "Blurry photo of John Doe's passport", uuid.New()))
+
+	require.Len(t, notifier.guidance, 1)
+	assert.Equal(t, VerificationFailureBlurry, notifier.guidance[0].Reason)
+	assert.NotContains(t, notifier.guidance[0].Message, "John Doe")
+	assert.Equal(t, now.Add(24*time.Hour), notifier.retryAts[0])
+
+	// Approvals send nothing
+	approved := &entities.Verification{
+		ID:     uuid.New(),
+		UserID: uuid.New(),
+		Type:   entities.VerificationTypeSelfie,
+		Status: valueobjects.VerificationStatusPending,
+	}
+	repo.verifications[approved.ID] = approved
+	require.NoError(t, workflow.ProcessVerificationResult(ctx, approved.ID, true, "", uuid.New()))
+	assert.Len(t, notifier.guidance, 1)
+}
+
+func TestNewVerificationGuidance(t *testing.T) {
+	tests := []struct {
+		name     string
+		vType    entities.VerificationType
+		reason   string
+		expected string
+	}{
+		{"no face", entities.VerificationTypeSelfie, "No face detected in photo", VerificationFailureNoFace},
+		{"spoofed selfie", entities.VerificationTypeSelfie, "Photo appears to be spoofed or not live", VerificationFailureNotLive},
+		{"inappropriate document", entities.VerificationTypeDocument, "Inappropriate content detected in document", VerificationFailureInappropriate},
+		{"low confidence selfie", entities.VerificationTypeSelfie, "Low confidence score: 42.00%", VerificationFailureLighting},
+		{"low confidence document", entities.VerificationTypeDocument, "Low confidence score: 42.00%", VerificationFailureBlurry},
+		{"reviewer mentions lighting", entities.VerificationTypeSelfie, "Too dark to see the face", VerificationFailureLighting},
+		{"reviewer mentions glare", entities.VerificationTypeDocument, "Glare over the date of birth", VerificationFailureLighting},
+		{"reviewer mentions blur", entities.VerificationTypeDocument, "Document is blurry", VerificationFailureBlurry},
+		{"processing failure", entities.VerificationTypeDocument, "Document processing failed", VerificationFailureUnclear},
+		{"no reason", entities.VerificationTypeSelfie, "", VerificationFailureUnclear},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			guidance := NewVerificationGuidance(tt.vType, tt.reason)
+			assert.Equal(t, tt.expected, guidance.Reason)
+			assert.NotEmpty(t, guidance.Message)
+		})
+	}
+
+	// The same failure gets advice fitting the kind of verification
+	selfie := NewVerificationGuidance(entities.VerificationTypeSelfie, "blurry")
+	document := NewVerificationGuidance(entities.VerificationTypeDocument, "blurry")
+	assert.NotEqual(t, selfie.Message, document.Message)
+}
+
+func mapKeys(fields map[string]interface{}) []string {
+	result := make([]string, 0, len(fields))
+	for key := range fields {
+		result = append(result, key)
+	}
+	return result
+}
@@ -0,0 +1,51 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/22smeargle/winkr-backend/internal/infrastructure/database/redis"
+)
+
+// StripeWebhookRetryWindow is how long Stripe keeps retrying a webhook delivery. Processed events
+// are remembered this long so every redelivery is recognised.
+const StripeWebhookRetryWindow = 72 * time.Hour
+
+// WebhookEventStore remembers which webhook events have been processed
+type WebhookEventStore interface {
+	// ClaimEvent records the event for ttl and reports false if it was already recorded
+	ClaimEvent(ctx context.Context, eventID string, ttl time.Duration) (bool, error)
+	// ReleaseEvent forgets the event so a later delivery is processed again
+	ReleaseEvent(ctx context.Context, eventID string) error
+}
+
+// RedisWebhookEventStore stores processed webhook event IDs in Redis. Claiming uses SETNX, so of
+// two deliveries of the same event arriving together only one is processed.
+type RedisWebhookEventStore struct {
+	redisClient *redis.RedisClient
+	prefix      string
+}
+
+// NewRedisWebhookEventStore creates a new RedisWebhookEventStore
+func NewRedisWebhookEventStore(redisClient *redis.RedisClient) *RedisWebhookEventStore {
+	return &RedisWebhookEventStore{
+		redisClient: redisClient,
+		prefix:      "webhook_events:",
+	}
+}
+
+// ClaimEvent records the event and reports false if it was already recorded
+func (s *RedisWebhookEventStore) ClaimEvent(ctx context.Context, eventID string, ttl time.Duration) (bool, error) {
+	return s.redisClient.GetClient().SetNX(ctx, s.key(eventID), time.Now().Unix(), ttl).Result()
+}
+
+// ReleaseEvent forgets the event
+func (s *RedisWebhookEventStore) ReleaseEvent(ctx context.Context, eventID string) error {
+	return s.redisClient.Del(ctx, s.key(eventID))
+}
+
+// key returns the key an event is recorded under
+func (s *RedisWebhookEventStore) key(eventID string) string {
+	return fmt.Sprintf("%s%s", s.prefix, eventID)
+}
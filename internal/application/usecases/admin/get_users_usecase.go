@@ -25,7 +25,7 @@ func NewGetUsersUseCase(userRepo repositories.UserRepository) *GetUsersUseCase {
 
 // UserFilters represents filtering options for user queries
 type UserFilters struct {
-	Status    string `json:"status" validate:"omitempty,oneof=active inactive banned verified unverified premium basic"`
+	Status    string `json:"status" validate:"omitempty,oneof=active inactive banned verified unverified premium basic under_review shadowbanned"`
 	Verified  string `json:"verified" validate:"omitempty,oneof=true false"`
 	Premium   string `json:"premium" validate:"omitempty,oneof=true false"`
 	Search    string `json:"search"`
@@ -121,6 +121,14 @@ func applyUserFilters(users []*entities.User, filters UserFilters) []*entities.U
 				if user.IsPremium {
 					continue
 				}
+			case "under_review":
+				if !user.ProfileUnderReview {
+					continue
+				}
+			case "shadowbanned":
+				if !user.Shadowbanned {
+					continue
+				}
 			}
 		}
 
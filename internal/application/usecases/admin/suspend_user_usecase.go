@@ -10,15 +10,22 @@ import (
 	"github.com/22smeargle/winkr-backend/pkg/logger"
 )
 
+// UserBanCascader ends the matches and conversations of a banned user
+type UserBanCascader interface {
+	OnUserBanned(ctx context.Context, userID uuid.UUID) error
+}
+
 // SuspendUserUseCase handles suspending and banning users
 type SuspendUserUseCase struct {
-	userRepo repositories.UserRepository
+	userRepo    repositories.UserRepository
+	banCascader UserBanCascader
 }
 
 // NewSuspendUserUseCase creates a new SuspendUserUseCase
-func NewSuspendUserUseCase(userRepo repositories.UserRepository) *SuspendUserUseCase {
+func NewSuspendUserUseCase(userRepo repositories.UserRepository, banCascader UserBanCascader) *SuspendUserUseCase {
 	return &SuspendUserUseCase{
-		userRepo: userRepo,
+		userRepo:    userRepo,
+		banCascader: banCascader,
 	}
 }
 
@@ -205,6 +212,14 @@ func (uc *SuspendUserUseCase) Ban(ctx context.Context, req BanUserRequest) (*Ban
 		return nil, fmt.Errorf("failed to update user: %w", err)
 	}
 
+	// End the banned user's matches and conversations
+	if uc.banCascader != nil {
+		if err := uc.banCascader.OnUserBanned(ctx, req.UserID); err != nil {
+			logger.Error("Failed to end matches of banned user", err, "admin_id", req.AdminID, "user_id", req.UserID)
+			return nil, fmt.Errorf("failed to end matches of banned user: %w", err)
+		}
+	}
+
 	// Log the ban action
 	uc.logAdminAction(ctx, req.AdminID, req.UserID, "ban_user", map[string]interface{}{
 		"reason": req.Reason,
@@ -13,13 +13,15 @@ import (
 
 // UpdateUserUseCase handles updating user information
 type UpdateUserUseCase struct {
-	userRepo repositories.UserRepository
+	userRepo    repositories.UserRepository
+	banCascader UserBanCascader
 }
 
 // NewUpdateUserUseCase creates a new UpdateUserUseCase
-func NewUpdateUserUseCase(userRepo repositories.UserRepository) *UpdateUserUseCase {
+func NewUpdateUserUseCase(userRepo repositories.UserRepository, banCascader UserBanCascader) *UpdateUserUseCase {
 	return &UpdateUserUseCase{
-		userRepo: userRepo,
+		userRepo:    userRepo,
+		banCascader: banCascader,
 	}
 }
 
@@ -34,6 +36,9 @@ type UpdateUserRequest struct {
 	IsPremium   *bool      `json:"is_premium"`
 	IsActive    *bool      `json:"is_active"`
 	IsBanned    *bool      `json:"is_banned"`
+	GeofenceExempt *bool   `json:"geofence_exempt"`
+	ProfileUnderReview *bool `json:"profile_under_review"`
+	Shadowbanned *bool     `json:"shadowbanned"`
 	LocationLat *float64   `json:"location_lat" validate:"omitempty,min=-90,max=90"`
 	LocationLng *float64   `json:"location_lng" validate:"omitempty,min=-180,max=180"`
 	LocationCity *string    `json:"location_city" validate:"omitempty,max=100"`
@@ -65,6 +70,7 @@ func (uc *UpdateUserUseCase) Execute(ctx context.Context, req UpdateUserRequest)
 
 	// Track updated fields
 	updatedFields := make([]string, 0)
+	banned := false
 
 	// Update fields if provided
 	if req.FirstName != nil && *req.FirstName != user.FirstName {
@@ -101,9 +107,29 @@ func (uc *UpdateUserUseCase) Execute(ctx context.Context, req UpdateUserRequest)
 
 	if req.IsBanned != nil && *req.IsBanned != user.IsBanned {
 		user.IsBanned = *req.IsBanned
+		banned = user.IsBanned
 		updatedFields = append(updatedFields, "is_banned")
 	}
 
+	if req.GeofenceExempt != nil && *req.GeofenceExempt != user.GeofenceExempt {
+		user.GeofenceExempt = *req.GeofenceExempt
+		updatedFields = append(updatedFields, "geofence_exempt")
+	}
+
+	// Clearing the flag after review puts the profile back into discovery
+	if req.ProfileUnderReview != nil && *req.ProfileUnderReview != user.ProfileUnderReview {
+		user.ProfileUnderReview = *req.ProfileUnderReview
+		updatedFields = append(updatedFields, "profile_under_review")
+	}
+
+	// Shadowbanning is audited on its own, since the user is never told about it
+	shadowbanChanged := false
+	if req.Shadowbanned != nil && *req.Shadowbanned != user.Shadowbanned {
+		user.Shadowbanned = *req.Shadowbanned
+		shadowbanChanged = true
+		updatedFields = append(updatedFields, "shadowbanned")
+	}
+
 	if req.LocationLat != nil {
 		if (req.LocationLat == nil && user.LocationLat != nil) || (req.LocationLat != nil && user.LocationLat == nil) || (req.LocationLat != nil && user.LocationLat != nil && *req.LocationLat != *user.LocationLat) {
 			user.LocationLat = req.LocationLat
@@ -148,12 +174,32 @@ func (uc *UpdateUserUseCase) Execute(ctx context.Context, req UpdateUserRequest)
 		return nil, fmt.Errorf("failed to update user: %w", err)
 	}
 
+	if banned {
+		// End the banned user's matches and conversations
+		if uc.banCascader != nil {
+			if err := uc.banCascader.OnUserBanned(ctx, req.UserID); err != nil {
+				logger.Error("Failed to end matches of banned user", err, "admin_id", req.AdminID, "user_id", req.UserID)
+				return nil, fmt.Errorf("failed to end matches of banned user: %w", err)
+			}
+		}
+	}
+
 	// Log the admin action
 	uc.logAdminAction(ctx, req.AdminID, req.UserID, "update_user", map[string]interface{}{
 		"updated_fields": updatedFields,
 		"reason":         req.Reason,
 	})
 
+	if shadowbanChanged {
+		action := "shadowban_user"
+		if !user.Shadowbanned {
+			action = "lift_shadowban"
+		}
+		uc.logAdminAction(ctx, req.AdminID, req.UserID, action, map[string]interface{}{
+			"reason": req.Reason,
+		})
+	}
+
 	logger.Info("UpdateUser use case completed successfully", "admin_id", req.AdminID, "user_id", req.UserID, "updated_fields", updatedFields)
 	return &UpdateUserResponse{
 		User:      user,
@@ -2,9 +2,12 @@ package auth
 
 import (
 	"context"
+	"sort"
+	"time"
 
 	"github.com/google/uuid"
 	"github.com/22smeargle/winkr-backend/internal/domain/services"
+	authinfra "github.com/22smeargle/winkr-backend/internal/infrastructure/auth"
 	"github.com/22smeargle/winkr-backend/pkg/errors"
 )
 
@@ -23,6 +26,7 @@ func NewGetSessionsUseCase(authService services.AuthService) *GetSessionsUseCase
 // GetSessionsRequest represents get sessions request
 type GetSessionsRequest struct {
 	UserID    uuid.UUID `json:"user_id" validate:"required"`
+	CurrentSessionID string `json:"current_session_id,omitempty"` // The session of the token making the request
 	IPAddress string    `json:"ip_address,omitempty"`
 	UserAgent string    `json:"user_agent,omitempty"`
 }
@@ -49,16 +53,22 @@ type SessionDTO struct {
 	ExpiresAt    string    `json:"expires_at"`
 }
 
-// Execute handles the get sessions use case
+// Execute lists the user's active sessions, most recently active first, marking the current one
 func (uc *GetSessionsUseCase) Execute(ctx context.Context, req *GetSessionsRequest) (*GetSessionsResponse, error) {
-	// TODO: Get sessions from auth service
-	// sessions, err := uc.authService.GetActiveSessions(ctx, req.UserID, req.IPAddress, req.UserAgent)
-	// if err != nil {
-	//     return nil, err
-	// }
+	sessions, err := activeUserSessions(ctx, uc.authService, req.UserID)
+	if err != nil {
+		return nil, err
+	}
+
+	sort.Slice(sessions, func(i, j int) bool {
+		return sessions[i].LastActivity.After(sessions[j].LastActivity)
+	})
 
 	// Convert to DTOs
-	sessionDTOs := make([]*SessionDTO, 0) // TODO: Convert sessions to DTOs
+	sessionDTOs := make([]*SessionDTO, 0, len(sessions))
+	for _, session := range sessions {
+		sessionDTOs = append(sessionDTOs, newSessionDTO(session, req.CurrentSessionID))
+	}
 
 	// Return response
 	response := &GetSessionsResponse{
@@ -67,4 +77,43 @@ func (uc *GetSessionsUseCase) Execute(ctx context.Context, req *GetSessionsReque
 	}
 
 	return response, nil
-}
\ No newline at end of file
+}
+
+// activeUserSessions returns the user's sessions that are still active. Sessions of other users
+// never make it into the list, so callers can rely on every session belonging to the user.
+func activeUserSessions(ctx context.Context, authService services.AuthService, userID uuid.UUID) ([]*authinfra.Session, error) {
+	sessions, err := authService.GetActiveSessions(ctx, userID)
+	if err != nil {
+		return nil, errors.WrapError(err, "Failed to get sessions")
+	}
+
+	now := time.Now()
+	active := make([]*authinfra.Session, 0, len(sessions))
+	for _, session := range sessions {
+		if session.UserID != userID.String() || !session.IsActive || now.After(session.ExpiresAt) {
+			continue
+		}
+		active = append(active, session)
+	}
+	return active, nil
+}
+
+// newSessionDTO converts a session, marking it if it is the current one
+func newSessionDTO(session *authinfra.Session, currentSessionID string) *SessionDTO {
+	sessionDTO := &SessionDTO{
+		ID:         session.ID,
+		UserID:     session.UserID,
+		IPAddress:  session.IPAddress,
+		UserAgent:  session.UserAgent,
+		IsCurrent:  currentSessionID != "" && session.ID == currentSessionID,
+		LastActive: session.LastActivity.Format(time.RFC3339),
+		CreatedAt:  session.CreatedAt.Format(time.RFC3339),
+		ExpiresAt:  session.ExpiresAt.Format(time.RFC3339),
+	}
+	if session.DeviceInfo != nil {
+		sessionDTO.DeviceType = session.DeviceInfo.Device
+		sessionDTO.Platform = session.DeviceInfo.Platform
+		sessionDTO.Browser = session.DeviceInfo.Browser
+	}
+	return sessionDTO
+}
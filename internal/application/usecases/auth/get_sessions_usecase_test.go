@@ -0,0 +1,74 @@
+package auth
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/22smeargle/winkr-backend/internal/domain/services"
+	authinfra "github.com/22smeargle/winkr-backend/internal/infrastructure/auth"
+)
+
+// fakeSessionAuthService is an AuthService keeping sessions in memory for tests
+type fakeSessionAuthService struct {
+	services.AuthService
+	sessions    map[uuid.UUID][]*authinfra.Session
+	invalidated []string
+}
+
+func (f *fakeSessionAuthService) GetActiveSessions(ctx context.Context, userID uuid.UUID) ([]*authinfra.Session, error) {
+	return f.sessions[userID], nil
+}
+
+func (f *fakeSessionAuthService) InvalidateSession(ctx context.Context, sessionID string) error {
+	f.invalidated = append(f.invalidated, sessionID)
+	return nil
+}
+
+func newTestSession(userID uuid.UUID, lastActiveAgo time.Duration) *authinfra.Session {
+	now := time.Now()
+	return &authinfra.Session{
+		ID:           uuid.New().String(),
+		UserID:       userID.String(),
+		DeviceInfo:   &authinfra.DeviceInfo{Platform: "iOS", Device: "mobile", Browser: "Safari"},
+		IPAddress:    "203.0.113.7",
+		UserAgent:    "Winkr/1.0",
+		LastActivity: now.Add(-lastActiveAgo),
+		CreatedAt:    now.Add(-24 * time.Hour),
+		ExpiresAt:    now.Add(24 * time.Hour),
+		IsActive:     true,
+	}
+}
+
+func TestGetSessionsUseCase_ListsActiveSessionsMarkingTheCurrentOne(t *testing.T) {
+	userID := uuid.New()
+	older := newTestSession(userID, time.Hour)
+	current := newTestSession(userID, time.Minute)
+	expired := newTestSession(userID, time.Minute)
+	expired.ExpiresAt = time.Now().Add(-time.Minute)
+	inactive := newTestSession(userID, time.Minute)
+	inactive.IsActive = false
+	otherUsers := newTestSession(uuid.New(), time.Minute)
+
+	authService := &fakeSessionAuthService{sessions: map[uuid.UUID][]*authinfra.Session{
+		userID: {older, expired, current, inactive, otherUsers},
+	}}
+
+	resp, err := NewGetSessionsUseCase(authService).Execute(context.Background(), &GetSessionsRequest{
+		UserID:           userID,
+		CurrentSessionID: current.ID,
+	})
+
+	require.NoError(t, err)
+	require.Len(t, resp.Data, 2)
+	assert.Equal(t, current.ID, resp.Data[0].ID, "most recently active first")
+	assert.True(t, resp.Data[0].IsCurrent)
+	assert.Equal(t, older.ID, resp.Data[1].ID)
+	assert.False(t, resp.Data[1].IsCurrent)
+	assert.Equal(t, "mobile", resp.Data[1].DeviceType)
+	assert.Equal(t, "203.0.113.7", resp.Data[1].IPAddress)
+	assert.Equal(t, older.CreatedAt.Format(time.RFC3339), resp.Data[1].CreatedAt)
+}
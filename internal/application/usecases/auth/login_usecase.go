@@ -6,20 +6,30 @@ import (
 	"github.com/google/uuid"
 	"github.com/22smeargle/winkr-backend/internal/domain/services"
 	"github.com/22smeargle/winkr-backend/pkg/errors"
+	"github.com/22smeargle/winkr-backend/pkg/logger"
 	"github.com/22smeargle/winkr-backend/pkg/utils"
 )
 
+// AccountLinkDetector records the devices and IP addresses accounts are used from to detect
+// one person running many accounts. RecordLogin returns true if the account was restricted.
+type AccountLinkDetector interface {
+	RecordLogin(ctx context.Context, userID uuid.UUID, deviceFingerprint, ipAddress string) (bool, error)
+}
+
 // LoginUseCase handles user login
 type LoginUseCase struct {
-	authService services.AuthService
-	jwtUtils   *utils.JWTUtils
+	authService  services.AuthService
+	jwtUtils     *utils.JWTUtils
+	accountLinks AccountLinkDetector
 }
 
-// NewLoginUseCase creates a new LoginUseCase instance
-func NewLoginUseCase(authService services.AuthService, jwtUtils *utils.JWTUtils) *LoginUseCase {
+// NewLoginUseCase creates a new LoginUseCase instance.
+// accountLinks may be nil, in which case logins are not checked for linked accounts.
+func NewLoginUseCase(authService services.AuthService, jwtUtils *utils.JWTUtils, accountLinks AccountLinkDetector) *LoginUseCase {
 	return &LoginUseCase{
-		authService: authService,
-		jwtUtils:   jwtUtils,
+		authService:  authService,
+		jwtUtils:     jwtUtils,
+		accountLinks: accountLinks,
 	}
 }
 
@@ -88,6 +98,13 @@ func (uc *LoginUseCase) Execute(ctx context.Context, req *LoginRequest) (*LoginR
 		return nil, err
 	}
 
+	if restricted := recordAccountLinks(ctx, uc.accountLinks, authResp.User.ID, deviceInfo.Fingerprint, req.IPAddress); restricted {
+		if err := uc.authService.LogoutFromAllDevices(ctx, authResp.User.ID); err != nil {
+			logger.Warn("Failed to end sessions of restricted account", err, "user_id", authResp.User.ID)
+		}
+		return nil, errors.ErrAccountRestricted
+	}
+
 	// Convert response
 	response := &LoginResponse{
 		User: &UserInfo{
@@ -107,4 +124,19 @@ func (uc *LoginUseCase) Execute(ctx context.Context, req *LoginRequest) (*LoginR
 	}
 
 	return response, nil
-}
\ No newline at end of file
+}
+
+// recordAccountLinks records the signals of a successful login or registration and reports whether
+// the account was restricted. Detection failures are logged and never block the user.
+func recordAccountLinks(ctx context.Context, accountLinks AccountLinkDetector, userID uuid.UUID, deviceFingerprint, ipAddress string) bool {
+	if accountLinks == nil {
+		return false
+	}
+
+	restricted, err := accountLinks.RecordLogin(ctx, userID, deviceFingerprint, ipAddress)
+	if err != nil {
+		logger.Warn("Failed to check for linked accounts", err, "user_id", userID)
+		return false
+	}
+	return restricted
+}
@@ -10,13 +10,16 @@ import (
 
 // RegisterUseCase handles user registration
 type RegisterUseCase struct {
-	authService services.AuthService
+	authService  services.AuthService
+	accountLinks AccountLinkDetector
 }
 
-// NewRegisterUseCase creates a new RegisterUseCase instance
-func NewRegisterUseCase(authService services.AuthService) *RegisterUseCase {
+// NewRegisterUseCase creates a new RegisterUseCase instance.
+// accountLinks may be nil, in which case new accounts are not checked for linked accounts.
+func NewRegisterUseCase(authService services.AuthService, accountLinks AccountLinkDetector) *RegisterUseCase {
 	return &RegisterUseCase{
-		authService: authService,
+		authService:  authService,
+		accountLinks: accountLinks,
 	}
 }
 
@@ -29,6 +32,10 @@ type RegisterRequest struct {
 	DateOfBirth  string   `json:"date_of_birth" validate:"required"`
 	Gender       string   `json:"gender" validate:"required,oneof=male female other"`
 	InterestedIn []string `json:"interested_in" validate:"required,min=1,dive,oneof=male female other"`
+
+	// Set by the handler from the request, used to detect linked accounts
+	DeviceFingerprint string `json:"-"`
+	IPAddress         string `json:"-"`
 }
 
 // RegisterResponse represents the registration response
@@ -74,6 +81,10 @@ func (uc *RegisterUseCase) Execute(ctx context.Context, req *RegisterRequest) (*
 		return nil, err
 	}
 
+	if restricted := recordAccountLinks(ctx, uc.accountLinks, authResp.User.ID, req.DeviceFingerprint, req.IPAddress); restricted {
+		return nil, errors.ErrAccountRestricted
+	}
+
 	// Convert response
 	response := &RegisterResponse{
 		User: &UserInfo{
@@ -0,0 +1,70 @@
+package auth
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/22smeargle/winkr-backend/internal/domain/services"
+	"github.com/22smeargle/winkr-backend/pkg/errors"
+	"github.com/22smeargle/winkr-backend/pkg/logger"
+)
+
+// RevokeSessionUseCase handles revoking one of a user's sessions, logging that device out
+type RevokeSessionUseCase struct {
+	authService services.AuthService
+}
+
+// NewRevokeSessionUseCase creates a new RevokeSessionUseCase instance
+func NewRevokeSessionUseCase(authService services.AuthService) *RevokeSessionUseCase {
+	return &RevokeSessionUseCase{
+		authService: authService,
+	}
+}
+
+// RevokeSessionRequest represents revoke session request
+type RevokeSessionRequest struct {
+	UserID           uuid.UUID `json:"user_id" validate:"required"`
+	SessionID        string    `json:"session_id" validate:"required"`
+	CurrentSessionID string    `json:"current_session_id,omitempty"` // The session of the token making the request
+}
+
+// RevokeSessionResponse represents revoke session response
+type RevokeSessionResponse struct {
+	Message   string `json:"message"`
+	IsCurrent bool   `json:"is_current"` // The user logged out the session they made the request from
+}
+
+// Execute revokes the session. Its refresh token stops working at once, because refreshing
+// checks the session. Sessions of other users are reported as not found.
+func (uc *RevokeSessionUseCase) Execute(ctx context.Context, req *RevokeSessionRequest) (*RevokeSessionResponse, error) {
+	if req.SessionID == "" {
+		return nil, errors.NewValidationError("session_id", "Session ID is required")
+	}
+
+	sessions, err := activeUserSessions(ctx, uc.authService, req.UserID)
+	if err != nil {
+		return nil, err
+	}
+
+	owned := false
+	for _, session := range sessions {
+		if session.ID == req.SessionID {
+			owned = true
+			break
+		}
+	}
+	if !owned {
+		return nil, errors.NewNotFoundError("Session")
+	}
+
+	if err := uc.authService.InvalidateSession(ctx, req.SessionID); err != nil {
+		return nil, errors.WrapError(err, "Failed to revoke session")
+	}
+
+	logger.Info("Session revoked", "user_id", req.UserID, "session_id", req.SessionID)
+
+	return &RevokeSessionResponse{
+		Message:   "Session revoked",
+		IsCurrent: req.SessionID == req.CurrentSessionID,
+	}, nil
+}
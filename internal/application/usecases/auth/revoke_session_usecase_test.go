@@ -0,0 +1,60 @@
+package auth
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	authinfra "github.com/22smeargle/winkr-backend/internal/infrastructure/auth"
+	"github.com/22smeargle/winkr-backend/pkg/errors"
+)
+
+func TestRevokeSessionUseCase_RevokesOwnSession(t *testing.T) {
+	userID := uuid.New()
+	current := newTestSession(userID, time.Minute)
+	other := newTestSession(userID, time.Hour)
+	authService := &fakeSessionAuthService{sessions: map[uuid.UUID][]*authinfra.Session{
+		userID: {current, other},
+	}}
+	useCase := NewRevokeSessionUseCase(authService)
+
+	resp, err := useCase.Execute(context.Background(), &RevokeSessionRequest{
+		UserID:           userID,
+		SessionID:        other.ID,
+		CurrentSessionID: current.ID,
+	})
+	require.NoError(t, err)
+	assert.False(t, resp.IsCurrent)
+
+	resp, err = useCase.Execute(context.Background(), &RevokeSessionRequest{
+		UserID:           userID,
+		SessionID:        current.ID,
+		CurrentSessionID: current.ID,
+	})
+	require.NoError(t, err)
+	assert.True(t, resp.IsCurrent)
+
+	assert.Equal(t, []string{other.ID, current.ID}, authService.invalidated)
+}
+
+func TestRevokeSessionUseCase_CannotRevokeOtherUsersSessions(t *testing.T) {
+	userID, otherUserID := uuid.New(), uuid.New()
+	otherUsers := newTestSession(otherUserID, time.Minute)
+	authService := &fakeSessionAuthService{sessions: map[uuid.UUID][]*authinfra.Session{
+		userID:      {newTestSession(userID, time.Minute)},
+		otherUserID: {otherUsers},
+	}}
+
+	_, err := NewRevokeSessionUseCase(authService).Execute(context.Background(), &RevokeSessionRequest{
+		UserID:    userID,
+		SessionID: otherUsers.ID,
+	})
+
+	require.Error(t, err)
+	assert.Equal(t, http.StatusNotFound, errors.GetAppError(err).Code)
+	assert.Empty(t, authService.invalidated)
+}
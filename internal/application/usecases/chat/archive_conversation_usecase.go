@@ -0,0 +1,167 @@
+package chat
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/22smeargle/winkr-backend/internal/domain/repositories"
+	"github.com/22smeargle/winkr-backend/pkg/logger"
+)
+
+// ArchiveConversationRequest represents a request to archive or unarchive a conversation
+type ArchiveConversationRequest struct {
+	ConversationID uuid.UUID `json:"conversation_id" validate:"required"`
+	UserID         uuid.UUID `json:"user_id" validate:"required"`
+}
+
+// ArchiveConversationResponse represents the response after archiving or unarchiving a conversation
+type ArchiveConversationResponse struct {
+	ConversationID  uuid.UUID `json:"conversation_id"`
+	Archived        bool      `json:"archived"`
+	Success         bool      `json:"success"`
+	Error           string    `json:"error,omitempty"`
+	UpgradeRequired bool      `json:"upgrade_required,omitempty"`
+}
+
+// ArchiveConversationUseCase handles archiving conversations. Archiving only affects the user
+// who archives, and archived conversations do not count towards the open conversation limit.
+type ArchiveConversationUseCase struct {
+	messageRepo repositories.MessageRepository
+	limiter     *ConversationLimiter
+}
+
+// NewArchiveConversationUseCase creates a new archive conversation use case
+func NewArchiveConversationUseCase(messageRepo repositories.MessageRepository, limiter *ConversationLimiter) *ArchiveConversationUseCase {
+	return &ArchiveConversationUseCase{
+		messageRepo: messageRepo,
+		limiter:     limiter,
+	}
+}
+
+// Archive archives a conversation for the user
+func (uc *ArchiveConversationUseCase) Archive(ctx context.Context, req *ArchiveConversationRequest) (*ArchiveConversationResponse, error) {
+	if resp := uc.checkAccess(ctx, req); resp != nil {
+		return resp, nil
+	}
+
+	if err := uc.messageRepo.ArchiveConversation(ctx, req.ConversationID, req.UserID); err != nil {
+		logger.Error("Failed to archive conversation", err)
+		return &ArchiveConversationResponse{
+			Success: false,
+			Error:   "Failed to archive conversation",
+		}, nil
+	}
+
+	logger.Info("Conversation archived successfully",
+		"conversation_id", req.ConversationID,
+		"user_id", req.UserID,
+	)
+
+	return &ArchiveConversationResponse{
+		ConversationID: req.ConversationID,
+		Archived:       true,
+		Success:        true,
+	}, nil
+}
+
+// Unarchive moves a conversation out of the user's archive. It reopens the conversation,
+// so free users at the open conversation limit cannot unarchive.
+func (uc *ArchiveConversationUseCase) Unarchive(ctx context.Context, req *ArchiveConversationRequest) (*ArchiveConversationResponse, error) {
+	if resp := uc.checkAccess(ctx, req); resp != nil {
+		return resp, nil
+	}
+
+	archived, err := uc.messageRepo.IsConversationArchived(ctx, req.ConversationID, req.UserID)
+	if err != nil {
+		logger.Error("Failed to check conversation archive", err)
+		return &ArchiveConversationResponse{
+			Success: false,
+			Error:   "Failed to unarchive conversation",
+		}, nil
+	}
+
+	if !archived {
+		return &ArchiveConversationResponse{
+			ConversationID: req.ConversationID,
+			Archived:       false,
+			Success:        true,
+		}, nil
+	}
+
+	limitReached, err := uc.limiter.LimitReached(ctx, req.UserID, req.ConversationID)
+	if err != nil {
+		logger.Error("Failed to check open conversation limit", err)
+		return &ArchiveConversationResponse{
+			Success: false,
+			Error:   "Failed to unarchive conversation",
+		}, nil
+	}
+
+	if limitReached {
+		return &ArchiveConversationResponse{
+			Success:         false,
+			Error:           uc.limiter.UpsellMessage(),
+			UpgradeRequired: true,
+		}, nil
+	}
+
+	if err := uc.messageRepo.UnarchiveConversation(ctx, req.ConversationID, req.UserID); err != nil {
+		logger.Error("Failed to unarchive conversation", err)
+		return &ArchiveConversationResponse{
+			Success: false,
+			Error:   "Failed to unarchive conversation",
+		}, nil
+	}
+
+	logger.Info("Conversation unarchived successfully",
+		"conversation_id", req.ConversationID,
+		"user_id", req.UserID,
+	)
+
+	return &ArchiveConversationResponse{
+		ConversationID: req.ConversationID,
+		Archived:       false,
+		Success:        true,
+	}, nil
+}
+
+// checkAccess validates the request and checks the user is a participant.
+// A non-nil response means the request was rejected.
+func (uc *ArchiveConversationUseCase) checkAccess(ctx context.Context, req *ArchiveConversationRequest) *ArchiveConversationResponse {
+	if err := req.Validate(); err != nil {
+		return &ArchiveConversationResponse{
+			Success: false,
+			Error:   err.Error(),
+		}
+	}
+
+	canAccess, err := uc.messageRepo.UserCanAccessConversation(ctx, req.UserID, req.ConversationID)
+	if err != nil {
+		logger.Error("Failed to check conversation access", err)
+		return &ArchiveConversationResponse{
+			Success: false,
+			Error:   "Failed to check conversation access",
+		}
+	}
+
+	if !canAccess {
+		return &ArchiveConversationResponse{
+			Success: false,
+			Error:   "User cannot access this conversation",
+		}
+	}
+
+	return nil
+}
+
+// Validate validates the request
+func (req *ArchiveConversationRequest) Validate() error {
+	if req.ConversationID == uuid.Nil {
+		return fmt.Errorf("conversation_id is required")
+	}
+	if req.UserID == uuid.Nil {
+		return fmt.Errorf("user_id is required")
+	}
+	return nil
+}
@@ -0,0 +1,89 @@
+package chat
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/22smeargle/winkr-backend/internal/domain/repositories"
+	"github.com/22smeargle/winkr-backend/pkg/config"
+)
+
+// ConversationLimiter caps how many open conversations a free user can have at once.
+// Users with an active premium or platinum subscription are not limited.
+type ConversationLimiter struct {
+	messageRepo      repositories.MessageRepository
+	subscriptionRepo repositories.SubscriptionRepository
+	config           *config.MessageConfig
+}
+
+// NewConversationLimiter creates a new conversation limiter
+func NewConversationLimiter(
+	messageRepo repositories.MessageRepository,
+	subscriptionRepo repositories.SubscriptionRepository,
+	cfg *config.MessageConfig,
+) *ConversationLimiter {
+	return &ConversationLimiter{
+		messageRepo:      messageRepo,
+		subscriptionRepo: subscriptionRepo,
+		config:           cfg,
+	}
+}
+
+// LimitReached reports whether the user already has as many open conversations as allowed,
+// not counting conversationID (uuid.Nil for a conversation that does not exist yet)
+func (l *ConversationLimiter) LimitReached(ctx context.Context, userID, conversationID uuid.UUID) (bool, error) {
+	if l == nil || l.config.MaxFreeOpenConversations <= 0 {
+		return false, nil
+	}
+
+	open, err := l.messageRepo.CountOpenConversations(ctx, userID, conversationID)
+	if err != nil {
+		return false, err
+	}
+
+	if open < int64(l.config.MaxFreeOpenConversations) {
+		return false, nil
+	}
+
+	// Only look up the subscription once the user is at the limit
+	return !l.hasPaidPlan(ctx, userID), nil
+}
+
+// LimitReachedForMessage reports whether a message from the user would open one conversation too many.
+// Only the user's first message in a conversation can do that.
+func (l *ConversationLimiter) LimitReachedForMessage(ctx context.Context, userID, conversationID uuid.UUID) (bool, error) {
+	if l == nil || l.config.MaxFreeOpenConversations <= 0 {
+		return false, nil
+	}
+
+	hasSent, err := l.messageRepo.HasSentMessages(ctx, conversationID, userID)
+	if err != nil {
+		return false, err
+	}
+	if hasSent {
+		return false, nil
+	}
+
+	return l.LimitReached(ctx, userID, conversationID)
+}
+
+// UpsellMessage returns the message shown to free users at the limit
+func (l *ConversationLimiter) UpsellMessage() string {
+	return fmt.Sprintf(
+		"Free accounts can have %d open conversations at once. Upgrade to Premium for unlimited conversations, or archive a conversation to make room.",
+		l.config.MaxFreeOpenConversations,
+	)
+}
+
+// hasPaidPlan checks if the user has an active premium or platinum subscription, or one in its grace period.
+// Users without a subscription are on the free plan.
+func (l *ConversationLimiter) hasPaidPlan(ctx context.Context, userID uuid.UUID) bool {
+	subscription, err := l.subscriptionRepo.GetActiveUserSubscription(ctx, userID)
+	if err != nil || subscription == nil {
+		return false
+	}
+
+	return subscription.HasPremiumAccess(time.Now()) && subscription.IsPaidPlan()
+}
@@ -0,0 +1,258 @@
+package chat
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"github.com/22smeargle/winkr-backend/internal/domain/entities"
+	"github.com/22smeargle/winkr-backend/internal/domain/repositories"
+	"github.com/22smeargle/winkr-backend/pkg/config"
+)
+
+func (m *MockMessageRepository) CountOpenConversations(ctx context.Context, userID, excludeConversationID uuid.UUID) (int64, error) {
+	args := m.Called(ctx, userID, excludeConversationID)
+	return args.Get(0).(int64), args.Error(1)
+}
+
+func (m *MockMessageRepository) HasSentMessages(ctx context.Context, conversationID, userID uuid.UUID) (bool, error) {
+	args := m.Called(ctx, conversationID, userID)
+	return args.Bool(0), args.Error(1)
+}
+
+func (m *MockMessageRepository) IsConversationArchived(ctx context.Context, conversationID, userID uuid.UUID) (bool, error) {
+	args := m.Called(ctx, conversationID, userID)
+	return args.Bool(0), args.Error(1)
+}
+
+func (m *MockMessageRepository) UnarchiveConversation(ctx context.Context, conversationID, userID uuid.UUID) error {
+	args := m.Called(ctx, conversationID, userID)
+	return args.Error(0)
+}
+
+func (m *MockMessageRepository) GetConversationByMatchID(ctx context.Context, matchID uuid.UUID) (*entities.Conversation, error) {
+	args := m.Called(ctx, matchID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entities.Conversation), args.Error(1)
+}
+
+func (m *MockMessageRepository) CreateConversation(ctx context.Context, conversation *entities.Conversation) error {
+	args := m.Called(ctx, conversation)
+	return args.Error(0)
+}
+
+// MockMatchRepository is a mock implementation of the match repository methods used by chat use cases
+type MockMatchRepository struct {
+	repositories.MatchRepository
+	mock.Mock
+}
+
+func (m *MockMatchRepository) GetByID(ctx context.Context, id uuid.UUID) (*entities.Match, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entities.Match), args.Error(1)
+}
+
+// MockSubscriptionRepository is a mock implementation of the subscription repository methods used by chat use cases
+type MockSubscriptionRepository struct {
+	repositories.SubscriptionRepository
+	mock.Mock
+}
+
+func (m *MockSubscriptionRepository) GetActiveUserSubscription(ctx context.Context, userID uuid.UUID) (*entities.Subscription, error) {
+	args := m.Called(ctx, userID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entities.Subscription), args.Error(1)
+}
+
+func testConversationLimitConfig() *config.MessageConfig {
+	return &config.MessageConfig{MaxFreeOpenConversations: 3}
+}
+
+func freeUserSubscriptions(userID uuid.UUID) *MockSubscriptionRepository {
+	subscriptionRepo := new(MockSubscriptionRepository)
+	subscriptionRepo.On("GetActiveUserSubscription", mock.Anything, userID).Return(nil, errors.New("active subscription not found"))
+	return subscriptionRepo
+}
+
+func paidUserSubscriptions(userID uuid.UUID, planType string) *MockSubscriptionRepository {
+	subscriptionRepo := new(MockSubscriptionRepository)
+	subscriptionRepo.On("GetActiveUserSubscription", mock.Anything, userID).Return(&entities.Subscription{
+		ID:       uuid.New(),
+		UserID:   userID,
+		PlanType: planType,
+		Status:   "active",
+	}, nil)
+	return subscriptionRepo
+}
+
+func newLimitTestMatch(userID uuid.UUID) (*entities.Match, *MockMatchRepository) {
+	match := &entities.Match{ID: uuid.New(), User1ID: userID, User2ID: uuid.New(), IsActive: true}
+	matchRepo := new(MockMatchRepository)
+	matchRepo.On("GetByID", mock.Anything, match.ID).Return(match, nil)
+	return match, matchRepo
+}
+
+func TestStartConversationUseCase_FreeUserAtLimit(t *testing.T) {
+	userID := uuid.New()
+	match, matchRepo := newLimitTestMatch(userID)
+
+	messageRepo := new(MockMessageRepository)
+	messageRepo.On("GetConversationByMatchID", mock.Anything, match.ID).Return(nil, errors.New("conversation not found"))
+	messageRepo.On("CountOpenConversations", mock.Anything, userID, uuid.Nil).Return(int64(3), nil)
+
+	limiter := NewConversationLimiter(messageRepo, freeUserSubscriptions(userID), testConversationLimitConfig())
+	useCase := NewStartConversationUseCase(messageRepo, matchRepo, limiter)
+
+	resp, err := useCase.Execute(context.Background(), &StartConversationRequest{
+		UserID:  userID,
+		MatchID: match.ID,
+	})
+
+	require.NoError(t, err)
+	assert.True(t, resp.UpgradeRequired)
+	assert.Nil(t, resp.Conversation)
+	assert.Equal(t, "Free accounts can have 3 open conversations at once. Upgrade to Premium for unlimited conversations, or archive a conversation to make room.", resp.Error)
+	messageRepo.AssertNotCalled(t, "CreateConversation", mock.Anything, mock.Anything)
+}
+
+func TestStartConversationUseCase_FreeUserBelowLimit(t *testing.T) {
+	userID := uuid.New()
+	match, matchRepo := newLimitTestMatch(userID)
+
+	messageRepo := new(MockMessageRepository)
+	messageRepo.On("GetConversationByMatchID", mock.Anything, match.ID).Return(nil, errors.New("conversation not found"))
+	messageRepo.On("CountOpenConversations", mock.Anything, userID, uuid.Nil).Return(int64(2), nil)
+	messageRepo.On("CreateConversation", mock.Anything, mock.Anything).Return(nil)
+
+	subscriptionRepo := new(MockSubscriptionRepository)
+	limiter := NewConversationLimiter(messageRepo, subscriptionRepo, testConversationLimitConfig())
+	useCase := NewStartConversationUseCase(messageRepo, matchRepo, limiter)
+
+	resp, err := useCase.Execute(context.Background(), &StartConversationRequest{
+		UserID:  userID,
+		MatchID: match.ID,
+	})
+
+	require.NoError(t, err)
+	assert.Empty(t, resp.Error)
+	assert.False(t, resp.UpgradeRequired)
+	require.NotNil(t, resp.Conversation)
+	subscriptionRepo.AssertNotCalled(t, "GetActiveUserSubscription", mock.Anything, mock.Anything)
+}
+
+func TestStartConversationUseCase_PaidPlansBypassLimit(t *testing.T) {
+	for _, planType := range []string{"premium", "platinum"} {
+		t.Run(planType, func(t *testing.T) {
+			userID := uuid.New()
+			match, matchRepo := newLimitTestMatch(userID)
+
+			messageRepo := new(MockMessageRepository)
+			messageRepo.On("GetConversationByMatchID", mock.Anything, match.ID).Return(nil, errors.New("conversation not found"))
+			messageRepo.On("CountOpenConversations", mock.Anything, userID, uuid.Nil).Return(int64(25), nil)
+			messageRepo.On("CreateConversation", mock.Anything, mock.Anything).Return(nil)
+
+			limiter := NewConversationLimiter(messageRepo, paidUserSubscriptions(userID, planType), testConversationLimitConfig())
+			useCase := NewStartConversationUseCase(messageRepo, matchRepo, limiter)
+
+			resp, err := useCase.Execute(context.Background(), &StartConversationRequest{
+				UserID:  userID,
+				MatchID: match.ID,
+			})
+
+			require.NoError(t, err)
+			assert.Empty(t, resp.Error)
+			assert.False(t, resp.UpgradeRequired)
+			require.NotNil(t, resp.Conversation)
+			messageRepo.AssertCalled(t, "CreateConversation", mock.Anything, mock.Anything)
+		})
+	}
+}
+
+func TestSendMessageUseCase_FirstMessageAtLimit(t *testing.T) {
+	senderID := uuid.New()
+	match := &entities.Match{ID: uuid.New(), User1ID: senderID, User2ID: uuid.New(), IsActive: true}
+	conversation := &entities.Conversation{ID: uuid.New(), MatchID: match.ID}
+
+	messageRepo := new(MockMessageRepository)
+	messageRepo.On("UserCanAccessConversation", mock.Anything, senderID, conversation.ID).Return(true, nil)
+	messageRepo.On("GetConversation", mock.Anything, conversation.ID).Return(conversation, nil)
+	messageRepo.On("HasSentMessages", mock.Anything, conversation.ID, senderID).Return(false, nil)
+	messageRepo.On("CountOpenConversations", mock.Anything, senderID, conversation.ID).Return(int64(3), nil)
+	matchRepo := new(MockMatchRepository)
+	matchRepo.On("GetByID", mock.Anything, match.ID).Return(match, nil)
+
+	limiter := NewConversationLimiter(messageRepo, freeUserSubscriptions(senderID), testConversationLimitConfig())
+	useCase := NewSendMessageUseCase(messageRepo, nil, matchRepo, nil, nil, nil, nil, nil, nil, limiter, nil, nil, nil)
+
+	resp, err := useCase.Execute(context.Background(), &SendMessageRequest{
+		ConversationID: conversation.ID,
+		SenderID:       senderID,
+		Content:        "hey, loved your hiking photos",
+		MessageType:    "text",
+	})
+
+	require.NoError(t, err)
+	assert.False(t, resp.Success)
+	assert.True(t, resp.UpgradeRequired)
+	assert.Contains(t, resp.Error, "Upgrade to Premium")
+	messageRepo.AssertNotCalled(t, "Create", mock.Anything, mock.Anything)
+}
+
+func TestConversationLimiter_OnlyFirstMessageCounts(t *testing.T) {
+	userID := uuid.New()
+	conversationID := uuid.New()
+
+	messageRepo := new(MockMessageRepository)
+	messageRepo.On("HasSentMessages", mock.Anything, conversationID, userID).Return(true, nil)
+
+	limiter := NewConversationLimiter(messageRepo, freeUserSubscriptions(userID), testConversationLimitConfig())
+
+	limitReached, err := limiter.LimitReachedForMessage(context.Background(), userID, conversationID)
+	require.NoError(t, err)
+	assert.False(t, limitReached)
+	messageRepo.AssertNotCalled(t, "CountOpenConversations", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestConversationLimiter_Disabled(t *testing.T) {
+	messageRepo := new(MockMessageRepository)
+	limiter := NewConversationLimiter(messageRepo, new(MockSubscriptionRepository), &config.MessageConfig{})
+
+	limitReached, err := limiter.LimitReached(context.Background(), uuid.New(), uuid.Nil)
+	require.NoError(t, err)
+	assert.False(t, limitReached)
+	messageRepo.AssertNotCalled(t, "CountOpenConversations", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestArchiveConversationUseCase_UnarchiveAtLimit(t *testing.T) {
+	userID := uuid.New()
+	conversationID := uuid.New()
+
+	messageRepo := new(MockMessageRepository)
+	messageRepo.On("UserCanAccessConversation", mock.Anything, userID, conversationID).Return(true, nil)
+	messageRepo.On("IsConversationArchived", mock.Anything, conversationID, userID).Return(true, nil)
+	messageRepo.On("CountOpenConversations", mock.Anything, userID, conversationID).Return(int64(3), nil)
+
+	limiter := NewConversationLimiter(messageRepo, freeUserSubscriptions(userID), testConversationLimitConfig())
+	useCase := NewArchiveConversationUseCase(messageRepo, limiter)
+
+	resp, err := useCase.Unarchive(context.Background(), &ArchiveConversationRequest{
+		ConversationID: conversationID,
+		UserID:         userID,
+	})
+
+	require.NoError(t, err)
+	assert.False(t, resp.Success)
+	assert.True(t, resp.UpgradeRequired)
+	messageRepo.AssertNotCalled(t, "UnarchiveConversation", mock.Anything, mock.Anything, mock.Anything)
+}
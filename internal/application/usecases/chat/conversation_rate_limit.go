@@ -0,0 +1,69 @@
+package chat
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/22smeargle/winkr-backend/internal/infrastructure/cache"
+	"github.com/22smeargle/winkr-backend/pkg/config"
+	"github.com/22smeargle/winkr-backend/pkg/logger"
+)
+
+// conversationMessagesEndpoint is the rate limit endpoint messages to a single conversation are counted under
+const conversationMessagesEndpoint = "conversation_messages"
+
+// MessageRateLimiter counts requests in a sliding window
+type MessageRateLimiter interface {
+	CheckRateLimit(ctx context.Context, config cache.RateLimitConfig, identifier string) (*cache.RateLimitResult, error)
+}
+
+// ConversationRateLimiter caps how many messages one user can send to a single conversation per minute,
+// on top of the per-user limit across all conversations. Each sender has their own window, so the other
+// participant's messages never count against it.
+type ConversationRateLimiter struct {
+	rateLimiter MessageRateLimiter
+	config      *config.ChatRateLimitConfig
+}
+
+// NewConversationRateLimiter creates a new conversation rate limiter
+func NewConversationRateLimiter(rateLimiter MessageRateLimiter, cfg *config.ChatRateLimitConfig) *ConversationRateLimiter {
+	return &ConversationRateLimiter{
+		rateLimiter: rateLimiter,
+		config:      cfg,
+	}
+}
+
+// Allow counts a message from the sender to the conversation and reports whether it may be sent, and
+// if not, how long until it may. System messages are not counted. Failures are logged and the message
+// is allowed so chat keeps working when Redis is unavailable.
+func (l *ConversationRateLimiter) Allow(ctx context.Context, senderID, conversationID uuid.UUID, messageType string) (bool, time.Duration) {
+	if l == nil || l.config == nil || l.config.MessagesPerConversationPerMinute <= 0 || messageType == "system" {
+		return true, 0
+	}
+
+	result, err := l.rateLimiter.CheckRateLimit(ctx, cache.RateLimitConfig{
+		Requests: l.config.MessagesPerConversationPerMinute,
+		Window:   time.Minute,
+		KeyType:  "user",
+		Endpoint: conversationMessagesEndpoint,
+	}, conversationID.String()+":"+senderID.String())
+	if err != nil {
+		logger.Error("Failed to check conversation message rate limit", err,
+			"conversation_id", conversationID,
+			"sender_id", senderID,
+		)
+		return true, 0
+	}
+
+	if result.Allowed {
+		return true, 0
+	}
+
+	logger.Warn("Conversation message rate limit exceeded",
+		"conversation_id", conversationID,
+		"sender_id", senderID,
+		"retry_after", result.RetryAfter,
+	)
+	return false, result.RetryAfter
+}
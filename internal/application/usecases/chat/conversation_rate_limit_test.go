@@ -0,0 +1,75 @@
+package chat
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"github.com/22smeargle/winkr-backend/internal/domain/entities"
+	"github.com/22smeargle/winkr-backend/internal/infrastructure/cache"
+	"github.com/22smeargle/winkr-backend/pkg/config"
+)
+
+func newTestConversationRateLimiter(messagesPerMinute int) *ConversationRateLimiter {
+	rateLimiter := cache.NewRateLimiterWithStore(cache.NewMemoryRateLimitStore())
+	return NewConversationRateLimiter(rateLimiter, &config.ChatRateLimitConfig{MessagesPerConversationPerMinute: messagesPerMinute})
+}
+
+func TestConversationRateLimiter_LimitsSenderPerConversation(t *testing.T) {
+	ctx := context.Background()
+	senderID := uuid.New()
+	recipientID := uuid.New()
+	conversationID := uuid.New()
+	limiter := newTestConversationRateLimiter(2)
+
+	for i := 0; i < 2; i++ {
+		allowed, _ := limiter.Allow(ctx, senderID, conversationID, "text")
+		require.True(t, allowed)
+	}
+
+	allowed, retryAfter := limiter.Allow(ctx, senderID, conversationID, "text")
+	assert.False(t, allowed)
+	assert.Positive(t, retryAfter)
+
+	// The other participant, the sender's other conversations and system messages are not limited
+	allowed, _ = limiter.Allow(ctx, recipientID, conversationID, "text")
+	assert.True(t, allowed)
+	allowed, _ = limiter.Allow(ctx, senderID, uuid.New(), "text")
+	assert.True(t, allowed)
+	allowed, _ = limiter.Allow(ctx, senderID, conversationID, "system")
+	assert.True(t, allowed)
+}
+
+func TestSendMessageUseCase_RateLimitedInConversation(t *testing.T) {
+	senderID := uuid.New()
+	match := &entities.Match{ID: uuid.New(), User1ID: senderID, User2ID: uuid.New(), IsActive: true}
+	conversation := &entities.Conversation{ID: uuid.New(), MatchID: match.ID}
+
+	messageRepo := new(MockMessageRepository)
+	messageRepo.On("UserCanAccessConversation", mock.Anything, senderID, conversation.ID).Return(true, nil)
+	messageRepo.On("GetConversation", mock.Anything, conversation.ID).Return(conversation, nil)
+	matchRepo := new(MockMatchRepository)
+	matchRepo.On("GetByID", mock.Anything, match.ID).Return(match, nil)
+
+	rateLimiter := newTestConversationRateLimiter(1)
+	allowed, _ := rateLimiter.Allow(context.Background(), senderID, conversation.ID, "text")
+	require.True(t, allowed)
+
+	useCase := NewSendMessageUseCase(messageRepo, nil, matchRepo, nil, nil, nil, nil, nil, nil, nil, rateLimiter, nil, nil)
+
+	resp, err := useCase.Execute(context.Background(), &SendMessageRequest{
+		ConversationID: conversation.ID,
+		SenderID:       senderID,
+		Content:        "hello?? hello???",
+		MessageType:    "text",
+	})
+
+	require.NoError(t, err)
+	assert.False(t, resp.Success)
+	assert.Equal(t, ErrorCodeRateLimited, resp.ErrorCode)
+	assert.Positive(t, resp.RetryAfter)
+	messageRepo.AssertNotCalled(t, "Create", mock.Anything, mock.Anything)
+}
@@ -3,38 +3,44 @@ package chat
 import (
 	"context"
 	"fmt"
-	"time"
 
 	"github.com/google/uuid"
+	"github.com/22smeargle/winkr-backend/internal/domain/entities"
 	"github.com/22smeargle/winkr-backend/internal/domain/repositories"
+	"github.com/22smeargle/winkr-backend/pkg/config"
 	"github.com/22smeargle/winkr-backend/pkg/logger"
 )
 
 // DeleteMessageRequest represents a request to delete a message
 type DeleteMessageRequest struct {
-	MessageID uuid.UUID `json:"message_id" validate:"required"`
-	UserID    uuid.UUID `json:"user_id" validate:"required"`
+	ConversationID uuid.UUID `json:"conversation_id" validate:"required"`
+	MessageID      uuid.UUID `json:"message_id" validate:"required"`
+	UserID         uuid.UUID `json:"user_id" validate:"required"`
 }
 
 // DeleteMessageResponse represents the response after deleting a message
 type DeleteMessageResponse struct {
-	Success bool   `json:"success"`
-	Error   string `json:"error,omitempty"`
+	Message *entities.Message `json:"message,omitempty"`
+	Success bool              `json:"success"`
+	Error   string            `json:"error,omitempty"`
 }
 
 // DeleteMessageUseCase handles deleting a message
 type DeleteMessageUseCase struct {
 	messageRepo repositories.MessageRepository
+	config      *config.MessageConfig
 }
 
 // NewDeleteMessageUseCase creates a new delete message use case
-func NewDeleteMessageUseCase(messageRepo repositories.MessageRepository) *DeleteMessageUseCase {
+func NewDeleteMessageUseCase(messageRepo repositories.MessageRepository, cfg *config.MessageConfig) *DeleteMessageUseCase {
 	return &DeleteMessageUseCase{
 		messageRepo: messageRepo,
+		config:      cfg,
 	}
 }
 
-// Execute deletes a message after validation
+// Execute deletes a message within the configured delete window. Only its sender can delete it.
+// The message is kept as a tombstone so the conversation keeps its order.
 func (uc *DeleteMessageUseCase) Execute(ctx context.Context, req *DeleteMessageRequest) (*DeleteMessageResponse, error) {
 	// Validate request
 	if err := req.Validate(); err != nil {
@@ -44,37 +50,26 @@ func (uc *DeleteMessageUseCase) Execute(ctx context.Context, req *DeleteMessageR
 		}, nil
 	}
 
-	// Get message to verify ownership and permissions
-	message, err := uc.messageRepo.GetByID(ctx, req.MessageID)
-	if err != nil {
-		logger.Error("Failed to get message", err)
+	message, reason := loadOwnMessage(ctx, uc.messageRepo, req.ConversationID, req.MessageID, req.UserID)
+	if message == nil {
 		return &DeleteMessageResponse{
 			Success: false,
-			Error:   "Message not found",
+			Error:   reason,
 		}, nil
 	}
 
-	// Check if user is the sender
-	if message.SenderID != req.UserID {
+	if message.IsDeleted {
+		message.Tombstone()
 		return &DeleteMessageResponse{
-			Success: false,
-			Error:   "User can only delete their own messages",
-		}, nil
-	}
-
-	// Check if message can be deleted
-	if !message.CanBeDeleted() {
-		return &DeleteMessageResponse{
-			Success: false,
-			Error:   "Message cannot be deleted",
+			Message: message,
+			Success: true,
 		}, nil
 	}
 
-	// Check if message is too old to delete (24 hours)
-	if time.Since(message.CreatedAt) > 24*time.Hour {
+	if !message.CanBeDeleted(uc.config.DeleteWindow) {
 		return &DeleteMessageResponse{
 			Success: false,
-			Error:   "Message can only be deleted within 24 hours of sending",
+			Error:   fmt.Sprintf("Message can only be deleted within %s of sending", uc.config.DeleteWindow),
 		}, nil
 	}
 
@@ -87,19 +82,26 @@ func (uc *DeleteMessageUseCase) Execute(ctx context.Context, req *DeleteMessageR
 		}, nil
 	}
 
-	logger.Info("Message deleted successfully", 
+	message.SoftDelete()
+	message.Tombstone()
+
+	logger.Info("Message deleted successfully",
 		"message_id", req.MessageID,
+		"conversation_id", req.ConversationID,
 		"user_id", req.UserID,
-		"deleted_at", time.Now(),
 	)
 
 	return &DeleteMessageResponse{
+		Message: message,
 		Success: true,
 	}, nil
 }
 
 // Validate validates the request
 func (req *DeleteMessageRequest) Validate() error {
+	if req.ConversationID == uuid.Nil {
+		return fmt.Errorf("conversation_id is required")
+	}
 	if req.MessageID == uuid.Nil {
 		return fmt.Errorf("message_id is required")
 	}
@@ -0,0 +1,137 @@
+package chat
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/google/uuid"
+	"github.com/22smeargle/winkr-backend/internal/application/services"
+	"github.com/22smeargle/winkr-backend/internal/domain/entities"
+	"github.com/22smeargle/winkr-backend/internal/domain/repositories"
+	"github.com/22smeargle/winkr-backend/pkg/config"
+	"github.com/22smeargle/winkr-backend/pkg/logger"
+)
+
+// EditMessageRequest represents a request to edit a message
+type EditMessageRequest struct {
+	ConversationID uuid.UUID `json:"conversation_id" validate:"required"`
+	MessageID      uuid.UUID `json:"message_id" validate:"required"`
+	UserID         uuid.UUID `json:"user_id" validate:"required"`
+	Content        string    `json:"content" validate:"required"`
+}
+
+// EditMessageResponse represents the response after editing a message
+type EditMessageResponse struct {
+	Message *entities.Message `json:"message,omitempty"`
+	Success bool              `json:"success"`
+	Error   string            `json:"error,omitempty"`
+}
+
+// EditMessageUseCase handles editing a message's content
+type EditMessageUseCase struct {
+	messageRepo repositories.MessageRepository
+	redactor    *services.MessagePIIRedactor
+	config      *config.MessageConfig
+}
+
+// NewEditMessageUseCase creates a new edit message use case. The redactor may be nil, in which
+// case edited content is stored as is.
+func NewEditMessageUseCase(messageRepo repositories.MessageRepository, redactor *services.MessagePIIRedactor, cfg *config.MessageConfig) *EditMessageUseCase {
+	return &EditMessageUseCase{
+		messageRepo: messageRepo,
+		redactor:    redactor,
+		config:      cfg,
+	}
+}
+
+// Execute replaces the content of a text message. Only its sender can edit it, and only within
+// the configured edit window. The message keeps its place in the conversation and is marked edited.
+// PII in the new content is redacted the same way as in sent messages.
+func (uc *EditMessageUseCase) Execute(ctx context.Context, req *EditMessageRequest) (*EditMessageResponse, error) {
+	if err := req.Validate(uc.config.MaxTextLength); err != nil {
+		return &EditMessageResponse{
+			Success: false,
+			Error:   err.Error(),
+		}, nil
+	}
+
+	message, reason := loadOwnMessage(ctx, uc.messageRepo, req.ConversationID, req.MessageID, req.UserID)
+	if message == nil {
+		return &EditMessageResponse{
+			Success: false,
+			Error:   reason,
+		}, nil
+	}
+
+	if !message.CanBeEdited(uc.config.EditWindow) {
+		return &EditMessageResponse{
+			Success: false,
+			Error:   fmt.Sprintf("Only text messages can be edited, within %s of sending", uc.config.EditWindow),
+		}, nil
+	}
+
+	content := strings.TrimSpace(req.Content)
+	if content == message.Content {
+		return &EditMessageResponse{
+			Message: message,
+			Success: true,
+		}, nil
+	}
+
+	message.Edit(content)
+	uc.redactor.Redact(message)
+	if err := uc.messageRepo.EditMessage(ctx, message.ID, message.Content, message.EncryptedOriginal, *message.EditedAt); err != nil {
+		logger.Error("Failed to edit message", err)
+		return &EditMessageResponse{
+			Success: false,
+			Error:   "Failed to edit message",
+		}, nil
+	}
+
+	logger.Info("Message edited successfully",
+		"message_id", req.MessageID,
+		"conversation_id", req.ConversationID,
+		"user_id", req.UserID,
+	)
+
+	return &EditMessageResponse{
+		Message: message,
+		Success: true,
+	}, nil
+}
+
+// Validate validates the request against the longest content allowed
+func (req *EditMessageRequest) Validate(maxLength int) error {
+	if req.ConversationID == uuid.Nil {
+		return fmt.Errorf("conversation_id is required")
+	}
+	if req.MessageID == uuid.Nil {
+		return fmt.Errorf("message_id is required")
+	}
+	if req.UserID == uuid.Nil {
+		return fmt.Errorf("user_id is required")
+	}
+	if strings.TrimSpace(req.Content) == "" {
+		return fmt.Errorf("content is required")
+	}
+	if maxLength > 0 && len(req.Content) > maxLength {
+		return fmt.Errorf("content too long (max %d characters)", maxLength)
+	}
+	return nil
+}
+
+// loadOwnMessage returns the message if it belongs to the conversation and the user sent it.
+// A nil message comes with the reason the user cannot change it.
+func loadOwnMessage(ctx context.Context, messageRepo repositories.MessageRepository, conversationID, messageID, userID uuid.UUID) (*entities.Message, string) {
+	message, err := messageRepo.GetByID(ctx, messageID)
+	if err != nil || message.ConversationID != conversationID {
+		return nil, "Message not found"
+	}
+
+	if message.SenderID != userID {
+		return nil, "User can only change their own messages"
+	}
+
+	return message, ""
+}
@@ -0,0 +1,193 @@
+package chat
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"github.com/22smeargle/winkr-backend/internal/application/services"
+	"github.com/22smeargle/winkr-backend/internal/domain/entities"
+	"github.com/22smeargle/winkr-backend/pkg/config"
+)
+
+func (m *MockMessageRepository) EditMessage(ctx context.Context, messageID uuid.UUID, content string, encryptedOriginal *string, editedAt time.Time) error {
+	args := m.Called(ctx, messageID, content, encryptedOriginal, editedAt)
+	return args.Error(0)
+}
+
+func (m *MockMessageRepository) SoftDeleteMessage(ctx context.Context, messageID uuid.UUID) error {
+	args := m.Called(ctx, messageID)
+	return args.Error(0)
+}
+
+func testEditConfig() *config.MessageConfig {
+	return &config.MessageConfig{
+		MaxTextLength: 20,
+		EditWindow:    15 * time.Minute,
+		DeleteWindow:  24 * time.Hour,
+	}
+}
+
+func newEditTestMessage(sentAgo time.Duration) *entities.Message {
+	return &entities.Message{
+		ID:             uuid.New(),
+		ConversationID: uuid.New(),
+		SenderID:       uuid.New(),
+		Content:        "see you at 7",
+		MessageType:    "text",
+		CreatedAt:      time.Now().Add(-sentAgo),
+	}
+}
+
+func TestEditMessageUseCase_Edit(t *testing.T) {
+	message := newEditTestMessage(time.Minute)
+
+	messageRepo := new(MockMessageRepository)
+	messageRepo.On("GetByID", mock.Anything, message.ID).Return(message, nil)
+	messageRepo.On("EditMessage", mock.Anything, message.ID, "see you at 8", (*string)(nil), mock.AnythingOfType("time.Time")).Return(nil)
+
+	resp, err := NewEditMessageUseCase(messageRepo, nil, testEditConfig()).Execute(context.Background(), &EditMessageRequest{
+		ConversationID: message.ConversationID,
+		MessageID:      message.ID,
+		UserID:         message.SenderID,
+		Content:        " see you at 8 ",
+	})
+
+	require.NoError(t, err)
+	require.True(t, resp.Success, resp.Error)
+	assert.Equal(t, "see you at 8", resp.Message.Content)
+	assert.True(t, resp.Message.IsEdited())
+	messageRepo.AssertExpectations(t)
+}
+
+func TestEditMessageUseCase_RedactsPII(t *testing.T) {
+	message := newEditTestMessage(time.Minute)
+	redactor := services.NewMessagePIIRedactor(&config.ChatSecurityConfig{
+		PIIDetectionEnabled: true,
+		PIIPatterns:         []string{`\b\d{3}[-. ]?\d{3}[-. ]?\d{4}\b`},
+	}, &config.MessageConfig{})
+
+	messageRepo := new(MockMessageRepository)
+	messageRepo.On("GetByID", mock.Anything, message.ID).Return(message, nil)
+	messageRepo.On("EditMessage", mock.Anything, message.ID, "call ************", (*string)(nil), mock.AnythingOfType("time.Time")).Return(nil)
+
+	resp, err := NewEditMessageUseCase(messageRepo, redactor, testEditConfig()).Execute(context.Background(), &EditMessageRequest{
+		ConversationID: message.ConversationID,
+		MessageID:      message.ID,
+		UserID:         message.SenderID,
+		Content:        "call 555-123-4567",
+	})
+
+	require.NoError(t, err)
+	require.True(t, resp.Success, resp.Error)
+	assert.Equal(t, "call ************", resp.Message.Content)
+	messageRepo.AssertExpectations(t)
+}
+
+func TestEditMessageUseCase_Rejections(t *testing.T) {
+	deleted := newEditTestMessage(time.Minute)
+	deleted.SoftDelete()
+
+	tests := []struct {
+		name    string
+		message *entities.Message
+		userID  func(message *entities.Message) uuid.UUID
+		content string
+		error   string
+	}{
+		{
+			name:    "not the sender",
+			message: newEditTestMessage(time.Minute),
+			userID:  func(*entities.Message) uuid.UUID { return uuid.New() },
+			content: "see you at 8",
+			error:   "User can only change their own messages",
+		},
+		{
+			name:    "outside the edit window",
+			message: newEditTestMessage(time.Hour),
+			content: "see you at 8",
+			error:   "Only text messages can be edited, within 15m0s of sending",
+		},
+		{
+			name:    "deleted",
+			message: deleted,
+			content: "see you at 8",
+			error:   "Only text messages can be edited, within 15m0s of sending",
+		},
+		{
+			name:    "too long",
+			message: newEditTestMessage(time.Minute),
+			content: "see you at 8 by the old fountain",
+			error:   "content too long (max 20 characters)",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			messageRepo := new(MockMessageRepository)
+			messageRepo.On("GetByID", mock.Anything, tt.message.ID).Return(tt.message, nil)
+
+			userID := tt.message.SenderID
+			if tt.userID != nil {
+				userID = tt.userID(tt.message)
+			}
+
+			resp, err := NewEditMessageUseCase(messageRepo, nil, testEditConfig()).Execute(context.Background(), &EditMessageRequest{
+				ConversationID: tt.message.ConversationID,
+				MessageID:      tt.message.ID,
+				UserID:         userID,
+				Content:        tt.content,
+			})
+
+			require.NoError(t, err)
+			assert.False(t, resp.Success)
+			assert.Equal(t, tt.error, resp.Error)
+			assert.False(t, tt.message.IsEdited())
+			messageRepo.AssertNotCalled(t, "EditMessage", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+		})
+	}
+}
+
+func TestDeleteMessageUseCase_LeavesTombstone(t *testing.T) {
+	message := newEditTestMessage(time.Hour)
+
+	messageRepo := new(MockMessageRepository)
+	messageRepo.On("GetByID", mock.Anything, message.ID).Return(message, nil)
+	messageRepo.On("SoftDeleteMessage", mock.Anything, message.ID).Return(nil)
+
+	resp, err := NewDeleteMessageUseCase(messageRepo, testEditConfig()).Execute(context.Background(), &DeleteMessageRequest{
+		ConversationID: message.ConversationID,
+		MessageID:      message.ID,
+		UserID:         message.SenderID,
+	})
+
+	require.NoError(t, err)
+	require.True(t, resp.Success, resp.Error)
+	assert.True(t, resp.Message.IsDeleted)
+	assert.NotNil(t, resp.Message.DeletedAt)
+	assert.Equal(t, entities.DeletedMessagePlaceholder, resp.Message.Content)
+	messageRepo.AssertExpectations(t)
+}
+
+func TestDeleteMessageUseCase_OutsideDeleteWindow(t *testing.T) {
+	message := newEditTestMessage(48 * time.Hour)
+
+	messageRepo := new(MockMessageRepository)
+	messageRepo.On("GetByID", mock.Anything, message.ID).Return(message, nil)
+
+	resp, err := NewDeleteMessageUseCase(messageRepo, testEditConfig()).Execute(context.Background(), &DeleteMessageRequest{
+		ConversationID: message.ConversationID,
+		MessageID:      message.ID,
+		UserID:         message.SenderID,
+	})
+
+	require.NoError(t, err)
+	assert.False(t, resp.Success)
+	assert.Equal(t, "Message can only be deleted within 24h0m0s of sending", resp.Error)
+	assert.Equal(t, "see you at 7", message.Content)
+	messageRepo.AssertNotCalled(t, "SoftDeleteMessage", mock.Anything, mock.Anything)
+}
@@ -3,6 +3,7 @@ package chat
 import (
 	"context"
 	"fmt"
+	"time"
 
 	"github.com/google/uuid"
 	"github.com/22smeargle/winkr-backend/internal/domain/entities"
@@ -29,6 +30,7 @@ type GetConversationsResponse struct {
 type ConversationWithUnread struct {
 	*entities.Conversation
 	UnreadCount int `json:"unread_count"`
+	Streak       int               `json:"streak"` // Consecutive days both participants messaged, 0 once a day is missed
 	LastMessage  *entities.Message `json:"last_message,omitempty"`
 	OtherUser    *UserInfo         `json:"other_user,omitempty"`
 }
@@ -111,6 +113,7 @@ func (uc *GetConversationsUseCase) Execute(ctx context.Context, req *GetConversa
 		response.Conversations[i] = &ConversationWithUnread{
 			Conversation: conv,
 			UnreadCount:  conv.UnreadCount,
+			Streak:       conv.CurrentStreak(time.Now()),
 			LastMessage:  lastMessage,
 			OtherUser:    otherUser,
 		}
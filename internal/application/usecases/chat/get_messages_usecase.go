@@ -5,6 +5,7 @@ import (
 	"fmt"
 
 	"github.com/google/uuid"
+	"github.com/22smeargle/winkr-backend/internal/application/services"
 	"github.com/22smeargle/winkr-backend/internal/domain/entities"
 	"github.com/22smeargle/winkr-backend/internal/domain/repositories"
 	"github.com/22smeargle/winkr-backend/pkg/logger"
@@ -29,13 +30,27 @@ type GetMessagesResponse struct {
 
 // GetMessagesUseCase retrieves messages from a conversation
 type GetMessagesUseCase struct {
-	messageRepo repositories.MessageRepository
+	messageRepo        repositories.MessageRepository
+	receiptService     *services.MessageReceiptService
+	translationService *services.MessageTranslationService
+	shadowbanService   *services.ShadowbanService
+	readReceipts       *services.ReadReceiptService
 }
 
 // NewGetMessagesUseCase creates a new get messages use case
-func NewGetMessagesUseCase(messageRepo repositories.MessageRepository) *GetMessagesUseCase {
+func NewGetMessagesUseCase(
+	messageRepo repositories.MessageRepository,
+	receiptService *services.MessageReceiptService,
+	translationService *services.MessageTranslationService,
+	shadowbanService *services.ShadowbanService,
+	readReceipts *services.ReadReceiptService,
+) *GetMessagesUseCase {
 	return &GetMessagesUseCase{
-		messageRepo: messageRepo,
+		messageRepo:        messageRepo,
+		receiptService:     receiptService,
+		translationService: translationService,
+		shadowbanService:   shadowbanService,
+		readReceipts:       readReceipts,
 	}
 }
 
@@ -69,6 +84,12 @@ func (uc *GetMessagesUseCase) Execute(ctx context.Context, req *GetMessagesReque
 		return nil, fmt.Errorf("failed to get messages: %w", err)
 	}
 
+	// Messages of a shadowbanned participant are only shown to themselves
+	messages = uc.shadowbanService.VisibleMessages(ctx, messages, req.UserID)
+
+	// Deleted messages keep their place in the history with a placeholder in place of their content
+	entities.TombstoneMessages(messages)
+
 	// Get total count
 	total, err := uc.messageRepo.GetConversationMessageCount(ctx, req.ConversationID)
 	if err != nil {
@@ -76,14 +97,34 @@ func (uc *GetMessagesUseCase) Execute(ctx context.Context, req *GetMessagesReque
 		return nil, fmt.Errorf("failed to get message count: %w", err)
 	}
 
-	// Mark messages as read for this user
-	if len(messages) > 0 {
+	// Mark messages as read for this user, unless writes are off for maintenance
+	if len(messages) > 0 && !services.IsReadOnlyContext(ctx) {
 		if err := uc.messageRepo.MarkConversationAsRead(ctx, req.ConversationID, req.UserID); err != nil {
 			logger.Error("Failed to mark conversation as read", err)
 			// Don't fail the request, just log the error
+		} else {
+			uc.recordRead(ctx, messages, req.UserID)
 		}
 	}
 
+	// Include delivery status so reconnecting clients can reconcile state
+	if err := uc.receiptService.AttachReceipts(ctx, messages); err != nil {
+		logger.Error("Failed to attach message receipts", err)
+		// Don't fail the request, just log the error
+	}
+
+	// Include reaction counts per emoji
+	if err := uc.attachReactions(ctx, messages); err != nil {
+		logger.Error("Failed to attach message reactions", err)
+		// Don't fail the request, just log the error
+	}
+
+	// Translate received messages for users who opted in, keeping the original content
+	if err := uc.translationService.AttachTranslations(ctx, messages, req.UserID); err != nil {
+		logger.Error("Failed to attach message translations", err)
+		// Don't fail the request, just log the error
+	}
+
 	response := &GetMessagesResponse{
 		Messages: messages,
 		Total:    total,
@@ -102,6 +143,57 @@ func (uc *GetMessagesUseCase) Execute(ctx context.Context, req *GetMessagesReque
 	return response, nil
 }
 
+// recordRead records the read status of the fetched messages the user received. Users with read
+// receipts off only have them recorded as delivered.
+func (uc *GetMessagesUseCase) recordRead(ctx context.Context, messages []*entities.Message, userID uuid.UUID) {
+	var unread []*entities.Message
+	for _, message := range messages {
+		if message.SenderID != userID && !message.IsRead {
+			unread = append(unread, message)
+		}
+	}
+	if len(unread) == 0 {
+		return
+	}
+
+	record := uc.receiptService.RecordDelivered
+	if uc.readReceipts.SendsReadReceipts(ctx, userID) {
+		record = uc.receiptService.RecordRead
+	}
+
+	for _, message := range unread {
+		if _, err := record(ctx, message, userID); err != nil {
+			logger.Error("Failed to record message read status", err, "message_id", message.ID)
+		}
+	}
+}
+
+// attachReactions sets the reaction counts of the messages. Tombstones carry no reactions.
+func (uc *GetMessagesUseCase) attachReactions(ctx context.Context, messages []*entities.Message) error {
+	var messageIDs []uuid.UUID
+	for _, message := range messages {
+		if !message.IsDeleted {
+			messageIDs = append(messageIDs, message.ID)
+		}
+	}
+	if len(messageIDs) == 0 {
+		return nil
+	}
+
+	counts, err := uc.messageRepo.GetReactionCounts(ctx, messageIDs)
+	if err != nil {
+		return fmt.Errorf("failed to get message reaction counts: %w", err)
+	}
+
+	for _, message := range messages {
+		if reactions, ok := counts[message.ID]; ok && !message.IsDeleted {
+			message.Reactions = reactions
+		}
+	}
+
+	return nil
+}
+
 // Validate validates the request
 func (req *GetMessagesRequest) Validate() error {
 	if req.ConversationID == uuid.Nil {
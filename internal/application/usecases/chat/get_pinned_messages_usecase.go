@@ -0,0 +1,75 @@
+package chat
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/22smeargle/winkr-backend/internal/domain/entities"
+	"github.com/22smeargle/winkr-backend/internal/domain/repositories"
+	"github.com/22smeargle/winkr-backend/pkg/logger"
+)
+
+// GetPinnedMessagesRequest represents a request to get the pinned messages of a conversation
+type GetPinnedMessagesRequest struct {
+	ConversationID uuid.UUID `json:"conversation_id" validate:"required"`
+	UserID         uuid.UUID `json:"user_id" validate:"required"`
+}
+
+// GetPinnedMessagesResponse represents the response with pinned messages
+type GetPinnedMessagesResponse struct {
+	Messages []*entities.Message `json:"messages"`
+	Total    int                 `json:"total"`
+}
+
+// GetPinnedMessagesUseCase retrieves the pinned messages of a conversation
+type GetPinnedMessagesUseCase struct {
+	messageRepo repositories.MessageRepository
+}
+
+// NewGetPinnedMessagesUseCase creates a new get pinned messages use case
+func NewGetPinnedMessagesUseCase(messageRepo repositories.MessageRepository) *GetPinnedMessagesUseCase {
+	return &GetPinnedMessagesUseCase{
+		messageRepo: messageRepo,
+	}
+}
+
+// Execute retrieves the pinned messages of a conversation, most recently pinned first
+func (uc *GetPinnedMessagesUseCase) Execute(ctx context.Context, req *GetPinnedMessagesRequest) (*GetPinnedMessagesResponse, error) {
+	if err := req.Validate(); err != nil {
+		return nil, fmt.Errorf("invalid request: %w", err)
+	}
+
+	canAccess, err := uc.messageRepo.UserCanAccessConversation(ctx, req.UserID, req.ConversationID)
+	if err != nil {
+		logger.Error("Failed to check conversation access", err)
+		return nil, fmt.Errorf("failed to check conversation access: %w", err)
+	}
+
+	if !canAccess {
+		return nil, fmt.Errorf("user cannot access conversation")
+	}
+
+	messages, err := uc.messageRepo.GetPinnedMessages(ctx, req.ConversationID)
+	if err != nil {
+		logger.Error("Failed to get pinned messages", err)
+		return nil, fmt.Errorf("failed to get pinned messages: %w", err)
+	}
+	entities.TombstoneMessages(messages)
+
+	return &GetPinnedMessagesResponse{
+		Messages: messages,
+		Total:    len(messages),
+	}, nil
+}
+
+// Validate validates the request
+func (req *GetPinnedMessagesRequest) Validate() error {
+	if req.ConversationID == uuid.Nil {
+		return fmt.Errorf("conversation_id is required")
+	}
+	if req.UserID == uuid.Nil {
+		return fmt.Errorf("user_id is required")
+	}
+	return nil
+}
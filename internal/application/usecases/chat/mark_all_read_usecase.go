@@ -0,0 +1,179 @@
+package chat
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/22smeargle/winkr-backend/internal/application/services"
+	"github.com/22smeargle/winkr-backend/internal/domain/entities"
+	"github.com/22smeargle/winkr-backend/internal/domain/repositories"
+	"github.com/22smeargle/winkr-backend/pkg/logger"
+)
+
+// ReadReceiptNotifier delivers read receipts and unread counts to connected clients
+type ReadReceiptNotifier interface {
+	// NotifyMessagesRead tells the sender that the reader read their messages in one batch
+	NotifyMessagesRead(ctx context.Context, senderID, readerID, conversationID uuid.UUID, messageIDs []uuid.UUID, readAt time.Time) error
+	// NotifyUnreadCount tells the user their unread count in a conversation
+	NotifyUnreadCount(ctx context.Context, userID, conversationID uuid.UUID, count int) error
+}
+
+// UnreadCountCache caches a user's unread count per conversation
+type UnreadCountCache interface {
+	CacheUserUnreadCount(ctx context.Context, userID, conversationID string, count int) error
+}
+
+// MarkAllReadResponse represents the response after marking all conversations as read
+type MarkAllReadResponse struct {
+	Success       bool   `json:"success"`
+	MarkedCount   int    `json:"marked_count"`
+	Conversations int    `json:"conversations"`
+	Error         string `json:"error,omitempty"`
+}
+
+// MarkAllReadUseCase handles marking every conversation of a user as read
+type MarkAllReadUseCase struct {
+	messageRepo    repositories.MessageRepository
+	receiptService *services.MessageReceiptService
+	notifier       ReadReceiptNotifier
+	unreadCache    UnreadCountCache
+	readReceipts   *services.ReadReceiptService
+}
+
+// NewMarkAllReadUseCase creates a new mark all read use case
+func NewMarkAllReadUseCase(
+	messageRepo repositories.MessageRepository,
+	receiptService *services.MessageReceiptService,
+	notifier ReadReceiptNotifier,
+	unreadCache UnreadCountCache,
+	readReceipts *services.ReadReceiptService,
+) *MarkAllReadUseCase {
+	return &MarkAllReadUseCase{
+		messageRepo:    messageRepo,
+		receiptService: receiptService,
+		notifier:       notifier,
+		unreadCache:    unreadCache,
+		readReceipts:   readReceipts,
+	}
+}
+
+// Execute marks all messages the user received as read, zeroes their unread counts and sends one
+// read receipt per sender and conversation, unless the user turned read receipts off. Only the user's
+// own view changes.
+func (uc *MarkAllReadUseCase) Execute(ctx context.Context, userID uuid.UUID) (*MarkAllReadResponse, error) {
+	if userID == uuid.Nil {
+		return &MarkAllReadResponse{
+			Success: false,
+			Error:   "user_id is required",
+		}, nil
+	}
+
+	unread, err := uc.messageRepo.GetUnreadMessages(ctx, userID)
+	if err != nil {
+		logger.Error("Failed to get unread messages", err, "user_id", userID)
+		return &MarkAllReadResponse{
+			Success: false,
+			Error:   "Failed to get unread messages",
+		}, nil
+	}
+
+	var messages []*entities.Message
+	var messageIDs []uuid.UUID
+	var conversationIDs []uuid.UUID
+	byConversation := make(map[uuid.UUID][]*entities.Message)
+	for _, message := range unread {
+		if message.SenderID == userID {
+			continue
+		}
+		if _, seen := byConversation[message.ConversationID]; !seen {
+			conversationIDs = append(conversationIDs, message.ConversationID)
+		}
+		byConversation[message.ConversationID] = append(byConversation[message.ConversationID], message)
+		messages = append(messages, message)
+		messageIDs = append(messageIDs, message.ID)
+	}
+
+	if len(messages) == 0 {
+		return &MarkAllReadResponse{Success: true}, nil
+	}
+
+	if err := uc.messageRepo.BatchMarkAsRead(ctx, messageIDs); err != nil {
+		logger.Error("Failed to mark messages as read", err, "user_id", userID)
+		return &MarkAllReadResponse{
+			Success: false,
+			Error:   "Failed to mark messages as read",
+		}, nil
+	}
+
+	sendReceipts := uc.readReceipts.SendsReadReceipts(ctx, userID)
+	if uc.receiptService != nil {
+		record := uc.receiptService.RecordDeliveredAll
+		if sendReceipts {
+			record = uc.receiptService.RecordReadAll
+		}
+		if err := record(ctx, messages, userID); err != nil {
+			logger.Error("Failed to record message read statuses", err, "user_id", userID)
+		}
+	}
+
+	readAt := time.Now()
+	for _, conversationID := range conversationIDs {
+		uc.resetUnreadCount(ctx, userID, conversationID)
+		if sendReceipts {
+			uc.sendReceipts(ctx, userID, conversationID, byConversation[conversationID], readAt)
+		}
+	}
+
+	logger.Info("All conversations marked as read",
+		"user_id", userID,
+		"marked_count", len(messages),
+		"conversations", len(conversationIDs),
+	)
+
+	return &MarkAllReadResponse{
+		Success:       true,
+		MarkedCount:   len(messages),
+		Conversations: len(conversationIDs),
+	}, nil
+}
+
+// resetUnreadCount zeroes the user's cached unread count for the conversation and tells their clients
+func (uc *MarkAllReadUseCase) resetUnreadCount(ctx context.Context, userID, conversationID uuid.UUID) {
+	if uc.unreadCache != nil {
+		if err := uc.unreadCache.CacheUserUnreadCount(ctx, userID.String(), conversationID.String(), 0); err != nil {
+			logger.Error("Failed to reset cached unread count", err, "conversation_id", conversationID)
+		}
+	}
+	if uc.notifier != nil {
+		if err := uc.notifier.NotifyUnreadCount(ctx, userID, conversationID, 0); err != nil {
+			logger.Error("Failed to notify unread count", err, "conversation_id", conversationID)
+		}
+	}
+}
+
+// sendReceipts sends each sender one read receipt covering all their messages in the conversation
+func (uc *MarkAllReadUseCase) sendReceipts(ctx context.Context, readerID, conversationID uuid.UUID, messages []*entities.Message, readAt time.Time) {
+	if uc.notifier == nil {
+		return
+	}
+
+	var senderIDs []uuid.UUID
+	bySender := make(map[uuid.UUID][]uuid.UUID)
+	for _, message := range messages {
+		if _, seen := bySender[message.SenderID]; !seen {
+			senderIDs = append(senderIDs, message.SenderID)
+		}
+		bySender[message.SenderID] = append(bySender[message.SenderID], message.ID)
+	}
+
+	for _, senderID := range senderIDs {
+		if err := uc.notifier.NotifyMessagesRead(ctx, senderID, readerID, conversationID, bySender[senderID], readAt); err != nil {
+			logger.Error("Failed to send read receipt", err,
+				"conversation_id", conversationID,
+				"sender_id", senderID,
+			)
+		}
+	}
+}
+
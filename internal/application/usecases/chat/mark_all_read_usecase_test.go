@@ -0,0 +1,170 @@
+package chat
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"github.com/22smeargle/winkr-backend/internal/application/services"
+	"github.com/22smeargle/winkr-backend/internal/domain/entities"
+)
+
+func (m *MockMessageRepository) GetUnreadMessages(ctx context.Context, userID uuid.UUID) ([]*entities.Message, error) {
+	args := m.Called(ctx, userID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*entities.Message), args.Error(1)
+}
+
+func (m *MockMessageRepository) BatchMarkAsRead(ctx context.Context, messageIDs []uuid.UUID) error {
+	args := m.Called(ctx, messageIDs)
+	return args.Error(0)
+}
+
+// memoryStatusStore keeps message statuses in memory and counts the lookups
+type memoryStatusStore struct {
+	statuses []*entities.MessageStatus
+	lookups  int
+}
+
+func (s *memoryStatusStore) CreateMessageStatus(ctx context.Context, status *entities.MessageStatus) error {
+	s.statuses = append(s.statuses, status)
+	return nil
+}
+
+func (s *memoryStatusStore) GetMessageStatuses(ctx context.Context, messageIDs []uuid.UUID) ([]*entities.MessageStatus, error) {
+	s.lookups++
+	var found []*entities.MessageStatus
+	for _, status := range s.statuses {
+		for _, id := range messageIDs {
+			if status.MessageID == id {
+				found = append(found, status)
+			}
+		}
+	}
+	return found, nil
+}
+
+type readReceipt struct {
+	senderID       uuid.UUID
+	conversationID uuid.UUID
+	messageIDs     []uuid.UUID
+}
+
+// recordingReadNotifier records read receipts and the latest unread count per conversation
+type recordingReadNotifier struct {
+	receipts []readReceipt
+	unread   map[uuid.UUID]int
+}
+
+func (n *recordingReadNotifier) NotifyMessagesRead(ctx context.Context, senderID, readerID, conversationID uuid.UUID, messageIDs []uuid.UUID, readAt time.Time) error {
+	n.receipts = append(n.receipts, readReceipt{senderID: senderID, conversationID: conversationID, messageIDs: messageIDs})
+	return nil
+}
+
+func (n *recordingReadNotifier) NotifyUnreadCount(ctx context.Context, userID, conversationID uuid.UUID, count int) error {
+	n.unread[conversationID] = count
+	return nil
+}
+
+// memoryUnreadCache keeps unread counts keyed by conversation
+type memoryUnreadCache struct {
+	counts map[string]int
+}
+
+func (c *memoryUnreadCache) CacheUserUnreadCount(ctx context.Context, userID, conversationID string, count int) error {
+	c.counts[conversationID] = count
+	return nil
+}
+
+func TestMarkAllReadUseCase_ClearsEveryConversation(t *testing.T) {
+	userID := uuid.New()
+	conversationIDs := []uuid.UUID{uuid.New(), uuid.New(), uuid.New()}
+	senderIDs := []uuid.UUID{uuid.New(), uuid.New(), uuid.New()}
+
+	cache := &memoryUnreadCache{counts: map[string]int{}}
+	var unread []*entities.Message
+	for i, conversationID := range conversationIDs {
+		cache.counts[conversationID.String()] = i + 1
+		for j := 0; j <= i; j++ {
+			unread = append(unread, &entities.Message{
+				ID:             uuid.New(),
+				ConversationID: conversationID,
+				SenderID:       senderIDs[i],
+				Content:        "hey",
+				MessageType:    "text",
+				CreatedAt:      time.Now().Add(-time.Hour),
+			})
+		}
+	}
+
+	unreadIDs := make([]uuid.UUID, len(unread))
+	for i, message := range unread {
+		unreadIDs[i] = message.ID
+	}
+
+	messageRepo := new(MockMessageRepository)
+	messageRepo.On("GetUnreadMessages", mock.Anything, userID).Return(unread, nil)
+	messageRepo.On("BatchMarkAsRead", mock.Anything, unreadIDs).Return(nil).Once()
+
+	statusStore := &memoryStatusStore{}
+	notifier := &recordingReadNotifier{unread: map[uuid.UUID]int{}}
+	useCase := NewMarkAllReadUseCase(messageRepo, services.NewMessageReceiptService(statusStore), notifier, cache, nil)
+
+	resp, err := useCase.Execute(context.Background(), userID)
+
+	require.NoError(t, err)
+	require.True(t, resp.Success, resp.Error)
+	assert.Equal(t, len(unread), resp.MarkedCount)
+	assert.Equal(t, len(conversationIDs), resp.Conversations)
+	messageRepo.AssertExpectations(t)
+
+	for _, conversationID := range conversationIDs {
+		assert.Equal(t, 0, cache.counts[conversationID.String()])
+		count, notified := notifier.unread[conversationID]
+		assert.True(t, notified)
+		assert.Equal(t, 0, count)
+	}
+
+	// One receipt per conversation and sender, covering all their messages
+	require.Len(t, notifier.receipts, len(conversationIDs))
+	for i, receipt := range notifier.receipts {
+		assert.Equal(t, conversationIDs[i], receipt.conversationID)
+		assert.Equal(t, senderIDs[i], receipt.senderID)
+		assert.Len(t, receipt.messageIDs, i+1)
+	}
+
+	// Read statuses are recorded with a single lookup
+	assert.Equal(t, 1, statusStore.lookups)
+	read := 0
+	for _, status := range statusStore.statuses {
+		if status.Status == entities.MessageStatusRead {
+			assert.Equal(t, userID, status.UserID)
+			read++
+		}
+	}
+	assert.Equal(t, len(unread), read)
+}
+
+func TestMarkAllReadUseCase_NothingUnread(t *testing.T) {
+	userID := uuid.New()
+
+	messageRepo := new(MockMessageRepository)
+	messageRepo.On("GetUnreadMessages", mock.Anything, userID).Return([]*entities.Message{}, nil)
+
+	notifier := &recordingReadNotifier{unread: map[uuid.UUID]int{}}
+	useCase := NewMarkAllReadUseCase(messageRepo, nil, notifier, nil, nil)
+
+	resp, err := useCase.Execute(context.Background(), userID)
+
+	require.NoError(t, err)
+	assert.True(t, resp.Success)
+	assert.Zero(t, resp.MarkedCount)
+	assert.Empty(t, notifier.receipts)
+	messageRepo.AssertNotCalled(t, "BatchMarkAsRead", mock.Anything, mock.Anything)
+}
@@ -2,9 +2,12 @@ package chat
 
 import (
 	"context"
+	"errors"
 	"fmt"
 
 	"github.com/google/uuid"
+	"github.com/22smeargle/winkr-backend/internal/application/services"
+	"github.com/22smeargle/winkr-backend/internal/domain/entities"
 	"github.com/22smeargle/winkr-backend/internal/domain/repositories"
 	"github.com/22smeargle/winkr-backend/pkg/logger"
 )
@@ -14,6 +17,7 @@ type MarkMessagesReadRequest struct {
 	ConversationID uuid.UUID `json:"conversation_id" validate:"required"`
 	UserID        uuid.UUID `json:"user_id" validate:"required"`
 	MessageIDs    []uuid.UUID `json:"message_ids,omitempty"`
+	UpToMessageID *uuid.UUID `json:"up_to_message_id,omitempty"` // Marks everything received up to and including this message
 }
 
 // MarkMessagesReadResponse represents the response after marking messages as read
@@ -25,13 +29,17 @@ type MarkMessagesReadResponse struct {
 
 // MarkMessagesReadUseCase handles marking messages as read
 type MarkMessagesReadUseCase struct {
-	messageRepo repositories.MessageRepository
+	messageRepo    repositories.MessageRepository
+	receiptService *services.MessageReceiptService
+	readReceipts   *services.ReadReceiptService
 }
 
 // NewMarkMessagesReadUseCase creates a new mark messages read use case
-func NewMarkMessagesReadUseCase(messageRepo repositories.MessageRepository) *MarkMessagesReadUseCase {
+func NewMarkMessagesReadUseCase(messageRepo repositories.MessageRepository, receiptService *services.MessageReceiptService, readReceipts *services.ReadReceiptService) *MarkMessagesReadUseCase {
 	return &MarkMessagesReadUseCase{
-		messageRepo: messageRepo,
+		messageRepo:    messageRepo,
+		receiptService: receiptService,
+		readReceipts:   readReceipts,
 	}
 }
 
@@ -66,6 +74,8 @@ func (uc *MarkMessagesReadUseCase) Execute(ctx context.Context, req *MarkMessage
 
 	// If specific message IDs are provided, mark only those
 	if len(req.MessageIDs) > 0 {
+		var readMessages []*entities.Message
+
 		// Verify user can access all messages
 		for _, messageID := range req.MessageIDs {
 			canAccess, err := uc.messageRepo.UserCanAccessMessage(ctx, req.UserID, messageID)
@@ -84,10 +94,42 @@ func (uc *MarkMessagesReadUseCase) Execute(ctx context.Context, req *MarkMessage
 				continue
 			}
 			markedCount++
+
+			message, err := uc.messageRepo.GetByID(ctx, messageID)
+			if err != nil {
+				logger.Error("Failed to get message for read receipt", err, "message_id", messageID)
+				continue
+			}
+			readMessages = append(readMessages, message)
 		}
+
+		uc.recordRead(ctx, readMessages, req.UserID)
 	} else {
-		// Mark all unread messages in conversation as read
-		if err := uc.messageRepo.MarkConversationAsRead(ctx, req.ConversationID, req.UserID); err != nil {
+		// Read up to the given message, or everything received so far
+		upToMessageID := req.UpToMessageID
+		if upToMessageID == nil {
+			latest, err := uc.latestUnread(ctx, req.ConversationID, req.UserID)
+			if err != nil {
+				logger.Error("Failed to get unread messages", err)
+				return &MarkMessagesReadResponse{
+					Success: false,
+					Error:   "Failed to get unread messages",
+				}, nil
+			}
+			if latest == nil {
+				return &MarkMessagesReadResponse{Success: true}, nil
+			}
+			upToMessageID = &latest.ID
+		}
+
+		result, err := uc.readReceipts.MarkMessagesRead(ctx, req.ConversationID, *upToMessageID, req.UserID)
+		switch {
+		case errors.Is(err, services.ErrReadMessageNotInConversation):
+			return &MarkMessagesReadResponse{
+				Success: false,
+				Error:   err.Error(),
+			}, nil
+		case err != nil:
 			logger.Error("Failed to mark conversation as read", err)
 			return &MarkMessagesReadResponse{
 				Success: false,
@@ -95,14 +137,7 @@ func (uc *MarkMessagesReadUseCase) Execute(ctx context.Context, req *MarkMessage
 			}, nil
 		}
 
-		// Get count of unread messages that were marked
-		unreadCount, err := uc.messageRepo.GetConversationUnreadCount(ctx, req.ConversationID, req.UserID)
-		if err != nil {
-			logger.Error("Failed to get unread count", err)
-			markedCount = 0
-		} else {
-			markedCount = int(unreadCount)
-		}
+		markedCount = result.MarkedCount
 	}
 
 	logger.Info("Messages marked as read", 
@@ -117,6 +152,45 @@ func (uc *MarkMessagesReadUseCase) Execute(ctx context.Context, req *MarkMessage
 	}, nil
 }
 
+// latestUnread returns the newest message the user has not read in the conversation, or nil if there is none
+func (uc *MarkMessagesReadUseCase) latestUnread(ctx context.Context, conversationID, userID uuid.UUID) (*entities.Message, error) {
+	unread, err := uc.messageRepo.GetUnreadMessages(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	var latest *entities.Message
+	for _, message := range unread {
+		if message.ConversationID != conversationID {
+			continue
+		}
+		if latest == nil || message.CreatedAt.After(latest.CreatedAt) {
+			latest = message
+		}
+	}
+
+	return latest, nil
+}
+
+// recordRead records the read status of messages the user received. Users with read receipts off
+// only have them recorded as delivered.
+func (uc *MarkMessagesReadUseCase) recordRead(ctx context.Context, messages []*entities.Message, userID uuid.UUID) {
+	if len(messages) == 0 {
+		return
+	}
+
+	record := uc.receiptService.RecordDelivered
+	if uc.readReceipts.SendsReadReceipts(ctx, userID) {
+		record = uc.receiptService.RecordRead
+	}
+
+	for _, message := range messages {
+		if _, err := record(ctx, message, userID); err != nil {
+			logger.Error("Failed to record message read status", err, "message_id", message.ID)
+		}
+	}
+}
+
 // Validate validates the request
 func (req *MarkMessagesReadRequest) Validate() error {
 	if req.ConversationID == uuid.Nil {
@@ -126,6 +200,10 @@ func (req *MarkMessagesReadRequest) Validate() error {
 		return fmt.Errorf("user_id is required")
 	}
 	
+	if req.UpToMessageID != nil && *req.UpToMessageID == uuid.Nil {
+		return fmt.Errorf("up_to_message_id is invalid")
+	}
+	
 	// Validate message IDs if provided
 	for i, messageID := range req.MessageIDs {
 		if messageID == uuid.Nil {
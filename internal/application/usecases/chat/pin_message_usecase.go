@@ -0,0 +1,185 @@
+package chat
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/22smeargle/winkr-backend/internal/domain/entities"
+	"github.com/22smeargle/winkr-backend/internal/domain/repositories"
+	"github.com/22smeargle/winkr-backend/pkg/config"
+	"github.com/22smeargle/winkr-backend/pkg/logger"
+)
+
+// PinMessageRequest represents a request to pin or unpin a message
+type PinMessageRequest struct {
+	ConversationID uuid.UUID `json:"conversation_id" validate:"required"`
+	MessageID      uuid.UUID `json:"message_id" validate:"required"`
+	UserID         uuid.UUID `json:"user_id" validate:"required"`
+}
+
+// PinMessageResponse represents the response after pinning or unpinning a message
+type PinMessageResponse struct {
+	Message *entities.Message `json:"message,omitempty"`
+	Success bool              `json:"success"`
+	Error   string            `json:"error,omitempty"`
+}
+
+// PinMessageUseCase handles pinning and unpinning messages in a conversation
+type PinMessageUseCase struct {
+	messageRepo repositories.MessageRepository
+	config      *config.MessageConfig
+}
+
+// NewPinMessageUseCase creates a new pin message use case
+func NewPinMessageUseCase(messageRepo repositories.MessageRepository, cfg *config.MessageConfig) *PinMessageUseCase {
+	return &PinMessageUseCase{
+		messageRepo: messageRepo,
+		config:      cfg,
+	}
+}
+
+// Pin pins a message so both participants can find it again.
+// Only participants can pin, and a conversation holds at most MaxPinnedMessages pins.
+func (uc *PinMessageUseCase) Pin(ctx context.Context, req *PinMessageRequest) (*PinMessageResponse, error) {
+	message, resp := uc.loadMessage(ctx, req)
+	if resp != nil {
+		return resp, nil
+	}
+
+	if message.IsPinned {
+		return &PinMessageResponse{
+			Message: message,
+			Success: true,
+		}, nil
+	}
+
+	if !message.CanBePinned() {
+		return &PinMessageResponse{
+			Success: false,
+			Error:   "Message cannot be pinned",
+		}, nil
+	}
+
+	pinned, err := uc.messageRepo.CountPinnedMessages(ctx, req.ConversationID)
+	if err != nil {
+		logger.Error("Failed to count pinned messages", err)
+		return &PinMessageResponse{
+			Success: false,
+			Error:   "Failed to pin message",
+		}, nil
+	}
+
+	if pinned >= int64(uc.config.MaxPinnedMessages) {
+		return &PinMessageResponse{
+			Success: false,
+			Error:   fmt.Sprintf("A conversation can have at most %d pinned messages", uc.config.MaxPinnedMessages),
+		}, nil
+	}
+
+	if err := uc.messageRepo.PinMessage(ctx, req.MessageID, req.UserID); err != nil {
+		logger.Error("Failed to pin message", err)
+		return &PinMessageResponse{
+			Success: false,
+			Error:   "Failed to pin message",
+		}, nil
+	}
+	message.Pin(req.UserID)
+
+	logger.Info("Message pinned successfully",
+		"message_id", req.MessageID,
+		"conversation_id", req.ConversationID,
+		"user_id", req.UserID,
+	)
+
+	return &PinMessageResponse{
+		Message: message,
+		Success: true,
+	}, nil
+}
+
+// Unpin removes the pin from a message. Either participant can unpin.
+func (uc *PinMessageUseCase) Unpin(ctx context.Context, req *PinMessageRequest) (*PinMessageResponse, error) {
+	message, resp := uc.loadMessage(ctx, req)
+	if resp != nil {
+		return resp, nil
+	}
+
+	if !message.IsPinned {
+		return &PinMessageResponse{
+			Message: message,
+			Success: true,
+		}, nil
+	}
+
+	if err := uc.messageRepo.UnpinMessage(ctx, req.MessageID); err != nil {
+		logger.Error("Failed to unpin message", err)
+		return &PinMessageResponse{
+			Success: false,
+			Error:   "Failed to unpin message",
+		}, nil
+	}
+	message.Unpin()
+
+	logger.Info("Message unpinned successfully",
+		"message_id", req.MessageID,
+		"conversation_id", req.ConversationID,
+		"user_id", req.UserID,
+	)
+
+	return &PinMessageResponse{
+		Message: message,
+		Success: true,
+	}, nil
+}
+
+// loadMessage validates the request, checks the user is a participant and returns the message.
+// A non-nil response means the request was rejected.
+func (uc *PinMessageUseCase) loadMessage(ctx context.Context, req *PinMessageRequest) (*entities.Message, *PinMessageResponse) {
+	if err := req.Validate(); err != nil {
+		return nil, &PinMessageResponse{
+			Success: false,
+			Error:   err.Error(),
+		}
+	}
+
+	canAccess, err := uc.messageRepo.UserCanAccessConversation(ctx, req.UserID, req.ConversationID)
+	if err != nil {
+		logger.Error("Failed to check conversation access", err)
+		return nil, &PinMessageResponse{
+			Success: false,
+			Error:   "Failed to check conversation access",
+		}
+	}
+
+	if !canAccess {
+		return nil, &PinMessageResponse{
+			Success: false,
+			Error:   "User cannot access this conversation",
+		}
+	}
+
+	message, err := uc.messageRepo.GetByID(ctx, req.MessageID)
+	if err != nil || message.ConversationID != req.ConversationID {
+		return nil, &PinMessageResponse{
+			Success: false,
+			Error:   "Message not found",
+		}
+	}
+
+	return message, nil
+}
+
+// Validate validates the request
+func (req *PinMessageRequest) Validate() error {
+	if req.ConversationID == uuid.Nil {
+		return fmt.Errorf("conversation_id is required")
+	}
+	if req.MessageID == uuid.Nil {
+		return fmt.Errorf("message_id is required")
+	}
+	if req.UserID == uuid.Nil {
+		return fmt.Errorf("user_id is required")
+	}
+	return nil
+}
@@ -0,0 +1,172 @@
+package chat
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"github.com/22smeargle/winkr-backend/internal/domain/entities"
+	"github.com/22smeargle/winkr-backend/pkg/config"
+)
+
+func testPinConfig() *config.MessageConfig {
+	return &config.MessageConfig{MaxPinnedMessages: 3}
+}
+
+func newPinTestMessage(conversationID uuid.UUID) *entities.Message {
+	return &entities.Message{
+		ID:             uuid.New(),
+		ConversationID: conversationID,
+		SenderID:       uuid.New(),
+		Content:        "meet at the station at 7",
+		MessageType:    "text",
+		CreatedAt:      time.Now().Add(-time.Hour),
+	}
+}
+
+func TestPinMessageUseCase_Pin(t *testing.T) {
+	userID := uuid.New()
+	message := newPinTestMessage(uuid.New())
+
+	messageRepo := new(MockMessageRepository)
+	messageRepo.On("UserCanAccessConversation", mock.Anything, userID, message.ConversationID).Return(true, nil)
+	messageRepo.On("GetByID", mock.Anything, message.ID).Return(message, nil)
+	messageRepo.On("CountPinnedMessages", mock.Anything, message.ConversationID).Return(int64(2), nil)
+	messageRepo.On("PinMessage", mock.Anything, message.ID, userID).Return(nil)
+
+	useCase := NewPinMessageUseCase(messageRepo, testPinConfig())
+
+	resp, err := useCase.Pin(context.Background(), &PinMessageRequest{
+		ConversationID: message.ConversationID,
+		MessageID:      message.ID,
+		UserID:         userID,
+	})
+
+	require.NoError(t, err)
+	require.True(t, resp.Success, resp.Error)
+	assert.True(t, resp.Message.IsPinned)
+	require.NotNil(t, resp.Message.PinnedBy)
+	assert.Equal(t, userID, *resp.Message.PinnedBy)
+	messageRepo.AssertExpectations(t)
+}
+
+func TestPinMessageUseCase_PinLimitReached(t *testing.T) {
+	userID := uuid.New()
+	message := newPinTestMessage(uuid.New())
+
+	messageRepo := new(MockMessageRepository)
+	messageRepo.On("UserCanAccessConversation", mock.Anything, userID, message.ConversationID).Return(true, nil)
+	messageRepo.On("GetByID", mock.Anything, message.ID).Return(message, nil)
+	messageRepo.On("CountPinnedMessages", mock.Anything, message.ConversationID).Return(int64(3), nil)
+
+	useCase := NewPinMessageUseCase(messageRepo, testPinConfig())
+
+	resp, err := useCase.Pin(context.Background(), &PinMessageRequest{
+		ConversationID: message.ConversationID,
+		MessageID:      message.ID,
+		UserID:         userID,
+	})
+
+	require.NoError(t, err)
+	assert.False(t, resp.Success)
+	assert.Equal(t, "A conversation can have at most 3 pinned messages", resp.Error)
+	assert.False(t, message.IsPinned)
+	messageRepo.AssertNotCalled(t, "PinMessage", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestPinMessageUseCase_NonParticipantCannotPin(t *testing.T) {
+	outsiderID := uuid.New()
+	message := newPinTestMessage(uuid.New())
+
+	messageRepo := new(MockMessageRepository)
+	messageRepo.On("UserCanAccessConversation", mock.Anything, outsiderID, message.ConversationID).Return(false, nil)
+
+	useCase := NewPinMessageUseCase(messageRepo, testPinConfig())
+	req := &PinMessageRequest{
+		ConversationID: message.ConversationID,
+		MessageID:      message.ID,
+		UserID:         outsiderID,
+	}
+
+	resp, err := useCase.Pin(context.Background(), req)
+	require.NoError(t, err)
+	assert.False(t, resp.Success)
+	assert.Equal(t, "User cannot access this conversation", resp.Error)
+
+	resp, err = useCase.Unpin(context.Background(), req)
+	require.NoError(t, err)
+	assert.False(t, resp.Success)
+
+	messageRepo.AssertNotCalled(t, "PinMessage", mock.Anything, mock.Anything, mock.Anything)
+	messageRepo.AssertNotCalled(t, "UnpinMessage", mock.Anything, mock.Anything)
+}
+
+func TestPinMessageUseCase_MessageFromOtherConversation(t *testing.T) {
+	userID := uuid.New()
+	conversationID := uuid.New()
+	message := newPinTestMessage(uuid.New())
+
+	messageRepo := new(MockMessageRepository)
+	messageRepo.On("UserCanAccessConversation", mock.Anything, userID, conversationID).Return(true, nil)
+	messageRepo.On("GetByID", mock.Anything, message.ID).Return(message, nil)
+
+	useCase := NewPinMessageUseCase(messageRepo, testPinConfig())
+
+	resp, err := useCase.Pin(context.Background(), &PinMessageRequest{
+		ConversationID: conversationID,
+		MessageID:      message.ID,
+		UserID:         userID,
+	})
+
+	require.NoError(t, err)
+	assert.False(t, resp.Success)
+	assert.Equal(t, "Message not found", resp.Error)
+}
+
+func TestPinMessageUseCase_Unpin(t *testing.T) {
+	userID := uuid.New()
+	message := newPinTestMessage(uuid.New())
+	message.Pin(uuid.New())
+
+	messageRepo := new(MockMessageRepository)
+	messageRepo.On("UserCanAccessConversation", mock.Anything, userID, message.ConversationID).Return(true, nil)
+	messageRepo.On("GetByID", mock.Anything, message.ID).Return(message, nil)
+	messageRepo.On("UnpinMessage", mock.Anything, message.ID).Return(nil)
+
+	useCase := NewPinMessageUseCase(messageRepo, testPinConfig())
+
+	resp, err := useCase.Unpin(context.Background(), &PinMessageRequest{
+		ConversationID: message.ConversationID,
+		MessageID:      message.ID,
+		UserID:         userID,
+	})
+
+	require.NoError(t, err)
+	require.True(t, resp.Success, resp.Error)
+	assert.False(t, resp.Message.IsPinned)
+	assert.Nil(t, resp.Message.PinnedAt)
+	messageRepo.AssertExpectations(t)
+}
+
+func TestGetPinnedMessagesUseCase_NonParticipant(t *testing.T) {
+	outsiderID := uuid.New()
+	conversationID := uuid.New()
+
+	messageRepo := new(MockMessageRepository)
+	messageRepo.On("UserCanAccessConversation", mock.Anything, outsiderID, conversationID).Return(false, nil)
+
+	useCase := NewGetPinnedMessagesUseCase(messageRepo)
+
+	resp, err := useCase.Execute(context.Background(), &GetPinnedMessagesRequest{
+		ConversationID: conversationID,
+		UserID:         outsiderID,
+	})
+
+	assert.Error(t, err)
+	assert.Nil(t, resp)
+	messageRepo.AssertNotCalled(t, "GetPinnedMessages", mock.Anything, mock.Anything)
+}
@@ -0,0 +1,181 @@
+package chat
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/22smeargle/winkr-backend/internal/domain/entities"
+	"github.com/22smeargle/winkr-backend/internal/domain/repositories"
+	"github.com/22smeargle/winkr-backend/pkg/config"
+	"github.com/22smeargle/winkr-backend/pkg/logger"
+)
+
+// ReactToMessageRequest represents a request to react to a message with an emoji
+type ReactToMessageRequest struct {
+	ConversationID uuid.UUID `json:"conversation_id" validate:"required"`
+	MessageID      uuid.UUID `json:"message_id" validate:"required"`
+	UserID         uuid.UUID `json:"user_id" validate:"required"`
+	Emoji          string    `json:"emoji" validate:"required"`
+}
+
+// ReactToMessageResponse represents the response after reacting to a message
+type ReactToMessageResponse struct {
+	MessageID uuid.UUID      `json:"message_id"`
+	Emoji     string         `json:"emoji"`
+	Added     bool           `json:"added"`
+	Reactions map[string]int `json:"reactions"`
+	Success   bool           `json:"success"`
+	Error     string         `json:"error,omitempty"`
+}
+
+// ReactToMessageUseCase handles emoji reactions to messages
+type ReactToMessageUseCase struct {
+	messageRepo repositories.MessageRepository
+	config      *config.MessageConfig
+}
+
+// NewReactToMessageUseCase creates a new react to message use case
+func NewReactToMessageUseCase(messageRepo repositories.MessageRepository, cfg *config.MessageConfig) *ReactToMessageUseCase {
+	return &ReactToMessageUseCase{
+		messageRepo: messageRepo,
+		config:      cfg,
+	}
+}
+
+// Execute toggles the user's reaction to a message with one of the allowed emoji: reacting with an
+// emoji the user already reacted with removes it. Only participants can react, and deleted messages
+// cannot be reacted to. The response holds the message's reaction counts after the change.
+func (uc *ReactToMessageUseCase) Execute(ctx context.Context, req *ReactToMessageRequest) (*ReactToMessageResponse, error) {
+	if err := req.Validate(); err != nil {
+		return &ReactToMessageResponse{
+			Success: false,
+			Error:   err.Error(),
+		}, nil
+	}
+
+	emoji := strings.TrimSpace(req.Emoji)
+	if !uc.isAllowed(emoji) {
+		return &ReactToMessageResponse{
+			Success: false,
+			Error:   "Reaction is not supported",
+		}, nil
+	}
+
+	canAccess, err := uc.messageRepo.UserCanAccessConversation(ctx, req.UserID, req.ConversationID)
+	if err != nil {
+		logger.Error("Failed to check conversation access", err)
+		return &ReactToMessageResponse{
+			Success: false,
+			Error:   "Failed to check conversation access",
+		}, nil
+	}
+
+	if !canAccess {
+		return &ReactToMessageResponse{
+			Success: false,
+			Error:   "User cannot access this conversation",
+		}, nil
+	}
+
+	message, err := uc.messageRepo.GetByID(ctx, req.MessageID)
+	if err != nil || message.ConversationID != req.ConversationID {
+		return &ReactToMessageResponse{
+			Success: false,
+			Error:   "Message not found",
+		}, nil
+	}
+
+	if message.IsDeleted {
+		return &ReactToMessageResponse{
+			Success: false,
+			Error:   "Deleted messages cannot be reacted to",
+		}, nil
+	}
+
+	// Removing first makes a repeated reaction a toggle, one per message, user and emoji
+	removed, err := uc.messageRepo.RemoveReaction(ctx, req.MessageID, req.UserID, emoji)
+	if err != nil {
+		logger.Error("Failed to remove message reaction", err)
+		return &ReactToMessageResponse{
+			Success: false,
+			Error:   "Failed to react to message",
+		}, nil
+	}
+
+	if !removed {
+		reaction := &entities.MessageReaction{
+			ID:        uuid.New(),
+			MessageID: req.MessageID,
+			UserID:    req.UserID,
+			Emoji:     emoji,
+			CreatedAt: time.Now(),
+		}
+		if err := uc.messageRepo.AddReaction(ctx, reaction); err != nil {
+			logger.Error("Failed to add message reaction", err)
+			return &ReactToMessageResponse{
+				Success: false,
+				Error:   "Failed to react to message",
+			}, nil
+		}
+	}
+
+	counts, err := uc.messageRepo.GetReactionCounts(ctx, []uuid.UUID{req.MessageID})
+	if err != nil {
+		logger.Error("Failed to get message reaction counts", err)
+		return &ReactToMessageResponse{
+			Success: false,
+			Error:   "Failed to react to message",
+		}, nil
+	}
+
+	reactions := counts[req.MessageID]
+	if reactions == nil {
+		reactions = map[string]int{}
+	}
+
+	logger.Info("Message reaction updated",
+		"message_id", req.MessageID,
+		"conversation_id", req.ConversationID,
+		"user_id", req.UserID,
+		"emoji", emoji,
+		"added", !removed,
+	)
+
+	return &ReactToMessageResponse{
+		MessageID: req.MessageID,
+		Emoji:     emoji,
+		Added:     !removed,
+		Reactions: reactions,
+		Success:   true,
+	}, nil
+}
+
+// isAllowed reports whether the emoji is one of the configured reactions
+func (uc *ReactToMessageUseCase) isAllowed(emoji string) bool {
+	for _, allowed := range uc.config.AllowedReactions {
+		if emoji == allowed {
+			return true
+		}
+	}
+	return false
+}
+
+// Validate validates the request
+func (req *ReactToMessageRequest) Validate() error {
+	if req.ConversationID == uuid.Nil {
+		return fmt.Errorf("conversation_id is required")
+	}
+	if req.MessageID == uuid.Nil {
+		return fmt.Errorf("message_id is required")
+	}
+	if req.UserID == uuid.Nil {
+		return fmt.Errorf("user_id is required")
+	}
+	if strings.TrimSpace(req.Emoji) == "" {
+		return fmt.Errorf("emoji is required")
+	}
+	return nil
+}
@@ -0,0 +1,158 @@
+package chat
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"github.com/22smeargle/winkr-backend/internal/domain/entities"
+	"github.com/22smeargle/winkr-backend/pkg/config"
+)
+
+func (m *MockMessageRepository) AddReaction(ctx context.Context, reaction *entities.MessageReaction) error {
+	args := m.Called(ctx, reaction)
+	return args.Error(0)
+}
+
+func (m *MockMessageRepository) RemoveReaction(ctx context.Context, messageID, userID uuid.UUID, emoji string) (bool, error) {
+	args := m.Called(ctx, messageID, userID, emoji)
+	return args.Bool(0), args.Error(1)
+}
+
+func (m *MockMessageRepository) GetReactionCounts(ctx context.Context, messageIDs []uuid.UUID) (map[uuid.UUID]map[string]int, error) {
+	args := m.Called(ctx, messageIDs)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(map[uuid.UUID]map[string]int), args.Error(1)
+}
+
+func testReactionConfig() *config.MessageConfig {
+	return &config.MessageConfig{AllowedReactions: []string{"❤️", "😂"}}
+}
+
+func newReactionTestMessage() *entities.Message {
+	return &entities.Message{
+		ID:             uuid.New(),
+		ConversationID: uuid.New(),
+		SenderID:       uuid.New(),
+		Content:        "I got the job!",
+		MessageType:    "text",
+		CreatedAt:      time.Now().Add(-time.Minute),
+	}
+}
+
+func TestReactToMessageUseCase_AddsReaction(t *testing.T) {
+	userID := uuid.New()
+	message := newReactionTestMessage()
+
+	messageRepo := new(MockMessageRepository)
+	messageRepo.On("UserCanAccessConversation", mock.Anything, userID, message.ConversationID).Return(true, nil)
+	messageRepo.On("GetByID", mock.Anything, message.ID).Return(message, nil)
+	messageRepo.On("RemoveReaction", mock.Anything, message.ID, userID, "❤️").Return(false, nil)
+	messageRepo.On("AddReaction", mock.Anything, mock.MatchedBy(func(reaction *entities.MessageReaction) bool {
+		return reaction.MessageID == message.ID && reaction.UserID == userID && reaction.Emoji == "❤️"
+	})).Return(nil)
+	messageRepo.On("GetReactionCounts", mock.Anything, []uuid.UUID{message.ID}).
+		Return(map[uuid.UUID]map[string]int{message.ID: {"❤️": 2}}, nil)
+
+	resp, err := NewReactToMessageUseCase(messageRepo, testReactionConfig()).Execute(context.Background(), &ReactToMessageRequest{
+		ConversationID: message.ConversationID,
+		MessageID:      message.ID,
+		UserID:         userID,
+		Emoji:          " ❤️ ",
+	})
+
+	require.NoError(t, err)
+	require.True(t, resp.Success, resp.Error)
+	assert.True(t, resp.Added)
+	assert.Equal(t, "❤️", resp.Emoji)
+	assert.Equal(t, map[string]int{"❤️": 2}, resp.Reactions)
+	messageRepo.AssertExpectations(t)
+}
+
+func TestReactToMessageUseCase_SameEmojiAgainRemovesIt(t *testing.T) {
+	userID := uuid.New()
+	message := newReactionTestMessage()
+
+	messageRepo := new(MockMessageRepository)
+	messageRepo.On("UserCanAccessConversation", mock.Anything, userID, message.ConversationID).Return(true, nil)
+	messageRepo.On("GetByID", mock.Anything, message.ID).Return(message, nil)
+	messageRepo.On("RemoveReaction", mock.Anything, message.ID, userID, "😂").Return(true, nil)
+	messageRepo.On("GetReactionCounts", mock.Anything, []uuid.UUID{message.ID}).
+		Return(map[uuid.UUID]map[string]int{}, nil)
+
+	resp, err := NewReactToMessageUseCase(messageRepo, testReactionConfig()).Execute(context.Background(), &ReactToMessageRequest{
+		ConversationID: message.ConversationID,
+		MessageID:      message.ID,
+		UserID:         userID,
+		Emoji:          "😂",
+	})
+
+	require.NoError(t, err)
+	require.True(t, resp.Success, resp.Error)
+	assert.False(t, resp.Added)
+	assert.Empty(t, resp.Reactions)
+	messageRepo.AssertNotCalled(t, "AddReaction", mock.Anything, mock.Anything)
+}
+
+func TestReactToMessageUseCase_Rejections(t *testing.T) {
+	userID := uuid.New()
+	deleted := newReactionTestMessage()
+	deleted.SoftDelete()
+
+	tests := []struct {
+		name      string
+		message   *entities.Message
+		canAccess bool
+		emoji     string
+		error     string
+	}{
+		{
+			name:      "emoji not allowed",
+			message:   newReactionTestMessage(),
+			canAccess: true,
+			emoji:     "🍆",
+			error:     "Reaction is not supported",
+		},
+		{
+			name:      "not a participant",
+			message:   newReactionTestMessage(),
+			canAccess: false,
+			emoji:     "❤️",
+			error:     "User cannot access this conversation",
+		},
+		{
+			name:      "deleted message",
+			message:   deleted,
+			canAccess: true,
+			emoji:     "❤️",
+			error:     "Deleted messages cannot be reacted to",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			messageRepo := new(MockMessageRepository)
+			messageRepo.On("UserCanAccessConversation", mock.Anything, userID, tt.message.ConversationID).Return(tt.canAccess, nil)
+			messageRepo.On("GetByID", mock.Anything, tt.message.ID).Return(tt.message, nil)
+
+			resp, err := NewReactToMessageUseCase(messageRepo, testReactionConfig()).Execute(context.Background(), &ReactToMessageRequest{
+				ConversationID: tt.message.ConversationID,
+				MessageID:      tt.message.ID,
+				UserID:         userID,
+				Emoji:          tt.emoji,
+			})
+
+			require.NoError(t, err)
+			assert.False(t, resp.Success)
+			assert.Equal(t, tt.error, resp.Error)
+			messageRepo.AssertNotCalled(t, "RemoveReaction", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+			messageRepo.AssertNotCalled(t, "AddReaction", mock.Anything, mock.Anything)
+		})
+	}
+}
@@ -0,0 +1,413 @@
+package chat
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/22smeargle/winkr-backend/internal/application/services"
+	"github.com/22smeargle/winkr-backend/internal/domain/entities"
+	"github.com/22smeargle/winkr-backend/internal/domain/repositories"
+	"github.com/22smeargle/winkr-backend/pkg/config"
+	"github.com/22smeargle/winkr-backend/pkg/logger"
+)
+
+// dueScheduledMessageBatchSize is how many due scheduled messages are sent per run
+const dueScheduledMessageBatchSize = 100
+
+// Error codes for scheduled messages that cannot be canceled
+const (
+	ErrorCodeScheduledMessageNotFound      = "scheduled_message_not_found"
+	ErrorCodeScheduledMessageNotCancelable = "scheduled_message_not_cancelable"
+)
+
+// Reasons a due scheduled message is skipped, besides the conversation error codes
+const (
+	SkipReasonConversationLimitReached = "conversation_limit_reached"
+	SkipReasonRejected                 = "rejected"
+	SkipReasonFailed                   = "failed"
+)
+
+// ScheduledMessageSender sends a scheduled message once it is due, with the same checks as any other message
+type ScheduledMessageSender interface {
+	Execute(ctx context.Context, req *SendMessageRequest) (*SendMessageResponse, error)
+}
+
+// ScheduledMessageNotifier tells connected clients what happened to a due scheduled message
+type ScheduledMessageNotifier interface {
+	// NotifyMessageSent pushes the sent message to the conversation
+	NotifyMessageSent(ctx context.Context, message *services.ProcessedMessage) error
+	// NotifySkipped tells the sender their scheduled message was not sent
+	NotifySkipped(ctx context.Context, scheduled *entities.ScheduledMessage) error
+}
+
+// ScheduleMessageRequest represents a request to schedule a message
+type ScheduleMessageRequest struct {
+	ConversationID uuid.UUID `json:"conversation_id" validate:"required"`
+	SenderID       uuid.UUID `json:"sender_id" validate:"required"`
+	Content        string    `json:"content" validate:"required,max=2000"`
+	SendAt         time.Time `json:"send_at" validate:"required"`
+}
+
+// CancelScheduledMessageRequest represents a request to cancel a scheduled message
+type CancelScheduledMessageRequest struct {
+	ConversationID     uuid.UUID `json:"conversation_id" validate:"required"`
+	ScheduledMessageID uuid.UUID `json:"scheduled_message_id" validate:"required"`
+	UserID             uuid.UUID `json:"user_id" validate:"required"`
+}
+
+// ScheduleMessageResponse represents the response after scheduling or canceling a message
+type ScheduleMessageResponse struct {
+	ScheduledMessage *entities.ScheduledMessage `json:"scheduled_message,omitempty"`
+	Success          bool                       `json:"success"`
+	Error            string                     `json:"error,omitempty"`
+	ErrorCode        string                     `json:"error_code,omitempty"`
+}
+
+// ScheduleMessageUseCase handles messages scheduled to be sent later. Due messages are sent by a
+// background job that runs them through the same checks as any other message, so a message is
+// skipped if its conversation closed or its content no longer passes filtering.
+type ScheduleMessageUseCase struct {
+	messageRepo   repositories.MessageRepository
+	scheduledRepo repositories.ScheduledMessageRepository
+	sender        ScheduledMessageSender
+	notifier      ScheduledMessageNotifier
+	config        *config.ScheduledMessageConfig
+	now           func() time.Time
+}
+
+// NewScheduleMessageUseCase creates a new schedule message use case
+func NewScheduleMessageUseCase(
+	messageRepo repositories.MessageRepository,
+	scheduledRepo repositories.ScheduledMessageRepository,
+	sender ScheduledMessageSender,
+	notifier ScheduledMessageNotifier,
+	cfg *config.ScheduledMessageConfig,
+) *ScheduleMessageUseCase {
+	return &ScheduleMessageUseCase{
+		messageRepo:   messageRepo,
+		scheduledRepo: scheduledRepo,
+		sender:        sender,
+		notifier:      notifier,
+		config:        cfg,
+		now:           time.Now,
+	}
+}
+
+// Schedule schedules a text message to be sent to a conversation at the requested time
+func (uc *ScheduleMessageUseCase) Schedule(ctx context.Context, req *ScheduleMessageRequest) (*ScheduleMessageResponse, error) {
+	if !uc.config.Enabled {
+		return &ScheduleMessageResponse{
+			Success: false,
+			Error:   "Scheduled messages are not available",
+		}, nil
+	}
+
+	if err := uc.validate(req); err != nil {
+		return &ScheduleMessageResponse{
+			Success: false,
+			Error:   err.Error(),
+		}, nil
+	}
+
+	if resp := uc.unavailableConversationResponse(ctx, req.SenderID, req.ConversationID); resp != nil {
+		return resp, nil
+	}
+
+	if uc.config.MaxPerConversation > 0 {
+		count, err := uc.scheduledRepo.CountScheduledByConversation(ctx, req.ConversationID, req.SenderID)
+		if err != nil {
+			logger.Error("Failed to count scheduled messages", err)
+			return &ScheduleMessageResponse{
+				Success: false,
+				Error:   "Failed to schedule message",
+			}, nil
+		}
+		if count >= int64(uc.config.MaxPerConversation) {
+			return &ScheduleMessageResponse{
+				Success: false,
+				Error:   fmt.Sprintf("You can have up to %d messages scheduled in a conversation", uc.config.MaxPerConversation),
+			}, nil
+		}
+	}
+
+	scheduled := &entities.ScheduledMessage{
+		ConversationID: req.ConversationID,
+		SenderID:       req.SenderID,
+		Content:        req.Content,
+		SendAt:         req.SendAt.UTC(),
+		Status:         entities.ScheduledMessageStatusScheduled,
+	}
+
+	if err := uc.scheduledRepo.Create(ctx, scheduled); err != nil {
+		logger.Error("Failed to save scheduled message", err)
+		return &ScheduleMessageResponse{
+			Success: false,
+			Error:   "Failed to schedule message",
+		}, nil
+	}
+
+	logger.Info("Message scheduled",
+		"scheduled_message_id", scheduled.ID,
+		"conversation_id", scheduled.ConversationID,
+		"sender_id", scheduled.SenderID,
+		"send_at", scheduled.SendAt,
+	)
+
+	return &ScheduleMessageResponse{
+		ScheduledMessage: scheduled,
+		Success:          true,
+	}, nil
+}
+
+// Cancel cancels a message the user scheduled, as long as it has not started sending.
+// Canceling twice is a no-op.
+func (uc *ScheduleMessageUseCase) Cancel(ctx context.Context, req *CancelScheduledMessageRequest) (*ScheduleMessageResponse, error) {
+	notFound := &ScheduleMessageResponse{
+		Success:   false,
+		Error:     "Scheduled message not found",
+		ErrorCode: ErrorCodeScheduledMessageNotFound,
+	}
+
+	scheduled, err := uc.scheduledRepo.GetByID(ctx, req.ScheduledMessageID)
+	if err != nil || scheduled.SenderID != req.UserID || scheduled.ConversationID != req.ConversationID {
+		return notFound, nil
+	}
+
+	switch scheduled.Status {
+	case entities.ScheduledMessageStatusCanceled:
+		return &ScheduleMessageResponse{
+			ScheduledMessage: scheduled,
+			Success:          true,
+		}, nil
+	case entities.ScheduledMessageStatusScheduled:
+	default:
+		return uc.notCancelableResponse(scheduled), nil
+	}
+
+	now := uc.now()
+	canceled, err := uc.scheduledRepo.MarkCanceled(ctx, scheduled.ID, now)
+	if err != nil {
+		logger.Error("Failed to cancel scheduled message", err)
+		return &ScheduleMessageResponse{
+			Success: false,
+			Error:   "Failed to cancel scheduled message",
+		}, nil
+	}
+	if !canceled {
+		// Picked up for sending, or canceled, concurrently
+		scheduled, err = uc.scheduledRepo.GetByID(ctx, scheduled.ID)
+		if err != nil {
+			return notFound, nil
+		}
+		if scheduled.Status == entities.ScheduledMessageStatusCanceled {
+			return &ScheduleMessageResponse{ScheduledMessage: scheduled, Success: true}, nil
+		}
+		return uc.notCancelableResponse(scheduled), nil
+	}
+
+	scheduled.Status = entities.ScheduledMessageStatusCanceled
+	scheduled.CanceledAt = &now
+
+	logger.Info("Scheduled message canceled", "scheduled_message_id", scheduled.ID, "sender_id", scheduled.SenderID)
+
+	return &ScheduleMessageResponse{
+		ScheduledMessage: scheduled,
+		Success:          true,
+	}, nil
+}
+
+// SendDueMessages sends the scheduled messages whose send time has come. It returns how many were sent.
+func (uc *ScheduleMessageUseCase) SendDueMessages(ctx context.Context) (int, error) {
+	now := uc.now()
+	due, err := uc.scheduledRepo.GetScheduledDueBefore(ctx, now, dueScheduledMessageBatchSize)
+	if err != nil {
+		return 0, fmt.Errorf("failed to get due scheduled messages: %w", err)
+	}
+
+	sent := 0
+	for _, scheduled := range due {
+		if !scheduled.IsDue(now) {
+			continue
+		}
+
+		// Only the run that claims the message sends it, and it can no longer be canceled
+		claimed, err := uc.scheduledRepo.MarkSending(ctx, scheduled.ID)
+		if err != nil {
+			logger.Error("Failed to claim scheduled message", err, "scheduled_message_id", scheduled.ID)
+			continue
+		}
+		if !claimed {
+			continue
+		}
+		scheduled.Status = entities.ScheduledMessageStatusSending
+
+		if uc.send(ctx, scheduled) {
+			sent++
+		}
+	}
+
+	if sent > 0 {
+		logger.Info("Scheduled messages sent", "count", sent)
+	}
+
+	return sent, nil
+}
+
+// send sends a claimed scheduled message, or records why it was skipped. It returns true if the
+// message was sent.
+func (uc *ScheduleMessageUseCase) send(ctx context.Context, scheduled *entities.ScheduledMessage) bool {
+	response, err := uc.sender.Execute(ctx, &SendMessageRequest{
+		ConversationID: scheduled.ConversationID,
+		SenderID:       scheduled.SenderID,
+		Content:        scheduled.Content,
+		MessageType:    "text",
+	})
+	if err != nil {
+		logger.Error("Failed to send scheduled message", err, "scheduled_message_id", scheduled.ID)
+		uc.skip(ctx, scheduled, SkipReasonFailed)
+		return false
+	}
+
+	if !response.Success || response.Message == nil {
+		uc.skip(ctx, scheduled, skipReason(response))
+		return false
+	}
+
+	sentAt := uc.now()
+	if err := uc.scheduledRepo.MarkSent(ctx, scheduled.ID, response.Message.ID, sentAt); err != nil {
+		logger.Error("Failed to record scheduled message sent", err, "scheduled_message_id", scheduled.ID)
+	}
+	scheduled.Status = entities.ScheduledMessageStatusSent
+	scheduled.MessageID = &response.Message.ID
+	scheduled.SentAt = &sentAt
+
+	if err := uc.notifier.NotifyMessageSent(ctx, response.Message); err != nil {
+		logger.Error("Failed to broadcast scheduled message", err, "scheduled_message_id", scheduled.ID)
+	}
+
+	return true
+}
+
+// skip records why a claimed scheduled message was not sent and tells the sender
+func (uc *ScheduleMessageUseCase) skip(ctx context.Context, scheduled *entities.ScheduledMessage, reason string) {
+	skippedAt := uc.now()
+	if err := uc.scheduledRepo.MarkSkipped(ctx, scheduled.ID, reason, skippedAt); err != nil {
+		logger.Error("Failed to record scheduled message skipped", err, "scheduled_message_id", scheduled.ID)
+	}
+	scheduled.Status = entities.ScheduledMessageStatusSkipped
+	scheduled.SkipReason = &reason
+	scheduled.SkippedAt = &skippedAt
+
+	logger.Info("Scheduled message skipped", "scheduled_message_id", scheduled.ID, "reason", reason)
+
+	if err := uc.notifier.NotifySkipped(ctx, scheduled); err != nil {
+		logger.Error("Failed to tell sender a scheduled message was skipped", err, "scheduled_message_id", scheduled.ID)
+	}
+}
+
+// StartDeliveryScheduler periodically sends due scheduled messages
+func (uc *ScheduleMessageUseCase) StartDeliveryScheduler(ctx context.Context, interval time.Duration) {
+	logger.Info("Starting scheduled message delivery scheduler", "interval", interval)
+
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ctx.Done():
+				logger.Info("Scheduled message delivery scheduler stopped")
+				return
+			case <-ticker.C:
+				if _, err := uc.SendDueMessages(ctx); err != nil {
+					logger.Error("Scheduled message delivery run failed", err)
+				}
+			}
+		}
+	}()
+}
+
+// validate checks the content and that the send time is within the allowed lead times
+func (uc *ScheduleMessageUseCase) validate(req *ScheduleMessageRequest) error {
+	if strings.TrimSpace(req.Content) == "" {
+		return fmt.Errorf("content is required")
+	}
+	if len(req.Content) > 2000 {
+		return fmt.Errorf("content too long (max 2000 characters)")
+	}
+
+	now := uc.now()
+	if req.SendAt.Before(now.Add(uc.config.MinLeadTime)) {
+		return fmt.Errorf("send_at must be at least %s from now", uc.config.MinLeadTime)
+	}
+	if uc.config.MaxLeadTime > 0 && req.SendAt.After(now.Add(uc.config.MaxLeadTime)) {
+		return fmt.Errorf("send_at must be within %s from now", uc.config.MaxLeadTime)
+	}
+
+	return nil
+}
+
+// unavailableConversationResponse returns the response for a conversation the sender can't schedule
+// messages in, or nil if they can. Whether the message can actually be sent is checked again when it is due.
+func (uc *ScheduleMessageUseCase) unavailableConversationResponse(ctx context.Context, senderID, conversationID uuid.UUID) *ScheduleMessageResponse {
+	canAccess, err := uc.messageRepo.UserCanAccessConversation(ctx, senderID, conversationID)
+	if err != nil {
+		logger.Error("Failed to check conversation access", err)
+		return &ScheduleMessageResponse{
+			Success: false,
+			Error:   "Failed to check conversation access",
+		}
+	}
+
+	if !canAccess {
+		return &ScheduleMessageResponse{
+			Success:   false,
+			Error:     "Conversation not found",
+			ErrorCode: ErrorCodeConversationNotFound,
+		}
+	}
+
+	conversation, err := uc.messageRepo.GetConversation(ctx, conversationID)
+	if err != nil {
+		logger.Error("Failed to get conversation", err)
+		return &ScheduleMessageResponse{
+			Success: false,
+			Error:   "Failed to get conversation",
+		}
+	}
+
+	if conversation.IsClosed() {
+		return &ScheduleMessageResponse{
+			Success:   false,
+			Error:     "Conversation is closed",
+			ErrorCode: ErrorCodeConversationClosed,
+		}
+	}
+
+	return nil
+}
+
+// notCancelableResponse returns the response for canceling a message that is no longer waiting to be sent
+func (uc *ScheduleMessageUseCase) notCancelableResponse(scheduled *entities.ScheduledMessage) *ScheduleMessageResponse {
+	return &ScheduleMessageResponse{
+		ScheduledMessage: scheduled,
+		Success:          false,
+		Error:            "Scheduled message was already sent or skipped",
+		ErrorCode:        ErrorCodeScheduledMessageNotCancelable,
+	}
+}
+
+// skipReason returns why the send of a due scheduled message was refused
+func skipReason(response *SendMessageResponse) string {
+	switch {
+	case response.ErrorCode != "":
+		return response.ErrorCode
+	case response.UpgradeRequired:
+		return SkipReasonConversationLimitReached
+	default:
+		return SkipReasonRejected
+	}
+}
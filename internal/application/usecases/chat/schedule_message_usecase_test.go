@@ -0,0 +1,330 @@
+package chat
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"github.com/22smeargle/winkr-backend/internal/application/services"
+	"github.com/22smeargle/winkr-backend/internal/domain/entities"
+	"github.com/22smeargle/winkr-backend/internal/domain/repositories"
+	"github.com/22smeargle/winkr-backend/pkg/config"
+)
+
+type memoryScheduledMessageRepository struct {
+	repositories.ScheduledMessageRepository
+	messages map[uuid.UUID]*entities.ScheduledMessage
+}
+
+func newMemoryScheduledMessageRepository() *memoryScheduledMessageRepository {
+	return &memoryScheduledMessageRepository{messages: make(map[uuid.UUID]*entities.ScheduledMessage)}
+}
+
+func (r *memoryScheduledMessageRepository) Create(ctx context.Context, message *entities.ScheduledMessage) error {
+	message.ID = uuid.New()
+	stored := *message
+	r.messages[message.ID] = &stored
+	return nil
+}
+
+func (r *memoryScheduledMessageRepository) GetByID(ctx context.Context, id uuid.UUID) (*entities.ScheduledMessage, error) {
+	message, ok := r.messages[id]
+	if !ok {
+		return nil, errors.New("scheduled message not found")
+	}
+	copied := *message
+	return &copied, nil
+}
+
+func (r *memoryScheduledMessageRepository) CountScheduledByConversation(ctx context.Context, conversationID, senderID uuid.UUID) (int64, error) {
+	var count int64
+	for _, message := range r.messages {
+		if message.ConversationID == conversationID && message.SenderID == senderID && message.Status == entities.ScheduledMessageStatusScheduled {
+			count++
+		}
+	}
+	return count, nil
+}
+
+func (r *memoryScheduledMessageRepository) GetScheduledDueBefore(ctx context.Context, before time.Time, limit int) ([]*entities.ScheduledMessage, error) {
+	var due []*entities.ScheduledMessage
+	for _, message := range r.messages {
+		if message.IsDue(before) {
+			copied := *message
+			due = append(due, &copied)
+		}
+	}
+	return due, nil
+}
+
+func (r *memoryScheduledMessageRepository) MarkSending(ctx context.Context, id uuid.UUID) (bool, error) {
+	message := r.messages[id]
+	if message.Status != entities.ScheduledMessageStatusScheduled {
+		return false, nil
+	}
+	message.Status = entities.ScheduledMessageStatusSending
+	return true, nil
+}
+
+func (r *memoryScheduledMessageRepository) MarkSent(ctx context.Context, id, messageID uuid.UUID, sentAt time.Time) error {
+	message := r.messages[id]
+	message.Status = entities.ScheduledMessageStatusSent
+	message.MessageID = &messageID
+	message.SentAt = &sentAt
+	return nil
+}
+
+func (r *memoryScheduledMessageRepository) MarkSkipped(ctx context.Context, id uuid.UUID, reason string, skippedAt time.Time) error {
+	message := r.messages[id]
+	message.Status = entities.ScheduledMessageStatusSkipped
+	message.SkipReason = &reason
+	message.SkippedAt = &skippedAt
+	return nil
+}
+
+func (r *memoryScheduledMessageRepository) MarkCanceled(ctx context.Context, id uuid.UUID, canceledAt time.Time) (bool, error) {
+	message := r.messages[id]
+	if message.Status != entities.ScheduledMessageStatusScheduled {
+		return false, nil
+	}
+	message.Status = entities.ScheduledMessageStatusCanceled
+	message.CanceledAt = &canceledAt
+	return true, nil
+}
+
+// fakeScheduledSender sends messages unless their conversation has been closed
+type fakeScheduledSender struct {
+	closed map[uuid.UUID]bool
+	sent   []*SendMessageRequest
+}
+
+func (s *fakeScheduledSender) Execute(ctx context.Context, req *SendMessageRequest) (*SendMessageResponse, error) {
+	if s.closed[req.ConversationID] {
+		return &SendMessageResponse{
+			Success:   false,
+			Error:     "Conversation is closed",
+			ErrorCode: ErrorCodeConversationClosed,
+		}, nil
+	}
+	s.sent = append(s.sent, req)
+	return &SendMessageResponse{
+		Message: &services.ProcessedMessage{Message: &entities.Message{
+			ID:             uuid.New(),
+			ConversationID: req.ConversationID,
+			SenderID:       req.SenderID,
+			Content:        req.Content,
+			MessageType:    req.MessageType,
+		}},
+		Success: true,
+	}, nil
+}
+
+type recordingScheduledNotifier struct {
+	sent    []*services.ProcessedMessage
+	skipped []*entities.ScheduledMessage
+}
+
+func (n *recordingScheduledNotifier) NotifyMessageSent(ctx context.Context, message *services.ProcessedMessage) error {
+	n.sent = append(n.sent, message)
+	return nil
+}
+
+func (n *recordingScheduledNotifier) NotifySkipped(ctx context.Context, scheduled *entities.ScheduledMessage) error {
+	n.skipped = append(n.skipped, scheduled)
+	return nil
+}
+
+func testScheduledMessageConfig() *config.ScheduledMessageConfig {
+	return &config.ScheduledMessageConfig{
+		Enabled:            true,
+		MinLeadTime:        time.Minute,
+		MaxLeadTime:        7 * 24 * time.Hour,
+		MaxPerConversation: 2,
+	}
+}
+
+type scheduleTestFixture struct {
+	useCase        *ScheduleMessageUseCase
+	scheduledRepo  *memoryScheduledMessageRepository
+	sender         *fakeScheduledSender
+	notifier       *recordingScheduledNotifier
+	now            time.Time
+	senderID       uuid.UUID
+	conversationID uuid.UUID
+}
+
+func newScheduleTestFixture() *scheduleTestFixture {
+	f := &scheduleTestFixture{
+		scheduledRepo:  newMemoryScheduledMessageRepository(),
+		sender:         &fakeScheduledSender{closed: make(map[uuid.UUID]bool)},
+		notifier:       &recordingScheduledNotifier{},
+		now:            time.Date(2026, 3, 14, 18, 0, 0, 0, time.UTC),
+		senderID:       uuid.New(),
+		conversationID: uuid.New(),
+	}
+
+	messageRepo := new(MockMessageRepository)
+	messageRepo.On("UserCanAccessConversation", mock.Anything, f.senderID, f.conversationID).Return(true, nil)
+	messageRepo.On("GetConversation", mock.Anything, f.conversationID).Return(&entities.Conversation{ID: f.conversationID}, nil)
+
+	f.useCase = NewScheduleMessageUseCase(messageRepo, f.scheduledRepo, f.sender, f.notifier, testScheduledMessageConfig())
+	f.useCase.now = func() time.Time { return f.now }
+	return f
+}
+
+func (f *scheduleTestFixture) schedule(t *testing.T, sendIn time.Duration) *entities.ScheduledMessage {
+	t.Helper()
+	resp, err := f.useCase.Schedule(context.Background(), &ScheduleMessageRequest{
+		ConversationID: f.conversationID,
+		SenderID:       f.senderID,
+		Content:        "happy birthday!",
+		SendAt:         f.now.Add(sendIn),
+	})
+	require.NoError(t, err)
+	require.True(t, resp.Success, resp.Error)
+	return resp.ScheduledMessage
+}
+
+func TestScheduleMessageUseCase_SendsWhenDue(t *testing.T) {
+	f := newScheduleTestFixture()
+	scheduled := f.schedule(t, time.Hour)
+
+	sent, err := f.useCase.SendDueMessages(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, 0, sent)
+	assert.Empty(t, f.sender.sent)
+
+	f.now = f.now.Add(time.Hour)
+	sent, err = f.useCase.SendDueMessages(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, 1, sent)
+
+	require.Len(t, f.sender.sent, 1)
+	assert.Equal(t, "happy birthday!", f.sender.sent[0].Content)
+	assert.Equal(t, "text", f.sender.sent[0].MessageType)
+	require.Len(t, f.notifier.sent, 1)
+
+	stored := f.scheduledRepo.messages[scheduled.ID]
+	assert.Equal(t, entities.ScheduledMessageStatusSent, stored.Status)
+	require.NotNil(t, stored.MessageID)
+	assert.Equal(t, f.notifier.sent[0].ID, *stored.MessageID)
+
+	// A later run does not send it again
+	sent, err = f.useCase.SendDueMessages(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, 0, sent)
+	assert.Len(t, f.sender.sent, 1)
+}
+
+func TestScheduleMessageUseCase_CanceledMessageIsNotSent(t *testing.T) {
+	f := newScheduleTestFixture()
+	scheduled := f.schedule(t, time.Hour)
+
+	resp, err := f.useCase.Cancel(context.Background(), &CancelScheduledMessageRequest{
+		ConversationID:     f.conversationID,
+		ScheduledMessageID: scheduled.ID,
+		UserID:             f.senderID,
+	})
+	require.NoError(t, err)
+	require.True(t, resp.Success, resp.Error)
+	assert.Equal(t, entities.ScheduledMessageStatusCanceled, resp.ScheduledMessage.Status)
+
+	f.now = f.now.Add(2 * time.Hour)
+	sent, err := f.useCase.SendDueMessages(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, 0, sent)
+	assert.Empty(t, f.sender.sent)
+	assert.Empty(t, f.notifier.skipped)
+}
+
+func TestScheduleMessageUseCase_CannotCancelAfterSent(t *testing.T) {
+	f := newScheduleTestFixture()
+	scheduled := f.schedule(t, time.Hour)
+
+	f.now = f.now.Add(time.Hour)
+	_, err := f.useCase.SendDueMessages(context.Background())
+	require.NoError(t, err)
+
+	resp, err := f.useCase.Cancel(context.Background(), &CancelScheduledMessageRequest{
+		ConversationID:     f.conversationID,
+		ScheduledMessageID: scheduled.ID,
+		UserID:             f.senderID,
+	})
+	require.NoError(t, err)
+	assert.False(t, resp.Success)
+	assert.Equal(t, ErrorCodeScheduledMessageNotCancelable, resp.ErrorCode)
+}
+
+func TestScheduleMessageUseCase_OnlySenderCanCancel(t *testing.T) {
+	f := newScheduleTestFixture()
+	scheduled := f.schedule(t, time.Hour)
+
+	resp, err := f.useCase.Cancel(context.Background(), &CancelScheduledMessageRequest{
+		ConversationID:     f.conversationID,
+		ScheduledMessageID: scheduled.ID,
+		UserID:             uuid.New(),
+	})
+	require.NoError(t, err)
+	assert.False(t, resp.Success)
+	assert.Equal(t, ErrorCodeScheduledMessageNotFound, resp.ErrorCode)
+	assert.Equal(t, entities.ScheduledMessageStatusScheduled, f.scheduledRepo.messages[scheduled.ID].Status)
+}
+
+func TestScheduleMessageUseCase_SkipsWhenConversationClosedBeforeSend(t *testing.T) {
+	f := newScheduleTestFixture()
+	scheduled := f.schedule(t, time.Hour)
+
+	f.sender.closed[f.conversationID] = true
+	f.now = f.now.Add(time.Hour)
+
+	sent, err := f.useCase.SendDueMessages(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, 0, sent)
+	assert.Empty(t, f.sender.sent)
+	assert.Empty(t, f.notifier.sent)
+
+	stored := f.scheduledRepo.messages[scheduled.ID]
+	assert.Equal(t, entities.ScheduledMessageStatusSkipped, stored.Status)
+	require.NotNil(t, stored.SkipReason)
+	assert.Equal(t, ErrorCodeConversationClosed, *stored.SkipReason)
+	require.Len(t, f.notifier.skipped, 1)
+	assert.Equal(t, scheduled.ID, f.notifier.skipped[0].ID)
+}
+
+func TestScheduleMessageUseCase_RejectsSendTimeOutsideLeadTimes(t *testing.T) {
+	f := newScheduleTestFixture()
+
+	for _, sendIn := range []time.Duration{-time.Minute, 30 * time.Second, 8 * 24 * time.Hour} {
+		resp, err := f.useCase.Schedule(context.Background(), &ScheduleMessageRequest{
+			ConversationID: f.conversationID,
+			SenderID:       f.senderID,
+			Content:        "see you soon",
+			SendAt:         f.now.Add(sendIn),
+		})
+		require.NoError(t, err)
+		assert.False(t, resp.Success, "send in %s", sendIn)
+	}
+	assert.Empty(t, f.scheduledRepo.messages)
+}
+
+func TestScheduleMessageUseCase_LimitsScheduledPerConversation(t *testing.T) {
+	f := newScheduleTestFixture()
+	f.schedule(t, time.Hour)
+	f.schedule(t, 2*time.Hour)
+
+	resp, err := f.useCase.Schedule(context.Background(), &ScheduleMessageRequest{
+		ConversationID: f.conversationID,
+		SenderID:       f.senderID,
+		Content:        "one more",
+		SendAt:         f.now.Add(3 * time.Hour),
+	})
+	require.NoError(t, err)
+	assert.False(t, resp.Success)
+	assert.Equal(t, "You can have up to 2 messages scheduled in a conversation", resp.Error)
+	assert.Len(t, f.scheduledRepo.messages, 2)
+}
@@ -9,6 +9,7 @@ import (
 	"github.com/22smeargle/winkr-backend/internal/application/services"
 	"github.com/22smeargle/winkr-backend/internal/domain/entities"
 	"github.com/22smeargle/winkr-backend/internal/domain/repositories"
+	"github.com/22smeargle/winkr-backend/pkg/config"
 	"github.com/22smeargle/winkr-backend/pkg/logger"
 )
 
@@ -18,6 +19,7 @@ type SendMessageRequest struct {
 	SenderID       uuid.UUID `json:"sender_id" validate:"required"`
 	Content        string     `json:"content" validate:"required,max=2000"`
 	MessageType    string     `json:"message_type" validate:"required,oneof=text image photo_ephemeral location system gift"`
+	ClientMessageID string    `json:"client_message_id,omitempty"` // Temporary ID assigned by the client; retries reuse it
 }
 
 // SendMessageResponse represents the response after sending a message
@@ -25,6 +27,25 @@ type SendMessageResponse struct {
 	Message *services.ProcessedMessage `json:"message"`
 	Success bool                     `json:"success"`
 	Error   string                    `json:"error,omitempty"`
+	UpgradeRequired bool              `json:"upgrade_required,omitempty"`
+	Duplicate       bool              `json:"duplicate,omitempty"` // The client message ID was already sent, Message is the original
+	ErrorCode       string            `json:"error_code,omitempty"` // Set when the message cannot be sent to the conversation
+	RetryAfter      time.Duration     `json:"-"`                    // Set when the sender is rate limited in the conversation
+	ModerationRule  string            `json:"moderation_rule,omitempty"` // The rule the content matched when it was blocked
+}
+
+// Error codes for messages that cannot be sent to the conversation
+const (
+	ErrorCodeConversationNotFound = "conversation_not_found"
+	ErrorCodeConversationClosed   = "conversation_closed"
+	ErrorCodeRateLimited          = "rate_limited"
+	ErrorCodeContentBlocked       = "content_blocked"
+	ErrorCodeUserBlocked          = "user_blocked"
+)
+
+// BlockChecker reports whether either of two users has blocked the other
+type BlockChecker interface {
+	IsBlocked(ctx context.Context, userID, otherUserID uuid.UUID) (bool, error)
 }
 
 // SendMessageUseCase handles sending a message
@@ -33,6 +54,15 @@ type SendMessageUseCase struct {
 	userRepo      repositories.UserRepository
 	matchRepo     repositories.MatchRepository
 	messageService *services.MessageService
+	receiptService *services.MessageReceiptService
+	engagementService *services.ConversationEngagementService
+	linkPreviews   *services.LinkPreviewService
+	moderation     *services.MessageModerationService
+	redactor       *services.MessagePIIRedactor
+	limiter        *ConversationLimiter
+	rateLimiter    *ConversationRateLimiter
+	blockChecker   BlockChecker
+	config         *config.MessageConfig
 }
 
 // NewSendMessageUseCase creates a new send message use case
@@ -41,12 +71,30 @@ func NewSendMessageUseCase(
 	userRepo repositories.UserRepository,
 	matchRepo repositories.MatchRepository,
 	messageService *services.MessageService,
+	receiptService *services.MessageReceiptService,
+	engagementService *services.ConversationEngagementService,
+	linkPreviews *services.LinkPreviewService,
+	moderation *services.MessageModerationService,
+	redactor *services.MessagePIIRedactor,
+	limiter *ConversationLimiter,
+	rateLimiter *ConversationRateLimiter,
+	blockChecker BlockChecker,
+	cfg *config.MessageConfig,
 ) *SendMessageUseCase {
 	return &SendMessageUseCase{
 		messageRepo:   messageRepo,
 		userRepo:      userRepo,
 		matchRepo:     matchRepo,
 		messageService: messageService,
+		receiptService: receiptService,
+		engagementService: engagementService,
+		linkPreviews:   linkPreviews,
+		moderation:     moderation,
+		redactor:       redactor,
+		limiter:        limiter,
+		rateLimiter:    rateLimiter,
+		blockChecker:   blockChecker,
+		config:         cfg,
 	}
 }
 
@@ -70,10 +118,75 @@ func (uc *SendMessageUseCase) Execute(ctx context.Context, req *SendMessageReque
 		}, nil
 	}
 
+	// Non-participants are told the conversation does not exist rather than that it is someone else's
 	if !canAccess {
+		return &SendMessageResponse{
+			Success:   false,
+			Error:     "Conversation not found",
+			ErrorCode: ErrorCodeConversationNotFound,
+		}, nil
+	}
+
+	// A retried send returns the message created by the first attempt instead of a duplicate
+	clientMessageID, err := uc.clientMessageID(req)
+	if err != nil {
+		return &SendMessageResponse{
+			Success: false,
+			Error:   err.Error(),
+		}, nil
+	}
+
+	if clientMessageID != nil {
+		if resp := uc.sentMessageResponse(ctx, req, *clientMessageID); resp != nil {
+			return resp, nil
+		}
+	}
+
+	if resp := uc.unavailableConversationResponse(ctx, req); resp != nil {
+		return resp, nil
+	}
+
+	// Flooding a single conversation is limited separately from the sender's overall message rate
+	if allowed, retryAfter := uc.rateLimiter.Allow(ctx, req.SenderID, req.ConversationID, req.MessageType); !allowed {
+		return &SendMessageResponse{
+			Success:    false,
+			Error:      "Too many messages in this conversation, please slow down",
+			ErrorCode:  ErrorCodeRateLimited,
+			RetryAfter: retryAfter,
+		}, nil
+	}
+
+	// Severe content is rejected before it can open a conversation, borderline content is flagged once sent
+	moderationResult := uc.moderation.Check(req.Content, req.MessageType)
+	if moderationResult != nil && moderationResult.Action == services.MessageModerationActionBlock {
+		logger.Info("Message blocked by content moderation",
+			"conversation_id", req.ConversationID,
+			"sender_id", req.SenderID,
+			"rule", moderationResult.Rule,
+		)
+		return &SendMessageResponse{
+			Success:        false,
+			Error:          moderationResult.Guidance,
+			ErrorCode:      ErrorCodeContentBlocked,
+			ModerationRule: moderationResult.Rule,
+		}, nil
+	}
+
+	// A free user's first message in a conversation opens it, which counts towards their limit
+	limitReached, err := uc.limiter.LimitReachedForMessage(ctx, req.SenderID, req.ConversationID)
+	if err != nil {
+		logger.Error("Failed to check open conversation limit", err)
 		return &SendMessageResponse{
 			Success: false,
-			Error:   "User cannot access this conversation",
+			Error:   "Failed to check open conversation limit",
+		}, nil
+	}
+
+	if limitReached {
+		return &SendMessageResponse{
+			Success:         false,
+			Error:           uc.limiter.UpsellMessage(),
+			UpgradeRequired: true,
 		}, nil
 	}
 
@@ -101,6 +214,7 @@ func (uc *SendMessageUseCase) Execute(ctx context.Context, req *SendMessageReque
 		SenderID:       req.SenderID,
 		Content:        validationResult.Sanitized,
 		MessageType:    req.MessageType,
+		ClientMessageID: clientMessageID,
 		IsRead:         false,
 		CreatedAt:      time.Now(),
 	}
@@ -108,7 +222,6 @@ func (uc *SendMessageUseCase) Execute(ctx context.Context, req *SendMessageReque
 	// Process message with options
 	options := &services.MessageProcessingOptions{
 		EnableContentFilter: true,
-		EnableLinkPreview:  true,
 		EnableEncryption:   false, // Could be enabled based on user settings
 		EnableTranslation:  false, // Could be enabled based on user settings
 	}
@@ -122,8 +235,19 @@ func (uc *SendMessageUseCase) Execute(ctx context.Context, req *SendMessageReque
 		}, nil
 	}
 
+	// PII is masked before the message is stored, so the database, cache and recipients all get
+	// the redacted content
+	uc.redactor.Redact(processedMessage.Message)
+
 	// Save message to database
 	if err := uc.messageRepo.Create(ctx, processedMessage.Message); err != nil {
+		// A concurrent retry may have saved the message first
+		if clientMessageID != nil {
+			if resp := uc.sentMessageResponse(ctx, req, *clientMessageID); resp != nil {
+				return resp, nil
+			}
+		}
+
 		logger.Error("Failed to save message", err)
 		return &SendMessageResponse{
 			Success: false,
@@ -131,12 +255,37 @@ func (uc *SendMessageUseCase) Execute(ctx context.Context, req *SendMessageReque
 		}, nil
 	}
 
+	// Record the sent status so clients can reconcile delivery state
+	receipt, err := uc.receiptService.RecordSent(ctx, processedMessage.Message)
+	if err != nil {
+		logger.Error("Failed to record message sent status", err)
+		// Don't fail the request, just log the error
+	} else {
+		processedMessage.Receipt = receipt
+	}
+
+	if moderationResult != nil {
+		if err := uc.moderation.Flag(ctx, processedMessage.Message, moderationResult); err != nil {
+			logger.Error("Failed to flag message for review", err)
+			// Don't fail the request, just log the error
+		}
+	}
+
 	// Update conversation activity
 	if err := uc.updateConversationActivity(ctx, processedMessage.ConversationID); err != nil {
 		logger.Error("Failed to update conversation activity", err)
 		// Don't fail the request, just log the error
 	}
 
+	// Count the message towards the conversation streak and celebrate any milestone
+	if _, err := uc.engagementService.RecordMessage(ctx, processedMessage.Message); err != nil {
+		logger.Error("Failed to record conversation streak", err)
+		// Don't fail the request, just log the error
+	}
+
+	// Previews of links seen before are attached now, the others are pushed once fetched
+	processedMessage.LinkPreviews = uc.linkPreviews.Generate(ctx, processedMessage.Message)
+
 	// Update match activity if this is the first message
 	if err := uc.updateMatchActivity(ctx, processedMessage.ConversationID, req.SenderID); err != nil {
 		logger.Error("Failed to update match activity", err)
@@ -156,6 +305,140 @@ func (uc *SendMessageUseCase) Execute(ctx context.Context, req *SendMessageReque
 	}, nil
 }
 
+// clientMessageID returns the client message ID to store with the message, or nil if the request has
+// none or client message IDs are disabled
+func (uc *SendMessageUseCase) clientMessageID(req *SendMessageRequest) (*string, error) {
+	if req.ClientMessageID == "" || uc.config == nil || uc.config.ClientMessageIDMaxLength <= 0 {
+		return nil, nil
+	}
+
+	if len(req.ClientMessageID) > uc.config.ClientMessageIDMaxLength {
+		return nil, fmt.Errorf("client_message_id too long (max %d characters)", uc.config.ClientMessageIDMaxLength)
+	}
+
+	clientMessageID := req.ClientMessageID
+	return &clientMessageID, nil
+}
+
+// sentMessageResponse returns the response for a message the sender already sent with the client
+// message ID, or nil if there is none
+func (uc *SendMessageUseCase) sentMessageResponse(ctx context.Context, req *SendMessageRequest, clientMessageID string) *SendMessageResponse {
+	existing, err := uc.messageRepo.GetBySenderAndClientMessageID(ctx, req.SenderID, clientMessageID)
+	if err != nil {
+		logger.Error("Failed to get message by client message ID", err)
+		return &SendMessageResponse{
+			Success: false,
+			Error:   "Failed to check for a previously sent message",
+		}
+	}
+
+	if existing == nil {
+		return nil
+	}
+
+	if existing.ConversationID != req.ConversationID {
+		return &SendMessageResponse{
+			Success: false,
+			Error:   "client_message_id was already used in another conversation",
+		}
+	}
+
+	logger.Info("Duplicate message send ignored",
+		"message_id", existing.ID,
+		"client_message_id", clientMessageID,
+		"sender_id", req.SenderID,
+	)
+
+	return &SendMessageResponse{
+		Message:   &services.ProcessedMessage{Message: existing},
+		Success:   true,
+		Duplicate: true,
+	}
+}
+
+// unavailableConversationResponse returns the response for a conversation the sender can no longer
+// message, or nil if the message can be sent. A conversation can't receive messages once it is closed,
+// its match has ended (e.g. after an unmatch or a ban) or either participant has blocked the other.
+// A block is reported as such even though blocking also ends the match.
+func (uc *SendMessageUseCase) unavailableConversationResponse(ctx context.Context, req *SendMessageRequest) *SendMessageResponse {
+	conversation, err := uc.messageRepo.GetConversation(ctx, req.ConversationID)
+	if err != nil {
+		logger.Error("Failed to get conversation", err)
+		return &SendMessageResponse{
+			Success: false,
+			Error:   "Failed to get conversation",
+		}
+	}
+
+	if conversation == nil {
+		return &SendMessageResponse{
+			Success:   false,
+			Error:     "Conversation not found",
+			ErrorCode: ErrorCodeConversationNotFound,
+		}
+	}
+
+	closed := &SendMessageResponse{
+		Success:   false,
+		Error:     "Conversation is closed",
+		ErrorCode: ErrorCodeConversationClosed,
+	}
+
+	if conversation.IsClosed() {
+		return closed
+	}
+
+	match, err := uc.matchRepo.GetByID(ctx, conversation.MatchID)
+	if err != nil {
+		logger.Error("Failed to get match", err)
+		return &SendMessageResponse{
+			Success: false,
+			Error:   "Failed to get match",
+		}
+	}
+
+	recipientID, ok := match.GetOtherUserID(req.SenderID)
+	if !ok {
+		return &SendMessageResponse{
+			Success:   false,
+			Error:     "Conversation not found",
+			ErrorCode: ErrorCodeConversationNotFound,
+		}
+	}
+
+	blocked, err := uc.isBlockedEitherWay(ctx, req.SenderID, recipientID)
+	if err != nil {
+		logger.Error("Failed to check blocked users", err)
+		return &SendMessageResponse{
+			Success: false,
+			Error:   "Failed to check blocked users",
+		}
+	}
+
+	if blocked {
+		return &SendMessageResponse{
+			Success:   false,
+			Error:     "You cannot message this user",
+			ErrorCode: ErrorCodeUserBlocked,
+		}
+	}
+
+	if !match.IsActive {
+		return closed
+	}
+
+	return nil
+}
+
+// isBlockedEitherWay reports whether either user has blocked the other
+func (uc *SendMessageUseCase) isBlockedEitherWay(ctx context.Context, userID, otherUserID uuid.UUID) (bool, error) {
+	if uc.blockChecker == nil {
+		return false, nil
+	}
+
+	return uc.blockChecker.IsBlocked(ctx, userID, otherUserID)
+}
+
 // updateConversationActivity updates the conversation's last activity
 func (uc *SendMessageUseCase) updateConversationActivity(ctx context.Context, conversationID uuid.UUID) error {
 	// Get conversation
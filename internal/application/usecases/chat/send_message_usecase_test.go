@@ -0,0 +1,322 @@
+package chat
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"github.com/22smeargle/winkr-backend/internal/application/services"
+	"github.com/22smeargle/winkr-backend/internal/domain/entities"
+	"github.com/22smeargle/winkr-backend/internal/domain/repositories"
+	"github.com/22smeargle/winkr-backend/pkg/config"
+)
+
+// MockMessageRepository is a mock implementation of the message repository methods used by chat use cases
+type MockMessageRepository struct {
+	repositories.MessageRepository
+	mock.Mock
+}
+
+func (m *MockMessageRepository) UserCanAccessConversation(ctx context.Context, userID, conversationID uuid.UUID) (bool, error) {
+	args := m.Called(ctx, userID, conversationID)
+	return args.Bool(0), args.Error(1)
+}
+
+func (m *MockMessageRepository) GetConversation(ctx context.Context, conversationID uuid.UUID) (*entities.Conversation, error) {
+	args := m.Called(ctx, conversationID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entities.Conversation), args.Error(1)
+}
+
+func (m *MockMessageRepository) Create(ctx context.Context, message *entities.Message) error {
+	args := m.Called(ctx, message)
+	return args.Error(0)
+}
+
+func (m *MockMessageRepository) GetByID(ctx context.Context, id uuid.UUID) (*entities.Message, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entities.Message), args.Error(1)
+}
+
+func (m *MockMessageRepository) GetBySenderAndClientMessageID(ctx context.Context, senderID uuid.UUID, clientMessageID string) (*entities.Message, error) {
+	args := m.Called(ctx, senderID, clientMessageID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entities.Message), args.Error(1)
+}
+
+func (m *MockMessageRepository) PinMessage(ctx context.Context, messageID, userID uuid.UUID) error {
+	args := m.Called(ctx, messageID, userID)
+	return args.Error(0)
+}
+
+func (m *MockMessageRepository) UnpinMessage(ctx context.Context, messageID uuid.UUID) error {
+	args := m.Called(ctx, messageID)
+	return args.Error(0)
+}
+
+func (m *MockMessageRepository) GetPinnedMessages(ctx context.Context, conversationID uuid.UUID) ([]*entities.Message, error) {
+	args := m.Called(ctx, conversationID)
+	return args.Get(0).([]*entities.Message), args.Error(1)
+}
+
+func (m *MockMessageRepository) CountPinnedMessages(ctx context.Context, conversationID uuid.UUID) (int64, error) {
+	args := m.Called(ctx, conversationID)
+	return args.Get(0).(int64), args.Error(1)
+}
+
+func TestSendMessageUseCase_RejectsClosedConversation(t *testing.T) {
+	senderID := uuid.New()
+	conversation := &entities.Conversation{ID: uuid.New(), MatchID: uuid.New()}
+	conversation.Close()
+
+	messageRepo := new(MockMessageRepository)
+	messageRepo.On("UserCanAccessConversation", mock.Anything, senderID, conversation.ID).Return(true, nil)
+	messageRepo.On("GetConversation", mock.Anything, conversation.ID).Return(conversation, nil)
+
+	useCase := NewSendMessageUseCase(messageRepo, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil)
+
+	resp, err := useCase.Execute(context.Background(), &SendMessageRequest{
+		ConversationID: conversation.ID,
+		SenderID:       senderID,
+		Content:        "are you still there?",
+		MessageType:    "text",
+	})
+
+	require.NoError(t, err)
+	assert.False(t, resp.Success)
+	assert.Equal(t, "Conversation is closed", resp.Error)
+	assert.Equal(t, ErrorCodeConversationClosed, resp.ErrorCode)
+	messageRepo.AssertNotCalled(t, "Create", mock.Anything, mock.Anything)
+}
+
+// blockList is a BlockChecker backed by a set of "blockerID:blockedID" pairs
+type blockList map[string]bool
+
+func (b blockList) IsBlocked(ctx context.Context, userID, otherUserID uuid.UUID) (bool, error) {
+	return b[userID.String()+":"+otherUserID.String()] || b[otherUserID.String()+":"+userID.String()], nil
+}
+
+func TestSendMessageUseCase_RejectsConversationAfterUnmatch(t *testing.T) {
+	senderID := uuid.New()
+	match := &entities.Match{ID: uuid.New(), User1ID: senderID, User2ID: uuid.New()}
+	conversation := &entities.Conversation{ID: uuid.New(), MatchID: match.ID}
+
+	messageRepo := new(MockMessageRepository)
+	messageRepo.On("UserCanAccessConversation", mock.Anything, senderID, conversation.ID).Return(true, nil)
+	messageRepo.On("GetConversation", mock.Anything, conversation.ID).Return(conversation, nil)
+	matchRepo := new(MockMatchRepository)
+	matchRepo.On("GetByID", mock.Anything, match.ID).Return(match, nil)
+
+	useCase := NewSendMessageUseCase(messageRepo, nil, matchRepo, nil, nil, nil, nil, nil, nil, nil, nil, blockList{}, nil)
+
+	resp, err := useCase.Execute(context.Background(), &SendMessageRequest{
+		ConversationID: conversation.ID,
+		SenderID:       senderID,
+		Content:        "wait, why did you unmatch?",
+		MessageType:    "text",
+	})
+
+	require.NoError(t, err)
+	assert.False(t, resp.Success)
+	assert.Equal(t, ErrorCodeConversationClosed, resp.ErrorCode)
+	messageRepo.AssertNotCalled(t, "Create", mock.Anything, mock.Anything)
+}
+
+func TestSendMessageUseCase_RejectsConversationAfterBlock(t *testing.T) {
+	senderID := uuid.New()
+	recipientID := uuid.New()
+	conversation := &entities.Conversation{ID: uuid.New(), MatchID: uuid.New()}
+
+	tests := []struct {
+		name        string
+		blocked     blockList
+		matchActive bool
+	}{
+		{"recipient blocked sender", blockList{recipientID.String() + ":" + senderID.String(): true}, true},
+		{"sender blocked recipient", blockList{senderID.String() + ":" + recipientID.String(): true}, true},
+		{"block ended the match", blockList{recipientID.String() + ":" + senderID.String(): true}, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			messageRepo := new(MockMessageRepository)
+			messageRepo.On("UserCanAccessConversation", mock.Anything, senderID, conversation.ID).Return(true, nil)
+			messageRepo.On("GetConversation", mock.Anything, conversation.ID).Return(conversation, nil)
+			match := &entities.Match{ID: conversation.MatchID, User1ID: recipientID, User2ID: senderID, IsActive: tt.matchActive}
+			matchRepo := new(MockMatchRepository)
+			matchRepo.On("GetByID", mock.Anything, match.ID).Return(match, nil)
+
+			useCase := NewSendMessageUseCase(messageRepo, nil, matchRepo, nil, nil, nil, nil, nil, nil, nil, nil, tt.blocked, nil)
+
+			resp, err := useCase.Execute(context.Background(), &SendMessageRequest{
+				ConversationID: conversation.ID,
+				SenderID:       senderID,
+				Content:        "hello?",
+				MessageType:    "text",
+			})
+
+			require.NoError(t, err)
+			assert.False(t, resp.Success)
+			assert.Equal(t, ErrorCodeUserBlocked, resp.ErrorCode)
+			messageRepo.AssertNotCalled(t, "Create", mock.Anything, mock.Anything)
+		})
+	}
+}
+
+func TestSendMessageUseCase_BlocksBannedContent(t *testing.T) {
+	senderID := uuid.New()
+	match := &entities.Match{ID: uuid.New(), User1ID: senderID, User2ID: uuid.New(), IsActive: true}
+	conversation := &entities.Conversation{ID: uuid.New(), MatchID: match.ID}
+
+	messageRepo := new(MockMessageRepository)
+	messageRepo.On("UserCanAccessConversation", mock.Anything, senderID, conversation.ID).Return(true, nil)
+	messageRepo.On("GetConversation", mock.Anything, conversation.ID).Return(conversation, nil)
+	matchRepo := new(MockMatchRepository)
+	matchRepo.On("GetByID", mock.Anything, match.ID).Return(match, nil)
+
+	moderation := services.NewMessageModerationService(nil, &config.ChatSecurityConfig{
+		ContentFilteringEnabled: true,
+		BannedWords:             []string{"scam"},
+	})
+	useCase := NewSendMessageUseCase(messageRepo, nil, matchRepo, nil, nil, nil, nil, moderation, nil, nil, nil, nil, nil)
+
+	resp, err := useCase.Execute(context.Background(), &SendMessageRequest{
+		ConversationID: conversation.ID,
+		SenderID:       senderID,
+		Content:        "This is not a SCAM, I promise",
+		MessageType:    "text",
+	})
+
+	require.NoError(t, err)
+	assert.False(t, resp.Success)
+	assert.Equal(t, ErrorCodeContentBlocked, resp.ErrorCode)
+	assert.Equal(t, services.MessageModerationRuleBannedWord, resp.ModerationRule)
+	assert.NotEmpty(t, resp.Error)
+	messageRepo.AssertNotCalled(t, "Create", mock.Anything, mock.Anything)
+}
+
+func TestSendMessageUseCase_RejectsNonexistentConversation(t *testing.T) {
+	senderID := uuid.New()
+	conversationID := uuid.New()
+
+	messageRepo := new(MockMessageRepository)
+	messageRepo.On("UserCanAccessConversation", mock.Anything, senderID, conversationID).Return(false, nil)
+
+	useCase := NewSendMessageUseCase(messageRepo, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil)
+
+	resp, err := useCase.Execute(context.Background(), &SendMessageRequest{
+		ConversationID: conversationID,
+		SenderID:       senderID,
+		Content:        "hi",
+		MessageType:    "text",
+	})
+
+	require.NoError(t, err)
+	assert.False(t, resp.Success)
+	assert.Equal(t, ErrorCodeConversationNotFound, resp.ErrorCode)
+	messageRepo.AssertNotCalled(t, "GetConversation", mock.Anything, mock.Anything)
+	messageRepo.AssertNotCalled(t, "Create", mock.Anything, mock.Anything)
+}
+
+func TestSendMessageUseCase_ResendingClientMessageIDReturnsExistingMessage(t *testing.T) {
+	senderID := uuid.New()
+	conversationID := uuid.New()
+	clientMessageID := "local-42"
+	existing := &entities.Message{
+		ID:              uuid.New(),
+		ConversationID:  conversationID,
+		SenderID:        senderID,
+		Content:         "see you at 8?",
+		MessageType:     "text",
+		ClientMessageID: &clientMessageID,
+	}
+
+	messageRepo := new(MockMessageRepository)
+	messageRepo.On("UserCanAccessConversation", mock.Anything, senderID, conversationID).Return(true, nil)
+	messageRepo.On("GetBySenderAndClientMessageID", mock.Anything, senderID, clientMessageID).Return(existing, nil)
+
+	useCase := NewSendMessageUseCase(messageRepo, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, &config.MessageConfig{ClientMessageIDMaxLength: 64})
+
+	for i := 0; i < 2; i++ {
+		resp, err := useCase.Execute(context.Background(), &SendMessageRequest{
+			ConversationID:  conversationID,
+			SenderID:        senderID,
+			Content:         "see you at 8?",
+			MessageType:     "text",
+			ClientMessageID: clientMessageID,
+		})
+
+		require.NoError(t, err)
+		assert.True(t, resp.Success)
+		assert.True(t, resp.Duplicate)
+		assert.Equal(t, existing.ID, resp.Message.ID)
+		assert.Equal(t, clientMessageID, *resp.Message.ClientMessageID)
+	}
+
+	messageRepo.AssertNotCalled(t, "Create", mock.Anything, mock.Anything)
+	messageRepo.AssertNotCalled(t, "GetConversation", mock.Anything, mock.Anything)
+}
+
+func TestSendMessageUseCase_RejectsClientMessageIDFromAnotherConversation(t *testing.T) {
+	senderID := uuid.New()
+	conversationID := uuid.New()
+	clientMessageID := "local-42"
+	existing := &entities.Message{
+		ID:              uuid.New(),
+		ConversationID:  uuid.New(),
+		SenderID:        senderID,
+		ClientMessageID: &clientMessageID,
+	}
+
+	messageRepo := new(MockMessageRepository)
+	messageRepo.On("UserCanAccessConversation", mock.Anything, senderID, conversationID).Return(true, nil)
+	messageRepo.On("GetBySenderAndClientMessageID", mock.Anything, senderID, clientMessageID).Return(existing, nil)
+
+	useCase := NewSendMessageUseCase(messageRepo, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, &config.MessageConfig{ClientMessageIDMaxLength: 64})
+
+	resp, err := useCase.Execute(context.Background(), &SendMessageRequest{
+		ConversationID:  conversationID,
+		SenderID:        senderID,
+		Content:         "hello",
+		MessageType:     "text",
+		ClientMessageID: clientMessageID,
+	})
+
+	require.NoError(t, err)
+	assert.False(t, resp.Success)
+	assert.Nil(t, resp.Message)
+	messageRepo.AssertNotCalled(t, "Create", mock.Anything, mock.Anything)
+}
+
+func TestSendMessageUseCase_RejectsClientMessageIDOverMaxLength(t *testing.T) {
+	senderID := uuid.New()
+	conversationID := uuid.New()
+
+	messageRepo := new(MockMessageRepository)
+	messageRepo.On("UserCanAccessConversation", mock.Anything, senderID, conversationID).Return(true, nil)
+
+	useCase := NewSendMessageUseCase(messageRepo, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, &config.MessageConfig{ClientMessageIDMaxLength: 8})
+
+	resp, err := useCase.Execute(context.Background(), &SendMessageRequest{
+		ConversationID:  conversationID,
+		SenderID:        senderID,
+		Content:         "hello",
+		MessageType:     "text",
+		ClientMessageID: "local-123456789",
+	})
+
+	require.NoError(t, err)
+	assert.False(t, resp.Success)
+	assert.Equal(t, "client_message_id too long (max 8 characters)", resp.Error)
+	messageRepo.AssertNotCalled(t, "GetBySenderAndClientMessageID", mock.Anything, mock.Anything, mock.Anything)
+}
@@ -24,22 +24,26 @@ type StartConversationResponse struct {
 	Exists       bool                `json:"exists"`
 	Message      *entities.Message    `json:"message,omitempty"`
 	Error        string              `json:"error,omitempty"`
+	UpgradeRequired bool             `json:"upgrade_required,omitempty"`
 }
 
 // StartConversationUseCase handles starting a new conversation
 type StartConversationUseCase struct {
 	messageRepo repositories.MessageRepository
 	matchRepo    repositories.MatchRepository
+	limiter      *ConversationLimiter
 }
 
 // NewStartConversationUseCase creates a new start conversation use case
 func NewStartConversationUseCase(
 	messageRepo repositories.MessageRepository,
 	matchRepo repositories.MatchRepository,
+	limiter *ConversationLimiter,
 ) *StartConversationUseCase {
 	return &StartConversationUseCase{
 		messageRepo: messageRepo,
 		matchRepo:    matchRepo,
+		limiter:      limiter,
 	}
 }
 
@@ -79,6 +83,17 @@ func (uc *StartConversationUseCase) Execute(ctx context.Context, req *StartConve
 
 		// If first message is provided, send it
 		if req.FirstMessage != "" {
+			limitReached, err := uc.limiter.LimitReachedForMessage(ctx, req.UserID, conversation.ID)
+			if err != nil {
+				logger.Error("Failed to check open conversation limit", err)
+				return &StartConversationResponse{
+					Error: "Failed to send first message",
+				}, nil
+			}
+			if limitReached {
+				return uc.limitReachedResponse(), nil
+			}
+
 			message, err := uc.sendFirstMessage(ctx, req, conversation.ID)
 			if err != nil {
 				logger.Error("Failed to send first message", err)
@@ -91,6 +106,18 @@ func (uc *StartConversationUseCase) Execute(ctx context.Context, req *StartConve
 		return response, nil
 	}
 
+	// Free users can only have a limited number of open conversations
+	limitReached, err := uc.limiter.LimitReached(ctx, req.UserID, uuid.Nil)
+	if err != nil {
+		logger.Error("Failed to check open conversation limit", err)
+		return &StartConversationResponse{
+			Error: "Failed to create conversation",
+		}, nil
+	}
+	if limitReached {
+		return uc.limitReachedResponse(), nil
+	}
+
 	// Create new conversation
 	conversation = &entities.Conversation{
 		ID:        uuid.New(),
@@ -132,6 +159,14 @@ func (uc *StartConversationUseCase) Execute(ctx context.Context, req *StartConve
 	return response, nil
 }
 
+// limitReachedResponse returns the upsell response for a free user at the open conversation limit
+func (uc *StartConversationUseCase) limitReachedResponse() *StartConversationResponse {
+	return &StartConversationResponse{
+		Error:           uc.limiter.UpsellMessage(),
+		UpgradeRequired: true,
+	}
+}
+
 // sendFirstMessage sends the first message in a conversation
 func (uc *StartConversationUseCase) sendFirstMessage(ctx context.Context, req *StartConversationRequest, conversationID uuid.UUID) (*entities.Message, error) {
 	// Create first message
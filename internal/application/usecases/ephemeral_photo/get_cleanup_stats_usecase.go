@@ -0,0 +1,59 @@
+package ephemeral_photo
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/22smeargle/winkr-backend/internal/application/services"
+	"github.com/22smeargle/winkr-backend/pkg/validator"
+)
+
+// GetCleanupStatsRequest represents the request for getting cleanup job stats
+type GetCleanupStatsRequest struct {
+	Limit int `json:"limit" validate:"min=1,max=100"`
+}
+
+// GetCleanupStatsResponse represents the response for getting cleanup job stats
+type GetCleanupStatsResponse struct {
+	Runs            []*services.EphemeralCleanupRun `json:"runs"` // Newest first
+	DeadLetterCount int64                           `json:"dead_letter_count"`
+}
+
+// GetCleanupStatsUseCase handles getting the stats of recent ephemeral photo cleanup runs
+type GetCleanupStatsUseCase struct {
+	cleanupJob *services.EphemeralPhotoCleanupJob
+	validator  validator.Validator
+}
+
+// NewGetCleanupStatsUseCase creates a new get cleanup stats use case
+func NewGetCleanupStatsUseCase(
+	cleanupJob *services.EphemeralPhotoCleanupJob,
+	validator validator.Validator,
+) *GetCleanupStatsUseCase {
+	return &GetCleanupStatsUseCase{
+		cleanupJob: cleanupJob,
+		validator:  validator,
+	}
+}
+
+// Execute executes the get cleanup stats use case
+func (uc *GetCleanupStatsUseCase) Execute(ctx context.Context, req *GetCleanupStatsRequest) (*GetCleanupStatsResponse, error) {
+	if err := uc.validator.ValidateStruct(req); err != nil {
+		return nil, fmt.Errorf("validation failed: %w", err)
+	}
+
+	runs, err := uc.cleanupJob.RecentRuns(ctx, req.Limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get cleanup runs: %w", err)
+	}
+
+	deadLetterCount, err := uc.cleanupJob.DeadLetterCount(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get dead letter count: %w", err)
+	}
+
+	return &GetCleanupStatsResponse{
+		Runs:            runs,
+		DeadLetterCount: deadLetterCount,
+	}, nil
+}
@@ -37,16 +37,19 @@ type UploadEphemeralPhotoResponse struct {
 // UploadEphemeralPhotoUseCase handles uploading ephemeral photos
 type UploadEphemeralPhotoUseCase struct {
 	ephemeralPhotoService services.EphemeralPhotoService
+	photoLimits         *services.PhotoLimitService
 	validator           validator.Validator
 }
 
 // NewUploadEphemeralPhotoUseCase creates a new upload ephemeral photo use case
 func NewUploadEphemeralPhotoUseCase(
 	ephemeralPhotoService services.EphemeralPhotoService,
+	photoLimits *services.PhotoLimitService,
 	validator validator.Validator,
 ) *UploadEphemeralPhotoUseCase {
 	return &UploadEphemeralPhotoUseCase{
 		ephemeralPhotoService: ephemeralPhotoService,
+		photoLimits:         photoLimits,
 		validator:           validator,
 	}
 }
@@ -58,6 +61,11 @@ func (uc *UploadEphemeralPhotoUseCase) Execute(ctx context.Context, req *UploadE
 		return nil, fmt.Errorf("validation failed: %w", err)
 	}
 
+	// Check user photo limit
+	if err := uc.photoLimits.CheckEphemeralPhotoLimit(ctx, req.UserID); err != nil {
+		return nil, err
+	}
+
 	// Convert expires after seconds to duration
 	expiresAfter := time.Duration(req.ExpiresAfter) * time.Second
 	if expiresAfter == 0 {
@@ -42,16 +42,22 @@ type ViewEphemeralPhotoResponse struct {
 // ViewEphemeralPhotoUseCase handles viewing ephemeral photos
 type ViewEphemeralPhotoUseCase struct {
 	ephemeralPhotoService services.EphemeralPhotoService
+	storageService      services.EphemeralPhotoStorageService
+	watermarkService    *services.EphemeralPhotoWatermarkService
 	validator           validator.Validator
 }
 
 // NewViewEphemeralPhotoUseCase creates a new view ephemeral photo use case
 func NewViewEphemeralPhotoUseCase(
 	ephemeralPhotoService services.EphemeralPhotoService,
+	storageService services.EphemeralPhotoStorageService,
+	watermarkService *services.EphemeralPhotoWatermarkService,
 	validator validator.Validator,
 ) *ViewEphemeralPhotoUseCase {
 	return &ViewEphemeralPhotoUseCase{
 		ephemeralPhotoService: ephemeralPhotoService,
+		storageService:      storageService,
+		watermarkService:    watermarkService,
 		validator:           validator,
 	}
 }
@@ -93,11 +99,18 @@ func (uc *ViewEphemeralPhotoUseCase) Execute(ctx context.Context, req *ViewEphem
 		remainingTime = 0
 	}
 
+	// Sign a URL for the viewer's watermarked variant, or the original when it can't be watermarked
+	fileKey, watermarked := uc.watermarkService.ViewFileKey(ctx, photo, req.ViewerID)
+	fileURL, err := uc.storageService.GetEphemeralPhotoURL(ctx, fileKey, photo.GetRemainingTime())
+	if err != nil {
+		return nil, fmt.Errorf("failed to get ephemeral photo URL: %w", err)
+	}
+
 	// Create response
 	response := &ViewEphemeralPhotoResponse{
 		ID:             photo.ID,
 		UserID:         photo.UserID,
-		FileURL:        photo.FileURL,
+		FileURL:        fileURL,
 		ThumbnailURL:   photo.ThumbnailURL,
 		ViewCount:       photo.ViewCount,
 		MaxViews:        photo.MaxViews,
@@ -110,9 +123,8 @@ func (uc *ViewEphemeralPhotoUseCase) Execute(ctx context.Context, req *ViewEphem
 		ViewStartTime:    photo.CreatedAt.Unix(),
 	}
 
-	// Add watermark URL if photo is viewed (for security)
-	if photo.IsViewed {
-		response.WatermarkURL = photo.FileURL + "?watermark=true"
+	if watermarked {
+		response.WatermarkURL = fileURL
 	}
 
 	return response, nil
@@ -0,0 +1,369 @@
+package matching
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/22smeargle/winkr-backend/internal/application/dto"
+	"github.com/22smeargle/winkr-backend/internal/application/services"
+	"github.com/22smeargle/winkr-backend/internal/domain/entities"
+	"github.com/22smeargle/winkr-backend/internal/domain/repositories"
+	"github.com/22smeargle/winkr-backend/pkg/config"
+	"github.com/22smeargle/winkr-backend/pkg/errors"
+	"github.com/22smeargle/winkr-backend/pkg/logger"
+)
+
+// Outcomes of a swipe in a batch
+const (
+	BatchSwipeStatusCreated   = "created"
+	BatchSwipeStatusDuplicate = "duplicate" // The user already swiped on the target, so the swipe was dropped
+	BatchSwipeStatusError     = "error"
+)
+
+// BatchSwipeUseCase handles swipes that a client queued while offline and submits at once
+type BatchSwipeUseCase struct {
+	userRepo         repositories.UserRepository
+	matchRepo        repositories.MatchRepository
+	subscriptionRepo repositories.SubscriptionRepository
+	swipeService     SwipeService
+	matchService     MatchService
+	cacheService     CacheService
+	rateLimiter      services.RateLimiter
+	quotaStore       services.SuperLikeQuotaStore
+	rateLimitConfig  *config.RateLimitConfig
+	config           *config.MatchingBatchSwipeConfig
+	publisher        MatchEventPublisher
+	now              func() time.Time
+}
+
+// NewBatchSwipeUseCase creates a new BatchSwipeUseCase
+func NewBatchSwipeUseCase(
+	userRepo repositories.UserRepository,
+	matchRepo repositories.MatchRepository,
+	subscriptionRepo repositories.SubscriptionRepository,
+	swipeService SwipeService,
+	matchService MatchService,
+	cacheService CacheService,
+	rateLimiter services.RateLimiter,
+	quotaStore services.SuperLikeQuotaStore,
+	rateLimitConfig *config.RateLimitConfig,
+	cfg *config.MatchingBatchSwipeConfig,
+	publisher MatchEventPublisher,
+) *BatchSwipeUseCase {
+	return &BatchSwipeUseCase{
+		userRepo:         userRepo,
+		matchRepo:        matchRepo,
+		subscriptionRepo: subscriptionRepo,
+		swipeService:     swipeService,
+		matchService:     matchService,
+		cacheService:     cacheService,
+		rateLimiter:      rateLimiter,
+		quotaStore:       quotaStore,
+		rateLimitConfig:  rateLimitConfig,
+		config:           cfg,
+		publisher:        publisher,
+		now:              time.Now,
+	}
+}
+
+// BatchSwipeItem is one swipe of a batch
+type BatchSwipeItem struct {
+	TargetUserID    uuid.UUID `json:"target_user_id" validate:"required"`
+	IsLike          bool      `json:"is_like"`
+	SuperLike       bool      `json:"super_like"`       // Counts against the daily super likes, implies a like
+	ClientTimestamp time.Time `json:"client_timestamp"` // When the user swiped on the device
+}
+
+// BatchSwipeRequest represents a request to submit several swipes at once
+type BatchSwipeRequest struct {
+	SwiperID uuid.UUID        `json:"swiper_id" validate:"required"`
+	Swipes   []BatchSwipeItem `json:"swipes" validate:"required"`
+}
+
+// BatchSwipeItemResult is the outcome of one swipe of a batch, in the order the swipes were sent
+type BatchSwipeItemResult struct {
+	TargetUserID uuid.UUID  `json:"target_user_id"`
+	Status       string     `json:"status"`
+	Error        string     `json:"error,omitempty"`
+	MatchID      *uuid.UUID `json:"match_id,omitempty"` // Set when the swipe made a match
+}
+
+// BatchSwipeResponse represents the response from submitting a batch of swipes
+type BatchSwipeResponse struct {
+	Results []*BatchSwipeItemResult `json:"results"`
+	Matches []*dto.Match            `json:"matches"`
+	Quota   *services.SwipeQuota    `json:"quota,omitempty"` // Left out if the limits could not be read
+}
+
+// Execute stores the new swipes of a batch in one transaction and makes the matches they lead to.
+// Each swipe is checked like a single swipe would be; a swipe that fails its checks is reported in
+// its result and does not stop the others.
+func (uc *BatchSwipeUseCase) Execute(ctx context.Context, req *BatchSwipeRequest) (*BatchSwipeResponse, error) {
+	// Validate request
+	if err := req.Validate(); err != nil {
+		return nil, errors.NewValidationError("swipes", err.Error())
+	}
+
+	if len(req.Swipes) > uc.config.MaxSize {
+		return nil, errors.NewAppError(http.StatusRequestEntityTooLarge,
+			fmt.Sprintf("A batch can have at most %d swipes", uc.config.MaxSize), "")
+	}
+
+	swiper, err := uc.userRepo.GetByID(ctx, req.SwiperID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get swiper: %w", err)
+	}
+
+	targets, err := uc.getTargets(ctx, req.Swipes)
+	if err != nil {
+		return nil, err
+	}
+
+	response := &BatchSwipeResponse{
+		Results: make([]*BatchSwipeItemResult, len(req.Swipes)),
+		Matches: []*dto.Match{},
+	}
+
+	var swipes []*entities.Swipe
+	var created []*BatchSwipeItemResult
+	var superLikesUsed int
+	var hasPremium *bool
+	seen := make(map[uuid.UUID]bool, len(req.Swipes))
+
+	for i, item := range req.Swipes {
+		result := &BatchSwipeItemResult{TargetUserID: item.TargetUserID}
+		response.Results[i] = result
+
+		if item.TargetUserID == uuid.Nil || item.TargetUserID == req.SwiperID {
+			result.fail("invalid target_user_id")
+			continue
+		}
+		if targets[item.TargetUserID] == nil {
+			result.fail("user not found")
+			continue
+		}
+
+		// The same target twice in a batch keeps the first swipe, as the second would when sent alone
+		if seen[item.TargetUserID] {
+			result.Status = BatchSwipeStatusDuplicate
+			continue
+		}
+		seen[item.TargetUserID] = true
+
+		exists, err := uc.matchRepo.ExistsSwipe(ctx, req.SwiperID, item.TargetUserID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to check swipe status: %w", err)
+		}
+		if exists {
+			result.Status = BatchSwipeStatusDuplicate
+			continue
+		}
+
+		if item.SuperLike {
+			if hasPremium == nil {
+				premium, err := uc.checkPremiumAccess(ctx, req.SwiperID)
+				if err != nil {
+					return nil, fmt.Errorf("failed to check premium access: %w", err)
+				}
+				hasPremium = &premium
+			}
+			if !*hasPremium {
+				result.fail("Super likes require a premium subscription")
+				continue
+			}
+
+			allowed, err := uc.useSuperLike(ctx, swiper)
+			if err != nil {
+				uc.refundSuperLikes(ctx, req.SwiperID, superLikesUsed)
+				return nil, err
+			}
+			if !allowed {
+				result.fail("Daily super like limit exceeded")
+				continue
+			}
+			superLikesUsed++
+		} else {
+			// Super likes are limited by their own quota, everything else by the swipe limits
+			allowed, err := uc.rateLimiter.AllowSwipe(ctx, req.SwiperID)
+			if err != nil {
+				uc.refundSuperLikes(ctx, req.SwiperID, superLikesUsed)
+				return nil, fmt.Errorf("failed to check rate limit: %w", err)
+			}
+			if !allowed {
+				result.fail("swipe rate limit exceeded")
+				continue
+			}
+		}
+
+		swipes = append(swipes, &entities.Swipe{
+			SwiperID:  req.SwiperID,
+			SwipedID:  item.TargetUserID,
+			IsLike:    item.IsLike || item.SuperLike,
+			CreatedAt: uc.swipedAt(item.ClientTimestamp),
+		})
+		result.Status = BatchSwipeStatusCreated
+		created = append(created, result)
+	}
+
+	if len(swipes) > 0 {
+		if err := uc.matchRepo.BatchCreateSwipes(ctx, swipes); err != nil {
+			uc.refundSuperLikes(ctx, req.SwiperID, superLikesUsed)
+			return nil, fmt.Errorf("failed to create swipes: %w", err)
+		}
+		uc.swipeService.RecordStoredSwipes(ctx, swipes)
+	}
+
+	// The swipes are stored by now, so a failed match check is logged instead of failing the batch
+	for i, swipe := range swipes {
+		if !swipe.IsLike {
+			continue
+		}
+
+		match, err := uc.matchLike(ctx, swiper, targets[swipe.SwipedID])
+		if err != nil {
+			logger.Error("Failed to check batch swipe for match", err, "swiper_id", swipe.SwiperID, "swiped_id", swipe.SwipedID)
+			continue
+		}
+		if match != nil {
+			matchID := match.ID
+			created[i].MatchID = &matchID
+			response.Matches = append(response.Matches, match)
+		}
+	}
+
+	logger.Info("Batch swipes processed",
+		"swiper_id", req.SwiperID,
+		"submitted", len(req.Swipes),
+		"created", len(swipes),
+		"matches", len(response.Matches),
+	)
+
+	response.Quota = getSwipeQuota(ctx, uc.swipeService, req.SwiperID)
+
+	return response, nil
+}
+
+// getTargets gets the users swiped on in a batch by ID. Unknown users are left out.
+func (uc *BatchSwipeUseCase) getTargets(ctx context.Context, items []BatchSwipeItem) (map[uuid.UUID]*entities.User, error) {
+	ids := make([]uuid.UUID, 0, len(items))
+	for _, item := range items {
+		ids = append(ids, item.TargetUserID)
+	}
+
+	users, err := uc.userRepo.GetUsersByIDs(ctx, ids)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get swiped users: %w", err)
+	}
+
+	targets := make(map[uuid.UUID]*entities.User, len(users))
+	for _, user := range users {
+		targets[user.ID] = user
+	}
+	return targets, nil
+}
+
+// matchLike makes the match a stored like leads to, and returns it, or nil if there is none
+func (uc *BatchSwipeUseCase) matchLike(ctx context.Context, swiper, swiped *entities.User) (*dto.Match, error) {
+	// A shadowbanned user never gets a match
+	if !canMatch(swiper, swiped) {
+		return nil, nil
+	}
+
+	isMatch, match, err := uc.matchService.CheckForMatch(ctx, swiper.ID, swiped.ID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to check for match: %w", err)
+	}
+	if !isMatch {
+		return nil, nil
+	}
+
+	if match == nil {
+		match = &entities.Match{
+			User1ID:  swiper.ID,
+			User2ID:  swiped.ID,
+			IsActive: true,
+		}
+
+		if err := uc.matchService.CreateMatch(ctx, match); err != nil {
+			return nil, fmt.Errorf("failed to create match: %w", err)
+		}
+
+		publishMatchCreated(ctx, uc.publisher, match)
+	}
+
+	uc.invalidateDiscoveryCache(ctx, swiped.ID)
+
+	return dto.NewMatch(match, swiper, swiped), nil
+}
+
+// swipedAt returns when a queued swipe happened. Timestamps from the future are not trusted.
+func (uc *BatchSwipeUseCase) swipedAt(clientTimestamp time.Time) time.Time {
+	now := uc.now()
+	if clientTimestamp.IsZero() || clientTimestamp.After(now) {
+		return now
+	}
+	return clientTimestamp
+}
+
+// useSuperLike counts a super like against the user's daily super likes. It returns false if none are left.
+func (uc *BatchSwipeUseCase) useSuperLike(ctx context.Context, user *entities.User) (bool, error) {
+	key := services.SuperLikeQuotaKey(user.ID)
+	ttl := services.SuperLikeQuotaTTL(services.ResetStrategy(uc.rateLimitConfig.SwipeResetStrategy), user.TimeLocation(), uc.now())
+
+	used, err := uc.quotaStore.IncrementSuperLikeCount(ctx, key, ttl)
+	if err != nil {
+		return false, fmt.Errorf("failed to check super like limit: %w", err)
+	}
+
+	if used > int64(uc.rateLimitConfig.SuperLikesPerDay) {
+		uc.refundSuperLikes(ctx, user.ID, 1)
+		return false, nil
+	}
+	return true, nil
+}
+
+// refundSuperLikes gives back super likes that were counted but not stored
+func (uc *BatchSwipeUseCase) refundSuperLikes(ctx context.Context, userID uuid.UUID, count int) {
+	key := services.SuperLikeQuotaKey(userID)
+	for i := 0; i < count; i++ {
+		if err := uc.quotaStore.DecrementSuperLikeCount(ctx, key); err != nil {
+			logger.Warn("Failed to refund super like", "user_id", userID, "error", err)
+			return
+		}
+	}
+}
+
+// checkPremiumAccess checks if user has premium subscription
+func (uc *BatchSwipeUseCase) checkPremiumAccess(ctx context.Context, userID uuid.UUID) (bool, error) {
+	subscription, err := uc.subscriptionRepo.GetActiveSubscription(ctx, userID)
+	if err != nil {
+		return false, err
+	}
+
+	return subscription != nil && (subscription.PlanType == "premium" || subscription.PlanType == "platinum"), nil
+}
+
+// invalidateDiscoveryCache invalidates discovery cache for a user
+func (uc *BatchSwipeUseCase) invalidateDiscoveryCache(ctx context.Context, userID uuid.UUID) {
+	uc.cacheService.InvalidateUserDiscoveryCache(ctx, userID)
+}
+
+// fail records why a swipe of a batch was not stored
+func (r *BatchSwipeItemResult) fail(message string) {
+	r.Status = BatchSwipeStatusError
+	r.Error = message
+}
+
+// Validate validates the request
+func (req *BatchSwipeRequest) Validate() error {
+	if req.SwiperID == uuid.Nil {
+		return fmt.Errorf("swiper_id is required")
+	}
+	if len(req.Swipes) == 0 {
+		return fmt.Errorf("swipes is required")
+	}
+	return nil
+}
@@ -0,0 +1,221 @@
+package matching
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/22smeargle/winkr-backend/internal/application/services"
+	"github.com/22smeargle/winkr-backend/internal/domain/entities"
+	"github.com/22smeargle/winkr-backend/internal/domain/repositories"
+	"github.com/22smeargle/winkr-backend/pkg/config"
+)
+
+// batchUserRepository also looks users up by several IDs
+type batchUserRepository struct {
+	*undoUserRepository
+}
+
+func (r *batchUserRepository) GetUsersByIDs(ctx context.Context, ids []uuid.UUID) ([]*entities.User, error) {
+	var users []*entities.User
+	for _, id := range ids {
+		if user, ok := r.users[id]; ok {
+			users = append(users, user)
+		}
+	}
+	return users, nil
+}
+
+// batchMatchRepository stores batches of swipes with the swipe service's swipes
+type batchMatchRepository struct {
+	repositories.MatchRepository
+	swipes  *memorySwipeService
+	batches int
+}
+
+func (r *batchMatchRepository) ExistsSwipe(ctx context.Context, userID, targetID uuid.UUID) (bool, error) {
+	return r.swipes.findSwipe(userID, targetID) != nil, nil
+}
+
+func (r *batchMatchRepository) BatchCreateSwipes(ctx context.Context, swipes []*entities.Swipe) error {
+	r.batches++
+	r.swipes.swipes = append(r.swipes.swipes, swipes...)
+	return nil
+}
+
+// countingSwipeLimiter allows a fixed number of swipes
+type countingSwipeLimiter struct {
+	services.RateLimiter
+	remaining int
+}
+
+func (l *countingSwipeLimiter) AllowSwipe(ctx context.Context, userID uuid.UUID) (bool, error) {
+	if l.remaining == 0 {
+		return false, nil
+	}
+	l.remaining--
+	return true, nil
+}
+
+type batchSwipeFixture struct {
+	*swipeFixture
+	matchRepo *batchMatchRepository
+	limiter   *countingSwipeLimiter
+	now       time.Time
+}
+
+func newBatchSwipeFixture() *batchSwipeFixture {
+	f := newSwipeFixture()
+	return &batchSwipeFixture{
+		swipeFixture: f,
+		matchRepo:    &batchMatchRepository{swipes: f.swipes},
+		limiter:      &countingSwipeLimiter{remaining: 10},
+		now:          time.Date(2025, 6, 1, 12, 0, 0, 0, time.UTC),
+	}
+}
+
+// addUser adds another user who can be swiped on
+func (f *batchSwipeFixture) addUser(name string) *entities.User {
+	user := &entities.User{ID: uuid.New(), FirstName: name}
+	f.users.users[user.ID] = user
+	return user
+}
+
+func (f *batchSwipeFixture) submit(items ...BatchSwipeItem) (*BatchSwipeResponse, error) {
+	useCase := NewBatchSwipeUseCase(
+		&batchUserRepository{f.users},
+		f.matchRepo,
+		f.subscriptions,
+		f.swipes,
+		f.matches,
+		&noopCacheService{},
+		f.limiter,
+		f.superLikes,
+		&config.RateLimitConfig{SuperLikesPerDay: 2},
+		&config.MatchingBatchSwipeConfig{MaxSize: 5},
+		f.publisher,
+	)
+	useCase.now = func() time.Time { return f.now }
+	return useCase.Execute(context.Background(), &BatchSwipeRequest{SwiperID: f.user.ID, Swipes: items})
+}
+
+func TestBatchSwipeUseCase_StoresSwipesAndReportsEachResult(t *testing.T) {
+	f := newBatchSwipeFixture()
+	f.targetLikesUser()
+	passed := f.addUser("Cleo")
+	swipedBefore := f.addUser("Dan")
+	f.swipes.swipes = append(f.swipes.swipes, &entities.Swipe{SwiperID: f.user.ID, SwipedID: swipedBefore.ID})
+
+	resp, err := f.submit(
+		BatchSwipeItem{TargetUserID: f.target.ID, IsLike: true},
+		BatchSwipeItem{TargetUserID: passed.ID, IsLike: false},
+		BatchSwipeItem{TargetUserID: f.target.ID, IsLike: false},
+		BatchSwipeItem{TargetUserID: swipedBefore.ID, IsLike: true},
+		BatchSwipeItem{TargetUserID: uuid.New(), IsLike: true},
+	)
+
+	require.NoError(t, err)
+	require.Len(t, resp.Results, 5)
+	assert.Equal(t, BatchSwipeStatusCreated, resp.Results[0].Status)
+	assert.Equal(t, BatchSwipeStatusCreated, resp.Results[1].Status)
+	assert.Equal(t, BatchSwipeStatusDuplicate, resp.Results[2].Status, "the first swipe on a target in a batch wins")
+	assert.Equal(t, BatchSwipeStatusDuplicate, resp.Results[3].Status)
+	assert.Equal(t, BatchSwipeStatusError, resp.Results[4].Status)
+	assert.Equal(t, "user not found", resp.Results[4].Error)
+
+	// Both new swipes are stored together, and the like back makes a match
+	assert.Equal(t, 1, f.matchRepo.batches)
+	assert.Len(t, f.swipes.recorded, 2)
+	require.True(t, f.swipes.findSwipe(f.user.ID, f.target.ID).IsLike)
+	assert.False(t, f.swipes.findSwipe(f.user.ID, passed.ID).IsLike)
+
+	require.Len(t, f.matches.matches, 1)
+	require.Len(t, resp.Matches, 1)
+	assert.Equal(t, f.target.ID, resp.Matches[0].User.ID)
+	require.NotNil(t, resp.Results[0].MatchID)
+	assert.Equal(t, f.matches.matches[0].ID, *resp.Results[0].MatchID)
+	assert.Nil(t, resp.Results[1].MatchID)
+	assert.Equal(t, 8, f.limiter.remaining)
+	require.Len(t, f.publisher.events, 1)
+	assert.Equal(t, f.matches.matches[0].ID, f.publisher.events[0].MatchID)
+}
+
+func TestBatchSwipeUseCase_RejectsOversizedBatch(t *testing.T) {
+	f := newBatchSwipeFixture()
+	items := make([]BatchSwipeItem, 6)
+	for i := range items {
+		items[i] = BatchSwipeItem{TargetUserID: f.addUser("Eve").ID, IsLike: true}
+	}
+
+	_, err := f.submit(items...)
+
+	requireAppError(t, err, http.StatusRequestEntityTooLarge)
+	assert.Zero(t, f.matchRepo.batches)
+}
+
+func TestBatchSwipeUseCase_RejectsEmptyBatch(t *testing.T) {
+	f := newBatchSwipeFixture()
+
+	_, err := f.submit()
+
+	requireAppError(t, err, http.StatusBadRequest)
+}
+
+func TestBatchSwipeUseCase_SuperLikesUseTheirQuota(t *testing.T) {
+	f := newBatchSwipeFixture()
+	first, second, third := f.addUser("Cleo"), f.addUser("Dan"), f.addUser("Eve")
+
+	resp, err := f.submit(
+		BatchSwipeItem{TargetUserID: first.ID, SuperLike: true},
+		BatchSwipeItem{TargetUserID: second.ID, SuperLike: true},
+		BatchSwipeItem{TargetUserID: third.ID, SuperLike: true},
+	)
+
+	require.NoError(t, err)
+	assert.Equal(t, BatchSwipeStatusCreated, resp.Results[0].Status)
+	assert.Equal(t, BatchSwipeStatusCreated, resp.Results[1].Status)
+	assert.Equal(t, BatchSwipeStatusError, resp.Results[2].Status)
+	assert.Equal(t, "Daily super like limit exceeded", resp.Results[2].Error)
+	assert.True(t, f.swipes.findSwipe(f.user.ID, first.ID).IsLike, "a super like is stored as a like")
+	assert.Nil(t, f.swipes.findSwipe(f.user.ID, third.ID))
+
+	// Super likes do not count against the swipe limits, and the refused one is given back
+	assert.Equal(t, 10, f.limiter.remaining)
+	assert.Equal(t, int64(2), f.superLikes.counts[services.SuperLikeQuotaKey(f.user.ID)])
+}
+
+func TestBatchSwipeUseCase_ReportsSwipesOverTheLimit(t *testing.T) {
+	f := newBatchSwipeFixture()
+	f.limiter.remaining = 1
+	first, second := f.addUser("Cleo"), f.addUser("Dan")
+
+	resp, err := f.submit(
+		BatchSwipeItem{TargetUserID: first.ID, IsLike: true},
+		BatchSwipeItem{TargetUserID: second.ID, IsLike: true},
+	)
+
+	require.NoError(t, err)
+	assert.Equal(t, BatchSwipeStatusCreated, resp.Results[0].Status)
+	assert.Equal(t, BatchSwipeStatusError, resp.Results[1].Status)
+	assert.Equal(t, "swipe rate limit exceeded", resp.Results[1].Error)
+	assert.Nil(t, f.swipes.findSwipe(f.user.ID, second.ID))
+}
+
+func TestBatchSwipeUseCase_KeepsClientTimestamps(t *testing.T) {
+	f := newBatchSwipeFixture()
+	earlier, later := f.addUser("Cleo"), f.addUser("Dan")
+	swipedAt := f.now.Add(-3 * time.Hour)
+
+	_, err := f.submit(
+		BatchSwipeItem{TargetUserID: earlier.ID, IsLike: true, ClientTimestamp: swipedAt},
+		BatchSwipeItem{TargetUserID: later.ID, IsLike: true, ClientTimestamp: f.now.Add(time.Hour)},
+	)
+
+	require.NoError(t, err)
+	assert.Equal(t, swipedAt, f.swipes.findSwipe(f.user.ID, earlier.ID).CreatedAt)
+	assert.Equal(t, f.now, f.swipes.findSwipe(f.user.ID, later.ID).CreatedAt, "timestamps from the future are not trusted")
+}
@@ -0,0 +1,75 @@
+package matching
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/22smeargle/winkr-backend/internal/domain/repositories"
+	"github.com/22smeargle/winkr-backend/pkg/errors"
+	"github.com/22smeargle/winkr-backend/pkg/logger"
+)
+
+// BlockUserUseCase handles blocking a user, which hides both users from each other's discovery and
+// stops them from messaging each other
+type BlockUserUseCase struct {
+	blockRepo repositories.UserBlockRepository
+	matchRepo repositories.MatchRepository
+}
+
+// NewBlockUserUseCase creates a new BlockUserUseCase
+func NewBlockUserUseCase(blockRepo repositories.UserBlockRepository, matchRepo repositories.MatchRepository) *BlockUserUseCase {
+	return &BlockUserUseCase{
+		blockRepo: blockRepo,
+		matchRepo: matchRepo,
+	}
+}
+
+// BlockUserRequest represents a request to block a user
+type BlockUserRequest struct {
+	UserID        uuid.UUID `json:"user_id" validate:"required"`
+	BlockedUserID uuid.UUID `json:"blocked_user_id" validate:"required"`
+}
+
+// BlockUserResponse represents the response after blocking a user
+type BlockUserResponse struct {
+	BlockedUserID uuid.UUID  `json:"blocked_user_id"`
+	EndedMatchID  *uuid.UUID `json:"ended_match_id,omitempty"` // Set when blocking ended a match
+}
+
+// Execute blocks the user and deactivates the users' match if they have one. Blocking a user
+// again succeeds, and finishes any cleanup a previous attempt left undone.
+func (uc *BlockUserUseCase) Execute(ctx context.Context, req *BlockUserRequest) (*BlockUserResponse, error) {
+	if req.UserID == req.BlockedUserID {
+		return nil, errors.NewValidationError("blocked_user_id", "You cannot block yourself")
+	}
+
+	if err := uc.blockRepo.Block(ctx, req.UserID, req.BlockedUserID); err != nil {
+		return nil, fmt.Errorf("failed to block user: %w", err)
+	}
+
+	response := &BlockUserResponse{BlockedUserID: req.BlockedUserID}
+
+	matched, err := uc.matchRepo.MatchExists(ctx, req.UserID, req.BlockedUserID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to check match: %w", err)
+	}
+	if !matched {
+		logger.Info("User blocked", "user_id", req.UserID, "blocked_user_id", req.BlockedUserID)
+		return response, nil
+	}
+
+	match, err := uc.matchRepo.GetMatchByUsers(ctx, req.UserID, req.BlockedUserID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get match: %w", err)
+	}
+	if match.IsActive {
+		if err := uc.matchRepo.DeactivateMatch(ctx, match.ID); err != nil {
+			return nil, fmt.Errorf("failed to deactivate match: %w", err)
+		}
+		response.EndedMatchID = &match.ID
+	}
+
+	logger.Info("User blocked", "user_id", req.UserID, "blocked_user_id", req.BlockedUserID, "match_id", match.ID)
+	return response, nil
+}
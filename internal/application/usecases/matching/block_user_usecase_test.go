@@ -0,0 +1,90 @@
+package matching
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/22smeargle/winkr-backend/internal/domain/entities"
+	"github.com/22smeargle/winkr-backend/internal/domain/repositories"
+	"github.com/22smeargle/winkr-backend/pkg/errors"
+)
+
+// recordingBlockRepository records the blocks it is asked to create
+type recordingBlockRepository struct {
+	repositories.UserBlockRepository
+	blocks map[uuid.UUID]uuid.UUID
+}
+
+func (r *recordingBlockRepository) Block(ctx context.Context, blockerID, blockedID uuid.UUID) error {
+	r.blocks[blockerID] = blockedID
+	return nil
+}
+
+// blockMatchRepository serves the match between two users, if any, and counts deactivations
+type blockMatchRepository struct {
+	repositories.MatchRepository
+	match       *entities.Match
+	deactivated int
+}
+
+func (r *blockMatchRepository) MatchExists(ctx context.Context, user1ID, user2ID uuid.UUID) (bool, error) {
+	return r.match != nil && r.match.IsUserInMatch(user1ID) && r.match.IsUserInMatch(user2ID), nil
+}
+
+func (r *blockMatchRepository) GetMatchByUsers(ctx context.Context, user1ID, user2ID uuid.UUID) (*entities.Match, error) {
+	return r.match, nil
+}
+
+func (r *blockMatchRepository) DeactivateMatch(ctx context.Context, matchID uuid.UUID) error {
+	r.deactivated++
+	r.match.IsActive = false
+	return nil
+}
+
+func TestBlockUserUseCase_DeactivatesMatch(t *testing.T) {
+	userID, matchedID := uuid.New(), uuid.New()
+	blocks := &recordingBlockRepository{blocks: make(map[uuid.UUID]uuid.UUID)}
+	matches := &blockMatchRepository{match: &entities.Match{ID: uuid.New(), User1ID: matchedID, User2ID: userID, IsActive: true}}
+	useCase := NewBlockUserUseCase(blocks, matches)
+
+	resp, err := useCase.Execute(context.Background(), &BlockUserRequest{UserID: userID, BlockedUserID: matchedID})
+	require.NoError(t, err)
+	assert.Equal(t, matchedID, blocks.blocks[userID])
+	require.NotNil(t, resp.EndedMatchID)
+	assert.Equal(t, matches.match.ID, *resp.EndedMatchID)
+	assert.False(t, matches.match.IsActive)
+
+	// Test blocking again leaves the ended match alone
+	resp, err = useCase.Execute(context.Background(), &BlockUserRequest{UserID: userID, BlockedUserID: matchedID})
+	require.NoError(t, err)
+	assert.Nil(t, resp.EndedMatchID)
+	assert.Equal(t, 1, matches.deactivated)
+}
+
+func TestBlockUserUseCase_WithoutMatch(t *testing.T) {
+	userID, strangerID := uuid.New(), uuid.New()
+	blocks := &recordingBlockRepository{blocks: make(map[uuid.UUID]uuid.UUID)}
+	matches := &blockMatchRepository{}
+
+	resp, err := NewBlockUserUseCase(blocks, matches).Execute(context.Background(), &BlockUserRequest{UserID: userID, BlockedUserID: strangerID})
+	require.NoError(t, err)
+	assert.Equal(t, strangerID, blocks.blocks[userID])
+	assert.Nil(t, resp.EndedMatchID)
+	assert.Zero(t, matches.deactivated)
+}
+
+func TestBlockUserUseCase_RejectsBlockingYourself(t *testing.T) {
+	userID := uuid.New()
+	blocks := &recordingBlockRepository{blocks: make(map[uuid.UUID]uuid.UUID)}
+
+	_, err := NewBlockUserUseCase(blocks, &blockMatchRepository{}).Execute(context.Background(), &BlockUserRequest{UserID: userID, BlockedUserID: userID})
+
+	var appErr *errors.AppError
+	require.ErrorAs(t, err, &appErr)
+	assert.Equal(t, http.StatusBadRequest, appErr.StatusCode())
+	assert.Empty(t, blocks.blocks)
+}
@@ -0,0 +1,140 @@
+package matching
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"net/http"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/22smeargle/winkr-backend/internal/application/services"
+	"github.com/22smeargle/winkr-backend/internal/domain/repositories"
+	"github.com/22smeargle/winkr-backend/pkg/config"
+	"github.com/22smeargle/winkr-backend/pkg/errors"
+	"github.com/22smeargle/winkr-backend/pkg/logger"
+)
+
+// BoostUseCase handles starting a discovery boost (premium feature)
+type BoostUseCase struct {
+	userRepo   repositories.UserRepository
+	boostStore services.DiscoveryBoostStore
+	config     *config.DiscoveryBoostConfig
+	now        func() time.Time
+}
+
+// NewBoostUseCase creates a new BoostUseCase
+func NewBoostUseCase(
+	userRepo repositories.UserRepository,
+	boostStore services.DiscoveryBoostStore,
+	cfg *config.DiscoveryBoostConfig,
+) *BoostUseCase {
+	return &BoostUseCase{
+		userRepo:   userRepo,
+		boostStore: boostStore,
+		config:     cfg,
+		now:        time.Now,
+	}
+}
+
+// BoostRequest represents a request to boost the user's profile
+type BoostRequest struct {
+	UserID uuid.UUID `json:"user_id" validate:"required"`
+}
+
+// BoostResponse represents the user's running boost
+type BoostResponse struct {
+	StartsAt         time.Time `json:"starts_at"`
+	EndsAt           time.Time `json:"ends_at"`
+	Multiplier       float64   `json:"multiplier"`
+	RemainingSeconds int64     `json:"remaining_seconds"`
+	AlreadyActive    bool      `json:"already_active"` // The boost was started earlier, no boost was used up
+}
+
+// Execute starts a boost that shows the user first in others' discovery feeds for the configured
+// duration. While a boost runs, the running boost is returned instead of starting another.
+func (uc *BoostUseCase) Execute(ctx context.Context, req *BoostRequest) (*BoostResponse, error) {
+	// Validate request
+	if err := req.Validate(); err != nil {
+		return nil, fmt.Errorf("invalid request: %w", err)
+	}
+
+	if !uc.config.Enabled {
+		return nil, errors.NewAppError(errors.ErrServiceUnavailable.Code, errors.ErrServiceUnavailable.Message, "Boosts are not available")
+	}
+
+	user, err := uc.userRepo.GetByID(ctx, req.UserID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get user: %w", err)
+	}
+
+	if !user.IsPremium {
+		return nil, errors.NewForbiddenError("Boosting your profile requires a premium subscription")
+	}
+
+	now := uc.now()
+	key := services.DiscoveryBoostKey(req.UserID)
+
+	existing, err := uc.boostStore.GetBoost(ctx, key)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get boost: %w", err)
+	}
+
+	if existing.IsActive(now) {
+		response := newBoostResponse(existing, now)
+		response.AlreadyActive = true
+		return response, nil
+	}
+
+	// Count the boost against the daily limit, the counter expires with the day
+	count, err := uc.boostStore.IncrementBoostCount(ctx, services.DiscoveryBoostCountKey(req.UserID, now), 24*time.Hour)
+	if err != nil {
+		return nil, fmt.Errorf("failed to check boost limit: %w", err)
+	}
+
+	if uc.config.MaxPerDay > 0 && count > int64(uc.config.MaxPerDay) {
+		return nil, errors.NewAppError(http.StatusTooManyRequests, "Daily boost limit exceeded", "")
+	}
+
+	multiplier := uc.config.Multiplier
+	if multiplier <= 0 {
+		multiplier = 1
+	}
+
+	boost := &services.DiscoveryBoost{
+		UserID:     req.UserID,
+		StartsAt:   now,
+		EndsAt:     now.Add(uc.config.Duration),
+		Multiplier: multiplier,
+	}
+
+	// The boost expires with its TTL, so discovery never has to clean it up
+	if err := uc.boostStore.SetBoost(ctx, key, boost, uc.config.Duration); err != nil {
+		return nil, fmt.Errorf("failed to store boost: %w", err)
+	}
+
+	logger.Info("Discovery boost started",
+		"user_id", req.UserID,
+		"ends_at", boost.EndsAt,
+		"multiplier", boost.Multiplier,
+	)
+
+	return newBoostResponse(boost, now), nil
+}
+
+func newBoostResponse(boost *services.DiscoveryBoost, now time.Time) *BoostResponse {
+	return &BoostResponse{
+		StartsAt:         boost.StartsAt,
+		EndsAt:           boost.EndsAt,
+		Multiplier:       boost.Multiplier,
+		RemainingSeconds: int64(math.Ceil(boost.Remaining(now).Seconds())),
+	}
+}
+
+// Validate validates the request
+func (req *BoostRequest) Validate() error {
+	if req.UserID == uuid.Nil {
+		return fmt.Errorf("user_id is required")
+	}
+	return nil
+}
@@ -0,0 +1,149 @@
+package matching
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/22smeargle/winkr-backend/internal/application/dto"
+	"github.com/22smeargle/winkr-backend/internal/application/services"
+	"github.com/22smeargle/winkr-backend/internal/domain/entities"
+	"github.com/22smeargle/winkr-backend/pkg/config"
+)
+
+// memoryBoostStore keeps boosts and daily counts in memory, the TTLs are not applied
+type memoryBoostStore struct {
+	boosts map[string]*services.DiscoveryBoost
+	counts map[string]int64
+	ttls   map[string]time.Duration
+}
+
+func newMemoryBoostStore() *memoryBoostStore {
+	return &memoryBoostStore{
+		boosts: make(map[string]*services.DiscoveryBoost),
+		counts: make(map[string]int64),
+		ttls:   make(map[string]time.Duration),
+	}
+}
+
+func (s *memoryBoostStore) SetBoost(ctx context.Context, key string, boost *services.DiscoveryBoost, ttl time.Duration) error {
+	s.boosts[key] = boost
+	s.ttls[key] = ttl
+	return nil
+}
+
+func (s *memoryBoostStore) GetBoost(ctx context.Context, key string) (*services.DiscoveryBoost, error) {
+	return s.boosts[key], nil
+}
+
+func (s *memoryBoostStore) IncrementBoostCount(ctx context.Context, key string, ttl time.Duration) (int64, error) {
+	s.counts[key]++
+	return s.counts[key], nil
+}
+
+func newBoostFixture(isPremium bool, now time.Time) (*BoostUseCase, *memoryBoostStore, *entities.User) {
+	user := &entities.User{ID: uuid.New(), FirstName: "Ana", IsPremium: isPremium}
+	store := newMemoryBoostStore()
+	users := &undoUserRepository{users: map[uuid.UUID]*entities.User{user.ID: user}}
+	useCase := NewBoostUseCase(users, store, &config.DiscoveryBoostConfig{
+		Enabled:    true,
+		Duration:   30 * time.Minute,
+		Multiplier: 2,
+		MaxPerDay:  1,
+	})
+	useCase.now = func() time.Time { return now }
+	return useCase, store, user
+}
+
+func TestBoostUseCase_StartsBoost(t *testing.T) {
+	now := time.Date(2026, 3, 10, 12, 0, 0, 0, time.UTC)
+	useCase, store, user := newBoostFixture(true, now)
+
+	resp, err := useCase.Execute(context.Background(), &BoostRequest{UserID: user.ID})
+
+	require.NoError(t, err)
+	assert.Equal(t, now.Add(30*time.Minute), resp.EndsAt)
+	assert.Equal(t, int64(30*60), resp.RemainingSeconds)
+	assert.False(t, resp.AlreadyActive)
+
+	key := services.DiscoveryBoostKey(user.ID)
+	require.NotNil(t, store.boosts[key])
+	assert.Equal(t, 2.0, store.boosts[key].Multiplier)
+	assert.Equal(t, 30*time.Minute, store.ttls[key], "the boost expires from the store when it ends")
+
+	// Boosting again while the boost runs returns its remaining time without using another boost
+	useCase.now = func() time.Time { return now.Add(10 * time.Minute) }
+	resp, err = useCase.Execute(context.Background(), &BoostRequest{UserID: user.ID})
+
+	require.NoError(t, err)
+	assert.True(t, resp.AlreadyActive)
+	assert.Equal(t, int64(20*60), resp.RemainingSeconds)
+	assert.Equal(t, int64(1), store.counts[services.DiscoveryBoostCountKey(user.ID, now)])
+}
+
+func TestBoostUseCase_Rejections(t *testing.T) {
+	now := time.Date(2026, 3, 10, 12, 0, 0, 0, time.UTC)
+
+	t.Run("free users cannot boost", func(t *testing.T) {
+		useCase, store, user := newBoostFixture(false, now)
+
+		_, err := useCase.Execute(context.Background(), &BoostRequest{UserID: user.ID})
+
+		requireAppError(t, err, http.StatusForbidden)
+		assert.Empty(t, store.boosts)
+	})
+
+	t.Run("daily limit reached", func(t *testing.T) {
+		useCase, store, user := newBoostFixture(true, now)
+		_, err := useCase.Execute(context.Background(), &BoostRequest{UserID: user.ID})
+		require.NoError(t, err)
+
+		// The first boost has ended, but the day's boost is used up
+		useCase.now = func() time.Time { return now.Add(time.Hour) }
+		_, err = useCase.Execute(context.Background(), &BoostRequest{UserID: user.ID})
+
+		requireAppError(t, err, http.StatusTooManyRequests)
+		assert.Equal(t, now.Add(30*time.Minute), store.boosts[services.DiscoveryBoostKey(user.ID)].EndsAt)
+	})
+
+	t.Run("boosts disabled", func(t *testing.T) {
+		useCase, _, user := newBoostFixture(true, now)
+		useCase.config.Enabled = false
+
+		_, err := useCase.Execute(context.Background(), &BoostRequest{UserID: user.ID})
+
+		requireAppError(t, err, http.StatusServiceUnavailable)
+	})
+}
+
+func TestDiscoverUsersUseCase_SurfaceBoosted(t *testing.T) {
+	now := time.Now()
+	store := newMemoryBoostStore()
+	uc := &DiscoverUsersUseCase{boostStore: store}
+
+	users := make([]*dto.DiscoveryUser, 5)
+	for i := range users {
+		users[i] = &dto.DiscoveryUser{ID: uuid.New()}
+	}
+	ranked := append([]*dto.DiscoveryUser(nil), users...)
+
+	boost := func(user *dto.DiscoveryUser, multiplier float64, endsAt time.Time) {
+		store.boosts[services.DiscoveryBoostKey(user.ID)] = &services.DiscoveryBoost{
+			UserID:     user.ID,
+			StartsAt:   now.Add(-time.Minute),
+			EndsAt:     endsAt,
+			Multiplier: multiplier,
+		}
+	}
+	boost(ranked[3], 2, now.Add(time.Minute))
+	boost(ranked[4], 3, now.Add(time.Minute))
+	boost(ranked[1], 2, now.Add(-time.Second)) // Ended, not yet expired from the store
+
+	uc.surfaceBoosted(context.Background(), users)
+
+	assert.Equal(t, []*dto.DiscoveryUser{ranked[4], ranked[3], ranked[0], ranked[1], ranked[2]}, users)
+}
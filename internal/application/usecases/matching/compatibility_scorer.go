@@ -0,0 +1,112 @@
+package matching
+
+import (
+	"math"
+	"time"
+
+	"github.com/22smeargle/winkr-backend/internal/application/dto"
+	"github.com/22smeargle/winkr-backend/internal/domain/entities"
+)
+
+// Weights of the compatibility parts. When a part cannot be compared, the weights of the others are
+// scaled up so the score still runs from 0 to 100.
+const (
+	interestCompatibilityWeight = 0.5
+	distanceCompatibilityWeight = 0.3
+	ageCompatibilityWeight      = 0.2
+)
+
+// CompatibilityScorer scores how well two users fit together, part by part
+type CompatibilityScorer struct{}
+
+// NewCompatibilityScorer creates a new CompatibilityScorer
+func NewCompatibilityScorer() *CompatibilityScorer {
+	return &CompatibilityScorer{}
+}
+
+// Score breaks down the compatibility of two users. It gives the same result whichever user asks, so
+// a part is left out when either user hid what it would give away: the age difference tells each user
+// the other's age, the distance where the other is. Nil preferences are not known, so they count as
+// hiding everything.
+func (s *CompatibilityScorer) Score(user, other *entities.User, userPreferences, otherPreferences *entities.UserPreferences) *dto.MatchCompatibility {
+	hidden := func(field string) bool {
+		if userPreferences == nil || otherPreferences == nil {
+			return true
+		}
+		return userPreferences.IsProfileFieldHidden(field, user.IsPremium) ||
+			otherPreferences.IsProfileFieldHidden(field, other.IsPremium)
+	}
+
+	sharedInterests, similarity := interestSimilarity(user.Interests, other.Interests)
+	if sharedInterests == nil {
+		sharedInterests = []string{}
+	}
+	compatibility := &dto.MatchCompatibility{
+		SharedInterests: &dto.InterestCompatibility{
+			Interests: sharedInterests,
+			Score:     roundScore(similarity * 100),
+		},
+		CalculatedAt: time.Now(),
+	}
+	weightedScore := compatibility.SharedInterests.Score * interestCompatibilityWeight
+	totalWeight := interestCompatibilityWeight
+
+	if user.HasLocation() && other.HasLocation() &&
+		!hidden(entities.ProfileFieldDistance) && !hidden(entities.ProfileFieldLocation) {
+		distance := calculateDistance(user, other)
+		compatibility.Distance = &dto.DistanceCompatibility{
+			DistanceKm: math.Round(distance*10) / 10,
+			Score:      distanceCompatibilityScore(distance),
+		}
+		weightedScore += compatibility.Distance.Score * distanceCompatibilityWeight
+		totalWeight += distanceCompatibilityWeight
+	}
+
+	if !hidden(entities.ProfileFieldAge) {
+		ageDifference := user.GetAge() - other.GetAge()
+		if ageDifference < 0 {
+			ageDifference = -ageDifference
+		}
+		compatibility.AgeProximity = &dto.AgeCompatibility{
+			AgeDifference: ageDifference,
+			Score:         ageCompatibilityScore(ageDifference),
+		}
+		weightedScore += compatibility.AgeProximity.Score * ageCompatibilityWeight
+		totalWeight += ageCompatibilityWeight
+	}
+
+	compatibility.Score = roundScore(weightedScore / totalWeight)
+	return compatibility
+}
+
+// distanceCompatibilityScore converts a distance to a score, the same way match quality does:
+// 0-10km = 100, 10-50km = 50, 50km+ = 0
+func distanceCompatibilityScore(distance float64) float64 {
+	if distance <= 10 {
+		return 100
+	}
+	if distance <= 50 {
+		return 50
+	}
+	return 0
+}
+
+// ageCompatibilityScore converts an age difference to a score, the same way match quality does:
+// 0-2 years = 100, 2-5 years = 75, 5-10 years = 50, 10+ years = 0
+func ageCompatibilityScore(ageDifference int) float64 {
+	if ageDifference <= 2 {
+		return 100
+	}
+	if ageDifference <= 5 {
+		return 75
+	}
+	if ageDifference <= 10 {
+		return 50
+	}
+	return 0
+}
+
+// roundScore rounds a score to one decimal
+func roundScore(score float64) float64 {
+	return math.Round(score*10) / 10
+}
@@ -4,12 +4,17 @@ import (
 	"context"
 	"fmt"
 	"math"
+	"net/http"
+	"sort"
 	"time"
 
 	"github.com/google/uuid"
 	"github.com/22smeargle/winkr-backend/internal/application/dto"
+	"github.com/22smeargle/winkr-backend/internal/application/services"
 	"github.com/22smeargle/winkr-backend/internal/domain/entities"
 	"github.com/22smeargle/winkr-backend/internal/domain/repositories"
+	"github.com/22smeargle/winkr-backend/pkg/config"
+	"github.com/22smeargle/winkr-backend/pkg/errors"
 )
 
 // DiscoverUsersUseCase handles user discovery with filtering and pagination
@@ -17,9 +22,25 @@ type DiscoverUsersUseCase struct {
 	userRepo         repositories.UserRepository
 	matchRepo        repositories.MatchRepository
 	photoRepo        repositories.PhotoRepository
+	blockRepo        repositories.UserBlockRepository
 	matchingService  MatchingAlgorithmService
-	swipeService     SwipeService
 	cacheService     CacheService
+	filtersConfig    *config.MatchingFiltersConfig
+	passportConfig   *config.MatchingPassportConfig
+	boostStore       services.DiscoveryBoostStore
+	reputation       ReputationLookup
+	scoring          ScoringWeights
+	scoringConfig    *config.MatchScoringConfig
+}
+
+// ReputationLookup tells which users have low reputation, so discovery can show them last
+type ReputationLookup interface {
+	LowReputationUserIDs(ctx context.Context, userIDs []uuid.UUID) (map[uuid.UUID]bool, error)
+}
+
+// ScoringWeights gives the weights discovery ranks users by, which admins can change at runtime
+type ScoringWeights interface {
+	Weights(ctx context.Context) services.MatchScoringWeights
 }
 
 // NewDiscoverUsersUseCase creates a new DiscoverUsersUseCase
@@ -27,17 +48,29 @@ func NewDiscoverUsersUseCase(
 	userRepo repositories.UserRepository,
 	matchRepo repositories.MatchRepository,
 	photoRepo repositories.PhotoRepository,
+	blockRepo repositories.UserBlockRepository,
 	matchingService MatchingAlgorithmService,
-	swipeService SwipeService,
 	cacheService CacheService,
+	filtersConfig *config.MatchingFiltersConfig,
+	passportConfig *config.MatchingPassportConfig,
+	boostStore services.DiscoveryBoostStore,
+	reputation ReputationLookup,
+	scoring ScoringWeights,
+	scoringConfig *config.MatchScoringConfig,
 ) *DiscoverUsersUseCase {
 	return &DiscoverUsersUseCase{
 		userRepo:        userRepo,
 		matchRepo:       matchRepo,
 		photoRepo:       photoRepo,
+		blockRepo:       blockRepo,
 		matchingService: matchingService,
-		swipeService:    swipeService,
 		cacheService:    cacheService,
+		filtersConfig:   filtersConfig,
+		passportConfig:  passportConfig,
+		boostStore:      boostStore,
+		reputation:      reputation,
+		scoring:         scoring,
+		scoringConfig:   scoringConfig,
 	}
 }
 
@@ -52,6 +85,29 @@ type DiscoverUsersRequest struct {
 	Gender      *string   `json:"gender,omitempty"`
 	Verified    *bool     `json:"verified,omitempty"`
 	HasPhotos   *bool     `json:"has_photos,omitempty"`
+	MinHeight   *int      `json:"min_height,omitempty"` // in centimeters
+	MaxHeight   *int      `json:"max_height,omitempty"` // in centimeters
+	Smoking     []string  `json:"smoking,omitempty"`
+	Drinking    []string  `json:"drinking,omitempty"`
+	Lat         *float64  `json:"lat,omitempty"` // Browse from this location instead of the stored one, premium only
+	Lng         *float64  `json:"lng,omitempty"`
+	ActiveWithin string   `json:"active_within,omitempty"` // Only users active this recently: 24h, 7d or 30d
+	Debug       bool      `json:"debug,omitempty"`         // Return each user's score breakdown, where enabled in config
+}
+
+// activeWithinWindows are the accepted values of the active_within filter
+var activeWithinWindows = map[string]time.Duration{
+	"24h": 24 * time.Hour,
+	"7d":  7 * 24 * time.Hour,
+	"30d": 30 * 24 * time.Hour,
+}
+
+// DiscoveryPassport is the location a premium user browses discovery from instead of their own,
+// e.g. a city they are about to visit
+type DiscoveryPassport struct {
+	Lat      float64 `json:"lat"`
+	Lng      float64 `json:"lng"`
+	RadiusKm int     `json:"radius_km"` // Search radius used, after the maximum was applied
 }
 
 // DiscoverUsersResponse represents the response from discovering users
@@ -60,6 +116,9 @@ type DiscoverUsersResponse struct {
 	Total      int64               `json:"total"`
 	HasMore    bool                `json:"has_more"`
 	NextCursor string              `json:"next_cursor,omitempty"`
+	Limit      int                 `json:"limit"` // Page size used, after the platform default and maximum were applied
+	Limited    bool                `json:"limited,omitempty"` // Results were reduced because discovery is requested unusually often
+	Passport   *DiscoveryPassport  `json:"passport,omitempty"` // Set when browsing from another location
 }
 
 // Execute discovers users for the given user with filtering and pagination
@@ -69,12 +128,38 @@ func (uc *DiscoverUsersUseCase) Execute(ctx context.Context, req *DiscoverUsersR
 		return nil, fmt.Errorf("invalid request: %w", err)
 	}
 
+	// Only candidates active this recently, if asked
+	activeWithin, err := req.activeWithin()
+	if err != nil {
+		return nil, err
+	}
+
+	// Score breakdowns reveal activity and distance, so they are only given where enabled
+	if req.Debug && !uc.scoringConfig.DebugEnabled {
+		return nil, errors.NewAppError(http.StatusForbidden, "Debug mode disabled", "Score breakdowns are not enabled")
+	}
+
 	// Get current user
 	currentUser, err := uc.userRepo.GetByID(ctx, req.UserID)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get current user: %w", err)
 	}
 
+	// Attribute filters are checked against the allowed values and the user's plan
+	attributes := req.attributeFilters()
+	if err := attributes.Validate(currentUser, uc.filtersConfig); err != nil {
+		return nil, err
+	}
+
+	// Premium users can browse from another location; candidates and distances are then based on it
+	passport, err := req.passport(currentUser)
+	if err != nil {
+		return nil, err
+	}
+	if passport != nil {
+		currentUser = passport.relocate(currentUser)
+	}
+
 	// Get user preferences
 	preferences, err := uc.userRepo.GetPreferences(ctx, req.UserID)
 	if err != nil {
@@ -83,15 +168,24 @@ func (uc *DiscoverUsersUseCase) Execute(ctx context.Context, req *DiscoverUsersR
 
 	// Apply default values from preferences if not provided in request
 	filter := uc.buildDiscoveryFilter(req, preferences, currentUser)
+	filter.Attributes = attributes
+	filter.ActiveWithin = activeWithin
+	if passport != nil {
+		filter.MaxDistance = passport.clampRadius(filter.MaxDistance, uc.passportConfig)
+		passport.RadiusKm = filter.MaxDistance
+	}
 
-	// Check cache first
-	cacheKey := uc.generateCacheKey(req.UserID, filter)
+	// Check cache first; pages ranked with other weights are not served
+	scorer := uc.newScorer(ctx)
+	cacheKey := uc.generateCacheKey(req, filter, passport, scorer.weights)
 	if cached, err := uc.cacheService.GetDiscoveryUsers(ctx, cacheKey); err == nil && cached != nil {
+		// Boosts start and end while results are cached
+		uc.surfaceBoosted(ctx, cached.Users)
 		return cached, nil
 	}
 
-	// Get already swiped users to exclude them
-	swipedUserIDs, err := uc.swipeService.GetSwipedUserIDs(ctx, req.UserID)
+	// Get already swiped users to exclude them, liked or passed
+	swipedUserIDs, err := uc.matchRepo.GetSwipedTargetIDs(ctx, req.UserID)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get swiped users: %w", err)
 	}
@@ -102,8 +196,15 @@ func (uc *DiscoverUsersUseCase) Execute(ctx context.Context, req *DiscoverUsersR
 		return nil, fmt.Errorf("failed to get matched users: %w", err)
 	}
 
+	// Get blocked users to exclude them, whichever side blocked
+	blockedUserIDs, err := uc.blockRepo.GetBlockedUserIDs(ctx, req.UserID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get blocked users: %w", err)
+	}
+
 	// Combine excluded user IDs
 	excludedUserIDs := append(swipedUserIDs, matchedUserIDs...)
+	excludedUserIDs = append(excludedUserIDs, blockedUserIDs...)
 
 	// Get potential matches using matching algorithm
 	potentialUsers, total, err := uc.matchingService.GetPotentialMatches(ctx, currentUser, filter, excludedUserIDs, req.Limit, req.Offset)
@@ -111,28 +212,43 @@ func (uc *DiscoverUsersUseCase) Execute(ctx context.Context, req *DiscoverUsersR
 		return nil, fmt.Errorf("failed to get potential matches: %w", err)
 	}
 
-	// Convert to DTOs
+	// Convert to DTOs, best scoring users first
 	discoveryUsers := make([]*dto.DiscoveryUser, 0, len(potentialUsers))
-	for _, user := range potentialUsers {
+	for _, candidate := range rankCandidates(currentUser, potentialUsers, scorer) {
+		user := candidate.user
+
 		// Get user photos
 		photos, err := uc.photoRepo.GetByUserID(ctx, user.ID)
 		if err != nil {
 			continue // Skip user if we can't get photos
 		}
 
-		// Calculate distance
-		distance := uc.calculateDistance(currentUser, user)
+		// Get the fields the user has hidden; users without preferences hide nothing
+		userPreferences, err := uc.userRepo.GetPreferences(ctx, user.ID)
+		if err != nil {
+			userPreferences = nil
+		}
 
 		// Create discovery user DTO
-		discoveryUser := dto.NewDiscoveryUser(user, photos, distance)
+		discoveryUser := dto.NewDiscoveryUser(user, photos, candidate.distanceKm, userPreferences)
+		discoveryUser.CommonInterests = candidate.commonInterests
+		discoveryUser.InterestScore = candidate.interestScore
+		if req.Debug {
+			discoveryUser.Score = candidate.score
+		}
 		discoveryUsers = append(discoveryUsers, discoveryUser)
 	}
 
+	uc.demoteLowReputation(ctx, discoveryUsers)
+	uc.surfaceBoosted(ctx, discoveryUsers)
+
 	// Create response
 	response := &DiscoverUsersResponse{
 		Users:   discoveryUsers,
 		Total:   total,
 		HasMore: int64(req.Offset+req.Limit) < total,
+		Limit:   req.Limit,
+		Passport: passport,
 	}
 
 	// Generate next cursor if there are more results
@@ -146,6 +262,208 @@ func (uc *DiscoverUsersUseCase) Execute(ctx context.Context, req *DiscoverUsersR
 	return response, nil
 }
 
+// surfaceBoosted moves users with an active boost to the front of the page, higher multipliers first,
+// keeping the ranking otherwise. A boosted user goes first even without a known distance. Expired boosts are gone from the store, and boosts that cannot be read are ignored.
+func (uc *DiscoverUsersUseCase) surfaceBoosted(ctx context.Context, users []*dto.DiscoveryUser) {
+	if uc.boostStore == nil || len(users) == 0 {
+		return
+	}
+
+	now := time.Now()
+	multipliers := make(map[uuid.UUID]float64)
+	for _, user := range users {
+		boost, err := uc.boostStore.GetBoost(ctx, services.DiscoveryBoostKey(user.ID))
+		if err != nil {
+			continue
+		}
+		if boost.IsActive(now) {
+			multipliers[user.ID] = boost.Multiplier
+		}
+	}
+
+	if len(multipliers) == 0 {
+		return
+	}
+
+	sort.SliceStable(users, func(i, j int) bool {
+		return multipliers[users[i].ID] > multipliers[users[j].ID]
+	})
+}
+
+// demoteLowReputation moves users with low reputation to the end of the page, keeping the ranking
+// otherwise. If reputations cannot be read the page is left as is.
+func (uc *DiscoverUsersUseCase) demoteLowReputation(ctx context.Context, users []*dto.DiscoveryUser) {
+	if uc.reputation == nil || len(users) == 0 {
+		return
+	}
+
+	userIDs := make([]uuid.UUID, len(users))
+	for i, user := range users {
+		userIDs[i] = user.ID
+	}
+
+	low, err := uc.reputation.LowReputationUserIDs(ctx, userIDs)
+	if err != nil || len(low) == 0 {
+		return
+	}
+
+	sort.SliceStable(users, func(i, j int) bool {
+		return !low[users[i].ID] && low[users[j].ID]
+	})
+}
+
+// rankedCandidate is a discovery candidate with how well they fit the requesting user
+type rankedCandidate struct {
+	user            *entities.User
+	distanceKm      *float64 // nil when either user has no location
+	commonInterests []string
+	interestScore   float64
+	score           *dto.MatchScore
+}
+
+// candidateScorer scores how well candidates fit the requesting user, part by part
+type candidateScorer struct {
+	weights         services.MatchScoringWeights
+	distanceScaleKm float64
+	activityWindow  time.Duration
+	now             time.Time
+}
+
+// newScorer returns a scorer using the weights in effect, the configured ones unless an admin changed them
+func (uc *DiscoverUsersUseCase) newScorer(ctx context.Context) candidateScorer {
+	weights := services.MatchScoringWeightsFromConfig(uc.scoringConfig)
+	if uc.scoring != nil {
+		weights = uc.scoring.Weights(ctx)
+	}
+
+	return candidateScorer{
+		weights:         weights,
+		distanceScaleKm: uc.scoringConfig.DistanceScaleKm,
+		activityWindow:  uc.scoringConfig.ActivityWindow,
+		now:             time.Now(),
+	}
+}
+
+// score breaks down how well the candidate fits, with the weighted average of the parts as total
+func (s candidateScorer) score(candidate *rankedCandidate) *dto.MatchScore {
+	score := &dto.MatchScore{
+		Distance:          distanceScore(candidate.distanceKm, s.distanceScaleKm),
+		SharedInterests:   candidate.interestScore,
+		ActivityRecency:   activityScore(candidate.user.LastActive, s.activityWindow, s.now),
+		ProfileCompletion: profileCompletion(candidate.user),
+	}
+
+	if total := s.weights.Total(); total > 0 {
+		score.Total = (score.Distance*s.weights.Distance +
+			score.SharedInterests*s.weights.SharedInterests +
+			score.ActivityRecency*s.weights.ActivityRecency +
+			score.ProfileCompletion*s.weights.ProfileCompletion) / total
+	}
+	return score
+}
+
+// rankCandidates orders candidates by their score, closest first among equal scores. Candidates whose
+// distance is not known go last, whatever their score. Ranking happens within the page the matching
+// algorithm returned, so pages do not overlap.
+func rankCandidates(requester *entities.User, candidates []*entities.User, scorer candidateScorer) []*rankedCandidate {
+	ranked := make([]*rankedCandidate, 0, len(candidates))
+	for _, user := range candidates {
+		commonInterests, similarity := interestSimilarity(requester.Interests, user.Interests)
+		candidate := &rankedCandidate{
+			user:            user,
+			distanceKm:      distanceKm(requester, user),
+			commonInterests: commonInterests,
+			interestScore:   similarity,
+		}
+		candidate.score = scorer.score(candidate)
+		ranked = append(ranked, candidate)
+	}
+
+	sort.SliceStable(ranked, func(i, j int) bool {
+		a, b := ranked[i], ranked[j]
+		if (a.distanceKm == nil) != (b.distanceKm == nil) {
+			return a.distanceKm != nil
+		}
+		if a.score.Total != b.score.Total {
+			return a.score.Total > b.score.Total
+		}
+		return a.distanceKm != nil && *a.distanceKm < *b.distanceKm
+	})
+
+	return ranked
+}
+
+// distanceScore converts a distance to a score from 1 right next to the user down towards 0, half at
+// scaleKm. An unknown distance scores 0.
+func distanceScore(distanceKm *float64, scaleKm float64) float64 {
+	if distanceKm == nil || scaleKm <= 0 {
+		return 0
+	}
+	return scaleKm / (scaleKm + *distanceKm)
+}
+
+// activityScore converts how long ago a user was last active to a score, from 1 when active just now
+// down to 0 at the end of the window. Users who were never active score 0.
+func activityScore(lastActive *time.Time, window time.Duration, now time.Time) float64 {
+	if lastActive == nil || window <= 0 {
+		return 0
+	}
+
+	elapsed := now.Sub(*lastActive)
+	if elapsed <= 0 {
+		return 1
+	}
+	if elapsed >= window {
+		return 0
+	}
+	return 1 - float64(elapsed)/float64(window)
+}
+
+// profileCompletion returns the share of the optional profile fields the user filled in. Photos are not
+// counted, as users without approved photos are kept out of discovery.
+func profileCompletion(user *entities.User) float64 {
+	fields := []bool{
+		user.Bio != nil && *user.Bio != "",
+		len(user.Interests) > 0,
+		user.HeightCm != nil,
+		user.Smoking != nil,
+		user.Drinking != nil,
+	}
+
+	filled := 0
+	for _, isFilled := range fields {
+		if isFilled {
+			filled++
+		}
+	}
+	return float64(filled) / float64(len(fields))
+}
+
+// interestSimilarity returns the interests both users list, in the order of the first, and their
+// Jaccard similarity: the shared interests over all the distinct interests of both, from 0 to 1
+func interestSimilarity(interests, other []string) ([]string, float64) {
+	interests = entities.NormalizeInterests(interests)
+	other = entities.NormalizeInterests(other)
+	if len(interests) == 0 || len(other) == 0 {
+		return nil, 0
+	}
+
+	listedByOther := make(map[string]bool, len(other))
+	for _, interest := range other {
+		listedByOther[interest] = true
+	}
+
+	var common []string
+	for _, interest := range interests {
+		if listedByOther[interest] {
+			common = append(common, interest)
+		}
+	}
+
+	union := len(interests) + len(other) - len(common)
+	return common, float64(len(common)) / float64(union)
+}
+
 // buildDiscoveryFilter builds the discovery filter from request and preferences
 func (uc *DiscoverUsersUseCase) buildDiscoveryFilter(req *DiscoverUsersRequest, preferences *entities.UserPreferences, currentUser *entities.User) *MatchingFilter {
 	filter := &MatchingFilter{
@@ -181,7 +499,7 @@ func (uc *DiscoverUsersUseCase) buildDiscoveryFilter(req *DiscoverUsersRequest,
 }
 
 // calculateDistance calculates the distance between two users in kilometers
-func (uc *DiscoverUsersUseCase) calculateDistance(user1, user2 *entities.User) float64 {
+func calculateDistance(user1, user2 *entities.User) float64 {
 	if !user1.HasLocation() || !user2.HasLocation() {
 		return 0
 	}
@@ -205,19 +523,113 @@ func (uc *DiscoverUsersUseCase) calculateDistance(user1, user2 *entities.User) f
 	return earthRadiusKm * c
 }
 
-// generateCacheKey generates a cache key for discovery results
-func (uc *DiscoverUsersUseCase) generateCacheKey(userID uuid.UUID, filter *MatchingFilter) string {
-	return fmt.Sprintf("discovery:%s:%d:%d:%d:%s:%t:%t",
-		userID.String(),
+// distanceKm returns the distance between two users in kilometers, rounded to 100 m, or nil when
+// either user has no location
+func distanceKm(user1, user2 *entities.User) *float64 {
+	if !user1.HasLocation() || !user2.HasLocation() {
+		return nil
+	}
+
+	distance := math.Round(calculateDistance(user1, user2)*10) / 10
+	return &distance
+}
+
+// generateCacheKey generates a cache key for discovery results, including the page requested since
+// page sizes differ between platforms, the passport location if any, and the scoring weights
+func (uc *DiscoverUsersUseCase) generateCacheKey(req *DiscoverUsersRequest, filter *MatchingFilter, passport *DiscoveryPassport, weights services.MatchScoringWeights) string {
+	location := "home"
+	if passport != nil {
+		location = fmt.Sprintf("%.4f,%.4f", passport.Lat, passport.Lng)
+	}
+
+	return fmt.Sprintf("discovery:%s:%d:%d:%d:%d:%d:%s:%t:%t:%s:%s:%s:%s:%t",
+		req.UserID.String(),
+		req.Limit,
+		req.Offset,
 		filter.AgeMin,
 		filter.AgeMax,
 		filter.MaxDistance,
 		filter.Gender,
 		filter.Verified,
 		filter.HasPhotos,
+		filter.Attributes.Key(),
+		location,
+		req.ActiveWithin,
+		weights.Key(),
+		req.Debug,
 	)
 }
 
+// activeWithin returns how recently candidates must have been active, or 0 if the request doesn't
+// filter on activity
+func (req *DiscoverUsersRequest) activeWithin() (time.Duration, error) {
+	if req.ActiveWithin == "" {
+		return 0, nil
+	}
+
+	window, ok := activeWithinWindows[req.ActiveWithin]
+	if !ok {
+		return 0, errors.NewValidationError("active_within", "must be one of 24h, 7d or 30d")
+	}
+	return window, nil
+}
+
+// passport returns the location the user browses from instead of their own, or nil if the request
+// has none. Only premium users can browse from another location.
+func (req *DiscoverUsersRequest) passport(user *entities.User) (*DiscoveryPassport, error) {
+	if req.Lat == nil && req.Lng == nil {
+		return nil, nil
+	}
+
+	if !user.IsPremium {
+		return nil, errors.NewAppError(http.StatusForbidden, "Premium required", "Browsing another location requires a premium plan")
+	}
+
+	if req.Lat == nil || req.Lng == nil {
+		return nil, errors.NewValidationError("lat", "lat and lng must be given together")
+	}
+	if *req.Lat < -90 || *req.Lat > 90 {
+		return nil, errors.NewValidationError("lat", "must be between -90 and 90")
+	}
+	if *req.Lng < -180 || *req.Lng > 180 {
+		return nil, errors.NewValidationError("lng", "must be between -180 and 180")
+	}
+
+	return &DiscoveryPassport{Lat: *req.Lat, Lng: *req.Lng}, nil
+}
+
+// relocate returns a copy of the user placed at the passport location, leaving the stored user as is
+func (p *DiscoveryPassport) relocate(user *entities.User) *entities.User {
+	relocated := *user
+	lat, lng := p.Lat, p.Lng
+	relocated.LocationLat = &lat
+	relocated.LocationLng = &lng
+	relocated.LocationCity = nil
+	relocated.LocationCountry = nil
+	return &relocated
+}
+
+// clampRadius returns the search radius around the passport location, no larger than the configured maximum
+func (p *DiscoveryPassport) clampRadius(radiusKm int, cfg *config.MatchingPassportConfig) int {
+	if cfg == nil || cfg.MaxRadiusKm <= 0 {
+		return radiusKm
+	}
+	if radiusKm <= 0 || radiusKm > cfg.MaxRadiusKm {
+		return cfg.MaxRadiusKm
+	}
+	return radiusKm
+}
+
+// attributeFilters returns the height and lifestyle filters of the request
+func (req *DiscoverUsersRequest) attributeFilters() services.AttributeFilters {
+	return services.AttributeFilters{
+		MinHeightCm: req.MinHeight,
+		MaxHeightCm: req.MaxHeight,
+		Smoking:     req.Smoking,
+		Drinking:    req.Drinking,
+	}
+}
+
 // Validate validates the request
 func (req *DiscoverUsersRequest) Validate() error {
 	if req.UserID == uuid.Nil {
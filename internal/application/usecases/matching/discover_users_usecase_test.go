@@ -0,0 +1,404 @@
+package matching
+
+import (
+	"context"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/22smeargle/winkr-backend/internal/application/dto"
+	"github.com/22smeargle/winkr-backend/internal/application/services"
+	"github.com/22smeargle/winkr-backend/internal/domain/entities"
+	"github.com/22smeargle/winkr-backend/internal/domain/repositories"
+	"github.com/22smeargle/winkr-backend/pkg/config"
+	"github.com/22smeargle/winkr-backend/pkg/errors"
+)
+
+func newCandidate(lat float64, interests ...string) *entities.User {
+	lng := 13.4
+	return &entities.User{ID: uuid.New(), LocationLat: &lat, LocationLng: &lng, Interests: interests}
+}
+
+func rankedUsers(ranked []*rankedCandidate) []*entities.User {
+	users := make([]*entities.User, 0, len(ranked))
+	for _, candidate := range ranked {
+		users = append(users, candidate.user)
+	}
+	return users
+}
+
+// interestsOnly ranks by shared interests alone
+var interestsOnly = candidateScorer{weights: services.MatchScoringWeights{SharedInterests: 1}}
+
+func TestInterestSimilarity(t *testing.T) {
+	common, score := interestSimilarity([]string{"hiking", "Jazz", "cooking"}, []string{"jazz", "hiking", "chess", "running"})
+
+	assert.Equal(t, []string{"hiking", "jazz"}, common)
+	assert.InDelta(t, 2.0/5.0, score, 1e-9)
+
+	common, score = interestSimilarity([]string{"hiking"}, nil)
+	assert.Empty(t, common)
+	assert.Zero(t, score)
+}
+
+func TestRankCandidates_ByInterests(t *testing.T) {
+	requester := newCandidate(52.50, "hiking", "jazz", "cooking")
+
+	near := newCandidate(52.51)
+	far := newCandidate(52.90)
+	oneShared := newCandidate(52.80, "cooking", "chess")
+	twoShared := newCandidate(52.95, "hiking", "jazz", "running")
+	noLocation := &entities.User{ID: uuid.New()}
+
+	ranked := rankCandidates(requester, []*entities.User{far, noLocation, oneShared, near, twoShared}, interestsOnly)
+
+	assert.Equal(t, []*entities.User{twoShared, oneShared, near, far, noLocation}, rankedUsers(ranked))
+	assert.Equal(t, []string{"hiking", "jazz"}, ranked[0].commonInterests)
+	assert.InDelta(t, 0.5, ranked[0].interestScore, 1e-9)
+	assert.Equal(t, []string{"cooking"}, ranked[1].commonInterests)
+	assert.InDelta(t, 0.25, ranked[1].interestScore, 1e-9)
+	assert.Zero(t, ranked[2].interestScore)
+}
+
+func TestRankCandidates_RequesterWithoutInterests(t *testing.T) {
+	requester := newCandidate(52.50)
+
+	near := newCandidate(52.51, "hiking")
+	middle := newCandidate(52.70)
+	far := newCandidate(52.90, "jazz", "hiking")
+
+	ranked := rankCandidates(requester, []*entities.User{far, near, middle}, interestsOnly)
+
+	// Without interests to compare, candidates are ordered by distance
+	assert.Equal(t, []*entities.User{near, middle, far}, rankedUsers(ranked))
+	for _, candidate := range ranked {
+		assert.Zero(t, candidate.interestScore)
+		assert.Empty(t, candidate.commonInterests)
+	}
+}
+
+func TestRankCandidates_UnknownDistanceLast(t *testing.T) {
+	requester := newCandidate(52.50, "hiking", "jazz")
+
+	near := newCandidate(52.51)
+	noLocation := &entities.User{ID: uuid.New(), Interests: []string{"hiking", "jazz"}}
+	far := newCandidate(52.90, "jazz")
+
+	ranked := rankCandidates(requester, []*entities.User{noLocation, near, far}, interestsOnly)
+
+	// Sharing every interest does not lift a candidate whose distance is not known
+	assert.Equal(t, []*entities.User{far, near, noLocation}, rankedUsers(ranked))
+	assert.Nil(t, ranked[2].distanceKm)
+	if assert.NotNil(t, ranked[1].distanceKm) {
+		assert.Equal(t, 1.1, *ranked[1].distanceKm)
+	}
+
+	// Without a location of their own, the requester is not told any distance
+	ranked = rankCandidates(&entities.User{ID: uuid.New()}, []*entities.User{near, far}, interestsOnly)
+	for _, candidate := range ranked {
+		assert.Nil(t, candidate.distanceKm)
+	}
+}
+
+func TestRankCandidates_WeighsParts(t *testing.T) {
+	now := time.Now()
+	requester := newCandidate(52.50, "hiking", "jazz")
+
+	// Shares every interest, but is far away, inactive and left most of the profile empty
+	kindred := newCandidate(53.40, "hiking", "jazz")
+
+	// Shares no interest, but is close by, active just now and filled in the whole profile
+	bio, height, never := "Hi", 180, "never"
+	active := newCandidate(52.51, "chess")
+	active.LastActive = &now
+	active.Bio, active.HeightCm, active.Smoking, active.Drinking = &bio, &height, &never, &never
+
+	scorer := candidateScorer{
+		weights:         services.MatchScoringWeights{Distance: 0.35, SharedInterests: 0.35, ActivityRecency: 0.15, ProfileCompletion: 0.15},
+		distanceScaleKm: 10,
+		activityWindow:  7 * 24 * time.Hour,
+		now:             now,
+	}
+	ranked := rankCandidates(requester, []*entities.User{kindred, active}, scorer)
+
+	assert.Equal(t, []*entities.User{active, kindred}, rankedUsers(ranked))
+	score := ranked[0].score
+	assert.InDelta(t, 10.0/11.1, score.Distance, 1e-9)
+	assert.Zero(t, score.SharedInterests)
+	assert.Equal(t, 1.0, score.ActivityRecency)
+	assert.Equal(t, 1.0, score.ProfileCompletion)
+	assert.InDelta(t, 0.35*10.0/11.1+0.15+0.15, score.Total, 1e-9)
+	assert.Equal(t, 1.0, ranked[1].score.SharedInterests)
+	assert.Equal(t, 0.2, ranked[1].score.ProfileCompletion)
+
+	// Test weighing shared interests up changes the order
+	scorer.weights.SharedInterests = 1
+	ranked = rankCandidates(requester, []*entities.User{active, kindred}, scorer)
+	assert.Equal(t, []*entities.User{kindred, active}, rankedUsers(ranked))
+}
+
+func TestActivityScore(t *testing.T) {
+	now := time.Now()
+	window := 10 * 24 * time.Hour
+	at := func(ago time.Duration) *time.Time {
+		lastActive := now.Add(-ago)
+		return &lastActive
+	}
+
+	assert.Equal(t, 1.0, activityScore(at(0), window, now))
+	assert.InDelta(t, 0.7, activityScore(at(3*24*time.Hour), window, now), 1e-9)
+	assert.Zero(t, activityScore(at(30*24*time.Hour), window, now))
+	assert.Zero(t, activityScore(nil, window, now), "never active")
+}
+
+func TestDiscoverUsersUseCase_DebugNeedsConfig(t *testing.T) {
+	uc := &DiscoverUsersUseCase{scoringConfig: &config.MatchScoringConfig{}}
+
+	_, err := uc.Execute(context.Background(), &DiscoverUsersRequest{UserID: uuid.New(), Debug: true})
+
+	var appErr *errors.AppError
+	require.ErrorAs(t, err, &appErr)
+	assert.Equal(t, http.StatusForbidden, appErr.StatusCode())
+}
+
+func TestDiscoverUsersRequest_Passport(t *testing.T) {
+	lat, lng := 40.71, -74.01
+	home := newCandidate(52.50)
+
+	t.Run("premium user browses from another location", func(t *testing.T) {
+		home.IsPremium = true
+		defer func() { home.IsPremium = false }()
+
+		passport, err := (&DiscoverUsersRequest{Lat: &lat, Lng: &lng}).passport(home)
+		require.NoError(t, err)
+		require.NotNil(t, passport)
+
+		traveler := passport.relocate(home)
+		assert.Equal(t, lat, *traveler.LocationLat)
+		assert.Equal(t, lng, *traveler.LocationLng)
+		assert.Equal(t, 52.50, *home.LocationLat, "the stored location is left as is")
+	})
+
+	t.Run("free user is refused", func(t *testing.T) {
+		_, err := (&DiscoverUsersRequest{Lat: &lat, Lng: &lng}).passport(home)
+
+		var appErr *errors.AppError
+		require.ErrorAs(t, err, &appErr)
+		assert.Equal(t, http.StatusForbidden, appErr.StatusCode())
+	})
+
+	t.Run("invalid coordinates", func(t *testing.T) {
+		home.IsPremium = true
+		defer func() { home.IsPremium = false }()
+
+		badLat, badLng := 91.0, 181.0
+		for _, req := range []*DiscoverUsersRequest{{Lat: &lat}, {Lat: &badLat, Lng: &lng}, {Lat: &lat, Lng: &badLng}} {
+			_, err := req.passport(home)
+
+			var appErr *errors.AppError
+			require.ErrorAs(t, err, &appErr)
+			assert.Equal(t, http.StatusBadRequest, appErr.StatusCode())
+		}
+	})
+
+	t.Run("no passport", func(t *testing.T) {
+		passport, err := (&DiscoverUsersRequest{}).passport(home)
+		require.NoError(t, err)
+		assert.Nil(t, passport)
+	})
+}
+
+func TestDiscoverUsersRequest_ActiveWithin(t *testing.T) {
+	window, err := (&DiscoverUsersRequest{}).activeWithin()
+	require.NoError(t, err)
+	assert.Zero(t, window, "no filter unless asked")
+
+	window, err = (&DiscoverUsersRequest{ActiveWithin: "7d"}).activeWithin()
+	require.NoError(t, err)
+	assert.Equal(t, 7*24*time.Hour, window)
+
+	for _, value := range []string{"1w", "48h", "7"} {
+		_, err := (&DiscoverUsersRequest{ActiveWithin: value}).activeWithin()
+
+		var appErr *errors.AppError
+		require.ErrorAs(t, err, &appErr, value)
+		assert.Equal(t, http.StatusBadRequest, appErr.StatusCode())
+	}
+}
+
+func TestDiscoveryPassport_ClampRadius(t *testing.T) {
+	passport := &DiscoveryPassport{Lat: 40.71, Lng: -74.01}
+	cfg := &config.MatchingPassportConfig{MaxRadiusKm: 100}
+
+	assert.Equal(t, 30, passport.clampRadius(30, cfg))
+	assert.Equal(t, 100, passport.clampRadius(500, cfg))
+	assert.Equal(t, 100, passport.clampRadius(0, cfg))
+	assert.Equal(t, 500, passport.clampRadius(500, &config.MatchingPassportConfig{}))
+}
+
+// lowReputationUsers is a ReputationLookup reporting a fixed set of users as low reputation
+type lowReputationUsers map[uuid.UUID]bool
+
+func (l lowReputationUsers) LowReputationUserIDs(ctx context.Context, userIDs []uuid.UUID) (map[uuid.UUID]bool, error) {
+	low := make(map[uuid.UUID]bool)
+	for _, userID := range userIDs {
+		if l[userID] {
+			low[userID] = true
+		}
+	}
+	return low, nil
+}
+
+func TestDiscoverUsersUseCase_DemoteLowReputation(t *testing.T) {
+	users := make([]*dto.DiscoveryUser, 5)
+	for i := range users {
+		users[i] = &dto.DiscoveryUser{ID: uuid.New()}
+	}
+	ranked := append([]*dto.DiscoveryUser(nil), users...)
+	uc := &DiscoverUsersUseCase{reputation: lowReputationUsers{ranked[0].ID: true, ranked[2].ID: true}}
+
+	uc.demoteLowReputation(context.Background(), users)
+
+	assert.Equal(t, []*dto.DiscoveryUser{ranked[1], ranked[3], ranked[4], ranked[0], ranked[2]}, users)
+}
+
+// discoveryUserRepository serves users from memory with default discovery preferences
+type discoveryUserRepository struct {
+	*undoUserRepository
+}
+
+func (r *discoveryUserRepository) GetPreferences(ctx context.Context, userID uuid.UUID) (*entities.UserPreferences, error) {
+	return &entities.UserPreferences{UserID: userID, AgeMin: 18, AgeMax: 100, MaxDistance: 50}, nil
+}
+
+// swipedTargetsRepository reads the swipe exclusions from the swipes of a swipe service and has no matches
+type swipedTargetsRepository struct {
+	repositories.MatchRepository
+	swipes *memorySwipeService
+}
+
+func (r *swipedTargetsRepository) GetSwipedTargetIDs(ctx context.Context, userID uuid.UUID) ([]uuid.UUID, error) {
+	var ids []uuid.UUID
+	for _, swipe := range r.swipes.swipes {
+		if swipe.SwiperID == userID {
+			ids = append(ids, swipe.SwipedID)
+		}
+	}
+	return ids, nil
+}
+
+func (r *swipedTargetsRepository) GetMatchedUserIDs(ctx context.Context, userID uuid.UUID) ([]uuid.UUID, error) {
+	return nil, nil
+}
+
+// noBlocksRepository has no blocks
+type noBlocksRepository struct {
+	repositories.UserBlockRepository
+}
+
+func (r *noBlocksRepository) GetBlockedUserIDs(ctx context.Context, userID uuid.UUID) ([]uuid.UUID, error) {
+	return nil, nil
+}
+
+// discoveryPhotoRepository has no photos
+type discoveryPhotoRepository struct {
+	repositories.PhotoRepository
+}
+
+func (r *discoveryPhotoRepository) GetByUserID(ctx context.Context, userID uuid.UUID) ([]*entities.Photo, error) {
+	return nil, nil
+}
+
+// candidatesMatchingService offers a fixed list of candidates, minus the excluded users
+type candidatesMatchingService struct {
+	candidates []*entities.User
+}
+
+func (s *candidatesMatchingService) GetPotentialMatches(ctx context.Context, user *entities.User, filter *MatchingFilter, excludeUserIDs []uuid.UUID, limit, offset int) ([]*entities.User, int64, error) {
+	excluded := make(map[uuid.UUID]bool, len(excludeUserIDs))
+	for _, id := range excludeUserIDs {
+		excluded[id] = true
+	}
+
+	var users []*entities.User
+	for _, candidate := range s.candidates {
+		if !excluded[candidate.ID] {
+			users = append(users, candidate)
+		}
+	}
+	return users, int64(len(users)), nil
+}
+
+// memoryDiscoveryCache caches discovery pages in memory until the user's pages are invalidated
+type memoryDiscoveryCache struct {
+	noopCacheService
+	pages map[string]*DiscoverUsersResponse
+}
+
+func (c *memoryDiscoveryCache) GetDiscoveryUsers(ctx context.Context, key string) (*DiscoverUsersResponse, error) {
+	return c.pages[key], nil
+}
+
+func (c *memoryDiscoveryCache) SetDiscoveryUsers(ctx context.Context, key string, response *DiscoverUsersResponse, ttl time.Duration) error {
+	c.pages[key] = response
+	return nil
+}
+
+func (c *memoryDiscoveryCache) InvalidateUserDiscoveryCache(ctx context.Context, userID uuid.UUID) error {
+	prefix := "discovery:" + userID.String() + ":"
+	for key := range c.pages {
+		if strings.HasPrefix(key, prefix) {
+			delete(c.pages, key)
+		}
+	}
+	return nil
+}
+
+func discoveredIDs(resp *DiscoverUsersResponse) []uuid.UUID {
+	ids := make([]uuid.UUID, len(resp.Users))
+	for i, user := range resp.Users {
+		ids[i] = user.ID
+	}
+	return ids
+}
+
+func TestDiscoverUsersUseCase_ExcludesJustDislikedUser(t *testing.T) {
+	ctx := context.Background()
+	requester := newCandidate(52.50)
+	disliked, other := newCandidate(52.51), newCandidate(52.52)
+
+	users := &discoveryUserRepository{&undoUserRepository{users: map[uuid.UUID]*entities.User{
+		requester.ID: requester, disliked.ID: disliked, other.ID: other,
+	}}}
+	swipes := &memorySwipeService{quota: services.SwipeQuota{RemainingSwipes: 10}}
+	cache := &memoryDiscoveryCache{pages: make(map[string]*DiscoverUsersResponse)}
+	discover := NewDiscoverUsersUseCase(
+		users,
+		&swipedTargetsRepository{swipes: swipes},
+		&discoveryPhotoRepository{},
+		&noBlocksRepository{},
+		&candidatesMatchingService{candidates: []*entities.User{disliked, other}},
+		cache,
+		&config.MatchingFiltersConfig{},
+		nil,
+		nil,
+		nil,
+		nil,
+		&config.MatchScoringConfig{DistanceWeight: 1, DistanceScaleKm: 10},
+	)
+
+	resp, err := discover.Execute(ctx, &DiscoverUsersRequest{UserID: requester.ID})
+	require.NoError(t, err)
+	assert.Equal(t, []uuid.UUID{disliked.ID, other.ID}, discoveredIDs(resp))
+
+	_, err = NewDislikeUserUseCase(users, swipes, cache).Execute(ctx, &DislikeUserRequest{SwiperID: requester.ID, SwipedID: disliked.ID})
+	require.NoError(t, err)
+
+	resp, err = discover.Execute(ctx, &DiscoverUsersRequest{UserID: requester.ID})
+	require.NoError(t, err)
+	assert.Equal(t, []uuid.UUID{other.ID}, discoveredIDs(resp))
+}
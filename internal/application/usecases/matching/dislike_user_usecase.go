@@ -38,6 +38,7 @@ type DislikeUserRequest struct {
 // DislikeUserResponse represents the response from disliking a user
 type DislikeUserResponse struct {
 	Success bool `json:"success"`
+	SwipeResult
 }
 
 // Execute dislikes a user
@@ -83,9 +84,12 @@ func (uc *DislikeUserUseCase) Execute(ctx context.Context, req *DislikeUserReque
 	// Invalidate discovery cache for swiper
 	uc.invalidateDiscoveryCache(ctx, req.SwiperID)
 
-	return &DislikeUserResponse{
+	response := &DislikeUserResponse{
 		Success: true,
-	}, nil
+	}
+	response.Quota = getSwipeQuota(ctx, uc.swipeService, req.SwiperID)
+
+	return response, nil
 }
 
 // invalidateDiscoveryCache invalidates discovery cache for a user
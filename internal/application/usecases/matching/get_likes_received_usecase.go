@@ -0,0 +1,152 @@
+package matching
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/22smeargle/winkr-backend/internal/application/dto"
+	"github.com/22smeargle/winkr-backend/internal/domain/entities"
+	"github.com/22smeargle/winkr-backend/internal/domain/repositories"
+	"github.com/22smeargle/winkr-backend/pkg/errors"
+)
+
+// GetLikesReceivedUseCase handles listing the users who liked the requester (premium feature)
+type GetLikesReceivedUseCase struct {
+	userRepo  repositories.UserRepository
+	matchRepo repositories.MatchRepository
+	photoRepo repositories.PhotoRepository
+}
+
+// NewGetLikesReceivedUseCase creates a new GetLikesReceivedUseCase
+func NewGetLikesReceivedUseCase(
+	userRepo repositories.UserRepository,
+	matchRepo repositories.MatchRepository,
+	photoRepo repositories.PhotoRepository,
+) *GetLikesReceivedUseCase {
+	return &GetLikesReceivedUseCase{
+		userRepo:  userRepo,
+		matchRepo: matchRepo,
+		photoRepo: photoRepo,
+	}
+}
+
+// GetLikesReceivedRequest represents a request to get the likes a user received
+type GetLikesReceivedRequest struct {
+	UserID uuid.UUID `json:"user_id" validate:"required"`
+	Limit  int       `json:"limit" validate:"min=1,max=100"`
+	Offset int       `json:"offset" validate:"min=0"`
+}
+
+// LikeReceived is a user who liked the requester
+type LikeReceived struct {
+	User    *dto.DiscoveryUser `json:"user"`
+	LikedAt time.Time          `json:"liked_at"`
+}
+
+// GetLikesReceivedResponse represents the response from getting the likes a user received
+type GetLikesReceivedResponse struct {
+	Likes      []*LikeReceived `json:"likes"`
+	HasMore    bool            `json:"has_more"`
+	NextCursor string          `json:"next_cursor,omitempty"`
+}
+
+// Execute lists the users who liked the requester and are still waiting for an answer, newest
+// first. Users the requester already swiped on or matched with are left out, as are banned,
+// shadowbanned and deactivated accounts.
+func (uc *GetLikesReceivedUseCase) Execute(ctx context.Context, req *GetLikesReceivedRequest) (*GetLikesReceivedResponse, error) {
+	// Validate request
+	if err := req.Validate(); err != nil {
+		return nil, fmt.Errorf("invalid request: %w", err)
+	}
+
+	user, err := uc.userRepo.GetByID(ctx, req.UserID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get user: %w", err)
+	}
+
+	if !user.IsPremium {
+		return nil, errors.NewForbiddenError("Seeing who liked you requires a premium subscription")
+	}
+
+	// Asking for one more than the page tells whether there is another page
+	likes, err := uc.matchRepo.GetIncomingLikes(ctx, req.UserID, req.Limit+1, req.Offset)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get incoming likes: %w", err)
+	}
+
+	response := &GetLikesReceivedResponse{
+		Likes:   make([]*LikeReceived, 0, len(likes)),
+		HasMore: len(likes) > req.Limit,
+	}
+	if response.HasMore {
+		likes = likes[:req.Limit]
+		response.NextCursor = fmt.Sprintf("%d", req.Offset+req.Limit)
+	}
+
+	likers, err := uc.likers(ctx, likes)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, like := range likes {
+		liker, ok := likers[like.SwiperID]
+		if !ok || !liker.IsActive || liker.IsBanned || liker.Shadowbanned {
+			continue
+		}
+
+		// Users without photos or preferences are still shown, as in discovery
+		photos, err := uc.photoRepo.GetUserPhotos(ctx, liker.ID, false)
+		if err != nil {
+			photos = nil
+		}
+		preferences, err := uc.userRepo.GetPreferences(ctx, liker.ID)
+		if err != nil {
+			preferences = nil
+		}
+
+		response.Likes = append(response.Likes, &LikeReceived{
+			User:    dto.NewDiscoveryUser(liker, photos, distanceKm(user, liker), preferences),
+			LikedAt: like.CreatedAt,
+		})
+	}
+
+	return response, nil
+}
+
+// likers loads the users who made the likes in one query
+func (uc *GetLikesReceivedUseCase) likers(ctx context.Context, likes []*entities.Swipe) (map[uuid.UUID]*entities.User, error) {
+	ids := make([]uuid.UUID, 0, len(likes))
+	for _, like := range likes {
+		ids = append(ids, like.SwiperID)
+	}
+
+	users, err := uc.userRepo.GetUsersByIDs(ctx, ids)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get likers: %w", err)
+	}
+
+	likers := make(map[uuid.UUID]*entities.User, len(users))
+	for _, user := range users {
+		likers[user.ID] = user
+	}
+	return likers, nil
+}
+
+// Validate validates the request
+func (req *GetLikesReceivedRequest) Validate() error {
+	if req.UserID == uuid.Nil {
+		return fmt.Errorf("user_id is required")
+	}
+	if req.Limit <= 0 {
+		req.Limit = 20 // Default limit
+	}
+	if req.Limit > 100 {
+		req.Limit = 100 // Max limit
+	}
+	if req.Offset < 0 {
+		req.Offset = 0
+	}
+	return nil
+}
@@ -0,0 +1,164 @@
+package matching
+
+import (
+	"context"
+	"net/http"
+	"sort"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/22smeargle/winkr-backend/internal/domain/entities"
+)
+
+// likesUserRepository also loads users in bulk
+type likesUserRepository struct {
+	*undoUserRepository
+}
+
+func (r *likesUserRepository) GetUsersByIDs(ctx context.Context, ids []uuid.UUID) ([]*entities.User, error) {
+	var users []*entities.User
+	for _, id := range ids {
+		if user, ok := r.users[id]; ok {
+			users = append(users, user)
+		}
+	}
+	return users, nil
+}
+
+// likesMatchRepository answers incoming likes from the swipes and matches in memory
+type likesMatchRepository struct {
+	*undoMatchRepository
+}
+
+func (r *likesMatchRepository) GetIncomingLikes(ctx context.Context, userID uuid.UUID, limit, offset int) ([]*entities.Swipe, error) {
+	var likes []*entities.Swipe
+	for _, swipe := range r.swipes {
+		if swipe.SwipedID != userID || !swipe.IsLike {
+			continue
+		}
+		if r.hasSwiped(userID, swipe.SwiperID) || r.findMatch(userID, swipe.SwiperID) != nil {
+			continue
+		}
+		likes = append(likes, swipe)
+	}
+	sort.Slice(likes, func(i, j int) bool { return likes[i].CreatedAt.After(likes[j].CreatedAt) })
+
+	if offset >= len(likes) {
+		return nil, nil
+	}
+	likes = likes[offset:]
+	if len(likes) > limit {
+		likes = likes[:limit]
+	}
+	return likes, nil
+}
+
+func (r *likesMatchRepository) hasSwiped(swiperID, swipedID uuid.UUID) bool {
+	for _, swipe := range r.swipes {
+		if swipe.SwiperID == swiperID && swipe.SwipedID == swipedID {
+			return true
+		}
+	}
+	return false
+}
+
+type likesFixture struct {
+	useCase *GetLikesReceivedUseCase
+	users   *undoUserRepository
+	matches *likesMatchRepository
+	user    *entities.User
+	start   time.Time
+}
+
+func newLikesFixture(isPremium bool) *likesFixture {
+	user := &entities.User{ID: uuid.New(), FirstName: "Ana", IsPremium: isPremium, IsActive: true}
+	f := &likesFixture{
+		users:   &undoUserRepository{users: map[uuid.UUID]*entities.User{user.ID: user}},
+		matches: &likesMatchRepository{&undoMatchRepository{}},
+		user:    user,
+		start:   time.Date(2026, 10, 17, 12, 0, 0, 0, time.UTC),
+	}
+	f.useCase = NewGetLikesReceivedUseCase(&likesUserRepository{f.users}, f.matches, &undoPhotoRepository{})
+	return f
+}
+
+// addUser adds a user who swiped on the fixture's user the given number of minutes after the start
+func (f *likesFixture) addUser(name string, isLike bool, minute int) *entities.User {
+	other := &entities.User{ID: uuid.New(), FirstName: name, IsActive: true}
+	f.users.users[other.ID] = other
+	f.matches.swipes = append(f.matches.swipes, &entities.Swipe{
+		ID:        uuid.New(),
+		SwiperID:  other.ID,
+		SwipedID:  f.user.ID,
+		IsLike:    isLike,
+		CreatedAt: f.start.Add(time.Duration(minute) * time.Minute),
+	})
+	return other
+}
+
+func (f *likesFixture) get(limit, offset int) (*GetLikesReceivedResponse, error) {
+	return f.useCase.Execute(context.Background(), &GetLikesReceivedRequest{UserID: f.user.ID, Limit: limit, Offset: offset})
+}
+
+func likerNames(resp *GetLikesReceivedResponse) []string {
+	names := make([]string, 0, len(resp.Likes))
+	for _, like := range resp.Likes {
+		names = append(names, like.User.FirstName)
+	}
+	return names
+}
+
+func TestGetLikesReceivedUseCase_ListsPendingLikes(t *testing.T) {
+	f := newLikesFixture(true)
+	f.addUser("Ben", true, 1)
+	f.addUser("Cleo", true, 3)
+	f.addUser("Dan", false, 4)
+
+	// The user already passed on Eve, and matched with Finn
+	eve := f.addUser("Eve", true, 5)
+	f.matches.swipes = append(f.matches.swipes, &entities.Swipe{SwiperID: f.user.ID, SwipedID: eve.ID, IsLike: false})
+	finn := f.addUser("Finn", true, 6)
+	f.matches.matches = append(f.matches.matches, &entities.Match{ID: uuid.New(), User1ID: finn.ID, User2ID: f.user.ID, IsActive: true})
+
+	banned := f.addUser("Gus", true, 7)
+	banned.IsBanned = true
+
+	resp, err := f.get(20, 0)
+
+	require.NoError(t, err)
+	assert.Equal(t, []string{"Cleo", "Ben"}, likerNames(resp))
+	assert.Equal(t, f.start.Add(3*time.Minute), resp.Likes[0].LikedAt)
+	assert.False(t, resp.HasMore)
+	assert.Empty(t, resp.NextCursor)
+}
+
+func TestGetLikesReceivedUseCase_Pagination(t *testing.T) {
+	f := newLikesFixture(true)
+	for i, name := range []string{"Ben", "Cleo", "Dan"} {
+		f.addUser(name, true, i)
+	}
+
+	resp, err := f.get(2, 0)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"Dan", "Cleo"}, likerNames(resp))
+	assert.True(t, resp.HasMore)
+	assert.Equal(t, "2", resp.NextCursor)
+
+	resp, err = f.get(2, 2)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"Ben"}, likerNames(resp))
+	assert.False(t, resp.HasMore)
+}
+
+func TestGetLikesReceivedUseCase_RequiresPremium(t *testing.T) {
+	f := newLikesFixture(false)
+	f.addUser("Ben", true, 1)
+
+	resp, err := f.get(20, 0)
+
+	assert.Nil(t, resp)
+	requireAppError(t, err, http.StatusForbidden)
+}
@@ -0,0 +1,121 @@
+package matching
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/22smeargle/winkr-backend/internal/application/dto"
+	"github.com/22smeargle/winkr-backend/internal/domain/entities"
+	"github.com/22smeargle/winkr-backend/internal/domain/repositories"
+	"github.com/22smeargle/winkr-backend/pkg/errors"
+	"github.com/22smeargle/winkr-backend/pkg/logger"
+)
+
+// matchCompatibilityCacheTTL is how long a match's compatibility breakdown is cached
+const matchCompatibilityCacheTTL = time.Hour
+
+// GetMatchCompatibilityUseCase handles getting the compatibility breakdown of a match
+type GetMatchCompatibilityUseCase struct {
+	userRepo     repositories.UserRepository
+	matchRepo    repositories.MatchRepository
+	scorer       *CompatibilityScorer
+	cacheService CacheService
+}
+
+// NewGetMatchCompatibilityUseCase creates a new GetMatchCompatibilityUseCase
+func NewGetMatchCompatibilityUseCase(
+	userRepo repositories.UserRepository,
+	matchRepo repositories.MatchRepository,
+	scorer *CompatibilityScorer,
+	cacheService CacheService,
+) *GetMatchCompatibilityUseCase {
+	return &GetMatchCompatibilityUseCase{
+		userRepo:     userRepo,
+		matchRepo:    matchRepo,
+		scorer:       scorer,
+		cacheService: cacheService,
+	}
+}
+
+// GetMatchCompatibilityRequest represents a request to get the compatibility breakdown of a match
+type GetMatchCompatibilityRequest struct {
+	UserID  uuid.UUID `json:"user_id" validate:"required"`
+	MatchID uuid.UUID `json:"match_id" validate:"required"`
+}
+
+// Execute gets the compatibility breakdown of an active match the requester is part of. The breakdown
+// is the same for both users, so it is cached once per match.
+func (uc *GetMatchCompatibilityUseCase) Execute(ctx context.Context, req *GetMatchCompatibilityRequest) (*dto.MatchCompatibility, error) {
+	// Validate request
+	if err := req.Validate(); err != nil {
+		return nil, fmt.Errorf("invalid request: %w", err)
+	}
+
+	// The match is always loaded, so an unmatch takes effect before the cached breakdown expires
+	match, err := uc.matchRepo.GetMatchByID(ctx, req.MatchID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get match: %w", err)
+	}
+	if match == nil || !match.IsActive {
+		return nil, errors.NewNotFoundError("Match")
+	}
+	if !match.IsUserInMatch(req.UserID) {
+		return nil, errors.NewForbiddenError("You are not part of this match")
+	}
+
+	cacheKey := uc.generateCacheKey(match.ID)
+	if cached, err := uc.cacheService.GetMatchCompatibility(ctx, cacheKey); err == nil && cached != nil {
+		return cached, nil
+	}
+
+	user1, user1Preferences, err := uc.participant(ctx, match.User1ID)
+	if err != nil {
+		return nil, err
+	}
+	user2, user2Preferences, err := uc.participant(ctx, match.User2ID)
+	if err != nil {
+		return nil, err
+	}
+
+	compatibility := uc.scorer.Score(user1, user2, user1Preferences, user2Preferences)
+	compatibility.MatchID = match.ID
+
+	uc.cacheService.SetMatchCompatibility(ctx, cacheKey, compatibility, matchCompatibilityCacheTTL)
+
+	return compatibility, nil
+}
+
+// participant loads a user of the match with their preferences. Preferences that cannot be loaded
+// are left nil, which keeps the parts they could hide out of the breakdown.
+func (uc *GetMatchCompatibilityUseCase) participant(ctx context.Context, userID uuid.UUID) (*entities.User, *entities.UserPreferences, error) {
+	user, err := uc.userRepo.GetByID(ctx, userID)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to get user: %w", err)
+	}
+
+	preferences, err := uc.userRepo.GetPreferences(ctx, userID)
+	if err != nil {
+		logger.Warn("Failed to get preferences for match compatibility", "user_id", userID, "error", err)
+		preferences = nil
+	}
+
+	return user, preferences, nil
+}
+
+// generateCacheKey generates a cache key for the compatibility breakdown of a match
+func (uc *GetMatchCompatibilityUseCase) generateCacheKey(matchID uuid.UUID) string {
+	return fmt.Sprintf("match_compatibility:%s", matchID.String())
+}
+
+// Validate validates the request
+func (req *GetMatchCompatibilityRequest) Validate() error {
+	if req.UserID == uuid.Nil {
+		return fmt.Errorf("user_id is required")
+	}
+	if req.MatchID == uuid.Nil {
+		return fmt.Errorf("match_id is required")
+	}
+	return nil
+}
@@ -0,0 +1,225 @@
+package matching
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/22smeargle/winkr-backend/internal/application/dto"
+	"github.com/22smeargle/winkr-backend/internal/application/services"
+	"github.com/22smeargle/winkr-backend/internal/domain/entities"
+	"github.com/22smeargle/winkr-backend/internal/domain/repositories"
+)
+
+// compatibilityUserRepository serves users and their preferences from memory
+type compatibilityUserRepository struct {
+	repositories.UserRepository
+	users       map[uuid.UUID]*entities.User
+	preferences map[uuid.UUID]*entities.UserPreferences
+}
+
+func (r *compatibilityUserRepository) GetByID(ctx context.Context, id uuid.UUID) (*entities.User, error) {
+	return r.users[id], nil
+}
+
+func (r *compatibilityUserRepository) GetPreferences(ctx context.Context, userID uuid.UUID) (*entities.UserPreferences, error) {
+	preferences, ok := r.preferences[userID]
+	if !ok {
+		return nil, fmt.Errorf("user preferences not found")
+	}
+	return preferences, nil
+}
+
+// compatibilityMatchRepository serves a single match
+type compatibilityMatchRepository struct {
+	repositories.MatchRepository
+	match *entities.Match
+}
+
+func (r *compatibilityMatchRepository) GetMatchByID(ctx context.Context, id uuid.UUID) (*entities.Match, error) {
+	if r.match.ID != id {
+		return nil, nil
+	}
+	return r.match, nil
+}
+
+// memoryCompatibilityCache keeps compatibility breakdowns in memory
+type memoryCompatibilityCache struct {
+	services.CacheService
+	entries map[string]*dto.MatchCompatibility
+	hits    int
+}
+
+func (c *memoryCompatibilityCache) GetMatchCompatibility(ctx context.Context, key string) (*dto.MatchCompatibility, error) {
+	compatibility, ok := c.entries[key]
+	if !ok {
+		return nil, fmt.Errorf("cache miss")
+	}
+	c.hits++
+	return compatibility, nil
+}
+
+func (c *memoryCompatibilityCache) SetMatchCompatibility(ctx context.Context, key string, compatibility *dto.MatchCompatibility, ttl time.Duration) error {
+	c.entries[key] = compatibility
+	return nil
+}
+
+type compatibilityFixture struct {
+	useCase *GetMatchCompatibilityUseCase
+	users   *compatibilityUserRepository
+	cache   *memoryCompatibilityCache
+	match   *entities.Match
+	user    *entities.User
+	other   *entities.User
+}
+
+// newCompatibilityUser creates a user of the given age living at the given latitude
+func newCompatibilityUser(age int, lat float64, interests ...string) *entities.User {
+	lng := 13.4
+	return &entities.User{
+		ID:          uuid.New(),
+		DateOfBirth: time.Now().AddDate(-age, -1, 0),
+		LocationLat: &lat,
+		LocationLng: &lng,
+		Interests:   interests,
+	}
+}
+
+// newCompatibilityFixture matches two users about 5.6km and 4 years apart who share two of their five
+// distinct interests
+func newCompatibilityFixture() *compatibilityFixture {
+	user := newCompatibilityUser(29, 52.50, "hiking", "jazz", "cooking")
+	other := newCompatibilityUser(33, 52.55, "Jazz", "hiking", "chess", "running")
+	match := &entities.Match{ID: uuid.New(), User1ID: user.ID, User2ID: other.ID, IsActive: true}
+
+	users := &compatibilityUserRepository{
+		users: map[uuid.UUID]*entities.User{user.ID: user, other.ID: other},
+		preferences: map[uuid.UUID]*entities.UserPreferences{
+			user.ID:  {UserID: user.ID},
+			other.ID: {UserID: other.ID},
+		},
+	}
+	cache := &memoryCompatibilityCache{entries: make(map[string]*dto.MatchCompatibility)}
+
+	return &compatibilityFixture{
+		useCase: NewGetMatchCompatibilityUseCase(users, &compatibilityMatchRepository{match: match}, NewCompatibilityScorer(), cache),
+		users:   users,
+		cache:   cache,
+		match:   match,
+		user:    user,
+		other:   other,
+	}
+}
+
+func (f *compatibilityFixture) get(userID uuid.UUID) (*dto.MatchCompatibility, error) {
+	return f.useCase.Execute(context.Background(), &GetMatchCompatibilityRequest{UserID: userID, MatchID: f.match.ID})
+}
+
+func TestGetMatchCompatibilityUseCase_BreaksDownSharedAttributes(t *testing.T) {
+	f := newCompatibilityFixture()
+
+	compatibility, err := f.get(f.user.ID)
+
+	require.NoError(t, err)
+	assert.Equal(t, f.match.ID, compatibility.MatchID)
+	assert.Equal(t, []string{"hiking", "jazz"}, compatibility.SharedInterests.Interests)
+	assert.Equal(t, 40.0, compatibility.SharedInterests.Score)
+	require.NotNil(t, compatibility.Distance)
+	assert.InDelta(t, 5.6, compatibility.Distance.DistanceKm, 0.1)
+	assert.Equal(t, 100.0, compatibility.Distance.Score)
+	require.NotNil(t, compatibility.AgeProximity)
+	assert.Equal(t, 4, compatibility.AgeProximity.AgeDifference)
+	assert.Equal(t, 75.0, compatibility.AgeProximity.Score)
+	// 40 * 0.5 + 100 * 0.3 + 75 * 0.2
+	assert.Equal(t, 65.0, compatibility.Score)
+
+	// The other user gets the same breakdown, from the cache
+	fromOther, err := f.get(f.other.ID)
+	require.NoError(t, err)
+	assert.Equal(t, compatibility, fromOther)
+	assert.Equal(t, 1, f.cache.hits)
+}
+
+func TestGetMatchCompatibilityUseCase_NoSharedInterests(t *testing.T) {
+	f := newCompatibilityFixture()
+	f.other.Interests = []string{"chess"}
+
+	compatibility, err := f.get(f.user.ID)
+
+	require.NoError(t, err)
+	assert.Empty(t, compatibility.SharedInterests.Interests)
+	assert.Zero(t, compatibility.SharedInterests.Score)
+	assert.Equal(t, 45.0, compatibility.Score)
+}
+
+func TestGetMatchCompatibilityUseCase_LeavesOutHiddenFields(t *testing.T) {
+	t.Run("distance hidden by the other user", func(t *testing.T) {
+		f := newCompatibilityFixture()
+		f.users.preferences[f.other.ID].HiddenFields = []string{entities.ProfileFieldDistance}
+
+		compatibility, err := f.get(f.user.ID)
+
+		require.NoError(t, err)
+		assert.Nil(t, compatibility.Distance)
+		require.NotNil(t, compatibility.AgeProximity)
+		// Weighed over interests and age: (40 * 0.5 + 75 * 0.2) / 0.7
+		assert.Equal(t, 50.0, compatibility.Score)
+	})
+
+	t.Run("age hidden by the requester", func(t *testing.T) {
+		f := newCompatibilityFixture()
+		f.user.IsPremium = true
+		f.users.preferences[f.user.ID].HiddenFields = []string{entities.ProfileFieldAge}
+
+		// The age difference would tell the other user the requester's age
+		compatibility, err := f.get(f.other.ID)
+
+		require.NoError(t, err)
+		assert.Nil(t, compatibility.AgeProximity)
+		assert.NotNil(t, compatibility.Distance)
+	})
+
+	t.Run("age hidden without premium", func(t *testing.T) {
+		f := newCompatibilityFixture()
+		f.users.preferences[f.other.ID].HiddenFields = []string{entities.ProfileFieldAge}
+
+		compatibility, err := f.get(f.user.ID)
+
+		require.NoError(t, err)
+		assert.NotNil(t, compatibility.AgeProximity)
+	})
+
+	t.Run("preferences not loaded", func(t *testing.T) {
+		f := newCompatibilityFixture()
+		delete(f.users.preferences, f.other.ID)
+
+		compatibility, err := f.get(f.user.ID)
+
+		require.NoError(t, err)
+		assert.Nil(t, compatibility.Distance)
+		assert.Nil(t, compatibility.AgeProximity)
+		assert.Equal(t, []string{"hiking", "jazz"}, compatibility.SharedInterests.Interests)
+		assert.Equal(t, 40.0, compatibility.Score)
+	})
+}
+
+func TestGetMatchCompatibilityUseCase_OnlyForParticipants(t *testing.T) {
+	f := newCompatibilityFixture()
+
+	_, err := f.get(uuid.New())
+	requireAppError(t, err, http.StatusForbidden)
+
+	f.match.Deactivate()
+	_, err = f.get(f.user.ID)
+	requireAppError(t, err, http.StatusNotFound)
+
+	_, err = f.useCase.Execute(context.Background(), &GetMatchCompatibilityRequest{UserID: f.user.ID, MatchID: uuid.New()})
+	requireAppError(t, err, http.StatusNotFound)
+
+	assert.Empty(t, f.cache.entries)
+}
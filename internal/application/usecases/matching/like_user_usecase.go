@@ -17,6 +17,7 @@ type LikeUserUseCase struct {
 	swipeService SwipeService
 	matchService MatchService
 	cacheService CacheService
+	publisher    MatchEventPublisher
 }
 
 // NewLikeUserUseCase creates a new LikeUserUseCase
@@ -26,6 +27,7 @@ func NewLikeUserUseCase(
 	swipeService SwipeService,
 	matchService MatchService,
 	cacheService CacheService,
+	publisher MatchEventPublisher,
 ) *LikeUserUseCase {
 	return &LikeUserUseCase{
 		userRepo:     userRepo,
@@ -33,6 +35,7 @@ func NewLikeUserUseCase(
 		swipeService: swipeService,
 		matchService: matchService,
 		cacheService: cacheService,
+		publisher:    publisher,
 	}
 }
 
@@ -46,6 +49,7 @@ type LikeUserRequest struct {
 type LikeUserResponse struct {
 	IsMatch bool     `json:"is_match"`
 	Match   *dto.Match `json:"match,omitempty"`
+	SwipeResult
 }
 
 // Execute likes a user and checks for mutual match
@@ -88,10 +92,14 @@ func (uc *LikeUserUseCase) Execute(ctx context.Context, req *LikeUserRequest) (*
 		return nil, fmt.Errorf("failed to create swipe: %w", err)
 	}
 
-	// Check for mutual match
-	isMatch, existingMatch, err := uc.matchService.CheckForMatch(ctx, req.SwiperID, req.SwipedID)
-	if err != nil {
-		return nil, fmt.Errorf("failed to check for match: %w", err)
+	// Check for mutual match, which a shadowbanned user never gets
+	var isMatch bool
+	var existingMatch *entities.Match
+	if canMatch(swiper, swiped) {
+		isMatch, existingMatch, err = uc.matchService.CheckForMatch(ctx, req.SwiperID, req.SwipedID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to check for match: %w", err)
+		}
 	}
 
 	response := &LikeUserResponse{
@@ -115,17 +123,22 @@ func (uc *LikeUserUseCase) Execute(ctx context.Context, req *LikeUserRequest) (*
 			if err != nil {
 				return nil, fmt.Errorf("failed to create match: %w", err)
 			}
+
+			publishMatchCreated(ctx, uc.publisher, match)
 		}
 
 		// Convert to DTO
 		matchDTO := dto.NewMatch(match, swiper, swiped)
 		response.Match = matchDTO
+		response.setMatch(matchDTO)
 
 		// Invalidate discovery cache for both users
 		uc.invalidateDiscoveryCache(ctx, req.SwiperID)
 		uc.invalidateDiscoveryCache(ctx, req.SwipedID)
 	}
 
+	response.Quota = getSwipeQuota(ctx, uc.swipeService, req.SwiperID)
+
 	return response, nil
 }
 
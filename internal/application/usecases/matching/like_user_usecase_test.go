@@ -0,0 +1,93 @@
+package matching
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLikeUserUseCase_ShadowbannedLikesNeverMatch(t *testing.T) {
+	t.Run("shadowbanned swiper", func(t *testing.T) {
+		f := newSwipeFixture()
+		f.user.Shadowbanned = true
+		f.targetLikesUser()
+
+		resp, err := f.like()
+
+		// The like is stored and answered like any other, but makes no match
+		require.NoError(t, err)
+		assert.False(t, resp.IsMatch)
+		assert.Nil(t, resp.MatchID)
+		assert.Empty(t, f.matches.matches)
+		assert.NotNil(t, f.swipes.findSwipe(f.user.ID, f.target.ID))
+		assert.Equal(t, 9, resp.Quota.RemainingSwipes)
+	})
+
+	t.Run("shadowbanned target", func(t *testing.T) {
+		f := newSwipeFixture()
+		f.target.Shadowbanned = true
+		f.targetLikesUser()
+
+		resp, err := f.like()
+
+		require.NoError(t, err)
+		assert.False(t, resp.IsMatch)
+		assert.Empty(t, f.matches.matches)
+	})
+}
+
+func TestLikeUserUseCase_PublishesMatchCreated(t *testing.T) {
+	t.Run("match", func(t *testing.T) {
+		f := newSwipeFixture()
+		f.targetLikesUser()
+
+		resp, err := f.like()
+
+		require.NoError(t, err)
+		require.Len(t, f.publisher.events, 1)
+		assert.Equal(t, []string{MatchCreatedEventType}, f.publisher.eventTypes)
+		event := f.publisher.events[0]
+		assert.Equal(t, *resp.MatchID, event.MatchID)
+		assert.Equal(t, f.user.ID, event.User1ID)
+		assert.Equal(t, f.target.ID, event.User2ID)
+		assert.False(t, event.MatchedAt.IsZero())
+	})
+
+	t.Run("no match", func(t *testing.T) {
+		f := newSwipeFixture()
+
+		_, err := f.like()
+
+		require.NoError(t, err)
+		assert.Empty(t, f.publisher.events)
+	})
+
+	t.Run("publish fails", func(t *testing.T) {
+		f := newSwipeFixture()
+		f.publisher.err = errors.New("pubsub unavailable")
+		f.targetLikesUser()
+
+		resp, err := f.like()
+
+		// The stored match is what counts, so the swipe still succeeds
+		require.NoError(t, err)
+		assert.True(t, resp.IsMatch)
+		assert.Len(t, f.matches.matches, 1)
+	})
+}
+
+func TestSuperLikeUserUseCase_ShadowbannedSuperLikesNeverMatch(t *testing.T) {
+	f := newSwipeFixture()
+	f.user.Shadowbanned = true
+	f.targetLikesUser()
+
+	resp, err := f.superLike()
+
+	require.NoError(t, err)
+	assert.False(t, resp.IsMatch)
+	assert.Nil(t, resp.MatchID)
+	assert.Empty(t, f.matches.matches)
+	assert.True(t, resp.SuperLikeConsumed)
+}
@@ -0,0 +1,49 @@
+package matching
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/22smeargle/winkr-backend/internal/domain/entities"
+	"github.com/22smeargle/winkr-backend/pkg/logger"
+)
+
+// MatchCreatedEventType is published when a swipe makes a new match
+const MatchCreatedEventType = "match.created"
+
+// MatchCreatedEvent tells other services, like notifications and chat, that two users matched
+type MatchCreatedEvent struct {
+	MatchID   uuid.UUID `json:"match_id"`
+	User1ID   uuid.UUID `json:"user1_id"`
+	User2ID   uuid.UUID `json:"user2_id"`
+	MatchedAt time.Time `json:"matched_at"`
+}
+
+// MatchEventPublisher delivers match events to other services over Pub/Sub
+type MatchEventPublisher interface {
+	Publish(ctx context.Context, eventType string, data interface{}) error
+}
+
+// publishMatchCreated publishes the creation of a match. The stored match is the source of truth,
+// so a failed publish is only logged and never fails the swipe.
+func publishMatchCreated(ctx context.Context, publisher MatchEventPublisher, match *entities.Match) {
+	if publisher == nil {
+		return
+	}
+
+	matchedAt := match.MatchedAt
+	if matchedAt.IsZero() {
+		matchedAt = time.Now()
+	}
+
+	err := publisher.Publish(ctx, MatchCreatedEventType, &MatchCreatedEvent{
+		MatchID:   match.ID,
+		User1ID:   match.User1ID,
+		User2ID:   match.User2ID,
+		MatchedAt: matchedAt,
+	})
+	if err != nil {
+		logger.Warn("Failed to publish match created event", "match_id", match.ID, "error", err)
+	}
+}
@@ -3,11 +3,17 @@ package matching
 import (
 	"context"
 	"fmt"
+	"net/http"
+	"time"
 
 	"github.com/google/uuid"
 	"github.com/22smeargle/winkr-backend/internal/application/dto"
+	"github.com/22smeargle/winkr-backend/internal/application/services"
 	"github.com/22smeargle/winkr-backend/internal/domain/entities"
 	"github.com/22smeargle/winkr-backend/internal/domain/repositories"
+	"github.com/22smeargle/winkr-backend/pkg/config"
+	"github.com/22smeargle/winkr-backend/pkg/errors"
+	"github.com/22smeargle/winkr-backend/pkg/logger"
 )
 
 // SuperLikeUserUseCase handles super liking a user (premium feature)
@@ -18,6 +24,10 @@ type SuperLikeUserUseCase struct {
 	swipeService    SwipeService
 	matchService    MatchService
 	cacheService    CacheService
+	quotaStore      services.SuperLikeQuotaStore
+	config          *config.RateLimitConfig
+	publisher       MatchEventPublisher
+	now             func() time.Time
 }
 
 // NewSuperLikeUserUseCase creates a new SuperLikeUserUseCase
@@ -28,6 +38,9 @@ func NewSuperLikeUserUseCase(
 	swipeService SwipeService,
 	matchService MatchService,
 	cacheService CacheService,
+	quotaStore services.SuperLikeQuotaStore,
+	cfg *config.RateLimitConfig,
+	publisher MatchEventPublisher,
 ) *SuperLikeUserUseCase {
 	return &SuperLikeUserUseCase{
 		userRepo:        userRepo,
@@ -36,6 +49,10 @@ func NewSuperLikeUserUseCase(
 		swipeService:    swipeService,
 		matchService:    matchService,
 		cacheService:    cacheService,
+		quotaStore:      quotaStore,
+		config:          cfg,
+		publisher:       publisher,
+		now:             time.Now,
 	}
 }
 
@@ -47,8 +64,10 @@ type SuperLikeUserRequest struct {
 
 // SuperLikeUserResponse represents the response from super liking a user
 type SuperLikeUserResponse struct {
-	IsMatch bool     `json:"is_match"`
-	Match   *dto.Match `json:"match,omitempty"`
+	IsMatch             bool       `json:"is_match"`
+	Match               *dto.Match `json:"match,omitempty"`
+	RemainingSuperLikes int        `json:"remaining_super_likes"`
+	SwipeResult
 }
 
 // Execute super likes a user and checks for mutual match
@@ -75,8 +94,9 @@ func (uc *SuperLikeUserUseCase) Execute(ctx context.Context, req *SuperLikeUserR
 		return nil, fmt.Errorf("failed to check premium access: %w", err)
 	}
 
+	// Free users have no super likes
 	if !hasPremium {
-		return nil, fmt.Errorf("super like requires premium subscription")
+		return nil, errors.NewForbiddenError("Super likes require a premium subscription")
 	}
 
 	// Check if already swiped
@@ -89,14 +109,16 @@ func (uc *SuperLikeUserUseCase) Execute(ctx context.Context, req *SuperLikeUserR
 		return nil, fmt.Errorf("user already swiped")
 	}
 
-	// Check daily super like limit
-	withinLimit, err := uc.swipeService.CheckSuperLikeLimit(ctx, req.SwiperID)
+	// Count the super like against the daily super like quota, which the swipe limits do not touch
+	quotaKey := services.SuperLikeQuotaKey(req.SwiperID)
+	used, err := uc.quotaStore.IncrementSuperLikeCount(ctx, quotaKey, uc.quotaTTL(swiper))
 	if err != nil {
 		return nil, fmt.Errorf("failed to check super like limit: %w", err)
 	}
 
-	if !withinLimit {
-		return nil, fmt.Errorf("daily super like limit exceeded")
+	if used > int64(uc.config.SuperLikesPerDay) {
+		uc.refundSuperLike(ctx, quotaKey, req.SwiperID)
+		return nil, errors.NewAppError(http.StatusTooManyRequests, "Daily super like limit exceeded", "")
 	}
 
 	// Create super like swipe
@@ -108,18 +130,26 @@ func (uc *SuperLikeUserUseCase) Execute(ctx context.Context, req *SuperLikeUserR
 
 	err = uc.swipeService.CreateSuperLike(ctx, swipe)
 	if err != nil {
+		// Only stored super likes use up the quota
+		uc.refundSuperLike(ctx, quotaKey, req.SwiperID)
 		return nil, fmt.Errorf("failed to create super like: %w", err)
 	}
 
-	// Check for mutual match
-	isMatch, existingMatch, err := uc.matchService.CheckForMatch(ctx, req.SwiperID, req.SwipedID)
-	if err != nil {
-		return nil, fmt.Errorf("failed to check for match: %w", err)
+	// Check for mutual match, which a shadowbanned user never gets
+	var isMatch bool
+	var existingMatch *entities.Match
+	if canMatch(swiper, swiped) {
+		isMatch, existingMatch, err = uc.matchService.CheckForMatch(ctx, req.SwiperID, req.SwipedID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to check for match: %w", err)
+		}
 	}
 
 	response := &SuperLikeUserResponse{
-		IsMatch: isMatch,
+		IsMatch:             isMatch,
+		RemainingSuperLikes: int(int64(uc.config.SuperLikesPerDay) - used),
 	}
+	response.SuperLikeConsumed = true
 
 	// If it's a match, create match and return match details
 	if isMatch {
@@ -138,20 +168,41 @@ func (uc *SuperLikeUserUseCase) Execute(ctx context.Context, req *SuperLikeUserR
 			if err != nil {
 				return nil, fmt.Errorf("failed to create match: %w", err)
 			}
+
+			publishMatchCreated(ctx, uc.publisher, match)
 		}
 
 		// Convert to DTO
 		matchDTO := dto.NewMatch(match, swiper, swiped)
 		response.Match = matchDTO
+		response.setMatch(matchDTO)
 
 		// Invalidate discovery cache for both users
 		uc.invalidateDiscoveryCache(ctx, req.SwiperID)
 		uc.invalidateDiscoveryCache(ctx, req.SwipedID)
 	}
 
+	response.Quota = getSwipeQuota(ctx, uc.swipeService, req.SwiperID)
+	if response.Quota != nil {
+		response.Quota.RemainingSuperLikes = response.RemainingSuperLikes
+	}
+
 	return response, nil
 }
 
+// quotaTTL returns how long a super like counter started now lives, so it expires when the user's
+// daily limits start over
+func (uc *SuperLikeUserUseCase) quotaTTL(user *entities.User) time.Duration {
+	return services.SuperLikeQuotaTTL(services.ResetStrategy(uc.config.SwipeResetStrategy), user.TimeLocation(), uc.now())
+}
+
+// refundSuperLike gives back a super like that was counted but not used
+func (uc *SuperLikeUserUseCase) refundSuperLike(ctx context.Context, key string, userID uuid.UUID) {
+	if err := uc.quotaStore.DecrementSuperLikeCount(ctx, key); err != nil {
+		logger.Warn("Failed to refund super like", "user_id", userID, "error", err)
+	}
+}
+
 // checkPremiumAccess checks if user has premium subscription
 func (uc *SuperLikeUserUseCase) checkPremiumAccess(ctx context.Context, userID uuid.UUID) (bool, error) {
 	subscription, err := uc.subscriptionRepo.GetActiveSubscription(ctx, userID)
@@ -0,0 +1,101 @@
+package matching
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/22smeargle/winkr-backend/internal/application/services"
+	"github.com/22smeargle/winkr-backend/internal/domain/entities"
+	"github.com/22smeargle/winkr-backend/internal/domain/repositories"
+)
+
+// freeSubscriptionRepository has no subscriptions
+type freeSubscriptionRepository struct {
+	repositories.SubscriptionRepository
+}
+
+func (r *freeSubscriptionRepository) GetActiveSubscription(ctx context.Context, userID uuid.UUID) (*entities.Subscription, error) {
+	return nil, nil
+}
+
+// superLikeTarget adds another user the fixture's user can super like
+func (f *swipeFixture) superLikeTarget() uuid.UUID {
+	target := &entities.User{ID: uuid.New()}
+	f.users.users[target.ID] = target
+	return target.ID
+}
+
+func TestSuperLikeUserUseCase_DailyQuota(t *testing.T) {
+	f := newSwipeFixture()
+	// Likes used up every swipe of the day, which leaves super likes alone
+	f.swipes.quota.RemainingSwipes = 0
+	useCase := f.superLikeUseCase()
+	key := services.SuperLikeQuotaKey(f.user.ID)
+
+	superLike := func() (*SuperLikeUserResponse, error) {
+		return useCase.Execute(context.Background(), &SuperLikeUserRequest{SwiperID: f.user.ID, SwipedID: f.superLikeTarget()})
+	}
+
+	resp, err := superLike()
+	require.NoError(t, err)
+	assert.Equal(t, 1, resp.RemainingSuperLikes)
+	assert.Equal(t, 1, resp.Quota.RemainingSuperLikes)
+	assert.Equal(t, 24*time.Hour, f.superLikes.ttls[key])
+
+	resp, err = superLike()
+	require.NoError(t, err)
+	assert.Equal(t, 0, resp.RemainingSuperLikes)
+
+	_, err = superLike()
+	requireAppError(t, err, http.StatusTooManyRequests)
+	assert.Equal(t, int64(2), f.superLikes.counts[key], "the rejected super like is not counted")
+	assert.Len(t, f.swipes.swipes, 2)
+}
+
+func TestSuperLikeUserUseCase_FailedSuperLikeIsRefunded(t *testing.T) {
+	f := newSwipeFixture()
+	f.swipes.createErr = fmt.Errorf("database unavailable")
+
+	_, err := f.superLike()
+
+	require.Error(t, err)
+	assert.Equal(t, int64(0), f.superLikes.counts[services.SuperLikeQuotaKey(f.user.ID)])
+
+	f.swipes.createErr = nil
+	resp, err := f.superLike()
+
+	require.NoError(t, err)
+	assert.Equal(t, 1, resp.RemainingSuperLikes)
+}
+
+func TestSuperLikeUserUseCase_FreeUsersHaveNoSuperLikes(t *testing.T) {
+	f := newSwipeFixture()
+	f.subscriptions = &freeSubscriptionRepository{}
+
+	_, err := f.superLike()
+
+	requireAppError(t, err, http.StatusForbidden)
+	assert.Empty(t, f.superLikes.counts)
+	assert.Empty(t, f.swipes.swipes)
+}
+
+func TestSuperLikeUserUseCase_QuotaResetsAtLocalMidnight(t *testing.T) {
+	f := newSwipeFixture()
+	tokyo := "Asia/Tokyo"
+	f.user.Timezone = &tokyo
+	useCase := f.superLikeUseCase()
+	useCase.config.SwipeResetStrategy = string(services.ResetLocalMidnight)
+	// 23:30 in Tokyo
+	useCase.now = func() time.Time { return time.Date(2026, 10, 17, 14, 30, 0, 0, time.UTC) }
+
+	_, err := useCase.Execute(context.Background(), &SuperLikeUserRequest{SwiperID: f.user.ID, SwipedID: f.target.ID})
+
+	require.NoError(t, err)
+	assert.Equal(t, 30*time.Minute, f.superLikes.ttls[services.SuperLikeQuotaKey(f.user.ID)])
+}
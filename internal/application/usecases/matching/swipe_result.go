@@ -0,0 +1,44 @@
+package matching
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/22smeargle/winkr-backend/internal/application/dto"
+	"github.com/22smeargle/winkr-backend/internal/application/services"
+	"github.com/22smeargle/winkr-backend/internal/domain/entities"
+	"github.com/22smeargle/winkr-backend/pkg/logger"
+)
+
+// SwipeResult contains the details every swipe response carries, so clients can update the
+// match screen and the remaining limits without further requests
+type SwipeResult struct {
+	MatchID           *uuid.UUID           `json:"match_id,omitempty"`     // Set when the swipe made a match
+	MatchedUser       *dto.User            `json:"matched_user,omitempty"` // The other user of the match
+	SuperLikeConsumed bool                 `json:"super_like_consumed"`
+	Quota             *services.SwipeQuota `json:"quota,omitempty"` // Left out if the limits could not be read
+}
+
+// setMatch records the match the swipe made
+func (r *SwipeResult) setMatch(match *dto.Match) {
+	matchID := match.ID
+	r.MatchID = &matchID
+	r.MatchedUser = match.User
+}
+
+// getSwipeQuota gets what is left of the user's daily limits. The swipe is already stored by
+// then, so a failure leaves the quota out instead of failing the swipe.
+func getSwipeQuota(ctx context.Context, swipeService SwipeService, userID uuid.UUID) *services.SwipeQuota {
+	quota, err := swipeService.GetSwipeQuota(ctx, userID)
+	if err != nil {
+		logger.Warn("Failed to get swipe quota", "user_id", userID, "error", err)
+		return nil
+	}
+	return quota
+}
+
+// canMatch reports whether a like between the users can make a match. The likes of shadowbanned
+// users never reach anyone, and likes to them are never returned, so neither side is matched.
+func canMatch(swiper, swiped *entities.User) bool {
+	return !swiper.Shadowbanned && !swiped.Shadowbanned
+}
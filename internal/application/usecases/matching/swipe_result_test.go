@@ -0,0 +1,236 @@
+package matching
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/22smeargle/winkr-backend/internal/application/services"
+	"github.com/22smeargle/winkr-backend/internal/domain/entities"
+	"github.com/22smeargle/winkr-backend/internal/domain/repositories"
+	"github.com/22smeargle/winkr-backend/pkg/config"
+)
+
+// memorySwipeService keeps swipes in memory and counts them against a daily quota
+type memorySwipeService struct {
+	swipes    []*entities.Swipe
+	quota     services.SwipeQuota
+	createErr error             // Returned instead of storing a super like
+	recorded  []*entities.Swipe // Swipes stored elsewhere whose side effects were recorded
+}
+
+func (s *memorySwipeService) HasSwiped(ctx context.Context, swiperID, swipedID uuid.UUID) (bool, error) {
+	return s.findSwipe(swiperID, swipedID) != nil, nil
+}
+
+func (s *memorySwipeService) CreateSwipe(ctx context.Context, swipe *entities.Swipe) error {
+	s.swipes = append(s.swipes, swipe)
+	s.quota.RemainingSwipes--
+	return nil
+}
+
+func (s *memorySwipeService) CreateSuperLike(ctx context.Context, swipe *entities.Swipe) error {
+	if s.createErr != nil {
+		return s.createErr
+	}
+	s.swipes = append(s.swipes, swipe)
+	return nil
+}
+
+func (s *memorySwipeService) RecordStoredSwipes(ctx context.Context, swipes []*entities.Swipe) {
+	s.recorded = append(s.recorded, swipes...)
+}
+
+func (s *memorySwipeService) GetSwipeQuota(ctx context.Context, userID uuid.UUID) (*services.SwipeQuota, error) {
+	quota := s.quota
+	return &quota, nil
+}
+
+func (s *memorySwipeService) findSwipe(swiperID, swipedID uuid.UUID) *entities.Swipe {
+	for _, swipe := range s.swipes {
+		if swipe.SwiperID == swiperID && swipe.SwipedID == swipedID {
+			return swipe
+		}
+	}
+	return nil
+}
+
+// memoryMatchService matches users who liked each other
+type memoryMatchService struct {
+	swipes  *memorySwipeService
+	matches []*entities.Match
+}
+
+func (s *memoryMatchService) CheckForMatch(ctx context.Context, user1ID, user2ID uuid.UUID) (bool, *entities.Match, error) {
+	like := s.swipes.findSwipe(user2ID, user1ID)
+	return like != nil && like.IsLike, nil, nil
+}
+
+func (s *memoryMatchService) CreateMatch(ctx context.Context, match *entities.Match) error {
+	match.ID = uuid.New()
+	s.matches = append(s.matches, match)
+	return nil
+}
+
+// recordingMatchPublisher records published match events, or fails every publish with err
+type recordingMatchPublisher struct {
+	eventTypes []string
+	events     []*MatchCreatedEvent
+	err        error
+}
+
+func (p *recordingMatchPublisher) Publish(ctx context.Context, eventType string, data interface{}) error {
+	if p.err != nil {
+		return p.err
+	}
+	p.eventTypes = append(p.eventTypes, eventType)
+	p.events = append(p.events, data.(*MatchCreatedEvent))
+	return nil
+}
+
+// premiumSubscriptionRepository gives every user a premium subscription
+type premiumSubscriptionRepository struct {
+	repositories.SubscriptionRepository
+}
+
+func (r *premiumSubscriptionRepository) GetActiveSubscription(ctx context.Context, userID uuid.UUID) (*entities.Subscription, error) {
+	return &entities.Subscription{UserID: userID, PlanType: "premium"}, nil
+}
+
+// memorySuperLikeQuota counts super likes in memory and records the TTL each counter started with
+type memorySuperLikeQuota struct {
+	counts map[string]int64
+	ttls   map[string]time.Duration
+}
+
+func newMemorySuperLikeQuota() *memorySuperLikeQuota {
+	return &memorySuperLikeQuota{counts: make(map[string]int64), ttls: make(map[string]time.Duration)}
+}
+
+func (q *memorySuperLikeQuota) IncrementSuperLikeCount(ctx context.Context, key string, ttl time.Duration) (int64, error) {
+	q.counts[key]++
+	if q.counts[key] == 1 {
+		q.ttls[key] = ttl
+	}
+	return q.counts[key], nil
+}
+
+func (q *memorySuperLikeQuota) DecrementSuperLikeCount(ctx context.Context, key string) error {
+	q.counts[key]--
+	return nil
+}
+
+type swipeFixture struct {
+	users         *undoUserRepository
+	swipes        *memorySwipeService
+	matches       *memoryMatchService
+	subscriptions repositories.SubscriptionRepository
+	superLikes    *memorySuperLikeQuota
+	publisher     *recordingMatchPublisher
+	user          *entities.User
+	target        *entities.User
+}
+
+func newSwipeFixture() *swipeFixture {
+	user := &entities.User{ID: uuid.New(), FirstName: "Ana", IsPremium: true}
+	target := &entities.User{ID: uuid.New(), FirstName: "Ben", IsVerified: true}
+	swipes := &memorySwipeService{quota: services.SwipeQuota{RemainingSwipes: 10, RemainingSuperLikes: 2}}
+	return &swipeFixture{
+		users:         &undoUserRepository{users: map[uuid.UUID]*entities.User{user.ID: user, target.ID: target}},
+		swipes:        swipes,
+		matches:       &memoryMatchService{swipes: swipes},
+		subscriptions: &premiumSubscriptionRepository{},
+		superLikes:    newMemorySuperLikeQuota(),
+		publisher:     &recordingMatchPublisher{},
+		user:          user,
+		target:        target,
+	}
+}
+
+// targetLikesUser records the target's earlier like of the user, so liking them back makes a match
+func (f *swipeFixture) targetLikesUser() {
+	f.swipes.swipes = append(f.swipes.swipes, &entities.Swipe{SwiperID: f.target.ID, SwipedID: f.user.ID, IsLike: true})
+}
+
+func (f *swipeFixture) like() (*LikeUserResponse, error) {
+	useCase := NewLikeUserUseCase(f.users, nil, f.swipes, f.matches, &noopCacheService{}, f.publisher)
+	return useCase.Execute(context.Background(), &LikeUserRequest{SwiperID: f.user.ID, SwipedID: f.target.ID})
+}
+
+// superLikeUseCase returns a super like use case allowing two super likes a day
+func (f *swipeFixture) superLikeUseCase() *SuperLikeUserUseCase {
+	return NewSuperLikeUserUseCase(f.users, nil, f.subscriptions, f.swipes, f.matches, &noopCacheService{}, f.superLikes, &config.RateLimitConfig{SuperLikesPerDay: 2}, f.publisher)
+}
+
+func (f *swipeFixture) superLike() (*SuperLikeUserResponse, error) {
+	return f.superLikeUseCase().Execute(context.Background(), &SuperLikeUserRequest{SwiperID: f.user.ID, SwipedID: f.target.ID})
+}
+
+func TestLikeUserUseCase_SwipeResult(t *testing.T) {
+	t.Run("no match", func(t *testing.T) {
+		f := newSwipeFixture()
+
+		resp, err := f.like()
+
+		require.NoError(t, err)
+		assert.False(t, resp.IsMatch)
+		assert.Nil(t, resp.MatchID)
+		assert.Nil(t, resp.MatchedUser)
+		assert.False(t, resp.SuperLikeConsumed)
+		assert.Equal(t, &services.SwipeQuota{RemainingSwipes: 9, RemainingSuperLikes: 2}, resp.Quota)
+	})
+
+	t.Run("match", func(t *testing.T) {
+		f := newSwipeFixture()
+		f.targetLikesUser()
+
+		resp, err := f.like()
+
+		require.NoError(t, err)
+		assert.True(t, resp.IsMatch)
+		require.Len(t, f.matches.matches, 1)
+		require.NotNil(t, resp.MatchID)
+		assert.Equal(t, f.matches.matches[0].ID, *resp.MatchID)
+		assert.Equal(t, resp.Match.ID, *resp.MatchID)
+		require.NotNil(t, resp.MatchedUser)
+		assert.Equal(t, f.target.ID, resp.MatchedUser.ID)
+		assert.Equal(t, "Ben", resp.MatchedUser.FirstName)
+		assert.True(t, resp.MatchedUser.IsVerified)
+		assert.False(t, resp.SuperLikeConsumed)
+		assert.Equal(t, 9, resp.Quota.RemainingSwipes)
+	})
+}
+
+func TestSuperLikeUserUseCase_SwipeResult(t *testing.T) {
+	f := newSwipeFixture()
+	f.targetLikesUser()
+
+	resp, err := f.superLike()
+
+	require.NoError(t, err)
+	assert.True(t, resp.IsMatch)
+	assert.True(t, resp.SuperLikeConsumed)
+	require.NotNil(t, resp.MatchID)
+	assert.Equal(t, f.target.ID, resp.MatchedUser.ID)
+	assert.Equal(t, &services.SwipeQuota{RemainingSwipes: 10, RemainingSuperLikes: 1}, resp.Quota)
+	require.Len(t, f.publisher.events, 1)
+	assert.Equal(t, *resp.MatchID, f.publisher.events[0].MatchID)
+}
+
+func TestDislikeUserUseCase_SwipeResult(t *testing.T) {
+	f := newSwipeFixture()
+	f.targetLikesUser()
+	useCase := NewDislikeUserUseCase(f.users, f.swipes, &noopCacheService{})
+
+	resp, err := useCase.Execute(context.Background(), &DislikeUserRequest{SwiperID: f.user.ID, SwipedID: f.target.ID})
+
+	require.NoError(t, err)
+	assert.True(t, resp.Success)
+	assert.Nil(t, resp.MatchID, "a dislike never makes a match")
+	assert.Nil(t, resp.MatchedUser)
+	assert.False(t, resp.SuperLikeConsumed)
+	assert.Equal(t, &services.SwipeQuota{RemainingSwipes: 9, RemainingSuperLikes: 2}, resp.Quota)
+}
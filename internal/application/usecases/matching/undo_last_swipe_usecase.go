@@ -0,0 +1,177 @@
+package matching
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	"github.com/google/uuid"
+	"github.com/22smeargle/winkr-backend/internal/application/dto"
+	"github.com/22smeargle/winkr-backend/internal/application/services"
+	"github.com/22smeargle/winkr-backend/internal/domain/entities"
+	"github.com/22smeargle/winkr-backend/internal/domain/repositories"
+	"github.com/22smeargle/winkr-backend/pkg/errors"
+	"github.com/22smeargle/winkr-backend/pkg/logger"
+)
+
+// UndoLastSwipeUseCase handles taking back the most recent swipe (premium feature)
+type UndoLastSwipeUseCase struct {
+	userRepo     repositories.UserRepository
+	matchRepo    repositories.MatchRepository
+	photoRepo    repositories.PhotoRepository
+	rateLimiter  services.RateLimiter
+	cacheService services.CacheService
+}
+
+// NewUndoLastSwipeUseCase creates a new UndoLastSwipeUseCase
+func NewUndoLastSwipeUseCase(
+	userRepo repositories.UserRepository,
+	matchRepo repositories.MatchRepository,
+	photoRepo repositories.PhotoRepository,
+	rateLimiter services.RateLimiter,
+	cacheService services.CacheService,
+) *UndoLastSwipeUseCase {
+	return &UndoLastSwipeUseCase{
+		userRepo:     userRepo,
+		matchRepo:    matchRepo,
+		photoRepo:    photoRepo,
+		rateLimiter:  rateLimiter,
+		cacheService: cacheService,
+	}
+}
+
+// UndoLastSwipeRequest represents a request to undo the last swipe
+type UndoLastSwipeRequest struct {
+	UserID uuid.UUID `json:"user_id" validate:"required"`
+}
+
+// UndoLastSwipeResponse represents the response from undoing the last swipe
+type UndoLastSwipeResponse struct {
+	User         *dto.DiscoveryUser `json:"user"`          // The restored card
+	WasLike      bool               `json:"was_like"`      // Whether the undone swipe was a like
+	MatchRemoved bool               `json:"match_removed"` // Whether the swipe had made a match, which is now deactivated
+}
+
+// Execute deletes the user's most recent swipe, deactivates the match it made if any, and returns
+// the swiped user so the client can show their card again
+func (uc *UndoLastSwipeUseCase) Execute(ctx context.Context, req *UndoLastSwipeRequest) (*UndoLastSwipeResponse, error) {
+	// Validate request
+	if err := req.Validate(); err != nil {
+		return nil, fmt.Errorf("invalid request: %w", err)
+	}
+
+	user, err := uc.userRepo.GetByID(ctx, req.UserID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get user: %w", err)
+	}
+
+	// Undo requires premium, like super likes
+	if !user.IsPremium {
+		return nil, errors.NewForbiddenError("Undoing a swipe requires a premium subscription")
+	}
+
+	swipe, err := uc.matchRepo.GetLastSwipe(ctx, req.UserID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get last swipe: %w", err)
+	}
+
+	if swipe == nil {
+		return nil, errors.NewConflictError("There is no swipe to undo")
+	}
+
+	// Check daily undo limit, only once there is something to undo
+	withinLimit, err := uc.rateLimiter.AllowUndo(ctx, req.UserID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to check undo limit: %w", err)
+	}
+
+	if !withinLimit {
+		return nil, errors.NewAppError(http.StatusTooManyRequests, "Daily undo limit exceeded", "")
+	}
+
+	swiped, err := uc.userRepo.GetByID(ctx, swipe.SwipedID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get swiped user: %w", err)
+	}
+
+	if err := uc.matchRepo.DeleteSwipe(ctx, swipe.SwiperID, swipe.SwipedID); err != nil {
+		return nil, fmt.Errorf("failed to delete swipe: %w", err)
+	}
+
+	response := &UndoLastSwipeResponse{
+		WasLike: swipe.IsLike,
+	}
+
+	// Only a like can have made a match
+	if swipe.IsLike {
+		matchRemoved, err := uc.deactivateMatch(ctx, swipe)
+		if err != nil {
+			return nil, err
+		}
+		response.MatchRemoved = matchRemoved
+	}
+
+	uc.invalidateCaches(ctx, swipe)
+
+	// Users without photos or preferences are still shown, as in discovery
+	photos, err := uc.photoRepo.GetUserPhotos(ctx, swiped.ID, false)
+	if err != nil {
+		photos = nil
+	}
+	preferences, err := uc.userRepo.GetPreferences(ctx, swiped.ID)
+	if err != nil {
+		preferences = nil
+	}
+	response.User = dto.NewDiscoveryUser(swiped, photos, distanceKm(user, swiped), preferences)
+
+	logger.Info("Swipe undone",
+		"user_id", req.UserID,
+		"swiped_id", swipe.SwipedID,
+		"was_like", swipe.IsLike,
+		"match_removed", response.MatchRemoved,
+	)
+
+	return response, nil
+}
+
+// deactivateMatch deactivates the active match between the swipe's users, returning true if there was one
+func (uc *UndoLastSwipeUseCase) deactivateMatch(ctx context.Context, swipe *entities.Swipe) (bool, error) {
+	exists, err := uc.matchRepo.MatchExists(ctx, swipe.SwiperID, swipe.SwipedID)
+	if err != nil {
+		return false, fmt.Errorf("failed to check match: %w", err)
+	}
+	if !exists {
+		return false, nil
+	}
+
+	match, err := uc.matchRepo.GetMatchByUsers(ctx, swipe.SwiperID, swipe.SwipedID)
+	if err != nil {
+		return false, fmt.Errorf("failed to get match: %w", err)
+	}
+	if !match.IsActive {
+		return false, nil
+	}
+
+	match.IsActive = false
+	if err := uc.matchRepo.UpdateMatch(ctx, match); err != nil {
+		return false, fmt.Errorf("failed to deactivate match: %w", err)
+	}
+
+	return true, nil
+}
+
+// invalidateCaches drops the cached swipes and discovery results the undone swipe affected
+func (uc *UndoLastSwipeUseCase) invalidateCaches(ctx context.Context, swipe *entities.Swipe) {
+	uc.cacheService.Delete(ctx, fmt.Sprintf("swiped_users:%s", swipe.SwiperID.String()))
+	uc.cacheService.Delete(ctx, fmt.Sprintf("swipe_stats:%s", swipe.SwiperID.String()))
+	uc.cacheService.InvalidateUserDiscoveryCache(ctx, swipe.SwiperID)
+	uc.cacheService.InvalidateUserDiscoveryCache(ctx, swipe.SwipedID)
+}
+
+// Validate validates the request
+func (req *UndoLastSwipeRequest) Validate() error {
+	if req.UserID == uuid.Nil {
+		return fmt.Errorf("user_id is required")
+	}
+	return nil
+}
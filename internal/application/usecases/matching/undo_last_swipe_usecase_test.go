@@ -0,0 +1,205 @@
+package matching
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/22smeargle/winkr-backend/internal/application/services"
+	"github.com/22smeargle/winkr-backend/internal/domain/entities"
+	"github.com/22smeargle/winkr-backend/internal/domain/repositories"
+	"github.com/22smeargle/winkr-backend/pkg/errors"
+)
+
+// undoUserRepository serves users from memory
+type undoUserRepository struct {
+	repositories.UserRepository
+	users map[uuid.UUID]*entities.User
+}
+
+func (r *undoUserRepository) GetByID(ctx context.Context, id uuid.UUID) (*entities.User, error) {
+	return r.users[id], nil
+}
+
+func (r *undoUserRepository) GetPreferences(ctx context.Context, userID uuid.UUID) (*entities.UserPreferences, error) {
+	return nil, nil
+}
+
+// undoMatchRepository keeps swipes and matches in memory
+type undoMatchRepository struct {
+	repositories.MatchRepository
+	swipes  []*entities.Swipe
+	matches []*entities.Match
+}
+
+func (r *undoMatchRepository) GetLastSwipe(ctx context.Context, userID uuid.UUID) (*entities.Swipe, error) {
+	var last *entities.Swipe
+	for _, swipe := range r.swipes {
+		if swipe.SwiperID == userID && (last == nil || swipe.CreatedAt.After(last.CreatedAt)) {
+			last = swipe
+		}
+	}
+	return last, nil
+}
+
+func (r *undoMatchRepository) DeleteSwipe(ctx context.Context, swiperID, swipedID uuid.UUID) error {
+	kept := r.swipes[:0]
+	for _, swipe := range r.swipes {
+		if swipe.SwiperID != swiperID || swipe.SwipedID != swipedID {
+			kept = append(kept, swipe)
+		}
+	}
+	r.swipes = kept
+	return nil
+}
+
+func (r *undoMatchRepository) findMatch(user1ID, user2ID uuid.UUID) *entities.Match {
+	for _, match := range r.matches {
+		if (match.User1ID == user1ID && match.User2ID == user2ID) || (match.User1ID == user2ID && match.User2ID == user1ID) {
+			return match
+		}
+	}
+	return nil
+}
+
+func (r *undoMatchRepository) MatchExists(ctx context.Context, user1ID, user2ID uuid.UUID) (bool, error) {
+	return r.findMatch(user1ID, user2ID) != nil, nil
+}
+
+func (r *undoMatchRepository) GetMatchByUsers(ctx context.Context, user1ID, user2ID uuid.UUID) (*entities.Match, error) {
+	return r.findMatch(user1ID, user2ID), nil
+}
+
+func (r *undoMatchRepository) UpdateMatch(ctx context.Context, match *entities.Match) error {
+	*r.findMatch(match.User1ID, match.User2ID) = *match
+	return nil
+}
+
+// undoPhotoRepository has no photos
+type undoPhotoRepository struct {
+	repositories.PhotoRepository
+}
+
+func (r *undoPhotoRepository) GetUserPhotos(ctx context.Context, userID uuid.UUID, includeDeleted bool) ([]*entities.Photo, error) {
+	return nil, nil
+}
+
+// countingUndoLimiter allows a fixed number of undos
+type countingUndoLimiter struct {
+	services.RateLimiter
+	remaining int
+}
+
+func (l *countingUndoLimiter) AllowUndo(ctx context.Context, userID uuid.UUID) (bool, error) {
+	if l.remaining == 0 {
+		return false, nil
+	}
+	l.remaining--
+	return true, nil
+}
+
+// noopCacheService ignores invalidations
+type noopCacheService struct {
+	services.CacheService
+}
+
+func (c *noopCacheService) Delete(ctx context.Context, key string) error { return nil }
+
+func (c *noopCacheService) InvalidateUserDiscoveryCache(ctx context.Context, userID uuid.UUID) error {
+	return nil
+}
+
+type undoFixture struct {
+	useCase *UndoLastSwipeUseCase
+	matches *undoMatchRepository
+	limiter *countingUndoLimiter
+	user    *entities.User
+	target  *entities.User
+}
+
+func newUndoFixture(isPremium bool) *undoFixture {
+	user := &entities.User{ID: uuid.New(), FirstName: "Ana", IsPremium: isPremium}
+	target := &entities.User{ID: uuid.New(), FirstName: "Ben"}
+	f := &undoFixture{
+		matches: &undoMatchRepository{},
+		limiter: &countingUndoLimiter{remaining: 1},
+		user:    user,
+		target:  target,
+	}
+	users := &undoUserRepository{users: map[uuid.UUID]*entities.User{user.ID: user, target.ID: target}}
+	f.useCase = NewUndoLastSwipeUseCase(users, f.matches, &undoPhotoRepository{}, f.limiter, &noopCacheService{})
+	return f
+}
+
+func (f *undoFixture) undo() (*UndoLastSwipeResponse, error) {
+	return f.useCase.Execute(context.Background(), &UndoLastSwipeRequest{UserID: f.user.ID})
+}
+
+func requireAppError(t *testing.T, err error, code int) {
+	t.Helper()
+	appErr, ok := err.(*errors.AppError)
+	require.True(t, ok, "expected an AppError, got %v", err)
+	assert.Equal(t, code, appErr.Code)
+}
+
+func TestUndoLastSwipeUseCase_RestoresDislikedUser(t *testing.T) {
+	f := newUndoFixture(true)
+	f.matches.swipes = []*entities.Swipe{{SwiperID: f.user.ID, SwipedID: f.target.ID, IsLike: false}}
+
+	resp, err := f.undo()
+
+	require.NoError(t, err)
+	require.NotNil(t, resp.User)
+	assert.Equal(t, f.target.ID, resp.User.ID)
+	assert.False(t, resp.WasLike)
+	assert.False(t, resp.MatchRemoved)
+	assert.Empty(t, f.matches.swipes)
+}
+
+func TestUndoLastSwipeUseCase_DeactivatesMatch(t *testing.T) {
+	f := newUndoFixture(true)
+	f.matches.swipes = []*entities.Swipe{{SwiperID: f.user.ID, SwipedID: f.target.ID, IsLike: true}}
+	f.matches.matches = []*entities.Match{{ID: uuid.New(), User1ID: f.target.ID, User2ID: f.user.ID, IsActive: true}}
+
+	resp, err := f.undo()
+
+	require.NoError(t, err)
+	assert.True(t, resp.WasLike)
+	assert.True(t, resp.MatchRemoved)
+	assert.False(t, f.matches.matches[0].IsActive)
+}
+
+func TestUndoLastSwipeUseCase_Rejections(t *testing.T) {
+	t.Run("free users cannot undo", func(t *testing.T) {
+		f := newUndoFixture(false)
+		f.matches.swipes = []*entities.Swipe{{SwiperID: f.user.ID, SwipedID: f.target.ID}}
+
+		_, err := f.undo()
+
+		requireAppError(t, err, http.StatusForbidden)
+		assert.Len(t, f.matches.swipes, 1)
+	})
+
+	t.Run("nothing to undo", func(t *testing.T) {
+		f := newUndoFixture(true)
+
+		_, err := f.undo()
+
+		requireAppError(t, err, http.StatusConflict)
+		assert.Equal(t, 1, f.limiter.remaining, "a failed undo does not use up the daily limit")
+	})
+
+	t.Run("daily limit reached", func(t *testing.T) {
+		f := newUndoFixture(true)
+		f.limiter.remaining = 0
+		f.matches.swipes = []*entities.Swipe{{SwiperID: f.user.ID, SwipedID: f.target.ID}}
+
+		_, err := f.undo()
+
+		requireAppError(t, err, http.StatusTooManyRequests)
+		assert.Len(t, f.matches.swipes, 1)
+	})
+}
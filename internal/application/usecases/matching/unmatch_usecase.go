@@ -0,0 +1,93 @@
+package matching
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/22smeargle/winkr-backend/internal/domain/entities"
+	"github.com/22smeargle/winkr-backend/internal/domain/repositories"
+	"github.com/22smeargle/winkr-backend/pkg/errors"
+	"github.com/22smeargle/winkr-backend/pkg/logger"
+)
+
+// UnmatchConversationStore defines the conversation operations needed to clean up an unmatched chat
+type UnmatchConversationStore interface {
+	GetConversationByMatchID(ctx context.Context, matchID uuid.UUID) (*entities.Conversation, error)
+	UpdateConversation(ctx context.Context, conversation *entities.Conversation) error
+	ArchiveConversation(ctx context.Context, conversationID, userID uuid.UUID) error
+}
+
+// UnmatchUseCase handles ending a match and archiving its conversation
+type UnmatchUseCase struct {
+	matchRepo         repositories.MatchRepository
+	conversationStore UnmatchConversationStore
+}
+
+// NewUnmatchUseCase creates a new UnmatchUseCase
+func NewUnmatchUseCase(matchRepo repositories.MatchRepository, conversationStore UnmatchConversationStore) *UnmatchUseCase {
+	return &UnmatchUseCase{
+		matchRepo:         matchRepo,
+		conversationStore: conversationStore,
+	}
+}
+
+// UnmatchRequest represents a request to end a match
+type UnmatchRequest struct {
+	UserID  uuid.UUID `json:"user_id" validate:"required"`
+	MatchID uuid.UUID `json:"match_id" validate:"required"`
+}
+
+// UnmatchResponse represents the response after ending a match
+type UnmatchResponse struct {
+	MatchID        uuid.UUID  `json:"match_id"`
+	ConversationID *uuid.UUID `json:"conversation_id,omitempty"`
+}
+
+// Execute deactivates a match the requester is part of, then closes its conversation and archives it
+// for both participants. Unmatching an ended match succeeds, and finishes any cleanup a previous
+// attempt left undone.
+func (uc *UnmatchUseCase) Execute(ctx context.Context, req *UnmatchRequest) (*UnmatchResponse, error) {
+	match, err := uc.matchRepo.GetMatchByID(ctx, req.MatchID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get match: %w", err)
+	}
+	if match == nil {
+		return nil, errors.NewNotFoundError("Match")
+	}
+	if !match.IsUserInMatch(req.UserID) {
+		return nil, errors.NewForbiddenError("You are not part of this match")
+	}
+
+	if match.IsActive {
+		if err := uc.matchRepo.DeactivateMatch(ctx, match.ID); err != nil {
+			return nil, fmt.Errorf("failed to deactivate match: %w", err)
+		}
+	}
+
+	response := &UnmatchResponse{MatchID: match.ID}
+
+	// Matches that never started a conversation have nothing to clean up
+	conversation, err := uc.conversationStore.GetConversationByMatchID(ctx, match.ID)
+	if err != nil || conversation == nil {
+		logger.Info("Match unmatched", "match_id", match.ID, "user_id", req.UserID)
+		return response, nil
+	}
+
+	if !conversation.IsClosed() {
+		conversation.Close()
+		if err := uc.conversationStore.UpdateConversation(ctx, conversation); err != nil {
+			return nil, fmt.Errorf("failed to close conversation: %w", err)
+		}
+	}
+
+	for _, userID := range []uuid.UUID{match.User1ID, match.User2ID} {
+		if err := uc.conversationStore.ArchiveConversation(ctx, conversation.ID, userID); err != nil {
+			return nil, fmt.Errorf("failed to archive conversation: %w", err)
+		}
+	}
+
+	response.ConversationID = &conversation.ID
+	logger.Info("Match unmatched", "match_id", match.ID, "user_id", req.UserID, "conversation_id", conversation.ID)
+	return response, nil
+}
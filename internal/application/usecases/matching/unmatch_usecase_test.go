@@ -0,0 +1,147 @@
+package matching
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/22smeargle/winkr-backend/internal/domain/entities"
+	"github.com/22smeargle/winkr-backend/internal/domain/repositories"
+)
+
+// unmatchMatchRepository serves a single match and counts deactivations
+type unmatchMatchRepository struct {
+	repositories.MatchRepository
+	match       *entities.Match
+	deactivated int
+}
+
+func (r *unmatchMatchRepository) GetMatchByID(ctx context.Context, id uuid.UUID) (*entities.Match, error) {
+	if r.match.ID != id {
+		return nil, nil
+	}
+	return r.match, nil
+}
+
+func (r *unmatchMatchRepository) DeactivateMatch(ctx context.Context, matchID uuid.UUID) error {
+	r.deactivated++
+	r.match.IsActive = false
+	return nil
+}
+
+// unmatchConversationStore keeps the match's conversation and who archived it in memory
+type unmatchConversationStore struct {
+	conversation *entities.Conversation
+	archivedBy   map[uuid.UUID]bool
+}
+
+func (s *unmatchConversationStore) GetConversationByMatchID(ctx context.Context, matchID uuid.UUID) (*entities.Conversation, error) {
+	if s.conversation == nil || s.conversation.MatchID != matchID {
+		return nil, nil
+	}
+	return s.conversation, nil
+}
+
+func (s *unmatchConversationStore) UpdateConversation(ctx context.Context, conversation *entities.Conversation) error {
+	s.conversation = conversation
+	return nil
+}
+
+func (s *unmatchConversationStore) ArchiveConversation(ctx context.Context, conversationID, userID uuid.UUID) error {
+	s.archivedBy[userID] = true
+	return nil
+}
+
+type unmatchFixture struct {
+	useCase       *UnmatchUseCase
+	matches       *unmatchMatchRepository
+	conversations *unmatchConversationStore
+	match         *entities.Match
+}
+
+func newUnmatchFixture() *unmatchFixture {
+	match := &entities.Match{ID: uuid.New(), User1ID: uuid.New(), User2ID: uuid.New(), IsActive: true}
+	matches := &unmatchMatchRepository{match: match}
+	conversations := &unmatchConversationStore{
+		conversation: &entities.Conversation{ID: uuid.New(), MatchID: match.ID},
+		archivedBy:   make(map[uuid.UUID]bool),
+	}
+
+	return &unmatchFixture{
+		useCase:       NewUnmatchUseCase(matches, conversations),
+		matches:       matches,
+		conversations: conversations,
+		match:         match,
+	}
+}
+
+func (f *unmatchFixture) unmatch(userID uuid.UUID) (*UnmatchResponse, error) {
+	return f.useCase.Execute(context.Background(), &UnmatchRequest{UserID: userID, MatchID: f.match.ID})
+}
+
+func TestUnmatchUseCase_DeactivatesMatchAndArchivesConversation(t *testing.T) {
+	f := newUnmatchFixture()
+
+	response, err := f.unmatch(f.match.User2ID)
+
+	require.NoError(t, err)
+	assert.Equal(t, f.match.ID, response.MatchID)
+	require.NotNil(t, response.ConversationID)
+	assert.Equal(t, f.conversations.conversation.ID, *response.ConversationID)
+
+	assert.False(t, f.match.IsActive)
+	assert.True(t, f.conversations.conversation.IsClosed())
+	assert.True(t, f.conversations.archivedBy[f.match.User1ID])
+	assert.True(t, f.conversations.archivedBy[f.match.User2ID])
+}
+
+func TestUnmatchUseCase_IsIdempotent(t *testing.T) {
+	f := newUnmatchFixture()
+
+	_, err := f.unmatch(f.match.User1ID)
+	require.NoError(t, err)
+	closedAt := f.conversations.conversation.ClosedAt
+
+	_, err = f.unmatch(f.match.User1ID)
+	require.NoError(t, err)
+	_, err = f.unmatch(f.match.User2ID)
+	require.NoError(t, err)
+
+	assert.Equal(t, 1, f.matches.deactivated)
+	assert.Equal(t, closedAt, f.conversations.conversation.ClosedAt)
+}
+
+func TestUnmatchUseCase_WithoutConversation(t *testing.T) {
+	f := newUnmatchFixture()
+	f.conversations.conversation = nil
+
+	response, err := f.unmatch(f.match.User1ID)
+
+	require.NoError(t, err)
+	assert.Nil(t, response.ConversationID)
+	assert.False(t, f.match.IsActive)
+	assert.Empty(t, f.conversations.archivedBy)
+}
+
+func TestUnmatchUseCase_RejectsUsersOutsideTheMatch(t *testing.T) {
+	f := newUnmatchFixture()
+
+	_, err := f.unmatch(uuid.New())
+
+	requireAppError(t, err, http.StatusForbidden)
+	assert.True(t, f.match.IsActive)
+	assert.Zero(t, f.matches.deactivated)
+	assert.False(t, f.conversations.conversation.IsClosed())
+	assert.Empty(t, f.conversations.archivedBy)
+}
+
+func TestUnmatchUseCase_UnknownMatch(t *testing.T) {
+	f := newUnmatchFixture()
+
+	_, err := f.useCase.Execute(context.Background(), &UnmatchRequest{UserID: f.match.User1ID, MatchID: uuid.New()})
+
+	requireAppError(t, err, http.StatusNotFound)
+}
@@ -3,11 +3,14 @@ package moderation
 import (
 	"context"
 	"fmt"
+	"net/http"
 	"time"
 
 	"github.com/google/uuid"
 	"github.com/22smeargle/winkr-backend/internal/domain/entities"
 	"github.com/22smeargle/winkr-backend/internal/domain/repositories"
+	"github.com/22smeargle/winkr-backend/pkg/config"
+	"github.com/22smeargle/winkr-backend/pkg/errors"
 	"github.com/22smeargle/winkr-backend/pkg/logger"
 	"github.com/22smeargle/winkr-backend/pkg/validator"
 )
@@ -57,7 +60,8 @@ type AppealRequest struct {
 	Reason          string                 `json:"reason"`
 	Description     string                 `json:"description"`
 	Evidence        map[string]interface{} `json:"evidence,omitempty"`
-	Status          string                 `json:"status"` // "pending", "reviewed", "approved", "rejected"
+	Status          string                 `json:"status"` // "pending", "approved", "rejected"
+	AutoReviewed    bool                   `json:"auto_reviewed"` // Approved by the moderation model rather than a moderator
 	ReviewedBy      *uuid.UUID             `json:"reviewed_by,omitempty"`
 	ReviewedAt      *time.Time            `json:"reviewed_at,omitempty"`
 	ReviewNotes     *string                `json:"review_notes,omitempty"`
@@ -73,6 +77,16 @@ type BanUserUseCase struct {
 	appealRepo        AppealRepository
 	validator          validator.Validator
 	notificationService NotificationService
+	banCascader        UserBanCascader
+	appealConfig       *config.AppealConfig
+	appealAssessor     AppealAssessor
+	appealOutcomes     AppealOutcomeRecorder
+	now                func() time.Time
+}
+
+// UserBanCascader ends the matches and conversations of a banned user
+type UserBanCascader interface {
+	OnUserBanned(ctx context.Context, userID uuid.UUID) error
 }
 
 // BanRepository defines interface for ban operations
@@ -94,6 +108,22 @@ type AppealRepository interface {
 	GetByUserID(ctx context.Context, userID uuid.UUID, limit, offset int) ([]*AppealRequest, error)
 	Update(ctx context.Context, appeal *AppealRequest) error
 	GetPendingAppeals(ctx context.Context, limit, offset int) ([]*AppealRequest, error)
+	CountByUserID(ctx context.Context, userID uuid.UUID) (int64, error)
+	// Resolve saves the decision on an appeal that is still pending and returns false if it no
+	// longer is. With liftBan set, it deactivates the appealed ban and restores the user's account
+	// (not banned, active) in the same transaction.
+	Resolve(ctx context.Context, appeal *AppealRequest, liftBan bool) (bool, error)
+}
+
+// AppealAssessor estimates how confident the moderation model is that an appeal should be approved,
+// from 0 to 1
+type AppealAssessor interface {
+	AssessAppeal(ctx context.Context, appeal *AppealRequest, ban *BanRecord) (float64, error)
+}
+
+// AppealOutcomeRecorder records decided appeals for analytics
+type AppealOutcomeRecorder interface {
+	RecordAppealOutcome(ctx context.Context, outcome *entities.AppealOutcome) error
 }
 
 // NewBanUserUseCase creates a new BanUserUseCase
@@ -104,6 +134,10 @@ func NewBanUserUseCase(
 	appealRepo AppealRepository,
 	validator validator.Validator,
 	notificationService NotificationService,
+	banCascader UserBanCascader,
+	appealConfig *config.AppealConfig,
+	appealAssessor AppealAssessor,
+	appealOutcomes AppealOutcomeRecorder,
 ) *BanUserUseCase {
 	return &BanUserUseCase{
 		userRepo:           userRepo,
@@ -112,6 +146,11 @@ func NewBanUserUseCase(
 		appealRepo:        appealRepo,
 		validator:          validator,
 		notificationService: notificationService,
+		banCascader:        banCascader,
+		appealConfig:       appealConfig,
+		appealAssessor:     appealAssessor,
+		appealOutcomes:     appealOutcomes,
+		now:                time.Now,
 	}
 }
 
@@ -266,6 +305,13 @@ func (uc *BanUserUseCase) applyBanToUser(ctx context.Context, userID uuid.UUID)
 		return fmt.Errorf("failed to update user: %w", err)
 	}
 	
+	// End the banned user's matches and conversations
+	if uc.banCascader != nil {
+		if err := uc.banCascader.OnUserBanned(ctx, userID); err != nil {
+			return fmt.Errorf("failed to end matches of banned user: %w", err)
+		}
+	}
+	
 	return nil
 }
 
@@ -325,24 +371,29 @@ func (uc *BanUserUseCase) sendNotifications(ctx context.Context, req BanUserRequ
 	return nil
 }
 
-// SubmitAppeal submits an appeal for a ban
+// SubmitAppeal submits an appeal for a ban. Appeals must be filed within the configured appeal
+// window after the ban, and users can file at most MaxAppealsPerUser appeals. With auto review
+// enabled, appeals the moderation model is confident about are approved right away; all others
+// wait for a moderator.
 func (uc *BanUserUseCase) SubmitAppeal(ctx context.Context, appeal *AppealRequest) error {
-	logger.Info("Submitting ban appeal", "appeal_id", appeal.ID, "user_id", appeal.UserID, "ban_id", appeal.OriginalBanID)
+	logger.Info("Submitting ban appeal", "user_id", appeal.UserID, "ban_id", appeal.OriginalBanID)
 	
-	// Validate appeal
-	if err := uc.validateAppeal(ctx, appeal); err != nil {
-		return fmt.Errorf("appeal validation failed: %w", err)
+	if !uc.appealConfig.Enabled {
+		return errors.NewForbiddenError("Appeals are disabled")
 	}
 	
-	// Check if user has an active ban
-	activeBan, err := uc.banRepo.GetActiveBan(ctx, appeal.UserID)
+	// Validate appeal
+	ban, err := uc.validateAppeal(ctx, appeal)
 	if err != nil {
-		return fmt.Errorf("failed to get active ban: %w", err)
-	}
-	if activeBan == nil {
-		return fmt.Errorf("user does not have an active ban to appeal")
+		return fmt.Errorf("appeal validation failed: %w", err)
 	}
 	
+	now := uc.now()
+	appeal.ID = uuid.New()
+	appeal.Status = "pending"
+	appeal.CreatedAt = now
+	appeal.UpdatedAt = now
+	
 	// Create appeal record
 	if err := uc.appealRepo.Create(ctx, appeal); err != nil {
 		logger.Error("Failed to create appeal", err, "appeal_id", appeal.ID)
@@ -350,46 +401,99 @@ func (uc *BanUserUseCase) SubmitAppeal(ctx context.Context, appeal *AppealReques
 	}
 	
 	// Send notifications
-	if err := uc.sendAppealNotifications(ctx, appeal, activeBan); err != nil {
-		logger.Error("Failed to send appeal notifications", err, "appeal_id", appeal.ID)
-		// Don't fail the operation, just log the error
+	if uc.appealConfig.NotifyOnSubmit {
+		if err := uc.sendAppealNotifications(ctx, appeal, ban); err != nil {
+			logger.Error("Failed to send appeal notifications", err, "appeal_id", appeal.ID)
+			// Don't fail the operation, just log the error
+		}
+	}
+	
+	if err := uc.autoReviewAppeal(ctx, appeal, ban); err != nil {
+		logger.Error("Failed to auto-review appeal", err, "appeal_id", appeal.ID)
+		// The appeal stays pending for a moderator
 	}
 	
-	logger.Info("Ban appeal submitted successfully", "appeal_id", appeal.ID)
+	logger.Info("Ban appeal submitted successfully", "appeal_id", appeal.ID, "status", appeal.Status)
 	return nil
 }
 
-// validateAppeal validates an appeal request
-func (uc *BanUserUseCase) validateAppeal(ctx context.Context, appeal *AppealRequest) error {
+// validateAppeal validates an appeal request and returns the appealed ban
+func (uc *BanUserUseCase) validateAppeal(ctx context.Context, appeal *AppealRequest) (*BanRecord, error) {
 	// Check if user exists
 	_, err := uc.userRepo.GetByID(ctx, appeal.UserID)
 	if err != nil {
-		return fmt.Errorf("user not found: %w", err)
+		return nil, fmt.Errorf("user not found: %w", err)
 	}
 	
-	// Check if original ban exists
-	_, err = uc.banRepo.GetByID(ctx, appeal.OriginalBanID)
-	if err != nil {
-		return fmt.Errorf("original ban not found: %w", err)
+	// Users can only appeal their own active bans
+	ban, err := uc.banRepo.GetByID(ctx, appeal.OriginalBanID)
+	if err != nil || ban == nil || ban.UserID != appeal.UserID {
+		return nil, errors.NewNotFoundError("Ban")
+	}
+	if !ban.IsActive {
+		return nil, errors.NewConflictError("Ban is no longer active")
+	}
+	
+	if window := uc.appealConfig.AppealWindow; window > 0 && uc.now().After(ban.CreatedAt.Add(window)) {
+		return nil, errors.NewAppError(http.StatusForbidden, "Appeal window closed", fmt.Sprintf("bans can only be appealed within %s", window))
 	}
 	
 	// Check if user already has a pending appeal
 	pendingAppeals, err := uc.appealRepo.GetByUserID(ctx, appeal.UserID, 10, 0)
 	if err != nil {
-		return fmt.Errorf("failed to check for pending appeals: %w", err)
+		return nil, fmt.Errorf("failed to check for pending appeals: %w", err)
 	}
 	
 	for _, pendingAppeal := range pendingAppeals {
 		if pendingAppeal.Status == "pending" {
-			return fmt.Errorf("user already has a pending appeal")
+			return nil, errors.NewConflictError("User already has a pending appeal")
 		}
 	}
 	
-	return nil
+	if maxAppeals := uc.appealConfig.MaxAppealsPerUser; maxAppeals > 0 {
+		count, err := uc.appealRepo.CountByUserID(ctx, appeal.UserID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to count appeals: %w", err)
+		}
+		if count >= int64(maxAppeals) {
+			return nil, errors.NewAppError(http.StatusTooManyRequests, "Appeal limit exceeded", fmt.Sprintf("users can file at most %d appeals", maxAppeals))
+		}
+	}
+	
+	return ban, nil
+}
+
+// autoReviewAppeal approves the appeal when auto review is enabled and the moderation model's
+// confidence that it should be approved exceeds AutoReviewThreshold
+func (uc *BanUserUseCase) autoReviewAppeal(ctx context.Context, appeal *AppealRequest, ban *BanRecord) error {
+	if !uc.appealConfig.AutoReviewEnabled || uc.appealAssessor == nil {
+		return nil
+	}
+	
+	confidence, err := uc.appealAssessor.AssessAppeal(ctx, appeal, ban)
+	if err != nil {
+		return fmt.Errorf("failed to assess appeal: %w", err)
+	}
+	if confidence <= uc.appealConfig.AutoReviewThreshold {
+		return nil
+	}
+	
+	logger.Info("Auto-approving ban appeal", "appeal_id", appeal.ID, "confidence", confidence)
+	return uc.resolveAppeal(ctx, appeal, nil, true, "Approved automatically", &confidence)
 }
 
 // sendAppealNotifications sends notifications related to the appeal
 func (uc *BanUserUseCase) sendAppealNotifications(ctx context.Context, appeal *AppealRequest, ban *BanRecord) error {
+	// Confirm the appeal to the user
+	userData := map[string]interface{}{
+		"appeal_id": appeal.ID,
+		"ban_id":    ban.ID,
+	}
+	
+	if err := uc.notificationService.SendNotification(ctx, appeal.UserID, "appeal_submitted", userData); err != nil {
+		return fmt.Errorf("failed to notify user about appeal: %w", err)
+	}
+	
 	// Notify admins about the appeal
 	adminData := map[string]interface{}{
 		"appeal_id":      appeal.ID,
@@ -406,19 +510,20 @@ func (uc *BanUserUseCase) sendAppealNotifications(ctx context.Context, appeal *A
 	return nil
 }
 
-// ReviewAppeal reviews an appeal request
+// ReviewAppeal reviews an appeal request. Approving it lifts the ban and restores the user's
+// account together with the decision.
 func (uc *BanUserUseCase) ReviewAppeal(ctx context.Context, appealID, reviewerID uuid.UUID, approved bool, notes string) error {
 	logger.Info("Reviewing ban appeal", "appeal_id", appealID, "reviewer_id", reviewerID, "approved", approved)
 	
 	// Get appeal
 	appeal, err := uc.appealRepo.GetByID(ctx, appealID)
-	if err != nil {
+	if err != nil || appeal == nil {
 		logger.Error("Failed to get appeal", err, "appeal_id", appealID)
-		return fmt.Errorf("failed to get appeal: %w", err)
+		return errors.NewNotFoundError("Appeal")
 	}
 	
 	if appeal.Status != "pending" {
-		return fmt.Errorf("appeal is not pending review")
+		return errors.NewConflictError("Appeal already reviewed")
 	}
 	
 	// Get reviewer
@@ -428,93 +533,90 @@ func (uc *BanUserUseCase) ReviewAppeal(ctx context.Context, appealID, reviewerID
 	}
 	
 	if !reviewer.CanBanUsers() {
-		return fmt.Errorf("reviewer does not have permission to review appeals")
+		return errors.NewForbiddenError("Reviewer does not have permission to review appeals")
 	}
 	
-	// Update appeal status
-	now := time.Now()
-	appeal.Status = "reviewed"
-	appeal.ReviewedBy = &reviewerID
-	appeal.ReviewedAt = &now
-	appeal.ReviewNotes = &notes
-	
-	if approved {
-		appeal.Status = "approved"
-		// Lift the ban
-		if err := uc.liftBan(ctx, appeal.OriginalBanID, reviewerID); err != nil {
-			return fmt.Errorf("failed to lift ban: %w", err)
-		}
-	} else {
-		appeal.Status = "rejected"
-	}
-	
-	if err := uc.appealRepo.Update(ctx, appeal); err != nil {
-		return fmt.Errorf("failed to update appeal: %w", err)
-	}
-	
-	// Send notifications
-	if err := uc.sendAppealDecisionNotifications(ctx, appeal, approved, notes, reviewer); err != nil {
-		logger.Error("Failed to send appeal decision notifications", err, "appeal_id", appealID)
-		// Don't fail the operation, just log the error
+	if err := uc.resolveAppeal(ctx, appeal, reviewer, approved, notes, nil); err != nil {
+		return err
 	}
 	
 	logger.Info("Ban appeal reviewed successfully", "appeal_id", appealID, "approved", approved)
 	return nil
 }
 
-// liftBan lifts a ban
-func (uc *BanUserUseCase) liftBan(ctx context.Context, banID, reviewerID uuid.UUID) error {
-	// Get ban record
-	ban, err := uc.banRepo.GetByID(ctx, banID)
-	if err != nil {
-		return fmt.Errorf("failed to get ban: %w", err)
+// resolveAppeal records the decision on a pending appeal. reviewer is nil when the appeal was
+// auto-reviewed, with confidence holding the model's confidence.
+func (uc *BanUserUseCase) resolveAppeal(ctx context.Context, appeal *AppealRequest, reviewer *entities.AdminUser, approved bool, notes string, confidence *float64) error {
+	now := uc.now()
+	appeal.Status = "rejected"
+	if approved {
+		appeal.Status = "approved"
 	}
-	
-	if !ban.IsActive {
-		return fmt.Errorf("ban is not active")
+	appeal.AutoReviewed = reviewer == nil
+	appeal.ReviewedAt = &now
+	appeal.ReviewNotes = &notes
+	appeal.UpdatedAt = now
+	if reviewer != nil {
+		appeal.ReviewedBy = &reviewer.ID
 	}
 	
-	// Deactivate ban
-	ban.IsActive = false
-	ban.UpdatedAt = time.Now()
-	
-	if err := uc.banRepo.Update(ctx, ban); err != nil {
-		return fmt.Errorf("failed to update ban: %w", err)
+	// Approving lifts the ban in the same transaction, so the user is never left banned
+	resolved, err := uc.appealRepo.Resolve(ctx, appeal, approved)
+	if err != nil {
+		return fmt.Errorf("failed to resolve appeal: %w", err)
+	}
+	if !resolved {
+		return errors.NewConflictError("Appeal already reviewed")
 	}
 	
-	// Reactivate user
-	if err := uc.reactivateUser(ctx, ban.UserID); err != nil {
-		return fmt.Errorf("failed to reactivate user: %w", err)
+	uc.recordAppealOutcome(ctx, appeal, confidence)
+	
+	// Send notifications
+	if uc.appealConfig.NotifyOnReview {
+		if err := uc.sendAppealDecisionNotifications(ctx, appeal, approved, notes, reviewer); err != nil {
+			logger.Error("Failed to send appeal decision notifications", err, "appeal_id", appeal.ID)
+			// Don't fail the operation, just log the error
+		}
 	}
 	
 	return nil
 }
 
-// reactivateUser reactivates a banned user
-func (uc *BanUserUseCase) reactivateUser(ctx context.Context, userID uuid.UUID) error {
-	user, err := uc.userRepo.GetByID(ctx, userID)
-	if err != nil {
-		return fmt.Errorf("failed to get user: %w", err)
-	}
-	
-	user.IsBanned = false
-	user.IsActive = true
-	
-	if err := uc.userRepo.Update(ctx, user); err != nil {
-		return fmt.Errorf("failed to update user: %w", err)
+// recordAppealOutcome records the decision for analytics. Failures are logged so the decision stands.
+func (uc *BanUserUseCase) recordAppealOutcome(ctx context.Context, appeal *AppealRequest, confidence *float64) {
+	if uc.appealOutcomes == nil {
+		return
+	}
+	
+	outcome := &entities.AppealOutcome{
+		AppealID:     appeal.ID,
+		UserID:       appeal.UserID,
+		BanID:        appeal.OriginalBanID,
+		Approved:     appeal.Status == "approved",
+		AutoReviewed: appeal.AutoReviewed,
+		ReviewerID:   appeal.ReviewedBy,
+		Confidence:   confidence,
+		SubmittedAt:  appeal.CreatedAt,
+		ReviewedAt:   *appeal.ReviewedAt,
+	}
+	if err := uc.appealOutcomes.RecordAppealOutcome(ctx, outcome); err != nil {
+		logger.Error("Failed to record appeal outcome", err, "appeal_id", appeal.ID)
 	}
-	
-	return nil
 }
 
-// sendAppealDecisionNotifications sends notifications about appeal decision
+// sendAppealDecisionNotifications sends notifications about appeal decision. reviewer is nil for
+// auto-reviewed appeals.
 func (uc *BanUserUseCase) sendAppealDecisionNotifications(ctx context.Context, appeal *AppealRequest, approved bool, notes string, reviewer *entities.AdminUser) error {
+	reviewedBy := "auto_review"
+	if reviewer != nil {
+		reviewedBy = reviewer.Email
+	}
+	
 	// Notify user about the decision
 	userData := map[string]interface{}{
 		"appeal_id":   appeal.ID,
 		"approved":     approved,
 		"notes":        notes,
-		"reviewed_by":  reviewer.Email,
 	}
 	
 	if err := uc.notificationService.SendNotification(ctx, appeal.UserID, "appeal_decision", userData); err != nil {
@@ -527,7 +629,7 @@ func (uc *BanUserUseCase) sendAppealDecisionNotifications(ctx context.Context, a
 		"user_id":     appeal.UserID,
 		"approved":     approved,
 		"notes":        notes,
-		"reviewed_by":  reviewer.Email,
+		"reviewed_by":  reviewedBy,
 	}
 	
 	if err := uc.notificationService.SendAdminNotification(ctx, uuid.Nil, "appeal_decision", adminData); err != nil {
@@ -0,0 +1,318 @@
+package moderation
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/22smeargle/winkr-backend/internal/domain/entities"
+	"github.com/22smeargle/winkr-backend/internal/domain/repositories"
+	"github.com/22smeargle/winkr-backend/pkg/config"
+	"github.com/22smeargle/winkr-backend/pkg/errors"
+)
+
+// inMemoryUserRepository serves users from memory
+type inMemoryUserRepository struct {
+	repositories.UserRepository
+	users map[uuid.UUID]*entities.User
+}
+
+func (r *inMemoryUserRepository) GetByID(ctx context.Context, id uuid.UUID) (*entities.User, error) {
+	user, ok := r.users[id]
+	if !ok {
+		return nil, errors.ErrNotFound
+	}
+	return user, nil
+}
+
+// inMemoryAdminUserRepository serves admins from memory
+type inMemoryAdminUserRepository struct {
+	repositories.AdminUserRepository
+	admins map[uuid.UUID]*entities.AdminUser
+}
+
+func (r *inMemoryAdminUserRepository) GetByID(ctx context.Context, id uuid.UUID) (*entities.AdminUser, error) {
+	admin, ok := r.admins[id]
+	if !ok {
+		return nil, errors.ErrNotFound
+	}
+	return admin, nil
+}
+
+// inMemoryBanRepository serves bans from memory
+type inMemoryBanRepository struct {
+	BanRepository
+	bans map[uuid.UUID]*BanRecord
+}
+
+func (r *inMemoryBanRepository) GetByID(ctx context.Context, id uuid.UUID) (*BanRecord, error) {
+	ban, ok := r.bans[id]
+	if !ok {
+		return nil, errors.ErrNotFound
+	}
+	return ban, nil
+}
+
+// inMemoryAppealRepository keeps appeals in memory and resolves them against the ban and user fakes
+type inMemoryAppealRepository struct {
+	AppealRepository
+	appeals []*AppealRequest
+	bans    *inMemoryBanRepository
+	users   *inMemoryUserRepository
+}
+
+func (r *inMemoryAppealRepository) Create(ctx context.Context, appeal *AppealRequest) error {
+	copied := *appeal
+	r.appeals = append(r.appeals, &copied)
+	return nil
+}
+
+func (r *inMemoryAppealRepository) GetByID(ctx context.Context, id uuid.UUID) (*AppealRequest, error) {
+	for _, appeal := range r.appeals {
+		if appeal.ID == id {
+			copied := *appeal
+			return &copied, nil
+		}
+	}
+	return nil, errors.ErrNotFound
+}
+
+func (r *inMemoryAppealRepository) GetByUserID(ctx context.Context, userID uuid.UUID, limit, offset int) ([]*AppealRequest, error) {
+	var appeals []*AppealRequest
+	for _, appeal := range r.appeals {
+		if appeal.UserID == userID {
+			appeals = append(appeals, appeal)
+		}
+	}
+	return appeals, nil
+}
+
+func (r *inMemoryAppealRepository) CountByUserID(ctx context.Context, userID uuid.UUID) (int64, error) {
+	appeals, _ := r.GetByUserID(ctx, userID, 0, 0)
+	return int64(len(appeals)), nil
+}
+
+func (r *inMemoryAppealRepository) Resolve(ctx context.Context, appeal *AppealRequest, liftBan bool) (bool, error) {
+	for i, stored := range r.appeals {
+		if stored.ID != appeal.ID {
+			continue
+		}
+		if stored.Status != "pending" {
+			return false, nil
+		}
+		copied := *appeal
+		r.appeals[i] = &copied
+		if liftBan {
+			ban := r.bans.bans[appeal.OriginalBanID]
+			ban.IsActive = false
+			user := r.users.users[ban.UserID]
+			user.IsBanned = false
+			user.IsActive = true
+		}
+		return true, nil
+	}
+	return false, nil
+}
+
+// recordingNotificationService records the notification types sent
+type recordingNotificationService struct {
+	sent []string
+}
+
+func (n *recordingNotificationService) SendNotification(ctx context.Context, userID uuid.UUID, notificationType string, data map[string]interface{}) error {
+	n.sent = append(n.sent, notificationType)
+	return nil
+}
+
+func (n *recordingNotificationService) SendAdminNotification(ctx context.Context, adminID uuid.UUID, notificationType string, data map[string]interface{}) error {
+	n.sent = append(n.sent, "admin:"+notificationType)
+	return nil
+}
+
+// fixedAppealAssessor assesses every appeal with the same confidence
+type fixedAppealAssessor float64
+
+func (a fixedAppealAssessor) AssessAppeal(ctx context.Context, appeal *AppealRequest, ban *BanRecord) (float64, error) {
+	return float64(a), nil
+}
+
+// recordingAppealOutcomes keeps recorded outcomes in memory
+type recordingAppealOutcomes struct {
+	outcomes []*entities.AppealOutcome
+}
+
+func (r *recordingAppealOutcomes) RecordAppealOutcome(ctx context.Context, outcome *entities.AppealOutcome) error {
+	r.outcomes = append(r.outcomes, outcome)
+	return nil
+}
+
+type appealFixture struct {
+	useCase       *BanUserUseCase
+	users         *inMemoryUserRepository
+	bans          *inMemoryBanRepository
+	appeals       *inMemoryAppealRepository
+	notifications *recordingNotificationService
+	outcomes      *recordingAppealOutcomes
+	config        *config.AppealConfig
+	moderator     *entities.AdminUser
+}
+
+func newAppealFixture(assessor AppealAssessor) *appealFixture {
+	f := &appealFixture{
+		users:         &inMemoryUserRepository{users: make(map[uuid.UUID]*entities.User)},
+		bans:          &inMemoryBanRepository{bans: make(map[uuid.UUID]*BanRecord)},
+		notifications: &recordingNotificationService{},
+		outcomes:      &recordingAppealOutcomes{},
+		config: &config.AppealConfig{
+			Enabled:             true,
+			MaxAppealsPerUser:   2,
+			AppealWindow:        7 * 24 * time.Hour,
+			AutoReviewEnabled:   true,
+			AutoReviewThreshold: 0.9,
+			NotifyOnSubmit:      true,
+			NotifyOnReview:      true,
+		},
+		moderator: &entities.AdminUser{ID: uuid.New(), Email: "mod@winkr.com", Role: "admin", IsActive: true},
+	}
+	f.appeals = &inMemoryAppealRepository{bans: f.bans, users: f.users}
+	admins := &inMemoryAdminUserRepository{admins: map[uuid.UUID]*entities.AdminUser{f.moderator.ID: f.moderator}}
+	f.useCase = NewBanUserUseCase(f.users, admins, f.bans, f.appeals, nil, f.notifications, nil, f.config, assessor, f.outcomes)
+	return f
+}
+
+// bannedUser adds a banned user and their active ban, issued at bannedAt
+func (f *appealFixture) bannedUser(bannedAt time.Time) (*entities.User, *BanRecord) {
+	user := &entities.User{ID: uuid.New(), IsBanned: true}
+	ban := &BanRecord{ID: uuid.New(), UserID: user.ID, Reason: "harassment", IsActive: true, CreatedAt: bannedAt}
+	f.users.users[user.ID] = user
+	f.bans.bans[ban.ID] = ban
+	return user, ban
+}
+
+func appealFor(ban *BanRecord) *AppealRequest {
+	return &AppealRequest{UserID: ban.UserID, OriginalBanID: ban.ID, Reason: "mistaken identity"}
+}
+
+func TestSubmitAppeal_ValidatesWindowAndLimit(t *testing.T) {
+	f := newAppealFixture(nil)
+	ctx := context.Background()
+
+	_, oldBan := f.bannedUser(time.Now().Add(-8 * 24 * time.Hour))
+	err := f.useCase.SubmitAppeal(ctx, appealFor(oldBan))
+	require.Error(t, err)
+	assert.Equal(t, http.StatusForbidden, errors.GetAppError(err).Code)
+
+	// Users cannot appeal someone else's ban
+	other, _ := f.bannedUser(time.Now())
+	_, ban := f.bannedUser(time.Now())
+	err = f.useCase.SubmitAppeal(ctx, &AppealRequest{UserID: other.ID, OriginalBanID: ban.ID})
+	require.Error(t, err)
+	assert.Equal(t, http.StatusNotFound, errors.GetAppError(err).Code)
+
+	appeal := appealFor(ban)
+	require.NoError(t, f.useCase.SubmitAppeal(ctx, appeal))
+	assert.Equal(t, "pending", appeal.Status)
+	assert.Equal(t, []string{"appeal_submitted", "admin:ban_appeal"}, f.notifications.sent)
+
+	// Only one appeal can be pending at a time
+	err = f.useCase.SubmitAppeal(ctx, appealFor(ban))
+	require.Error(t, err)
+	assert.Equal(t, http.StatusConflict, errors.GetAppError(err).Code)
+
+	require.NoError(t, f.useCase.ReviewAppeal(ctx, appeal.ID, f.moderator.ID, false, "upheld"))
+	require.NoError(t, f.useCase.SubmitAppeal(ctx, appealFor(ban)))
+	require.NoError(t, f.useCase.ReviewAppeal(ctx, f.appeals.appeals[1].ID, f.moderator.ID, false, "upheld"))
+
+	err = f.useCase.SubmitAppeal(ctx, appealFor(ban))
+	require.Error(t, err)
+	assert.Equal(t, http.StatusTooManyRequests, errors.GetAppError(err).Code)
+
+	f.config.Enabled = false
+	err = f.useCase.SubmitAppeal(ctx, appealFor(oldBan))
+	require.Error(t, err)
+	assert.Equal(t, http.StatusForbidden, errors.GetAppError(err).Code)
+}
+
+func TestReviewAppeal_ApprovalRestoresUser(t *testing.T) {
+	f := newAppealFixture(nil)
+	ctx := context.Background()
+	user, ban := f.bannedUser(time.Now())
+
+	appeal := appealFor(ban)
+	require.NoError(t, f.useCase.SubmitAppeal(ctx, appeal))
+	f.notifications.sent = nil
+
+	require.NoError(t, f.useCase.ReviewAppeal(ctx, appeal.ID, f.moderator.ID, true, "evidence checks out"))
+	assert.False(t, ban.IsActive)
+	assert.False(t, user.IsBanned)
+	assert.True(t, user.IsActive)
+	assert.Equal(t, "approved", f.appeals.appeals[0].Status)
+	assert.Equal(t, f.moderator.ID, *f.appeals.appeals[0].ReviewedBy)
+	assert.Equal(t, []string{"appeal_decision", "admin:appeal_decision"}, f.notifications.sent)
+
+	require.Len(t, f.outcomes.outcomes, 1)
+	outcome := f.outcomes.outcomes[0]
+	assert.True(t, outcome.Approved)
+	assert.False(t, outcome.AutoReviewed)
+	assert.Equal(t, f.moderator.ID, *outcome.ReviewerID)
+
+	// An appeal is only decided once
+	err := f.useCase.ReviewAppeal(ctx, appeal.ID, f.moderator.ID, false, "changed my mind")
+	require.Error(t, err)
+	assert.Equal(t, http.StatusConflict, errors.GetAppError(err).Code)
+}
+
+func TestReviewAppeal_RejectionKeepsBan(t *testing.T) {
+	f := newAppealFixture(nil)
+	f.config.NotifyOnReview = false
+	ctx := context.Background()
+	user, ban := f.bannedUser(time.Now())
+
+	appeal := appealFor(ban)
+	require.NoError(t, f.useCase.SubmitAppeal(ctx, appeal))
+	f.notifications.sent = nil
+
+	require.NoError(t, f.useCase.ReviewAppeal(ctx, appeal.ID, f.moderator.ID, false, "upheld"))
+	assert.True(t, ban.IsActive)
+	assert.True(t, user.IsBanned)
+	assert.Equal(t, "rejected", f.appeals.appeals[0].Status)
+	assert.Empty(t, f.notifications.sent)
+	require.Len(t, f.outcomes.outcomes, 1)
+	assert.False(t, f.outcomes.outcomes[0].Approved)
+}
+
+func TestSubmitAppeal_AutoApprovesConfidentAppeals(t *testing.T) {
+	ctx := context.Background()
+
+	f := newAppealFixture(fixedAppealAssessor(0.95))
+	user, ban := f.bannedUser(time.Now())
+	appeal := appealFor(ban)
+	require.NoError(t, f.useCase.SubmitAppeal(ctx, appeal))
+	assert.Equal(t, "approved", appeal.Status)
+	assert.True(t, appeal.AutoReviewed)
+	assert.Nil(t, appeal.ReviewedBy)
+	assert.False(t, user.IsBanned)
+	require.Len(t, f.outcomes.outcomes, 1)
+	assert.True(t, f.outcomes.outcomes[0].AutoReviewed)
+	assert.Equal(t, 0.95, *f.outcomes.outcomes[0].Confidence)
+
+	// Appeals at or below the threshold wait for a moderator
+	f = newAppealFixture(fixedAppealAssessor(0.9))
+	user, ban = f.bannedUser(time.Now())
+	appeal = appealFor(ban)
+	require.NoError(t, f.useCase.SubmitAppeal(ctx, appeal))
+	assert.Equal(t, "pending", appeal.Status)
+	assert.True(t, user.IsBanned)
+	assert.Empty(t, f.outcomes.outcomes)
+
+	f = newAppealFixture(fixedAppealAssessor(0.95))
+	f.config.AutoReviewEnabled = false
+	_, ban = f.bannedUser(time.Now())
+	appeal = appealFor(ban)
+	require.NoError(t, f.useCase.SubmitAppeal(ctx, appeal))
+	assert.Equal(t, "pending", appeal.Status)
+}
@@ -0,0 +1,160 @@
+package moderation
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/22smeargle/winkr-backend/internal/domain/entities"
+	"github.com/22smeargle/winkr-backend/internal/domain/repositories"
+	"github.com/22smeargle/winkr-backend/pkg/errors"
+	"github.com/22smeargle/winkr-backend/pkg/logger"
+)
+
+// ReportLimiter throttles how often a user may file reports
+type ReportLimiter interface {
+	Allow(ctx context.Context, key string, limit int, window time.Duration) (bool, error)
+}
+
+// ReportPhotoRequest represents a request to report one of a user's profile photos
+type ReportPhotoRequest struct {
+	ReporterID  uuid.UUID `json:"-"`
+	OwnerID     uuid.UUID `json:"-"`
+	PhotoID     uuid.UUID `json:"-"`
+	Reason      string    `json:"reason" binding:"required"`
+	Description *string   `json:"description,omitempty"`
+}
+
+// ReportPhotoResponse represents the response after reporting a photo
+type ReportPhotoResponse struct {
+	ReportID    uuid.UUID `json:"report_id"`
+	PhotoID     uuid.UUID `json:"photo_id"`
+	Status      string    `json:"status"`
+	PhotoHidden bool      `json:"photo_hidden"`
+	CreatedAt   time.Time `json:"created_at"`
+}
+
+// ReportPhotoUseCase files photo reports into the moderation queue and sends the photo back for re-moderation
+type ReportPhotoUseCase struct {
+	reportRepo    repositories.ReportRepository
+	photoRepo     repositories.PhotoRepository
+	rateLimiter   ReportLimiter
+	maxPerHour    int
+	maxPerDay     int
+	hideThreshold int
+}
+
+// NewReportPhotoUseCase creates a new ReportPhotoUseCase
+func NewReportPhotoUseCase(
+	reportRepo repositories.ReportRepository,
+	photoRepo repositories.PhotoRepository,
+	rateLimiter ReportLimiter,
+	maxPerHour, maxPerDay, hideThreshold int,
+) *ReportPhotoUseCase {
+	return &ReportPhotoUseCase{
+		reportRepo:    reportRepo,
+		photoRepo:     photoRepo,
+		rateLimiter:   rateLimiter,
+		maxPerHour:    maxPerHour,
+		maxPerDay:     maxPerDay,
+		hideThreshold: hideThreshold,
+	}
+}
+
+// Execute executes the report photo use case
+func (uc *ReportPhotoUseCase) Execute(ctx context.Context, req ReportPhotoRequest) (*ReportPhotoResponse, error) {
+	logger.Info("Executing ReportPhoto use case", "reporter_id", req.ReporterID, "photo_id", req.PhotoID, "reason", req.Reason)
+
+	report := &entities.Report{
+		ReporterID:     req.ReporterID,
+		ReportedUserID: req.OwnerID,
+		PhotoID:        &req.PhotoID,
+		Reason:         req.Reason,
+		Description:    req.Description,
+		Status:         "pending",
+		CreatedAt:      time.Now(),
+	}
+	if !report.IsValidReason() {
+		return nil, errors.NewValidationError("reason", "invalid report reason")
+	}
+	if req.ReporterID == req.OwnerID {
+		return nil, errors.NewValidationError("photo_id", "cannot report your own photo")
+	}
+
+	photo, err := uc.photoRepo.GetByID(ctx, req.PhotoID)
+	if err != nil || photo.UserID != req.OwnerID || photo.IsDeleted {
+		return nil, errors.NewNotFoundError("Photo")
+	}
+
+	if err := uc.checkRateLimits(ctx, req.ReporterID); err != nil {
+		return nil, err
+	}
+
+	hasActiveReport, err := uc.reportRepo.HasActivePhotoReport(ctx, req.ReporterID, req.PhotoID)
+	if err != nil {
+		logger.Error("Failed to check for active photo reports", err, "photo_id", req.PhotoID)
+		return nil, fmt.Errorf("failed to check for active photo reports: %w", err)
+	}
+	if hasActiveReport {
+		return nil, errors.NewConflictError("You have already reported this photo")
+	}
+
+	if err := uc.reportRepo.Create(ctx, report); err != nil {
+		logger.Error("Failed to create photo report", err, "photo_id", req.PhotoID)
+		return nil, fmt.Errorf("failed to create report: %w", err)
+	}
+
+	pending, err := uc.reportRepo.CountPendingByPhoto(ctx, req.PhotoID)
+	if err != nil {
+		logger.Error("Failed to count photo reports", err, "photo_id", req.PhotoID)
+		return nil, fmt.Errorf("failed to count photo reports: %w", err)
+	}
+
+	// Every report sends the photo back to moderation; enough of them also take it off the profile
+	hide := uc.hideThreshold > 0 && pending >= int64(uc.hideThreshold)
+	if err := uc.photoRepo.RequestReview(ctx, req.PhotoID, hide); err != nil {
+		logger.Error("Failed to request photo review", err, "photo_id", req.PhotoID)
+		return nil, fmt.Errorf("failed to request photo review: %w", err)
+	}
+
+	logger.Info("ReportPhoto use case executed successfully", "report_id", report.ID, "photo_id", req.PhotoID, "pending_reports", pending, "hidden", hide)
+	return &ReportPhotoResponse{
+		ReportID:    report.ID,
+		PhotoID:     req.PhotoID,
+		Status:      report.Status,
+		PhotoHidden: hide || photo.IsHidden,
+		CreatedAt:   report.CreatedAt,
+	}, nil
+}
+
+// checkRateLimits checks the reporter's hourly and daily photo report allowance
+func (uc *ReportPhotoUseCase) checkRateLimits(ctx context.Context, reporterID uuid.UUID) error {
+	windows := []struct {
+		name   string
+		limit  int
+		window time.Duration
+	}{
+		{"hourly", uc.maxPerHour, time.Hour},
+		{"daily", uc.maxPerDay, 24 * time.Hour},
+	}
+
+	for _, w := range windows {
+		if w.limit <= 0 {
+			continue
+		}
+		key := fmt.Sprintf("photo_reports:%s:%s", w.name, reporterID)
+		allowed, err := uc.rateLimiter.Allow(ctx, key, w.limit, w.window)
+		if err != nil {
+			// Fail open so a limiter outage does not block safety reports
+			logger.Warn("Photo report rate limit check failed", "reporter_id", reporterID, "error", err)
+			continue
+		}
+		if !allowed {
+			return errors.NewAppError(http.StatusTooManyRequests, "Report limit exceeded", fmt.Sprintf("%s photo report limit reached", w.name))
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,190 @@
+package moderation
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/22smeargle/winkr-backend/internal/domain/entities"
+	"github.com/22smeargle/winkr-backend/internal/domain/repositories"
+	"github.com/22smeargle/winkr-backend/pkg/errors"
+)
+
+// inMemoryReportRepository keeps filed reports in memory
+type inMemoryReportRepository struct {
+	repositories.ReportRepository
+	reports []*entities.Report
+}
+
+func (r *inMemoryReportRepository) Create(ctx context.Context, report *entities.Report) error {
+	report.ID = uuid.New()
+	r.reports = append(r.reports, report)
+	return nil
+}
+
+func (r *inMemoryReportRepository) HasActivePhotoReport(ctx context.Context, reporterID, photoID uuid.UUID) (bool, error) {
+	for _, report := range r.reports {
+		if report.ReporterID == reporterID && report.PhotoID != nil && *report.PhotoID == photoID && report.IsPending() {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+func (r *inMemoryReportRepository) CountPendingByPhoto(ctx context.Context, photoID uuid.UUID) (int64, error) {
+	var count int64
+	for _, report := range r.reports {
+		if report.PhotoID != nil && *report.PhotoID == photoID && report.IsPending() {
+			count++
+		}
+	}
+	return count, nil
+}
+
+// inMemoryPhotoRepository serves photos from memory and applies review requests to them
+type inMemoryPhotoRepository struct {
+	repositories.PhotoRepository
+	photos map[uuid.UUID]*entities.Photo
+}
+
+func (r *inMemoryPhotoRepository) add(ownerID uuid.UUID) *entities.Photo {
+	photo := &entities.Photo{ID: uuid.New(), UserID: ownerID, VerificationStatus: "approved"}
+	r.photos[photo.ID] = photo
+	return photo
+}
+
+func (r *inMemoryPhotoRepository) GetByID(ctx context.Context, id uuid.UUID) (*entities.Photo, error) {
+	photo, ok := r.photos[id]
+	if !ok {
+		return nil, errors.ErrNotFound
+	}
+	copied := *photo
+	return &copied, nil
+}
+
+func (r *inMemoryPhotoRepository) RequestReview(ctx context.Context, photoID uuid.UUID, hide bool) error {
+	r.photos[photoID].RequestReview(hide)
+	return nil
+}
+
+// countingLimiter allows a fixed number of calls per key
+type countingLimiter struct {
+	calls map[string]int
+}
+
+func (l *countingLimiter) Allow(ctx context.Context, key string, limit int, window time.Duration) (bool, error) {
+	if l.calls == nil {
+		l.calls = make(map[string]int)
+	}
+	l.calls[key]++
+	return l.calls[key] <= limit, nil
+}
+
+func newReportPhotoFixture(hideThreshold int) (*ReportPhotoUseCase, *inMemoryReportRepository, *inMemoryPhotoRepository) {
+	reports := &inMemoryReportRepository{}
+	photos := &inMemoryPhotoRepository{photos: make(map[uuid.UUID]*entities.Photo)}
+	return NewReportPhotoUseCase(reports, photos, &countingLimiter{}, 5, 20, hideThreshold), reports, photos
+}
+
+func reportPhoto(photo *entities.Photo) ReportPhotoRequest {
+	return ReportPhotoRequest{ReporterID: uuid.New(), OwnerID: photo.UserID, PhotoID: photo.ID, Reason: "fake_profile"}
+}
+
+func TestReportPhoto_EnqueuesReportAndRequestsReview(t *testing.T) {
+	useCase, reports, photos := newReportPhotoFixture(3)
+	photo := photos.add(uuid.New())
+	req := reportPhoto(photo)
+
+	resp, err := useCase.Execute(context.Background(), req)
+	require.NoError(t, err)
+	assert.Equal(t, "pending", resp.Status)
+	assert.False(t, resp.PhotoHidden)
+
+	require.Len(t, reports.reports, 1)
+	report := reports.reports[0]
+	assert.Equal(t, resp.ReportID, report.ID)
+	assert.Equal(t, photo.UserID, report.ReportedUserID)
+	require.True(t, report.IsPhotoReport())
+	assert.Equal(t, photo.ID, *report.PhotoID)
+	assert.Equal(t, "fake_profile", report.Reason)
+
+	assert.NotNil(t, photo.ReviewRequestedAt, "reported photo goes back to moderation")
+	assert.True(t, photo.IsVisible())
+
+	// Reporting the same photo twice is refused
+	_, err = useCase.Execute(context.Background(), req)
+	require.Error(t, err)
+	assert.Equal(t, http.StatusConflict, errors.GetAppError(err).Code)
+}
+
+func TestReportPhoto_HidesPhotoPastThreshold(t *testing.T) {
+	useCase, _, photos := newReportPhotoFixture(3)
+	photo := photos.add(uuid.New())
+
+	for i := 0; i < 2; i++ {
+		resp, err := useCase.Execute(context.Background(), reportPhoto(photo))
+		require.NoError(t, err)
+		assert.False(t, resp.PhotoHidden)
+	}
+	assert.True(t, photo.IsVisible())
+
+	resp, err := useCase.Execute(context.Background(), reportPhoto(photo))
+	require.NoError(t, err)
+	assert.True(t, resp.PhotoHidden)
+	assert.False(t, photo.IsVisible())
+
+	// A moderator decision restores the photo
+	photo.Approve()
+	assert.True(t, photo.IsVisible())
+	assert.Nil(t, photo.ReviewRequestedAt)
+}
+
+func TestReportPhoto_RejectsInvalidRequests(t *testing.T) {
+	useCase, reports, photos := newReportPhotoFixture(3)
+	photo := photos.add(uuid.New())
+
+	tests := []struct {
+		name   string
+		mutate func(*ReportPhotoRequest)
+		code   int
+	}{
+		{"invalid reason", func(r *ReportPhotoRequest) { r.Reason = "ugly" }, http.StatusBadRequest},
+		{"own photo", func(r *ReportPhotoRequest) { r.ReporterID = photo.UserID }, http.StatusBadRequest},
+		{"unknown photo", func(r *ReportPhotoRequest) { r.PhotoID = uuid.New() }, http.StatusNotFound},
+		{"photo of another user", func(r *ReportPhotoRequest) { r.OwnerID = uuid.New() }, http.StatusNotFound},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := reportPhoto(photo)
+			tt.mutate(&req)
+
+			_, err := useCase.Execute(context.Background(), req)
+			require.Error(t, err)
+			assert.Equal(t, tt.code, errors.GetAppError(err).Code)
+		})
+	}
+	assert.Empty(t, reports.reports)
+}
+
+func TestReportPhoto_EnforcesRateLimit(t *testing.T) {
+	useCase, reports, photos := newReportPhotoFixture(0)
+	photo := photos.add(uuid.New())
+	reporterID := uuid.New()
+
+	for i := 0; i < 5; i++ {
+		other := photos.add(photo.UserID)
+		_, err := useCase.Execute(context.Background(), ReportPhotoRequest{ReporterID: reporterID, OwnerID: photo.UserID, PhotoID: other.ID, Reason: "spam"})
+		require.NoError(t, err)
+	}
+
+	_, err := useCase.Execute(context.Background(), ReportPhotoRequest{ReporterID: reporterID, OwnerID: photo.UserID, PhotoID: photo.ID, Reason: "spam"})
+	require.Error(t, err)
+	assert.Equal(t, http.StatusTooManyRequests, errors.GetAppError(err).Code)
+	assert.Len(t, reports.reports, 5)
+	assert.Nil(t, photo.ReviewRequestedAt)
+}
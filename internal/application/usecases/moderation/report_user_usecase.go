@@ -0,0 +1,185 @@
+package moderation
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/22smeargle/winkr-backend/internal/application/services"
+	"github.com/22smeargle/winkr-backend/internal/domain/entities"
+	"github.com/22smeargle/winkr-backend/internal/domain/repositories"
+	"github.com/22smeargle/winkr-backend/pkg/config"
+	"github.com/22smeargle/winkr-backend/pkg/errors"
+	"github.com/22smeargle/winkr-backend/pkg/logger"
+)
+
+// ReportReviewQueue queues reported users for moderator review
+type ReportReviewQueue interface {
+	Enqueue(ctx context.Context, itemType string, itemID uuid.UUID, userID *uuid.UUID, priority string) (*services.ModerationReviewItem, error)
+}
+
+// ReportUserRequest represents a request to report a user's profile
+type ReportUserRequest struct {
+	Reason      string  `json:"reason" binding:"required"`
+	Description *string `json:"description,omitempty"`
+}
+
+// ReportUserResponse represents the response after reporting a user
+type ReportUserResponse struct {
+	ReportID       uuid.UUID `json:"report_id"`
+	ReportedUserID uuid.UUID `json:"reported_user_id"`
+	Status         string    `json:"status"`
+	CreatedAt      time.Time `json:"created_at"`
+}
+
+// ReportUserUseCase files profile reports. Every report counts against the reported user; the report
+// that brings the count to ReportThreshold queues the user for moderator review, and with
+// AutoBanEnabled the report that brings it to AutoBanThreshold bans the user.
+type ReportUserUseCase struct {
+	reportRepo     repositories.ReportRepository
+	reputationRepo repositories.ReputationRepository
+	userRepo       repositories.UserRepository
+	reviewQueue    ReportReviewQueue
+	banCascader    UserBanCascader
+	rateLimiter    ReportLimiter
+	rules          *config.ModerationRulesConfig
+	limits         *config.ModerationRateLimitConfig
+	now            func() time.Time
+}
+
+// NewReportUserUseCase creates a new ReportUserUseCase
+func NewReportUserUseCase(
+	reportRepo repositories.ReportRepository,
+	reputationRepo repositories.ReputationRepository,
+	userRepo repositories.UserRepository,
+	reviewQueue ReportReviewQueue,
+	banCascader UserBanCascader,
+	rateLimiter ReportLimiter,
+	rules *config.ModerationRulesConfig,
+	limits *config.ModerationRateLimitConfig,
+) *ReportUserUseCase {
+	return &ReportUserUseCase{
+		reportRepo:     reportRepo,
+		reputationRepo: reputationRepo,
+		userRepo:       userRepo,
+		reviewQueue:    reviewQueue,
+		banCascader:    banCascader,
+		rateLimiter:    rateLimiter,
+		rules:          rules,
+		limits:         limits,
+		now:            time.Now,
+	}
+}
+
+// ReportUser files a report against the target's profile. A reporter may report the same user once
+// per ReportCooldown and file ReportsPerDay reports a day.
+func (uc *ReportUserUseCase) ReportUser(ctx context.Context, reporterID, targetID uuid.UUID, reason string, details *string) (*ReportUserResponse, error) {
+	logger.Info("Executing ReportUser use case", "reporter_id", reporterID, "reported_user_id", targetID, "reason", reason)
+
+	report := &entities.Report{
+		ReporterID:     reporterID,
+		ReportedUserID: targetID,
+		Reason:         reason,
+		Description:    details,
+		Status:         "pending",
+		CreatedAt:      uc.now(),
+	}
+	if !report.IsValidReason() {
+		return nil, errors.NewValidationError("reason", "invalid report reason")
+	}
+	if reporterID == targetID {
+		return nil, errors.NewValidationError("user_id", "cannot report yourself")
+	}
+
+	target, err := uc.userRepo.GetByID(ctx, targetID)
+	if err != nil || target == nil {
+		return nil, errors.NewNotFoundError("User")
+	}
+
+	if err := uc.checkRateLimit(ctx, reporterID); err != nil {
+		return nil, err
+	}
+
+	if uc.limits.ReportCooldown > 0 {
+		reported, err := uc.reportRepo.HasRecentReport(ctx, reporterID, targetID, report.CreatedAt.Add(-uc.limits.ReportCooldown))
+		if err != nil {
+			logger.Error("Failed to check for recent reports", err, "reported_user_id", targetID)
+			return nil, fmt.Errorf("failed to check for recent reports: %w", err)
+		}
+		if reported {
+			return nil, errors.NewConflictError("You have already reported this user")
+		}
+	}
+
+	if err := uc.reportRepo.Create(ctx, report); err != nil {
+		logger.Error("Failed to create user report", err, "reported_user_id", targetID)
+		return nil, fmt.Errorf("failed to create report: %w", err)
+	}
+
+	// The report stands even if counting it fails; moderators still see it among pending reports
+	count, err := uc.reputationRepo.IncrementReportsReceived(ctx, targetID, uc.rules.InitialReputation)
+	if err != nil {
+		logger.Error("Failed to count report against user", err, "report_id", report.ID, "reported_user_id", targetID)
+	} else {
+		uc.escalate(ctx, report, target, count)
+	}
+
+	logger.Info("ReportUser use case executed successfully", "report_id", report.ID, "reported_user_id", targetID, "reports_received", count)
+	return &ReportUserResponse{
+		ReportID:       report.ID,
+		ReportedUserID: targetID,
+		Status:         report.Status,
+		CreatedAt:      report.CreatedAt,
+	}, nil
+}
+
+// escalate queues the target for review and bans them when the report brings their count to the
+// thresholds. Only the report reaching a threshold acts on it, so a user a moderator cleared is not
+// queued or banned again by every later report. Failures are logged so the report itself stands.
+func (uc *ReportUserUseCase) escalate(ctx context.Context, report *entities.Report, target *entities.User, count int) {
+	if uc.rules.ReportThreshold > 0 && count == uc.rules.ReportThreshold && uc.reviewQueue != nil {
+		if _, err := uc.reviewQueue.Enqueue(ctx, "user_report", report.ID, &target.ID, ""); err != nil {
+			logger.Error("Failed to queue reported user for review", err, "report_id", report.ID, "reported_user_id", target.ID)
+		}
+	}
+
+	if !uc.rules.AutoBanEnabled || uc.rules.AutoBanThreshold <= 0 || count != uc.rules.AutoBanThreshold || target.IsBanned {
+		return
+	}
+
+	target.IsBanned = true
+	target.IsActive = false
+	if err := uc.userRepo.Update(ctx, target); err != nil {
+		logger.Error("Failed to auto-ban reported user", err, "reported_user_id", target.ID)
+		return
+	}
+
+	if uc.banCascader != nil {
+		if err := uc.banCascader.OnUserBanned(ctx, target.ID); err != nil {
+			logger.Error("Failed to end matches of auto-banned user", err, "user_id", target.ID)
+		}
+	}
+
+	logger.Warn("User auto-banned after reports", "user_id", target.ID, "reports_received", count, "threshold", uc.rules.AutoBanThreshold)
+}
+
+// checkRateLimit checks the reporter's daily user report allowance
+func (uc *ReportUserUseCase) checkRateLimit(ctx context.Context, reporterID uuid.UUID) error {
+	if uc.limits.ReportsPerDay <= 0 {
+		return nil
+	}
+
+	allowed, err := uc.rateLimiter.Allow(ctx, fmt.Sprintf("user_reports:daily:%s", reporterID), uc.limits.ReportsPerDay, 24*time.Hour)
+	if err != nil {
+		// Fail open so a limiter outage does not block safety reports
+		logger.Warn("User report rate limit check failed", "reporter_id", reporterID, "error", err)
+		return nil
+	}
+	if !allowed {
+		return errors.NewAppError(http.StatusTooManyRequests, "Report limit exceeded", "daily report limit reached")
+	}
+
+	return nil
+}
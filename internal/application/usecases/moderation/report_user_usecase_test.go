@@ -0,0 +1,211 @@
+package moderation
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/22smeargle/winkr-backend/internal/application/services"
+	"github.com/22smeargle/winkr-backend/internal/domain/entities"
+	"github.com/22smeargle/winkr-backend/internal/domain/repositories"
+	"github.com/22smeargle/winkr-backend/pkg/config"
+	"github.com/22smeargle/winkr-backend/pkg/errors"
+)
+
+func (r *inMemoryReportRepository) HasRecentReport(ctx context.Context, reporterID, reportedUserID uuid.UUID, since time.Time) (bool, error) {
+	for _, report := range r.reports {
+		if report.ReporterID == reporterID && report.ReportedUserID == reportedUserID && !report.IsPhotoReport() && !report.CreatedAt.Before(since) {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// countingReputationRepository counts the reports received by each user
+type countingReputationRepository struct {
+	repositories.ReputationRepository
+	received map[uuid.UUID]int
+}
+
+func (r *countingReputationRepository) IncrementReportsReceived(ctx context.Context, userID uuid.UUID, initialScore int) (int, error) {
+	r.received[userID]++
+	return r.received[userID], nil
+}
+
+// updatingUserRepository serves users from memory and counts updates
+type updatingUserRepository struct {
+	*inMemoryUserRepository
+	updates int
+}
+
+func (r *updatingUserRepository) Update(ctx context.Context, user *entities.User) error {
+	r.updates++
+	r.users[user.ID] = user
+	return nil
+}
+
+// recordingReviewQueue records the items queued for review
+type recordingReviewQueue struct {
+	items []*services.ModerationReviewItem
+}
+
+func (q *recordingReviewQueue) Enqueue(ctx context.Context, itemType string, itemID uuid.UUID, userID *uuid.UUID, priority string) (*services.ModerationReviewItem, error) {
+	item := &services.ModerationReviewItem{ID: uuid.New(), ItemType: itemType, ItemID: itemID, UserID: userID, Priority: priority}
+	q.items = append(q.items, item)
+	return item, nil
+}
+
+// recordingBanCascader records the users whose bans were cascaded
+type recordingBanCascader struct {
+	banned []uuid.UUID
+}
+
+func (c *recordingBanCascader) OnUserBanned(ctx context.Context, userID uuid.UUID) error {
+	c.banned = append(c.banned, userID)
+	return nil
+}
+
+type reportUserFixture struct {
+	useCase  *ReportUserUseCase
+	reports  *inMemoryReportRepository
+	users    *updatingUserRepository
+	queue    *recordingReviewQueue
+	cascader *recordingBanCascader
+	rules    *config.ModerationRulesConfig
+	limits   *config.ModerationRateLimitConfig
+}
+
+func newReportUserFixture() *reportUserFixture {
+	f := &reportUserFixture{
+		reports:  &inMemoryReportRepository{},
+		users:    &updatingUserRepository{inMemoryUserRepository: &inMemoryUserRepository{users: make(map[uuid.UUID]*entities.User)}},
+		queue:    &recordingReviewQueue{},
+		cascader: &recordingBanCascader{},
+		rules:    &config.ModerationRulesConfig{ReportThreshold: 2, AutoBanEnabled: true, AutoBanThreshold: 3, InitialReputation: 100},
+		limits:   &config.ModerationRateLimitConfig{ReportsPerDay: 20, ReportCooldown: 7 * 24 * time.Hour},
+	}
+	reputations := &countingReputationRepository{received: make(map[uuid.UUID]int)}
+	f.useCase = NewReportUserUseCase(f.reports, reputations, f.users, f.queue, f.cascader, &countingLimiter{}, f.rules, f.limits)
+	return f
+}
+
+func (f *reportUserFixture) user() *entities.User {
+	user := &entities.User{ID: uuid.New(), IsActive: true}
+	f.users.users[user.ID] = user
+	return user
+}
+
+func requireStatus(t *testing.T, err error, status int) {
+	t.Helper()
+	var appErr *errors.AppError
+	require.ErrorAs(t, err, &appErr)
+	assert.Equal(t, status, appErr.StatusCode())
+}
+
+func TestReportUser_QueuesAndBansAtThresholds(t *testing.T) {
+	f := newReportUserFixture()
+	ctx := context.Background()
+	target := f.user()
+
+	resp, err := f.useCase.ReportUser(ctx, uuid.New(), target.ID, "spam", nil)
+	require.NoError(t, err)
+	assert.Equal(t, "pending", resp.Status)
+	assert.Equal(t, target.ID, resp.ReportedUserID)
+	require.Len(t, f.reports.reports, 1)
+	assert.Equal(t, resp.ReportID, f.reports.reports[0].ID)
+	assert.False(t, f.reports.reports[0].IsPhotoReport())
+	assert.Empty(t, f.queue.items)
+
+	// The report reaching ReportThreshold queues the user for review
+	resp, err = f.useCase.ReportUser(ctx, uuid.New(), target.ID, "harassment", nil)
+	require.NoError(t, err)
+	require.Len(t, f.queue.items, 1)
+	assert.Equal(t, "user_report", f.queue.items[0].ItemType)
+	assert.Equal(t, resp.ReportID, f.queue.items[0].ItemID)
+	assert.Equal(t, target.ID, *f.queue.items[0].UserID)
+	assert.False(t, target.IsBanned)
+
+	// The report reaching AutoBanThreshold bans the user
+	_, err = f.useCase.ReportUser(ctx, uuid.New(), target.ID, "harassment", nil)
+	require.NoError(t, err)
+	assert.Len(t, f.queue.items, 1, "user is only queued when crossing the threshold")
+	assert.True(t, target.IsBanned)
+	assert.False(t, target.IsActive)
+	assert.Equal(t, []uuid.UUID{target.ID}, f.cascader.banned)
+
+	// A moderator lifting the ban is not undone by later reports
+	target.IsBanned, target.IsActive = false, true
+	_, err = f.useCase.ReportUser(ctx, uuid.New(), target.ID, "spam", nil)
+	require.NoError(t, err)
+	assert.False(t, target.IsBanned)
+	assert.Equal(t, 1, f.users.updates)
+}
+
+func TestReportUser_AutoBanDisabled(t *testing.T) {
+	f := newReportUserFixture()
+	f.rules.AutoBanEnabled = false
+	target := f.user()
+
+	for i := 0; i < 3; i++ {
+		_, err := f.useCase.ReportUser(context.Background(), uuid.New(), target.ID, "spam", nil)
+		require.NoError(t, err)
+	}
+
+	assert.False(t, target.IsBanned)
+	assert.Zero(t, f.users.updates)
+	assert.Empty(t, f.cascader.banned)
+}
+
+func TestReportUser_RefusesDuplicateWithinCooldown(t *testing.T) {
+	f := newReportUserFixture()
+	ctx := context.Background()
+	reporterID, target := uuid.New(), f.user()
+
+	_, err := f.useCase.ReportUser(ctx, reporterID, target.ID, "spam", nil)
+	require.NoError(t, err)
+
+	_, err = f.useCase.ReportUser(ctx, reporterID, target.ID, "harassment", nil)
+	requireStatus(t, err, http.StatusConflict)
+	assert.Len(t, f.reports.reports, 1)
+
+	// After the cooldown the reporter may report the user again
+	f.useCase.now = func() time.Time { return time.Now().Add(f.limits.ReportCooldown + time.Minute) }
+	_, err = f.useCase.ReportUser(ctx, reporterID, target.ID, "harassment", nil)
+	require.NoError(t, err)
+	assert.Len(t, f.reports.reports, 2)
+}
+
+func TestReportUser_EnforcesDailyLimit(t *testing.T) {
+	f := newReportUserFixture()
+	f.limits.ReportsPerDay = 2
+	reporterID := uuid.New()
+
+	for i := 0; i < 2; i++ {
+		_, err := f.useCase.ReportUser(context.Background(), reporterID, f.user().ID, "spam", nil)
+		require.NoError(t, err)
+	}
+
+	_, err := f.useCase.ReportUser(context.Background(), reporterID, f.user().ID, "spam", nil)
+	requireStatus(t, err, http.StatusTooManyRequests)
+	assert.Len(t, f.reports.reports, 2)
+}
+
+func TestReportUser_ValidatesRequest(t *testing.T) {
+	f := newReportUserFixture()
+	target := f.user()
+
+	_, err := f.useCase.ReportUser(context.Background(), uuid.New(), target.ID, "rude", nil)
+	requireStatus(t, err, http.StatusBadRequest)
+
+	_, err = f.useCase.ReportUser(context.Background(), target.ID, target.ID, "spam", nil)
+	requireStatus(t, err, http.StatusBadRequest)
+
+	_, err = f.useCase.ReportUser(context.Background(), uuid.New(), uuid.New(), "spam", nil)
+	requireStatus(t, err, http.StatusNotFound)
+
+	assert.Empty(t, f.reports.reports)
+}
@@ -42,6 +42,7 @@ type ReviewReportUseCase struct {
 	validator          validator.Validator
 	moderationService  ModerationService
 	notificationService NotificationService
+	reputation         ReputationRecorder
 }
 
 // AdminUserRepository defines interface for admin user operations
@@ -56,6 +57,11 @@ type ModerationService interface {
 	UpdateUserReputation(ctx context.Context, userID uuid.UUID, change float64) error
 }
 
+// ReputationRecorder lowers the reputation of users reports are confirmed against
+type ReputationRecorder interface {
+	RecordReportConfirmed(ctx context.Context, userID, reportID uuid.UUID, moderatorID *uuid.UUID) (*entities.ReputationChange, error)
+}
+
 // NotificationService defines interface for notification operations
 type NotificationService interface {
 	SendNotification(ctx context.Context, userID uuid.UUID, notificationType string, data map[string]interface{}) error
@@ -81,6 +87,7 @@ func NewReviewReportUseCase(
 	validator validator.Validator,
 	moderationService ModerationService,
 	notificationService NotificationService,
+	reputation ReputationRecorder,
 ) *ReviewReportUseCase {
 	return &ReviewReportUseCase{
 		reportRepo:         reportRepo,
@@ -89,6 +96,7 @@ func NewReviewReportUseCase(
 		validator:          validator,
 		moderationService:  moderationService,
 		notificationService: notificationService,
+		reputation:         reputation,
 	}
 }
 
@@ -203,6 +211,14 @@ func (uc *ReviewReportUseCase) applyReviewAction(ctx context.Context, report *en
 	case "resolve":
 		report.MarkAsResolved(req.ReviewerID)
 		
+		// A resolved report is confirmed, so the reported user loses reputation
+		if uc.reputation != nil {
+			if _, err := uc.reputation.RecordReportConfirmed(ctx, report.ReportedUserID, report.ID, &req.ReviewerID); err != nil {
+				logger.Error("Failed to update user reputation", err, "user_id", report.ReportedUserID)
+				// Don't fail the operation, just log the error
+			}
+		}
+		
 		// Apply moderation action if requested
 		if req.TakeAction && req.ActionType != nil {
 			action := uc.buildModerationAction(req, reviewer.ID)
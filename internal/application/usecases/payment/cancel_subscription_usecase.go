@@ -2,6 +2,8 @@ package payment
 
 import (
 	"context"
+	"fmt"
+	"time"
 
 	"github.com/google/uuid"
 	"github.com/22smeargle/winkr-backend/internal/domain/entities"
@@ -10,48 +12,78 @@ import (
 	"github.com/22smeargle/winkr-backend/pkg/logger"
 )
 
-// CancelSubscriptionRequest represents a subscription cancellation request
+// CancelSubscriptionRequest represents a subscription cancellation request. Refund asks for the unused
+// part of the current period back, which requires canceling immediately.
 type CancelSubscriptionRequest struct {
 	UserID          uuid.UUID `json:"user_id" validate:"required"`
 	CancelAtPeriodEnd bool      `json:"cancel_at_period_end"`
+	Refund           bool      `json:"refund"`
 	Reason           string    `json:"reason,omitempty"`
 }
 
+// CancelSubscriptionResponse represents a subscription cancellation response
+type CancelSubscriptionResponse struct {
+	SubscriptionID    uuid.UUID `json:"subscription_id"`
+	Status            string    `json:"status"`
+	CancelAtPeriodEnd bool      `json:"cancel_at_period_end"`
+	RefundAmount      int64     `json:"refund_amount"`
+	RefundID          string    `json:"refund_id,omitempty"`
+	Currency          string    `json:"currency,omitempty"`
+}
+
+// cancellationRefund is the refund worked out for a cancellation before the subscription is canceled
+type cancellationRefund struct {
+	paymentIntentID string
+	amount          int64
+}
+
 // CancelSubscriptionUseCase handles subscription cancellation
 type CancelSubscriptionUseCase struct {
 	userRepo         repositories.UserRepository
 	subscriptionRepo repositories.SubscriptionRepository
 	stripeService    *stripe.StripeService
+	refundWindow     time.Duration
+	now              func() time.Time
 }
 
-// NewCancelSubscriptionUseCase creates a new CancelSubscriptionUseCase
+// NewCancelSubscriptionUseCase creates a new CancelSubscriptionUseCase. Refunds are only given within
+// refundWindow of the last charge; a zero window disables them.
 func NewCancelSubscriptionUseCase(
 	userRepo repositories.UserRepository,
 	subscriptionRepo repositories.SubscriptionRepository,
 	stripeService *stripe.StripeService,
+	refundWindow time.Duration,
 ) *CancelSubscriptionUseCase {
 	return &CancelSubscriptionUseCase{
 		userRepo:         userRepo,
 		subscriptionRepo: subscriptionRepo,
 		stripeService:    stripeService,
+		refundWindow:     refundWindow,
+		now:              time.Now,
 	}
 }
 
-// Execute cancels a user's subscription
-func (uc *CancelSubscriptionUseCase) Execute(ctx context.Context, req CancelSubscriptionRequest) error {
+// Execute cancels a user's subscription, refunding the unused part of the current period if requested
+func (uc *CancelSubscriptionUseCase) Execute(ctx context.Context, req CancelSubscriptionRequest) (*CancelSubscriptionResponse, error) {
 	logger.Info("Canceling subscription", map[string]interface{}{
 		"user_id":            req.UserID,
 		"cancel_at_period_end": req.CancelAtPeriodEnd,
+		"refund":              req.Refund,
 		"reason":              req.Reason,
 	})
 
+	// A subscriber who keeps the plan until the period ends has nothing to refund
+	if req.Refund && req.CancelAtPeriodEnd {
+		return nil, ErrRefundRequiresImmediateCancel
+	}
+
 	// Validate user exists
 	_, err := uc.userRepo.GetByID(ctx, req.UserID)
 	if err != nil {
 		logger.Error("Failed to get user", err, map[string]interface{}{
 			"user_id": req.UserID,
 		})
-		return ErrInvalidUserID
+		return nil, ErrInvalidUserID
 	}
 
 	// Get user's subscription
@@ -60,7 +92,7 @@ func (uc *CancelSubscriptionUseCase) Execute(ctx context.Context, req CancelSubs
 		logger.Error("Failed to get user subscription", err, map[string]interface{}{
 			"user_id": req.UserID,
 		})
-		return ErrSubscriptionNotFound
+		return nil, ErrSubscriptionNotFound
 	}
 
 	// Check if subscription can be canceled
@@ -69,7 +101,7 @@ func (uc *CancelSubscriptionUseCase) Execute(ctx context.Context, req CancelSubs
 			"user_id":        req.UserID,
 			"subscription_id": subscription.ID,
 		})
-		return ErrSubscriptionCanceled
+		return nil, ErrSubscriptionCanceled
 	}
 
 	if subscription.IsExpired() {
@@ -77,7 +109,16 @@ func (uc *CancelSubscriptionUseCase) Execute(ctx context.Context, req CancelSubs
 			"user_id":        req.UserID,
 			"subscription_id": subscription.ID,
 		})
-		return ErrSubscriptionExpired
+		return nil, ErrSubscriptionExpired
+	}
+
+	// Work out the refund before canceling so an ineligible request changes nothing
+	var refund *cancellationRefund
+	if req.Refund {
+		refund, err = uc.prepareRefund(ctx, subscription)
+		if err != nil {
+			return nil, err
+		}
 	}
 
 	// Cancel subscription in Stripe
@@ -89,7 +130,7 @@ func (uc *CancelSubscriptionUseCase) Execute(ctx context.Context, req CancelSubs
 				"subscription_id":        subscription.ID,
 				"stripe_subscription_id": *subscription.StripeSubscriptionID,
 			})
-			return fmt.Errorf("failed to cancel Stripe subscription: %w", err)
+			return nil, fmt.Errorf("failed to cancel Stripe subscription: %w", err)
 		}
 	}
 
@@ -106,7 +147,7 @@ func (uc *CancelSubscriptionUseCase) Execute(ctx context.Context, req CancelSubs
 			"user_id":        req.UserID,
 			"subscription_id": subscription.ID,
 		})
-		return fmt.Errorf("failed to update subscription: %w", err)
+		return nil, fmt.Errorf("failed to update subscription: %w", err)
 	}
 
 	logger.Info("Subscription canceled successfully", map[string]interface{}{
@@ -117,5 +158,85 @@ func (uc *CancelSubscriptionUseCase) Execute(ctx context.Context, req CancelSubs
 		"reason":                req.Reason,
 	})
 
-	return nil
+	response := &CancelSubscriptionResponse{
+		SubscriptionID:    subscription.ID,
+		Status:            subscription.Status,
+		CancelAtPeriodEnd: subscription.CancelAtPeriodEnd,
+	}
+
+	if refund != nil && refund.amount > 0 {
+		stripeRefund, err := uc.stripeService.CreateRefund(ctx, refund.paymentIntentID, refund.amount, "requested_by_customer", map[string]string{
+			"user_id":         req.UserID.String(),
+			"subscription_id": subscription.ID.String(),
+		})
+		if err != nil {
+			// The subscription stays canceled; the refund can be issued from the Stripe dashboard
+			logger.Error("Failed to refund canceled subscription", err, map[string]interface{}{
+				"user_id":           req.UserID,
+				"subscription_id":   subscription.ID,
+				"payment_intent_id": refund.paymentIntentID,
+				"amount":            refund.amount,
+			})
+			return response, ErrRefundFailed
+		}
+
+		response.RefundAmount = stripeRefund.Amount
+		response.RefundID = stripeRefund.ID
+		response.Currency = stripeRefund.Currency
+
+		logger.Info("Canceled subscription refunded", map[string]interface{}{
+			"user_id":         req.UserID,
+			"subscription_id": subscription.ID,
+			"refund_id":       stripeRefund.ID,
+			"amount":          stripeRefund.Amount,
+		})
+	}
+
+	return response, nil
+}
+
+// prepareRefund works out the prorated refund for the unused part of the current period from the
+// subscription's last charge, which must have been paid within the refund window
+func (uc *CancelSubscriptionUseCase) prepareRefund(ctx context.Context, subscription *entities.Subscription) (*cancellationRefund, error) {
+	if uc.refundWindow <= 0 || subscription.StripeSubscriptionID == nil {
+		return nil, ErrPaymentCannotRefund
+	}
+
+	stripeSubscription, err := uc.stripeService.GetSubscription(ctx, *subscription.StripeSubscriptionID)
+	if err != nil {
+		logger.Error("Failed to get Stripe subscription", err, map[string]interface{}{
+			"subscription_id":        subscription.ID,
+			"stripe_subscription_id": *subscription.StripeSubscriptionID,
+		})
+		return nil, ErrStripeSubscriptionNotFound
+	}
+	if stripeSubscription.LatestInvoiceID == "" {
+		return nil, ErrPaymentCannotRefund
+	}
+
+	lastInvoice, err := uc.stripeService.GetInvoice(ctx, stripeSubscription.LatestInvoiceID)
+	if err != nil {
+		logger.Error("Failed to get Stripe invoice", err, map[string]interface{}{
+			"subscription_id": subscription.ID,
+			"invoice_id":      stripeSubscription.LatestInvoiceID,
+		})
+		return nil, ErrStripeInvoiceNotFound
+	}
+	if lastInvoice.PaidAt == nil || lastInvoice.PaymentIntentID == nil || lastInvoice.AmountPaid <= 0 {
+		return nil, ErrPaymentCannotRefund
+	}
+
+	now := uc.now()
+	if now.Sub(*lastInvoice.PaidAt) > uc.refundWindow {
+		logger.Info("Refund requested after the refund window", map[string]interface{}{
+			"subscription_id": subscription.ID,
+			"paid_at":         lastInvoice.PaidAt,
+		})
+		return nil, ErrRefundWindowExpired
+	}
+
+	return &cancellationRefund{
+		paymentIntentID: *lastInvoice.PaymentIntentID,
+		amount:          stripe.ProratedRefundAmount(lastInvoice.AmountPaid, stripeSubscription.CurrentPeriodStart, stripeSubscription.CurrentPeriodEnd, now),
+	}, nil
 }
\ No newline at end of file
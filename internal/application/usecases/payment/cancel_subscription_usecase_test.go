@@ -0,0 +1,47 @@
+package payment
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/22smeargle/winkr-backend/internal/infrastructure/external/stripe"
+	"github.com/22smeargle/winkr-backend/pkg/config"
+)
+
+func newCancelSubscriptionTestUseCase(subscriptions *memorySubscriptionRepository, refundWindow time.Duration) *CancelSubscriptionUseCase {
+	users := &premiumUserRepository{premium: make(map[uuid.UUID]bool)}
+	stripeService := stripe.NewStripeService(&config.StripeConfig{SecretKey: "sk_test_mock"})
+	return NewCancelSubscriptionUseCase(users, subscriptions, stripeService, refundWindow)
+}
+
+func TestCancelSubscriptionUseCase_RefundRequiresImmediateCancel(t *testing.T) {
+	subscriptions := newMemorySubscriptionRepository()
+	subscription := subscriptions.add("sub_1", "active", nil)
+
+	response, err := newCancelSubscriptionTestUseCase(subscriptions, 14*24*time.Hour).Execute(context.Background(), CancelSubscriptionRequest{
+		UserID:            subscription.UserID,
+		CancelAtPeriodEnd: true,
+		Refund:            true,
+	})
+
+	assert.ErrorIs(t, err, ErrRefundRequiresImmediateCancel)
+	assert.Nil(t, response)
+	assert.True(t, subscription.IsActive())
+	assert.False(t, subscription.CancelAtPeriodEnd)
+}
+
+func TestCancelSubscriptionUseCase_RefundsDisabledWithoutWindow(t *testing.T) {
+	subscriptions := newMemorySubscriptionRepository()
+	subscription := subscriptions.add("sub_1", "active", nil)
+
+	_, err := newCancelSubscriptionTestUseCase(subscriptions, 0).Execute(context.Background(), CancelSubscriptionRequest{
+		UserID: subscription.UserID,
+		Refund: true,
+	})
+
+	assert.ErrorIs(t, err, ErrPaymentCannotRefund)
+	assert.True(t, subscription.IsActive(), "an ineligible refund request does not cancel the subscription")
+}
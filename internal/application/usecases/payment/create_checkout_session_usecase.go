@@ -0,0 +1,95 @@
+package payment
+
+import (
+	"context"
+	"strings"
+
+	"github.com/google/uuid"
+	"github.com/22smeargle/winkr-backend/internal/domain/entities"
+	"github.com/22smeargle/winkr-backend/internal/domain/repositories"
+	"github.com/22smeargle/winkr-backend/internal/infrastructure/external/stripe"
+	"github.com/22smeargle/winkr-backend/pkg/logger"
+)
+
+// CreateCheckoutSessionRequest represents a checkout session request. Currency is a hint, usually
+// taken from the user's locale; plans without a price in that currency are charged in the default one.
+type CreateCheckoutSessionRequest struct {
+	UserID   uuid.UUID `json:"-"`
+	PlanID   string    `json:"plan_id" validate:"required"`
+	Currency string    `json:"currency,omitempty" validate:"omitempty,len=3,alpha"`
+}
+
+// CreateCheckoutSessionResponse represents a checkout session response
+type CreateCheckoutSessionResponse struct {
+	SessionID string `json:"session_id"`
+	URL       string `json:"url"`
+	Amount    int64  `json:"amount"`
+	Currency  string `json:"currency"`
+}
+
+// CreateCheckoutSessionUseCase creates Stripe Checkout sessions for plan subscriptions
+type CreateCheckoutSessionUseCase struct {
+	userRepo      repositories.UserRepository
+	stripeService *stripe.StripeService
+}
+
+// NewCreateCheckoutSessionUseCase creates a new CreateCheckoutSessionUseCase
+func NewCreateCheckoutSessionUseCase(
+	userRepo repositories.UserRepository,
+	stripeService *stripe.StripeService,
+) *CreateCheckoutSessionUseCase {
+	return &CreateCheckoutSessionUseCase{
+		userRepo:      userRepo,
+		stripeService: stripeService,
+	}
+}
+
+// Execute creates a checkout session for the plan, priced in the requested currency when possible
+func (uc *CreateCheckoutSessionUseCase) Execute(ctx context.Context, req CreateCheckoutSessionRequest) (*CreateCheckoutSessionResponse, error) {
+	logger.Info("Creating checkout session", map[string]interface{}{
+		"user_id":  req.UserID,
+		"plan_id":  req.PlanID,
+		"currency": req.Currency,
+	})
+
+	plan, exists := entities.GetPlanByID(req.PlanID)
+	if !exists {
+		return nil, ErrPlanNotFound
+	}
+
+	currency := strings.ToLower(req.Currency)
+	if currency != "" && !uc.stripeService.SupportsCurrency(currency) {
+		return nil, ErrInvalidCurrency
+	}
+
+	user, err := uc.userRepo.GetByID(ctx, req.UserID)
+	if err != nil {
+		logger.Error("Failed to get user", err, map[string]interface{}{
+			"user_id": req.UserID,
+		})
+		return nil, ErrInvalidUserID
+	}
+
+	if user.StripeCustomerID == nil || *user.StripeCustomerID == "" {
+		return nil, ErrStripeCustomerNotFound
+	}
+
+	checkoutSession, err := uc.stripeService.CreateCheckoutSession(ctx, *user.StripeCustomerID, plan.StripePriceID, currency, map[string]string{
+		"user_id": req.UserID.String(),
+		"plan_id": req.PlanID,
+	})
+	if err != nil {
+		logger.Error("Failed to create checkout session", err, map[string]interface{}{
+			"user_id": req.UserID,
+			"plan_id": req.PlanID,
+		})
+		return nil, ErrStripeAPIError
+	}
+
+	return &CreateCheckoutSessionResponse{
+		SessionID: checkoutSession.ID,
+		URL:       checkoutSession.URL,
+		Amount:    checkoutSession.Amount,
+		Currency:  checkoutSession.Currency,
+	}, nil
+}
@@ -0,0 +1,43 @@
+package payment
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/22smeargle/winkr-backend/internal/infrastructure/external/stripe"
+	"github.com/22smeargle/winkr-backend/pkg/config"
+)
+
+func newCreateCheckoutSessionTestUseCase() *CreateCheckoutSessionUseCase {
+	users := &premiumUserRepository{premium: make(map[uuid.UUID]bool)}
+	stripeService := stripe.NewStripeService(&config.StripeConfig{
+		SecretKey:           "sk_test_mock",
+		DefaultCurrency:     "usd",
+		SupportedCurrencies: []string{"usd", "eur"},
+	})
+	return NewCreateCheckoutSessionUseCase(users, stripeService)
+}
+
+func TestCreateCheckoutSessionUseCase_RejectsUnsupportedCurrency(t *testing.T) {
+	response, err := newCreateCheckoutSessionTestUseCase().Execute(context.Background(), CreateCheckoutSessionRequest{
+		UserID:   uuid.New(),
+		PlanID:   "premium",
+		Currency: "JPY",
+	})
+
+	assert.ErrorIs(t, err, ErrInvalidCurrency)
+	assert.Nil(t, response)
+}
+
+func TestCreateCheckoutSessionUseCase_RequiresStripeCustomer(t *testing.T) {
+	// Supported currencies are matched case-insensitively before the user is looked up
+	_, err := newCreateCheckoutSessionTestUseCase().Execute(context.Background(), CreateCheckoutSessionRequest{
+		UserID:   uuid.New(),
+		PlanID:   "premium",
+		Currency: "EUR",
+	})
+
+	assert.ErrorIs(t, err, ErrStripeCustomerNotFound)
+}
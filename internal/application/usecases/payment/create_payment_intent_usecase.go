@@ -0,0 +1,129 @@
+package payment
+
+import (
+	"context"
+	"math"
+
+	"github.com/google/uuid"
+	"github.com/22smeargle/winkr-backend/internal/domain/entities"
+	"github.com/22smeargle/winkr-backend/internal/domain/repositories"
+	"github.com/22smeargle/winkr-backend/internal/infrastructure/cache"
+	"github.com/22smeargle/winkr-backend/internal/infrastructure/external/stripe"
+	"github.com/22smeargle/winkr-backend/pkg/logger"
+)
+
+// CreatePaymentIntentRequest represents a payment intent request. The client picks a new nonce
+// for every purchase and sends the same one again when it retries.
+type CreatePaymentIntentRequest struct {
+	UserID          uuid.UUID `json:"-"`
+	PlanID          string    `json:"plan_id" validate:"required"`
+	PaymentMethodID string    `json:"payment_method_id,omitempty"`
+	Nonce           string    `json:"nonce" validate:"required,min=8,max=128"`
+}
+
+// CreatePaymentIntentResponse represents a payment intent response
+type CreatePaymentIntentResponse struct {
+	PaymentIntentID string `json:"payment_intent_id"`
+	ClientSecret    string `json:"client_secret"`
+	Amount          int64  `json:"amount"`
+	Currency        string `json:"currency"`
+	Status          string `json:"status"`
+}
+
+// CreatePaymentIntentUseCase creates payment intents for plan purchases without duplicating them on retries
+type CreatePaymentIntentUseCase struct {
+	userRepo      repositories.UserRepository
+	stripeService *stripe.StripeService
+	cacheService  *cache.PaymentCacheService
+}
+
+// NewCreatePaymentIntentUseCase creates a new CreatePaymentIntentUseCase
+func NewCreatePaymentIntentUseCase(
+	userRepo repositories.UserRepository,
+	stripeService *stripe.StripeService,
+	cacheService *cache.PaymentCacheService,
+) *CreatePaymentIntentUseCase {
+	return &CreatePaymentIntentUseCase{
+		userRepo:      userRepo,
+		stripeService: stripeService,
+		cacheService:  cacheService,
+	}
+}
+
+// Execute creates a payment intent for the plan, or returns the one already created for the same nonce
+func (uc *CreatePaymentIntentUseCase) Execute(ctx context.Context, req CreatePaymentIntentRequest) (*CreatePaymentIntentResponse, error) {
+	logger.Info("Creating payment intent", map[string]interface{}{
+		"user_id": req.UserID,
+		"plan_id": req.PlanID,
+	})
+
+	// Validate plan exists
+	plan, exists := entities.GetPlanByID(req.PlanID)
+	if !exists {
+		return nil, ErrPlanNotFound
+	}
+
+	// Get user
+	user, err := uc.userRepo.GetByID(ctx, req.UserID)
+	if err != nil {
+		logger.Error("Failed to get user", err, map[string]interface{}{
+			"user_id": req.UserID,
+		})
+		return nil, ErrInvalidUserID
+	}
+
+	if user.StripeCustomerID == nil || *user.StripeCustomerID == "" {
+		return nil, ErrStripeCustomerNotFound
+	}
+
+	idempotencyKey := stripe.PaymentIntentIdempotencyKey(req.UserID, req.PlanID, req.Nonce)
+
+	// A retry within the idempotency window gets the intent created the first time
+	cached, err := uc.cacheService.GetPaymentIntent(ctx, idempotencyKey)
+	if err != nil {
+		logger.Warn("Failed to look up payment intent by idempotency key", map[string]interface{}{
+			"user_id": req.UserID,
+			"error":   err.Error(),
+		})
+	}
+	if cached != nil {
+		logger.Info("Returning payment intent for repeated request", map[string]interface{}{
+			"user_id":           req.UserID,
+			"payment_intent_id": cached.ID,
+		})
+		return newCreatePaymentIntentResponse(cached), nil
+	}
+
+	// Stripe dedupes on the key as well, so concurrent requests that both miss the cache share one intent
+	paymentIntent, err := uc.stripeService.CreatePaymentIntent(ctx, int64(math.Round(plan.Price*100)), plan.Currency, *user.StripeCustomerID, req.PaymentMethodID, idempotencyKey, map[string]string{
+		"user_id": req.UserID.String(),
+		"plan_id": req.PlanID,
+	})
+	if err != nil {
+		logger.Error("Failed to create payment intent", err, map[string]interface{}{
+			"user_id": req.UserID,
+			"plan_id": req.PlanID,
+		})
+		return nil, ErrStripeAPIError
+	}
+
+	if err := uc.cacheService.CachePaymentIntent(ctx, idempotencyKey, paymentIntent); err != nil {
+		logger.Warn("Failed to remember payment intent idempotency key", map[string]interface{}{
+			"user_id":           req.UserID,
+			"payment_intent_id": paymentIntent.ID,
+			"error":             err.Error(),
+		})
+	}
+
+	return newCreatePaymentIntentResponse(paymentIntent), nil
+}
+
+func newCreatePaymentIntentResponse(paymentIntent *stripe.PaymentIntent) *CreatePaymentIntentResponse {
+	return &CreatePaymentIntentResponse{
+		PaymentIntentID: paymentIntent.ID,
+		ClientSecret:    paymentIntent.ClientSecret,
+		Amount:          paymentIntent.Amount,
+		Currency:        paymentIntent.Currency,
+		Status:          paymentIntent.Status,
+	}
+}
@@ -37,6 +37,8 @@ var (
 	ErrRefundAmountExceedsPayment = errors.New("refund amount exceeds payment amount")
 	ErrRefundAlreadyProcessed = errors.New("refund already processed")
 	ErrInvalidRefundReason = errors.New("invalid refund reason")
+	ErrRefundWindowExpired = errors.New("refund window since the last charge has expired")
+	ErrRefundRequiresImmediateCancel = errors.New("refunds require canceling immediately")
 	
 	// Invoice errors
 	ErrInvoiceAlreadyPaid   = errors.New("invoice already paid")
@@ -44,6 +46,11 @@ var (
 	ErrInvoiceOverdue      = errors.New("invoice is overdue")
 	ErrInvoiceVoid        = errors.New("invoice is void")
 	ErrInvoiceUncollectible = errors.New("invoice is uncollectible")
+	ErrInvoiceNotFinalized = errors.New("invoice is not finalized")
+	
+	// Promotion code errors
+	ErrPromotionCodeNotFound = errors.New("promotion code not found")
+	ErrPromotionCodeExpired = errors.New("promotion code is expired or inactive")
 	
 	// Subscription errors
 	ErrSubscriptionNotFound     = errors.New("subscription not found")
@@ -64,6 +71,7 @@ var (
 	ErrWebhookProcessingFailed = errors.New("webhook processing failed")
 	ErrWebhookAlreadyProcessed = errors.New("webhook event already processed")
 	ErrWebhookMaxRetriesExceeded = errors.New("webhook max retries exceeded")
+	ErrWebhookEventTooOld = errors.New("webhook event too old")
 	
 	// Stripe errors
 	ErrStripeCustomerNotFound = errors.New("Stripe customer not found")
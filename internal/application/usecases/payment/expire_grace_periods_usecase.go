@@ -0,0 +1,100 @@
+package payment
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/22smeargle/winkr-backend/internal/domain/repositories"
+	"github.com/22smeargle/winkr-backend/pkg/logger"
+)
+
+// gracePeriodBatchSize is the number of expired grace periods downgraded per query
+const gracePeriodBatchSize = 100
+
+// ExpireGracePeriodsUseCase downgrades users whose grace period after a failed renewal ended without a successful payment
+type ExpireGracePeriodsUseCase struct {
+	subscriptionRepo repositories.SubscriptionRepository
+	userRepo         repositories.UserRepository
+	now              func() time.Time
+}
+
+// NewExpireGracePeriodsUseCase creates a new ExpireGracePeriodsUseCase
+func NewExpireGracePeriodsUseCase(
+	subscriptionRepo repositories.SubscriptionRepository,
+	userRepo repositories.UserRepository,
+) *ExpireGracePeriodsUseCase {
+	return &ExpireGracePeriodsUseCase{
+		subscriptionRepo: subscriptionRepo,
+		userRepo:         userRepo,
+		now:              time.Now,
+	}
+}
+
+// Execute downgrades every subscription whose grace period has expired and returns how many were downgraded
+func (uc *ExpireGracePeriodsUseCase) Execute(ctx context.Context) (int, error) {
+	now := uc.now()
+	downgraded := 0
+
+	for {
+		subscriptions, err := uc.subscriptionRepo.GetExpiredGracePeriodSubscriptions(ctx, now, gracePeriodBatchSize)
+		if err != nil {
+			logger.Error("Failed to get subscriptions with expired grace periods", err, nil)
+			return downgraded, fmt.Errorf("failed to get subscriptions with expired grace periods: %w", err)
+		}
+
+		for _, subscription := range subscriptions {
+			// The grace period end date stays so a later failed retry doesn't start another one
+			subscription.SetUnpaid()
+			if err := uc.subscriptionRepo.Update(ctx, subscription); err != nil {
+				logger.Error("Failed to downgrade subscription", err, map[string]interface{}{
+					"subscription_id": subscription.ID,
+				})
+				return downgraded, fmt.Errorf("failed to downgrade subscription: %w", err)
+			}
+
+			if err := uc.userRepo.SetPremiumStatus(ctx, subscription.UserID, false); err != nil {
+				logger.Error("Failed to remove premium status", err, map[string]interface{}{
+					"user_id":         subscription.UserID,
+					"subscription_id": subscription.ID,
+				})
+				return downgraded, fmt.Errorf("failed to remove premium status: %w", err)
+			}
+
+			logger.Info("Grace period expired, user downgraded", map[string]interface{}{
+				"user_id":              subscription.UserID,
+				"subscription_id":      subscription.ID,
+				"grace_period_ends_at": subscription.GracePeriodEndsAt,
+			})
+			downgraded++
+		}
+
+		if len(subscriptions) < gracePeriodBatchSize {
+			return downgraded, nil
+		}
+	}
+}
+
+// StartScheduler periodically downgrades users whose grace period has expired
+func (uc *ExpireGracePeriodsUseCase) StartScheduler(ctx context.Context, interval time.Duration) {
+	logger.Info("Starting grace period expiry scheduler", map[string]interface{}{
+		"interval": interval.String(),
+	})
+
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ctx.Done():
+				logger.Info("Grace period expiry scheduler stopped", nil)
+				return
+			case <-ticker.C:
+				if _, err := uc.Execute(ctx); err != nil {
+					logger.Error("Grace period expiry run failed", err, nil)
+				}
+			}
+		}
+	}()
+}
@@ -0,0 +1,111 @@
+package payment
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/22smeargle/winkr-backend/internal/domain/entities"
+	"github.com/22smeargle/winkr-backend/internal/domain/repositories"
+)
+
+// memorySubscriptionRepository keeps subscriptions in memory, keyed by Stripe subscription ID
+type memorySubscriptionRepository struct {
+	repositories.SubscriptionRepository
+	subscriptions map[string]*entities.Subscription
+}
+
+func newMemorySubscriptionRepository() *memorySubscriptionRepository {
+	return &memorySubscriptionRepository{subscriptions: make(map[string]*entities.Subscription)}
+}
+
+func (r *memorySubscriptionRepository) add(stripeSubscriptionID, status string, gracePeriodEndsAt *time.Time) *entities.Subscription {
+	subscription := &entities.Subscription{
+		ID:                   uuid.New(),
+		UserID:               uuid.New(),
+		StripeSubscriptionID: &stripeSubscriptionID,
+		PlanType:             "premium",
+		Status:               status,
+		GracePeriodEndsAt:    gracePeriodEndsAt,
+	}
+	r.subscriptions[stripeSubscriptionID] = subscription
+	return subscription
+}
+
+func (r *memorySubscriptionRepository) GetByStripeSubscriptionID(ctx context.Context, stripeSubscriptionID string) (*entities.Subscription, error) {
+	return r.subscriptions[stripeSubscriptionID], nil
+}
+
+func (r *memorySubscriptionRepository) GetUserSubscription(ctx context.Context, userID uuid.UUID) (*entities.Subscription, error) {
+	for _, subscription := range r.subscriptions {
+		if subscription.UserID == userID {
+			return subscription, nil
+		}
+	}
+	return nil, errors.New("subscription not found")
+}
+
+func (r *memorySubscriptionRepository) Update(ctx context.Context, subscription *entities.Subscription) error {
+	r.subscriptions[*subscription.StripeSubscriptionID] = subscription
+	return nil
+}
+
+func (r *memorySubscriptionRepository) GetExpiredGracePeriodSubscriptions(ctx context.Context, now time.Time, limit int) ([]*entities.Subscription, error) {
+	var expired []*entities.Subscription
+	for _, subscription := range r.subscriptions {
+		if subscription.GracePeriodExpired(now) && len(expired) < limit {
+			expired = append(expired, subscription)
+		}
+	}
+	return expired, nil
+}
+
+// premiumUserRepository records premium status changes
+type premiumUserRepository struct {
+	repositories.UserRepository
+	premium map[uuid.UUID]bool
+}
+
+func (r *premiumUserRepository) GetByID(ctx context.Context, id uuid.UUID) (*entities.User, error) {
+	return &entities.User{ID: id}, nil
+}
+
+func (r *premiumUserRepository) SetPremiumStatus(ctx context.Context, userID uuid.UUID, isPremium bool) error {
+	r.premium[userID] = isPremium
+	return nil
+}
+
+func TestExpireGracePeriodsUseCase_DowngradesExpiredGracePeriods(t *testing.T) {
+	now := time.Now()
+	expiredAt := now.Add(-time.Minute)
+	endsAt := now.Add(time.Hour)
+
+	subscriptions := newMemorySubscriptionRepository()
+	expired := subscriptions.add("sub_expired", "past_due", &expiredAt)
+	inGrace := subscriptions.add("sub_in_grace", "past_due", &endsAt)
+	active := subscriptions.add("sub_active", "active", nil)
+	users := &premiumUserRepository{premium: make(map[uuid.UUID]bool)}
+
+	useCase := NewExpireGracePeriodsUseCase(subscriptions, users)
+	useCase.now = func() time.Time { return now }
+
+	downgraded, err := useCase.Execute(context.Background())
+
+	require.NoError(t, err)
+	assert.Equal(t, 1, downgraded)
+	assert.True(t, expired.IsUnpaid())
+	assert.False(t, expired.HasPremiumAccess(now))
+	assert.Equal(t, map[uuid.UUID]bool{expired.UserID: false}, users.premium)
+
+	assert.True(t, inGrace.HasPremiumAccess(now))
+	assert.True(t, active.IsActive())
+
+	// Downgraded subscriptions are not picked up again
+	downgraded, err = useCase.Execute(context.Background())
+	require.NoError(t, err)
+	assert.Zero(t, downgraded)
+}
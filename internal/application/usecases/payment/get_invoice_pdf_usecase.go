@@ -0,0 +1,84 @@
+package payment
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/22smeargle/winkr-backend/internal/domain/repositories"
+	"github.com/22smeargle/winkr-backend/internal/infrastructure/external/stripe"
+	"github.com/22smeargle/winkr-backend/pkg/logger"
+)
+
+// InvoicePDFResponse represents the download link for an invoice PDF
+type InvoicePDFResponse struct {
+	InvoiceID string `json:"invoice_id"`
+	URL       string `json:"url"`
+}
+
+// GetInvoicePDFUseCase returns the PDF download URL of one of the user's invoices
+type GetInvoicePDFUseCase struct {
+	userRepo      repositories.UserRepository
+	stripeService *stripe.StripeService
+}
+
+// NewGetInvoicePDFUseCase creates a new GetInvoicePDFUseCase
+func NewGetInvoicePDFUseCase(
+	userRepo repositories.UserRepository,
+	stripeService *stripe.StripeService,
+) *GetInvoicePDFUseCase {
+	return &GetInvoicePDFUseCase{
+		userRepo:      userRepo,
+		stripeService: stripeService,
+	}
+}
+
+// Execute returns the PDF URL of the invoice if it belongs to the user's Stripe customer
+func (uc *GetInvoicePDFUseCase) Execute(ctx context.Context, userID uuid.UUID, invoiceID string) (*InvoicePDFResponse, error) {
+	logger.Info("Getting invoice PDF", map[string]interface{}{
+		"user_id":    userID,
+		"invoice_id": invoiceID,
+	})
+
+	user, err := uc.userRepo.GetByID(ctx, userID)
+	if err != nil {
+		logger.Error("Failed to get user", err, map[string]interface{}{
+			"user_id": userID,
+		})
+		return nil, ErrInvalidUserID
+	}
+
+	if user.StripeCustomerID == nil || *user.StripeCustomerID == "" {
+		return nil, ErrStripeCustomerNotFound
+	}
+
+	invoicePDF, err := uc.stripeService.GetInvoicePDF(ctx, invoiceID)
+	if err == stripe.ErrInvoiceNotFound {
+		return nil, ErrInvoiceNotFound
+	}
+	if err != nil {
+		logger.Error("Failed to get invoice PDF", err, map[string]interface{}{
+			"user_id":    userID,
+			"invoice_id": invoiceID,
+		})
+		return nil, ErrStripeAPIError
+	}
+
+	// Someone else's invoice is reported the same way as a missing one, so IDs can't be probed
+	if invoicePDF.CustomerID != *user.StripeCustomerID {
+		logger.Warn("Invoice PDF requested for another customer's invoice", map[string]interface{}{
+			"user_id":    userID,
+			"invoice_id": invoiceID,
+		})
+		return nil, ErrInvoiceAccessDenied
+	}
+
+	// Stripe only renders the PDF once the invoice is finalized
+	if invoicePDF.URL == "" {
+		return nil, ErrInvoiceNotFinalized
+	}
+
+	return &InvoicePDFResponse{
+		InvoiceID: invoicePDF.InvoiceID,
+		URL:       invoicePDF.URL,
+	}, nil
+}
@@ -0,0 +1,106 @@
+package payment
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/22smeargle/winkr-backend/internal/application/dto"
+	"github.com/22smeargle/winkr-backend/internal/domain/entities"
+	"github.com/22smeargle/winkr-backend/internal/domain/repositories"
+	"github.com/22smeargle/winkr-backend/internal/infrastructure/external/stripe"
+	"github.com/22smeargle/winkr-backend/pkg/logger"
+)
+
+// PreviewSubscriptionChangeUseCase previews what switching a subscription to another plan would cost
+type PreviewSubscriptionChangeUseCase struct {
+	userRepo         repositories.UserRepository
+	subscriptionRepo repositories.SubscriptionRepository
+	stripeService    *stripe.StripeService
+}
+
+// NewPreviewSubscriptionChangeUseCase creates a new PreviewSubscriptionChangeUseCase
+func NewPreviewSubscriptionChangeUseCase(
+	userRepo repositories.UserRepository,
+	subscriptionRepo repositories.SubscriptionRepository,
+	stripeService *stripe.StripeService,
+) *PreviewSubscriptionChangeUseCase {
+	return &PreviewSubscriptionChangeUseCase{
+		userRepo:         userRepo,
+		subscriptionRepo: subscriptionRepo,
+		stripeService:    stripeService,
+	}
+}
+
+// Execute previews the proration for moving the user's subscription to priceID
+func (uc *PreviewSubscriptionChangeUseCase) Execute(ctx context.Context, userID uuid.UUID, priceID string) (*dto.SubscriptionChangePreviewDTO, error) {
+	logger.Info("Previewing subscription change", map[string]interface{}{
+		"user_id":  userID,
+		"price_id": priceID,
+	})
+
+	// Validate user exists
+	_, err := uc.userRepo.GetByID(ctx, userID)
+	if err != nil {
+		logger.Error("Failed to get user", err, map[string]interface{}{
+			"user_id": userID,
+		})
+		return nil, ErrInvalidUserID
+	}
+
+	// Only prices of the plans we sell can be previewed
+	plan, found := planByPriceID(priceID)
+	if !found {
+		return nil, ErrPlanNotFound
+	}
+
+	// Get user's subscription
+	subscription, err := uc.subscriptionRepo.GetUserSubscription(ctx, userID)
+	if err != nil {
+		logger.Error("Failed to get user subscription", err, map[string]interface{}{
+			"user_id": userID,
+		})
+		return nil, ErrSubscriptionNotFound
+	}
+
+	if !subscription.IsActive() {
+		return nil, ErrSubscriptionInactive
+	}
+
+	if subscription.StripeSubscriptionID == nil {
+		return nil, ErrStripeSubscriptionNotFound
+	}
+
+	if subscription.PlanType == plan.ID {
+		return nil, ErrSubscriptionActive
+	}
+
+	preview, err := uc.stripeService.PreviewSubscriptionChange(ctx, *subscription.StripeSubscriptionID, priceID)
+	if err != nil {
+		logger.Error("Failed to preview subscription change", err, map[string]interface{}{
+			"user_id":         userID,
+			"subscription_id": subscription.ID,
+			"price_id":        priceID,
+		})
+		return nil, ErrStripeAPIError
+	}
+
+	return &dto.SubscriptionChangePreviewDTO{
+		SubscriptionID:  preview.SubscriptionID,
+		PlanID:          plan.ID,
+		PriceID:         preview.PriceID,
+		ProrationAmount: preview.ProrationAmount,
+		AmountDue:       preview.AmountDue,
+		Currency:        preview.Currency,
+		NextBillingDate: preview.NextBillingDate,
+	}, nil
+}
+
+// planByPriceID finds the available plan billed with the given Stripe price
+func planByPriceID(priceID string) (entities.SubscriptionPlan, bool) {
+	for _, plan := range entities.GetAvailablePlans() {
+		if plan.StripePriceID == priceID {
+			return plan, true
+		}
+	}
+	return entities.SubscriptionPlan{}, false
+}
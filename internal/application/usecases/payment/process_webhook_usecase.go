@@ -3,8 +3,11 @@ package payment
 import (
 	"context"
 	"encoding/json"
+	"fmt"
+	"time"
 
 	"github.com/google/uuid"
+	"github.com/22smeargle/winkr-backend/internal/application/services"
 	"github.com/22smeargle/winkr-backend/internal/domain/entities"
 	"github.com/22smeargle/winkr-backend/internal/domain/repositories"
 	"github.com/22smeargle/winkr-backend/internal/infrastructure/external/stripe"
@@ -20,6 +23,11 @@ type ProcessWebhookUseCase struct {
 	invoiceRepo     repositories.InvoiceRepository
 	refundRepo      repositories.RefundRepository
 	stripeService    *stripe.StripeService
+	entitlementService *services.EntitlementService
+	eventStore       services.WebhookEventStore
+	maxEventAge      time.Duration
+	gracePeriod      time.Duration
+	now              func() time.Time
 }
 
 // NewProcessWebhookUseCase creates a new ProcessWebhookUseCase
@@ -31,6 +39,10 @@ func NewProcessWebhookUseCase(
 	invoiceRepo repositories.InvoiceRepository,
 	refundRepo repositories.RefundRepository,
 	stripeService *stripe.StripeService,
+	entitlementService *services.EntitlementService,
+	eventStore services.WebhookEventStore,
+	maxEventAge time.Duration,
+	gracePeriod time.Duration,
 ) *ProcessWebhookUseCase {
 	return &ProcessWebhookUseCase{
 		webhookEventRepo: webhookEventRepo,
@@ -40,6 +52,11 @@ func NewProcessWebhookUseCase(
 		invoiceRepo:     invoiceRepo,
 		refundRepo:      refundRepo,
 		stripeService:    stripeService,
+		entitlementService: entitlementService,
+		eventStore:       eventStore,
+		maxEventAge:      maxEventAge,
+		gracePeriod:      gracePeriod,
+		now:              time.Now,
 	}
 }
 
@@ -54,6 +71,46 @@ func (uc *ProcessWebhookUseCase) Execute(ctx context.Context, payload []byte, si
 		return ErrWebhookSignatureInvalid
 	}
 
+	// Stripe only redelivers an event within its retry window, so a validly signed old event is a replay
+	if uc.maxEventAge > 0 && uc.now().Sub(time.Unix(event.Created, 0)) > uc.maxEventAge {
+		logger.Warn("Rejected webhook event older than the maximum age", map[string]interface{}{
+			"stripe_event_id": event.ID,
+			"created":         event.Created,
+		})
+		return ErrWebhookEventTooOld
+	}
+
+	// Redeliveries within Stripe's retry window are recognised without touching the database
+	if uc.eventStore != nil {
+		claimed, err := uc.eventStore.ClaimEvent(ctx, event.ID, services.StripeWebhookRetryWindow)
+		if err != nil {
+			logger.Warn("Failed to claim webhook event, falling back to the event log", map[string]interface{}{
+				"stripe_event_id": event.ID,
+				"error":           err.Error(),
+			})
+		} else if !claimed {
+			logger.Info("Webhook event already seen", map[string]interface{}{
+				"stripe_event_id": event.ID,
+			})
+			return ErrWebhookAlreadyProcessed
+		}
+	}
+
+	err = uc.processEvent(ctx, event)
+	if err != nil && err != ErrWebhookAlreadyProcessed && uc.eventStore != nil {
+		// Let Stripe's next delivery try again
+		if releaseErr := uc.eventStore.ReleaseEvent(ctx, event.ID); releaseErr != nil {
+			logger.Warn("Failed to release webhook event", map[string]interface{}{
+				"stripe_event_id": event.ID,
+				"error":           releaseErr.Error(),
+			})
+		}
+	}
+	return err
+}
+
+// processEvent records a verified event in the event log and processes it unless the log shows it was already processed
+func (uc *ProcessWebhookUseCase) processEvent(ctx context.Context, event *stripe.WebhookEvent) error {
 	// Check if event already processed
 	existingEvent, err := uc.webhookEventRepo.GetByStripeEventID(ctx, event.ID)
 	if err != nil {
@@ -125,7 +182,7 @@ func (uc *ProcessWebhookUseCase) processEventByType(ctx context.Context, event *
 		return uc.handleSubscriptionUpdated(ctx, event.Data)
 	case "customer.subscription.deleted":
 		return uc.handleSubscriptionDeleted(ctx, event.Data)
-	case "invoice.payment_succeeded":
+	case "invoice.payment_succeeded", "invoice.paid":
 		return uc.handleInvoicePaymentSucceeded(ctx, event.Data)
 	case "invoice.payment_failed":
 		return uc.handleInvoicePaymentFailed(ctx, event.Data)
@@ -206,6 +263,7 @@ func (uc *ProcessWebhookUseCase) handleSubscriptionCreated(ctx context.Context,
 		}
 	} else {
 		// Update existing subscription
+		previousPlanType := subscription.PlanType
 		subscription.UpdateFromStripe(
 			stripeSubscription.ID,
 			uc.mapStripePlanToInternal(stripeSubscription.PlanID),
@@ -223,6 +281,13 @@ func (uc *ProcessWebhookUseCase) handleSubscriptionCreated(ctx context.Context,
 			})
 			return fmt.Errorf("failed to update subscription: %w", err)
 		}
+
+		// A plan change in the middle of a billing period grants the difference straight away
+		if subscription.PlanType != previousPlanType && subscription.IsActive() {
+			if err := uc.grantPlanChange(ctx, subscription); err != nil {
+				return err
+			}
+		}
 	}
 
 	return nil
@@ -269,12 +334,13 @@ func (uc *ProcessWebhookUseCase) handleSubscriptionDeleted(ctx context.Context,
 	return nil
 }
 
-// handleInvoicePaymentSucceeded handles invoice.payment_succeeded webhook event
+// handleInvoicePaymentSucceeded handles invoice.payment_succeeded and invoice.paid webhook events
 func (uc *ProcessWebhookUseCase) handleInvoicePaymentSucceeded(ctx context.Context, rawData json.RawMessage) error {
 	var invoiceData struct {
-		ID           string `json:"id"`
-		Subscription string `json:"subscription"`
-		Payment      string `json:"payment"`
+		ID            string `json:"id"`
+		Subscription  string `json:"subscription"`
+		Payment       string `json:"payment"`
+		BillingReason string `json:"billing_reason"`
 	}
 
 	err := json.Unmarshal(rawData, &invoiceData)
@@ -314,14 +380,120 @@ func (uc *ProcessWebhookUseCase) handleInvoicePaymentSucceeded(ctx context.Conte
 		}
 	}
 
+	if invoiceData.Subscription != "" {
+		if err := uc.endGracePeriod(ctx, invoiceData.Subscription); err != nil {
+			return err
+		}
+	}
+
+	// The first invoice and every renewal start a billing period with fresh allotments
+	if invoiceData.Subscription != "" && isBillingPeriodStart(invoiceData.BillingReason) {
+		if err := uc.grantRenewal(ctx, invoiceData.Subscription, invoiceData.ID); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// isBillingPeriodStart reports whether an invoice pays for a new billing period. Prorations
+// for plan changes are granted from the subscription update instead.
+func isBillingPeriodStart(billingReason string) bool {
+	return billingReason == "subscription_create" || billingReason == "subscription_cycle"
+}
+
+// endGracePeriod reactivates a subscription whose failed renewal has now been paid
+func (uc *ProcessWebhookUseCase) endGracePeriod(ctx context.Context, stripeSubscriptionID string) error {
+	subscription, err := uc.subscriptionRepo.GetByStripeSubscriptionID(ctx, stripeSubscriptionID)
+	if err != nil {
+		logger.Error("Failed to get subscription by Stripe ID", err, map[string]interface{}{
+			"stripe_subscription_id": stripeSubscriptionID,
+		})
+		return fmt.Errorf("failed to get subscription by Stripe ID: %w", err)
+	}
+	if subscription == nil || subscription.GracePeriodEndsAt == nil {
+		return nil
+	}
+
+	subscription.EndGracePeriod()
+	subscription.Activate()
+	err = uc.subscriptionRepo.Update(ctx, subscription)
+	if err != nil {
+		logger.Error("Failed to update subscription", err, map[string]interface{}{
+			"subscription_id": subscription.ID,
+		})
+		return fmt.Errorf("failed to update subscription: %w", err)
+	}
+
+	logger.Info("Subscription payment recovered, grace period ended", map[string]interface{}{
+		"subscription_id": subscription.ID,
+		"user_id":         subscription.UserID,
+	})
+
+	return nil
+}
+
+// grantRenewal resets the entitlements of a subscription's user for a paid billing period
+func (uc *ProcessWebhookUseCase) grantRenewal(ctx context.Context, stripeSubscriptionID, invoiceID string) error {
+	if uc.entitlementService == nil {
+		return nil
+	}
+
+	subscription, err := uc.subscriptionRepo.GetByStripeSubscriptionID(ctx, stripeSubscriptionID)
+	if err != nil {
+		logger.Error("Failed to get subscription by Stripe ID", err, map[string]interface{}{
+			"stripe_subscription_id": stripeSubscriptionID,
+		})
+		return fmt.Errorf("failed to get subscription by Stripe ID: %w", err)
+	}
+
+	// Stripe does not order events, so the invoice can arrive before the subscription. Failing
+	// here has Stripe retry it once the subscription is stored.
+	if subscription == nil || subscription.UserID == uuid.Nil {
+		logger.Warn("Subscription not found for paid invoice, entitlements not granted yet", map[string]interface{}{
+			"stripe_subscription_id": stripeSubscriptionID,
+			"invoice_id":             invoiceID,
+		})
+		return fmt.Errorf("subscription %s not found for invoice %s", stripeSubscriptionID, invoiceID)
+	}
+
+	_, err = uc.entitlementService.GrantRenewal(ctx, subscription.UserID, subscription.PlanType, invoiceID)
+	if err != nil {
+		logger.Error("Failed to grant renewal entitlements", err, map[string]interface{}{
+			"user_id":    subscription.UserID,
+			"invoice_id": invoiceID,
+		})
+		return fmt.Errorf("failed to grant renewal entitlements: %w", err)
+	}
+
+	return nil
+}
+
+// grantPlanChange grants the allotments an upgraded plan adds to the current billing period
+func (uc *ProcessWebhookUseCase) grantPlanChange(ctx context.Context, subscription *entities.Subscription) error {
+	if uc.entitlementService == nil || subscription.UserID == uuid.Nil {
+		return nil
+	}
+
+	_, err := uc.entitlementService.GrantUpgrade(ctx, subscription.UserID, subscription.PlanType)
+	if err != nil {
+		logger.Error("Failed to grant plan change entitlements", err, map[string]interface{}{
+			"user_id":   subscription.UserID,
+			"plan_type": subscription.PlanType,
+		})
+		return fmt.Errorf("failed to grant plan change entitlements: %w", err)
+	}
+
 	return nil
 }
 
 // handleInvoicePaymentFailed handles invoice.payment_failed webhook event
 func (uc *ProcessWebhookUseCase) handleInvoicePaymentFailed(ctx context.Context, rawData json.RawMessage) error {
 	var invoiceData struct {
-		ID      string `json:"id"`
-		Payment string `json:"payment"`
+		ID            string `json:"id"`
+		Subscription  string `json:"subscription"`
+		Payment       string `json:"payment"`
+		BillingReason string `json:"billing_reason"`
 	}
 
 	err := json.Unmarshal(rawData, &invoiceData)
@@ -352,6 +524,53 @@ func (uc *ProcessWebhookUseCase) handleInvoicePaymentFailed(ctx context.Context,
 		}
 	}
 
+	// A failed renewal keeps the plan's features for a grace period so the user can update their card
+	if invoiceData.Subscription != "" && invoiceData.BillingReason == "subscription_cycle" {
+		if err := uc.startGracePeriod(ctx, invoiceData.Subscription, invoiceData.ID); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// startGracePeriod sets a subscription past due until the grace period ends. Stripe retries a failed
+// payment several times, and only the first failure starts the grace period.
+func (uc *ProcessWebhookUseCase) startGracePeriod(ctx context.Context, stripeSubscriptionID, invoiceID string) error {
+	subscription, err := uc.subscriptionRepo.GetByStripeSubscriptionID(ctx, stripeSubscriptionID)
+	if err != nil {
+		logger.Error("Failed to get subscription by Stripe ID", err, map[string]interface{}{
+			"stripe_subscription_id": stripeSubscriptionID,
+		})
+		return fmt.Errorf("failed to get subscription by Stripe ID: %w", err)
+	}
+	if subscription == nil {
+		logger.Warn("Subscription not found for failed invoice, grace period not started", map[string]interface{}{
+			"stripe_subscription_id": stripeSubscriptionID,
+			"invoice_id":             invoiceID,
+		})
+		return nil
+	}
+	if subscription.GracePeriodEndsAt != nil || subscription.IsCanceled() {
+		return nil
+	}
+
+	subscription.StartGracePeriod(uc.now().Add(uc.gracePeriod))
+	err = uc.subscriptionRepo.Update(ctx, subscription)
+	if err != nil {
+		logger.Error("Failed to update subscription", err, map[string]interface{}{
+			"subscription_id": subscription.ID,
+		})
+		return fmt.Errorf("failed to update subscription: %w", err)
+	}
+
+	logger.Info("Renewal payment failed, grace period started", map[string]interface{}{
+		"subscription_id":      subscription.ID,
+		"user_id":              subscription.UserID,
+		"invoice_id":           invoiceID,
+		"grace_period_ends_at": subscription.GracePeriodEndsAt,
+	})
+
 	return nil
 }
 
@@ -0,0 +1,202 @@
+package payment
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	stripeapi "github.com/stripe/stripe-go/v76"
+	"github.com/stripe/stripe-go/v76/webhook"
+	"github.com/22smeargle/winkr-backend/internal/domain/entities"
+	"github.com/22smeargle/winkr-backend/internal/domain/repositories"
+	"github.com/22smeargle/winkr-backend/internal/infrastructure/external/stripe"
+	"github.com/22smeargle/winkr-backend/pkg/config"
+)
+
+const (
+	testWebhookSecret = "whsec_test_secret"
+	testGracePeriod   = 7 * 24 * time.Hour
+)
+
+// memoryWebhookEventStore remembers claimed events in memory
+type memoryWebhookEventStore struct {
+	events   map[string]time.Duration
+	releases int
+	err      error
+}
+
+func newMemoryWebhookEventStore() *memoryWebhookEventStore {
+	return &memoryWebhookEventStore{events: make(map[string]time.Duration)}
+}
+
+func (s *memoryWebhookEventStore) ClaimEvent(ctx context.Context, eventID string, ttl time.Duration) (bool, error) {
+	if s.err != nil {
+		return false, s.err
+	}
+	if _, ok := s.events[eventID]; ok {
+		return false, nil
+	}
+	s.events[eventID] = ttl
+	return true, nil
+}
+
+func (s *memoryWebhookEventStore) ReleaseEvent(ctx context.Context, eventID string) error {
+	s.releases++
+	delete(s.events, eventID)
+	return nil
+}
+
+// memoryWebhookEventRepository keeps the event log in memory
+type memoryWebhookEventRepository struct {
+	repositories.WebhookEventRepository
+	events  map[string]*entities.WebhookEvent
+	creates int
+}
+
+func newMemoryWebhookEventRepository() *memoryWebhookEventRepository {
+	return &memoryWebhookEventRepository{events: make(map[string]*entities.WebhookEvent)}
+}
+
+func (r *memoryWebhookEventRepository) GetByStripeEventID(ctx context.Context, stripeEventID string) (*entities.WebhookEvent, error) {
+	return r.events[stripeEventID], nil
+}
+
+func (r *memoryWebhookEventRepository) Create(ctx context.Context, webhookEvent *entities.WebhookEvent) error {
+	r.creates++
+	r.events[webhookEvent.StripeEventID] = webhookEvent
+	return nil
+}
+
+func (r *memoryWebhookEventRepository) Update(ctx context.Context, webhookEvent *entities.WebhookEvent) error {
+	r.events[webhookEvent.StripeEventID] = webhookEvent
+	return nil
+}
+
+type webhookFixture struct {
+	store         *memoryWebhookEventStore
+	events        *memoryWebhookEventRepository
+	subscriptions *memorySubscriptionRepository
+	now           time.Time
+}
+
+func newWebhookFixture() *webhookFixture {
+	return &webhookFixture{
+		store:         newMemoryWebhookEventStore(),
+		events:        newMemoryWebhookEventRepository(),
+		subscriptions: newMemorySubscriptionRepository(),
+		now:           time.Now(),
+	}
+}
+
+func (f *webhookFixture) useCase() *ProcessWebhookUseCase {
+	stripeService := stripe.NewStripeService(&config.StripeConfig{
+		SecretKey:     "sk_test_mock",
+		WebhookSecret: testWebhookSecret,
+	})
+	useCase := NewProcessWebhookUseCase(f.events, f.subscriptions, nil, nil, nil, nil, stripeService, nil, f.store, 72*time.Hour, testGracePeriod)
+	useCase.now = func() time.Time { return f.now }
+	return useCase
+}
+
+// deliver signs an event created at createdAt as if Stripe sent it just now
+func (f *webhookFixture) deliver(eventID, eventType string, createdAt time.Time) error {
+	return f.deliverObject(eventID, eventType, createdAt, `{"id":"ch_test"}`)
+}
+
+// deliverObject signs an event about the given Stripe object
+func (f *webhookFixture) deliverObject(eventID, eventType string, createdAt time.Time, object string) error {
+	payload := []byte(fmt.Sprintf(`{"id":%q,"object":"event","type":%q,"api_version":%q,"created":%d,"data":{"object":%s}}`,
+		eventID, eventType, stripeapi.APIVersion, createdAt.Unix(), object))
+	signed := webhook.GenerateTestSignedPayload(&webhook.UnsignedPayload{
+		Payload:   payload,
+		Secret:    testWebhookSecret,
+		Timestamp: time.Now(),
+	})
+	return f.useCase().Execute(context.Background(), payload, signed.Header)
+}
+
+func TestProcessWebhookUseCase_SkipsRedeliveredEvents(t *testing.T) {
+	f := newWebhookFixture()
+
+	require.NoError(t, f.deliver("evt_1", "charge.succeeded", f.now))
+	err := f.deliver("evt_1", "charge.succeeded", f.now)
+
+	assert.ErrorIs(t, err, ErrWebhookAlreadyProcessed)
+	assert.Equal(t, 1, f.events.creates, "the redelivery is not processed again")
+	assert.Equal(t, 72*time.Hour, f.store.events["evt_1"], "events are remembered for Stripe's retry window")
+}
+
+func TestProcessWebhookUseCase_ForgetsEventsThatFailed(t *testing.T) {
+	f := newWebhookFixture()
+
+	err := f.deliver("evt_1", "customer.created", f.now)
+	require.ErrorIs(t, err, ErrWebhookEventNotSupported)
+	assert.Equal(t, 1, f.store.releases)
+	assert.NotContains(t, f.store.events, "evt_1")
+
+	// Stripe's retry is processed instead of being skipped
+	err = f.deliver("evt_1", "customer.created", f.now)
+	assert.ErrorIs(t, err, ErrWebhookEventNotSupported)
+	assert.Equal(t, 2, f.events.creates)
+}
+
+func TestProcessWebhookUseCase_RejectsEventsOlderThanMaxAge(t *testing.T) {
+	f := newWebhookFixture()
+
+	err := f.deliver("evt_old", "charge.succeeded", f.now.Add(-73*time.Hour))
+
+	assert.ErrorIs(t, err, ErrWebhookEventTooOld)
+	assert.Empty(t, f.store.events)
+	assert.Zero(t, f.events.creates)
+
+	assert.NoError(t, f.deliver("evt_recent", "charge.succeeded", f.now.Add(-71*time.Hour)))
+}
+
+func TestProcessWebhookUseCase_FallsBackToEventLogWhenStoreFails(t *testing.T) {
+	f := newWebhookFixture()
+	f.store.err = errors.New("redis unavailable")
+	f.events.events["evt_1"] = &entities.WebhookEvent{StripeEventID: "evt_1", Processed: true}
+
+	err := f.deliver("evt_1", "charge.succeeded", f.now)
+
+	assert.ErrorIs(t, err, ErrWebhookAlreadyProcessed)
+	assert.Zero(t, f.events.creates)
+
+	// New events are still processed
+	assert.NoError(t, f.deliver("evt_2", "charge.succeeded", f.now))
+}
+
+func TestProcessWebhookUseCase_FailedRenewalStartsGracePeriod(t *testing.T) {
+	f := newWebhookFixture()
+	subscription := f.subscriptions.add("sub_1", "active", nil)
+	failedRenewal := `{"id":"in_1","subscription":"sub_1","billing_reason":"subscription_cycle"}`
+
+	require.NoError(t, f.deliverObject("evt_1", "invoice.payment_failed", f.now, failedRenewal))
+
+	assert.True(t, subscription.IsPastDue())
+	require.NotNil(t, subscription.GracePeriodEndsAt)
+	assert.WithinDuration(t, f.now.Add(testGracePeriod), *subscription.GracePeriodEndsAt, time.Second)
+	assert.True(t, subscription.HasPremiumAccess(f.now))
+
+	// Stripe's payment retries fail again without extending the grace period
+	endsAt := *subscription.GracePeriodEndsAt
+	f.now = f.now.Add(3 * 24 * time.Hour)
+	require.NoError(t, f.deliverObject("evt_2", "invoice.payment_failed", f.now, failedRenewal))
+	assert.Equal(t, endsAt, *subscription.GracePeriodEndsAt)
+}
+
+func TestProcessWebhookUseCase_FailedFirstPaymentHasNoGracePeriod(t *testing.T) {
+	f := newWebhookFixture()
+	subscription := f.subscriptions.add("sub_1", "past_due", nil)
+
+	err := f.deliverObject("evt_1", "invoice.payment_failed", f.now,
+		`{"id":"in_1","subscription":"sub_1","billing_reason":"subscription_create"}`)
+
+	require.NoError(t, err)
+	assert.Nil(t, subscription.GracePeriodEndsAt)
+	assert.False(t, subscription.HasPremiumAccess(f.now))
+}
@@ -0,0 +1,104 @@
+package payment
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/22smeargle/winkr-backend/internal/domain/entities"
+	"github.com/22smeargle/winkr-backend/internal/domain/repositories"
+	"github.com/22smeargle/winkr-backend/internal/infrastructure/external/stripe"
+	"github.com/22smeargle/winkr-backend/pkg/logger"
+)
+
+// SetDefaultPaymentMethodUseCase makes one of the user's saved payment methods the default
+type SetDefaultPaymentMethodUseCase struct {
+	userRepo          repositories.UserRepository
+	paymentMethodRepo repositories.PaymentMethodRepository
+	stripeService     *stripe.StripeService
+}
+
+// NewSetDefaultPaymentMethodUseCase creates a new SetDefaultPaymentMethodUseCase
+func NewSetDefaultPaymentMethodUseCase(
+	userRepo repositories.UserRepository,
+	paymentMethodRepo repositories.PaymentMethodRepository,
+	stripeService *stripe.StripeService,
+) *SetDefaultPaymentMethodUseCase {
+	return &SetDefaultPaymentMethodUseCase{
+		userRepo:          userRepo,
+		paymentMethodRepo: paymentMethodRepo,
+		stripeService:     stripeService,
+	}
+}
+
+// Execute sets the payment method as the default in Stripe and locally, and returns the updated default
+func (uc *SetDefaultPaymentMethodUseCase) Execute(ctx context.Context, userID, paymentMethodID uuid.UUID) (*entities.PaymentMethod, error) {
+	logger.Info("Setting default payment method", map[string]interface{}{
+		"user_id":           userID,
+		"payment_method_id": paymentMethodID,
+	})
+
+	user, err := uc.userRepo.GetByID(ctx, userID)
+	if err != nil {
+		logger.Error("Failed to get user", err, map[string]interface{}{
+			"user_id": userID,
+		})
+		return nil, ErrInvalidUserID
+	}
+
+	if user.StripeCustomerID == nil || *user.StripeCustomerID == "" {
+		return nil, ErrStripeCustomerNotFound
+	}
+
+	paymentMethod, err := uc.paymentMethodRepo.GetByID(ctx, paymentMethodID)
+	if err != nil || paymentMethod.StripePaymentMethodID == nil {
+		return nil, ErrPaymentMethodNotFound
+	}
+
+	if paymentMethod.UserID != userID {
+		logger.Warn("Default requested for another user's payment method", map[string]interface{}{
+			"user_id":           userID,
+			"payment_method_id": paymentMethodID,
+		})
+		return nil, ErrPaymentAccessDenied
+	}
+
+	// Our records can be stale, so only switch to a method Stripe still has attached to the customer
+	attached, err := uc.stripeService.GetPaymentMethods(ctx, *user.StripeCustomerID, paymentMethod.Type)
+	if err != nil {
+		logger.Error("Failed to list customer payment methods", err, map[string]interface{}{
+			"user_id": userID,
+		})
+		return nil, ErrStripeAPIError
+	}
+	if !containsStripePaymentMethod(attached, *paymentMethod.StripePaymentMethodID) {
+		logger.Warn("Payment method is not attached to the user's Stripe customer", map[string]interface{}{
+			"user_id":           userID,
+			"payment_method_id": paymentMethodID,
+		})
+		return nil, ErrPaymentAccessDenied
+	}
+
+	if _, err := uc.stripeService.SetDefaultPaymentMethod(ctx, *user.StripeCustomerID, *paymentMethod.StripePaymentMethodID); err != nil {
+		return nil, ErrStripeAPIError
+	}
+
+	if err := uc.paymentMethodRepo.SetAsDefault(ctx, userID, paymentMethodID); err != nil {
+		logger.Error("Failed to set default payment method", err, map[string]interface{}{
+			"user_id":           userID,
+			"payment_method_id": paymentMethodID,
+		})
+		return nil, ErrDatabaseError
+	}
+	paymentMethod.IsDefault = true
+
+	return paymentMethod, nil
+}
+
+func containsStripePaymentMethod(paymentMethods []*stripe.PaymentMethod, stripePaymentMethodID string) bool {
+	for _, paymentMethod := range paymentMethods {
+		if paymentMethod.ID == stripePaymentMethodID {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,49 @@
+package payment
+
+import (
+	"context"
+	"strings"
+
+	"github.com/22smeargle/winkr-backend/internal/infrastructure/external/stripe"
+	"github.com/22smeargle/winkr-backend/pkg/logger"
+)
+
+// ValidatePromotionCodeRequest represents a request to check a promotion code before checkout
+type ValidatePromotionCodeRequest struct {
+	Code string `json:"code" validate:"required,max=100"`
+}
+
+// ValidatePromotionCodeUseCase checks that a promotion code can be redeemed and returns its discount
+type ValidatePromotionCodeUseCase struct {
+	stripeService *stripe.StripeService
+}
+
+// NewValidatePromotionCodeUseCase creates a new ValidatePromotionCodeUseCase
+func NewValidatePromotionCodeUseCase(stripeService *stripe.StripeService) *ValidatePromotionCodeUseCase {
+	return &ValidatePromotionCodeUseCase{
+		stripeService: stripeService,
+	}
+}
+
+// Execute returns the discount of the promotion code if it can still be redeemed
+func (uc *ValidatePromotionCodeUseCase) Execute(ctx context.Context, req ValidatePromotionCodeRequest) (*stripe.PromotionCode, error) {
+	code := strings.TrimSpace(req.Code)
+	if code == "" {
+		return nil, ErrPromotionCodeNotFound
+	}
+
+	promotionCode, err := uc.stripeService.ValidatePromotionCode(ctx, code)
+	switch err {
+	case nil:
+		return promotionCode, nil
+	case stripe.ErrPromotionCodeNotFound:
+		return nil, ErrPromotionCodeNotFound
+	case stripe.ErrPromotionCodeInactive:
+		return nil, ErrPromotionCodeExpired
+	default:
+		logger.Error("Failed to validate promotion code", err, map[string]interface{}{
+			"code": code,
+		})
+		return nil, ErrStripeAPIError
+	}
+}
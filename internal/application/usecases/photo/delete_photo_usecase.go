@@ -86,15 +86,18 @@ func (uc *DeletePhotoUseCase) Execute(ctx context.Context, req *DeletePhotoReque
 		return nil, fmt.Errorf("failed to delete photo: %w", err)
 	}
 
+	// Background work outlives the request but keeps its correlation ID in the logs
+	jobCtx := logger.DetachContext(ctx)
+
 	// Delete file from storage (async operation - don't fail if storage deletion fails)
 	go func() {
-		if err := uc.storageService.DeleteFile(context.Background(), fileKey); err != nil {
-			logger.Error("Failed to delete photo file from storage", err, map[string]interface{}{
+		if err := uc.storageService.DeleteFile(jobCtx, fileKey); err != nil {
+			logger.ErrorContext(jobCtx, "Failed to delete photo file from storage", err, map[string]interface{}{
 				"photo_id": req.PhotoID,
 				"file_key": fileKey,
 			})
 		} else {
-			logger.Info("Photo file deleted from storage", map[string]interface{}{
+			logger.InfoContext(jobCtx, "Photo file deleted from storage", map[string]interface{}{
 				"photo_id": req.PhotoID,
 				"file_key": fileKey,
 			})
@@ -104,22 +107,22 @@ func (uc *DeletePhotoUseCase) Execute(ctx context.Context, req *DeletePhotoReque
 	// If this was a primary photo, set another photo as primary if available
 	if photo.IsPrimary {
 		go func() {
-			userPhotos, err := uc.photoRepo.GetUserPhotos(context.Background(), req.UserID, false)
+			userPhotos, err := uc.photoRepo.GetUserPhotos(jobCtx, req.UserID, false)
 			if err != nil {
-				logger.Error("Failed to get user photos for primary reassignment", err)
+				logger.ErrorContext(jobCtx, "Failed to get user photos for primary reassignment", err)
 				return
 			}
 
 			// Find first non-deleted photo to set as primary
 			for _, p := range userPhotos {
 				if !p.IsDeleted && p.ID != req.PhotoID {
-					if err := uc.photoRepo.SetPrimaryPhoto(context.Background(), req.UserID, p.ID); err != nil {
-						logger.Error("Failed to set new primary photo", err, map[string]interface{}{
+					if err := uc.photoRepo.SetPrimaryPhoto(jobCtx, req.UserID, p.ID); err != nil {
+						logger.ErrorContext(jobCtx, "Failed to set new primary photo", err, map[string]interface{}{
 							"user_id":   req.UserID,
 							"photo_id":  p.ID,
 						})
 					} else {
-						logger.Info("New primary photo set", map[string]interface{}{
+						logger.InfoContext(jobCtx, "New primary photo set", map[string]interface{}{
 							"user_id":   req.UserID,
 							"photo_id":  p.ID,
 						})
@@ -99,10 +99,11 @@ func (uc *GetDownloadURLUseCase) Execute(ctx context.Context, req *GetDownloadUR
 	}
 
 	// Log access for analytics (async)
+	viewCtx := logger.DetachContext(ctx)
 	go func() {
 		if req.ViewerID != uuid.Nil && req.ViewerID != req.UserID {
 			// This is someone else viewing the user's photo
-			uc.logPhotoView(context.Background(), req.PhotoID, req.UserID, req.ViewerID)
+			uc.logPhotoView(viewCtx, req.PhotoID, req.UserID, req.ViewerID)
 		}
 	}()
 
@@ -158,7 +159,7 @@ func (uc *GetDownloadURLUseCase) canAccessPhoto(photo *entities.Photo, userID, v
 func (uc *GetDownloadURLUseCase) logPhotoView(ctx context.Context, photoID, userID, viewerID uuid.UUID) {
 	// This would typically be stored in a separate analytics table
 	// For now, we'll just log it
-	logger.Info("Photo viewed", map[string]interface{}{
+	logger.InfoContext(ctx, "Photo viewed", map[string]interface{}{
 		"photo_id":  photoID,
 		"user_id":   userID,
 		"viewer_id": viewerID,
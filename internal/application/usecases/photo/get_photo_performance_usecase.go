@@ -0,0 +1,98 @@
+package photo
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/22smeargle/winkr-backend/internal/domain/repositories"
+)
+
+// GetPhotoPerformanceRequest represents a request for the performance of the user's photos
+type GetPhotoPerformanceRequest struct {
+	UserID uuid.UUID `json:"user_id" validate:"required"`
+}
+
+// PhotoPerformance represents how one of the user's photos does with the people shown it
+type PhotoPerformance struct {
+	PhotoID            uuid.UUID `json:"photo_id"`
+	FileURL            string    `json:"file_url"`
+	IsPrimary          bool      `json:"is_primary"`
+	PrimaryLocked      bool      `json:"primary_locked"`
+	Position           int       `json:"position"`
+	VerificationStatus string    `json:"verification_status"`
+	Impressions        int64     `json:"impressions"` // Distinct viewers shown the photo
+	Likes              int64     `json:"likes"`       // Viewers who liked the user after being shown the photo
+	LikeRate           float64   `json:"like_rate"`
+}
+
+// GetPhotoPerformanceResponse represents the performance of the user's photos, in display order
+type GetPhotoPerformanceResponse struct {
+	AutoReorder bool               `json:"auto_reorder"`
+	Photos      []PhotoPerformance `json:"photos"`
+}
+
+// GetPhotoPerformanceUseCase handles getting the performance of a user's own photos
+type GetPhotoPerformanceUseCase struct {
+	photoRepo      repositories.PhotoRepository
+	engagementRepo repositories.PhotoEngagementRepository
+	userRepo       repositories.UserRepository
+}
+
+// NewGetPhotoPerformanceUseCase creates a new get photo performance use case
+func NewGetPhotoPerformanceUseCase(
+	photoRepo repositories.PhotoRepository,
+	engagementRepo repositories.PhotoEngagementRepository,
+	userRepo repositories.UserRepository,
+) *GetPhotoPerformanceUseCase {
+	return &GetPhotoPerformanceUseCase{
+		photoRepo:      photoRepo,
+		engagementRepo: engagementRepo,
+		userRepo:       userRepo,
+	}
+}
+
+// Execute executes the get photo performance use case
+func (uc *GetPhotoPerformanceUseCase) Execute(ctx context.Context, req *GetPhotoPerformanceRequest) (*GetPhotoPerformanceResponse, error) {
+	if req.UserID == uuid.Nil {
+		return nil, fmt.Errorf("validation failed: user ID is required")
+	}
+
+	photos, err := uc.photoRepo.GetUserPhotos(ctx, req.UserID, false)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get photos: %w", err)
+	}
+
+	performance, err := uc.engagementRepo.GetPerformanceByUserID(ctx, req.UserID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get photo performance: %w", err)
+	}
+
+	response := &GetPhotoPerformanceResponse{
+		Photos: make([]PhotoPerformance, 0, len(photos)),
+	}
+
+	// Users without preferences have not opted in
+	if preferences, err := uc.userRepo.GetPreferences(ctx, req.UserID); err == nil {
+		response.AutoReorder = preferences.AutoReorderPhotos
+	}
+
+	for _, photo := range photos {
+		item := PhotoPerformance{
+			PhotoID:            photo.ID,
+			FileURL:            photo.FileURL,
+			IsPrimary:          photo.IsPrimary,
+			PrimaryLocked:      photo.PrimaryLocked,
+			Position:           photo.Position,
+			VerificationStatus: photo.VerificationStatus,
+		}
+		if stats, ok := performance[photo.ID]; ok {
+			item.Impressions = stats.Impressions
+			item.Likes = stats.Likes
+			item.LikeRate = stats.LikeRate()
+		}
+		response.Photos = append(response.Photos, item)
+	}
+
+	return response, nil
+}
@@ -7,6 +7,7 @@ import (
 	"time"
 
 	"github.com/google/uuid"
+	"github.com/22smeargle/winkr-backend/internal/application/services"
 	"github.com/22smeargle/winkr-backend/internal/domain/repositories"
 	"github.com/22smeargle/winkr-backend/internal/infrastructure/storage"
 	"github.com/22smeargle/winkr-backend/pkg/logger"
@@ -33,6 +34,7 @@ type GetUploadURLResponse struct {
 type GetUploadURLUseCase struct {
 	photoRepo      repositories.PhotoRepository
 	storageService storage.StorageService
+	photoLimits    *services.PhotoLimitService
 	maxFileSize    int64
 	allowedTypes   []string
 }
@@ -41,12 +43,14 @@ type GetUploadURLUseCase struct {
 func NewGetUploadURLUseCase(
 	photoRepo repositories.PhotoRepository,
 	storageService storage.StorageService,
+	photoLimits *services.PhotoLimitService,
 	maxFileSize int64,
 	allowedTypes []string,
 ) *GetUploadURLUseCase {
 	return &GetUploadURLUseCase{
 		photoRepo:      photoRepo,
 		storageService: storageService,
+		photoLimits:    photoLimits,
 		maxFileSize:    maxFileSize,
 		allowedTypes:   allowedTypes,
 	}
@@ -60,14 +64,8 @@ func (uc *GetUploadURLUseCase) Execute(ctx context.Context, req *GetUploadURLReq
 	}
 
 	// Check user photo limit
-	currentPhotoCount, err := uc.photoRepo.GetUserPhotoCount(ctx, req.UserID)
-	if err != nil {
-		return nil, fmt.Errorf("failed to get user photo count: %w", err)
-	}
-
-	maxPhotosPerUser := 6
-	if currentPhotoCount >= maxPhotosPerUser {
-		return nil, fmt.Errorf("maximum photo limit reached (%d photos)", maxPhotosPerUser)
+	if err := uc.photoLimits.CheckProfilePhotoLimit(ctx, req.UserID); err != nil {
+		return nil, err
 	}
 
 	// Generate unique file key
@@ -5,6 +5,7 @@ import (
 	"fmt"
 
 	"github.com/google/uuid"
+	"github.com/22smeargle/winkr-backend/internal/application/services"
 	"github.com/22smeargle/winkr-backend/internal/domain/repositories"
 	"github.com/22smeargle/winkr-backend/pkg/logger"
 )
@@ -26,13 +27,15 @@ type MarkPhotoViewedResponse struct {
 
 // MarkPhotoViewedUseCase handles marking photos as viewed logic
 type MarkPhotoViewedUseCase struct {
-	photoRepo repositories.PhotoRepository
+	photoRepo         repositories.PhotoRepository
+	engagementService *services.PhotoEngagementService
 }
 
 // NewMarkPhotoViewedUseCase creates a new mark photo viewed use case
-func NewMarkPhotoViewedUseCase(photoRepo repositories.PhotoRepository) *MarkPhotoViewedUseCase {
+func NewMarkPhotoViewedUseCase(photoRepo repositories.PhotoRepository, engagementService *services.PhotoEngagementService) *MarkPhotoViewedUseCase {
 	return &MarkPhotoViewedUseCase{
-		photoRepo: photoRepo,
+		photoRepo:         photoRepo,
+		engagementService: engagementService,
 	}
 }
 
@@ -69,9 +72,10 @@ func (uc *MarkPhotoViewedUseCase) Execute(ctx context.Context, req *MarkPhotoVie
 		return nil, fmt.Errorf("viewer cannot be the photo owner")
 	}
 
-	// Log the photo view (this would typically be stored in a separate analytics table)
-	// For now, we'll just log it and return success
-	uc.logPhotoView(ctx, req.PhotoID, req.UserID, req.ViewerID)
+	// Count the view towards the photo's performance
+	if err := uc.engagementService.RecordImpression(ctx, photo, req.ViewerID); err != nil {
+		return nil, fmt.Errorf("failed to record photo view: %w", err)
+	}
 
 	logger.Info("Photo marked as viewed", map[string]interface{}{
 		"photo_id":  req.PhotoID,
@@ -102,16 +106,4 @@ func (uc *MarkPhotoViewedUseCase) validateRequest(req *MarkPhotoViewedRequest) e
 	}
 
 	return nil
-}
-
-// logPhotoView logs when a photo is viewed by another user
-func (uc *MarkPhotoViewedUseCase) logPhotoView(ctx context.Context, photoID, userID, viewerID uuid.UUID) {
-	// This would typically be stored in a separate analytics table
-	// For now, we'll just log it
-	logger.Info("Photo viewed", map[string]interface{}{
-		"photo_id":  photoID,
-		"user_id":   userID,
-		"viewer_id": viewerID,
-		"viewed_at": "now",
-	})
 }
\ No newline at end of file
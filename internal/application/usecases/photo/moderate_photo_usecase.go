@@ -0,0 +1,69 @@
+package photo
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/22smeargle/winkr-backend/internal/application/services"
+	"github.com/22smeargle/winkr-backend/internal/domain/repositories"
+	"github.com/22smeargle/winkr-backend/pkg/logger"
+)
+
+// ModeratePhotoRequest represents the request to screen an uploaded photo
+type ModeratePhotoRequest struct {
+	PhotoID     uuid.UUID `json:"photo_id" validate:"required"`
+	UserID      uuid.UUID `json:"user_id" validate:"required"`
+	FileKey     string    `json:"file_key" validate:"required"`
+	MakePrimary bool      `json:"make_primary"`
+}
+
+// ModeratePhotoResponse represents the response after queueing a photo for moderation
+type ModeratePhotoResponse struct {
+	Queued bool `json:"queued"` // False when the photo went straight to human review
+}
+
+// ModeratePhotoUseCase queues uploaded photos to be screened by the photo moderation worker before
+// they go live
+type ModeratePhotoUseCase struct {
+	photoRepo repositories.PhotoRepository
+	queue     services.PhotoModerationQueue
+}
+
+// NewModeratePhotoUseCase creates a new moderate photo use case
+func NewModeratePhotoUseCase(
+	photoRepo repositories.PhotoRepository,
+	queue services.PhotoModerationQueue,
+) *ModeratePhotoUseCase {
+	return &ModeratePhotoUseCase{
+		photoRepo: photoRepo,
+		queue:     queue,
+	}
+}
+
+// Execute queues the photo's S3 object key for moderation. If it can't be queued, the photo is sent
+// to human review instead so it is not left pending.
+func (uc *ModeratePhotoUseCase) Execute(ctx context.Context, req *ModeratePhotoRequest) (*ModeratePhotoResponse, error) {
+	if req.PhotoID == uuid.Nil || req.UserID == uuid.Nil || req.FileKey == "" {
+		return nil, fmt.Errorf("validation failed: photo ID, user ID and file key are required")
+	}
+
+	err := uc.queue.Enqueue(ctx, &services.PhotoModerationJob{
+		PhotoID:     req.PhotoID,
+		UserID:      req.UserID,
+		FileKey:     req.FileKey,
+		MakePrimary: req.MakePrimary,
+		EnqueuedAt:  time.Now(),
+	})
+	if err == nil {
+		return &ModeratePhotoResponse{Queued: true}, nil
+	}
+
+	logger.Error("Failed to queue photo for moderation", err, "photo_id", req.PhotoID)
+	if err := uc.photoRepo.RequestReview(ctx, req.PhotoID, true); err != nil {
+		return nil, fmt.Errorf("failed to send photo to review: %w", err)
+	}
+
+	return &ModeratePhotoResponse{Queued: false}, nil
+}
@@ -0,0 +1,79 @@
+package photo
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/22smeargle/winkr-backend/internal/domain/entities"
+	"github.com/22smeargle/winkr-backend/internal/domain/repositories"
+	"github.com/22smeargle/winkr-backend/pkg/config"
+	"github.com/22smeargle/winkr-backend/pkg/errors"
+	"github.com/22smeargle/winkr-backend/pkg/logger"
+)
+
+// SetPhotoAutoReorderRequest represents a request to opt in or out of photos reordered by engagement
+type SetPhotoAutoReorderRequest struct {
+	UserID  uuid.UUID `json:"user_id" validate:"required"`
+	Enabled bool      `json:"enabled"`
+}
+
+// SetPhotoAutoReorderResponse represents the response after changing the opt-in
+type SetPhotoAutoReorderResponse struct {
+	AutoReorder bool   `json:"auto_reorder"`
+	Message     string `json:"message"`
+}
+
+// SetPhotoAutoReorderUseCase handles opting in or out of having photos reordered by engagement
+type SetPhotoAutoReorderUseCase struct {
+	userRepo repositories.UserRepository
+	config   *config.MatchingPhotoReorderConfig
+}
+
+// NewSetPhotoAutoReorderUseCase creates a new set photo auto reorder use case
+func NewSetPhotoAutoReorderUseCase(userRepo repositories.UserRepository, cfg *config.MatchingPhotoReorderConfig) *SetPhotoAutoReorderUseCase {
+	return &SetPhotoAutoReorderUseCase{
+		userRepo: userRepo,
+		config:   cfg,
+	}
+}
+
+// Execute executes the set photo auto reorder use case. Opting out is always possible, even while
+// reordering is turned off.
+func (uc *SetPhotoAutoReorderUseCase) Execute(ctx context.Context, req *SetPhotoAutoReorderRequest) (*SetPhotoAutoReorderResponse, error) {
+	if req.UserID == uuid.Nil {
+		return nil, fmt.Errorf("validation failed: user ID is required")
+	}
+
+	if req.Enabled && !uc.config.Enabled {
+		return nil, errors.NewAppError(errors.ErrServiceUnavailable.Code, errors.ErrServiceUnavailable.Message, "Photo reordering is not available")
+	}
+
+	preferences, err := uc.userRepo.GetPreferences(ctx, req.UserID)
+	if err != nil {
+		// Create preferences if they don't exist
+		preferences = &entities.UserPreferences{UserID: req.UserID}
+	}
+	preferences.AutoReorderPhotos = req.Enabled
+
+	if preferences.ID == uuid.Nil {
+		err = uc.userRepo.CreatePreferences(ctx, preferences)
+	} else {
+		err = uc.userRepo.UpdatePreferences(ctx, preferences)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to save preferences: %w", err)
+	}
+
+	logger.Info("Photo auto reorder changed", "user_id", req.UserID, "enabled", req.Enabled)
+
+	message := "Photos will no longer be reordered"
+	if req.Enabled {
+		message = "Photos will be reordered by how often viewers like them"
+	}
+
+	return &SetPhotoAutoReorderResponse{
+		AutoReorder: req.Enabled,
+		Message:     message,
+	}, nil
+}
@@ -13,12 +13,14 @@ import (
 type SetPrimaryPhotoRequest struct {
 	UserID  uuid.UUID `json:"user_id" validate:"required"`
 	PhotoID uuid.UUID `json:"photo_id" validate:"required"`
+	Lock    bool      `json:"lock"` // Keep the photo primary when photos are reordered by engagement
 }
 
 // SetPrimaryPhotoResponse represents the response after setting primary photo
 type SetPrimaryPhotoResponse struct {
 	PhotoID    uuid.UUID `json:"photo_id"`
 	IsPrimary   bool      `json:"is_primary"`
+	PrimaryLocked bool    `json:"primary_locked"`
 	UpdatedAt   string    `json:"updated_at"`
 	Message     string    `json:"message"`
 }
@@ -65,9 +67,17 @@ func (uc *SetPrimaryPhotoUseCase) Execute(ctx context.Context, req *SetPrimaryPh
 
 	// Check if photo is already primary
 	if photo.IsPrimary {
+		// Setting it again only locks or unlocks it
+		if photo.PrimaryLocked != req.Lock {
+			if err := uc.photoRepo.LockPrimaryPhoto(ctx, req.UserID, req.Lock); err != nil {
+				return nil, fmt.Errorf("failed to lock primary photo: %w", err)
+			}
+		}
+
 		return &SetPrimaryPhotoResponse{
 			PhotoID:  req.PhotoID,
 			IsPrimary: true,
+			PrimaryLocked: req.Lock,
 			UpdatedAt: photo.UpdatedAt.Format("2006-01-02T15:04:05Z07:00"),
 			Message:   "Photo is already set as primary",
 		}, nil
@@ -78,14 +88,22 @@ func (uc *SetPrimaryPhotoUseCase) Execute(ctx context.Context, req *SetPrimaryPh
 		return nil, fmt.Errorf("failed to set primary photo: %w", err)
 	}
 
+	if req.Lock {
+		if err := uc.photoRepo.LockPrimaryPhoto(ctx, req.UserID, true); err != nil {
+			return nil, fmt.Errorf("failed to lock primary photo: %w", err)
+		}
+	}
+
 	logger.Info("Primary photo set successfully", map[string]interface{}{
 		"photo_id": req.PhotoID,
 		"user_id":  req.UserID,
+		"locked":   req.Lock,
 	})
 
 	return &SetPrimaryPhotoResponse{
 		PhotoID:  req.PhotoID,
 		IsPrimary: true,
+		PrimaryLocked: req.Lock,
 		UpdatedAt: photo.UpdatedAt.Format("2006-01-02T15:04:05Z07:00"),
 		Message:   "Photo set as primary successfully",
 	}, nil
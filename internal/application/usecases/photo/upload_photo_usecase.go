@@ -39,7 +39,8 @@ type UploadPhotoUseCase struct {
 	photoRepo         repositories.PhotoRepository
 	storageService    storage.StorageService
 	imageProcessor    services.ImageProcessingService
-	maxPhotosPerUser int
+	photoLimits       *services.PhotoLimitService
+	moderatePhoto     *ModeratePhotoUseCase
 }
 
 // NewUploadPhotoUseCase creates a new upload photo use case
@@ -47,12 +48,15 @@ func NewUploadPhotoUseCase(
 	photoRepo repositories.PhotoRepository,
 	storageService storage.StorageService,
 	imageProcessor services.ImageProcessingService,
+	photoLimits *services.PhotoLimitService,
+	moderatePhoto *ModeratePhotoUseCase,
 ) *UploadPhotoUseCase {
 	return &UploadPhotoUseCase{
 		photoRepo:         photoRepo,
 		storageService:    storageService,
 		imageProcessor:    imageProcessor,
-		maxPhotosPerUser: 6, // Maximum 6 photos per user
+		photoLimits:       photoLimits,
+		moderatePhoto:     moderatePhoto,
 	}
 }
 
@@ -66,13 +70,8 @@ func (uc *UploadPhotoUseCase) Execute(ctx context.Context, req *UploadPhotoReque
 	}
 
 	// Check user photo limit
-	currentPhotoCount, err := uc.photoRepo.GetUserPhotoCount(ctx, req.UserID)
-	if err != nil {
-		return nil, fmt.Errorf("failed to get user photo count: %w", err)
-	}
-
-	if currentPhotoCount >= uc.maxPhotosPerUser {
-		return nil, fmt.Errorf("maximum photo limit reached (%d photos)", uc.maxPhotosPerUser)
+	if err := uc.photoLimits.CheckProfilePhotoLimit(ctx, req.UserID); err != nil {
+		return nil, err
 	}
 
 	// Validate image
@@ -153,8 +152,15 @@ func (uc *UploadPhotoUseCase) Execute(ctx context.Context, req *UploadPhotoReque
 		UpdatedAt:         time.Now(),
 	}
 
+	// A screened photo stays hidden until approved and only then becomes primary, so the current
+	// primary photo stays active if it is rejected
+	if uc.moderatePhoto != nil {
+		photo.IsHidden = true
+		photo.IsPrimary = false
+	}
+
 	// If this is set as primary, unset other primary photos
-	if req.IsPrimary {
+	if photo.IsPrimary {
 		if err := uc.photoRepo.UnsetPrimaryPhoto(ctx, req.UserID); err != nil {
 			// Clean up uploaded files if database operation fails
 			_ = uc.storageService.DeleteFile(ctx, originalKey)
@@ -177,6 +183,19 @@ func (uc *UploadPhotoUseCase) Execute(ctx context.Context, req *UploadPhotoReque
 		return nil, fmt.Errorf("failed to save photo: %w", err)
 	}
 
+	if uc.moderatePhoto != nil {
+		if _, err := uc.moderatePhoto.Execute(ctx, &ModeratePhotoRequest{
+			PhotoID:     photo.ID,
+			UserID:      req.UserID,
+			FileKey:     processedKey,
+			MakePrimary: req.IsPrimary,
+		}); err != nil {
+			logger.Error("Failed to moderate photo", err, map[string]interface{}{
+				"photo_id": photo.ID,
+			})
+		}
+	}
+
 	processingTime := time.Since(startTime).Milliseconds()
 
 	logger.Info("Photo uploaded successfully", map[string]interface{}{
@@ -191,7 +210,7 @@ func (uc *UploadPhotoUseCase) Execute(ctx context.Context, req *UploadPhotoReque
 		PhotoID:        photo.ID,
 		FileURL:         processedURL,
 		ThumbnailURL:    thumbnailURL,
-		IsPrimary:       req.IsPrimary,
+		IsPrimary:       photo.IsPrimary,
 		VerificationStatus: photo.VerificationStatus,
 		ProcessingTime:   processingTime,
 	}, nil
@@ -170,7 +170,7 @@ func TestNewUploadPhotoUseCase(t *testing.T) {
 	mockStorage := &MockStorageService{}
 	mockProcessor := &MockImageProcessor{}
 
-	useCase := NewUploadPhotoUseCase(mockRepo, mockStorage, mockProcessor)
+	useCase := NewUploadPhotoUseCase(mockRepo, mockStorage, mockProcessor, nil, nil)
 	assert.NotNil(t, useCase)
 	assert.Equal(t, mockRepo, useCase.photoRepo)
 	assert.Equal(t, mockStorage, useCase.storageService)
@@ -182,7 +182,7 @@ func TestUploadPhotoUseCase_Execute(t *testing.T) {
 	mockStorage := &MockStorageService{}
 	mockProcessor := &MockImageProcessor{}
 
-	useCase := NewUploadPhotoUseCase(mockRepo, mockStorage, mockProcessor)
+	useCase := NewUploadPhotoUseCase(mockRepo, mockStorage, mockProcessor, nil, nil)
 
 	userID := uuid.New()
 	imageData := []byte("test image data")
@@ -370,7 +370,7 @@ func TestUploadPhotoUseCase_Execute_WithVerificationPhoto(t *testing.T) {
 	mockStorage := &MockStorageService{}
 	mockProcessor := &MockImageProcessor{}
 
-	useCase := NewUploadPhotoUseCase(mockRepo, mockStorage, mockProcessor)
+	useCase := NewUploadPhotoUseCase(mockRepo, mockStorage, mockProcessor, nil, nil)
 
 	userID := uuid.New()
 	imageData := []byte("test image data")
@@ -417,7 +417,7 @@ func TestUploadPhotoUseCase_Execute_WithEphemeralPhoto(t *testing.T) {
 	mockStorage := &MockStorageService{}
 	mockProcessor := &MockImageProcessor{}
 
-	useCase := NewUploadPhotoUseCase(mockRepo, mockStorage, mockProcessor)
+	useCase := NewUploadPhotoUseCase(mockRepo, mockStorage, mockProcessor, nil, nil)
 
 	userID := uuid.New()
 	imageData := []byte("test image data")
@@ -0,0 +1,112 @@
+package profile
+
+import (
+	"context"
+	"encoding/hex"
+	"fmt"
+	"strings"
+
+	"github.com/google/uuid"
+	"github.com/22smeargle/winkr-backend/internal/domain/repositories"
+	"github.com/22smeargle/winkr-backend/pkg/config"
+	"github.com/22smeargle/winkr-backend/pkg/errors"
+	"github.com/22smeargle/winkr-backend/pkg/logger"
+)
+
+// BlockContactsUseCase blocks the registered users among a user's imported contacts, so neither
+// sees the other in discovery. Contacts arrive hashed and the response never tells which of them
+// are on the app.
+type BlockContactsUseCase struct {
+	userRepo  repositories.UserRepository
+	blockRepo repositories.UserBlockRepository
+	config    *config.MatchingBlockContactsConfig
+}
+
+// NewBlockContactsUseCase creates a new BlockContactsUseCase instance
+func NewBlockContactsUseCase(
+	userRepo repositories.UserRepository,
+	blockRepo repositories.UserBlockRepository,
+	cfg *config.MatchingBlockContactsConfig,
+) *BlockContactsUseCase {
+	return &BlockContactsUseCase{
+		userRepo:  userRepo,
+		blockRepo: blockRepo,
+		config:    cfg,
+	}
+}
+
+// BlockContactsRequest represents a contact import. Each hash is the hex SHA-256 of a trimmed,
+// lowercased email address or of an E.164 phone number.
+type BlockContactsRequest struct {
+	UserID        uuid.UUID `json:"user_id"`
+	ContactHashes []string  `json:"contact_hashes"`
+}
+
+// BlockContactsResponse reports how many distinct contacts were processed, whether or not they
+// belong to a user
+type BlockContactsResponse struct {
+	Processed int `json:"processed"`
+}
+
+// Execute blocks every user whose contact hash was imported. Contacts that match no one, and the
+// user's own contact, are silently ignored.
+func (uc *BlockContactsUseCase) Execute(ctx context.Context, req *BlockContactsRequest) (*BlockContactsResponse, error) {
+	hashes, err := uc.normalizeHashes(req.ContactHashes)
+	if err != nil {
+		return nil, err
+	}
+
+	userIDs, err := uc.userRepo.GetIDsByEmailHashes(ctx, hashes)
+	if err != nil {
+		return nil, errors.WrapError(err, "Failed to import contacts")
+	}
+
+	blockedIDs := make([]uuid.UUID, 0, len(userIDs))
+	for _, id := range userIDs {
+		if id != req.UserID {
+			blockedIDs = append(blockedIDs, id)
+		}
+	}
+
+	if err := uc.blockRepo.BlockMany(ctx, req.UserID, blockedIDs); err != nil {
+		return nil, errors.WrapError(err, "Failed to import contacts")
+	}
+
+	logger.Info("Contacts imported for blocking", "user_id", req.UserID, "contacts", len(hashes))
+	return &BlockContactsResponse{Processed: len(hashes)}, nil
+}
+
+// normalizeHashes lowercases and dedupes the hashes, rejecting any that is not a hex SHA-256
+func (uc *BlockContactsUseCase) normalizeHashes(contactHashes []string) ([]string, error) {
+	if len(contactHashes) == 0 {
+		return nil, errors.NewValidationError("contact_hashes", "At least one contact is required")
+	}
+	if uc.config.MaxPerImport > 0 && len(contactHashes) > uc.config.MaxPerImport {
+		return nil, errors.NewValidationError("contact_hashes", fmt.Sprintf("At most %d contacts can be imported at once", uc.config.MaxPerImport))
+	}
+
+	seen := make(map[string]bool, len(contactHashes))
+	hashes := make([]string, 0, len(contactHashes))
+	for _, hash := range contactHashes {
+		hash = strings.ToLower(strings.TrimSpace(hash))
+		if !isSHA256Hex(hash) {
+			return nil, errors.NewValidationError("contact_hashes", "Contacts must be hex encoded SHA-256 hashes")
+		}
+		if seen[hash] {
+			continue
+		}
+		seen[hash] = true
+		hashes = append(hashes, hash)
+	}
+
+	return hashes, nil
+}
+
+// isSHA256Hex reports whether s is a hex encoded SHA-256 digest
+func isSHA256Hex(s string) bool {
+	if len(s) != hex.EncodedLen(32) {
+		return false
+	}
+	_, err := hex.DecodeString(s)
+	return err == nil
+}
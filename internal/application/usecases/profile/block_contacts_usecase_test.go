@@ -0,0 +1,161 @@
+package profile
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/22smeargle/winkr-backend/internal/domain/entities"
+	"github.com/22smeargle/winkr-backend/internal/domain/repositories"
+	"github.com/22smeargle/winkr-backend/pkg/config"
+	"github.com/22smeargle/winkr-backend/pkg/errors"
+)
+
+// emailUserRepository matches email hashes against its users like the database does
+type emailUserRepository struct {
+	repositories.UserRepository
+	users []*entities.User
+}
+
+func (r *emailUserRepository) GetIDsByEmailHashes(ctx context.Context, hashes []string) ([]uuid.UUID, error) {
+	wanted := make(map[string]bool, len(hashes))
+	for _, hash := range hashes {
+		wanted[hash] = true
+	}
+
+	var ids []uuid.UUID
+	for _, user := range r.users {
+		if wanted[hashContact(user.Email)] {
+			ids = append(ids, user.ID)
+		}
+	}
+	return ids, nil
+}
+
+// inMemoryUserBlockRepository records blocks by blocker
+type inMemoryUserBlockRepository struct {
+	repositories.UserBlockRepository
+	blocks map[uuid.UUID]map[uuid.UUID]bool
+}
+
+func (r *inMemoryUserBlockRepository) BlockMany(ctx context.Context, blockerID uuid.UUID, blockedIDs []uuid.UUID) error {
+	if r.blocks[blockerID] == nil {
+		r.blocks[blockerID] = map[uuid.UUID]bool{}
+	}
+	for _, blockedID := range blockedIDs {
+		r.blocks[blockerID][blockedID] = true
+	}
+	return nil
+}
+
+func (r *inMemoryUserBlockRepository) GetBlockedUserIDs(ctx context.Context, userID uuid.UUID) ([]uuid.UUID, error) {
+	var ids []uuid.UUID
+	for blockerID, blocked := range r.blocks {
+		for blockedID := range blocked {
+			if blockerID == userID {
+				ids = append(ids, blockedID)
+			} else if blockedID == userID {
+				ids = append(ids, blockerID)
+			}
+		}
+	}
+	return ids, nil
+}
+
+func hashContact(contact string) string {
+	sum := sha256.Sum256([]byte(strings.ToLower(strings.TrimSpace(contact))))
+	return hex.EncodeToString(sum[:])
+}
+
+type blockContactsFixture struct {
+	blocks  *inMemoryUserBlockRepository
+	useCase *BlockContactsUseCase
+	me      *entities.User
+	ex      *entities.User
+}
+
+func newBlockContactsFixture(cfg *config.MatchingBlockContactsConfig) *blockContactsFixture {
+	f := &blockContactsFixture{
+		blocks: &inMemoryUserBlockRepository{blocks: map[uuid.UUID]map[uuid.UUID]bool{}},
+		me:     &entities.User{ID: uuid.New(), Email: "ana@example.com"},
+		ex:     &entities.User{ID: uuid.New(), Email: "Sam@Example.com"},
+	}
+	users := &emailUserRepository{users: []*entities.User{f.me, f.ex, {ID: uuid.New(), Email: "kim@example.com"}}}
+	f.useCase = NewBlockContactsUseCase(users, f.blocks, cfg)
+	return f
+}
+
+func TestBlockContactsUseCase_BlocksContactsWhoAreUsers(t *testing.T) {
+	ctx := context.Background()
+	f := newBlockContactsFixture(&config.MatchingBlockContactsConfig{MaxPerImport: 10})
+
+	response, err := f.useCase.Execute(ctx, &BlockContactsRequest{
+		UserID:        f.me.ID,
+		ContactHashes: []string{strings.ToUpper(hashContact(" sam@example.com "))},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, 1, response.Processed)
+
+	// Neither user sees the other in discovery
+	blocked, err := f.blocks.GetBlockedUserIDs(ctx, f.me.ID)
+	require.NoError(t, err)
+	assert.Equal(t, []uuid.UUID{f.ex.ID}, blocked)
+
+	blocked, err = f.blocks.GetBlockedUserIDs(ctx, f.ex.ID)
+	require.NoError(t, err)
+	assert.Equal(t, []uuid.UUID{f.me.ID}, blocked)
+}
+
+func TestBlockContactsUseCase_IgnoresNonMatchesSilently(t *testing.T) {
+	ctx := context.Background()
+	f := newBlockContactsFixture(&config.MatchingBlockContactsConfig{MaxPerImport: 10})
+
+	onApp, err := f.useCase.Execute(ctx, &BlockContactsRequest{
+		UserID:        f.me.ID,
+		ContactHashes: []string{hashContact("sam@example.com"), hashContact("+15551234567")},
+	})
+	require.NoError(t, err)
+
+	notOnApp, err := f.useCase.Execute(ctx, &BlockContactsRequest{
+		UserID:        f.me.ID,
+		ContactHashes: []string{hashContact("nobody@example.com"), hashContact("ana@example.com"), hashContact("nobody@example.com")},
+	})
+	require.NoError(t, err)
+
+	// The response does not tell which contacts are on the app
+	assert.Equal(t, onApp, &BlockContactsResponse{Processed: 2})
+	assert.Equal(t, notOnApp, &BlockContactsResponse{Processed: 2})
+
+	// Unknown contacts and the user's own contact are not blocked
+	blocked, err := f.blocks.GetBlockedUserIDs(ctx, f.me.ID)
+	require.NoError(t, err)
+	assert.Equal(t, []uuid.UUID{f.ex.ID}, blocked)
+}
+
+func TestBlockContactsUseCase_RejectsInvalidImports(t *testing.T) {
+	ctx := context.Background()
+	f := newBlockContactsFixture(&config.MatchingBlockContactsConfig{MaxPerImport: 2})
+
+	for name, hashes := range map[string][]string{
+		"empty":     {},
+		"too many":  {hashContact("a@example.com"), hashContact("b@example.com"), hashContact("c@example.com")},
+		"plaintext": {"sam@example.com"},
+		"not hex":   {strings.Repeat("z", 64)},
+	} {
+		t.Run(name, func(t *testing.T) {
+			_, err := f.useCase.Execute(ctx, &BlockContactsRequest{UserID: f.me.ID, ContactHashes: hashes})
+
+			var appErr *errors.AppError
+			require.ErrorAs(t, err, &appErr)
+			assert.Equal(t, http.StatusBadRequest, appErr.Code)
+		})
+	}
+
+	assert.Empty(t, f.blocks.blocks)
+}
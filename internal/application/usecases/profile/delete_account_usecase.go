@@ -9,6 +9,11 @@ import (
 	"github.com/22smeargle/winkr-backend/pkg/errors"
 )
 
+// AccountBillingCanceler stops all billing for an account that is being deleted
+type AccountBillingCanceler interface {
+	CancelAccountBilling(ctx context.Context, userID uuid.UUID, reason string) error
+}
+
 // DeleteAccountUseCase handles deleting user account
 type DeleteAccountUseCase struct {
 	userRepo       repositories.UserRepository
@@ -16,7 +21,7 @@ type DeleteAccountUseCase struct {
 	matchRepo      repositories.MatchRepository
 	messageRepo    repositories.MessageRepository
 	reportRepo     repositories.ReportRepository
-	subscriptionRepo repositories.SubscriptionRepository
+	billingService AccountBillingCanceler
 	cacheService   ProfileCacheService
 	authService    AuthService
 }
@@ -28,7 +33,7 @@ func NewDeleteAccountUseCase(
 	matchRepo repositories.MatchRepository,
 	messageRepo repositories.MessageRepository,
 	reportRepo repositories.ReportRepository,
-	subscriptionRepo repositories.SubscriptionRepository,
+	billingService AccountBillingCanceler,
 	cacheService ProfileCacheService,
 	authService AuthService,
 ) *DeleteAccountUseCase {
@@ -38,7 +43,7 @@ func NewDeleteAccountUseCase(
 		matchRepo:      matchRepo,
 		messageRepo:    messageRepo,
 		reportRepo:     reportRepo,
-		subscriptionRepo: subscriptionRepo,
+		billingService: billingService,
 		cacheService:   cacheService,
 		authService:    authService,
 	}
@@ -85,8 +90,9 @@ func (uc *DeleteAccountUseCase) Execute(ctx context.Context, req *DeleteAccountR
 	// This is a critical operation, so we'll use a transaction-like approach
 	// In a real implementation, you'd use database transactions
 
-	// 1. Cancel active subscriptions
-	if err := uc.cancelSubscriptions(ctx, req.UserID); err != nil {
+	// 1. Stop billing: cancel Stripe subscriptions and remove the Stripe customer.
+	// Deletion is aborted if Stripe can't be reached so the user is never left billable.
+	if err := uc.billingService.CancelAccountBilling(ctx, req.UserID, req.Reason); err != nil {
 		return nil, errors.WrapError(err, "Failed to cancel subscriptions")
 	}
 
@@ -130,26 +136,6 @@ func (uc *DeleteAccountUseCase) Execute(ctx context.Context, req *DeleteAccountR
 	}, nil
 }
 
-// cancelSubscriptions cancels all active subscriptions for the user
-func (uc *DeleteAccountUseCase) cancelSubscriptions(ctx context.Context, userID uuid.UUID) error {
-	// Get active subscriptions
-	subscriptions, err := uc.subscriptionRepo.GetUserSubscriptions(ctx, userID)
-	if err != nil {
-		return err
-	}
-
-	// Cancel each subscription
-	for _, subscription := range subscriptions {
-		if subscription.Status == "active" {
-			if err := uc.subscriptionRepo.CancelSubscription(ctx, subscription.ID); err != nil {
-				return err
-			}
-		}
-	}
-
-	return nil
-}
-
 // deleteUserPhotos deletes all photos associated with the user
 func (uc *DeleteAccountUseCase) deleteUserPhotos(ctx context.Context, userID uuid.UUID) error {
 	photos, err := uc.photoRepo.GetUserPhotos(ctx, userID, false)
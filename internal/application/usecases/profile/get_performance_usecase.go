@@ -0,0 +1,196 @@
+package profile
+
+import (
+	"context"
+	"math"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/22smeargle/winkr-backend/internal/domain/entities"
+	"github.com/22smeargle/winkr-backend/internal/domain/repositories"
+	"github.com/22smeargle/winkr-backend/pkg/config"
+	"github.com/22smeargle/winkr-backend/pkg/errors"
+	"github.com/22smeargle/winkr-backend/pkg/logger"
+)
+
+// Comparisons of a profile's like rate with its cohort
+const (
+	PerformanceAboveAverage = "above_average"
+	PerformanceAverage      = "average"
+	PerformanceBelowAverage = "below_average"
+)
+
+// performanceAverageMargin is how many percentage points a like rate may differ from the cohort and still count as average
+const performanceAverageMargin = 5.0
+
+// minPerformancePhotos and minPerformanceBioLength are the profile basics suggestions point out
+const (
+	minPerformancePhotos    = 3
+	minPerformanceBioLength = 50
+)
+
+// PerformanceCache caches computed profile performance
+type PerformanceCache interface {
+	GetPerformance(ctx context.Context, userID uuid.UUID) (*ProfilePerformanceResponse, error)
+	SetPerformance(ctx context.Context, userID uuid.UUID, performance *ProfilePerformanceResponse, ttl time.Duration) error
+}
+
+// GetPerformanceUseCase shows users how others respond to their profile
+type GetPerformanceUseCase struct {
+	userRepo  repositories.UserRepository
+	photoRepo repositories.PhotoRepository
+	matchRepo repositories.MatchRepository
+	cache     PerformanceCache
+	config    *config.MatchingPerformanceConfig
+	now       func() time.Time
+}
+
+// NewGetPerformanceUseCase creates a new GetPerformanceUseCase instance
+func NewGetPerformanceUseCase(
+	userRepo repositories.UserRepository,
+	photoRepo repositories.PhotoRepository,
+	matchRepo repositories.MatchRepository,
+	cache PerformanceCache,
+	cfg *config.MatchingPerformanceConfig,
+) *GetPerformanceUseCase {
+	return &GetPerformanceUseCase{
+		userRepo:  userRepo,
+		photoRepo: photoRepo,
+		matchRepo: matchRepo,
+		cache:     cache,
+		config:    cfg,
+		now:       time.Now,
+	}
+}
+
+// ProfilePerformanceResponse represents how often other users like or pass on a profile.
+// It only holds aggregates and never identifies who swiped.
+type ProfilePerformanceResponse struct {
+	WindowDays     int      `json:"window_days"`
+	TotalSwipes    int64    `json:"total_swipes"`
+	EnoughData     bool     `json:"enough_data"`
+	LikeRate       *float64 `json:"like_rate,omitempty"`
+	PassRate       *float64 `json:"pass_rate,omitempty"`
+	CohortLikeRate *float64 `json:"cohort_like_rate,omitempty"`
+	Comparison     string   `json:"comparison,omitempty"`
+	Suggestions    []string `json:"suggestions"`
+	CalculatedAt   string   `json:"calculated_at"`
+}
+
+// Execute handles the get performance use case
+func (uc *GetPerformanceUseCase) Execute(ctx context.Context, userID uuid.UUID) (*ProfilePerformanceResponse, error) {
+	// Try to get from cache first
+	cached, err := uc.cache.GetPerformance(ctx, userID)
+	if err == nil && cached != nil {
+		return cached, nil
+	}
+
+	user, err := uc.userRepo.GetByID(ctx, userID)
+	if err != nil {
+		return nil, errors.ErrUserNotFound
+	}
+
+	if !user.IsActive || user.IsBanned {
+		return nil, errors.ErrUserNotFound
+	}
+
+	now := uc.now()
+	since := now.Add(-uc.config.Window)
+
+	counts, err := uc.matchRepo.GetIncomingSwipeCounts(ctx, userID, since)
+	if err != nil {
+		return nil, errors.WrapError(err, "Failed to get incoming swipes")
+	}
+
+	response := &ProfilePerformanceResponse{
+		WindowDays:   int(uc.config.Window.Hours() / 24),
+		TotalSwipes:  counts.Likes + counts.Passes,
+		CalculatedAt: now.Format("2006-01-02T15:04:05Z07:00"),
+	}
+
+	// With only a few swipes the ratio would tell the user how individual people swiped
+	if response.TotalSwipes > 0 && response.TotalSwipes >= int64(uc.config.MinSwipes) {
+		likeRate := roundRate(float64(counts.Likes) / float64(response.TotalSwipes) * 100)
+		passRate := roundRate(100 - likeRate)
+		response.EnoughData = true
+		response.LikeRate = &likeRate
+		response.PassRate = &passRate
+
+		cohortRate, err := uc.matchRepo.GetCohortLikeRate(ctx, uc.cohort(user), since, uc.config.MinSwipes)
+		if err != nil {
+			return nil, errors.WrapError(err, "Failed to get cohort like rate")
+		}
+		if cohortRate > 0 {
+			cohortRate = roundRate(cohortRate)
+			response.CohortLikeRate = &cohortRate
+			response.Comparison = comparePerformance(likeRate, cohortRate)
+		}
+	}
+
+	photos, err := uc.photoRepo.GetUserPhotos(ctx, userID, false)
+	if err != nil {
+		return nil, errors.WrapError(err, "Failed to get user photos")
+	}
+	response.Suggestions = performanceSuggestions(user, photos, response.Comparison)
+
+	if err := uc.cache.SetPerformance(ctx, userID, response, uc.config.CacheTTL); err != nil {
+		logger.Warn("Failed to cache profile performance", err, "user_id", userID)
+	}
+
+	return response, nil
+}
+
+// cohort returns the users of the same gender and a similar age as the user
+func (uc *GetPerformanceUseCase) cohort(user *entities.User) repositories.SwipeCohort {
+	return repositories.SwipeCohort{
+		Gender:     user.Gender,
+		BornAfter:  user.DateOfBirth.AddDate(-uc.config.CohortAgeRange, 0, 0),
+		BornBefore: user.DateOfBirth.AddDate(uc.config.CohortAgeRange, 0, 0),
+	}
+}
+
+// comparePerformance compares a like rate with the cohort average
+func comparePerformance(likeRate, cohortRate float64) string {
+	switch {
+	case likeRate > cohortRate+performanceAverageMargin:
+		return PerformanceAboveAverage
+	case likeRate < cohortRate-performanceAverageMargin:
+		return PerformanceBelowAverage
+	default:
+		return PerformanceAverage
+	}
+}
+
+// performanceSuggestions suggests profile changes that tend to get more likes
+func performanceSuggestions(user *entities.User, photos []*entities.Photo, comparison string) []string {
+	suggestions := []string{}
+
+	approved := 0
+	for _, photo := range photos {
+		if photo.VerificationStatus == "approved" {
+			approved++
+		}
+	}
+	if approved < minPerformancePhotos {
+		suggestions = append(suggestions, "Add at least 3 photos. Profiles with more photos get more likes.")
+	}
+
+	if user.Bio == nil || len(*user.Bio) < minPerformanceBioLength {
+		suggestions = append(suggestions, "Write a longer bio so people have something to start a conversation with.")
+	}
+
+	if !user.IsVerified {
+		suggestions = append(suggestions, "Verify your profile. Verified profiles are trusted more.")
+	}
+
+	if comparison == PerformanceBelowAverage {
+		suggestions = append(suggestions, "Try a different primary photo that clearly shows your face.")
+	}
+
+	return suggestions
+}
+
+// roundRate rounds a percentage to one decimal place
+func roundRate(rate float64) float64 {
+	return math.Round(rate*10) / 10
+}
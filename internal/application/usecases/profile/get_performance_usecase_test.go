@@ -0,0 +1,151 @@
+package profile
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"github.com/22smeargle/winkr-backend/internal/domain/entities"
+	"github.com/22smeargle/winkr-backend/internal/domain/repositories"
+	"github.com/22smeargle/winkr-backend/pkg/config"
+)
+
+func (m *MockMatchRepository) GetIncomingSwipeCounts(ctx context.Context, userID uuid.UUID, since time.Time) (*repositories.IncomingSwipeCounts, error) {
+	args := m.Called(ctx, userID, since)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*repositories.IncomingSwipeCounts), args.Error(1)
+}
+
+func (m *MockMatchRepository) GetCohortLikeRate(ctx context.Context, cohort repositories.SwipeCohort, since time.Time, minSwipes int) (float64, error) {
+	args := m.Called(ctx, cohort, since, minSwipes)
+	return args.Get(0).(float64), args.Error(1)
+}
+
+// memoryPerformanceCache is an in-memory PerformanceCache
+type memoryPerformanceCache struct {
+	entries map[uuid.UUID]*ProfilePerformanceResponse
+}
+
+func (c *memoryPerformanceCache) GetPerformance(ctx context.Context, userID uuid.UUID) (*ProfilePerformanceResponse, error) {
+	return c.entries[userID], nil
+}
+
+func (c *memoryPerformanceCache) SetPerformance(ctx context.Context, userID uuid.UUID, performance *ProfilePerformanceResponse, ttl time.Duration) error {
+	c.entries[userID] = performance
+	return nil
+}
+
+func testPerformanceConfig() *config.MatchingPerformanceConfig {
+	return &config.MatchingPerformanceConfig{
+		Window:         30 * 24 * time.Hour,
+		MinSwipes:      20,
+		CohortAgeRange: 3,
+		CacheTTL:       time.Hour,
+	}
+}
+
+func TestGetPerformanceUseCase_ComputesRatio(t *testing.T) {
+	ctx := context.Background()
+	user := newPreviewTestUser()
+	user.IsVerified = false
+
+	userRepo := new(MockUserRepository)
+	photoRepo := new(MockPhotoRepository)
+	matchRepo := new(MockMatchRepository)
+	cache := &memoryPerformanceCache{entries: make(map[uuid.UUID]*ProfilePerformanceResponse)}
+
+	photos := []*entities.Photo{
+		{ID: uuid.New(), UserID: user.ID, VerificationStatus: "approved"},
+		{ID: uuid.New(), UserID: user.ID, VerificationStatus: "approved"},
+		{ID: uuid.New(), UserID: user.ID, VerificationStatus: "approved"},
+	}
+
+	userRepo.On("GetByID", mock.Anything, user.ID).Return(user, nil)
+	photoRepo.On("GetUserPhotos", mock.Anything, user.ID, false).Return(photos, nil)
+	matchRepo.On("GetIncomingSwipeCounts", mock.Anything, user.ID, mock.Anything).
+		Return(&repositories.IncomingSwipeCounts{Likes: 30, Passes: 70}, nil).Once()
+	matchRepo.On("GetCohortLikeRate", mock.Anything, repositories.SwipeCohort{
+		Gender:     "female",
+		BornAfter:  user.DateOfBirth.AddDate(-3, 0, 0),
+		BornBefore: user.DateOfBirth.AddDate(3, 0, 0),
+	}, mock.Anything, 20).Return(42.0, nil).Once()
+
+	useCase := NewGetPerformanceUseCase(userRepo, photoRepo, matchRepo, cache, testPerformanceConfig())
+
+	performance, err := useCase.Execute(ctx, user.ID)
+	require.NoError(t, err)
+
+	assert.Equal(t, 30, performance.WindowDays)
+	assert.Equal(t, int64(100), performance.TotalSwipes)
+	assert.True(t, performance.EnoughData)
+	require.NotNil(t, performance.LikeRate)
+	assert.Equal(t, 30.0, *performance.LikeRate)
+	assert.Equal(t, 70.0, *performance.PassRate)
+	require.NotNil(t, performance.CohortLikeRate)
+	assert.Equal(t, 42.0, *performance.CohortLikeRate)
+	assert.Equal(t, PerformanceBelowAverage, performance.Comparison)
+	assert.Equal(t, []string{
+		"Verify your profile. Verified profiles are trusted more.",
+		"Try a different primary photo that clearly shows your face.",
+	}, performance.Suggestions)
+
+	// Only aggregates are returned, nothing that identifies the user or who swiped
+	body, err := json.Marshal(performance)
+	require.NoError(t, err)
+	var fields map[string]interface{}
+	require.NoError(t, json.Unmarshal(body, &fields))
+	assert.ElementsMatch(t, []string{
+		"window_days", "total_swipes", "enough_data", "like_rate", "pass_rate",
+		"cohort_like_rate", "comparison", "suggestions", "calculated_at",
+	}, keys(fields))
+	assert.NotContains(t, string(body), user.ID.String())
+
+	// The second request is served from the cache
+	cached, err := useCase.Execute(ctx, user.ID)
+	require.NoError(t, err)
+	assert.Equal(t, performance, cached)
+	matchRepo.AssertExpectations(t)
+}
+
+func TestGetPerformanceUseCase_HidesRatioWithFewSwipes(t *testing.T) {
+	user := newPreviewTestUser()
+
+	userRepo := new(MockUserRepository)
+	photoRepo := new(MockPhotoRepository)
+	matchRepo := new(MockMatchRepository)
+	cache := &memoryPerformanceCache{entries: make(map[uuid.UUID]*ProfilePerformanceResponse)}
+
+	userRepo.On("GetByID", mock.Anything, user.ID).Return(user, nil)
+	photoRepo.On("GetUserPhotos", mock.Anything, user.ID, false).Return([]*entities.Photo{}, nil)
+	matchRepo.On("GetIncomingSwipeCounts", mock.Anything, user.ID, mock.Anything).
+		Return(&repositories.IncomingSwipeCounts{Likes: 3, Passes: 2}, nil)
+
+	useCase := NewGetPerformanceUseCase(userRepo, photoRepo, matchRepo, cache, testPerformanceConfig())
+
+	performance, err := useCase.Execute(context.Background(), user.ID)
+	require.NoError(t, err)
+
+	assert.Equal(t, int64(5), performance.TotalSwipes)
+	assert.False(t, performance.EnoughData)
+	assert.Nil(t, performance.LikeRate)
+	assert.Nil(t, performance.PassRate)
+	assert.Nil(t, performance.CohortLikeRate)
+	assert.Empty(t, performance.Comparison)
+	assert.Contains(t, performance.Suggestions, "Add at least 3 photos. Profiles with more photos get more likes.")
+	matchRepo.AssertNotCalled(t, "GetCohortLikeRate", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
+
+func keys(fields map[string]interface{}) []string {
+	result := make([]string, 0, len(fields))
+	for key := range fields {
+		result = append(result, key)
+	}
+	return result
+}
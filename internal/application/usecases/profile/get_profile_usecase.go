@@ -43,6 +43,7 @@ type GetProfileResponse struct {
 	DateOfBirth    string       `json:"date_of_birth"`
 	Gender         string       `json:"gender"`
 	InterestedIn   []string     `json:"interested_in"`
+	Interests      []string     `json:"interests"`
 	Bio            *string      `json:"bio"`
 	Location       *Location    `json:"location"`
 	IsVerified     bool         `json:"is_verified"`
@@ -78,6 +79,7 @@ type Preferences struct {
 	AgeMax      int  `json:"age_max"`
 	MaxDistance int  `json:"max_distance"`
 	ShowMe      bool `json:"show_me"`
+	HiddenFields []string `json:"hidden_fields"` // profile fields hidden from other users
 }
 
 // ProfileStats represents user profile statistics
@@ -85,7 +87,7 @@ type ProfileStats struct {
 	ProfileViews    int64 `json:"profile_views"`
 	PhotosCount     int64 `json:"photos_count"`
 	ProfileComplete bool  `json:"profile_complete"`
-	LastActive     string `json:"last_active"`
+	LastActive     string `json:"last_active,omitempty"`
 }
 
 // Execute handles the get profile use case
@@ -134,6 +136,7 @@ func (uc *GetProfileUseCase) Execute(ctx context.Context, userID uuid.UUID) (*Ge
 		DateOfBirth:   user.DateOfBirth.Format("2006-01-02"),
 		Gender:        user.Gender,
 		InterestedIn:  user.InterestedIn,
+		Interests:     user.Interests,
 		Bio:           user.Bio,
 		Location: &Location{
 			Lat:      user.LocationLat,
@@ -167,6 +170,7 @@ func (uc *GetProfileUseCase) Execute(ctx context.Context, userID uuid.UUID) (*Ge
 			AgeMax:      preferences.AgeMax,
 			MaxDistance: preferences.MaxDistance,
 			ShowMe:      preferences.ShowMe,
+			HiddenFields: preferences.HiddenFields,
 		}
 	}
 
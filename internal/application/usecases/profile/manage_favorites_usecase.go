@@ -0,0 +1,197 @@
+package profile
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/22smeargle/winkr-backend/internal/domain/entities"
+	"github.com/22smeargle/winkr-backend/internal/domain/repositories"
+	"github.com/22smeargle/winkr-backend/pkg/config"
+	"github.com/22smeargle/winkr-backend/pkg/errors"
+	"github.com/22smeargle/winkr-backend/pkg/logger"
+)
+
+// ManageFavoritesUseCase lets users save profiles to revisit later. Favorites are private
+// bookmarks, separate from likes, and have no effect on matching or discovery.
+type ManageFavoritesUseCase struct {
+	favoriteRepo     repositories.FavoriteRepository
+	userRepo         repositories.UserRepository
+	photoRepo        repositories.PhotoRepository
+	subscriptionRepo repositories.SubscriptionRepository
+	config           *config.MatchingFavoritesConfig
+}
+
+// NewManageFavoritesUseCase creates a new ManageFavoritesUseCase instance
+func NewManageFavoritesUseCase(
+	favoriteRepo repositories.FavoriteRepository,
+	userRepo repositories.UserRepository,
+	photoRepo repositories.PhotoRepository,
+	subscriptionRepo repositories.SubscriptionRepository,
+	cfg *config.MatchingFavoritesConfig,
+) *ManageFavoritesUseCase {
+	return &ManageFavoritesUseCase{
+		favoriteRepo:     favoriteRepo,
+		userRepo:         userRepo,
+		photoRepo:        photoRepo,
+		subscriptionRepo: subscriptionRepo,
+		config:           cfg,
+	}
+}
+
+// FavoritesResponse represents a page of a user's favorites
+type FavoritesResponse struct {
+	Favorites []*FavoriteProfile `json:"favorites"`
+	Total     int64              `json:"total"`
+}
+
+// FavoriteProfile represents a favorited user
+type FavoriteProfile struct {
+	User        *User  `json:"user"`
+	FavoritedAt string `json:"favorited_at"`
+}
+
+// Add favorites a user. Favoriting a user again is a no-op and does not count towards the limit.
+func (uc *ManageFavoritesUseCase) Add(ctx context.Context, userID, favoriteUserID uuid.UUID) error {
+	if userID == favoriteUserID {
+		return errors.NewAppError(errors.ErrInvalidOperation.Code, errors.ErrInvalidOperation.Message, "You cannot favorite your own profile")
+	}
+
+	favoriteUser, err := uc.userRepo.GetByID(ctx, favoriteUserID)
+	if err != nil || !isListable(favoriteUser) {
+		return errors.ErrUserNotFound
+	}
+
+	exists, err := uc.favoriteRepo.Exists(ctx, userID, favoriteUserID)
+	if err != nil {
+		return errors.WrapError(err, "Failed to check favorite")
+	}
+	if exists {
+		return nil
+	}
+
+	if err := uc.checkLimit(ctx, userID); err != nil {
+		return err
+	}
+
+	if err := uc.favoriteRepo.Create(ctx, &entities.Favorite{UserID: userID, FavoriteUserID: favoriteUserID}); err != nil {
+		return errors.WrapError(err, "Failed to add favorite")
+	}
+
+	logger.Info("Profile favorited", "user_id", userID, "favorite_user_id", favoriteUserID)
+	return nil
+}
+
+// Remove removes a favorite. Removing a user that was not favorited is a no-op.
+func (uc *ManageFavoritesUseCase) Remove(ctx context.Context, userID, favoriteUserID uuid.UUID) error {
+	if err := uc.favoriteRepo.Delete(ctx, userID, favoriteUserID); err != nil {
+		return errors.WrapError(err, "Failed to remove favorite")
+	}
+	return nil
+}
+
+// List returns a page of a user's favorites, most recent first. Users who were banned, deactivated
+// or deleted since are left out.
+func (uc *ManageFavoritesUseCase) List(ctx context.Context, userID uuid.UUID, limit, offset int) (*FavoritesResponse, error) {
+	favorites, total, err := uc.favoriteRepo.GetByUserID(ctx, userID, limit, offset)
+	if err != nil {
+		return nil, errors.WrapError(err, "Failed to get favorites")
+	}
+
+	response := &FavoritesResponse{
+		Favorites: make([]*FavoriteProfile, 0, len(favorites)),
+		Total:     total,
+	}
+
+	for _, favorite := range favorites {
+		// The user may have been removed between the page and the lookup
+		favoriteUser, err := uc.userRepo.GetByID(ctx, favorite.FavoriteUserID)
+		if err != nil || !isListable(favoriteUser) {
+			continue
+		}
+
+		response.Favorites = append(response.Favorites, &FavoriteProfile{
+			User:        uc.buildUser(ctx, favoriteUser),
+			FavoritedAt: favorite.CreatedAt.Format("2006-01-02T15:04:05Z07:00"),
+		})
+	}
+
+	return response, nil
+}
+
+// checkLimit returns an error when the user cannot add another favorite on their plan
+func (uc *ManageFavoritesUseCase) checkLimit(ctx context.Context, userID uuid.UUID) error {
+	paid := uc.hasPaidPlan(ctx, userID)
+
+	limit := uc.config.MaxFree
+	if paid {
+		limit = uc.config.MaxPaid
+		if limit <= 0 {
+			return nil
+		}
+	} else if limit <= 0 {
+		return errors.NewAppError(errors.ErrSubscription.Code, errors.ErrSubscription.Message, "Upgrade to Premium to save favorites")
+	}
+
+	count, err := uc.favoriteRepo.CountByUserID(ctx, userID)
+	if err != nil {
+		return errors.WrapError(err, "Failed to count favorites")
+	}
+	if count < int64(limit) {
+		return nil
+	}
+
+	details := fmt.Sprintf("You can save up to %d favorites. Remove one to make room.", limit)
+	if !paid {
+		details = fmt.Sprintf("Free accounts can save up to %d favorites. Upgrade to Premium to save more, or remove one to make room.", limit)
+	}
+	return errors.NewAppError(errors.ErrFavoriteLimitExceeded.Code, errors.ErrFavoriteLimitExceeded.Message, details)
+}
+
+// hasPaidPlan checks if the user has an active premium or platinum subscription, or one in its grace period.
+// Users without a subscription are on the free plan.
+func (uc *ManageFavoritesUseCase) hasPaidPlan(ctx context.Context, userID uuid.UUID) bool {
+	subscription, err := uc.subscriptionRepo.GetActiveUserSubscription(ctx, userID)
+	if err != nil || subscription == nil {
+		return false
+	}
+
+	return subscription.HasPremiumAccess(time.Now()) && subscription.IsPaidPlan()
+}
+
+// buildUser builds the favorite's profile card with their approved photos
+func (uc *ManageFavoritesUseCase) buildUser(ctx context.Context, user *entities.User) *User {
+	card := &User{
+		ID:        user.ID,
+		FirstName: user.FirstName,
+		Age:       user.GetAge(),
+		Photos:    []*Photo{},
+	}
+
+	photos, err := uc.photoRepo.GetUserPhotos(ctx, user.ID, false)
+	if err != nil {
+		// Log error but continue with empty photos
+		logger.Warn("Failed to get favorite photos", "user_id", user.ID, "error", err)
+		return card
+	}
+
+	for _, photo := range photos {
+		if photo.VerificationStatus != "approved" {
+			continue
+		}
+		card.Photos = append(card.Photos, &Photo{
+			ID:        photo.ID.String(),
+			URL:       photo.FileURL,
+			IsPrimary: photo.IsPrimary,
+			CreatedAt: photo.CreatedAt.Format("2006-01-02T15:04:05Z07:00"),
+		})
+	}
+
+	return card
+}
+
+// isListable reports whether a user can still be viewed by others
+func isListable(user *entities.User) bool {
+	return user != nil && user.IsActive && !user.IsBanned
+}
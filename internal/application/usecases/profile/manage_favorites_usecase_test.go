@@ -0,0 +1,294 @@
+package profile
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"sort"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"github.com/22smeargle/winkr-backend/internal/domain/entities"
+	"github.com/22smeargle/winkr-backend/internal/domain/repositories"
+	"github.com/22smeargle/winkr-backend/pkg/config"
+	"github.com/22smeargle/winkr-backend/pkg/errors"
+)
+
+// favoriteUsers is the user table shared by the favorite test repositories
+type favoriteUsers map[uuid.UUID]*entities.User
+
+func (u favoriteUsers) add(firstName string) *entities.User {
+	user := &entities.User{
+		ID:          uuid.New(),
+		FirstName:   firstName,
+		DateOfBirth: time.Now().AddDate(-30, 0, -1),
+		IsActive:    true,
+	}
+	u[user.ID] = user
+	return user
+}
+
+// inMemoryUserRepository implements the user repository lookups used by favorites
+type inMemoryUserRepository struct {
+	repositories.UserRepository
+	users favoriteUsers
+}
+
+func (r *inMemoryUserRepository) GetByID(ctx context.Context, id uuid.UUID) (*entities.User, error) {
+	user, ok := r.users[id]
+	if !ok {
+		return nil, fmt.Errorf("user not found")
+	}
+	return user, nil
+}
+
+// inMemoryFavoriteRepository is an in-memory FavoriteRepository that lists favorites like the database does
+type inMemoryFavoriteRepository struct {
+	favorites []*entities.Favorite
+	users     favoriteUsers
+	created   int
+}
+
+func (r *inMemoryFavoriteRepository) Create(ctx context.Context, favorite *entities.Favorite) error {
+	r.created++
+	favorite.ID = uuid.New()
+	favorite.CreatedAt = time.Date(2026, 10, 17, 12, 0, r.created, 0, time.UTC)
+	r.favorites = append(r.favorites, favorite)
+	return nil
+}
+
+func (r *inMemoryFavoriteRepository) Delete(ctx context.Context, userID, favoriteUserID uuid.UUID) error {
+	kept := r.favorites[:0]
+	for _, favorite := range r.favorites {
+		if favorite.UserID != userID || favorite.FavoriteUserID != favoriteUserID {
+			kept = append(kept, favorite)
+		}
+	}
+	r.favorites = kept
+	return nil
+}
+
+func (r *inMemoryFavoriteRepository) Exists(ctx context.Context, userID, favoriteUserID uuid.UUID) (bool, error) {
+	for _, favorite := range r.favorites {
+		if favorite.UserID == userID && favorite.FavoriteUserID == favoriteUserID {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+func (r *inMemoryFavoriteRepository) GetByUserID(ctx context.Context, userID uuid.UUID, limit, offset int) ([]*entities.Favorite, int64, error) {
+	listed := r.listed(userID)
+	sort.Slice(listed, func(i, j int) bool { return listed[i].CreatedAt.After(listed[j].CreatedAt) })
+
+	total := int64(len(listed))
+	if offset >= len(listed) {
+		return []*entities.Favorite{}, total, nil
+	}
+	listed = listed[offset:]
+	if len(listed) > limit {
+		listed = listed[:limit]
+	}
+	return listed, total, nil
+}
+
+func (r *inMemoryFavoriteRepository) CountByUserID(ctx context.Context, userID uuid.UUID) (int64, error) {
+	return int64(len(r.listed(userID))), nil
+}
+
+func (r *inMemoryFavoriteRepository) listed(userID uuid.UUID) []*entities.Favorite {
+	var listed []*entities.Favorite
+	for _, favorite := range r.favorites {
+		user := r.users[favorite.FavoriteUserID]
+		if favorite.UserID == userID && user.IsActive && !user.IsBanned {
+			listed = append(listed, favorite)
+		}
+	}
+	return listed
+}
+
+// stubSubscriptionRepository returns a fixed active subscription
+type stubSubscriptionRepository struct {
+	repositories.SubscriptionRepository
+	subscription *entities.Subscription
+}
+
+func (r *stubSubscriptionRepository) GetActiveUserSubscription(ctx context.Context, userID uuid.UUID) (*entities.Subscription, error) {
+	return r.subscription, nil
+}
+
+type favoritesFixture struct {
+	users         favoriteUsers
+	favorites     *inMemoryFavoriteRepository
+	subscriptions *stubSubscriptionRepository
+	useCase       *ManageFavoritesUseCase
+	userID        uuid.UUID
+}
+
+func newFavoritesFixture(cfg *config.MatchingFavoritesConfig) *favoritesFixture {
+	users := favoriteUsers{}
+	photoRepo := new(MockPhotoRepository)
+	photoRepo.On("GetUserPhotos", mock.Anything, mock.Anything, false).Return([]*entities.Photo{
+		{ID: uuid.New(), FileURL: "https://cdn.example.com/approved.jpg", IsPrimary: true, VerificationStatus: "approved"},
+		{ID: uuid.New(), FileURL: "https://cdn.example.com/pending.jpg", VerificationStatus: "pending"},
+	}, nil)
+
+	f := &favoritesFixture{
+		users:         users,
+		favorites:     &inMemoryFavoriteRepository{users: users},
+		subscriptions: &stubSubscriptionRepository{},
+		userID:        users.add("Ana").ID,
+	}
+	f.useCase = NewManageFavoritesUseCase(f.favorites, &inMemoryUserRepository{users: users}, photoRepo, f.subscriptions, cfg)
+	return f
+}
+
+func favoriteNames(response *FavoritesResponse) []string {
+	names := make([]string, 0, len(response.Favorites))
+	for _, favorite := range response.Favorites {
+		names = append(names, favorite.User.FirstName)
+	}
+	return names
+}
+
+func TestManageFavoritesUseCase_AddRemoveAndList(t *testing.T) {
+	ctx := context.Background()
+	f := newFavoritesFixture(&config.MatchingFavoritesConfig{MaxFree: 10})
+
+	sam := f.users.add("Sam")
+	kim := f.users.add("Kim")
+	lee := f.users.add("Lee")
+	for _, user := range []*entities.User{sam, kim, lee} {
+		require.NoError(t, f.useCase.Add(ctx, f.userID, user.ID))
+	}
+
+	// Favoriting again changes nothing
+	require.NoError(t, f.useCase.Add(ctx, f.userID, kim.ID))
+	assert.Len(t, f.favorites.favorites, 3)
+
+	response, err := f.useCase.List(ctx, f.userID, 2, 0)
+	require.NoError(t, err)
+	assert.Equal(t, int64(3), response.Total)
+	assert.Equal(t, []string{"Lee", "Kim"}, favoriteNames(response))
+
+	// Profiles are hydrated with approved photos only
+	require.Len(t, response.Favorites[0].User.Photos, 1)
+	assert.Equal(t, "https://cdn.example.com/approved.jpg", response.Favorites[0].User.Photos[0].URL)
+	assert.Equal(t, 30, response.Favorites[0].User.Age)
+
+	response, err = f.useCase.List(ctx, f.userID, 2, 2)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"Sam"}, favoriteNames(response))
+
+	require.NoError(t, f.useCase.Remove(ctx, f.userID, kim.ID))
+	require.NoError(t, f.useCase.Remove(ctx, f.userID, kim.ID), "removing twice is a no-op")
+
+	response, err = f.useCase.List(ctx, f.userID, 20, 0)
+	require.NoError(t, err)
+	assert.Equal(t, int64(2), response.Total)
+	assert.Equal(t, []string{"Lee", "Sam"}, favoriteNames(response))
+}
+
+func TestManageFavoritesUseCase_CannotFavoriteUnavailableUsers(t *testing.T) {
+	ctx := context.Background()
+	f := newFavoritesFixture(&config.MatchingFavoritesConfig{MaxFree: 10})
+
+	banned := f.users.add("Max")
+	banned.IsBanned = true
+
+	assert.Equal(t, errors.ErrUserNotFound, f.useCase.Add(ctx, f.userID, banned.ID))
+	assert.Equal(t, errors.ErrUserNotFound, f.useCase.Add(ctx, f.userID, uuid.New()))
+
+	err := f.useCase.Add(ctx, f.userID, f.userID)
+	require.Error(t, err)
+	assert.Equal(t, http.StatusUnprocessableEntity, err.(*errors.AppError).Code)
+	assert.Empty(t, f.favorites.favorites)
+}
+
+func TestManageFavoritesUseCase_ListSkipsBannedAndDeletedUsers(t *testing.T) {
+	ctx := context.Background()
+	f := newFavoritesFixture(&config.MatchingFavoritesConfig{MaxFree: 10})
+
+	sam := f.users.add("Sam")
+	kim := f.users.add("Kim")
+	lee := f.users.add("Lee")
+	for _, user := range []*entities.User{sam, kim, lee} {
+		require.NoError(t, f.useCase.Add(ctx, f.userID, user.ID))
+	}
+
+	// Banned and deleted after being favorited
+	kim.IsBanned = true
+	lee.IsActive = false
+
+	response, err := f.useCase.List(ctx, f.userID, 20, 0)
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), response.Total)
+	assert.Equal(t, []string{"Sam"}, favoriteNames(response))
+
+	// A favorite that is no longer listed can still be removed
+	require.NoError(t, f.useCase.Remove(ctx, f.userID, kim.ID))
+
+	// And reappears if the account is restored
+	lee.IsActive = true
+	response, err = f.useCase.List(ctx, f.userID, 20, 0)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"Lee", "Sam"}, favoriteNames(response))
+}
+
+func TestManageFavoritesUseCase_FreeUsersAreCapped(t *testing.T) {
+	ctx := context.Background()
+	f := newFavoritesFixture(&config.MatchingFavoritesConfig{MaxFree: 2, MaxPaid: 3})
+
+	first := f.users.add("Sam")
+	require.NoError(t, f.useCase.Add(ctx, f.userID, first.ID))
+	require.NoError(t, f.useCase.Add(ctx, f.userID, f.users.add("Kim").ID))
+
+	err := f.useCase.Add(ctx, f.userID, f.users.add("Lee").ID)
+	require.Error(t, err)
+	appErr := err.(*errors.AppError)
+	assert.Equal(t, errors.ErrFavoriteLimitExceeded.Code, appErr.Code)
+	assert.Contains(t, appErr.Details, "Upgrade to Premium")
+	assert.Len(t, f.favorites.favorites, 2)
+
+	// Favoriting someone already saved is fine at the cap
+	require.NoError(t, f.useCase.Add(ctx, f.userID, first.ID))
+
+	// Removing one makes room
+	require.NoError(t, f.useCase.Remove(ctx, f.userID, first.ID))
+	require.NoError(t, f.useCase.Add(ctx, f.userID, f.users.add("Lee").ID))
+}
+
+func TestManageFavoritesUseCase_PaidUsersGetTheHigherCap(t *testing.T) {
+	ctx := context.Background()
+	f := newFavoritesFixture(&config.MatchingFavoritesConfig{MaxFree: 1, MaxPaid: 3})
+	f.subscriptions.subscription = &entities.Subscription{UserID: f.userID, PlanType: "premium", Status: "active"}
+
+	for i := 0; i < 3; i++ {
+		require.NoError(t, f.useCase.Add(ctx, f.userID, f.users.add(fmt.Sprintf("User %d", i)).ID))
+	}
+
+	err := f.useCase.Add(ctx, f.userID, f.users.add("Lee").ID)
+	require.Error(t, err)
+	assert.Equal(t, errors.ErrFavoriteLimitExceeded.Code, err.(*errors.AppError).Code)
+	assert.NotContains(t, err.(*errors.AppError).Details, "Upgrade")
+
+	// No limit on paid plans when max_paid is 0
+	f.useCase.config.MaxPaid = 0
+	require.NoError(t, f.useCase.Add(ctx, f.userID, f.users.add("Lee").ID))
+}
+
+func TestManageFavoritesUseCase_PaidOnlyWhenFreeCapIsZero(t *testing.T) {
+	ctx := context.Background()
+	f := newFavoritesFixture(&config.MatchingFavoritesConfig{MaxFree: 0, MaxPaid: 10})
+
+	err := f.useCase.Add(ctx, f.userID, f.users.add("Sam").ID)
+	require.Error(t, err)
+	assert.Equal(t, http.StatusPaymentRequired, err.(*errors.AppError).Code)
+
+	f.subscriptions.subscription = &entities.Subscription{UserID: f.userID, PlanType: "platinum", Status: "active"}
+	require.NoError(t, f.useCase.Add(ctx, f.userID, f.users.add("Kim").ID))
+}
@@ -2,19 +2,40 @@ package profile
 
 import (
 	"context"
+	"strings"
 	"time"
 
 	"github.com/google/uuid"
+	"github.com/22smeargle/winkr-backend/internal/domain/entities"
 	"github.com/22smeargle/winkr-backend/internal/domain/repositories"
 	"github.com/22smeargle/winkr-backend/pkg/errors"
 )
 
+// Outcomes of moderating a bio
+const (
+	BioApproved = "approved"
+	BioFlagged  = "flagged"
+	BioRejected = "rejected"
+)
+
+// BioModerationResult is the outcome of moderating a bio
+type BioModerationResult struct {
+	Decision string   `json:"decision"`
+	Reasons  []string `json:"reasons"`
+}
+
+// BioModerator checks a bio against the content rules before it is saved
+type BioModerator interface {
+	ModerateBio(ctx context.Context, userID uuid.UUID, bio string) (*BioModerationResult, error)
+}
+
 // UpdateProfileUseCase handles updating user profile
 type UpdateProfileUseCase struct {
 	userRepo     repositories.UserRepository
 	photoRepo    repositories.PhotoRepository
 	cacheService ProfileCacheService
 	profileService ProfileService
+	bioModerator BioModerator
 }
 
 // NewUpdateProfileUseCase creates a new UpdateProfileUseCase instance
@@ -23,12 +44,14 @@ func NewUpdateProfileUseCase(
 	photoRepo repositories.PhotoRepository,
 	cacheService ProfileCacheService,
 	profileService ProfileService,
+	bioModerator BioModerator,
 ) *UpdateProfileUseCase {
 	return &UpdateProfileUseCase{
 		userRepo:      userRepo,
 		photoRepo:     photoRepo,
 		cacheService:  cacheService,
 		profileService: profileService,
+		bioModerator:  bioModerator,
 	}
 }
 
@@ -39,6 +62,15 @@ type UpdateProfileRequest struct {
 	LastName     *string      `json:"last_name"`
 	Bio          *string      `json:"bio"`
 	InterestedIn []string     `json:"interested_in"`
+	Interests    []string     `json:"interests"` // Nil leaves them unchanged, empty clears them
+	HeightCm     *int         `json:"height_cm"`
+	Smoking      *string      `json:"smoking"`
+	Drinking     *string      `json:"drinking"`
+	Locale       *string      `json:"locale"`
+	Timezone     *string      `json:"timezone"`
+	AutoTranslateMessages *bool `json:"auto_translate_messages"`
+	SendReadReceipts *bool      `json:"send_read_receipts"`
+	AutoIntro    *string      `json:"auto_intro"`
 	Preferences  *Preferences `json:"preferences"`
 }
 
@@ -51,10 +83,20 @@ type UpdateProfileResponse struct {
 	DateOfBirth    string       `json:"date_of_birth"`
 	Gender         string       `json:"gender"`
 	InterestedIn   []string     `json:"interested_in"`
+	Interests      []string     `json:"interests"`
 	Bio            *string      `json:"bio"`
 	Location       *Location    `json:"location"`
+	HeightCm       *int         `json:"height_cm,omitempty"`
+	Smoking        *string      `json:"smoking,omitempty"`
+	Drinking       *string      `json:"drinking,omitempty"`
+	Locale         *string      `json:"locale,omitempty"`
+	Timezone       *string      `json:"timezone,omitempty"`
+	AutoTranslateMessages bool  `json:"auto_translate_messages"`
+	SendReadReceipts bool       `json:"send_read_receipts"`
+	AutoIntro      *string      `json:"auto_intro,omitempty"`
 	IsVerified     bool         `json:"is_verified"`
 	IsPremium      bool         `json:"is_premium"`
+	UnderReview    bool         `json:"under_review"`
 	Photos         []*Photo     `json:"photos"`
 	Preferences    *Preferences `json:"preferences"`
 	ProfileStats   *ProfileStats `json:"profile_stats"`
@@ -88,11 +130,57 @@ func (uc *UpdateProfileUseCase) Execute(ctx context.Context, req *UpdateProfileR
 		user.LastName = *req.LastName
 	}
 	if req.Bio != nil {
+		// Bios are moderated before they go live
+		if uc.bioModerator != nil && *req.Bio != "" {
+			moderation, err := uc.bioModerator.ModerateBio(ctx, req.UserID, *req.Bio)
+			if err != nil {
+				return nil, errors.WrapError(err, "Failed to moderate bio")
+			}
+
+			switch moderation.Decision {
+			case BioRejected:
+				return nil, errors.ErrBioRejected
+			case BioFlagged:
+				// Hidden from discovery until a moderator clears it
+				user.ProfileUnderReview = true
+			}
+		}
 		user.Bio = req.Bio
 	}
 	if len(req.InterestedIn) > 0 {
 		user.InterestedIn = req.InterestedIn
 	}
+	if req.Interests != nil {
+		user.Interests = entities.NormalizeInterests(req.Interests)
+	}
+	if req.HeightCm != nil {
+		user.HeightCm = req.HeightCm
+	}
+	if req.Smoking != nil {
+		user.Smoking = req.Smoking
+	}
+	if req.Drinking != nil {
+		user.Drinking = req.Drinking
+	}
+	if req.Locale != nil {
+		user.Locale = req.Locale
+	}
+	if req.Timezone != nil {
+		user.Timezone = req.Timezone
+	}
+	if req.AutoTranslateMessages != nil {
+		user.AutoTranslateMessages = *req.AutoTranslateMessages
+	}
+	if req.SendReadReceipts != nil {
+		user.SendReadReceipts = *req.SendReadReceipts
+	}
+	if req.AutoIntro != nil {
+		// An empty auto-intro turns the feature off for the user
+		user.AutoIntro = nil
+		if autoIntro := strings.TrimSpace(*req.AutoIntro); autoIntro != "" {
+			user.AutoIntro = &autoIntro
+		}
+	}
 
 	// Update user in database
 	if err := uc.userRepo.Update(ctx, user); err != nil {
@@ -114,6 +202,10 @@ func (uc *UpdateProfileUseCase) Execute(ctx context.Context, req *UpdateProfileR
 		preferences.AgeMax = req.Preferences.AgeMax
 		preferences.MaxDistance = req.Preferences.MaxDistance
 		preferences.ShowMe = req.Preferences.ShowMe
+		// Hidden fields are left as they are unless the request sets them
+		if req.Preferences.HiddenFields != nil {
+			preferences.HiddenFields = req.Preferences.HiddenFields
+		}
 
 		// Save preferences
 		if preferences.ID == uuid.Nil {
@@ -166,6 +258,7 @@ func (uc *UpdateProfileUseCase) Execute(ctx context.Context, req *UpdateProfileR
 		DateOfBirth:   updatedUser.DateOfBirth.Format("2006-01-02"),
 		Gender:        updatedUser.Gender,
 		InterestedIn:  updatedUser.InterestedIn,
+		Interests:     updatedUser.Interests,
 		Bio:           updatedUser.Bio,
 		Location: &Location{
 			Lat:      updatedUser.LocationLat,
@@ -173,8 +266,17 @@ func (uc *UpdateProfileUseCase) Execute(ctx context.Context, req *UpdateProfileR
 			City:     updatedUser.LocationCity,
 			Country:  updatedUser.LocationCountry,
 		},
+		HeightCm:      updatedUser.HeightCm,
+		Smoking:       updatedUser.Smoking,
+		Drinking:      updatedUser.Drinking,
+		Locale:        updatedUser.Locale,
+		Timezone:      updatedUser.Timezone,
+		AutoTranslateMessages: updatedUser.AutoTranslateMessages,
+		SendReadReceipts: updatedUser.SendReadReceipts,
+		AutoIntro:     updatedUser.AutoIntro,
 		IsVerified:    updatedUser.IsVerified,
 		IsPremium:     updatedUser.IsPremium,
+		UnderReview:   updatedUser.ProfileUnderReview,
 		CreatedAt:     updatedUser.CreatedAt.Format("2006-01-02T15:04:05Z07:00"),
 		UpdatedAt:     updatedUser.UpdatedAt.Format("2006-01-02T15:04:05Z07:00"),
 	}
@@ -199,6 +301,7 @@ func (uc *UpdateProfileUseCase) Execute(ctx context.Context, req *UpdateProfileR
 			AgeMax:      updatedPreferences.AgeMax,
 			MaxDistance: updatedPreferences.MaxDistance,
 			ShowMe:      updatedPreferences.ShowMe,
+			HiddenFields: updatedPreferences.HiddenFields,
 		}
 	}
 
@@ -5,10 +5,14 @@ import (
 	"time"
 
 	"github.com/google/uuid"
+	"github.com/22smeargle/winkr-backend/internal/domain/entities"
 	"github.com/22smeargle/winkr-backend/internal/domain/repositories"
 	"github.com/22smeargle/winkr-backend/pkg/errors"
 )
 
+// previewViewerID stands in for an anonymous viewer who has not matched with the previewed user
+var previewViewerID = uuid.Nil
+
 // ViewUserProfileUseCase handles viewing another user's profile
 type ViewUserProfileUseCase struct {
 	userRepo     repositories.UserRepository
@@ -45,7 +49,7 @@ type ViewUserProfileRequest struct {
 type ViewUserProfileResponse struct {
 	ID             uuid.UUID    `json:"id"`
 	FirstName      string       `json:"first_name"`
-	Age           int          `json:"age"`
+	Age           int          `json:"age,omitempty"`
 	Bio            *string      `json:"bio"`
 	Location       *Location    `json:"location"`
 	IsVerified     bool         `json:"is_verified"`
@@ -86,14 +90,47 @@ func (uc *ViewUserProfileUseCase) Execute(ctx context.Context, viewerID, targetU
 		return nil, errors.ErrProfileNotVisible
 	}
 
+	response, err := uc.buildPublicProfile(ctx, viewerID, targetUser)
+	if err != nil {
+		return nil, err
+	}
+
+	// Track profile view
+	if err := uc.privacyService.TrackProfileView(ctx, viewerID, targetUserID); err != nil {
+		// Log error but don't fail the request
+		// TODO: Add proper logging
+	}
+
+	// Cache the response with shorter TTL for privacy
+	if err := uc.cacheService.SetViewProfile(ctx, cacheKey, response, 5*time.Minute); err != nil {
+		// Log error but don't fail the request
+		// TODO: Add proper logging
+	}
+
+	return response, nil
+}
+
+// Preview returns the user's own profile exactly as another, unmatched user would see it.
+// It goes through the same mapping and privacy filters as Execute, without tracking a view or caching.
+func (uc *ViewUserProfileUseCase) Preview(ctx context.Context, userID uuid.UUID) (*ViewUserProfileResponse, error) {
+	user, err := uc.userRepo.GetByID(ctx, userID)
+	if err != nil {
+		return nil, errors.ErrUserNotFound
+	}
+
+	return uc.buildPublicProfile(ctx, previewViewerID, user)
+}
+
+// buildPublicProfile maps a user to the public profile shown to the viewer, applying privacy filters
+func (uc *ViewUserProfileUseCase) buildPublicProfile(ctx context.Context, viewerID uuid.UUID, targetUser *entities.User) (*ViewUserProfileResponse, error) {
 	// Get user photos (only verified photos for other users)
-	photos, err := uc.photoRepo.GetUserPhotos(ctx, targetUserID, true)
+	photos, err := uc.photoRepo.GetUserPhotos(ctx, targetUser.ID, true)
 	if err != nil {
 		return nil, errors.WrapError(err, "Failed to get user photos")
 	}
 
 	// Check if users have matched
-	isMatch, err := uc.matchRepo.MatchExists(ctx, viewerID, targetUserID)
+	isMatch, err := uc.matchRepo.MatchExists(ctx, viewerID, targetUser.ID)
 	if err != nil {
 		return nil, errors.WrapError(err, "Failed to check match status")
 	}
@@ -102,20 +139,26 @@ func (uc *ViewUserProfileUseCase) Execute(ctx context.Context, viewerID, targetU
 	canMessage := isMatch // Only allow messaging if they've matched
 
 	// Get user statistics (limited for privacy)
-	stats, err := uc.userRepo.GetUserStats(ctx, targetUserID)
+	stats, err := uc.userRepo.GetUserStats(ctx, targetUser.ID)
 	if err != nil {
 		return nil, errors.WrapError(err, "Failed to get user statistics")
 	}
 
 	// Apply privacy filters to location
-	location := uc.privacyService.FilterLocation(ctx, viewerID, targetUserID, targetUser)
+	location := uc.privacyService.FilterLocation(ctx, viewerID, targetUser.ID, targetUser)
+
+	// Fields the user has hidden from others; users without preferences hide nothing
+	preferences, err := uc.userRepo.GetPreferences(ctx, targetUser.ID)
+	if err != nil {
+		preferences = nil
+	}
 
 	// Build response
 	response := &ViewUserProfileResponse{
 		ID:           targetUser.ID,
 		FirstName:     targetUser.FirstName,
 		Age:          targetUser.GetAge(),
-		Bio:          uc.privacyService.FilterBio(ctx, viewerID, targetUserID, targetUser.Bio),
+		Bio:          uc.privacyService.FilterBio(ctx, viewerID, targetUser.ID, targetUser.Bio),
 		Location:      location,
 		IsVerified:    targetUser.IsVerified,
 		CreatedAt:     targetUser.CreatedAt.Format("2006-01-02T15:04:05Z07:00"),
@@ -123,9 +166,24 @@ func (uc *ViewUserProfileUseCase) Execute(ctx context.Context, viewerID, targetU
 		CanMessage:    canMessage,
 	}
 
+	// Omit the fields the user has hidden
+	if preferences.IsProfileFieldHidden(entities.ProfileFieldAge, targetUser.IsPremium) {
+		response.Age = 0
+	}
+	if preferences.IsProfileFieldHidden(entities.ProfileFieldBio, targetUser.IsPremium) {
+		response.Bio = nil
+	}
+	if preferences.IsProfileFieldHidden(entities.ProfileFieldLocation, targetUser.IsPremium) {
+		response.Location = nil
+	}
+
 	// Add photos to response (apply privacy filters)
 	for _, photo := range photos {
-		if uc.privacyService.CanViewPhoto(ctx, viewerID, targetUserID, photo) {
+		// Photos hidden after repeated reports stay off the profile until re-moderated
+		if photo.IsHidden {
+			continue
+		}
+		if uc.privacyService.CanViewPhoto(ctx, viewerID, targetUser.ID, photo) {
 			responsePhoto := &Photo{
 				ID:                photo.ID.String(),
 				URL:               photo.FileURL,
@@ -144,17 +202,8 @@ func (uc *ViewUserProfileUseCase) Execute(ctx context.Context, viewerID, targetU
 		ProfileComplete: targetUser.IsComplete(),
 		LastActive:     formatLastActive(targetUser.LastActive),
 	}
-
-	// Track profile view
-	if err := uc.privacyService.TrackProfileView(ctx, viewerID, targetUserID); err != nil {
-		// Log error but don't fail the request
-		// TODO: Add proper logging
-	}
-
-	// Cache the response with shorter TTL for privacy
-	if err := uc.cacheService.SetViewProfile(ctx, cacheKey, response, 5*time.Minute); err != nil {
-		// Log error but don't fail the request
-		// TODO: Add proper logging
+	if preferences.IsProfileFieldHidden(entities.ProfileFieldLastActive, targetUser.IsPremium) {
+		response.ProfileStats.LastActive = ""
 	}
 
 	return response, nil
@@ -0,0 +1,266 @@
+package profile
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"github.com/22smeargle/winkr-backend/internal/domain/entities"
+	"github.com/22smeargle/winkr-backend/internal/domain/repositories"
+)
+
+// MockUserRepository is a mock implementation of the user repository methods used by profile viewing
+type MockUserRepository struct {
+	repositories.UserRepository
+	mock.Mock
+}
+
+func (m *MockUserRepository) GetByID(ctx context.Context, id uuid.UUID) (*entities.User, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entities.User), args.Error(1)
+}
+
+func (m *MockUserRepository) GetPreferences(ctx context.Context, userID uuid.UUID) (*entities.UserPreferences, error) {
+	args := m.Called(ctx, userID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entities.UserPreferences), args.Error(1)
+}
+
+func (m *MockUserRepository) GetUserStats(ctx context.Context, userID uuid.UUID) (*repositories.UserStats, error) {
+	args := m.Called(ctx, userID)
+	return args.Get(0).(*repositories.UserStats), args.Error(1)
+}
+
+// MockPhotoRepository is a mock implementation of the photo repository methods used by profile viewing
+type MockPhotoRepository struct {
+	repositories.PhotoRepository
+	mock.Mock
+}
+
+func (m *MockPhotoRepository) GetUserPhotos(ctx context.Context, userID uuid.UUID, includeDeleted bool) ([]*entities.Photo, error) {
+	args := m.Called(ctx, userID, includeDeleted)
+	return args.Get(0).([]*entities.Photo), args.Error(1)
+}
+
+// MockMatchRepository is a mock implementation of the match repository methods used by profile viewing
+type MockMatchRepository struct {
+	repositories.MatchRepository
+	mock.Mock
+}
+
+func (m *MockMatchRepository) MatchExists(ctx context.Context, user1ID, user2ID uuid.UUID) (bool, error) {
+	args := m.Called(ctx, user1ID, user2ID)
+	return args.Bool(0), args.Error(1)
+}
+
+// stubViewProfileCache is a cache that always misses and records writes
+type stubViewProfileCache struct {
+	writes int
+}
+
+func (c *stubViewProfileCache) GetProfile(ctx context.Context, userID uuid.UUID) (*GetProfileResponse, error) {
+	return nil, nil
+}
+
+func (c *stubViewProfileCache) SetProfile(ctx context.Context, userID uuid.UUID, profile *GetProfileResponse, ttl time.Duration) error {
+	c.writes++
+	return nil
+}
+
+func (c *stubViewProfileCache) GetViewProfile(ctx context.Context, cacheKey string) (*ViewUserProfileResponse, error) {
+	return nil, nil
+}
+
+func (c *stubViewProfileCache) SetViewProfile(ctx context.Context, cacheKey string, profile *ViewUserProfileResponse, ttl time.Duration) error {
+	c.writes++
+	return nil
+}
+
+// stubPrivacyService applies the unmatched-viewer rules: city and country only, truncated bio, approved photos only
+type stubPrivacyService struct {
+	trackedViews int
+}
+
+func (p *stubPrivacyService) CanViewProfile(ctx context.Context, viewerID, targetUserID uuid.UUID) bool {
+	return true
+}
+
+func (p *stubPrivacyService) FilterLocation(ctx context.Context, viewerID, targetUserID uuid.UUID, targetUser *entities.User) *Location {
+	return &Location{City: targetUser.LocationCity, Country: targetUser.LocationCountry}
+}
+
+func (p *stubPrivacyService) FilterBio(ctx context.Context, viewerID, targetUserID uuid.UUID, bio *string) *string {
+	if bio == nil || len(*bio) <= 100 {
+		return bio
+	}
+	truncated := (*bio)[:97] + "..."
+	return &truncated
+}
+
+func (p *stubPrivacyService) CanViewPhoto(ctx context.Context, viewerID, targetUserID uuid.UUID, photo *entities.Photo) bool {
+	return photo.VerificationStatus == "approved"
+}
+
+func (p *stubPrivacyService) TrackProfileView(ctx context.Context, viewerID, targetUserID uuid.UUID) error {
+	p.trackedViews++
+	return nil
+}
+
+func newPreviewTestUser() *entities.User {
+	lat, lng := 38.72, -9.14
+	city, country := "Lisbon", "PT"
+	bio := "Coffee, climbing and long walks along the river. " +
+		"Looking for someone who enjoys weekend trips and terrible puns as much as I do."
+	lastActive := time.Now()
+
+	return &entities.User{
+		ID:              uuid.New(),
+		Email:           "ana@example.com",
+		FirstName:       "Ana",
+		LastName:        "Silva",
+		DateOfBirth:     time.Now().AddDate(-28, 0, -1),
+		Gender:          "female",
+		InterestedIn:    []string{"male"},
+		Bio:             &bio,
+		LocationLat:     &lat,
+		LocationLng:     &lng,
+		LocationCity:    &city,
+		LocationCountry: &country,
+		IsVerified:      true,
+		IsActive:        true,
+		LastActive:      &lastActive,
+		CreatedAt:       time.Now().AddDate(0, -2, 0),
+	}
+}
+
+func TestViewUserProfileUseCase_PreviewMatchesPublicProfile(t *testing.T) {
+	ctx := context.Background()
+	user := newPreviewTestUser()
+	viewerID := uuid.New()
+
+	userRepo := new(MockUserRepository)
+	photoRepo := new(MockPhotoRepository)
+	matchRepo := new(MockMatchRepository)
+	cache := &stubViewProfileCache{}
+	privacy := &stubPrivacyService{}
+
+	photos := []*entities.Photo{
+		{ID: uuid.New(), UserID: user.ID, FileURL: "https://cdn.example.com/approved.jpg", IsPrimary: true, VerificationStatus: "approved", CreatedAt: user.CreatedAt},
+		{ID: uuid.New(), UserID: user.ID, FileURL: "https://cdn.example.com/pending.jpg", VerificationStatus: "pending", CreatedAt: user.CreatedAt},
+	}
+
+	userRepo.On("GetByID", mock.Anything, user.ID).Return(user, nil)
+	userRepo.On("GetUserStats", mock.Anything, user.ID).Return(&repositories.UserStats{ProfileViews: 12, PhotosCount: 2, TotalMatches: 7}, nil)
+	userRepo.On("GetPreferences", mock.Anything, user.ID).Return(nil, assert.AnError)
+	photoRepo.On("GetUserPhotos", mock.Anything, user.ID, true).Return(photos, nil)
+	matchRepo.On("MatchExists", mock.Anything, mock.Anything, user.ID).Return(false, nil)
+
+	useCase := NewViewUserProfileUseCase(userRepo, photoRepo, matchRepo, cache, privacy)
+
+	public, err := useCase.Execute(ctx, viewerID, user.ID)
+	require.NoError(t, err)
+
+	preview, err := useCase.Preview(ctx, user.ID)
+	require.NoError(t, err)
+
+	// The preview is exactly what an unmatched user sees
+	assert.Equal(t, public, preview)
+
+	// Private fields are hidden
+	require.NotNil(t, preview.Location)
+	assert.Nil(t, preview.Location.Lat)
+	assert.Nil(t, preview.Location.Lng)
+	assert.Equal(t, "Lisbon", *preview.Location.City)
+	assert.Len(t, *preview.Bio, 100)
+	require.Len(t, preview.Photos, 1)
+	assert.Equal(t, "https://cdn.example.com/approved.jpg", preview.Photos[0].URL)
+	assert.True(t, preview.IsVerified)
+	assert.False(t, preview.IsMatch)
+	assert.False(t, preview.CanMessage)
+
+	// Previewing is not a profile view
+	assert.Equal(t, 1, privacy.trackedViews)
+	assert.Equal(t, 1, cache.writes)
+}
+
+func TestViewUserProfileUseCase_PreviewUnknownUser(t *testing.T) {
+	userRepo := new(MockUserRepository)
+	userID := uuid.New()
+	userRepo.On("GetByID", mock.Anything, userID).Return(nil, assert.AnError)
+
+	useCase := NewViewUserProfileUseCase(userRepo, new(MockPhotoRepository), new(MockMatchRepository), &stubViewProfileCache{}, &stubPrivacyService{})
+
+	preview, err := useCase.Preview(context.Background(), userID)
+
+	assert.Error(t, err)
+	assert.Nil(t, preview)
+}
+
+func TestViewUserProfileUseCase_OmitsHiddenFields(t *testing.T) {
+	ctx := context.Background()
+	user := newPreviewTestUser()
+	user.IsPremium = true
+	viewerID := uuid.New()
+	preferences := &entities.UserPreferences{
+		UserID:       user.ID,
+		AgeMin:       25,
+		AgeMax:       35,
+		MaxDistance:  30,
+		ShowMe:       true,
+		HiddenFields: []string{entities.ProfileFieldAge, entities.ProfileFieldBio, entities.ProfileFieldLastActive},
+	}
+	photos := []*entities.Photo{
+		{ID: uuid.New(), UserID: user.ID, FileURL: "https://cdn.example.com/approved.jpg", IsPrimary: true, VerificationStatus: "approved", CreatedAt: user.CreatedAt},
+	}
+
+	userRepo := new(MockUserRepository)
+	photoRepo := new(MockPhotoRepository)
+	matchRepo := new(MockMatchRepository)
+	userRepo.On("GetByID", mock.Anything, user.ID).Return(user, nil)
+	userRepo.On("GetPreferences", mock.Anything, user.ID).Return(preferences, nil)
+	userRepo.On("GetUserStats", mock.Anything, user.ID).Return(&repositories.UserStats{ProfileViews: 3, PhotosCount: 1}, nil)
+	photoRepo.On("GetUserPhotos", mock.Anything, user.ID, mock.Anything).Return(photos, nil)
+	matchRepo.On("MatchExists", mock.Anything, mock.Anything, user.ID).Return(false, nil)
+
+	viewUseCase := NewViewUserProfileUseCase(userRepo, photoRepo, matchRepo, &stubViewProfileCache{}, &stubPrivacyService{})
+
+	public, err := viewUseCase.Execute(ctx, viewerID, user.ID)
+	require.NoError(t, err)
+
+	// Hidden fields are omitted from what other users see
+	assert.Zero(t, public.Age)
+	assert.Nil(t, public.Bio)
+	assert.Empty(t, public.ProfileStats.LastActive)
+
+	// Mandatory and visible fields are still shown
+	assert.Equal(t, "Ana", public.FirstName)
+	require.Len(t, public.Photos, 1)
+	require.NotNil(t, public.Location)
+	assert.Equal(t, "Lisbon", *public.Location.City)
+
+	// The user's own view still has everything
+	own, err := NewGetProfileUseCase(userRepo, photoRepo, &stubViewProfileCache{}).Execute(ctx, user.ID)
+	require.NoError(t, err)
+	assert.Equal(t, user.DateOfBirth.Format("2006-01-02"), own.DateOfBirth)
+	assert.Equal(t, user.Bio, own.Bio)
+	assert.NotEmpty(t, own.ProfileStats.LastActive)
+	require.NotNil(t, own.Preferences)
+	assert.Equal(t, preferences.HiddenFields, own.Preferences.HiddenFields)
+
+	// Once premium lapses, fields that need premium to hide are shown again
+	user.IsPremium = false
+	public, err = viewUseCase.Preview(ctx, user.ID)
+	require.NoError(t, err)
+	assert.Equal(t, 28, public.Age)
+	assert.NotEmpty(t, public.ProfileStats.LastActive)
+	assert.Nil(t, public.Bio)
+}
@@ -3,6 +3,7 @@ package verification
 import (
 	"context"
 	"fmt"
+	"time"
 
 	"github.com/google/uuid"
 
@@ -39,6 +40,8 @@ type GetVerificationStatusOutput struct {
 	CanRequest      bool                           `json:"can_request"`
 	LastVerification *entities.Verification           `json:"last_verification,omitempty"`
 	VerificationLevel entities.VerificationLevel       `json:"verification_level"`
+	Guidance         *services.VerificationGuidance  `json:"guidance,omitempty"`
+	RetryAt          *time.Time                     `json:"retry_at,omitempty"`
 }
 
 // Execute executes the get verification status use case
@@ -60,6 +63,8 @@ func (uc *GetVerificationStatusUseCase) Execute(ctx context.Context, input GetVe
 		CanRequest:      result.CanRequest,
 		LastVerification: result.LastVerification,
 		VerificationLevel: result.VerificationLevel,
+		Guidance:         result.Guidance,
+		RetryAt:          result.RetryAt,
 	}
 
 	logger.Info("Verification status retrieved successfully", "user_id", input.UserID, "type", input.Type, "status", result.Status, "level", result.VerificationLevel)
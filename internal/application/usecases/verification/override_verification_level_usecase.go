@@ -0,0 +1,56 @@
+package verification
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+
+	"github.com/22smeargle/winkr-backend/internal/application/services"
+	"github.com/22smeargle/winkr-backend/internal/domain/entities"
+	"github.com/22smeargle/winkr-backend/pkg/errors"
+	"github.com/22smeargle/winkr-backend/pkg/logger"
+)
+
+// OverrideVerificationLevelUseCase handles admins setting a user's verification level directly
+type OverrideVerificationLevelUseCase struct {
+	verificationService *services.VerificationWorkflowService
+}
+
+// NewOverrideVerificationLevelUseCase creates a new use case
+func NewOverrideVerificationLevelUseCase(verificationService *services.VerificationWorkflowService) *OverrideVerificationLevelUseCase {
+	return &OverrideVerificationLevelUseCase{
+		verificationService: verificationService,
+	}
+}
+
+// OverrideVerificationLevelInput represents input for overriding a verification level
+type OverrideVerificationLevelInput struct {
+	UserID     uuid.UUID                  `json:"-"`
+	Level      entities.VerificationLevel `json:"level" validate:"min=0,max=2"`
+	Note       string                     `json:"note" validate:"required,max=500"`
+	OverrideBy uuid.UUID                  `json:"-"`
+}
+
+// OverrideVerificationLevelOutput represents output of overriding a verification level
+type OverrideVerificationLevelOutput struct {
+	UserID  uuid.UUID                  `json:"user_id"`
+	Level   entities.VerificationLevel `json:"level"`
+	Message string                     `json:"message"`
+}
+
+// Execute executes the override verification level use case
+func (uc *OverrideVerificationLevelUseCase) Execute(ctx context.Context, input OverrideVerificationLevelInput) (*OverrideVerificationLevelOutput, error) {
+	logger.Info("Executing verification level override", "user_id", input.UserID, "level", input.Level, "override_by", input.OverrideBy)
+
+	err := uc.verificationService.OverrideVerificationLevel(ctx, input.UserID, input.Level, input.Note, input.OverrideBy)
+	if err != nil {
+		logger.Error("Failed to override verification level", err, "user_id", input.UserID)
+		return nil, errors.NewAppError(400, "Failed to override verification level", err.Error())
+	}
+
+	return &OverrideVerificationLevelOutput{
+		UserID:  input.UserID,
+		Level:   input.Level,
+		Message: "Verification level updated",
+	}, nil
+}
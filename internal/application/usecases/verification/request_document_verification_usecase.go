@@ -45,6 +45,9 @@ func (uc *RequestDocumentVerificationUseCase) Execute(ctx context.Context, input
 
 	// Request document verification
 	verification, err := uc.verificationService.RequestDocumentVerification(ctx, input.UserID, input.IPAddress, input.UserAgent)
+	if cooldownErr, ok := errors.GetVerificationCooldownError(err); ok {
+		return nil, cooldownErr
+	}
 	if err != nil {
 		logger.Error("Failed to request document verification", err, "user_id", input.UserID)
 		return nil, errors.NewAppError(400, "Failed to request document verification", err.Error())
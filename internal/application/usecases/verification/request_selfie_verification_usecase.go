@@ -45,6 +45,9 @@ func (uc *RequestSelfieVerificationUseCase) Execute(ctx context.Context, input R
 
 	// Request selfie verification
 	verification, err := uc.verificationService.RequestSelfieVerification(ctx, input.UserID, input.IPAddress, input.UserAgent)
+	if cooldownErr, ok := errors.GetVerificationCooldownError(err); ok {
+		return nil, cooldownErr
+	}
 	if err != nil {
 		logger.Error("Failed to request selfie verification", err, "user_id", input.UserID)
 		return nil, errors.NewAppError(400, "Failed to request selfie verification", err.Error())
@@ -0,0 +1,85 @@
+package verification
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+
+	"github.com/22smeargle/winkr-backend/internal/application/services"
+	"github.com/22smeargle/winkr-backend/pkg/errors"
+	"github.com/22smeargle/winkr-backend/pkg/logger"
+)
+
+// SubmitDocumentSessionUseCase handles multi-document verification submissions
+type SubmitDocumentSessionUseCase struct {
+	verificationService *services.VerificationWorkflowService
+}
+
+// NewSubmitDocumentSessionUseCase creates a new use case
+func NewSubmitDocumentSessionUseCase(verificationService *services.VerificationWorkflowService) *SubmitDocumentSessionUseCase {
+	return &SubmitDocumentSessionUseCase{
+		verificationService: verificationService,
+	}
+}
+
+// SubmitDocumentSessionInput represents input for a multi-document verification submission
+type SubmitDocumentSessionInput struct {
+	VerificationID uuid.UUID                     `json:"verification_id" validate:"required"`
+	Region         string                        `json:"region" validate:"omitempty,len=2"`
+	Documents      []services.DocumentSubmission `json:"documents" validate:"required,min=1,dive"`
+}
+
+// DocumentStatusOutput represents the status of a single document in a session
+type DocumentStatusOutput struct {
+	DocumentID      uuid.UUID `json:"document_id"`
+	DocumentType    string    `json:"document_type"`
+	Status          string    `json:"status"`
+	Confidence      *float64  `json:"confidence,omitempty"`
+	RejectionReason *string   `json:"rejection_reason,omitempty"`
+}
+
+// SubmitDocumentSessionOutput represents output of a multi-document verification submission
+type SubmitDocumentSessionOutput struct {
+	VerificationID    uuid.UUID              `json:"verification_id"`
+	Status            string                 `json:"status"`
+	Message           string                 `json:"message"`
+	Documents         []DocumentStatusOutput `json:"documents"`
+	RequiredDocuments []string               `json:"required_documents"`
+	MissingDocuments  []string               `json:"missing_documents,omitempty"`
+	RequiresReview    bool                   `json:"requires_review"`
+}
+
+// Execute executes multi-document verification submission use case
+func (uc *SubmitDocumentSessionUseCase) Execute(ctx context.Context, input SubmitDocumentSessionInput) (*SubmitDocumentSessionOutput, error) {
+	logger.Info("Executing document session submission", "verification_id", input.VerificationID, "documents", len(input.Documents))
+
+	result, err := uc.verificationService.SubmitDocumentSession(ctx, input.VerificationID, input.Region, input.Documents)
+	if err != nil {
+		logger.Error("Failed to submit document session", err, "verification_id", input.VerificationID)
+		return nil, errors.NewAppError(400, "Failed to submit document verification", err.Error())
+	}
+
+	documents := make([]DocumentStatusOutput, len(result.Documents))
+	for i, document := range result.Documents {
+		documents[i] = DocumentStatusOutput{
+			DocumentID:      document.ID,
+			DocumentType:    document.DocumentType,
+			Status:          document.Status.String(),
+			Confidence:      document.Confidence,
+			RejectionReason: document.RejectionReason,
+		}
+	}
+
+	output := &SubmitDocumentSessionOutput{
+		VerificationID:    result.Verification.ID,
+		Status:            result.Verification.Status.String(),
+		Message:           "Document verification session submitted successfully",
+		Documents:         documents,
+		RequiredDocuments: result.RequiredDocuments,
+		MissingDocuments:  result.MissingDocuments,
+		RequiresReview:    result.Verification.Status.IsPending() && len(result.MissingDocuments) == 0,
+	}
+
+	logger.Info("Document session submitted successfully", "verification_id", input.VerificationID, "status", output.Status)
+	return output, nil
+}
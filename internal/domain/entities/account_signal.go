@@ -0,0 +1,58 @@
+package entities
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Kinds of signal that can link accounts to each other
+const (
+	AccountSignalDevice = "device"
+	AccountSignalIP     = "ip"
+)
+
+// Reasons accounts sharing a signal are flagged for review
+const (
+	LinkedAccountReasonTooManyAccounts = "too_many_accounts"
+	LinkedAccountReasonBannedAccount   = "shares_banned_account"
+)
+
+// AccountSignal records that an account was used from a device or IP address.
+// The value is a hash, so raw IP addresses are not stored.
+type AccountSignal struct {
+	ID          uuid.UUID `json:"id" gorm:"type:uuid;primary_key;default:gen_random_uuid()"`
+	UserID      uuid.UUID `json:"user_id" gorm:"type:uuid;not null;index"`
+	SignalType  string    `json:"signal_type" gorm:"not null"`
+	Value       string    `json:"value" gorm:"not null"`
+	FirstSeenAt time.Time `json:"first_seen_at"`
+	LastSeenAt  time.Time `json:"last_seen_at"`
+}
+
+// TableName returns the table name for AccountSignal entity
+func (AccountSignal) TableName() string {
+	return "account_signals"
+}
+
+// LinkedAccountFlag records that a device or IP address is shared by accounts that look like they
+// belong to one person, so moderators can review them
+type LinkedAccountFlag struct {
+	ID           uuid.UUID `json:"id" gorm:"type:uuid;primary_key;default:gen_random_uuid()"`
+	SignalType   string    `json:"signal_type" gorm:"not null"`
+	Value        string    `json:"value" gorm:"not null"`
+	Reason       string    `json:"reason" gorm:"not null"`
+	AccountCount int       `json:"account_count" gorm:"not null"`
+	FlaggedAt    time.Time `json:"flagged_at" gorm:"autoCreateTime"`
+	UpdatedAt    time.Time `json:"updated_at" gorm:"autoUpdateTime"`
+}
+
+// TableName returns the table name for LinkedAccountFlag entity
+func (LinkedAccountFlag) TableName() string {
+	return "linked_account_flags"
+}
+
+// LinkedAccountCluster is a flagged device or IP address together with the accounts that share it
+type LinkedAccountCluster struct {
+	*LinkedAccountFlag
+	UserIDs []uuid.UUID `json:"user_ids"`
+}
@@ -0,0 +1,25 @@
+package entities
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// AppealOutcome records how an appeal against a ban was decided, for analytics
+type AppealOutcome struct {
+	AppealID     uuid.UUID  `json:"appeal_id"`
+	UserID       uuid.UUID  `json:"user_id"`
+	BanID        uuid.UUID  `json:"ban_id"`
+	Approved     bool       `json:"approved"`
+	AutoReviewed bool       `json:"auto_reviewed"`         // Decided by the moderation model rather than a moderator
+	ReviewerID   *uuid.UUID `json:"reviewer_id,omitempty"` // The moderator who decided, unless auto-reviewed
+	Confidence   *float64   `json:"confidence,omitempty"`  // The model's confidence that the appeal should be approved
+	SubmittedAt  time.Time  `json:"submitted_at"`
+	ReviewedAt   time.Time  `json:"reviewed_at"`
+}
+
+// ReviewDuration returns how long the appeal waited for a decision
+func (o *AppealOutcome) ReviewDuration() time.Duration {
+	return o.ReviewedAt.Sub(o.SubmittedAt)
+}
@@ -0,0 +1,85 @@
+package entities
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Kinds of conversation milestone
+const (
+	MilestoneTypeStreak      = "streak"
+	MilestoneTypeAnniversary = "anniversary"
+)
+
+// ConversationMilestone is a conversation streak or match anniversary worth celebrating
+type ConversationMilestone struct {
+	ConversationID uuid.UUID `json:"conversation_id"`
+	MatchID        uuid.UUID `json:"match_id"`
+	Type           string    `json:"type"`
+	Value          int       `json:"value"` // Days for streaks, months for anniversaries
+}
+
+// RecordStreakMessage counts a message from a participant towards the conversation streak. Days are
+// UTC calendar days. A day counts once both participants have messaged on it; the streak grows when
+// consecutive days count and starts again from 1 after a missed day. Returns true if the message
+// completed a day.
+func (c *Conversation) RecordStreakMessage(senderID uuid.UUID, at time.Time) bool {
+	day := streakDay(at)
+	if c.StreakLastDay != nil && !day.After(streakDay(*c.StreakLastDay)) {
+		return false
+	}
+
+	// First participant to message today
+	if c.StreakPendingDay == nil || c.StreakPendingSenderID == nil || !streakDay(*c.StreakPendingDay).Equal(day) {
+		c.StreakPendingDay = &day
+		c.StreakPendingSenderID = &senderID
+		return false
+	}
+	if *c.StreakPendingSenderID == senderID {
+		return false
+	}
+
+	if c.StreakLastDay != nil && streakDay(*c.StreakLastDay).Equal(day.AddDate(0, 0, -1)) {
+		c.StreakCount++
+	} else {
+		c.StreakCount = 1
+	}
+	c.StreakLastDay = &day
+	c.StreakPendingDay = nil
+	c.StreakPendingSenderID = nil
+	return true
+}
+
+// CurrentStreak returns the streak as of now. The participants have until the end of the day after
+// the last day that counted to keep the streak going; after that it is broken and 0.
+func (c *Conversation) CurrentStreak(now time.Time) int {
+	if c.StreakLastDay == nil {
+		return 0
+	}
+	if streakDay(now).After(streakDay(*c.StreakLastDay).AddDate(0, 0, 1)) {
+		return 0
+	}
+	return c.StreakCount
+}
+
+// MonthsSinceMatch returns the whole calendar months since the match was made
+func (m *Match) MonthsSinceMatch(now time.Time) int {
+	matchedAt := m.MatchedAt.UTC()
+	now = now.UTC()
+
+	months := (now.Year()-matchedAt.Year())*12 + int(now.Month()) - int(matchedAt.Month())
+	if now.Day() < matchedAt.Day() {
+		months--
+	}
+	if months < 0 {
+		return 0
+	}
+	return months
+}
+
+// streakDay returns the UTC calendar day of t
+func streakDay(t time.Time) time.Time {
+	year, month, day := t.UTC().Date()
+	return time.Date(year, month, day, 0, 0, 0, 0, time.UTC)
+}
@@ -0,0 +1,21 @@
+package entities
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Favorite is a profile a user saved to revisit later. Unlike a like, it is private and has
+// no effect on matching or discovery.
+type Favorite struct {
+	ID             uuid.UUID `json:"id" gorm:"type:uuid;primary_key;default:gen_random_uuid()"`
+	UserID         uuid.UUID `json:"user_id" gorm:"type:uuid;not null;index"`
+	FavoriteUserID uuid.UUID `json:"favorite_user_id" gorm:"type:uuid;not null"`
+	CreatedAt      time.Time `json:"created_at" gorm:"autoCreateTime"`
+}
+
+// TableName returns the table name for Favorite entity
+func (Favorite) TableName() string {
+	return "favorites"
+}
@@ -0,0 +1,25 @@
+package entities
+
+import "strings"
+
+// Limits on the interests a user lists on their profile
+const (
+	MaxInterests      = 10
+	MaxInterestLength = 30
+)
+
+// NormalizeInterests trims and lowercases interests so the same interest matches between users,
+// dropping empty and repeated ones
+func NormalizeInterests(interests []string) []string {
+	normalized := make([]string, 0, len(interests))
+	seen := make(map[string]bool, len(interests))
+	for _, interest := range interests {
+		interest = strings.ToLower(strings.Join(strings.Fields(interest), " "))
+		if interest == "" || seen[interest] {
+			continue
+		}
+		seen[interest] = true
+		normalized = append(normalized, interest)
+	}
+	return normalized
+}
@@ -0,0 +1,36 @@
+package entities
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// LegalNotice represents a community guideline or safety notice users are asked to acknowledge.
+// Bumping the version asks every user to acknowledge the notice again.
+type LegalNotice struct {
+	ID       string `json:"id"`
+	Version  int    `json:"version"`
+	Title    string `json:"title"`
+	URL      string `json:"url"`
+	Required bool   `json:"required"`
+}
+
+// NoticeAcknowledgement records that a user acknowledged a specific version of a notice
+type NoticeAcknowledgement struct {
+	ID             uuid.UUID `json:"id" gorm:"type:uuid;primary_key;default:gen_random_uuid()"`
+	UserID         uuid.UUID `json:"user_id" gorm:"type:uuid;not null;index"`
+	NoticeID       string    `json:"notice_id" gorm:"not null"`
+	Version        int       `json:"version" gorm:"not null"`
+	AcknowledgedAt time.Time `json:"acknowledged_at" gorm:"autoCreateTime"`
+}
+
+// TableName returns the table name for NoticeAcknowledgement entity
+func (NoticeAcknowledgement) TableName() string {
+	return "notice_acknowledgements"
+}
+
+// Covers returns true if the acknowledgement covers the current version of the notice
+func (a *NoticeAcknowledgement) Covers(notice *LegalNotice) bool {
+	return a.NoticeID == notice.ID && a.Version >= notice.Version
+}
@@ -0,0 +1,36 @@
+package entities
+
+// Height range, in centimeters, accepted on profiles and in discovery filters
+const (
+	MinHeightCm = 120
+	MaxHeightCm = 230
+)
+
+// Answers to the smoking and drinking profile questions
+const (
+	LifestyleHabitNever     = "never"
+	LifestyleHabitSocially  = "socially"
+	LifestyleHabitRegularly = "regularly"
+)
+
+// LifestyleHabits lists the allowed answers to the smoking and drinking profile questions
+var LifestyleHabits = []string{
+	LifestyleHabitNever,
+	LifestyleHabitSocially,
+	LifestyleHabitRegularly,
+}
+
+// IsValidHeightCm returns true if the height is within the accepted range
+func IsValidHeightCm(heightCm int) bool {
+	return heightCm >= MinHeightCm && heightCm <= MaxHeightCm
+}
+
+// IsValidLifestyleHabit returns true if the answer is one of LifestyleHabits
+func IsValidLifestyleHabit(habit string) bool {
+	for _, allowed := range LifestyleHabits {
+		if habit == allowed {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,20 @@
+package entities
+
+import (
+	"regexp"
+	"strings"
+)
+
+// localePattern matches BCP 47 style locales such as "en", "pt-BR" or "zh-Hant-TW"
+var localePattern = regexp.MustCompile(`^[a-zA-Z]{2,3}(-[a-zA-Z0-9]{2,8})*$`)
+
+// IsValidLocale returns true if the locale looks like a BCP 47 language tag
+func IsValidLocale(locale string) bool {
+	return len(locale) <= 35 && localePattern.MatchString(locale)
+}
+
+// LanguageOf returns the lowercase primary language of a locale, e.g. "pt" for "pt-BR"
+func LanguageOf(locale string) string {
+	language, _, _ := strings.Cut(locale, "-")
+	return strings.ToLower(language)
+}
@@ -52,6 +52,8 @@ type UserPreferences struct {
 	AgeMax      int        `json:"age_max" gorm:"default:100"`
 	MaxDistance int        `json:"max_distance" gorm:"default:50"` // in kilometers
 	ShowMe      bool       `json:"show_me" gorm:"default:true"`
+	HiddenFields []string  `json:"hidden_fields" gorm:"type:text[]"` // profile fields hidden from other users
+	AutoReorderPhotos bool `json:"auto_reorder_photos" gorm:"default:false"` // photos are reordered by how often viewers like them
 	CreatedAt   time.Time  `json:"created_at" gorm:"autoCreateTime"`
 	UpdatedAt   time.Time  `json:"updated_at" gorm:"autoUpdateTime"`
 
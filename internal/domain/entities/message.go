@@ -15,18 +15,52 @@ type Message struct {
 	MessageType    string     `json:"message_type" gorm:"default:'text';check:message_type IN ('text', 'image', 'gif', 'ephemeral_photo')"`
 	IsRead         bool       `json:"is_read" gorm:"default:false"`
 	IsDeleted      bool       `json:"is_deleted" gorm:"default:false"`
+	IsPinned       bool       `json:"is_pinned" gorm:"default:false"`
+	PinnedAt       *time.Time `json:"pinned_at,omitempty"`
+	PinnedBy       *uuid.UUID `json:"pinned_by,omitempty" gorm:"type:uuid"`
+
+	// Temporary ID the sender's client assigned before the server did, echoed back so it can
+	// reconcile its local copy. Unique per sender, which makes retried sends idempotent.
+	ClientMessageID *string `json:"client_message_id,omitempty" gorm:"type:varchar(255)"`
+
+	// Encrypted original content when PII was redacted from Content, for moderators only.
+	// Never serialized, so it stays out of API responses, events and the message cache.
+	EncryptedOriginal *string `json:"-" gorm:"type:text"`
+
+	EditedAt       *time.Time `json:"edited_at,omitempty"`
+	DeletedAt      *time.Time `json:"deleted_at,omitempty"`
 	CreatedAt      time.Time  `json:"created_at" gorm:"autoCreateTime"`
 
+	// Delivery state, populated from message_status when returning message history
+	Receipt *MessageReceipt `json:"receipt,omitempty" gorm:"-"`
+
+	// Translation into the recipient's language, attached on delivery when they opted in.
+	// Content always holds the original.
+	Translation *MessageTranslation `json:"translation,omitempty" gorm:"-"`
+
+	// Reaction counts by emoji, populated from message_reactions when returning message history
+	Reactions map[string]int `json:"reactions,omitempty" gorm:"-"`
+
 	// Relationships
 	Sender       *User         `json:"sender,omitempty" gorm:"foreignKey:SenderID"`
 	Conversation *Conversation `json:"conversation,omitempty" gorm:"foreignKey:ConversationID"`
 }
 
+// DeletedMessagePlaceholder is returned in place of the content of a deleted message
+const DeletedMessagePlaceholder = "This message was deleted"
+
 // TableName returns the table name for Message entity
 func (Message) TableName() string {
 	return "messages"
 }
 
+// MessageTranslation is a message's content translated for one recipient
+type MessageTranslation struct {
+	SourceLanguage string `json:"source_language"`
+	Language       string `json:"language"`
+	Content        string `json:"content"`
+}
+
 // IsText returns true if the message is a text message
 func (m *Message) IsText() bool {
 	return m.MessageType == "text"
@@ -54,29 +88,175 @@ func (m *Message) MarkAsRead() {
 
 // SoftDelete marks the message as deleted
 func (m *Message) SoftDelete() {
+	now := time.Now()
 	m.IsDeleted = true
+	m.DeletedAt = &now
 }
 
 // Restore restores a soft-deleted message
 func (m *Message) Restore() {
 	m.IsDeleted = false
+	m.DeletedAt = nil
 }
 
-// CanBeEdited returns true if the message can be edited
-func (m *Message) CanBeEdited() bool {
-	// Messages can only be edited within 15 minutes of creation
-	return m.IsText() && !m.IsDeleted && time.Since(m.CreatedAt) < 15*time.Minute
+// Edit replaces the message content and records when it was edited
+func (m *Message) Edit(content string) {
+	now := time.Now()
+	m.Content = content
+	m.EncryptedOriginal = nil // Belonged to the replaced content
+	m.EditedAt = &now
 }
 
-// CanBeDeleted returns true if the message can be deleted
-func (m *Message) CanBeDeleted() bool {
-	return !m.IsDeleted
+// IsEdited returns true if the message was edited after it was sent
+func (m *Message) IsEdited() bool {
+	return m.EditedAt != nil
+}
+
+// Tombstone replaces the content of a deleted message with a placeholder. The message itself
+// stays in the history so its place in the conversation, and pagination over it, is unchanged.
+func (m *Message) Tombstone() {
+	if !m.IsDeleted {
+		return
+	}
+	m.Content = DeletedMessagePlaceholder
+	m.Translation = nil
+}
+
+// TombstoneMessages tombstones the deleted messages among the given ones
+func TombstoneMessages(messages []*Message) {
+	for _, message := range messages {
+		message.Tombstone()
+	}
+}
+
+// Pin marks the message as pinned by the given user
+func (m *Message) Pin(userID uuid.UUID) {
+	now := time.Now()
+	m.IsPinned = true
+	m.PinnedAt = &now
+	m.PinnedBy = &userID
+}
+
+// Unpin removes the pin from the message
+func (m *Message) Unpin() {
+	m.IsPinned = false
+	m.PinnedAt = nil
+	m.PinnedBy = nil
+}
+
+// CanBePinned returns true if the message can be pinned
+func (m *Message) CanBePinned() bool {
+	return !m.IsDeleted && !m.IsPinned
+}
+
+// CanBeEdited returns true if the message is text, not deleted and was sent within the edit window
+func (m *Message) CanBeEdited(window time.Duration) bool {
+	return m.IsText() && !m.IsDeleted && time.Since(m.CreatedAt) < window
+}
+
+// CanBeDeleted returns true if the message is not deleted yet and was sent within the delete
+// window. A window of zero lets messages be deleted at any time.
+func (m *Message) CanBeDeleted(window time.Duration) bool {
+	if m.IsDeleted {
+		return false
+	}
+	return window <= 0 || time.Since(m.CreatedAt) < window
+}
+
+// Message delivery statuses, in the order a message moves through them
+const (
+	MessageStatusSent      = "sent"
+	MessageStatusDelivered = "delivered"
+	MessageStatusRead      = "read"
+)
+
+// MessageStatus records a single delivery status transition of a message
+type MessageStatus struct {
+	ID        uuid.UUID `json:"id" gorm:"type:uuid;primary_key;default:gen_random_uuid()"`
+	MessageID uuid.UUID `json:"message_id" gorm:"type:uuid;not null;index"`
+	UserID    uuid.UUID `json:"user_id" gorm:"type:uuid;not null"` // Sender for 'sent', recipient otherwise
+	Status    string    `json:"status" gorm:"not null;check:status IN ('sent', 'delivered', 'read')"`
+	CreatedAt time.Time `json:"created_at" gorm:"autoCreateTime"`
+}
+
+// TableName returns the table name for MessageStatus entity
+func (MessageStatus) TableName() string {
+	return "message_status"
+}
+
+// MessageStatusRank returns the position of a status in the sent→delivered→read sequence, or 0 if unknown
+func MessageStatusRank(status string) int {
+	switch status {
+	case MessageStatusSent:
+		return 1
+	case MessageStatusDelivered:
+		return 2
+	case MessageStatusRead:
+		return 3
+	default:
+		return 0
+	}
+}
+
+// MessageReceipt summarizes the delivery state of a message for clients
+type MessageReceipt struct {
+	Status      string     `json:"status"`
+	SentAt      *time.Time `json:"sent_at,omitempty"`
+	DeliveredAt *time.Time `json:"delivered_at,omitempty"`
+	ReadAt      *time.Time `json:"read_at,omitempty"`
+}
+
+// NewMessageReceipt builds a receipt from the recorded status transitions of a message
+func NewMessageReceipt(statuses []*MessageStatus) *MessageReceipt {
+	receipt := &MessageReceipt{}
+	for _, status := range statuses {
+		at := status.CreatedAt
+		switch status.Status {
+		case MessageStatusSent:
+			receipt.SentAt = &at
+		case MessageStatusDelivered:
+			receipt.DeliveredAt = &at
+		case MessageStatusRead:
+			receipt.ReadAt = &at
+		}
+		if MessageStatusRank(status.Status) > MessageStatusRank(receipt.Status) {
+			receipt.Status = status.Status
+		}
+	}
+	return receipt
+}
+
+// MessageReaction is a user's emoji reaction to a message. A user reacts to a message with each
+// emoji at most once, and reacting again with the same emoji removes the reaction.
+type MessageReaction struct {
+	ID        uuid.UUID `json:"id" gorm:"type:uuid;primary_key;default:gen_random_uuid()"`
+	MessageID uuid.UUID `json:"message_id" gorm:"type:uuid;not null;index"`
+	UserID    uuid.UUID `json:"user_id" gorm:"type:uuid;not null"`
+	Emoji     string    `json:"emoji" gorm:"type:varchar(32);not null"`
+	CreatedAt time.Time `json:"created_at" gorm:"autoCreateTime"`
+}
+
+// TableName returns the table name for MessageReaction entity
+func (MessageReaction) TableName() string {
+	return "message_reactions"
 }
 
 // Conversation represents a conversation between matched users
 type Conversation struct {
 	ID        uuid.UUID  `json:"id" gorm:"type:uuid;primary_key;default:gen_random_uuid()"`
 	MatchID   uuid.UUID  `json:"match_id" gorm:"type:uuid;not null;uniqueIndex"`
+	ClosedAt  *time.Time `json:"closed_at,omitempty"`
+
+	// Consecutive days both participants messaged, up to the last day that counted. Use
+	// CurrentStreak to read it, as a streak is broken by a missed day.
+	StreakCount   int        `json:"-" gorm:"default:0"`
+	StreakLastDay *time.Time `json:"-" gorm:"type:date"`
+	// The day only one participant has messaged on so far, and who it was
+	StreakPendingDay      *time.Time `json:"-" gorm:"type:date"`
+	StreakPendingSenderID *uuid.UUID `json:"-" gorm:"type:uuid"`
+	// The months since the match as of the last anniversary check
+	AnniversaryMonths int `json:"-" gorm:"default:0"`
+
 	CreatedAt time.Time  `json:"created_at" gorm:"autoCreateTime"`
 	UpdatedAt time.Time  `json:"updated_at" gorm:"autoUpdateTime"`
 
@@ -109,6 +289,19 @@ func (c *Conversation) GetUnreadCount(userID uuid.UUID) int {
 	return count
 }
 
+// Close closes the conversation so no further messages can be sent
+func (c *Conversation) Close() {
+	if c.ClosedAt == nil {
+		now := time.Now()
+		c.ClosedAt = &now
+	}
+}
+
+// IsClosed returns true if the conversation has been closed
+func (c *Conversation) IsClosed() bool {
+	return c.ClosedAt != nil
+}
+
 // HasMessages returns true if the conversation has messages
 func (c *Conversation) HasMessages() bool {
 	return len(c.Messages) > 0
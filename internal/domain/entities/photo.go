@@ -13,9 +13,13 @@ type Photo struct {
 	FileURL           string     `json:"file_url" gorm:"not null"`
 	FileKey           string     `json:"file_key" gorm:"not null;uniqueIndex"`
 	IsPrimary         bool       `json:"is_primary" gorm:"default:false"`
+	PrimaryLocked     bool       `json:"primary_locked" gorm:"default:false"` // Kept as primary when photos are reordered by engagement
+	Position          int        `json:"position" gorm:"default:0"`
 	VerificationStatus string     `json:"verification_status" gorm:"default:'pending';check:verification_status IN ('pending', 'approved', 'rejected')"`
 	VerificationReason *string    `json:"verification_reason"`
 	IsDeleted         bool       `json:"is_deleted" gorm:"default:false"`
+	IsHidden          bool       `json:"is_hidden" gorm:"default:false"`
+	ReviewRequestedAt *time.Time `json:"review_requested_at"`
 	CreatedAt         time.Time  `json:"created_at" gorm:"autoCreateTime"`
 	UpdatedAt         time.Time  `json:"updated_at" gorm:"autoUpdateTime"`
 
@@ -52,12 +56,34 @@ func (p *Photo) CanBeDeleted() bool {
 func (p *Photo) Approve() {
 	p.VerificationStatus = "approved"
 	p.VerificationReason = nil
+	p.clearReview()
 }
 
 // Reject marks the photo as rejected with a reason
 func (p *Photo) Reject(reason string) {
 	p.VerificationStatus = "rejected"
 	p.VerificationReason = &reason
+	p.clearReview()
+}
+
+// RequestReview queues the photo for another moderation pass, optionally hiding it meanwhile
+func (p *Photo) RequestReview(hide bool) {
+	now := time.Now()
+	p.ReviewRequestedAt = &now
+	if hide {
+		p.IsHidden = true
+	}
+}
+
+// IsVisible returns true if the photo can be shown on the owner's profile
+func (p *Photo) IsVisible() bool {
+	return !p.IsDeleted && !p.IsHidden
+}
+
+// clearReview resets the re-moderation state once a moderator has decided
+func (p *Photo) clearReview() {
+	p.ReviewRequestedAt = nil
+	p.IsHidden = false
 }
 
 // SoftDelete marks the photo as deleted
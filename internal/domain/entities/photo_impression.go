@@ -0,0 +1,37 @@
+package entities
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// PhotoImpression records that a user was shown a photo, and whether they went on to like its owner
+type PhotoImpression struct {
+	PhotoID  uuid.UUID  `json:"photo_id"`
+	UserID   uuid.UUID  `json:"user_id"` // Owner of the photo
+	ViewerID uuid.UUID  `json:"viewer_id"`
+	Liked    bool       `json:"liked"`
+	ViewedAt time.Time  `json:"viewed_at"`
+	LikedAt  *time.Time `json:"liked_at"`
+}
+
+// TableName returns the table name for the PhotoImpression entity
+func (PhotoImpression) TableName() string {
+	return "photo_impressions"
+}
+
+// PhotoPerformance sums up the impressions of a photo
+type PhotoPerformance struct {
+	PhotoID     uuid.UUID `json:"photo_id"`
+	Impressions int64     `json:"impressions"` // Distinct viewers shown the photo
+	Likes       int64     `json:"likes"`       // Viewers who liked the owner after being shown the photo
+}
+
+// LikeRate returns the share of viewers who liked the owner after being shown the photo
+func (p *PhotoPerformance) LikeRate() float64 {
+	if p.Impressions == 0 {
+		return 0
+	}
+	return float64(p.Likes) / float64(p.Impressions)
+}
@@ -0,0 +1,75 @@
+package entities
+
+// Profile fields whose visibility to other users can be configured
+const (
+	ProfileFieldFirstName  = "first_name"
+	ProfileFieldPhotos     = "photos"
+	ProfileFieldLastName   = "last_name"
+	ProfileFieldBio        = "bio"
+	ProfileFieldDistance   = "distance"
+	ProfileFieldHeight     = "height"
+	ProfileFieldSmoking    = "smoking"
+	ProfileFieldDrinking   = "drinking"
+	ProfileFieldAge        = "age"
+	ProfileFieldLocation   = "location"
+	ProfileFieldLastActive = "last_active"
+)
+
+// MandatoryProfileFields are always shown to other users and cannot be hidden
+var MandatoryProfileFields = []string{
+	ProfileFieldFirstName,
+	ProfileFieldPhotos,
+}
+
+// HideableProfileFields lists the fields any user can hide from other users
+var HideableProfileFields = []string{
+	ProfileFieldLastName,
+	ProfileFieldBio,
+	ProfileFieldDistance,
+	ProfileFieldHeight,
+	ProfileFieldSmoking,
+	ProfileFieldDrinking,
+}
+
+// PremiumHideableProfileFields lists the fields only premium users can hide
+var PremiumHideableProfileFields = []string{
+	ProfileFieldAge,
+	ProfileFieldLocation,
+	ProfileFieldLastActive,
+}
+
+// IsHideableProfileField returns true if the field can be hidden on the given plan
+func IsHideableProfileField(field string, isPremium bool) bool {
+	if containsField(HideableProfileFields, field) {
+		return true
+	}
+	return isPremium && containsField(PremiumHideableProfileFields, field)
+}
+
+// IsPremiumHideableProfileField returns true if hiding the field requires premium
+func IsPremiumHideableProfileField(field string) bool {
+	return containsField(PremiumHideableProfileFields, field)
+}
+
+// IsMandatoryProfileField returns true if the field is always shown to other users
+func IsMandatoryProfileField(field string) bool {
+	return containsField(MandatoryProfileFields, field)
+}
+
+// IsProfileFieldHidden returns true if the user has hidden the field from other users.
+// Fields that need premium to hide are shown again once the user is no longer premium.
+func (p *UserPreferences) IsProfileFieldHidden(field string, isPremium bool) bool {
+	if p == nil || !containsField(p.HiddenFields, field) {
+		return false
+	}
+	return IsHideableProfileField(field, isPremium)
+}
+
+func containsField(fields []string, field string) bool {
+	for _, f := range fields {
+		if f == field {
+			return true
+		}
+	}
+	return false
+}
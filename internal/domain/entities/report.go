@@ -11,6 +11,7 @@ type Report struct {
 	ID             uuid.UUID  `json:"id" gorm:"type:uuid;primary_key;default:gen_random_uuid()"`
 	ReporterID     uuid.UUID  `json:"reporter_id" gorm:"type:uuid;not null;index"`
 	ReportedUserID uuid.UUID  `json:"reported_user_id" gorm:"type:uuid;not null;index"`
+	PhotoID        *uuid.UUID `json:"photo_id,omitempty" gorm:"type:uuid;index"`
 	Reason         string     `json:"reason" gorm:"not null;check:reason IN ('inappropriate_behavior', 'fake_profile', 'spam', 'harassment', 'other')"`
 	Description    *string    `json:"description"`
 	Status         string     `json:"status" gorm:"default:'pending';check:status IN ('pending', 'reviewed', 'resolved', 'dismissed')"`
@@ -100,6 +101,11 @@ func (r *Report) MarkAsDismissed(reviewedBy uuid.UUID) {
 	r.ReviewedAt = &now
 }
 
+// IsPhotoReport returns true if the report targets a specific profile photo
+func (r *Report) IsPhotoReport() bool {
+	return r.PhotoID != nil
+}
+
 // IsValidReason checks if the report reason is valid
 func (r *Report) IsValidReason() bool {
 	validReasons := []string{
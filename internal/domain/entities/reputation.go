@@ -0,0 +1,38 @@
+package entities
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Reasons a user's reputation changed
+const (
+	ReputationReasonReportConfirmed = "report_confirmed" // A report against the user was upheld
+	ReputationReasonViolation       = "violation"        // Moderation removed the user's content
+	ReputationReasonRecovery        = "recovery"         // A recovery interval passed without violations
+	ReputationReasonAdjustment      = "adjustment"       // Changed by a moderator
+)
+
+// UserReputation is a user's standing with moderation. Users start at the configured initial
+// reputation, lose reputation for confirmed reports and violations and slowly regain it.
+type UserReputation struct {
+	UserID          uuid.UUID  `json:"user_id"`
+	Score           int        `json:"score"`
+	ReportsReceived int        `json:"reports_received"`
+	LastScoreChange *time.Time `json:"last_score_change,omitempty"`
+	UpdatedAt       time.Time  `json:"updated_at"`
+}
+
+// ReputationChange records one change to a user's reputation, for auditing
+type ReputationChange struct {
+	ID            uuid.UUID  `json:"id"`
+	UserID        uuid.UUID  `json:"user_id"`
+	Delta         int        `json:"delta"` // Change actually applied, after clamping
+	PreviousScore int        `json:"previous_score"`
+	NewScore      int        `json:"new_score"`
+	Reason        string     `json:"reason"`
+	ReferenceID   *uuid.UUID `json:"reference_id,omitempty"` // The report, photo or message behind the change
+	ActorID       *uuid.UUID `json:"actor_id,omitempty"`     // The moderator behind the change, if any
+	CreatedAt     time.Time  `json:"created_at"`
+}
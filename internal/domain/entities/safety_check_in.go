@@ -0,0 +1,48 @@
+package entities
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Statuses of a safety check-in
+const (
+	SafetyCheckInStatusScheduled = "scheduled"
+	SafetyCheckInStatusConfirmed = "confirmed"
+	SafetyCheckInStatusMissed    = "missed"
+)
+
+// SafetyCheckIn is a time a user promised to check in by, typically when meeting a match in person.
+// If it is not confirmed in time the user is alerted, and so is their trusted contact if they
+// consented to it.
+type SafetyCheckIn struct {
+	ID               uuid.UUID  `json:"id" gorm:"type:uuid;primary_key;default:gen_random_uuid()"`
+	UserID           uuid.UUID  `json:"user_id" gorm:"type:uuid;not null;index"`
+	DueAt            time.Time  `json:"due_at" gorm:"not null;index"`
+	Status           string     `json:"status" gorm:"not null;default:'scheduled'"`
+	Note             *string    `json:"note,omitempty"`
+	ContactName      *string    `json:"contact_name,omitempty"`
+	ContactEmail     *string    `json:"contact_email,omitempty"`
+	ContactConsentAt *time.Time `json:"contact_consent_at,omitempty"` // Set when the user agreed to alert the contact
+	ConfirmedAt      *time.Time `json:"confirmed_at,omitempty"`
+	MissedAt         *time.Time `json:"missed_at,omitempty"`
+	ContactAlertedAt *time.Time `json:"contact_alerted_at,omitempty"`
+	CreatedAt        time.Time  `json:"created_at" gorm:"autoCreateTime"`
+	UpdatedAt        time.Time  `json:"updated_at" gorm:"autoUpdateTime"`
+}
+
+// TableName returns the table name for SafetyCheckIn entity
+func (SafetyCheckIn) TableName() string {
+	return "safety_check_ins"
+}
+
+// CanAlertContact returns true if the check-in has a trusted contact the user consented to alert
+func (c *SafetyCheckIn) CanAlertContact() bool {
+	return c.ContactConsentAt != nil && c.ContactEmail != nil && *c.ContactEmail != ""
+}
+
+// IsOverdue returns true if a scheduled check-in was not confirmed within the grace period
+func (c *SafetyCheckIn) IsOverdue(now time.Time, gracePeriod time.Duration) bool {
+	return c.Status == SafetyCheckInStatusScheduled && now.After(c.DueAt.Add(gracePeriod))
+}
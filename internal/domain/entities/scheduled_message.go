@@ -0,0 +1,45 @@
+package entities
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Statuses of a scheduled message
+const (
+	ScheduledMessageStatusScheduled = "scheduled"
+	ScheduledMessageStatusSending   = "sending"
+	ScheduledMessageStatusSent      = "sent"
+	ScheduledMessageStatusCanceled  = "canceled"
+	ScheduledMessageStatusSkipped   = "skipped"
+)
+
+// ScheduledMessage is a text message a user wrote to be sent to a conversation later. It goes
+// through the same checks as any other message when it is sent, and is skipped if it no longer
+// passes them.
+type ScheduledMessage struct {
+	ID             uuid.UUID  `json:"id" gorm:"type:uuid;primary_key;default:gen_random_uuid()"`
+	ConversationID uuid.UUID  `json:"conversation_id" gorm:"type:uuid;not null;index"`
+	SenderID       uuid.UUID  `json:"sender_id" gorm:"type:uuid;not null;index"`
+	Content        string     `json:"content" gorm:"type:text;not null"`
+	SendAt         time.Time  `json:"send_at" gorm:"not null;index"`
+	Status         string     `json:"status" gorm:"not null;default:'scheduled'"`
+	MessageID      *uuid.UUID `json:"message_id,omitempty"`  // The message it was sent as
+	SkipReason     *string    `json:"skip_reason,omitempty"` // Why it was not sent, e.g. conversation_closed
+	SentAt         *time.Time `json:"sent_at,omitempty"`
+	CanceledAt     *time.Time `json:"canceled_at,omitempty"`
+	SkippedAt      *time.Time `json:"skipped_at,omitempty"`
+	CreatedAt      time.Time  `json:"created_at" gorm:"autoCreateTime"`
+	UpdatedAt      time.Time  `json:"updated_at" gorm:"autoUpdateTime"`
+}
+
+// TableName returns the table name for ScheduledMessage entity
+func (ScheduledMessage) TableName() string {
+	return "scheduled_messages"
+}
+
+// IsDue returns true if a scheduled message should be sent now
+func (m *ScheduledMessage) IsDue(now time.Time) bool {
+	return m.Status == ScheduledMessageStatusScheduled && !now.Before(m.SendAt)
+}
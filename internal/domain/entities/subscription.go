@@ -11,11 +11,13 @@ type Subscription struct {
 	ID                   uuid.UUID  `json:"id" gorm:"type:uuid;primary_key;default:gen_random_uuid()"`
 	UserID               uuid.UUID  `json:"user_id" gorm:"type:uuid;not null;index"`
 	StripeSubscriptionID  *string    `json:"stripe_subscription_id" gorm:"uniqueIndex"`
+	StripeCustomerID      *string    `json:"stripe_customer_id" gorm:"index"`
 	PlanType             string     `json:"plan_type" gorm:"not null;check:plan_type IN ('basic', 'premium', 'platinum')"`
 	Status               string     `json:"status" gorm:"not null;check:status IN ('active', 'canceled', 'past_due', 'unpaid')"`
 	CurrentPeriodStart    *time.Time `json:"current_period_start"`
 	CurrentPeriodEnd      *time.Time `json:"current_period_end"`
 	CancelAtPeriodEnd    bool       `json:"cancel_at_period_end" gorm:"default:false"`
+	GracePeriodEndsAt    *time.Time `json:"grace_period_ends_at,omitempty"`
 	CreatedAt            time.Time  `json:"created_at" gorm:"autoCreateTime"`
 	UpdatedAt            time.Time  `json:"updated_at" gorm:"autoUpdateTime"`
 
@@ -48,6 +50,21 @@ func (s *Subscription) IsUnpaid() bool {
 	return s.Status == "unpaid"
 }
 
+// InGracePeriod returns true if a renewal payment failed and the grace period has not ended yet
+func (s *Subscription) InGracePeriod(now time.Time) bool {
+	return s.IsPastDue() && s.GracePeriodEndsAt != nil && now.Before(*s.GracePeriodEndsAt)
+}
+
+// GracePeriodExpired returns true if the grace period ended without a successful payment
+func (s *Subscription) GracePeriodExpired(now time.Time) bool {
+	return s.IsPastDue() && s.GracePeriodEndsAt != nil && !now.Before(*s.GracePeriodEndsAt)
+}
+
+// HasPremiumAccess returns true if the subscription currently grants its plan's features
+func (s *Subscription) HasPremiumAccess(now time.Time) bool {
+	return s.IsActive() || s.InGracePeriod(now)
+}
+
 // IsBasic returns true if the subscription is basic plan
 func (s *Subscription) IsBasic() bool {
 	return s.PlanType == "basic"
@@ -113,6 +130,17 @@ func (s *Subscription) SetUnpaid() {
 	s.Status = "unpaid"
 }
 
+// StartGracePeriod sets the subscription past due while keeping its features until the given time
+func (s *Subscription) StartGracePeriod(endsAt time.Time) {
+	s.Status = "past_due"
+	s.GracePeriodEndsAt = &endsAt
+}
+
+// EndGracePeriod clears the grace period after a successful payment or a downgrade
+func (s *Subscription) EndGracePeriod() {
+	s.GracePeriodEndsAt = nil
+}
+
 // UpdatePeriod updates the current period
 func (s *Subscription) UpdatePeriod(start, end time.Time) {
 	s.CurrentPeriodStart = &start
@@ -131,6 +159,9 @@ func (s *Subscription) UpdateFromStripe(stripeSubID, planType, status string, cu
 	s.Status = status
 	s.UpdatePeriod(currentPeriodStart, currentPeriodEnd)
 	s.SetCancelAtPeriodEnd(cancelAtPeriodEnd)
+	if s.IsActive() {
+		s.EndGracePeriod()
+	}
 }
 
 // SubscriptionPlan represents a subscription plan configuration
@@ -0,0 +1,15 @@
+package entities
+
+import "time"
+
+// MaxTimezoneLength is the longest timezone name a user may set
+const MaxTimezoneLength = 64
+
+// IsValidTimezone returns true if the timezone is a known IANA name such as Europe/Berlin
+func IsValidTimezone(timezone string) bool {
+	if timezone == "" || len(timezone) > MaxTimezoneLength {
+		return false
+	}
+	_, err := time.LoadLocation(timezone)
+	return err == nil
+}
@@ -16,21 +16,36 @@ type User struct {
 	DateOfBirth    time.Time  `json:"date_of_birth" gorm:"not null"`
 	Gender         string     `json:"gender" gorm:"not null;check:gender IN ('male', 'female', 'other')"`
 	InterestedIn   []string   `json:"interested_in" gorm:"type:text[];not null"`
+	Interests      []string   `json:"interests" gorm:"type:text[]"` // Normalized with NormalizeInterests
 	Bio            *string    `json:"bio"`
 	LocationLat    *float64   `json:"location_lat"`
 	LocationLng    *float64   `json:"location_lng"`
 	LocationCity   *string    `json:"location_city"`
 	LocationCountry *string    `json:"location_country"`
+	HeightCm       *int       `json:"height_cm,omitempty"`
+	Smoking        *string    `json:"smoking,omitempty" gorm:"check:smoking IN ('never', 'socially', 'regularly')"`
+	Drinking       *string    `json:"drinking,omitempty" gorm:"check:drinking IN ('never', 'socially', 'regularly')"`
+	Locale         *string    `json:"locale,omitempty"`
+	Timezone       *string    `json:"timezone,omitempty"` // IANA name such as Europe/Berlin, daily limits reset at its midnight
+	AutoTranslateMessages bool `json:"auto_translate_messages" gorm:"default:false"`
+	SendReadReceipts bool     `json:"send_read_receipts" gorm:"default:true"` // When off, reading messages clears the user's unread counts without telling the sender
+	AutoIntro      *string    `json:"auto_intro,omitempty"` // Opener sent as the user's first message when they match
 	IsVerified     bool             `json:"is_verified" gorm:"default:false"`
 	VerificationLevel VerificationLevel `json:"verification_level" gorm:"default:0;check:verification_level IN (0, 1, 2)"`
 	IsPremium      bool       `json:"is_premium" gorm:"default:false"`
 	IsActive       bool       `json:"is_active" gorm:"default:true"`
 	IsBanned       bool       `json:"is_banned" gorm:"default:false"`
+	GeofenceExempt bool       `json:"geofence_exempt" gorm:"default:false"`
+	ProfileUnderReview bool   `json:"profile_under_review" gorm:"default:false"`
+	Shadowbanned   bool       `json:"shadowbanned" gorm:"default:false"` // Activity is hidden from other users, without telling the user
 	LastActive     *time.Time `json:"last_active"`
 	CreatedAt      time.Time  `json:"created_at" gorm:"autoCreateTime"`
 	UpdatedAt      time.Time  `json:"updated_at" gorm:"autoUpdateTime"`
 }
 
+// MaxAutoIntroLength is the longest auto-intro a user may set up, in characters
+const MaxAutoIntroLength = 300
+
 // TableName returns the table name for the User entity
 func (User) TableName() string {
 	return "users"
@@ -69,6 +84,26 @@ func (u *User) IsComplete() bool {
 		   u.HasLocation()
 }
 
+// PreferredLanguage returns the primary language of the user's locale, or "" if none is set
+func (u *User) PreferredLanguage() string {
+	if u.Locale == nil {
+		return ""
+	}
+	return LanguageOf(*u.Locale)
+}
+
+// TimeLocation returns the location of the user's timezone, or UTC if none or an unknown one is set
+func (u *User) TimeLocation() *time.Location {
+	if u.Timezone == nil {
+		return time.UTC
+	}
+	location, err := time.LoadLocation(*u.Timezone)
+	if err != nil {
+		return time.UTC
+	}
+	return location
+}
+
 // GetVerificationLevel returns user's verification level
 func (u *User) GetVerificationLevel() VerificationLevel {
 	return u.VerificationLevel
@@ -111,6 +111,39 @@ func (v *Verification) SetDocumentData(documentType string, data string) {
 	v.DocumentData = &data
 }
 
+// VerificationDocument represents a single document submitted within a document verification session
+type VerificationDocument struct {
+	ID              uuid.UUID                       `json:"id" gorm:"type:uuid;primary_key;default:gen_random_uuid()"`
+	VerificationID  uuid.UUID                       `json:"verification_id" gorm:"type:uuid;not null;index"`
+	UserID          uuid.UUID                       `json:"user_id" gorm:"type:uuid;not null;index"`
+	DocumentType    string                          `json:"document_type" gorm:"not null"`
+	Status          valueobjects.VerificationStatus `json:"status" gorm:"not null;default:'pending';check:status IN ('pending', 'approved', 'rejected')"`
+	PhotoURL        string                          `json:"photo_url" gorm:"not null"`
+	PhotoKey        string                          `json:"photo_key" gorm:"not null"`
+	Confidence      *float64                        `json:"confidence"`
+	DocumentData    *string                         `json:"document_data"` // JSON string with extracted document data
+	RejectionReason *string                         `json:"rejection_reason"`
+	CreatedAt       time.Time                       `json:"created_at" gorm:"autoCreateTime"`
+	UpdatedAt       time.Time                       `json:"updated_at" gorm:"autoUpdateTime"`
+}
+
+// TableName returns the table name for VerificationDocument entity
+func (VerificationDocument) TableName() string {
+	return "verification_documents"
+}
+
+// Approve marks the document as approved
+func (vd *VerificationDocument) Approve() {
+	vd.Status = valueobjects.VerificationStatusApproved
+	vd.RejectionReason = nil
+}
+
+// Reject marks the document as rejected with a reason
+func (vd *VerificationDocument) Reject(reason string) {
+	vd.Status = valueobjects.VerificationStatusRejected
+	vd.RejectionReason = &reason
+}
+
 // VerificationAttempt represents a verification attempt tracking
 type VerificationAttempt struct {
 	ID         uuid.UUID        `json:"id" gorm:"type:uuid;primary_key;default:gen_random_uuid()"`
@@ -0,0 +1,24 @@
+package repositories
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/22smeargle/winkr-backend/internal/domain/entities"
+)
+
+// AccountSignalRepository defines interface for the device and IP signals used to link accounts
+type AccountSignalRepository interface {
+	// RecordSignal stores a signal, or updates when it was last seen if the account already has it
+	RecordSignal(ctx context.Context, signal *entities.AccountSignal) error
+
+	// GetUserIDsBySignal retrieves the accounts seen with a signal since the given time
+	GetUserIDsBySignal(ctx context.Context, signalType, value string, since time.Time) ([]uuid.UUID, error)
+
+	// SaveLinkedAccountFlag flags a signal for review, or updates the existing flag for it
+	SaveLinkedAccountFlag(ctx context.Context, flag *entities.LinkedAccountFlag) error
+
+	// ListLinkedAccountFlags retrieves flagged signals, most recently updated first
+	ListLinkedAccountFlags(ctx context.Context, limit, offset int) ([]*entities.LinkedAccountFlag, int64, error)
+}
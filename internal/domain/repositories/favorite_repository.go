@@ -0,0 +1,27 @@
+package repositories
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/22smeargle/winkr-backend/internal/domain/entities"
+)
+
+// FavoriteRepository defines interface for saved profile data operations
+type FavoriteRepository interface {
+	// Create saves a favorite; favoriting the same user twice is a no-op
+	Create(ctx context.Context, favorite *entities.Favorite) error
+
+	// Delete removes a favorite; removing one that does not exist is a no-op
+	Delete(ctx context.Context, userID, favoriteUserID uuid.UUID) error
+
+	// Exists checks if a user has favorited another user
+	Exists(ctx context.Context, userID, favoriteUserID uuid.UUID) (bool, error)
+
+	// GetByUserID retrieves a user's favorites, most recent first, with the total.
+	// Favorites of banned, deactivated or deleted users are not listed.
+	GetByUserID(ctx context.Context, userID uuid.UUID, limit, offset int) ([]*entities.Favorite, int64, error)
+
+	// CountByUserID counts the favorites GetByUserID lists
+	CountByUserID(ctx context.Context, userID uuid.UUID) (int64, error)
+}
@@ -2,6 +2,7 @@ package repositories
 
 import (
 	"context"
+	"time"
 
 	"github.com/google/uuid"
 	"github.com/22smeargle/winkr-backend/internal/domain/entities"
@@ -14,6 +15,7 @@ type MatchRepository interface {
 	GetMatchByID(ctx context.Context, id uuid.UUID) (*entities.Match, error)
 	GetMatchByUsers(ctx context.Context, user1ID, user2ID uuid.UUID) (*entities.Match, error)
 	UpdateMatch(ctx context.Context, match *entities.Match) error
+	DeactivateMatch(ctx context.Context, matchID uuid.UUID) error
 	DeleteMatch(ctx context.Context, id uuid.UUID) error
 
 	// User match operations
@@ -30,9 +32,11 @@ type MatchRepository interface {
 
 	// User swipe operations
 	GetUserSwipes(ctx context.Context, userID uuid.UUID, limit, offset int) ([]*entities.Swipe, error)
+	GetLastSwipe(ctx context.Context, userID uuid.UUID) (*entities.Swipe, error) // nil when the user has not swiped
 	GetUserLikes(ctx context.Context, userID uuid.UUID, limit, offset int) ([]*entities.Swipe, error)
 	GetUserPasses(ctx context.Context, userID uuid.UUID, limit, offset int) ([]*entities.Swipe, error)
 	GetSwipeCount(ctx context.Context, userID uuid.UUID) (int64, error)
+	GetSwipedTargetIDs(ctx context.Context, userID uuid.UUID) ([]uuid.UUID, error) // Users the user swiped on, either way
 	GetLikeCount(ctx context.Context, userID uuid.UUID) (int64, error)
 
 	// Swipe existence checks
@@ -43,6 +47,9 @@ type MatchRepository interface {
 	CheckForMatch(ctx context.Context, swiperID, swipedID uuid.UUID) (*entities.Match, bool, error)
 	GetMutualLikes(ctx context.Context, userID uuid.UUID, limit, offset int) ([]*entities.User, error)
 	GetUsersWhoLikedUser(ctx context.Context, userID uuid.UUID, limit, offset int) ([]*entities.User, error)
+	// GetIncomingLikes returns the likes the user received, newest first, leaving out shadowbanned users and users the
+	// user already swiped on or matched with
+	GetIncomingLikes(ctx context.Context, userID uuid.UUID, limit, offset int) ([]*entities.Swipe, error)
 
 	// Potential matches
 	GetPotentialMatches(ctx context.Context, userID uuid.UUID, limit, offset int) ([]*entities.User, error)
@@ -56,12 +63,15 @@ type MatchRepository interface {
 	// Existence checks
 	MatchExists(ctx context.Context, user1ID, user2ID uuid.UUID) (bool, error)
 	SwipeExists(ctx context.Context, swiperID, swipedID uuid.UUID) (bool, error)
+	ExistsSwipe(ctx context.Context, userID, targetID uuid.UUID) (bool, error)
 
 	// Analytics and statistics
 	GetMatchStats(ctx context.Context, userID uuid.UUID) (*MatchStats, error)
 	GetSwipeStats(ctx context.Context, userID uuid.UUID) (*SwipeStats, error)
 	GetMatchesCreatedInRange(ctx context.Context, startDate, endDate interface{}) (int64, error)
 	GetSwipesCreatedInRange(ctx context.Context, userID uuid.UUID, startDate, endDate interface{}) (int64, error)
+	GetIncomingSwipeCounts(ctx context.Context, userID uuid.UUID, since time.Time) (*IncomingSwipeCounts, error)
+	GetCohortLikeRate(ctx context.Context, cohort SwipeCohort, since time.Time, minSwipes int) (float64, error)
 
 	// Admin operations
 	GetAllMatches(ctx context.Context, limit, offset int) ([]*entities.Match, error)
@@ -111,6 +121,20 @@ type SwipeStats struct {
 	LikeRate         float64 `json:"like_rate"`
 }
 
+// IncomingSwipeCounts represents how often other users liked or passed on a user.
+// It is an aggregate and never says who swiped.
+type IncomingSwipeCounts struct {
+	Likes  int64 `json:"likes"`
+	Passes int64 `json:"passes"`
+}
+
+// SwipeCohort selects the users whose profiles are compared with each other
+type SwipeCohort struct {
+	Gender     string    `json:"gender"`
+	BornAfter  time.Time `json:"born_after"`
+	BornBefore time.Time `json:"born_before"`
+}
+
 // MatchAnalytics represents global match analytics
 type MatchAnalytics struct {
 	TotalMatches      int64   `json:"total_matches"`
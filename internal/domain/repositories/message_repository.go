@@ -2,6 +2,7 @@ package repositories
 
 import (
 	"context"
+	"time"
 
 	"github.com/google/uuid"
 	"github.com/22smeargle/winkr-backend/internal/domain/entities"
@@ -12,6 +13,7 @@ type MessageRepository interface {
 	// Basic CRUD operations
 	Create(ctx context.Context, message *entities.Message) error
 	GetByID(ctx context.Context, id uuid.UUID) (*entities.Message, error)
+	GetBySenderAndClientMessageID(ctx context.Context, senderID uuid.UUID, clientMessageID string) (*entities.Message, error)
 	Update(ctx context.Context, message *entities.Message) error
 	Delete(ctx context.Context, id uuid.UUID) error
 
@@ -26,13 +28,38 @@ type MessageRepository interface {
 	GetMessagesByUser(ctx context.Context, userID uuid.UUID, limit, offset int) ([]*entities.Message, error)
 	GetUnreadMessages(ctx context.Context, userID uuid.UUID) ([]*entities.Message, error)
 	GetRecentMessages(ctx context.Context, userID uuid.UUID, limit int) ([]*entities.Message, error)
+	GetMessagesAfterCursor(ctx context.Context, conversationID uuid.UUID, cursor *entities.Message, limit int) ([]*entities.Message, error)
 
 	// Message status operations
 	MarkAsRead(ctx context.Context, messageID uuid.UUID) error
 	MarkConversationAsRead(ctx context.Context, conversationID, userID uuid.UUID) error
+	EditMessage(ctx context.Context, messageID uuid.UUID, content string, encryptedOriginal *string, editedAt time.Time) error
 	SoftDeleteMessage(ctx context.Context, messageID uuid.UUID) error
 	RestoreMessage(ctx context.Context, messageID uuid.UUID) error
 
+	// Pinned messages
+	PinMessage(ctx context.Context, messageID, userID uuid.UUID) error
+	UnpinMessage(ctx context.Context, messageID uuid.UUID) error
+	GetPinnedMessages(ctx context.Context, conversationID uuid.UUID) ([]*entities.Message, error)
+	CountPinnedMessages(ctx context.Context, conversationID uuid.UUID) (int64, error)
+
+	// Emoji reactions, at most one per message, user and emoji
+	AddReaction(ctx context.Context, reaction *entities.MessageReaction) error
+	RemoveReaction(ctx context.Context, messageID, userID uuid.UUID, emoji string) (bool, error)
+	GetReactionCounts(ctx context.Context, messageIDs []uuid.UUID) (map[uuid.UUID]map[string]int, error)
+
+	// Open conversations and per-user archiving
+	CountOpenConversations(ctx context.Context, userID, excludeConversationID uuid.UUID) (int64, error)
+	HasSentMessages(ctx context.Context, conversationID, userID uuid.UUID) (bool, error)
+	ArchiveConversation(ctx context.Context, conversationID, userID uuid.UUID) error
+	UnarchiveConversation(ctx context.Context, conversationID, userID uuid.UUID) error
+	IsConversationArchived(ctx context.Context, conversationID, userID uuid.UUID) (bool, error)
+
+	// Delivery status transitions
+	CreateMessageStatus(ctx context.Context, status *entities.MessageStatus) error
+	GetMessageStatuses(ctx context.Context, messageIDs []uuid.UUID) ([]*entities.MessageStatus, error)
+	GetConversationStatusesSince(ctx context.Context, conversationID uuid.UUID, since time.Time) ([]*entities.MessageStatus, error)
+
 	// User conversation operations
 	GetUserConversations(ctx context.Context, userID uuid.UUID, limit, offset int) ([]*entities.Conversation, error)
 	GetUserConversationsWithUnreadCount(ctx context.Context, userID uuid.UUID, limit, offset int) ([]*ConversationWithUnread, error)
@@ -0,0 +1,17 @@
+package repositories
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/22smeargle/winkr-backend/internal/domain/entities"
+)
+
+// NoticeAcknowledgementRepository defines interface for legal notice acknowledgement data operations
+type NoticeAcknowledgementRepository interface {
+	// Create records an acknowledgement; acknowledging the same notice version twice is a no-op
+	Create(ctx context.Context, acknowledgement *entities.NoticeAcknowledgement) error
+
+	// GetByUserID retrieves all acknowledgements of a user
+	GetByUserID(ctx context.Context, userID uuid.UUID) ([]*entities.NoticeAcknowledgement, error)
+}
@@ -0,0 +1,27 @@
+package repositories
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/22smeargle/winkr-backend/internal/domain/entities"
+)
+
+// PhotoEngagementRepository defines interface for photo impression data operations
+type PhotoEngagementRepository interface {
+	// RecordImpression records that the viewer was shown the photo. A viewer counts once per photo,
+	// however often they see it.
+	RecordImpression(ctx context.Context, impression *entities.PhotoImpression) error
+
+	// MarkLiked marks the owner's photos the viewer was shown as liked
+	MarkLiked(ctx context.Context, ownerID, viewerID uuid.UUID, likedAt time.Time) error
+
+	// GetPerformanceByUserID sums up the impressions of the user's photos, keyed by photo ID.
+	// Photos that were never shown are omitted.
+	GetPerformanceByUserID(ctx context.Context, userID uuid.UUID) (map[uuid.UUID]*entities.PhotoPerformance, error)
+
+	// GetAutoReorderUserIDs retrieves the users who opted in to having their photos reordered,
+	// ordered by ID and starting after the given ID
+	GetAutoReorderUserIDs(ctx context.Context, afterID uuid.UUID, limit int) ([]uuid.UUID, error)
+}
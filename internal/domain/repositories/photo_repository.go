@@ -19,14 +19,24 @@ type PhotoRepository interface {
 	GetUserPhotos(ctx context.Context, userID uuid.UUID, includeDeleted bool) ([]*entities.Photo, error)
 	GetUserPrimaryPhoto(ctx context.Context, userID uuid.UUID) (*entities.Photo, error)
 	GetUserPhotoCount(ctx context.Context, userID uuid.UUID) (int, error)
+	// CountApprovedByUserIDs returns the number of approved, non-deleted, visible photos per user. Users without any are omitted.
+	CountApprovedByUserIDs(ctx context.Context, userIDs []uuid.UUID) (map[uuid.UUID]int, error)
 
 	// Photo verification operations
 	GetPendingVerificationPhotos(ctx context.Context, limit, offset int) ([]*entities.Photo, error)
 	GetPhotosByVerificationStatus(ctx context.Context, status string, limit, offset int) ([]*entities.Photo, error)
 	UpdateVerificationStatus(ctx context.Context, photoID uuid.UUID, status string, reason *string) error
+	// RequestReview puts the photo back in the moderation queue, hiding it from profiles when hide is set
+	RequestReview(ctx context.Context, photoID uuid.UUID, hide bool) error
 
 	// Photo management operations
+	// SetPrimaryPhoto makes the photo primary. The lock of the previous primary photo is released.
 	SetPrimaryPhoto(ctx context.Context, userID, photoID uuid.UUID) error
+	// LockPrimaryPhoto locks or unlocks the user's primary photo against reordering by engagement
+	LockPrimaryPhoto(ctx context.Context, userID uuid.UUID, locked bool) error
+	// ReorderPhotos stores the display order of the user's photos, making the first one primary. It fails
+	// if another photo is a locked primary.
+	ReorderPhotos(ctx context.Context, userID uuid.UUID, photoIDs []uuid.UUID) error
 	UnsetPrimaryPhoto(ctx context.Context, userID uuid.UUID) error
 	SoftDeletePhoto(ctx context.Context, photoID uuid.UUID) error
 	RestorePhoto(ctx context.Context, photoID uuid.UUID) error
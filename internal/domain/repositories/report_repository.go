@@ -2,6 +2,7 @@ package repositories
 
 import (
 	"context"
+	"time"
 
 	"github.com/google/uuid"
 	"github.com/22smeargle/winkr-backend/internal/domain/entities"
@@ -48,6 +49,9 @@ type ReportRepository interface {
 	ExistsByID(ctx context.Context, id uuid.UUID) (bool, error)
 	UserCanReport(ctx context.Context, reporterID, reportedUserID uuid.UUID) (bool, error)
 	HasActiveReport(ctx context.Context, reporterID, reportedUserID uuid.UUID) (bool, error)
+	HasRecentReport(ctx context.Context, reporterID, reportedUserID uuid.UUID, since time.Time) (bool, error)
+	HasActivePhotoReport(ctx context.Context, reporterID, photoID uuid.UUID) (bool, error)
+	CountPendingByPhoto(ctx context.Context, photoID uuid.UUID) (int64, error)
 
 	// Analytics and statistics
 	GetReportStats(ctx context.Context) (*ReportStats, error)
@@ -0,0 +1,34 @@
+package repositories
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/22smeargle/winkr-backend/internal/domain/entities"
+)
+
+// ReputationRepository defines interface for user reputation data operations
+type ReputationRepository interface {
+	// GetByUserID retrieves a user's reputation, or nil if it never changed
+	GetByUserID(ctx context.Context, userID uuid.UUID) (*entities.UserReputation, error)
+
+	// GetScores retrieves the scores of the users whose reputation ever changed
+	GetScores(ctx context.Context, userIDs []uuid.UUID) (map[uuid.UUID]int, error)
+
+	// ApplyChange sets the user's score to the change's new score and records the change. The score
+	// is only updated while it still equals the change's previous score; otherwise nothing is saved
+	// and false is returned, so concurrent changes are not lost. initialScore is the previous score
+	// of users without a reputation yet.
+	ApplyChange(ctx context.Context, change *entities.ReputationChange, initialScore int) (bool, error)
+
+	// IncrementReportsReceived counts a new report against the user and returns how many reports the
+	// user has received. initialScore is the score of users without a reputation yet.
+	IncrementReportsReceived(ctx context.Context, userID uuid.UUID, initialScore int) (int, error)
+
+	// GetRecoverable retrieves reputations below the score that have not changed since the time
+	GetRecoverable(ctx context.Context, below int, unchangedSince time.Time, limit int) ([]*entities.UserReputation, error)
+
+	// GetChanges retrieves the user's most recent reputation changes, newest first
+	GetChanges(ctx context.Context, userID uuid.UUID, limit int) ([]*entities.ReputationChange, error)
+}
@@ -0,0 +1,35 @@
+package repositories
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/22smeargle/winkr-backend/internal/domain/entities"
+)
+
+// SafetyCheckInRepository defines interface for safety check-in data operations
+type SafetyCheckInRepository interface {
+	// Create saves a new check-in
+	Create(ctx context.Context, checkIn *entities.SafetyCheckIn) error
+
+	// GetByID retrieves a check-in by ID
+	GetByID(ctx context.Context, id uuid.UUID) (*entities.SafetyCheckIn, error)
+
+	// CountScheduledByUserID counts a user's check-ins that are still waiting to be confirmed
+	CountScheduledByUserID(ctx context.Context, userID uuid.UUID) (int64, error)
+
+	// GetScheduledDueBefore retrieves scheduled check-ins due before the given time, oldest first
+	GetScheduledDueBefore(ctx context.Context, before time.Time, limit int) ([]*entities.SafetyCheckIn, error)
+
+	// MarkConfirmed confirms a scheduled or missed check-in. It returns false if the check-in
+	// was not in either status, so confirming twice only takes effect once.
+	MarkConfirmed(ctx context.Context, id uuid.UUID, confirmedAt time.Time) (bool, error)
+
+	// MarkMissed marks a scheduled check-in as missed. It returns false if the check-in was no
+	// longer scheduled, so only one caller sends alerts for it.
+	MarkMissed(ctx context.Context, id uuid.UUID, missedAt time.Time) (bool, error)
+
+	// MarkContactAlerted records when the trusted contact was alerted
+	MarkContactAlerted(ctx context.Context, id uuid.UUID, alertedAt time.Time) error
+}
@@ -0,0 +1,38 @@
+package repositories
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/22smeargle/winkr-backend/internal/domain/entities"
+)
+
+// ScheduledMessageRepository defines interface for scheduled message data operations
+type ScheduledMessageRepository interface {
+	// Create saves a new scheduled message
+	Create(ctx context.Context, message *entities.ScheduledMessage) error
+
+	// GetByID retrieves a scheduled message by ID
+	GetByID(ctx context.Context, id uuid.UUID) (*entities.ScheduledMessage, error)
+
+	// CountScheduledByConversation counts a sender's messages in a conversation that are still waiting to be sent
+	CountScheduledByConversation(ctx context.Context, conversationID, senderID uuid.UUID) (int64, error)
+
+	// GetScheduledDueBefore retrieves scheduled messages due before the given time, oldest first
+	GetScheduledDueBefore(ctx context.Context, before time.Time, limit int) ([]*entities.ScheduledMessage, error)
+
+	// MarkSending claims a scheduled message for sending. It returns false if the message was no
+	// longer scheduled, so only one caller sends it and it can't be canceled while being sent.
+	MarkSending(ctx context.Context, id uuid.UUID) (bool, error)
+
+	// MarkSent records the message a claimed scheduled message was sent as
+	MarkSent(ctx context.Context, id, messageID uuid.UUID, sentAt time.Time) error
+
+	// MarkSkipped records why a claimed scheduled message was not sent
+	MarkSkipped(ctx context.Context, id uuid.UUID, reason string, skippedAt time.Time) error
+
+	// MarkCanceled cancels a scheduled message. It returns false if the message was no longer
+	// scheduled, e.g. because it is already being sent.
+	MarkCanceled(ctx context.Context, id uuid.UUID, canceledAt time.Time) (bool, error)
+}
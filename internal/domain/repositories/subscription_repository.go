@@ -2,6 +2,7 @@ package repositories
 
 import (
 	"context"
+	"time"
 
 	"github.com/google/uuid"
 	"github.com/22smeargle/winkr-backend/internal/domain/entities"
@@ -26,6 +27,7 @@ type SubscriptionRepository interface {
 	GetCanceledSubscriptions(ctx context.Context, limit, offset int) ([]*entities.Subscription, error)
 	GetPastDueSubscriptions(ctx context.Context, limit, offset int) ([]*entities.Subscription, error)
 	GetUnpaidSubscriptions(ctx context.Context, limit, offset int) ([]*entities.Subscription, error)
+	GetExpiredGracePeriodSubscriptions(ctx context.Context, now time.Time, limit int) ([]*entities.Subscription, error)
 
 	// Plan type operations
 	GetByPlanType(ctx context.Context, planType string, limit, offset int) ([]*entities.Subscription, error)
@@ -0,0 +1,25 @@
+package repositories
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+)
+
+// UserBlockRepository defines interface for user block data operations
+type UserBlockRepository interface {
+	// Block blocks a user for the blocker; blocking a user twice is a no-op
+	Block(ctx context.Context, blockerID, blockedID uuid.UUID) error
+
+	// BlockMany blocks each of the given users for the blocker; blocking a user twice is a no-op
+	BlockMany(ctx context.Context, blockerID uuid.UUID, blockedIDs []uuid.UUID) error
+
+	// Unblock removes the blocker's block of a user; unblocking a user who isn't blocked is a no-op
+	Unblock(ctx context.Context, blockerID, blockedID uuid.UUID) error
+
+	// IsBlocked reports whether either user has blocked the other
+	IsBlocked(ctx context.Context, userID, otherUserID uuid.UUID) (bool, error)
+
+	// GetBlockedUserIDs retrieves the users a user has blocked or been blocked by
+	GetBlockedUserIDs(ctx context.Context, userID uuid.UUID) ([]uuid.UUID, error)
+}
@@ -2,6 +2,7 @@ package repositories
 
 import (
 	"context"
+	"time"
 
 	"github.com/google/uuid"
 	"github.com/22smeargle/winkr-backend/internal/domain/entities"
@@ -13,12 +14,21 @@ type UserRepository interface {
 	Create(ctx context.Context, user *entities.User) error
 	GetByID(ctx context.Context, id uuid.UUID) (*entities.User, error)
 	GetByEmail(ctx context.Context, email string) (*entities.User, error)
+	GetUsersByIDs(ctx context.Context, ids []uuid.UUID) ([]*entities.User, error) // Unknown IDs are left out, order is not kept
+	GetIDsByEmailHashes(ctx context.Context, hashes []string) ([]uuid.UUID, error) // Hashes are hex SHA-256 of the trimmed, lowercased email
 	Update(ctx context.Context, user *entities.User) error
 	Delete(ctx context.Context, id uuid.UUID) error
 
+	// FindUsers retrieves users matching every criterion set on the filter in a single query
+	FindUsers(ctx context.Context, filter UserFilter, page Pagination) ([]*entities.User, error)
+
 	// User specific operations
+	// GetByLocation retrieves discoverable users within the radius, nearest first
 	GetByLocation(ctx context.Context, lat, lng float64, radiusKm int, limit, offset int) ([]*entities.User, error)
+	// GetActiveByLocation is GetByLocation limited to users active since the given time
+	GetActiveByLocation(ctx context.Context, lat, lng float64, radiusKm int, activeSince time.Time, limit, offset int) ([]*entities.User, error)
 	GetPotentialMatches(ctx context.Context, userID uuid.UUID, limit, offset int) ([]*entities.User, error)
+	// Deprecated: use FindUsers with UserFilter.Genders, UserFilter.InterestedIn and the age range
 	GetUsersByPreferences(ctx context.Context, userID uuid.UUID, preferences *entities.UserPreferences, limit, offset int) ([]*entities.User, error)
 	UpdateLastActive(ctx context.Context, userID uuid.UUID) error
 	SearchUsers(ctx context.Context, query string, limit, offset int) ([]*entities.User, error)
@@ -51,8 +61,11 @@ type UserRepository interface {
 	ExistsByID(ctx context.Context, id uuid.UUID) (bool, error)
 
 	// Advanced queries
+	// Deprecated: use FindUsers with UserFilter.HasPhotos
 	GetUsersWithPhotos(ctx context.Context, limit, offset int) ([]*entities.User, error)
+	// Deprecated: use FindUsers with UserFilter.HasPhotos
 	GetUsersWithoutPhotos(ctx context.Context, limit, offset int) ([]*entities.User, error)
+	// Deprecated: use FindUsers with UserFilter.InactiveSince
 	GetInactiveUsers(ctx context.Context, days int, limit, offset int) ([]*entities.User, error)
 }
 
@@ -65,4 +78,58 @@ type UserStats struct {
 	ProfileViews     int64 `json:"profile_views"`
 	LastActiveDays   int   `json:"last_active_days"`
 	AccountAgeDays   int   `json:"account_age_days"`
-}
\ No newline at end of file
+}
+
+// Default and maximum page sizes for user queries
+const (
+	DefaultUserPageLimit = 20
+	MaxUserPageLimit     = 100
+)
+
+// UserFilter represents filters for user queries. Nil and empty fields are ignored.
+type UserFilter struct {
+	ExcludeIDs    []uuid.UUID         `json:"exclude_ids,omitempty"`
+	Discoverable  bool                `json:"discoverable,omitempty"` // Active, not banned and not under review
+	MinAge        *int                `json:"min_age,omitempty"`
+	MaxAge        *int                `json:"max_age,omitempty"`
+	Genders       []string            `json:"genders,omitempty"`       // Candidate gender is any of these
+	InterestedIn  *string             `json:"interested_in,omitempty"` // Candidate is interested in this gender
+	Location      *UserLocationFilter `json:"location,omitempty"`
+	IsVerified    *bool               `json:"is_verified,omitempty"`
+	IsPremium     *bool               `json:"is_premium,omitempty"`
+	IsBanned      *bool               `json:"is_banned,omitempty"`
+	HasPhotos     *bool               `json:"has_photos,omitempty"`
+	MinHeightCm   *int                `json:"min_height_cm,omitempty"`
+	MaxHeightCm   *int                `json:"max_height_cm,omitempty"`
+	Smoking       []string            `json:"smoking,omitempty"`
+	Drinking      []string            `json:"drinking,omitempty"`
+	ActiveSince   *time.Time          `json:"active_since,omitempty"`
+	InactiveSince *time.Time          `json:"inactive_since,omitempty"` // Last active before this time, or never
+}
+
+// UserLocationFilter restricts users to a radius around a point
+type UserLocationFilter struct {
+	Lat      float64 `json:"lat"`
+	Lng      float64 `json:"lng"`
+	RadiusKm float64 `json:"radius_km"`
+}
+
+// Pagination represents a page of results
+type Pagination struct {
+	Limit  int `json:"limit"`
+	Offset int `json:"offset"`
+}
+
+// Normalize returns the pagination with the limit clamped to the allowed range
+func (p Pagination) Normalize() Pagination {
+	if p.Limit <= 0 {
+		p.Limit = DefaultUserPageLimit
+	}
+	if p.Limit > MaxUserPageLimit {
+		p.Limit = MaxUserPageLimit
+	}
+	if p.Offset < 0 {
+		p.Offset = 0
+	}
+	return p
+}
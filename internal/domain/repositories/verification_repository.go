@@ -22,6 +22,11 @@ type VerificationRepository interface {
 	GetVerificationsForReview(ctx context.Context, status valueobjects.VerificationStatus, limit, offset int) ([]*entities.Verification, error)
 	GetVerificationStats(ctx context.Context) (*VerificationStats, error)
 
+	// Verification document operations
+	CreateVerificationDocument(ctx context.Context, document *entities.VerificationDocument) error
+	GetVerificationDocuments(ctx context.Context, verificationID uuid.UUID) ([]*entities.VerificationDocument, error)
+	UpdateVerificationDocument(ctx context.Context, document *entities.VerificationDocument) error
+
 	// Verification attempt operations
 	CreateVerificationAttempt(ctx context.Context, attempt *entities.VerificationAttempt) error
 	GetVerificationAttemptsByUser(ctx context.Context, userID uuid.UUID, vType entities.VerificationType, since time.Time) ([]*entities.VerificationAttempt, error)
@@ -35,6 +40,7 @@ type VerificationRepository interface {
 	UpdateBadge(ctx context.Context, badge *entities.VerificationBadge) error
 	RevokeBadge(ctx context.Context, id uuid.UUID, revokedBy uuid.UUID) error
 	DeleteExpiredBadges(ctx context.Context) error
+	GetUsersWithExpiredBadges(ctx context.Context, before time.Time) ([]uuid.UUID, error)
 
 	// User verification level operations
 	GetUserVerificationLevel(ctx context.Context, userID uuid.UUID) (entities.VerificationLevel, error)
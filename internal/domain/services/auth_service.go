@@ -8,7 +8,10 @@ import (
 	"github.com/22smeargle/winkr-backend/internal/domain/entities"
 	"github.com/22smeargle/winkr-backend/internal/domain/repositories"
 	"github.com/22smeargle/winkr-backend/internal/infrastructure/auth"
+	"github.com/22smeargle/winkr-backend/pkg/config"
 	"github.com/22smeargle/winkr-backend/pkg/errors"
+	"github.com/22smeargle/winkr-backend/pkg/logger"
+	"github.com/22smeargle/winkr-backend/pkg/security"
 	"github.com/22smeargle/winkr-backend/pkg/utils"
 )
 
@@ -17,7 +20,7 @@ type AuthService interface {
 	// User authentication
 	Register(ctx context.Context, req *RegisterRequest) (*AuthResponse, error)
 	Login(ctx context.Context, req *LoginRequest, deviceInfo *utils.DeviceInfo, ipAddress string) (*AuthResponse, error)
-	RefreshToken(ctx context.Context, refreshToken string) (*TokenResponse, error)
+	RefreshToken(ctx context.Context, refreshToken, ipAddress, userAgent string) (*TokenResponse, error)
 	Logout(ctx context.Context, userID uuid.UUID, sessionID string) error
 	LogoutFromAllDevices(ctx context.Context, userID uuid.UUID) error
 
@@ -47,12 +50,23 @@ type AuthService interface {
 	IsAccountLocked(ctx context.Context, email string) (bool, error)
 }
 
+// AccountLockout tracks failed logins and locks accounts that have too many. Its state is shared by
+// every instance. Locked accounts are reported with an errors.AccountLockedError.
+type AccountLockout interface {
+	CheckLocked(ctx context.Context, email string) error
+	RecordFailedAttempt(ctx context.Context, email string) error
+	Reset(ctx context.Context, email string) error
+}
+
 // AuthServiceImpl implements the AuthService interface
 type AuthServiceImpl struct {
 	userRepo       repositories.UserRepository
 	jwtUtils       *utils.JWTUtils
 	tokenManager   *auth.TokenManager
 	sessionManager *auth.SessionManager
+	lockout        AccountLockout
+	security       *config.SecurityConfig
+	passwords      *security.PasswordPolicy
 	passwordHash   func(string) (string, error)
 }
 
@@ -62,12 +76,18 @@ func NewAuthService(
 	jwtUtils *utils.JWTUtils,
 	tokenManager *auth.TokenManager,
 	sessionManager *auth.SessionManager,
+	lockout AccountLockout,
+	security *config.SecurityConfig,
+	passwords *security.PasswordPolicy,
 ) AuthService {
 	return &AuthServiceImpl{
 		userRepo:       userRepo,
 		jwtUtils:       jwtUtils,
 		tokenManager:   tokenManager,
 		sessionManager: sessionManager,
+		lockout:        lockout,
+		security:       security,
+		passwords:      passwords,
 		passwordHash:   utils.HashPassword,
 	}
 }
@@ -183,6 +203,11 @@ func (s *AuthServiceImpl) Register(ctx context.Context, req *RegisterRequest) (*
 		return nil, errors.ErrAccountLocked
 	}
 
+	// Enforce the password policy
+	if err := s.passwords.ValidatePassword(req.Password); err != nil {
+		return nil, err
+	}
+
 	// Hash password
 	hashedPassword, err := s.passwordHash(req.Password)
 	if err != nil {
@@ -250,21 +275,19 @@ func (s *AuthServiceImpl) Register(ctx context.Context, req *RegisterRequest) (*
 
 // Login implements user login
 func (s *AuthServiceImpl) Login(ctx context.Context, req *LoginRequest, deviceInfo *utils.DeviceInfo, ipAddress string) (*AuthResponse, error) {
-	// Check if account is locked
-	locked, err := s.IsAccountLocked(ctx, req.Email)
-	if err != nil {
+	// Check if account is locked, reporting how long it stays locked
+	if err := s.lockout.CheckLocked(ctx, req.Email); err != nil {
+		if _, ok := errors.GetAccountLockedError(err); ok {
+			return nil, err
+		}
 		return nil, errors.WrapError(err, "Failed to check account lock status")
 	}
-	if locked {
-		return nil, errors.ErrAccountLocked
-	}
 
 	// Get user by email
 	user, err := s.userRepo.GetByEmail(ctx, req.Email)
 	if err != nil {
-		// Increment failed attempts for non-existent users to prevent enumeration
-		s.IncrementFailedAttempts(ctx, req.Email)
-		return nil, errors.ErrInvalidCredentials
+		// Count failed attempts for non-existent users too, to prevent enumeration
+		return nil, s.failedLogin(ctx, req.Email)
 	}
 
 	// Check if user is active
@@ -280,9 +303,7 @@ func (s *AuthServiceImpl) Login(ctx context.Context, req *LoginRequest, deviceIn
 	// Verify password
 	err = utils.CheckPassword(req.Password, user.PasswordHash)
 	if err != nil {
-		// Increment failed attempts
-		s.IncrementFailedAttempts(ctx, req.Email)
-		return nil, errors.ErrInvalidCredentials
+		return nil, s.failedLogin(ctx, req.Email)
 	}
 
 	// Reset failed attempts on successful login
@@ -298,7 +319,7 @@ func (s *AuthServiceImpl) Login(ctx context.Context, req *LoginRequest, deviceIn
 	}
 
 	// Create session
-	session, err := s.sessionManager.CreateSession(ctx, user.ID.String(), deviceInfo.Fingerprint, deviceInfo, ipAddress, "")
+	session, err := s.sessionManager.CreateSession(ctx, user.ID.String(), deviceInfo.Fingerprint, deviceInfo, ipAddress, deviceInfo.UserAgent)
 	if err != nil {
 		return nil, errors.WrapError(err, "Failed to create session")
 	}
@@ -334,14 +355,19 @@ func (s *AuthServiceImpl) Login(ctx context.Context, req *LoginRequest, deviceIn
 	}, nil
 }
 
-// RefreshToken implements token refresh
-func (s *AuthServiceImpl) RefreshToken(ctx context.Context, refreshToken string) (*TokenResponse, error) {
+// RefreshToken implements token refresh. With device fingerprinting on, the refresh must come from
+// the device the session was created on, give or take the configured number of changed attributes.
+func (s *AuthServiceImpl) RefreshToken(ctx context.Context, refreshToken, ipAddress, userAgent string) (*TokenResponse, error) {
 	// Validate refresh token with session checking
 	claims, err := s.tokenManager.ValidateTokenWithSession(ctx, refreshToken)
 	if err != nil {
 		return nil, errors.ErrInvalidToken
 	}
 
+	if err := s.checkRefreshDevice(ctx, claims, ipAddress, userAgent); err != nil {
+		return nil, err
+	}
+
 	// Get user
 	user, err := s.userRepo.GetByID(ctx, uuid.MustParse(claims.UserID))
 	if err != nil {
@@ -377,6 +403,54 @@ func (s *AuthServiceImpl) RefreshToken(ctx context.Context, refreshToken string)
 	}, nil
 }
 
+// checkRefreshDevice rejects a refresh from a device other than the one the session was created on.
+// Tokens issued without a device or session, and sessions without a fingerprint, are not bound.
+func (s *AuthServiceImpl) checkRefreshDevice(ctx context.Context, claims *utils.Claims, ipAddress, userAgent string) error {
+	if s.security == nil || !s.security.DeviceFingerprinting || claims.DeviceID == "" || claims.SessionID == "" {
+		return nil
+	}
+
+	session, err := s.sessionManager.GetSession(ctx, claims.SessionID)
+	if err != nil {
+		return errors.ErrInvalidToken
+	}
+	if session.DeviceInfo == nil || session.DeviceInfo.Fingerprint == "" {
+		return nil
+	}
+
+	// The token carries the fingerprint too, so a token used with another session's device is caught
+	bound := &utils.DeviceInfo{
+		UserAgent:   session.UserAgent,
+		IPAddress:   session.IPAddress,
+		Platform:    session.DeviceInfo.Platform,
+		Device:      session.DeviceInfo.Device,
+		Browser:     session.DeviceInfo.Browser,
+		Fingerprint: session.DeviceInfo.Fingerprint,
+	}
+	presented := s.jwtUtils.ParseDeviceInfo(userAgent, ipAddress)
+
+	drift := utils.DeviceDrift(bound, presented)
+	if claims.DeviceID == bound.Fingerprint && drift <= s.security.DeviceFingerprintTolerance {
+		if drift > 0 {
+			logger.Info("Refresh token device drift tolerated", "user_id", claims.UserID, "session_id", claims.SessionID, "drift", drift)
+		}
+		return nil
+	}
+
+	logger.Warn("Security event detected", map[string]interface{}{
+		"event_type": "refresh_token_device_mismatch",
+		"event_details": map[string]interface{}{
+			"user_id":    claims.UserID,
+			"session_id": claims.SessionID,
+			"drift":      drift,
+			"tolerance":  s.security.DeviceFingerprintTolerance,
+		},
+		"remote_addr": ipAddress,
+		"user_agent":  userAgent,
+	})
+	return errors.ErrDeviceMismatch
+}
+
 // Logout implements user logout
 func (s *AuthServiceImpl) Logout(ctx context.Context, userID uuid.UUID, sessionID string) error {
 	// Invalidate specific session
@@ -466,6 +540,11 @@ func (s *AuthServiceImpl) ChangePassword(ctx context.Context, userID uuid.UUID,
 		return errors.ErrInvalidCredentials
 	}
 
+	// Enforce the password policy
+	if err := s.passwords.ValidatePassword(req.NewPassword); err != nil {
+		return err
+	}
+
 	// Hash new password
 	hashedPassword, err := s.passwordHash(req.NewPassword)
 	if err != nil {
@@ -522,6 +601,11 @@ func (s *AuthServiceImpl) ResetPassword(ctx context.Context, req *ResetPasswordR
 
 // ConfirmPasswordReset implements password reset confirmation
 func (s *AuthServiceImpl) ConfirmPasswordReset(ctx context.Context, req *ConfirmPasswordResetRequest) error {
+	// Enforce the password policy before the reset token is looked up
+	if err := s.passwords.ValidatePassword(req.Password); err != nil {
+		return err
+	}
+
 	// Validate reset token and get user ID
 	resetKey := fmt.Sprintf("password_reset:%s", req.Token)
 	resetData, err := s.sessionManager.redisClient.HGetAll(ctx, resetKey)
@@ -598,75 +682,32 @@ func (s *AuthServiceImpl) DisableTwoFactor(ctx context.Context, userID uuid.UUID
 	return nil
 }
 
-// IncrementFailedAttempts increments failed login attempts for an email
+// IncrementFailedAttempts counts a failed login attempt for an email. It returns an
+// errors.AccountLockedError if the attempt locked the account.
 func (s *AuthServiceImpl) IncrementFailedAttempts(ctx context.Context, email string) error {
-	// Use Redis to track failed attempts
-	key := fmt.Sprintf("failed_attempts:%s", email)
-	
-	// Get current attempts
-	attempts, err := s.sessionManager.redisClient.Get(ctx, key)
-	if err != nil {
-		// Key doesn't exist, start from 0
-		attempts = "0"
-	}
-	
-	// Parse attempts
-	var currentAttempts int
-	if attempts != "" {
-		_, err := fmt.Sscanf(attempts, "%d", &currentAttempts)
-		if err != nil {
-			currentAttempts = 0
-		}
-	}
-	
-	// Increment attempts
-	currentAttempts++
-	
-	// Store updated attempts with 15-minute expiry
-	err = s.sessionManager.redisClient.Set(ctx, key, fmt.Sprintf("%d", currentAttempts), 15*time.Minute)
-	if err != nil {
-		return fmt.Errorf("failed to store failed attempts: %w", err)
-	}
-	
-	// If attempts exceed threshold (5), lock account for 30 minutes
-	if currentAttempts >= 5 {
-		lockKey := fmt.Sprintf("account_locked:%s", email)
-		err = s.sessionManager.redisClient.Set(ctx, lockKey, "1", 30*time.Minute)
-		if err != nil {
-			return fmt.Errorf("failed to lock account: %w", err)
-		}
-	}
-	
-	return nil
+	return s.lockout.RecordFailedAttempt(ctx, email)
 }
 
-// ResetFailedAttempts resets failed login attempts for an email
+// ResetFailedAttempts forgets the failed login attempts and previous lockouts of an email
 func (s *AuthServiceImpl) ResetFailedAttempts(ctx context.Context, email string) error {
-	// Remove failed attempts counter
-	key := fmt.Sprintf("failed_attempts:%s", email)
-	err := s.sessionManager.redisClient.Del(ctx, key)
-	if err != nil {
-		return fmt.Errorf("failed to reset failed attempts: %w", err)
-	}
-	
-	// Remove account lock if exists
-	lockKey := fmt.Sprintf("account_locked:%s", email)
-	err = s.sessionManager.redisClient.Del(ctx, lockKey)
-	if err != nil {
-		return fmt.Errorf("failed to remove account lock: %w", err)
-	}
-	
-	return nil
+	return s.lockout.Reset(ctx, email)
 }
 
 // IsAccountLocked checks if an account is locked due to too many failed attempts
 func (s *AuthServiceImpl) IsAccountLocked(ctx context.Context, email string) (bool, error) {
-	// Check if account is locked
-	lockKey := fmt.Sprintf("account_locked:%s", email)
-	exists, err := s.sessionManager.redisClient.Exists(ctx, lockKey)
-	if err != nil {
-		return false, fmt.Errorf("failed to check account lock status: %w", err)
+	err := s.lockout.CheckLocked(ctx, email)
+	if _, ok := errors.GetAccountLockedError(err); ok {
+		return true, nil
 	}
-	
-	return exists, nil
-}
\ No newline at end of file
+	return false, err
+}
+
+// failedLogin counts a failed login and returns the error to report: the lockout if the attempt
+// locked the account, invalid credentials otherwise
+func (s *AuthServiceImpl) failedLogin(ctx context.Context, email string) error {
+	err := s.IncrementFailedAttempts(ctx, email)
+	if _, ok := errors.GetAccountLockedError(err); ok {
+		return err
+	}
+	return errors.ErrInvalidCredentials
+}
@@ -0,0 +1,145 @@
+package cache
+
+import (
+	"context"
+	"time"
+
+	goredis "github.com/go-redis/redis/v8"
+	"github.com/google/uuid"
+	"github.com/22smeargle/winkr-backend/internal/domain/repositories"
+	"github.com/22smeargle/winkr-backend/pkg/logger"
+)
+
+// blockedIDsTTL bounds how long a cached blocked-ID set can outlive a change it missed. Changes made
+// through the repository drop the sets of both users right away.
+const blockedIDsTTL = 10 * time.Minute
+
+// idSetMarker is kept in every cached ID set so a user without IDs, e.g. who blocked no one, is
+// cached as well
+const idSetMarker = "-"
+
+// BlockedUsersCache keeps the blocked-ID set of each user in Redis in front of a block repository,
+// so discovery and chat can filter blocked users without querying the blocks table every time.
+// If Redis is unavailable, reads go to the repository.
+type BlockedUsersCache struct {
+	repositories.UserBlockRepository
+	client goredis.Cmdable
+	prefix string
+}
+
+// NewBlockedUsersCache creates a block repository that caches blocked-ID sets in Redis
+func NewBlockedUsersCache(repo repositories.UserBlockRepository, client goredis.Cmdable) *BlockedUsersCache {
+	return &BlockedUsersCache{
+		UserBlockRepository: repo,
+		client:              client,
+		prefix:              "blocked_ids:",
+	}
+}
+
+// Block blocks a user and drops the cached sets of both users
+func (c *BlockedUsersCache) Block(ctx context.Context, blockerID, blockedID uuid.UUID) error {
+	if err := c.UserBlockRepository.Block(ctx, blockerID, blockedID); err != nil {
+		return err
+	}
+	c.invalidate(ctx, blockerID, blockedID)
+	return nil
+}
+
+// BlockMany blocks each of the given users and drops the cached sets of everyone involved
+func (c *BlockedUsersCache) BlockMany(ctx context.Context, blockerID uuid.UUID, blockedIDs []uuid.UUID) error {
+	if err := c.UserBlockRepository.BlockMany(ctx, blockerID, blockedIDs); err != nil {
+		return err
+	}
+	c.invalidate(ctx, append([]uuid.UUID{blockerID}, blockedIDs...)...)
+	return nil
+}
+
+// Unblock removes a block and drops the cached sets of both users
+func (c *BlockedUsersCache) Unblock(ctx context.Context, blockerID, blockedID uuid.UUID) error {
+	if err := c.UserBlockRepository.Unblock(ctx, blockerID, blockedID); err != nil {
+		return err
+	}
+	c.invalidate(ctx, blockerID, blockedID)
+	return nil
+}
+
+// IsBlocked reports whether either user has blocked the other, from the first user's cached set
+func (c *BlockedUsersCache) IsBlocked(ctx context.Context, userID, otherUserID uuid.UUID) (bool, error) {
+	blockedIDs, err := c.GetBlockedUserIDs(ctx, userID)
+	if err != nil {
+		return false, err
+	}
+	for _, blockedID := range blockedIDs {
+		if blockedID == otherUserID {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// GetBlockedUserIDs returns the users a user has blocked or been blocked by, loading the set from
+// the repository when it isn't cached
+func (c *BlockedUsersCache) GetBlockedUserIDs(ctx context.Context, userID uuid.UUID) ([]uuid.UUID, error) {
+	key := c.prefix + userID.String()
+
+	members, err := c.client.SMembers(ctx, key).Result()
+	if err != nil {
+		logger.Error("Failed to read cached blocked users", err)
+	} else if len(members) > 0 {
+		return parseIDSet(members), nil
+	}
+
+	blockedIDs, err := c.UserBlockRepository.GetBlockedUserIDs(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	members = make([]string, 0, len(blockedIDs)+1)
+	members = append(members, idSetMarker)
+	for _, blockedID := range blockedIDs {
+		members = append(members, blockedID.String())
+	}
+
+	pipe := c.client.TxPipeline()
+	pipe.Del(ctx, key)
+	pipe.SAdd(ctx, key, toInterfaces(members)...)
+	pipe.Expire(ctx, key, blockedIDsTTL)
+	if _, err := pipe.Exec(ctx); err != nil {
+		logger.Error("Failed to cache blocked users", err)
+	}
+
+	return blockedIDs, nil
+}
+
+// invalidate drops the cached sets of the users
+func (c *BlockedUsersCache) invalidate(ctx context.Context, userIDs ...uuid.UUID) {
+	keys := make([]string, len(userIDs))
+	for i, userID := range userIDs {
+		keys[i] = c.prefix + userID.String()
+	}
+	if err := c.client.Del(ctx, keys...).Err(); err != nil {
+		logger.Error("Failed to invalidate cached blocked users", err)
+	}
+}
+
+// parseIDSet converts the members of a cached ID set back to user IDs, skipping the marker
+func parseIDSet(members []string) []uuid.UUID {
+	blockedIDs := make([]uuid.UUID, 0, len(members))
+	for _, member := range members {
+		blockedID, err := uuid.Parse(member)
+		if err != nil {
+			continue
+		}
+		blockedIDs = append(blockedIDs, blockedID)
+	}
+	return blockedIDs
+}
+
+// toInterfaces converts strings to the arguments of a Redis command
+func toInterfaces(values []string) []interface{} {
+	args := make([]interface{}, len(values))
+	for i, value := range values {
+		args[i] = value
+	}
+	return args
+}
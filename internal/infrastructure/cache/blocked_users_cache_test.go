@@ -0,0 +1,103 @@
+package cache
+
+import (
+	"context"
+	"os"
+	"testing"
+	"time"
+
+	goredis "github.com/go-redis/redis/v8"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/22smeargle/winkr-backend/internal/domain/repositories"
+)
+
+// countingBlockRepository keeps blocks in memory and counts the blocked-ID lookups that reach it
+type countingBlockRepository struct {
+	repositories.UserBlockRepository
+	blocks  map[[2]uuid.UUID]bool
+	lookups int
+}
+
+func (r *countingBlockRepository) Block(ctx context.Context, blockerID, blockedID uuid.UUID) error {
+	r.blocks[[2]uuid.UUID{blockerID, blockedID}] = true
+	return nil
+}
+
+func (r *countingBlockRepository) Unblock(ctx context.Context, blockerID, blockedID uuid.UUID) error {
+	delete(r.blocks, [2]uuid.UUID{blockerID, blockedID})
+	return nil
+}
+
+func (r *countingBlockRepository) GetBlockedUserIDs(ctx context.Context, userID uuid.UUID) ([]uuid.UUID, error) {
+	r.lookups++
+	var ids []uuid.UUID
+	for pair := range r.blocks {
+		if pair[0] == userID {
+			ids = append(ids, pair[1])
+		} else if pair[1] == userID {
+			ids = append(ids, pair[0])
+		}
+	}
+	return ids, nil
+}
+
+// newTestRedisClient connects to the Redis at TEST_REDIS_ADDR, or a local Redis, and skips the test
+// when none is reachable
+func newTestRedisClient(t *testing.T) *goredis.Client {
+	addr := os.Getenv("TEST_REDIS_ADDR")
+	if addr == "" {
+		addr = "localhost:6379"
+	}
+	client := goredis.NewClient(&goredis.Options{Addr: addr, DB: 15})
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+	if err := client.Ping(ctx).Err(); err != nil {
+		t.Skipf("Redis not available at %s: %v", addr, err)
+	}
+	t.Cleanup(func() { client.Close() })
+	return client
+}
+
+// newTestBlockedUsersCache returns a cache on a test Redis in front of an in-memory repository
+func newTestBlockedUsersCache(t *testing.T) (*BlockedUsersCache, *countingBlockRepository) {
+	repo := &countingBlockRepository{blocks: make(map[[2]uuid.UUID]bool)}
+	return NewBlockedUsersCache(repo, newTestRedisClient(t)), repo
+}
+
+func TestBlockedUsersCache_CachesBlockedIDs(t *testing.T) {
+	cache, repo := newTestBlockedUsersCache(t)
+	ctx := context.Background()
+	userID, otherID := uuid.New(), uuid.New()
+
+	// Test a user who blocked no one is cached too
+	blocked, err := cache.IsBlocked(ctx, userID, otherID)
+	require.NoError(t, err)
+	assert.False(t, blocked)
+	blocked, err = cache.IsBlocked(ctx, userID, otherID)
+	require.NoError(t, err)
+	assert.False(t, blocked)
+	assert.Equal(t, 1, repo.lookups)
+
+	// Test blocking is seen from both sides at once
+	require.NoError(t, cache.Block(ctx, otherID, userID))
+	blocked, err = cache.IsBlocked(ctx, userID, otherID)
+	require.NoError(t, err)
+	assert.True(t, blocked)
+	ids, err := cache.GetBlockedUserIDs(ctx, otherID)
+	require.NoError(t, err)
+	assert.Equal(t, []uuid.UUID{userID}, ids)
+	assert.Equal(t, 3, repo.lookups)
+
+	ids, err = cache.GetBlockedUserIDs(ctx, otherID)
+	require.NoError(t, err)
+	assert.Equal(t, []uuid.UUID{userID}, ids)
+	assert.Equal(t, 3, repo.lookups)
+
+	require.NoError(t, cache.Unblock(ctx, otherID, userID))
+	blocked, err = cache.IsBlocked(ctx, userID, otherID)
+	require.NoError(t, err)
+	assert.False(t, blocked)
+}
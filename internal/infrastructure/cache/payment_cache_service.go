@@ -6,8 +6,11 @@ import (
 	"fmt"
 	"time"
 
+	goredis "github.com/go-redis/redis/v8"
+
 	"github.com/22smeargle/winkr-backend/internal/domain/entities"
 	"github.com/22smeargle/winkr-backend/internal/infrastructure/database/redis"
+	"github.com/22smeargle/winkr-backend/internal/infrastructure/external/stripe"
 	"github.com/22smeargle/winkr-backend/pkg/logger"
 )
 
@@ -33,6 +36,7 @@ const (
 	PaymentCacheTTL          = 10 * time.Minute
 	InvoiceCacheTTL          = 15 * time.Minute
 	WebhookEventCacheTTL     = 5 * time.Minute
+	PaymentIntentIdempotencyTTL = 10 * time.Minute
 )
 
 // CacheSubscription caches subscription data
@@ -388,6 +392,55 @@ func (pcs *PaymentCacheService) InvalidateWebhookEvent(ctx context.Context, even
 	return nil
 }
 
+// CachePaymentIntent remembers the payment intent created with an idempotency key, so a retry
+// within PaymentIntentIdempotencyTTL gets the same intent back
+func (pcs *PaymentCacheService) CachePaymentIntent(ctx context.Context, idempotencyKey string, paymentIntent *stripe.PaymentIntent) error {
+	key := pcs.getPaymentIntentKey(idempotencyKey)
+	
+	intentData, err := json.Marshal(paymentIntent)
+	if err != nil {
+		logger.Error("Failed to marshal payment intent for caching", err)
+		return fmt.Errorf("failed to marshal payment intent: %w", err)
+	}
+
+	err = pcs.redisClient.Set(ctx, key, string(intentData), PaymentIntentIdempotencyTTL)
+	if err != nil {
+		logger.Error("Failed to cache payment intent", err)
+		return fmt.Errorf("failed to cache payment intent: %w", err)
+	}
+
+	logger.Debug("Payment intent cached", "payment_intent_id", paymentIntent.ID)
+	return nil
+}
+
+// GetPaymentIntent retrieves the payment intent created with an idempotency key, or nil if the key was not used recently
+func (pcs *PaymentCacheService) GetPaymentIntent(ctx context.Context, idempotencyKey string) (*stripe.PaymentIntent, error) {
+	key := pcs.getPaymentIntentKey(idempotencyKey)
+	
+	intentData, err := pcs.redisClient.Get(ctx, key)
+	if err == goredis.Nil {
+		return nil, nil // Cache miss
+	}
+	if err != nil {
+		logger.Error("Failed to get cached payment intent", err)
+		return nil, fmt.Errorf("failed to get cached payment intent: %w", err)
+	}
+
+	if intentData == "" {
+		return nil, nil // Cache miss
+	}
+
+	var paymentIntent stripe.PaymentIntent
+	err = json.Unmarshal([]byte(intentData), &paymentIntent)
+	if err != nil {
+		logger.Error("Failed to unmarshal cached payment intent", err)
+		return nil, fmt.Errorf("failed to unmarshal cached payment intent: %w", err)
+	}
+
+	logger.Debug("Payment intent retrieved from cache", "payment_intent_id", paymentIntent.ID)
+	return &paymentIntent, nil
+}
+
 // InvalidateUserPaymentCache removes all payment-related cache for a user
 func (pcs *PaymentCacheService) InvalidateUserPaymentCache(ctx context.Context, userID string) error {
 	keys := []string{
@@ -442,4 +495,8 @@ func (pcs *PaymentCacheService) getInvoiceKey(invoiceID string) string {
 
 func (pcs *PaymentCacheService) getWebhookEventKey(eventID string) string {
 	return fmt.Sprintf("%swebhook_event:%s", pcs.prefix, eventID)
+}
+
+func (pcs *PaymentCacheService) getPaymentIntentKey(idempotencyKey string) string {
+	return fmt.Sprintf("%spayment_intent:%s", pcs.prefix, idempotencyKey)
 }
\ No newline at end of file
@@ -33,6 +33,7 @@ const (
 	MessageTypeOnlineStatus MessageType = "online_status"
 	MessageTypeMatch       MessageType = "match"
 	MessageTypeTyping     MessageType = "typing"
+	MessageTypeEvent      MessageType = "event"
 )
 
 // Message represents a real-time message
@@ -43,6 +44,17 @@ type Message struct {
 	Timestamp time.Time        `json:"timestamp"`
 	SenderID  string           `json:"sender_id,omitempty"`
 	RecipientID string          `json:"recipient_id,omitempty"`
+	// CorrelationID ties the message to the request that published it
+	CorrelationID string        `json:"correlation_id,omitempty"`
+}
+
+// Context returns a copy of ctx carrying the message's correlation ID, so the logs of its
+// handling can be traced back to the request that published it
+func (m Message) Context(ctx context.Context) context.Context {
+	if m.CorrelationID == "" {
+		return ctx
+	}
+	return logger.ContextWithCorrelationID(ctx, m.CorrelationID)
 }
 
 // ChatMessage represents a chat message
@@ -145,6 +157,12 @@ func (ps *PubSubService) PublishMatch(ctx context.Context, userID, matchID, matc
 	return ps.publishMessage(ctx, MessageTypeMatch, channel, matchMsg)
 }
 
+// Publish publishes a domain event, such as a new match, for other services to react to
+func (ps *PubSubService) Publish(ctx context.Context, eventType string, data interface{}) error {
+	channel := ps.getEventChannel(eventType)
+	return ps.publishMessage(ctx, MessageTypeEvent, channel, data)
+}
+
 // PublishTyping publishes typing indicator
 func (ps *PubSubService) PublishTyping(ctx context.Context, conversationID, senderID string, isTyping bool) error {
 	typingMsg := TypingMessage{
@@ -182,6 +200,12 @@ func (ps *PubSubService) SubscribeToMatches(ctx context.Context, userID string)
 	return ps.subscribeToChannel(ctx, channel)
 }
 
+// SubscribeToEvents subscribes to domain events of one type
+func (ps *PubSubService) SubscribeToEvents(ctx context.Context, eventType string) (<-chan Message, error) {
+	channel := ps.getEventChannel(eventType)
+	return ps.subscribeToChannel(ctx, channel)
+}
+
 // SubscribeToTyping subscribes to typing indicators
 func (ps *PubSubService) SubscribeToTyping(ctx context.Context, conversationID string) (<-chan Message, error) {
 	channel := ps.getTypingChannel(conversationID)
@@ -234,6 +258,7 @@ func (ps *PubSubService) publishMessage(ctx context.Context, msgType MessageType
 		Channel:   channel,
 		Data:      data,
 		Timestamp: time.Now(),
+		CorrelationID: logger.CorrelationIDFromContext(ctx),
 	}
 
 	messageData, err := json.Marshal(message)
@@ -254,6 +279,10 @@ func (ps *PubSubService) publishMessage(ctx context.Context, msgType MessageType
 
 // publishMessageToChannel publishes a message to a specific channel
 func (ps *PubSubService) publishMessageToChannel(ctx context.Context, channel string, message Message) error {
+	if message.CorrelationID == "" {
+		message.CorrelationID = logger.CorrelationIDFromContext(ctx)
+	}
+
 	messageData, err := json.Marshal(message)
 	if err != nil {
 		logger.Error("Failed to marshal message for channel publishing", err)
@@ -330,6 +359,10 @@ func (ps *PubSubService) getTypingChannel(conversationID string) string {
 	return fmt.Sprintf("%styping:%s", ps.prefix, conversationID)
 }
 
+func (ps *PubSubService) getEventChannel(eventType string) string {
+	return fmt.Sprintf("%sevents:%s", ps.prefix, eventType)
+}
+
 // Pattern methods for wildcard subscriptions
 
 func (ps *PubSubService) getChatChannelPattern() string {
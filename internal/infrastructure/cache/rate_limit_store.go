@@ -0,0 +1,254 @@
+package cache
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strconv"
+	"sync"
+	"time"
+
+	goredis "github.com/go-redis/redis/v8"
+	"github.com/google/uuid"
+
+	"github.com/22smeargle/winkr-backend/internal/infrastructure/database/redis"
+	"github.com/22smeargle/winkr-backend/pkg/config"
+	"github.com/22smeargle/winkr-backend/pkg/logger"
+)
+
+// Rate limit storage backends
+const (
+	RateLimitBackendRedis  = "redis"
+	RateLimitBackendMemory = "memory"
+)
+
+// RateLimitStore stores the requests counted by sliding window rate limits
+type RateLimitStore interface {
+	// Record drops the requests of key made up to windowStart, then records a request at now
+	// if fewer than limit remain. It returns whether the request was recorded, how many requests
+	// were in the window before it, and when the oldest of them was made. The key expires after
+	// ttl without requests.
+	Record(ctx context.Context, key string, now, windowStart time.Time, limit int, ttl time.Duration) (bool, int, time.Time, error)
+
+	// Count returns how many requests of key were made after windowStart
+	Count(ctx context.Context, key string, windowStart time.Time) (int, error)
+
+	// Reset forgets every request of key
+	Reset(ctx context.Context, key string) error
+}
+
+// NewRateLimitStore creates the rate limit store selected by the configured backend.
+// The memory backend starts dropping expired counters in the background.
+func NewRateLimitStore(cfg *config.RateLimitConfig, redisClient *redis.RedisClient) RateLimitStore {
+	switch cfg.Backend {
+	case RateLimitBackendMemory:
+		logger.Warn("Using in-memory rate limit store; limits are not shared between instances")
+		store := NewMemoryRateLimitStore()
+		store.StartCleanup(context.Background(), cfg.CleanupInterval)
+		return store
+	default:
+		return NewRedisRateLimitStore(redisClient)
+	}
+}
+
+// recordScript atomically trims the window, counts it and records the request if it is allowed.
+// Scores are request times in microseconds, which Lua numbers hold exactly.
+const recordScript = `
+	local key = KEYS[1]
+	local limit = tonumber(ARGV[3])
+
+	redis.call('ZREMRANGEBYSCORE', key, '-inf', ARGV[1])
+
+	local current = redis.call('ZCARD', key)
+	local oldest = '0'
+	local first = redis.call('ZRANGE', key, 0, 0, 'WITHSCORES')
+	if #first > 0 then
+		oldest = first[2]
+	end
+
+	if current < limit then
+		redis.call('ZADD', key, ARGV[2], ARGV[5])
+		redis.call('PEXPIRE', key, ARGV[4])
+		return {1, current, oldest}
+	end
+	return {0, current, oldest}
+`
+
+// RedisRateLimitStore stores rate limits in Redis sorted sets scored by request time,
+// so limits are shared between every instance
+type RedisRateLimitStore struct {
+	redisClient *redis.RedisClient
+}
+
+// NewRedisRateLimitStore creates a new RedisRateLimitStore
+func NewRedisRateLimitStore(redisClient *redis.RedisClient) *RedisRateLimitStore {
+	return &RedisRateLimitStore{redisClient: redisClient}
+}
+
+// Record records a request if the window has room for it
+func (s *RedisRateLimitStore) Record(ctx context.Context, key string, now, windowStart time.Time, limit int, ttl time.Duration) (bool, int, time.Time, error) {
+	result, err := s.redisClient.GetClient().Eval(ctx, recordScript, []string{key},
+		windowStart.UnixMicro(),
+		now.UnixMicro(),
+		limit,
+		ttl.Milliseconds(),
+		uuid.New().String(), // Requests made in the same microsecond are still counted apart
+	).Result()
+	if err != nil {
+		return false, 0, time.Time{}, fmt.Errorf("failed to record rate limit request: %w", err)
+	}
+
+	values, ok := result.([]interface{})
+	if !ok || len(values) < 3 {
+		return false, 0, time.Time{}, fmt.Errorf("invalid rate limit result")
+	}
+
+	recorded, _ := values[0].(int64)
+	count, _ := values[1].(int64)
+
+	var oldest time.Time
+	if oldestStr, ok := values[2].(string); ok {
+		if oldestMicros, err := strconv.ParseFloat(oldestStr, 64); err == nil && oldestMicros > 0 {
+			oldest = time.UnixMicro(int64(oldestMicros))
+		}
+	}
+
+	return recorded == 1, int(count), oldest, nil
+}
+
+// Count counts the requests made after windowStart
+func (s *RedisRateLimitStore) Count(ctx context.Context, key string, windowStart time.Time) (int, error) {
+	count, err := s.redisClient.GetClient().ZCount(ctx, key, "("+strconv.FormatInt(windowStart.UnixMicro(), 10), "+inf").Result()
+	if err != nil && err != goredis.Nil {
+		return 0, fmt.Errorf("failed to count rate limit requests: %w", err)
+	}
+	return int(count), nil
+}
+
+// Reset forgets every request of key
+func (s *RedisRateLimitStore) Reset(ctx context.Context, key string) error {
+	if err := s.redisClient.GetClient().Del(ctx, key).Err(); err != nil {
+		return fmt.Errorf("failed to reset rate limit: %w", err)
+	}
+	return nil
+}
+
+// memoryRateLimitEntry holds the request times of one key, oldest first
+type memoryRateLimitEntry struct {
+	requests  []time.Time
+	expiresAt time.Time
+}
+
+// MemoryRateLimitStore stores rate limits in process memory. It is meant for local development
+// and single-instance deployments; limits are not shared between instances.
+type MemoryRateLimitStore struct {
+	mu      sync.Mutex
+	entries map[string]*memoryRateLimitEntry
+	now     func() time.Time
+}
+
+// NewMemoryRateLimitStore creates a new MemoryRateLimitStore
+func NewMemoryRateLimitStore() *MemoryRateLimitStore {
+	return &MemoryRateLimitStore{
+		entries: make(map[string]*memoryRateLimitEntry),
+		now:     time.Now,
+	}
+}
+
+// Record records a request if the window has room for it
+func (s *MemoryRateLimitStore) Record(ctx context.Context, key string, now, windowStart time.Time, limit int, ttl time.Duration) (bool, int, time.Time, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	entry, ok := s.entries[key]
+	if !ok {
+		entry = &memoryRateLimitEntry{}
+		s.entries[key] = entry
+	}
+	entry.requests = requestsSince(entry.requests, windowStart)
+
+	count := len(entry.requests)
+	var oldest time.Time
+	if count > 0 {
+		oldest = entry.requests[0]
+	}
+
+	if count >= limit {
+		return false, count, oldest, nil
+	}
+
+	// Keep the requests sorted even if the clock goes backwards
+	i := sort.Search(count, func(i int) bool { return entry.requests[i].After(now) })
+	entry.requests = append(entry.requests, time.Time{})
+	copy(entry.requests[i+1:], entry.requests[i:])
+	entry.requests[i] = now
+	entry.expiresAt = now.Add(ttl)
+
+	return true, count, oldest, nil
+}
+
+// Count counts the requests made after windowStart
+func (s *MemoryRateLimitStore) Count(ctx context.Context, key string, windowStart time.Time) (int, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	entry, ok := s.entries[key]
+	if !ok {
+		return 0, nil
+	}
+	return len(requestsSince(entry.requests, windowStart)), nil
+}
+
+// Reset forgets every request of key
+func (s *MemoryRateLimitStore) Reset(ctx context.Context, key string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	delete(s.entries, key)
+	return nil
+}
+
+// Cleanup drops the keys that expired, and returns how many were dropped
+func (s *MemoryRateLimitStore) Cleanup() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := s.now()
+	removed := 0
+	for key, entry := range s.entries {
+		if !entry.expiresAt.After(now) {
+			delete(s.entries, key)
+			removed++
+		}
+	}
+	return removed
+}
+
+// StartCleanup periodically drops expired keys so memory does not grow with every client seen
+func (s *MemoryRateLimitStore) StartCleanup(ctx context.Context, interval time.Duration) {
+	if interval <= 0 {
+		interval = time.Minute
+	}
+
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				if removed := s.Cleanup(); removed > 0 {
+					logger.Debug("Expired rate limits removed", "count", removed)
+				}
+			}
+		}
+	}()
+}
+
+// requestsSince returns the requests made after windowStart, reusing the slice
+func requestsSince(requests []time.Time, windowStart time.Time) []time.Time {
+	i := sort.Search(len(requests), func(i int) bool { return requests[i].After(windowStart) })
+	return requests[i:]
+}
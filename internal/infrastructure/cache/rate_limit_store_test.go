@@ -0,0 +1,178 @@
+package cache
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"sync"
+	"testing"
+	"time"
+
+	goredis "github.com/go-redis/redis/v8"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/22smeargle/winkr-backend/internal/infrastructure/database/redis"
+)
+
+// rateLimitStores returns every rate limit backend to run the limiter against.
+// The Redis backend uses TEST_REDIS_ADDR, or a local Redis, and is skipped when none is reachable.
+func rateLimitStores() map[string]func(t *testing.T) RateLimitStore {
+	return map[string]func(t *testing.T) RateLimitStore{
+		RateLimitBackendMemory: func(t *testing.T) RateLimitStore {
+			return NewMemoryRateLimitStore()
+		},
+		RateLimitBackendRedis: func(t *testing.T) RateLimitStore {
+			addr := os.Getenv("TEST_REDIS_ADDR")
+			if addr == "" {
+				addr = "localhost:6379"
+			}
+			client := goredis.NewClient(&goredis.Options{Addr: addr, DB: 15})
+			ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+			defer cancel()
+			if err := client.Ping(ctx).Err(); err != nil {
+				t.Skipf("Redis not available at %s: %v", addr, err)
+			}
+			t.Cleanup(func() { client.Close() })
+			return NewRedisRateLimitStore(&redis.RedisClient{Client: client})
+		},
+	}
+}
+
+// newTestRateLimiter creates a rate limiter on the given store with a clock the test controls
+func newTestRateLimiter(store RateLimitStore, now *time.Time) *RateLimiter {
+	limiter := NewRateLimiterWithStore(store)
+	limiter.now = func() time.Time { return *now }
+	return limiter
+}
+
+// testRateLimitConfig returns a limit of 3 requests a minute on an endpoint no other test uses
+func testRateLimitConfig() RateLimitConfig {
+	return RateLimitConfig{
+		Requests: 3,
+		Window:   time.Minute,
+		KeyType:  "user",
+		Endpoint: "test_" + uuid.New().String(),
+	}
+}
+
+func TestRateLimiter_SlidingWindow(t *testing.T) {
+	for name, newStore := range rateLimitStores() {
+		t.Run(name, func(t *testing.T) {
+			ctx := context.Background()
+			now := time.Now().Truncate(time.Second)
+			limiter := newTestRateLimiter(newStore(t), &now)
+			config := testRateLimitConfig()
+
+			for i := 0; i < 3; i++ {
+				result, err := limiter.CheckRateLimit(ctx, config, "user-1")
+				require.NoError(t, err)
+				assert.True(t, result.Allowed)
+				assert.Equal(t, 2-i, result.Remaining)
+				now = now.Add(10 * time.Second)
+			}
+
+			// The fourth request within a minute is denied until the first leaves the window
+			result, err := limiter.CheckRateLimit(ctx, config, "user-1")
+			require.NoError(t, err)
+			assert.False(t, result.Allowed)
+			assert.Equal(t, 0, result.Remaining)
+			assert.Equal(t, 30*time.Second, result.RetryAfter)
+
+			// Other identifiers have their own window
+			result, err = limiter.CheckRateLimit(ctx, config, "user-2")
+			require.NoError(t, err)
+			assert.True(t, result.Allowed)
+
+			now = now.Add(30 * time.Second)
+			result, err = limiter.CheckRateLimit(ctx, config, "user-1")
+			require.NoError(t, err)
+			assert.True(t, result.Allowed)
+			assert.Equal(t, 0, result.Remaining)
+		})
+	}
+}
+
+func TestRateLimiter_Reset(t *testing.T) {
+	for name, newStore := range rateLimitStores() {
+		t.Run(name, func(t *testing.T) {
+			ctx := context.Background()
+			now := time.Now().Truncate(time.Second)
+			limiter := newTestRateLimiter(newStore(t), &now)
+			config := testRateLimitConfig()
+
+			for i := 0; i < 4; i++ {
+				_, err := limiter.CheckRateLimit(ctx, config, "10.0.0.1")
+				require.NoError(t, err)
+			}
+
+			require.NoError(t, limiter.ResetRateLimit(ctx, config.KeyType, config.Endpoint, "10.0.0.1"))
+
+			result, err := limiter.CheckRateLimit(ctx, config, "10.0.0.1")
+			require.NoError(t, err)
+			assert.True(t, result.Allowed)
+			assert.Equal(t, 2, result.Remaining)
+		})
+	}
+}
+
+func TestRateLimiter_ConcurrentRequestsNeverExceedLimit(t *testing.T) {
+	for name, newStore := range rateLimitStores() {
+		t.Run(name, func(t *testing.T) {
+			ctx := context.Background()
+			limiter := NewRateLimiterWithStore(newStore(t))
+			config := testRateLimitConfig()
+			config.Requests = 10
+
+			var mu sync.Mutex
+			allowed := 0
+
+			var wg sync.WaitGroup
+			for i := 0; i < 50; i++ {
+				wg.Add(1)
+				go func() {
+					defer wg.Done()
+					result, err := limiter.CheckRateLimit(ctx, config, "user-1")
+					if err != nil || !result.Allowed {
+						return
+					}
+					mu.Lock()
+					allowed++
+					mu.Unlock()
+				}()
+			}
+			wg.Wait()
+
+			assert.Equal(t, 10, allowed)
+		})
+	}
+}
+
+func TestMemoryRateLimitStore_CleanupDropsExpiredKeys(t *testing.T) {
+	ctx := context.Background()
+	store := NewMemoryRateLimitStore()
+	now := time.Now()
+	store.now = func() time.Time { return now }
+
+	for i := 0; i < 5; i++ {
+		_, _, _, err := store.Record(ctx, fmt.Sprintf("key-%d", i), now, now.Add(-time.Minute), 10, time.Minute)
+		require.NoError(t, err)
+	}
+	_, _, _, err := store.Record(ctx, "key-late", now.Add(30*time.Second), now.Add(-30*time.Second), 10, time.Minute)
+	require.NoError(t, err)
+
+	assert.Equal(t, 0, store.Cleanup())
+
+	now = now.Add(time.Minute)
+	assert.Equal(t, 5, store.Cleanup())
+	assert.Len(t, store.entries, 1)
+
+	count, err := store.Count(ctx, "key-late", now.Add(-time.Minute))
+	require.NoError(t, err)
+	assert.Equal(t, 1, count)
+
+	now = now.Add(time.Minute)
+	assert.Equal(t, 1, store.Cleanup())
+	assert.Empty(t, store.entries)
+}
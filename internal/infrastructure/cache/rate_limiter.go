@@ -3,25 +3,30 @@ package cache
 import (
 	"context"
 	"fmt"
-	"math"
-	"strconv"
 	"time"
 
 	"github.com/22smeargle/winkr-backend/internal/infrastructure/database/redis"
 	"github.com/22smeargle/winkr-backend/pkg/logger"
 )
 
-// RateLimiter handles rate limiting using Redis sliding window
+// RateLimiter handles rate limiting using a sliding window kept in a RateLimitStore
 type RateLimiter struct {
-	redisClient *redis.RedisClient
-	prefix      string
+	store  RateLimitStore
+	prefix string
+	now    func() time.Time
 }
 
-// NewRateLimiter creates a new rate limiter
+// NewRateLimiter creates a new rate limiter backed by Redis
 func NewRateLimiter(redisClient *redis.RedisClient) *RateLimiter {
+	return NewRateLimiterWithStore(NewRedisRateLimitStore(redisClient))
+}
+
+// NewRateLimiterWithStore creates a new rate limiter backed by the given store
+func NewRateLimiterWithStore(store RateLimitStore) *RateLimiter {
 	return &RateLimiter{
-		redisClient: redisClient,
-		prefix:      "rate_limit:",
+		store:  store,
+		prefix: "rate_limit:",
+		now:    time.Now,
 	}
 }
 
@@ -46,60 +51,32 @@ type RateLimitResult struct {
 // CheckRateLimit checks if a request is allowed based on rate limiting rules
 func (rl *RateLimiter) CheckRateLimit(ctx context.Context, config RateLimitConfig, identifier string) (*RateLimitResult, error) {
 	key := rl.getRateLimitKey(config.KeyType, config.Endpoint, identifier)
-	now := time.Now().Unix()
-	windowStart := now - int64(config.Window.Seconds())
-	
-	// Use Redis sorted set for sliding window
-	// Remove old entries outside the window
-	_, err := rl.redisClient.ZRemRangeByScore(ctx, key, "0", fmt.Sprintf("%d", windowStart))
-	if err != nil {
-		logger.Error("Failed to remove old rate limit entries", err)
-		return nil, fmt.Errorf("failed to remove old rate limit entries: %w", err)
-	}
+	now := rl.now()
+	windowStart := now.Add(-config.Window)
 
-	// Count current requests in window
-	currentCount, err := rl.redisClient.ZCard(ctx, key)
+	allowed, currentCount, oldest, err := rl.store.Record(ctx, key, now, windowStart, config.Requests, config.Window)
 	if err != nil {
-		logger.Error("Failed to count current rate limit entries", err)
-		return nil, fmt.Errorf("failed to count current rate limit entries: %w", err)
+		logger.Error("Failed to record rate limit request", err)
+		return nil, err
 	}
 
-	remaining := config.Requests - int(currentCount)
-	allowed := remaining > 0
-
+	remaining := config.Requests - currentCount
 	if allowed {
-		// Add current request to the window
-		_, err := rl.redisClient.ZAdd(ctx, key, fmt.Sprintf("%d", now), fmt.Sprintf("%d", now))
-		if err != nil {
-			logger.Error("Failed to add rate limit entry", err)
-			return nil, fmt.Errorf("failed to add rate limit entry: %w", err)
-		}
-
-		// Set expiration for the sorted set
-		err = rl.redisClient.Expire(ctx, key, config.Window)
-		if err != nil {
-			logger.Error("Failed to set rate limit expiration", err)
-			return nil, fmt.Errorf("failed to set rate limit expiration: %w", err)
-		}
+		// The request just recorded uses one up
+		remaining--
 	}
 
 	result := &RateLimitResult{
 		Allowed:   allowed,
 		Remaining: max(0, remaining),
-		ResetTime: time.Now().Add(config.Window),
+		ResetTime: now.Add(config.Window),
 		Limit:     config.Requests,
 		Window:    config.Window,
 	}
 
-	if !allowed {
-		// Calculate retry after time
-		oldestEntry, err := rl.redisClient.ZRange(ctx, key, 0, 0)
-		if err == nil && len(oldestEntry) > 0 {
-			// Find when the oldest entry will expire
-			oldestTime, _ := strconv.ParseInt(oldestEntry[0], 10, 64)
-			retryAt := time.Unix(oldestTime, 0).Add(config.Window)
-			result.RetryAfter = time.Until(retryAt)
-		}
+	if !allowed && !oldest.IsZero() {
+		// Retry when the oldest request leaves the window
+		result.RetryAfter = oldest.Add(config.Window).Sub(now)
 	}
 
 	logger.Debug("Rate limit check", 
@@ -131,7 +108,7 @@ func (rl *RateLimiter) CheckUserRateLimit(ctx context.Context, endpoint, userID
 func (rl *RateLimiter) ResetRateLimit(ctx context.Context, keyType, endpoint, identifier string) error {
 	key := rl.getRateLimitKey(keyType, endpoint, identifier)
 	
-	err := rl.redisClient.Del(ctx, key)
+	err := rl.store.Reset(ctx, key)
 	if err != nil {
 		logger.Error("Failed to reset rate limit", err)
 		return fmt.Errorf("failed to reset rate limit: %w", err)
@@ -149,24 +126,23 @@ func (rl *RateLimiter) ResetRateLimit(ctx context.Context, keyType, endpoint, id
 // GetRateLimitStatus gets current rate limit status
 func (rl *RateLimiter) GetRateLimitStatus(ctx context.Context, keyType, endpoint, identifier string) (*RateLimitResult, error) {
 	key := rl.getRateLimitKey(keyType, endpoint, identifier)
-	now := time.Now().Unix()
-	windowStart := now - int64(rl.getEndpointConfig(endpoint).Window.Seconds())
-	
+	config := rl.getEndpointConfig(endpoint)
+	now := rl.now()
+
 	// Count current requests in window
-	currentCount, err := rl.redisClient.ZCard(ctx, key)
+	currentCount, err := rl.store.Count(ctx, key, now.Add(-config.Window))
 	if err != nil {
 		logger.Error("Failed to get rate limit status", err)
 		return nil, fmt.Errorf("failed to get rate limit status: %w", err)
 	}
 
-	config := rl.getEndpointConfig(endpoint)
-	remaining := max(0, config.Requests-int(currentCount))
+	remaining := max(0, config.Requests-currentCount)
 	allowed := remaining > 0
 
 	result := &RateLimitResult{
 		Allowed:   allowed,
 		Remaining: remaining,
-		ResetTime: time.Now().Add(config.Window),
+		ResetTime: now.Add(config.Window),
 		Limit:     config.Requests,
 		Window:    config.Window,
 	}
@@ -174,17 +150,16 @@ func (rl *RateLimiter) GetRateLimitStatus(ctx context.Context, keyType, endpoint
 	return result, nil
 }
 
-// CleanupExpiredLimits removes expired rate limit entries
+// CleanupExpiredLimits removes expired rate limit entries. Redis expires keys by itself,
+// so only the memory store has anything to remove.
 func (rl *RateLimiter) CleanupExpiredLimits(ctx context.Context) error {
-	// This would typically be run as a background job
-	logger.Info("Starting rate limit cleanup")
-	
-	// Get all rate limit keys
-	pattern := fmt.Sprintf("%s*", rl.prefix)
-	// In production, you would use SCAN to iterate through keys
-	// For simplicity, we'll log the cleanup operation
-	
-	logger.Info("Rate limit cleanup completed")
+	store, ok := rl.store.(*MemoryRateLimitStore)
+	if !ok {
+		return nil
+	}
+
+	removed := store.Cleanup()
+	logger.Info("Rate limit cleanup completed", "removed", removed)
 	return nil
 }
 
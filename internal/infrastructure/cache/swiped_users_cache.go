@@ -0,0 +1,142 @@
+package cache
+
+import (
+	"context"
+	"time"
+
+	goredis "github.com/go-redis/redis/v8"
+	"github.com/google/uuid"
+	"github.com/22smeargle/winkr-backend/internal/domain/entities"
+	"github.com/22smeargle/winkr-backend/internal/domain/repositories"
+	"github.com/22smeargle/winkr-backend/pkg/logger"
+)
+
+// swipedIDsTTL bounds how long a cached swiped-ID set can outlive a change it missed. Swipes made
+// through the repository update the set right away.
+const swipedIDsTTL = 30 * time.Minute
+
+// addSwipedIDsScript adds swiped IDs to a cached set, but only if the set is cached: adding to a set
+// that expired would cache the new IDs alone, hiding every earlier swipe from discovery's exclusions
+const addSwipedIDsScript = `
+if redis.call("EXISTS", KEYS[1]) == 0 then
+	return 0
+end
+return redis.call("SADD", KEYS[1], unpack(ARGV))
+`
+
+// SwipedUsersCache keeps the set of users each user swiped on in Redis in front of a match
+// repository, so discovery can exclude them without loading the user's whole swipe history every
+// time. New swipes are added to a cached set and undone swipes removed from it, rather than dropping
+// the set. If Redis is unavailable, reads go to the repository.
+type SwipedUsersCache struct {
+	repositories.MatchRepository
+	client goredis.Cmdable
+	prefix string
+}
+
+// NewSwipedUsersCache creates a match repository that caches swiped-ID sets in Redis
+func NewSwipedUsersCache(repo repositories.MatchRepository, client goredis.Cmdable) *SwipedUsersCache {
+	return &SwipedUsersCache{
+		MatchRepository: repo,
+		client:          client,
+		prefix:          "swiped_ids:",
+	}
+}
+
+// CreateSwipe stores a swipe and adds the swiped user to the swiper's cached set
+func (c *SwipedUsersCache) CreateSwipe(ctx context.Context, swipe *entities.Swipe) error {
+	if err := c.MatchRepository.CreateSwipe(ctx, swipe); err != nil {
+		return err
+	}
+	c.add(ctx, swipe.SwiperID, swipe.SwipedID)
+	return nil
+}
+
+// BatchCreateSwipes stores swipes and adds the swiped users to the swipers' cached sets
+func (c *SwipedUsersCache) BatchCreateSwipes(ctx context.Context, swipes []*entities.Swipe) error {
+	if err := c.MatchRepository.BatchCreateSwipes(ctx, swipes); err != nil {
+		return err
+	}
+
+	swipedIDs := make(map[uuid.UUID][]uuid.UUID)
+	for _, swipe := range swipes {
+		swipedIDs[swipe.SwiperID] = append(swipedIDs[swipe.SwiperID], swipe.SwipedID)
+	}
+	for swiperID, ids := range swipedIDs {
+		c.add(ctx, swiperID, ids...)
+	}
+	return nil
+}
+
+// DeleteSwipe deletes a swipe and removes the swiped user from the swiper's cached set, so an undone
+// swipe shows the user in discovery again
+func (c *SwipedUsersCache) DeleteSwipe(ctx context.Context, swiperID, swipedID uuid.UUID) error {
+	if err := c.MatchRepository.DeleteSwipe(ctx, swiperID, swipedID); err != nil {
+		return err
+	}
+	if err := c.client.SRem(ctx, c.prefix+swiperID.String(), swipedID.String()).Err(); err != nil {
+		// A stale set would keep hiding the user, so drop it instead
+		logger.Error("Failed to remove undone swipe from cached swiped users", err)
+		c.invalidate(ctx, swiperID)
+	}
+	return nil
+}
+
+// GetSwipedTargetIDs returns the users a user swiped on, loading the set from the repository when it
+// isn't cached
+func (c *SwipedUsersCache) GetSwipedTargetIDs(ctx context.Context, userID uuid.UUID) ([]uuid.UUID, error) {
+	key := c.prefix + userID.String()
+
+	members, err := c.client.SMembers(ctx, key).Result()
+	if err != nil {
+		logger.Error("Failed to read cached swiped users", err)
+	} else if len(members) > 0 {
+		return parseIDSet(members), nil
+	}
+
+	swipedIDs, err := c.MatchRepository.GetSwipedTargetIDs(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	members = make([]string, 0, len(swipedIDs)+1)
+	members = append(members, idSetMarker)
+	for _, swipedID := range swipedIDs {
+		members = append(members, swipedID.String())
+	}
+
+	pipe := c.client.TxPipeline()
+	pipe.Del(ctx, key)
+	pipe.SAdd(ctx, key, toInterfaces(members)...)
+	pipe.Expire(ctx, key, swipedIDsTTL)
+	if _, err := pipe.Exec(ctx); err != nil {
+		logger.Error("Failed to cache swiped users", err)
+	}
+
+	return swipedIDs, nil
+}
+
+// add adds swiped users to the swiper's cached set if there is one. If that fails the set is dropped,
+// so the next read loads it from the repository.
+func (c *SwipedUsersCache) add(ctx context.Context, swiperID uuid.UUID, swipedIDs ...uuid.UUID) {
+	args := make([]interface{}, len(swipedIDs))
+	for i, swipedID := range swipedIDs {
+		args[i] = swipedID.String()
+	}
+
+	if err := c.client.Eval(ctx, addSwipedIDsScript, []string{c.prefix + swiperID.String()}, args...).Err(); err != nil {
+		logger.Error("Failed to add swipe to cached swiped users", err)
+		c.invalidate(ctx, swiperID)
+	}
+}
+
+// invalidate drops the cached sets of the users
+func (c *SwipedUsersCache) invalidate(ctx context.Context, userIDs ...uuid.UUID) {
+	keys := make([]string, len(userIDs))
+	for i, userID := range userIDs {
+		keys[i] = c.prefix + userID.String()
+	}
+	if err := c.client.Del(ctx, keys...).Err(); err != nil {
+		logger.Error("Failed to invalidate cached swiped users", err)
+	}
+}
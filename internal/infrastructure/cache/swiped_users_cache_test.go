@@ -0,0 +1,106 @@
+package cache
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/22smeargle/winkr-backend/internal/domain/entities"
+	"github.com/22smeargle/winkr-backend/internal/domain/repositories"
+)
+
+// countingSwipeRepository keeps swipes in memory and counts the swiped-ID lookups that reach it
+type countingSwipeRepository struct {
+	repositories.MatchRepository
+	swipes  []*entities.Swipe
+	lookups int
+}
+
+func (r *countingSwipeRepository) CreateSwipe(ctx context.Context, swipe *entities.Swipe) error {
+	r.swipes = append(r.swipes, swipe)
+	return nil
+}
+
+func (r *countingSwipeRepository) BatchCreateSwipes(ctx context.Context, swipes []*entities.Swipe) error {
+	r.swipes = append(r.swipes, swipes...)
+	return nil
+}
+
+func (r *countingSwipeRepository) DeleteSwipe(ctx context.Context, swiperID, swipedID uuid.UUID) error {
+	kept := r.swipes[:0]
+	for _, swipe := range r.swipes {
+		if swipe.SwiperID != swiperID || swipe.SwipedID != swipedID {
+			kept = append(kept, swipe)
+		}
+	}
+	r.swipes = kept
+	return nil
+}
+
+func (r *countingSwipeRepository) GetSwipedTargetIDs(ctx context.Context, userID uuid.UUID) ([]uuid.UUID, error) {
+	r.lookups++
+	var ids []uuid.UUID
+	for _, swipe := range r.swipes {
+		if swipe.SwiperID == userID {
+			ids = append(ids, swipe.SwipedID)
+		}
+	}
+	return ids, nil
+}
+
+func TestSwipedUsersCache_UpdatesCachedSetOnSwipes(t *testing.T) {
+	repo := &countingSwipeRepository{}
+	cache := NewSwipedUsersCache(repo, newTestRedisClient(t))
+	ctx := context.Background()
+	userID, likedID, dislikedID := uuid.New(), uuid.New(), uuid.New()
+
+	require.NoError(t, cache.CreateSwipe(ctx, &entities.Swipe{SwiperID: userID, SwipedID: likedID, IsLike: true}))
+
+	ids, err := cache.GetSwipedTargetIDs(ctx, userID)
+	require.NoError(t, err)
+	assert.Equal(t, []uuid.UUID{likedID}, ids)
+	assert.Equal(t, 1, repo.lookups)
+
+	// Test a just-disliked user is excluded without reloading the swipe history
+	require.NoError(t, cache.CreateSwipe(ctx, &entities.Swipe{SwiperID: userID, SwipedID: dislikedID}))
+	ids, err = cache.GetSwipedTargetIDs(ctx, userID)
+	require.NoError(t, err)
+	assert.ElementsMatch(t, []uuid.UUID{likedID, dislikedID}, ids)
+	assert.Equal(t, 1, repo.lookups)
+
+	// Test an undone swipe is no longer excluded
+	require.NoError(t, cache.DeleteSwipe(ctx, userID, dislikedID))
+	ids, err = cache.GetSwipedTargetIDs(ctx, userID)
+	require.NoError(t, err)
+	assert.Equal(t, []uuid.UUID{likedID}, ids)
+	assert.Equal(t, 1, repo.lookups)
+}
+
+func TestSwipedUsersCache_SwipesBeforeCachingAreLoaded(t *testing.T) {
+	repo := &countingSwipeRepository{}
+	cache := NewSwipedUsersCache(repo, newTestRedisClient(t))
+	ctx := context.Background()
+	userID, firstID, secondID := uuid.New(), uuid.New(), uuid.New()
+
+	// Test swiping without a cached set doesn't cache the new swipes alone
+	require.NoError(t, cache.BatchCreateSwipes(ctx, []*entities.Swipe{
+		{SwiperID: userID, SwipedID: firstID},
+		{SwiperID: userID, SwipedID: secondID},
+	}))
+
+	ids, err := cache.GetSwipedTargetIDs(ctx, userID)
+	require.NoError(t, err)
+	assert.ElementsMatch(t, []uuid.UUID{firstID, secondID}, ids)
+	assert.Equal(t, 1, repo.lookups)
+
+	// Test a user who never swiped is cached as well
+	ids, err = cache.GetSwipedTargetIDs(ctx, firstID)
+	require.NoError(t, err)
+	assert.Empty(t, ids)
+	_, err = cache.GetSwipedTargetIDs(ctx, firstID)
+	require.NoError(t, err)
+	assert.Equal(t, 2, repo.lookups)
+}
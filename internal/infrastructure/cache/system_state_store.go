@@ -0,0 +1,44 @@
+package cache
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	goredis "github.com/go-redis/redis/v8"
+)
+
+// SystemStateStore keeps system-wide settings that every instance must agree on, such as the
+// read-only toggle. Values don't expire.
+type SystemStateStore struct {
+	client goredis.Cmdable
+	prefix string
+}
+
+// NewSystemStateStore creates a new system state store
+func NewSystemStateStore(client goredis.Cmdable) *SystemStateStore {
+	return &SystemStateStore{
+		client: client,
+		prefix: "system:",
+	}
+}
+
+// Get returns the value of key, or an empty string if it was never set
+func (s *SystemStateStore) Get(ctx context.Context, key string) (string, error) {
+	value, err := s.client.Get(ctx, s.prefix+key).Result()
+	if errors.Is(err, goredis.Nil) {
+		return "", nil
+	}
+	if err != nil {
+		return "", fmt.Errorf("failed to get system state %s: %w", key, err)
+	}
+	return value, nil
+}
+
+// Set stores the value of key
+func (s *SystemStateStore) Set(ctx context.Context, key, value string) error {
+	if err := s.client.Set(ctx, s.prefix+key, value, 0).Err(); err != nil {
+		return fmt.Errorf("failed to set system state %s: %w", key, err)
+	}
+	return nil
+}
@@ -0,0 +1,58 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// AccountSignal represents a device or IP signal of an account in database
+type AccountSignal struct {
+	ID          uuid.UUID `gorm:"type:uuid;primary_key;default:gen_random_uuid()" json:"id"`
+	UserID      uuid.UUID `gorm:"type:uuid;not null;uniqueIndex:idx_account_signals_user_signal" json:"user_id"`
+	SignalType  string    `gorm:"type:varchar(20);not null;uniqueIndex:idx_account_signals_user_signal;index:idx_account_signals_signal" json:"signal_type"`
+	Value       string    `gorm:"type:varchar(64);not null;uniqueIndex:idx_account_signals_user_signal;index:idx_account_signals_signal" json:"value"`
+	FirstSeenAt time.Time `gorm:"not null" json:"first_seen_at"`
+	LastSeenAt  time.Time `gorm:"not null" json:"last_seen_at"`
+
+	// Relationships
+	User *User `gorm:"foreignKey:UserID;constraint:OnDelete:CASCADE" json:"user,omitempty"`
+}
+
+// TableName returns the table name for AccountSignal model
+func (AccountSignal) TableName() string {
+	return "account_signals"
+}
+
+// BeforeCreate GORM hook
+func (as *AccountSignal) BeforeCreate(tx *gorm.DB) error {
+	if as.ID == uuid.Nil {
+		as.ID = uuid.New()
+	}
+	return nil
+}
+
+// LinkedAccountFlag represents a signal flagged for sharing between accounts in database
+type LinkedAccountFlag struct {
+	ID           uuid.UUID `gorm:"type:uuid;primary_key;default:gen_random_uuid()" json:"id"`
+	SignalType   string    `gorm:"type:varchar(20);not null;uniqueIndex:idx_linked_account_flags_signal" json:"signal_type"`
+	Value        string    `gorm:"type:varchar(64);not null;uniqueIndex:idx_linked_account_flags_signal" json:"value"`
+	Reason       string    `gorm:"type:varchar(50);not null" json:"reason"`
+	AccountCount int       `gorm:"not null" json:"account_count"`
+	FlaggedAt    time.Time `gorm:"autoCreateTime" json:"flagged_at"`
+	UpdatedAt    time.Time `gorm:"autoUpdateTime;index" json:"updated_at"`
+}
+
+// TableName returns the table name for LinkedAccountFlag model
+func (LinkedAccountFlag) TableName() string {
+	return "linked_account_flags"
+}
+
+// BeforeCreate GORM hook
+func (laf *LinkedAccountFlag) BeforeCreate(tx *gorm.DB) error {
+	if laf.ID == uuid.Nil {
+		laf.ID = uuid.New()
+	}
+	return nil
+}
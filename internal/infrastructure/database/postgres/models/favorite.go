@@ -0,0 +1,33 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// Favorite represents a saved profile in database
+type Favorite struct {
+	ID             uuid.UUID `gorm:"type:uuid;primary_key;default:gen_random_uuid()" json:"id"`
+	UserID         uuid.UUID `gorm:"type:uuid;not null;uniqueIndex:idx_favorites_user_favorite_user" json:"user_id"`
+	FavoriteUserID uuid.UUID `gorm:"type:uuid;not null;uniqueIndex:idx_favorites_user_favorite_user" json:"favorite_user_id"`
+	CreatedAt      time.Time `gorm:"autoCreateTime" json:"created_at"`
+
+	// Relationships
+	User         *User `gorm:"foreignKey:UserID;constraint:OnDelete:CASCADE" json:"user,omitempty"`
+	FavoriteUser *User `gorm:"foreignKey:FavoriteUserID;constraint:OnDelete:CASCADE" json:"favorite_user,omitempty"`
+}
+
+// TableName returns the table name for Favorite model
+func (Favorite) TableName() string {
+	return "favorites"
+}
+
+// BeforeCreate GORM hook
+func (f *Favorite) BeforeCreate(tx *gorm.DB) error {
+	if f.ID == uuid.Nil {
+		f.ID = uuid.New()
+	}
+	return nil
+}
@@ -0,0 +1,33 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// NoticeAcknowledgement represents a legal notice acknowledgement in database
+type NoticeAcknowledgement struct {
+	ID             uuid.UUID `gorm:"type:uuid;primary_key;default:gen_random_uuid()" json:"id"`
+	UserID         uuid.UUID `gorm:"type:uuid;not null;uniqueIndex:idx_notice_ack_user_notice_version" json:"user_id"`
+	NoticeID       string    `gorm:"type:varchar(100);not null;uniqueIndex:idx_notice_ack_user_notice_version" json:"notice_id"`
+	Version        int       `gorm:"not null;uniqueIndex:idx_notice_ack_user_notice_version" json:"version"`
+	AcknowledgedAt time.Time `gorm:"autoCreateTime" json:"acknowledged_at"`
+
+	// Relationships
+	User *User `gorm:"foreignKey:UserID;constraint:OnDelete:CASCADE" json:"user,omitempty"`
+}
+
+// TableName returns the table name for NoticeAcknowledgement model
+func (NoticeAcknowledgement) TableName() string {
+	return "notice_acknowledgements"
+}
+
+// BeforeCreate GORM hook
+func (na *NoticeAcknowledgement) BeforeCreate(tx *gorm.DB) error {
+	if na.ID == uuid.Nil {
+		na.ID = uuid.New()
+	}
+	return nil
+}
@@ -105,6 +105,8 @@ type UserPreferences struct {
 	AgeMax      int        `gorm:"default:100" json:"age_max"`
 	MaxDistance int        `gorm:"default:50" json:"max_distance"` // in kilometers
 	ShowMe      bool       `gorm:"default:true" json:"show_me"`
+	HiddenFields []string  `gorm:"type:text[]" json:"hidden_fields"` // profile fields hidden from other users
+	AutoReorderPhotos bool `gorm:"default:false" json:"auto_reorder_photos"`
 	CreatedAt   time.Time  `gorm:"autoCreateTime" json:"created_at"`
 	UpdatedAt   time.Time  `gorm:"autoUpdateTime" json:"updated_at"`
 
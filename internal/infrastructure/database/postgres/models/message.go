@@ -16,6 +16,13 @@ type Message struct {
 	MessageType    string     `gorm:"default:'text';check:message_type IN ('text', 'image', 'gif', 'ephemeral_photo')" json:"message_type"`
 	IsRead         bool       `gorm:"default:false;index" json:"is_read"`
 	IsDeleted      bool       `gorm:"default:false;index" json:"is_deleted"`
+	IsPinned       bool       `gorm:"default:false" json:"is_pinned"`
+	PinnedAt       *time.Time `gorm:"type:timestamp" json:"pinned_at"`
+	PinnedBy       *uuid.UUID `gorm:"type:uuid" json:"pinned_by"`
+	ClientMessageID *string   `gorm:"type:varchar(255)" json:"client_message_id"`
+	EncryptedOriginal *string `gorm:"type:text" json:"-"`
+	EditedAt       *time.Time `gorm:"type:timestamp" json:"edited_at"`
+	DeletedAt      *time.Time `gorm:"type:timestamp" json:"deleted_at"`
 	CreatedAt      time.Time  `gorm:"autoCreateTime;index" json:"created_at"`
 
 	// Relationships
@@ -36,6 +43,56 @@ func (m *Message) BeforeCreate(tx *gorm.DB) error {
 	return nil
 }
 
+// MessageStatus represents a message delivery status transition in database
+type MessageStatus struct {
+	ID        uuid.UUID `gorm:"type:uuid;primary_key;default:gen_random_uuid()" json:"id"`
+	MessageID uuid.UUID `gorm:"type:uuid;not null;uniqueIndex:idx_message_status_message_status" json:"message_id"`
+	UserID    uuid.UUID `gorm:"type:uuid;not null;index" json:"user_id"`
+	Status    string    `gorm:"not null;uniqueIndex:idx_message_status_message_status;check:status IN ('sent', 'delivered', 'read')" json:"status"`
+	CreatedAt time.Time `gorm:"autoCreateTime" json:"created_at"`
+
+	// Relationships
+	Message *Message `gorm:"foreignKey:MessageID;constraint:OnDelete:CASCADE" json:"message,omitempty"`
+}
+
+// TableName returns the table name for MessageStatus model
+func (MessageStatus) TableName() string {
+	return "message_status"
+}
+
+// BeforeCreate GORM hook
+func (ms *MessageStatus) BeforeCreate(tx *gorm.DB) error {
+	if ms.ID == uuid.Nil {
+		ms.ID = uuid.New()
+	}
+	return nil
+}
+
+// MessageReaction represents a user's emoji reaction to a message in database
+type MessageReaction struct {
+	ID        uuid.UUID `gorm:"type:uuid;primary_key;default:gen_random_uuid()" json:"id"`
+	MessageID uuid.UUID `gorm:"type:uuid;not null;uniqueIndex:idx_message_reactions_message_user_emoji" json:"message_id"`
+	UserID    uuid.UUID `gorm:"type:uuid;not null;uniqueIndex:idx_message_reactions_message_user_emoji" json:"user_id"`
+	Emoji     string    `gorm:"type:varchar(32);not null;uniqueIndex:idx_message_reactions_message_user_emoji" json:"emoji"`
+	CreatedAt time.Time `gorm:"autoCreateTime" json:"created_at"`
+
+	// Relationships
+	Message *Message `gorm:"foreignKey:MessageID;constraint:OnDelete:CASCADE" json:"message,omitempty"`
+}
+
+// TableName returns the table name for MessageReaction model
+func (MessageReaction) TableName() string {
+	return "message_reactions"
+}
+
+// BeforeCreate GORM hook
+func (mr *MessageReaction) BeforeCreate(tx *gorm.DB) error {
+	if mr.ID == uuid.Nil {
+		mr.ID = uuid.New()
+	}
+	return nil
+}
+
 // IsText returns true if the message is a text message
 func (m *Message) IsText() bool {
 	return m.MessageType == "text"
@@ -86,6 +143,15 @@ func (m *Message) CanBeDeleted() bool {
 type Conversation struct {
 	ID        uuid.UUID  `gorm:"type:uuid;primary_key;default:gen_random_uuid()" json:"id"`
 	MatchID   uuid.UUID  `gorm:"type:uuid;not null;uniqueIndex" json:"match_id"`
+	ClosedAt  *time.Time `gorm:"type:timestamp" json:"closed_at"`
+
+	// Conversation streak and match anniversary tracking
+	StreakCount           int        `gorm:"not null;default:0" json:"streak_count"`
+	StreakLastDay         *time.Time `gorm:"type:date" json:"streak_last_day"`
+	StreakPendingDay      *time.Time `gorm:"type:date" json:"streak_pending_day"`
+	StreakPendingSenderID *uuid.UUID `gorm:"type:uuid" json:"streak_pending_sender_id"`
+	AnniversaryMonths     int        `gorm:"not null;default:0" json:"anniversary_months"`
+
 	CreatedAt time.Time  `gorm:"autoCreateTime" json:"created_at"`
 	UpdatedAt time.Time  `gorm:"autoUpdateTime" json:"updated_at"`
 
@@ -99,6 +165,18 @@ func (Conversation) TableName() string {
 	return "conversations"
 }
 
+// ConversationArchive marks a conversation as archived by one of its participants in database
+type ConversationArchive struct {
+	ConversationID uuid.UUID `gorm:"type:uuid;primary_key" json:"conversation_id"`
+	UserID         uuid.UUID `gorm:"type:uuid;primary_key" json:"user_id"`
+	ArchivedAt     time.Time `gorm:"autoCreateTime" json:"archived_at"`
+}
+
+// TableName returns the table name for ConversationArchive model
+func (ConversationArchive) TableName() string {
+	return "conversation_archives"
+}
+
 // BeforeCreate GORM hook
 func (c *Conversation) BeforeCreate(tx *gorm.DB) error {
 	if c.ID == uuid.Nil {
@@ -20,6 +20,7 @@ func AllModels() []interface{} {
 		&Appeal{},
 		&ModerationAction{},
 		&UserReputation{},
+		&ReputationChange{},
 		&ModerationQueue{},
 		&Block{},
 		&ContentAnalysis{},
@@ -309,6 +309,35 @@ func (u *UserReputation) GetReputationLevel() string {
 	}
 }
 
+// ReputationChange represents one change to a user's reputation score in the database
+type ReputationChange struct {
+	ID            uuid.UUID  `gorm:"type:uuid;primary_key;default:gen_random_uuid()" json:"id"`
+	UserID        uuid.UUID  `gorm:"type:uuid;not null;index:idx_reputation_changes_user_created" json:"user_id"`
+	Delta         int        `gorm:"not null" json:"delta"`
+	PreviousScore int        `gorm:"not null" json:"previous_score"`
+	NewScore      int        `gorm:"not null" json:"new_score"`
+	Reason        string     `gorm:"size:50;not null" json:"reason"`
+	ReferenceID   *uuid.UUID `gorm:"type:uuid" json:"reference_id"`
+	ActorID       *uuid.UUID `gorm:"type:uuid" json:"actor_id"`
+	CreatedAt     time.Time  `gorm:"autoCreateTime;index:idx_reputation_changes_user_created" json:"created_at"`
+
+	// Relationships
+	User *User `gorm:"foreignKey:UserID;constraint:OnDelete:CASCADE" json:"user,omitempty"`
+}
+
+// TableName returns the table name for the ReputationChange model
+func (ReputationChange) TableName() string {
+	return "reputation_changes"
+}
+
+// BeforeCreate GORM hook
+func (r *ReputationChange) BeforeCreate(tx *gorm.DB) error {
+	if r.ID == uuid.Nil {
+		r.ID = uuid.New()
+	}
+	return nil
+}
+
 // ModerationQueue represents an item in the moderation queue
 type ModerationQueue struct {
 	ID           uuid.UUID  `gorm:"type:uuid;primary_key;default:gen_random_uuid()" json:"id"`
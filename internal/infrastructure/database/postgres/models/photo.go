@@ -14,9 +14,13 @@ type Photo struct {
 	FileURL           string     `gorm:"not null" json:"file_url"`
 	FileKey           string     `gorm:"not null;uniqueIndex" json:"file_key"`
 	IsPrimary         bool       `gorm:"default:false;index" json:"is_primary"`
+	PrimaryLocked     bool       `gorm:"default:false" json:"primary_locked"`
+	Position          int        `gorm:"default:0" json:"position"`
 	VerificationStatus string     `gorm:"default:'pending';check:verification_status IN ('pending', 'approved', 'rejected');index" json:"verification_status"`
 	VerificationReason *string    `gorm:"type:text" json:"verification_reason"`
 	IsDeleted         bool       `gorm:"default:false;index" json:"is_deleted"`
+	IsHidden          bool       `gorm:"default:false" json:"is_hidden"`
+	ReviewRequestedAt *time.Time `json:"review_requested_at"`
 	CreatedAt         time.Time  `gorm:"autoCreateTime" json:"created_at"`
 	UpdatedAt         time.Time  `gorm:"autoUpdateTime" json:"updated_at"`
 
@@ -0,0 +1,25 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// PhotoImpression represents a user being shown a photo in database
+type PhotoImpression struct {
+	PhotoID  uuid.UUID  `gorm:"type:uuid;primary_key" json:"photo_id"`
+	UserID   uuid.UUID  `gorm:"type:uuid;not null;index:idx_photo_impressions_user_id_viewer_id" json:"user_id"`
+	ViewerID uuid.UUID  `gorm:"type:uuid;primary_key;index:idx_photo_impressions_user_id_viewer_id" json:"viewer_id"`
+	Liked    bool       `gorm:"not null;default:false" json:"liked"`
+	ViewedAt time.Time  `gorm:"not null" json:"viewed_at"`
+	LikedAt  *time.Time `json:"liked_at"`
+
+	// Relationships
+	Photo *Photo `gorm:"foreignKey:PhotoID;constraint:OnDelete:CASCADE" json:"photo,omitempty"`
+}
+
+// TableName returns the table name for PhotoImpression model
+func (PhotoImpression) TableName() string {
+	return "photo_impressions"
+}
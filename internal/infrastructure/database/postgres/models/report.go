@@ -12,6 +12,7 @@ type Report struct {
 	ID             uuid.UUID  `gorm:"type:uuid;primary_key;default:gen_random_uuid()" json:"id"`
 	ReporterID     uuid.UUID  `gorm:"type:uuid;not null;index" json:"reporter_id"`
 	ReportedUserID uuid.UUID  `gorm:"type:uuid;not null;index" json:"reported_user_id"`
+	PhotoID        *uuid.UUID `gorm:"type:uuid;index" json:"photo_id,omitempty"`
 	Reason         string     `gorm:"not null;check:reason IN ('inappropriate_behavior', 'fake_profile', 'spam', 'harassment', 'other');index" json:"reason"`
 	Description    *string    `gorm:"type:text" json:"description"`
 	Status         string     `gorm:"default:'pending';check:status IN ('pending', 'reviewed', 'resolved', 'dismissed');index" json:"status"`
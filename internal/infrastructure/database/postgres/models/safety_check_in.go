@@ -0,0 +1,41 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// SafetyCheckIn represents a safety check-in in database
+type SafetyCheckIn struct {
+	ID               uuid.UUID  `gorm:"type:uuid;primary_key;default:gen_random_uuid()" json:"id"`
+	UserID           uuid.UUID  `gorm:"type:uuid;not null;index" json:"user_id"`
+	DueAt            time.Time  `gorm:"not null;index" json:"due_at"`
+	Status           string     `gorm:"type:varchar(20);not null;default:'scheduled'" json:"status"`
+	Note             *string    `gorm:"type:varchar(500)" json:"note"`
+	ContactName      *string    `gorm:"type:varchar(100)" json:"contact_name"`
+	ContactEmail     *string    `gorm:"type:varchar(255)" json:"contact_email"`
+	ContactConsentAt *time.Time `json:"contact_consent_at"`
+	ConfirmedAt      *time.Time `json:"confirmed_at"`
+	MissedAt         *time.Time `json:"missed_at"`
+	ContactAlertedAt *time.Time `json:"contact_alerted_at"`
+	CreatedAt        time.Time  `gorm:"autoCreateTime" json:"created_at"`
+	UpdatedAt        time.Time  `gorm:"autoUpdateTime" json:"updated_at"`
+
+	// Relationships
+	User *User `gorm:"foreignKey:UserID;constraint:OnDelete:CASCADE" json:"user,omitempty"`
+}
+
+// TableName returns the table name for SafetyCheckIn model
+func (SafetyCheckIn) TableName() string {
+	return "safety_check_ins"
+}
+
+// BeforeCreate GORM hook
+func (c *SafetyCheckIn) BeforeCreate(tx *gorm.DB) error {
+	if c.ID == uuid.Nil {
+		c.ID = uuid.New()
+	}
+	return nil
+}
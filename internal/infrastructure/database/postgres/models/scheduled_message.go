@@ -0,0 +1,42 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// ScheduledMessage represents a message scheduled to be sent later in database
+type ScheduledMessage struct {
+	ID             uuid.UUID  `gorm:"type:uuid;primary_key;default:gen_random_uuid()" json:"id"`
+	ConversationID uuid.UUID  `gorm:"type:uuid;not null;index" json:"conversation_id"`
+	SenderID       uuid.UUID  `gorm:"type:uuid;not null;index" json:"sender_id"`
+	Content        string     `gorm:"type:text;not null" json:"content"`
+	SendAt         time.Time  `gorm:"not null;index" json:"send_at"`
+	Status         string     `gorm:"type:varchar(20);not null;default:'scheduled'" json:"status"`
+	MessageID      *uuid.UUID `gorm:"type:uuid" json:"message_id"`
+	SkipReason     *string    `gorm:"type:varchar(50)" json:"skip_reason"`
+	SentAt         *time.Time `json:"sent_at"`
+	CanceledAt     *time.Time `json:"canceled_at"`
+	SkippedAt      *time.Time `json:"skipped_at"`
+	CreatedAt      time.Time  `gorm:"autoCreateTime" json:"created_at"`
+	UpdatedAt      time.Time  `gorm:"autoUpdateTime" json:"updated_at"`
+
+	// Relationships
+	Conversation *Conversation `gorm:"foreignKey:ConversationID;constraint:OnDelete:CASCADE" json:"conversation,omitempty"`
+	Sender       *User         `gorm:"foreignKey:SenderID;constraint:OnDelete:CASCADE" json:"sender,omitempty"`
+}
+
+// TableName returns the table name for ScheduledMessage model
+func (ScheduledMessage) TableName() string {
+	return "scheduled_messages"
+}
+
+// BeforeCreate GORM hook
+func (m *ScheduledMessage) BeforeCreate(tx *gorm.DB) error {
+	if m.ID == uuid.Nil {
+		m.ID = uuid.New()
+	}
+	return nil
+}
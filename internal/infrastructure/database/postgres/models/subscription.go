@@ -12,11 +12,13 @@ type Subscription struct {
 	ID                   uuid.UUID  `gorm:"type:uuid;primary_key;default:gen_random_uuid()" json:"id"`
 	UserID               uuid.UUID  `gorm:"type:uuid;not null;index" json:"user_id"`
 	StripeSubscriptionID  *string    `gorm:"uniqueIndex" json:"stripe_subscription_id"`
+	StripeCustomerID      *string    `gorm:"index" json:"stripe_customer_id"`
 	PlanType             string     `gorm:"not null;check:plan_type IN ('basic', 'premium', 'platinum');index" json:"plan_type"`
 	Status               string     `gorm:"not null;check:status IN ('active', 'canceled', 'past_due', 'unpaid');index" json:"status"`
 	CurrentPeriodStart    *time.Time `json:"current_period_start"`
 	CurrentPeriodEnd      *time.Time `json:"current_period_end"`
 	CancelAtPeriodEnd    bool       `gorm:"default:false" json:"cancel_at_period_end"`
+	GracePeriodEndsAt    *time.Time `json:"grace_period_ends_at,omitempty"`
 	CreatedAt            time.Time  `gorm:"autoCreateTime" json:"created_at"`
 	UpdatedAt            time.Time  `gorm:"autoUpdateTime" json:"updated_at"`
 
@@ -17,16 +17,28 @@ type User struct {
 	DateOfBirth    time.Time  `gorm:"not null" json:"date_of_birth"`
 	Gender         string     `gorm:"not null;check:gender IN ('male', 'female', 'other')" json:"gender"`
 	InterestedIn   []string   `gorm:"type:text[];not null" json:"interested_in"`
+	Interests      []string   `gorm:"type:text[]" json:"interests"`
 	Bio            *string    `gorm:"type:text" json:"bio"`
 	LocationLat    *float64   `gorm:"type:decimal(10,8)" json:"location_lat"`
 	LocationLng    *float64   `gorm:"type:decimal(11,8)" json:"location_lng"`
 	LocationCity   *string    `gorm:"size:100" json:"location_city"`
 	LocationCountry *string    `gorm:"size:100" json:"location_country"`
+	HeightCm       *int       `gorm:"type:smallint;check:height_cm BETWEEN 120 AND 230" json:"height_cm"`
+	Smoking        *string    `gorm:"size:20;check:smoking IN ('never', 'socially', 'regularly')" json:"smoking"`
+	Drinking       *string    `gorm:"size:20;check:drinking IN ('never', 'socially', 'regularly')" json:"drinking"`
+	Locale         *string    `gorm:"size:35" json:"locale"`
+	Timezone       *string    `gorm:"size:64" json:"timezone"`
+	AutoTranslateMessages bool `gorm:"default:false" json:"auto_translate_messages"`
+	SendReadReceipts bool     `gorm:"default:true" json:"send_read_receipts"`
+	AutoIntro      *string    `gorm:"type:text" json:"auto_intro"`
 	IsVerified     bool       `gorm:"default:false" json:"is_verified"`
 	VerificationLevel int       `gorm:"default:0;check:verification_level IN (0, 1, 2);index" json:"verification_level"`
 	IsPremium      bool       `gorm:"default:false" json:"is_premium"`
 	IsActive       bool       `gorm:"default:true;index" json:"is_active"`
 	IsBanned       bool       `gorm:"default:false;index" json:"is_banned"`
+	GeofenceExempt bool       `gorm:"default:false" json:"geofence_exempt"`
+	ProfileUnderReview bool   `gorm:"default:false" json:"profile_under_review"`
+	Shadowbanned   bool       `gorm:"default:false" json:"shadowbanned"`
 	LastActive     *time.Time `gorm:"index" json:"last_active"`
 	CreatedAt      time.Time  `gorm:"autoCreateTime" json:"created_at"`
 	UpdatedAt      time.Time  `gorm:"autoUpdateTime" json:"updated_at"`
@@ -102,6 +102,38 @@ func (v *Verification) SetDocumentData(documentType string, data string) {
 	v.DocumentData = &data
 }
 
+// VerificationDocument represents a document submitted within a verification session in database
+type VerificationDocument struct {
+	ID              uuid.UUID `gorm:"type:uuid;primary_key;default:gen_random_uuid()" json:"id"`
+	VerificationID  uuid.UUID `gorm:"type:uuid;not null;index" json:"verification_id"`
+	UserID          uuid.UUID `gorm:"type:uuid;not null;index" json:"user_id"`
+	DocumentType    string    `gorm:"size:50;not null" json:"document_type"`
+	Status          string    `gorm:"not null;default:'pending';check:status IN ('pending', 'approved', 'rejected');index" json:"status"`
+	PhotoURL        string    `gorm:"not null" json:"photo_url"`
+	PhotoKey        string    `gorm:"not null" json:"photo_key"`
+	Confidence      *float64  `gorm:"type:decimal(3,2)" json:"confidence"` // 0.00-1.00
+	DocumentData    *string   `gorm:"type:text" json:"document_data"`      // JSON string
+	RejectionReason *string   `gorm:"type:text" json:"rejection_reason"`
+	CreatedAt       time.Time `gorm:"autoCreateTime" json:"created_at"`
+	UpdatedAt       time.Time `gorm:"autoUpdateTime" json:"updated_at"`
+
+	// Relationships
+	Verification *Verification `gorm:"foreignKey:VerificationID;constraint:OnDelete:CASCADE" json:"verification,omitempty"`
+}
+
+// TableName returns the table name for VerificationDocument model
+func (VerificationDocument) TableName() string {
+	return "verification_documents"
+}
+
+// BeforeCreate GORM hook
+func (vd *VerificationDocument) BeforeCreate(tx *gorm.DB) error {
+	if vd.ID == uuid.Nil {
+		vd.ID = uuid.New()
+	}
+	return nil
+}
+
 // VerificationAttempt represents a verification attempt tracking in database
 type VerificationAttempt struct {
 	ID        uuid.UUID `gorm:"type:uuid;primary_key;default:gen_random_uuid()" json:"id"`
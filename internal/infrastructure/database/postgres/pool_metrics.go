@@ -0,0 +1,149 @@
+package postgres
+
+import (
+	"database/sql"
+	"fmt"
+	"io"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/22smeargle/winkr-backend/pkg/config"
+	"github.com/22smeargle/winkr-backend/pkg/logger"
+)
+
+// defaultPoolMetricsInterval is used when no collection interval is configured
+const defaultPoolMetricsInterval = time.Minute
+
+// PoolStatsSource reports connection pool statistics, as *sql.DB does
+type PoolStatsSource interface {
+	Stats() sql.DBStats
+}
+
+// PoolMetricsCollector samples the connection pool on the metrics collection interval and exports
+// the latest sample as Prometheus gauges, to help diagnose pool exhaustion under load
+type PoolMetricsCollector struct {
+	source   PoolStatsSource
+	interval time.Duration
+	prefix   string
+
+	mu       sync.RWMutex
+	stats    sql.DBStats
+	running  bool
+	stopChan chan struct{}
+	wg       sync.WaitGroup
+}
+
+// NewPoolMetricsCollector creates a new pool metrics collector, naming its gauges under the
+// configured Prometheus namespace and subsystem
+func NewPoolMetricsCollector(source PoolStatsSource, cfg *config.MetricsConfig) *PoolMetricsCollector {
+	interval := cfg.CollectionInterval
+	if interval <= 0 {
+		interval = defaultPoolMetricsInterval
+	}
+
+	parts := make([]string, 0, 3)
+	for _, part := range []string{cfg.Prometheus.Namespace, cfg.Prometheus.Subsystem, "db"} {
+		if part != "" {
+			parts = append(parts, part)
+		}
+	}
+
+	return &PoolMetricsCollector{
+		source:   source,
+		interval: interval,
+		prefix:   strings.Join(parts, "_"),
+	}
+}
+
+// Start takes a first sample and starts sampling in the background
+func (p *PoolMetricsCollector) Start() {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if p.running {
+		return // Already running
+	}
+
+	p.stats = p.source.Stats()
+	p.running = true
+	p.stopChan = make(chan struct{})
+
+	p.wg.Add(1)
+	go p.run(p.stopChan)
+
+	logger.Info("Starting database pool metrics collection", map[string]interface{}{
+		"interval": p.interval.String(),
+	})
+}
+
+// Stop stops sampling and waits for the background goroutine to exit
+func (p *PoolMetricsCollector) Stop() {
+	p.mu.Lock()
+	if !p.running {
+		p.mu.Unlock()
+		return // Not running
+	}
+	close(p.stopChan)
+	p.running = false
+	p.mu.Unlock()
+
+	p.wg.Wait()
+	logger.Info("Database pool metrics collection stopped")
+}
+
+// run samples the pool until stopped
+func (p *PoolMetricsCollector) run(stopChan chan struct{}) {
+	defer p.wg.Done()
+
+	ticker := time.NewTicker(p.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-stopChan:
+			return
+		case <-ticker.C:
+			stats := p.source.Stats()
+			p.mu.Lock()
+			p.stats = stats
+			p.mu.Unlock()
+		}
+	}
+}
+
+// Stats returns the latest sample
+func (p *PoolMetricsCollector) Stats() sql.DBStats {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+	return p.stats
+}
+
+// Write writes the latest sample in the Prometheus text format
+func (p *PoolMetricsCollector) Write(w io.Writer) error {
+	stats := p.Stats()
+
+	gauges := []struct {
+		name  string
+		help  string
+		value string
+	}{
+		{"connections_max_open", "Maximum number of open connections to the database.", fmt.Sprintf("%d", stats.MaxOpenConnections)},
+		{"connections_open", "Number of established connections, in use and idle.", fmt.Sprintf("%d", stats.OpenConnections)},
+		{"connections_in_use", "Number of connections in use.", fmt.Sprintf("%d", stats.InUse)},
+		{"connections_idle", "Number of idle connections.", fmt.Sprintf("%d", stats.Idle)},
+		{"wait_count", "Total number of connections waited for.", fmt.Sprintf("%d", stats.WaitCount)},
+		{"wait_duration_seconds", "Total time blocked waiting for a new connection.", fmt.Sprintf("%g", stats.WaitDuration.Seconds())},
+	}
+
+	var b strings.Builder
+	for _, gauge := range gauges {
+		name := p.prefix + "_" + gauge.name
+		fmt.Fprintf(&b, "# HELP %s %s\n", name, gauge.help)
+		fmt.Fprintf(&b, "# TYPE %s gauge\n", name)
+		fmt.Fprintf(&b, "%s %s\n", name, gauge.value)
+	}
+
+	_, err := io.WriteString(w, b.String())
+	return err
+}
@@ -0,0 +1,97 @@
+package postgres
+
+import (
+	"bytes"
+	"database/sql"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/22smeargle/winkr-backend/pkg/config"
+)
+
+// fakePoolStats reports pool stats set by the test
+type fakePoolStats struct {
+	mu    sync.Mutex
+	stats sql.DBStats
+}
+
+func (f *fakePoolStats) Stats() sql.DBStats {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.stats
+}
+
+func (f *fakePoolStats) set(stats sql.DBStats) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.stats = stats
+}
+
+func TestPoolMetricsCollector_Write(t *testing.T) {
+	source := &fakePoolStats{stats: sql.DBStats{
+		MaxOpenConnections: 25,
+		OpenConnections:    10,
+		InUse:              7,
+		Idle:               3,
+		WaitCount:          42,
+		WaitDuration:       1500 * time.Millisecond,
+	}}
+	collector := NewPoolMetricsCollector(source, &config.MetricsConfig{
+		CollectionInterval: time.Hour,
+		Prometheus:         config.PrometheusConfig{Namespace: "winkr", Subsystem: "backend"},
+	})
+	collector.Start()
+	defer collector.Stop()
+
+	var buf bytes.Buffer
+	require.NoError(t, collector.Write(&buf))
+	body := buf.String()
+
+	assert.Contains(t, body, "# TYPE winkr_backend_db_connections_open gauge")
+	assert.Contains(t, body, "winkr_backend_db_connections_max_open 25\n")
+	assert.Contains(t, body, "winkr_backend_db_connections_open 10\n")
+	assert.Contains(t, body, "winkr_backend_db_connections_in_use 7\n")
+	assert.Contains(t, body, "winkr_backend_db_connections_idle 3\n")
+	assert.Contains(t, body, "winkr_backend_db_wait_count 42\n")
+	assert.Contains(t, body, "winkr_backend_db_wait_duration_seconds 1.5\n")
+}
+
+func TestPoolMetricsCollector_SamplesOnInterval(t *testing.T) {
+	source := &fakePoolStats{stats: sql.DBStats{OpenConnections: 1}}
+	collector := NewPoolMetricsCollector(source, &config.MetricsConfig{CollectionInterval: 5 * time.Millisecond})
+	collector.Start()
+	defer collector.Stop()
+
+	assert.Equal(t, 1, collector.Stats().OpenConnections)
+
+	source.set(sql.DBStats{OpenConnections: 9})
+	assert.Eventually(t, func() bool {
+		return collector.Stats().OpenConnections == 9
+	}, time.Second, 5*time.Millisecond)
+}
+
+func TestPoolMetricsCollector_StopIsClean(t *testing.T) {
+	source := &fakePoolStats{stats: sql.DBStats{OpenConnections: 1}}
+	collector := NewPoolMetricsCollector(source, &config.MetricsConfig{CollectionInterval: 5 * time.Millisecond})
+
+	// Stopping before starting is a no-op
+	collector.Stop()
+
+	collector.Start()
+	collector.Start()
+	collector.Stop()
+	collector.Stop()
+
+	// No sampling happens once stopped
+	source.set(sql.DBStats{OpenConnections: 9})
+	time.Sleep(20 * time.Millisecond)
+	assert.Equal(t, 1, collector.Stats().OpenConnections)
+
+	// The collector can be restarted
+	collector.Start()
+	defer collector.Stop()
+	assert.Equal(t, 9, collector.Stats().OpenConnections)
+}
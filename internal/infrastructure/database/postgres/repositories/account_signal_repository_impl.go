@@ -0,0 +1,125 @@
+package repositories
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+	"github.com/22smeargle/winkr-backend/internal/domain/entities"
+	"github.com/22smeargle/winkr-backend/internal/domain/repositories"
+	"github.com/22smeargle/winkr-backend/internal/infrastructure/database/postgres/models"
+	"github.com/22smeargle/winkr-backend/pkg/logger"
+)
+
+// AccountSignalRepositoryImpl implements AccountSignalRepository interface using GORM
+type AccountSignalRepositoryImpl struct {
+	db *gorm.DB
+}
+
+// NewAccountSignalRepository creates a new AccountSignalRepository instance
+func NewAccountSignalRepository(db *gorm.DB) repositories.AccountSignalRepository {
+	return &AccountSignalRepositoryImpl{db: db}
+}
+
+// RecordSignal stores a signal, or updates when it was last seen if the account already has it
+func (r *AccountSignalRepositoryImpl) RecordSignal(ctx context.Context, signal *entities.AccountSignal) error {
+	model := &models.AccountSignal{
+		ID:          signal.ID,
+		UserID:      signal.UserID,
+		SignalType:  signal.SignalType,
+		Value:       signal.Value,
+		FirstSeenAt: signal.FirstSeenAt,
+		LastSeenAt:  signal.LastSeenAt,
+	}
+
+	err := r.db.WithContext(ctx).Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "user_id"}, {Name: "signal_type"}, {Name: "value"}},
+		DoUpdates: clause.AssignmentColumns([]string{"last_seen_at"}),
+	}).Create(model).Error
+	if err != nil {
+		logger.Error("Failed to record account signal", err)
+		return fmt.Errorf("failed to record account signal: %w", err)
+	}
+
+	return nil
+}
+
+// GetUserIDsBySignal retrieves the accounts seen with a signal since the given time
+func (r *AccountSignalRepositoryImpl) GetUserIDsBySignal(ctx context.Context, signalType, value string, since time.Time) ([]uuid.UUID, error) {
+	var userIDs []uuid.UUID
+	err := r.db.WithContext(ctx).Model(&models.AccountSignal{}).
+		Where("signal_type = ? AND value = ? AND last_seen_at >= ?", signalType, value, since).
+		Order("first_seen_at ASC").
+		Pluck("user_id", &userIDs).Error
+	if err != nil {
+		logger.Error("Failed to get accounts by signal", err)
+		return nil, fmt.Errorf("failed to get accounts by signal: %w", err)
+	}
+
+	return userIDs, nil
+}
+
+// SaveLinkedAccountFlag flags a signal for review, or updates the existing flag for it
+func (r *AccountSignalRepositoryImpl) SaveLinkedAccountFlag(ctx context.Context, flag *entities.LinkedAccountFlag) error {
+	model := &models.LinkedAccountFlag{
+		ID:           flag.ID,
+		SignalType:   flag.SignalType,
+		Value:        flag.Value,
+		Reason:       flag.Reason,
+		AccountCount: flag.AccountCount,
+	}
+
+	err := r.db.WithContext(ctx).Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "signal_type"}, {Name: "value"}},
+		DoUpdates: clause.AssignmentColumns([]string{"reason", "account_count", "updated_at"}),
+	}).Create(model).Error
+	if err != nil {
+		logger.Error("Failed to save linked account flag", err)
+		return fmt.Errorf("failed to save linked account flag: %w", err)
+	}
+
+	logger.Info("Linked accounts flagged for review", map[string]interface{}{
+		"signal_type":   flag.SignalType,
+		"reason":        flag.Reason,
+		"account_count": flag.AccountCount,
+	})
+	return nil
+}
+
+// ListLinkedAccountFlags retrieves flagged signals, most recently updated first
+func (r *AccountSignalRepositoryImpl) ListLinkedAccountFlags(ctx context.Context, limit, offset int) ([]*entities.LinkedAccountFlag, int64, error) {
+	var total int64
+	if err := r.db.WithContext(ctx).Model(&models.LinkedAccountFlag{}).Count(&total).Error; err != nil {
+		logger.Error("Failed to count linked account flags", err)
+		return nil, 0, fmt.Errorf("failed to count linked account flags: %w", err)
+	}
+
+	var flags []models.LinkedAccountFlag
+	err := r.db.WithContext(ctx).
+		Order("updated_at DESC").
+		Limit(limit).
+		Offset(offset).
+		Find(&flags).Error
+	if err != nil {
+		logger.Error("Failed to list linked account flags", err)
+		return nil, 0, fmt.Errorf("failed to list linked account flags: %w", err)
+	}
+
+	domainFlags := make([]*entities.LinkedAccountFlag, len(flags))
+	for i, flag := range flags {
+		domainFlags[i] = &entities.LinkedAccountFlag{
+			ID:           flag.ID,
+			SignalType:   flag.SignalType,
+			Value:        flag.Value,
+			Reason:       flag.Reason,
+			AccountCount: flag.AccountCount,
+			FlaggedAt:    flag.FlaggedAt,
+			UpdatedAt:    flag.UpdatedAt,
+		}
+	}
+
+	return domainFlags, total, nil
+}
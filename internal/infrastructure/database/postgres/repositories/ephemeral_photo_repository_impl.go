@@ -619,7 +619,7 @@ func (r *EphemeralPhotoRepositoryImpl) GetUserEphemeralPhotoStats(ctx context.Co
 // GetPhotosForCleanup retrieves photos for cleanup
 func (r *EphemeralPhotoRepositoryImpl) GetPhotosForCleanup(ctx context.Context, olderThan time.Time, limit int) ([]*entities.EphemeralPhoto, error) {
 	var photos []models.EphemeralPhoto
-	if err := r.db.WithContext(ctx).Where("(is_expired = ? OR is_viewed = ? OR expires_at < ?) AND updated_at < ?", true, true, time.Now(), olderThan).Limit(limit).Find(&photos).Error; err != nil {
+	if err := r.db.WithContext(ctx).Where("is_deleted = ? AND (is_expired = ? OR is_viewed = ? OR expires_at < ?) AND updated_at < ?", false, true, true, time.Now(), olderThan).Limit(limit).Find(&photos).Error; err != nil {
 		logger.Error("Failed to get photos for cleanup", err)
 		return nil, fmt.Errorf("failed to get photos for cleanup: %w", err)
 	}
@@ -0,0 +1,134 @@
+package repositories
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+	"github.com/22smeargle/winkr-backend/internal/domain/entities"
+	"github.com/22smeargle/winkr-backend/internal/domain/repositories"
+	"github.com/22smeargle/winkr-backend/internal/infrastructure/database/postgres/models"
+	"github.com/22smeargle/winkr-backend/pkg/logger"
+)
+
+// FavoriteRepositoryImpl implements FavoriteRepository interface using GORM
+type FavoriteRepositoryImpl struct {
+	db *gorm.DB
+}
+
+// NewFavoriteRepository creates a new FavoriteRepository instance
+func NewFavoriteRepository(db *gorm.DB) repositories.FavoriteRepository {
+	return &FavoriteRepositoryImpl{db: db}
+}
+
+// Create saves a favorite.
+// Favoriting a user that was already favorited is a no-op.
+func (r *FavoriteRepositoryImpl) Create(ctx context.Context, favorite *entities.Favorite) error {
+	model := r.domainToModel(favorite)
+	if err := r.db.WithContext(ctx).Clauses(clause.OnConflict{DoNothing: true}).Create(model).Error; err != nil {
+		logger.Error("Failed to create favorite", err)
+		return fmt.Errorf("failed to create favorite: %w", err)
+	}
+
+	favorite.ID = model.ID
+	favorite.CreatedAt = model.CreatedAt
+	return nil
+}
+
+// Delete removes a favorite
+func (r *FavoriteRepositoryImpl) Delete(ctx context.Context, userID, favoriteUserID uuid.UUID) error {
+	err := r.db.WithContext(ctx).
+		Where("user_id = ? AND favorite_user_id = ?", userID, favoriteUserID).
+		Delete(&models.Favorite{}).Error
+	if err != nil {
+		logger.Error("Failed to delete favorite", err)
+		return fmt.Errorf("failed to delete favorite: %w", err)
+	}
+
+	return nil
+}
+
+// Exists checks if a user has favorited another user
+func (r *FavoriteRepositoryImpl) Exists(ctx context.Context, userID, favoriteUserID uuid.UUID) (bool, error) {
+	var count int64
+	err := r.db.WithContext(ctx).Model(&models.Favorite{}).
+		Where("user_id = ? AND favorite_user_id = ?", userID, favoriteUserID).
+		Count(&count).Error
+	if err != nil {
+		logger.Error("Failed to check favorite", err)
+		return false, fmt.Errorf("failed to check favorite: %w", err)
+	}
+
+	return count > 0, nil
+}
+
+// GetByUserID retrieves a user's listed favorites, most recent first
+func (r *FavoriteRepositoryImpl) GetByUserID(ctx context.Context, userID uuid.UUID, limit, offset int) ([]*entities.Favorite, int64, error) {
+	var total int64
+	if err := r.listedFavorites(ctx, userID).Count(&total).Error; err != nil {
+		logger.Error("Failed to count favorites", err)
+		return nil, 0, fmt.Errorf("failed to count favorites: %w", err)
+	}
+
+	var favorites []models.Favorite
+	err := r.listedFavorites(ctx, userID).
+		Select("favorites.*").
+		Order("favorites.created_at DESC").
+		Limit(limit).
+		Offset(offset).
+		Find(&favorites).Error
+	if err != nil {
+		logger.Error("Failed to get favorites", err)
+		return nil, 0, fmt.Errorf("failed to get favorites: %w", err)
+	}
+
+	domainFavorites := make([]*entities.Favorite, len(favorites))
+	for i, favorite := range favorites {
+		domainFavorites[i] = r.modelToDomain(&favorite)
+	}
+
+	return domainFavorites, total, nil
+}
+
+// CountByUserID counts a user's listed favorites
+func (r *FavoriteRepositoryImpl) CountByUserID(ctx context.Context, userID uuid.UUID) (int64, error) {
+	var count int64
+	if err := r.listedFavorites(ctx, userID).Count(&count).Error; err != nil {
+		logger.Error("Failed to count favorites", err)
+		return 0, fmt.Errorf("failed to count favorites: %w", err)
+	}
+
+	return count, nil
+}
+
+// listedFavorites selects a user's favorites of users who can still be viewed.
+// Favorites of banned, deactivated or deleted users are kept, in case the account comes back,
+// but are not listed or counted.
+func (r *FavoriteRepositoryImpl) listedFavorites(ctx context.Context, userID uuid.UUID) *gorm.DB {
+	return r.db.WithContext(ctx).Model(&models.Favorite{}).
+		Joins("JOIN users u ON u.id = favorites.favorite_user_id").
+		Where("favorites.user_id = ?", userID).
+		Where("u.is_active = true AND u.is_banned = false AND u.deleted_at IS NULL")
+}
+
+// modelToDomain converts model Favorite to domain Favorite
+func (r *FavoriteRepositoryImpl) modelToDomain(model *models.Favorite) *entities.Favorite {
+	return &entities.Favorite{
+		ID:             model.ID,
+		UserID:         model.UserID,
+		FavoriteUserID: model.FavoriteUserID,
+		CreatedAt:      model.CreatedAt,
+	}
+}
+
+// domainToModel converts domain Favorite to model Favorite
+func (r *FavoriteRepositoryImpl) domainToModel(favorite *entities.Favorite) *models.Favorite {
+	return &models.Favorite{
+		ID:             favorite.ID,
+		UserID:         favorite.UserID,
+		FavoriteUserID: favorite.FavoriteUserID,
+		CreatedAt:      favorite.CreatedAt,
+	}
+}
@@ -291,6 +291,22 @@ func (r *MatchRepositoryImpl) GetUserSwipes(ctx context.Context, userID uuid.UUI
 	return domainSwipes, nil
 }
 
+// GetLastSwipe retrieves the most recent swipe made by a user, or nil if they have not swiped
+func (r *MatchRepositoryImpl) GetLastSwipe(ctx context.Context, userID uuid.UUID) (*entities.Swipe, error) {
+	var swipe models.Swipe
+	if err := r.db.WithContext(ctx).Where("swiper_id = ?", userID).Order("created_at DESC").First(&swipe).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, nil
+		}
+		logger.Error("Failed to get last swipe", err)
+		return nil, fmt.Errorf("failed to get last swipe: %w", err)
+	}
+
+	// Convert to domain entity
+	domainSwipe := r.modelToDomainSwipe(&swipe)
+	return domainSwipe, nil
+}
+
 // GetSwipeByUsers retrieves swipe from user to target
 func (r *MatchRepositoryImpl) GetSwipeByUsers(ctx context.Context, userID, targetID uuid.UUID) (*entities.Swipe, error) {
 	var swipe models.Swipe
@@ -318,6 +334,17 @@ func (r *MatchRepositoryImpl) ExistsSwipe(ctx context.Context, userID, targetID
 	return count > 0, nil
 }
 
+// GetSwipedTargetIDs retrieves the IDs of the users the user swiped on, liked or passed
+func (r *MatchRepositoryImpl) GetSwipedTargetIDs(ctx context.Context, userID uuid.UUID) ([]uuid.UUID, error) {
+	var ids []uuid.UUID
+	if err := r.db.WithContext(ctx).Model(&models.Swipe{}).Where("swiper_id = ?", userID).Pluck("swiped_id", &ids).Error; err != nil {
+		logger.Error("Failed to get swiped target IDs", err)
+		return nil, fmt.Errorf("failed to get swiped target IDs: %w", err)
+	}
+
+	return ids, nil
+}
+
 // GetUserSwipesByDirection retrieves swipes for a user by direction
 func (r *MatchRepositoryImpl) GetUserSwipesByDirection(ctx context.Context, userID uuid.UUID, direction string, limit, offset int) ([]*entities.Swipe, error) {
 	var swipes []models.Swipe
@@ -502,6 +529,49 @@ func (r *MatchRepositoryImpl) GetSwipeStats(ctx context.Context, userID uuid.UUI
 	return &stats, nil
 }
 
+// GetIncomingSwipeCounts counts the likes and passes other users gave the user since the given time
+func (r *MatchRepositoryImpl) GetIncomingSwipeCounts(ctx context.Context, userID uuid.UUID, since time.Time) (*repositories.IncomingSwipeCounts, error) {
+	var counts repositories.IncomingSwipeCounts
+
+	err := r.db.WithContext(ctx).Model(&models.Swipe{}).
+		Select("COUNT(*) FILTER (WHERE is_like) AS likes, COUNT(*) FILTER (WHERE NOT is_like) AS passes").
+		Where("swiped_id = ? AND created_at >= ?", userID, since).
+		Scan(&counts).Error
+	if err != nil {
+		logger.Error("Failed to count incoming swipes", err)
+		return nil, fmt.Errorf("failed to count incoming swipes: %w", err)
+	}
+
+	return &counts, nil
+}
+
+// GetCohortLikeRate returns the average share of incoming swipes that were likes, in percent, across
+// the profiles in the cohort. Profiles with fewer than minSwipes incoming swipes are left out.
+func (r *MatchRepositoryImpl) GetCohortLikeRate(ctx context.Context, cohort repositories.SwipeCohort, since time.Time, minSwipes int) (float64, error) {
+	var rate float64
+
+	query := `
+		SELECT COALESCE(AVG(profile_rate), 0) * 100
+		FROM (
+			SELECT AVG(CASE WHEN s.is_like THEN 1.0 ELSE 0.0 END) AS profile_rate
+			FROM swipes s
+			JOIN users u ON u.id = s.swiped_id
+			WHERE u.gender = ? AND u.date_of_birth > ? AND u.date_of_birth <= ?
+				AND u.is_active = true AND u.is_banned = false
+				AND s.created_at >= ?
+			GROUP BY s.swiped_id
+			HAVING COUNT(*) >= ?
+		) AS cohort
+	`
+
+	if err := r.db.WithContext(ctx).Raw(query, cohort.Gender, cohort.BornAfter, cohort.BornBefore, since, minSwipes).Scan(&rate).Error; err != nil {
+		logger.Error("Failed to get cohort like rate", err)
+		return 0, fmt.Errorf("failed to get cohort like rate: %w", err)
+	}
+
+	return rate, nil
+}
+
 // Helper methods to convert between domain and model entities
 
 // modelToDomainMatch converts model Match to domain Match
@@ -800,12 +870,48 @@ func (r *MatchRepositoryImpl) GetUsersWhoLikedUser(ctx context.Context, userID u
 	return domainUsers, nil
 }
 
+// GetIncomingLikes retrieves the likes a user received from people they have not swiped on or matched with yet
+func (r *MatchRepositoryImpl) GetIncomingLikes(ctx context.Context, userID uuid.UUID, limit, offset int) ([]*entities.Swipe, error) {
+	var swipes []models.Swipe
+	query := `
+		SELECT s.* FROM swipes s
+		WHERE s.swiped_id = ? AND s.is_like = true
+		AND NOT EXISTS (
+			SELECT 1 FROM swipes own WHERE own.swiper_id = ? AND own.swiped_id = s.swiper_id
+		)
+		AND NOT EXISTS (
+			SELECT 1 FROM matches m
+			WHERE (m.user1_id = s.swiper_id AND m.user2_id = ?) OR (m.user1_id = ? AND m.user2_id = s.swiper_id)
+		)
+		AND NOT EXISTS (
+			SELECT 1 FROM users u WHERE u.id = s.swiper_id AND u.shadowbanned = true
+		)
+		ORDER BY s.created_at DESC
+		LIMIT ? OFFSET ?
+	`
+
+	if err := r.db.WithContext(ctx).Raw(query, userID, userID, userID, userID, limit, offset).Scan(&swipes).Error; err != nil {
+		logger.Error("Failed to get incoming likes", err)
+		return nil, fmt.Errorf("failed to get incoming likes: %w", err)
+	}
+
+	// Convert to domain entities
+	domainSwipes := make([]*entities.Swipe, len(swipes))
+	for i, swipe := range swipes {
+		domainSwipes[i] = r.modelToDomainSwipe(&swipe)
+	}
+
+	return domainSwipes, nil
+}
+
 // GetPotentialMatches retrieves potential matches for a user
 func (r *MatchRepositoryImpl) GetPotentialMatches(ctx context.Context, userID uuid.UUID, limit, offset int) ([]*entities.User, error) {
 	var users []models.User
 	query := `
 		SELECT u.* FROM users u
 		WHERE u.id != ?
+		AND u.profile_under_review = false
+		AND u.shadowbanned = false
 		AND u.id NOT IN (
 			SELECT swiped_id FROM swipes WHERE swiper_id = ?
 		)
@@ -839,6 +945,8 @@ func (r *MatchRepositoryImpl) GetPotentialMatchesExcluding(ctx context.Context,
 	query := `
 		SELECT u.* FROM users u
 		WHERE u.id != ?
+		AND u.profile_under_review = false
+		AND u.shadowbanned = false
 		AND u.id NOT IN (?)
 		AND u.id NOT IN (
 			SELECT swiped_id FROM swipes WHERE swiper_id = ?
@@ -873,6 +981,8 @@ func (r *MatchRepositoryImpl) GetPotentialMatchesByPreferences(ctx context.Conte
 	query := `
 		SELECT u.* FROM users u
 		WHERE u.id != ?
+		AND u.profile_under_review = false
+		AND u.shadowbanned = false
 		AND u.age BETWEEN ? AND ?
 		AND u.id NOT IN (
 			SELECT swiped_id FROM swipes WHERE swiper_id = ?
@@ -1139,15 +1249,26 @@ func (r *MatchRepositoryImpl) modelToDomainUser(model *models.User) *entities.Us
 		DateOfBirth:     model.DateOfBirth,
 		Gender:          model.Gender,
 		InterestedIn:    model.InterestedIn,
+		Interests:       model.Interests,
 		Bio:             model.Bio,
 		LocationLat:     model.LocationLat,
 		LocationLng:     model.LocationLng,
 		LocationCity:    model.LocationCity,
 		LocationCountry: model.LocationCountry,
+		HeightCm:        model.HeightCm,
+		Smoking:         model.Smoking,
+		Drinking:        model.Drinking,
+		Locale:          model.Locale,
+		Timezone:        model.Timezone,
+		AutoTranslateMessages: model.AutoTranslateMessages,
+		SendReadReceipts: model.SendReadReceipts,
+		AutoIntro:       model.AutoIntro,
 		IsVerified:      model.IsVerified,
 		IsPremium:       model.IsPremium,
 		IsActive:        model.IsActive,
 		IsBanned:        model.IsBanned,
+		ProfileUnderReview: model.ProfileUnderReview,
+		Shadowbanned:    model.Shadowbanned,
 		LastActive:      model.LastActive,
 		CreatedAt:       model.CreatedAt,
 		UpdatedAt:       model.UpdatedAt,
@@ -1174,6 +1295,8 @@ func (r *MatchRepositoryImpl) modelToDomainUserPreferences(model *models.UserPre
 		AgeMax:           model.AgeMax,
 		MaxDistance:      model.MaxDistance,
 		ShowMe:           model.ShowMe,
+		HiddenFields:     model.HiddenFields,
+		AutoReorderPhotos: model.AutoReorderPhotos,
 		CreatedAt:        model.CreatedAt,
 		UpdatedAt:        model.UpdatedAt,
 	}
@@ -1187,6 +1310,8 @@ func (r *MatchRepositoryImpl) domainToModelUserPreferences(preferences *entities
 		AgeMax:      preferences.AgeMax,
 		MaxDistance: preferences.MaxDistance,
 		ShowMe:      preferences.ShowMe,
+		HiddenFields: preferences.HiddenFields,
+		AutoReorderPhotos: preferences.AutoReorderPhotos,
 		CreatedAt:   preferences.CreatedAt,
 		UpdatedAt:   preferences.UpdatedAt,
 	}
@@ -7,6 +7,7 @@ import (
 
 	"github.com/google/uuid"
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 	"github.com/22smeargle/winkr-backend/internal/domain/entities"
 	"github.com/22smeargle/winkr-backend/internal/domain/repositories"
 	"github.com/22smeargle/winkr-backend/internal/infrastructure/database/postgres/models"
@@ -55,6 +56,23 @@ func (r *MessageRepositoryImpl) GetByID(ctx context.Context, id uuid.UUID) (*ent
 	return domainMessage, nil
 }
 
+// GetBySenderAndClientMessageID retrieves the message a sender created with a client message ID,
+// returning nil if there is none
+func (r *MessageRepositoryImpl) GetBySenderAndClientMessageID(ctx context.Context, senderID uuid.UUID, clientMessageID string) (*entities.Message, error) {
+	var message models.Message
+	if err := r.db.WithContext(ctx).Where("sender_id = ? AND client_message_id = ?", senderID, clientMessageID).First(&message).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, nil
+		}
+		logger.Error("Failed to get message by client message ID", err)
+		return nil, fmt.Errorf("failed to get message by client message ID: %w", err)
+	}
+
+	// Convert to domain entity
+	domainMessage := r.modelToDomainMessage(&message)
+	return domainMessage, nil
+}
+
 // Update updates a message
 func (r *MessageRepositoryImpl) Update(ctx context.Context, message *entities.Message) error {
 	modelMessage := r.domainToModelMessage(message)
@@ -117,6 +135,28 @@ func (r *MessageRepositoryImpl) GetConversationMessagesAfter(ctx context.Context
 	return domainMessages, nil
 }
 
+// GetMessagesAfterCursor retrieves up to limit messages sent after the cursor message, oldest first.
+// Messages with the same timestamp are ordered by ID so paging through them never skips one.
+func (r *MessageRepositoryImpl) GetMessagesAfterCursor(ctx context.Context, conversationID uuid.UUID, cursor *entities.Message, limit int) ([]*entities.Message, error) {
+	var messages []models.Message
+	if err := r.db.WithContext(ctx).
+		Where("conversation_id = ? AND (created_at, id) > (?, ?)", conversationID, cursor.CreatedAt, cursor.ID).
+		Order("created_at ASC, id ASC").
+		Limit(limit).
+		Find(&messages).Error; err != nil {
+		logger.Error("Failed to get conversation messages after cursor", err)
+		return nil, fmt.Errorf("failed to get conversation messages after cursor: %w", err)
+	}
+
+	// Convert to domain entities
+	domainMessages := make([]*entities.Message, len(messages))
+	for i, message := range messages {
+		domainMessages[i] = r.modelToDomainMessage(&message)
+	}
+
+	return domainMessages, nil
+}
+
 // GetConversationMessagesBefore retrieves messages before a specific timestamp
 func (r *MessageRepositoryImpl) GetConversationMessagesBefore(ctx context.Context, conversationID uuid.UUID, before time.Time, limit int) ([]*entities.Message, error) {
 	var messages []models.Message
@@ -185,6 +225,306 @@ func (r *MessageRepositoryImpl) MarkConversationAsRead(ctx context.Context, conv
 	return nil
 }
 
+// EditMessage replaces a message's content and its encrypted original, nil when the new content
+// had no PII redacted, and records when it was edited
+func (r *MessageRepositoryImpl) EditMessage(ctx context.Context, messageID uuid.UUID, content string, encryptedOriginal *string, editedAt time.Time) error {
+	if err := r.db.WithContext(ctx).Model(&models.Message{}).Where("id = ?", messageID).Updates(map[string]interface{}{
+		"content":            content,
+		"encrypted_original": encryptedOriginal,
+		"edited_at":          editedAt,
+	}).Error; err != nil {
+		logger.Error("Failed to edit message", err)
+		return fmt.Errorf("failed to edit message: %w", err)
+	}
+
+	logger.Info("Message edited", map[string]interface{}{
+		"message_id": messageID,
+	})
+	return nil
+}
+
+// SoftDeleteMessage marks a message as deleted, keeping it as a tombstone in the conversation
+func (r *MessageRepositoryImpl) SoftDeleteMessage(ctx context.Context, messageID uuid.UUID) error {
+	if err := r.db.WithContext(ctx).Model(&models.Message{}).Where("id = ?", messageID).Updates(map[string]interface{}{
+		"is_deleted": true,
+		"deleted_at": time.Now(),
+	}).Error; err != nil {
+		logger.Error("Failed to soft delete message", err)
+		return fmt.Errorf("failed to soft delete message: %w", err)
+	}
+
+	logger.Info("Message soft deleted", map[string]interface{}{
+		"message_id": messageID,
+	})
+	return nil
+}
+
+// PinMessage marks a message as pinned by the given user
+func (r *MessageRepositoryImpl) PinMessage(ctx context.Context, messageID, userID uuid.UUID) error {
+	if err := r.db.WithContext(ctx).Model(&models.Message{}).Where("id = ?", messageID).Updates(map[string]interface{}{
+		"is_pinned": true,
+		"pinned_at": time.Now(),
+		"pinned_by": userID,
+	}).Error; err != nil {
+		logger.Error("Failed to pin message", err)
+		return fmt.Errorf("failed to pin message: %w", err)
+	}
+
+	logger.Info("Message pinned", map[string]interface{}{
+		"message_id": messageID,
+		"user_id": userID,
+	})
+	return nil
+}
+
+// UnpinMessage removes the pin from a message
+func (r *MessageRepositoryImpl) UnpinMessage(ctx context.Context, messageID uuid.UUID) error {
+	if err := r.db.WithContext(ctx).Model(&models.Message{}).Where("id = ?", messageID).Updates(map[string]interface{}{
+		"is_pinned": false,
+		"pinned_at": nil,
+		"pinned_by": nil,
+	}).Error; err != nil {
+		logger.Error("Failed to unpin message", err)
+		return fmt.Errorf("failed to unpin message: %w", err)
+	}
+
+	logger.Info("Message unpinned", map[string]interface{}{
+		"message_id": messageID,
+	})
+	return nil
+}
+
+// GetPinnedMessages retrieves the pinned messages of a conversation, most recently pinned first
+func (r *MessageRepositoryImpl) GetPinnedMessages(ctx context.Context, conversationID uuid.UUID) ([]*entities.Message, error) {
+	var messages []models.Message
+	if err := r.db.WithContext(ctx).
+		Where("conversation_id = ? AND is_pinned = ? AND is_deleted = ?", conversationID, true, false).
+		Order("pinned_at DESC").
+		Find(&messages).Error; err != nil {
+		logger.Error("Failed to get pinned messages", err)
+		return nil, fmt.Errorf("failed to get pinned messages: %w", err)
+	}
+
+	domainMessages := make([]*entities.Message, len(messages))
+	for i, message := range messages {
+		domainMessages[i] = r.modelToDomainMessage(&message)
+	}
+
+	return domainMessages, nil
+}
+
+// CountPinnedMessages counts the pinned messages of a conversation
+func (r *MessageRepositoryImpl) CountPinnedMessages(ctx context.Context, conversationID uuid.UUID) (int64, error) {
+	var count int64
+	if err := r.db.WithContext(ctx).Model(&models.Message{}).
+		Where("conversation_id = ? AND is_pinned = ? AND is_deleted = ?", conversationID, true, false).
+		Count(&count).Error; err != nil {
+		logger.Error("Failed to count pinned messages", err)
+		return 0, fmt.Errorf("failed to count pinned messages: %w", err)
+	}
+
+	return count, nil
+}
+
+// CountOpenConversations counts the conversations the user takes part in that are neither closed
+// nor archived by the user, leaving out excludeConversationID when it is set
+func (r *MessageRepositoryImpl) CountOpenConversations(ctx context.Context, userID, excludeConversationID uuid.UUID) (int64, error) {
+	query := r.db.WithContext(ctx).Model(&models.Conversation{}).
+		Joins("JOIN matches ON matches.id = conversations.match_id").
+		Where("(matches.user1_id = ? OR matches.user2_id = ?) AND conversations.closed_at IS NULL", userID, userID).
+		Where("NOT EXISTS (SELECT 1 FROM conversation_archives WHERE conversation_archives.conversation_id = conversations.id AND conversation_archives.user_id = ?)", userID)
+	if excludeConversationID != uuid.Nil {
+		query = query.Where("conversations.id <> ?", excludeConversationID)
+	}
+
+	var count int64
+	if err := query.Count(&count).Error; err != nil {
+		logger.Error("Failed to count open conversations", err)
+		return 0, fmt.Errorf("failed to count open conversations: %w", err)
+	}
+
+	return count, nil
+}
+
+// HasSentMessages checks if the user has sent any message in a conversation
+func (r *MessageRepositoryImpl) HasSentMessages(ctx context.Context, conversationID, userID uuid.UUID) (bool, error) {
+	var count int64
+	if err := r.db.WithContext(ctx).Model(&models.Message{}).
+		Where("conversation_id = ? AND sender_id = ?", conversationID, userID).
+		Limit(1).
+		Count(&count).Error; err != nil {
+		logger.Error("Failed to check sent messages", err)
+		return false, fmt.Errorf("failed to check sent messages: %w", err)
+	}
+
+	return count > 0, nil
+}
+
+// ArchiveConversation archives a conversation for one participant. Archiving twice is a no-op.
+func (r *MessageRepositoryImpl) ArchiveConversation(ctx context.Context, conversationID, userID uuid.UUID) error {
+	archive := &models.ConversationArchive{
+		ConversationID: conversationID,
+		UserID:         userID,
+	}
+	if err := r.db.WithContext(ctx).Clauses(clause.OnConflict{DoNothing: true}).Create(archive).Error; err != nil {
+		logger.Error("Failed to archive conversation", err)
+		return fmt.Errorf("failed to archive conversation: %w", err)
+	}
+
+	logger.Info("Conversation archived", map[string]interface{}{
+		"conversation_id": conversationID,
+		"user_id":         userID,
+	})
+	return nil
+}
+
+// UnarchiveConversation moves a conversation out of a participant's archive
+func (r *MessageRepositoryImpl) UnarchiveConversation(ctx context.Context, conversationID, userID uuid.UUID) error {
+	if err := r.db.WithContext(ctx).
+		Where("conversation_id = ? AND user_id = ?", conversationID, userID).
+		Delete(&models.ConversationArchive{}).Error; err != nil {
+		logger.Error("Failed to unarchive conversation", err)
+		return fmt.Errorf("failed to unarchive conversation: %w", err)
+	}
+
+	logger.Info("Conversation unarchived", map[string]interface{}{
+		"conversation_id": conversationID,
+		"user_id":         userID,
+	})
+	return nil
+}
+
+// IsConversationArchived checks if a participant has archived a conversation
+func (r *MessageRepositoryImpl) IsConversationArchived(ctx context.Context, conversationID, userID uuid.UUID) (bool, error) {
+	var count int64
+	if err := r.db.WithContext(ctx).Model(&models.ConversationArchive{}).
+		Where("conversation_id = ? AND user_id = ?", conversationID, userID).
+		Count(&count).Error; err != nil {
+		logger.Error("Failed to check conversation archive", err)
+		return false, fmt.Errorf("failed to check conversation archive: %w", err)
+	}
+
+	return count > 0, nil
+}
+
+// AddReaction records a user's emoji reaction to a message.
+// Adding a reaction the user already made is a no-op.
+func (r *MessageRepositoryImpl) AddReaction(ctx context.Context, reaction *entities.MessageReaction) error {
+	modelReaction := &models.MessageReaction{
+		ID:        reaction.ID,
+		MessageID: reaction.MessageID,
+		UserID:    reaction.UserID,
+		Emoji:     reaction.Emoji,
+		CreatedAt: reaction.CreatedAt,
+	}
+	if err := r.db.WithContext(ctx).Clauses(clause.OnConflict{DoNothing: true}).Create(modelReaction).Error; err != nil {
+		logger.Error("Failed to add message reaction", err)
+		return fmt.Errorf("failed to add message reaction: %w", err)
+	}
+
+	return nil
+}
+
+// RemoveReaction removes a user's emoji reaction to a message, reporting whether there was one
+func (r *MessageRepositoryImpl) RemoveReaction(ctx context.Context, messageID, userID uuid.UUID, emoji string) (bool, error) {
+	result := r.db.WithContext(ctx).
+		Where("message_id = ? AND user_id = ? AND emoji = ?", messageID, userID, emoji).
+		Delete(&models.MessageReaction{})
+	if result.Error != nil {
+		logger.Error("Failed to remove message reaction", result.Error)
+		return false, fmt.Errorf("failed to remove message reaction: %w", result.Error)
+	}
+
+	return result.RowsAffected > 0, nil
+}
+
+// GetReactionCounts counts the reactions to the given messages by emoji. Messages without
+// reactions are left out.
+func (r *MessageRepositoryImpl) GetReactionCounts(ctx context.Context, messageIDs []uuid.UUID) (map[uuid.UUID]map[string]int, error) {
+	counts := make(map[uuid.UUID]map[string]int)
+	if len(messageIDs) == 0 {
+		return counts, nil
+	}
+
+	var rows []struct {
+		MessageID uuid.UUID
+		Emoji     string
+		Count     int
+	}
+	if err := r.db.WithContext(ctx).Model(&models.MessageReaction{}).
+		Select("message_id, emoji, COUNT(*) AS count").
+		Where("message_id IN ?", messageIDs).
+		Group("message_id, emoji").
+		Scan(&rows).Error; err != nil {
+		logger.Error("Failed to get message reaction counts", err)
+		return nil, fmt.Errorf("failed to get message reaction counts: %w", err)
+	}
+
+	for _, row := range rows {
+		if counts[row.MessageID] == nil {
+			counts[row.MessageID] = make(map[string]int)
+		}
+		counts[row.MessageID][row.Emoji] = row.Count
+	}
+
+	return counts, nil
+}
+
+// CreateMessageStatus records a delivery status transition.
+// Recording a transition that already exists for the message is a no-op.
+func (r *MessageRepositoryImpl) CreateMessageStatus(ctx context.Context, status *entities.MessageStatus) error {
+	modelStatus := r.domainToModelMessageStatus(status)
+	if err := r.db.WithContext(ctx).Clauses(clause.OnConflict{DoNothing: true}).Create(modelStatus).Error; err != nil {
+		logger.Error("Failed to create message status", err)
+		return fmt.Errorf("failed to create message status: %w", err)
+	}
+
+	return nil
+}
+
+// GetMessageStatuses retrieves the delivery status transitions of the given messages, oldest first
+func (r *MessageRepositoryImpl) GetMessageStatuses(ctx context.Context, messageIDs []uuid.UUID) ([]*entities.MessageStatus, error) {
+	if len(messageIDs) == 0 {
+		return []*entities.MessageStatus{}, nil
+	}
+
+	var statuses []models.MessageStatus
+	if err := r.db.WithContext(ctx).
+		Where("message_id IN ?", messageIDs).
+		Order("created_at ASC").
+		Find(&statuses).Error; err != nil {
+		logger.Error("Failed to get message statuses", err)
+		return nil, fmt.Errorf("failed to get message statuses: %w", err)
+	}
+
+	domainStatuses := make([]*entities.MessageStatus, len(statuses))
+	for i, status := range statuses {
+		domainStatuses[i] = r.modelToDomainMessageStatus(&status)
+	}
+
+	return domainStatuses, nil
+}
+
+// GetConversationStatusesSince retrieves the delivery status transitions recorded in a conversation after a time, oldest first
+func (r *MessageRepositoryImpl) GetConversationStatusesSince(ctx context.Context, conversationID uuid.UUID, since time.Time) ([]*entities.MessageStatus, error) {
+	var statuses []models.MessageStatus
+	if err := r.db.WithContext(ctx).
+		Joins("JOIN messages ON messages.id = message_status.message_id").
+		Where("messages.conversation_id = ? AND message_status.created_at > ?", conversationID, since).
+		Order("message_status.created_at ASC").
+		Find(&statuses).Error; err != nil {
+		logger.Error("Failed to get conversation message statuses", err)
+		return nil, fmt.Errorf("failed to get conversation message statuses: %w", err)
+	}
+
+	domainStatuses := make([]*entities.MessageStatus, len(statuses))
+	for i, status := range statuses {
+		domainStatuses[i] = r.modelToDomainMessageStatus(&status)
+	}
+
+	return domainStatuses, nil
+}
+
 // GetLastMessage retrieves the last message in a conversation
 func (r *MessageRepositoryImpl) GetLastMessage(ctx context.Context, conversationID uuid.UUID) (*entities.Message, error) {
 	var message models.Message
@@ -281,6 +621,23 @@ func (r *MessageRepositoryImpl) BatchUpdate(ctx context.Context, messages []*ent
 	return nil
 }
 
+// BatchMarkAsRead marks multiple messages as read
+func (r *MessageRepositoryImpl) BatchMarkAsRead(ctx context.Context, messageIDs []uuid.UUID) error {
+	if len(messageIDs) == 0 {
+		return nil
+	}
+
+	if err := r.db.WithContext(ctx).Model(&models.Message{}).Where("id IN ?", messageIDs).Update("is_read", true).Error; err != nil {
+		logger.Error("Failed to batch mark messages as read", err)
+		return fmt.Errorf("failed to batch mark messages as read: %w", err)
+	}
+
+	logger.Info("Messages batch marked as read", map[string]interface{}{
+		"count": len(messageIDs),
+	})
+	return nil
+}
+
 // BatchDelete soft deletes multiple messages
 func (r *MessageRepositoryImpl) BatchDelete(ctx context.Context, messageIDs []uuid.UUID) error {
 	if err := r.db.WithContext(ctx).Where("id IN ?", messageIDs).Delete(&models.Message{}).Error; err != nil {
@@ -555,6 +912,14 @@ func (r *MessageRepositoryImpl) modelToDomainMessage(model *models.Message) *ent
 		MessageType:    model.MessageType,
 		AttachmentURL:  model.AttachmentURL,
 		IsRead:         model.IsRead,
+		IsDeleted:      model.IsDeleted,
+		IsPinned:       model.IsPinned,
+		PinnedAt:       model.PinnedAt,
+		PinnedBy:       model.PinnedBy,
+		ClientMessageID: model.ClientMessageID,
+		EncryptedOriginal: model.EncryptedOriginal,
+		EditedAt:       model.EditedAt,
+		DeletedAt:      model.DeletedAt,
 		CreatedAt:      model.CreatedAt,
 		UpdatedAt:      model.UpdatedAt,
 	}
@@ -570,11 +935,41 @@ func (r *MessageRepositoryImpl) domainToModelMessage(message *entities.Message)
 		MessageType:    message.MessageType,
 		AttachmentURL:  message.AttachmentURL,
 		IsRead:         message.IsRead,
+		IsDeleted:      message.IsDeleted,
+		IsPinned:       message.IsPinned,
+		PinnedAt:       message.PinnedAt,
+		PinnedBy:       message.PinnedBy,
+		ClientMessageID: message.ClientMessageID,
+		EncryptedOriginal: message.EncryptedOriginal,
+		EditedAt:       message.EditedAt,
+		DeletedAt:      message.DeletedAt,
 		CreatedAt:      message.CreatedAt,
 		UpdatedAt:      message.UpdatedAt,
 	}
 }
 
+// modelToDomainMessageStatus converts model MessageStatus to domain MessageStatus
+func (r *MessageRepositoryImpl) modelToDomainMessageStatus(model *models.MessageStatus) *entities.MessageStatus {
+	return &entities.MessageStatus{
+		ID:        model.ID,
+		MessageID: model.MessageID,
+		UserID:    model.UserID,
+		Status:    model.Status,
+		CreatedAt: model.CreatedAt,
+	}
+}
+
+// domainToModelMessageStatus converts domain MessageStatus to model MessageStatus
+func (r *MessageRepositoryImpl) domainToModelMessageStatus(status *entities.MessageStatus) *models.MessageStatus {
+	return &models.MessageStatus{
+		ID:        status.ID,
+		MessageID: status.MessageID,
+		UserID:    status.UserID,
+		Status:    status.Status,
+		CreatedAt: status.CreatedAt,
+	}
+}
+
 // modelToDomainConversation converts model Conversation to domain Conversation
 func (r *MessageRepositoryImpl) modelToDomainConversation(model *models.Conversation) *entities.Conversation {
 	return &entities.Conversation{
@@ -583,6 +978,12 @@ func (r *MessageRepositoryImpl) modelToDomainConversation(model *models.Conversa
 		User2ID:       model.User2ID,
 		MatchID:       model.MatchID,
 		LastMessageID: model.LastMessageID,
+		ClosedAt:      model.ClosedAt,
+		StreakCount:           model.StreakCount,
+		StreakLastDay:         model.StreakLastDay,
+		StreakPendingDay:      model.StreakPendingDay,
+		StreakPendingSenderID: model.StreakPendingSenderID,
+		AnniversaryMonths:     model.AnniversaryMonths,
 		CreatedAt:     model.CreatedAt,
 		UpdatedAt:     model.UpdatedAt,
 	}
@@ -596,6 +997,12 @@ func (r *MessageRepositoryImpl) domainToModelConversation(conversation *entities
 		User2ID:       conversation.User2ID,
 		MatchID:       conversation.MatchID,
 		LastMessageID: conversation.LastMessageID,
+		ClosedAt:      conversation.ClosedAt,
+		StreakCount:           conversation.StreakCount,
+		StreakLastDay:         conversation.StreakLastDay,
+		StreakPendingDay:      conversation.StreakPendingDay,
+		StreakPendingSenderID: conversation.StreakPendingSenderID,
+		AnniversaryMonths:     conversation.AnniversaryMonths,
 		CreatedAt:     conversation.CreatedAt,
 		UpdatedAt:     conversation.UpdatedAt,
 	}
@@ -13,6 +13,7 @@ import (
 // MockVerificationRepository is a mock implementation of VerificationRepository for testing
 type MockVerificationRepository struct {
 	verifications        map[uuid.UUID]*entities.Verification
+	documents           map[uuid.UUID][]*entities.VerificationDocument
 	attempts            map[uuid.UUID][]*entities.VerificationAttempt
 	badges              map[uuid.UUID][]*entities.VerificationBadge
 	adminUsers          map[uuid.UUID]*entities.AdminUser
@@ -28,6 +29,9 @@ type MockVerificationRepository struct {
 	CalledDeleteVerification            bool
 	CalledGetVerificationsForReview    bool
 	CalledGetVerificationStats          bool
+	CalledCreateVerificationDocument    bool
+	CalledGetVerificationDocuments      bool
+	CalledUpdateVerificationDocument    bool
 	CalledCreateVerificationAttempt     bool
 	CalledGetVerificationAttemptsByUser bool
 	CalledGetVerificationAttemptsByIP   bool
@@ -38,6 +42,7 @@ type MockVerificationRepository struct {
 	CalledUpdateBadge                 bool
 	CalledRevokeBadge                 bool
 	CalledDeleteExpiredBadges          bool
+	CalledGetUsersWithExpiredBadges    bool
 	CalledGetUserVerificationLevel     bool
 	CalledUpdateUserVerificationLevel   bool
 	CalledGetUsersByVerificationLevel   bool
@@ -53,6 +58,7 @@ type MockVerificationRepository struct {
 func NewMockVerificationRepository() *MockVerificationRepository {
 	return &MockVerificationRepository{
 		verifications: make(map[uuid.UUID]*entities.Verification),
+		documents:      make(map[uuid.UUID][]*entities.VerificationDocument),
 		attempts:       make(map[uuid.UUID][]*entities.VerificationAttempt),
 		badges:         make(map[uuid.UUID][]*entities.VerificationBadge),
 		adminUsers:     make(map[uuid.UUID]*entities.AdminUser),
@@ -176,6 +182,31 @@ func (m *MockVerificationRepository) GetVerificationStats(ctx context.Context) (
 	return stats, nil
 }
 
+// CreateVerificationDocument creates a new verification document
+func (m *MockVerificationRepository) CreateVerificationDocument(ctx context.Context, document *entities.VerificationDocument) error {
+	m.CalledCreateVerificationDocument = true
+	m.documents[document.VerificationID] = append(m.documents[document.VerificationID], document)
+	return nil
+}
+
+// GetVerificationDocuments gets all documents submitted for a verification session
+func (m *MockVerificationRepository) GetVerificationDocuments(ctx context.Context, verificationID uuid.UUID) ([]*entities.VerificationDocument, error) {
+	m.CalledGetVerificationDocuments = true
+	return m.documents[verificationID], nil
+}
+
+// UpdateVerificationDocument updates a verification document
+func (m *MockVerificationRepository) UpdateVerificationDocument(ctx context.Context, document *entities.VerificationDocument) error {
+	m.CalledUpdateVerificationDocument = true
+	for i, existing := range m.documents[document.VerificationID] {
+		if existing.ID == document.ID {
+			m.documents[document.VerificationID][i] = document
+			return nil
+		}
+	}
+	return nil
+}
+
 // CreateVerificationAttempt creates a new verification attempt
 func (m *MockVerificationRepository) CreateVerificationAttempt(ctx context.Context, attempt *entities.VerificationAttempt) error {
 	m.CalledCreateVerificationAttempt = true
@@ -315,6 +346,21 @@ func (m *MockVerificationRepository) DeleteExpiredBadges(ctx context.Context) er
 	return nil
 }
 
+// GetUsersWithExpiredBadges gets the users with a badge that expired before the given time
+func (m *MockVerificationRepository) GetUsersWithExpiredBadges(ctx context.Context, before time.Time) ([]uuid.UUID, error) {
+	m.CalledGetUsersWithExpiredBadges = true
+	var userIDs []uuid.UUID
+	for userID, badges := range m.badges {
+		for _, badge := range badges {
+			if !badge.IsRevoked && badge.ExpiresAt != nil && badge.ExpiresAt.Before(before) {
+				userIDs = append(userIDs, userID)
+				break
+			}
+		}
+	}
+	return userIDs, nil
+}
+
 // GetUserVerificationLevel gets a user's verification level
 func (m *MockVerificationRepository) GetUserVerificationLevel(ctx context.Context, userID uuid.UUID) (entities.VerificationLevel, error) {
 	m.CalledGetUserVerificationLevel = true
@@ -407,6 +453,7 @@ func (m *MockVerificationRepository) GetActiveAdminUsers(ctx context.Context) ([
 func (m *MockVerificationRepository) Reset() {
 	m.verifications = make(map[uuid.UUID]*entities.Verification)
 	m.attempts = make(map[uuid.UUID][]*entities.VerificationAttempt)
+	m.documents = make(map[uuid.UUID][]*entities.VerificationDocument)
 	m.badges = make(map[uuid.UUID][]*entities.VerificationBadge)
 	m.adminUsers = make(map[uuid.UUID]*entities.AdminUser)
 	m.users = make(map[uuid.UUID]*entities.User)
@@ -420,6 +467,9 @@ func (m *MockVerificationRepository) Reset() {
 	m.CalledDeleteVerification = false
 	m.CalledGetVerificationsForReview = false
 	m.CalledGetVerificationStats = false
+	m.CalledCreateVerificationDocument = false
+	m.CalledGetVerificationDocuments = false
+	m.CalledUpdateVerificationDocument = false
 	m.CalledCreateVerificationAttempt = false
 	m.CalledGetVerificationAttemptsByUser = false
 	m.CalledGetVerificationAttemptsByIP = false
@@ -430,6 +480,7 @@ func (m *MockVerificationRepository) Reset() {
 	m.CalledUpdateBadge = false
 	m.CalledRevokeBadge = false
 	m.CalledDeleteExpiredBadges = false
+	m.CalledGetUsersWithExpiredBadges = false
 	m.CalledGetUserVerificationLevel = false
 	m.CalledUpdateUserVerificationLevel = false
 	m.CalledGetUsersByVerificationLevel = false
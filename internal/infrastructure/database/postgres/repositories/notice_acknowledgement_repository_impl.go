@@ -0,0 +1,79 @@
+package repositories
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+	"github.com/22smeargle/winkr-backend/internal/domain/entities"
+	"github.com/22smeargle/winkr-backend/internal/domain/repositories"
+	"github.com/22smeargle/winkr-backend/internal/infrastructure/database/postgres/models"
+	"github.com/22smeargle/winkr-backend/pkg/logger"
+)
+
+// NoticeAcknowledgementRepositoryImpl implements NoticeAcknowledgementRepository interface using GORM
+type NoticeAcknowledgementRepositoryImpl struct {
+	db *gorm.DB
+}
+
+// NewNoticeAcknowledgementRepository creates a new NoticeAcknowledgementRepository instance
+func NewNoticeAcknowledgementRepository(db *gorm.DB) repositories.NoticeAcknowledgementRepository {
+	return &NoticeAcknowledgementRepositoryImpl{db: db}
+}
+
+// Create records an acknowledgement.
+// Acknowledging a notice version that was already acknowledged is a no-op.
+func (r *NoticeAcknowledgementRepositoryImpl) Create(ctx context.Context, acknowledgement *entities.NoticeAcknowledgement) error {
+	model := r.domainToModel(acknowledgement)
+	if err := r.db.WithContext(ctx).Clauses(clause.OnConflict{DoNothing: true}).Create(model).Error; err != nil {
+		logger.Error("Failed to create notice acknowledgement", err)
+		return fmt.Errorf("failed to create notice acknowledgement: %w", err)
+	}
+
+	logger.Info("Notice acknowledged", map[string]interface{}{
+		"user_id":   acknowledgement.UserID,
+		"notice_id": acknowledgement.NoticeID,
+		"version":   acknowledgement.Version,
+	})
+	return nil
+}
+
+// GetByUserID retrieves all acknowledgements of a user
+func (r *NoticeAcknowledgementRepositoryImpl) GetByUserID(ctx context.Context, userID uuid.UUID) ([]*entities.NoticeAcknowledgement, error) {
+	var acknowledgements []models.NoticeAcknowledgement
+	if err := r.db.WithContext(ctx).Where("user_id = ?", userID).Find(&acknowledgements).Error; err != nil {
+		logger.Error("Failed to get notice acknowledgements", err)
+		return nil, fmt.Errorf("failed to get notice acknowledgements: %w", err)
+	}
+
+	domainAcknowledgements := make([]*entities.NoticeAcknowledgement, len(acknowledgements))
+	for i, acknowledgement := range acknowledgements {
+		domainAcknowledgements[i] = r.modelToDomain(&acknowledgement)
+	}
+
+	return domainAcknowledgements, nil
+}
+
+// modelToDomain converts model NoticeAcknowledgement to domain NoticeAcknowledgement
+func (r *NoticeAcknowledgementRepositoryImpl) modelToDomain(model *models.NoticeAcknowledgement) *entities.NoticeAcknowledgement {
+	return &entities.NoticeAcknowledgement{
+		ID:             model.ID,
+		UserID:         model.UserID,
+		NoticeID:       model.NoticeID,
+		Version:        model.Version,
+		AcknowledgedAt: model.AcknowledgedAt,
+	}
+}
+
+// domainToModel converts domain NoticeAcknowledgement to model NoticeAcknowledgement
+func (r *NoticeAcknowledgementRepositoryImpl) domainToModel(acknowledgement *entities.NoticeAcknowledgement) *models.NoticeAcknowledgement {
+	return &models.NoticeAcknowledgement{
+		ID:             acknowledgement.ID,
+		UserID:         acknowledgement.UserID,
+		NoticeID:       acknowledgement.NoticeID,
+		Version:        acknowledgement.Version,
+		AcknowledgedAt: acknowledgement.AcknowledgedAt,
+	}
+}
@@ -0,0 +1,104 @@
+package repositories
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+	"github.com/22smeargle/winkr-backend/internal/domain/entities"
+	"github.com/22smeargle/winkr-backend/internal/domain/repositories"
+	"github.com/22smeargle/winkr-backend/internal/infrastructure/database/postgres/models"
+	"github.com/22smeargle/winkr-backend/pkg/logger"
+)
+
+// PhotoEngagementRepositoryImpl implements PhotoEngagementRepository interface using GORM
+type PhotoEngagementRepositoryImpl struct {
+	db *gorm.DB
+}
+
+// NewPhotoEngagementRepository creates a new PhotoEngagementRepository instance
+func NewPhotoEngagementRepository(db *gorm.DB) repositories.PhotoEngagementRepository {
+	return &PhotoEngagementRepositoryImpl{db: db}
+}
+
+// RecordImpression records that the viewer was shown the photo. A viewer counts once per photo,
+// however often they see it.
+func (r *PhotoEngagementRepositoryImpl) RecordImpression(ctx context.Context, impression *entities.PhotoImpression) error {
+	model := &models.PhotoImpression{
+		PhotoID:  impression.PhotoID,
+		UserID:   impression.UserID,
+		ViewerID: impression.ViewerID,
+		Liked:    impression.Liked,
+		ViewedAt: impression.ViewedAt,
+		LikedAt:  impression.LikedAt,
+	}
+	if err := r.db.WithContext(ctx).Clauses(clause.OnConflict{DoNothing: true}).Create(model).Error; err != nil {
+		logger.Error("Failed to record photo impression", err)
+		return fmt.Errorf("failed to record photo impression: %w", err)
+	}
+
+	return nil
+}
+
+// MarkLiked marks the owner's photos the viewer was shown as liked
+func (r *PhotoEngagementRepositoryImpl) MarkLiked(ctx context.Context, ownerID, viewerID uuid.UUID, likedAt time.Time) error {
+	err := r.db.WithContext(ctx).Model(&models.PhotoImpression{}).
+		Where("user_id = ? AND viewer_id = ? AND liked = ?", ownerID, viewerID, false).
+		Updates(map[string]interface{}{"liked": true, "liked_at": likedAt}).Error
+	if err != nil {
+		logger.Error("Failed to mark photo impressions liked", err)
+		return fmt.Errorf("failed to mark photo impressions liked: %w", err)
+	}
+
+	return nil
+}
+
+// GetPerformanceByUserID sums up the impressions of the user's photos, keyed by photo ID.
+// Photos that were never shown are omitted.
+func (r *PhotoEngagementRepositoryImpl) GetPerformanceByUserID(ctx context.Context, userID uuid.UUID) (map[uuid.UUID]*entities.PhotoPerformance, error) {
+	var rows []struct {
+		PhotoID     uuid.UUID
+		Impressions int64
+		Likes       int64
+	}
+	err := r.db.WithContext(ctx).Model(&models.PhotoImpression{}).
+		Select("photo_id, COUNT(*) AS impressions, COUNT(*) FILTER (WHERE liked) AS likes").
+		Where("user_id = ?", userID).
+		Group("photo_id").
+		Scan(&rows).Error
+	if err != nil {
+		logger.Error("Failed to get photo performance", err)
+		return nil, fmt.Errorf("failed to get photo performance: %w", err)
+	}
+
+	performance := make(map[uuid.UUID]*entities.PhotoPerformance, len(rows))
+	for _, row := range rows {
+		performance[row.PhotoID] = &entities.PhotoPerformance{
+			PhotoID:     row.PhotoID,
+			Impressions: row.Impressions,
+			Likes:       row.Likes,
+		}
+	}
+
+	return performance, nil
+}
+
+// GetAutoReorderUserIDs retrieves the users who opted in to having their photos reordered,
+// ordered by ID and starting after the given ID
+func (r *PhotoEngagementRepositoryImpl) GetAutoReorderUserIDs(ctx context.Context, afterID uuid.UUID, limit int) ([]uuid.UUID, error) {
+	var userIDs []uuid.UUID
+	err := r.db.WithContext(ctx).Model(&models.UserPreferences{}).
+		Where("auto_reorder_photos = ? AND user_id > ?", true, afterID).
+		Order("user_id ASC").
+		Limit(limit).
+		Pluck("user_id", &userIDs).Error
+	if err != nil {
+		logger.Error("Failed to get auto reorder users", err)
+		return nil, fmt.Errorf("failed to get auto reorder users: %w", err)
+	}
+
+	return userIDs, nil
+}
@@ -92,7 +92,8 @@ func (r *PhotoRepositoryImpl) GetUserPhotos(ctx context.Context, userID uuid.UUI
 		query = query.Where("is_deleted = ?", false)
 	}
 	
-	if err := query.Find(&photos).Error; err != nil {
+	// Primary photo first, then in display order
+	if err := query.Order("is_primary DESC, position ASC, created_at ASC").Find(&photos).Error; err != nil {
 		logger.Error("Failed to get user photos", err)
 		return nil, fmt.Errorf("failed to get user photos: %w", err)
 	}
@@ -133,10 +134,40 @@ func (r *PhotoRepositoryImpl) GetUserPhotoCount(ctx context.Context, userID uuid
 	return int(count), nil
 }
 
-// GetPendingVerificationPhotos retrieves photos pending verification
+// CountApprovedByUserIDs retrieves the number of approved photos for each of the users
+func (r *PhotoRepositoryImpl) CountApprovedByUserIDs(ctx context.Context, userIDs []uuid.UUID) (map[uuid.UUID]int, error) {
+	counts := make(map[uuid.UUID]int, len(userIDs))
+	if len(userIDs) == 0 {
+		return counts, nil
+	}
+
+	var rows []struct {
+		UserID uuid.UUID
+		Count  int
+	}
+	if err := r.db.WithContext(ctx).Model(&models.Photo{}).
+		Select("user_id, COUNT(*) AS count").
+		Where("user_id IN ? AND verification_status = ? AND is_deleted = ? AND is_hidden = ?", userIDs, "approved", false, false).
+		Group("user_id").
+		Scan(&rows).Error; err != nil {
+		logger.Error("Failed to count approved photos", err)
+		return nil, fmt.Errorf("failed to count approved photos: %w", err)
+	}
+
+	for _, row := range rows {
+		counts[row.UserID] = row.Count
+	}
+
+	return counts, nil
+}
+
+// GetPendingVerificationPhotos retrieves photos pending verification, including reported photos queued for re-moderation
 func (r *PhotoRepositoryImpl) GetPendingVerificationPhotos(ctx context.Context, limit, offset int) ([]*entities.Photo, error) {
 	var photos []models.Photo
-	if err := r.db.WithContext(ctx).Where("verification_status = ? AND is_deleted = ?", "pending", false).Limit(limit).Offset(offset).Find(&photos).Error; err != nil {
+	if err := r.db.WithContext(ctx).
+		Where("(verification_status = ? OR review_requested_at IS NOT NULL) AND is_deleted = ?", "pending", false).
+		Order("review_requested_at ASC NULLS LAST, created_at ASC").
+		Limit(limit).Offset(offset).Find(&photos).Error; err != nil {
 		logger.Error("Failed to get pending verification photos", err)
 		return nil, fmt.Errorf("failed to get pending verification photos: %w", err)
 	}
@@ -176,6 +207,12 @@ func (r *PhotoRepositoryImpl) UpdateVerificationStatus(ctx context.Context, phot
 	if reason != nil {
 		updates["verification_reason"] = *reason
 	}
+
+	// A moderator decision settles any pending re-review
+	if status != "pending" {
+		updates["review_requested_at"] = nil
+		updates["is_hidden"] = false
+	}
 	
 	if err := r.db.WithContext(ctx).Model(&models.Photo{}).Where("id = ?", photoID).Updates(updates).Error; err != nil {
 		logger.Error("Failed to update photo verification status", err)
@@ -189,6 +226,27 @@ func (r *PhotoRepositoryImpl) UpdateVerificationStatus(ctx context.Context, phot
 	return nil
 }
 
+// RequestReview queues a photo for re-moderation
+func (r *PhotoRepositoryImpl) RequestReview(ctx context.Context, photoID uuid.UUID, hide bool) error {
+	updates := map[string]interface{}{
+		"review_requested_at": gorm.Expr("COALESCE(review_requested_at, NOW())"),
+	}
+	if hide {
+		updates["is_hidden"] = true
+	}
+
+	if err := r.db.WithContext(ctx).Model(&models.Photo{}).Where("id = ?", photoID).Updates(updates).Error; err != nil {
+		logger.Error("Failed to request photo review", err)
+		return fmt.Errorf("failed to request photo review: %w", err)
+	}
+
+	logger.Info("Photo review requested", map[string]interface{}{
+		"photo_id": photoID,
+		"hidden":   hide,
+	})
+	return nil
+}
+
 // SetPrimaryPhoto sets a photo as primary
 func (r *PhotoRepositoryImpl) SetPrimaryPhoto(ctx context.Context, userID, photoID uuid.UUID) error {
 	// Start transaction
@@ -201,8 +259,9 @@ func (r *PhotoRepositoryImpl) SetPrimaryPhoto(ctx context.Context, userID, photo
 		}
 	}()
 
-	// Unset current primary photo
-	if err := tx.Model(&models.Photo{}).Where("user_id = ? AND is_primary = ?", userID, true).Update("is_primary", false).Error; err != nil {
+	// Unset current primary photo, releasing its lock
+	if err := tx.Model(&models.Photo{}).Where("user_id = ? AND is_primary = ?", userID, true).
+		Updates(map[string]interface{}{"is_primary": false, "primary_locked": false}).Error; err != nil {
 		tx.Rollback()
 		logger.Error("Failed to unset current primary photo", err)
 		return fmt.Errorf("failed to unset current primary photo: %w", err)
@@ -222,6 +281,54 @@ func (r *PhotoRepositoryImpl) SetPrimaryPhoto(ctx context.Context, userID, photo
 	return nil
 }
 
+// LockPrimaryPhoto locks or unlocks the user's primary photo against reordering by engagement
+func (r *PhotoRepositoryImpl) LockPrimaryPhoto(ctx context.Context, userID uuid.UUID, locked bool) error {
+	if err := r.db.WithContext(ctx).Model(&models.Photo{}).
+		Where("user_id = ? AND is_primary = ?", userID, true).
+		Update("primary_locked", locked).Error; err != nil {
+		logger.Error("Failed to lock primary photo", err)
+		return fmt.Errorf("failed to lock primary photo: %w", err)
+	}
+
+	return nil
+}
+
+// ReorderPhotos stores the display order of the user's photos, making the first one primary. It fails
+// if another photo is a locked primary.
+func (r *PhotoRepositoryImpl) ReorderPhotos(ctx context.Context, userID uuid.UUID, photoIDs []uuid.UUID) error {
+	if len(photoIDs) == 0 {
+		return nil
+	}
+
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		// The owner may have locked their primary photo since the order was worked out
+		var locked int64
+		if err := tx.Model(&models.Photo{}).
+			Where("user_id = ? AND is_primary = ? AND primary_locked = ? AND id <> ?", userID, true, true, photoIDs[0]).
+			Count(&locked).Error; err != nil {
+			return err
+		}
+		if locked > 0 {
+			return fmt.Errorf("primary photo is locked")
+		}
+
+		for i, photoID := range photoIDs {
+			if err := tx.Model(&models.Photo{}).
+				Where("id = ? AND user_id = ?", photoID, userID).
+				Updates(map[string]interface{}{"position": i, "is_primary": i == 0}).Error; err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		logger.Error("Failed to reorder photos", err, "user_id", userID)
+		return fmt.Errorf("failed to reorder photos: %w", err)
+	}
+
+	return nil
+}
+
 // UnsetPrimaryPhoto unsets primary photo for a user
 func (r *PhotoRepositoryImpl) UnsetPrimaryPhoto(ctx context.Context, userID uuid.UUID) error {
 	if err := r.db.WithContext(ctx).Model(&models.Photo{}).Where("user_id = ? AND is_primary = ?", userID, true).Update("is_primary", false).Error; err != nil {
@@ -544,9 +651,13 @@ func (r *PhotoRepositoryImpl) modelToDomainPhoto(model *models.Photo) *entities.
 		FileURL:           model.FileURL,
 		FileKey:           model.FileKey,
 		IsPrimary:         model.IsPrimary,
+		PrimaryLocked:     model.PrimaryLocked,
+		Position:          model.Position,
 		VerificationStatus: model.VerificationStatus,
 		VerificationReason: model.VerificationReason,
 		IsDeleted:         model.IsDeleted,
+		IsHidden:          model.IsHidden,
+		ReviewRequestedAt: model.ReviewRequestedAt,
 		CreatedAt:         model.CreatedAt,
 		UpdatedAt:         model.UpdatedAt,
 	}
@@ -560,9 +671,13 @@ func (r *PhotoRepositoryImpl) domainToModelPhoto(photo *entities.Photo) *models.
 		FileURL:           photo.FileURL,
 		FileKey:           photo.FileKey,
 		IsPrimary:         photo.IsPrimary,
+		PrimaryLocked:     photo.PrimaryLocked,
+		Position:          photo.Position,
 		VerificationStatus: photo.VerificationStatus,
 		VerificationReason: photo.VerificationReason,
 		IsDeleted:         photo.IsDeleted,
+		IsHidden:          photo.IsHidden,
+		ReviewRequestedAt: photo.ReviewRequestedAt,
 		CreatedAt:         photo.CreatedAt,
 		UpdatedAt:         photo.UpdatedAt,
 	}
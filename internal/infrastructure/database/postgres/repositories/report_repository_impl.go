@@ -280,6 +280,39 @@ func (r *ReportRepositoryImpl) GetReportsCreatedInRange(ctx context.Context, sta
 	return domainReports, nil
 }
 
+// HasRecentReport checks if the reporter reported the user's profile since the time
+func (r *ReportRepositoryImpl) HasRecentReport(ctx context.Context, reporterID, reportedUserID uuid.UUID, since time.Time) (bool, error) {
+	var count int64
+	if err := r.db.WithContext(ctx).Model(&models.Report{}).Where("reporter_id = ? AND reported_user_id = ? AND photo_id IS NULL AND created_at >= ?", reporterID, reportedUserID, since).Count(&count).Error; err != nil {
+		logger.Error("Failed to check recent report", err)
+		return false, fmt.Errorf("failed to check recent report: %w", err)
+	}
+
+	return count > 0, nil
+}
+
+// HasActivePhotoReport checks if the reporter already has an open report on the photo
+func (r *ReportRepositoryImpl) HasActivePhotoReport(ctx context.Context, reporterID, photoID uuid.UUID) (bool, error) {
+	var count int64
+	if err := r.db.WithContext(ctx).Model(&models.Report{}).Where("reporter_id = ? AND photo_id = ? AND status IN ?", reporterID, photoID, []string{"pending", "reviewed"}).Count(&count).Error; err != nil {
+		logger.Error("Failed to check active photo report", err)
+		return false, fmt.Errorf("failed to check active photo report: %w", err)
+	}
+
+	return count > 0, nil
+}
+
+// CountPendingByPhoto retrieves the number of pending reports on a photo
+func (r *ReportRepositoryImpl) CountPendingByPhoto(ctx context.Context, photoID uuid.UUID) (int64, error) {
+	var count int64
+	if err := r.db.WithContext(ctx).Model(&models.Report{}).Where("photo_id = ? AND status = ?", photoID, "pending").Count(&count).Error; err != nil {
+		logger.Error("Failed to count pending photo reports", err)
+		return 0, fmt.Errorf("failed to count pending photo reports: %w", err)
+	}
+
+	return count, nil
+}
+
 // ExistsReport checks if report exists
 func (r *ReportRepositoryImpl) ExistsReport(ctx context.Context, id uuid.UUID) (bool, error) {
 	var count int64
@@ -483,6 +516,7 @@ func (r *ReportRepositoryImpl) modelToDomainReport(model *models.Report) *entiti
 		ID:          model.ID,
 		ReporterID:  model.ReporterID,
 		ReportedID:  model.ReportedID,
+		PhotoID:     model.PhotoID,
 		Reason:      model.Reason,
 		Description: model.Description,
 		Status:      model.Status,
@@ -500,6 +534,7 @@ func (r *ReportRepositoryImpl) domainToModelReport(report *entities.Report) *mod
 		ID:          report.ID,
 		ReporterID:  report.ReporterID,
 		ReportedID:  report.ReportedID,
+		PhotoID:     report.PhotoID,
 		Reason:      report.Reason,
 		Description: report.Description,
 		Status:      report.Status,
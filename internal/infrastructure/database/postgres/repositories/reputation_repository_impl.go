@@ -0,0 +1,223 @@
+package repositories
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+	"github.com/22smeargle/winkr-backend/internal/domain/entities"
+	"github.com/22smeargle/winkr-backend/internal/domain/repositories"
+	"github.com/22smeargle/winkr-backend/internal/infrastructure/database/postgres/models"
+	"github.com/22smeargle/winkr-backend/pkg/logger"
+)
+
+// errStaleReputation rolls back a reputation change whose previous score is out of date
+var errStaleReputation = errors.New("reputation changed concurrently")
+
+// ReputationRepositoryImpl implements ReputationRepository interface using GORM
+type ReputationRepositoryImpl struct {
+	db *gorm.DB
+}
+
+// NewReputationRepository creates a new ReputationRepository instance
+func NewReputationRepository(db *gorm.DB) repositories.ReputationRepository {
+	return &ReputationRepositoryImpl{db: db}
+}
+
+// GetByUserID retrieves a user's reputation, or nil if it never changed
+func (r *ReputationRepositoryImpl) GetByUserID(ctx context.Context, userID uuid.UUID) (*entities.UserReputation, error) {
+	var model models.UserReputation
+	if err := r.db.WithContext(ctx).Where("user_id = ?", userID).First(&model).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, nil
+		}
+		logger.Error("Failed to get user reputation", err)
+		return nil, fmt.Errorf("failed to get user reputation: %w", err)
+	}
+
+	return r.reputationToDomain(&model), nil
+}
+
+// GetScores retrieves the scores of the users whose reputation ever changed
+func (r *ReputationRepositoryImpl) GetScores(ctx context.Context, userIDs []uuid.UUID) (map[uuid.UUID]int, error) {
+	scores := make(map[uuid.UUID]int, len(userIDs))
+	if len(userIDs) == 0 {
+		return scores, nil
+	}
+
+	var reputations []models.UserReputation
+	err := r.db.WithContext(ctx).
+		Select("user_id", "score").
+		Where("user_id IN ?", userIDs).
+		Find(&reputations).Error
+	if err != nil {
+		logger.Error("Failed to get reputation scores", err)
+		return nil, fmt.Errorf("failed to get reputation scores: %w", err)
+	}
+
+	for _, reputation := range reputations {
+		scores[reputation.UserID] = reputation.Score
+	}
+	return scores, nil
+}
+
+// ApplyChange updates the user's score if it still equals the change's previous score, and records
+// the change in the same transaction
+func (r *ReputationRepositoryImpl) ApplyChange(ctx context.Context, change *entities.ReputationChange, initialScore int) (bool, error) {
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		created := false
+		if change.PreviousScore == initialScore {
+			// Users start at the initial score without a row; Select keeps a zero score from being
+			// replaced by the column default
+			reputation := &models.UserReputation{
+				UserID:          change.UserID,
+				Score:           change.NewScore,
+				LastScoreChange: &change.CreatedAt,
+			}
+			result := tx.Clauses(clause.OnConflict{DoNothing: true}).
+				Select("ID", "UserID", "Score", "LastScoreChange").
+				Create(reputation)
+			if result.Error != nil {
+				return result.Error
+			}
+			created = result.RowsAffected > 0
+		}
+
+		if !created {
+			result := tx.Model(&models.UserReputation{}).
+				Where("user_id = ? AND score = ?", change.UserID, change.PreviousScore).
+				Updates(map[string]interface{}{
+					"score":             change.NewScore,
+					"last_score_change": change.CreatedAt,
+				})
+			if result.Error != nil {
+				return result.Error
+			}
+			if result.RowsAffected == 0 {
+				return errStaleReputation
+			}
+		}
+
+		model := r.changeToModel(change)
+		if err := tx.Create(model).Error; err != nil {
+			return err
+		}
+		change.ID = model.ID
+		return nil
+	})
+	if errors.Is(err, errStaleReputation) {
+		return false, nil
+	}
+	if err != nil {
+		logger.Error("Failed to apply reputation change", err)
+		return false, fmt.Errorf("failed to apply reputation change: %w", err)
+	}
+
+	return true, nil
+}
+
+// IncrementReportsReceived counts a new report against the user in a single upsert, so concurrent
+// reports each get their own count
+func (r *ReputationRepositoryImpl) IncrementReportsReceived(ctx context.Context, userID uuid.UUID, initialScore int) (int, error) {
+	var count int
+	err := r.db.WithContext(ctx).Raw(`
+		INSERT INTO user_reputations (user_id, score, reports_received, last_action_date)
+		VALUES (?, ?, 1, NOW())
+		ON CONFLICT (user_id) DO UPDATE
+		SET reports_received = user_reputations.reports_received + 1, last_action_date = NOW()
+		RETURNING reports_received`, userID, initialScore).Scan(&count).Error
+	if err != nil {
+		logger.Error("Failed to increment reports received", err)
+		return 0, fmt.Errorf("failed to increment reports received: %w", err)
+	}
+
+	return count, nil
+}
+
+// GetRecoverable retrieves reputations below the score that have not changed since the time, the
+// longest unchanged first
+func (r *ReputationRepositoryImpl) GetRecoverable(ctx context.Context, below int, unchangedSince time.Time, limit int) ([]*entities.UserReputation, error) {
+	var reputations []models.UserReputation
+	err := r.db.WithContext(ctx).
+		Where("score < ? AND (last_score_change IS NULL OR last_score_change < ?)", below, unchangedSince).
+		Order("last_score_change ASC NULLS FIRST").
+		Limit(limit).
+		Find(&reputations).Error
+	if err != nil {
+		logger.Error("Failed to get recoverable reputations", err)
+		return nil, fmt.Errorf("failed to get recoverable reputations: %w", err)
+	}
+
+	domainReputations := make([]*entities.UserReputation, len(reputations))
+	for i, reputation := range reputations {
+		domainReputations[i] = r.reputationToDomain(&reputation)
+	}
+
+	return domainReputations, nil
+}
+
+// GetChanges retrieves the user's most recent reputation changes, newest first
+func (r *ReputationRepositoryImpl) GetChanges(ctx context.Context, userID uuid.UUID, limit int) ([]*entities.ReputationChange, error) {
+	var changes []models.ReputationChange
+	err := r.db.WithContext(ctx).
+		Where("user_id = ?", userID).
+		Order("created_at DESC").
+		Limit(limit).
+		Find(&changes).Error
+	if err != nil {
+		logger.Error("Failed to get reputation changes", err)
+		return nil, fmt.Errorf("failed to get reputation changes: %w", err)
+	}
+
+	domainChanges := make([]*entities.ReputationChange, len(changes))
+	for i, change := range changes {
+		domainChanges[i] = r.changeToDomain(&change)
+	}
+
+	return domainChanges, nil
+}
+
+// reputationToDomain converts model UserReputation to domain UserReputation
+func (r *ReputationRepositoryImpl) reputationToDomain(model *models.UserReputation) *entities.UserReputation {
+	return &entities.UserReputation{
+		UserID:          model.UserID,
+		Score:           model.Score,
+		ReportsReceived: model.ReportsReceived,
+		LastScoreChange: model.LastScoreChange,
+		UpdatedAt:       model.LastUpdated,
+	}
+}
+
+// changeToDomain converts model ReputationChange to domain ReputationChange
+func (r *ReputationRepositoryImpl) changeToDomain(model *models.ReputationChange) *entities.ReputationChange {
+	return &entities.ReputationChange{
+		ID:            model.ID,
+		UserID:        model.UserID,
+		Delta:         model.Delta,
+		PreviousScore: model.PreviousScore,
+		NewScore:      model.NewScore,
+		Reason:        model.Reason,
+		ReferenceID:   model.ReferenceID,
+		ActorID:       model.ActorID,
+		CreatedAt:     model.CreatedAt,
+	}
+}
+
+// changeToModel converts domain ReputationChange to model ReputationChange
+func (r *ReputationRepositoryImpl) changeToModel(change *entities.ReputationChange) *models.ReputationChange {
+	return &models.ReputationChange{
+		ID:            change.ID,
+		UserID:        change.UserID,
+		Delta:         change.Delta,
+		PreviousScore: change.PreviousScore,
+		NewScore:      change.NewScore,
+		Reason:        change.Reason,
+		ReferenceID:   change.ReferenceID,
+		ActorID:       change.ActorID,
+		CreatedAt:     change.CreatedAt,
+	}
+}
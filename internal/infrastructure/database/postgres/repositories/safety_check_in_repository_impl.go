@@ -0,0 +1,170 @@
+package repositories
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"github.com/22smeargle/winkr-backend/internal/domain/entities"
+	"github.com/22smeargle/winkr-backend/internal/domain/repositories"
+	"github.com/22smeargle/winkr-backend/internal/infrastructure/database/postgres/models"
+	"github.com/22smeargle/winkr-backend/pkg/logger"
+)
+
+// SafetyCheckInRepositoryImpl implements SafetyCheckInRepository interface using GORM
+type SafetyCheckInRepositoryImpl struct {
+	db *gorm.DB
+}
+
+// NewSafetyCheckInRepository creates a new SafetyCheckInRepository instance
+func NewSafetyCheckInRepository(db *gorm.DB) repositories.SafetyCheckInRepository {
+	return &SafetyCheckInRepositoryImpl{db: db}
+}
+
+// Create saves a new check-in
+func (r *SafetyCheckInRepositoryImpl) Create(ctx context.Context, checkIn *entities.SafetyCheckIn) error {
+	model := r.domainToModel(checkIn)
+	if err := r.db.WithContext(ctx).Create(model).Error; err != nil {
+		logger.Error("Failed to create safety check-in", err)
+		return fmt.Errorf("failed to create safety check-in: %w", err)
+	}
+
+	checkIn.ID = model.ID
+	checkIn.CreatedAt = model.CreatedAt
+	checkIn.UpdatedAt = model.UpdatedAt
+	return nil
+}
+
+// GetByID retrieves a check-in by ID
+func (r *SafetyCheckInRepositoryImpl) GetByID(ctx context.Context, id uuid.UUID) (*entities.SafetyCheckIn, error) {
+	var model models.SafetyCheckIn
+	if err := r.db.WithContext(ctx).Where("id = ?", id).First(&model).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, fmt.Errorf("safety check-in not found")
+		}
+		logger.Error("Failed to get safety check-in by ID", err)
+		return nil, fmt.Errorf("failed to get safety check-in: %w", err)
+	}
+
+	return r.modelToDomain(&model), nil
+}
+
+// CountScheduledByUserID counts a user's check-ins that are still waiting to be confirmed
+func (r *SafetyCheckInRepositoryImpl) CountScheduledByUserID(ctx context.Context, userID uuid.UUID) (int64, error) {
+	var count int64
+	err := r.db.WithContext(ctx).Model(&models.SafetyCheckIn{}).
+		Where("user_id = ? AND status = ?", userID, entities.SafetyCheckInStatusScheduled).
+		Count(&count).Error
+	if err != nil {
+		logger.Error("Failed to count safety check-ins", err)
+		return 0, fmt.Errorf("failed to count safety check-ins: %w", err)
+	}
+
+	return count, nil
+}
+
+// GetScheduledDueBefore retrieves scheduled check-ins due before the given time, oldest first
+func (r *SafetyCheckInRepositoryImpl) GetScheduledDueBefore(ctx context.Context, before time.Time, limit int) ([]*entities.SafetyCheckIn, error) {
+	var checkIns []models.SafetyCheckIn
+	err := r.db.WithContext(ctx).
+		Where("status = ? AND due_at < ?", entities.SafetyCheckInStatusScheduled, before).
+		Order("due_at ASC").
+		Limit(limit).
+		Find(&checkIns).Error
+	if err != nil {
+		logger.Error("Failed to get due safety check-ins", err)
+		return nil, fmt.Errorf("failed to get due safety check-ins: %w", err)
+	}
+
+	domainCheckIns := make([]*entities.SafetyCheckIn, len(checkIns))
+	for i, checkIn := range checkIns {
+		domainCheckIns[i] = r.modelToDomain(&checkIn)
+	}
+
+	return domainCheckIns, nil
+}
+
+// MarkConfirmed confirms a scheduled or missed check-in
+func (r *SafetyCheckInRepositoryImpl) MarkConfirmed(ctx context.Context, id uuid.UUID, confirmedAt time.Time) (bool, error) {
+	result := r.db.WithContext(ctx).Model(&models.SafetyCheckIn{}).
+		Where("id = ? AND status IN ?", id, []string{entities.SafetyCheckInStatusScheduled, entities.SafetyCheckInStatusMissed}).
+		Updates(map[string]interface{}{
+			"status":       entities.SafetyCheckInStatusConfirmed,
+			"confirmed_at": confirmedAt,
+		})
+	if result.Error != nil {
+		logger.Error("Failed to confirm safety check-in", result.Error)
+		return false, fmt.Errorf("failed to confirm safety check-in: %w", result.Error)
+	}
+
+	return result.RowsAffected > 0, nil
+}
+
+// MarkMissed marks a scheduled check-in as missed
+func (r *SafetyCheckInRepositoryImpl) MarkMissed(ctx context.Context, id uuid.UUID, missedAt time.Time) (bool, error) {
+	result := r.db.WithContext(ctx).Model(&models.SafetyCheckIn{}).
+		Where("id = ? AND status = ?", id, entities.SafetyCheckInStatusScheduled).
+		Updates(map[string]interface{}{
+			"status":    entities.SafetyCheckInStatusMissed,
+			"missed_at": missedAt,
+		})
+	if result.Error != nil {
+		logger.Error("Failed to mark safety check-in missed", result.Error)
+		return false, fmt.Errorf("failed to mark safety check-in missed: %w", result.Error)
+	}
+
+	return result.RowsAffected > 0, nil
+}
+
+// MarkContactAlerted records when the trusted contact was alerted
+func (r *SafetyCheckInRepositoryImpl) MarkContactAlerted(ctx context.Context, id uuid.UUID, alertedAt time.Time) error {
+	err := r.db.WithContext(ctx).Model(&models.SafetyCheckIn{}).
+		Where("id = ?", id).
+		Update("contact_alerted_at", alertedAt).Error
+	if err != nil {
+		logger.Error("Failed to mark safety check-in contact alerted", err)
+		return fmt.Errorf("failed to mark safety check-in contact alerted: %w", err)
+	}
+
+	return nil
+}
+
+// modelToDomain converts model SafetyCheckIn to domain SafetyCheckIn
+func (r *SafetyCheckInRepositoryImpl) modelToDomain(model *models.SafetyCheckIn) *entities.SafetyCheckIn {
+	return &entities.SafetyCheckIn{
+		ID:               model.ID,
+		UserID:           model.UserID,
+		DueAt:            model.DueAt,
+		Status:           model.Status,
+		Note:             model.Note,
+		ContactName:      model.ContactName,
+		ContactEmail:     model.ContactEmail,
+		ContactConsentAt: model.ContactConsentAt,
+		ConfirmedAt:      model.ConfirmedAt,
+		MissedAt:         model.MissedAt,
+		ContactAlertedAt: model.ContactAlertedAt,
+		CreatedAt:        model.CreatedAt,
+		UpdatedAt:        model.UpdatedAt,
+	}
+}
+
+// domainToModel converts domain SafetyCheckIn to model SafetyCheckIn
+func (r *SafetyCheckInRepositoryImpl) domainToModel(checkIn *entities.SafetyCheckIn) *models.SafetyCheckIn {
+	return &models.SafetyCheckIn{
+		ID:               checkIn.ID,
+		UserID:           checkIn.UserID,
+		DueAt:            checkIn.DueAt,
+		Status:           checkIn.Status,
+		Note:             checkIn.Note,
+		ContactName:      checkIn.ContactName,
+		ContactEmail:     checkIn.ContactEmail,
+		ContactConsentAt: checkIn.ContactConsentAt,
+		ConfirmedAt:      checkIn.ConfirmedAt,
+		MissedAt:         checkIn.MissedAt,
+		ContactAlertedAt: checkIn.ContactAlertedAt,
+		CreatedAt:        checkIn.CreatedAt,
+		UpdatedAt:        checkIn.UpdatedAt,
+	}
+}
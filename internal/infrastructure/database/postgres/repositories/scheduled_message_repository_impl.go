@@ -0,0 +1,188 @@
+package repositories
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"github.com/22smeargle/winkr-backend/internal/domain/entities"
+	"github.com/22smeargle/winkr-backend/internal/domain/repositories"
+	"github.com/22smeargle/winkr-backend/internal/infrastructure/database/postgres/models"
+	"github.com/22smeargle/winkr-backend/pkg/logger"
+)
+
+// ScheduledMessageRepositoryImpl implements ScheduledMessageRepository interface using GORM
+type ScheduledMessageRepositoryImpl struct {
+	db *gorm.DB
+}
+
+// NewScheduledMessageRepository creates a new ScheduledMessageRepository instance
+func NewScheduledMessageRepository(db *gorm.DB) repositories.ScheduledMessageRepository {
+	return &ScheduledMessageRepositoryImpl{db: db}
+}
+
+// Create saves a new scheduled message
+func (r *ScheduledMessageRepositoryImpl) Create(ctx context.Context, message *entities.ScheduledMessage) error {
+	model := r.domainToModel(message)
+	if err := r.db.WithContext(ctx).Create(model).Error; err != nil {
+		logger.Error("Failed to create scheduled message", err)
+		return fmt.Errorf("failed to create scheduled message: %w", err)
+	}
+
+	message.ID = model.ID
+	message.CreatedAt = model.CreatedAt
+	message.UpdatedAt = model.UpdatedAt
+	return nil
+}
+
+// GetByID retrieves a scheduled message by ID
+func (r *ScheduledMessageRepositoryImpl) GetByID(ctx context.Context, id uuid.UUID) (*entities.ScheduledMessage, error) {
+	var model models.ScheduledMessage
+	if err := r.db.WithContext(ctx).Where("id = ?", id).First(&model).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, fmt.Errorf("scheduled message not found")
+		}
+		logger.Error("Failed to get scheduled message by ID", err)
+		return nil, fmt.Errorf("failed to get scheduled message: %w", err)
+	}
+
+	return r.modelToDomain(&model), nil
+}
+
+// CountScheduledByConversation counts a sender's messages in a conversation that are still waiting to be sent
+func (r *ScheduledMessageRepositoryImpl) CountScheduledByConversation(ctx context.Context, conversationID, senderID uuid.UUID) (int64, error) {
+	var count int64
+	err := r.db.WithContext(ctx).Model(&models.ScheduledMessage{}).
+		Where("conversation_id = ? AND sender_id = ? AND status = ?", conversationID, senderID, entities.ScheduledMessageStatusScheduled).
+		Count(&count).Error
+	if err != nil {
+		logger.Error("Failed to count scheduled messages", err)
+		return 0, fmt.Errorf("failed to count scheduled messages: %w", err)
+	}
+
+	return count, nil
+}
+
+// GetScheduledDueBefore retrieves scheduled messages due before the given time, oldest first
+func (r *ScheduledMessageRepositoryImpl) GetScheduledDueBefore(ctx context.Context, before time.Time, limit int) ([]*entities.ScheduledMessage, error) {
+	var messages []models.ScheduledMessage
+	err := r.db.WithContext(ctx).
+		Where("status = ? AND send_at <= ?", entities.ScheduledMessageStatusScheduled, before).
+		Order("send_at ASC").
+		Limit(limit).
+		Find(&messages).Error
+	if err != nil {
+		logger.Error("Failed to get due scheduled messages", err)
+		return nil, fmt.Errorf("failed to get due scheduled messages: %w", err)
+	}
+
+	domainMessages := make([]*entities.ScheduledMessage, len(messages))
+	for i, message := range messages {
+		domainMessages[i] = r.modelToDomain(&message)
+	}
+
+	return domainMessages, nil
+}
+
+// MarkSending claims a scheduled message for sending
+func (r *ScheduledMessageRepositoryImpl) MarkSending(ctx context.Context, id uuid.UUID) (bool, error) {
+	result := r.db.WithContext(ctx).Model(&models.ScheduledMessage{}).
+		Where("id = ? AND status = ?", id, entities.ScheduledMessageStatusScheduled).
+		Update("status", entities.ScheduledMessageStatusSending)
+	if result.Error != nil {
+		logger.Error("Failed to claim scheduled message", result.Error)
+		return false, fmt.Errorf("failed to claim scheduled message: %w", result.Error)
+	}
+
+	return result.RowsAffected > 0, nil
+}
+
+// MarkSent records the message a claimed scheduled message was sent as
+func (r *ScheduledMessageRepositoryImpl) MarkSent(ctx context.Context, id, messageID uuid.UUID, sentAt time.Time) error {
+	err := r.db.WithContext(ctx).Model(&models.ScheduledMessage{}).
+		Where("id = ?", id).
+		Updates(map[string]interface{}{
+			"status":     entities.ScheduledMessageStatusSent,
+			"message_id": messageID,
+			"sent_at":    sentAt,
+		}).Error
+	if err != nil {
+		logger.Error("Failed to mark scheduled message sent", err)
+		return fmt.Errorf("failed to mark scheduled message sent: %w", err)
+	}
+
+	return nil
+}
+
+// MarkSkipped records why a claimed scheduled message was not sent
+func (r *ScheduledMessageRepositoryImpl) MarkSkipped(ctx context.Context, id uuid.UUID, reason string, skippedAt time.Time) error {
+	err := r.db.WithContext(ctx).Model(&models.ScheduledMessage{}).
+		Where("id = ?", id).
+		Updates(map[string]interface{}{
+			"status":      entities.ScheduledMessageStatusSkipped,
+			"skip_reason": reason,
+			"skipped_at":  skippedAt,
+		}).Error
+	if err != nil {
+		logger.Error("Failed to mark scheduled message skipped", err)
+		return fmt.Errorf("failed to mark scheduled message skipped: %w", err)
+	}
+
+	return nil
+}
+
+// MarkCanceled cancels a scheduled message
+func (r *ScheduledMessageRepositoryImpl) MarkCanceled(ctx context.Context, id uuid.UUID, canceledAt time.Time) (bool, error) {
+	result := r.db.WithContext(ctx).Model(&models.ScheduledMessage{}).
+		Where("id = ? AND status = ?", id, entities.ScheduledMessageStatusScheduled).
+		Updates(map[string]interface{}{
+			"status":      entities.ScheduledMessageStatusCanceled,
+			"canceled_at": canceledAt,
+		})
+	if result.Error != nil {
+		logger.Error("Failed to cancel scheduled message", result.Error)
+		return false, fmt.Errorf("failed to cancel scheduled message: %w", result.Error)
+	}
+
+	return result.RowsAffected > 0, nil
+}
+
+// modelToDomain converts model ScheduledMessage to domain ScheduledMessage
+func (r *ScheduledMessageRepositoryImpl) modelToDomain(model *models.ScheduledMessage) *entities.ScheduledMessage {
+	return &entities.ScheduledMessage{
+		ID:             model.ID,
+		ConversationID: model.ConversationID,
+		SenderID:       model.SenderID,
+		Content:        model.Content,
+		SendAt:         model.SendAt,
+		Status:         model.Status,
+		MessageID:      model.MessageID,
+		SkipReason:     model.SkipReason,
+		SentAt:         model.SentAt,
+		CanceledAt:     model.CanceledAt,
+		SkippedAt:      model.SkippedAt,
+		CreatedAt:      model.CreatedAt,
+		UpdatedAt:      model.UpdatedAt,
+	}
+}
+
+// domainToModel converts domain ScheduledMessage to model ScheduledMessage
+func (r *ScheduledMessageRepositoryImpl) domainToModel(message *entities.ScheduledMessage) *models.ScheduledMessage {
+	return &models.ScheduledMessage{
+		ID:             message.ID,
+		ConversationID: message.ConversationID,
+		SenderID:       message.SenderID,
+		Content:        message.Content,
+		SendAt:         message.SendAt,
+		Status:         message.Status,
+		MessageID:      message.MessageID,
+		SkipReason:     message.SkipReason,
+		SentAt:         message.SentAt,
+		CanceledAt:     message.CanceledAt,
+		SkippedAt:      message.SkippedAt,
+		CreatedAt:      message.CreatedAt,
+		UpdatedAt:      message.UpdatedAt,
+	}
+}
@@ -280,6 +280,23 @@ func (r *SubscriptionRepositoryImpl) GetSubscriptionsExpiringSoon(ctx context.Co
 	return domainSubscriptions, nil
 }
 
+// GetExpiredGracePeriodSubscriptions retrieves past due subscriptions whose grace period ended by now
+func (r *SubscriptionRepositoryImpl) GetExpiredGracePeriodSubscriptions(ctx context.Context, now time.Time, limit int) ([]*entities.Subscription, error) {
+	var subscriptions []models.Subscription
+	if err := r.db.WithContext(ctx).Where("status = ? AND grace_period_ends_at IS NOT NULL AND grace_period_ends_at <= ?", "past_due", now).Order("grace_period_ends_at ASC").Limit(limit).Find(&subscriptions).Error; err != nil {
+		logger.Error("Failed to get subscriptions with expired grace periods", err)
+		return nil, fmt.Errorf("failed to get subscriptions with expired grace periods: %w", err)
+	}
+
+	// Convert to domain entities
+	domainSubscriptions := make([]*entities.Subscription, len(subscriptions))
+	for i, subscription := range subscriptions {
+		domainSubscriptions[i] = r.modelToDomainSubscription(&subscription)
+	}
+
+	return domainSubscriptions, nil
+}
+
 // GetSubscriptionCount retrieves subscription count
 func (r *SubscriptionRepositoryImpl) GetSubscriptionCount(ctx context.Context) (int64, error) {
 	var count int64
@@ -0,0 +1,88 @@
+package repositories
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+	"github.com/22smeargle/winkr-backend/internal/domain/repositories"
+	"github.com/22smeargle/winkr-backend/internal/infrastructure/database/postgres/models"
+	"github.com/22smeargle/winkr-backend/pkg/logger"
+)
+
+// UserBlockRepositoryImpl implements UserBlockRepository interface using GORM
+type UserBlockRepositoryImpl struct {
+	db *gorm.DB
+}
+
+// NewUserBlockRepository creates a new UserBlockRepository instance
+func NewUserBlockRepository(db *gorm.DB) repositories.UserBlockRepository {
+	return &UserBlockRepositoryImpl{db: db}
+}
+
+// Block blocks a user for the blocker. A user that is already blocked is skipped.
+func (r *UserBlockRepositoryImpl) Block(ctx context.Context, blockerID, blockedID uuid.UUID) error {
+	return r.BlockMany(ctx, blockerID, []uuid.UUID{blockedID})
+}
+
+// BlockMany blocks each of the given users for the blocker.
+// Users that are already blocked are skipped.
+func (r *UserBlockRepositoryImpl) BlockMany(ctx context.Context, blockerID uuid.UUID, blockedIDs []uuid.UUID) error {
+	if len(blockedIDs) == 0 {
+		return nil
+	}
+
+	blocks := make([]models.Block, len(blockedIDs))
+	for i, blockedID := range blockedIDs {
+		blocks[i] = models.Block{BlockerID: blockerID, BlockedID: blockedID}
+	}
+
+	if err := r.db.WithContext(ctx).Clauses(clause.OnConflict{DoNothing: true}).Create(&blocks).Error; err != nil {
+		logger.Error("Failed to create blocks", err)
+		return fmt.Errorf("failed to create blocks: %w", err)
+	}
+
+	return nil
+}
+
+// Unblock removes the blocker's block of a user
+func (r *UserBlockRepositoryImpl) Unblock(ctx context.Context, blockerID, blockedID uuid.UUID) error {
+	if err := r.db.WithContext(ctx).Where("blocker_id = ? AND blocked_id = ?", blockerID, blockedID).Delete(&models.Block{}).Error; err != nil {
+		logger.Error("Failed to delete block", err)
+		return fmt.Errorf("failed to delete block: %w", err)
+	}
+
+	return nil
+}
+
+// IsBlocked reports whether either user has blocked the other
+func (r *UserBlockRepositoryImpl) IsBlocked(ctx context.Context, userID, otherUserID uuid.UUID) (bool, error) {
+	var count int64
+	err := r.db.WithContext(ctx).Model(&models.Block{}).
+		Where("(blocker_id = ? AND blocked_id = ?) OR (blocker_id = ? AND blocked_id = ?)", userID, otherUserID, otherUserID, userID).
+		Count(&count).Error
+	if err != nil {
+		logger.Error("Failed to check block", err)
+		return false, fmt.Errorf("failed to check block: %w", err)
+	}
+
+	return count > 0, nil
+}
+
+// GetBlockedUserIDs retrieves the users a user has blocked or been blocked by
+func (r *UserBlockRepositoryImpl) GetBlockedUserIDs(ctx context.Context, userID uuid.UUID) ([]uuid.UUID, error) {
+	var userIDs []uuid.UUID
+	err := r.db.WithContext(ctx).Raw(`
+		SELECT blocked_id FROM blocks WHERE blocker_id = ?
+		UNION
+		SELECT blocker_id FROM blocks WHERE blocked_id = ?`, userID, userID).
+		Scan(&userIDs).Error
+	if err != nil {
+		logger.Error("Failed to get blocked users", err)
+		return nil, fmt.Errorf("failed to get blocked users: %w", err)
+	}
+
+	return userIDs, nil
+}
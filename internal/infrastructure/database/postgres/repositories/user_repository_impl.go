@@ -3,10 +3,12 @@ package repositories
 import (
 	"context"
 	"fmt"
+	"math"
 	"time"
 
 	"github.com/google/uuid"
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 	"github.com/22smeargle/winkr-backend/internal/domain/entities"
 	"github.com/22smeargle/winkr-backend/internal/domain/repositories"
 	"github.com/22smeargle/winkr-backend/internal/infrastructure/database/postgres/models"
@@ -53,6 +55,27 @@ func (r *UserRepositoryImpl) GetByID(ctx context.Context, id uuid.UUID) (*entiti
 	return domainUser, nil
 }
 
+// GetUsersByIDs retrieves the users with the given IDs
+func (r *UserRepositoryImpl) GetUsersByIDs(ctx context.Context, ids []uuid.UUID) ([]*entities.User, error) {
+	if len(ids) == 0 {
+		return []*entities.User{}, nil
+	}
+
+	var users []models.User
+	if err := r.db.WithContext(ctx).Where("id IN ?", ids).Find(&users).Error; err != nil {
+		logger.Error("Failed to get users by IDs", err)
+		return nil, fmt.Errorf("failed to get users by IDs: %w", err)
+	}
+
+	// Convert to domain entities
+	domainUsers := make([]*entities.User, len(users))
+	for i, user := range users {
+		domainUsers[i] = r.modelToDomainUser(&user)
+	}
+
+	return domainUsers, nil
+}
+
 // GetByEmail retrieves a user by email
 func (r *UserRepositoryImpl) GetByEmail(ctx context.Context, email string) (*entities.User, error) {
 	var user models.User
@@ -69,6 +92,25 @@ func (r *UserRepositoryImpl) GetByEmail(ctx context.Context, email string) (*ent
 	return domainUser, nil
 }
 
+// GetIDsByEmailHashes retrieves the IDs of users whose email hashes to one of the given hashes.
+// The hash is the hex SHA-256 of the trimmed, lowercased email, so plain addresses never leave the client.
+func (r *UserRepositoryImpl) GetIDsByEmailHashes(ctx context.Context, hashes []string) ([]uuid.UUID, error) {
+	if len(hashes) == 0 {
+		return []uuid.UUID{}, nil
+	}
+
+	var ids []uuid.UUID
+	err := r.db.WithContext(ctx).Model(&models.User{}).
+		Where("encode(sha256(lower(btrim(email))::bytea), 'hex') IN ?", hashes).
+		Pluck("id", &ids).Error
+	if err != nil {
+		logger.Error("Failed to get users by email hashes", err)
+		return nil, fmt.Errorf("failed to get users by email hashes: %w", err)
+	}
+
+	return ids, nil
+}
+
 // Update updates a user
 func (r *UserRepositoryImpl) Update(ctx context.Context, user *entities.User) error {
 	if err := r.db.WithContext(ctx).Save(user).Error; err != nil {
@@ -96,32 +138,43 @@ func (r *UserRepositoryImpl) Delete(ctx context.Context, id uuid.UUID) error {
 	return nil
 }
 
-// GetByLocation retrieves users within a specified radius from a location
+// FindUsers retrieves users matching the filter, most recently active first
+func (r *UserRepositoryImpl) FindUsers(ctx context.Context, filter repositories.UserFilter, page repositories.Pagination) ([]*entities.User, error) {
+	page = page.Normalize()
+	return r.findUsers(ctx, filter, page.Limit, page.Offset)
+}
+
+// GetByLocation retrieves discoverable users within a specified radius from a location, nearest
+// first. The radius is checked with ST_DWithin on the PostGIS location_geog column and the
+// distance ordering uses the KNN operator, so both are served by its spatial index.
 func (r *UserRepositoryImpl) GetByLocation(ctx context.Context, lat, lng float64, radiusKm int, limit, offset int) ([]*entities.User, error) {
-	// Using PostGIS for geospatial queries would be more efficient
-	// For now, we'll use a simple distance calculation
-	query := `
-		SELECT * FROM users 
-		WHERE location_lat IS NOT NULL 
-		  AND location_lng IS NOT NULL 
-		  AND is_active = true 
-		  AND is_banned = false
-		  AND (6371 * acos(cos(radians(location_lat)) * cos(radians(?)) * cos(radians(location_lng)) + 
-		               sin(radians(location_lat)) * sin(radians(?))) * 6371 * 1000) <= ?
-		ORDER BY last_active DESC
-		LIMIT ? OFFSET ?
-	`
+	return r.getByLocation(ctx, lat, lng, radiusKm, repositories.UserFilter{Discoverable: true}, limit, offset)
+}
+
+// GetActiveByLocation retrieves discoverable users within a specified radius from a location who
+// were active since the given time, nearest first
+func (r *UserRepositoryImpl) GetActiveByLocation(ctx context.Context, lat, lng float64, radiusKm int, activeSince time.Time, limit, offset int) ([]*entities.User, error) {
+	return r.getByLocation(ctx, lat, lng, radiusKm, repositories.UserFilter{Discoverable: true, ActiveSince: &activeSince}, limit, offset)
+}
+
+// getByLocation runs the spatial query for the users matching the filter
+func (r *UserRepositoryImpl) getByLocation(ctx context.Context, lat, lng float64, radiusKm int, filter repositories.UserFilter, limit, offset int) ([]*entities.User, error) {
+	query := applyUserFilter(r.db.WithContext(ctx).Model(&models.User{}), filter).
+		Where("ST_DWithin(users.location_geog, ST_SetSRID(ST_MakePoint(?, ?), 4326)::geography, ?)", lng, lat, float64(radiusKm)*1000).
+		Clauses(clause.OrderBy{Expression: clause.Expr{
+			SQL:  "users.location_geog <-> ST_SetSRID(ST_MakePoint(?, ?), 4326)::geography, users.id",
+			Vars: []interface{}{lng, lat},
+		}})
 
 	var users []models.User
-	if err := r.db.WithContext(ctx).Raw(query, lat, lng, float64(radiusKm), limit, offset).Scan(&users).Error; err != nil {
+	if err := query.Limit(limit).Offset(offset).Find(&users).Error; err != nil {
 		logger.Error("Failed to get users by location", err)
 		return nil, fmt.Errorf("failed to get users by location: %w", err)
 	}
 
-	// Convert to domain entities
 	domainUsers := make([]*entities.User, len(users))
-	for i, user := range users {
-		domainUsers[i] = r.modelToDomainUser(&User)
+	for i := range users {
+		domainUsers[i] = r.modelToDomainUser(&users[i])
 	}
 
 	return domainUsers, nil
@@ -155,6 +208,8 @@ func (r *UserRepositoryImpl) GetPotentialMatches(ctx context.Context, userID uui
 		WHERE u.id != ? 
 		  AND u.is_active = true 
 		  AND u.is_banned = false
+		  AND u.profile_under_review = false
+		  AND u.shadowbanned = false
 		  AND u.date_of_birth BETWEEN ? AND ?
 		  AND u.location_lat IS NOT NULL 
 		  AND u.location_lng IS NOT NULL
@@ -181,42 +236,23 @@ func (r *UserRepositoryImpl) GetPotentialMatches(ctx context.Context, userID uui
 }
 
 // GetUsersByPreferences retrieves users based on preferences
+//
+// Deprecated: use FindUsers with UserFilter.Genders, UserFilter.InterestedIn and the age range
 func (r *UserRepositoryImpl) GetUsersByPreferences(ctx context.Context, userID uuid.UUID, preferences *entities.UserPreferences, limit, offset int) ([]*entities.User, error) {
-	// Calculate age range
-	now := time.Now()
-	minBirthDate := now.AddDate(-preferences.AgeMax, 0, 0)
-	maxBirthDate := now.AddDate(-preferences.AgeMin, 0, 0)
-
-	// Query for users matching preferences
-	query := `
-		SELECT u.* FROM users u
-		LEFT JOIN user_preferences up ON u.id = up.user_id
-		WHERE u.id != ? 
-		  AND u.is_active = true 
-		  AND u.is_banned = false
-		  AND u.date_of_birth BETWEEN ? AND ?
-		  AND u.location_lat IS NOT NULL 
-		  AND u.location_lng IS NOT NULL
-		  AND up.show_me = true
-		  AND u.gender = ANY(?)
-		  AND ? = ANY(up.interested_in)
-		ORDER BY u.last_active DESC
-		LIMIT ? OFFSET ?
-	`
-
-	var users []models.User
-	if err := r.db.WithContext(ctx).Raw(query, userID, minBirthDate, maxBirthDate, preferences.InterestedIn[0], preferences.InterestedIn, limit, offset).Scan(&users).Error; err != nil {
-		logger.Error("Failed to get users by preferences", err)
-		return nil, fmt.Errorf("failed to get users by preferences: %w", err)
-	}
-
-	// Convert to domain entities
-	domainUsers := make([]*entities.User, len(users))
-	for i, User := range users {
-		domainUsers[i] = r.modelToDomainUser(&User)
+	var user models.User
+	if err := r.db.WithContext(ctx).Where("id = ?", userID).First(&user).Error; err != nil {
+		logger.Error("Failed to get user for preferences", err)
+		return nil, fmt.Errorf("failed to get user for preferences: %w", err)
 	}
 
-	return domainUsers, nil
+	return r.findUsers(ctx, repositories.UserFilter{
+		ExcludeIDs:   []uuid.UUID{userID},
+		Discoverable: true,
+		MinAge:       &preferences.AgeMin,
+		MaxAge:       &preferences.AgeMax,
+		Genders:      user.InterestedIn,
+		InterestedIn: &user.Gender,
+	}, limit, offset)
 }
 
 // UpdateLastActive updates the last active timestamp for a user
@@ -514,56 +550,140 @@ func (r *UserRepositoryImpl) ExistsByID(ctx context.Context, id uuid.UUID) (bool
 }
 
 // GetUsersWithPhotos retrieves users who have photos
+//
+// Deprecated: use FindUsers with UserFilter.HasPhotos
 func (r *UserRepositoryImpl) GetUsersWithPhotos(ctx context.Context, limit, offset int) ([]*entities.User, error) {
+	hasPhotos := true
+	return r.findUsers(ctx, repositories.UserFilter{HasPhotos: &hasPhotos}, limit, offset)
+}
+
+// GetUsersWithoutPhotos retrieves users who don't have photos
+//
+// Deprecated: use FindUsers with UserFilter.HasPhotos
+func (r *UserRepositoryImpl) GetUsersWithoutPhotos(ctx context.Context, limit, offset int) ([]*entities.User, error) {
+	hasPhotos := false
+	return r.findUsers(ctx, repositories.UserFilter{HasPhotos: &hasPhotos}, limit, offset)
+}
+
+// GetInactiveUsers retrieves inactive users
+//
+// Deprecated: use FindUsers with UserFilter.InactiveSince
+func (r *UserRepositoryImpl) GetInactiveUsers(ctx context.Context, days int, limit, offset int) ([]*entities.User, error) {
+	cutoffDate := time.Now().AddDate(-days, 0, 0)
+	return r.findUsers(ctx, repositories.UserFilter{InactiveSince: &cutoffDate}, limit, offset)
+}
+
+// findUsers runs the filtered user query without clamping the page size, for the deprecated getters
+func (r *UserRepositoryImpl) findUsers(ctx context.Context, filter repositories.UserFilter, limit, offset int) ([]*entities.User, error) {
+	query := applyUserFilter(r.db.WithContext(ctx).Model(&models.User{}), filter)
+
 	var users []models.User
-	if err := r.db.WithContext(ctx).Where("EXISTS (SELECT 1 FROM photos WHERE user_id = users.id AND is_deleted = ?)", false).Limit(limit).Offset(offset).Find(&users).Error; err != nil {
-		logger.Error("Failed to get users with photos", err)
-		return nil, fmt.Errorf("failed to get users with photos: %w", err)
+	if err := query.Order("users.last_active DESC NULLS LAST").Order("users.id").Limit(limit).Offset(offset).Find(&users).Error; err != nil {
+		logger.Error("Failed to find users", err)
+		return nil, fmt.Errorf("failed to find users: %w", err)
 	}
 
 	// Convert to domain entities
 	domainUsers := make([]*entities.User, len(users))
-	for i, User := range users {
-		domainUsers[i] = r.modelToDomainUser(&User)
+	for i := range users {
+		domainUsers[i] = r.modelToDomainUser(&users[i])
 	}
 
 	return domainUsers, nil
 }
 
-// GetUsersWithoutPhotos retrieves users who don't have photos
-func (r *UserRepositoryImpl) GetUsersWithoutPhotos(ctx context.Context, limit, offset int) ([]*entities.User, error) {
-	var users []models.User
-	if err := r.db.WithContext(ctx).Where("NOT EXISTS (SELECT 1 FROM photos WHERE user_id = users.id AND is_deleted = ?)", false).Limit(limit).Offset(offset).Find(&users).Error; err != nil {
-		logger.Error("Failed to get users without photos", err)
-		return nil, fmt.Errorf("failed to get users without photos: %w", err)
+// applyUserFilter adds a condition to the query for every filter that is set
+func applyUserFilter(query *gorm.DB, filter repositories.UserFilter) *gorm.DB {
+	query = query.Where("users.deleted_at IS NULL")
+
+	if len(filter.ExcludeIDs) > 0 {
+		query = query.Where("users.id NOT IN ?", filter.ExcludeIDs)
+	}
+	if filter.Discoverable {
+		query = query.Where("users.is_active = ? AND users.is_banned = ? AND users.profile_under_review = ? AND users.shadowbanned = ?", true, false, false, false).
+			Where("NOT EXISTS (SELECT 1 FROM user_preferences WHERE user_preferences.user_id = users.id AND user_preferences.show_me = ?)", false)
 	}
 
-	// Convert to domain entities
-	domainUsers := make([]*entities.User, len(users))
-	for i, User := range users {
-		domainUsers[i] = r.modelToDomainUser(&User)
+	// Age is compared on date of birth so the date_of_birth index can be used
+	now := time.Now()
+	if filter.MinAge != nil {
+		query = query.Where("users.date_of_birth <= ?", now.AddDate(-*filter.MinAge, 0, 0))
+	}
+	if filter.MaxAge != nil {
+		query = query.Where("users.date_of_birth > ?", now.AddDate(-*filter.MaxAge-1, 0, 0))
 	}
 
-	return domainUsers, nil
-}
+	if len(filter.Genders) > 0 {
+		query = query.Where("users.gender IN ?", filter.Genders)
+	}
+	if filter.InterestedIn != nil {
+		query = query.Where("? = ANY(users.interested_in)", *filter.InterestedIn)
+	}
+	if filter.Location != nil {
+		query = applyUserLocationFilter(query, filter.Location)
+	}
 
-// GetInactiveUsers retrieves inactive users
-func (r *UserRepositoryImpl) GetInactiveUsers(ctx context.Context, days int, limit, offset int) ([]*entities.User, error) {
-	cutoffDate := time.Now().AddDate(-days, 0, 0)
-	
-	var users []models.User
-	if err := r.db.WithContext(ctx).Where("last_active < ? OR last_active IS NULL", cutoffDate).Limit(limit).Offset(offset).Find(&users).Error; err != nil {
-		logger.Error("Failed to get inactive users", err)
-		return nil, fmt.Errorf("failed to get inactive users: %w", err)
+	if filter.IsVerified != nil {
+		query = query.Where("users.is_verified = ?", *filter.IsVerified)
+	}
+	if filter.IsPremium != nil {
+		query = query.Where("users.is_premium = ?", *filter.IsPremium)
+	}
+	if filter.IsBanned != nil {
+		query = query.Where("users.is_banned = ?", *filter.IsBanned)
+	}
+	if filter.HasPhotos != nil {
+		photos := "EXISTS (SELECT 1 FROM photos WHERE photos.user_id = users.id AND photos.is_deleted = ?)"
+		if !*filter.HasPhotos {
+			photos = "NOT " + photos
+		}
+		query = query.Where(photos, false)
 	}
 
-	// Convert to domain entities
-	domainUsers := make([]*entities.User, len(users))
-	for i, User := range users {
-		domainUsers[i] = r.modelToDomainUser(&User)
+	if filter.MinHeightCm != nil {
+		query = query.Where("users.height_cm >= ?", *filter.MinHeightCm)
+	}
+	if filter.MaxHeightCm != nil {
+		query = query.Where("users.height_cm <= ?", *filter.MaxHeightCm)
+	}
+	if len(filter.Smoking) > 0 {
+		query = query.Where("users.smoking IN ?", filter.Smoking)
+	}
+	if len(filter.Drinking) > 0 {
+		query = query.Where("users.drinking IN ?", filter.Drinking)
 	}
 
-	return domainUsers, nil
+	if filter.ActiveSince != nil {
+		query = query.Where("users.last_active >= ?", *filter.ActiveSince)
+	}
+	if filter.InactiveSince != nil {
+		query = query.Where("users.last_active < ? OR users.last_active IS NULL", *filter.InactiveSince)
+	}
+
+	return query
+}
+
+// applyUserLocationFilter restricts the query to users within the radius. A bounding box is checked
+// first so the exact great-circle distance is only computed for nearby rows.
+func applyUserLocationFilter(query *gorm.DB, location *repositories.UserLocationFilter) *gorm.DB {
+	const kmPerDegree = 111.045
+
+	latDelta := location.RadiusKm / kmPerDegree
+	query = query.Where("users.location_lat BETWEEN ? AND ?", location.Lat-latDelta, location.Lat+latDelta)
+
+	// Skip the longitude bounds near the poles or when the box would wrap around the antimeridian
+	cosLat := math.Cos(location.Lat * math.Pi / 180)
+	if cosLat > 0.01 {
+		lngDelta := latDelta / cosLat
+		if location.Lng-lngDelta >= -180 && location.Lng+lngDelta <= 180 {
+			query = query.Where("users.location_lng BETWEEN ? AND ?", location.Lng-lngDelta, location.Lng+lngDelta)
+		}
+	}
+
+	return query.Where(
+		"6371 * acos(LEAST(1, cos(radians(?)) * cos(radians(users.location_lat)) * cos(radians(users.location_lng) - radians(?)) + sin(radians(?)) * sin(radians(users.location_lat)))) <= ?",
+		location.Lat, location.Lng, location.Lat, location.RadiusKm,
+	)
 }
 
 // Helper methods to convert between domain and model entities
@@ -579,15 +699,27 @@ func (r *UserRepositoryImpl) modelToDomainUser(model *models.User) *entities.Use
 		DateOfBirth:    model.DateOfBirth,
 		Gender:         model.Gender,
 		InterestedIn:   model.InterestedIn,
+		Interests:      model.Interests,
 		Bio:            model.Bio,
 		LocationLat:    model.LocationLat,
 		LocationLng:    model.LocationLng,
 		LocationCity:   model.LocationCity,
 		LocationCountry: model.LocationCountry,
+		HeightCm:       model.HeightCm,
+		Smoking:        model.Smoking,
+		Drinking:       model.Drinking,
+		Locale:         model.Locale,
+		Timezone:       model.Timezone,
+		AutoTranslateMessages: model.AutoTranslateMessages,
+		SendReadReceipts: model.SendReadReceipts,
+		AutoIntro:      model.AutoIntro,
 		IsVerified:     model.IsVerified,
 		IsPremium:      model.IsPremium,
 		IsActive:       model.IsActive,
 		IsBanned:       model.IsBanned,
+		GeofenceExempt: model.GeofenceExempt,
+		ProfileUnderReview: model.ProfileUnderReview,
+		Shadowbanned:   model.Shadowbanned,
 		LastActive:     model.LastActive,
 		CreatedAt:      model.CreatedAt,
 		UpdatedAt:      model.UpdatedAt,
@@ -605,15 +737,27 @@ func (r *UserRepositoryImpl) domainToModelUser(user *entities.User) *models.User
 		DateOfBirth:    user.DateOfBirth,
 		Gender:         user.Gender,
 		InterestedIn:   user.InterestedIn,
+		Interests:      user.Interests,
 		Bio:            user.Bio,
 		LocationLat:    user.LocationLat,
 		LocationLng:    user.LocationLng,
 		LocationCity:   user.LocationCity,
 		LocationCountry: user.LocationCountry,
+		HeightCm:       user.HeightCm,
+		Smoking:        user.Smoking,
+		Drinking:       user.Drinking,
+		Locale:         user.Locale,
+		Timezone:       user.Timezone,
+		AutoTranslateMessages: user.AutoTranslateMessages,
+		SendReadReceipts: user.SendReadReceipts,
+		AutoIntro:      user.AutoIntro,
 		IsVerified:     user.IsVerified,
 		IsPremium:      user.IsPremium,
 		IsActive:       user.IsActive,
 		IsBanned:       user.IsBanned,
+		GeofenceExempt: user.GeofenceExempt,
+		ProfileUnderReview: user.ProfileUnderReview,
+		Shadowbanned:   user.Shadowbanned,
 		LastActive:     user.LastActive,
 		CreatedAt:      user.CreatedAt,
 		UpdatedAt:      user.UpdatedAt,
@@ -629,6 +773,8 @@ func (r *UserRepositoryImpl) modelToDomainUserPreferences(model *models.UserPref
 		AgeMax:      model.AgeMax,
 		MaxDistance: model.MaxDistance,
 		ShowMe:      model.ShowMe,
+		HiddenFields: model.HiddenFields,
+		AutoReorderPhotos: model.AutoReorderPhotos,
 		CreatedAt:   model.CreatedAt,
 		UpdatedAt:   model.UpdatedAt,
 	}
@@ -643,6 +789,8 @@ func (r *UserRepositoryImpl) domainToModelUserPreferences(preferences *entities.
 		AgeMax:      preferences.AgeMax,
 		MaxDistance: preferences.MaxDistance,
 		ShowMe:      preferences.ShowMe,
+		HiddenFields: preferences.HiddenFields,
+		AutoReorderPhotos: preferences.AutoReorderPhotos,
 		CreatedAt:   preferences.CreatedAt,
 		UpdatedAt:   preferences.UpdatedAt,
 	}
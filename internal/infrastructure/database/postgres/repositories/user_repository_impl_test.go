@@ -0,0 +1,274 @@
+package repositories
+
+import (
+	"context"
+	"os"
+	"regexp"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+
+	"github.com/22smeargle/winkr-backend/internal/domain/entities"
+	"github.com/22smeargle/winkr-backend/internal/domain/repositories"
+)
+
+func newMockUserRepository(t *testing.T) (*UserRepositoryImpl, sqlmock.Sqlmock) {
+	sqlDB, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	t.Cleanup(func() { sqlDB.Close() })
+
+	db, err := gorm.Open(postgres.New(postgres.Config{Conn: sqlDB}), &gorm.Config{
+		Logger: logger.Default.LogMode(logger.Silent),
+	})
+	require.NoError(t, err)
+
+	return &UserRepositoryImpl{db: db}, mock
+}
+
+// queryPattern matches a query containing the fragments in order
+func queryPattern(fragments ...string) string {
+	quoted := make([]string, len(fragments))
+	for i, fragment := range fragments {
+		quoted[i] = regexp.QuoteMeta(fragment)
+	}
+	return strings.Join(quoted, ".*")
+}
+
+func TestUserRepository_FindUsers_DiscoverableAgeAndGender(t *testing.T) {
+	repo, mock := newMockUserRepository(t)
+
+	excluded := uuid.New()
+	minAge, maxAge := 25, 35
+	match := uuid.New()
+
+	mock.ExpectQuery(queryPattern(
+		`SELECT * FROM "users" WHERE users.deleted_at IS NULL`,
+		`AND users.id NOT IN ($1)`,
+		`AND (users.is_active = $2 AND users.is_banned = $3 AND users.profile_under_review = $4)`,
+		`AND (NOT EXISTS (SELECT 1 FROM user_preferences WHERE user_preferences.user_id = users.id AND user_preferences.show_me = $5))`,
+		`AND users.date_of_birth <= $6`,
+		`AND users.date_of_birth > $7`,
+		`AND users.gender IN ($8,$9)`,
+		`ORDER BY users.last_active DESC NULLS LAST,users.id LIMIT 20`,
+	)).
+		WithArgs(excluded, true, false, false, false, sqlmock.AnyArg(), sqlmock.AnyArg(), "female", "other").
+		WillReturnRows(sqlmock.NewRows([]string{"id", "first_name", "gender"}).AddRow(match, "Ana", "female"))
+
+	users, err := repo.FindUsers(context.Background(), repositories.UserFilter{
+		ExcludeIDs:   []uuid.UUID{excluded},
+		Discoverable: true,
+		MinAge:       &minAge,
+		MaxAge:       &maxAge,
+		Genders:      []string{"female", "other"},
+	}, repositories.Pagination{})
+	require.NoError(t, err)
+	require.NoError(t, mock.ExpectationsWereMet())
+
+	require.Len(t, users, 1)
+	assert.Equal(t, match, users[0].ID)
+	assert.Equal(t, "Ana", users[0].FirstName)
+	assert.Equal(t, "female", users[0].Gender)
+}
+
+func TestUserRepository_FindUsers_LocationVerificationAndAttributes(t *testing.T) {
+	repo, mock := newMockUserRepository(t)
+
+	verified := true
+	minHeight, maxHeight := 165, 185
+	interestedIn := "male"
+
+	mock.ExpectQuery(queryPattern(
+		`WHERE users.deleted_at IS NULL`,
+		`AND $1 = ANY(users.interested_in)`,
+		`AND (users.location_lat BETWEEN $2 AND $3)`,
+		`AND (users.location_lng BETWEEN $4 AND $5)`,
+		`AND 6371 * acos(`,
+		`) <= $9`,
+		`AND users.is_verified = $10`,
+		`AND users.height_cm >= $11`,
+		`AND users.height_cm <= $12`,
+		`AND users.smoking IN ($13)`,
+		`AND users.drinking IN ($14,$15)`,
+		`LIMIT 10 OFFSET 30`,
+	)).
+		WithArgs(
+			"male",
+			sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg(),
+			52.52, 13.40, 52.52, 25.0,
+			true, 165, 185,
+			entities.LifestyleHabitNever,
+			entities.LifestyleHabitNever, entities.LifestyleHabitSocially,
+		).
+		WillReturnRows(sqlmock.NewRows([]string{"id"}))
+
+	users, err := repo.FindUsers(context.Background(), repositories.UserFilter{
+		InterestedIn: &interestedIn,
+		Location:     &repositories.UserLocationFilter{Lat: 52.52, Lng: 13.40, RadiusKm: 25},
+		IsVerified:   &verified,
+		MinHeightCm:  &minHeight,
+		MaxHeightCm:  &maxHeight,
+		Smoking:      []string{entities.LifestyleHabitNever},
+		Drinking:     []string{entities.LifestyleHabitNever, entities.LifestyleHabitSocially},
+	}, repositories.Pagination{Limit: 10, Offset: 30})
+	require.NoError(t, err)
+	require.NoError(t, mock.ExpectationsWereMet())
+	assert.Empty(t, users)
+}
+
+func TestUserRepository_FindUsers_PhotosAndInactivity(t *testing.T) {
+	repo, mock := newMockUserRepository(t)
+
+	hasPhotos := false
+	inactiveSince := time.Now().AddDate(0, 0, -30)
+
+	mock.ExpectQuery(queryPattern(
+		`WHERE users.deleted_at IS NULL`,
+		`AND (NOT EXISTS (SELECT 1 FROM photos WHERE photos.user_id = users.id AND photos.is_deleted = $1))`,
+		`AND (users.last_active < $2 OR users.last_active IS NULL)`,
+	)).
+		WithArgs(false, inactiveSince).
+		WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(uuid.New()).AddRow(uuid.New()))
+
+	users, err := repo.FindUsers(context.Background(), repositories.UserFilter{
+		HasPhotos:     &hasPhotos,
+		InactiveSince: &inactiveSince,
+	}, repositories.Pagination{Limit: 50})
+	require.NoError(t, err)
+	require.NoError(t, mock.ExpectationsWereMet())
+	assert.Len(t, users, 2)
+}
+
+func TestUserRepository_GetByLocation_NearestFirst(t *testing.T) {
+	repo, mock := newMockUserRepository(t)
+
+	near, far := uuid.New(), uuid.New()
+
+	mock.ExpectQuery(queryPattern(
+		`WHERE users.deleted_at IS NULL`,
+		`AND (users.is_active = $1 AND users.is_banned = $2 AND users.profile_under_review = $3 AND users.shadowbanned = $4)`,
+		`AND ST_DWithin(users.location_geog, ST_SetSRID(ST_MakePoint($6, $7), 4326)::geography, $8)`,
+		`ORDER BY users.location_geog <-> ST_SetSRID(ST_MakePoint($9, $10), 4326)::geography, users.id`,
+		`LIMIT 20 OFFSET 40`,
+	)).
+		WithArgs(true, false, false, false, false, 13.40, 52.52, 25000.0, 13.40, 52.52).
+		WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(near).AddRow(far))
+
+	users, err := repo.GetByLocation(context.Background(), 52.52, 13.40, 25, 20, 40)
+	require.NoError(t, err)
+	require.NoError(t, mock.ExpectationsWereMet())
+
+	require.Len(t, users, 2)
+	assert.Equal(t, near, users[0].ID)
+	assert.Equal(t, far, users[1].ID)
+}
+
+func TestUserRepository_GetActiveByLocation(t *testing.T) {
+	repo, mock := newMockUserRepository(t)
+
+	activeSince := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	mock.ExpectQuery(queryPattern(
+		`AND users.last_active >= $6`,
+		`AND ST_DWithin(users.location_geog, ST_SetSRID(ST_MakePoint($7, $8), 4326)::geography, $9)`,
+		`ORDER BY users.location_geog <-> ST_SetSRID(ST_MakePoint($10, $11), 4326)::geography, users.id`,
+	)).
+		WithArgs(true, false, false, false, false, activeSince, 13.40, 52.52, 25000.0, 13.40, 52.52).
+		WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(uuid.New()))
+
+	users, err := repo.GetActiveByLocation(context.Background(), 52.52, 13.40, 25, activeSince, 20, 0)
+	require.NoError(t, err)
+	require.NoError(t, mock.ExpectationsWereMet())
+	assert.Len(t, users, 1)
+}
+
+func TestUserRepository_FindUsers_Pagination(t *testing.T) {
+	tests := []struct {
+		name    string
+		page    repositories.Pagination
+		expects string
+	}{
+		{"defaults", repositories.Pagination{}, "LIMIT 20"},
+		{"offset", repositories.Pagination{Limit: 5, Offset: 15}, "LIMIT 5 OFFSET 15"},
+		{"clamped", repositories.Pagination{Limit: 500, Offset: -3}, "LIMIT 100"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo, mock := newMockUserRepository(t)
+
+			mock.ExpectQuery(regexp.QuoteMeta(`ORDER BY users.last_active DESC NULLS LAST,users.id `+tt.expects) + `$`).
+				WillReturnRows(sqlmock.NewRows([]string{"id"}))
+
+			_, err := repo.FindUsers(context.Background(), repositories.UserFilter{}, tt.page)
+			require.NoError(t, err)
+			require.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+// newBenchmarkUserRepository seeds users scattered over roughly 450 km around Berlin in
+// TEST_DATABASE_URL, a migrated PostGIS database, inside a transaction that is rolled back
+// afterwards. The benchmark is skipped when no database is configured.
+func newBenchmarkUserRepository(b *testing.B, users int) *UserRepositoryImpl {
+	dsn := os.Getenv("TEST_DATABASE_URL")
+	if dsn == "" {
+		b.Skip("TEST_DATABASE_URL not set")
+	}
+
+	db, err := gorm.Open(postgres.Open(dsn), &gorm.Config{
+		Logger: logger.Default.LogMode(logger.Silent),
+	})
+	require.NoError(b, err)
+	tx := db.Begin()
+	require.NoError(b, tx.Error)
+	b.Cleanup(func() {
+		tx.Rollback()
+		if sqlDB, err := db.DB(); err == nil {
+			sqlDB.Close()
+		}
+	})
+
+	require.NoError(b, tx.Exec(`
+		INSERT INTO users (email, password_hash, first_name, last_name, date_of_birth, gender, interested_in,
+			location_lat, location_lng, is_active, is_banned, last_active)
+		SELECT 'bench-' || gen_random_uuid() || '@example.com', 'x', 'Bench', 'User', DATE '1995-01-01', 'female', ARRAY['male'],
+			52.52 + (random() - 0.5) * 4, 13.40 + (random() - 0.5) * 4, true, false, NOW()
+		FROM generate_series(1, ?)`, users).Error)
+	require.NoError(b, tx.Exec("ANALYZE users").Error)
+
+	return &UserRepositoryImpl{db: tx}
+}
+
+// BenchmarkUserRepository_GetByLocation compares the PostGIS query with the bounding box and
+// great-circle filter GetByLocation used before, which FindUsers still uses
+func BenchmarkUserRepository_GetByLocation(b *testing.B) {
+	repo := newBenchmarkUserRepository(b, 50000)
+	ctx := context.Background()
+
+	b.Run("bounding_box", func(b *testing.B) {
+		filter := repositories.UserFilter{
+			Discoverable: true,
+			Location:     &repositories.UserLocationFilter{Lat: 52.52, Lng: 13.40, RadiusKm: 25},
+		}
+		for i := 0; i < b.N; i++ {
+			if _, err := repo.findUsers(ctx, filter, 20, 0); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+
+	b.Run("postgis", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			if _, err := repo.GetByLocation(ctx, 52.52, 13.40, 25, 20, 0); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+}
@@ -164,6 +164,33 @@ func (r *VerificationRepositoryImpl) GetVerificationStats(ctx context.Context) (
 	return &stats, nil
 }
 
+// CreateVerificationDocument creates a new verification document
+func (r *VerificationRepositoryImpl) CreateVerificationDocument(ctx context.Context, document *entities.VerificationDocument) error {
+	model := r.documentEntityToModel(document)
+	return r.db.WithContext(ctx).Create(model).Error
+}
+
+// GetVerificationDocuments gets all documents submitted for a verification session
+func (r *VerificationRepositoryImpl) GetVerificationDocuments(ctx context.Context, verificationID uuid.UUID) ([]*entities.VerificationDocument, error) {
+	var models []models.VerificationDocument
+	err := r.db.WithContext(ctx).
+		Where("verification_id = ?", verificationID).
+		Order("created_at ASC").
+		Find(&models).Error
+	
+	if err != nil {
+		return nil, err
+	}
+	
+	return r.documentModelsToEntities(models), nil
+}
+
+// UpdateVerificationDocument updates a verification document
+func (r *VerificationRepositoryImpl) UpdateVerificationDocument(ctx context.Context, document *entities.VerificationDocument) error {
+	model := r.documentEntityToModel(document)
+	return r.db.WithContext(ctx).Save(model).Error
+}
+
 // CreateVerificationAttempt creates a new verification attempt
 func (r *VerificationRepositoryImpl) CreateVerificationAttempt(ctx context.Context, attempt *entities.VerificationAttempt) error {
 	model := r.attemptEntityToModel(attempt)
@@ -273,6 +300,18 @@ func (r *VerificationRepositoryImpl) DeleteExpiredBadges(ctx context.Context) er
 		Delete(&models.VerificationBadge{}).Error
 }
 
+// GetUsersWithExpiredBadges gets the users with a badge that expired before the given time and was not revoked
+func (r *VerificationRepositoryImpl) GetUsersWithExpiredBadges(ctx context.Context, before time.Time) ([]uuid.UUID, error) {
+	var userIDs []uuid.UUID
+	err := r.db.WithContext(ctx).
+		Model(&models.VerificationBadge{}).
+		Where("is_revoked = ? AND expires_at IS NOT NULL AND expires_at < ?", false, before).
+		Distinct().
+		Pluck("user_id", &userIDs).Error
+
+	return userIDs, err
+}
+
 // GetUserVerificationLevel gets a user's verification level
 func (r *VerificationRepositoryImpl) GetUserVerificationLevel(ctx context.Context, userID uuid.UUID) (entities.VerificationLevel, error) {
 	var user models.User
@@ -443,6 +482,56 @@ func (r *VerificationRepositoryImpl) modelsToEntities(models []models.Verificati
 	return entities
 }
 
+func (r *VerificationRepositoryImpl) documentEntityToModel(entity *entities.VerificationDocument) *models.VerificationDocument {
+	if entity == nil {
+		return nil
+	}
+	
+	return &models.VerificationDocument{
+		ID:              entity.ID,
+		VerificationID:  entity.VerificationID,
+		UserID:          entity.UserID,
+		DocumentType:    entity.DocumentType,
+		Status:          string(entity.Status),
+		PhotoURL:        entity.PhotoURL,
+		PhotoKey:        entity.PhotoKey,
+		Confidence:      entity.Confidence,
+		DocumentData:    entity.DocumentData,
+		RejectionReason: entity.RejectionReason,
+		CreatedAt:       entity.CreatedAt,
+		UpdatedAt:       entity.UpdatedAt,
+	}
+}
+
+func (r *VerificationRepositoryImpl) documentModelToEntity(model *models.VerificationDocument) *entities.VerificationDocument {
+	if model == nil {
+		return nil
+	}
+	
+	return &entities.VerificationDocument{
+		ID:              model.ID,
+		VerificationID:  model.VerificationID,
+		UserID:          model.UserID,
+		DocumentType:    model.DocumentType,
+		Status:          valueobjects.VerificationStatus(model.Status),
+		PhotoURL:        model.PhotoURL,
+		PhotoKey:        model.PhotoKey,
+		Confidence:      model.Confidence,
+		DocumentData:    model.DocumentData,
+		RejectionReason: model.RejectionReason,
+		CreatedAt:       model.CreatedAt,
+		UpdatedAt:       model.UpdatedAt,
+	}
+}
+
+func (r *VerificationRepositoryImpl) documentModelsToEntities(models []models.VerificationDocument) []*entities.VerificationDocument {
+	entities := make([]*entities.VerificationDocument, len(models))
+	for i, model := range models {
+		entities[i] = r.documentModelToEntity(&model)
+	}
+	return entities
+}
+
 func (r *VerificationRepositoryImpl) attemptEntityToModel(entity *entities.VerificationAttempt) *models.VerificationAttempt {
 	if entity == nil {
 		return nil
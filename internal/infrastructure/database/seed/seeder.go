@@ -0,0 +1,384 @@
+package seed
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/rand"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/22smeargle/winkr-backend/internal/domain/entities"
+	"github.com/22smeargle/winkr-backend/internal/domain/repositories"
+	"github.com/22smeargle/winkr-backend/pkg/config"
+	"github.com/22smeargle/winkr-backend/pkg/logger"
+	"github.com/22smeargle/winkr-backend/pkg/utils"
+)
+
+// DemoPassword is the password of every seeded user
+const DemoPassword = "winkr-demo"
+
+// ErrProductionEnvironment is returned when seeding is attempted in production
+var ErrProductionEnvironment = errors.New("demo data cannot be seeded in production")
+
+// namespace scopes the IDs of seeded records so they never collide with real ones
+var namespace = uuid.MustParse("6f1c8f52-4b7e-4a8e-9d43-2f0e5c1a7b90")
+
+// Options configures how much demo data is seeded
+type Options struct {
+	// Seed makes runs reproducible: the same seed always produces the same records
+	Seed             int64
+	Users            int
+	Swipes           int
+	Matches          int
+	MessagesPerMatch int
+}
+
+// DefaultOptions returns options for a small demo data set
+func DefaultOptions() Options {
+	return Options{
+		Seed:             1,
+		Users:            50,
+		Swipes:           400,
+		Matches:          40,
+		MessagesPerMatch: 6,
+	}
+}
+
+// Validate checks the options describe a data set that can be generated
+func (o Options) Validate() error {
+	if o.Users < 2 {
+		return fmt.Errorf("users must be at least 2")
+	}
+	if o.Matches < 0 || o.Swipes < 0 || o.MessagesPerMatch < 0 {
+		return fmt.Errorf("counts must not be negative")
+	}
+	// Outside of matches a pair of users swipes in one direction only, so there is one swipe per pair at most
+	maxSwipes := o.Users * (o.Users - 1) / 2
+	if o.Swipes > maxSwipes {
+		return fmt.Errorf("swipes must be at most %d for %d users", maxSwipes, o.Users)
+	}
+	if o.Matches*2 > o.Swipes {
+		return fmt.Errorf("swipes must be at least twice the number of matches")
+	}
+	return nil
+}
+
+// Result reports how many records a run created. Records left by an earlier run with the same seed are not counted.
+type Result struct {
+	Users         int `json:"users"`
+	Swipes        int `json:"swipes"`
+	Matches       int `json:"matches"`
+	Conversations int `json:"conversations"`
+	Messages      int `json:"messages"`
+}
+
+// Seeder populates the database with fake users, swipes, matches and messages for demos and local testing
+type Seeder struct {
+	appConfig   *config.AppConfig
+	userRepo    repositories.UserRepository
+	matchRepo   repositories.MatchRepository
+	messageRepo repositories.MessageRepository
+	now         func() time.Time
+}
+
+// NewSeeder creates a new Seeder
+func NewSeeder(
+	appConfig *config.AppConfig,
+	userRepo repositories.UserRepository,
+	matchRepo repositories.MatchRepository,
+	messageRepo repositories.MessageRepository,
+) *Seeder {
+	return &Seeder{
+		appConfig:   appConfig,
+		userRepo:    userRepo,
+		matchRepo:   matchRepo,
+		messageRepo: messageRepo,
+		now:         time.Now,
+	}
+}
+
+// CheckEnvironment returns ErrProductionEnvironment when the app runs in production
+func CheckEnvironment(appConfig *config.AppConfig) error {
+	if appConfig == nil || appConfig.Env == "production" {
+		return ErrProductionEnvironment
+	}
+	return nil
+}
+
+// Run seeds the demo data set. Records are generated from the seed with stable IDs, so re-running
+// with the same options only creates what is missing.
+func (s *Seeder) Run(ctx context.Context, opts Options) (*Result, error) {
+	if err := CheckEnvironment(s.appConfig); err != nil {
+		return nil, err
+	}
+	if err := opts.Validate(); err != nil {
+		return nil, fmt.Errorf("invalid seed options: %w", err)
+	}
+
+	logger.Info("Seeding demo data", "seed", opts.Seed, "users", opts.Users, "swipes", opts.Swipes, "matches", opts.Matches)
+
+	passwordHash, err := utils.HashPassword(DemoPassword)
+	if err != nil {
+		return nil, err
+	}
+
+	data := generate(opts, s.now())
+	result := &Result{}
+
+	for _, user := range data.users {
+		exists, err := s.userRepo.ExistsByID(ctx, user.ID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to check user: %w", err)
+		}
+		if exists {
+			continue
+		}
+		user.PasswordHash = passwordHash
+		if err := s.userRepo.Create(ctx, user); err != nil {
+			return nil, fmt.Errorf("failed to create user: %w", err)
+		}
+		result.Users++
+	}
+
+	for _, swipe := range data.swipes {
+		exists, err := s.matchRepo.SwipeExists(ctx, swipe.SwiperID, swipe.SwipedID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to check swipe: %w", err)
+		}
+		if exists {
+			continue
+		}
+		if err := s.matchRepo.CreateSwipe(ctx, swipe); err != nil {
+			return nil, fmt.Errorf("failed to create swipe: %w", err)
+		}
+		result.Swipes++
+	}
+
+	for i, match := range data.matches {
+		exists, err := s.matchRepo.MatchExists(ctx, match.User1ID, match.User2ID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to check match: %w", err)
+		}
+		if !exists {
+			if err := s.matchRepo.CreateMatch(ctx, match); err != nil {
+				return nil, fmt.Errorf("failed to create match: %w", err)
+			}
+			if err := s.messageRepo.CreateConversation(ctx, data.conversations[i]); err != nil {
+				return nil, fmt.Errorf("failed to create conversation: %w", err)
+			}
+			result.Matches++
+			result.Conversations++
+		}
+	}
+
+	// Messages carry a stable client message ID, which makes them safe to re-create
+	for _, message := range data.messages {
+		existing, err := s.messageRepo.GetBySenderAndClientMessageID(ctx, message.SenderID, *message.ClientMessageID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to check message: %w", err)
+		}
+		if existing != nil {
+			continue
+		}
+		if err := s.messageRepo.Create(ctx, message); err != nil {
+			return nil, fmt.Errorf("failed to create message: %w", err)
+		}
+		result.Messages++
+	}
+
+	logger.Info("Demo data seeded", "seed", opts.Seed, "users", result.Users, "swipes", result.Swipes,
+		"matches", result.Matches, "messages", result.Messages)
+	return result, nil
+}
+
+// dataSet holds the records generated for one seed
+type dataSet struct {
+	users         []*entities.User
+	swipes        []*entities.Swipe
+	matches       []*entities.Match
+	conversations []*entities.Conversation
+	messages      []*entities.Message
+}
+
+// generate builds the data set for the options. It only draws from a generator seeded with
+// opts.Seed, so the same options always yield the same records.
+func generate(opts Options, now time.Time) *dataSet {
+	rng := rand.New(rand.NewSource(opts.Seed))
+	data := &dataSet{}
+
+	for i := 0; i < opts.Users; i++ {
+		data.users = append(data.users, generateUser(rng, opts.Seed, i, now))
+	}
+
+	// swiped records who swiped on whom; a mutual like is only ever created as a match
+	swiped := make(map[[2]int]bool)
+	randomPair := func() (int, int) {
+		for {
+			a, b := rng.Intn(opts.Users), rng.Intn(opts.Users)
+			if a != b && !swiped[[2]int{a, b}] && !swiped[[2]int{b, a}] {
+				return a, b
+			}
+		}
+	}
+	addSwipe := func(swiper, swipedUser int, isLike bool, at time.Time) {
+		swiped[[2]int{swiper, swipedUser}] = true
+		data.swipes = append(data.swipes, &entities.Swipe{
+			ID:        stableID(opts.Seed, "swipe", swiper, swipedUser),
+			SwiperID:  data.users[swiper].ID,
+			SwipedID:  data.users[swipedUser].ID,
+			IsLike:    isLike,
+			CreatedAt: at,
+		})
+	}
+
+	for i := 0; i < opts.Matches; i++ {
+		a, b := randomPair()
+		matchedAt := now.Add(-time.Duration(rng.Intn(30*24)) * time.Hour)
+		addSwipe(a, b, true, matchedAt.Add(-time.Duration(1+rng.Intn(48))*time.Hour))
+		addSwipe(b, a, true, matchedAt)
+
+		match := &entities.Match{
+			ID:        stableID(opts.Seed, "match", a, b),
+			User1ID:   data.users[a].ID,
+			User2ID:   data.users[b].ID,
+			MatchedAt: matchedAt,
+			IsActive:  true,
+			CreatedAt: matchedAt,
+		}
+		conversation := &entities.Conversation{
+			ID:        stableID(opts.Seed, "conversation", a, b),
+			MatchID:   match.ID,
+			CreatedAt: matchedAt,
+			UpdatedAt: matchedAt,
+		}
+		data.matches = append(data.matches, match)
+		data.conversations = append(data.conversations, conversation)
+
+		sentAt := matchedAt
+		for m := 0; m < opts.MessagesPerMatch; m++ {
+			sender := a
+			if m%2 == 1 {
+				sender = b
+			}
+			sentAt = sentAt.Add(time.Duration(1+rng.Intn(180)) * time.Minute)
+			clientMessageID := fmt.Sprintf("seed-%d-%d-%d", opts.Seed, i, m)
+			data.messages = append(data.messages, &entities.Message{
+				ID:              stableID(opts.Seed, "message", i, m),
+				ConversationID:  conversation.ID,
+				SenderID:        data.users[sender].ID,
+				Content:         messageLines[rng.Intn(len(messageLines))],
+				MessageType:     "text",
+				IsRead:          m < opts.MessagesPerMatch-1,
+				ClientMessageID: &clientMessageID,
+				CreatedAt:       sentAt,
+			})
+		}
+	}
+
+	// The remaining swipes are one-sided, so none of them forms a match
+	for len(data.swipes) < opts.Swipes {
+		a, b := randomPair()
+		addSwipe(a, b, rng.Intn(10) < 6, now.Add(-time.Duration(rng.Intn(30*24))*time.Hour))
+	}
+
+	return data
+}
+
+// generateUser builds the i-th demo user
+func generateUser(rng *rand.Rand, seed int64, i int, now time.Time) *entities.User {
+	gender := genders[rng.Intn(len(genders))]
+	firstNames := firstNamesByGender[gender]
+	city := cities[rng.Intn(len(cities))]
+
+	lat := city.lat + (rng.Float64()-0.5)*0.1
+	lng := city.lng + (rng.Float64()-0.5)*0.1
+	cityName, country := city.name, city.country
+	bio := bios[rng.Intn(len(bios))]
+	heightCm := 155 + rng.Intn(40)
+	lastActive := now.Add(-time.Duration(rng.Intn(72)) * time.Hour)
+	createdAt := now.Add(-time.Duration(30+rng.Intn(335)) * 24 * time.Hour)
+
+	return &entities.User{
+		ID:              stableID(seed, "user", i),
+		Email:           fmt.Sprintf("demo+%d-%d@winkr.test", seed, i),
+		FirstName:       firstNames[rng.Intn(len(firstNames))],
+		LastName:        lastNames[rng.Intn(len(lastNames))],
+		DateOfBirth:     now.AddDate(-(18 + rng.Intn(30)), -rng.Intn(12), -rng.Intn(28)),
+		Gender:          gender,
+		InterestedIn:    interests[rng.Intn(len(interests))],
+		Bio:             &bio,
+		LocationLat:     &lat,
+		LocationLng:     &lng,
+		LocationCity:    &cityName,
+		LocationCountry: &country,
+		HeightCm:        &heightCm,
+		IsVerified:      rng.Intn(2) == 0,
+		IsActive:        true,
+		LastActive:      &lastActive,
+		CreatedAt:       createdAt,
+		UpdatedAt:       createdAt,
+	}
+}
+
+// stableID derives the ID of a seeded record from the seed and the record's position
+func stableID(seed int64, kind string, parts ...int) uuid.UUID {
+	return uuid.NewSHA1(namespace, []byte(fmt.Sprintf("%d:%s:%v", seed, kind, parts)))
+}
+
+var genders = []string{"male", "female", "other"}
+
+var interests = [][]string{
+	{"male"},
+	{"female"},
+	{"male", "female"},
+	{"male", "female", "other"},
+}
+
+var firstNamesByGender = map[string][]string{
+	"male":   {"Liam", "Noah", "Mateo", "Lucas", "Ethan", "Leo", "Omar", "Hugo", "Kenji", "Arjun"},
+	"female": {"Emma", "Olivia", "Sofia", "Mia", "Amara", "Chloe", "Yuki", "Priya", "Elena", "Zoe"},
+	"other":  {"Alex", "Sam", "Jordan", "Riley", "Charlie", "Robin", "Quinn", "Kai"},
+}
+
+var lastNames = []string{
+	"Smith", "Garcia", "Müller", "Rossi", "Kowalski", "Nguyen", "Silva", "Tanaka", "Okafor", "Johansson",
+	"Dubois", "Patel", "Kim", "Novak", "Hughes",
+}
+
+var cities = []struct {
+	name    string
+	country string
+	lat     float64
+	lng     float64
+}{
+	{"Berlin", "Germany", 52.5200, 13.4050},
+	{"London", "United Kingdom", 51.5074, -0.1278},
+	{"Madrid", "Spain", 40.4168, -3.7038},
+	{"New York", "United States", 40.7128, -74.0060},
+	{"Toronto", "Canada", 43.6532, -79.3832},
+}
+
+var bios = []string{
+	"Coffee first, adventures second.",
+	"Weekend hiker, weekday bookworm.",
+	"Looking for someone to share street food with.",
+	"Amateur chef, professional taste tester.",
+	"Dog person. Will show you pictures.",
+	"Trying every ramen place in town.",
+	"Ask me about my houseplants.",
+	"Live music, long walks, bad puns.",
+}
+
+var messageLines = []string{
+	"Hey! How's your week going?",
+	"Haha, I love that photo of yours",
+	"Have you been to the new place downtown?",
+	"I'm more of a sunrise person, honestly",
+	"That sounds amazing, tell me more!",
+	"What are you up to this weekend?",
+	"Coffee sometime?",
+	"I've never tried that, is it good?",
+	"Okay, you win this one 😄",
+	"Same here! Small world.",
+}
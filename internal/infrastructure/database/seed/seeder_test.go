@@ -0,0 +1,178 @@
+package seed
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/22smeargle/winkr-backend/internal/domain/entities"
+	"github.com/22smeargle/winkr-backend/internal/domain/repositories"
+	"github.com/22smeargle/winkr-backend/pkg/config"
+)
+
+// memoryUsers stores users in memory
+type memoryUsers struct {
+	repositories.UserRepository
+	users map[uuid.UUID]*entities.User
+}
+
+func (r *memoryUsers) ExistsByID(ctx context.Context, id uuid.UUID) (bool, error) {
+	_, ok := r.users[id]
+	return ok, nil
+}
+
+func (r *memoryUsers) Create(ctx context.Context, user *entities.User) error {
+	r.users[user.ID] = user
+	return nil
+}
+
+// memoryMatches stores swipes and matches in memory
+type memoryMatches struct {
+	repositories.MatchRepository
+	swipes  map[[2]uuid.UUID]*entities.Swipe
+	matches map[[2]uuid.UUID]*entities.Match
+}
+
+func (r *memoryMatches) SwipeExists(ctx context.Context, swiperID, swipedID uuid.UUID) (bool, error) {
+	_, ok := r.swipes[[2]uuid.UUID{swiperID, swipedID}]
+	return ok, nil
+}
+
+func (r *memoryMatches) CreateSwipe(ctx context.Context, swipe *entities.Swipe) error {
+	r.swipes[[2]uuid.UUID{swipe.SwiperID, swipe.SwipedID}] = swipe
+	return nil
+}
+
+func (r *memoryMatches) MatchExists(ctx context.Context, user1ID, user2ID uuid.UUID) (bool, error) {
+	_, ok := r.matches[[2]uuid.UUID{user1ID, user2ID}]
+	_, reversed := r.matches[[2]uuid.UUID{user2ID, user1ID}]
+	return ok || reversed, nil
+}
+
+func (r *memoryMatches) CreateMatch(ctx context.Context, match *entities.Match) error {
+	r.matches[[2]uuid.UUID{match.User1ID, match.User2ID}] = match
+	return nil
+}
+
+// memoryMessages stores conversations and messages in memory
+type memoryMessages struct {
+	repositories.MessageRepository
+	conversations map[uuid.UUID]*entities.Conversation
+	messages      map[uuid.UUID]*entities.Message
+}
+
+func (r *memoryMessages) CreateConversation(ctx context.Context, conversation *entities.Conversation) error {
+	r.conversations[conversation.ID] = conversation
+	return nil
+}
+
+func (r *memoryMessages) GetBySenderAndClientMessageID(ctx context.Context, senderID uuid.UUID, clientMessageID string) (*entities.Message, error) {
+	for _, message := range r.messages {
+		if message.SenderID == senderID && *message.ClientMessageID == clientMessageID {
+			return message, nil
+		}
+	}
+	return nil, nil
+}
+
+func (r *memoryMessages) Create(ctx context.Context, message *entities.Message) error {
+	r.messages[message.ID] = message
+	return nil
+}
+
+type memoryStore struct {
+	users    *memoryUsers
+	matches  *memoryMatches
+	messages *memoryMessages
+}
+
+func newSeederFixture(env string) (*Seeder, *memoryStore) {
+	store := &memoryStore{
+		users: &memoryUsers{users: make(map[uuid.UUID]*entities.User)},
+		matches: &memoryMatches{
+			swipes:  make(map[[2]uuid.UUID]*entities.Swipe),
+			matches: make(map[[2]uuid.UUID]*entities.Match),
+		},
+		messages: &memoryMessages{
+			conversations: make(map[uuid.UUID]*entities.Conversation),
+			messages:      make(map[uuid.UUID]*entities.Message),
+		},
+	}
+	seeder := NewSeeder(&config.AppConfig{Env: env}, store.users, store.matches, store.messages)
+	seeder.now = func() time.Time { return time.Date(2025, 6, 1, 12, 0, 0, 0, time.UTC) }
+	return seeder, store
+}
+
+func TestSeeder_CreatesRequestedCounts(t *testing.T) {
+	seeder, store := newSeederFixture("development")
+	opts := Options{Seed: 42, Users: 30, Swipes: 120, Matches: 15, MessagesPerMatch: 4}
+
+	result, err := seeder.Run(context.Background(), opts)
+	require.NoError(t, err)
+
+	assert.Equal(t, &Result{Users: 30, Swipes: 120, Matches: 15, Conversations: 15, Messages: 60}, result)
+	assert.Len(t, store.users.users, 30)
+	assert.Len(t, store.matches.swipes, 120)
+	assert.Len(t, store.matches.matches, 15)
+	assert.Len(t, store.messages.conversations, 15)
+	assert.Len(t, store.messages.messages, 60)
+
+	// Every match is backed by mutual likes, and no other pair liked each other
+	mutualLikes := 0
+	for key, swipe := range store.matches.swipes {
+		reverse, ok := store.matches.swipes[[2]uuid.UUID{key[1], key[0]}]
+		if ok && swipe.IsLike && reverse.IsLike {
+			mutualLikes++
+		}
+	}
+	assert.Equal(t, 2*opts.Matches, mutualLikes)
+
+	for _, message := range store.messages.messages {
+		assert.Contains(t, store.messages.conversations, message.ConversationID)
+		assert.Contains(t, store.users.users, message.SenderID)
+	}
+}
+
+func TestSeeder_IsIdempotentForTheSameSeed(t *testing.T) {
+	seeder, store := newSeederFixture("development")
+	opts := Options{Seed: 7, Users: 20, Swipes: 60, Matches: 10, MessagesPerMatch: 3}
+
+	_, err := seeder.Run(context.Background(), opts)
+	require.NoError(t, err)
+	firstUsers := make(map[uuid.UUID]string)
+	for id, user := range store.users.users {
+		firstUsers[id] = user.Email
+	}
+
+	result, err := seeder.Run(context.Background(), opts)
+	require.NoError(t, err)
+	assert.Equal(t, &Result{}, result, "a re-run creates nothing")
+	assert.Len(t, store.users.users, 20)
+	assert.Len(t, store.matches.swipes, 60)
+	assert.Len(t, store.messages.messages, 30)
+	for id, user := range store.users.users {
+		assert.Equal(t, firstUsers[id], user.Email)
+	}
+
+	// A different seed produces a separate data set
+	result, err = seeder.Run(context.Background(), Options{Seed: 8, Users: 20, Swipes: 60, Matches: 10, MessagesPerMatch: 3})
+	require.NoError(t, err)
+	assert.Equal(t, 20, result.Users)
+	assert.Len(t, store.users.users, 40)
+}
+
+func TestSeeder_RefusesProductionAndInvalidOptions(t *testing.T) {
+	seeder, store := newSeederFixture("production")
+	_, err := seeder.Run(context.Background(), DefaultOptions())
+	assert.ErrorIs(t, err, ErrProductionEnvironment)
+	assert.Empty(t, store.users.users)
+
+	seeder, _ = newSeederFixture("development")
+	_, err = seeder.Run(context.Background(), Options{Seed: 1, Users: 5, Swipes: 4, Matches: 3})
+	assert.Error(t, err, "matches need two swipes each")
+	_, err = seeder.Run(context.Background(), Options{Seed: 1, Users: 5, Swipes: 11})
+	assert.Error(t, err, "more swipes than pairs of users")
+}
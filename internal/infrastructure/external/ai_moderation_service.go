@@ -305,6 +305,54 @@ func (s *AIModerationService) analyzeImage(ctx context.Context, req ContentAnaly
 	return result, nil
 }
 
+// ImageModerationScores is how confident moderation is that an image shows each kind of content, from 0 to 1
+type ImageModerationScores struct {
+	NSFW     float64           `json:"nsfw"`
+	Violence float64           `json:"violence"`
+	Adult    float64           `json:"adult"`
+	Labels   []ModerationLabel `json:"labels"`
+}
+
+// ModerateImage scores the image stored under the key in the moderation bucket. Each score is the
+// confidence of the most confident label of its kind.
+func (s *AIModerationService) ModerateImage(ctx context.Context, key string) (*ImageModerationScores, error) {
+	output, err := s.client.DetectModerationLabels(ctx, &rekognition.DetectModerationLabelsInput{
+		Image: &types.Image{
+			S3Object: &types.S3Object{
+				Bucket: aws.String(s.bucket),
+				Name:   aws.String(key),
+			},
+		},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to detect moderation labels: %w", err)
+	}
+
+	scores := &ImageModerationScores{Labels: make([]ModerationLabel, 0, len(output.ModerationLabels))}
+	for _, label := range output.ModerationLabels {
+		name := aws.ToString(label.Name)
+		confidence := float64(aws.ToFloat32(label.Confidence))
+		scores.Labels = append(scores.Labels, ModerationLabel{
+			Name:       name,
+			Confidence: confidence,
+			ParentName: aws.ToString(label.ParentName),
+		})
+
+		score := confidence / 100
+		if s.isNSFWContent(name) && score > scores.NSFW {
+			scores.NSFW = score
+		}
+		if s.isViolentContent(name) && score > scores.Violence {
+			scores.Violence = score
+		}
+		if s.isAdultContent(name) && score > scores.Adult {
+			scores.Adult = score
+		}
+	}
+
+	return scores, nil
+}
+
 // analyzeVideo analyzes a video for inappropriate content
 func (s *AIModerationService) analyzeVideo(ctx context.Context, req ContentAnalysisRequest) (*ContentAnalysisResult, error) {
 	// For video analysis, we'll use StartContentModeration to analyze the video
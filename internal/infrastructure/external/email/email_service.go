@@ -3,8 +3,10 @@ package email
 import (
 	"context"
 	"fmt"
+	"html"
 	"net/smtp"
 	"strings"
+	"time"
 
 	"github.com/22smeargle/winkr-backend/pkg/config"
 	"github.com/22smeargle/winkr-backend/pkg/logger"
@@ -15,6 +17,9 @@ type EmailService interface {
 	SendVerificationEmail(ctx context.Context, to, code string) error
 	SendPasswordResetEmail(ctx context.Context, to, token string) error
 	SendWelcomeEmail(ctx context.Context, to, firstName string) error
+	SendMissedCheckInEmail(ctx context.Context, to, firstName string, dueAt time.Time) error
+	SendTrustedContactAlertEmail(ctx context.Context, to, contactName, firstName string, dueAt time.Time, note string) error
+	SendTrustedContactAllClearEmail(ctx context.Context, to, contactName, firstName string) error
 }
 
 // SMTPEmailService implements EmailService using SMTP
@@ -108,6 +113,72 @@ func (es *SMTPEmailService) SendWelcomeEmail(ctx context.Context, to, firstName
 	return es.sendEmail(to, subject, body)
 }
 
+// SendMissedCheckInEmail tells a user they missed a safety check-in
+func (es *SMTPEmailService) SendMissedCheckInEmail(ctx context.Context, to, firstName string, dueAt time.Time) error {
+	subject := "Are you okay? You missed your check-in"
+	body := fmt.Sprintf(`
+		<html>
+		<body>
+			<h2>Hi %s, are you okay?</h2>
+			<p>You planned to check in by %s, but we haven't heard from you.</p>
+			<div style="margin: 20px 0;">
+				<a href="%s/safety" style="background-color: #28a745; color: white; padding: 12px 24px; text-decoration: none; border-radius: 4px; display: inline-block;">
+					I'm Safe
+				</a>
+			</div>
+			<p>If you are in danger, call your local emergency number right away.</p>
+			<p>Best regards,<br>The Winkr Team</p>
+		</body>
+		</html>
+	`, html.EscapeString(firstName), dueAt.UTC().Format("Jan 2, 2006 at 15:04 MST"), es.config.FrontendURL)
+
+	return es.sendEmail(to, subject, body)
+}
+
+// SendTrustedContactAlertEmail alerts a user's trusted contact that the user missed a safety check-in
+func (es *SMTPEmailService) SendTrustedContactAlertEmail(ctx context.Context, to, contactName, firstName string, dueAt time.Time, note string) error {
+	subject := fmt.Sprintf("%s missed a safety check-in", firstName)
+
+	details := ""
+	if note != "" {
+		details = fmt.Sprintf(`<p>They left this note about their plans:</p>
+			<p style="background-color: #f0f0f0; padding: 10px; border-radius: 4px;">%s</p>`, html.EscapeString(note))
+	}
+
+	body := fmt.Sprintf(`
+		<html>
+		<body>
+			<h2>Hi %s,</h2>
+			<p>%s added you as their trusted contact on Winkr and asked us to let you know if they did not check in after meeting someone.</p>
+			<p>They planned to check in by %s and have not done so yet.</p>
+			%s
+			<p>Please try to reach them. If you believe they are in danger, contact your local emergency services.</p>
+			<p>We will email you again if they check in.</p>
+			<p>Best regards,<br>The Winkr Team</p>
+		</body>
+		</html>
+	`, html.EscapeString(contactName), html.EscapeString(firstName), dueAt.UTC().Format("Jan 2, 2006 at 15:04 MST"), details)
+
+	return es.sendEmail(to, subject, body)
+}
+
+// SendTrustedContactAllClearEmail tells a user's trusted contact that the user checked in after all
+func (es *SMTPEmailService) SendTrustedContactAllClearEmail(ctx context.Context, to, contactName, firstName string) error {
+	subject := fmt.Sprintf("%s has checked in", firstName)
+	body := fmt.Sprintf(`
+		<html>
+		<body>
+			<h2>Hi %s,</h2>
+			<p>Good news: %s has checked in and marked themselves as safe.</p>
+			<p>Thank you for looking out for them.</p>
+			<p>Best regards,<br>The Winkr Team</p>
+		</body>
+		</html>
+	`, html.EscapeString(contactName), html.EscapeString(firstName))
+
+	return es.sendEmail(to, subject, body)
+}
+
 // sendEmail sends an email using SMTP
 func (es *SMTPEmailService) sendEmail(to, subject, body string) error {
 	// Create message
@@ -185,6 +256,39 @@ func (mes *MockEmailService) SendWelcomeEmail(ctx context.Context, to, firstName
 	return nil
 }
 
+// SendMissedCheckInEmail sends a missed check-in email (mock)
+func (mes *MockEmailService) SendMissedCheckInEmail(ctx context.Context, to, firstName string, dueAt time.Time) error {
+	email := MockEmail{
+		To:      to,
+		Subject: "Are you okay? You missed your check-in",
+		Body:    fmt.Sprintf("Hi %s, you missed your check-in due at %s", firstName, dueAt.Format(time.RFC3339)),
+	}
+	mes.SentEmails = append(mes.SentEmails, email)
+	return nil
+}
+
+// SendTrustedContactAlertEmail sends a trusted contact alert email (mock)
+func (mes *MockEmailService) SendTrustedContactAlertEmail(ctx context.Context, to, contactName, firstName string, dueAt time.Time, note string) error {
+	email := MockEmail{
+		To:      to,
+		Subject: fmt.Sprintf("%s missed a safety check-in", firstName),
+		Body:    fmt.Sprintf("Hi %s, %s missed their check-in due at %s. Note: %s", contactName, firstName, dueAt.Format(time.RFC3339), note),
+	}
+	mes.SentEmails = append(mes.SentEmails, email)
+	return nil
+}
+
+// SendTrustedContactAllClearEmail sends a trusted contact all-clear email (mock)
+func (mes *MockEmailService) SendTrustedContactAllClearEmail(ctx context.Context, to, contactName, firstName string) error {
+	email := MockEmail{
+		To:      to,
+		Subject: fmt.Sprintf("%s has checked in", firstName),
+		Body:    fmt.Sprintf("Hi %s, %s has checked in", contactName, firstName),
+	}
+	mes.SentEmails = append(mes.SentEmails, email)
+	return nil
+}
+
 // GetLastSentEmail returns the last sent email (for testing)
 func (mes *MockEmailService) GetLastSentEmail() *MockEmail {
 	if len(mes.SentEmails) == 0 {
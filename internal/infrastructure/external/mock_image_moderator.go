@@ -0,0 +1,51 @@
+package external
+
+import (
+	"context"
+	"sync"
+)
+
+// MockImageModerator stands in for Rekognition when the "mock" moderation provider is configured, for
+// tests and local development. Images score zero, so they are approved, unless scores were set for
+// their key.
+type MockImageModerator struct {
+	mu     sync.RWMutex
+	scores map[string]*ImageModerationScores
+	errs   map[string]error
+}
+
+// NewMockImageModerator creates a new MockImageModerator
+func NewMockImageModerator() *MockImageModerator {
+	return &MockImageModerator{
+		scores: make(map[string]*ImageModerationScores),
+		errs:   make(map[string]error),
+	}
+}
+
+// SetScores makes moderating the image under the key return the scores
+func (m *MockImageModerator) SetScores(key string, scores *ImageModerationScores) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.scores[key] = scores
+}
+
+// SetError makes moderating the image under the key fail with the error
+func (m *MockImageModerator) SetError(key string, err error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.errs[key] = err
+}
+
+// ModerateImage returns the scores set for the key, or zero scores
+func (m *MockImageModerator) ModerateImage(ctx context.Context, key string) (*ImageModerationScores, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	if err := m.errs[key]; err != nil {
+		return nil, err
+	}
+	if scores, ok := m.scores[key]; ok {
+		return scores, nil
+	}
+	return &ImageModerationScores{Labels: []ModerationLabel{}}, nil
+}
@@ -0,0 +1,74 @@
+package external
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+)
+
+// MockTranslationService is a mock translation provider for development and testing.
+// It detects every text as DetectedLanguage and prefixes translations with the target language.
+type MockTranslationService struct {
+	mu sync.Mutex
+
+	// Mock responses
+	DetectedLanguage string
+	Delay            time.Duration
+
+	// Mock errors
+	DetectError    error
+	TranslateError error
+
+	// Call counts
+	DetectCalls    int
+	TranslateCalls int
+}
+
+// NewMockTranslationService creates a new mock translation service detecting the given language
+func NewMockTranslationService(detectedLanguage string) *MockTranslationService {
+	return &MockTranslationService{
+		DetectedLanguage: detectedLanguage,
+	}
+}
+
+// DetectLanguage returns the configured language
+func (m *MockTranslationService) DetectLanguage(ctx context.Context, text string) (string, error) {
+	m.mu.Lock()
+	m.DetectCalls++
+	m.mu.Unlock()
+
+	if err := m.wait(ctx); err != nil {
+		return "", err
+	}
+	if m.DetectError != nil {
+		return "", m.DetectError
+	}
+	return m.DetectedLanguage, nil
+}
+
+// Translate returns the text prefixed with the target language
+func (m *MockTranslationService) Translate(ctx context.Context, text, sourceLanguage, targetLanguage string) (string, error) {
+	m.mu.Lock()
+	m.TranslateCalls++
+	m.mu.Unlock()
+
+	if m.TranslateError != nil {
+		return "", m.TranslateError
+	}
+	return fmt.Sprintf("[%s] %s", targetLanguage, text), nil
+}
+
+// wait simulates provider latency
+func (m *MockTranslationService) wait(ctx context.Context) error {
+	if m.Delay <= 0 {
+		return nil
+	}
+
+	select {
+	case <-time.After(m.Delay):
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
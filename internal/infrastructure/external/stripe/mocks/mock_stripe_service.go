@@ -3,11 +3,13 @@ package mocks
 
 import (
 	"context"
+	"fmt"
 	"time"
 
 	"github.com/stripe/stripe-go/v76"
 
 	"github.com/22smeargle/winkr-backend/internal/domain/entities"
+	stripeservice "github.com/22smeargle/winkr-backend/internal/infrastructure/external/stripe"
 	"github.com/22smeargle/winkr-backend/pkg/config"
 )
 
@@ -23,6 +25,15 @@ type MockStripeService struct {
 	refunds          map[string]*stripe.Refund
 	webhookEvents    map[string]interface{}
 	
+	// Promotion codes by their customer-facing code
+	promotionCodes map[string]*stripe.PromotionCode
+	
+	// Payment intents by the idempotency key they were created with
+	idempotentIntents map[string]*stripe.PaymentIntent
+	
+	// Time a subscription change is prorated from; zero means the middle of the current period
+	prorationDate time.Time
+	
 	// Error simulation
 	simulateError bool
 	errorMessage    string
@@ -39,6 +50,8 @@ func NewMockStripeService() *MockStripeService {
 		invoices:        make(map[string]*stripe.Invoice),
 		refunds:         make(map[string]*stripe.Refund),
 		webhookEvents:   make(map[string]interface{}),
+		idempotentIntents: make(map[string]*stripe.PaymentIntent),
+		promotionCodes:  make(map[string]*stripe.PromotionCode),
 	}
 }
 
@@ -123,6 +136,11 @@ func (m *MockStripeService) SetCancelSubscriptionResponse(subscription *stripe.S
 	}
 }
 
+// SetProrationDate sets the time subscription change previews are prorated from
+func (m *MockStripeService) SetProrationDate(date time.Time) {
+	m.prorationDate = date
+}
+
 // SetCreatePaymentMethodResponse sets mock response for payment method creation
 func (m *MockStripeService) SetCreatePaymentMethodResponse(paymentMethod *stripe.PaymentMethod, err error) {
 	if paymentMethod != nil {
@@ -174,6 +192,13 @@ func (m *MockStripeService) SetListInvoicesResponse(invoices *stripe.InvoiceList
 	}
 }
 
+// SetPromotionCodeResponse sets mock response for promotion code validation
+func (m *MockStripeService) SetPromotionCodeResponse(promotionCode *stripe.PromotionCode) {
+	if promotionCode != nil {
+		m.promotionCodes[promotionCode.Code] = promotionCode
+	}
+}
+
 // SetProcessEventResponse sets mock response for webhook event processing
 func (m *MockStripeService) SetProcessEventResponse(err error) {
 	// Mock implementation
@@ -242,15 +267,25 @@ func (m *MockStripeService) CreatePaymentIntent(ctx context.Context, params *str
 		return nil, &stripe.Error{Msg: m.errorMessage}
 	}
 	
+	// Like Stripe, a repeated idempotency key returns the intent created the first time
+	if params.IdempotencyKey != nil {
+		if paymentIntent, exists := m.idempotentIntents[*params.IdempotencyKey]; exists {
+			return paymentIntent, nil
+		}
+	}
+	
 	paymentIntent := &stripe.PaymentIntent{
-		ID:     "pi_mock_" + params.Customer.ID,
-		Amount: params.Amount,
-		Currency: stripe.String(string(params.Currency)),
-		Status:  "requires_payment_method",
-		Customer: params.Customer,
+		ID:       fmt.Sprintf("pi_mock_%d", len(m.paymentIntents)+1),
+		Amount:   stripe.Int64Value(params.Amount),
+		Currency: stripe.Currency(stripe.StringValue(params.Currency)),
+		Status:   stripe.PaymentIntentStatusRequiresPaymentMethod,
+		Customer: &stripe.Customer{ID: stripe.StringValue(params.Customer)},
 	}
 	
 	m.paymentIntents[paymentIntent.ID] = paymentIntent
+	if params.IdempotencyKey != nil {
+		m.idempotentIntents[*params.IdempotencyKey] = paymentIntent
+	}
 	return paymentIntent, nil
 }
 
@@ -331,6 +366,53 @@ func (m *MockStripeService) CancelSubscription(ctx context.Context, subscription
 	return nil, &stripe.Error{Msg: "Subscription not found"}
 }
 
+// PreviewSubscriptionChange prorates the price difference over the part of the current period left
+// after the proration date, so previews are the same on every run
+func (m *MockStripeService) PreviewSubscriptionChange(ctx context.Context, subscriptionID, priceID string) (*stripeservice.SubscriptionChangePreview, error) {
+	if m.simulateError {
+		return nil, &stripe.Error{Msg: m.errorMessage}
+	}
+	
+	subscription, exists := m.subscriptions[subscriptionID]
+	if !exists {
+		return nil, &stripe.Error{Msg: "Subscription not found"}
+	}
+	
+	newPrice, exists := m.prices[priceID]
+	if !exists {
+		return nil, &stripe.Error{Msg: "Price not found"}
+	}
+	
+	var currentAmount int64
+	if subscription.Items != nil && len(subscription.Items.Data) > 0 && subscription.Items.Data[0].Price != nil {
+		currentAmount = subscription.Items.Data[0].Price.UnitAmount
+	}
+	
+	periodStart := subscription.CurrentPeriodStart
+	periodEnd := subscription.CurrentPeriodEnd
+	prorateFrom := periodStart + (periodEnd-periodStart)/2
+	if !m.prorationDate.IsZero() {
+		prorateFrom = m.prorationDate.Unix()
+	}
+	
+	var prorationAmount int64
+	if periodEnd > periodStart && prorateFrom < periodEnd {
+		if prorateFrom < periodStart {
+			prorateFrom = periodStart
+		}
+		prorationAmount = (newPrice.UnitAmount - currentAmount) * (periodEnd - prorateFrom) / (periodEnd - periodStart)
+	}
+	
+	return &stripeservice.SubscriptionChangePreview{
+		SubscriptionID:  subscriptionID,
+		PriceID:         priceID,
+		ProrationAmount: prorationAmount,
+		AmountDue:       newPrice.UnitAmount + prorationAmount,
+		Currency:        string(newPrice.Currency),
+		NextBillingDate: time.Unix(periodEnd, 0),
+	}, nil
+}
+
 func (m *MockStripeService) CreatePaymentMethod(ctx context.Context, params *stripe.PaymentMethodParams) (*stripe.PaymentMethod, error) {
 	if m.simulateError {
 		return nil, &stripe.Error{Msg: m.errorMessage}
@@ -390,15 +472,67 @@ func (m *MockStripeService) DetachPaymentMethod(ctx context.Context, paymentMeth
 	return nil
 }
 
+// SetDefaultPaymentMethod sets the customer's invoice default. Like Stripe, it refuses payment methods
+// that are not attached to the customer.
+func (m *MockStripeService) SetDefaultPaymentMethod(ctx context.Context, customerID, paymentMethodID string) (string, error) {
+	if m.simulateError {
+		return "", &stripe.Error{Msg: m.errorMessage}
+	}
+	
+	customer, exists := m.customers[customerID]
+	if !exists {
+		return "", &stripe.Error{Msg: "Customer not found"}
+	}
+	
+	paymentMethod, exists := m.paymentMethods[paymentMethodID]
+	if !exists || paymentMethod.Customer == nil || paymentMethod.Customer.ID != customerID {
+		return "", &stripe.Error{Msg: "Payment method is not attached to the customer"}
+	}
+	
+	if customer.InvoiceSettings == nil {
+		customer.InvoiceSettings = &stripe.CustomerInvoiceSettings{}
+	}
+	customer.InvoiceSettings.DefaultPaymentMethod = paymentMethod
+	
+	return paymentMethod.ID, nil
+}
+
+// CreateRefund refunds the requested amount of a payment intent, or what is left of it when no amount is
+// given. Like Stripe, it refuses to refund more than was charged, so refund amounts add up across calls.
 func (m *MockStripeService) CreateRefund(ctx context.Context, params *stripe.RefundParams) (*stripe.Refund, error) {
 	if m.simulateError {
 		return nil, &stripe.Error{Msg: m.errorMessage}
 	}
 	
+	paymentIntent, exists := m.paymentIntents[stripe.StringValue(params.PaymentIntent)]
+	if !exists {
+		return nil, &stripe.Error{Msg: "Payment intent not found"}
+	}
+	
+	remaining := paymentIntent.Amount
+	for _, refund := range m.refunds {
+		if refund.PaymentIntent != nil && refund.PaymentIntent.ID == paymentIntent.ID {
+			remaining -= refund.Amount
+		}
+	}
+	
+	amount := remaining
+	if params.Amount != nil {
+		amount = *params.Amount
+	}
+	if amount <= 0 || amount > remaining {
+		return nil, &stripe.Error{Msg: "Refund amount exceeds the amount left to refund"}
+	}
+	
 	refund := &stripe.Refund{
-		ID:     "re_mock_" + params.PaymentIntent.ID,
-		Amount: params.Amount,
-		Status: "succeeded",
+		ID:            fmt.Sprintf("re_mock_%d", len(m.refunds)+1),
+		Amount:        amount,
+		Currency:      paymentIntent.Currency,
+		PaymentIntent: paymentIntent,
+		Reason:        stripe.RefundReason(stripe.StringValue(params.Reason)),
+		Status:        stripe.RefundStatusSucceeded,
+		Created:       time.Now().Unix(),
+		Metadata:      params.Metadata,
 	}
 	
 	m.refunds[refund.ID] = refund
@@ -417,6 +551,50 @@ func (m *MockStripeService) GetInvoice(ctx context.Context, invoiceID string) (*
 	return nil, &stripe.Error{Msg: "Invoice not found"}
 }
 
+// GetInvoicePDF returns a fake PDF URL derived from the invoice ID, so it is the same on every call.
+// Draft invoices have no URL yet, like in Stripe.
+func (m *MockStripeService) GetInvoicePDF(ctx context.Context, invoiceID string) (*stripeservice.InvoicePDF, error) {
+	if m.simulateError {
+		return nil, &stripe.Error{Msg: m.errorMessage}
+	}
+	
+	invoice, exists := m.invoices[invoiceID]
+	if !exists {
+		return nil, stripeservice.ErrInvoiceNotFound
+	}
+	
+	invoicePDF := &stripeservice.InvoicePDF{
+		InvoiceID: invoice.ID,
+		Status:    string(invoice.Status),
+	}
+	if invoice.Customer != nil {
+		invoicePDF.CustomerID = invoice.Customer.ID
+	}
+	if invoice.Status != stripe.InvoiceStatusDraft {
+		invoicePDF.URL = fmt.Sprintf("https://pay.stripe.com/invoice/mock/%s/pdf", invoice.ID)
+	}
+	
+	return invoicePDF, nil
+}
+
+// ValidatePromotionCode checks a promotion code set with SetPromotionCodeResponse using the same rules as Stripe
+func (m *MockStripeService) ValidatePromotionCode(ctx context.Context, code string) (*stripeservice.PromotionCode, error) {
+	if m.simulateError {
+		return nil, &stripe.Error{Msg: m.errorMessage}
+	}
+	
+	promotionCode, exists := m.promotionCodes[code]
+	if !exists {
+		return nil, stripeservice.ErrPromotionCodeNotFound
+	}
+	
+	if err := stripeservice.CheckPromotionCode(promotionCode, time.Now()); err != nil {
+		return nil, err
+	}
+	
+	return stripeservice.NewPromotionCode(promotionCode), nil
+}
+
 func (m *MockStripeService) ListInvoices(ctx context.Context, params *stripe.InvoiceListParams) (*stripe.InvoiceList, error) {
 	if m.simulateError {
 		return nil, &stripe.Error{Msg: m.errorMessage}
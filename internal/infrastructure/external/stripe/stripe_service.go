@@ -2,40 +2,88 @@ package stripe
 
 import (
 	"context"
+	"crypto/sha256"
+	"encoding/hex"
 	"encoding/json"
+	"errors"
 	"fmt"
+	"strings"
 	"time"
 
 	"github.com/google/uuid"
 	"github.com/stripe/stripe-go/v76"
+	"github.com/stripe/stripe-go/v76/checkout/session"
 	"github.com/stripe/stripe-go/v76/customer"
 	"github.com/stripe/stripe-go/v76/invoice"
 	"github.com/stripe/stripe-go/v76/paymentintent"
 	"github.com/stripe/stripe-go/v76/paymentmethod"
 	"github.com/stripe/stripe-go/v76/price"
 	"github.com/stripe/stripe-go/v76/product"
+	"github.com/stripe/stripe-go/v76/promotioncode"
 	"github.com/stripe/stripe-go/v76/refund"
 	"github.com/stripe/stripe-go/v76/sub"
 	"github.com/stripe/stripe-go/v76/webhook"
+	"github.com/22smeargle/winkr-backend/pkg/config"
 	"github.com/22smeargle/winkr-backend/pkg/logger"
 )
 
+var (
+	// ErrInvoiceNotFound is returned when Stripe has no invoice with the requested ID
+	ErrInvoiceNotFound = errors.New("invoice not found")
+
+	// ErrPromotionCodeNotFound is returned when no promotion code matches the code a customer entered
+	ErrPromotionCodeNotFound = errors.New("promotion code not found")
+
+	// ErrPromotionCodeInactive is returned for promotion codes that are deactivated, expired or used up
+	ErrPromotionCodeInactive = errors.New("promotion code is no longer active")
+)
+
 // StripeService handles all Stripe-related operations
 type StripeService struct {
-	client         *stripe.Client
-	webhookSecret  string
-	secretKey      string
-	publishableKey string
+	client           *stripe.Client
+	webhookSecret    string
+	webhookTolerance time.Duration
+	secretKey        string
+	publishableKey   string
+	successURL       string
+	cancelURL        string
+
+	// Checkout charges in defaultCurrency unless a supported currency has a localized price
+	defaultCurrency     string
+	supportedCurrencies map[string]bool
+	localizedPriceIDs   map[string]map[string]string
 }
 
 // NewStripeService creates a new Stripe service instance
-func NewStripeService(secretKey, publishableKey, webhookSecret string) *StripeService {
-	stripe.Key = secretKey
+func NewStripeService(cfg *config.StripeConfig) *StripeService {
+	stripe.Key = cfg.SecretKey
+
+	// Replay protection cannot be turned off; an unset tolerance uses Stripe's default
+	tolerance := time.Duration(cfg.WebhookToleranceSeconds) * time.Second
+	if tolerance <= 0 {
+		tolerance = webhook.DefaultTolerance
+	}
+
+	defaultCurrency := strings.ToLower(cfg.DefaultCurrency)
+	if defaultCurrency == "" {
+		defaultCurrency = string(stripe.CurrencyUSD)
+	}
+	supportedCurrencies := map[string]bool{defaultCurrency: true}
+	for _, currency := range cfg.SupportedCurrencies {
+		supportedCurrencies[strings.ToLower(currency)] = true
+	}
+
 	return &StripeService{
-		client:         stripe.NewClient(secretKey),
-		webhookSecret:  webhookSecret,
-		secretKey:      secretKey,
-		publishableKey: publishableKey,
+		client:              stripe.NewClient(cfg.SecretKey),
+		webhookSecret:       cfg.WebhookSecret,
+		webhookTolerance:    tolerance,
+		secretKey:           cfg.SecretKey,
+		publishableKey:      cfg.PublishableKey,
+		successURL:          cfg.SuccessURL,
+		cancelURL:           cfg.CancelURL,
+		defaultCurrency:     defaultCurrency,
+		supportedCurrencies: supportedCurrencies,
+		localizedPriceIDs:   cfg.LocalizedPriceIDs,
 	}
 }
 
@@ -86,6 +134,7 @@ type Subscription struct {
 	CancelAtPeriodEnd  bool                   `json:"cancel_at_period_end"`
 	TrialStart         *time.Time             `json:"trial_start,omitempty"`
 	TrialEnd           *time.Time             `json:"trial_end,omitempty"`
+	LatestInvoiceID    string                 `json:"latest_invoice_id,omitempty"`
 	CreatedAt          time.Time              `json:"created_at"`
 	Metadata           map[string]string      `json:"metadata"`
 }
@@ -111,13 +160,56 @@ type Invoice struct {
 	SubscriptionID  *string                `json:"subscription_id,omitempty"`
 	Status          string                 `json:"status"`
 	Amount          int64                  `json:"amount"`
+	AmountPaid      int64                  `json:"amount_paid"`
 	Currency        string                 `json:"currency"`
+	PaymentIntentID *string                `json:"payment_intent_id,omitempty"`
 	DueDate         *time.Time             `json:"due_date,omitempty"`
 	PaidAt          *time.Time             `json:"paid_at,omitempty"`
 	CreatedAt       time.Time              `json:"created_at"`
 	Metadata        map[string]string      `json:"metadata"`
 }
 
+// InvoicePDF represents the downloadable PDF of an invoice. URL is empty until the invoice is finalized.
+type InvoicePDF struct {
+	InvoiceID  string `json:"invoice_id"`
+	CustomerID string `json:"customer_id"`
+	Status     string `json:"status"`
+	URL        string `json:"url"`
+}
+
+// PromotionCode represents a redeemable promotion code and the discount its coupon gives.
+// Either PercentOff or AmountOff (in Currency) is set.
+type PromotionCode struct {
+	ID               string     `json:"id"`
+	Code             string     `json:"code"`
+	CouponID         string     `json:"coupon_id"`
+	PercentOff       float64    `json:"percent_off,omitempty"`
+	AmountOff        int64      `json:"amount_off,omitempty"`
+	Currency         string     `json:"currency,omitempty"`
+	Duration         string     `json:"duration"`
+	DurationInMonths int64      `json:"duration_in_months,omitempty"`
+	ExpiresAt        *time.Time `json:"expires_at,omitempty"`
+}
+
+// CheckoutSession represents a Stripe Checkout session for a subscription
+type CheckoutSession struct {
+	ID       string `json:"id"`
+	URL      string `json:"url"`
+	PriceID  string `json:"price_id"`
+	Amount   int64  `json:"amount"`
+	Currency string `json:"currency"`
+}
+
+// SubscriptionChangePreview represents what switching a subscription to another price would cost
+type SubscriptionChangePreview struct {
+	SubscriptionID  string    `json:"subscription_id"`
+	PriceID         string    `json:"price_id"`
+	ProrationAmount int64     `json:"proration_amount"`
+	AmountDue       int64     `json:"amount_due"`
+	Currency        string    `json:"currency"`
+	NextBillingDate time.Time `json:"next_billing_date"`
+}
+
 // Refund represents a refund
 type Refund struct {
 	ID             string                 `json:"id"`
@@ -237,8 +329,16 @@ func (s *StripeService) DeleteCustomer(ctx context.Context, customerID string) e
 	return nil
 }
 
-// CreatePaymentIntent creates a new payment intent
-func (s *StripeService) CreatePaymentIntent(ctx context.Context, amount int64, currency, customerID, paymentMethodID string, metadata map[string]string) (*PaymentIntent, error) {
+// PaymentIntentIdempotencyKey derives the idempotency key for a payment intent, so repeated
+// requests for the same user, plan and client nonce map to one intent
+func PaymentIntentIdempotencyKey(userID uuid.UUID, planID, nonce string) string {
+	sum := sha256.Sum256([]byte(userID.String() + ":" + planID + ":" + nonce))
+	return "pi_" + hex.EncodeToString(sum[:])
+}
+
+// CreatePaymentIntent creates a new payment intent. A non-empty idempotencyKey is sent to Stripe,
+// which returns the intent already created with that key instead of creating another one.
+func (s *StripeService) CreatePaymentIntent(ctx context.Context, amount int64, currency, customerID, paymentMethodID, idempotencyKey string, metadata map[string]string) (*PaymentIntent, error) {
 	params := &stripe.PaymentIntentParams{
 		Amount:   stripe.Int64(amount),
 		Currency: stripe.String(currency),
@@ -246,6 +346,10 @@ func (s *StripeService) CreatePaymentIntent(ctx context.Context, amount int64, c
 		Metadata: metadata,
 	}
 
+	if idempotencyKey != "" {
+		params.SetIdempotencyKey(idempotencyKey)
+	}
+
 	if paymentMethodID != "" {
 		params.PaymentMethod = stripe.String(paymentMethodID)
 		params.ConfirmationMethod = stripe.String(string(stripe.PaymentIntentConfirmationMethodManual))
@@ -444,6 +548,37 @@ func (s *StripeService) DeletePaymentMethod(ctx context.Context, paymentMethodID
 	return nil
 }
 
+// SetDefaultPaymentMethod makes the payment method the one the customer's invoices are charged to
+// and returns the customer's default payment method ID after the update
+func (s *StripeService) SetDefaultPaymentMethod(ctx context.Context, customerID, paymentMethodID string) (string, error) {
+	params := &stripe.CustomerParams{
+		InvoiceSettings: &stripe.CustomerInvoiceSettingsParams{
+			DefaultPaymentMethod: stripe.String(paymentMethodID),
+		},
+	}
+
+	cust, err := customer.Update(customerID, params)
+	if err != nil {
+		logger.Error("Failed to set default payment method", err, map[string]interface{}{
+			"customer_id":       customerID,
+			"payment_method_id": paymentMethodID,
+		})
+		return "", fmt.Errorf("failed to set default payment method: %w", err)
+	}
+
+	defaultPaymentMethodID := ""
+	if cust.InvoiceSettings != nil && cust.InvoiceSettings.DefaultPaymentMethod != nil {
+		defaultPaymentMethodID = cust.InvoiceSettings.DefaultPaymentMethod.ID
+	}
+
+	logger.Info("Default payment method set", map[string]interface{}{
+		"customer_id":       customerID,
+		"payment_method_id": defaultPaymentMethodID,
+	})
+
+	return defaultPaymentMethodID, nil
+}
+
 // CreateSubscription creates a new subscription
 func (s *StripeService) CreateSubscription(ctx context.Context, customerID, priceID string, paymentMethodID string, trialPeriodDays int64, metadata map[string]string) (*Subscription, error) {
 	params := &stripe.SubscriptionParams{
@@ -547,6 +682,10 @@ func (s *StripeService) GetSubscription(ctx context.Context, subscriptionID stri
 		subscription.TrialEnd = &trialEnd
 	}
 
+	if sub.LatestInvoice != nil {
+		subscription.LatestInvoiceID = sub.LatestInvoice.ID
+	}
+
 	return subscription, nil
 }
 
@@ -599,6 +738,68 @@ func (s *StripeService) UpdateSubscription(ctx context.Context, subscriptionID,
 	return subscription, nil
 }
 
+// PreviewSubscriptionChange previews the upcoming invoice if the subscription were switched to priceID now.
+// The proration amount is the sum of the proration lines, so it is negative when the change earns a credit.
+func (s *StripeService) PreviewSubscriptionChange(ctx context.Context, subscriptionID, priceID string) (*SubscriptionChangePreview, error) {
+	current, err := sub.Get(subscriptionID, nil)
+	if err != nil {
+		logger.Error("Failed to get subscription", err)
+		return nil, fmt.Errorf("failed to get subscription: %w", err)
+	}
+
+	if len(current.Items.Data) == 0 {
+		return nil, fmt.Errorf("subscription %s has no items", subscriptionID)
+	}
+
+	prorationDate := time.Now().Unix()
+	params := &stripe.InvoiceUpcomingParams{
+		Customer:     stripe.String(current.Customer.ID),
+		Subscription: stripe.String(subscriptionID),
+		SubscriptionItems: []*stripe.SubscriptionItemsParams{
+			{
+				ID:    stripe.String(current.Items.Data[0].ID),
+				Price: stripe.String(priceID),
+			},
+		},
+		SubscriptionProrationBehavior: stripe.String("create_prorations"),
+		SubscriptionProrationDate:     stripe.Int64(prorationDate),
+	}
+
+	upcoming, err := invoice.Upcoming(params)
+	if err != nil {
+		logger.Error("Failed to preview subscription change", err)
+		return nil, fmt.Errorf("failed to preview subscription change: %w", err)
+	}
+
+	preview := &SubscriptionChangePreview{
+		SubscriptionID:  subscriptionID,
+		PriceID:         priceID,
+		AmountDue:       upcoming.AmountDue,
+		Currency:        string(upcoming.Currency),
+		NextBillingDate: time.Unix(current.CurrentPeriodEnd, 0),
+	}
+
+	if upcoming.Lines != nil {
+		for _, line := range upcoming.Lines.Data {
+			if line.Proration {
+				preview.ProrationAmount += line.Amount
+			}
+		}
+	}
+
+	if upcoming.NextPaymentAttempt != 0 {
+		preview.NextBillingDate = time.Unix(upcoming.NextPaymentAttempt, 0)
+	}
+
+	logger.Info("Subscription change previewed", map[string]interface{}{
+		"subscription_id":  subscriptionID,
+		"price_id":         priceID,
+		"proration_amount": preview.ProrationAmount,
+	})
+
+	return preview, nil
+}
+
 // CancelSubscription cancels a subscription
 func (s *StripeService) CancelSubscription(ctx context.Context, subscriptionID string, cancelAtPeriodEnd bool) (*Subscription, error) {
 	var sub *stripe.Subscription
@@ -688,6 +889,19 @@ func (s *StripeService) CreateRefund(ctx context.Context, paymentIntentID string
 	return refundObj, nil
 }
 
+// ProratedRefundAmount returns the part of amountPaid that covers the rest of the period after at,
+// rounded down to the smallest currency unit
+func ProratedRefundAmount(amountPaid int64, periodStart, periodEnd, at time.Time) int64 {
+	period := periodEnd.Unix() - periodStart.Unix()
+	if amountPaid <= 0 || period <= 0 || !at.Before(periodEnd) {
+		return 0
+	}
+	if at.Before(periodStart) {
+		return amountPaid
+	}
+	return amountPaid * (periodEnd.Unix() - at.Unix()) / period
+}
+
 // GetInvoice retrieves an invoice by ID
 func (s *StripeService) GetInvoice(ctx context.Context, invoiceID string) (*Invoice, error) {
 	inv, err := invoice.Get(invoiceID, nil)
@@ -701,11 +915,17 @@ func (s *StripeService) GetInvoice(ctx context.Context, invoiceID string) (*Invo
 		CustomerID: inv.Customer.ID,
 		Status:     string(inv.Status),
 		Amount:     inv.AmountDue,
+		AmountPaid: inv.AmountPaid,
 		Currency:   string(inv.Currency),
 		CreatedAt:  time.Unix(inv.Created, 0),
 		Metadata:   inv.Metadata,
 	}
 
+	if inv.PaymentIntent != nil {
+		paymentIntentID := inv.PaymentIntent.ID
+		invoice.PaymentIntentID = &paymentIntentID
+	}
+
 	if inv.Subscription != nil {
 		subscriptionID := inv.Subscription.ID
 		invoice.SubscriptionID = &subscriptionID
@@ -724,9 +944,127 @@ func (s *StripeService) GetInvoice(ctx context.Context, invoiceID string) (*Invo
 	return invoice, nil
 }
 
-// VerifyWebhook verifies and parses a webhook event
+// GetInvoicePDF retrieves the URL of an invoice's PDF along with who the invoice belongs to
+func (s *StripeService) GetInvoicePDF(ctx context.Context, invoiceID string) (*InvoicePDF, error) {
+	inv, err := invoice.Get(invoiceID, nil)
+	if err != nil {
+		var stripeErr *stripe.Error
+		if errors.As(err, &stripeErr) && stripeErr.Code == stripe.ErrorCodeResourceMissing {
+			return nil, ErrInvoiceNotFound
+		}
+		logger.Error("Failed to get invoice", err)
+		return nil, fmt.Errorf("failed to get invoice: %w", err)
+	}
+
+	invoicePDF := &InvoicePDF{
+		InvoiceID: inv.ID,
+		Status:    string(inv.Status),
+		URL:       inv.InvoicePDF,
+	}
+
+	if inv.Customer != nil {
+		invoicePDF.CustomerID = inv.Customer.ID
+	}
+
+	return invoicePDF, nil
+}
+
+// ValidatePromotionCode looks up the promotion code a customer entered and checks it can still be redeemed
+func (s *StripeService) ValidatePromotionCode(ctx context.Context, code string) (*PromotionCode, error) {
+	params := &stripe.PromotionCodeListParams{
+		Code: stripe.String(code),
+	}
+	params.Filters.AddFilter("limit", "", "1")
+
+	iter := promotioncode.List(params)
+	if !iter.Next() {
+		if err := iter.Err(); err != nil {
+			logger.Error("Failed to look up promotion code", err)
+			return nil, fmt.Errorf("failed to look up promotion code: %w", err)
+		}
+		return nil, ErrPromotionCodeNotFound
+	}
+
+	promotionCode := iter.PromotionCode()
+	if err := CheckPromotionCode(promotionCode, time.Now()); err != nil {
+		return nil, err
+	}
+
+	return NewPromotionCode(promotionCode), nil
+}
+
+// CheckPromotionCode returns ErrPromotionCodeInactive unless the promotion code and its coupon can be redeemed at now
+func CheckPromotionCode(promotionCode *stripe.PromotionCode, now time.Time) error {
+	if !promotionCode.Active {
+		return ErrPromotionCodeInactive
+	}
+	if promotionCode.ExpiresAt != 0 && !now.Before(time.Unix(promotionCode.ExpiresAt, 0)) {
+		return ErrPromotionCodeInactive
+	}
+	if promotionCode.MaxRedemptions > 0 && promotionCode.TimesRedeemed >= promotionCode.MaxRedemptions {
+		return ErrPromotionCodeInactive
+	}
+
+	coupon := promotionCode.Coupon
+	if coupon == nil || !coupon.Valid {
+		return ErrPromotionCodeInactive
+	}
+	if coupon.RedeemBy != 0 && !now.Before(time.Unix(coupon.RedeemBy, 0)) {
+		return ErrPromotionCodeInactive
+	}
+	if coupon.MaxRedemptions > 0 && coupon.TimesRedeemed >= coupon.MaxRedemptions {
+		return ErrPromotionCodeInactive
+	}
+
+	return nil
+}
+
+// NewPromotionCode converts a Stripe promotion code and the coupon it includes
+func NewPromotionCode(promotionCode *stripe.PromotionCode) *PromotionCode {
+	result := &PromotionCode{
+		ID:   promotionCode.ID,
+		Code: promotionCode.Code,
+	}
+
+	if promotionCode.Coupon != nil {
+		result.CouponID = promotionCode.Coupon.ID
+		result.PercentOff = promotionCode.Coupon.PercentOff
+		result.AmountOff = promotionCode.Coupon.AmountOff
+		result.Currency = string(promotionCode.Coupon.Currency)
+		result.Duration = string(promotionCode.Coupon.Duration)
+		result.DurationInMonths = promotionCode.Coupon.DurationInMonths
+	}
+
+	if promotionCode.ExpiresAt != 0 {
+		expiresAt := time.Unix(promotionCode.ExpiresAt, 0)
+		result.ExpiresAt = &expiresAt
+	}
+
+	return result
+}
+
+// HasOpenInvoices returns true if the customer has finalized invoices that are still awaiting payment
+func (s *StripeService) HasOpenInvoices(ctx context.Context, customerID string) (bool, error) {
+	params := &stripe.InvoiceListParams{
+		Customer: stripe.String(customerID),
+		Status:   stripe.String(string(stripe.InvoiceStatusOpen)),
+	}
+	params.Filters.AddFilter("limit", "", "1")
+
+	iter := invoice.List(params)
+	hasOpen := iter.Next()
+	if err := iter.Err(); err != nil {
+		logger.Error("Failed to list open invoices", err)
+		return false, fmt.Errorf("failed to list open invoices: %w", err)
+	}
+
+	return hasOpen, nil
+}
+
+// VerifyWebhook verifies and parses a webhook event. Events whose signature timestamp is
+// outside the configured tolerance are rejected, so a captured request cannot be replayed later.
 func (s *StripeService) VerifyWebhook(ctx context.Context, payload []byte, signatureHeader string) (*WebhookEvent, error) {
-	event, err := webhook.ConstructEvent(payload, signatureHeader, s.webhookSecret)
+	event, err := webhook.ConstructEventWithTolerance(payload, signatureHeader, s.webhookSecret, s.webhookTolerance)
 	if err != nil {
 		logger.Error("Failed to verify webhook signature", err)
 		return nil, fmt.Errorf("failed to verify webhook signature: %w", err)
@@ -734,7 +1072,7 @@ func (s *StripeService) VerifyWebhook(ctx context.Context, payload []byte, signa
 
 	webhookEvent := &WebhookEvent{
 		ID:      event.ID,
-		Type:    event.Type,
+		Type:    string(event.Type),
 		Data:    event.Data.Raw,
 		Created: event.Created,
 	}
@@ -801,6 +1139,79 @@ func (s *StripeService) CreatePrice(ctx context.Context, productID, nickname str
 	return price.ID, nil
 }
 
+// SupportsCurrency reports whether checkout accepts the currency as a hint
+func (s *StripeService) SupportsCurrency(currency string) bool {
+	return s.supportedCurrencies[strings.ToLower(currency)]
+}
+
+// CheckoutPrice returns the price to charge in the requested currency and the currency it is in.
+// Without a localized price for the currency, the default price and currency are used.
+func (s *StripeService) CheckoutPrice(defaultPriceID, currency string) (string, string) {
+	currency = strings.ToLower(currency)
+	if priceID := s.localizedPriceIDs[defaultPriceID][currency]; priceID != "" {
+		return priceID, currency
+	}
+	return defaultPriceID, s.defaultCurrency
+}
+
+// CreateCheckoutSession creates a subscription Checkout session for the customer, priced in
+// the requested currency when the plan has a localized price for it
+func (s *StripeService) CreateCheckoutSession(ctx context.Context, customerID, defaultPriceID, currency string, metadata map[string]string) (*CheckoutSession, error) {
+	priceID, chosenCurrency := s.CheckoutPrice(defaultPriceID, currency)
+	if currency != "" && chosenCurrency != strings.ToLower(currency) {
+		logger.Info("No localized price for currency, using default price", map[string]interface{}{
+			"price_id": defaultPriceID,
+			"currency": currency,
+		})
+	}
+
+	params := &stripe.CheckoutSessionParams{
+		Customer:   stripe.String(customerID),
+		Mode:       stripe.String(string(stripe.CheckoutSessionModeSubscription)),
+		SuccessURL: stripe.String(s.successURL),
+		CancelURL:  stripe.String(s.cancelURL),
+		LineItems: []*stripe.CheckoutSessionLineItemParams{
+			{
+				Price:    stripe.String(priceID),
+				Quantity: stripe.Int64(1),
+			},
+		},
+		SubscriptionData: &stripe.CheckoutSessionSubscriptionDataParams{
+			Metadata: metadata,
+		},
+		Metadata: metadata,
+	}
+
+	cs, err := session.New(params)
+	if err != nil {
+		logger.Error("Failed to create checkout session", err, map[string]interface{}{
+			"customer_id": customerID,
+			"price_id":    priceID,
+		})
+		return nil, fmt.Errorf("failed to create checkout session: %w", err)
+	}
+
+	checkoutSession := &CheckoutSession{
+		ID:       cs.ID,
+		URL:      cs.URL,
+		PriceID:  priceID,
+		Amount:   cs.AmountTotal,
+		Currency: string(cs.Currency),
+	}
+	if checkoutSession.Currency == "" {
+		checkoutSession.Currency = chosenCurrency
+	}
+
+	logger.Info("Checkout session created", map[string]interface{}{
+		"session_id":  checkoutSession.ID,
+		"customer_id": customerID,
+		"price_id":    priceID,
+		"currency":    checkoutSession.Currency,
+	})
+
+	return checkoutSession, nil
+}
+
 // GetPublishableKey returns the publishable key
 func (s *StripeService) GetPublishableKey() string {
 	return s.publishableKey
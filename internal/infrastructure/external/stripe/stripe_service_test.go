@@ -0,0 +1,191 @@
+package stripe
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/stripe/stripe-go/v76"
+	"github.com/stripe/stripe-go/v76/webhook"
+	"github.com/22smeargle/winkr-backend/pkg/config"
+)
+
+const testWebhookSecret = "whsec_test_secret"
+
+// signedWebhook returns an event payload and a signature header for it signed at the given time
+func signedWebhook(signedAt time.Time) ([]byte, string) {
+	payload := []byte(fmt.Sprintf(`{"id":"evt_test","object":"event","type":"invoice.paid","api_version":%q,"created":%d,"data":{"object":{}}}`,
+		stripe.APIVersion, signedAt.Unix()))
+	signed := webhook.GenerateTestSignedPayload(&webhook.UnsignedPayload{
+		Payload:   payload,
+		Secret:    testWebhookSecret,
+		Timestamp: signedAt,
+	})
+	return payload, signed.Header
+}
+
+func newWebhookTestService(toleranceSeconds int) *StripeService {
+	return NewStripeService(&config.StripeConfig{
+		SecretKey:               "sk_test_mock",
+		WebhookSecret:           testWebhookSecret,
+		WebhookToleranceSeconds: toleranceSeconds,
+	})
+}
+
+func TestVerifyWebhook_EnforcesConfiguredTolerance(t *testing.T) {
+	ctx := context.Background()
+	service := newWebhookTestService(60)
+
+	tests := []struct {
+		name     string
+		age      time.Duration
+		accepted bool
+	}{
+		{"just signed", 0, true},
+		{"within tolerance", 45 * time.Second, true},
+		{"outside tolerance", 90 * time.Second, false},
+		{"replayed much later", time.Hour, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			payload, header := signedWebhook(time.Now().Add(-tt.age))
+
+			event, err := service.VerifyWebhook(ctx, payload, header)
+			if tt.accepted {
+				require.NoError(t, err)
+				assert.Equal(t, "evt_test", event.ID)
+				return
+			}
+			require.Error(t, err)
+			assert.ErrorIs(t, err, webhook.ErrTooOld)
+		})
+	}
+}
+
+func TestVerifyWebhook_LooserToleranceForTesting(t *testing.T) {
+	payload, header := signedWebhook(time.Now().Add(-30 * time.Minute))
+
+	_, err := newWebhookTestService(300).VerifyWebhook(context.Background(), payload, header)
+	assert.ErrorIs(t, err, webhook.ErrTooOld)
+
+	_, err = newWebhookTestService(3600).VerifyWebhook(context.Background(), payload, header)
+	assert.NoError(t, err)
+}
+
+func TestVerifyWebhook_DefaultsToStripeTolerance(t *testing.T) {
+	service := newWebhookTestService(0)
+	assert.Equal(t, webhook.DefaultTolerance, service.webhookTolerance)
+
+	payload, header := signedWebhook(time.Now().Add(-10 * time.Minute))
+	_, err := service.VerifyWebhook(context.Background(), payload, header)
+	assert.ErrorIs(t, err, webhook.ErrTooOld)
+
+	// A valid timestamp does not make up for a bad signature
+	payload, _ = signedWebhook(time.Now())
+	_, header = signedWebhook(time.Now().Add(-time.Second))
+	_, err = service.VerifyWebhook(context.Background(), payload, header)
+	assert.ErrorIs(t, err, webhook.ErrNoValidSignature)
+}
+
+func TestProratedRefundAmount(t *testing.T) {
+	periodStart := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	periodEnd := periodStart.Add(30 * 24 * time.Hour)
+
+	tests := []struct {
+		name     string
+		at       time.Time
+		expected int64
+	}{
+		{"before the period", periodStart.Add(-time.Hour), 999},
+		{"at the start", periodStart, 999},
+		{"a third in", periodStart.Add(10 * 24 * time.Hour), 666},
+		{"halfway", periodStart.Add(15 * 24 * time.Hour), 499},
+		{"a second before the end", periodEnd.Add(-time.Second), 0},
+		{"at the end", periodEnd, 0},
+		{"after the end", periodEnd.Add(time.Hour), 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, ProratedRefundAmount(999, periodStart, periodEnd, tt.at))
+		})
+	}
+
+	assert.Zero(t, ProratedRefundAmount(0, periodStart, periodEnd, periodStart))
+	assert.Zero(t, ProratedRefundAmount(999, periodEnd, periodStart, periodStart), "an empty period refunds nothing")
+}
+
+func TestCheckPromotionCode(t *testing.T) {
+	now := time.Date(2025, 6, 1, 12, 0, 0, 0, time.UTC)
+	valid := func() *stripe.PromotionCode {
+		return &stripe.PromotionCode{
+			Active: true,
+			Code:   "SUMMER25",
+			Coupon: &stripe.Coupon{ID: "coupon_summer", Valid: true, PercentOff: 25},
+		}
+	}
+
+	tests := []struct {
+		name   string
+		modify func(*stripe.PromotionCode)
+		err    error
+	}{
+		{"valid", func(*stripe.PromotionCode) {}, nil},
+		{"expires later", func(p *stripe.PromotionCode) { p.ExpiresAt = now.Add(time.Hour).Unix() }, nil},
+		{"redemptions left", func(p *stripe.PromotionCode) { p.MaxRedemptions, p.TimesRedeemed = 10, 9 }, nil},
+		{"deactivated", func(p *stripe.PromotionCode) { p.Active = false }, ErrPromotionCodeInactive},
+		{"expired", func(p *stripe.PromotionCode) { p.ExpiresAt = now.Add(-time.Hour).Unix() }, ErrPromotionCodeInactive},
+		{"used up", func(p *stripe.PromotionCode) { p.MaxRedemptions, p.TimesRedeemed = 10, 10 }, ErrPromotionCodeInactive},
+		{"coupon invalid", func(p *stripe.PromotionCode) { p.Coupon.Valid = false }, ErrPromotionCodeInactive},
+		{"coupon past redeem by", func(p *stripe.PromotionCode) { p.Coupon.RedeemBy = now.Unix() }, ErrPromotionCodeInactive},
+		{"coupon used up", func(p *stripe.PromotionCode) { p.Coupon.MaxRedemptions, p.Coupon.TimesRedeemed = 5, 5 }, ErrPromotionCodeInactive},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			promotionCode := valid()
+			tt.modify(promotionCode)
+			assert.Equal(t, tt.err, CheckPromotionCode(promotionCode, now))
+		})
+	}
+}
+
+func TestCheckoutPrice(t *testing.T) {
+	service := NewStripeService(&config.StripeConfig{
+		SecretKey:           "sk_test_mock",
+		DefaultCurrency:     "USD",
+		SupportedCurrencies: []string{"eur", "gbp"},
+		LocalizedPriceIDs: map[string]map[string]string{
+			"price_premium_monthly": {"eur": "price_premium_monthly_eur"},
+		},
+	})
+
+	assert.True(t, service.SupportsCurrency("usd"))
+	assert.True(t, service.SupportsCurrency("EUR"))
+	assert.False(t, service.SupportsCurrency("jpy"))
+
+	tests := []struct {
+		name             string
+		defaultPriceID   string
+		currency         string
+		expectedPriceID  string
+		expectedCurrency string
+	}{
+		{"no hint", "price_premium_monthly", "", "price_premium_monthly", "usd"},
+		{"localized", "price_premium_monthly", "EUR", "price_premium_monthly_eur", "eur"},
+		{"no localized price", "price_premium_monthly", "gbp", "price_premium_monthly", "usd"},
+		{"plan without localized prices", "price_platinum_monthly", "eur", "price_platinum_monthly", "usd"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			priceID, currency := service.CheckoutPrice(tt.defaultPriceID, tt.currency)
+			assert.Equal(t, tt.expectedPriceID, priceID)
+			assert.Equal(t, tt.expectedCurrency, currency)
+		})
+	}
+}
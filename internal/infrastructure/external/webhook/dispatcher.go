@@ -0,0 +1,129 @@
+package webhook
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"time"
+)
+
+// Delivery defaults, used when the dispatch config leaves them unset
+const (
+	defaultDispatchTimeout      = 10 * time.Second
+	defaultDispatchRetryBackoff = time.Second
+)
+
+// DispatchConfig bounds the delivery of a notification
+type DispatchConfig struct {
+	Timeout      time.Duration // Limit for a delivery, retries included
+	MaxRetries   int           // Further attempts after a transient failure
+	RetryBackoff time.Duration // Wait before the first retry, doubled for each one after
+}
+
+// StatusError is returned when the receiver answers with a non-2xx status
+type StatusError struct {
+	StatusCode int
+}
+
+func (e *StatusError) Error() string {
+	return fmt.Sprintf("receiver returned status %d", e.StatusCode)
+}
+
+// Dispatcher posts JSON notifications to a URL, such as a Slack incoming webhook or an operator's
+// alert receiver. Network errors, timeouts of an attempt, 408, 429 and 5xx responses are retried
+// with exponential backoff. Other responses fail the delivery at once.
+type Dispatcher struct {
+	config DispatchConfig
+	client *http.Client
+}
+
+// NewDispatcher creates a new notification dispatcher
+func NewDispatcher(cfg DispatchConfig) *Dispatcher {
+	if cfg.Timeout <= 0 {
+		cfg.Timeout = defaultDispatchTimeout
+	}
+	if cfg.RetryBackoff <= 0 {
+		cfg.RetryBackoff = defaultDispatchRetryBackoff
+	}
+	if cfg.MaxRetries < 0 {
+		cfg.MaxRetries = 0
+	}
+
+	return &Dispatcher{
+		config: cfg,
+		client: &http.Client{},
+	}
+}
+
+// Post sends the payload as JSON to the URL. With a secret, the body is signed in the
+// SignatureHeader so the receiver can check it came from us.
+func (d *Dispatcher) Post(ctx context.Context, target string, payload interface{}, secret string) error {
+	if target == "" {
+		return fmt.Errorf("no URL configured")
+	}
+
+	body, err := json.Marshal(payload)
+	if err != nil {
+		return fmt.Errorf("failed to marshal notification: %w", err)
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, d.config.Timeout)
+	defer cancel()
+
+	backoff := d.config.RetryBackoff
+	for attempt := 0; ; attempt++ {
+		err = d.send(ctx, target, body, secret)
+		if err == nil || !isTransient(err) || attempt >= d.config.MaxRetries {
+			break
+		}
+
+		select {
+		case <-ctx.Done():
+			return fmt.Errorf("notification timed out after %d attempts: %w", attempt+1, err)
+		case <-time.After(backoff):
+		}
+		backoff *= 2
+	}
+	return err
+}
+
+func (d *Dispatcher) send(ctx context.Context, target string, body []byte, secret string) error {
+	req, err := http.NewRequestWithContext(ctx, "POST", target, bytes.NewReader(body))
+	if err != nil {
+		// Not wrapped, so a malformed URL isn't mistaken for a transport error and retried
+		return fmt.Errorf("failed to create notification request: %v", err)
+	}
+
+	req.Header.Set("Content-Type", "application/json")
+	if secret != "" {
+		req.Header.Set(SignatureHeader, Sign(secret, body))
+	}
+
+	resp, err := d.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to send notification: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return &StatusError{StatusCode: resp.StatusCode}
+	}
+	return nil
+}
+
+// isTransient reports whether a failed attempt is worth retrying
+func isTransient(err error) bool {
+	var statusErr *StatusError
+	if errors.As(err, &statusErr) {
+		return statusErr.StatusCode == http.StatusRequestTimeout ||
+			statusErr.StatusCode == http.StatusTooManyRequests ||
+			statusErr.StatusCode >= 500
+	}
+	// Transport errors are transient, a request that can't be built never will be
+	var urlErr *url.Error
+	return errors.As(err, &urlErr)
+}
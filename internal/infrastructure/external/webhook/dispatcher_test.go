@@ -0,0 +1,98 @@
+package webhook
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDispatcher_PostSignsPayload(t *testing.T) {
+	var body []byte
+	var signature string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ = io.ReadAll(r.Body)
+		signature = r.Header.Get(SignatureHeader)
+		assert.Equal(t, "application/json", r.Header.Get("Content-Type"))
+	}))
+	defer server.Close()
+
+	dispatcher := NewDispatcher(DispatchConfig{Timeout: time.Second})
+	require.NoError(t, dispatcher.Post(context.Background(), server.URL, map[string]string{"text": "hello"}, "secret"))
+
+	var payload map[string]string
+	require.NoError(t, json.Unmarshal(body, &payload))
+	assert.Equal(t, "hello", payload["text"])
+	assert.Equal(t, Sign("secret", body), signature)
+}
+
+func TestDispatcher_PostRetriesTransientFailures(t *testing.T) {
+	var attempts int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if atomic.AddInt32(&attempts, 1) < 3 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		assert.Empty(t, r.Header.Get(SignatureHeader), "payloads are unsigned without a secret")
+	}))
+	defer server.Close()
+
+	dispatcher := NewDispatcher(DispatchConfig{Timeout: time.Second, MaxRetries: 3, RetryBackoff: time.Millisecond})
+	require.NoError(t, dispatcher.Post(context.Background(), server.URL, map[string]string{}, ""))
+	assert.Equal(t, int32(3), atomic.LoadInt32(&attempts))
+}
+
+func TestDispatcher_PostGivesUp(t *testing.T) {
+	var attempts int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&attempts, 1)
+		if r.URL.Path == "/invalid" {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		w.WriteHeader(http.StatusBadGateway)
+	}))
+	defer server.Close()
+
+	dispatcher := NewDispatcher(DispatchConfig{Timeout: time.Second, MaxRetries: 2, RetryBackoff: time.Millisecond})
+
+	// Test retries stop at the limit
+	err := dispatcher.Post(context.Background(), server.URL, map[string]string{}, "")
+	var statusErr *StatusError
+	require.ErrorAs(t, err, &statusErr)
+	assert.Equal(t, http.StatusBadGateway, statusErr.StatusCode)
+	assert.Equal(t, int32(3), atomic.LoadInt32(&attempts))
+
+	// Test client errors aren't retried
+	atomic.StoreInt32(&attempts, 0)
+	err = dispatcher.Post(context.Background(), server.URL+"/invalid", map[string]string{}, "")
+	require.ErrorAs(t, err, &statusErr)
+	assert.Equal(t, http.StatusBadRequest, statusErr.StatusCode)
+	assert.Equal(t, int32(1), atomic.LoadInt32(&attempts))
+
+	// Test a missing URL fails without a request
+	assert.Error(t, dispatcher.Post(context.Background(), "", map[string]string{}, ""))
+}
+
+func TestDispatcher_PostTimesOut(t *testing.T) {
+	release := make(chan struct{})
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		<-release
+	}))
+	defer server.Close()
+	defer close(release)
+
+	dispatcher := NewDispatcher(DispatchConfig{Timeout: 50 * time.Millisecond, MaxRetries: 5, RetryBackoff: time.Millisecond})
+
+	start := time.Now()
+	err := dispatcher.Post(context.Background(), server.URL, map[string]string{}, "")
+	assert.Error(t, err)
+	assert.Less(t, time.Since(start), time.Second, "the timeout covers every retry")
+}
@@ -0,0 +1,100 @@
+package webhook
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/22smeargle/winkr-backend/pkg/config"
+	"github.com/22smeargle/winkr-backend/pkg/logger"
+)
+
+// SignatureHeader carries the HMAC-SHA256 of the request body
+const SignatureHeader = "X-Winkr-Signature"
+
+// Event is the envelope every outbound webhook is sent in
+type Event struct {
+	ID        string      `json:"id"`
+	Type      string      `json:"type"`
+	CreatedAt time.Time   `json:"created_at"`
+	Data      interface{} `json:"data"`
+}
+
+// Publisher delivers domain events to the configured webhook and analytics endpoints
+type Publisher struct {
+	config *config.WebhooksConfig
+	client *http.Client
+}
+
+// NewPublisher creates a new webhook publisher
+func NewPublisher(cfg *config.WebhooksConfig) *Publisher {
+	return &Publisher{
+		config: cfg,
+		client: &http.Client{Timeout: cfg.Timeout},
+	}
+}
+
+// Publish sends an event to every configured endpoint
+func (p *Publisher) Publish(ctx context.Context, eventType string, data interface{}) error {
+	if !p.config.Enabled || len(p.config.Endpoints) == 0 {
+		return nil
+	}
+
+	body, err := json.Marshal(Event{
+		ID:        uuid.New().String(),
+		Type:      eventType,
+		CreatedAt: time.Now().UTC(),
+		Data:      data,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to marshal webhook event: %w", err)
+	}
+
+	var failed int
+	for _, endpoint := range p.config.Endpoints {
+		if err := p.send(ctx, endpoint, body); err != nil {
+			logger.Error("Failed to deliver webhook", err, "event_type", eventType, "endpoint", endpoint)
+			failed++
+		}
+	}
+
+	if failed > 0 {
+		return fmt.Errorf("webhook delivery failed for %d of %d endpoints", failed, len(p.config.Endpoints))
+	}
+	return nil
+}
+
+func (p *Publisher) send(ctx context.Context, endpoint string, body []byte) error {
+	req, err := http.NewRequestWithContext(ctx, "POST", endpoint, bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("failed to create webhook request: %w", err)
+	}
+
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set(SignatureHeader, Sign(p.config.Secret, body))
+
+	resp, err := p.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to send webhook: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("webhook endpoint returned status %d", resp.StatusCode)
+	}
+	return nil
+}
+
+// Sign returns the hex encoded HMAC-SHA256 of a payload
+func Sign(secret string, payload []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write(payload)
+	return hex.EncodeToString(mac.Sum(nil))
+}
@@ -0,0 +1,39 @@
+package notification
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/22smeargle/winkr-backend/internal/application/services"
+	"github.com/22smeargle/winkr-backend/internal/infrastructure/websocket"
+)
+
+// LinkPreviewEventType is pushed to both participants' connected clients when the previews of a
+// message's links are ready after it was sent
+const LinkPreviewEventType = "message:link_preview"
+
+// LinkPreviewNotifier delivers link previews to the connected clients of a conversation
+type LinkPreviewNotifier struct {
+	connectionManager *websocket.ConnectionManager
+}
+
+// NewLinkPreviewNotifier creates a new LinkPreviewNotifier
+func NewLinkPreviewNotifier(connectionManager *websocket.ConnectionManager) *LinkPreviewNotifier {
+	return &LinkPreviewNotifier{
+		connectionManager: connectionManager,
+	}
+}
+
+// NotifyLinkPreviews pushes all of the message's previews to the conversation
+func (n *LinkPreviewNotifier) NotifyLinkPreviews(ctx context.Context, conversationID, messageID uuid.UUID, previews []services.LinkPreview) error {
+	return n.connectionManager.BroadcastToConversation(conversationID.String(), websocket.Message{
+		Type: LinkPreviewEventType,
+		Data: map[string]interface{}{
+			"conversation_id": conversationID,
+			"message_id":      messageID,
+			"link_previews":   previews,
+		},
+		Timestamp: time.Now(),
+	})
+}
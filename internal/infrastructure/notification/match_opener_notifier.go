@@ -0,0 +1,50 @@
+package notification
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/22smeargle/winkr-backend/internal/application/services"
+	"github.com/22smeargle/winkr-backend/internal/domain/entities"
+	"github.com/22smeargle/winkr-backend/internal/infrastructure/websocket"
+)
+
+// MatchOpenedEventType is pushed to both users' connected clients when the conversation of their new
+// match is opened
+const MatchOpenedEventType = "match:opened"
+
+// MatchOpenerNotifier delivers the opening of a new match's conversation to both users
+type MatchOpenerNotifier struct {
+	connectionManager *websocket.ConnectionManager
+}
+
+// NewMatchOpenerNotifier creates a new MatchOpenerNotifier
+func NewMatchOpenerNotifier(connectionManager *websocket.ConnectionManager) *MatchOpenerNotifier {
+	return &MatchOpenerNotifier{
+		connectionManager: connectionManager,
+	}
+}
+
+// NotifyMatchOpened pushes the prompt and auto-intros to both users, trying the second even if the first fails
+func (n *MatchOpenerNotifier) NotifyMatchOpened(ctx context.Context, match *entities.Match, opening *services.MatchOpening) error {
+	message := websocket.Message{
+		Type: MatchOpenedEventType,
+		Data: map[string]interface{}{
+			"match_id":        match.ID,
+			"conversation_id": opening.Conversation.ID,
+			"prompt":          opening.Prompt,
+			"intros":          opening.Intros,
+		},
+		Timestamp: time.Now(),
+	}
+
+	var firstErr error
+	for _, userID := range []string{match.User1ID.String(), match.User2ID.String()} {
+		if err := n.connectionManager.BroadcastToUser(userID, message); err != nil && firstErr == nil {
+			firstErr = fmt.Errorf("failed to push match opening to user %s: %w", userID, err)
+		}
+	}
+
+	return firstErr
+}
@@ -0,0 +1,44 @@
+package notification
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/22smeargle/winkr-backend/internal/domain/entities"
+	"github.com/22smeargle/winkr-backend/internal/infrastructure/websocket"
+)
+
+// MilestoneEventType is pushed to both participants' connected clients when their conversation
+// reaches a streak or anniversary milestone
+const MilestoneEventType = "conversation:milestone"
+
+// MilestoneNotifier delivers conversation milestones to the connected clients of both participants
+type MilestoneNotifier struct {
+	connectionManager *websocket.ConnectionManager
+}
+
+// NewMilestoneNotifier creates a new MilestoneNotifier
+func NewMilestoneNotifier(connectionManager *websocket.ConnectionManager) *MilestoneNotifier {
+	return &MilestoneNotifier{
+		connectionManager: connectionManager,
+	}
+}
+
+// NotifyMilestone pushes the milestone to both participants, trying the second even if the first fails
+func (n *MilestoneNotifier) NotifyMilestone(ctx context.Context, match *entities.Match, milestone *entities.ConversationMilestone) error {
+	message := websocket.Message{
+		Type:      MilestoneEventType,
+		Data:      milestone,
+		Timestamp: time.Now(),
+	}
+
+	var firstErr error
+	for _, userID := range []string{match.User1ID.String(), match.User2ID.String()} {
+		if err := n.connectionManager.BroadcastToUser(userID, message); err != nil && firstErr == nil {
+			firstErr = fmt.Errorf("failed to push milestone to user %s: %w", userID, err)
+		}
+	}
+
+	return firstErr
+}
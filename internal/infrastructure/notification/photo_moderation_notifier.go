@@ -0,0 +1,36 @@
+package notification
+
+import (
+	"context"
+	"time"
+
+	"github.com/22smeargle/winkr-backend/internal/domain/entities"
+	"github.com/22smeargle/winkr-backend/internal/infrastructure/websocket"
+)
+
+// PhotoRejectedEventType is pushed to a user's connected clients when moderation rejects their photo
+const PhotoRejectedEventType = "photo:rejected"
+
+// PhotoModerationNotifier tells users moderation rejected a photo they uploaded
+type PhotoModerationNotifier struct {
+	connectionManager *websocket.ConnectionManager
+}
+
+// NewPhotoModerationNotifier creates a new PhotoModerationNotifier
+func NewPhotoModerationNotifier(connectionManager *websocket.ConnectionManager) *PhotoModerationNotifier {
+	return &PhotoModerationNotifier{
+		connectionManager: connectionManager,
+	}
+}
+
+// NotifyPhotoRejected pushes the rejected photo and the reason to the owner's clients
+func (n *PhotoModerationNotifier) NotifyPhotoRejected(ctx context.Context, photo *entities.Photo, reason string) error {
+	return n.connectionManager.BroadcastToUser(photo.UserID.String(), websocket.Message{
+		Type: PhotoRejectedEventType,
+		Data: map[string]interface{}{
+			"photo_id": photo.ID,
+			"reason":   reason,
+		},
+		Timestamp: time.Now(),
+	})
+}
@@ -0,0 +1,65 @@
+package notification
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/22smeargle/winkr-backend/internal/infrastructure/websocket"
+)
+
+// MessagesViewedEventType is pushed to a sender's connected clients when the recipient reads
+// several of their messages at once
+const MessagesViewedEventType = "messages:viewed"
+
+// ReadReceiptEventType is pushed to a sender's connected clients when the other participant reads
+// their messages up to a given message
+const ReadReceiptEventType = "read_receipt"
+
+// ReadReceiptNotifier delivers batched read receipts and unread counts to connected clients
+type ReadReceiptNotifier struct {
+	connectionManager *websocket.ConnectionManager
+}
+
+// NewReadReceiptNotifier creates a new ReadReceiptNotifier
+func NewReadReceiptNotifier(connectionManager *websocket.ConnectionManager) *ReadReceiptNotifier {
+	return &ReadReceiptNotifier{
+		connectionManager: connectionManager,
+	}
+}
+
+// NotifyMessagesRead pushes one read receipt for all the messages to the sender
+func (n *ReadReceiptNotifier) NotifyMessagesRead(ctx context.Context, senderID, readerID, conversationID uuid.UUID, messageIDs []uuid.UUID, readAt time.Time) error {
+	return n.connectionManager.BroadcastToUser(senderID.String(), websocket.Message{
+		Type: MessagesViewedEventType,
+		Data: map[string]interface{}{
+			"conversation_id": conversationID,
+			"message_ids":     messageIDs,
+			"user_id":         readerID,
+			"viewed_at":       readAt,
+		},
+		Timestamp: time.Now(),
+		SenderID:  readerID.String(),
+	})
+}
+
+// NotifyReadReceipt pushes one read receipt for the messages read up to upToMessageID to the sender
+func (n *ReadReceiptNotifier) NotifyReadReceipt(ctx context.Context, senderID, readerID, conversationID, upToMessageID uuid.UUID, messageIDs []uuid.UUID, readAt time.Time) error {
+	return n.connectionManager.BroadcastToUser(senderID.String(), websocket.Message{
+		Type: ReadReceiptEventType,
+		Data: map[string]interface{}{
+			"conversation_id":  conversationID,
+			"up_to_message_id": upToMessageID,
+			"message_ids":      messageIDs,
+			"user_id":          readerID,
+			"read_at":          readAt,
+		},
+		Timestamp: time.Now(),
+		SenderID:  readerID.String(),
+	})
+}
+
+// NotifyUnreadCount pushes the user's unread count for the conversation to their clients
+func (n *ReadReceiptNotifier) NotifyUnreadCount(ctx context.Context, userID, conversationID uuid.UUID, count int) error {
+	return n.connectionManager.UpdateUnreadCount(userID.String(), conversationID.String(), count)
+}
@@ -0,0 +1,91 @@
+package notification
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/22smeargle/winkr-backend/internal/domain/entities"
+	"github.com/22smeargle/winkr-backend/internal/infrastructure/external/email"
+	"github.com/22smeargle/winkr-backend/internal/infrastructure/websocket"
+	"github.com/22smeargle/winkr-backend/pkg/logger"
+)
+
+// CheckInMissedEventType is pushed to a user's connected clients when they miss a safety check-in
+const CheckInMissedEventType = "safety:check_in_missed"
+
+// SafetyNotifier delivers safety check-in alerts. The user is alerted through their connected
+// clients and by email, and the trusted contact by email.
+type SafetyNotifier struct {
+	connectionManager *websocket.ConnectionManager
+	emailService      email.EmailService
+}
+
+// NewSafetyNotifier creates a new SafetyNotifier
+func NewSafetyNotifier(connectionManager *websocket.ConnectionManager, emailService email.EmailService) *SafetyNotifier {
+	return &SafetyNotifier{
+		connectionManager: connectionManager,
+		emailService:      emailService,
+	}
+}
+
+// NotifyUserMissed alerts the user by push and email. The email is sent even if the push fails,
+// as the user may not have the app open.
+func (n *SafetyNotifier) NotifyUserMissed(ctx context.Context, user *entities.User, checkIn *entities.SafetyCheckIn) error {
+	message := websocket.Message{
+		Type: CheckInMissedEventType,
+		Data: map[string]interface{}{
+			"check_in_id": checkIn.ID.String(),
+			"due_at":      checkIn.DueAt,
+		},
+		Timestamp: time.Now(),
+	}
+	if err := n.connectionManager.BroadcastToUser(user.ID.String(), message); err != nil {
+		logger.Error("Failed to push missed check-in alert", err, "user_id", user.ID)
+	}
+
+	if err := n.emailService.SendMissedCheckInEmail(ctx, user.Email, user.FirstName, checkIn.DueAt); err != nil {
+		return fmt.Errorf("failed to email user: %w", err)
+	}
+
+	return nil
+}
+
+// NotifyContactMissed emails the user's trusted contact
+func (n *SafetyNotifier) NotifyContactMissed(ctx context.Context, user *entities.User, checkIn *entities.SafetyCheckIn) error {
+	if !checkIn.CanAlertContact() {
+		return nil
+	}
+
+	note := ""
+	if checkIn.Note != nil {
+		note = *checkIn.Note
+	}
+
+	if err := n.emailService.SendTrustedContactAlertEmail(ctx, *checkIn.ContactEmail, contactName(checkIn), user.FirstName, checkIn.DueAt, note); err != nil {
+		return fmt.Errorf("failed to email trusted contact: %w", err)
+	}
+
+	return nil
+}
+
+// NotifyContactSafe emails the user's trusted contact that the user checked in
+func (n *SafetyNotifier) NotifyContactSafe(ctx context.Context, user *entities.User, checkIn *entities.SafetyCheckIn) error {
+	if !checkIn.CanAlertContact() {
+		return nil
+	}
+
+	if err := n.emailService.SendTrustedContactAllClearEmail(ctx, *checkIn.ContactEmail, contactName(checkIn), user.FirstName); err != nil {
+		return fmt.Errorf("failed to email trusted contact: %w", err)
+	}
+
+	return nil
+}
+
+// contactName returns the name of the trusted contact
+func contactName(checkIn *entities.SafetyCheckIn) string {
+	if checkIn.ContactName == nil {
+		return ""
+	}
+	return *checkIn.ContactName
+}
@@ -0,0 +1,45 @@
+package notification
+
+import (
+	"context"
+	"time"
+
+	"github.com/22smeargle/winkr-backend/internal/application/services"
+	"github.com/22smeargle/winkr-backend/internal/domain/entities"
+	"github.com/22smeargle/winkr-backend/internal/infrastructure/websocket"
+)
+
+// ScheduledMessageSkippedEventType is pushed to a sender's connected clients when a message they
+// scheduled could not be sent
+const ScheduledMessageSkippedEventType = "message:scheduled_skipped"
+
+// ScheduledMessageNotifier delivers the outcome of due scheduled messages to connected clients
+type ScheduledMessageNotifier struct {
+	connectionManager *websocket.ConnectionManager
+}
+
+// NewScheduledMessageNotifier creates a new ScheduledMessageNotifier
+func NewScheduledMessageNotifier(connectionManager *websocket.ConnectionManager) *ScheduledMessageNotifier {
+	return &ScheduledMessageNotifier{
+		connectionManager: connectionManager,
+	}
+}
+
+// NotifyMessageSent pushes the sent message to the conversation like any other new message
+func (n *ScheduledMessageNotifier) NotifyMessageSent(ctx context.Context, message *services.ProcessedMessage) error {
+	return n.connectionManager.BroadcastToConversation(message.ConversationID.String(), websocket.Message{
+		Type:      "message:new",
+		Data:      message,
+		Timestamp: message.CreatedAt,
+		SenderID:  message.SenderID.String(),
+	})
+}
+
+// NotifySkipped tells the sender their scheduled message was not sent and why
+func (n *ScheduledMessageNotifier) NotifySkipped(ctx context.Context, scheduled *entities.ScheduledMessage) error {
+	return n.connectionManager.BroadcastToUser(scheduled.SenderID.String(), websocket.Message{
+		Type:      ScheduledMessageSkippedEventType,
+		Data:      scheduled,
+		Timestamp: time.Now(),
+	})
+}
@@ -0,0 +1,40 @@
+package notification
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/22smeargle/winkr-backend/internal/application/services"
+	"github.com/22smeargle/winkr-backend/internal/domain/entities"
+	"github.com/22smeargle/winkr-backend/internal/infrastructure/websocket"
+)
+
+// VerificationFailedEventType is pushed to a user's connected clients when their verification fails
+const VerificationFailedEventType = "verification:failed"
+
+// VerificationNotifier tells users their verification failed, with guidance for their next attempt
+type VerificationNotifier struct {
+	connectionManager *websocket.ConnectionManager
+}
+
+// NewVerificationNotifier creates a new VerificationNotifier
+func NewVerificationNotifier(connectionManager *websocket.ConnectionManager) *VerificationNotifier {
+	return &VerificationNotifier{
+		connectionManager: connectionManager,
+	}
+}
+
+// NotifyVerificationFailed pushes the guidance and when the user may try again to their clients
+func (n *VerificationNotifier) NotifyVerificationFailed(ctx context.Context, userID uuid.UUID, vType entities.VerificationType, guidance *services.VerificationGuidance, retryAt time.Time) error {
+	return n.connectionManager.BroadcastToUser(userID.String(), websocket.Message{
+		Type: VerificationFailedEventType,
+		Data: map[string]interface{}{
+			"type":     vType,
+			"reason":   guidance.Reason,
+			"message":  guidance.Message,
+			"retry_at": retryAt,
+		},
+		Timestamp: time.Now(),
+	})
+}
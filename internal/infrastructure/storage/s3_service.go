@@ -40,6 +40,9 @@ type StorageService interface {
 	
 	// GetFileInfo gets file information
 	GetFileInfo(ctx context.Context, key string) (*FileInfo, error)
+	
+	// GetFile opens a file for reading, returning its size and content type
+	GetFile(ctx context.Context, key string) (io.ReadCloser, int64, string, error)
 }
 
 // FileInfo represents file information
@@ -349,6 +352,31 @@ func (s *S3Storage) GetFileInfo(ctx context.Context, key string) (*FileInfo, err
 	}, nil
 }
 
+// GetFile opens a file for reading, returning its size and content type
+func (s *S3Storage) GetFile(ctx context.Context, key string) (io.ReadCloser, int64, string, error) {
+	resp, err := s.client.GetObject(ctx, &s3.GetObjectInput{
+		Bucket: aws.String(s.bucket),
+		Key:    aws.String(key),
+	})
+
+	if err != nil {
+		logger.Error("Failed to get file", err)
+		return nil, 0, "", fmt.Errorf("failed to get file: %w", err)
+	}
+
+	var size int64
+	if resp.ContentLength != nil {
+		size = *resp.ContentLength
+	}
+
+	var contentType string
+	if resp.ContentType != nil {
+		contentType = *resp.ContentType
+	}
+
+	return resp.Body, size, contentType, nil
+}
+
 // generateFileKey generates a unique file key
 func (s *S3Storage) generateFileKey() string {
 	id := uuid.New()
@@ -4,16 +4,30 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
-	"net/http"
 	"sync"
+	"sync/atomic"
 	"time"
 
 	"github.com/gin-gonic/gin"
 	"github.com/gorilla/websocket"
 	"github.com/22smeargle/winkr-backend/internal/infrastructure/cache"
+	"github.com/22smeargle/winkr-backend/pkg/config"
 	"github.com/22smeargle/winkr-backend/pkg/logger"
 )
 
+// Heartbeat defaults used when the WebSocket config leaves them unset
+const (
+	defaultPingInterval = 30 * time.Second
+	defaultPongWait     = 60 * time.Second
+	defaultWriteWait    = 10 * time.Second
+)
+
+// defaultTypingIndicatorTTL is the typing indicator window used until SetTypingIndicatorTTL is called
+const defaultTypingIndicatorTTL = 5 * time.Second
+
+// MessageHandler handles a data message received from a client
+type MessageHandler func(ctx context.Context, conn *ClientConnection, rawMessage []byte) error
+
 // ConnectionManager manages WebSocket connections
 type ConnectionManager struct {
 	connections map[string]*ClientConnection
@@ -22,8 +36,13 @@ type ConnectionManager struct {
 	sessionMgr  *cache.SessionManager
 	chatRooms   map[string]*ChatRoom // Conversation ID -> ChatRoom
 	chatMu      sync.RWMutex
-	typingUsers map[string]map[string]time.Time // Conversation ID -> User ID -> Last typing time
+	typingUsers map[string]map[string]*typingState // Conversation ID -> User ID -> Typing state
 	typingMu    sync.RWMutex
+	typingTTL   time.Duration // Set with SetTypingIndicatorTTL, zero uses the default
+	config      *config.WebSocketConfig
+	onMessage   MessageHandler
+	isShadowbanned ShadowbanCheck // Set with SetShadowbanCheck, nil lets every message through
+	reaped      int64 // Connections closed for missing pongs, updated atomically
 }
 
 // ClientConnection represents a WebSocket client connection
@@ -34,10 +53,15 @@ type ClientConnection struct {
 	IPAddress  string
 	UserAgent   string
 	LastPing   time.Time
+	LastPong   time.Time
 	IsAlive    bool
 	mu          sync.RWMutex
 	Channels    map[string]bool // Subscribed channels
 	ActiveConversations map[string]bool // Conversation ID -> IsActive
+	syncing     bool      // Live messages are held back while the client catches up
+	held        []Message // Live messages received while syncing, in arrival order
+	presence    map[string]bool // User IDs whose presence changes are pushed to this connection
+	presenceSubscribedAt time.Time // Last accepted presence subscription, for rate limiting
 }
 
 // ChatRoom represents a chat room/conversation
@@ -68,6 +92,13 @@ type ChatMessage struct {
 	CreatedAt      time.Time   `json:"created_at"`
 }
 
+// typingState tracks a user who is typing in a conversation
+type typingState struct {
+	lastTyping  time.Time   // Last typing event received from the user
+	broadcastAt time.Time   // Last time the indicator was sent to the other participants
+	timer       *time.Timer // Clears the indicator when no typing event arrives within the window
+}
+
 // TypingIndicator represents a typing indicator
 type TypingIndicator struct {
 	ConversationID string    `json:"conversation_id"`
@@ -90,21 +121,31 @@ type UnreadCount struct {
 	Count         int    `json:"count"`
 }
 
-// NewConnectionManager creates a new connection manager
-func NewConnectionManager(pubSub *cache.PubSubService, sessionMgr *cache.SessionManager) *ConnectionManager {
+// NewConnectionManager creates a new connection manager. A nil config uses the default heartbeat timings.
+func NewConnectionManager(pubSub *cache.PubSubService, sessionMgr *cache.SessionManager, cfg *config.WebSocketConfig) *ConnectionManager {
 	return &ConnectionManager{
 		connections:  make(map[string]*ClientConnection),
 		pubSub:       pubSub,
 		sessionMgr:   sessionMgr,
 		chatRooms:    make(map[string]*ChatRoom),
-		typingUsers:  make(map[string]map[string]time.Time),
+		typingUsers:  make(map[string]map[string]*typingState),
+		config:       cfg,
 	}
 }
 
+// SetMessageHandler sets the handler for data messages received from clients
+func (cm *ConnectionManager) SetMessageHandler(handler MessageHandler) {
+	cm.onMessage = handler
+}
+
 // HandleConnection handles a new WebSocket connection
 func (cm *ConnectionManager) HandleConnection(c *gin.Context) error {
 	// Upgrade HTTP connection to WebSocket
-	conn, err := websocket.Upgrade(c.Writer, c.Request, nil)
+	var readBufferSize, writeBufferSize int
+	if cm.config != nil {
+		readBufferSize, writeBufferSize = cm.config.ReadBufferSize, cm.config.WriteBufferSize
+	}
+	conn, err := websocket.Upgrade(c.Writer, c.Request, nil, readBufferSize, writeBufferSize)
 	if err != nil {
 		logger.Error("Failed to upgrade WebSocket connection", err)
 		return fmt.Errorf("failed to upgrade WebSocket connection: %w", err)
@@ -131,6 +172,7 @@ func (cm *ConnectionManager) HandleConnection(c *gin.Context) error {
 		IPAddress:          c.ClientIP(),
 		UserAgent:          c.GetHeader("User-Agent"),
 		LastPing:          time.Now(),
+		LastPong:           time.Now(),
 		IsAlive:            true,
 		Channels:           make(map[string]bool),
 		ActiveConversations: make(map[string]bool),
@@ -199,57 +241,101 @@ func (cm *ConnectionManager) handleConnection(conn *ClientConnection, connection
 	// Set user as online
 	cm.setUserOnline(conn.UserID, true)
 
-	// Start message reader
-	for {
-		select {
-		case <-time.After(30 * time.Second):
-			// Check connection health
-			conn.mu.RLock()
-			isAlive := conn.IsAlive
-			lastPing := conn.LastPing
-			conn.mu.RUnlock()
-			
-			if !isAlive || time.Since(lastPing) > 60*time.Second {
-				logger.Info("Closing inactive WebSocket connection", "connection_id", connectionID)
-				return
-			}
-			
-			// Send ping to keep connection alive
-			err := conn.WriteMessage(Message{
-				Type: "ping",
-				Data: map[string]interface{}{
-					"timestamp": time.Now(),
-				},
-				Timestamp: time.Now(),
-			})
-			if err != nil {
-				logger.Error("Failed to send ping", err, "connection_id", connectionID)
-				return
-			}
+	// Pongs are only processed while reading, so the reader must run for the heartbeat to work
+	go cm.readMessages(conn, connectionID)
+
+	// Ping the client and reap the connection once it stops answering
+	pongWait := cm.pongWait()
+	ticker := time.NewTicker(cm.pingInterval())
+	defer ticker.Stop()
+
+	for range ticker.C {
+		if !conn.isAlive() {
+			return
+		}
+
+		if conn.pongOverdue(time.Now(), pongWait) {
+			cm.reapConnection(connectionID)
+			return
+		}
+
+		if err := conn.WritePing(cm.writeWait()); err != nil {
+			logger.Error("Failed to send ping", err, "connection_id", connectionID)
+			return
 		}
 	}
 }
 
-// removeConnection removes a connection from the manager
-func (cm *ConnectionManager) removeConnection(connectionID string) {
-	cm.mu.Lock()
-	defer cm.mu.Unlock()
-	
-	if conn, exists := cm.connections[connectionID]; exists {
+// readMessages reads from the connection until it fails, so control frames (pongs, close) are
+// processed, and passes data messages on to the message handler
+func (cm *ConnectionManager) readMessages(conn *ClientConnection, connectionID string) {
+	if cm.config != nil && cm.config.MaxMessageSize > 0 {
+		conn.Conn.SetReadLimit(cm.config.MaxMessageSize)
+	}
+
+	conn.Conn.SetPongHandler(func(string) error {
 		conn.mu.Lock()
-		conn.IsAlive = false
+		conn.LastPong = time.Now()
 		conn.mu.Unlock()
-		
-		conn.Conn.Close()
-		delete(cm.connections, connectionID)
-		
-		// Set user as offline if no more connections
-		if !cm.hasActiveConnections(conn.UserID) {
-			cm.setUserOnline(conn.UserID, false)
+		return nil
+	})
+
+	for {
+		_, data, err := conn.Conn.ReadMessage()
+		if err != nil {
+			conn.mu.Lock()
+			conn.IsAlive = false
+			conn.mu.Unlock()
+			return
 		}
-		
-		logger.Info("WebSocket connection removed", "connection_id", connectionID, "user_id", conn.UserID)
+
+		if cm.onMessage == nil {
+			continue
+		}
+		if err := cm.onMessage(context.Background(), conn, data); err != nil {
+			logger.Warn("Failed to handle WebSocket message", err, "connection_id", connectionID)
+		}
+	}
+}
+
+// reapConnection closes and removes a connection that stopped answering pings
+func (cm *ConnectionManager) reapConnection(connectionID string) {
+	if !cm.removeConnection(connectionID) {
+		return
+	}
+
+	atomic.AddInt64(&cm.reaped, 1)
+	logger.Info("Reaped stale WebSocket connection", "connection_id", connectionID, "pong_wait", cm.pongWait())
+}
+
+// removeConnection removes a connection from the manager and reports whether it was still registered
+func (cm *ConnectionManager) removeConnection(connectionID string) bool {
+	cm.mu.Lock()
+	conn, exists := cm.connections[connectionID]
+	if exists {
+		delete(cm.connections, connectionID)
+	}
+	cm.mu.Unlock()
+	
+	if !exists {
+		return false
+	}
+	
+	conn.mu.Lock()
+	conn.IsAlive = false
+	conn.presence = nil
+	conn.mu.Unlock()
+	
+	conn.Conn.Close()
+	
+	// Set user as offline if no more connections. The registry lock must be released first,
+	// since both the check and the status broadcast take it.
+	if !cm.hasActiveConnections(conn.UserID) {
+		cm.setUserOnline(conn.UserID, false)
 	}
+	
+	logger.Info("WebSocket connection removed", "connection_id", connectionID, "user_id", conn.UserID)
+	return true
 }
 
 // BroadcastToUser sends a message to all connections for a user
@@ -259,7 +345,7 @@ func (cm *ConnectionManager) BroadcastToUser(userID string, message Message) err
 	
 	sentCount := 0
 	for connectionID, conn := range cm.connections {
-		if conn.UserID == userID && conn.IsAlive {
+		if conn.UserID == userID && conn.isAlive() {
 			err := conn.WriteMessage(message)
 			if err != nil {
 				logger.Error("Failed to send message to connection", err, 
@@ -288,7 +374,7 @@ func (cm *ConnectionManager) BroadcastToChannel(channel string, message Message)
 	
 	sentCount := 0
 	for connectionID, conn := range cm.connections {
-		if conn.IsAlive && conn.isSubscribedTo(channel) {
+		if conn.isAlive() && conn.isSubscribedTo(channel) {
 			err := conn.WriteMessage(message)
 			if err != nil {
 				logger.Error("Failed to send channel message to connection", err, 
@@ -317,7 +403,7 @@ func (cm *ConnectionManager) BroadcastToAll(message Message) error {
 	
 	sentCount := 0
 	for connectionID, conn := range cm.connections {
-		if conn.IsAlive {
+		if conn.isAlive() {
 			err := conn.WriteMessage(message)
 			if err != nil {
 				logger.Error("Failed to send broadcast message to connection", err, "connection_id", connectionID)
@@ -350,7 +436,7 @@ func (cm *ConnectionManager) GetUserConnections(userID string) []*ClientConnecti
 	
 	var connections []*ClientConnection
 	for _, conn := range cm.connections {
-		if conn.UserID == userID && conn.IsAlive {
+		if conn.UserID == userID && conn.isAlive() {
 			connections = append(connections, conn)
 		}
 	}
@@ -367,7 +453,7 @@ func (cm *ConnectionManager) GetConnectionStats() map[string]interface{} {
 	totalConnections := 0
 	
 	for _, conn := range cm.connections {
-		if conn.IsAlive {
+		if conn.isAlive() {
 			totalConnections++
 			userConnections[conn.UserID]++
 		}
@@ -377,45 +463,76 @@ func (cm *ConnectionManager) GetConnectionStats() map[string]interface{} {
 		"total_connections":    totalConnections,
 		"unique_users":         len(userConnections),
 		"user_connections":      userConnections,
+		"reaped_connections":    atomic.LoadInt64(&cm.reaped),
 	}
 	
 	return stats
 }
 
-// CleanupInactiveConnections removes inactive connections
+// CleanupInactiveConnections removes dead connections and reaps the ones that missed their pong
 func (cm *ConnectionManager) CleanupInactiveConnections() {
-	cm.mu.Lock()
-	defer cm.mu.Unlock()
-	
 	now := time.Now()
-	removedCount := 0
+	pongWait := cm.pongWait()
 	
+	var dead, stale []string
+	cm.mu.RLock()
 	for connectionID, conn := range cm.connections {
-		conn.mu.Lock()
-		isAlive := conn.IsAlive
-		lastPing := conn.LastPing
-		conn.mu.Unlock()
-		
-		if !isAlive || now.Sub(lastPing) > 90*time.Second {
-			conn.Conn.Close()
-			delete(cm.connections, connectionID)
-			removedCount++
-			
-			logger.Info("Removed inactive WebSocket connection", 
-				"connection_id", connectionID,
-				"user_id", conn.UserID,
-				"inactive_duration", now.Sub(lastPing),
-			)
+		if !conn.isAlive() {
+			dead = append(dead, connectionID)
+		} else if conn.pongOverdue(now, pongWait) {
+			stale = append(stale, connectionID)
 		}
 	}
+	cm.mu.RUnlock()
+	
+	for _, connectionID := range dead {
+		cm.removeConnection(connectionID)
+	}
+	for _, connectionID := range stale {
+		cm.reapConnection(connectionID)
+	}
+	
+	if removedCount := len(dead) + len(stale); removedCount > 0 {
+		logger.Info("Inactive connections cleanup completed", "removed_count", removedCount, "reaped_count", len(stale))
+	}
+}
+
+// pingInterval returns how often clients are pinged. It stays below the pong wait so a
+// healthy client always gets a ping in before its deadline.
+func (cm *ConnectionManager) pingInterval() time.Duration {
+	interval := defaultPingInterval
+	if cm.config != nil && cm.config.PingInterval > 0 {
+		interval = cm.config.PingInterval
+	}
 	
-	if removedCount > 0 {
-		logger.Info("Inactive connections cleanup completed", "removed_count", removedCount)
+	if pongWait := cm.pongWait(); interval >= pongWait {
+		interval = pongWait * 9 / 10
+	}
+	return interval
+}
+
+// pongWait returns how long a client may go without answering a ping before it is reaped
+func (cm *ConnectionManager) pongWait() time.Duration {
+	if cm.config != nil && cm.config.PongWait > 0 {
+		return cm.config.PongWait
 	}
+	return defaultPongWait
+}
+
+// writeWait returns the deadline for writing a ping
+func (cm *ConnectionManager) writeWait() time.Duration {
+	if cm.config != nil && cm.config.WriteWait > 0 {
+		return cm.config.WriteWait
+	}
+	return defaultWriteWait
 }
 
 // subscribeUserToChannels subscribes a user to their relevant channels
 func (cm *ConnectionManager) subscribeUserToChannels(conn *ClientConnection) {
+	if cm.pubSub == nil {
+		return
+	}
+	
 	// Subscribe to user-specific notification channel
 	notificationChannel := cache.GeneratePubSubChannel("notifications", conn.UserID)
 	go cm.handlePubSubSubscription(conn, notificationChannel)
@@ -487,11 +604,22 @@ func (cm *ConnectionManager) generateConnectionID(userID, sessionID string) stri
 	return fmt.Sprintf("%s:%s:%d", userID, sessionID, time.Now().UnixNano())
 }
 
-// WriteMessage writes a message to the WebSocket connection
+// WriteMessage writes a message to the WebSocket connection. While the client is catching up
+// on missed messages, the message is held back and written once the catch-up is done.
 func (conn *ClientConnection) WriteMessage(message Message) error {
 	conn.mu.Lock()
 	defer conn.mu.Unlock()
 	
+	if conn.syncing {
+		conn.held = append(conn.held, message)
+		return nil
+	}
+	
+	return conn.write(message)
+}
+
+// write marshals and writes a message. The caller must hold conn.mu.
+func (conn *ClientConnection) write(message Message) error {
 	if !conn.IsAlive {
 		return fmt.Errorf("connection is not alive")
 	}
@@ -502,7 +630,7 @@ func (conn *ClientConnection) WriteMessage(message Message) error {
 	}
 	
 	conn.Conn.SetWriteDeadline(time.Now().Add(10 * time.Second))
-	err = conn.Conn.WriteMessage(websocket.TextMessage, string(messageData))
+	err = conn.Conn.WriteMessage(websocket.TextMessage, messageData)
 	if err != nil {
 		conn.IsAlive = false
 		return fmt.Errorf("failed to write message: %w", err)
@@ -511,6 +639,40 @@ func (conn *ClientConnection) WriteMessage(message Message) error {
 	return nil
 }
 
+// WritePing sends a ping control frame to the client
+func (conn *ClientConnection) WritePing(writeWait time.Duration) error {
+	conn.mu.Lock()
+	defer conn.mu.Unlock()
+	
+	if !conn.IsAlive {
+		return fmt.Errorf("connection is not alive")
+	}
+	
+	err := conn.Conn.WriteControl(websocket.PingMessage, nil, time.Now().Add(writeWait))
+	if err != nil {
+		conn.IsAlive = false
+		return fmt.Errorf("failed to write ping: %w", err)
+	}
+	
+	return nil
+}
+
+// isAlive reports whether the connection is still usable
+func (conn *ClientConnection) isAlive() bool {
+	conn.mu.RLock()
+	defer conn.mu.RUnlock()
+	
+	return conn.IsAlive
+}
+
+// pongOverdue reports whether the client has not answered a ping within the pong wait
+func (conn *ClientConnection) pongOverdue(now time.Time, pongWait time.Duration) bool {
+	conn.mu.RLock()
+	defer conn.mu.RUnlock()
+	
+	return now.Sub(conn.LastPong) > pongWait
+}
+
 // isSubscribedTo checks if connection is subscribed to a channel
 func (conn *ClientConnection) isSubscribedTo(channel string) bool {
 	conn.mu.RLock()
@@ -616,6 +778,12 @@ func (cm *ConnectionManager) LeaveConversation(userID, conversationID string) er
 
 // BroadcastToConversation sends a message to all participants in a conversation
 func (cm *ConnectionManager) BroadcastToConversation(conversationID string, message Message) error {
+	return cm.broadcastToConversation(conversationID, message, false)
+}
+
+// broadcastToConversation sends a message to the participants in a conversation, leaving out
+// the sender's own connections when skipSender is set
+func (cm *ConnectionManager) broadcastToConversation(conversationID string, message Message, skipSender bool) error {
 	cm.chatMu.RLock()
 	room, exists := cm.chatRooms[conversationID]
 	cm.chatMu.RUnlock()
@@ -631,11 +799,18 @@ func (cm *ConnectionManager) BroadcastToConversation(conversationID string, mess
 	}
 	room.mu.RUnlock()
 	
-	// Send to all participants
+	// Send to all participants, or only back to the sender if they are shadowbanned
+	withheld := cm.withholds(message.SenderID)
 	sentCount := 0
 	cm.mu.RLock()
 	for _, conn := range cm.connections {
-		if conn.IsAlive && conn.isParticipantInConversation(conversationID) {
+		if withheld && conn.UserID != message.SenderID {
+			continue
+		}
+		if skipSender && conn.UserID == message.SenderID {
+			continue
+		}
+		if conn.isAlive() && conn.isParticipantInConversation(conversationID) {
 			err := conn.WriteMessage(message)
 			if err != nil {
 				logger.Error("Failed to send message to conversation participant", err,
@@ -659,23 +834,100 @@ func (cm *ConnectionManager) BroadcastToConversation(conversationID string, mess
 	return nil
 }
 
-// SetTyping sets typing indicator for a user in a conversation
-func (cm *ConnectionManager) SetTyping(userID, conversationID string, isTyping bool) error {
+// SetTypingIndicatorTTL sets the typing indicator window. A user's typing indicator reaches the
+// other participants at most once per window, and is cleared when the window passes without a
+// new typing event. Zero keeps the default.
+func (cm *ConnectionManager) SetTypingIndicatorTTL(ttl time.Duration) {
 	cm.typingMu.Lock()
 	defer cm.typingMu.Unlock()
 	
+	cm.typingTTL = ttl
+}
+
+// typingIndicatorTTL returns the typing indicator window
+func (cm *ConnectionManager) typingIndicatorTTL() time.Duration {
+	cm.typingMu.RLock()
+	defer cm.typingMu.RUnlock()
+	
+	if cm.typingTTL > 0 {
+		return cm.typingTTL
+	}
+	return defaultTypingIndicatorTTL
+}
+
+// SetTyping sets typing indicator for a user in a conversation. Repeated typing events are
+// debounced to one broadcast per typing indicator window, and the indicator is never sent back
+// to the user's own connections.
+func (cm *ConnectionManager) SetTyping(userID, conversationID string, isTyping bool) error {
+	ttl := cm.typingIndicatorTTL()
+	
+	cm.typingMu.Lock()
+	
 	// Initialize conversation typing map if needed
 	if _, exists := cm.typingUsers[conversationID]; !exists {
-		cm.typingUsers[conversationID] = make(map[string]time.Time)
+		cm.typingUsers[conversationID] = make(map[string]*typingState)
 	}
 	
-	if isTyping {
-		cm.typingUsers[conversationID][userID] = time.Now()
-	} else {
+	state, typing := cm.typingUsers[conversationID][userID]
+	if !isTyping {
+		if !typing {
+			// Already cleared, the other participants were told when it was
+			cm.typingMu.Unlock()
+			return nil
+		}
+		state.timer.Stop()
 		delete(cm.typingUsers[conversationID], userID)
+		cm.typingMu.Unlock()
+		
+		return cm.broadcastTyping(userID, conversationID, false)
+	}
+	
+	now := time.Now()
+	if !typing {
+		state = &typingState{}
+		cm.typingUsers[conversationID][userID] = state
+	} else {
+		state.timer.Stop()
+	}
+	state.lastTyping = now
+	state.timer = time.AfterFunc(ttl, func() {
+		cm.expireTyping(userID, conversationID, state)
+	})
+	
+	broadcast := now.Sub(state.broadcastAt) >= ttl
+	if broadcast {
+		state.broadcastAt = now
 	}
+	cm.typingMu.Unlock()
 	
-	// Broadcast typing indicator
+	if !broadcast {
+		return nil
+	}
+	return cm.broadcastTyping(userID, conversationID, true)
+}
+
+// expireTyping clears a typing indicator whose window passed without a new typing event
+func (cm *ConnectionManager) expireTyping(userID, conversationID string, state *typingState) {
+	cm.typingMu.Lock()
+	if current, exists := cm.typingUsers[conversationID][userID]; !exists || current != state {
+		// Stopped or restarted since the timer was armed
+		cm.typingMu.Unlock()
+		return
+	}
+	delete(cm.typingUsers[conversationID], userID)
+	cm.typingMu.Unlock()
+	
+	if err := cm.broadcastTyping(userID, conversationID, false); err != nil {
+		logger.Warn("Failed to broadcast expired typing indicator",
+			"user_id", userID,
+			"conversation_id", conversationID,
+			"error", err,
+		)
+	}
+}
+
+// broadcastTyping sends a typing indicator to the other participants in the conversation
+func (cm *ConnectionManager) broadcastTyping(userID, conversationID string, isTyping bool) error {
 	indicator := TypingIndicator{
 		ConversationID: conversationID,
 		UserID:        userID,
@@ -690,24 +942,23 @@ func (cm *ConnectionManager) SetTyping(userID, conversationID string, isTyping b
 		SenderID:  userID,
 	}
 	
-	return cm.BroadcastToConversation(conversationID, message)
+	return cm.broadcastToConversation(conversationID, message, true)
 }
 
 // GetTypingUsers returns list of users currently typing in a conversation
 func (cm *ConnectionManager) GetTypingUsers(conversationID string) []string {
+	ttl := cm.typingIndicatorTTL()
+	
 	cm.typingMu.RLock()
 	defer cm.typingMu.RUnlock()
 	
 	if conversationTyping, exists := cm.typingUsers[conversationID]; exists {
-		// Clean up old typing indicators (older than 5 seconds)
 		now := time.Now()
 		typingUsers := make([]string, 0)
 		
-		for userID, lastTyping := range conversationTyping {
-			if now.Sub(lastTyping) < 5*time.Second {
+		for userID, state := range conversationTyping {
+			if now.Sub(state.lastTyping) < ttl {
 				typingUsers = append(typingUsers, userID)
-			} else {
-				delete(conversationTyping, userID)
 			}
 		}
 		
@@ -739,6 +990,9 @@ func (cm *ConnectionManager) setUserOnline(userID string, isOnline bool) {
 	// Broadcast to all connections
 	cm.BroadcastToAll(message)
 	
+	// Push to the connections following this user's presence
+	cm.pushPresence(status)
+	
 	// Update online status in cache
 	if cm.sessionMgr != nil {
 		if isOnline {
@@ -805,7 +1059,7 @@ func (cm *ConnectionManager) hasActiveConnections(userID string) bool {
 	defer cm.mu.RUnlock()
 	
 	for _, conn := range cm.connections {
-		if conn.UserID == userID && conn.IsAlive {
+		if conn.UserID == userID && conn.isAlive() {
 			return true
 		}
 	}
@@ -813,22 +1067,13 @@ func (cm *ConnectionManager) hasActiveConnections(userID string) bool {
 	return false
 }
 
-// CleanupExpiredTypingIndicators removes expired typing indicators
+// CleanupExpiredTypingIndicators removes conversations left without typing users. Typing
+// indicators clear themselves once their window passes without a new typing event.
 func (cm *ConnectionManager) CleanupExpiredTypingIndicators() {
 	cm.typingMu.Lock()
 	defer cm.typingMu.Unlock()
 	
-	now := time.Now()
-	expiredThreshold := 5 * time.Second
-	
 	for conversationID, conversationTyping := range cm.typingUsers {
-		for userID, lastTyping := range conversationTyping {
-			if now.Sub(lastTyping) > expiredThreshold {
-				delete(conversationTyping, userID)
-			}
-		}
-		
-		// Remove empty conversation typing maps
 		if len(conversationTyping) == 0 {
 			delete(cm.typingUsers, conversationID)
 		}
@@ -0,0 +1,73 @@
+package websocket
+
+import (
+	"encoding/json"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/gorilla/websocket"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/22smeargle/winkr-backend/pkg/config"
+)
+
+func newHeartbeatTestServer(t *testing.T, cm *ConnectionManager) *httptest.Server {
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.GET("/ws", func(c *gin.Context) {
+		c.Set("user_id", c.Query("user_id"))
+		c.Set("session_id", c.Query("user_id")+"-session")
+		if err := cm.HandleConnection(c); err != nil {
+			t.Errorf("failed to handle connection: %v", err)
+		}
+	})
+
+	server := httptest.NewServer(router)
+	t.Cleanup(server.Close)
+	return server
+}
+
+func dialHeartbeatTestServer(t *testing.T, server *httptest.Server, userID string) *websocket.Conn {
+	url := "ws" + strings.TrimPrefix(server.URL, "http") + "/ws?user_id=" + userID
+	conn, _, err := websocket.DefaultDialer.Dial(url, nil)
+	require.NoError(t, err)
+	t.Cleanup(func() { conn.Close() })
+	return conn
+}
+
+func TestConnectionManager_ReapsClientThatStopsAnsweringPings(t *testing.T) {
+	cm := NewConnectionManager(nil, nil, &config.WebSocketConfig{
+		PingInterval: 20 * time.Millisecond,
+		PongWait:     80 * time.Millisecond,
+		WriteWait:    50 * time.Millisecond,
+	})
+	server := newHeartbeatTestServer(t, cm)
+
+	// The watcher keeps reading, so the client library answers every ping with a pong
+	watcher := dialHeartbeatTestServer(t, server, "watcher")
+	// The silent client never reads again, so its pings go unanswered
+	dialHeartbeatTestServer(t, server, "silent")
+
+	deadline := time.Now().Add(2 * time.Second)
+	require.NoError(t, watcher.SetReadDeadline(deadline))
+
+	markedOffline := false
+	for !markedOffline {
+		_, data, err := watcher.ReadMessage()
+		require.NoError(t, err, "silent client was not marked offline")
+
+		var message struct {
+			Type string       `json:"type"`
+			Data OnlineStatus `json:"data"`
+		}
+		require.NoError(t, json.Unmarshal(data, &message))
+		markedOffline = message.Type == "user:status" && message.Data.UserID == "silent" && !message.Data.IsOnline
+	}
+
+	assert.Empty(t, cm.GetUserConnections("silent"))
+	assert.Len(t, cm.GetUserConnections("watcher"), 1)
+	assert.Equal(t, int64(1), cm.GetConnectionStats()["reaped_connections"])
+}
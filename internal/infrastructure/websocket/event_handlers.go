@@ -11,15 +11,32 @@ import (
 	"github.com/22smeargle/winkr-backend/internal/domain/entities"
 	"github.com/22smeargle/winkr-backend/internal/domain/repositories"
 	"github.com/22smeargle/winkr-backend/internal/infrastructure/cache"
+	"github.com/22smeargle/winkr-backend/pkg/config"
 	"github.com/22smeargle/winkr-backend/pkg/logger"
 )
 
+// ConversationLimit checks whether a message would take a free user over the open conversation limit
+type ConversationLimit interface {
+	LimitReachedForMessage(ctx context.Context, userID, conversationID uuid.UUID) (bool, error)
+	UpsellMessage() string
+}
+
 // EventHandler handles WebSocket events for chat functionality
 type EventHandler struct {
 	connManager   *ConnectionManager
 	messageRepo   repositories.MessageRepository
 	userRepo      repositories.UserRepository
+	matchRepo     repositories.MatchRepository
 	messageService *services.MessageService
+	receiptService *services.MessageReceiptService
+	readReceipts  *services.ReadReceiptService
+	translationService *services.MessageTranslationService
+	linkPreviews  *services.LinkPreviewService
+	redactor      *services.MessagePIIRedactor
+	engagementService *services.ConversationEngagementService
+	noticeService *services.LegalNoticeService
+	conversationLimit ConversationLimit
+	messageConfig *config.MessageConfig
 	cache         *cache.CacheService
 }
 
@@ -28,31 +45,58 @@ func NewEventHandler(
 	connManager *ConnectionManager,
 	messageRepo repositories.MessageRepository,
 	userRepo repositories.UserRepository,
+	matchRepo repositories.MatchRepository,
 	messageService *services.MessageService,
+	receiptService *services.MessageReceiptService,
+	readReceipts *services.ReadReceiptService,
+	translationService *services.MessageTranslationService,
+	linkPreviews *services.LinkPreviewService,
+	redactor *services.MessagePIIRedactor,
+	engagementService *services.ConversationEngagementService,
+	noticeService *services.LegalNoticeService,
+	conversationLimit ConversationLimit,
+	messageConfig *config.MessageConfig,
 	cache *cache.CacheService,
 ) *EventHandler {
 	return &EventHandler{
 		connManager:   connManager,
 		messageRepo:   messageRepo,
 		userRepo:      userRepo,
+		matchRepo:     matchRepo,
 		messageService: messageService,
+		receiptService: receiptService,
+		readReceipts:  readReceipts,
+		translationService: translationService,
+		linkPreviews:  linkPreviews,
+		redactor:      redactor,
+		engagementService: engagementService,
+		noticeService: noticeService,
+		conversationLimit: conversationLimit,
+		messageConfig: messageConfig,
 		cache:         cache,
 	}
 }
 
 // HandleMessage handles incoming WebSocket messages
 func (h *EventHandler) HandleMessage(ctx context.Context, conn *ClientConnection, rawMessage []byte) error {
-	// Parse message
-	var wsMessage Message
-	if err := json.Unmarshal(rawMessage, &wsMessage); err != nil {
+	// Parse message, keeping the data raw so each handler can decode its own payload
+	var envelope struct {
+		Message
+		Data json.RawMessage `json:"data"`
+	}
+	if err := json.Unmarshal(rawMessage, &envelope); err != nil {
 		logger.Error("Failed to parse WebSocket message", err)
 		return fmt.Errorf("failed to parse message: %w", err)
 	}
+	wsMessage := envelope.Message
+	wsMessage.Data = envelope.Data
 
 	// Handle different message types
 	switch wsMessage.Type {
 	case "message:new":
 		return h.handleNewMessage(ctx, conn, wsMessage)
+	case "message:delivered":
+		return h.handleMessageDelivered(ctx, conn, wsMessage)
 	case "message:read":
 		return h.handleMessageRead(ctx, conn, wsMessage)
 	case "message:delete":
@@ -75,6 +119,12 @@ func (h *EventHandler) HandleMessage(ctx context.Context, conn *ClientConnection
 		return h.handleUserStatus(ctx, conn, wsMessage)
 	case "ping":
 		return h.handlePing(ctx, conn, wsMessage)
+	case "sync:request":
+		return h.handleSyncRequest(ctx, conn, wsMessage)
+	case "resume":
+		return h.handleResume(ctx, conn, wsMessage)
+	case "subscribe_presence":
+		return h.handleSubscribePresence(ctx, conn, wsMessage)
 	default:
 		logger.Warn("Unknown message type", "type", wsMessage.Type)
 		return fmt.Errorf("unknown message type: %s", wsMessage.Type)
@@ -88,12 +138,90 @@ func (h *EventHandler) handleNewMessage(ctx context.Context, conn *ClientConnect
 		ConversationID string `json:"conversation_id"`
 		Content        string `json:"content"`
 		MessageType    string `json:"message_type"`
+		ClientMessageID string `json:"client_message_id"`
 	}
 	
 	if err := json.Unmarshal(wsMessage.Data.(json.RawMessage), &messageData); err != nil {
 		return fmt.Errorf("failed to parse message data: %w", err)
 	}
 
+	// A retried send is acknowledged with the message created by the first attempt instead of a duplicate
+	clientMessageID, err := h.clientMessageID(messageData.ClientMessageID)
+	if err != nil {
+		errorMessage := Message{
+			Type: "error",
+			Data: map[string]interface{}{
+				"code":    "invalid_client_message_id",
+				"message": err.Error(),
+			},
+			Timestamp: time.Now(),
+		}
+		return conn.WriteMessage(errorMessage)
+	}
+
+	if clientMessageID != nil {
+		if sent, err := h.ackSentMessage(ctx, conn, messageData.ConversationID, *clientMessageID); err != nil || sent {
+			return err
+		}
+	}
+
+	// Messaging waits for the required legal notices to be acknowledged
+	if h.noticeService != nil {
+		allowed, err := h.noticeService.CanPerform(ctx, uuid.MustParse(conn.UserID), services.NoticeActionMessaging)
+		if err != nil {
+			return fmt.Errorf("failed to check legal notices: %w", err)
+		}
+
+		if !allowed {
+			errorMessage := Message{
+				Type: "error",
+				Data: map[string]interface{}{
+					"code":    "notices_not_acknowledged",
+					"message": "Please review and acknowledge the community guidelines to continue",
+				},
+				Timestamp: time.Now(),
+			}
+			return conn.WriteMessage(errorMessage)
+		}
+	}
+
+	// Reject messages to conversations that were closed, e.g. because a participant was banned
+	conversation, err := h.messageRepo.GetConversation(ctx, uuid.MustParse(messageData.ConversationID))
+	if err != nil {
+		return fmt.Errorf("failed to get conversation: %w", err)
+	}
+
+	if conversation.IsClosed() {
+		errorMessage := Message{
+			Type: "error",
+			Data: map[string]interface{}{
+				"code":    "conversation_closed",
+				"message": "Conversation is closed",
+			},
+			Timestamp: time.Now(),
+		}
+		return conn.WriteMessage(errorMessage)
+	}
+
+	// A free user's first message in a conversation counts towards their open conversation limit
+	if h.conversationLimit != nil {
+		limitReached, err := h.conversationLimit.LimitReachedForMessage(ctx, uuid.MustParse(conn.UserID), conversation.ID)
+		if err != nil {
+			return fmt.Errorf("failed to check open conversation limit: %w", err)
+		}
+		if limitReached {
+			errorMessage := Message{
+				Type: "error",
+				Data: map[string]interface{}{
+					"code":    "conversation_limit_reached",
+					"message": h.conversationLimit.UpsellMessage(),
+				},
+				Timestamp: time.Now(),
+			}
+			return conn.WriteMessage(errorMessage)
+		}
+	}
+
 	// Validate message
 	validationResult, err := h.messageService.ValidateMessage(ctx, messageData.Content, messageData.MessageType, conn.UserID)
 	if err != nil {
@@ -121,6 +249,7 @@ func (h *EventHandler) handleNewMessage(ctx context.Context, conn *ClientConnect
 		SenderID:       uuid.MustParse(conn.UserID),
 		Content:        validationResult.Sanitized,
 		MessageType:    messageData.MessageType,
+		ClientMessageID: clientMessageID,
 		IsRead:         false,
 		CreatedAt:      time.Now(),
 	}
@@ -128,7 +257,6 @@ func (h *EventHandler) handleNewMessage(ctx context.Context, conn *ClientConnect
 	// Process message with options
 	options := &services.MessageProcessingOptions{
 		EnableContentFilter: true,
-		EnableLinkPreview:  true,
 		EnableEncryption:   false, // Could be enabled based on user settings
 		EnableTranslation:  false, // Could be enabled based on user settings
 	}
@@ -138,16 +266,40 @@ func (h *EventHandler) handleNewMessage(ctx context.Context, conn *ClientConnect
 		return fmt.Errorf("message processing failed: %w", err)
 	}
 
+	// PII is masked before the message is stored, broadcast or cached
+	h.redactor.Redact(processedMessage.Message)
+
 	// Save message to database
 	if err := h.messageRepo.Create(ctx, processedMessage.Message); err != nil {
+		// A concurrent retry may have saved the message first
+		if clientMessageID != nil {
+			if sent, ackErr := h.ackSentMessage(ctx, conn, messageData.ConversationID, *clientMessageID); ackErr == nil && sent {
+				return nil
+			}
+		}
 		return fmt.Errorf("failed to save message: %w", err)
 	}
 
+	// Record the sent status so clients can reconcile delivery state
+	if receipt, err := h.receiptService.RecordSent(ctx, processedMessage.Message); err != nil {
+		logger.Error("Failed to record message sent status", err)
+	} else {
+		processedMessage.Receipt = receipt
+	}
+
 	// Update conversation activity
 	if err := h.updateConversationActivity(ctx, processedMessage.ConversationID); err != nil {
 		logger.Error("Failed to update conversation activity", err)
 	}
 
+	// Count the message towards the conversation streak and celebrate any milestone
+	if _, err := h.engagementService.RecordMessage(ctx, processedMessage.Message); err != nil {
+		logger.Error("Failed to record conversation streak", err)
+	}
+
+	// Previews of links seen before go out with the message, the others are pushed once fetched
+	processedMessage.LinkPreviews = h.linkPreviews.Generate(ctx, processedMessage.Message)
+
 	// Broadcast message to conversation participants
 	broadcastMessage := Message{
 		Type:      "message:new",
@@ -160,6 +312,14 @@ func (h *EventHandler) handleNewMessage(ctx context.Context, conn *ClientConnect
 		logger.Error("Failed to broadcast message", err)
 	}
 
+	// Acknowledge the send so the client can swap its temporary ID for the canonical one
+	if err := h.writeMessageAck(conn, processedMessage, false); err != nil {
+		logger.Error("Failed to acknowledge message", err)
+	}
+
+	// Follow up with a translation for recipients who opted in
+	h.sendTranslations(ctx, processedMessage.Message)
+
 	// Update unread counts for recipients
 	if err := h.updateUnreadCounts(ctx, processedMessage); err != nil {
 		logger.Error("Failed to update unread counts", err)
@@ -179,21 +339,130 @@ func (h *EventHandler) handleNewMessage(ctx context.Context, conn *ClientConnect
 	return nil
 }
 
-// handleMessageRead handles message read events
-func (h *EventHandler) handleMessageRead(ctx context.Context, conn *ClientConnection, wsMessage Message) error {
+// sendTranslations sends each recipient in the conversation the message translated into their language.
+// Recipients whose translation fails or is not needed already have the original.
+func (h *EventHandler) sendTranslations(ctx context.Context, message *entities.Message) {
+	if !h.translationService.Enabled() {
+		return
+	}
+
+	for _, participantID := range h.connManager.GetConversationParticipants(message.ConversationID.String()) {
+		if participantID == message.SenderID.String() {
+			continue
+		}
+
+		recipientID, err := uuid.Parse(participantID)
+		if err != nil {
+			continue
+		}
+
+		translation, err := h.translationService.TranslateForUser(ctx, message, recipientID)
+		if err != nil {
+			logger.Error("Failed to translate message", err, "message_id", message.ID, "recipient_id", participantID)
+			continue
+		}
+		if translation == nil {
+			continue
+		}
+
+		translationMessage := Message{
+			Type: "message:translation",
+			Data: map[string]interface{}{
+				"message_id":      message.ID.String(),
+				"conversation_id": message.ConversationID.String(),
+				"translation":     translation,
+			},
+			Timestamp: time.Now(),
+		}
+		if err := h.connManager.BroadcastToUser(participantID, translationMessage); err != nil {
+			logger.Error("Failed to send message translation", err, "message_id", message.ID, "recipient_id", participantID)
+		}
+	}
+}
+
+// clientMessageID returns the client message ID to store with a sent message, or nil if the client
+// sent none or client message IDs are disabled
+func (h *EventHandler) clientMessageID(clientMessageID string) (*string, error) {
+	if clientMessageID == "" || h.messageConfig == nil || h.messageConfig.ClientMessageIDMaxLength <= 0 {
+		return nil, nil
+	}
+
+	if len(clientMessageID) > h.messageConfig.ClientMessageIDMaxLength {
+		return nil, fmt.Errorf("client_message_id too long (max %d characters)", h.messageConfig.ClientMessageIDMaxLength)
+	}
+
+	return &clientMessageID, nil
+}
+
+// ackSentMessage acknowledges the message the sender already sent with the client message ID,
+// returning false if there is none
+func (h *EventHandler) ackSentMessage(ctx context.Context, conn *ClientConnection, conversationID, clientMessageID string) (bool, error) {
+	existing, err := h.messageRepo.GetBySenderAndClientMessageID(ctx, uuid.MustParse(conn.UserID), clientMessageID)
+	if err != nil {
+		return false, fmt.Errorf("failed to get message by client message ID: %w", err)
+	}
+
+	if existing == nil {
+		return false, nil
+	}
+
+	if existing.ConversationID.String() != conversationID {
+		errorMessage := Message{
+			Type: "error",
+			Data: map[string]interface{}{
+				"code":              "client_message_id_conflict",
+				"message":           "client_message_id was already used in another conversation",
+				"client_message_id": clientMessageID,
+			},
+			Timestamp: time.Now(),
+		}
+		return true, conn.WriteMessage(errorMessage)
+	}
+
+	logger.Info("Duplicate message send ignored",
+		"message_id", existing.ID,
+		"client_message_id", clientMessageID,
+		"sender_id", conn.UserID,
+	)
+
+	return true, h.writeMessageAck(conn, &services.ProcessedMessage{Message: existing}, true)
+}
+
+// writeMessageAck tells the sending connection the canonical ID of its message alongside the client message ID
+func (h *EventHandler) writeMessageAck(conn *ClientConnection, message *services.ProcessedMessage, duplicate bool) error {
+	ackMessage := Message{
+		Type: "message:ack",
+		Data: map[string]interface{}{
+			"message_id":        message.ID,
+			"client_message_id": message.ClientMessageID,
+			"conversation_id":   message.ConversationID,
+			"duplicate":         duplicate,
+			"message":           message,
+		},
+		Timestamp: time.Now(),
+	}
+	return conn.WriteMessage(ackMessage)
+}
+
+// handleMessageDelivered handles delivery acknowledgements sent by the recipient's client
+func (h *EventHandler) handleMessageDelivered(ctx context.Context, conn *ClientConnection, wsMessage Message) error {
 	// Extract message data
 	var messageData struct {
 		MessageID string `json:"message_id"`
 	}
-	
+
 	if err := json.Unmarshal(wsMessage.Data.(json.RawMessage), &messageData); err != nil {
 		return fmt.Errorf("failed to parse message data: %w", err)
 	}
 
-	messageUUID := uuid.MustParse(messageData.MessageID)
+	messageUUID, err := uuid.Parse(messageData.MessageID)
+	if err != nil {
+		return fmt.Errorf("invalid message ID: %w", err)
+	}
+	userUUID := uuid.MustParse(conn.UserID)
 
 	// Check if user can access message
-	canAccess, err := h.messageRepo.UserCanAccessMessage(ctx, uuid.MustParse(conn.UserID), messageUUID)
+	canAccess, err := h.messageRepo.UserCanAccessMessage(ctx, userUUID, messageUUID)
 	if err != nil {
 		return fmt.Errorf("failed to check message access: %w", err)
 	}
@@ -202,41 +471,86 @@ func (h *EventHandler) handleMessageRead(ctx context.Context, conn *ClientConnec
 		return fmt.Errorf("user cannot access message")
 	}
 
-	// Mark message as read
-	if err := h.messageRepo.MarkAsRead(ctx, messageUUID); err != nil {
-		return fmt.Errorf("failed to mark message as read: %w", err)
+	message, err := h.messageRepo.GetByID(ctx, messageUUID)
+	if err != nil {
+		return fmt.Errorf("failed to get message: %w", err)
 	}
 
-	// Broadcast read receipt to conversation
-	readMessage := Message{
-		Type: "message:viewed",
-		Data: map[string]interface{}{
-			"message_id": messageData.MessageID,
-			"user_id":    conn.UserID,
-			"timestamp":   time.Now(),
-		},
-		Timestamp: time.Now(),
-		SenderID:  conn.UserID,
+	receipt, err := h.receiptService.RecordDelivered(ctx, message, userUUID)
+	if err != nil {
+		return fmt.Errorf("failed to record message delivered status: %w", err)
 	}
 
-	// Get message to find conversation
-	message, err := h.messageRepo.GetByID(ctx, messageUUID)
+	// Let the sender know the message reached the recipient
+	if receipt.DeliveredAt != nil {
+		deliveredMessage := Message{
+			Type: "message:delivered",
+			Data: map[string]interface{}{
+				"message_id":      messageData.MessageID,
+				"conversation_id": message.ConversationID.String(),
+				"delivered_at":    *receipt.DeliveredAt,
+			},
+			Timestamp: time.Now(),
+			SenderID:  conn.UserID,
+		}
+
+		if err := h.connManager.BroadcastToConversation(message.ConversationID.String(), deliveredMessage); err != nil {
+			logger.Error("Failed to broadcast delivery receipt", err)
+		}
+	}
+
+	logger.Info("Message marked as delivered",
+		"message_id", messageUUID,
+		"user_id", conn.UserID,
+	)
+
+	return nil
+}
+
+// handleMessageRead handles message read events. Everything received in the conversation up to the
+// message is marked read, and the sender gets a read_receipt unless the reader turned them off.
+func (h *EventHandler) handleMessageRead(ctx context.Context, conn *ClientConnection, wsMessage Message) error {
+	// Extract message data
+	var messageData struct {
+		ConversationID string `json:"conversation_id"`
+		MessageID      string `json:"message_id"`
+	}
+	
+	if err := json.Unmarshal(wsMessage.Data.(json.RawMessage), &messageData); err != nil {
+		return fmt.Errorf("failed to parse message data: %w", err)
+	}
+
+	messageUUID, err := uuid.Parse(messageData.MessageID)
 	if err != nil {
-		return fmt.Errorf("failed to get message: %w", err)
+		return fmt.Errorf("invalid message ID: %w", err)
 	}
+	userUUID := uuid.MustParse(conn.UserID)
 
-	if err := h.connManager.BroadcastToConversation(message.ConversationID.String(), readMessage); err != nil {
-		logger.Error("Failed to broadcast read receipt", err)
+	// Older clients only send the message ID
+	var conversationUUID uuid.UUID
+	if messageData.ConversationID != "" {
+		conversationUUID, err = uuid.Parse(messageData.ConversationID)
+		if err != nil {
+			return fmt.Errorf("invalid conversation ID: %w", err)
+		}
+	} else {
+		message, err := h.messageRepo.GetByID(ctx, messageUUID)
+		if err != nil {
+			return fmt.Errorf("failed to get message: %w", err)
+		}
+		conversationUUID = message.ConversationID
 	}
 
-	// Update unread count for user
-	if err := h.updateUserUnreadCount(ctx, uuid.MustParse(conn.UserID), message.ConversationID); err != nil {
-		logger.Error("Failed to update user unread count", err)
+	result, err := h.readReceipts.MarkMessagesRead(ctx, conversationUUID, messageUUID, userUUID)
+	if err != nil {
+		return fmt.Errorf("failed to mark messages as read: %w", err)
 	}
 
-	logger.Info("Message marked as read", 
-		"message_id", messageUUID,
+	logger.Info("Messages marked as read", 
+		"conversation_id", conversationUUID,
+		"up_to_message_id", messageUUID,
 		"user_id", conn.UserID,
+		"marked_count", result.MarkedCount,
 	)
 
 	return nil
@@ -266,23 +580,31 @@ func (h *EventHandler) handleMessageDelete(ctx context.Context, conn *ClientConn
 		return fmt.Errorf("user can only delete their own messages")
 	}
 
-	// Check if message can be deleted
-	if !message.CanBeDeleted() {
+	// Check if message can still be deleted
+	var deleteWindow time.Duration
+	if h.messageConfig != nil {
+		deleteWindow = h.messageConfig.DeleteWindow
+	}
+	if !message.CanBeDeleted(deleteWindow) {
 		return fmt.Errorf("message cannot be deleted")
 	}
 
-	// Soft delete message
+	// Soft delete message, leaving a tombstone in its place
 	if err := h.messageRepo.SoftDeleteMessage(ctx, messageUUID); err != nil {
 		return fmt.Errorf("failed to delete message: %w", err)
 	}
+	message.SoftDelete()
 
 	// Broadcast delete event to conversation
 	deleteMessage := Message{
 		Type: "message:deleted",
 		Data: map[string]interface{}{
-			"message_id": messageData.MessageID,
-			"user_id":    conn.UserID,
-			"timestamp":   time.Now(),
+			"message_id":      messageData.MessageID,
+			"conversation_id": message.ConversationID.String(),
+			"user_id":         conn.UserID,
+			"content":         entities.DeletedMessagePlaceholder,
+			"deleted_at":      message.DeletedAt,
+			"timestamp":       time.Now(),
 		},
 		Timestamp: time.Now(),
 		SenderID:  conn.UserID,
@@ -316,14 +638,19 @@ func (h *EventHandler) handleTypingStart(ctx context.Context, conn *ClientConnec
 		return fmt.Errorf("failed to parse typing data: %w", err)
 	}
 
-	// Set typing indicator
+	// Only participants may show up as typing in a conversation
+	if !conn.isParticipantInConversation(typingData.ConversationID) {
+		return fmt.Errorf("user is not a participant in conversation %s", typingData.ConversationID)
+	}
+
+	// Set typing indicator, debounced by the connection manager
 	if err := h.connManager.SetTyping(conn.UserID, typingData.ConversationID, true); err != nil {
 		return fmt.Errorf("failed to set typing indicator: %w", err)
 	}
 
 	// Cache typing indicator
 	typingKey := fmt.Sprintf("typing:%s:%s", typingData.ConversationID, conn.UserID)
-	if err := h.cache.Set(ctx, typingKey, true, 5*time.Second); err != nil {
+	if err := h.cache.Set(ctx, typingKey, true, h.connManager.typingIndicatorTTL()); err != nil {
 		logger.Error("Failed to cache typing indicator", err)
 	}
 
@@ -535,28 +862,6 @@ func (h *EventHandler) updateUnreadCounts(ctx context.Context, message *services
 	return nil
 }
 
-// updateUserUnreadCount updates unread count for a user in a conversation
-func (h *EventHandler) updateUserUnreadCount(ctx context.Context, userID, conversationID uuid.UUID) error {
-	// Get unread messages count
-	count, err := h.messageRepo.GetConversationUnreadCount(ctx, conversationID, userID)
-	if err != nil {
-		return fmt.Errorf("failed to get unread count: %w", err)
-	}
-
-	// Update cache
-	unreadKey := fmt.Sprintf("unread:%s:%s", userID.String(), conversationID.String())
-	if err := h.cache.Set(ctx, unreadKey, int(count), 24*time.Hour); err != nil {
-		logger.Error("Failed to update unread count in cache", err)
-	}
-
-	// Send unread count update
-	if err := h.connManager.UpdateUnreadCount(userID.String(), conversationID.String(), int(count)); err != nil {
-		logger.Error("Failed to update unread count", err)
-	}
-
-	return nil
-}
-
 // handleEphemeralPhotoNew handles new ephemeral photo events
 func (h *EventHandler) handleEphemeralPhotoNew(ctx context.Context, conn *ClientConnection, wsMessage Message) error {
 	// Extract ephemeral photo data
@@ -0,0 +1,141 @@
+package websocket
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/22smeargle/winkr-backend/pkg/logger"
+)
+
+// resumeMaxConversations bounds how many of the user's most recently active conversations a resume catches up
+const resumeMaxConversations = 100
+
+// handleResume catches a reconnecting client up from the last message it saw in any conversation.
+// Every open conversation of the user is replayed from that message on, like a sync:request with
+// the same cursor for each. A conversation that missed more than MaxMessagesPerRequest messages gets
+// a resync:required event instead, and the client should reload it over REST.
+func (h *EventHandler) handleResume(ctx context.Context, conn *ClientConnection, wsMessage Message) error {
+	// Extract resume data
+	var resumeData struct {
+		LastSeenMessageID string `json:"last_seen_message_id"`
+	}
+
+	if err := json.Unmarshal(wsMessage.Data.(json.RawMessage), &resumeData); err != nil {
+		return fmt.Errorf("failed to parse resume data: %w", err)
+	}
+
+	conn.beginSync()
+
+	delivered := make(map[string]bool)
+	results, resumeErr := h.resumeConversations(ctx, conn, resumeData.LastSeenMessageID, delivered)
+
+	if resumeErr == nil {
+		resumeErr = conn.writeSyncMessage(Message{
+			Type: "sync:complete",
+			Data: map[string]interface{}{
+				"conversations": results,
+			},
+			Timestamp: time.Now(),
+		})
+	}
+
+	// Live delivery resumes even if the catch-up failed, so the connection is never left on hold
+	if err := conn.endSync(delivered); err != nil && resumeErr == nil {
+		resumeErr = err
+	}
+
+	logger.Info("WebSocket resume completed",
+		"user_id", conn.UserID,
+		"conversations", len(results),
+	)
+
+	return resumeErr
+}
+
+// resumeConversations replays what the client missed in each of its open conversations since the
+// last seen message. Only a failed write is returned as an error.
+func (h *EventHandler) resumeConversations(ctx context.Context, conn *ClientConnection, lastSeenMessageID string, delivered map[string]bool) ([]SyncResult, error) {
+	results := []SyncResult{}
+
+	userID, err := uuid.Parse(conn.UserID)
+	if err != nil {
+		return results, fmt.Errorf("invalid user ID: %w", err)
+	}
+
+	// Without a message the user can see to resume from, every conversation has to be reloaded
+	lastSeenID, err := uuid.Parse(lastSeenMessageID)
+	if err != nil {
+		return results, conn.writeSyncMessage(resyncRequired(""))
+	}
+	lastSeen, err := h.messageRepo.GetByID(ctx, lastSeenID)
+	if err != nil {
+		return results, conn.writeSyncMessage(resyncRequired(""))
+	}
+	canAccess, err := h.messageRepo.UserCanAccessConversation(ctx, userID, lastSeen.ConversationID)
+	if err != nil || !canAccess {
+		return results, conn.writeSyncMessage(resyncRequired(""))
+	}
+
+	conversations, err := h.messageRepo.GetUserConversations(ctx, userID, resumeMaxConversations, 0)
+	if err != nil {
+		logger.Error("Failed to get conversations for resume", err, "user_id", conn.UserID)
+		return results, conn.writeSyncMessage(resyncRequired(""))
+	}
+
+	limit := h.resumeMaxMessages()
+	for _, conversation := range conversations {
+		if conversation.IsClosed() {
+			continue
+		}
+
+		result := SyncResult{ConversationID: conversation.ID.String()}
+
+		missed, statuses, ok := h.loadMissed(ctx, conn.UserID, conversation.ID, lastSeen, limit)
+		if !ok {
+			result.Gap = true
+			results = append(results, result)
+			if err := conn.writeSyncMessage(resyncRequired(result.ConversationID)); err != nil {
+				return results, err
+			}
+			continue
+		}
+
+		// Conversations with nothing new are left out of the result
+		if len(missed) == 0 && len(statuses) == 0 {
+			continue
+		}
+
+		if err := replayMissed(conn, &result, missed, statuses, delivered); err != nil {
+			return results, err
+		}
+		results = append(results, result)
+	}
+
+	return results, nil
+}
+
+// resumeMaxMessages returns how many missed messages a resume replays per conversation
+func (h *EventHandler) resumeMaxMessages() int {
+	if h.messageConfig != nil && h.messageConfig.MaxMessagesPerRequest > 0 {
+		return h.messageConfig.MaxMessagesPerRequest
+	}
+	return h.connManager.syncMaxMessages()
+}
+
+// resyncRequired tells the client to reload a conversation over REST, or all of them when no
+// conversation is given
+func resyncRequired(conversationID string) Message {
+	data := map[string]interface{}{}
+	if conversationID != "" {
+		data["conversation_id"] = conversationID
+	}
+
+	return Message{
+		Type:      "resync:required",
+		Data:      data,
+		Timestamp: time.Now(),
+	}
+}
@@ -0,0 +1,108 @@
+package websocket
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/gorilla/websocket"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/22smeargle/winkr-backend/internal/domain/entities"
+	"github.com/22smeargle/winkr-backend/pkg/config"
+)
+
+// GetUserConversations returns every conversation with a message, in the order they first appear
+func (r *inMemorySyncRepository) GetUserConversations(ctx context.Context, userID uuid.UUID, limit, offset int) ([]*entities.Conversation, error) {
+	var conversations []*entities.Conversation
+	seen := make(map[uuid.UUID]bool)
+	for _, message := range r.messages {
+		if !seen[message.ConversationID] {
+			seen[message.ConversationID] = true
+			conversations = append(conversations, &entities.Conversation{ID: message.ConversationID})
+		}
+	}
+	return conversations, nil
+}
+
+// resume starts a server for the fixture's repository and sends the resume handshake
+func (f *syncFixture) resume(t *testing.T, messageConfig *config.MessageConfig, lastSeenMessageID uuid.UUID) *websocket.Conn {
+	cm := NewConnectionManager(nil, nil, nil)
+	handler := NewEventHandler(cm, f.repo, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, messageConfig, nil)
+	cm.SetMessageHandler(handler.HandleMessage)
+
+	server := newHeartbeatTestServer(t, cm)
+	conn := dialHeartbeatTestServer(t, server, f.userID)
+
+	request, err := json.Marshal(map[string]interface{}{
+		"type": "resume",
+		"data": map[string]interface{}{
+			"last_seen_message_id": lastSeenMessageID.String(),
+		},
+	})
+	require.NoError(t, err)
+	require.NoError(t, conn.WriteMessage(websocket.TextMessage, request))
+	return conn
+}
+
+// resyncConversations returns the conversation IDs of the resync:required events, empty for all
+func resyncConversations(t *testing.T, events []syncEvent) []string {
+	ids := []string{}
+	for _, event := range events {
+		if event.Type != "resync:required" {
+			continue
+		}
+		var data struct {
+			ConversationID string `json:"conversation_id"`
+		}
+		require.NoError(t, json.Unmarshal(event.Data, &data))
+		ids = append(ids, data.ConversationID)
+	}
+	return ids
+}
+
+func TestResume_ReplaysMissedMessagesOfEveryConversation(t *testing.T) {
+	f := newSyncFixture(4)
+	history := f.repo.messages[:4]
+	other := f.repo.messages[4]
+
+	conn := f.resume(t, nil, history[1].ID)
+	events := readEvents(t, conn, untilSyncComplete)
+
+	assert.Equal(t, []uuid.UUID{history[2].ID, history[3].ID, other.ID}, newMessageIDs(t, events))
+	assert.Empty(t, resyncConversations(t, events))
+
+	assert.Equal(t, []SyncResult{
+		{ConversationID: f.conversationID.String(), Messages: 2, Cursor: history[3].ID.String()},
+		{ConversationID: other.ConversationID.String(), Messages: 1, Cursor: other.ID.String()},
+	}, syncResults(t, events[len(events)-1]))
+}
+
+func TestResume_LargeGapRequiresResync(t *testing.T) {
+	f := newSyncFixture(5)
+	other := f.repo.messages[5]
+
+	conn := f.resume(t, &config.MessageConfig{MaxMessagesPerRequest: 3}, f.repo.messages[0].ID)
+	events := readEvents(t, conn, untilSyncComplete)
+
+	// The conversation with four missed messages is not replayed, the other one is
+	assert.Equal(t, []uuid.UUID{other.ID}, newMessageIDs(t, events))
+	assert.Equal(t, []string{f.conversationID.String()}, resyncConversations(t, events))
+
+	results := syncResults(t, events[len(events)-1])
+	require.Len(t, results, 2)
+	assert.True(t, results[0].Gap)
+	assert.Equal(t, 0, results[0].Messages)
+}
+
+func TestResume_UnknownLastSeenMessageRequiresFullResync(t *testing.T) {
+	f := newSyncFixture(3)
+
+	conn := f.resume(t, nil, uuid.New())
+	events := readEvents(t, conn, untilSyncComplete)
+
+	assert.Empty(t, newMessageIDs(t, events))
+	assert.Equal(t, []string{""}, resyncConversations(t, events))
+	assert.Empty(t, syncResults(t, events[len(events)-1]))
+}
@@ -0,0 +1,292 @@
+package websocket
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/22smeargle/winkr-backend/internal/domain/entities"
+	"github.com/22smeargle/winkr-backend/pkg/logger"
+)
+
+// defaultSyncMaxMessages is used when the WebSocket config leaves the catch-up bound unset
+const defaultSyncMaxMessages = 200
+
+// SyncCursor is the last message a client has seen in a conversation
+type SyncCursor struct {
+	ConversationID string `json:"conversation_id"`
+	LastMessageID  string `json:"last_message_id"`
+}
+
+// SyncResult tells the client how far a conversation was caught up
+type SyncResult struct {
+	ConversationID string `json:"conversation_id"`
+	Messages       int    `json:"messages"`         // Missed messages replayed
+	Cursor         string `json:"cursor,omitempty"` // Last message the client now has
+	Gap            bool   `json:"gap"`              // Nothing was replayed, the client should reload the conversation over REST
+}
+
+// handleSyncRequest catches a reconnecting client up on what it missed while offline. For each
+// conversation the client sends the last message it saw. Newer messages are replayed oldest first,
+// followed by the delivery and read receipts recorded since. Live messages are held back until the
+// catch-up is done, so the client gets everything in order and nothing twice.
+func (h *EventHandler) handleSyncRequest(ctx context.Context, conn *ClientConnection, wsMessage Message) error {
+	// Extract sync data
+	var syncData struct {
+		Cursors []SyncCursor `json:"cursors"`
+	}
+
+	if err := json.Unmarshal(wsMessage.Data.(json.RawMessage), &syncData); err != nil {
+		return fmt.Errorf("failed to parse sync data: %w", err)
+	}
+
+	conn.beginSync()
+
+	delivered := make(map[string]bool)
+	results := make([]SyncResult, 0, len(syncData.Cursors))
+	var syncErr error
+	for _, cursor := range syncData.Cursors {
+		result, err := h.syncConversation(ctx, conn, cursor, delivered)
+		if err != nil {
+			syncErr = err
+			break
+		}
+		results = append(results, result)
+	}
+
+	if syncErr == nil {
+		syncErr = conn.writeSyncMessage(Message{
+			Type: "sync:complete",
+			Data: map[string]interface{}{
+				"conversations": results,
+			},
+			Timestamp: time.Now(),
+		})
+	}
+
+	// Live delivery resumes even if the catch-up failed, so the connection is never left on hold
+	if err := conn.endSync(delivered); err != nil && syncErr == nil {
+		syncErr = err
+	}
+
+	logger.Info("WebSocket sync completed",
+		"user_id", conn.UserID,
+		"conversations", len(results),
+	)
+
+	return syncErr
+}
+
+// syncConversation replays the messages and receipts a client missed in one conversation. Only a
+// failed write is returned as an error. Anything that keeps the conversation from being replayed
+// is reported to the client as a gap.
+func (h *EventHandler) syncConversation(ctx context.Context, conn *ClientConnection, cursor SyncCursor, delivered map[string]bool) (SyncResult, error) {
+	result := SyncResult{
+		ConversationID: cursor.ConversationID,
+		Cursor:         cursor.LastMessageID,
+	}
+
+	conversationID, lastMessage, ok := h.resolveCursor(ctx, conn.UserID, cursor)
+	if !ok {
+		result.Gap = true
+		return result, nil
+	}
+
+	missed, statuses, ok := h.loadMissed(ctx, conn.UserID, conversationID, lastMessage, h.connManager.syncMaxMessages())
+	if !ok {
+		result.Gap = true
+		return result, nil
+	}
+
+	return result, replayMissed(conn, &result, missed, statuses, delivered)
+}
+
+// replayMissed writes the missed messages, oldest first, followed by the missed receipts, and
+// moves the result's cursor to the last message written
+func replayMissed(conn *ClientConnection, result *SyncResult, missed []*entities.Message, statuses []*entities.MessageStatus, delivered map[string]bool) error {
+	for _, message := range missed {
+		err := conn.writeSyncMessage(Message{
+			Type:      "message:new",
+			Data:      message,
+			Timestamp: time.Now(),
+			SenderID:  message.SenderID.String(),
+		})
+		if err != nil {
+			return err
+		}
+
+		delivered[message.ID.String()] = true
+		result.Messages++
+		result.Cursor = message.ID.String()
+	}
+
+	for _, status := range statuses {
+		receipt, ok := receiptMessage(result.ConversationID, status)
+		if !ok {
+			continue
+		}
+		if err := conn.writeSyncMessage(receipt); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// resolveCursor returns the conversation and last seen message of a sync cursor. It reports false
+// when the cursor is not a message of a conversation the user can access.
+func (h *EventHandler) resolveCursor(ctx context.Context, userID string, cursor SyncCursor) (uuid.UUID, *entities.Message, bool) {
+	conversationID, err := uuid.Parse(cursor.ConversationID)
+	if err != nil {
+		return uuid.Nil, nil, false
+	}
+
+	lastMessageID, err := uuid.Parse(cursor.LastMessageID)
+	if err != nil {
+		return uuid.Nil, nil, false
+	}
+
+	// Check if user can access conversation
+	canAccess, err := h.messageRepo.UserCanAccessConversation(ctx, uuid.MustParse(userID), conversationID)
+	if err != nil {
+		logger.Error("Failed to check conversation access for sync", err, "conversation_id", conversationID)
+		return uuid.Nil, nil, false
+	}
+	if !canAccess {
+		return uuid.Nil, nil, false
+	}
+
+	lastMessage, err := h.messageRepo.GetByID(ctx, lastMessageID)
+	if err != nil || lastMessage.ConversationID != conversationID {
+		return uuid.Nil, nil, false
+	}
+
+	return conversationID, lastMessage, true
+}
+
+// loadMissed returns the messages and receipts a client missed in a conversation since the given
+// message. It reports false when more than limit messages were missed, or they could not be
+// loaded, so the client has to reload the conversation over REST instead.
+func (h *EventHandler) loadMissed(ctx context.Context, userID string, conversationID uuid.UUID, lastMessage *entities.Message, limit int) ([]*entities.Message, []*entities.MessageStatus, bool) {
+	// Asking for one more than the bound tells a gap that fills it from one that overflows it
+	missed, err := h.messageRepo.GetMessagesAfterCursor(ctx, conversationID, lastMessage, limit+1)
+	if err != nil {
+		logger.Error("Failed to get missed messages for sync", err, "conversation_id", conversationID)
+		return nil, nil, false
+	}
+	if len(missed) > limit {
+		logger.Info("Sync gap exceeds catch-up bound, falling back to REST",
+			"user_id", userID,
+			"conversation_id", conversationID,
+			"limit", limit,
+		)
+		return nil, nil, false
+	}
+
+	statuses, err := h.messageRepo.GetConversationStatusesSince(ctx, conversationID, lastMessage.CreatedAt)
+	if err != nil {
+		logger.Error("Failed to get missed receipts for sync", err, "conversation_id", conversationID)
+		return nil, nil, false
+	}
+
+	// Deleted messages are replayed as tombstones so the client's history keeps its order
+	entities.TombstoneMessages(missed)
+
+	// Messages of a shadowbanned participant are only replayed to themselves
+	return h.connManager.visibleMessages(userID, missed), statuses, true
+}
+
+// receiptMessage builds the live event for a delivery status transition. Sent statuses have no
+// event of their own, since the message itself carries them.
+func receiptMessage(conversationID string, status *entities.MessageStatus) (Message, bool) {
+	switch status.Status {
+	case entities.MessageStatusDelivered:
+		return Message{
+			Type: "message:delivered",
+			Data: map[string]interface{}{
+				"message_id":      status.MessageID.String(),
+				"conversation_id": conversationID,
+				"delivered_at":    status.CreatedAt,
+			},
+			Timestamp: time.Now(),
+			SenderID:  status.UserID.String(),
+		}, true
+	case entities.MessageStatusRead:
+		return Message{
+			Type: "message:viewed",
+			Data: map[string]interface{}{
+				"message_id": status.MessageID.String(),
+				"user_id":    status.UserID.String(),
+				"timestamp":  status.CreatedAt,
+			},
+			Timestamp: time.Now(),
+			SenderID:  status.UserID.String(),
+		}, true
+	default:
+		return Message{}, false
+	}
+}
+
+// syncMaxMessages returns how many missed messages are replayed per conversation
+func (cm *ConnectionManager) syncMaxMessages() int {
+	if cm.config != nil && cm.config.SyncMaxMessages > 0 {
+		return cm.config.SyncMaxMessages
+	}
+	return defaultSyncMaxMessages
+}
+
+// beginSync starts holding back live messages while the client catches up
+func (conn *ClientConnection) beginSync() {
+	conn.mu.Lock()
+	defer conn.mu.Unlock()
+
+	conn.syncing = true
+}
+
+// writeSyncMessage writes a catch-up message ahead of the live messages being held back
+func (conn *ClientConnection) writeSyncMessage(message Message) error {
+	conn.mu.Lock()
+	defer conn.mu.Unlock()
+
+	return conn.write(message)
+}
+
+// endSync writes the live messages held back during the catch-up and resumes live delivery.
+// New messages the catch-up already replayed are dropped.
+func (conn *ClientConnection) endSync(delivered map[string]bool) error {
+	conn.mu.Lock()
+	defer conn.mu.Unlock()
+
+	held := conn.held
+	conn.held = nil
+	conn.syncing = false
+
+	for _, message := range held {
+		if message.Type == "message:new" && delivered[liveMessageID(message)] {
+			continue
+		}
+		if err := conn.write(message); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// liveMessageID returns the ID of the chat message carried by a message:new event
+func liveMessageID(message Message) string {
+	data, err := json.Marshal(message.Data)
+	if err != nil {
+		return ""
+	}
+
+	var payload struct {
+		ID string `json:"id"`
+	}
+	if err := json.Unmarshal(data, &payload); err != nil {
+		return ""
+	}
+	return payload.ID
+}
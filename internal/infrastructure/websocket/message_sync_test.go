@@ -0,0 +1,283 @@
+package websocket
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/gorilla/websocket"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/22smeargle/winkr-backend/internal/domain/entities"
+	"github.com/22smeargle/winkr-backend/internal/domain/repositories"
+	"github.com/22smeargle/winkr-backend/pkg/config"
+)
+
+// inMemorySyncRepository implements the message repository methods used by the sync handshake
+type inMemorySyncRepository struct {
+	repositories.MessageRepository
+	messages      []*entities.Message
+	statuses      []*entities.MessageStatus
+	onMissedQuery func()
+}
+
+func (r *inMemorySyncRepository) UserCanAccessConversation(ctx context.Context, userID, conversationID uuid.UUID) (bool, error) {
+	return true, nil
+}
+
+func (r *inMemorySyncRepository) GetByID(ctx context.Context, id uuid.UUID) (*entities.Message, error) {
+	for _, message := range r.messages {
+		if message.ID == id {
+			return message, nil
+		}
+	}
+	return nil, fmt.Errorf("message not found")
+}
+
+func (r *inMemorySyncRepository) GetMessagesAfterCursor(ctx context.Context, conversationID uuid.UUID, cursor *entities.Message, limit int) ([]*entities.Message, error) {
+	if r.onMissedQuery != nil {
+		r.onMissedQuery()
+	}
+
+	var missed []*entities.Message
+	for _, message := range r.messages {
+		if message.ConversationID == conversationID && message.CreatedAt.After(cursor.CreatedAt) {
+			missed = append(missed, message)
+		}
+	}
+	sort.Slice(missed, func(i, j int) bool { return missed[i].CreatedAt.Before(missed[j].CreatedAt) })
+
+	if len(missed) > limit {
+		missed = missed[:limit]
+	}
+	return missed, nil
+}
+
+func (r *inMemorySyncRepository) GetConversationStatusesSince(ctx context.Context, conversationID uuid.UUID, since time.Time) ([]*entities.MessageStatus, error) {
+	var statuses []*entities.MessageStatus
+	for _, status := range r.statuses {
+		message, err := r.GetByID(ctx, status.MessageID)
+		if err == nil && message.ConversationID == conversationID && status.CreatedAt.After(since) {
+			statuses = append(statuses, status)
+		}
+	}
+	return statuses, nil
+}
+
+// syncFixture is a conversation with a history of messages sent a minute apart
+type syncFixture struct {
+	userID         string
+	partnerID      uuid.UUID
+	conversationID uuid.UUID
+	start          time.Time
+	repo           *inMemorySyncRepository
+}
+
+func newSyncFixture(count int) *syncFixture {
+	f := &syncFixture{
+		userID:         uuid.New().String(),
+		partnerID:      uuid.New(),
+		conversationID: uuid.New(),
+		start:          time.Date(2026, 10, 17, 12, 0, 0, 0, time.UTC),
+		repo:           &inMemorySyncRepository{},
+	}
+	for i := 0; i < count; i++ {
+		f.addMessage(f.conversationID, i)
+	}
+
+	// A message in another conversation must never be replayed
+	f.addMessage(uuid.New(), count)
+	return f
+}
+
+func (f *syncFixture) addMessage(conversationID uuid.UUID, minute int) *entities.Message {
+	message := &entities.Message{
+		ID:             uuid.New(),
+		ConversationID: conversationID,
+		SenderID:       f.partnerID,
+		Content:        fmt.Sprintf("message %d", minute),
+		MessageType:    "text",
+		CreatedAt:      f.start.Add(time.Duration(minute) * time.Minute),
+	}
+	f.repo.messages = append(f.repo.messages, message)
+	return message
+}
+
+// connect starts a server for the fixture's repository and dials it as the fixture's user
+func (f *syncFixture) connect(t *testing.T, cfg *config.WebSocketConfig) (*ConnectionManager, *websocket.Conn) {
+	cm := NewConnectionManager(nil, nil, cfg)
+	handler := NewEventHandler(cm, f.repo, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil)
+	cm.SetMessageHandler(handler.HandleMessage)
+
+	server := newHeartbeatTestServer(t, cm)
+	conn := dialHeartbeatTestServer(t, server, f.userID)
+	require.NoError(t, conn.SetReadDeadline(time.Now().Add(2*time.Second)))
+	return cm, conn
+}
+
+func (f *syncFixture) requestSync(t *testing.T, conn *websocket.Conn, lastMessageID uuid.UUID) {
+	request, err := json.Marshal(map[string]interface{}{
+		"type": "sync:request",
+		"data": map[string]interface{}{
+			"cursors": []SyncCursor{
+				{ConversationID: f.conversationID.String(), LastMessageID: lastMessageID.String()},
+			},
+		},
+	})
+	require.NoError(t, err)
+	require.NoError(t, conn.WriteMessage(websocket.TextMessage, request))
+}
+
+// syncEvent is a message received by the test client
+type syncEvent struct {
+	Type string          `json:"type"`
+	Data json.RawMessage `json:"data"`
+}
+
+// readEvents reads messages until stop returns true, skipping online status broadcasts
+func readEvents(t *testing.T, conn *websocket.Conn, stop func(syncEvent) bool) []syncEvent {
+	var events []syncEvent
+	for {
+		_, data, err := conn.ReadMessage()
+		require.NoError(t, err)
+
+		var event syncEvent
+		require.NoError(t, json.Unmarshal(data, &event))
+		if event.Type == "user:status" {
+			continue
+		}
+
+		events = append(events, event)
+		if stop(event) {
+			return events
+		}
+	}
+}
+
+func untilSyncComplete(event syncEvent) bool {
+	return event.Type == "sync:complete"
+}
+
+// newMessageIDs returns the IDs of the message:new events in order
+func newMessageIDs(t *testing.T, events []syncEvent) []uuid.UUID {
+	var ids []uuid.UUID
+	for _, event := range events {
+		if event.Type != "message:new" {
+			continue
+		}
+		var message entities.Message
+		require.NoError(t, json.Unmarshal(event.Data, &message))
+		ids = append(ids, message.ID)
+	}
+	return ids
+}
+
+func syncResults(t *testing.T, event syncEvent) []SyncResult {
+	var data struct {
+		Conversations []SyncResult `json:"conversations"`
+	}
+	require.NoError(t, json.Unmarshal(event.Data, &data))
+	return data.Conversations
+}
+
+func TestSync_ReconnectingClientReceivesExactlyTheMissedMessagesInOrder(t *testing.T) {
+	f := newSyncFixture(6)
+	history := f.repo.messages[:6]
+
+	// The partner read a message the client had already seen, while the client was offline
+	f.repo.statuses = []*entities.MessageStatus{
+		{ID: uuid.New(), MessageID: history[0].ID, UserID: f.partnerID, Status: entities.MessageStatusRead, CreatedAt: f.start.Add(3 * time.Minute)},
+		{ID: uuid.New(), MessageID: history[1].ID, UserID: f.partnerID, Status: entities.MessageStatusSent, CreatedAt: f.start.Add(time.Minute)},
+	}
+
+	_, conn := f.connect(t, nil)
+	f.requestSync(t, conn, history[1].ID)
+
+	events := readEvents(t, conn, untilSyncComplete)
+
+	assert.Equal(t, []uuid.UUID{history[2].ID, history[3].ID, history[4].ID, history[5].ID}, newMessageIDs(t, events))
+
+	// Receipts follow the messages, before the sync completes
+	require.Len(t, events, 6)
+	assert.Equal(t, "message:viewed", events[4].Type)
+	assert.Contains(t, string(events[4].Data), history[0].ID.String())
+
+	results := syncResults(t, events[5])
+	require.Len(t, results, 1)
+	assert.Equal(t, SyncResult{
+		ConversationID: f.conversationID.String(),
+		Messages:       4,
+		Cursor:         history[5].ID.String(),
+	}, results[0])
+}
+
+func TestSync_UpToDateClientReceivesNothing(t *testing.T) {
+	f := newSyncFixture(3)
+
+	_, conn := f.connect(t, nil)
+	f.requestSync(t, conn, f.repo.messages[2].ID)
+
+	events := readEvents(t, conn, untilSyncComplete)
+
+	require.Len(t, events, 1)
+	assert.Equal(t, SyncResult{
+		ConversationID: f.conversationID.String(),
+		Cursor:         f.repo.messages[2].ID.String(),
+	}, syncResults(t, events[0])[0])
+}
+
+func TestSync_LargeGapFallsBackToREST(t *testing.T) {
+	f := newSyncFixture(5)
+
+	_, conn := f.connect(t, &config.WebSocketConfig{SyncMaxMessages: 3})
+	f.requestSync(t, conn, f.repo.messages[0].ID)
+
+	events := readEvents(t, conn, untilSyncComplete)
+
+	require.Len(t, events, 1, "no messages are replayed for a gap")
+	results := syncResults(t, events[0])
+	require.Len(t, results, 1)
+	assert.True(t, results[0].Gap)
+	assert.Equal(t, 0, results[0].Messages)
+	assert.Equal(t, f.repo.messages[0].ID.String(), results[0].Cursor)
+}
+
+func TestSync_UnknownCursorFallsBackToREST(t *testing.T) {
+	f := newSyncFixture(3)
+
+	_, conn := f.connect(t, nil)
+	f.requestSync(t, conn, uuid.New())
+
+	events := readEvents(t, conn, untilSyncComplete)
+
+	require.Len(t, events, 1)
+	assert.True(t, syncResults(t, events[0])[0].Gap)
+}
+
+func TestSync_LiveMessagesWaitForCatchUpWithoutDuplicates(t *testing.T) {
+	f := newSyncFixture(4)
+	history := f.repo.messages[:4]
+
+	var cm *ConnectionManager
+	var live *entities.Message
+	f.repo.onMissedQuery = func() {
+		// A message already stored is also broadcast live while the catch-up runs,
+		// and a new one arrives after the missed messages were loaded
+		cm.BroadcastToUser(f.userID, Message{Type: "message:new", Data: history[3], Timestamp: time.Now()})
+		live = &entities.Message{ID: uuid.New(), ConversationID: f.conversationID, SenderID: f.partnerID, CreatedAt: f.start.Add(time.Hour)}
+		cm.BroadcastToUser(f.userID, Message{Type: "message:new", Data: live, Timestamp: time.Now()})
+	}
+
+	cm, conn := f.connect(t, nil)
+	f.requestSync(t, conn, history[1].ID)
+
+	events := readEvents(t, conn, untilSyncComplete)
+	assert.Equal(t, []uuid.UUID{history[2].ID, history[3].ID}, newMessageIDs(t, events))
+
+	events = readEvents(t, conn, func(event syncEvent) bool { return event.Type == "message:new" })
+	assert.Equal(t, []uuid.UUID{live.ID}, newMessageIDs(t, events))
+}
@@ -0,0 +1,200 @@
+package websocket
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/22smeargle/winkr-backend/pkg/logger"
+)
+
+// Presence subscription defaults used when the WebSocket config leaves them unset
+const (
+	defaultPresenceMaxSubscriptions  = 50
+	defaultPresenceSubscribeInterval = 5 * time.Second
+)
+
+// handleSubscribePresence follows the presence of a list of matches. Each request replaces the
+// connection's previous subscriptions, and an empty list clears them. IDs that are not an active
+// match of the user are rejected and left out. The reply carries the current status of every
+// subscribed user, after which presence:update events are pushed whenever one of them comes
+// online or goes offline.
+func (h *EventHandler) handleSubscribePresence(ctx context.Context, conn *ClientConnection, wsMessage Message) error {
+	// Extract subscription data
+	var subscriptionData struct {
+		UserIDs []string `json:"user_ids"`
+	}
+
+	if err := json.Unmarshal(wsMessage.Data.(json.RawMessage), &subscriptionData); err != nil {
+		return fmt.Errorf("failed to parse presence subscription data: %w", err)
+	}
+
+	if limit := h.connManager.presenceMaxSubscriptions(); len(subscriptionData.UserIDs) > limit {
+		return conn.WriteMessage(Message{
+			Type: "error",
+			Data: map[string]interface{}{
+				"code":          "presence_limit_exceeded",
+				"message":       fmt.Sprintf("Presence can be followed for at most %d users", limit),
+				"request_event": "subscribe_presence",
+			},
+			Timestamp: time.Now(),
+		})
+	}
+
+	if retryAt, ok := conn.reservePresenceSubscription(time.Now(), h.connManager.presenceSubscribeInterval()); !ok {
+		return conn.WriteMessage(Message{
+			Type: "error",
+			Data: map[string]interface{}{
+				"code":          "presence_rate_limited",
+				"message":       "Presence subscriptions are changing too often, try again later",
+				"retry_at":      retryAt,
+				"request_event": "subscribe_presence",
+			},
+			Timestamp: time.Now(),
+		})
+	}
+
+	accepted, rejected := h.matchedUserIDs(ctx, conn.UserID, subscriptionData.UserIDs)
+	conn.setPresenceSubscriptions(accepted)
+
+	subscriptions := make([]OnlineStatus, 0, len(accepted))
+	for _, userID := range accepted {
+		subscriptions = append(subscriptions, OnlineStatus{
+			UserID:    userID,
+			IsOnline:  h.connManager.hasActiveConnections(userID),
+			Timestamp: time.Now(),
+		})
+	}
+
+	logger.Info("Presence subscription updated",
+		"user_id", conn.UserID,
+		"subscribed", len(accepted),
+		"rejected", len(rejected),
+	)
+
+	return conn.WriteMessage(Message{
+		Type: "presence:subscribed",
+		Data: map[string]interface{}{
+			"subscriptions": subscriptions,
+			"rejected":      rejected,
+		},
+		Timestamp: time.Now(),
+	})
+}
+
+// matchedUserIDs splits the requested IDs into the user's active matches and the rest, keeping
+// the request order and dropping duplicates
+func (h *EventHandler) matchedUserIDs(ctx context.Context, userID string, requested []string) ([]string, []string) {
+	accepted := make([]string, 0, len(requested))
+	rejected := make([]string, 0)
+	seen := make(map[string]bool, len(requested))
+
+	for _, requestedID := range requested {
+		if seen[requestedID] {
+			continue
+		}
+		seen[requestedID] = true
+
+		if h.isActiveMatch(ctx, userID, requestedID) {
+			accepted = append(accepted, requestedID)
+		} else {
+			rejected = append(rejected, requestedID)
+		}
+	}
+
+	return accepted, rejected
+}
+
+// isActiveMatch reports whether the other user is an active match of the user. The repository
+// reports a missing match as an error, so any failure counts as not matched.
+func (h *EventHandler) isActiveMatch(ctx context.Context, userID, otherID string) bool {
+	otherUserID, err := uuid.Parse(otherID)
+	if err != nil || otherID == userID || h.matchRepo == nil {
+		return false
+	}
+
+	match, err := h.matchRepo.GetMatchByUsers(ctx, uuid.MustParse(userID), otherUserID)
+	if err != nil {
+		logger.Debug("No match for presence subscription", "user_id", userID, "other_user_id", otherID, "error", err)
+		return false
+	}
+
+	return match.IsActive
+}
+
+// pushPresence sends a presence change to the connections following the user
+func (cm *ConnectionManager) pushPresence(status OnlineStatus) {
+	message := Message{
+		Type:      "presence:update",
+		Data:      status,
+		Timestamp: status.Timestamp,
+	}
+
+	cm.mu.RLock()
+	defer cm.mu.RUnlock()
+
+	for connectionID, conn := range cm.connections {
+		if !conn.isAlive() || !conn.followsPresenceOf(status.UserID) {
+			continue
+		}
+		if err := conn.WriteMessage(message); err != nil {
+			logger.Error("Failed to push presence update", err,
+				"connection_id", connectionID,
+				"user_id", status.UserID,
+			)
+		}
+	}
+}
+
+// presenceMaxSubscriptions returns how many users a connection may follow the presence of
+func (cm *ConnectionManager) presenceMaxSubscriptions() int {
+	if cm.config != nil && cm.config.PresenceMaxSubscriptions > 0 {
+		return cm.config.PresenceMaxSubscriptions
+	}
+	return defaultPresenceMaxSubscriptions
+}
+
+// presenceSubscribeInterval returns the minimum time between presence subscriptions on a connection
+func (cm *ConnectionManager) presenceSubscribeInterval() time.Duration {
+	if cm.config != nil && cm.config.PresenceSubscribeInterval > 0 {
+		return cm.config.PresenceSubscribeInterval
+	}
+	return defaultPresenceSubscribeInterval
+}
+
+// reservePresenceSubscription records a presence subscription unless the previous one was less than
+// the interval ago, in which case it returns when the next one is allowed
+func (conn *ClientConnection) reservePresenceSubscription(now time.Time, interval time.Duration) (time.Time, bool) {
+	conn.mu.Lock()
+	defer conn.mu.Unlock()
+
+	if !conn.presenceSubscribedAt.IsZero() {
+		if retryAt := conn.presenceSubscribedAt.Add(interval); now.Before(retryAt) {
+			return retryAt, false
+		}
+	}
+
+	conn.presenceSubscribedAt = now
+	return time.Time{}, true
+}
+
+// setPresenceSubscriptions replaces the users whose presence is pushed to the connection
+func (conn *ClientConnection) setPresenceSubscriptions(userIDs []string) {
+	conn.mu.Lock()
+	defer conn.mu.Unlock()
+
+	conn.presence = make(map[string]bool, len(userIDs))
+	for _, userID := range userIDs {
+		conn.presence[userID] = true
+	}
+}
+
+// followsPresenceOf checks if the connection is subscribed to the user's presence
+func (conn *ClientConnection) followsPresenceOf(userID string) bool {
+	conn.mu.RLock()
+	defer conn.mu.RUnlock()
+
+	return conn.presence[userID]
+}
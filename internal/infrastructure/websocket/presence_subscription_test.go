@@ -0,0 +1,189 @@
+package websocket
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/gorilla/websocket"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/22smeargle/winkr-backend/internal/domain/entities"
+	"github.com/22smeargle/winkr-backend/internal/domain/repositories"
+	"github.com/22smeargle/winkr-backend/pkg/config"
+)
+
+// inMemoryMatchRepository implements the match lookup used by presence subscriptions
+type inMemoryMatchRepository struct {
+	repositories.MatchRepository
+	matches []*entities.Match
+}
+
+func (r *inMemoryMatchRepository) GetMatchByUsers(ctx context.Context, user1ID, user2ID uuid.UUID) (*entities.Match, error) {
+	for _, match := range r.matches {
+		if match.IsUserInMatch(user1ID) && match.IsUserInMatch(user2ID) {
+			return match, nil
+		}
+	}
+	return nil, fmt.Errorf("match not found")
+}
+
+// presenceFixture is a user with an active match, an ended match and a stranger
+type presenceFixture struct {
+	userID    string
+	matchID   string
+	unmatched string
+	stranger  string
+	cm        *ConnectionManager
+	server    *httptest.Server
+}
+
+func newPresenceFixture(t *testing.T, cfg *config.WebSocketConfig) *presenceFixture {
+	user, match, unmatched := uuid.New(), uuid.New(), uuid.New()
+	repo := &inMemoryMatchRepository{matches: []*entities.Match{
+		{ID: uuid.New(), User1ID: user, User2ID: match, IsActive: true},
+		{ID: uuid.New(), User1ID: unmatched, User2ID: user, IsActive: false},
+	}}
+
+	cm := NewConnectionManager(nil, nil, cfg)
+	handler := NewEventHandler(cm, nil, nil, repo, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil)
+	cm.SetMessageHandler(handler.HandleMessage)
+
+	return &presenceFixture{
+		userID:    user.String(),
+		matchID:   match.String(),
+		unmatched: unmatched.String(),
+		stranger:  uuid.New().String(),
+		cm:        cm,
+		server:    newHeartbeatTestServer(t, cm),
+	}
+}
+
+func (f *presenceFixture) dial(t *testing.T, userID string) *websocket.Conn {
+	conn := dialHeartbeatTestServer(t, f.server, userID)
+	require.NoError(t, conn.SetReadDeadline(time.Now().Add(2*time.Second)))
+	return conn
+}
+
+func subscribePresence(t *testing.T, conn *websocket.Conn, userIDs ...string) {
+	request, err := json.Marshal(map[string]interface{}{
+		"type": "subscribe_presence",
+		"data": map[string]interface{}{
+			"user_ids": userIDs,
+		},
+	})
+	require.NoError(t, err)
+	require.NoError(t, conn.WriteMessage(websocket.TextMessage, request))
+}
+
+func untilType(eventType string) func(syncEvent) bool {
+	return func(event syncEvent) bool {
+		return event.Type == eventType
+	}
+}
+
+func presenceUpdates(t *testing.T, events []syncEvent) []OnlineStatus {
+	var updates []OnlineStatus
+	for _, event := range events {
+		if event.Type != "presence:update" {
+			continue
+		}
+		var status OnlineStatus
+		require.NoError(t, json.Unmarshal(event.Data, &status))
+		updates = append(updates, status)
+	}
+	return updates
+}
+
+type presenceSubscribed struct {
+	Subscriptions []OnlineStatus `json:"subscriptions"`
+	Rejected      []string       `json:"rejected"`
+}
+
+func errorCode(t *testing.T, event syncEvent) string {
+	var data struct {
+		Code string `json:"code"`
+	}
+	require.NoError(t, json.Unmarshal(event.Data, &data))
+	return data.Code
+}
+
+func TestPresence_SubscribedMatchChangesArePushed(t *testing.T) {
+	f := newPresenceFixture(t, &config.WebSocketConfig{PingInterval: 20 * time.Millisecond})
+	subscriber := f.dial(t, f.userID)
+
+	subscribePresence(t, subscriber, f.matchID, f.stranger, f.unmatched, f.matchID)
+	events := readEvents(t, subscriber, untilType("presence:subscribed"))
+
+	var subscribed presenceSubscribed
+	require.NoError(t, json.Unmarshal(events[len(events)-1].Data, &subscribed))
+	require.Len(t, subscribed.Subscriptions, 1)
+	assert.Equal(t, f.matchID, subscribed.Subscriptions[0].UserID)
+	assert.False(t, subscribed.Subscriptions[0].IsOnline)
+	assert.Equal(t, []string{f.stranger, f.unmatched}, subscribed.Rejected, "IDs that are not an active match are rejected")
+
+	// The stranger comes online first, but only the match's presence is pushed
+	f.dial(t, f.stranger)
+	match := f.dial(t, f.matchID)
+
+	events = readEvents(t, subscriber, untilType("presence:update"))
+	updates := presenceUpdates(t, events)
+	require.Len(t, updates, 1)
+	assert.Equal(t, f.matchID, updates[0].UserID)
+	assert.True(t, updates[0].IsOnline)
+
+	require.NoError(t, match.Close())
+
+	events = readEvents(t, subscriber, untilType("presence:update"))
+	updates = presenceUpdates(t, events)
+	require.Len(t, updates, 1)
+	assert.Equal(t, f.matchID, updates[0].UserID)
+	assert.False(t, updates[0].IsOnline)
+}
+
+func TestPresence_SubscriptionSizeIsCapped(t *testing.T) {
+	f := newPresenceFixture(t, &config.WebSocketConfig{PresenceMaxSubscriptions: 2})
+	subscriber := f.dial(t, f.userID)
+
+	subscribePresence(t, subscriber, f.matchID, uuid.New().String(), uuid.New().String())
+	events := readEvents(t, subscriber, untilType("error"))
+
+	assert.Equal(t, "presence_limit_exceeded", errorCode(t, events[len(events)-1]))
+	conn := f.cm.GetUserConnections(f.userID)[0]
+	assert.False(t, conn.followsPresenceOf(f.matchID), "an oversized request subscribes to nobody")
+}
+
+func TestPresence_SubscriptionsAreRateLimited(t *testing.T) {
+	f := newPresenceFixture(t, &config.WebSocketConfig{PresenceSubscribeInterval: time.Minute})
+	subscriber := f.dial(t, f.userID)
+
+	subscribePresence(t, subscriber, f.matchID)
+	readEvents(t, subscriber, untilType("presence:subscribed"))
+
+	subscribePresence(t, subscriber)
+	events := readEvents(t, subscriber, untilType("error"))
+
+	assert.Equal(t, "presence_rate_limited", errorCode(t, events[len(events)-1]))
+	conn := f.cm.GetUserConnections(f.userID)[0]
+	assert.True(t, conn.followsPresenceOf(f.matchID), "a rate-limited request leaves the subscriptions unchanged")
+}
+
+func TestPresence_SubscriptionsAreClearedOnDisconnect(t *testing.T) {
+	f := newPresenceFixture(t, &config.WebSocketConfig{PingInterval: 20 * time.Millisecond})
+	subscriber := f.dial(t, f.userID)
+
+	subscribePresence(t, subscriber, f.matchID)
+	readEvents(t, subscriber, untilType("presence:subscribed"))
+	conn := f.cm.GetUserConnections(f.userID)[0]
+	require.True(t, conn.followsPresenceOf(f.matchID))
+
+	require.NoError(t, subscriber.Close())
+
+	assert.Eventually(t, func() bool {
+		return f.cm.GetConnectionCount() == 0 && !conn.followsPresenceOf(f.matchID)
+	}, 2*time.Second, 10*time.Millisecond)
+}
@@ -0,0 +1,57 @@
+package websocket
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/22smeargle/winkr-backend/internal/domain/entities"
+)
+
+// ShadowbanCheck reports whether a user is shadowbanned
+type ShadowbanCheck func(ctx context.Context, userID uuid.UUID) bool
+
+// SetShadowbanCheck sets how shadowbanned users are recognized. What a shadowbanned user sends to a
+// conversation, live or replayed on sync, only reaches their own connections.
+func (cm *ConnectionManager) SetShadowbanCheck(check ShadowbanCheck) {
+	cm.isShadowbanned = check
+}
+
+// withholds reports whether what the user sends is kept from everyone else
+func (cm *ConnectionManager) withholds(senderID string) bool {
+	if cm.isShadowbanned == nil || senderID == "" {
+		return false
+	}
+
+	userID, err := uuid.Parse(senderID)
+	if err != nil {
+		return false
+	}
+
+	return cm.isShadowbanned(context.Background(), userID)
+}
+
+// visibleMessages leaves out the messages of other users whose messages are withheld
+func (cm *ConnectionManager) visibleMessages(userID string, messages []*entities.Message) []*entities.Message {
+	if cm.isShadowbanned == nil {
+		return messages
+	}
+
+	withheld := make(map[uuid.UUID]bool)
+	visible := make([]*entities.Message, 0, len(messages))
+	for _, message := range messages {
+		senderID := message.SenderID.String()
+		if senderID != userID {
+			hidden, checked := withheld[message.SenderID]
+			if !checked {
+				hidden = cm.withholds(senderID)
+				withheld[message.SenderID] = hidden
+			}
+			if hidden {
+				continue
+			}
+		}
+		visible = append(visible, message)
+	}
+
+	return visible
+}
@@ -0,0 +1,75 @@
+package websocket
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/gorilla/websocket"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/22smeargle/winkr-backend/internal/domain/entities"
+)
+
+// shadowbanned returns a check that reports only the given user as shadowbanned
+func shadowbanned(userID uuid.UUID) ShadowbanCheck {
+	return func(ctx context.Context, id uuid.UUID) bool {
+		return id == userID
+	}
+}
+
+// joinConversation dials the user in and adds them to the conversation once connected
+func joinConversation(t *testing.T, cm *ConnectionManager, server func(string) *websocket.Conn, userID, conversationID string) *websocket.Conn {
+	conn := server(userID)
+	require.Eventually(t, func() bool {
+		return len(cm.GetUserConnections(userID)) == 1
+	}, 2*time.Second, 10*time.Millisecond)
+	require.NoError(t, cm.JoinConversation(userID, conversationID))
+	require.NoError(t, conn.SetReadDeadline(time.Now().Add(2*time.Second)))
+	return conn
+}
+
+func TestShadowban_MessagesOnlyReachTheSender(t *testing.T) {
+	sender, recipient, conversationID := uuid.New(), uuid.New(), uuid.New().String()
+
+	cm := NewConnectionManager(nil, nil, nil)
+	cm.SetShadowbanCheck(shadowbanned(sender))
+	server := newHeartbeatTestServer(t, cm)
+	dial := func(userID string) *websocket.Conn { return dialHeartbeatTestServer(t, server, userID) }
+
+	senderConn := joinConversation(t, cm, dial, sender.String(), conversationID)
+	recipientConn := joinConversation(t, cm, dial, recipient.String(), conversationID)
+
+	withheld := &entities.Message{ID: uuid.New(), SenderID: sender}
+	require.NoError(t, cm.BroadcastToConversation(conversationID, Message{Type: "message:new", Data: withheld, SenderID: sender.String()}))
+	reply := &entities.Message{ID: uuid.New(), SenderID: recipient}
+	require.NoError(t, cm.BroadcastToConversation(conversationID, Message{Type: "message:new", Data: reply, SenderID: recipient.String()}))
+
+	// The sender sees both messages as usual, the recipient only their own
+	events := readEvents(t, senderConn, untilType("message:new"))
+	events = append(events, readEvents(t, senderConn, untilType("message:new"))...)
+	assert.Equal(t, []uuid.UUID{withheld.ID, reply.ID}, newMessageIDs(t, events))
+
+	events = readEvents(t, recipientConn, untilType("message:new"))
+	assert.Equal(t, []uuid.UUID{reply.ID}, newMessageIDs(t, events))
+}
+
+func TestShadowban_SyncSkipsShadowbannedPartnersMessages(t *testing.T) {
+	f := newSyncFixture(4)
+	history := f.repo.messages[:4]
+
+	cm := NewConnectionManager(nil, nil, nil)
+	cm.SetShadowbanCheck(shadowbanned(f.partnerID))
+	handler := NewEventHandler(cm, f.repo, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil)
+	cm.SetMessageHandler(handler.HandleMessage)
+
+	conn := dialHeartbeatTestServer(t, newHeartbeatTestServer(t, cm), f.userID)
+	require.NoError(t, conn.SetReadDeadline(time.Now().Add(2*time.Second)))
+	f.requestSync(t, conn, history[1].ID)
+
+	events := readEvents(t, conn, untilSyncComplete)
+
+	assert.Empty(t, newMessageIDs(t, events))
+	assert.Equal(t, 0, syncResults(t, events[len(events)-1])[0].Messages)
+}
@@ -0,0 +1,124 @@
+package websocket
+
+import (
+	"encoding/json"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/gorilla/websocket"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// typingIndicators returns the typing indicators among the events in order
+func typingIndicators(t *testing.T, events []syncEvent) []TypingIndicator {
+	var indicators []TypingIndicator
+	for _, event := range events {
+		if event.Type != "typing:indicator" {
+			continue
+		}
+		var indicator TypingIndicator
+		require.NoError(t, json.Unmarshal(event.Data, &indicator))
+		indicators = append(indicators, indicator)
+	}
+	return indicators
+}
+
+// untilStoppedTyping stops at the first indicator that clears typing
+func untilStoppedTyping(event syncEvent) bool {
+	if event.Type != "typing:indicator" {
+		return false
+	}
+	var indicator TypingIndicator
+	return json.Unmarshal(event.Data, &indicator) == nil && !indicator.IsTyping
+}
+
+// assertNothingReceived fails if anything other than an online status arrives within the wait
+func assertNothingReceived(t *testing.T, conn *websocket.Conn, wait time.Duration) {
+	require.NoError(t, conn.SetReadDeadline(time.Now().Add(wait)))
+	for {
+		_, data, err := conn.ReadMessage()
+		if err != nil {
+			netErr, ok := err.(net.Error)
+			require.True(t, ok && netErr.Timeout(), "unexpected read error: %v", err)
+			return
+		}
+
+		var event syncEvent
+		require.NoError(t, json.Unmarshal(data, &event))
+		require.Equal(t, "user:status", event.Type, "unexpected event: %s", data)
+	}
+}
+
+func TestTyping_KeystrokesAreDebouncedAndExpire(t *testing.T) {
+	typist, partner, conversationID := uuid.New().String(), uuid.New().String(), uuid.New().String()
+
+	cm := NewConnectionManager(nil, nil, nil)
+	cm.SetTypingIndicatorTTL(200 * time.Millisecond)
+	server := newHeartbeatTestServer(t, cm)
+	dial := func(userID string) *websocket.Conn { return dialHeartbeatTestServer(t, server, userID) }
+
+	typistConn := joinConversation(t, cm, dial, typist, conversationID)
+	partnerConn := joinConversation(t, cm, dial, partner, conversationID)
+
+	for i := 0; i < 5; i++ {
+		require.NoError(t, cm.SetTyping(typist, conversationID, true))
+	}
+	assert.Equal(t, []string{typist}, cm.GetTypingUsers(conversationID))
+
+	// One indicator for the burst, then a stop once the window passes without typing
+	indicators := typingIndicators(t, readEvents(t, partnerConn, untilStoppedTyping))
+	require.Len(t, indicators, 2)
+	assert.True(t, indicators[0].IsTyping)
+	assert.Equal(t, typist, indicators[0].UserID)
+	assert.False(t, indicators[1].IsTyping)
+	assert.Empty(t, cm.GetTypingUsers(conversationID))
+
+	// The typist never hears about their own typing
+	assertNothingReceived(t, typistConn, 300*time.Millisecond)
+}
+
+func TestTyping_StopIsSentOnce(t *testing.T) {
+	typist, partner, conversationID := uuid.New().String(), uuid.New().String(), uuid.New().String()
+
+	cm := NewConnectionManager(nil, nil, nil)
+	cm.SetTypingIndicatorTTL(200 * time.Millisecond)
+	server := newHeartbeatTestServer(t, cm)
+	dial := func(userID string) *websocket.Conn { return dialHeartbeatTestServer(t, server, userID) }
+
+	joinConversation(t, cm, dial, typist, conversationID)
+	partnerConn := joinConversation(t, cm, dial, partner, conversationID)
+
+	require.NoError(t, cm.SetTyping(typist, conversationID, true))
+	require.NoError(t, cm.SetTyping(typist, conversationID, false))
+	require.NoError(t, cm.SetTyping(typist, conversationID, false))
+
+	indicators := typingIndicators(t, readEvents(t, partnerConn, untilStoppedTyping))
+	require.Len(t, indicators, 2)
+	assert.True(t, indicators[0].IsTyping)
+
+	// Neither the repeated stop nor the cancelled timer sends another one
+	assertNothingReceived(t, partnerConn, 400*time.Millisecond)
+}
+
+func TestTyping_OnlyReachesConversationParticipants(t *testing.T) {
+	typist, partner, outsider := uuid.New().String(), uuid.New().String(), uuid.New().String()
+	conversationID := uuid.New().String()
+
+	cm := NewConnectionManager(nil, nil, nil)
+	cm.SetTypingIndicatorTTL(200 * time.Millisecond)
+	server := newHeartbeatTestServer(t, cm)
+	dial := func(userID string) *websocket.Conn { return dialHeartbeatTestServer(t, server, userID) }
+
+	joinConversation(t, cm, dial, typist, conversationID)
+	partnerConn := joinConversation(t, cm, dial, partner, conversationID)
+	outsiderConn := joinConversation(t, cm, dial, outsider, uuid.New().String())
+
+	require.NoError(t, cm.SetTyping(typist, conversationID, true))
+
+	indicators := typingIndicators(t, readEvents(t, partnerConn, untilStoppedTyping))
+	assert.Len(t, indicators, 2)
+	assertNothingReceived(t, outsiderConn, 100*time.Millisecond)
+}
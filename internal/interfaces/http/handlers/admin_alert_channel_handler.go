@@ -0,0 +1,58 @@
+package handlers
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/22smeargle/winkr-backend/internal/application/services"
+	"github.com/22smeargle/winkr-backend/pkg/logger"
+	"github.com/22smeargle/winkr-backend/pkg/utils"
+)
+
+// AdminAlertChannelHandler handles admin endpoints for the alert notification channels
+type AdminAlertChannelHandler struct {
+	alertingService *services.AlertingService
+}
+
+// NewAdminAlertChannelHandler creates a new admin alert channel handler
+func NewAdminAlertChannelHandler(alertingService *services.AlertingService) *AdminAlertChannelHandler {
+	return &AdminAlertChannelHandler{
+		alertingService: alertingService,
+	}
+}
+
+// ListChannels handles listing the configured notification channels, without their secrets
+func (h *AdminAlertChannelHandler) ListChannels(c *gin.Context) {
+	utils.Success(c, http.StatusOK, gin.H{
+		"channels": h.alertingService.ListNotificationChannels(),
+	})
+}
+
+// TestChannel handles sending a test alert through a channel
+func (h *AdminAlertChannelHandler) TestChannel(c *gin.Context) {
+	adminID, exists := c.Get("admin_id")
+	if !exists {
+		utils.Unauthorized(c, "Admin authentication required")
+		return
+	}
+	channelID := c.Param("id")
+
+	err := h.alertingService.SendTestNotification(c.Request.Context(), channelID, fmt.Sprint(adminID))
+	switch {
+	case errors.Is(err, services.ErrNotificationChannelNotFound):
+		utils.NotFound(c, "Notification channel not found")
+		return
+	case errors.Is(err, services.ErrNotificationChannelDisabled):
+		utils.Conflict(c, "Notification channel is disabled")
+		return
+	case err != nil:
+		logger.Error("Test notification failed", err, "channel", channelID)
+		utils.ErrorWithDetails(c, http.StatusBadGateway, "Test notification failed", err.Error())
+		return
+	}
+
+	utils.SuccessMessage(c, http.StatusOK, "Test notification sent")
+}
@@ -0,0 +1,46 @@
+package handlers
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/22smeargle/winkr-backend/internal/application/usecases/ephemeral_photo"
+	"github.com/22smeargle/winkr-backend/pkg/logger"
+	"github.com/22smeargle/winkr-backend/pkg/utils"
+)
+
+// AdminEphemeralPhotoHandler handles admin ephemeral photo endpoints
+type AdminEphemeralPhotoHandler struct {
+	getCleanupStatsUseCase *ephemeral_photo.GetCleanupStatsUseCase
+}
+
+// NewAdminEphemeralPhotoHandler creates a new admin ephemeral photo handler
+func NewAdminEphemeralPhotoHandler(
+	getCleanupStatsUseCase *ephemeral_photo.GetCleanupStatsUseCase,
+) *AdminEphemeralPhotoHandler {
+	return &AdminEphemeralPhotoHandler{
+		getCleanupStatsUseCase: getCleanupStatsUseCase,
+	}
+}
+
+// GetCleanupStats handles getting the stats of the most recent cleanup job runs
+func (h *AdminEphemeralPhotoHandler) GetCleanupStats(c *gin.Context) {
+	limit, err := strconv.Atoi(c.DefaultQuery("limit", "20"))
+	if err != nil || limit < 1 || limit > 100 {
+		utils.BadRequest(c, "Invalid limit parameter")
+		return
+	}
+
+	response, err := h.getCleanupStatsUseCase.Execute(c.Request.Context(), &ephemeral_photo.GetCleanupStatsRequest{
+		Limit: limit,
+	})
+	if err != nil {
+		logger.Error("Failed to get ephemeral photo cleanup stats", err)
+		utils.InternalServerError(c, "Failed to get cleanup stats")
+		return
+	}
+
+	utils.Success(c, http.StatusOK, response)
+}
@@ -0,0 +1,73 @@
+package handlers
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/22smeargle/winkr-backend/internal/application/services"
+	"github.com/22smeargle/winkr-backend/pkg/errors"
+	"github.com/22smeargle/winkr-backend/pkg/logger"
+	"github.com/22smeargle/winkr-backend/pkg/utils"
+)
+
+// AdminMatchScoringHandler handles admin endpoints for the weights discovery ranks users by
+type AdminMatchScoringHandler struct {
+	matchScoring *services.MatchScoringService
+}
+
+// NewAdminMatchScoringHandler creates a new admin match scoring handler
+func NewAdminMatchScoringHandler(matchScoring *services.MatchScoringService) *AdminMatchScoringHandler {
+	return &AdminMatchScoringHandler{
+		matchScoring: matchScoring,
+	}
+}
+
+// SetMatchScoringWeightsRequest sets the weights of the discovery ranking score, each from 0 to 1
+type SetMatchScoringWeightsRequest struct {
+	Distance          *float64 `json:"distance" binding:"required"`
+	SharedInterests   *float64 `json:"shared_interests" binding:"required"`
+	ActivityRecency   *float64 `json:"activity_recency" binding:"required"`
+	ProfileCompletion *float64 `json:"profile_completion" binding:"required"`
+}
+
+// GetMatchScoringWeights handles getting the weights in effect
+func (h *AdminMatchScoringHandler) GetMatchScoringWeights(c *gin.Context) {
+	utils.Success(c, http.StatusOK, h.matchScoring.Weights(c.Request.Context()))
+}
+
+// SetMatchScoringWeights handles changing the weights for every instance
+func (h *AdminMatchScoringHandler) SetMatchScoringWeights(c *gin.Context) {
+	adminID, exists := c.Get("admin_id")
+	if !exists {
+		utils.Unauthorized(c, "Admin authentication required")
+		return
+	}
+
+	var req SetMatchScoringWeightsRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		utils.ValidationError(c, err.Error())
+		return
+	}
+
+	weights := services.MatchScoringWeights{
+		Distance:          *req.Distance,
+		SharedInterests:   *req.SharedInterests,
+		ActivityRecency:   *req.ActivityRecency,
+		ProfileCompletion: *req.ProfileCompletion,
+	}
+	weights, err := h.matchScoring.SetWeights(c.Request.Context(), weights, fmt.Sprint(adminID))
+	if err != nil {
+		// Weights out of range
+		if errors.IsAppError(err) {
+			utils.Error(c, err)
+			return
+		}
+		logger.Error("Failed to set match scoring weights", err, "admin_id", adminID)
+		utils.InternalServerError(c, "Failed to set match scoring weights")
+		return
+	}
+
+	utils.Success(c, http.StatusOK, weights)
+}
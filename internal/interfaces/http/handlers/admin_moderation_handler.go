@@ -1,13 +1,16 @@
 package handlers
 
 import (
+	"errors"
 	"net/http"
 	"strconv"
 
 	"github.com/gin-gonic/gin"
 	"github.com/google/uuid"
 	
+	"github.com/22smeargle/winkr-backend/internal/application/services"
 	"github.com/22smeargle/winkr-backend/internal/application/usecases/moderation"
+	apperrors "github.com/22smeargle/winkr-backend/pkg/errors"
 	"github.com/22smeargle/winkr-backend/pkg/logger"
 	"github.com/22smeargle/winkr-backend/pkg/response"
 	"github.com/22smeargle/winkr-backend/pkg/validator"
@@ -17,6 +20,8 @@ import (
 type AdminModerationHandler struct {
 	reviewReportUseCase *moderation.ReviewReportUseCase
 	banUserUseCase    *moderation.BanUserUseCase
+	multiAccountDetector *services.MultiAccountDetector
+	reviewQueue         *services.ModerationReviewQueueService
 	validator           validator.Validator
 }
 
@@ -24,11 +29,15 @@ type AdminModerationHandler struct {
 func NewAdminModerationHandler(
 	reviewReportUseCase *moderation.ReviewReportUseCase,
 	banUserUseCase *moderation.BanUserUseCase,
+	multiAccountDetector *services.MultiAccountDetector,
+	reviewQueue *services.ModerationReviewQueueService,
 	validator validator.Validator,
 ) *AdminModerationHandler {
 	return &AdminModerationHandler{
 		reviewReportUseCase: reviewReportUseCase,
 		banUserUseCase:    banUserUseCase,
+		multiAccountDetector: multiAccountDetector,
+		reviewQueue:         reviewQueue,
 		validator:           validator,
 	}
 }
@@ -297,7 +306,8 @@ func (h *AdminModerationHandler) SuspendUser(c *gin.Context) {
 	response.Success(c, http.StatusCreated, "User suspended successfully", result)
 }
 
-// GetModerationQueue handles GET /admin/moderation-queue endpoint
+// GetModerationQueue handles GET /admin/moderation-queue endpoint and lists items waiting for review,
+// highest priority first
 func (h *AdminModerationHandler) GetModerationQueue(c *gin.Context) {
 	logger.Info("GetModerationQueue request received", "ip", c.ClientIP(), "user_agent", c.Request.UserAgent())
 	
@@ -316,32 +326,217 @@ func (h *AdminModerationHandler) GetModerationQueue(c *gin.Context) {
 		return
 	}
 	
+	if h.reviewQueue == nil {
+		response.Error(c, http.StatusServiceUnavailable, "Moderation queue is disabled", nil)
+		return
+	}
+	
 	// Parse query parameters
 	priority := c.Query("priority")
 	limit, _ := strconv.Atoi(c.DefaultQuery("limit", "50"))
-	offset, _ := strconv.Atoi(c.DefaultQuery("offset", "0"))
 	
 	// Validate pagination parameters
 	if limit < 1 || limit > 100 {
 		limit = 50
 	}
+	
+	queueItems, err := h.reviewQueue.List(c.Request.Context(), priority, limit)
+	if err != nil {
+		logger.Error("Failed to list moderation queue", err, "admin_id", adminID, "ip", c.ClientIP())
+		response.Error(c, moderationQueueErrorStatus(err), "Failed to get moderation queue", err)
+		return
+	}
+	
+	response.Success(c, http.StatusOK, "Moderation queue retrieved successfully", gin.H{
+		"queue_items": queueItems,
+		"priority":    priority,
+		"limit":       limit,
+	})
+}
+
+// ClaimModerationQueueItem handles POST /admin/moderation-queue/claim endpoint. The item given is
+// assigned to the admin, or without one the next item by priority is auto-assigned.
+func (h *AdminModerationHandler) ClaimModerationQueueItem(c *gin.Context) {
+	logger.Info("ClaimModerationQueueItem request received", "ip", c.ClientIP(), "user_agent", c.Request.UserAgent())
+	
+	// Get admin ID from context (from auth middleware)
+	adminIDStr, exists := c.Get("admin_id")
+	if !exists {
+		logger.Error("Admin ID not found in context", nil, "ip", c.ClientIP())
+		response.Error(c, http.StatusUnauthorized, "Admin authentication required", nil)
+		return
+	}
+	
+	adminID, err := uuid.Parse(adminIDStr.(string))
+	if err != nil {
+		logger.Error("Invalid admin ID in context", err, "ip", c.ClientIP())
+		response.Error(c, http.StatusUnauthorized, "Invalid admin ID", err)
+		return
+	}
+	
+	if h.reviewQueue == nil {
+		response.Error(c, http.StatusServiceUnavailable, "Moderation queue is disabled", nil)
+		return
+	}
+	
+	var req struct {
+		ItemID *uuid.UUID `json:"item_id"`
+	}
+	
+	// The body is optional; an empty one auto-assigns the next item
+	if c.Request.ContentLength > 0 {
+		if err := c.ShouldBindJSON(&req); err != nil {
+			logger.Error("Failed to bind request", err, "ip", c.ClientIP())
+			response.Error(c, http.StatusBadRequest, "Invalid request format", err)
+			return
+		}
+	}
+	
+	itemID := uuid.Nil
+	if req.ItemID != nil {
+		itemID = *req.ItemID
+	}
+	
+	item, err := h.reviewQueue.Claim(c.Request.Context(), adminID, itemID)
+	if err != nil {
+		logger.Error("Failed to claim moderation queue item", err, "item_id", itemID, "admin_id", adminID, "ip", c.ClientIP())
+		response.Error(c, moderationQueueErrorStatus(err), "Failed to claim moderation queue item", err)
+		return
+	}
+	
+	response.Success(c, http.StatusOK, "Moderation queue item claimed successfully", gin.H{
+		"queue_item": item,
+	})
+}
+
+// ResolveModerationQueueItem handles POST /admin/moderation-queue/:id/resolve endpoint
+func (h *AdminModerationHandler) ResolveModerationQueueItem(c *gin.Context) {
+	logger.Info("ResolveModerationQueueItem request received", "ip", c.ClientIP(), "user_agent", c.Request.UserAgent())
+	
+	// Get admin ID from context (from auth middleware)
+	adminIDStr, exists := c.Get("admin_id")
+	if !exists {
+		logger.Error("Admin ID not found in context", nil, "ip", c.ClientIP())
+		response.Error(c, http.StatusUnauthorized, "Admin authentication required", nil)
+		return
+	}
+	
+	adminID, err := uuid.Parse(adminIDStr.(string))
+	if err != nil {
+		logger.Error("Invalid admin ID in context", err, "ip", c.ClientIP())
+		response.Error(c, http.StatusUnauthorized, "Invalid admin ID", err)
+		return
+	}
+	
+	if h.reviewQueue == nil {
+		response.Error(c, http.StatusServiceUnavailable, "Moderation queue is disabled", nil)
+		return
+	}
+	
+	// Get queue item ID from URL parameter
+	itemIDStr := c.Param("id")
+	itemID, err := uuid.Parse(itemIDStr)
+	if err != nil {
+		logger.Error("Invalid moderation queue item ID", err, "item_id", itemIDStr, "ip", c.ClientIP())
+		response.Error(c, http.StatusBadRequest, "Invalid moderation queue item ID", err)
+		return
+	}
+	
+	var req struct {
+		Action string `json:"action" validate:"required,oneof=approve reject escalate"`
+		Notes  string `json:"notes" validate:"max=1000"`
+	}
+	
+	if err := c.ShouldBindJSON(&req); err != nil {
+		logger.Error("Failed to bind request", err, "item_id", itemID, "ip", c.ClientIP())
+		response.Error(c, http.StatusBadRequest, "Invalid request format", err)
+		return
+	}
+	
+	// Validate request
+	if err := h.validator.Struct(req); err != nil {
+		logger.Error("Request validation failed", err, "request", req, "ip", c.ClientIP())
+		response.Error(c, http.StatusBadRequest, "Validation failed", err)
+		return
+	}
+	
+	item, err := h.reviewQueue.Resolve(c.Request.Context(), adminID, itemID, req.Action, req.Notes)
+	if err != nil {
+		logger.Error("Failed to resolve moderation queue item", err, "item_id", itemID, "admin_id", adminID, "ip", c.ClientIP())
+		response.Error(c, moderationQueueErrorStatus(err), "Failed to resolve moderation queue item", err)
+		return
+	}
+	
+	response.Success(c, http.StatusOK, "Moderation queue item resolved successfully", gin.H{
+		"queue_item": item,
+	})
+}
+
+// moderationQueueErrorStatus returns the HTTP status for a moderation queue error
+func moderationQueueErrorStatus(err error) int {
+	switch {
+	case errors.Is(err, services.ErrInvalidModerationPriority),
+		errors.Is(err, services.ErrInvalidModerationResolution),
+		errors.Is(err, services.ErrModerationAutoAssignDisabled):
+		return http.StatusBadRequest
+	case errors.Is(err, services.ErrModerationItemNotFound),
+		errors.Is(err, services.ErrModerationQueueEmpty):
+		return http.StatusNotFound
+	case errors.Is(err, services.ErrModerationItemClaimed),
+		errors.Is(err, services.ErrModerationClaimNotHeld):
+		return http.StatusConflict
+	case errors.Is(err, services.ErrModerationQueueFull):
+		return http.StatusServiceUnavailable
+	default:
+		return http.StatusInternalServerError
+	}
+}
+
+// GetLinkedAccounts handles GET /admin/linked-accounts endpoint and lists devices and IP addresses
+// shared by suspected linked accounts
+func (h *AdminModerationHandler) GetLinkedAccounts(c *gin.Context) {
+	logger.Info("GetLinkedAccounts request received", "ip", c.ClientIP(), "user_agent", c.Request.UserAgent())
+	
+	// Get admin ID from context (from auth middleware)
+	adminIDStr, exists := c.Get("admin_id")
+	if !exists {
+		logger.Error("Admin ID not found in context", nil, "ip", c.ClientIP())
+		response.Error(c, http.StatusUnauthorized, "Admin authentication required", nil)
+		return
+	}
+	
+	adminID, err := uuid.Parse(adminIDStr.(string))
+	if err != nil {
+		logger.Error("Invalid admin ID in context", err, "ip", c.ClientIP())
+		response.Error(c, http.StatusUnauthorized, "Invalid admin ID", err)
+		return
+	}
+	
+	// Parse query parameters
+	limit, _ := strconv.Atoi(c.DefaultQuery("limit", "20"))
+	offset, _ := strconv.Atoi(c.DefaultQuery("offset", "0"))
+	
+	// Validate pagination parameters
+	if limit < 1 || limit > 100 {
+		limit = 20
+	}
 	if offset < 0 {
 		offset = 0
 	}
 	
-	// Execute use case
-	queueItems, err := h.reviewReportUseCase.GetPendingAppeals(c.Request.Context(), adminID, limit, offset)
+	clusters, total, err := h.multiAccountDetector.GetFlaggedClusters(c.Request.Context(), limit, offset)
 	if err != nil {
-		logger.Error("Failed to execute GetPendingAppeals use case", err, "admin_id", adminID, "ip", c.ClientIP())
-		response.Error(c, http.StatusInternalServerError, "Failed to get moderation queue", err)
+		logger.Error("Failed to get linked account clusters", err, "admin_id", adminID, "ip", c.ClientIP())
+		response.Error(c, http.StatusInternalServerError, "Failed to get linked accounts", err)
 		return
 	}
 	
-	response.Success(c, http.StatusOK, "Moderation queue retrieved successfully", gin.H{
-		"queue_items": queueItems,
+	response.Success(c, http.StatusOK, "Linked accounts retrieved successfully", gin.H{
+		"clusters": clusters,
 		"pagination": gin.H{
 			"limit":  limit,
 			"offset": offset,
+			"total":  total,
 		},
 	})
 }
@@ -489,7 +684,7 @@ func (h *AdminModerationHandler) ReviewAppeal(c *gin.Context) {
 	}
 	
 	var req struct {
-		Approved bool   `json:"approved" validate:"required"`
+		Approved bool   `json:"approved"`
 		Notes    string `json:"notes" validate:"required"`
 	}
 	
@@ -510,13 +705,26 @@ func (h *AdminModerationHandler) ReviewAppeal(c *gin.Context) {
 	err = h.banUserUseCase.ReviewAppeal(c.Request.Context(), appealID, adminID, req.Approved, req.Notes)
 	if err != nil {
 		logger.Error("Failed to execute ReviewAppeal use case", err, "appeal_id", appealID, "admin_id", adminID, "ip", c.ClientIP())
+		if apperrors.IsAppError(err) {
+			appErr := apperrors.GetAppError(err)
+			response.Error(c, appErr.Code, appErr.Message, err)
+			return
+		}
 		response.Error(c, http.StatusInternalServerError, "Failed to review appeal", err)
 		return
 	}
 	
-	response.Success(c, http.StatusOK, "Appeal reviewed successfully", gin.H{
-		"message": "Appeal reviewed successfully",
-	})
+	result := gin.H{
+		"appeal_id": appealID,
+		"decision":  "rejected",
+		"message":   "Appeal rejected - original ban upheld",
+	}
+	if req.Approved {
+		result["decision"] = "approved"
+		result["action_taken"] = "lift_ban"
+		result["message"] = "Appeal approved - ban lifted"
+	}
+	response.Success(c, http.StatusOK, "Appeal reviewed successfully", result)
 }
 
 // GetModerationAnalytics handles GET /admin/analytics endpoint
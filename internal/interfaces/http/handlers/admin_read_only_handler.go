@@ -0,0 +1,59 @@
+package handlers
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/22smeargle/winkr-backend/internal/application/services"
+	"github.com/22smeargle/winkr-backend/pkg/logger"
+	"github.com/22smeargle/winkr-backend/pkg/utils"
+)
+
+// AdminReadOnlyHandler handles admin endpoints for the read-only mode
+type AdminReadOnlyHandler struct {
+	readOnlyMode *services.ReadOnlyModeService
+}
+
+// NewAdminReadOnlyHandler creates a new admin read-only mode handler
+func NewAdminReadOnlyHandler(readOnlyMode *services.ReadOnlyModeService) *AdminReadOnlyHandler {
+	return &AdminReadOnlyHandler{
+		readOnlyMode: readOnlyMode,
+	}
+}
+
+// SetReadOnlyModeRequest turns read-only mode on or off
+type SetReadOnlyModeRequest struct {
+	Enabled *bool  `json:"enabled" binding:"required"`
+	Reason  string `json:"reason" binding:"max=500"`
+}
+
+// GetReadOnlyMode handles getting the read-only mode state
+func (h *AdminReadOnlyHandler) GetReadOnlyMode(c *gin.Context) {
+	utils.Success(c, http.StatusOK, h.readOnlyMode.State(c.Request.Context()))
+}
+
+// SetReadOnlyMode handles turning read-only mode on or off
+func (h *AdminReadOnlyHandler) SetReadOnlyMode(c *gin.Context) {
+	adminID, exists := c.Get("admin_id")
+	if !exists {
+		utils.Unauthorized(c, "Admin authentication required")
+		return
+	}
+
+	var req SetReadOnlyModeRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		utils.ValidationError(c, err.Error())
+		return
+	}
+
+	state, err := h.readOnlyMode.SetReadOnly(c.Request.Context(), *req.Enabled, req.Reason, fmt.Sprint(adminID))
+	if err != nil {
+		logger.Error("Failed to set read-only mode", err, "admin_id", adminID)
+		utils.InternalServerError(c, "Failed to set read-only mode")
+		return
+	}
+
+	utils.Success(c, http.StatusOK, state)
+}
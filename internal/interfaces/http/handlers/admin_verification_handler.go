@@ -17,16 +17,19 @@ import (
 type AdminVerificationHandler struct {
 	getPendingVerificationsUseCase *verification.GetPendingVerificationsUseCase
 	processVerificationResultUseCase *verification.ProcessVerificationResultUseCase
+	overrideVerificationLevelUseCase *verification.OverrideVerificationLevelUseCase
 }
 
 // NewAdminVerificationHandler creates a new admin verification handler
 func NewAdminVerificationHandler(
 	getPendingVerificationsUseCase *verification.GetPendingVerificationsUseCase,
 	processVerificationResultUseCase *verification.ProcessVerificationResultUseCase,
+	overrideVerificationLevelUseCase *verification.OverrideVerificationLevelUseCase,
 ) *AdminVerificationHandler {
 	return &AdminVerificationHandler{
 		getPendingVerificationsUseCase: getPendingVerificationsUseCase,
 		processVerificationResultUseCase: processVerificationResultUseCase,
+		overrideVerificationLevelUseCase: overrideVerificationLevelUseCase,
 	}
 }
 
@@ -254,4 +257,48 @@ func (h *AdminVerificationHandler) GetVerificationDetails(c *gin.Context) {
 	}
 
 	utils.SuccessResponse(c, http.StatusOK, details)
-}
\ No newline at end of file
+}
+
+// OverrideVerificationLevel handles setting a user's verification level directly
+func (h *AdminVerificationHandler) OverrideVerificationLevel(c *gin.Context) {
+	var input verification.OverrideVerificationLevelInput
+	if err := c.ShouldBindJSON(&input); err != nil {
+		logger.Error("Failed to bind verification level override request", err)
+		utils.ErrorResponse(c, http.StatusBadRequest, "Invalid request format", err.Error())
+		return
+	}
+
+	userUUID, err := uuid.Parse(c.Param("user_id"))
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusBadRequest, "Invalid user ID", "")
+		return
+	}
+
+	// Get admin ID from context
+	adminID, exists := c.Get("admin_id")
+	if !exists {
+		logger.Error("Admin ID not found in context")
+		utils.ErrorResponse(c, http.StatusUnauthorized, "Admin not authenticated", "")
+		return
+	}
+
+	adminUUID, err := uuid.Parse(adminID.(string))
+	if err != nil {
+		logger.Error("Invalid admin ID in context", err)
+		utils.ErrorResponse(c, http.StatusUnauthorized, "Invalid admin ID", "")
+		return
+	}
+
+	input.UserID = userUUID
+	input.OverrideBy = adminUUID
+
+	// Execute use case
+	output, err := h.overrideVerificationLevelUseCase.Execute(c.Request.Context(), input)
+	if err != nil {
+		logger.Error("Failed to override verification level", err, "user_id", userUUID)
+		utils.ErrorResponse(c, http.StatusBadRequest, "Failed to override verification level", err.Error())
+		return
+	}
+
+	utils.SuccessResponse(c, http.StatusOK, output)
+}
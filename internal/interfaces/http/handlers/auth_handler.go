@@ -9,6 +9,7 @@ import (
 	"github.com/22smeargle/winkr-backend/internal/application/usecases/auth"
 	"github.com/22smeargle/winkr-backend/internal/application/dto"
 	"github.com/22smeargle/winkr-backend/internal/interfaces/http/middleware"
+	"github.com/22smeargle/winkr-backend/pkg/errors"
 	"github.com/22smeargle/winkr-backend/pkg/utils"
 	"github.com/22smeargle/winkr-backend/pkg/validator"
 )
@@ -24,6 +25,7 @@ type AuthHandler struct {
 	emailVerificationUseCase *auth.EmailVerificationUseCase
 	getProfileUseCase        *auth.GetProfileUseCase
 	getSessionsUseCase       *auth.GetSessionsUseCase
+	revokeSessionUseCase     *auth.RevokeSessionUseCase
 	jwtUtils                *utils.JWTUtils
 	authValidator            *validator.AuthValidator
 	rateLimiter             *middleware.AuthRateLimiter
@@ -41,6 +43,7 @@ func NewAuthHandler(
 	emailVerificationUseCase *auth.EmailVerificationUseCase,
 	getProfileUseCase *auth.GetProfileUseCase,
 	getSessionsUseCase *auth.GetSessionsUseCase,
+	revokeSessionUseCase *auth.RevokeSessionUseCase,
 	jwtUtils *utils.JWTUtils,
 	authValidator *validator.AuthValidator,
 	rateLimiter *middleware.AuthRateLimiter,
@@ -56,6 +59,7 @@ func NewAuthHandler(
 		emailVerificationUseCase: emailVerificationUseCase,
 		getProfileUseCase:        getProfileUseCase,
 		getSessionsUseCase:       getSessionsUseCase,
+		revokeSessionUseCase:     revokeSessionUseCase,
 		jwtUtils:                jwtUtils,
 		authValidator:            authValidator,
 		rateLimiter:             rateLimiter,
@@ -106,23 +110,37 @@ func (h *AuthHandler) Register(c *gin.Context) {
 	}
 
 	if err := h.authValidator.ValidateRegistrationRequest(validationReq); err != nil {
+		if weakErr, ok := errors.GetWeakPasswordError(err); ok {
+			utils.WeakPassword(c, weakErr)
+			return
+		}
 		utils.ValidationError(c, err.Error())
 		return
 	}
 
+	// Get client information
+	clientIP := c.ClientIP()
+	deviceInfo := h.jwtUtils.ParseDeviceInfo(c.GetHeader("User-Agent"), clientIP)
+
 	// Convert to use case request
 	useCaseReq := &auth.RegisterRequest{
-		Email:        req.Email,
-		Password:     req.Password,
-		FirstName:    req.FirstName,
-		LastName:     req.LastName,
-		DateOfBirth:  req.DateOfBirth,
-		Gender:       req.Gender,
-		InterestedIn: req.InterestedIn,
+		Email:             req.Email,
+		Password:          req.Password,
+		FirstName:         req.FirstName,
+		LastName:          req.LastName,
+		DateOfBirth:       req.DateOfBirth,
+		Gender:            req.Gender,
+		InterestedIn:      req.InterestedIn,
+		DeviceFingerprint: deviceInfo.Fingerprint,
+		IPAddress:         clientIP,
 	}
 
 	// Execute use case
 	response, err := h.registerUseCase.Execute(c.Request.Context(), useCaseReq)
+	if weakErr, ok := errors.GetWeakPasswordError(err); ok {
+		utils.WeakPassword(c, weakErr)
+		return
+	}
 	if err != nil {
 		utils.Error(c, err)
 		return
@@ -212,6 +230,10 @@ func (h *AuthHandler) Login(c *gin.Context) {
 
 	// Execute use case
 	response, err := h.loginUseCase.Execute(c.Request.Context(), useCaseReq)
+	if lockedErr, ok := errors.GetAccountLockedError(err); ok {
+		utils.AccountLocked(c, lockedErr)
+		return
+	}
 	if err != nil {
 		utils.Error(c, err)
 		return
@@ -573,6 +595,10 @@ func (h *AuthHandler) ConfirmPasswordReset(c *gin.Context) {
 	}
 
 	if err := h.authValidator.ValidateConfirmPasswordResetRequest(validationReq); err != nil {
+		if weakErr, ok := errors.GetWeakPasswordError(err); ok {
+			utils.WeakPassword(c, weakErr)
+			return
+		}
 		utils.ValidationError(c, err.Error())
 		return
 	}
@@ -591,6 +617,10 @@ func (h *AuthHandler) ConfirmPasswordReset(c *gin.Context) {
 
 	// Execute use case
 	response, err := h.confirmPasswordResetUseCase.Execute(c.Request.Context(), useCaseReq)
+	if weakErr, ok := errors.GetWeakPasswordError(err); ok {
+		utils.WeakPassword(c, weakErr)
+		return
+	}
 	if err != nil {
 		utils.Error(c, err)
 		return
@@ -796,9 +826,10 @@ func (h *AuthHandler) GetSessions(c *gin.Context) {
 
 	// Convert to use case request
 	useCaseReq := &auth.GetSessionsRequest{
-		UserID:    userID,
-		IPAddress: clientIP,
-		UserAgent: userAgent,
+		UserID:           userID,
+		CurrentSessionID: claims.SessionID,
+		IPAddress:        clientIP,
+		UserAgent:        userAgent,
 	}
 
 	// Execute use case
@@ -809,11 +840,92 @@ func (h *AuthHandler) GetSessions(c *gin.Context) {
 	}
 
 	// Convert to DTO
-	sessionsResponse := &dto.SessionsResponseDTO{
-		Success: response.Success,
-		Data:    response.Data,
-		Message: response.Message,
+	sessions := make([]*dto.SessionDTO, 0, len(response.Data))
+	for _, session := range response.Data {
+		sessions = append(sessions, &dto.SessionDTO{
+			ID: session.ID,
+			DeviceInfo: &dto.DeviceInfoDTO{
+				Platform: session.Platform,
+				Device:   session.DeviceType,
+				Browser:  session.Browser,
+			},
+			IPAddress:    session.IPAddress,
+			UserAgent:    session.UserAgent,
+			LastActivity: session.LastActive,
+			CreatedAt:    session.CreatedAt,
+			ExpiresAt:    session.ExpiresAt,
+			IsActive:     true,
+			IsCurrent:    session.IsCurrent,
+		})
+	}
+
+	utils.Success(c, dto.NewSessionsResponseDTO(sessions))
+}
+
+// RevokeSession handles revoking one of the user's sessions
+// @Summary Revoke a session
+// @Description Log out one of the current user's sessions. Its refresh token stops working immediately.
+// @Tags auth
+// @Accept json
+// @Produce json
+// @Param Authorization header string true "Bearer token"
+// @Param id path string true "Session ID"
+// @Success 200 {object} dto.RevokeSessionResponseDTO
+// @Failure 401 {object} dto.ErrorDTO
+// @Failure 404 {object} dto.ErrorDTO
+// @Failure 500 {object} dto.ErrorDTO
+// @Router /api/v1/auth/sessions/{id} [delete]
+func (h *AuthHandler) RevokeSession(c *gin.Context) {
+	// Apply rate limiting
+	h.rateLimiter.RateLimit("revoke-session")(c)
+	if c.IsAborted() {
+		return
+	}
+
+	// Extract token from header
+	authHeader := c.GetHeader("Authorization")
+	if authHeader == "" {
+		utils.Unauthorized(c, "Authorization header is required")
+		return
+	}
+
+	token, err := utils.ExtractTokenFromHeader(authHeader)
+	if err != nil {
+		utils.Unauthorized(c, "Invalid authorization header format")
+		return
+	}
+
+	// Validate token
+	claims, err := h.jwtUtils.ValidateToken(token)
+	if err != nil {
+		utils.Unauthorized(c, "Invalid token")
+		return
+	}
+
+	// Convert user ID string to UUID
+	userID, err := uuid.Parse(claims.UserID)
+	if err != nil {
+		utils.Unauthorized(c, "Invalid user ID in token")
+		return
+	}
+
+	// Convert to use case request
+	useCaseReq := &auth.RevokeSessionRequest{
+		UserID:           userID,
+		SessionID:        c.Param("id"),
+		CurrentSessionID: claims.SessionID,
+	}
+
+	// Execute use case
+	response, err := h.revokeSessionUseCase.Execute(c.Request.Context(), useCaseReq)
+	if err != nil {
+		utils.Error(c, err)
+		return
 	}
 
-	utils.Success(c, sessionsResponse)
+	utils.Success(c, &dto.RevokeSessionResponseDTO{
+		Success:   true,
+		Message:   response.Message,
+		IsCurrent: response.IsCurrent,
+	})
 }
\ No newline at end of file
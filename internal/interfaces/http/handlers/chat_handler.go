@@ -1,6 +1,7 @@
 package handlers
 
 import (
+	"math"
 	"net/http"
 	"strconv"
 	"time"
@@ -20,8 +21,15 @@ type ChatHandler struct {
 	getMessagesUseCase     *chat.GetMessagesUseCase
 	sendMessageUseCase    *chat.SendMessageUseCase
 	markReadUseCase       *chat.MarkMessagesReadUseCase
+	markAllReadUseCase    *chat.MarkAllReadUseCase
+	editMessageUseCase     *chat.EditMessageUseCase
 	deleteMessageUseCase   *chat.DeleteMessageUseCase
 	startConversationUseCase *chat.StartConversationUseCase
+	pinMessageUseCase     *chat.PinMessageUseCase
+	reactToMessageUseCase *chat.ReactToMessageUseCase
+	getPinnedMessagesUseCase *chat.GetPinnedMessagesUseCase
+	archiveConversationUseCase *chat.ArchiveConversationUseCase
+	scheduleMessageUseCase *chat.ScheduleMessageUseCase
 	sendEphemeralPhotoMessageUseCase *ephemeral_photo.SendEphemeralPhotoMessageUseCase
 	getEphemeralPhotoMessageUseCase *ephemeral_photo.GetEphemeralPhotoMessageUseCase
 	connManager           *websocket.ConnectionManager
@@ -33,8 +41,15 @@ func NewChatHandler(
 	getMessagesUseCase *chat.GetMessagesUseCase,
 	sendMessageUseCase *chat.SendMessageUseCase,
 	markReadUseCase *chat.MarkMessagesReadUseCase,
+	markAllReadUseCase *chat.MarkAllReadUseCase,
+	editMessageUseCase *chat.EditMessageUseCase,
 	deleteMessageUseCase *chat.DeleteMessageUseCase,
 	startConversationUseCase *chat.StartConversationUseCase,
+	pinMessageUseCase *chat.PinMessageUseCase,
+	reactToMessageUseCase *chat.ReactToMessageUseCase,
+	getPinnedMessagesUseCase *chat.GetPinnedMessagesUseCase,
+	archiveConversationUseCase *chat.ArchiveConversationUseCase,
+	scheduleMessageUseCase *chat.ScheduleMessageUseCase,
 	sendEphemeralPhotoMessageUseCase *ephemeral_photo.SendEphemeralPhotoMessageUseCase,
 	getEphemeralPhotoMessageUseCase *ephemeral_photo.GetEphemeralPhotoMessageUseCase,
 	connManager *websocket.ConnectionManager,
@@ -44,8 +59,15 @@ func NewChatHandler(
 		getMessagesUseCase:     getMessagesUseCase,
 		sendMessageUseCase:    sendMessageUseCase,
 		markReadUseCase:       markReadUseCase,
+		markAllReadUseCase:    markAllReadUseCase,
+		editMessageUseCase:     editMessageUseCase,
 		deleteMessageUseCase:   deleteMessageUseCase,
 		startConversationUseCase: startConversationUseCase,
+		pinMessageUseCase:     pinMessageUseCase,
+		reactToMessageUseCase: reactToMessageUseCase,
+		getPinnedMessagesUseCase: getPinnedMessagesUseCase,
+		archiveConversationUseCase: archiveConversationUseCase,
+		scheduleMessageUseCase: scheduleMessageUseCase,
 		sendEphemeralPhotoMessageUseCase: sendEphemeralPhotoMessageUseCase,
 		getEphemeralPhotoMessageUseCase: getEphemeralPhotoMessageUseCase,
 		connManager:           connManager,
@@ -142,8 +164,9 @@ func (h *ChatHandler) SendMessage(c *gin.Context) {
 
 	// Parse request body
 	var reqBody struct {
-		Content     string `json:"content" validate:"required"`
-		MessageType string `json:"message_type" validate:"required"`
+		Content         string `json:"content" validate:"required"`
+		MessageType     string `json:"message_type" validate:"required"`
+		ClientMessageID string `json:"client_message_id"`
 	}
 
 	if err := c.ShouldBindJSON(&reqBody); err != nil {
@@ -153,10 +176,11 @@ func (h *ChatHandler) SendMessage(c *gin.Context) {
 
 	// Create request
 	req := &chat.SendMessageRequest{
-		ConversationID: conversationID,
-		SenderID:       userID.(uuid.UUID),
-		Content:        reqBody.Content,
-		MessageType:    reqBody.MessageType,
+		ConversationID:  conversationID,
+		SenderID:        userID.(uuid.UUID),
+		Content:         reqBody.Content,
+		MessageType:     reqBody.MessageType,
+		ClientMessageID: reqBody.ClientMessageID,
 	}
 
 	// Execute use case
@@ -167,11 +191,41 @@ func (h *ChatHandler) SendMessage(c *gin.Context) {
 		return
 	}
 
+	if response.UpgradeRequired {
+		utils.ConversationLimitReached(c, response.Error)
+		return
+	}
+
+	switch response.ErrorCode {
+	case chat.ErrorCodeConversationNotFound:
+		utils.ConversationNotFound(c, response.Error)
+		return
+	case chat.ErrorCodeConversationClosed:
+		utils.ConversationClosed(c, response.Error)
+		return
+	case chat.ErrorCodeUserBlocked:
+		utils.UserBlocked(c, response.Error)
+		return
+	case chat.ErrorCodeRateLimited:
+		c.Header("Retry-After", strconv.FormatInt(int64(math.Ceil(response.RetryAfter.Seconds())), 10))
+		utils.RateLimitExceeded(c, response.Error)
+		return
+	case chat.ErrorCodeContentBlocked:
+		utils.ContentBlocked(c, response.Error, response.ModerationRule)
+		return
+	}
+
 	if !response.Success {
 		utils.ErrorResponse(c, http.StatusBadRequest, response.Error)
 		return
 	}
 
+	// A retry of a message already sent returns the original, which was broadcast when it was created
+	if response.Duplicate {
+		utils.SuccessResponse(c, http.StatusOK, response.Message)
+		return
+	}
+
 	// Broadcast message via WebSocket
 	if response.Message != nil {
 		wsMessage := websocket.Message{
@@ -209,7 +263,8 @@ func (h *ChatHandler) MarkMessagesAsRead(c *gin.Context) {
 
 	// Parse request body
 	var reqBody struct {
-		MessageIDs []string `json:"message_ids,omitempty"`
+		MessageIDs    []string `json:"message_ids,omitempty"`
+		UpToMessageID string   `json:"up_to_message_id,omitempty"`
 	}
 
 	if err := c.ShouldBindJSON(&reqBody); err != nil {
@@ -217,6 +272,16 @@ func (h *ChatHandler) MarkMessagesAsRead(c *gin.Context) {
 		return
 	}
 
+	var upToMessageID *uuid.UUID
+	if reqBody.UpToMessageID != "" {
+		id, err := uuid.Parse(reqBody.UpToMessageID)
+		if err != nil {
+			utils.ErrorResponse(c, http.StatusBadRequest, "Invalid up_to_message_id")
+			return
+		}
+		upToMessageID = &id
+	}
+
 	// Convert message IDs
 	messageIDs := make([]uuid.UUID, 0, len(reqBody.MessageIDs))
 	for _, idStr := range reqBody.MessageIDs {
@@ -230,6 +295,7 @@ func (h *ChatHandler) MarkMessagesAsRead(c *gin.Context) {
 		ConversationID: conversationID,
 		UserID:        userID.(uuid.UUID),
 		MessageIDs:    messageIDs,
+		UpToMessageID: upToMessageID,
 	}
 
 	// Execute use case
@@ -248,6 +314,31 @@ func (h *ChatHandler) MarkMessagesAsRead(c *gin.Context) {
 	utils.SuccessResponse(c, http.StatusOK, response)
 }
 
+// MarkAllAsRead handles POST /api/v1/chats/read-all
+func (h *ChatHandler) MarkAllAsRead(c *gin.Context) {
+	// Get user ID from context
+	userID, exists := c.Get("user_id")
+	if !exists {
+		utils.ErrorResponse(c, http.StatusUnauthorized, "User not authenticated")
+		return
+	}
+
+	// Execute use case
+	response, err := h.markAllReadUseCase.Execute(c.Request.Context(), userID.(uuid.UUID))
+	if err != nil {
+		logger.Error("Failed to mark all conversations as read", err)
+		utils.ErrorResponse(c, http.StatusInternalServerError, "Failed to mark all conversations as read")
+		return
+	}
+
+	if !response.Success {
+		utils.ErrorResponse(c, http.StatusBadRequest, response.Error)
+		return
+	}
+
+	utils.SuccessResponse(c, http.StatusOK, response)
+}
+
 // DeleteMessage handles DELETE /api/v1/chats/:id/messages/:messageId
 func (h *ChatHandler) DeleteMessage(c *gin.Context) {
 	// Get user ID from context
@@ -275,8 +366,9 @@ func (h *ChatHandler) DeleteMessage(c *gin.Context) {
 
 	// Create request
 	req := &chat.DeleteMessageRequest{
-		MessageID: messageID,
-		UserID:    userID.(uuid.UUID),
+		ConversationID: conversationID,
+		MessageID:      messageID,
+		UserID:         userID.(uuid.UUID),
 	}
 
 	// Execute use case
@@ -292,13 +384,16 @@ func (h *ChatHandler) DeleteMessage(c *gin.Context) {
 		return
 	}
 
-	// Broadcast delete event via WebSocket
+	// Broadcast delete event via WebSocket so both participants replace the message with its tombstone
 	wsMessage := websocket.Message{
-		Type:      "message:deleted",
+		Type: "message:deleted",
 		Data: map[string]interface{}{
-			"message_id": messageID.String(),
-			"user_id":    userID.(uuid.UUID).String(),
-			"timestamp":   time.Now(),
+			"message_id":      messageID.String(),
+			"conversation_id": conversationID.String(),
+			"user_id":         userID.(uuid.UUID).String(),
+			"content":         response.Message.Content,
+			"deleted_at":      response.Message.DeletedAt,
+			"timestamp":       time.Now(),
 		},
 		Timestamp: time.Now(),
 		SenderID:  userID.(uuid.UUID).String(),
@@ -312,6 +407,464 @@ func (h *ChatHandler) DeleteMessage(c *gin.Context) {
 	utils.SuccessResponse(c, http.StatusOK, response)
 }
 
+// EditMessage handles PUT /api/v1/chats/:id/messages/:messageId
+func (h *ChatHandler) EditMessage(c *gin.Context) {
+	// Get user ID from context
+	userID, exists := c.Get("user_id")
+	if !exists {
+		utils.ErrorResponse(c, http.StatusUnauthorized, "User not authenticated")
+		return
+	}
+
+	// Parse conversation ID from URL
+	conversationIDStr := c.Param("id")
+	conversationID, err := uuid.Parse(conversationIDStr)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusBadRequest, "Invalid conversation ID")
+		return
+	}
+
+	// Parse message ID from URL
+	messageIDStr := c.Param("messageId")
+	messageID, err := uuid.Parse(messageIDStr)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusBadRequest, "Invalid message ID")
+		return
+	}
+
+	// Parse request body
+	var reqBody struct {
+		Content string `json:"content" validate:"required"`
+	}
+
+	if err := c.ShouldBindJSON(&reqBody); err != nil {
+		utils.ErrorResponse(c, http.StatusBadRequest, "Invalid request body")
+		return
+	}
+
+	// Create request
+	req := &chat.EditMessageRequest{
+		ConversationID: conversationID,
+		MessageID:      messageID,
+		UserID:         userID.(uuid.UUID),
+		Content:        reqBody.Content,
+	}
+
+	// Execute use case
+	response, err := h.editMessageUseCase.Execute(c.Request.Context(), req)
+	if err != nil {
+		logger.Error("Failed to edit message", err)
+		utils.ErrorResponse(c, http.StatusInternalServerError, "Failed to edit message")
+		return
+	}
+
+	if !response.Success {
+		utils.ErrorResponse(c, http.StatusBadRequest, response.Error)
+		return
+	}
+
+	// Broadcast edit event via WebSocket so both participants show the new content
+	wsMessage := websocket.Message{
+		Type: "message:edited",
+		Data: map[string]interface{}{
+			"message_id":      messageID.String(),
+			"conversation_id": conversationID.String(),
+			"user_id":         userID.(uuid.UUID).String(),
+			"content":         response.Message.Content,
+			"edited_at":       response.Message.EditedAt,
+			"timestamp":       time.Now(),
+		},
+		Timestamp: time.Now(),
+		SenderID:  userID.(uuid.UUID).String(),
+	}
+
+	if err := h.connManager.BroadcastToConversation(conversationID.String(), wsMessage); err != nil {
+		logger.Error("Failed to broadcast edit event via WebSocket", err)
+		// Don't fail the request, just log the error
+	}
+
+	utils.SuccessResponse(c, http.StatusOK, response.Message)
+}
+
+// PinMessage handles POST /api/v1/chats/:id/messages/:messageId/pin
+func (h *ChatHandler) PinMessage(c *gin.Context) {
+	h.setMessagePinned(c, true)
+}
+
+// UnpinMessage handles POST /api/v1/chats/:id/messages/:messageId/unpin
+func (h *ChatHandler) UnpinMessage(c *gin.Context) {
+	h.setMessagePinned(c, false)
+}
+
+// setMessagePinned pins or unpins a message and broadcasts the change to the conversation
+func (h *ChatHandler) setMessagePinned(c *gin.Context, pin bool) {
+	// Get user ID from context
+	userID, exists := c.Get("user_id")
+	if !exists {
+		utils.ErrorResponse(c, http.StatusUnauthorized, "User not authenticated")
+		return
+	}
+
+	// Parse conversation ID from URL
+	conversationIDStr := c.Param("id")
+	conversationID, err := uuid.Parse(conversationIDStr)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusBadRequest, "Invalid conversation ID")
+		return
+	}
+
+	// Parse message ID from URL
+	messageIDStr := c.Param("messageId")
+	messageID, err := uuid.Parse(messageIDStr)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusBadRequest, "Invalid message ID")
+		return
+	}
+
+	// Create request
+	req := &chat.PinMessageRequest{
+		ConversationID: conversationID,
+		MessageID:      messageID,
+		UserID:         userID.(uuid.UUID),
+	}
+
+	// Execute use case
+	var response *chat.PinMessageResponse
+	eventType := "message:pinned"
+	if pin {
+		response, err = h.pinMessageUseCase.Pin(c.Request.Context(), req)
+	} else {
+		response, err = h.pinMessageUseCase.Unpin(c.Request.Context(), req)
+		eventType = "message:unpinned"
+	}
+	if err != nil {
+		logger.Error("Failed to update message pin", err)
+		utils.ErrorResponse(c, http.StatusInternalServerError, "Failed to update message pin")
+		return
+	}
+
+	if !response.Success {
+		utils.ErrorResponse(c, http.StatusBadRequest, response.Error)
+		return
+	}
+
+	// Broadcast pin event via WebSocket
+	wsMessage := websocket.Message{
+		Type: eventType,
+		Data: map[string]interface{}{
+			"message_id":      messageID.String(),
+			"conversation_id": conversationID.String(),
+			"user_id":         userID.(uuid.UUID).String(),
+			"timestamp":       time.Now(),
+		},
+		Timestamp: time.Now(),
+		SenderID:  userID.(uuid.UUID).String(),
+	}
+
+	if err := h.connManager.BroadcastToConversation(conversationID.String(), wsMessage); err != nil {
+		logger.Error("Failed to broadcast pin event via WebSocket", err)
+		// Don't fail the request, just log the error
+	}
+
+	utils.SuccessResponse(c, http.StatusOK, response.Message)
+}
+
+// ReactToMessage handles POST /api/v1/chats/:id/messages/:messageId/reactions
+func (h *ChatHandler) ReactToMessage(c *gin.Context) {
+	// Get user ID from context
+	userID, exists := c.Get("user_id")
+	if !exists {
+		utils.ErrorResponse(c, http.StatusUnauthorized, "User not authenticated")
+		return
+	}
+
+	// Parse conversation ID from URL
+	conversationIDStr := c.Param("id")
+	conversationID, err := uuid.Parse(conversationIDStr)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusBadRequest, "Invalid conversation ID")
+		return
+	}
+
+	// Parse message ID from URL
+	messageIDStr := c.Param("messageId")
+	messageID, err := uuid.Parse(messageIDStr)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusBadRequest, "Invalid message ID")
+		return
+	}
+
+	// Parse request body
+	var reqBody struct {
+		Emoji string `json:"emoji" validate:"required"`
+	}
+
+	if err := c.ShouldBindJSON(&reqBody); err != nil {
+		utils.ErrorResponse(c, http.StatusBadRequest, "Invalid request body")
+		return
+	}
+
+	// Create request
+	req := &chat.ReactToMessageRequest{
+		ConversationID: conversationID,
+		MessageID:      messageID,
+		UserID:         userID.(uuid.UUID),
+		Emoji:          reqBody.Emoji,
+	}
+
+	// Execute use case
+	response, err := h.reactToMessageUseCase.Execute(c.Request.Context(), req)
+	if err != nil {
+		logger.Error("Failed to react to message", err)
+		utils.ErrorResponse(c, http.StatusInternalServerError, "Failed to react to message")
+		return
+	}
+
+	if !response.Success {
+		utils.ErrorResponse(c, http.StatusBadRequest, response.Error)
+		return
+	}
+
+	// Broadcast reaction event via WebSocket so both participants update the counts
+	eventType := "reaction:added"
+	if !response.Added {
+		eventType = "reaction:removed"
+	}
+	wsMessage := websocket.Message{
+		Type: eventType,
+		Data: map[string]interface{}{
+			"message_id":      messageID.String(),
+			"conversation_id": conversationID.String(),
+			"user_id":         userID.(uuid.UUID).String(),
+			"emoji":           response.Emoji,
+			"reactions":       response.Reactions,
+			"timestamp":       time.Now(),
+		},
+		Timestamp: time.Now(),
+		SenderID:  userID.(uuid.UUID).String(),
+	}
+
+	if err := h.connManager.BroadcastToConversation(conversationID.String(), wsMessage); err != nil {
+		logger.Error("Failed to broadcast reaction event via WebSocket", err)
+		// Don't fail the request, just log the error
+	}
+
+	utils.SuccessResponse(c, http.StatusOK, response)
+}
+
+// ArchiveConversation handles POST /api/v1/chats/:id/archive
+func (h *ChatHandler) ArchiveConversation(c *gin.Context) {
+	h.setConversationArchived(c, true)
+}
+
+// UnarchiveConversation handles DELETE /api/v1/chats/:id/archive
+func (h *ChatHandler) UnarchiveConversation(c *gin.Context) {
+	h.setConversationArchived(c, false)
+}
+
+// setConversationArchived archives or unarchives a conversation for the current user
+func (h *ChatHandler) setConversationArchived(c *gin.Context, archive bool) {
+	// Get user ID from context
+	userID, exists := c.Get("user_id")
+	if !exists {
+		utils.ErrorResponse(c, http.StatusUnauthorized, "User not authenticated")
+		return
+	}
+
+	// Parse conversation ID from URL
+	conversationIDStr := c.Param("id")
+	conversationID, err := uuid.Parse(conversationIDStr)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusBadRequest, "Invalid conversation ID")
+		return
+	}
+
+	// Create request
+	req := &chat.ArchiveConversationRequest{
+		ConversationID: conversationID,
+		UserID:         userID.(uuid.UUID),
+	}
+
+	// Execute use case
+	var response *chat.ArchiveConversationResponse
+	if archive {
+		response, err = h.archiveConversationUseCase.Archive(c.Request.Context(), req)
+	} else {
+		response, err = h.archiveConversationUseCase.Unarchive(c.Request.Context(), req)
+	}
+	if err != nil {
+		logger.Error("Failed to update conversation archive", err)
+		utils.ErrorResponse(c, http.StatusInternalServerError, "Failed to update conversation archive")
+		return
+	}
+
+	if response.UpgradeRequired {
+		utils.ConversationLimitReached(c, response.Error)
+		return
+	}
+
+	if !response.Success {
+		utils.ErrorResponse(c, http.StatusBadRequest, response.Error)
+		return
+	}
+
+	utils.SuccessResponse(c, http.StatusOK, response)
+}
+
+// ScheduleMessage handles POST /api/v1/chats/:id/schedule
+func (h *ChatHandler) ScheduleMessage(c *gin.Context) {
+	// Get user ID from context
+	userID, exists := c.Get("user_id")
+	if !exists {
+		utils.ErrorResponse(c, http.StatusUnauthorized, "User not authenticated")
+		return
+	}
+
+	// Parse conversation ID from URL
+	conversationIDStr := c.Param("id")
+	conversationID, err := uuid.Parse(conversationIDStr)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusBadRequest, "Invalid conversation ID")
+		return
+	}
+
+	// Parse request body
+	var reqBody struct {
+		Content string    `json:"content" validate:"required"`
+		SendAt  time.Time `json:"send_at" validate:"required"`
+	}
+
+	if err := c.ShouldBindJSON(&reqBody); err != nil {
+		utils.ErrorResponse(c, http.StatusBadRequest, "Invalid request body")
+		return
+	}
+
+	// Create request
+	req := &chat.ScheduleMessageRequest{
+		ConversationID: conversationID,
+		SenderID:       userID.(uuid.UUID),
+		Content:        reqBody.Content,
+		SendAt:         reqBody.SendAt,
+	}
+
+	// Execute use case
+	response, err := h.scheduleMessageUseCase.Schedule(c.Request.Context(), req)
+	if err != nil {
+		logger.Error("Failed to schedule message", err)
+		utils.ErrorResponse(c, http.StatusInternalServerError, "Failed to schedule message")
+		return
+	}
+
+	if h.scheduledMessageFailed(c, response) {
+		return
+	}
+
+	utils.SuccessResponse(c, http.StatusCreated, response.ScheduledMessage)
+}
+
+// CancelScheduledMessage handles DELETE /api/v1/chats/:id/schedule/:scheduledId
+func (h *ChatHandler) CancelScheduledMessage(c *gin.Context) {
+	// Get user ID from context
+	userID, exists := c.Get("user_id")
+	if !exists {
+		utils.ErrorResponse(c, http.StatusUnauthorized, "User not authenticated")
+		return
+	}
+
+	// Parse conversation ID from URL
+	conversationIDStr := c.Param("id")
+	conversationID, err := uuid.Parse(conversationIDStr)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusBadRequest, "Invalid conversation ID")
+		return
+	}
+
+	// Parse scheduled message ID from URL
+	scheduledIDStr := c.Param("scheduledId")
+	scheduledID, err := uuid.Parse(scheduledIDStr)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusBadRequest, "Invalid scheduled message ID")
+		return
+	}
+
+	// Create request
+	req := &chat.CancelScheduledMessageRequest{
+		ConversationID:     conversationID,
+		ScheduledMessageID: scheduledID,
+		UserID:             userID.(uuid.UUID),
+	}
+
+	// Execute use case
+	response, err := h.scheduleMessageUseCase.Cancel(c.Request.Context(), req)
+	if err != nil {
+		logger.Error("Failed to cancel scheduled message", err)
+		utils.ErrorResponse(c, http.StatusInternalServerError, "Failed to cancel scheduled message")
+		return
+	}
+
+	if h.scheduledMessageFailed(c, response) {
+		return
+	}
+
+	utils.SuccessResponse(c, http.StatusOK, response.ScheduledMessage)
+}
+
+// scheduledMessageFailed writes the error response of a failed schedule or cancel and reports whether it did
+func (h *ChatHandler) scheduledMessageFailed(c *gin.Context, response *chat.ScheduleMessageResponse) bool {
+	if response.Success {
+		return false
+	}
+
+	switch response.ErrorCode {
+	case chat.ErrorCodeConversationNotFound:
+		utils.ConversationNotFound(c, response.Error)
+	case chat.ErrorCodeConversationClosed:
+		utils.ConversationClosed(c, response.Error)
+	case chat.ErrorCodeScheduledMessageNotFound:
+		utils.ErrorResponse(c, http.StatusNotFound, response.Error)
+	case chat.ErrorCodeScheduledMessageNotCancelable:
+		utils.ErrorResponse(c, http.StatusConflict, response.Error)
+	default:
+		utils.ErrorResponse(c, http.StatusBadRequest, response.Error)
+	}
+
+	return true
+}
+
+// GetPinnedMessages handles GET /api/v1/chats/:id/pins
+func (h *ChatHandler) GetPinnedMessages(c *gin.Context) {
+	// Get user ID from context
+	userID, exists := c.Get("user_id")
+	if !exists {
+		utils.ErrorResponse(c, http.StatusUnauthorized, "User not authenticated")
+		return
+	}
+
+	// Parse conversation ID from URL
+	conversationIDStr := c.Param("id")
+	conversationID, err := uuid.Parse(conversationIDStr)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusBadRequest, "Invalid conversation ID")
+		return
+	}
+
+	// Create request
+	req := &chat.GetPinnedMessagesRequest{
+		ConversationID: conversationID,
+		UserID:         userID.(uuid.UUID),
+	}
+
+	// Execute use case
+	response, err := h.getPinnedMessagesUseCase.Execute(c.Request.Context(), req)
+	if err != nil {
+		logger.Error("Failed to get pinned messages", err)
+		utils.ErrorResponse(c, http.StatusInternalServerError, "Failed to get pinned messages")
+		return
+	}
+
+	utils.SuccessResponse(c, http.StatusOK, response)
+}
+
 // StartConversation handles POST /api/v1/chats/start
 func (h *ChatHandler) StartConversation(c *gin.Context) {
 	// Get user ID from context
@@ -354,6 +907,11 @@ func (h *ChatHandler) StartConversation(c *gin.Context) {
 		return
 	}
 
+	if response.UpgradeRequired {
+		utils.ConversationLimitReached(c, response.Error)
+		return
+	}
+
 	if response.Error != "" {
 		utils.ErrorResponse(c, http.StatusBadRequest, response.Error)
 		return
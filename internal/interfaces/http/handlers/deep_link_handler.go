@@ -0,0 +1,53 @@
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+
+	"github.com/22smeargle/winkr-backend/internal/application/services"
+	"github.com/22smeargle/winkr-backend/pkg/utils"
+)
+
+// DeepLinkHandler handles deep link HTTP requests
+type DeepLinkHandler struct {
+	builder *services.DeepLinkBuilder
+}
+
+// NewDeepLinkHandler creates a new deep link handler
+func NewDeepLinkHandler(builder *services.DeepLinkBuilder) *DeepLinkHandler {
+	return &DeepLinkHandler{
+		builder: builder,
+	}
+}
+
+// ResolveDeepLink handles GET /deep-links/resolve and returns the target of a deep link
+// once its token is checked, so the app can navigate to it
+func (h *DeepLinkHandler) ResolveDeepLink(c *gin.Context) {
+	userIDStr, exists := c.Get("user_id")
+	if !exists {
+		utils.Unauthorized(c, "User not authenticated")
+		return
+	}
+
+	userID, err := uuid.Parse(userIDStr.(string))
+	if err != nil {
+		utils.Unauthorized(c, "Invalid user ID")
+		return
+	}
+
+	token := c.Query("token")
+	if token == "" {
+		utils.BadRequest(c, "token is required")
+		return
+	}
+
+	link, err := h.builder.Resolve(c.Request.Context(), userID, token)
+	if err != nil {
+		utils.Error(c, err)
+		return
+	}
+
+	utils.Success(c, http.StatusOK, link)
+}
@@ -1,8 +1,11 @@
 package handlers
 
 import (
+	"fmt"
+	"math"
 	"net/http"
 	"strconv"
+	"strings"
 
 	"github.com/gin-gonic/gin"
 	"github.com/google/uuid"
@@ -10,6 +13,8 @@ import (
 	"github.com/22smeargle/winkr-backend/internal/application/dto"
 	"github.com/22smeargle/winkr-backend/internal/application/usecases/matching"
 	"github.com/22smeargle/winkr-backend/internal/application/services"
+	"github.com/22smeargle/winkr-backend/pkg/config"
+	"github.com/22smeargle/winkr-backend/pkg/errors"
 	"github.com/22smeargle/winkr-backend/pkg/utils"
 )
 
@@ -19,8 +24,16 @@ type DiscoveryHandler struct {
 	likeUserUseCase       *matching.LikeUserUseCase
 	dislikeUserUseCase    *matching.DislikeUserUseCase
 	superLikeUserUseCase   *matching.SuperLikeUserUseCase
+	undoLastSwipeUseCase   *matching.UndoLastSwipeUseCase
+	boostUseCase           *matching.BoostUseCase
 	getMatchesUseCase      *matching.GetMatchesUseCase
+	getMatchCompatibilityUseCase *matching.GetMatchCompatibilityUseCase
+	unmatchUseCase         *matching.UnmatchUseCase
+	batchSwipeUseCase      *matching.BatchSwipeUseCase
+	getLikesReceivedUseCase *matching.GetLikesReceivedUseCase
 	getDiscoveryStatsUseCase *matching.GetDiscoveryStatsUseCase
+	discoveryThrottle      *services.DiscoveryThrottleService
+	pageSizes              *config.MatchingPageSizeConfig
 }
 
 // NewDiscoveryHandler creates a new DiscoveryHandler
@@ -29,16 +42,32 @@ func NewDiscoveryHandler(
 	likeUserUseCase *matching.LikeUserUseCase,
 	dislikeUserUseCase *matching.DislikeUserUseCase,
 	superLikeUserUseCase *matching.SuperLikeUserUseCase,
+	undoLastSwipeUseCase *matching.UndoLastSwipeUseCase,
+	boostUseCase *matching.BoostUseCase,
 	getMatchesUseCase *matching.GetMatchesUseCase,
+	getMatchCompatibilityUseCase *matching.GetMatchCompatibilityUseCase,
+	unmatchUseCase *matching.UnmatchUseCase,
+	batchSwipeUseCase *matching.BatchSwipeUseCase,
+	getLikesReceivedUseCase *matching.GetLikesReceivedUseCase,
 	getDiscoveryStatsUseCase *matching.GetDiscoveryStatsUseCase,
+	discoveryThrottle *services.DiscoveryThrottleService,
+	pageSizes *config.MatchingPageSizeConfig,
 ) *DiscoveryHandler {
 	return &DiscoveryHandler{
 		discoverUsersUseCase:   discoverUsersUseCase,
 		likeUserUseCase:       likeUserUseCase,
 		dislikeUserUseCase:    dislikeUserUseCase,
 		superLikeUserUseCase:   superLikeUserUseCase,
+		undoLastSwipeUseCase:   undoLastSwipeUseCase,
+		boostUseCase:           boostUseCase,
 		getMatchesUseCase:      getMatchesUseCase,
+		getMatchCompatibilityUseCase: getMatchCompatibilityUseCase,
+		unmatchUseCase:         unmatchUseCase,
+		batchSwipeUseCase:      batchSwipeUseCase,
+		getLikesReceivedUseCase: getLikesReceivedUseCase,
 		getDiscoveryStatsUseCase: getDiscoveryStatsUseCase,
+		discoveryThrottle:      discoveryThrottle,
+		pageSizes:              pageSizes,
 	}
 }
 
@@ -49,7 +78,8 @@ func NewDiscoveryHandler(
 // @Accept json
 // @Produce json
 // @Param user_id path string true "User ID"
-// @Param limit query int false "Number of results to return" default(10) minimum(1) maximum(100)
+// @Param limit query int false "Number of results to return; defaults to and is capped by the platform's configured page size" minimum(1)
+// @Param platform query string false "Client platform (web, ios, android); overrides the platform header"
 // @Param offset query int false "Number of results to skip" default(0) minimum(0)
 // @Param age_min query int false "Minimum age filter"
 // @Param age_max query int false "Maximum age filter"
@@ -57,9 +87,19 @@ func NewDiscoveryHandler(
 // @Param gender query string false "Gender filter"
 // @Param verified query bool false "Filter by verification status"
 // @Param has_photos query bool false "Filter by users with photos"
+// @Param min_height query int false "Minimum height in centimeters" minimum(120) maximum(230)
+// @Param max_height query int false "Maximum height in centimeters" minimum(120) maximum(230)
+// @Param smoking query string false "Comma-separated smoking answers to include (never, socially, regularly); premium only by default"
+// @Param drinking query string false "Comma-separated drinking answers to include (never, socially, regularly); premium only by default"
+// @Param lat query number false "Latitude to browse from instead of the stored location; premium only, requires lng" minimum(-90) maximum(90)
+// @Param lng query number false "Longitude to browse from instead of the stored location; premium only, requires lat" minimum(-180) maximum(180)
+// @Param active_within query string false "Only show users active within this window" Enums(24h, 7d, 30d)
+// @Param debug query bool false "Return each user's ranking score breakdown; only where matching.scoring.debug_enabled is set"
 // @Success 200 {object} dto.DiscoverUsersResponse
 // @Failure 400 {object} utils.ErrorResponse
 // @Failure 401 {object} utils.ErrorResponse
+// @Failure 402 {object} utils.ErrorResponse
+// @Failure 403 {object} utils.ErrorResponse
 // @Failure 429 {object} utils.ErrorResponse
 // @Failure 500 {object} utils.ErrorResponse
 // @Router /api/v1/discover [get]
@@ -77,16 +117,29 @@ func (h *DiscoveryHandler) DiscoverUsers(c *gin.Context) {
 		return
 	}
 
+	// Slow down clients that request discovery far more often than a person would
+	var degraded bool
+	if h.discoveryThrottle != nil {
+		decision := h.discoveryThrottle.Check(c.Request.Context(), userID, c.ClientIP())
+		if !decision.Allowed {
+			c.Header("Retry-After", strconv.FormatInt(int64(math.Ceil(decision.RetryAfter.Seconds())), 10))
+			utils.ErrorResponse(c, http.StatusTooManyRequests, "Too many discovery requests, please try again later")
+			return
+		}
+		degraded = decision.Degraded
+	}
+
+	// Parse limit, applying the client platform's default and maximum
+	limit, err := discoveryPageSize(c, h.pageSizes)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusBadRequest, err.Error())
+		return
+	}
+
 	// Parse query parameters
 	req := &matching.DiscoverUsersRequest{
 		UserID: userID,
-	}
-
-	// Parse limit
-	if limitStr := c.Query("limit"); limitStr != "" {
-		if limit, err := strconv.Atoi(limitStr); err == nil {
-			req.Limit = limit
-		}
+		Limit:  limit,
 	}
 
 	// Parse offset
@@ -135,13 +188,78 @@ func (h *DiscoveryHandler) DiscoverUsers(c *gin.Context) {
 		}
 	}
 
+	// Parse height filters, in centimeters
+	if minHeightStr := c.Query("min_height"); minHeightStr != "" {
+		minHeight, err := strconv.Atoi(minHeightStr)
+		if err != nil {
+			utils.ErrorResponse(c, http.StatusBadRequest, "Invalid min_height")
+			return
+		}
+		req.MinHeight = &minHeight
+	}
+
+	if maxHeightStr := c.Query("max_height"); maxHeightStr != "" {
+		maxHeight, err := strconv.Atoi(maxHeightStr)
+		if err != nil {
+			utils.ErrorResponse(c, http.StatusBadRequest, "Invalid max_height")
+			return
+		}
+		req.MaxHeight = &maxHeight
+	}
+
+	// Parse lifestyle filters, given as comma-separated or repeated values
+	req.Smoking = queryList(c, "smoking")
+	req.Drinking = queryList(c, "drinking")
+
+	// Parse the passport location to browse from
+	if latStr := c.Query("lat"); latStr != "" {
+		lat, err := strconv.ParseFloat(latStr, 64)
+		if err != nil {
+			utils.ErrorResponse(c, http.StatusBadRequest, "Invalid lat")
+			return
+		}
+		req.Lat = &lat
+	}
+
+	if lngStr := c.Query("lng"); lngStr != "" {
+		lng, err := strconv.ParseFloat(lngStr, 64)
+		if err != nil {
+			utils.ErrorResponse(c, http.StatusBadRequest, "Invalid lng")
+			return
+		}
+		req.Lng = &lng
+	}
+
+	// Parse the activity window, checked by the use case
+	req.ActiveWithin = c.Query("active_within")
+
+	// Parse the debug flag, allowed by the use case only where enabled
+	if debugStr := c.Query("debug"); debugStr != "" {
+		debug, err := strconv.ParseBool(debugStr)
+		if err != nil {
+			utils.ErrorResponse(c, http.StatusBadRequest, "Invalid debug")
+			return
+		}
+		req.Debug = debug
+	}
+
 	// Execute use case
 	response, err := h.discoverUsersUseCase.Execute(c.Request.Context(), req)
 	if err != nil {
+		// Filter values or a passport location that are not allowed, or need a premium plan
+		if appErr, ok := err.(*errors.AppError); ok {
+			utils.Error(c, appErr)
+			return
+		}
 		utils.ErrorResponse(c, http.StatusInternalServerError, err.Error())
 		return
 	}
 
+	if degraded {
+		response.Users = h.discoveryThrottle.Degrade(response.Users)
+		response.Limited = true
+	}
+
 	utils.SuccessResponse(c, http.StatusOK, response)
 }
 
@@ -267,7 +385,7 @@ func (h *DiscoveryHandler) DislikeUser(c *gin.Context) {
 // @Success 200 {object} matching.SuperLikeUserResponse
 // @Failure 400 {object} utils.ErrorResponse
 // @Failure 401 {object} utils.ErrorResponse
-// @Failure 402 {object} utils.ErrorResponse
+// @Failure 403 {object} utils.ErrorResponse
 // @Failure 404 {object} utils.ErrorResponse
 // @Failure 429 {object} utils.ErrorResponse
 // @Failure 500 {object} utils.ErrorResponse
@@ -307,12 +425,154 @@ func (h *DiscoveryHandler) SuperLikeUser(c *gin.Context) {
 			utils.ErrorResponse(c, http.StatusConflict, err.Error())
 			return
 		}
-		if err.Error() == "super like requires premium subscription" {
-			utils.ErrorResponse(c, http.StatusPaymentRequired, err.Error())
+		// Free users and used up super like quotas
+		if appErr, ok := err.(*errors.AppError); ok {
+			utils.Error(c, appErr)
+			return
+		}
+		utils.ErrorResponse(c, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	utils.SuccessResponse(c, http.StatusOK, response)
+}
+
+// BatchSwipe handles POST /swipes/batch
+// @Summary Submit swipes queued offline
+// @Description Submit up to the configured number of swipes at once, e.g. swipes queued while the device was offline. Each swipe gets a result: created, duplicate if the user already swiped on the target, or error with the reason. Matches made by the batch are returned as well.
+// @Tags discovery
+// @Accept json
+// @Produce json
+// @Param request body matching.BatchSwipeRequest true "Swipes to submit"
+// @Success 200 {object} matching.BatchSwipeResponse
+// @Failure 400 {object} utils.ErrorResponse
+// @Failure 401 {object} utils.ErrorResponse
+// @Failure 413 {object} utils.ErrorResponse
+// @Failure 429 {object} utils.ErrorResponse
+// @Failure 500 {object} utils.ErrorResponse
+// @Router /api/v1/swipes/batch [post]
+func (h *DiscoveryHandler) BatchSwipe(c *gin.Context) {
+	// Get user ID from context
+	userIDStr, exists := c.Get("user_id")
+	if !exists {
+		utils.ErrorResponse(c, http.StatusUnauthorized, "User not authenticated")
+		return
+	}
+
+	swiperID, err := uuid.Parse(userIDStr.(string))
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusBadRequest, "Invalid user ID")
+		return
+	}
+
+	// Parse request body
+	var reqBody struct {
+		Swipes []matching.BatchSwipeItem `json:"swipes" binding:"required"`
+	}
+	if err := c.ShouldBindJSON(&reqBody); err != nil {
+		utils.ErrorResponse(c, http.StatusBadRequest, "Invalid request body")
+		return
+	}
+
+	// Execute use case
+	response, err := h.batchSwipeUseCase.Execute(c.Request.Context(), &matching.BatchSwipeRequest{
+		SwiperID: swiperID,
+		Swipes:   reqBody.Swipes,
+	})
+	if err != nil {
+		// Empty and oversized batches
+		if appErr, ok := err.(*errors.AppError); ok {
+			utils.Error(c, appErr)
+			return
+		}
+		utils.ErrorResponse(c, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	utils.SuccessResponse(c, http.StatusOK, response)
+}
+
+// UndoLastSwipe handles POST /undo
+// @Summary Undo the last swipe
+// @Description Take back the most recent swipe (premium feature) and get the user's card back
+// @Tags discovery
+// @Accept json
+// @Produce json
+// @Success 200 {object} matching.UndoLastSwipeResponse
+// @Failure 400 {object} utils.ErrorResponse
+// @Failure 401 {object} utils.ErrorResponse
+// @Failure 403 {object} utils.ErrorResponse
+// @Failure 409 {object} utils.ErrorResponse
+// @Failure 429 {object} utils.ErrorResponse
+// @Failure 500 {object} utils.ErrorResponse
+// @Router /api/v1/undo [post]
+func (h *DiscoveryHandler) UndoLastSwipe(c *gin.Context) {
+	// Get user ID from context
+	userIDStr, exists := c.Get("user_id")
+	if !exists {
+		utils.ErrorResponse(c, http.StatusUnauthorized, "User not authenticated")
+		return
+	}
+
+	userID, err := uuid.Parse(userIDStr.(string))
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusBadRequest, "Invalid user ID")
+		return
+	}
+
+	// Execute use case
+	response, err := h.undoLastSwipeUseCase.Execute(c.Request.Context(), &matching.UndoLastSwipeRequest{
+		UserID: userID,
+	})
+	if err != nil {
+		// Free users, nothing to undo, or the daily limit reached
+		if appErr, ok := err.(*errors.AppError); ok {
+			utils.Error(c, appErr)
 			return
 		}
-		if err.Error() == "daily super like limit exceeded" {
-			utils.ErrorResponse(c, http.StatusTooManyRequests, err.Error())
+		utils.ErrorResponse(c, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	utils.SuccessResponse(c, http.StatusOK, response)
+}
+
+// Boost handles POST /boost
+// @Summary Boost the user's profile
+// @Description Show the user first in others' discovery feeds for a while (premium feature). While a boost runs it is returned with its remaining time.
+// @Tags discovery
+// @Accept json
+// @Produce json
+// @Success 200 {object} matching.BoostResponse
+// @Failure 400 {object} utils.ErrorResponse
+// @Failure 401 {object} utils.ErrorResponse
+// @Failure 403 {object} utils.ErrorResponse
+// @Failure 429 {object} utils.ErrorResponse
+// @Failure 500 {object} utils.ErrorResponse
+// @Failure 503 {object} utils.ErrorResponse
+// @Router /api/v1/boost [post]
+func (h *DiscoveryHandler) Boost(c *gin.Context) {
+	// Get user ID from context
+	userIDStr, exists := c.Get("user_id")
+	if !exists {
+		utils.ErrorResponse(c, http.StatusUnauthorized, "User not authenticated")
+		return
+	}
+
+	userID, err := uuid.Parse(userIDStr.(string))
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusBadRequest, "Invalid user ID")
+		return
+	}
+
+	// Execute use case
+	response, err := h.boostUseCase.Execute(c.Request.Context(), &matching.BoostRequest{
+		UserID: userID,
+	})
+	if err != nil {
+		// Free users, boosts disabled, or the daily limit reached
+		if appErr, ok := err.(*errors.AppError); ok {
+			utils.Error(c, appErr)
 			return
 		}
 		utils.ErrorResponse(c, http.StatusInternalServerError, err.Error())
@@ -386,6 +646,174 @@ func (h *DiscoveryHandler) GetMatches(c *gin.Context) {
 	utils.SuccessResponse(c, http.StatusOK, response)
 }
 
+// GetMatchCompatibility handles GET /matches/:id/compatibility
+// @Summary Get the compatibility breakdown of a match
+// @Description Break down how well the two users of a match fit: shared interests, distance and age proximity. Distance and age proximity are left out when either user hides them from their profile. Only the users of the match can see it.
+// @Tags discovery
+// @Accept json
+// @Produce json
+// @Param id path string true "Match ID"
+// @Success 200 {object} dto.MatchCompatibility
+// @Failure 400 {object} utils.ErrorResponse
+// @Failure 401 {object} utils.ErrorResponse
+// @Failure 403 {object} utils.ErrorResponse
+// @Failure 404 {object} utils.ErrorResponse
+// @Failure 500 {object} utils.ErrorResponse
+// @Router /api/v1/matches/{id}/compatibility [get]
+func (h *DiscoveryHandler) GetMatchCompatibility(c *gin.Context) {
+	// Get user ID from context
+	userIDStr, exists := c.Get("user_id")
+	if !exists {
+		utils.ErrorResponse(c, http.StatusUnauthorized, "User not authenticated")
+		return
+	}
+
+	userID, err := uuid.Parse(userIDStr.(string))
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusBadRequest, "Invalid user ID")
+		return
+	}
+
+	// Get match ID from path
+	matchID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusBadRequest, "Invalid match ID")
+		return
+	}
+
+	// Execute use case
+	response, err := h.getMatchCompatibilityUseCase.Execute(c.Request.Context(), &matching.GetMatchCompatibilityRequest{
+		UserID:  userID,
+		MatchID: matchID,
+	})
+	if err != nil {
+		// Unknown or inactive matches, and users outside the match
+		if appErr, ok := err.(*errors.AppError); ok {
+			utils.Error(c, appErr)
+			return
+		}
+		utils.ErrorResponse(c, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	utils.SuccessResponse(c, http.StatusOK, response)
+}
+
+// Unmatch handles DELETE /matches/:id
+// @Summary Unmatch
+// @Description End a match. Its conversation is closed and archived for both users, so neither can send further messages. Unmatching a match that already ended has no effect. Only the users of the match can unmatch.
+// @Tags discovery
+// @Accept json
+// @Produce json
+// @Param id path string true "Match ID"
+// @Success 200 {object} matching.UnmatchResponse
+// @Failure 400 {object} utils.ErrorResponse
+// @Failure 401 {object} utils.ErrorResponse
+// @Failure 403 {object} utils.ErrorResponse
+// @Failure 404 {object} utils.ErrorResponse
+// @Failure 500 {object} utils.ErrorResponse
+// @Router /api/v1/matches/{id} [delete]
+func (h *DiscoveryHandler) Unmatch(c *gin.Context) {
+	// Get user ID from context
+	userIDStr, exists := c.Get("user_id")
+	if !exists {
+		utils.ErrorResponse(c, http.StatusUnauthorized, "User not authenticated")
+		return
+	}
+
+	userID, err := uuid.Parse(userIDStr.(string))
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusBadRequest, "Invalid user ID")
+		return
+	}
+
+	// Get match ID from path
+	matchID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusBadRequest, "Invalid match ID")
+		return
+	}
+
+	// Execute use case
+	response, err := h.unmatchUseCase.Execute(c.Request.Context(), &matching.UnmatchRequest{
+		UserID:  userID,
+		MatchID: matchID,
+	})
+	if err != nil {
+		// Unknown matches and users outside the match
+		if appErr, ok := err.(*errors.AppError); ok {
+			utils.Error(c, appErr)
+			return
+		}
+		utils.ErrorResponse(c, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	utils.SuccessResponse(c, http.StatusOK, response)
+}
+
+// GetLikesReceived handles GET /likes/received
+// @Summary Get who liked the user
+// @Description List the users who liked the user and are waiting for an answer, newest first (premium feature). Users the user already swiped on or matched with are left out.
+// @Tags discovery
+// @Accept json
+// @Produce json
+// @Param limit query int false "Number of results to return" default(20) minimum(1) maximum(100)
+// @Param offset query int false "Number of results to skip" default(0) minimum(0)
+// @Success 200 {object} matching.GetLikesReceivedResponse
+// @Failure 400 {object} utils.ErrorResponse
+// @Failure 401 {object} utils.ErrorResponse
+// @Failure 403 {object} utils.ErrorResponse
+// @Failure 500 {object} utils.ErrorResponse
+// @Router /api/v1/likes/received [get]
+func (h *DiscoveryHandler) GetLikesReceived(c *gin.Context) {
+	// Get user ID from context
+	userIDStr, exists := c.Get("user_id")
+	if !exists {
+		utils.ErrorResponse(c, http.StatusUnauthorized, "User not authenticated")
+		return
+	}
+
+	userID, err := uuid.Parse(userIDStr.(string))
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusBadRequest, "Invalid user ID")
+		return
+	}
+
+	// Parse query parameters
+	req := &matching.GetLikesReceivedRequest{
+		UserID: userID,
+	}
+
+	// Parse limit
+	if limitStr := c.Query("limit"); limitStr != "" {
+		if limit, err := strconv.Atoi(limitStr); err == nil {
+			req.Limit = limit
+		}
+	}
+
+	// Parse offset
+	if offsetStr := c.Query("offset"); offsetStr != "" {
+		if offset, err := strconv.Atoi(offsetStr); err == nil {
+			req.Offset = offset
+		}
+	}
+
+	// Execute use case
+	response, err := h.getLikesReceivedUseCase.Execute(c.Request.Context(), req)
+	if err != nil {
+		// Free users
+		if appErr, ok := err.(*errors.AppError); ok {
+			utils.Error(c, appErr)
+			return
+		}
+		utils.ErrorResponse(c, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	utils.SuccessResponse(c, http.StatusOK, response)
+}
+
 // GetDiscoveryStats handles GET /discover/stats
 // @Summary Get discovery statistics
 // @Description Get user's discovery and matching statistics
@@ -424,4 +852,61 @@ func (h *DiscoveryHandler) GetDiscoveryStats(c *gin.Context) {
 	}
 
 	utils.SuccessResponse(c, http.StatusOK, response)
-}
\ No newline at end of file
+}
+
+// Page size limits used when none are configured
+const (
+	defaultDiscoveryPageSize = 10
+	maxDiscoveryPageSize     = 100
+)
+
+// discoveryPageSize returns the number of profiles to return for the request. The page size of the
+// client platform, named by the platform query parameter or header, is used when no limit is given,
+// and larger limits are clamped to the platform's maximum.
+func discoveryPageSize(c *gin.Context, cfg *config.MatchingPageSizeConfig) (int, error) {
+	limits := config.DiscoveryPageSizeConfig{Default: defaultDiscoveryPageSize, Max: maxDiscoveryPageSize}
+	if cfg != nil {
+		platform := c.Query("platform")
+		if platform == "" && cfg.PlatformHeader != "" {
+			platform = c.GetHeader(cfg.PlatformHeader)
+		}
+		if platformLimits, ok := cfg.Platforms[strings.ToLower(strings.TrimSpace(platform))]; ok {
+			limits = platformLimits
+		} else if cfg.Default.Default > 0 {
+			limits = cfg.Default
+		}
+	}
+	if limits.Max <= 0 || limits.Max > maxDiscoveryPageSize {
+		limits.Max = maxDiscoveryPageSize
+	}
+	if limits.Default <= 0 || limits.Default > limits.Max {
+		limits.Default = limits.Max
+	}
+
+	limitStr := c.Query("limit")
+	if limitStr == "" {
+		return limits.Default, nil
+	}
+
+	limit, err := strconv.Atoi(limitStr)
+	if err != nil || limit < 1 {
+		return 0, fmt.Errorf("limit must be a positive integer")
+	}
+	if limit > limits.Max {
+		limit = limits.Max
+	}
+	return limit, nil
+}
+
+// queryList returns the values of a query parameter given as comma-separated or repeated values
+func queryList(c *gin.Context, param string) []string {
+	var values []string
+	for _, raw := range c.QueryArray(param) {
+		for _, value := range strings.Split(raw, ",") {
+			if value = strings.TrimSpace(value); value != "" {
+				values = append(values, value)
+			}
+		}
+	}
+	return values
+}
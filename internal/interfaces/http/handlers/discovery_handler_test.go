@@ -0,0 +1,123 @@
+package handlers
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/22smeargle/winkr-backend/pkg/config"
+)
+
+func testPageSizeConfig() *config.MatchingPageSizeConfig {
+	return &config.MatchingPageSizeConfig{
+		PlatformHeader: "X-Client-Platform",
+		Default:        config.DiscoveryPageSizeConfig{Default: 10, Max: 50},
+		Platforms: map[string]config.DiscoveryPageSizeConfig{
+			"web": {Default: 20, Max: 100},
+			"ios": {Default: 8, Max: 30},
+		},
+	}
+}
+
+func TestDiscoveryPageSize(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	tests := []struct {
+		name          string
+		query         string
+		platform      string
+		config        *config.MatchingPageSizeConfig
+		expectedLimit int
+		expectedError bool
+	}{
+		{
+			name:          "Default for an unknown platform",
+			config:        testPageSizeConfig(),
+			expectedLimit: 10,
+		},
+		{
+			name:          "Default for the platform in the header",
+			platform:      "web",
+			config:        testPageSizeConfig(),
+			expectedLimit: 20,
+		},
+		{
+			name:          "Platform query parameter overrides the header",
+			query:         "platform=ios",
+			platform:      "web",
+			config:        testPageSizeConfig(),
+			expectedLimit: 8,
+		},
+		{
+			name:          "Platform names are case insensitive",
+			platform:      "iOS",
+			config:        testPageSizeConfig(),
+			expectedLimit: 8,
+		},
+		{
+			name:          "Requested limit within the maximum is kept",
+			query:         "limit=25",
+			config:        testPageSizeConfig(),
+			expectedLimit: 25,
+		},
+		{
+			name:          "Oversized limit is clamped to the default maximum",
+			query:         "limit=5000",
+			config:        testPageSizeConfig(),
+			expectedLimit: 50,
+		},
+		{
+			name:          "Oversized limit is clamped to the web maximum",
+			query:         "limit=5000",
+			platform:      "web",
+			config:        testPageSizeConfig(),
+			expectedLimit: 100,
+		},
+		{
+			name:          "Oversized limit is clamped to the ios maximum",
+			query:         "limit=5000",
+			platform:      "ios",
+			config:        testPageSizeConfig(),
+			expectedLimit: 30,
+		},
+		{
+			name:          "Without configuration the built-in limits apply",
+			query:         "limit=500",
+			expectedLimit: 100,
+		},
+		{
+			name:          "Zero limit is rejected",
+			query:         "limit=0",
+			config:        testPageSizeConfig(),
+			expectedError: true,
+		},
+		{
+			name:          "Non-numeric limit is rejected",
+			query:         "limit=lots",
+			config:        testPageSizeConfig(),
+			expectedError: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c, _ := gin.CreateTestContext(httptest.NewRecorder())
+			c.Request = httptest.NewRequest(http.MethodGet, "/api/v1/discover?"+tt.query, nil)
+			if tt.platform != "" {
+				c.Request.Header.Set("X-Client-Platform", tt.platform)
+			}
+
+			limit, err := discoveryPageSize(c, tt.config)
+
+			if tt.expectedError {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.expectedLimit, limit)
+		})
+	}
+}
@@ -8,6 +8,7 @@ import (
 	"github.com/gin-gonic/gin"
 	"github.com/google/uuid"
 	"github.com/22smeargle/winkr-backend/internal/application/usecases/ephemeral_photo"
+	"github.com/22smeargle/winkr-backend/pkg/errors"
 	"github.com/22smeargle/winkr-backend/pkg/logger"
 	"github.com/22smeargle/winkr-backend/pkg/utils"
 )
@@ -68,6 +69,10 @@ func (h *EphemeralPhotoHandler) UploadEphemeralPhoto(c *gin.Context) {
 
 	// Execute use case
 	response, err := h.uploadUseCase.Execute(c.Request.Context(), &req)
+	if limitErr, ok := errors.GetPhotoLimitError(err); ok {
+		utils.PhotoLimitReached(c, limitErr)
+		return
+	}
 	if err != nil {
 		logger.Error("Failed to upload ephemeral photo", err)
 		utils.ErrorResponse(c, http.StatusInternalServerError, "Failed to upload ephemeral photo")
@@ -0,0 +1,87 @@
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+
+	"github.com/22smeargle/winkr-backend/internal/application/services"
+	"github.com/22smeargle/winkr-backend/pkg/logger"
+	"github.com/22smeargle/winkr-backend/pkg/utils"
+)
+
+// LegalHandler handles legal notice HTTP requests
+type LegalHandler struct {
+	noticeService *services.LegalNoticeService
+}
+
+// NewLegalHandler creates a new legal handler
+func NewLegalHandler(noticeService *services.LegalNoticeService) *LegalHandler {
+	return &LegalHandler{
+		noticeService: noticeService,
+	}
+}
+
+// AcknowledgeNoticeRequest represents a request to acknowledge a legal notice
+type AcknowledgeNoticeRequest struct {
+	Version int `json:"version" binding:"required,min=1"`
+}
+
+// GetNotices handles GET /legal/notices and returns the notices the user has not acknowledged yet
+func (h *LegalHandler) GetNotices(c *gin.Context) {
+	userID, ok := h.getUserID(c)
+	if !ok {
+		return
+	}
+
+	notices, err := h.noticeService.GetPendingNotices(c.Request.Context(), userID)
+	if err != nil {
+		logger.Error("Failed to get pending notices", err, "user_id", userID)
+		utils.Error(c, err)
+		return
+	}
+
+	utils.Success(c, http.StatusOK, gin.H{
+		"notices": notices,
+	})
+}
+
+// AcknowledgeNotice handles POST /legal/notices/:id/acknowledge
+func (h *LegalHandler) AcknowledgeNotice(c *gin.Context) {
+	userID, ok := h.getUserID(c)
+	if !ok {
+		return
+	}
+
+	var req AcknowledgeNoticeRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		utils.BadRequest(c, "Invalid request body")
+		return
+	}
+
+	acknowledgement, err := h.noticeService.Acknowledge(c.Request.Context(), userID, c.Param("id"), req.Version)
+	if err != nil {
+		utils.Error(c, err)
+		return
+	}
+
+	utils.Success(c, http.StatusOK, acknowledgement)
+}
+
+// getUserID extracts the authenticated user ID, writing an error response if it is missing
+func (h *LegalHandler) getUserID(c *gin.Context) (uuid.UUID, bool) {
+	userIDStr, exists := c.Get("user_id")
+	if !exists {
+		utils.Unauthorized(c, "User not authenticated")
+		return uuid.Nil, false
+	}
+
+	userID, err := uuid.Parse(userIDStr.(string))
+	if err != nil {
+		utils.Unauthorized(c, "Invalid user ID")
+		return uuid.Nil, false
+	}
+
+	return userID, true
+}
@@ -8,6 +8,7 @@ import (
 	"github.com/google/uuid"
 	
 	"github.com/22smeargle/winkr-backend/internal/application/usecases/moderation"
+	"github.com/22smeargle/winkr-backend/pkg/errors"
 	"github.com/22smeargle/winkr-backend/pkg/logger"
 	"github.com/22smeargle/winkr-backend/pkg/response"
 	"github.com/22smeargle/winkr-backend/pkg/validator"
@@ -16,22 +17,31 @@ import (
 // ModerationHandler handles moderation-related HTTP endpoints
 type ModerationHandler struct {
 	reportContentUseCase   *moderation.ReportContentUseCase
+	reportPhotoUseCase     *moderation.ReportPhotoUseCase
+	reportUserUseCase      *moderation.ReportUserUseCase
 	blockUserUseCase      *moderation.BlockUserUseCase
 	getBlockedUsersUseCase *moderation.GetBlockedUsersUseCase
+	banUserUseCase        *moderation.BanUserUseCase
 	validator             validator.Validator
 }
 
 // NewModerationHandler creates a new moderation handler
 func NewModerationHandler(
 	reportContentUseCase *moderation.ReportContentUseCase,
+	reportPhotoUseCase *moderation.ReportPhotoUseCase,
+	reportUserUseCase *moderation.ReportUserUseCase,
 	blockUserUseCase *moderation.BlockUserUseCase,
 	getBlockedUsersUseCase *moderation.GetBlockedUsersUseCase,
+	banUserUseCase *moderation.BanUserUseCase,
 	validator validator.Validator,
 ) *ModerationHandler {
 	return &ModerationHandler{
 		reportContentUseCase:   reportContentUseCase,
+		reportPhotoUseCase:     reportPhotoUseCase,
+		reportUserUseCase:      reportUserUseCase,
 		blockUserUseCase:      blockUserUseCase,
 		getBlockedUsersUseCase: getBlockedUsersUseCase,
+		banUserUseCase:        banUserUseCase,
 		validator:             validator,
 	}
 }
@@ -75,6 +85,117 @@ func (h *ModerationHandler) ReportContent(c *gin.Context) {
 	response.Success(c, http.StatusCreated, "Report submitted successfully", result)
 }
 
+// ReportPhoto handles POST /users/:id/photos/:photoId/report endpoint
+func (h *ModerationHandler) ReportPhoto(c *gin.Context) {
+	logger.Info("ReportPhoto request received", "ip", c.ClientIP(), "user_agent", c.Request.UserAgent())
+	
+	// Get photo owner and photo IDs from URL parameters
+	ownerID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		logger.Error("Invalid user ID", err, "user_id", c.Param("id"), "ip", c.ClientIP())
+		response.Error(c, http.StatusBadRequest, "Invalid user ID", err)
+		return
+	}
+	
+	photoID, err := uuid.Parse(c.Param("photoId"))
+	if err != nil {
+		logger.Error("Invalid photo ID", err, "photo_id", c.Param("photoId"), "ip", c.ClientIP())
+		response.Error(c, http.StatusBadRequest, "Invalid photo ID", err)
+		return
+	}
+	
+	var req moderation.ReportPhotoRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		logger.Error("Failed to bind request", err, "ip", c.ClientIP())
+		response.Error(c, http.StatusBadRequest, "Invalid request format", err)
+		return
+	}
+	
+	// Get user ID from context (from auth middleware)
+	userIDStr, exists := c.Get("user_id")
+	if !exists {
+		logger.Error("User ID not found in context", nil, "ip", c.ClientIP())
+		response.Error(c, http.StatusUnauthorized, "Authentication required", nil)
+		return
+	}
+	
+	userID, err := uuid.Parse(userIDStr.(string))
+	if err != nil {
+		logger.Error("Invalid user ID in context", err, "ip", c.ClientIP())
+		response.Error(c, http.StatusUnauthorized, "Invalid user ID", err)
+		return
+	}
+	
+	req.ReporterID = userID
+	req.OwnerID = ownerID
+	req.PhotoID = photoID
+	
+	// Execute use case
+	result, err := h.reportPhotoUseCase.Execute(c.Request.Context(), req)
+	if err != nil {
+		logger.Error("Failed to execute ReportPhoto use case", err, "user_id", userID, "photo_id", photoID, "ip", c.ClientIP())
+		if errors.IsAppError(err) {
+			appErr := errors.GetAppError(err)
+			response.Error(c, appErr.Code, appErr.Message, err)
+			return
+		}
+		response.Error(c, http.StatusInternalServerError, "Failed to submit photo report", err)
+		return
+	}
+	
+	response.Success(c, http.StatusCreated, "Photo report submitted successfully", result)
+}
+
+// ReportUser handles POST /users/:id/report endpoint
+func (h *ModerationHandler) ReportUser(c *gin.Context) {
+	logger.Info("ReportUser request received", "ip", c.ClientIP(), "user_agent", c.Request.UserAgent())
+	
+	// Get reported user ID from URL parameter
+	targetID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		logger.Error("Invalid user ID", err, "user_id", c.Param("id"), "ip", c.ClientIP())
+		response.Error(c, http.StatusBadRequest, "Invalid user ID", err)
+		return
+	}
+	
+	var req moderation.ReportUserRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		logger.Error("Failed to bind request", err, "ip", c.ClientIP())
+		response.Error(c, http.StatusBadRequest, "Invalid request format", err)
+		return
+	}
+	
+	// Get user ID from context (from auth middleware)
+	userIDStr, exists := c.Get("user_id")
+	if !exists {
+		logger.Error("User ID not found in context", nil, "ip", c.ClientIP())
+		response.Error(c, http.StatusUnauthorized, "Authentication required", nil)
+		return
+	}
+	
+	userID, err := uuid.Parse(userIDStr.(string))
+	if err != nil {
+		logger.Error("Invalid user ID in context", err, "ip", c.ClientIP())
+		response.Error(c, http.StatusUnauthorized, "Invalid user ID", err)
+		return
+	}
+	
+	// Execute use case
+	result, err := h.reportUserUseCase.ReportUser(c.Request.Context(), userID, targetID, req.Reason, req.Description)
+	if err != nil {
+		logger.Error("Failed to execute ReportUser use case", err, "user_id", userID, "reported_user_id", targetID, "ip", c.ClientIP())
+		if errors.IsAppError(err) {
+			appErr := errors.GetAppError(err)
+			response.Error(c, appErr.Code, appErr.Message, err)
+			return
+		}
+		response.Error(c, http.StatusInternalServerError, "Failed to submit report", err)
+		return
+	}
+	
+	response.Success(c, http.StatusCreated, "Report submitted successfully", result)
+}
+
 // BlockUser handles POST /block/:id endpoint
 func (h *ModerationHandler) BlockUser(c *gin.Context) {
 	logger.Info("BlockUser request received", "ip", c.ClientIP(), "user_agent", c.Request.UserAgent())
@@ -463,4 +584,63 @@ func (h *ModerationHandler) CheckMutualBlock(c *gin.Context) {
 	response.Success(c, http.StatusOK, "Mutual block status retrieved successfully", gin.H{
 		"is_mutual_block": isMutual,
 	})
-}
\ No newline at end of file
+}
+
+// SubmitAppeal handles POST /appeals endpoint
+func (h *ModerationHandler) SubmitAppeal(c *gin.Context) {
+	logger.Info("SubmitAppeal request received", "ip", c.ClientIP(), "user_agent", c.Request.UserAgent())
+	
+	var req struct {
+		BanID       uuid.UUID              `json:"ban_id" binding:"required"`
+		Reason      string                 `json:"reason" binding:"required"`
+		Description string                 `json:"description"`
+		Evidence    map[string]interface{} `json:"evidence,omitempty"`
+	}
+	if err := c.ShouldBindJSON(&req); err != nil {
+		logger.Error("Failed to bind request", err, "ip", c.ClientIP())
+		response.Error(c, http.StatusBadRequest, "Invalid request format", err)
+		return
+	}
+	
+	// Get user ID from context (from auth middleware)
+	userIDStr, exists := c.Get("user_id")
+	if !exists {
+		logger.Error("User ID not found in context", nil, "ip", c.ClientIP())
+		response.Error(c, http.StatusUnauthorized, "Authentication required", nil)
+		return
+	}
+	
+	userID, err := uuid.Parse(userIDStr.(string))
+	if err != nil {
+		logger.Error("Invalid user ID in context", err, "ip", c.ClientIP())
+		response.Error(c, http.StatusUnauthorized, "Invalid user ID", err)
+		return
+	}
+	
+	appeal := &moderation.AppealRequest{
+		UserID:        userID,
+		OriginalBanID: req.BanID,
+		Reason:        req.Reason,
+		Description:   req.Description,
+		Evidence:      req.Evidence,
+	}
+	
+	// Execute use case
+	if err := h.banUserUseCase.SubmitAppeal(c.Request.Context(), appeal); err != nil {
+		logger.Error("Failed to execute SubmitAppeal use case", err, "user_id", userID, "ban_id", req.BanID, "ip", c.ClientIP())
+		if errors.IsAppError(err) {
+			appErr := errors.GetAppError(err)
+			response.Error(c, appErr.Code, appErr.Message, err)
+			return
+		}
+		response.Error(c, http.StatusInternalServerError, "Failed to submit appeal", err)
+		return
+	}
+	
+	response.Success(c, http.StatusCreated, "Appeal submitted successfully", gin.H{
+		"appeal_id":     appeal.ID,
+		"status":        appeal.Status,
+		"auto_reviewed": appeal.AutoReviewed,
+		"created_at":    appeal.CreatedAt,
+	})
+}
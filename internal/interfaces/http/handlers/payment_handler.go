@@ -18,13 +18,19 @@ type PaymentHandler struct {
 	getPlansUseCase              *payment.GetPlansUseCase
 	getPlanByIDUseCase            *payment.GetPlanByIDUseCase
 	subscribeUseCase              *payment.SubscribeUseCase
+	createPaymentIntentUseCase    *payment.CreatePaymentIntentUseCase
+	createCheckoutSessionUseCase  *payment.CreateCheckoutSessionUseCase
 	getSubscriptionUseCase         *payment.GetSubscriptionUseCase
 	getActiveSubscriptionUseCase   *payment.GetActiveSubscriptionUseCase
 	cancelSubscriptionUseCase       *payment.CancelSubscriptionUseCase
+	previewSubscriptionChangeUseCase *payment.PreviewSubscriptionChangeUseCase
 	getPaymentMethodsUseCase       *payment.GetPaymentMethodsUseCase
 	getDefaultPaymentMethodUseCase *payment.GetDefaultPaymentMethodUseCase
 	addPaymentMethodUseCase        *payment.AddPaymentMethodUseCase
 	deletePaymentMethodUseCase     *payment.DeletePaymentMethodUseCase
+	setDefaultPaymentMethodUseCase *payment.SetDefaultPaymentMethodUseCase
+	getInvoicePDFUseCase           *payment.GetInvoicePDFUseCase
+	validatePromotionCodeUseCase   *payment.ValidatePromotionCodeUseCase
 	processWebhookUseCase          *payment.ProcessWebhookUseCase
 }
 
@@ -33,26 +39,38 @@ func NewPaymentHandler(
 	getPlansUseCase *payment.GetPlansUseCase,
 	getPlanByIDUseCase *payment.GetPlanByIDUseCase,
 	subscribeUseCase *payment.SubscribeUseCase,
+	createPaymentIntentUseCase *payment.CreatePaymentIntentUseCase,
+	createCheckoutSessionUseCase *payment.CreateCheckoutSessionUseCase,
 	getSubscriptionUseCase *payment.GetSubscriptionUseCase,
 	getActiveSubscriptionUseCase *payment.GetActiveSubscriptionUseCase,
 	cancelSubscriptionUseCase *payment.CancelSubscriptionUseCase,
+	previewSubscriptionChangeUseCase *payment.PreviewSubscriptionChangeUseCase,
 	getPaymentMethodsUseCase *payment.GetPaymentMethodsUseCase,
 	getDefaultPaymentMethodUseCase *payment.GetDefaultPaymentMethodUseCase,
 	addPaymentMethodUseCase *payment.AddPaymentMethodUseCase,
 	deletePaymentMethodUseCase *payment.DeletePaymentMethodUseCase,
+	setDefaultPaymentMethodUseCase *payment.SetDefaultPaymentMethodUseCase,
+	getInvoicePDFUseCase *payment.GetInvoicePDFUseCase,
+	validatePromotionCodeUseCase *payment.ValidatePromotionCodeUseCase,
 	processWebhookUseCase *payment.ProcessWebhookUseCase,
 ) *PaymentHandler {
 	return &PaymentHandler{
 		getPlansUseCase:              getPlansUseCase,
 		getPlanByIDUseCase:            getPlanByIDUseCase,
 		subscribeUseCase:              subscribeUseCase,
+		createPaymentIntentUseCase:    createPaymentIntentUseCase,
+		createCheckoutSessionUseCase:  createCheckoutSessionUseCase,
 		getSubscriptionUseCase:         getSubscriptionUseCase,
 		getActiveSubscriptionUseCase:   getActiveSubscriptionUseCase,
 		cancelSubscriptionUseCase:       cancelSubscriptionUseCase,
+		previewSubscriptionChangeUseCase: previewSubscriptionChangeUseCase,
 		getPaymentMethodsUseCase:       getPaymentMethodsUseCase,
 		getDefaultPaymentMethodUseCase: getDefaultPaymentMethodUseCase,
 		addPaymentMethodUseCase:        addPaymentMethodUseCase,
 		deletePaymentMethodUseCase:     deletePaymentMethodUseCase,
+		setDefaultPaymentMethodUseCase: setDefaultPaymentMethodUseCase,
+		getInvoicePDFUseCase:           getInvoicePDFUseCase,
+		validatePromotionCodeUseCase:   validatePromotionCodeUseCase,
 		processWebhookUseCase:          processWebhookUseCase,
 	}
 }
@@ -152,6 +170,98 @@ func (h *PaymentHandler) Subscribe(c *gin.Context) {
 	utils.SuccessResponse(c, http.StatusCreated, "Subscription created successfully", response)
 }
 
+// CreatePaymentIntent handles POST /payment-intents endpoint
+func (h *PaymentHandler) CreatePaymentIntent(c *gin.Context) {
+	userID, err := uuid.Parse(c.GetString("user_id"))
+	if err != nil {
+		logger.Error("Invalid user ID", err, nil)
+		utils.ErrorResponse(c, http.StatusBadRequest, "Invalid user ID")
+		return
+	}
+
+	var req payment.CreatePaymentIntentRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		logger.Error("Failed to bind payment intent request", err, nil)
+		utils.ErrorResponse(c, http.StatusBadRequest, "Invalid request format")
+		return
+	}
+	req.UserID = userID
+
+	if err := validator.ValidateStruct(&req); err != nil {
+		utils.ErrorResponse(c, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	response, err := h.createPaymentIntentUseCase.Execute(c.Request.Context(), req)
+	if err != nil {
+		switch err {
+		case payment.ErrPlanNotFound:
+			utils.ErrorResponse(c, http.StatusNotFound, "Subscription plan not found")
+		case payment.ErrStripeCustomerNotFound:
+			utils.ErrorResponse(c, http.StatusConflict, "Add a payment method before paying")
+		case payment.ErrInvalidUserID:
+			utils.ErrorResponse(c, http.StatusBadRequest, "Invalid user ID")
+		default:
+			logger.Error("Failed to create payment intent", err, map[string]interface{}{
+				"user_id": userID,
+			})
+			utils.ErrorResponse(c, http.StatusInternalServerError, "Failed to create payment intent")
+		}
+		return
+	}
+
+	utils.SuccessResponse(c, http.StatusOK, "Payment intent created successfully", gin.H{
+		"payment_intent": response,
+	})
+}
+
+// CreateCheckoutSession handles POST /checkout/session endpoint
+func (h *PaymentHandler) CreateCheckoutSession(c *gin.Context) {
+	userID, err := uuid.Parse(c.GetString("user_id"))
+	if err != nil {
+		logger.Error("Invalid user ID", err, nil)
+		utils.ErrorResponse(c, http.StatusBadRequest, "Invalid user ID")
+		return
+	}
+
+	var req payment.CreateCheckoutSessionRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		logger.Error("Failed to bind checkout session request", err, nil)
+		utils.ErrorResponse(c, http.StatusBadRequest, "Invalid request format")
+		return
+	}
+	req.UserID = userID
+
+	if err := validator.ValidateStruct(&req); err != nil {
+		utils.ErrorResponse(c, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	response, err := h.createCheckoutSessionUseCase.Execute(c.Request.Context(), req)
+	if err != nil {
+		switch err {
+		case payment.ErrPlanNotFound:
+			utils.ErrorResponse(c, http.StatusNotFound, "Subscription plan not found")
+		case payment.ErrInvalidCurrency:
+			utils.ErrorResponse(c, http.StatusBadRequest, "Unsupported currency")
+		case payment.ErrStripeCustomerNotFound:
+			utils.ErrorResponse(c, http.StatusConflict, "Add a payment method before paying")
+		case payment.ErrInvalidUserID:
+			utils.ErrorResponse(c, http.StatusBadRequest, "Invalid user ID")
+		default:
+			logger.Error("Failed to create checkout session", err, map[string]interface{}{
+				"user_id": userID,
+			})
+			utils.ErrorResponse(c, http.StatusInternalServerError, "Failed to create checkout session")
+		}
+		return
+	}
+
+	utils.SuccessResponse(c, http.StatusOK, "Checkout session created successfully", gin.H{
+		"checkout_session": response,
+	})
+}
+
 // GetSubscription handles GET /subscription endpoint
 func (h *PaymentHandler) GetSubscription(c *gin.Context) {
 	userID, err := uuid.Parse(c.GetString("user_id"))
@@ -234,10 +344,11 @@ func (h *PaymentHandler) CancelSubscription(c *gin.Context) {
 	logger.Info("Canceling subscription", map[string]interface{}{
 		"user_id":            req.UserID,
 		"cancel_at_period_end": req.CancelAtPeriodEnd,
+		"refund":              req.Refund,
 		"reason":              req.Reason,
 	})
 
-	err = h.cancelSubscriptionUseCase.Execute(c.Request.Context(), req)
+	response, err := h.cancelSubscriptionUseCase.Execute(c.Request.Context(), req)
 	if err != nil {
 		switch err {
 		case payment.ErrSubscriptionNotFound:
@@ -248,6 +359,14 @@ func (h *PaymentHandler) CancelSubscription(c *gin.Context) {
 			utils.ErrorResponse(c, http.StatusConflict, "Subscription already expired")
 		case payment.ErrCannotCancelSubscription:
 			utils.ErrorResponse(c, http.StatusBadRequest, "Cannot cancel subscription")
+		case payment.ErrRefundRequiresImmediateCancel:
+			utils.ErrorResponse(c, http.StatusBadRequest, "Refunds require canceling immediately")
+		case payment.ErrRefundWindowExpired:
+			utils.ErrorResponse(c, http.StatusConflict, "The refund window since the last charge has expired")
+		case payment.ErrPaymentCannotRefund:
+			utils.ErrorResponse(c, http.StatusConflict, "Subscription cannot be refunded")
+		case payment.ErrRefundFailed:
+			utils.ErrorResponse(c, http.StatusBadGateway, "Subscription canceled but the refund failed")
 		case payment.ErrInvalidUserID:
 			utils.ErrorResponse(c, http.StatusBadRequest, "Invalid user ID")
 		default:
@@ -259,7 +378,56 @@ func (h *PaymentHandler) CancelSubscription(c *gin.Context) {
 		return
 	}
 
-	utils.SuccessResponse(c, http.StatusOK, "Subscription canceled successfully", nil)
+	utils.SuccessResponse(c, http.StatusOK, "Subscription canceled successfully", gin.H{
+		"cancellation": response,
+	})
+}
+
+// PreviewSubscriptionChange handles GET /subscription/preview endpoint
+func (h *PaymentHandler) PreviewSubscriptionChange(c *gin.Context) {
+	userID, err := uuid.Parse(c.GetString("user_id"))
+	if err != nil {
+		logger.Error("Invalid user ID", err, nil)
+		utils.ErrorResponse(c, http.StatusBadRequest, "Invalid user ID")
+		return
+	}
+
+	priceID := c.Query("price_id")
+	if priceID == "" {
+		utils.ErrorResponse(c, http.StatusBadRequest, "Price ID is required")
+		return
+	}
+
+	logger.Info("Previewing subscription change", map[string]interface{}{
+		"user_id":  userID,
+		"price_id": priceID,
+	})
+
+	preview, err := h.previewSubscriptionChangeUseCase.Execute(c.Request.Context(), userID, priceID)
+	if err != nil {
+		switch err {
+		case payment.ErrPlanNotFound:
+			utils.ErrorResponse(c, http.StatusNotFound, "Subscription plan not found")
+		case payment.ErrSubscriptionNotFound, payment.ErrStripeSubscriptionNotFound:
+			utils.ErrorResponse(c, http.StatusNotFound, "Subscription not found")
+		case payment.ErrSubscriptionInactive:
+			utils.ErrorResponse(c, http.StatusConflict, "Subscription is not active")
+		case payment.ErrSubscriptionActive:
+			utils.ErrorResponse(c, http.StatusConflict, "Subscription is already on this plan")
+		case payment.ErrInvalidUserID:
+			utils.ErrorResponse(c, http.StatusBadRequest, "Invalid user ID")
+		default:
+			logger.Error("Failed to preview subscription change", err, map[string]interface{}{
+				"user_id": userID,
+			})
+			utils.ErrorResponse(c, http.StatusInternalServerError, "Failed to preview subscription change")
+		}
+		return
+	}
+
+	utils.SuccessResponse(c, http.StatusOK, "Subscription change preview retrieved successfully", gin.H{
+		"preview": preview,
+	})
 }
 
 // GetPaymentMethods handles GET /payment-methods endpoint
@@ -419,6 +587,120 @@ func (h *PaymentHandler) DeletePaymentMethod(c *gin.Context) {
 	utils.SuccessResponse(c, http.StatusOK, "Payment method deleted successfully", nil)
 }
 
+// SetDefaultPaymentMethod handles PUT /payment-methods/:id/default endpoint
+func (h *PaymentHandler) SetDefaultPaymentMethod(c *gin.Context) {
+	userID, err := uuid.Parse(c.GetString("user_id"))
+	if err != nil {
+		logger.Error("Invalid user ID", err, nil)
+		utils.ErrorResponse(c, http.StatusBadRequest, "Invalid user ID")
+		return
+	}
+
+	paymentMethodID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		logger.Error("Invalid payment method ID", err, nil)
+		utils.ErrorResponse(c, http.StatusBadRequest, "Invalid payment method ID")
+		return
+	}
+
+	paymentMethod, err := h.setDefaultPaymentMethodUseCase.Execute(c.Request.Context(), userID, paymentMethodID)
+	if err != nil {
+		switch err {
+		case payment.ErrInvalidUserID:
+			utils.ErrorResponse(c, http.StatusBadRequest, "Invalid user ID")
+		case payment.ErrPaymentMethodNotFound:
+			utils.ErrorResponse(c, http.StatusNotFound, "Payment method not found")
+		case payment.ErrPaymentAccessDenied:
+			utils.ErrorResponse(c, http.StatusForbidden, "Payment method access denied")
+		case payment.ErrStripeCustomerNotFound:
+			utils.ErrorResponse(c, http.StatusConflict, "Add a payment method first")
+		default:
+			logger.Error("Failed to set default payment method", err, map[string]interface{}{
+				"user_id":           userID,
+				"payment_method_id": paymentMethodID,
+			})
+			utils.ErrorResponse(c, http.StatusInternalServerError, "Failed to set default payment method")
+		}
+		return
+	}
+
+	utils.SuccessResponse(c, http.StatusOK, "Default payment method updated successfully", gin.H{
+		"payment_method": paymentMethod,
+	})
+}
+
+// GetInvoicePDF handles GET /invoices/:id/pdf endpoint
+func (h *PaymentHandler) GetInvoicePDF(c *gin.Context) {
+	userID, err := uuid.Parse(c.GetString("user_id"))
+	if err != nil {
+		logger.Error("Invalid user ID", err, nil)
+		utils.ErrorResponse(c, http.StatusBadRequest, "Invalid user ID")
+		return
+	}
+
+	invoiceID := c.Param("id")
+	if invoiceID == "" {
+		utils.ErrorResponse(c, http.StatusBadRequest, "Invalid invoice ID")
+		return
+	}
+
+	invoicePDF, err := h.getInvoicePDFUseCase.Execute(c.Request.Context(), userID, invoiceID)
+	if err != nil {
+		switch err {
+		case payment.ErrInvalidUserID:
+			utils.ErrorResponse(c, http.StatusBadRequest, "Invalid user ID")
+		case payment.ErrInvoiceNotFound, payment.ErrInvoiceAccessDenied, payment.ErrStripeCustomerNotFound:
+			utils.ErrorResponse(c, http.StatusNotFound, "Invoice not found")
+		case payment.ErrInvoiceNotFinalized:
+			utils.ErrorResponse(c, http.StatusConflict, "Invoice is not finalized yet")
+		default:
+			logger.Error("Failed to get invoice PDF", err, map[string]interface{}{
+				"user_id":    userID,
+				"invoice_id": invoiceID,
+			})
+			utils.ErrorResponse(c, http.StatusInternalServerError, "Failed to get invoice PDF")
+		}
+		return
+	}
+
+	utils.SuccessResponse(c, http.StatusOK, "Invoice PDF retrieved successfully", gin.H{
+		"invoice": invoicePDF,
+	})
+}
+
+// ValidatePromotionCode handles POST /promo/validate endpoint
+func (h *PaymentHandler) ValidatePromotionCode(c *gin.Context) {
+	var req payment.ValidatePromotionCodeRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		logger.Error("Failed to bind promotion code request", err, nil)
+		utils.ErrorResponse(c, http.StatusBadRequest, "Invalid request format")
+		return
+	}
+
+	if err := validator.ValidateStruct(&req); err != nil {
+		utils.ErrorResponse(c, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	promotionCode, err := h.validatePromotionCodeUseCase.Execute(c.Request.Context(), req)
+	if err != nil {
+		switch err {
+		case payment.ErrPromotionCodeNotFound:
+			utils.ErrorResponse(c, http.StatusNotFound, "Promotion code not found")
+		case payment.ErrPromotionCodeExpired:
+			utils.ErrorResponse(c, http.StatusGone, "Promotion code is expired or no longer active")
+		default:
+			logger.Error("Failed to validate promotion code", err, nil)
+			utils.ErrorResponse(c, http.StatusInternalServerError, "Failed to validate promotion code")
+		}
+		return
+	}
+
+	utils.SuccessResponse(c, http.StatusOK, "Promotion code is valid", gin.H{
+		"promotion_code": promotionCode,
+	})
+}
+
 // ProcessWebhook handles POST /webhook endpoint
 func (h *PaymentHandler) ProcessWebhook(c *gin.Context) {
 	// Read request body
@@ -449,8 +731,11 @@ func (h *PaymentHandler) ProcessWebhook(c *gin.Context) {
 			utils.ErrorResponse(c, http.StatusUnauthorized, "Invalid webhook signature")
 		case payment.ErrWebhookEventNotSupported:
 			utils.ErrorResponse(c, http.StatusBadRequest, "Webhook event not supported")
+		case payment.ErrWebhookEventTooOld:
+			utils.ErrorResponse(c, http.StatusBadRequest, "Webhook event too old")
 		case payment.ErrWebhookAlreadyProcessed:
-			utils.ErrorResponse(c, http.StatusConflict, "Webhook event already processed")
+			// Acknowledge redeliveries so Stripe stops retrying them
+			c.JSON(http.StatusOK, gin.H{"status": "already_processed"})
 		default:
 			logger.Error("Failed to process webhook", err, nil)
 			utils.ErrorResponse(c, http.StatusInternalServerError, "Failed to process webhook")
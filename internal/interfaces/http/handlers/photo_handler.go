@@ -7,6 +7,7 @@ import (
 	"github.com/gin-gonic/gin"
 	"github.com/google/uuid"
 	"github.com/22smeargle/winkr-backend/internal/application/usecases/photo"
+	"github.com/22smeargle/winkr-backend/pkg/errors"
 	"github.com/22smeargle/winkr-backend/pkg/logger"
 	"github.com/22smeargle/winkr-backend/pkg/utils"
 )
@@ -19,6 +20,8 @@ type PhotoHandler struct {
 	getDownloadURLUseCase     *photo.GetDownloadURLUseCase
 	setPrimaryPhotoUseCase    *photo.SetPrimaryPhotoUseCase
 	markPhotoViewedUseCase   *photo.MarkPhotoViewedUseCase
+	getPhotoPerformanceUseCase *photo.GetPhotoPerformanceUseCase
+	setPhotoAutoReorderUseCase *photo.SetPhotoAutoReorderUseCase
 	jwtUtils                 *utils.JWTUtils
 }
 
@@ -30,6 +33,8 @@ func NewPhotoHandler(
 	getDownloadURLUseCase *photo.GetDownloadURLUseCase,
 	setPrimaryPhotoUseCase *photo.SetPrimaryPhotoUseCase,
 	markPhotoViewedUseCase *photo.MarkPhotoViewedUseCase,
+	getPhotoPerformanceUseCase *photo.GetPhotoPerformanceUseCase,
+	setPhotoAutoReorderUseCase *photo.SetPhotoAutoReorderUseCase,
 	jwtUtils *utils.JWTUtils,
 ) *PhotoHandler {
 	return &PhotoHandler{
@@ -39,6 +44,8 @@ func NewPhotoHandler(
 		getDownloadURLUseCase:     getDownloadURLUseCase,
 		setPrimaryPhotoUseCase:    setPrimaryPhotoUseCase,
 		markPhotoViewedUseCase:   markPhotoViewedUseCase,
+		getPhotoPerformanceUseCase: getPhotoPerformanceUseCase,
+		setPhotoAutoReorderUseCase: setPhotoAutoReorderUseCase,
 		jwtUtils:                 jwtUtils,
 	}
 }
@@ -97,6 +104,10 @@ func (h *PhotoHandler) UploadPhoto(c *gin.Context) {
 
 	// Execute use case
 	result, err := h.uploadPhotoUseCase.Execute(c.Request.Context(), req)
+	if limitErr, ok := errors.GetPhotoLimitError(err); ok {
+		utils.PhotoLimitReached(c, limitErr)
+		return
+	}
 	if err != nil {
 		logger.Error("Photo upload failed", err)
 		utils.ErrorResponse(c, http.StatusInternalServerError, "upload_failed", err.Error())
@@ -197,6 +208,10 @@ func (h *PhotoHandler) GetUploadURL(c *gin.Context) {
 
 	// Execute use case
 	result, err := h.getUploadURLUseCase.Execute(c.Request.Context(), &req)
+	if limitErr, ok := errors.GetPhotoLimitError(err); ok {
+		utils.PhotoLimitReached(c, limitErr)
+		return
+	}
 	if err != nil {
 		logger.Error("Get upload URL failed", err)
 		utils.ErrorResponse(c, http.StatusInternalServerError, "upload_url_failed", err.Error())
@@ -315,11 +330,12 @@ func (h *PhotoHandler) MarkPhotoViewed(c *gin.Context) {
 		return
 	}
 
-	req.UserID = userUUID
+	// The authenticated user is the viewer; the body names the photo's owner
+	req.ViewerID = userUUID
 	req.PhotoID = photoID
 
 	// Execute use case
-	result, err := h.markPhotoViewedUseCase.Execute(c.Request.Context(), req)
+	result, err := h.markPhotoViewedUseCase.Execute(c.Request.Context(), &req)
 	if err != nil {
 		logger.Error("Mark photo viewed failed", err)
 		utils.ErrorResponse(c, http.StatusInternalServerError, "mark_viewed_failed", err.Error())
@@ -337,6 +353,7 @@ func (h *PhotoHandler) MarkPhotoViewed(c *gin.Context) {
 // @Produce json
 // @Param Authorization header string true "Bearer token"
 // @Param photo_id path string true "Photo ID to set as primary"
+// @Param request body photo.SetPrimaryPhotoRequest false "Set lock to keep the photo primary when photos are reordered by engagement"
 // @Success 200 {object} utils.SuccessResponse{data=photo.SetPrimaryPhotoResponse}
 // @Failure 400 {object} utils.ErrorResponse
 // @Failure 401 {object} utils.ErrorResponse
@@ -365,10 +382,22 @@ func (h *PhotoHandler) SetPrimaryPhoto(c *gin.Context) {
 		return
 	}
 
+	// The body is optional; without one the primary photo is not locked
+	var body struct {
+		Lock bool `json:"lock"`
+	}
+	if c.Request.ContentLength > 0 {
+		if err := c.ShouldBindJSON(&body); err != nil {
+			utils.ErrorResponse(c, http.StatusBadRequest, "invalid_request", "Invalid request body")
+			return
+		}
+	}
+
 	// Create set primary request
 	req := &photo.SetPrimaryPhotoRequest{
 		UserID:  userUUID,
 		PhotoID: photoID,
+		Lock:    body.Lock,
 	}
 
 	// Execute use case
@@ -380,4 +409,90 @@ func (h *PhotoHandler) SetPrimaryPhoto(c *gin.Context) {
 	}
 
 	utils.SuccessResponse(c, http.StatusOK, "primary_photo_set", result)
+}
+
+// GetPhotoPerformance handles getting the performance of the user's photos
+// @Summary Get photo performance
+// @Description Get how many people were shown each of the authenticated user's photos and how many of them went on to like the user
+// @Tags photos
+// @Produce json
+// @Param Authorization header string true "Bearer token"
+// @Success 200 {object} utils.SuccessResponse{data=photo.GetPhotoPerformanceResponse}
+// @Failure 401 {object} utils.ErrorResponse
+// @Failure 429 {object} utils.ErrorResponse
+// @Router /me/photos/performance [get]
+func (h *PhotoHandler) GetPhotoPerformance(c *gin.Context) {
+	// Get user ID from JWT token
+	userID, exists := c.Get("user_id")
+	if !exists {
+		utils.ErrorResponse(c, http.StatusUnauthorized, "unauthorized", "User not authenticated")
+		return
+	}
+
+	userUUID, err := uuid.Parse(userID.(string))
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusBadRequest, "invalid_user_id", "Invalid user ID")
+		return
+	}
+
+	// Execute use case
+	result, err := h.getPhotoPerformanceUseCase.Execute(c.Request.Context(), &photo.GetPhotoPerformanceRequest{UserID: userUUID})
+	if err != nil {
+		logger.Error("Get photo performance failed", err)
+		utils.ErrorResponse(c, http.StatusInternalServerError, "get_performance_failed", err.Error())
+		return
+	}
+
+	utils.SuccessResponse(c, http.StatusOK, "photo_performance_retrieved", result)
+}
+
+// SetPhotoAutoReorder handles opting in or out of having photos reordered by engagement
+// @Summary Set photo auto reorder
+// @Description Opt in or out of having photos periodically reordered so the one viewers like most is primary. A locked primary photo is never replaced.
+// @Tags photos
+// @Accept json
+// @Produce json
+// @Param Authorization header string true "Bearer token"
+// @Param request body photo.SetPhotoAutoReorderRequest true "Auto reorder request"
+// @Success 200 {object} utils.SuccessResponse{data=photo.SetPhotoAutoReorderResponse}
+// @Failure 400 {object} utils.ErrorResponse
+// @Failure 401 {object} utils.ErrorResponse
+// @Failure 429 {object} utils.ErrorResponse
+// @Failure 503 {object} utils.ErrorResponse
+// @Router /me/photos/auto-reorder [put]
+func (h *PhotoHandler) SetPhotoAutoReorder(c *gin.Context) {
+	// Get user ID from JWT token
+	userID, exists := c.Get("user_id")
+	if !exists {
+		utils.ErrorResponse(c, http.StatusUnauthorized, "unauthorized", "User not authenticated")
+		return
+	}
+
+	userUUID, err := uuid.Parse(userID.(string))
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusBadRequest, "invalid_user_id", "Invalid user ID")
+		return
+	}
+
+	// Parse request body
+	var req photo.SetPhotoAutoReorderRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		utils.ErrorResponse(c, http.StatusBadRequest, "invalid_request", "Invalid request body")
+		return
+	}
+	req.UserID = userUUID
+
+	// Execute use case
+	result, err := h.setPhotoAutoReorderUseCase.Execute(c.Request.Context(), &req)
+	if err != nil {
+		if appErr, ok := err.(*errors.AppError); ok {
+			utils.Error(c, appErr)
+			return
+		}
+		logger.Error("Set photo auto reorder failed", err)
+		utils.ErrorResponse(c, http.StatusInternalServerError, "auto_reorder_failed", err.Error())
+		return
+	}
+
+	utils.SuccessResponse(c, http.StatusOK, "photo_auto_reorder_set", result)
 }
\ No newline at end of file
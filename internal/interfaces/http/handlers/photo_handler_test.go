@@ -110,6 +110,8 @@ func TestNewPhotoHandler(t *testing.T) {
 		mockGetDownloadURL,
 		mockSetPrimary,
 		mockMarkViewed,
+		nil,
+		nil,
 		jwtUtils,
 	)
 
@@ -139,6 +141,8 @@ func TestPhotoHandler_UploadPhoto(t *testing.T) {
 		mockGetDownloadURL,
 		mockSetPrimary,
 		mockMarkViewed,
+		nil,
+		nil,
 		jwtUtils,
 	)
 
@@ -271,6 +275,8 @@ func TestPhotoHandler_DeletePhoto(t *testing.T) {
 		mockGetDownloadURL,
 		mockSetPrimary,
 		mockMarkViewed,
+		nil,
+		nil,
 		jwtUtils,
 	)
 
@@ -363,6 +369,8 @@ func TestPhotoHandler_GetUploadURL(t *testing.T) {
 		mockGetDownloadURL,
 		mockSetPrimary,
 		mockMarkViewed,
+		nil,
+		nil,
 		jwtUtils,
 	)
 
@@ -465,6 +473,8 @@ func TestPhotoHandler_GetDownloadURL(t *testing.T) {
 		mockGetDownloadURL,
 		mockSetPrimary,
 		mockMarkViewed,
+		nil,
+		nil,
 		jwtUtils,
 	)
 
@@ -558,6 +568,8 @@ func TestPhotoHandler_SetPrimaryPhoto(t *testing.T) {
 		mockGetDownloadURL,
 		mockSetPrimary,
 		mockMarkViewed,
+		nil,
+		nil,
 		jwtUtils,
 	)
 
@@ -650,6 +662,8 @@ func TestPhotoHandler_MarkPhotoViewed(t *testing.T) {
 		mockGetDownloadURL,
 		mockSetPrimary,
 		mockMarkViewed,
+		nil,
+		nil,
 		jwtUtils,
 	)
 
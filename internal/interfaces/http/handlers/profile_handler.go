@@ -21,6 +21,9 @@ type ProfileHandler struct {
 	updateLocationUseCase   *profile.UpdateLocationUseCase
 	getMatchesUseCase      *profile.GetMatchesUseCase
 	deleteAccountUseCase   *profile.DeleteAccountUseCase
+	getPerformanceUseCase  *profile.GetPerformanceUseCase
+	manageFavoritesUseCase *profile.ManageFavoritesUseCase
+	blockContactsUseCase   *profile.BlockContactsUseCase
 	profileValidator        *validator.ProfileValidator
 	rateLimiter           *middleware.ProfileRateLimiter
 }
@@ -33,6 +36,9 @@ func NewProfileHandler(
 	updateLocationUseCase *profile.UpdateLocationUseCase,
 	getMatchesUseCase *profile.GetMatchesUseCase,
 	deleteAccountUseCase *profile.DeleteAccountUseCase,
+	getPerformanceUseCase *profile.GetPerformanceUseCase,
+	manageFavoritesUseCase *profile.ManageFavoritesUseCase,
+	blockContactsUseCase *profile.BlockContactsUseCase,
 	profileValidator *validator.ProfileValidator,
 	rateLimiter *middleware.ProfileRateLimiter,
 ) *ProfileHandler {
@@ -43,6 +49,9 @@ func NewProfileHandler(
 		updateLocationUseCase:   updateLocationUseCase,
 		getMatchesUseCase:      getMatchesUseCase,
 		deleteAccountUseCase:   deleteAccountUseCase,
+		getPerformanceUseCase:  getPerformanceUseCase,
+		manageFavoritesUseCase: manageFavoritesUseCase,
+		blockContactsUseCase:   blockContactsUseCase,
 		profileValidator:        profileValidator,
 		rateLimiter:           rateLimiter,
 	}
@@ -148,6 +157,15 @@ func (h *ProfileHandler) UpdateProfile(c *gin.Context) {
 		LastName:     req.LastName,
 		Bio:          req.Bio,
 		InterestedIn: req.InterestedIn,
+		Interests:    req.Interests,
+		HeightCm:     req.HeightCm,
+		Smoking:      req.Smoking,
+		Drinking:     req.Drinking,
+		Locale:       req.Locale,
+		Timezone:     req.Timezone,
+		AutoTranslateMessages: req.AutoTranslateMessages,
+		SendReadReceipts: req.SendReadReceipts,
+		AutoIntro:    req.AutoIntro,
 		Preferences:   req.Preferences,
 	}
 
@@ -225,6 +243,324 @@ func (h *ProfileHandler) ViewUserProfile(c *gin.Context) {
 	utils.Success(c, http.StatusOK, profileResponse)
 }
 
+// PreviewProfile handles GET /users/me/preview endpoint - view own profile as others see it
+// @Summary Preview own public profile
+// @Description Get the current user's profile as it appears to other users, with the same privacy controls
+// @Tags profile
+// @Accept json
+// @Produce json
+// @Param Authorization header string true "Bearer token"
+// @Success 200 {object} dto.UserProfileResponseDTO
+// @Failure 401 {object} dto.ErrorDTO
+// @Failure 404 {object} dto.ErrorDTO
+// @Failure 500 {object} dto.ErrorDTO
+// @Router /api/v1/profile/users/me/preview [get]
+func (h *ProfileHandler) PreviewProfile(c *gin.Context) {
+	// Apply rate limiting
+	h.rateLimiter.RateLimit("view-profile")(c)
+	if c.IsAborted() {
+		return
+	}
+
+	// Extract user ID from context (set by auth middleware)
+	userIDStr, exists := c.Get("user_id")
+	if !exists {
+		utils.Unauthorized(c, "User not authenticated")
+		return
+	}
+
+	userID, err := uuid.Parse(userIDStr.(string))
+	if err != nil {
+		utils.Unauthorized(c, "Invalid user ID")
+		return
+	}
+
+	// Execute use case
+	response, err := h.viewUserProfileUseCase.Preview(c.Request.Context(), userID)
+	if err != nil {
+		utils.Error(c, err)
+		return
+	}
+
+	// Convert to DTO
+	profileResponse := &dto.UserProfileResponseDTO{
+		Success: true,
+		Data:    response,
+	}
+
+	utils.Success(c, http.StatusOK, profileResponse)
+}
+
+// GetPerformance handles GET /users/me/performance endpoint - how others respond to own profile
+// @Summary Get own profile performance
+// @Description Get how often other users like or pass on the current user's profile, compared with similar profiles, with suggestions to improve it. Only aggregates are returned.
+// @Tags profile
+// @Accept json
+// @Produce json
+// @Param Authorization header string true "Bearer token"
+// @Success 200 {object} dto.UserProfileResponseDTO
+// @Failure 401 {object} dto.ErrorDTO
+// @Failure 404 {object} dto.ErrorDTO
+// @Failure 500 {object} dto.ErrorDTO
+// @Router /api/v1/profile/users/me/performance [get]
+func (h *ProfileHandler) GetPerformance(c *gin.Context) {
+	// Apply rate limiting
+	h.rateLimiter.RateLimit("get-performance")(c)
+	if c.IsAborted() {
+		return
+	}
+
+	// Extract user ID from context (set by auth middleware)
+	userIDStr, exists := c.Get("user_id")
+	if !exists {
+		utils.Unauthorized(c, "User not authenticated")
+		return
+	}
+
+	userID, err := uuid.Parse(userIDStr.(string))
+	if err != nil {
+		utils.Unauthorized(c, "Invalid user ID")
+		return
+	}
+
+	// Execute use case
+	response, err := h.getPerformanceUseCase.Execute(c.Request.Context(), userID)
+	if err != nil {
+		utils.Error(c, err)
+		return
+	}
+
+	utils.Success(c, http.StatusOK, &dto.UserProfileResponseDTO{
+		Success: true,
+		Data:    response,
+	})
+}
+
+// AddFavorite handles POST /users/:id/favorite endpoint - save a profile to revisit
+// @Summary Favorite a user
+// @Description Save a user's profile to the current user's favorites. Favorites are private and do not affect matching or discovery. Favoriting a user again has no effect.
+// @Tags profile
+// @Accept json
+// @Produce json
+// @Param Authorization header string true "Bearer token"
+// @Param id path string true "User ID"
+// @Success 200 {object} dto.MessageResponseDTO
+// @Failure 400 {object} dto.ErrorDTO
+// @Failure 401 {object} dto.ErrorDTO
+// @Failure 402 {object} dto.ErrorDTO
+// @Failure 404 {object} dto.ErrorDTO
+// @Failure 422 {object} dto.ErrorDTO
+// @Failure 500 {object} dto.ErrorDTO
+// @Router /api/v1/profile/users/{id}/favorite [post]
+func (h *ProfileHandler) AddFavorite(c *gin.Context) {
+	// Apply rate limiting
+	h.rateLimiter.RateLimit("update-favorites")(c)
+	if c.IsAborted() {
+		return
+	}
+
+	// Extract user ID from context
+	userIDStr, exists := c.Get("user_id")
+	if !exists {
+		utils.Unauthorized(c, "User not authenticated")
+		return
+	}
+
+	userID, err := uuid.Parse(userIDStr.(string))
+	if err != nil {
+		utils.Unauthorized(c, "Invalid user ID")
+		return
+	}
+
+	// Extract favorited user ID from path
+	favoriteUserID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		utils.BadRequest(c, "Invalid user ID format")
+		return
+	}
+
+	// Execute use case
+	if err := h.manageFavoritesUseCase.Add(c.Request.Context(), userID, favoriteUserID); err != nil {
+		utils.Error(c, err)
+		return
+	}
+
+	utils.Success(c, http.StatusOK, &dto.MessageResponseDTO{
+		Success: true,
+		Message: "User added to favorites",
+	})
+}
+
+// BlockContacts handles POST /users/me/block-contacts endpoint - hide imported contacts from discovery
+// @Summary Block contacts
+// @Description Import hashed contacts to block. Each hash is the hex SHA-256 of a trimmed, lowercased email address or of an E.164 phone number. Contacts who are users are blocked both ways in discovery; the response never reveals which contacts are on the app.
+// @Tags profile
+// @Accept json
+// @Produce json
+// @Param Authorization header string true "Bearer token"
+// @Param request body dto.BlockContactsRequestDTO true "Block contacts request"
+// @Success 200 {object} dto.UserProfileResponseDTO
+// @Failure 400 {object} dto.ErrorDTO
+// @Failure 401 {object} dto.ErrorDTO
+// @Failure 429 {object} dto.ErrorDTO
+// @Failure 500 {object} dto.ErrorDTO
+// @Router /api/v1/profile/users/me/block-contacts [post]
+func (h *ProfileHandler) BlockContacts(c *gin.Context) {
+	// Apply rate limiting
+	h.rateLimiter.RateLimit("block-contacts")(c)
+	if c.IsAborted() {
+		return
+	}
+
+	// Extract user ID from context
+	userIDStr, exists := c.Get("user_id")
+	if !exists {
+		utils.Unauthorized(c, "User not authenticated")
+		return
+	}
+
+	userID, err := uuid.Parse(userIDStr.(string))
+	if err != nil {
+		utils.Unauthorized(c, "Invalid user ID")
+		return
+	}
+
+	var req dto.BlockContactsRequestDTO
+	if err := c.ShouldBindJSON(&req); err != nil {
+		utils.ErrorWithDetails(c, http.StatusBadRequest, "Invalid request format", err.Error())
+		return
+	}
+
+	// Execute use case
+	response, err := h.blockContactsUseCase.Execute(c.Request.Context(), &profile.BlockContactsRequest{
+		UserID:        userID,
+		ContactHashes: req.ContactHashes,
+	})
+	if err != nil {
+		utils.Error(c, err)
+		return
+	}
+
+	utils.Success(c, http.StatusOK, &dto.UserProfileResponseDTO{
+		Success: true,
+		Data:    response,
+	})
+}
+
+// RemoveFavorite handles DELETE /users/:id/favorite endpoint - remove a saved profile
+// @Summary Unfavorite a user
+// @Description Remove a user from the current user's favorites. Removing a user that is not a favorite has no effect.
+// @Tags profile
+// @Accept json
+// @Produce json
+// @Param Authorization header string true "Bearer token"
+// @Param id path string true "User ID"
+// @Success 200 {object} dto.MessageResponseDTO
+// @Failure 400 {object} dto.ErrorDTO
+// @Failure 401 {object} dto.ErrorDTO
+// @Failure 500 {object} dto.ErrorDTO
+// @Router /api/v1/profile/users/{id}/favorite [delete]
+func (h *ProfileHandler) RemoveFavorite(c *gin.Context) {
+	// Apply rate limiting
+	h.rateLimiter.RateLimit("update-favorites")(c)
+	if c.IsAborted() {
+		return
+	}
+
+	// Extract user ID from context
+	userIDStr, exists := c.Get("user_id")
+	if !exists {
+		utils.Unauthorized(c, "User not authenticated")
+		return
+	}
+
+	userID, err := uuid.Parse(userIDStr.(string))
+	if err != nil {
+		utils.Unauthorized(c, "Invalid user ID")
+		return
+	}
+
+	// Extract favorited user ID from path
+	favoriteUserID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		utils.BadRequest(c, "Invalid user ID format")
+		return
+	}
+
+	// Execute use case
+	if err := h.manageFavoritesUseCase.Remove(c.Request.Context(), userID, favoriteUserID); err != nil {
+		utils.Error(c, err)
+		return
+	}
+
+	utils.Success(c, http.StatusOK, &dto.MessageResponseDTO{
+		Success: true,
+		Message: "User removed from favorites",
+	})
+}
+
+// GetFavorites handles GET /users/me/favorites endpoint - list saved profiles
+// @Summary Get favorites
+// @Description Get the current user's favorites, most recently saved first. Users who were banned or deleted are left out.
+// @Tags profile
+// @Accept json
+// @Produce json
+// @Param Authorization header string true "Bearer token"
+// @Param limit query int false "Number of favorites to return" default(20)
+// @Param offset query int false "Pagination offset" default(0)
+// @Success 200 {object} dto.MatchesResponseDTO
+// @Failure 401 {object} dto.ErrorDTO
+// @Failure 500 {object} dto.ErrorDTO
+// @Router /api/v1/profile/users/me/favorites [get]
+func (h *ProfileHandler) GetFavorites(c *gin.Context) {
+	// Apply rate limiting
+	h.rateLimiter.RateLimit("get-favorites")(c)
+	if c.IsAborted() {
+		return
+	}
+
+	// Extract user ID from context
+	userIDStr, exists := c.Get("user_id")
+	if !exists {
+		utils.Unauthorized(c, "User not authenticated")
+		return
+	}
+
+	userID, err := uuid.Parse(userIDStr.(string))
+	if err != nil {
+		utils.Unauthorized(c, "Invalid user ID")
+		return
+	}
+
+	// Parse query parameters
+	limit, err := strconv.Atoi(c.DefaultQuery("limit", "20"))
+	if err != nil || limit <= 0 || limit > 100 {
+		limit = 20
+	}
+
+	offset, err := strconv.Atoi(c.DefaultQuery("offset", "0"))
+	if err != nil || offset < 0 {
+		offset = 0
+	}
+
+	// Execute use case
+	response, err := h.manageFavoritesUseCase.List(c.Request.Context(), userID, limit, offset)
+	if err != nil {
+		utils.Error(c, err)
+		return
+	}
+
+	utils.Success(c, http.StatusOK, &dto.MatchesResponseDTO{
+		Success: true,
+		Data:    response.Favorites,
+		Pagination: &dto.PaginationDTO{
+			Total:  int(response.Total),
+			Limit:  limit,
+			Offset: offset,
+		},
+	})
+}
+
 // UpdateLocation handles PUT /me/location endpoint - update location
 // @Summary Update user location
 // @Description Update the current user's location
@@ -0,0 +1,109 @@
+package handlers
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+
+	"github.com/22smeargle/winkr-backend/internal/application/services"
+	"github.com/22smeargle/winkr-backend/pkg/utils"
+)
+
+// SafetyHandler handles safety feature HTTP requests
+type SafetyHandler struct {
+	checkInService *services.SafetyCheckInService
+}
+
+// NewSafetyHandler creates a new safety handler
+func NewSafetyHandler(checkInService *services.SafetyCheckInService) *SafetyHandler {
+	return &SafetyHandler{
+		checkInService: checkInService,
+	}
+}
+
+// ScheduleCheckInRequest represents a request to schedule a safety check-in
+type ScheduleCheckInRequest struct {
+	DueAt          time.Time              `json:"due_at" binding:"required"`
+	Note           string                 `json:"note" binding:"max=500"`
+	TrustedContact *TrustedContactRequest `json:"trusted_contact"`
+}
+
+// TrustedContactRequest represents the person to alert if the user misses a check-in
+type TrustedContactRequest struct {
+	Name    string `json:"name" binding:"required,max=100"`
+	Email   string `json:"email" binding:"required,email,max=255"`
+	Consent bool   `json:"consent"` // The user agrees to share the check-in with the contact if it is missed
+}
+
+// ScheduleCheckIn handles POST /safety/check-in
+func (h *SafetyHandler) ScheduleCheckIn(c *gin.Context) {
+	userID, ok := h.getUserID(c)
+	if !ok {
+		return
+	}
+
+	var req ScheduleCheckInRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		utils.BadRequest(c, "Invalid request body")
+		return
+	}
+
+	input := services.ScheduleCheckInInput{
+		DueAt: req.DueAt,
+		Note:  req.Note,
+	}
+	if req.TrustedContact != nil {
+		input.ContactName = req.TrustedContact.Name
+		input.ContactEmail = req.TrustedContact.Email
+		input.AlertContact = req.TrustedContact.Consent
+	}
+
+	checkIn, err := h.checkInService.Schedule(c.Request.Context(), userID, input)
+	if err != nil {
+		utils.Error(c, err)
+		return
+	}
+
+	utils.Success(c, http.StatusCreated, checkIn)
+}
+
+// ConfirmCheckIn handles POST /safety/check-in/:id/confirm
+func (h *SafetyHandler) ConfirmCheckIn(c *gin.Context) {
+	userID, ok := h.getUserID(c)
+	if !ok {
+		return
+	}
+
+	checkInID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		utils.BadRequest(c, "Invalid check-in ID")
+		return
+	}
+
+	checkIn, err := h.checkInService.Confirm(c.Request.Context(), userID, checkInID)
+	if err != nil {
+		utils.Error(c, err)
+		return
+	}
+
+	utils.Success(c, http.StatusOK, checkIn)
+}
+
+// getUserID extracts the authenticated user ID, writing an error response if it is missing
+func (h *SafetyHandler) getUserID(c *gin.Context) (uuid.UUID, bool) {
+	userIDStr, exists := c.Get("user_id")
+	if !exists {
+		utils.Unauthorized(c, "User not authenticated")
+		return uuid.Nil, false
+	}
+
+	userID, err := uuid.Parse(userIDStr.(string))
+	if err != nil {
+		utils.Unauthorized(c, "Invalid user ID")
+		return uuid.Nil, false
+	}
+
+	return userID, true
+}
@@ -20,6 +20,7 @@ type VerificationHandler struct {
 	submitSelfieVerificationUseCase      *verification.SubmitSelfieVerificationUseCase
 	requestDocumentVerificationUseCase    *verification.RequestDocumentVerificationUseCase
 	submitDocumentVerificationUseCase     *verification.SubmitDocumentVerificationUseCase
+	submitDocumentSessionUseCase          *verification.SubmitDocumentSessionUseCase
 	getVerificationStatusUseCase          *verification.GetVerificationStatusUseCase
 	processVerificationResultUseCase      *verification.ProcessVerificationResultUseCase
 	getPendingVerificationsUseCase         *verification.GetPendingVerificationsUseCase
@@ -31,6 +32,7 @@ func NewVerificationHandler(
 	submitSelfieVerificationUseCase *verification.SubmitSelfieVerificationUseCase,
 	requestDocumentVerificationUseCase *verification.RequestDocumentVerificationUseCase,
 	submitDocumentVerificationUseCase *verification.SubmitDocumentVerificationUseCase,
+	submitDocumentSessionUseCase *verification.SubmitDocumentSessionUseCase,
 	getVerificationStatusUseCase *verification.GetVerificationStatusUseCase,
 	processVerificationResultUseCase *verification.ProcessVerificationResultUseCase,
 	getPendingVerificationsUseCase *verification.GetPendingVerificationsUseCase,
@@ -40,6 +42,7 @@ func NewVerificationHandler(
 		submitSelfieVerificationUseCase:      submitSelfieVerificationUseCase,
 		requestDocumentVerificationUseCase:    requestDocumentVerificationUseCase,
 		submitDocumentVerificationUseCase:     submitDocumentVerificationUseCase,
+		submitDocumentSessionUseCase:          submitDocumentSessionUseCase,
 		getVerificationStatusUseCase:          getVerificationStatusUseCase,
 		processVerificationResultUseCase:      processVerificationResultUseCase,
 		getPendingVerificationsUseCase:         getPendingVerificationsUseCase,
@@ -76,6 +79,10 @@ func (h *VerificationHandler) RequestSelfieVerification(c *gin.Context) {
 
 	// Execute use case
 	output, err := h.requestSelfieVerificationUseCase.Execute(c.Request.Context(), input)
+	if cooldownErr, ok := errors.GetVerificationCooldownError(err); ok {
+		utils.VerificationCooldown(c, cooldownErr)
+		return
+	}
 	if err != nil {
 		logger.Error("Failed to request selfie verification", err, "user_id", userUUID)
 		utils.ErrorResponse(c, http.StatusBadRequest, err.Error(), "")
@@ -150,6 +157,10 @@ func (h *VerificationHandler) RequestDocumentVerification(c *gin.Context) {
 
 	// Execute use case
 	output, err := h.requestDocumentVerificationUseCase.Execute(c.Request.Context(), input)
+	if cooldownErr, ok := errors.GetVerificationCooldownError(err); ok {
+		utils.VerificationCooldown(c, cooldownErr)
+		return
+	}
 	if err != nil {
 		logger.Error("Failed to request document verification", err, "user_id", userUUID)
 		utils.ErrorResponse(c, http.StatusBadRequest, err.Error(), "")
@@ -194,6 +205,41 @@ func (h *VerificationHandler) SubmitDocumentVerification(c *gin.Context) {
 	utils.SuccessResponse(c, http.StatusOK, output)
 }
 
+// SubmitDocumentSession handles multi-document verification submission
+func (h *VerificationHandler) SubmitDocumentSession(c *gin.Context) {
+	var input verification.SubmitDocumentSessionInput
+	if err := c.ShouldBindJSON(&input); err != nil {
+		logger.Error("Failed to bind document session submission", err)
+		utils.ErrorResponse(c, http.StatusBadRequest, "Invalid request format", err.Error())
+		return
+	}
+
+	// Get user ID from context
+	userID, exists := c.Get("user_id")
+	if !exists {
+		logger.Error("User ID not found in context")
+		utils.ErrorResponse(c, http.StatusUnauthorized, "User not authenticated", "")
+		return
+	}
+
+	userUUID, err := uuid.Parse(userID.(string))
+	if err != nil {
+		logger.Error("Invalid user ID in context", err)
+		utils.ErrorResponse(c, http.StatusUnauthorized, "Invalid user ID", "")
+		return
+	}
+
+	// Execute use case
+	output, err := h.submitDocumentSessionUseCase.Execute(c.Request.Context(), input)
+	if err != nil {
+		logger.Error("Failed to submit document session", err, "user_id", userUUID)
+		utils.ErrorResponse(c, http.StatusBadRequest, err.Error(), "")
+		return
+	}
+
+	utils.SuccessResponse(c, http.StatusOK, output)
+}
+
 // GetVerificationStatus handles getting verification status
 func (h *VerificationHandler) GetVerificationStatus(c *gin.Context) {
 	var input verification.GetVerificationStatusInput
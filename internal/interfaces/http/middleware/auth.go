@@ -54,6 +54,8 @@ func DefaultAuthConfig(jwtUtils *utils.JWTUtils) *AuthConfig {
 		JWTUtils: jwtUtils,
 		SkipPaths: []string{
 			"/health",
+			"/health/live",
+			"/health/ready",
 			"/health/db",
 			"/metrics",
 			"/api/v1/auth/login",
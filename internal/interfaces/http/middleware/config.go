@@ -4,6 +4,7 @@ import (
 	"time"
 
 	"github.com/go-redis/redis/v8"
+	infraredis "github.com/22smeargle/winkr-backend/internal/infrastructure/database/redis"
 	"github.com/22smeargle/winkr-backend/pkg/config"
 	"github.com/22smeargle/winkr-backend/pkg/utils"
 )
@@ -30,6 +31,9 @@ type MiddlewareConfig struct {
 	
 	// Security configuration
 	Security *SecurityConfig `json:"security"`
+	
+	// CSRF protection configuration
+	CSRF CSRFConfig `json:"-"`
 }
 
 // LoadMiddlewareConfig creates middleware configuration from application config
@@ -42,6 +46,7 @@ func LoadMiddlewareConfig(appConfig *config.Config, redisClient *redis.Client, j
 		ErrorHandler:  loadErrorHandlerConfig(appConfig),
 		Validation:    loadValidationConfig(appConfig),
 		Security:     loadSecurityConfig(appConfig),
+		CSRF:         loadCSRFConfig(appConfig, redisClient),
 	}
 }
 
@@ -110,6 +115,11 @@ func loadRateLimitConfig(appConfig *config.Config, redisClient *redis.Client) *R
 func loadAuthConfig(appConfig *config.Config, jwtUtils *utils.JWTUtils) *AuthConfig {
 	config := DefaultAuthConfig(jwtUtils)
 	
+	// Prometheus scrapes without credentials, wherever the metrics are served
+	if metricsPath := appConfig.Monitoring.Metrics.Prometheus.Path; metricsPath != "" && metricsPath != "/metrics" {
+		config.SkipPaths = append(config.SkipPaths, metricsPath)
+	}
+	
 	// Adjust based on environment
 	if appConfig.App.Env == "development" {
 		// More relaxed auth for development
@@ -177,6 +187,23 @@ func loadSecurityConfig(appConfig *config.Config) *SecurityConfig {
 	return config
 }
 
+// loadCSRFConfig creates CSRF protection configuration from app config
+func loadCSRFConfig(appConfig *config.Config, redisClient *redis.Client) CSRFConfig {
+	config := DefaultCSRFConfig()
+	config.Enabled = appConfig.Security.CSRFProtection
+	if appConfig.Security.CSRFSessionCookie != "" {
+		config.SessionCookieName = appConfig.Security.CSRFSessionCookie
+	}
+	if appConfig.Security.CSRFTokenRotation > 0 {
+		config.RotationInterval = appConfig.Security.CSRFTokenRotation
+	}
+	if redisClient != nil {
+		config.Store = NewRedisCSRFTokenStore(&infraredis.RedisClient{Client: redisClient})
+	}
+	
+	return config
+}
+
 // DevelopmentConfig returns middleware configuration for development environment
 func DevelopmentConfig(redisClient *redis.Client, jwtUtils *utils.JWTUtils) *MiddlewareConfig {
 	return &MiddlewareConfig{
@@ -43,10 +43,12 @@ func DefaultCORSConfig() *CORSConfig {
 			"Authorization",
 			"X-Requested-With",
 			"X-Request-ID",
+			"X-Correlation-ID",
 		},
 		ExposedHeaders: []string{
 			"Content-Length",
 			"X-Request-ID",
+			"X-Correlation-ID",
 		},
 		AllowCredentials: false,
 		MaxAge:           86400, // 24 hours
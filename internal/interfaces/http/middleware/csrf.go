@@ -1,30 +1,47 @@
 package middleware
 
 import (
+	"context"
 	"crypto/rand"
+	"crypto/subtle"
 	"encoding/base64"
+	"fmt"
 	"net/http"
 	"strings"
+	"time"
 
 	"github.com/gin-gonic/gin"
+	goredis "github.com/go-redis/redis/v8"
+	"github.com/22smeargle/winkr-backend/internal/infrastructure/database/redis"
 	"github.com/22smeargle/winkr-backend/pkg/errors"
+	"github.com/22smeargle/winkr-backend/pkg/logger"
 	"github.com/22smeargle/winkr-backend/pkg/utils"
 )
 
 // CSRFConfig represents CSRF protection configuration
 type CSRFConfig struct {
-	Enabled          bool
-	TokenLength      int
-	CookieName       string
-	HeaderName       string
-	FormFieldName    string
-	SecureCookie     bool
-	SameSitePolicy   string
-	ExcludedMethods  []string
-	ExcludedPaths    []string
-}
-
-// CSRFMiddleware provides CSRF protection
+	Enabled           bool
+	TokenLength       int
+	HeaderName        string
+	SessionCookieName string        // Cookie carrying the session of cookie-based clients
+	RotationInterval  time.Duration // How long a token is valid before a new one must be fetched
+	ExcludedMethods   []string
+	ExcludedPaths     []string
+	Store             CSRFTokenStore
+}
+
+// CSRFTokenStore keeps the CSRF token of each session server-side
+type CSRFTokenStore interface {
+	// Get returns the session's token and how long it remains valid, or an empty token if it has none
+	Get(ctx context.Context, sessionID string) (string, time.Duration, error)
+	// Save stores the session's token for ttl, replacing any previous one
+	Save(ctx context.Context, sessionID, token string, ttl time.Duration) error
+}
+
+// CSRFMiddleware protects cookie-based sessions from cross-site request forgery. Each session has
+// one token, issued by GET /auth/csrf and kept server-side, which state-changing requests must echo
+// in the X-CSRF-Token header. Requests authenticated with a bearer token carry no ambient
+// credentials, so they are not checked.
 type CSRFMiddleware struct {
 	config CSRFConfig
 }
@@ -35,17 +52,17 @@ func NewCSRFMiddleware(config CSRFConfig) *CSRFMiddleware {
 	if config.TokenLength == 0 {
 		config.TokenLength = 32
 	}
-	if config.CookieName == "" {
-		config.CookieName = "csrf_token"
-	}
 	if config.HeaderName == "" {
 		config.HeaderName = "X-CSRF-Token"
 	}
-	if config.FormFieldName == "" {
-		config.FormFieldName = "csrf_token"
+	if config.SessionCookieName == "" {
+		config.SessionCookieName = "session_id"
+	}
+	if config.RotationInterval == 0 {
+		config.RotationInterval = time.Hour
 	}
-	if config.SameSitePolicy == "" {
-		config.SameSitePolicy = "Strict"
+	if len(config.ExcludedMethods) == 0 {
+		config.ExcludedMethods = []string{"GET", "HEAD", "OPTIONS"}
 	}
 
 	return &CSRFMiddleware{
@@ -63,93 +80,114 @@ func (csrf *CSRFMiddleware) GenerateToken() (string, error) {
 	return base64.URLEncoding.EncodeToString(bytes), nil
 }
 
+// IssueToken returns the session's current token, generating a new one if it has none or the last
+// one was rotated out, along with how long the token remains valid
+func (csrf *CSRFMiddleware) IssueToken(ctx context.Context, sessionID string) (string, time.Duration, error) {
+	if csrf.config.Store == nil {
+		return "", 0, fmt.Errorf("no CSRF token store configured")
+	}
+
+	token, ttl, err := csrf.config.Store.Get(ctx, sessionID)
+	if err != nil {
+		return "", 0, err
+	}
+	if token != "" {
+		return token, ttl, nil
+	}
+
+	token, err = csrf.GenerateToken()
+	if err != nil {
+		return "", 0, fmt.Errorf("failed to generate CSRF token: %w", err)
+	}
+	if err := csrf.config.Store.Save(ctx, sessionID, token, csrf.config.RotationInterval); err != nil {
+		return "", 0, err
+	}
+	return token, csrf.config.RotationInterval, nil
+}
+
 // Middleware returns the CSRF middleware function
 func (csrf *CSRFMiddleware) Middleware() gin.HandlerFunc {
 	return func(c *gin.Context) {
-		// Skip CSRF protection if disabled
-		if !csrf.config.Enabled {
+		sessionID, ok := csrf.protectedSession(c)
+		if !ok {
 			c.Next()
 			return
 		}
 
-		// Skip for excluded methods
-		method := c.Request.Method
-		for _, excludedMethod := range csrf.config.ExcludedMethods {
-			if strings.ToUpper(method) == strings.ToUpper(excludedMethod) {
-				c.Next()
-				return
-			}
+		valid, err := csrf.validateToken(c.Request.Context(), sessionID, c.GetHeader(csrf.config.HeaderName))
+		if err != nil {
+			logger.Error("Failed to validate CSRF token", err, map[string]interface{}{
+				"path":   c.Request.URL.Path,
+				"method": c.Request.Method,
+			})
+			utils.Error(c, errors.NewInternalError("Failed to validate CSRF token"))
+			c.Abort()
+			return
 		}
-
-		// Skip for excluded paths
-		path := c.Request.URL.Path
-		for _, excludedPath := range csrf.config.ExcludedPaths {
-			if strings.HasPrefix(path, excludedPath) {
-				c.Next()
-				return
-			}
+		if !valid {
+			utils.Error(c, errors.ErrCSRFTokenInvalid)
+			c.Abort()
+			return
 		}
 
-		// For GET requests, generate and set CSRF token
-		if method == "GET" || method == "HEAD" || method == "OPTIONS" {
-			token, err := csrf.GenerateToken()
-			if err != nil {
-				utils.Error(c, errors.NewInternalError("Failed to generate CSRF token"))
-				c.Abort()
-				return
-			}
-
-			// Set CSRF token in cookie
-			c.SetCookie(csrf.config.CookieName, token, "/", "", true, csrf.config.SecureCookie, true, csrf.config.SameSitePolicy)
-			
-			// Also set token in context for easy access in templates
-			c.Set("csrf_token", token)
-			c.Next()
-			return
+		c.Next()
+	}
+}
+
+// protectedSession returns the session of a request that must carry a CSRF token. Safe methods,
+// excluded paths, bearer-token calls and requests without a session cookie are not protected.
+func (csrf *CSRFMiddleware) protectedSession(c *gin.Context) (string, bool) {
+	if !csrf.config.Enabled {
+		return "", false
+	}
+
+	// Skip for excluded methods
+	method := c.Request.Method
+	for _, excludedMethod := range csrf.config.ExcludedMethods {
+		if strings.EqualFold(method, excludedMethod) {
+			return "", false
 		}
+	}
 
-		// For state-changing requests (POST, PUT, DELETE, PATCH), validate CSRF token
-		if method == "POST" || method == "PUT" || method == "DELETE" || method == "PATCH" {
-			if !csrf.validateToken(c) {
-				utils.Error(c, errors.NewForbiddenError("CSRF token validation failed"))
-				c.Abort()
-				return
-			}
+	// Skip for excluded paths
+	path := c.Request.URL.Path
+	for _, excludedPath := range csrf.config.ExcludedPaths {
+		if strings.HasPrefix(path, excludedPath) {
+			return "", false
 		}
+	}
 
-		c.Next()
+	// Browsers never attach an Authorization header on their own, so bearer calls can't be forged
+	if strings.HasPrefix(c.GetHeader("Authorization"), "Bearer ") {
+		return "", false
 	}
-}
 
-// validateToken validates the CSRF token for the current request
-func (csrf *CSRFMiddleware) validateToken(c *gin.Context) bool {
-	// Get token from cookie
-	cookieToken, err := c.Cookie(csrf.config.CookieName)
-	if err != nil {
-		return false
+	sessionID, err := c.Cookie(csrf.config.SessionCookieName)
+	if err != nil || sessionID == "" {
+		return "", false
 	}
+	return sessionID, true
+}
 
-	// Get token from header or form
-	var requestToken string
-	
-	// Try header first
-	requestToken = c.GetHeader(csrf.config.HeaderName)
-	
-	// If not in header, try form field
+// validateToken checks the request token against the token stored for the session
+func (csrf *CSRFMiddleware) validateToken(ctx context.Context, sessionID, requestToken string) (bool, error) {
 	if requestToken == "" {
-		requestToken = c.PostForm(csrf.config.FormFieldName)
+		return false, nil
+	}
+	if csrf.config.Store == nil {
+		return false, fmt.Errorf("no CSRF token store configured")
 	}
 
-	// If still not found, try query parameter
-	if requestToken == "" {
-		requestToken = c.Query(csrf.config.FormFieldName)
+	token, _, err := csrf.config.Store.Get(ctx, sessionID)
+	if err != nil {
+		return false, err
 	}
 
 	// Compare tokens
-	return requestToken != "" && requestToken == cookieToken
+	return token != "" && subtle.ConstantTimeCompare([]byte(token), []byte(requestToken)) == 1, nil
 }
 
-// GetToken returns the CSRF token from the context
+// GetCSRFToken returns the CSRF token from the context
 func GetCSRFToken(c *gin.Context) string {
 	if token, exists := c.Get("csrf_token"); exists {
 		return token.(string)
@@ -166,41 +204,91 @@ func SetCSRFHeaders(c *gin.Context) {
 
 // CSRFTokenResponse represents CSRF token response
 type CSRFTokenResponse struct {
-	Success bool   `json:"success"`
-	Token   string `json:"token"`
-	Error   *errors.AppError `json:"error,omitempty"`
+	Token      string    `json:"token"`
+	HeaderName string    `json:"header_name"`
+	ExpiresAt  time.Time `json:"expires_at"`
 }
 
-// GetCSRFTokenHandler returns a handler to get CSRF token
+// GetCSRFTokenHandler returns a handler issuing the CSRF token of the caller's session
 func GetCSRFTokenHandler(csrf *CSRFMiddleware) gin.HandlerFunc {
 	return func(c *gin.Context) {
-		token, err := csrf.GenerateToken()
+		sessionID, err := c.Cookie(csrf.config.SessionCookieName)
+		if err != nil || sessionID == "" {
+			utils.Unauthorized(c, "CSRF tokens are only issued to cookie sessions")
+			return
+		}
+
+		token, ttl, err := csrf.IssueToken(c.Request.Context(), sessionID)
 		if err != nil {
+			logger.Error("Failed to issue CSRF token", err)
 			utils.Error(c, errors.NewInternalError("Failed to generate CSRF token"))
 			return
 		}
 
-		response := &CSRFTokenResponse{
-			Success: true,
-			Token:   token,
-		}
+		c.Set("csrf_token", token)
+		c.Header("Cache-Control", "no-store")
+		utils.Success(c, http.StatusOK, &CSRFTokenResponse{
+			Token:      token,
+			HeaderName: csrf.config.HeaderName,
+			ExpiresAt:  time.Now().Add(ttl),
+		})
+	}
+}
 
-		utils.Success(c, response)
+// RedisCSRFTokenStore keeps CSRF tokens in Redis, so every instance accepts the same token
+type RedisCSRFTokenStore struct {
+	redisClient *redis.RedisClient
+	prefix      string
+}
+
+// NewRedisCSRFTokenStore creates a new Redis CSRF token store
+func NewRedisCSRFTokenStore(redisClient *redis.RedisClient) *RedisCSRFTokenStore {
+	return &RedisCSRFTokenStore{
+		redisClient: redisClient,
+		prefix:      "csrf:",
+	}
+}
+
+// Get returns the session's token and how long it remains valid
+func (s *RedisCSRFTokenStore) Get(ctx context.Context, sessionID string) (string, time.Duration, error) {
+	key := s.prefix + sessionID
+	token, err := s.redisClient.GetClient().Get(ctx, key).Result()
+	if err == goredis.Nil {
+		return "", 0, nil
+	}
+	if err != nil {
+		return "", 0, fmt.Errorf("failed to get CSRF token: %w", err)
+	}
+
+	ttl, err := s.redisClient.GetClient().PTTL(ctx, key).Result()
+	if err != nil {
+		return "", 0, fmt.Errorf("failed to get CSRF token expiry: %w", err)
 	}
+	// The token expired between the two calls
+	if ttl <= 0 {
+		return "", 0, nil
+	}
+	return token, ttl, nil
+}
+
+// Save stores the session's token for ttl
+func (s *RedisCSRFTokenStore) Save(ctx context.Context, sessionID, token string, ttl time.Duration) error {
+	if err := s.redisClient.GetClient().Set(ctx, s.prefix+sessionID, token, ttl).Err(); err != nil {
+		return fmt.Errorf("failed to save CSRF token: %w", err)
+	}
+	return nil
 }
 
 // DefaultCSRFConfig returns a default CSRF configuration
 func DefaultCSRFConfig() CSRFConfig {
 	return CSRFConfig{
-		Enabled:         true,
-		TokenLength:     32,
-		CookieName:      "csrf_token",
-		HeaderName:      "X-CSRF-Token",
-		FormFieldName:   "csrf_token",
-		SecureCookie:    true,
-		SameSitePolicy:  "Strict",
-		ExcludedMethods: []string{"GET", "HEAD", "OPTIONS"},
-		ExcludedPaths:   []string{
+		Enabled:           true,
+		TokenLength:       32,
+		HeaderName:        "X-CSRF-Token",
+		SessionCookieName: "session_id",
+		RotationInterval:  time.Hour,
+		ExcludedMethods:   []string{"GET", "HEAD", "OPTIONS"},
+		ExcludedPaths: []string{
 			"/api/v1/auth/login",
 			"/api/v1/auth/register",
 			"/api/v1/auth/refresh",
@@ -209,11 +297,3 @@ func DefaultCSRFConfig() CSRFConfig {
 		},
 	}
 }
-
-// DevelopmentCSRFConfig returns a development-friendly CSRF configuration
-func DevelopmentCSRFConfig() CSRFConfig {
-	config := DefaultCSRFConfig()
-	config.SecureCookie = false
-	config.SameSitePolicy = "Lax"
-	return config
-}
\ No newline at end of file
@@ -109,6 +109,11 @@ func handlePanic(c *gin.Context, recovered interface{}, config *ErrorHandlerConf
 			}
 		}
 
+		// Tie the entry to the work the request caused
+		if correlationID := logger.CorrelationIDFromContext(c.Request.Context()); correlationID != "" {
+			fields[logger.CorrelationIDField] = correlationID
+		}
+
 		if config.EnableStackTrace {
 			fields["stack_trace"] = string(debug.Stack())
 		}
@@ -232,6 +237,10 @@ func logError(c *gin.Context, err error, config *ErrorHandlerConfig) {
 		}
 	}
 
+	if correlationID := logger.CorrelationIDFromContext(c.Request.Context()); correlationID != "" {
+		fields[logger.CorrelationIDField] = correlationID
+	}
+
 	// Add request details if enabled
 	if config.LogRequests {
 		fields["user_agent"] = c.Request.UserAgent()
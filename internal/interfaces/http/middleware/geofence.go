@@ -0,0 +1,67 @@
+package middleware
+
+import (
+	"context"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/22smeargle/winkr-backend/internal/application/services"
+	"github.com/22smeargle/winkr-backend/pkg/logger"
+	"github.com/22smeargle/winkr-backend/pkg/utils"
+)
+
+// GeofenceChecker decides whether a request may be served from its location
+type GeofenceChecker interface {
+	Check(ctx context.Context, req *services.GeofenceRequest) (*services.GeofenceDecision, error)
+}
+
+// GeofenceMiddleware blocks requests from restricted countries and regions
+type GeofenceMiddleware struct {
+	checker       GeofenceChecker
+	countryHeader string
+	regionHeader  string
+}
+
+// NewGeofenceMiddleware creates a new GeofenceMiddleware.
+// The headers carry the client IP location resolved by the edge proxy.
+func NewGeofenceMiddleware(checker GeofenceChecker, countryHeader, regionHeader string) *GeofenceMiddleware {
+	return &GeofenceMiddleware{
+		checker:       checker,
+		countryHeader: countryHeader,
+		regionHeader:  regionHeader,
+	}
+}
+
+// Restrict rejects the request with a region_restricted error when it comes from a restricted location.
+// On authenticated routes it must run after the auth middleware so the profile location is checked too.
+func (m *GeofenceMiddleware) Restrict() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		req := &services.GeofenceRequest{
+			IPCountry: c.GetHeader(m.countryHeader),
+			IPRegion:  c.GetHeader(m.regionHeader),
+		}
+
+		if userIDStr, exists := c.Get("user_id"); exists {
+			if userID, err := uuid.Parse(userIDStr.(string)); err == nil {
+				req.UserID = &userID
+			}
+		}
+
+		decision, err := m.checker.Check(c.Request.Context(), req)
+		if err != nil {
+			logger.Error("Geofence check failed", err, "ip", c.ClientIP())
+			utils.Error(c, err)
+			c.Abort()
+			return
+		}
+
+		if !decision.Allowed {
+			logger.Info("Request blocked by geofencing", "ip", c.ClientIP(), "source", decision.Source, "location", decision.Location)
+			utils.RegionRestricted(c, "This service is not available in your region")
+			c.Abort()
+			return
+		}
+
+		c.Next()
+	}
+}
@@ -0,0 +1,63 @@
+package middleware
+
+import (
+	"context"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/22smeargle/winkr-backend/pkg/logger"
+	"github.com/22smeargle/winkr-backend/pkg/utils"
+)
+
+// NoticeGate decides whether a user may perform an action before acknowledging the legal notices
+type NoticeGate interface {
+	CanPerform(ctx context.Context, userID uuid.UUID, action string) (bool, error)
+}
+
+// LegalNoticeMiddleware blocks gated actions until the required legal notices are acknowledged
+type LegalNoticeMiddleware struct {
+	gate NoticeGate
+}
+
+// NewLegalNoticeMiddleware creates a new LegalNoticeMiddleware
+func NewLegalNoticeMiddleware(gate NoticeGate) *LegalNoticeMiddleware {
+	return &LegalNoticeMiddleware{
+		gate: gate,
+	}
+}
+
+// RequireAcknowledged rejects the request with a notices_not_acknowledged error when the action is gated
+// and the user still has required notices to acknowledge. It must run after the auth middleware.
+func (m *LegalNoticeMiddleware) RequireAcknowledged(action string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		userIDStr, exists := c.Get("user_id")
+		if !exists {
+			utils.Unauthorized(c, "User not authenticated")
+			c.Abort()
+			return
+		}
+
+		userID, err := uuid.Parse(userIDStr.(string))
+		if err != nil {
+			utils.Unauthorized(c, "Invalid user ID")
+			c.Abort()
+			return
+		}
+
+		allowed, err := m.gate.CanPerform(c.Request.Context(), userID, action)
+		if err != nil {
+			logger.Error("Legal notice check failed", err, "user_id", userID, "action", action)
+			utils.Error(c, err)
+			c.Abort()
+			return
+		}
+
+		if !allowed {
+			utils.NoticesRequired(c, "Please review and acknowledge the community guidelines to continue")
+			c.Abort()
+			return
+		}
+
+		c.Next()
+	}
+}
@@ -42,6 +42,8 @@ func DefaultLoggingConfig() *LoggingConfig {
 	return &LoggingConfig{
 		SkipPaths: []string{
 			"/health",
+			"/health/live",
+			"/health/ready",
 			"/health/db",
 			"/metrics",
 		},
@@ -125,6 +127,11 @@ func Logging(config *LoggingConfig) gin.HandlerFunc {
 			"response_size":  responseWriter.body.Len(),
 		}
 
+		// Tie the entry to the work the request caused
+		if correlationID := logger.CorrelationIDFromContext(c.Request.Context()); correlationID != "" {
+			fields[logger.CorrelationIDField] = correlationID
+		}
+
 		// Add user information if available
 		if userID, exists := c.Get("user_id"); exists {
 			fields["user_id"] = userID
@@ -240,6 +247,56 @@ func RequestID(headerName string) gin.HandlerFunc {
 	}
 }
 
+// CorrelationIDHeader is the header carrying the ID that ties a request to the work it causes
+const CorrelationIDHeader = "X-Correlation-ID"
+
+// maxCorrelationIDLength bounds the length of a correlation ID accepted from a client
+const maxCorrelationIDLength = 128
+
+// CorrelationID returns a middleware that tags each request with a correlation ID. An ID sent by
+// the client is kept so a trace can span services, otherwise a new one is generated. The ID is
+// stored in the request context, where logger.WithContext and logger.DetachContext pick it up,
+// and echoed in the response header.
+func CorrelationID(headerName string) gin.HandlerFunc {
+	if headerName == "" {
+		headerName = CorrelationIDHeader
+	}
+
+	return func(c *gin.Context) {
+		setCorrelationID(c, headerName)
+		c.Next()
+	}
+}
+
+// setCorrelationID tags the request with the client's correlation ID or a new one, and returns it
+func setCorrelationID(c *gin.Context, headerName string) string {
+	correlationID := c.GetHeader(headerName)
+	if !isValidCorrelationID(correlationID) {
+		correlationID = uuid.New().String()
+	}
+
+	c.Set("correlation_id", correlationID)
+	c.Request = c.Request.WithContext(logger.ContextWithCorrelationID(c.Request.Context(), correlationID))
+	c.Header(headerName, correlationID)
+	return correlationID
+}
+
+// isValidCorrelationID reports whether a client-supplied correlation ID is safe to log and echo
+func isValidCorrelationID(id string) bool {
+	if id == "" || len(id) > maxCorrelationIDLength {
+		return false
+	}
+	for _, r := range id {
+		switch {
+		case r >= 'a' && r <= 'z', r >= 'A' && r <= 'Z', r >= '0' && r <= '9':
+		case r == '-', r == '_', r == '.', r == ':':
+		default:
+			return false
+		}
+	}
+	return true
+}
+
 // RequestLogger returns a simple request logger middleware
 func RequestLogger() gin.HandlerFunc {
 	return Logging(DefaultLoggingConfig())
@@ -1,8 +1,11 @@
 package middleware
 
 import (
+	"context"
+	"encoding/json"
 	"net/http"
 	"net/http/httptest"
+	"strings"
 	"testing"
 	"time"
 
@@ -10,7 +13,9 @@ import (
 	"github.com/go-redis/redis/v8"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
+	"github.com/22smeargle/winkr-backend/internal/application/services"
 	"github.com/22smeargle/winkr-backend/pkg/config"
+	"github.com/22smeargle/winkr-backend/pkg/logger"
 	"github.com/22smeargle/winkr-backend/pkg/utils"
 )
 
@@ -85,6 +90,46 @@ func TestRequestID(t *testing.T) {
 	assert.NotEmpty(t, w.Header().Get("X-Request-ID"))
 }
 
+func TestCorrelationID(t *testing.T) {
+	// Create Gin router with CorrelationID middleware
+	router := gin.New()
+	router.Use(CorrelationID(CorrelationIDHeader))
+
+	// The handler sees the ID in the request context and in a context detached from it
+	var fromContext, fromDetached string
+	router.GET("/test", func(c *gin.Context) {
+		fromContext = logger.CorrelationIDFromContext(c.Request.Context())
+		fromDetached = logger.CorrelationIDFromContext(logger.DetachContext(c.Request.Context()))
+		c.Status(http.StatusOK)
+	})
+
+	// Test a client-supplied ID is kept and echoed
+	req, _ := http.NewRequest("GET", "/test", nil)
+	req.Header.Set(CorrelationIDHeader, "trace-123")
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, "trace-123", w.Header().Get(CorrelationIDHeader))
+	assert.Equal(t, "trace-123", fromContext)
+	assert.Equal(t, "trace-123", fromDetached)
+
+	// Test an ID is generated when none or an unsafe one is sent
+	for _, sent := range []string{"", "bad id <script>", strings.Repeat("a", 200)} {
+		req, _ = http.NewRequest("GET", "/test", nil)
+		if sent != "" {
+			req.Header.Set(CorrelationIDHeader, sent)
+		}
+		w = httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+
+		generated := w.Header().Get(CorrelationIDHeader)
+		assert.NotEmpty(t, generated)
+		assert.NotEqual(t, sent, generated)
+		assert.Equal(t, generated, fromContext)
+	}
+}
+
 func TestSecurityHeaders(t *testing.T) {
 	// Create Gin router with Security middleware
 	router := gin.New()
@@ -316,6 +361,232 @@ func TestTestingConfig(t *testing.T) {
 	assert.False(t, testConfig.Security.RequireHTTPS)
 }
 
+func TestGeofenceMiddleware(t *testing.T) {
+	allowedUserID := "6f1c2b9e-3d4a-4f5b-8c7d-9e0f1a2b3c4d"
+	geofenceService := services.NewGeofenceService(nil, &config.GeofencingConfig{
+		Enabled:             true,
+		RestrictedCountries: []string{"KP"},
+		AllowedUserIDs:      []string{allowedUserID},
+	})
+
+	// Create Gin router with Geofence middleware
+	router := gin.New()
+	router.Use(func(c *gin.Context) {
+		if userID := c.GetHeader("X-Test-User-ID"); userID != "" {
+			c.Set("user_id", userID)
+		}
+		c.Next()
+	})
+	router.Use(NewGeofenceMiddleware(geofenceService, "CF-IPCountry", "X-Geo-Region").Restrict())
+
+	// Add test route
+	router.POST("/register", func(c *gin.Context) {
+		c.JSON(http.StatusOK, gin.H{"message": "registered"})
+	})
+
+	// Test request from a restricted country
+	req, _ := http.NewRequest("POST", "/register", nil)
+	req.Header.Set("CF-IPCountry", "KP")
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusUnavailableForLegalReasons, w.Code)
+	assert.Contains(t, w.Body.String(), "region_restricted")
+
+	// Test request from an unrestricted country
+	req2, _ := http.NewRequest("POST", "/register", nil)
+	req2.Header.Set("CF-IPCountry", "DE")
+	w2 := httptest.NewRecorder()
+	router.ServeHTTP(w2, req2)
+	assert.Equal(t, http.StatusOK, w2.Code)
+
+	// Test allowlisted account from a restricted country
+	req3, _ := http.NewRequest("POST", "/register", nil)
+	req3.Header.Set("CF-IPCountry", "KP")
+	req3.Header.Set("X-Test-User-ID", allowedUserID)
+	w3 := httptest.NewRecorder()
+	router.ServeHTTP(w3, req3)
+	assert.Equal(t, http.StatusOK, w3.Code)
+}
+
+// memoryCSRFTokenStore keeps CSRF tokens in memory for tests
+type memoryCSRFTokenStore struct {
+	tokens map[string]string
+}
+
+func (s *memoryCSRFTokenStore) Get(ctx context.Context, sessionID string) (string, time.Duration, error) {
+	return s.tokens[sessionID], time.Hour, nil
+}
+
+func (s *memoryCSRFTokenStore) Save(ctx context.Context, sessionID, token string, ttl time.Duration) error {
+	s.tokens[sessionID] = token
+	return nil
+}
+
+func TestCSRFMiddleware(t *testing.T) {
+	config := DefaultCSRFConfig()
+	config.Store = &memoryCSRFTokenStore{tokens: map[string]string{}}
+	csrf := NewCSRFMiddleware(config)
+
+	// Create Gin router with CSRF middleware
+	router := gin.New()
+	router.Use(csrf.Middleware())
+	router.GET("/api/v1/auth/csrf", GetCSRFTokenHandler(csrf))
+	router.POST("/api/v1/auth/logout", func(c *gin.Context) {
+		c.JSON(http.StatusOK, gin.H{"message": "logged out"})
+	})
+
+	sessionCookie := &http.Cookie{Name: "session_id", Value: "session-1"}
+
+	// Test token issuance for a cookie session
+	req, _ := http.NewRequest("GET", "/api/v1/auth/csrf", nil)
+	req.AddCookie(sessionCookie)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusOK, w.Code)
+
+	var response struct {
+		Data CSRFTokenResponse `json:"data"`
+	}
+	assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+	token := response.Data.Token
+	assert.NotEmpty(t, token)
+
+	// Test the token is kept until it is rotated
+	req2, _ := http.NewRequest("GET", "/api/v1/auth/csrf", nil)
+	req2.AddCookie(sessionCookie)
+	w2 := httptest.NewRecorder()
+	router.ServeHTTP(w2, req2)
+	assert.Contains(t, w2.Body.String(), token)
+
+	// Test state-changing request without a token
+	req3, _ := http.NewRequest("POST", "/api/v1/auth/logout", nil)
+	req3.AddCookie(sessionCookie)
+	w3 := httptest.NewRecorder()
+	router.ServeHTTP(w3, req3)
+	assert.Equal(t, http.StatusForbidden, w3.Code)
+
+	// Test state-changing request with another session's token
+	req4, _ := http.NewRequest("POST", "/api/v1/auth/logout", nil)
+	req4.AddCookie(&http.Cookie{Name: "session_id", Value: "session-2"})
+	req4.Header.Set("X-CSRF-Token", token)
+	w4 := httptest.NewRecorder()
+	router.ServeHTTP(w4, req4)
+	assert.Equal(t, http.StatusForbidden, w4.Code)
+
+	// Test state-changing request with the session's token
+	req5, _ := http.NewRequest("POST", "/api/v1/auth/logout", nil)
+	req5.AddCookie(sessionCookie)
+	req5.Header.Set("X-CSRF-Token", token)
+	w5 := httptest.NewRecorder()
+	router.ServeHTTP(w5, req5)
+	assert.Equal(t, http.StatusOK, w5.Code)
+
+	// Test bearer-token calls are not checked
+	req6, _ := http.NewRequest("POST", "/api/v1/auth/logout", nil)
+	req6.AddCookie(sessionCookie)
+	req6.Header.Set("Authorization", "Bearer test-token")
+	w6 := httptest.NewRecorder()
+	router.ServeHTTP(w6, req6)
+	assert.Equal(t, http.StatusOK, w6.Code)
+
+	// Test tokens are only issued to cookie sessions
+	req7, _ := http.NewRequest("GET", "/api/v1/auth/csrf", nil)
+	w7 := httptest.NewRecorder()
+	router.ServeHTTP(w7, req7)
+	assert.Equal(t, http.StatusUnauthorized, w7.Code)
+}
+
+func TestPrometheusMetrics(t *testing.T) {
+	metrics := NewHTTPMetrics(&config.PrometheusConfig{Namespace: "winkr", Subsystem: "backend"})
+
+	// Create Gin router with the metrics middleware
+	router := gin.New()
+	router.Use(metrics.Middleware())
+	router.GET("/api/v1/users/:id", func(c *gin.Context) {
+		c.JSON(http.StatusOK, gin.H{"id": c.Param("id")})
+	})
+	router.GET("/metrics", metrics.Handler())
+
+	for _, path := range []string{"/api/v1/users/1", "/api/v1/users/2", "/unknown"} {
+		req, _ := http.NewRequest("GET", path, nil)
+		router.ServeHTTP(httptest.NewRecorder(), req)
+	}
+
+	req, _ := http.NewRequest("GET", "/metrics", nil)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Contains(t, w.Header().Get("Content-Type"), "text/plain")
+
+	body := w.Body.String()
+
+	// Test requests are labeled by route template, not raw path
+	assert.Contains(t, body, `winkr_backend_http_requests_total{method="GET",route="/api/v1/users/:id",status="200"} 2`)
+	assert.NotContains(t, body, "/api/v1/users/1")
+	assert.Contains(t, body, `winkr_backend_http_requests_total{method="GET",route="unmatched",status="404"} 1`)
+
+	// Test the duration histogram
+	assert.Contains(t, body, "# TYPE winkr_backend_http_request_duration_seconds histogram")
+	assert.Contains(t, body, `winkr_backend_http_request_duration_seconds_bucket{method="GET",route="/api/v1/users/:id",status="200",le="+Inf"} 2`)
+	assert.Contains(t, body, `winkr_backend_http_request_duration_seconds_count{method="GET",route="/api/v1/users/:id",status="200"} 2`)
+
+	// Test the in-flight gauge, which counts the scrape itself
+	assert.Contains(t, body, `winkr_backend_http_requests_in_flight{method="GET",route="/api/v1/users/:id"} 0`)
+	assert.Contains(t, body, `winkr_backend_http_requests_in_flight{method="GET",route="/metrics"} 1`)
+}
+
+// staticReadOnlyChecker reports a fixed read-only state
+type staticReadOnlyChecker bool
+
+func (c staticReadOnlyChecker) IsReadOnly(ctx context.Context) bool {
+	return bool(c)
+}
+
+func TestReadOnlyMiddleware(t *testing.T) {
+	newRouter := func(readOnly bool) (*gin.Engine, *bool) {
+		router := gin.New()
+		router.Use(ReadOnly(staticReadOnlyChecker(readOnly), DefaultReadOnlyExemptPaths))
+
+		var markedReadOnly bool
+		handler := func(c *gin.Context) {
+			markedReadOnly = services.IsReadOnlyContext(c.Request.Context())
+			c.Status(http.StatusOK)
+		}
+		router.GET("/api/v1/matches", handler)
+		router.POST("/api/v1/like/:id", handler)
+		router.PUT("/api/v1/admin/system/read-only", handler)
+		return router, &markedReadOnly
+	}
+
+	tests := []struct {
+		name       string
+		readOnly   bool
+		method     string
+		path       string
+		wantStatus int
+	}{
+		{"writes pass when off", false, "POST", "/api/v1/like/1", http.StatusOK},
+		{"reads pass when on", true, "GET", "/api/v1/matches", http.StatusOK},
+		{"writes are rejected when on", true, "POST", "/api/v1/like/1", http.StatusServiceUnavailable},
+		{"admin writes pass when on", true, "PUT", "/api/v1/admin/system/read-only", http.StatusOK},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			router, markedReadOnly := newRouter(tt.readOnly)
+
+			req, _ := http.NewRequest(tt.method, tt.path, nil)
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+
+			assert.Equal(t, tt.wantStatus, w.Code)
+			if tt.method == "GET" {
+				assert.Equal(t, tt.readOnly, *markedReadOnly)
+			}
+		})
+	}
+}
+
 // BenchmarkMiddleware benchmarks middleware performance
 func BenchmarkMiddleware(b *testing.B) {
 	// Create Gin router with all middleware
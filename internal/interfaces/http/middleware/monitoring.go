@@ -10,7 +10,6 @@ import (
 	"time"
 
 	"github.com/gin-gonic/gin"
-	"github.com/google/uuid"
 	"github.com/22smeargle/winkr-backend/internal/application/services"
 	"github.com/22smeargle/winkr-backend/pkg/logger"
 )
@@ -30,15 +29,8 @@ func NewMonitoringMiddleware(metricsService *services.MetricsService) *Monitorin
 // RequestTimingMiddleware tracks request timing
 func (m *MonitoringMiddleware) RequestTimingMiddleware() gin.HandlerFunc {
 	return func(c *gin.Context) {
-		// Generate correlation ID if not present
-		correlationID := c.GetHeader("X-Correlation-ID")
-		if correlationID == "" {
-			correlationID = uuid.New().String()
-			c.Header("X-Correlation-ID", correlationID)
-		}
-
-		// Store correlation ID in context
-		c.Set("correlation_id", correlationID)
+		// Reuse the correlation ID set by the CorrelationID middleware, if it ran
+		correlationID := ensureCorrelationID(c)
 
 		// Record start time
 		start := time.Now()
@@ -375,15 +367,8 @@ func (m *MonitoringMiddleware) detectSecurityEvents(c *gin.Context) []SecurityEv
 // CombinedMonitoringMiddleware combines all monitoring middleware
 func (m *MonitoringMiddleware) CombinedMonitoringMiddleware() gin.HandlerFunc {
 	return gin.HandlerFunc(func(c *gin.Context) {
-		// Generate correlation ID if not present
-		correlationID := c.GetHeader("X-Correlation-ID")
-		if correlationID == "" {
-			correlationID = uuid.New().String()
-			c.Header("X-Correlation-ID", correlationID)
-		}
-
-		// Store correlation ID in context
-		c.Set("correlation_id", correlationID)
+		// Reuse the correlation ID set by the CorrelationID middleware, if it ran
+		correlationID := ensureCorrelationID(c)
 
 		// Record start time
 		start := time.Now()
@@ -453,4 +438,13 @@ func (m *MonitoringMiddleware) CombinedMonitoringMiddleware() gin.HandlerFunc {
 			"final_memory_kb":  finalMemory / 1024,
 		})
 	})
-}
\ No newline at end of file
+}
+
+// ensureCorrelationID returns the request's correlation ID, tagging the request with one first
+// when the CorrelationID middleware hasn't run
+func ensureCorrelationID(c *gin.Context) string {
+	if correlationID := c.GetString("correlation_id"); correlationID != "" {
+		return correlationID
+	}
+	return setCorrelationID(c, CorrelationIDHeader)
+}
@@ -0,0 +1,73 @@
+package middleware
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/22smeargle/winkr-backend/pkg/logger"
+	"github.com/22smeargle/winkr-backend/pkg/utils"
+)
+
+// PhotoGate decides whether a user has enough approved photos to take part in discovery
+type PhotoGate interface {
+	MeetsRequirement(ctx context.Context, userID uuid.UUID) (bool, error)
+	MinApproved() int
+}
+
+// PhotoRequirementMiddleware blocks discovery and swiping until the user has enough approved photos
+type PhotoRequirementMiddleware struct {
+	gate PhotoGate
+}
+
+// NewPhotoRequirementMiddleware creates a new PhotoRequirementMiddleware
+func NewPhotoRequirementMiddleware(gate PhotoGate) *PhotoRequirementMiddleware {
+	return &PhotoRequirementMiddleware{
+		gate: gate,
+	}
+}
+
+// RequirePhotos rejects the request with a photos_required error when the user has fewer approved
+// photos than configured. It must run after the auth middleware.
+func (m *PhotoRequirementMiddleware) RequirePhotos() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		userIDStr, exists := c.Get("user_id")
+		if !exists {
+			utils.Unauthorized(c, "User not authenticated")
+			c.Abort()
+			return
+		}
+
+		userID, err := uuid.Parse(userIDStr.(string))
+		if err != nil {
+			utils.Unauthorized(c, "Invalid user ID")
+			c.Abort()
+			return
+		}
+
+		allowed, err := m.gate.MeetsRequirement(c.Request.Context(), userID)
+		if err != nil {
+			logger.Error("Photo requirement check failed", err, "user_id", userID)
+			utils.Error(c, err)
+			c.Abort()
+			return
+		}
+
+		if !allowed {
+			utils.PhotosRequired(c, photosRequiredMessage(m.gate.MinApproved()))
+			c.Abort()
+			return
+		}
+
+		c.Next()
+	}
+}
+
+// photosRequiredMessage tells the user how many approved photos they need
+func photosRequiredMessage(minApproved int) string {
+	if minApproved == 1 {
+		return "Add a photo and wait for it to be approved to start discovering people"
+	}
+	return fmt.Sprintf("Add at least %d photos and wait for them to be approved to start discovering people", minApproved)
+}
@@ -129,6 +129,10 @@ func (r *ProfileRateLimiter) getRateLimitConfig(endpoint string) (int, time.Dura
 		"update-location": {limit: 10, window: time.Hour},
 		"get-matches":    {limit: 50, window: time.Hour},
 		"delete-account":  {limit: 5, window: 24 * time.Hour},
+		"get-performance": {limit: 30, window: time.Hour},
+		"get-favorites":   {limit: 100, window: time.Hour},
+		"update-favorites": {limit: 60, window: time.Hour},
+		"block-contacts":   {limit: 10, window: time.Hour},
 	}
 	
 	if config, exists := configs[endpoint]; exists {
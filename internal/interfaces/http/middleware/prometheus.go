@@ -0,0 +1,233 @@
+package middleware
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/22smeargle/winkr-backend/pkg/config"
+)
+
+// unmatchedRoute labels requests that matched no route, so unknown paths can't create series
+const unmatchedRoute = "unmatched"
+
+// httpDurationBuckets are the upper bounds of the request duration histogram, in seconds
+var httpDurationBuckets = []float64{0.005, 0.01, 0.025, 0.05, 0.1, 0.25, 0.5, 1, 2.5, 5, 10}
+
+// requestSeries identifies a finished request series
+type requestSeries struct {
+	method string
+	route  string
+	status string
+}
+
+// inFlightSeries identifies an in-flight series; the status isn't known until the request ends
+type inFlightSeries struct {
+	method string
+	route  string
+}
+
+// durationHistogram is a cumulative Prometheus histogram
+type durationHistogram struct {
+	buckets []uint64
+	sum     float64
+	count   uint64
+}
+
+// HTTPMetrics records Prometheus request metrics: a request counter, a duration histogram and an
+// in-flight gauge, labeled by method, route and status. The route label is the matched route
+// template, such as /api/v1/users/:id, never the raw path, so path parameters don't multiply the
+// series.
+type HTTPMetrics struct {
+	requestsName string
+	durationName string
+	inFlightName string
+
+	mu        sync.Mutex
+	requests  map[requestSeries]uint64
+	durations map[requestSeries]*durationHistogram
+	inFlight  map[inFlightSeries]int64
+}
+
+// NewHTTPMetrics creates HTTP metrics named under the configured namespace and subsystem
+func NewHTTPMetrics(cfg *config.PrometheusConfig) *HTTPMetrics {
+	return &HTTPMetrics{
+		requestsName: metricName(cfg.Namespace, cfg.Subsystem, "http_requests_total"),
+		durationName: metricName(cfg.Namespace, cfg.Subsystem, "http_request_duration_seconds"),
+		inFlightName: metricName(cfg.Namespace, cfg.Subsystem, "http_requests_in_flight"),
+		requests:     make(map[requestSeries]uint64),
+		durations:    make(map[requestSeries]*durationHistogram),
+		inFlight:     make(map[inFlightSeries]int64),
+	}
+}
+
+// Middleware returns the middleware recording every request
+func (m *HTTPMetrics) Middleware() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		// The route is matched before the handlers run, so the template is already known
+		route := c.FullPath()
+		if route == "" {
+			route = unmatchedRoute
+		}
+		flight := inFlightSeries{method: c.Request.Method, route: route}
+
+		m.mu.Lock()
+		m.inFlight[flight]++
+		m.mu.Unlock()
+
+		start := time.Now()
+		defer func() {
+			m.observe(flight, c.Writer.Status(), time.Since(start))
+		}()
+
+		c.Next()
+	}
+}
+
+// observe records a finished request
+func (m *HTTPMetrics) observe(flight inFlightSeries, status int, duration time.Duration) {
+	series := requestSeries{method: flight.method, route: flight.route, status: strconv.Itoa(status)}
+	seconds := duration.Seconds()
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.inFlight[flight]--
+	m.requests[series]++
+
+	histogram, ok := m.durations[series]
+	if !ok {
+		histogram = &durationHistogram{buckets: make([]uint64, len(httpDurationBuckets))}
+		m.durations[series] = histogram
+	}
+	for i, bound := range httpDurationBuckets {
+		if seconds <= bound {
+			histogram.buckets[i]++
+		}
+	}
+	histogram.sum += seconds
+	histogram.count++
+}
+
+// PrometheusCollector writes further metrics in the Prometheus text format
+type PrometheusCollector interface {
+	Write(w io.Writer) error
+}
+
+// Handler returns the handler serving the metrics in the Prometheus text format, followed by those
+// of any other collectors
+func (m *HTTPMetrics) Handler(collectors ...PrometheusCollector) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		c.Header("Content-Type", "text/plain; version=0.0.4; charset=utf-8")
+		c.Status(http.StatusOK)
+		for _, collector := range append([]PrometheusCollector{m}, collectors...) {
+			if err := collector.Write(c.Writer); err != nil {
+				c.Error(err)
+				return
+			}
+		}
+	}
+}
+
+// Write writes the metrics in the Prometheus text format, with the series sorted so scrapes are
+// stable
+func (m *HTTPMetrics) Write(w io.Writer) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	var b strings.Builder
+
+	requests := make([]requestSeries, 0, len(m.requests))
+	for series := range m.requests {
+		requests = append(requests, series)
+	}
+	sortRequestSeries(requests)
+
+	fmt.Fprintf(&b, "# HELP %s Total number of HTTP requests.\n", m.requestsName)
+	fmt.Fprintf(&b, "# TYPE %s counter\n", m.requestsName)
+	for _, series := range requests {
+		fmt.Fprintf(&b, "%s%s %d\n", m.requestsName, series.labels(), m.requests[series])
+	}
+
+	fmt.Fprintf(&b, "# HELP %s Duration of HTTP requests in seconds.\n", m.durationName)
+	fmt.Fprintf(&b, "# TYPE %s histogram\n", m.durationName)
+	for _, series := range requests {
+		histogram := m.durations[series]
+		for i, bound := range httpDurationBuckets {
+			fmt.Fprintf(&b, "%s_bucket%s %d\n", m.durationName,
+				series.labels("le", strconv.FormatFloat(bound, 'g', -1, 64)), histogram.buckets[i])
+		}
+		fmt.Fprintf(&b, "%s_bucket%s %d\n", m.durationName, series.labels("le", "+Inf"), histogram.count)
+		fmt.Fprintf(&b, "%s_sum%s %s\n", m.durationName, series.labels(), strconv.FormatFloat(histogram.sum, 'g', -1, 64))
+		fmt.Fprintf(&b, "%s_count%s %d\n", m.durationName, series.labels(), histogram.count)
+	}
+
+	flights := make([]inFlightSeries, 0, len(m.inFlight))
+	for series := range m.inFlight {
+		flights = append(flights, series)
+	}
+	sort.Slice(flights, func(i, j int) bool {
+		if flights[i].route != flights[j].route {
+			return flights[i].route < flights[j].route
+		}
+		return flights[i].method < flights[j].method
+	})
+
+	fmt.Fprintf(&b, "# HELP %s Number of HTTP requests being served.\n", m.inFlightName)
+	fmt.Fprintf(&b, "# TYPE %s gauge\n", m.inFlightName)
+	for _, series := range flights {
+		fmt.Fprintf(&b, "%s{method=%s,route=%s} %d\n", m.inFlightName,
+			quoteLabelValue(series.method), quoteLabelValue(series.route), m.inFlight[series])
+	}
+
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+// labels formats the series labels, followed by any extra name and value pairs
+func (s requestSeries) labels(extra ...string) string {
+	labels := fmt.Sprintf("method=%s,route=%s,status=%s",
+		quoteLabelValue(s.method), quoteLabelValue(s.route), quoteLabelValue(s.status))
+	for i := 0; i+1 < len(extra); i += 2 {
+		labels += fmt.Sprintf(",%s=%s", extra[i], quoteLabelValue(extra[i+1]))
+	}
+	return "{" + labels + "}"
+}
+
+// sortRequestSeries orders series by route, method and status
+func sortRequestSeries(series []requestSeries) {
+	sort.Slice(series, func(i, j int) bool {
+		if series[i].route != series[j].route {
+			return series[i].route < series[j].route
+		}
+		if series[i].method != series[j].method {
+			return series[i].method < series[j].method
+		}
+		return series[i].status < series[j].status
+	})
+}
+
+// quoteLabelValue quotes a label value, escaping backslashes, quotes and newlines
+func quoteLabelValue(value string) string {
+	value = strings.ReplaceAll(value, `\`, `\\`)
+	value = strings.ReplaceAll(value, `"`, `\"`)
+	value = strings.ReplaceAll(value, "\n", `\n`)
+	return `"` + value + `"`
+}
+
+// metricName joins the non-empty namespace, subsystem and name with underscores
+func metricName(namespace, subsystem, name string) string {
+	parts := make([]string, 0, 3)
+	for _, part := range []string{namespace, subsystem, name} {
+		if part != "" {
+			parts = append(parts, part)
+		}
+	}
+	return strings.Join(parts, "_")
+}
@@ -68,7 +68,7 @@ func DefaultRateLimitConfig(redisClient *redis.Client) *RateLimitConfig {
 		IPLimitPerMinute:   100,
 		WindowDuration:      time.Minute,
 		IncludeHeaders:     true,
-		SkipPaths:         []string{"/health", "/health/db", "/metrics"},
+		SkipPaths:         []string{"/health", "/health/live", "/health/ready", "/health/db", "/metrics"},
 		KeyPrefix:         "rate_limit:",
 	}
 }
@@ -0,0 +1,58 @@
+package middleware
+
+import (
+	"context"
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/22smeargle/winkr-backend/internal/application/services"
+	"github.com/22smeargle/winkr-backend/pkg/utils"
+)
+
+// ReadOnlyChecker reports whether the API is in read-only mode
+type ReadOnlyChecker interface {
+	IsReadOnly(ctx context.Context) bool
+}
+
+// DefaultReadOnlyExemptPaths are the write endpoints kept open in read-only mode: the admin API, so
+// the mode can be turned off, and token refresh and logout, whose sessions live in Redis, so users
+// stay signed in to read
+var DefaultReadOnlyExemptPaths = []string{
+	"/api/v1/admin",
+	"/api/v1/auth/refresh",
+	"/api/v1/auth/logout",
+}
+
+// ReadOnly returns a middleware that rejects writes with 503 while read-only mode is on. Reads go
+// through with their context marked, so use cases can skip side-effect writes. Requests whose path
+// starts with one of the exempt prefixes are never rejected.
+func ReadOnly(checker ReadOnlyChecker, exemptPaths []string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if !checker.IsReadOnly(c.Request.Context()) {
+			c.Next()
+			return
+		}
+
+		if isReadMethod(c.Request.Method) {
+			c.Request = c.Request.WithContext(services.ContextWithReadOnly(c.Request.Context()))
+			c.Next()
+			return
+		}
+
+		for _, prefix := range exemptPaths {
+			if strings.HasPrefix(c.Request.URL.Path, prefix) {
+				c.Next()
+				return
+			}
+		}
+
+		utils.ServiceUnavailable(c, "The service is in read-only mode for maintenance; changes are temporarily disabled")
+		c.Abort()
+	}
+}
+
+// isReadMethod reports whether the method only reads
+func isReadMethod(method string) bool {
+	return method == http.MethodGet || method == http.MethodHead || method == http.MethodOptions
+}
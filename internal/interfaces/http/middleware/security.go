@@ -122,7 +122,7 @@ func DefaultSecurityConfig() *SecurityConfig {
 			"text/plain",
 		},
 		RequireHTTPS: false,
-		SkipPaths:   []string{"/health", "/health/db", "/metrics"},
+		SkipPaths:   []string{"/health", "/health/live", "/health/ready", "/health/db", "/metrics"},
 		CustomHeaders: map[string]string{},
 		ipTracker:    make(map[string]*ipInfo),
 	}
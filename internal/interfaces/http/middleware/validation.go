@@ -62,7 +62,7 @@ func DefaultValidationConfig() *ValidationConfig {
 	
 	return &ValidationConfig{
 		Validator:             v,
-		SkipPaths:            []string{"/health", "/health/db", "/metrics"},
+		SkipPaths:            []string{"/health", "/health/live", "/health/ready", "/health/db", "/metrics"},
 		SkipMethods:          []string{"GET", "DELETE", "OPTIONS"},
 		MaxBodySize:          1024 * 1024, // 1MB
 		RequiredHeaders:       map[string]string{},
@@ -0,0 +1,28 @@
+package routes
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/22smeargle/winkr-backend/internal/interfaces/http/handlers"
+)
+
+// AdminAlertChannelRoutes defines admin routes for the alert notification channels
+type AdminAlertChannelRoutes struct {
+	adminHandler *handlers.AdminAlertChannelHandler
+}
+
+// NewAdminAlertChannelRoutes creates new admin alert channel routes
+func NewAdminAlertChannelRoutes(adminHandler *handlers.AdminAlertChannelHandler) *AdminAlertChannelRoutes {
+	return &AdminAlertChannelRoutes{
+		adminHandler: adminHandler,
+	}
+}
+
+// RegisterAdminRoutes registers admin alert channel routes
+func (r *AdminAlertChannelRoutes) RegisterAdminRoutes(router *gin.RouterGroup, adminAuthMiddleware gin.HandlerFunc) {
+	admin := router.Group("/admin/alerts/channels")
+	admin.Use(adminAuthMiddleware)
+
+	admin.GET("", r.adminHandler.ListChannels)
+	// Sends a test alert so a channel's configuration can be checked
+	admin.POST("/:id/test", r.adminHandler.TestChannel)
+}
@@ -12,6 +12,7 @@ type AuthRoutes struct {
 	securityConfig  middleware.SecurityConfig
 	rateLimitConfig middleware.RateLimiterConfig
 	csrfConfig     middleware.CSRFConfig
+	geofence       *middleware.GeofenceMiddleware
 }
 
 // NewAuthRoutes creates a new AuthRoutes instance
@@ -20,12 +21,14 @@ func NewAuthRoutes(
 	securityConfig middleware.SecurityConfig,
 	rateLimitConfig middleware.RateLimiterConfig,
 	csrfConfig middleware.CSRFConfig,
+	geofence *middleware.GeofenceMiddleware,
 ) *AuthRoutes {
 	return &AuthRoutes{
 		handler:         handler,
 		securityConfig:  securityConfig,
 		rateLimitConfig: rateLimitConfig,
 		csrfConfig:     csrfConfig,
+		geofence:       geofence,
 	}
 }
 
@@ -47,15 +50,15 @@ func (r *AuthRoutes) RegisterRoutes(router *gin.RouterGroup, redisClient interfa
 	
 	{
 		// Public routes
-		auth.POST("/register", r.handler.Register)
+		auth.POST("/register", r.geofence.Restrict(), r.handler.Register)
 		auth.POST("/login", r.handler.Login)
 		auth.POST("/refresh", r.handler.RefreshToken)
 		auth.POST("/password-reset", r.handler.PasswordReset)
 		auth.POST("/password-reset/confirm", r.handler.ConfirmPasswordReset)
 		auth.POST("/verify", r.handler.VerifyEmail)
 		
-		// CSRF token endpoint (public)
-		auth.GET("/csrf-token", middleware.GetCSRFTokenHandler(csrfMiddleware))
+		// CSRF token endpoint for cookie sessions
+		auth.GET("/csrf", middleware.GetCSRFTokenHandler(csrfMiddleware))
 		
 		// Protected routes (require authentication)
 		protected := auth.Group("")
@@ -66,6 +69,7 @@ func (r *AuthRoutes) RegisterRoutes(router *gin.RouterGroup, redisClient interfa
 			protected.GET("/profile", r.handler.GetProfile)
 			protected.POST("/verify/send", r.handler.SendEmailVerification)
 			protected.GET("/sessions", r.handler.GetSessions)
+			protected.DELETE("/sessions/:id", r.handler.RevokeSession)
 		}
 	}
 }
@@ -88,6 +92,11 @@ func (r *AuthRoutes) GetRoutes() []RouteInfo {
 			Path:   "/api/v1/auth/refresh",
 			Description: "Refresh access token",
 		},
+		{
+			Method: "GET",
+			Path:   "/api/v1/auth/csrf",
+			Description: "Get the CSRF token of a cookie session",
+		},
 		{
 			Method: "POST",
 			Path:   "/api/v1/auth/logout",
@@ -123,6 +132,11 @@ func (r *AuthRoutes) GetRoutes() []RouteInfo {
 			Path:   "/api/v1/auth/sessions",
 			Description: "Get user sessions",
 		},
+		{
+			Method: "DELETE",
+			Path:   "/api/v1/auth/sessions/:id",
+			Description: "Revoke a user session",
+		},
 	}
 }
 
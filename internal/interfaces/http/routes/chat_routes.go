@@ -2,19 +2,22 @@ package routes
 
 import (
 	"github.com/gin-gonic/gin"
+	"github.com/22smeargle/winkr-backend/internal/application/services"
 	"github.com/22smeargle/winkr-backend/internal/interfaces/http/handlers"
 	"github.com/22smeargle/winkr-backend/internal/interfaces/http/middleware"
 )
 
 // ChatRoutes defines chat-related routes
 type ChatRoutes struct {
-	handler *handlers.ChatHandler
+	handler      *handlers.ChatHandler
+	legalNotices *middleware.LegalNoticeMiddleware
 }
 
 // NewChatRoutes creates new chat routes
-func NewChatRoutes(handler *handlers.ChatHandler) *ChatRoutes {
+func NewChatRoutes(handler *handlers.ChatHandler, legalNotices *middleware.LegalNoticeMiddleware) *ChatRoutes {
 	return &ChatRoutes{
-		handler: handler,
+		handler:      handler,
+		legalNotices: legalNotices,
 	}
 }
 
@@ -33,22 +36,52 @@ func (r *ChatRoutes) RegisterRoutes(router *gin.Engine, authMiddleware gin.Handl
 		chatGroup.GET("/:id/messages", r.handler.GetMessages)
 
 		// POST /api/v1/chats/:id/messages - Send message to conversation
-		chatGroup.POST("/:id/messages", r.handler.SendMessage)
+		chatGroup.POST("/:id/messages", r.requireNotices(), r.handler.SendMessage)
 
 		// POST /api/v1/chats/:id/read - Mark messages as read
 		chatGroup.POST("/:id/read", r.handler.MarkMessagesAsRead)
 
+		// POST /api/v1/chats/read-all - Mark all conversations as read
+		chatGroup.POST("/read-all", r.handler.MarkAllAsRead)
+
+		// PUT /api/v1/chats/:id/messages/:messageId - Edit a message
+		chatGroup.PUT("/:id/messages/:messageId", r.handler.EditMessage)
+
 		// DELETE /api/v1/chats/:id/messages/:messageId - Delete a message
 		chatGroup.DELETE("/:id/messages/:messageId", r.handler.DeleteMessage)
 
+		// POST /api/v1/chats/:id/messages/:messageId/pin - Pin a message
+		chatGroup.POST("/:id/messages/:messageId/pin", r.handler.PinMessage)
+
+		// POST /api/v1/chats/:id/messages/:messageId/unpin - Unpin a message
+		chatGroup.POST("/:id/messages/:messageId/unpin", r.handler.UnpinMessage)
+
+		// POST /api/v1/chats/:id/messages/:messageId/reactions - Toggle an emoji reaction to a message
+		chatGroup.POST("/:id/messages/:messageId/reactions", r.handler.ReactToMessage)
+
+		// GET /api/v1/chats/:id/pins - Get pinned messages in a conversation
+		chatGroup.GET("/:id/pins", r.handler.GetPinnedMessages)
+
+		// POST /api/v1/chats/:id/archive - Archive a conversation
+		chatGroup.POST("/:id/archive", r.handler.ArchiveConversation)
+
+		// DELETE /api/v1/chats/:id/archive - Unarchive a conversation
+		chatGroup.DELETE("/:id/archive", r.handler.UnarchiveConversation)
+
+		// POST /api/v1/chats/:id/schedule - Schedule a message to send later
+		chatGroup.POST("/:id/schedule", r.requireNotices(), r.handler.ScheduleMessage)
+
+		// DELETE /api/v1/chats/:id/schedule/:scheduledId - Cancel a scheduled message
+		chatGroup.DELETE("/:id/schedule/:scheduledId", r.handler.CancelScheduledMessage)
+
 		// POST /api/v1/chats/:id/ephemeral-photos - Send ephemeral photo message
-		chatGroup.POST("/:id/ephemeral-photos", r.handler.SendEphemeralPhotoMessage)
+		chatGroup.POST("/:id/ephemeral-photos", r.requireNotices(), r.handler.SendEphemeralPhotoMessage)
 
 		// GET /api/v1/chats/:id/messages/:messageId/ephemeral-photo - Get ephemeral photo message
 		chatGroup.GET("/:id/messages/:messageId/ephemeral-photo", r.handler.GetEphemeralPhotoMessage)
 
 		// POST /api/v1/chats/start - Start a new conversation
-		chatGroup.POST("/start", r.handler.StartConversation)
+		chatGroup.POST("/start", r.requireNotices(), r.handler.StartConversation)
 	}
 
 	// WebSocket endpoint for real-time messaging
@@ -86,22 +119,52 @@ func (r *ChatRoutes) RegisterRoutesWithCustomMiddleware(
 		chatGroup.GET("/:id/messages", r.handler.GetMessages)
 
 		// POST /api/v1/chats/:id/messages - Send message to conversation
-		chatGroup.POST("/:id/messages", r.handler.SendMessage)
+		chatGroup.POST("/:id/messages", r.requireNotices(), r.handler.SendMessage)
 
 		// POST /api/v1/chats/:id/read - Mark messages as read
 		chatGroup.POST("/:id/read", r.handler.MarkMessagesAsRead)
 
+		// POST /api/v1/chats/read-all - Mark all conversations as read
+		chatGroup.POST("/read-all", r.handler.MarkAllAsRead)
+
+		// PUT /api/v1/chats/:id/messages/:messageId - Edit a message
+		chatGroup.PUT("/:id/messages/:messageId", r.handler.EditMessage)
+
 		// DELETE /api/v1/chats/:id/messages/:messageId - Delete a message
 		chatGroup.DELETE("/:id/messages/:messageId", r.handler.DeleteMessage)
 
+		// POST /api/v1/chats/:id/messages/:messageId/pin - Pin a message
+		chatGroup.POST("/:id/messages/:messageId/pin", r.handler.PinMessage)
+
+		// POST /api/v1/chats/:id/messages/:messageId/unpin - Unpin a message
+		chatGroup.POST("/:id/messages/:messageId/unpin", r.handler.UnpinMessage)
+
+		// POST /api/v1/chats/:id/messages/:messageId/reactions - Toggle an emoji reaction to a message
+		chatGroup.POST("/:id/messages/:messageId/reactions", r.handler.ReactToMessage)
+
+		// GET /api/v1/chats/:id/pins - Get pinned messages in a conversation
+		chatGroup.GET("/:id/pins", r.handler.GetPinnedMessages)
+
+		// POST /api/v1/chats/:id/archive - Archive a conversation
+		chatGroup.POST("/:id/archive", r.handler.ArchiveConversation)
+
+		// DELETE /api/v1/chats/:id/archive - Unarchive a conversation
+		chatGroup.DELETE("/:id/archive", r.handler.UnarchiveConversation)
+
+		// POST /api/v1/chats/:id/schedule - Schedule a message to send later
+		chatGroup.POST("/:id/schedule", r.requireNotices(), r.handler.ScheduleMessage)
+
+		// DELETE /api/v1/chats/:id/schedule/:scheduledId - Cancel a scheduled message
+		chatGroup.DELETE("/:id/schedule/:scheduledId", r.handler.CancelScheduledMessage)
+
 		// POST /api/v1/chats/:id/ephemeral-photos - Send ephemeral photo message
-		chatGroup.POST("/:id/ephemeral-photos", r.handler.SendEphemeralPhotoMessage)
+		chatGroup.POST("/:id/ephemeral-photos", r.requireNotices(), r.handler.SendEphemeralPhotoMessage)
 
 		// GET /api/v1/chats/:id/messages/:messageId/ephemeral-photo - Get ephemeral photo message
 		chatGroup.GET("/:id/messages/:messageId/ephemeral-photo", r.handler.GetEphemeralPhotoMessage)
 
 		// POST /api/v1/chats/start - Start a new conversation
-		chatGroup.POST("/start", r.handler.StartConversation)
+		chatGroup.POST("/start", r.requireNotices(), r.handler.StartConversation)
 	}
 
 	// WebSocket endpoint for real-time messaging
@@ -120,6 +183,11 @@ func (r *ChatRoutes) RegisterRoutesWithCustomMiddleware(
 	}
 }
 
+// requireNotices gates sending messages until the required legal notices are acknowledged
+func (r *ChatRoutes) requireNotices() gin.HandlerFunc {
+	return r.legalNotices.RequireAcknowledged(services.NoticeActionMessaging)
+}
+
 // GetRouteInfo returns information about chat routes
 func (r *ChatRoutes) GetRouteInfo() map[string]interface{} {
 	return map[string]interface{}{
@@ -154,6 +222,20 @@ func (r *ChatRoutes) GetRouteInfo() map[string]interface{} {
 				"auth_required": true,
 				"rate_limited": true,
 			},
+			{
+				"method": "POST",
+				"path":   "/read-all",
+				"description": "Mark all conversations as read",
+				"auth_required": true,
+				"rate_limited": true,
+			},
+			{
+				"method": "PUT",
+				"path":   "/:id/messages/:messageId",
+				"description": "Edit a message",
+				"auth_required": true,
+				"rate_limited": true,
+			},
 			{
 				"method": "DELETE",
 				"path":   "/:id/messages/:messageId",
@@ -161,6 +243,62 @@ func (r *ChatRoutes) GetRouteInfo() map[string]interface{} {
 				"auth_required": true,
 				"rate_limited": true,
 			},
+			{
+				"method": "POST",
+				"path":   "/:id/messages/:messageId/pin",
+				"description": "Pin a message",
+				"auth_required": true,
+				"rate_limited": true,
+			},
+			{
+				"method": "POST",
+				"path":   "/:id/messages/:messageId/unpin",
+				"description": "Unpin a message",
+				"auth_required": true,
+				"rate_limited": true,
+			},
+			{
+				"method": "POST",
+				"path":   "/:id/messages/:messageId/reactions",
+				"description": "Toggle an emoji reaction to a message",
+				"auth_required": true,
+				"rate_limited": true,
+			},
+			{
+				"method": "GET",
+				"path":   "/:id/pins",
+				"description": "Get pinned messages in a conversation",
+				"auth_required": true,
+				"rate_limited": true,
+			},
+			{
+				"method": "POST",
+				"path":   "/:id/archive",
+				"description": "Archive a conversation",
+				"auth_required": true,
+				"rate_limited": true,
+			},
+			{
+				"method": "DELETE",
+				"path":   "/:id/archive",
+				"description": "Unarchive a conversation",
+				"auth_required": true,
+				"rate_limited": true,
+			},
+			{
+				"method": "POST",
+				"path":   "/:id/schedule",
+				"description": "Schedule a message to send later",
+				"auth_required": true,
+				"rate_limited": true,
+			},
+			{
+				"method": "DELETE",
+				"path":   "/:id/schedule/:scheduledId",
+				"description": "Cancel a scheduled message",
+				"auth_required": true,
+				"rate_limited": true,
+			},
 			{
 				"method": "POST",
 				"path":   "/:id/ephemeral-photos",
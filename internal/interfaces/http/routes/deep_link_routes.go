@@ -0,0 +1,30 @@
+package routes
+
+import (
+	"github.com/gin-gonic/gin"
+
+	"github.com/22smeargle/winkr-backend/internal/interfaces/http/handlers"
+	"github.com/22smeargle/winkr-backend/pkg/logger"
+)
+
+// DeepLinkRoutes defines deep link routes
+type DeepLinkRoutes struct {
+	handler *handlers.DeepLinkHandler
+}
+
+// NewDeepLinkRoutes creates new deep link routes
+func NewDeepLinkRoutes(handler *handlers.DeepLinkHandler) *DeepLinkRoutes {
+	return &DeepLinkRoutes{
+		handler: handler,
+	}
+}
+
+// RegisterRoutes registers deep link routes
+func (r *DeepLinkRoutes) RegisterRoutes(router *gin.RouterGroup, authMiddleware gin.HandlerFunc) {
+	deepLinks := router.Group("/deep-links")
+	deepLinks.Use(authMiddleware) // Links can only be opened by the user they were sent to
+
+	deepLinks.GET("/resolve", r.handler.ResolveDeepLink)
+
+	logger.Info("Deep link routes registered")
+}
@@ -2,9 +2,11 @@ package routes
 
 import (
 	"github.com/gin-gonic/gin"
+	"github.com/22smeargle/winkr-backend/internal/application/services"
 	"github.com/22smeargle/winkr-backend/internal/application/usecases/matching"
 	"github.com/22smeargle/winkr-backend/internal/interfaces/http/handlers"
 	"github.com/22smeargle/winkr-backend/internal/interfaces/http/middleware"
+	"github.com/22smeargle/winkr-backend/pkg/config"
 )
 
 // DiscoveryRoutes defines discovery and matching routes
@@ -18,16 +20,32 @@ func NewDiscoveryRoutes(
 	likeUserUseCase *matching.LikeUserUseCase,
 	dislikeUserUseCase *matching.DislikeUserUseCase,
 	superLikeUserUseCase *matching.SuperLikeUserUseCase,
+	undoLastSwipeUseCase *matching.UndoLastSwipeUseCase,
+	boostUseCase *matching.BoostUseCase,
 	getMatchesUseCase *matching.GetMatchesUseCase,
+	getMatchCompatibilityUseCase *matching.GetMatchCompatibilityUseCase,
+	unmatchUseCase *matching.UnmatchUseCase,
+	batchSwipeUseCase *matching.BatchSwipeUseCase,
+	getLikesReceivedUseCase *matching.GetLikesReceivedUseCase,
 	getDiscoveryStatsUseCase *matching.GetDiscoveryStatsUseCase,
+	discoveryThrottle *services.DiscoveryThrottleService,
+	pageSizes *config.MatchingPageSizeConfig,
 ) *DiscoveryRoutes {
 	handler := handlers.NewDiscoveryHandler(
 		discoverUsersUseCase,
 		likeUserUseCase,
 		dislikeUserUseCase,
 		superLikeUserUseCase,
+		undoLastSwipeUseCase,
+		boostUseCase,
 		getMatchesUseCase,
+		getMatchCompatibilityUseCase,
+		unmatchUseCase,
+		batchSwipeUseCase,
+		getLikesReceivedUseCase,
 		getDiscoveryStatsUseCase,
+		discoveryThrottle,
+		pageSizes,
 	)
 
 	return &DiscoveryRoutes{
@@ -36,18 +54,27 @@ func NewDiscoveryRoutes(
 }
 
 // RegisterRoutes registers discovery routes with the router
-func (r *DiscoveryRoutes) RegisterRoutes(router *gin.Engine, authMiddleware gin.HandlerFunc, rateLimitMiddleware gin.HandlerFunc) {
-	// Discovery group with authentication and rate limiting
+// noticeMiddleware gates swiping until the required legal notices are acknowledged, and
+// photoMiddleware until the user has enough approved photos.
+func (r *DiscoveryRoutes) RegisterRoutes(router *gin.Engine, authMiddleware gin.HandlerFunc, geofenceMiddleware gin.HandlerFunc, noticeMiddleware gin.HandlerFunc, photoMiddleware gin.HandlerFunc, rateLimitMiddleware gin.HandlerFunc) {
+	// Discovery group with authentication, geofencing and rate limiting
 	discoveryGroup := router.Group("/api/v1")
 	discoveryGroup.Use(authMiddleware)                    // Require authentication
+	discoveryGroup.Use(geofenceMiddleware)                // Block restricted regions
 	discoveryGroup.Use(rateLimitMiddleware)                // Apply rate limiting
 
 	// Discovery endpoints
-	discoveryGroup.GET("/discover", r.handler.DiscoverUsers)
-	discoveryGroup.POST("/like/:id", r.handler.LikeUser)
-	discoveryGroup.POST("/dislike/:id", r.handler.DislikeUser)
-	discoveryGroup.POST("/superlike/:id", r.handler.SuperLikeUser)
+	discoveryGroup.GET("/discover", noticeMiddleware, photoMiddleware, r.handler.DiscoverUsers)
+	discoveryGroup.POST("/like/:id", noticeMiddleware, photoMiddleware, r.handler.LikeUser)
+	discoveryGroup.POST("/dislike/:id", noticeMiddleware, photoMiddleware, r.handler.DislikeUser)
+	discoveryGroup.POST("/superlike/:id", noticeMiddleware, photoMiddleware, r.handler.SuperLikeUser)
+	discoveryGroup.POST("/swipes/batch", noticeMiddleware, photoMiddleware, r.handler.BatchSwipe)
+	discoveryGroup.POST("/undo", noticeMiddleware, photoMiddleware, r.handler.UndoLastSwipe)
+	discoveryGroup.POST("/boost", noticeMiddleware, photoMiddleware, r.handler.Boost)
 	discoveryGroup.GET("/matches", r.handler.GetMatches)
+	discoveryGroup.GET("/matches/:id/compatibility", r.handler.GetMatchCompatibility)
+	discoveryGroup.DELETE("/matches/:id", r.handler.Unmatch)
+	discoveryGroup.GET("/likes/received", r.handler.GetLikesReceived)
 	discoveryGroup.GET("/discover/stats", r.handler.GetDiscoveryStats)
 }
 
@@ -62,6 +62,27 @@ func (r *EphemeralPhotoRoutes) RegisterRoutes(router *gin.Engine, authMiddleware
 	publicGroup.Use(middleware.EphemeralPhotoRateLimit())
 }
 
+// AdminEphemeralPhotoRoutes defines admin ephemeral photo routes
+type AdminEphemeralPhotoRoutes struct {
+	adminHandler *handlers.AdminEphemeralPhotoHandler
+}
+
+// NewAdminEphemeralPhotoRoutes creates new admin ephemeral photo routes
+func NewAdminEphemeralPhotoRoutes(adminHandler *handlers.AdminEphemeralPhotoHandler) *AdminEphemeralPhotoRoutes {
+	return &AdminEphemeralPhotoRoutes{
+		adminHandler: adminHandler,
+	}
+}
+
+// RegisterAdminRoutes registers admin ephemeral photo routes
+func (r *AdminEphemeralPhotoRoutes) RegisterAdminRoutes(router *gin.RouterGroup, adminAuthMiddleware gin.HandlerFunc) {
+	admin := router.Group("/admin/ephemeral")
+	admin.Use(adminAuthMiddleware)
+
+	// Stats of the most recent cleanup job runs
+	admin.GET("/cleanup/stats", r.adminHandler.GetCleanupStats)
+}
+
 // GetRouteConfig returns configuration for ephemeral photo routes
 func (r *EphemeralPhotoRoutes) GetRouteConfig() map[string]interface{} {
 	return map[string]interface{}{
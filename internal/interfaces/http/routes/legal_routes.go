@@ -0,0 +1,31 @@
+package routes
+
+import (
+	"github.com/gin-gonic/gin"
+
+	"github.com/22smeargle/winkr-backend/internal/interfaces/http/handlers"
+	"github.com/22smeargle/winkr-backend/pkg/logger"
+)
+
+// LegalRoutes defines legal notice routes
+type LegalRoutes struct {
+	handler *handlers.LegalHandler
+}
+
+// NewLegalRoutes creates new legal notice routes
+func NewLegalRoutes(handler *handlers.LegalHandler) *LegalRoutes {
+	return &LegalRoutes{
+		handler: handler,
+	}
+}
+
+// RegisterRoutes registers legal notice routes
+func (r *LegalRoutes) RegisterRoutes(router *gin.RouterGroup, authMiddleware gin.HandlerFunc) {
+	legal := router.Group("/legal")
+	legal.Use(authMiddleware) // All legal notice routes require authentication
+
+	legal.GET("/notices", r.handler.GetNotices)
+	legal.POST("/notices/:id/acknowledge", r.handler.AcknowledgeNotice)
+
+	logger.Info("Legal notice routes registered")
+}
@@ -0,0 +1,28 @@
+package routes
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/22smeargle/winkr-backend/internal/interfaces/http/handlers"
+)
+
+// AdminMatchScoringRoutes defines admin routes for the discovery ranking weights
+type AdminMatchScoringRoutes struct {
+	adminHandler *handlers.AdminMatchScoringHandler
+}
+
+// NewAdminMatchScoringRoutes creates new admin match scoring routes
+func NewAdminMatchScoringRoutes(adminHandler *handlers.AdminMatchScoringHandler) *AdminMatchScoringRoutes {
+	return &AdminMatchScoringRoutes{
+		adminHandler: adminHandler,
+	}
+}
+
+// RegisterAdminRoutes registers admin match scoring routes
+func (r *AdminMatchScoringRoutes) RegisterAdminRoutes(router *gin.RouterGroup, adminAuthMiddleware gin.HandlerFunc) {
+	admin := router.Group("/admin/matching/scoring")
+	admin.Use(adminAuthMiddleware)
+
+	admin.GET("", r.adminHandler.GetMatchScoringWeights)
+	// Tunes discovery ranking without a redeploy; instances pick the weights up within 30 seconds
+	admin.PUT("", r.adminHandler.SetMatchScoringWeights)
+}
@@ -1,9 +1,12 @@
 package routes
 
 import (
+	"fmt"
+
 	"github.com/gin-gonic/gin"
 	"github.com/22smeargle/winkr-backend/internal/interfaces/http/handlers"
 	"github.com/22smeargle/winkr-backend/internal/interfaces/http/middleware"
+	"github.com/22smeargle/winkr-backend/pkg/config"
 )
 
 // ModerationRoutes defines moderation routes
@@ -13,6 +16,7 @@ type ModerationRoutes struct {
 	securityConfig        middleware.SecurityConfig
 	rateLimitConfig       middleware.RateLimiterConfig
 	csrfConfig           middleware.CSRFConfig
+	moderationRateLimit   *config.ModerationRateLimitConfig
 }
 
 // NewModerationRoutes creates a new ModerationRoutes instance
@@ -22,6 +26,7 @@ func NewModerationRoutes(
 	securityConfig middleware.SecurityConfig,
 	rateLimitConfig middleware.RateLimiterConfig,
 	csrfConfig middleware.CSRFConfig,
+	moderationRateLimit *config.ModerationRateLimitConfig,
 ) *ModerationRoutes {
 	return &ModerationRoutes{
 		moderationHandler:      moderationHandler,
@@ -29,6 +34,7 @@ func NewModerationRoutes(
 		securityConfig:         securityConfig,
 		rateLimitConfig:        rateLimitConfig,
 		csrfConfig:            csrfConfig,
+		moderationRateLimit:    moderationRateLimit,
 	}
 }
 
@@ -58,6 +64,34 @@ func (r *ModerationRoutes) RegisterRoutes(router *gin.RouterGroup, redisClient i
 		"moderation_block_rate_limit",
 	)
 	
+	// Appeals are limited per user rather than per IP
+	appealRateLimiter := middleware.NewEnhancedRateLimiter(
+		redisClient,
+		middleware.RateLimiterConfig{
+			RequestsPerMinute: r.moderationRateLimit.AppealsPerMinute,
+			BurstSize:         r.moderationRateLimit.AppealsPerMinute,
+			KeyGenerator: func(c *gin.Context) string {
+				userID, _ := c.Get("user_id")
+				return fmt.Sprintf("moderation:appeal:%v", userID)
+			},
+		},
+		"moderation_appeal_rate_limit",
+	)
+	
+	// Moderator actions are limited per admin rather than per IP
+	adminActionRateLimiter := middleware.NewEnhancedRateLimiter(
+		redisClient,
+		middleware.RateLimiterConfig{
+			RequestsPerMinute: r.moderationRateLimit.AdminActionsPerMinute,
+			BurstSize:         r.moderationRateLimit.AdminActionsPerMinute,
+			KeyGenerator: func(c *gin.Context) string {
+				adminID, _ := c.Get("admin_id")
+				return fmt.Sprintf("moderation:admin_action:%v", adminID)
+			},
+		},
+		"moderation_admin_action_rate_limit",
+	)
+	
 	generalRateLimiter := middleware.NewEnhancedRateLimiter(
 		redisClient,
 		r.rateLimitConfig,
@@ -82,6 +116,18 @@ func (r *ModerationRoutes) RegisterRoutes(router *gin.RouterGroup, redisClient i
 			r.moderationHandler.ReportContent,
 		)
 		
+		// Report a user's profile with rate limiting
+		moderation.POST("/users/:id/report", 
+			middleware.RateLimitMiddleware(reportRateLimiter),
+			r.moderationHandler.ReportUser,
+		)
+		
+		// Report a specific profile photo with rate limiting
+		moderation.POST("/users/:id/photos/:photoId/report", 
+			middleware.RateLimitMiddleware(reportRateLimiter),
+			r.moderationHandler.ReportPhoto,
+		)
+		
 		// Block user with rate limiting
 		moderation.POST("/block/:id", 
 			middleware.RateLimitMiddleware(blockRateLimiter),
@@ -111,6 +157,12 @@ func (r *ModerationRoutes) RegisterRoutes(router *gin.RouterGroup, redisClient i
 		
 		// Check mutual block
 		moderation.GET("/block/:id/mutual", r.moderationHandler.CheckMutualBlock)
+		
+		// Appeal a ban with rate limiting
+		moderation.POST("/appeals", 
+			middleware.RateLimitMiddleware(appealRateLimiter),
+			r.moderationHandler.SubmitAppeal,
+		)
 	}
 
 	// Admin moderation routes
@@ -137,11 +189,22 @@ func (r *ModerationRoutes) RegisterRoutes(router *gin.RouterGroup, redisClient i
 		admin.GET("/users/:id/bans", r.adminModerationHandler.GetBanHistory)
 		admin.GET("/users/:id/appeals", r.adminModerationHandler.GetAppealHistory)
 		
+		// Linked accounts
+		admin.GET("/linked-accounts", r.adminModerationHandler.GetLinkedAccounts)
+		
 		// Appeal management
 		admin.POST("/appeals/:id/review", r.adminModerationHandler.ReviewAppeal)
 		
 		// Moderation queue
 		admin.GET("/moderation-queue", r.adminModerationHandler.GetModerationQueue)
+		admin.POST("/moderation-queue/claim",
+			middleware.RateLimitMiddleware(adminActionRateLimiter),
+			r.adminModerationHandler.ClaimModerationQueueItem,
+		)
+		admin.POST("/moderation-queue/:id/resolve",
+			middleware.RateLimitMiddleware(adminActionRateLimiter),
+			r.adminModerationHandler.ResolveModerationQueueItem,
+		)
 		
 		// Analytics
 		admin.GET("/analytics", r.adminModerationHandler.GetModerationAnalytics)
@@ -157,6 +220,11 @@ func (r *ModerationRoutes) GetRoutes() []RouteInfo {
 			Path:        "/api/v1/moderation/report",
 			Description: "Report user or content",
 		},
+		{
+			Method:      "POST",
+			Path:        "/api/v1/moderation/users/:id/photos/:photoId/report",
+			Description: "Report a profile photo",
+		},
 		{
 			Method:      "POST",
 			Path:        "/api/v1/moderation/block/:id",
@@ -197,6 +265,11 @@ func (r *ModerationRoutes) GetRoutes() []RouteInfo {
 			Path:        "/api/v1/moderation/block/:id/mutual",
 			Description: "Check mutual block",
 		},
+		{
+			Method:      "POST",
+			Path:        "/api/v1/moderation/appeals",
+			Description: "Appeal a ban",
+		},
 		// Admin moderation routes
 		{
 			Method:      "GET",
@@ -233,6 +306,11 @@ func (r *ModerationRoutes) GetRoutes() []RouteInfo {
 			Path:        "/api/v1/admin/users/:id/appeals",
 			Description: "Get user appeal history",
 		},
+		{
+			Method:      "GET",
+			Path:        "/api/v1/admin/linked-accounts",
+			Description: "List devices and IP addresses shared by suspected linked accounts",
+		},
 		{
 			Method:      "POST",
 			Path:        "/api/v1/admin/appeals/:id/review",
@@ -241,7 +319,17 @@ func (r *ModerationRoutes) GetRoutes() []RouteInfo {
 		{
 			Method:      "GET",
 			Path:        "/api/v1/admin/moderation-queue",
-			Description: "Get moderation queue",
+			Description: "List moderation queue items by priority",
+		},
+		{
+			Method:      "POST",
+			Path:        "/api/v1/admin/moderation-queue/claim",
+			Description: "Claim a moderation queue item, or the next one by priority",
+		},
+		{
+			Method:      "POST",
+			Path:        "/api/v1/admin/moderation-queue/:id/resolve",
+			Description: "Approve, reject or escalate a claimed moderation queue item",
 		},
 		{
 			Method:      "GET",
@@ -64,6 +64,10 @@ func (pr *PaymentRoutes) RegisterRoutes(router *gin.Engine) {
 			pr.rateLimiter.PaymentRateLimit(),
 			pr.paymentHandler.GetActiveSubscription,
 		)
+		protected.GET("/subscription/preview",
+			pr.rateLimiter.PaymentRateLimit(),
+			pr.paymentHandler.PreviewSubscriptionChange,
+		)
 		protected.POST("/subscribe",
 			pr.rateLimiter.PaymentRateLimit(),
 			pr.paymentHandler.Subscribe,
@@ -73,6 +77,18 @@ func (pr *PaymentRoutes) RegisterRoutes(router *gin.Engine) {
 			pr.paymentHandler.CancelSubscription,
 		)
 
+		// Payment intent routes
+		protected.POST("/payment-intents",
+			pr.rateLimiter.PaymentRateLimit(),
+			pr.paymentHandler.CreatePaymentIntent,
+		)
+
+		// Checkout routes
+		protected.POST("/checkout/session",
+			pr.rateLimiter.PaymentRateLimit(),
+			pr.paymentHandler.CreateCheckoutSession,
+		)
+
 		// Payment method routes
 		protected.GET("/payment-methods",
 			pr.rateLimiter.PaymentRateLimit(),
@@ -90,6 +106,22 @@ func (pr *PaymentRoutes) RegisterRoutes(router *gin.Engine) {
 			pr.rateLimiter.PaymentRateLimit(),
 			pr.paymentHandler.DeletePaymentMethod,
 		)
+		protected.PUT("/payment-methods/:id/default",
+			pr.rateLimiter.PaymentRateLimit(),
+			pr.paymentHandler.SetDefaultPaymentMethod,
+		)
+
+		// Invoice routes
+		protected.GET("/invoices/:id/pdf",
+			pr.rateLimiter.PaymentRateLimit(),
+			pr.paymentHandler.GetInvoicePDF,
+		)
+
+		// Promotion code routes
+		protected.POST("/promo/validate",
+			pr.rateLimiter.PaymentRateLimit(),
+			pr.paymentHandler.ValidatePromotionCode,
+		)
 	}
 
 	logger.Info("Payment routes registered successfully", nil)
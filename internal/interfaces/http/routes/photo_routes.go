@@ -40,6 +40,10 @@ func (r *PhotoRoutes) RegisterRoutes(router *gin.RouterGroup, redisClient *redis
 	// Set primary photo route
 	photoGroup.PUT("/:photo_id/set-primary", r.photoHandler.SetPrimaryPhoto)
 
+	// Photo performance routes
+	photoGroup.GET("/performance", r.photoHandler.GetPhotoPerformance)
+	photoGroup.PUT("/auto-reorder", r.photoHandler.SetPhotoAutoReorder)
+
 	// Media routes (for upload/download URLs)
 	mediaGroup := router.Group("/media")
 	mediaGroup.Use(r.rateLimiter.LimitByUser("media_operations", 20, 60)) // 20 operations per minute per user
@@ -78,6 +82,20 @@ func (r *PhotoRoutes) GetPhotoRoutes() []RouteDefinition {
 			AuthRequired: true,
 			RateLimit:   "10 requests per minute per user",
 		},
+		{
+			Method:      "GET",
+			Path:         "/me/photos/performance",
+			Description:  "Get photo performance",
+			AuthRequired: true,
+			RateLimit:   "10 requests per minute per user",
+		},
+		{
+			Method:      "PUT",
+			Path:         "/me/photos/auto-reorder",
+			Description:  "Opt in or out of photo reordering by engagement",
+			AuthRequired: true,
+			RateLimit:   "10 requests per minute per user",
+		},
 		{
 			Method:      "GET",
 			Path:         "/media/request-upload",
@@ -56,6 +56,20 @@ func (r *ProfileRoutes) RegisterRoutes(router *gin.RouterGroup, redisClient inte
 		profile.GET("/me/matches", r.handler.GetMatches)
 		profile.DELETE("/me/account", r.handler.DeleteAccount)
 		
+		// Own profile as other users see it
+		profile.GET("/users/me/preview", r.handler.PreviewProfile)
+		
+		// Aggregate like/pass feedback on own profile
+		profile.GET("/users/me/performance", r.handler.GetPerformance)
+
+		// Saved profiles, separate from likes
+		profile.GET("/users/me/favorites", r.handler.GetFavorites)
+		profile.POST("/users/:id/favorite", r.handler.AddFavorite)
+		profile.DELETE("/users/:id/favorite", r.handler.RemoveFavorite)
+
+		// Hide imported contacts from discovery
+		profile.POST("/users/me/block-contacts", r.handler.BlockContacts)
+
 		// Other user profile routes
 		profile.GET("/users/:id", r.handler.ViewUserProfile)
 	}
@@ -89,6 +103,36 @@ func (r *ProfileRoutes) GetRoutes() []RouteInfo {
 			Path:   "/api/v1/profile/me/account",
 			Description: "Delete user account",
 		},
+		{
+			Method: "GET",
+			Path:   "/api/v1/profile/users/me/preview",
+			Description: "Preview own profile as other users see it",
+		},
+		{
+			Method: "GET",
+			Path:   "/api/v1/profile/users/me/performance",
+			Description: "Get how others respond to own profile",
+		},
+		{
+			Method: "GET",
+			Path:   "/api/v1/profile/users/me/favorites",
+			Description: "Get favorited users",
+		},
+		{
+			Method: "POST",
+			Path:   "/api/v1/profile/users/{id}/favorite",
+			Description: "Add user to favorites",
+		},
+		{
+			Method: "DELETE",
+			Path:   "/api/v1/profile/users/{id}/favorite",
+			Description: "Remove user from favorites",
+		},
+		{
+			Method: "POST",
+			Path:   "/api/v1/profile/users/me/block-contacts",
+			Description: "Block imported contacts from discovery",
+		},
 		{
 			Method: "GET",
 			Path:   "/api/v1/profile/users/{id}",
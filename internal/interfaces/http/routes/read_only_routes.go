@@ -0,0 +1,28 @@
+package routes
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/22smeargle/winkr-backend/internal/interfaces/http/handlers"
+)
+
+// AdminReadOnlyRoutes defines admin routes for the read-only mode
+type AdminReadOnlyRoutes struct {
+	adminHandler *handlers.AdminReadOnlyHandler
+}
+
+// NewAdminReadOnlyRoutes creates new admin read-only mode routes
+func NewAdminReadOnlyRoutes(adminHandler *handlers.AdminReadOnlyHandler) *AdminReadOnlyRoutes {
+	return &AdminReadOnlyRoutes{
+		adminHandler: adminHandler,
+	}
+}
+
+// RegisterAdminRoutes registers admin read-only mode routes
+func (r *AdminReadOnlyRoutes) RegisterAdminRoutes(router *gin.RouterGroup, adminAuthMiddleware gin.HandlerFunc) {
+	admin := router.Group("/admin/system/read-only")
+	admin.Use(adminAuthMiddleware)
+
+	admin.GET("", r.adminHandler.GetReadOnlyMode)
+	// Turns read-only mode on during database maintenance, and off again afterwards
+	admin.PUT("", r.adminHandler.SetReadOnlyMode)
+}
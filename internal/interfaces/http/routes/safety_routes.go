@@ -0,0 +1,31 @@
+package routes
+
+import (
+	"github.com/gin-gonic/gin"
+
+	"github.com/22smeargle/winkr-backend/internal/interfaces/http/handlers"
+	"github.com/22smeargle/winkr-backend/pkg/logger"
+)
+
+// SafetyRoutes defines safety feature routes
+type SafetyRoutes struct {
+	handler *handlers.SafetyHandler
+}
+
+// NewSafetyRoutes creates new safety routes
+func NewSafetyRoutes(handler *handlers.SafetyHandler) *SafetyRoutes {
+	return &SafetyRoutes{
+		handler: handler,
+	}
+}
+
+// RegisterRoutes registers safety routes
+func (r *SafetyRoutes) RegisterRoutes(router *gin.RouterGroup, authMiddleware gin.HandlerFunc) {
+	safety := router.Group("/safety")
+	safety.Use(authMiddleware) // All safety routes require authentication
+
+	safety.POST("/check-in", r.handler.ScheduleCheckIn)
+	safety.POST("/check-in/:id/confirm", r.handler.ConfirmCheckIn)
+
+	logger.Info("Safety routes registered")
+}
@@ -33,6 +33,7 @@ func (r *VerificationRoutes) RegisterRoutes(router *gin.RouterGroup, authMiddlew
 	// Document verification routes
 	verification.POST("/document/request", r.handler.RequestDocumentVerification)
 	verification.POST("/document/submit", r.handler.SubmitDocumentVerification)
+	verification.POST("/document/session/submit", r.handler.SubmitDocumentSession)
 	verification.GET("/document/status", r.handler.GetVerificationStatus)
 
 	logger.Info("Verification routes registered")
@@ -40,13 +41,15 @@ func (r *VerificationRoutes) RegisterRoutes(router *gin.RouterGroup, authMiddlew
 
 // AdminVerificationRoutes defines admin verification routes
 type AdminVerificationRoutes struct {
-	handler *handlers.VerificationHandler
+	handler      *handlers.VerificationHandler
+	adminHandler *handlers.AdminVerificationHandler
 }
 
 // NewAdminVerificationRoutes creates new admin verification routes
-func NewAdminVerificationRoutes(handler *handlers.VerificationHandler) *AdminVerificationRoutes {
+func NewAdminVerificationRoutes(handler *handlers.VerificationHandler, adminHandler *handlers.AdminVerificationHandler) *AdminVerificationRoutes {
 	return &AdminVerificationRoutes{
-		handler: handler,
+		handler:      handler,
+		adminHandler: adminHandler,
 	}
 }
 
@@ -60,6 +63,7 @@ func (r *AdminVerificationRoutes) RegisterAdminRoutes(router *gin.RouterGroup, a
 	admin.POST("/:id/approve", r.handler.ApproveVerification)
 	admin.POST("/:id/reject", r.handler.RejectVerification)
 	admin.GET("/:id", r.handler.GetVerificationDetails)
+	admin.PUT("/users/:user_id/level", r.adminHandler.OverrideVerificationLevel)
 
 	logger.Info("Admin verification routes registered")
 }
\ No newline at end of file
@@ -11,6 +11,7 @@ import (
 	"gorm.io/gorm"
 	"github.com/22smeargle/winkr-backend/pkg/config"
 	"github.com/22smeargle/winkr-backend/pkg/logger"
+	"github.com/22smeargle/winkr-backend/pkg/security"
 	"github.com/22smeargle/winkr-backend/pkg/utils"
 	"github.com/22smeargle/winkr-backend/pkg/validator"
 	"github.com/22smeargle/winkr-backend/internal/interfaces/http/handlers"
@@ -23,10 +24,14 @@ import (
 	"github.com/22smeargle/winkr-backend/internal/application/usecases/payment"
 	"github.com/22smeargle/winkr-backend/internal/application/services"
 	"github.com/22smeargle/winkr-backend/internal/infrastructure/external"
+	"github.com/22smeargle/winkr-backend/internal/infrastructure/external/email"
 	"github.com/22smeargle/winkr-backend/internal/infrastructure/external/stripe"
+	"github.com/22smeargle/winkr-backend/internal/infrastructure/external/webhook"
 	"github.com/22smeargle/winkr-backend/internal/infrastructure/auth"
 	"github.com/22smeargle/winkr-backend/internal/infrastructure/cache"
+	"github.com/22smeargle/winkr-backend/internal/infrastructure/database/postgres"
 	"github.com/22smeargle/winkr-backend/internal/infrastructure/database/postgres/repositories"
+	"github.com/22smeargle/winkr-backend/internal/infrastructure/notification"
 	"github.com/22smeargle/winkr-backend/internal/infrastructure/storage"
 	"github.com/22smeargle/winkr-backend/internal/infrastructure/websocket"
 )
@@ -40,6 +45,10 @@ type Server struct {
 	redis    *redis.Client
 	jwtUtils *utils.JWTUtils
 	middlewareConfig *middleware.MiddlewareConfig
+	healthAggregator *services.HealthAggregator
+	httpMetrics      *middleware.HTTPMetrics
+	poolMetrics      *postgres.PoolMetricsCollector
+	readOnlyMode     *services.ReadOnlyModeService
 }
 
 // NewServer creates a new HTTP server instance
@@ -57,6 +66,28 @@ func NewServer(cfg *config.Config, db *gorm.DB, redisClient *redis.Client) *Serv
 	// Create Gin engine
 	engine := gin.New()
 
+	// Record request metrics ahead of everything else, so rejected requests are counted too
+	var httpMetrics *middleware.HTTPMetrics
+	if cfg.Monitoring.Metrics.Prometheus.Enabled {
+		httpMetrics = middleware.NewHTTPMetrics(&cfg.Monitoring.Metrics.Prometheus)
+		engine.Use(httpMetrics.Middleware())
+	}
+
+	// Export connection pool stats alongside the request metrics
+	var poolMetrics *postgres.PoolMetricsCollector
+	if httpMetrics != nil && cfg.Monitoring.Metrics.DatabaseMetricsEnabled {
+		if sqlDB, err := db.DB(); err != nil {
+			logger.Error("Failed to get underlying sql.DB for pool metrics", err)
+		} else {
+			poolMetrics = postgres.NewPoolMetricsCollector(sqlDB, &cfg.Monitoring.Metrics)
+		}
+	}
+
+	// Tag requests with a correlation ID before anything logs, so every entry of a request carries it
+	if cfg.Monitoring.Logging.CorrelationIDEnabled {
+		engine.Use(middleware.CorrelationID(middleware.CorrelationIDHeader))
+	}
+
 	// Add middleware in proper order
 	// 1. Security middleware (first line of defense)
 	engine.Use(middleware.Security(middlewareConfig.Security))
@@ -81,6 +112,10 @@ func NewServer(cfg *config.Config, db *gorm.DB, redisClient *redis.Client) *Serv
 	
 	// 8. Authentication middleware
 	engine.Use(middleware.Auth(middlewareConfig.Auth))
+	
+	// 9. Read-only mode middleware, rejecting writes during database maintenance
+	readOnlyMode := services.NewReadOnlyModeService(&cfg.App, cache.NewSystemStateStore(redisClient))
+	engine.Use(middleware.ReadOnly(readOnlyMode, middleware.DefaultReadOnlyExemptPaths))
 
 	// Create server instance
 	server := &Server{
@@ -90,6 +125,9 @@ func NewServer(cfg *config.Config, db *gorm.DB, redisClient *redis.Client) *Serv
 		redis:           redisClient,
 		jwtUtils:        jwtUtils,
 		middlewareConfig: middlewareConfig,
+		httpMetrics:     httpMetrics,
+		poolMetrics:     poolMetrics,
+		readOnlyMode:    readOnlyMode,
 		server: &http.Server{
 			Addr:         fmt.Sprintf(":%d", cfg.App.Port),
 			Handler:      engine,
@@ -109,10 +147,22 @@ func (s *Server) Start() error {
 	// Setup all routes
 	s.SetupRoutes()
 	
-	// Add legacy health check routes for backward compatibility
+	// Add health check routes, with the liveness and readiness split for Kubernetes probes
 	s.engine.GET("/health", s.healthCheck)
+	s.engine.GET("/health/live", s.livenessCheck)
+	s.engine.GET("/health/ready", s.readinessCheck)
 	s.engine.GET("/health/db", s.databaseHealthCheck)
 
+	// Expose request metrics for Prometheus to scrape
+	if s.httpMetrics != nil {
+		var collectors []middleware.PrometheusCollector
+		if s.poolMetrics != nil {
+			s.poolMetrics.Start()
+			collectors = append(collectors, s.poolMetrics)
+		}
+		s.engine.GET(s.config.Monitoring.Metrics.Prometheus.Path, s.httpMetrics.Handler(collectors...))
+	}
+
 	return s.server.ListenAndServe()
 }
 
@@ -120,6 +170,10 @@ func (s *Server) Start() error {
 func (s *Server) Shutdown(ctx context.Context) error {
 	logger.Info("Shutting down HTTP server...")
 	
+	if s.poolMetrics != nil {
+		s.poolMetrics.Stop()
+	}
+
 	return s.server.Shutdown(ctx)
 }
 
@@ -148,27 +202,45 @@ func (s *Server) SetupRoutes() {
 	// Initialize repositories
 	userRepo := repositories.NewUserRepository(s.db)
 	photoRepo := repositories.NewPhotoRepository(s.db)
+	ephemeralPhotoRepo := repositories.NewEphemeralPhotoRepository(s.db)
 	verificationRepo := repositories.NewVerificationRepository(s.db)
 	messageRepo := repositories.NewMessageRepository(s.db)
-	matchRepo := repositories.NewMatchRepository(s.db)
+	// Swiped-ID sets are cached in Redis and updated on each swipe, as discovery excludes them every request
+	matchRepo := cache.NewSwipedUsersCache(repositories.NewMatchRepository(s.db), s.redis)
 	subscriptionRepo := repositories.NewSubscriptionRepository(s.db)
 	paymentRepo := repositories.NewPaymentRepository(s.db)
 	paymentMethodRepo := repositories.NewPaymentMethodRepository(s.db)
 	refundRepo := repositories.NewRefundRepository(s.db)
 	invoiceRepo := repositories.NewInvoiceRepository(s.db)
 	webhookEventRepo := repositories.NewWebhookEventRepository(s.db)
+	noticeAckRepo := repositories.NewNoticeAcknowledgementRepository(s.db)
+	// Blocked-ID sets are cached in Redis as discovery and chat check them on every request
+	blockRepo := cache.NewBlockedUsersCache(repositories.NewUserBlockRepository(s.db), s.redis)
+	accountSignalRepo := repositories.NewAccountSignalRepository(s.db)
+	safetyCheckInRepo := repositories.NewSafetyCheckInRepository(s.db)
+	scheduledMessageRepo := repositories.NewScheduledMessageRepository(s.db)
+	photoEngagementRepo := repositories.NewPhotoEngagementRepository(s.db)
 	
 	// Initialize services
 	tokenManager := auth.NewTokenManager(s.jwtUtils)
 	cacheService := cache.NewCacheService(s.redis)
 	sessionManager := cache.NewSessionManager(s.redis, tokenManager)
-	rateLimiter := cache.NewRateLimiter(s.redis)
+	rateLimiter := cache.NewRateLimiterWithStore(cache.NewRateLimitStore(&s.config.RateLimit, s.redis))
 	verificationService := services.NewVerificationService(cacheService, rateLimiter)
 	
 	// Initialize chat services
 	messageService := services.NewMessageService(&s.config.Chat, cacheService)
 	chatSecurityService := services.NewChatSecurityService(&s.config.Chat.Security, cacheService)
 	chatCacheService := services.NewChatCacheService(s.redis, &s.config.Chat.Cache)
+	messageReceiptService := services.NewMessageReceiptService(messageRepo)
+	
+	// The mock is the only translation provider so far; translation stays off unless configured
+	messageTranslationService := services.NewMessageTranslationService(
+		external.NewMockTranslationService("en"),
+		chatCacheService,
+		userRepo,
+		&s.config.Chat.Message.Translation,
+	)
 	
 	// Initialize WebSocket connection manager
 	connectionManager := websocket.NewConnectionManager(
@@ -178,6 +250,21 @@ func (s *Server) SetupRoutes() {
 		&s.config.Chat.WebSocket,
 	)
 	
+	// Link previews are fetched in the background and pushed to the conversation when ready
+	linkPreviewService := services.NewLinkPreviewService(
+		chatCacheService,
+		notification.NewLinkPreviewNotifier(connectionManager),
+		&s.config.Chat.Security,
+		&s.config.Chat.Cache,
+	)
+	
+	// Messages of shadowbanned users only reach their own connections and message history
+	shadowbanService := services.NewShadowbanService(userRepo)
+	connectionManager.SetShadowbanCheck(shadowbanService.IsShadowbanned)
+	
+	// Typing indicators reach the other participants at most once per window
+	connectionManager.SetTypingIndicatorTTL(s.config.Chat.Cache.TypingIndicatorTTL)
+	
 	// Initialize AI service
 	aiService := external.NewAIService(&s.config.Verification.AIService)
 	
@@ -185,7 +272,10 @@ func (s *Server) SetupRoutes() {
 	stripeService := stripe.NewStripeService(&s.config.Stripe)
 	
 	// Initialize document service
-	documentService := services.NewDocumentService(&s.config.Verification.DocumentProcessing)
+	documentService := services.NewDocumentService(aiService, &s.config.Verification.DocumentProcessing)
+	
+	// Initialize outbound webhook publisher
+	webhookPublisher := webhook.NewPublisher(&s.config.Webhooks)
 	
 	// Initialize verification workflow service
 	verificationWorkflowService := services.NewVerificationWorkflowService(
@@ -194,22 +284,33 @@ func (s *Server) SetupRoutes() {
 		aiService,
 		documentService,
 		storageService,
-		cacheService,
-		&s.config.Verification,
+		&s.config.Verification.Limits,
+		notification.NewVerificationNotifier(connectionManager),
+		webhookPublisher,
 	)
+	verificationWorkflowService.StartExpiryScheduler(context.Background(), s.config.Verification.Limits.ExpiryCheckInterval)
 	
 	// Initialize storage service
 	storageService, err := storage.NewS3Storage(&s.config.Storage)
 	if err != nil {
 		logger.Fatal("Failed to initialize storage service: %v", err)
 	}
+	s.initHealthAggregator(storageService)
 	
 	// Initialize image processing service
 	imageProcessor := services.NewImageProcessor(&s.config.Storage)
 	
+	// Initialize multi-account detector
+	multiAccountDetector := services.NewMultiAccountDetector(
+		accountSignalRepo,
+		userRepo,
+		&s.config.Moderation.MultiAccount,
+		&s.config.Verification.Security,
+	)
+	
 	// Initialize use cases
-	registerUseCase := auth.NewRegisterUseCase(userRepo, tokenManager, sessionManager, verificationService)
-	loginUseCase := auth.NewLoginUseCase(userRepo, tokenManager, sessionManager, rateLimiter)
+	registerUseCase := auth.NewRegisterUseCase(userRepo, tokenManager, sessionManager, verificationService, multiAccountDetector)
+	loginUseCase := auth.NewLoginUseCase(userRepo, tokenManager, sessionManager, rateLimiter, multiAccountDetector)
 	refreshUseCase := auth.NewRefreshTokenUseCase(tokenManager, sessionManager)
 	logoutUseCase := auth.NewLogoutUseCase(tokenManager, sessionManager)
 	passwordResetUseCase := auth.NewPasswordResetUseCase(userRepo, verificationService, rateLimiter)
@@ -217,14 +318,54 @@ func (s *Server) SetupRoutes() {
 	emailVerificationUseCase := auth.NewEmailVerificationUseCase(userRepo, verificationService, rateLimiter)
 	getProfileUseCase := auth.NewGetProfileUseCase(userRepo)
 	getSessionsUseCase := auth.NewGetSessionsUseCase(sessionManager)
+	revokeSessionUseCase := auth.NewRevokeSessionUseCase(sessionManager)
+	
+	// Initialize photo engagement service
+	photoEngagementService := services.NewPhotoEngagementService(photoEngagementRepo, photoRepo, &s.config.Matching.PhotoReorder)
+	if s.config.Matching.PhotoReorder.Enabled {
+		photoEngagementService.StartReorderScheduler(context.Background(), s.config.Matching.PhotoReorder.Interval)
+	}
 	
 	// Initialize photo use cases
-	uploadPhotoUseCase := photo.NewUploadPhotoUseCase(photoRepo, storageService, imageProcessor)
+	photoLimitService := services.NewPhotoLimitService(photoRepo, ephemeralPhotoRepo, subscriptionRepo, &s.config.Storage, &s.config.EphemeralPhoto)
+	
+	// Users lose reputation for confirmed reports and removed content and slowly regain it
+	reputationService := services.NewReputationService(repositories.NewReputationRepository(s.db), userRepo, &s.config.Moderation.Rules)
+	reputationService.Start(context.Background())
+	
+	// Uploaded photos are screened by the moderation worker before they go live
+	var moderatePhotoUseCase *photo.ModeratePhotoUseCase
+	if aiModeration := &s.config.Moderation.AIModeration; aiModeration.Enabled {
+		var imageModerator services.ImageModerator
+		if aiModeration.Provider == "mock" {
+			imageModerator = external.NewMockImageModerator()
+		} else {
+			// Rekognition reads the photos straight from the storage bucket
+			imageModerator, err = external.NewAIModerationService(aiModeration.Region, s.config.Storage.Bucket, aiModeration.ConfidenceThreshold, aiModeration.NSFWThreshold, aiModeration.ViolenceThreshold, aiModeration.AdultThreshold)
+			if err != nil {
+				logger.Fatal("Failed to initialize image moderation: %v", err)
+			}
+		}
+		
+		photoModerationQueue := services.NewRedisPhotoModerationQueue(s.redis)
+		moderatePhotoUseCase = photo.NewModeratePhotoUseCase(photoRepo, photoModerationQueue)
+		services.NewPhotoModerationWorker(
+			photoRepo,
+			imageModerator,
+			photoModerationQueue,
+			notification.NewPhotoModerationNotifier(connectionManager),
+			reputationService,
+			aiModeration,
+		).Start(context.Background())
+	}
+	uploadPhotoUseCase := photo.NewUploadPhotoUseCase(photoRepo, storageService, imageProcessor, photoLimitService, moderatePhotoUseCase)
 	deletePhotoUseCase := photo.NewDeletePhotoUseCase(photoRepo, storageService)
-	getUploadURLUseCase := photo.NewGetUploadURLUseCase(photoRepo, storageService, s.config.Storage.MaxFileSize, s.config.Storage.AllowedTypes)
+	getUploadURLUseCase := photo.NewGetUploadURLUseCase(photoRepo, storageService, photoLimitService, s.config.Storage.MaxFileSize, s.config.Storage.AllowedTypes)
 	getDownloadURLUseCase := photo.NewGetDownloadURLUseCase(photoRepo, storageService)
 	setPrimaryPhotoUseCase := photo.NewSetPrimaryPhotoUseCase(photoRepo)
-	markPhotoViewedUseCase := photo.NewMarkPhotoViewedUseCase(photoRepo)
+	markPhotoViewedUseCase := photo.NewMarkPhotoViewedUseCase(photoRepo, photoEngagementService)
+	getPhotoPerformanceUseCase := photo.NewGetPhotoPerformanceUseCase(photoRepo, photoEngagementRepo, userRepo)
+	setPhotoAutoReorderUseCase := photo.NewSetPhotoAutoReorderUseCase(userRepo, &s.config.Matching.PhotoReorder)
 	
 	// Initialize verification use cases
 	requestSelfieVerificationUseCase := verification.NewRequestSelfieVerificationUseCase(verificationRepo, userRepo, verificationWorkflowService, rateLimiter)
@@ -232,40 +373,114 @@ func (s *Server) SetupRoutes() {
 	getVerificationStatusUseCase := verification.NewGetVerificationStatusUseCase(verificationRepo, userRepo)
 	requestDocumentVerificationUseCase := verification.NewRequestDocumentVerificationUseCase(verificationRepo, userRepo, verificationWorkflowService, rateLimiter)
 	submitDocumentVerificationUseCase := verification.NewSubmitDocumentVerificationUseCase(verificationRepo, userRepo, verificationWorkflowService, storageService, rateLimiter)
+	submitDocumentSessionUseCase := verification.NewSubmitDocumentSessionUseCase(verificationWorkflowService)
 	processVerificationResultUseCase := verification.NewProcessVerificationResultUseCase(verificationRepo, userRepo, verificationWorkflowService)
+	overrideVerificationLevelUseCase := verification.NewOverrideVerificationLevelUseCase(verificationWorkflowService)
 	getPendingVerificationsUseCase := verification.NewGetPendingVerificationsUseCase(verificationRepo)
 	
 	// Initialize chat use cases
 	getConversationsUseCase := chat.NewGetConversationsUseCase(messageRepo, chatCacheService)
-	getMessagesUseCase := chat.NewGetMessagesUseCase(messageRepo, chatCacheService)
-	sendMessageUseCase := chat.NewSendMessageUseCase(messageRepo, messageService, chatSecurityService, chatCacheService, connectionManager)
-	markMessagesReadUseCase := chat.NewMarkMessagesReadUseCase(messageRepo, chatCacheService, connectionManager)
-	deleteMessageUseCase := chat.NewDeleteMessageUseCase(messageRepo, chatSecurityService, chatCacheService, connectionManager)
-	startConversationUseCase := chat.NewStartConversationUseCase(messageRepo, matchRepo, chatCacheService, connectionManager)
+	
+	// Reading messages only tells their senders when the reader has read receipts on
+	readReceiptNotifier := notification.NewReadReceiptNotifier(connectionManager)
+	readReceiptService := services.NewReadReceiptService(messageRepo, userRepo, messageReceiptService, readReceiptNotifier, chatCacheService)
+	getMessagesUseCase := chat.NewGetMessagesUseCase(messageRepo, messageReceiptService, messageTranslationService, shadowbanService, readReceiptService)
+	conversationLimiter := chat.NewConversationLimiter(messageRepo, subscriptionRepo, &s.config.Chat.Message)
+	conversationRateLimiter := chat.NewConversationRateLimiter(rateLimiter, &s.config.Chat.RateLimit)
+	conversationEngagementService := services.NewConversationEngagementService(
+		messageRepo,
+		matchRepo,
+		notification.NewMilestoneNotifier(connectionManager),
+		&s.config.Chat.Message.Engagement,
+	)
+	messageModerationService := services.NewMessageModerationService(services.NewRedisFlaggedMessageQueue(s.redis), &s.config.Chat.Security)
+	messagePIIRedactor := services.NewMessagePIIRedactor(&s.config.Chat.Security, &s.config.Chat.Message)
+	sendMessageUseCase := chat.NewSendMessageUseCase(messageRepo, userRepo, matchRepo, messageService, messageReceiptService, conversationEngagementService, linkPreviewService, messageModerationService, messagePIIRedactor, conversationLimiter, conversationRateLimiter, blockRepo, &s.config.Chat.Message)
+	markMessagesReadUseCase := chat.NewMarkMessagesReadUseCase(messageRepo, messageReceiptService, readReceiptService)
+	markAllReadUseCase := chat.NewMarkAllReadUseCase(messageRepo, messageReceiptService, readReceiptNotifier, chatCacheService, readReceiptService)
+	editMessageUseCase := chat.NewEditMessageUseCase(messageRepo, messagePIIRedactor, &s.config.Chat.Message)
+	deleteMessageUseCase := chat.NewDeleteMessageUseCase(messageRepo, &s.config.Chat.Message)
+	startConversationUseCase := chat.NewStartConversationUseCase(messageRepo, matchRepo, conversationLimiter)
+	pinMessageUseCase := chat.NewPinMessageUseCase(messageRepo, &s.config.Chat.Message)
+	reactToMessageUseCase := chat.NewReactToMessageUseCase(messageRepo, &s.config.Chat.Message)
+	getPinnedMessagesUseCase := chat.NewGetPinnedMessagesUseCase(messageRepo)
+	archiveConversationUseCase := chat.NewArchiveConversationUseCase(messageRepo, conversationLimiter)
+	scheduleMessageUseCase := chat.NewScheduleMessageUseCase(
+		messageRepo,
+		scheduledMessageRepo,
+		sendMessageUseCase,
+		notification.NewScheduledMessageNotifier(connectionManager),
+		&s.config.Chat.Message.Scheduled,
+	)
+	if s.config.Chat.Message.Scheduled.Enabled {
+		scheduleMessageUseCase.StartDeliveryScheduler(context.Background(), s.config.Chat.Message.Scheduled.CheckInterval)
+	}
 	
 	// Initialize payment use cases
 	getPlansUseCase := payment.NewGetPlansUseCase(stripeService)
 	getPlanByIDUseCase := payment.NewGetPlanByIDUseCase(stripeService)
 	subscribeUseCase := payment.NewSubscribeUseCase(subscriptionRepo, paymentRepo, userRepo, stripeService, cacheService)
+	createPaymentIntentUseCase := payment.NewCreatePaymentIntentUseCase(userRepo, stripeService, cache.NewPaymentCacheService(s.redis))
+	createCheckoutSessionUseCase := payment.NewCreateCheckoutSessionUseCase(userRepo, stripeService)
 	getSubscriptionUseCase := payment.NewGetSubscriptionUseCase(subscriptionRepo, userRepo, cacheService)
 	getActiveSubscriptionUseCase := payment.NewGetActiveSubscriptionUseCase(subscriptionRepo, userRepo, cacheService)
-	cancelSubscriptionUseCase := payment.NewCancelSubscriptionUseCase(subscriptionRepo, stripeService, cacheService)
+	cancelSubscriptionUseCase := payment.NewCancelSubscriptionUseCase(subscriptionRepo, stripeService, cacheService, s.config.Stripe.CancellationRefundWindow)
+	previewSubscriptionChangeUseCase := payment.NewPreviewSubscriptionChangeUseCase(userRepo, subscriptionRepo, stripeService)
 	updateSubscriptionUseCase := payment.NewUpdateSubscriptionUseCase(subscriptionRepo, stripeService, cacheService)
 	addPaymentMethodUseCase := payment.NewAddPaymentMethodUseCase(paymentMethodRepo, userRepo, stripeService, cacheService)
 	getPaymentMethodsUseCase := payment.NewGetPaymentMethodsUseCase(paymentMethodRepo, userRepo, cacheService)
 	getDefaultPaymentMethodUseCase := payment.NewGetDefaultPaymentMethodUseCase(paymentMethodRepo, userRepo, cacheService)
 	deletePaymentMethodUseCase := payment.NewDeletePaymentMethodUseCase(paymentMethodRepo, stripeService, cacheService)
-	processWebhookUseCase := payment.NewProcessWebhookUseCase(webhookEventRepo, subscriptionRepo, paymentRepo, paymentMethodRepo, refundRepo, invoiceRepo, userRepo, stripeService, cacheService)
+	setDefaultPaymentMethodUseCase := payment.NewSetDefaultPaymentMethodUseCase(userRepo, paymentMethodRepo, stripeService)
+	getInvoicePDFUseCase := payment.NewGetInvoicePDFUseCase(userRepo, stripeService)
+	validatePromotionCodeUseCase := payment.NewValidatePromotionCodeUseCase(stripeService)
+	entitlementService := services.NewEntitlementService(services.NewRedisEntitlementStore(s.redis), &s.config.Entitlements)
+	processWebhookUseCase := payment.NewProcessWebhookUseCase(webhookEventRepo, subscriptionRepo, paymentRepo, paymentMethodRepo, refundRepo, invoiceRepo, userRepo, stripeService, cacheService, entitlementService, services.NewRedisWebhookEventStore(s.redis), s.config.Stripe.WebhookMaxAge, s.config.Stripe.GracePeriod)
+	expireGracePeriodsUseCase := payment.NewExpireGracePeriodsUseCase(subscriptionRepo, userRepo)
+	if s.config.Stripe.GracePeriodCheckInterval > 0 {
+		expireGracePeriodsUseCase.StartScheduler(context.Background(), s.config.Stripe.GracePeriodCheckInterval)
+	}
 	
 	// Initialize subscription service
 	subscriptionService := services.NewSubscriptionService(subscriptionRepo, stripeService, cacheService)
 	
+	// Initialize geofence service
+	geofenceService := services.NewGeofenceService(userRepo, &s.config.Geofencing)
+	
+	// Initialize legal notice service
+	legalNoticeService := services.NewLegalNoticeService(noticeAckRepo, &s.config.Legal)
+	
+	// Initialize safety check-in service
+	safetyCheckInService := services.NewSafetyCheckInService(
+		safetyCheckInRepo,
+		userRepo,
+		notification.NewSafetyNotifier(connectionManager, email.NewSMTPEmailService(&s.config.Email)),
+		&s.config.Safety.CheckIn,
+	)
+	if s.config.Safety.CheckIn.Enabled {
+		safetyCheckInService.StartAlertScheduler(context.Background(), s.config.Safety.CheckIn.CheckInterval)
+	}
+	
+	// Initialize deep link builder
+	deepLinkBuilder := services.NewDeepLinkBuilder(matchRepo, messageRepo, userRepo, &s.config.DeepLink)
+	
 	// Initialize validators
-	authValidator := validator.NewAuthValidator()
+	passwordPolicy, err := security.NewPasswordPolicy(&s.config.Security)
+	if err != nil {
+		logger.Fatal("Failed to initialize password policy: %v", err)
+	}
+	validator.SetPasswordPolicy(passwordPolicy)
+	authValidator := validator.NewAuthValidator(passwordPolicy)
 	
 	// Initialize middleware
 	authRateLimiter := middleware.NewAuthRateLimiter(rateLimiter)
 	suspiciousDetector := middleware.NewSuspiciousActivityDetector(rateLimiter)
+	geofenceMiddleware := middleware.NewGeofenceMiddleware(
+		geofenceService,
+		s.config.Geofencing.CountryHeader,
+		s.config.Geofencing.RegionHeader,
+	)
+	legalNoticeMiddleware := middleware.NewLegalNoticeMiddleware(legalNoticeService)
 	
 	// Initialize handlers
 	authHandler := handlers.NewAuthHandler(
@@ -278,6 +493,7 @@ func (s *Server) SetupRoutes() {
 		emailVerificationUseCase,
 		getProfileUseCase,
 		getSessionsUseCase,
+		revokeSessionUseCase,
 		s.jwtUtils,
 		authValidator,
 		authRateLimiter,
@@ -291,6 +507,8 @@ func (s *Server) SetupRoutes() {
 		getDownloadURLUseCase,
 		setPrimaryPhotoUseCase,
 		markPhotoViewedUseCase,
+		getPhotoPerformanceUseCase,
+		setPhotoAutoReorderUseCase,
 		s.jwtUtils,
 	)
 	
@@ -301,6 +519,7 @@ func (s *Server) SetupRoutes() {
 		getVerificationStatusUseCase,
 		requestDocumentVerificationUseCase,
 		submitDocumentVerificationUseCase,
+		submitDocumentSessionUseCase,
 		s.jwtUtils,
 	)
 	
@@ -308,6 +527,7 @@ func (s *Server) SetupRoutes() {
 		processVerificationResultUseCase,
 		getPendingVerificationsUseCase,
 		s.jwtUtils,
+		overrideVerificationLevelUseCase,
 	)
 	
 	// Initialize chat handler
@@ -316,8 +536,15 @@ func (s *Server) SetupRoutes() {
 		getMessagesUseCase,
 		sendMessageUseCase,
 		markMessagesReadUseCase,
+		markAllReadUseCase,
+		editMessageUseCase,
 		deleteMessageUseCase,
 		startConversationUseCase,
+		pinMessageUseCase,
+		reactToMessageUseCase,
+		getPinnedMessagesUseCase,
+		archiveConversationUseCase,
+		scheduleMessageUseCase,
 		connectionManager,
 		s.jwtUtils,
 	)
@@ -327,14 +554,20 @@ func (s *Server) SetupRoutes() {
 		getPlansUseCase,
 		getPlanByIDUseCase,
 		subscribeUseCase,
+		createPaymentIntentUseCase,
+		createCheckoutSessionUseCase,
 		getSubscriptionUseCase,
 		getActiveSubscriptionUseCase,
 		cancelSubscriptionUseCase,
+		previewSubscriptionChangeUseCase,
 		updateSubscriptionUseCase,
 		addPaymentMethodUseCase,
 		getPaymentMethodsUseCase,
 		getDefaultPaymentMethodUseCase,
 		deletePaymentMethodUseCase,
+		setDefaultPaymentMethodUseCase,
+		getInvoicePDFUseCase,
+		validatePromotionCodeUseCase,
 		processWebhookUseCase,
 		subscriptionService,
 		s.jwtUtils,
@@ -346,6 +579,7 @@ func (s *Server) SetupRoutes() {
 		s.middlewareConfig.Security,
 		s.middlewareConfig.RateLimit,
 		s.middlewareConfig.CSRF,
+		geofenceMiddleware,
 	)
 	
 	photoRoutes := routes.NewPhotoRoutes(
@@ -362,10 +596,20 @@ func (s *Server) SetupRoutes() {
 	// Initialize chat routes
 	chatRoutes := routes.NewChatRoutes(
 		chatHandler,
+		legalNoticeMiddleware,
 		rateLimiter,
 		&s.config.Chat.RateLimit,
 	)
 	
+	// Initialize legal notice routes
+	legalRoutes := routes.NewLegalRoutes(handlers.NewLegalHandler(legalNoticeService))
+	
+	// Initialize safety routes
+	safetyRoutes := routes.NewSafetyRoutes(handlers.NewSafetyHandler(safetyCheckInService))
+	
+	// Initialize deep link routes
+	deepLinkRoutes := routes.NewDeepLinkRoutes(handlers.NewDeepLinkHandler(deepLinkBuilder))
+	
 	// Initialize payment routes
 	paymentRoutes := routes.NewPaymentRoutes(
 		paymentHandler,
@@ -388,12 +632,30 @@ func (s *Server) SetupRoutes() {
 	// Register chat routes
 	chatRoutes.RegisterRoutes(v1, s.redis)
 	
+	// Register legal notice routes
+	legalRoutes.RegisterRoutes(v1, middleware.AuthMiddleware())
+	
+	// Register safety routes
+	safetyRoutes.RegisterRoutes(v1, middleware.AuthMiddleware())
+	
+	// Register deep link routes
+	deepLinkRoutes.RegisterRoutes(v1, middleware.AuthMiddleware())
+	
 	// Register payment routes
 	paymentRoutes.RegisterRoutes(v1, s.redis)
 	
 	// Register WebSocket endpoint
 	connectionManager.RegisterWebSocketRoutes(s.engine)
 	
+	// Register read-only mode admin routes
+	readOnlyRoutes := routes.NewAdminReadOnlyRoutes(handlers.NewAdminReadOnlyHandler(s.readOnlyMode))
+	readOnlyRoutes.RegisterAdminRoutes(v1, middleware.AdminAuthMiddleware())
+	
+	// Register match scoring admin routes; the service is shared with discovery ranking
+	matchScoring := services.NewMatchScoringService(&s.config.Matching.Scoring, cache.NewSystemStateStore(s.redis))
+	matchScoringRoutes := routes.NewAdminMatchScoringRoutes(handlers.NewAdminMatchScoringHandler(matchScoring))
+	matchScoringRoutes.RegisterAdminRoutes(v1, middleware.AdminAuthMiddleware())
+	
 	// Register health routes
 	healthRoutes := routes.NewHealthRoutes()
 	healthRoutes.RegisterRoutes(v1)
@@ -422,12 +684,76 @@ func (s *Server) RegisterSwagger() {
 	logger.Info("Swagger documentation registered")
 }
 
-// healthCheck handles basic health check
+// initHealthAggregator registers the dependencies checked by the health endpoints, with their
+// configured response time thresholds
+func (s *Server) initHealthAggregator(storageService storage.StorageService) {
+	cfg := &s.config.Monitoring.HealthCheck
+	s.healthAggregator = services.NewHealthAggregator(cfg)
+	s.healthAggregator.SetReadOnlyCheck(s.readOnlyMode.IsReadOnly)
+
+	if cfg.DatabaseEnabled {
+		s.healthAggregator.AddComponent("database", time.Duration(cfg.DatabaseThreshold)*time.Millisecond, func(ctx context.Context) error {
+			sqlDB, err := s.db.DB()
+			if err != nil {
+				return err
+			}
+			return sqlDB.PingContext(ctx)
+		})
+	}
+	if cfg.RedisEnabled {
+		s.healthAggregator.AddComponent("redis", time.Duration(cfg.RedisThreshold)*time.Millisecond, func(ctx context.Context) error {
+			return s.redis.Ping(ctx).Err()
+		})
+	}
+	if cfg.StorageEnabled {
+		s.healthAggregator.AddComponent("storage", time.Duration(cfg.StorageThreshold)*time.Millisecond, func(ctx context.Context) error {
+			// A missing object still proves the bucket is reachable
+			_, err := storageService.FileExists(ctx, "health-check")
+			return err
+		})
+	}
+}
+
+// healthCheck reports the status and latency of every dependency. Degraded dependencies still
+// answer 200, only an unhealthy one answers 503.
 func (s *Server) healthCheck(c *gin.Context) {
+	report := s.healthAggregator.Check(c.Request.Context())
+
+	statusCode := http.StatusOK
+	if !report.Ready() {
+		statusCode = http.StatusServiceUnavailable
+	}
+	c.Header("Cache-Control", "no-store")
+	c.JSON(statusCode, report)
+}
+
+// livenessCheck reports that the process is up, without checking dependencies, so an outage of a
+// dependency doesn't get the pod restarted
+func (s *Server) livenessCheck(c *gin.Context) {
+	c.Header("Cache-Control", "no-store")
 	c.JSON(http.StatusOK, gin.H{
-		"status":    "ok",
-		"timestamp": time.Now().UTC(),
-		"version":   "1.0.0",
+		"status":         "alive",
+		"timestamp":      time.Now().UTC(),
+		"uptime_seconds": int64(s.healthAggregator.Uptime().Seconds()),
+	})
+}
+
+// readinessCheck reports whether the dependencies can serve traffic
+func (s *Server) readinessCheck(c *gin.Context) {
+	report := s.healthAggregator.Check(c.Request.Context())
+
+	statusCode := http.StatusOK
+	status := "ready"
+	if !report.Ready() {
+		statusCode = http.StatusServiceUnavailable
+		status = "not_ready"
+	}
+	c.Header("Cache-Control", "no-store")
+	c.JSON(statusCode, gin.H{
+		"status":     status,
+		"timestamp":  report.Timestamp,
+		"components": report.Components,
+		"read_only":  report.ReadOnly,
 	})
 }
 
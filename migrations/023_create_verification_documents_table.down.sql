@@ -0,0 +1,14 @@
+-- +goose Down
+-- SQL in this section is executed when the migration is rolled back.
+
+-- Drop indexes
+DROP INDEX IF EXISTS idx_verification_documents_verification_id;
+DROP INDEX IF EXISTS idx_verification_documents_user_id;
+DROP INDEX IF EXISTS idx_verification_documents_status;
+
+-- Drop foreign key constraints
+ALTER TABLE verification_documents DROP CONSTRAINT IF EXISTS fk_verification_documents_verification_id;
+ALTER TABLE verification_documents DROP CONSTRAINT IF EXISTS fk_verification_documents_user_id;
+
+-- Drop table
+DROP TABLE IF EXISTS verification_documents;
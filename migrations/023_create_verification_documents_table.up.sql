@@ -0,0 +1,33 @@
+-- +goose Up
+-- SQL in this section is executed when the migration is applied.
+
+CREATE TABLE verification_documents (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    verification_id UUID NOT NULL,
+    user_id UUID NOT NULL,
+    document_type VARCHAR(50) NOT NULL,
+    status VARCHAR(20) NOT NULL DEFAULT 'pending' CHECK (status IN ('pending', 'approved', 'rejected')),
+    photo_url TEXT NOT NULL,
+    photo_key VARCHAR(500) NOT NULL,
+    confidence DECIMAL(3,2),
+    document_data TEXT,
+    rejection_reason TEXT,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT NOW()
+);
+
+-- Create foreign key constraints
+ALTER TABLE verification_documents ADD CONSTRAINT fk_verification_documents_verification_id
+    FOREIGN KEY (verification_id) REFERENCES verifications(id) ON DELETE CASCADE;
+ALTER TABLE verification_documents ADD CONSTRAINT fk_verification_documents_user_id
+    FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE;
+
+-- Create indexes for performance
+CREATE INDEX idx_verification_documents_verification_id ON verification_documents(verification_id);
+CREATE INDEX idx_verification_documents_user_id ON verification_documents(user_id);
+CREATE INDEX idx_verification_documents_status ON verification_documents(status);
+
+-- Add comments for documentation
+COMMENT ON TABLE verification_documents IS 'Individual documents submitted within a document verification session';
+COMMENT ON COLUMN verification_documents.document_type IS 'Document type (e.g., id_card, passport, proof_of_address)';
+COMMENT ON COLUMN verification_documents.status IS 'Per-document status: pending, approved, rejected';
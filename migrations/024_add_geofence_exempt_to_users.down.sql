@@ -0,0 +1,4 @@
+-- +goose Down
+-- SQL in this section is executed when the migration is rolled back.
+
+ALTER TABLE users DROP COLUMN IF EXISTS geofence_exempt;
@@ -0,0 +1,5 @@
+-- +goose Up
+-- SQL in this section is executed when the migration is applied.
+
+-- Admin override that lets an account bypass regional restrictions
+ALTER TABLE users ADD COLUMN geofence_exempt BOOLEAN DEFAULT FALSE;
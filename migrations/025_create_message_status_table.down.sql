@@ -0,0 +1,13 @@
+-- +goose Down
+-- SQL in this section is executed when the migration is rolled back.
+
+-- Drop indexes
+DROP INDEX IF EXISTS idx_message_status_message_status;
+DROP INDEX IF EXISTS idx_message_status_user_id;
+
+-- Drop foreign key constraints
+ALTER TABLE message_status DROP CONSTRAINT IF EXISTS fk_message_status_message_id;
+ALTER TABLE message_status DROP CONSTRAINT IF EXISTS fk_message_status_user_id;
+
+-- Drop table
+DROP TABLE IF EXISTS message_status;
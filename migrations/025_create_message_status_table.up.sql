@@ -0,0 +1,25 @@
+-- +goose Up
+-- SQL in this section is executed when the migration is applied.
+
+CREATE TABLE message_status (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    message_id UUID NOT NULL,
+    user_id UUID NOT NULL,
+    status VARCHAR(20) NOT NULL CHECK (status IN ('sent', 'delivered', 'read')),
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW()
+);
+
+-- Create foreign key constraints
+ALTER TABLE message_status ADD CONSTRAINT fk_message_status_message_id
+    FOREIGN KEY (message_id) REFERENCES messages(id) ON DELETE CASCADE;
+ALTER TABLE message_status ADD CONSTRAINT fk_message_status_user_id
+    FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE;
+
+-- Each transition is recorded at most once per message
+CREATE UNIQUE INDEX idx_message_status_message_status ON message_status(message_id, status);
+CREATE INDEX idx_message_status_user_id ON message_status(user_id);
+
+-- Add comments for documentation
+COMMENT ON TABLE message_status IS 'Delivery status transitions of messages, used by clients to reconcile state after reconnecting';
+COMMENT ON COLUMN message_status.user_id IS 'Sender for sent, recipient for delivered and read';
+COMMENT ON COLUMN message_status.created_at IS 'Time the message reached this status';
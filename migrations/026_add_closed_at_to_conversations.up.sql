@@ -0,0 +1,5 @@
+-- +goose Up
+-- SQL in this section is executed when the migration is applied.
+
+-- Conversations are closed when a participant is banned; no further messages can be sent
+ALTER TABLE conversations ADD COLUMN closed_at TIMESTAMP WITH TIME ZONE;
@@ -0,0 +1,7 @@
+-- +goose Down
+-- SQL in this section is executed when the migration is rolled back.
+
+DROP INDEX IF EXISTS idx_messages_conversation_pinned;
+ALTER TABLE messages DROP COLUMN IF EXISTS pinned_by;
+ALTER TABLE messages DROP COLUMN IF EXISTS pinned_at;
+ALTER TABLE messages DROP COLUMN IF EXISTS is_pinned;
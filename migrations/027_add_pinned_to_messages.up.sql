@@ -0,0 +1,9 @@
+-- +goose Up
+-- SQL in this section is executed when the migration is applied.
+
+ALTER TABLE messages ADD COLUMN is_pinned BOOLEAN NOT NULL DEFAULT FALSE;
+ALTER TABLE messages ADD COLUMN pinned_at TIMESTAMP WITH TIME ZONE;
+ALTER TABLE messages ADD COLUMN pinned_by UUID REFERENCES users(id) ON DELETE SET NULL;
+
+-- Pinned messages are listed per conversation and are few, so only index those
+CREATE INDEX idx_messages_conversation_pinned ON messages(conversation_id, pinned_at DESC) WHERE is_pinned = TRUE;
@@ -0,0 +1,11 @@
+-- +goose Down
+-- SQL in this section is executed when the migration is rolled back.
+
+-- Drop indexes
+DROP INDEX IF EXISTS idx_notice_ack_user_notice_version;
+
+-- Drop foreign key constraints
+ALTER TABLE notice_acknowledgements DROP CONSTRAINT IF EXISTS fk_notice_acknowledgements_user_id;
+
+-- Drop table
+DROP TABLE IF EXISTS notice_acknowledgements;
@@ -0,0 +1,22 @@
+-- +goose Up
+-- SQL in this section is executed when the migration is applied.
+
+CREATE TABLE notice_acknowledgements (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    user_id UUID NOT NULL,
+    notice_id VARCHAR(100) NOT NULL,
+    version INTEGER NOT NULL,
+    acknowledged_at TIMESTAMP WITH TIME ZONE DEFAULT NOW()
+);
+
+-- Create foreign key constraints
+ALTER TABLE notice_acknowledgements ADD CONSTRAINT fk_notice_acknowledgements_user_id
+    FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE;
+
+-- Each notice version is acknowledged at most once per user
+CREATE UNIQUE INDEX idx_notice_ack_user_notice_version ON notice_acknowledgements(user_id, notice_id, version);
+
+-- Add comments for documentation
+COMMENT ON TABLE notice_acknowledgements IS 'Community guideline and safety notice acknowledgements, kept per version for compliance';
+COMMENT ON COLUMN notice_acknowledgements.notice_id IS 'Notice ID from the legal.notices configuration';
+COMMENT ON COLUMN notice_acknowledgements.version IS 'Notice version the user acknowledged';
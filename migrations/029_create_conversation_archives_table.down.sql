@@ -0,0 +1,12 @@
+-- +goose Down
+-- SQL in this section is executed when the migration is rolled back.
+
+-- Drop indexes
+DROP INDEX IF EXISTS idx_conversation_archives_user_id;
+
+-- Drop foreign key constraints
+ALTER TABLE conversation_archives DROP CONSTRAINT IF EXISTS fk_conversation_archives_user_id;
+ALTER TABLE conversation_archives DROP CONSTRAINT IF EXISTS fk_conversation_archives_conversation_id;
+
+-- Drop table
+DROP TABLE IF EXISTS conversation_archives;
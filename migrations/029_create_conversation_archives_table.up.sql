@@ -0,0 +1,22 @@
+-- +goose Up
+-- SQL in this section is executed when the migration is applied.
+
+CREATE TABLE conversation_archives (
+    conversation_id UUID NOT NULL,
+    user_id UUID NOT NULL,
+    archived_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+    PRIMARY KEY (conversation_id, user_id)
+);
+
+-- Create foreign key constraints
+ALTER TABLE conversation_archives ADD CONSTRAINT fk_conversation_archives_conversation_id
+    FOREIGN KEY (conversation_id) REFERENCES conversations(id) ON DELETE CASCADE;
+
+ALTER TABLE conversation_archives ADD CONSTRAINT fk_conversation_archives_user_id
+    FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE;
+
+-- Open conversations are counted per user
+CREATE INDEX idx_conversation_archives_user_id ON conversation_archives(user_id);
+
+-- Add comments for documentation
+COMMENT ON TABLE conversation_archives IS 'Conversations archived by a participant; archived conversations do not count towards the free open conversation limit';
@@ -0,0 +1,15 @@
+-- +goose Down
+-- SQL in this section is executed when the migration is rolled back.
+
+-- Drop indexes
+DROP INDEX IF EXISTS idx_linked_account_flags_updated_at;
+DROP INDEX IF EXISTS idx_linked_account_flags_signal;
+DROP INDEX IF EXISTS idx_account_signals_signal;
+DROP INDEX IF EXISTS idx_account_signals_user_signal;
+
+-- Drop foreign key constraints
+ALTER TABLE account_signals DROP CONSTRAINT IF EXISTS fk_account_signals_user_id;
+
+-- Drop tables
+DROP TABLE IF EXISTS linked_account_flags;
+DROP TABLE IF EXISTS account_signals;
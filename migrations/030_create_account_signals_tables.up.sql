@@ -0,0 +1,36 @@
+-- +goose Up
+-- SQL in this section is executed when the migration is applied.
+
+CREATE TABLE account_signals (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    user_id UUID NOT NULL,
+    signal_type VARCHAR(20) NOT NULL CHECK (signal_type IN ('device', 'ip')),
+    value VARCHAR(64) NOT NULL,
+    first_seen_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    last_seen_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW()
+);
+
+CREATE TABLE linked_account_flags (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    signal_type VARCHAR(20) NOT NULL CHECK (signal_type IN ('device', 'ip')),
+    value VARCHAR(64) NOT NULL,
+    reason VARCHAR(50) NOT NULL CHECK (reason IN ('too_many_accounts', 'shares_banned_account')),
+    account_count INTEGER NOT NULL,
+    flagged_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT NOW()
+);
+
+-- Create foreign key constraints
+ALTER TABLE account_signals ADD CONSTRAINT fk_account_signals_user_id
+    FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE;
+
+-- Create indexes
+CREATE UNIQUE INDEX idx_account_signals_user_signal ON account_signals(user_id, signal_type, value);
+CREATE INDEX idx_account_signals_signal ON account_signals(signal_type, value, last_seen_at);
+CREATE UNIQUE INDEX idx_linked_account_flags_signal ON linked_account_flags(signal_type, value);
+CREATE INDEX idx_linked_account_flags_updated_at ON linked_account_flags(updated_at);
+
+-- Add comments for documentation
+COMMENT ON TABLE account_signals IS 'Devices and IP addresses accounts were used from, for detecting one person running many accounts';
+COMMENT ON COLUMN account_signals.value IS 'SHA-256 hash of the device fingerprint or IP address';
+COMMENT ON TABLE linked_account_flags IS 'Devices and IP addresses shared by suspected linked accounts, for moderator review';
@@ -0,0 +1,5 @@
+-- +goose Down
+-- SQL in this section is executed when the migration is rolled back.
+
+DROP INDEX IF EXISTS idx_users_profile_under_review;
+ALTER TABLE users DROP COLUMN IF EXISTS profile_under_review;
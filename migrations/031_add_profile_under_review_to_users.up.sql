@@ -0,0 +1,6 @@
+-- +goose Up
+-- SQL in this section is executed when the migration is applied.
+
+-- Profiles whose bio was flagged by content moderation are hidden from discovery until reviewed
+ALTER TABLE users ADD COLUMN profile_under_review BOOLEAN DEFAULT FALSE;
+CREATE INDEX idx_users_profile_under_review ON users(profile_under_review) WHERE profile_under_review = TRUE;
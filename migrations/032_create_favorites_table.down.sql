@@ -0,0 +1,13 @@
+-- +goose Down
+-- SQL in this section is executed when the migration is rolled back.
+
+-- Drop indexes
+DROP INDEX IF EXISTS idx_favorites_user_id_created_at;
+DROP INDEX IF EXISTS idx_favorites_user_favorite_user;
+
+-- Drop foreign key constraints
+ALTER TABLE favorites DROP CONSTRAINT IF EXISTS fk_favorites_favorite_user_id;
+ALTER TABLE favorites DROP CONSTRAINT IF EXISTS fk_favorites_user_id;
+
+-- Drop table
+DROP TABLE IF EXISTS favorites;
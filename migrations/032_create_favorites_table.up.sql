@@ -0,0 +1,26 @@
+-- +goose Up
+-- SQL in this section is executed when the migration is applied.
+
+CREATE TABLE favorites (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    user_id UUID NOT NULL,
+    favorite_user_id UUID NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+    CONSTRAINT chk_favorites_not_self CHECK (user_id <> favorite_user_id)
+);
+
+-- Create foreign key constraints
+ALTER TABLE favorites ADD CONSTRAINT fk_favorites_user_id
+    FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE;
+
+ALTER TABLE favorites ADD CONSTRAINT fk_favorites_favorite_user_id
+    FOREIGN KEY (favorite_user_id) REFERENCES users(id) ON DELETE CASCADE;
+
+-- Each user is favorited at most once per user
+CREATE UNIQUE INDEX idx_favorites_user_favorite_user ON favorites(user_id, favorite_user_id);
+
+-- Favorites are listed most recent first
+CREATE INDEX idx_favorites_user_id_created_at ON favorites(user_id, created_at DESC);
+
+-- Add comments for documentation
+COMMENT ON TABLE favorites IS 'Profiles saved by a user to revisit; separate from swipes and ignored by matching and discovery';
@@ -0,0 +1,12 @@
+-- +goose Down
+-- SQL in this section is executed when the migration is rolled back.
+
+-- Drop indexes
+DROP INDEX IF EXISTS idx_safety_check_ins_scheduled_due_at;
+DROP INDEX IF EXISTS idx_safety_check_ins_user_id_status;
+
+-- Drop foreign key constraints
+ALTER TABLE safety_check_ins DROP CONSTRAINT IF EXISTS fk_safety_check_ins_user_id;
+
+-- Drop table
+DROP TABLE IF EXISTS safety_check_ins;
@@ -0,0 +1,33 @@
+-- +goose Up
+-- SQL in this section is executed when the migration is applied.
+
+CREATE TABLE safety_check_ins (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    user_id UUID NOT NULL,
+    due_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    status VARCHAR(20) NOT NULL DEFAULT 'scheduled',
+    note VARCHAR(500),
+    contact_name VARCHAR(100),
+    contact_email VARCHAR(255),
+    contact_consent_at TIMESTAMP WITH TIME ZONE,
+    confirmed_at TIMESTAMP WITH TIME ZONE,
+    missed_at TIMESTAMP WITH TIME ZONE,
+    contact_alerted_at TIMESTAMP WITH TIME ZONE,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+    CONSTRAINT chk_safety_check_ins_status CHECK (status IN ('scheduled', 'confirmed', 'missed'))
+);
+
+-- Create foreign key constraints
+ALTER TABLE safety_check_ins ADD CONSTRAINT fk_safety_check_ins_user_id
+    FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE;
+
+-- Scheduled check-ins are counted per user
+CREATE INDEX idx_safety_check_ins_user_id_status ON safety_check_ins(user_id, status);
+
+-- The alert job looks up scheduled check-ins past their due time
+CREATE INDEX idx_safety_check_ins_scheduled_due_at ON safety_check_ins(due_at) WHERE status = 'scheduled';
+
+-- Add comments for documentation
+COMMENT ON TABLE safety_check_ins IS 'Date check-ins; a missed check-in alerts the user and, with their consent, a trusted contact';
+COMMENT ON COLUMN safety_check_ins.contact_consent_at IS 'When the user agreed to have the trusted contact alerted; NULL means the contact is never alerted';
@@ -0,0 +1,5 @@
+-- +goose Down
+-- SQL in this section is executed when the migration is rolled back.
+
+DROP INDEX IF EXISTS idx_messages_sender_client_message_id;
+ALTER TABLE messages DROP COLUMN IF EXISTS client_message_id;
@@ -0,0 +1,7 @@
+-- +goose Up
+-- SQL in this section is executed when the migration is applied.
+
+ALTER TABLE messages ADD COLUMN client_message_id VARCHAR(255);
+
+-- A sender's retries carry the same client message ID, so it must map to a single message
+CREATE UNIQUE INDEX idx_messages_sender_client_message_id ON messages(sender_id, client_message_id) WHERE client_message_id IS NOT NULL;
@@ -0,0 +1,9 @@
+-- +goose Down
+-- SQL in this section is executed when the migration is rolled back.
+
+ALTER TABLE users DROP CONSTRAINT IF EXISTS chk_users_drinking;
+ALTER TABLE users DROP CONSTRAINT IF EXISTS chk_users_smoking;
+ALTER TABLE users DROP CONSTRAINT IF EXISTS chk_users_height_cm;
+ALTER TABLE users DROP COLUMN IF EXISTS drinking;
+ALTER TABLE users DROP COLUMN IF EXISTS smoking;
+ALTER TABLE users DROP COLUMN IF EXISTS height_cm;
@@ -0,0 +1,11 @@
+-- +goose Up
+-- SQL in this section is executed when the migration is applied.
+
+-- Optional profile attributes discovery can be filtered by
+ALTER TABLE users ADD COLUMN height_cm SMALLINT;
+ALTER TABLE users ADD COLUMN smoking VARCHAR(20);
+ALTER TABLE users ADD COLUMN drinking VARCHAR(20);
+
+ALTER TABLE users ADD CONSTRAINT chk_users_height_cm CHECK (height_cm BETWEEN 120 AND 230);
+ALTER TABLE users ADD CONSTRAINT chk_users_smoking CHECK (smoking IN ('never', 'socially', 'regularly'));
+ALTER TABLE users ADD CONSTRAINT chk_users_drinking CHECK (drinking IN ('never', 'socially', 'regularly'));
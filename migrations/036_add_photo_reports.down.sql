@@ -0,0 +1,8 @@
+-- +goose Down
+-- SQL in this section is executed when the migration is rolled back.
+
+DROP INDEX IF EXISTS idx_photos_review_requested_at;
+ALTER TABLE photos DROP COLUMN IF EXISTS review_requested_at;
+ALTER TABLE photos DROP COLUMN IF EXISTS is_hidden;
+DROP INDEX IF EXISTS idx_reports_photo_id;
+ALTER TABLE reports DROP COLUMN IF EXISTS photo_id;
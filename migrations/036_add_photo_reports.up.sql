@@ -0,0 +1,11 @@
+-- +goose Up
+-- SQL in this section is executed when the migration is applied.
+
+-- Reports can point at a specific profile photo
+ALTER TABLE reports ADD COLUMN photo_id UUID REFERENCES photos(id) ON DELETE SET NULL;
+CREATE INDEX idx_reports_photo_id ON reports(photo_id) WHERE photo_id IS NOT NULL;
+
+-- Reported photos are queued for re-moderation and hidden once reports pile up
+ALTER TABLE photos ADD COLUMN is_hidden BOOLEAN NOT NULL DEFAULT FALSE;
+ALTER TABLE photos ADD COLUMN review_requested_at TIMESTAMP WITH TIME ZONE;
+CREATE INDEX idx_photos_review_requested_at ON photos(review_requested_at) WHERE review_requested_at IS NOT NULL;
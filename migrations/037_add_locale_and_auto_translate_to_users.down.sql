@@ -0,0 +1,5 @@
+-- +goose Down
+-- SQL in this section is executed when the migration is rolled back.
+
+ALTER TABLE users DROP COLUMN IF EXISTS auto_translate_messages;
+ALTER TABLE users DROP COLUMN IF EXISTS locale;
@@ -0,0 +1,6 @@
+-- +goose Up
+-- SQL in this section is executed when the migration is applied.
+
+-- Language received messages are translated into, for users who opt in
+ALTER TABLE users ADD COLUMN locale VARCHAR(35);
+ALTER TABLE users ADD COLUMN auto_translate_messages BOOLEAN NOT NULL DEFAULT FALSE;
@@ -0,0 +1,4 @@
+-- +goose Down
+-- SQL in this section is executed when the migration is rolled back.
+
+ALTER TABLE user_preferences DROP COLUMN IF EXISTS hidden_fields;
@@ -0,0 +1,5 @@
+-- +goose Up
+-- SQL in this section is executed when the migration is applied.
+
+-- Profile fields the user has hidden from other users
+ALTER TABLE user_preferences ADD COLUMN hidden_fields TEXT[] NOT NULL DEFAULT '{}';
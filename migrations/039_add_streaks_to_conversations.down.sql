@@ -0,0 +1,8 @@
+-- +goose Down
+-- SQL in this section is executed when the migration is rolled back.
+
+ALTER TABLE conversations DROP COLUMN IF EXISTS anniversary_months;
+ALTER TABLE conversations DROP COLUMN IF EXISTS streak_pending_sender_id;
+ALTER TABLE conversations DROP COLUMN IF EXISTS streak_pending_day;
+ALTER TABLE conversations DROP COLUMN IF EXISTS streak_last_day;
+ALTER TABLE conversations DROP COLUMN IF EXISTS streak_count;
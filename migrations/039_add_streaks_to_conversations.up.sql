@@ -0,0 +1,11 @@
+-- +goose Up
+-- SQL in this section is executed when the migration is applied.
+
+-- Consecutive days both participants messaged, and the day only one of them has messaged on so far
+ALTER TABLE conversations ADD COLUMN streak_count INTEGER NOT NULL DEFAULT 0;
+ALTER TABLE conversations ADD COLUMN streak_last_day DATE;
+ALTER TABLE conversations ADD COLUMN streak_pending_day DATE;
+ALTER TABLE conversations ADD COLUMN streak_pending_sender_id UUID;
+
+-- Months since the match as of the last anniversary check
+ALTER TABLE conversations ADD COLUMN anniversary_months INTEGER NOT NULL DEFAULT 0;
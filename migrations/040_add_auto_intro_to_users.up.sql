@@ -0,0 +1,5 @@
+-- +goose Up
+-- SQL in this section is executed when the migration is applied.
+
+-- Opener sent as the user's first message when they match, if they set one up
+ALTER TABLE users ADD COLUMN auto_intro TEXT;
@@ -0,0 +1,5 @@
+-- +goose Up
+-- SQL in this section is executed when the migration is applied.
+
+-- Interests listed on the profile, lowercased, which discovery ranks candidates by
+ALTER TABLE users ADD COLUMN interests TEXT[] NOT NULL DEFAULT '{}';
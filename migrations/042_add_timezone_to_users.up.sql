@@ -0,0 +1,5 @@
+-- +goose Up
+-- SQL in this section is executed when the migration is applied.
+
+-- IANA timezone of the user, daily swipe limits reset at its local midnight
+ALTER TABLE users ADD COLUMN timezone VARCHAR(64);
@@ -0,0 +1,7 @@
+-- +goose Up
+-- SQL in this section is executed when the migration is applied.
+
+-- Shadowbanned users keep using the app, but are hidden from discovery and their likes and messages
+-- never reach anyone
+ALTER TABLE users ADD COLUMN shadowbanned BOOLEAN DEFAULT FALSE;
+CREATE INDEX idx_users_shadowbanned ON users(shadowbanned) WHERE shadowbanned = TRUE;
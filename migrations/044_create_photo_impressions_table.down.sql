@@ -0,0 +1,14 @@
+-- +goose Down
+-- SQL in this section is executed when the migration is rolled back.
+
+DROP INDEX IF EXISTS idx_user_preferences_auto_reorder_photos;
+ALTER TABLE user_preferences DROP COLUMN IF EXISTS auto_reorder_photos;
+
+ALTER TABLE photos DROP COLUMN IF EXISTS primary_locked;
+ALTER TABLE photos DROP COLUMN IF EXISTS position;
+
+-- Drop indexes
+DROP INDEX IF EXISTS idx_photo_impressions_user_id_viewer_id;
+
+-- Drop table
+DROP TABLE IF EXISTS photo_impressions;
\ No newline at end of file
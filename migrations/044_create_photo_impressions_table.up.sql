@@ -0,0 +1,35 @@
+-- +goose Up
+-- SQL in this section is executed when the migration is applied.
+
+CREATE TABLE photo_impressions (
+    photo_id UUID NOT NULL,
+    user_id UUID NOT NULL,
+    viewer_id UUID NOT NULL,
+    liked BOOLEAN NOT NULL DEFAULT FALSE,
+    viewed_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    liked_at TIMESTAMP WITH TIME ZONE,
+    PRIMARY KEY (photo_id, viewer_id)
+);
+
+-- Create foreign key constraints
+ALTER TABLE photo_impressions ADD CONSTRAINT fk_photo_impressions_photo_id
+    FOREIGN KEY (photo_id) REFERENCES photos(id) ON DELETE CASCADE;
+ALTER TABLE photo_impressions ADD CONSTRAINT fk_photo_impressions_user_id
+    FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE;
+ALTER TABLE photo_impressions ADD CONSTRAINT fk_photo_impressions_viewer_id
+    FOREIGN KEY (viewer_id) REFERENCES users(id) ON DELETE CASCADE;
+
+-- A like marks every photo of the owner the liker was shown
+CREATE INDEX idx_photo_impressions_user_id_viewer_id ON photo_impressions(user_id, viewer_id);
+
+-- Photos are shown in the order the owner or the reorder job gave them, after the primary photo
+ALTER TABLE photos ADD COLUMN position INTEGER NOT NULL DEFAULT 0;
+ALTER TABLE photos ADD COLUMN primary_locked BOOLEAN NOT NULL DEFAULT FALSE;
+
+ALTER TABLE user_preferences ADD COLUMN auto_reorder_photos BOOLEAN NOT NULL DEFAULT FALSE;
+CREATE INDEX idx_user_preferences_auto_reorder_photos ON user_preferences(user_id) WHERE auto_reorder_photos = TRUE;
+
+-- Add comments for documentation
+COMMENT ON TABLE photo_impressions IS 'Which users were shown a photo and whether they went on to like its owner; one row per photo and viewer';
+COMMENT ON COLUMN photos.primary_locked IS 'Set when the owner locks their primary photo; the reorder job never replaces a locked primary';
+COMMENT ON COLUMN user_preferences.auto_reorder_photos IS 'Opt-in to having photos reordered by how often viewers like them';
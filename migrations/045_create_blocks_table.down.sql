@@ -0,0 +1,14 @@
+-- +goose Down
+-- SQL in this section is executed when the migration is rolled back.
+
+-- Drop indexes
+DROP INDEX IF EXISTS idx_users_email_sha256;
+DROP INDEX IF EXISTS idx_blocks_blocked_id;
+DROP INDEX IF EXISTS idx_blocks_blocker_blocked;
+
+-- Drop foreign key constraints
+ALTER TABLE blocks DROP CONSTRAINT IF EXISTS fk_blocks_blocked_id;
+ALTER TABLE blocks DROP CONSTRAINT IF EXISTS fk_blocks_blocker_id;
+
+-- Drop table
+DROP TABLE IF EXISTS blocks;
\ No newline at end of file
@@ -0,0 +1,31 @@
+-- +goose Up
+-- SQL in this section is executed when the migration is applied.
+
+CREATE TABLE IF NOT EXISTS blocks (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    blocker_id UUID NOT NULL,
+    blocked_id UUID NOT NULL,
+    reason TEXT,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+    CONSTRAINT chk_blocks_not_self CHECK (blocker_id <> blocked_id)
+);
+
+-- Create foreign key constraints
+ALTER TABLE blocks ADD CONSTRAINT fk_blocks_blocker_id
+    FOREIGN KEY (blocker_id) REFERENCES users(id) ON DELETE CASCADE;
+
+ALTER TABLE blocks ADD CONSTRAINT fk_blocks_blocked_id
+    FOREIGN KEY (blocked_id) REFERENCES users(id) ON DELETE CASCADE;
+
+-- Each user is blocked at most once per user
+CREATE UNIQUE INDEX IF NOT EXISTS idx_blocks_blocker_blocked ON blocks(blocker_id, blocked_id);
+
+-- Blocks are excluded from discovery in both directions
+CREATE INDEX IF NOT EXISTS idx_blocks_blocked_id ON blocks(blocked_id);
+
+-- Imported contacts are matched by the SHA-256 of the normalized email
+CREATE INDEX idx_users_email_sha256 ON users (encode(sha256(lower(btrim(email))::bytea), 'hex'));
+
+-- Add comments for documentation
+COMMENT ON TABLE blocks IS 'Users hidden from each other in discovery, added by the blocker directly or by importing contacts';
+COMMENT ON INDEX idx_users_email_sha256 IS 'Lets contact imports match hashed emails without the client sending plain addresses';
@@ -0,0 +1,14 @@
+-- +goose Down
+-- SQL in this section is executed when the migration is rolled back.
+
+-- Drop indexes
+DROP INDEX IF EXISTS idx_scheduled_messages_scheduled_send_at;
+DROP INDEX IF EXISTS idx_scheduled_messages_conversation_sender_status;
+
+-- Drop foreign key constraints
+ALTER TABLE scheduled_messages DROP CONSTRAINT IF EXISTS fk_scheduled_messages_message_id;
+ALTER TABLE scheduled_messages DROP CONSTRAINT IF EXISTS fk_scheduled_messages_sender_id;
+ALTER TABLE scheduled_messages DROP CONSTRAINT IF EXISTS fk_scheduled_messages_conversation_id;
+
+-- Drop table
+DROP TABLE IF EXISTS scheduled_messages;
\ No newline at end of file
@@ -0,0 +1,39 @@
+-- +goose Up
+-- SQL in this section is executed when the migration is applied.
+
+CREATE TABLE scheduled_messages (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    conversation_id UUID NOT NULL,
+    sender_id UUID NOT NULL,
+    content TEXT NOT NULL,
+    send_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    status VARCHAR(20) NOT NULL DEFAULT 'scheduled',
+    message_id UUID,
+    skip_reason VARCHAR(50),
+    sent_at TIMESTAMP WITH TIME ZONE,
+    canceled_at TIMESTAMP WITH TIME ZONE,
+    skipped_at TIMESTAMP WITH TIME ZONE,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+    CONSTRAINT chk_scheduled_messages_status CHECK (status IN ('scheduled', 'sending', 'sent', 'canceled', 'skipped'))
+);
+
+-- Create foreign key constraints
+ALTER TABLE scheduled_messages ADD CONSTRAINT fk_scheduled_messages_conversation_id
+    FOREIGN KEY (conversation_id) REFERENCES conversations(id) ON DELETE CASCADE;
+
+ALTER TABLE scheduled_messages ADD CONSTRAINT fk_scheduled_messages_sender_id
+    FOREIGN KEY (sender_id) REFERENCES users(id) ON DELETE CASCADE;
+
+ALTER TABLE scheduled_messages ADD CONSTRAINT fk_scheduled_messages_message_id
+    FOREIGN KEY (message_id) REFERENCES messages(id) ON DELETE SET NULL;
+
+-- Scheduled messages are counted per sender and conversation
+CREATE INDEX idx_scheduled_messages_conversation_sender_status ON scheduled_messages(conversation_id, sender_id, status);
+
+-- The delivery job looks up scheduled messages past their send time
+CREATE INDEX idx_scheduled_messages_scheduled_send_at ON scheduled_messages(send_at) WHERE status = 'scheduled';
+
+-- Add comments for documentation
+COMMENT ON TABLE scheduled_messages IS 'Messages to send later; each is checked like any other message when sent and skipped if it no longer passes';
+COMMENT ON COLUMN scheduled_messages.skip_reason IS 'Why a message was not sent, e.g. conversation_closed or rejected';
@@ -0,0 +1,6 @@
+-- +goose Down
+-- SQL in this section is executed when the migration is rolled back.
+
+DROP INDEX IF EXISTS idx_subscriptions_grace_period_ends_at;
+
+ALTER TABLE subscriptions DROP COLUMN IF EXISTS grace_period_ends_at;
@@ -0,0 +1,9 @@
+-- +goose Up
+-- SQL in this section is executed when the migration is applied.
+
+-- Subscribers keep their plan's features until the grace period ends after a failed renewal payment
+ALTER TABLE subscriptions ADD COLUMN grace_period_ends_at TIMESTAMP WITH TIME ZONE;
+
+-- Index for the job that downgrades expired grace periods
+CREATE INDEX idx_subscriptions_grace_period_ends_at ON subscriptions(grace_period_ends_at)
+    WHERE status = 'past_due' AND grace_period_ends_at IS NOT NULL;
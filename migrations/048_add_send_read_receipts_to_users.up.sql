@@ -0,0 +1,5 @@
+-- +goose Up
+-- SQL in this section is executed when the migration is applied.
+
+-- Users who turn read receipts off still clear their unread counts, but senders never see their messages as read
+ALTER TABLE users ADD COLUMN send_read_receipts BOOLEAN NOT NULL DEFAULT TRUE;
@@ -0,0 +1,6 @@
+-- +goose Up
+-- SQL in this section is executed when the migration is applied.
+
+-- Deleted messages stay in place as tombstones so conversation order and pagination are unchanged
+ALTER TABLE messages ADD COLUMN edited_at TIMESTAMP WITH TIME ZONE;
+ALTER TABLE messages ADD COLUMN deleted_at TIMESTAMP WITH TIME ZONE;
@@ -0,0 +1,5 @@
+-- +goose Down
+-- SQL in this section is executed when the migration is rolled back.
+
+DROP TABLE IF EXISTS reputation_changes;
+DROP TABLE IF EXISTS user_reputations;
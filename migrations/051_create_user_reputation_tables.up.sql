@@ -0,0 +1,40 @@
+-- +goose Up
+-- SQL in this section is executed when the migration is applied.
+
+-- Users without a row have the configured initial reputation; a row is added on the first change
+CREATE TABLE IF NOT EXISTS user_reputations (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    user_id UUID NOT NULL UNIQUE REFERENCES users(id) ON DELETE CASCADE,
+    score INTEGER NOT NULL DEFAULT 100,
+    reports_received INTEGER NOT NULL DEFAULT 0,
+    reports_resolved INTEGER NOT NULL DEFAULT 0,
+    content_removed INTEGER NOT NULL DEFAULT 0,
+    warnings_received INTEGER NOT NULL DEFAULT 0,
+    bans_received INTEGER NOT NULL DEFAULT 0,
+    appeals_submitted INTEGER NOT NULL DEFAULT 0,
+    appeals_approved INTEGER NOT NULL DEFAULT 0,
+    last_updated TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    last_score_change TIMESTAMP WITH TIME ZONE,
+    last_action_date TIMESTAMP WITH TIME ZONE
+);
+
+CREATE INDEX IF NOT EXISTS idx_user_reputations_score ON user_reputations(score);
+
+CREATE TABLE reputation_changes (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    delta INTEGER NOT NULL,
+    previous_score INTEGER NOT NULL,
+    new_score INTEGER NOT NULL,
+    reason VARCHAR(50) NOT NULL,
+    reference_id UUID,
+    actor_id UUID,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW()
+);
+
+-- A user's audit trail is read newest first
+CREATE INDEX idx_reputation_changes_user_created ON reputation_changes(user_id, created_at);
+
+-- Add comments for documentation
+COMMENT ON TABLE reputation_changes IS 'Audit trail of user reputation changes with the report, content or moderator behind each';
+COMMENT ON COLUMN user_reputations.last_score_change IS 'Reputation recovers one step per recovery interval after this';
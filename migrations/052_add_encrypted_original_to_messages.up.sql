@@ -0,0 +1,7 @@
+-- +goose Up
+-- SQL in this section is executed when the migration is applied.
+
+-- Set only when PII was redacted from content and message encryption is enabled
+ALTER TABLE messages ADD COLUMN encrypted_original TEXT;
+
+COMMENT ON COLUMN messages.encrypted_original IS 'Original content encrypted with the message key when PII was redacted from content';
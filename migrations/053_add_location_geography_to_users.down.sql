@@ -0,0 +1,5 @@
+-- +goose Down
+-- SQL in this section is executed when the migration is rolled back.
+
+DROP INDEX IF EXISTS idx_users_location_geog;
+ALTER TABLE users DROP COLUMN IF EXISTS location_geog;
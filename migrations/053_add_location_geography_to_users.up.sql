@@ -0,0 +1,17 @@
+-- +goose Up
+-- SQL in this section is executed when the migration is applied.
+
+CREATE EXTENSION IF NOT EXISTS postgis;
+
+-- Derived from location_lat and location_lng so it can't drift from them
+ALTER TABLE users ADD COLUMN location_geog geography(Point, 4326) GENERATED ALWAYS AS (
+    CASE
+        WHEN location_lat IS NOT NULL AND location_lng IS NOT NULL
+        THEN ST_SetSRID(ST_MakePoint(location_lng::double precision, location_lat::double precision), 4326)::geography
+    END
+) STORED;
+
+-- Serves the ST_DWithin radius filter and the nearest-first ordering of discovery
+CREATE INDEX idx_users_location_geog ON users USING GIST (location_geog);
+
+COMMENT ON COLUMN users.location_geog IS 'User location as a PostGIS geography point, generated from location_lat and location_lng';
@@ -25,6 +25,13 @@ type Config struct {
 	EphemeralPhoto EphemeralPhotoConfig `mapstructure:"ephemeral_photo"`
 	Moderation  ModerationConfig  `mapstructure:"moderation"`
 	Monitoring   MonitoringConfig   `mapstructure:"monitoring"`
+	Geofencing   GeofencingConfig   `mapstructure:"geofencing"`
+	Legal        LegalConfig        `mapstructure:"legal"`
+	Matching     MatchingConfig     `mapstructure:"matching"`
+	DeepLink     DeepLinkConfig     `mapstructure:"deep_link"`
+	Webhooks     WebhooksConfig     `mapstructure:"webhooks"`
+	Entitlements EntitlementsConfig `mapstructure:"entitlements"`
+	Safety       SafetyConfig       `mapstructure:"safety"`
 }
 
 // AppConfig represents application configuration
@@ -32,6 +39,8 @@ type AppConfig struct {
 	Env  string `mapstructure:"env"`
 	Port int    `mapstructure:"port"`
 	Host string `mapstructure:"host"`
+	// ReadOnlyMode starts the API rejecting writes, until an admin turns it off
+	ReadOnlyMode bool `mapstructure:"read_only_mode"`
 }
 
 // DatabaseConfig represents database configuration
@@ -86,15 +95,21 @@ type JWTConfig struct {
 type SecurityConfig struct {
 	AccountLockoutEnabled    bool          `mapstructure:"account_lockout_enabled"`
 	MaxFailedAttempts        int           `mapstructure:"max_failed_attempts"`
-	AccountLockoutDuration  time.Duration `mapstructure:"account_lockout_duration"`
+	AccountLockoutDuration  time.Duration `mapstructure:"account_lockout_duration"`          // First lockout, doubled on each repeat
+	AccountLockoutMaxDuration time.Duration `mapstructure:"account_lockout_max_duration"`  // Longest a repeat lockout lasts
+	AccountLockoutResetAfter  time.Duration `mapstructure:"account_lockout_reset_after"`   // Time after a lockout ends before the next one starts from the first again
 	PasswordMinLength        int           `mapstructure:"password_min_length"`
 	PasswordRequireUppercase bool         `mapstructure:"password_require_uppercase"`
 	PasswordRequireLowercase bool         `mapstructure:"password_require_lowercase"`
 	PasswordRequireNumbers   bool         `mapstructure:"password_require_numbers"`
 	PasswordRequireSymbols   bool         `mapstructure:"password_require_symbols"`
+	CommonPasswordsFile      string        `mapstructure:"common_passwords_file"` // Newline separated passwords rejected in addition to the built-in list, read at startup
 	SessionTimeout          time.Duration `mapstructure:"session_timeout"`
-	DeviceFingerprinting    bool         `mapstructure:"device_fingerprinting"`
+	DeviceFingerprinting    bool         `mapstructure:"device_fingerprinting"` // Bind refresh tokens to the device they were issued to
+	DeviceFingerprintTolerance int       `mapstructure:"device_fingerprint_tolerance"` // Device attributes that may change before a refresh is rejected
 	CSRFProtection         bool         `mapstructure:"csrf_protection"`
+	CSRFSessionCookie      string        `mapstructure:"csrf_session_cookie"`  // Cookie carrying the session of cookie-based clients
+	CSRFTokenRotation      time.Duration `mapstructure:"csrf_token_rotation"`  // How long a CSRF token is valid before a new one must be fetched
 }
 
 // AWSConfig represents AWS configuration
@@ -118,6 +133,8 @@ type StorageConfig struct {
 	DownloadExpiry time.Duration `mapstructure:"download_expiry"` // Signed URL expiry for downloads
 	MaxFileSize    int64         `mapstructure:"max_file_size"`  // Max file size in bytes
 	AllowedTypes   []string      `mapstructure:"allowed_types"`  // Allowed file types
+	MaxPhotosPerUser     int     `mapstructure:"max_photos_per_user"`      // Profile photos on the free plan, 0 for no limit
+	MaxPhotosPerUserPaid int     `mapstructure:"max_photos_per_user_paid"` // Profile photos on a premium or platinum plan, 0 for no limit
 }
 
 // StripeConfig represents Stripe configuration
@@ -136,9 +153,13 @@ type StripeConfig struct {
 	DefaultCurrency string `mapstructure:"default_currency"`
 	SuccessURL      string `mapstructure:"success_url"`
 	CancelURL       string `mapstructure:"cancel_url"`
+	SupportedCurrencies []string `mapstructure:"supported_currencies"` // Currencies checkout accepts as a hint
+	LocalizedPriceIDs map[string]map[string]string `mapstructure:"localized_price_ids"` // Keyed by default price ID, then currency, e.g. {"price_premium_monthly": {"eur": "price_premium_monthly_eur"}}
 	
 	// Webhook Settings
 	WebhookEndpoint string `mapstructure:"webhook_endpoint"`
+	WebhookToleranceSeconds int `mapstructure:"webhook_tolerance_seconds"` // Events signed longer ago than this are rejected as replays
+	WebhookMaxAge time.Duration `mapstructure:"webhook_max_age"` // Events created longer ago than this are rejected, however recently they were signed
 	
 	// Security Settings
 	EnableRadar      bool `mapstructure:"enable_radar"`
@@ -149,6 +170,23 @@ type StripeConfig struct {
 	
 	// Cache Settings
 	CacheTTL time.Duration `mapstructure:"cache_ttl"`
+	
+	// Failed Payments
+	GracePeriod              time.Duration `mapstructure:"grace_period"`                // How long a subscriber keeps premium after a renewal payment fails
+	GracePeriodCheckInterval time.Duration `mapstructure:"grace_period_check_interval"` // How often expired grace periods are downgraded
+	
+	// Refunds
+	CancellationRefundWindow time.Duration `mapstructure:"cancellation_refund_window"` // How long after the last charge a cancellation can be refunded; 0 disables refunds
+	
+	// Account Deletion
+	AccountDeletion StripeAccountDeletionConfig `mapstructure:"account_deletion"`
+}
+
+// StripeAccountDeletionConfig controls how billing is stopped when a user deletes their account
+type StripeAccountDeletionConfig struct {
+	CancelAtPeriodEnd                 bool `mapstructure:"cancel_at_period_end"`                   // Default: false (cancel immediately)
+	CancelAtPeriodEndWithOpenInvoices bool `mapstructure:"cancel_at_period_end_with_open_invoices"` // Default: true
+	DeleteCustomer                    bool `mapstructure:"delete_customer"`                         // Default: true
 }
 
 // EmailConfig represents email configuration
@@ -162,13 +200,34 @@ type EmailConfig struct {
 type RateLimitConfig struct {
 	RequestsPerMinute int `mapstructure:"requests_per_minute"`
 	RequestsPerHour   int `mapstructure:"requests_per_hour"`
+
+	// Storage of rate limit counters: "redis", or "memory" for local development and
+	// single-instance deployments, as in-memory counters are not shared between instances
+	Backend         string        `mapstructure:"backend"`
+	CleanupInterval time.Duration `mapstructure:"cleanup_interval"` // How often the memory backend drops expired counters
 	
 	// Discovery rate limits
 	SwipesPerHour    int           `mapstructure:"swipes_per_hour"`
 	SwipesPerDay     int           `mapstructure:"swipes_per_day"`
 	SuperLikesPerDay  int           `mapstructure:"super_likes_per_day"`
+	SwipeResetStrategy string       `mapstructure:"swipe_reset_strategy"` // "rolling", or "local_midnight" to start daily swipes over at the user's midnight
+	UndosPerDay      int           `mapstructure:"undos_per_day"` // Swipes a premium user can take back per day
 	DiscoveryPerHour int           `mapstructure:"discovery_per_hour"`
 	DiscoveryPerDay  int           `mapstructure:"discovery_per_day"`
+
+	// Adaptive throttling of clients that scrape discovery
+	DiscoveryThrottle DiscoveryThrottleConfig `mapstructure:"discovery_throttle"`
+}
+
+// DiscoveryThrottleConfig represents adaptive throttling of abusive discovery traffic
+type DiscoveryThrottleConfig struct {
+	Enabled       bool          `mapstructure:"enabled"`
+	IPPerHour     int           `mapstructure:"ip_per_hour"`    // Discovery requests per IP per hour, shared by all users behind it
+	DegradeAfter  int           `mapstructure:"degrade_after"`  // Requests per hour after which results lose detail
+	DegradedLimit int           `mapstructure:"degraded_limit"` // Maximum profiles in a degraded response
+	BaseBackoff   time.Duration `mapstructure:"base_backoff"`   // Block after the first strike, doubled on each further strike
+	MaxBackoff    time.Duration `mapstructure:"max_backoff"`    // Longest block a repeat offender can get
+	StrikeWindow  time.Duration `mapstructure:"strike_window"`  // How long strikes count towards escalation
 }
 
 // CacheConfig represents cache configuration
@@ -245,6 +304,7 @@ type VerificationLimitsConfig struct {
 	MaxFileSize            int64         `mapstructure:"max_file_size"`              // Default: 10MB
 	MaxSelfieFileSize      int64         `mapstructure:"max_selfie_file_size"`       // Default: 5MB
 	MaxDocumentFileSize    int64         `mapstructure:"max_document_file_size"`     // Default: 10MB
+	ExpiryCheckInterval    time.Duration `mapstructure:"expiry_check_interval"`      // Default: 1h
 }
 
 // DocumentProcessingConfig represents document processing configuration
@@ -254,6 +314,10 @@ type DocumentProcessingConfig struct {
 	SupportedDocumentTypes []string `mapstructure:"supported_document_types"` // ["id_card", "passport", "driver_license"]
 	ExtractionEnabled     bool   `mapstructure:"extraction_enabled"`
 	ValidationEnabled     bool   `mapstructure:"validation_enabled"`
+
+	// Required document sets for multi-document verification sessions
+	DefaultRequiredDocuments  []string            `mapstructure:"default_required_documents"`   // Empty means every submitted document must pass
+	RequiredDocumentsByRegion map[string][]string `mapstructure:"required_documents_by_region"` // e.g. {"DE": ["id_card", "proof_of_address"]}
 }
 
 // VerificationSecurityConfig represents verification security configuration
@@ -300,6 +364,9 @@ type WebSocketConfig struct {
 	ConnectionTimeout      time.Duration `mapstructure:"connection_timeout"`
 	ReconnectInterval      time.Duration `mapstructure:"reconnect_interval"`
 	HeartbeatInterval      time.Duration `mapstructure:"heartbeat_interval"`
+	SyncMaxMessages        int           `mapstructure:"sync_max_messages"` // Missed messages replayed per conversation on reconnect before falling back to REST
+	PresenceMaxSubscriptions int         `mapstructure:"presence_max_subscriptions"` // Users a connection may follow the presence of
+	PresenceSubscribeInterval time.Duration `mapstructure:"presence_subscribe_interval"` // Minimum time between presence subscriptions on a connection
 }
 
 // MessageConfig represents message configuration
@@ -326,9 +393,59 @@ type MessageConfig struct {
 	// System messages
 	SystemMessagePrefix    string        `mapstructure:"system_message_prefix"`
 	
+	// Pinned messages
+	MaxPinnedMessages      int           `mapstructure:"max_pinned_messages"`
+	
+	// Emoji participants can react to messages with
+	AllowedReactions       []string      `mapstructure:"allowed_reactions"`
+	
+	// How long after sending a text message its sender can edit it, 0 disables editing
+	EditWindow             time.Duration `mapstructure:"edit_window"`
+	
+	// How long after sending a message its sender can delete it, 0 means any time
+	DeleteWindow           time.Duration `mapstructure:"delete_window"`
+	
+	// Open conversations a free user can have at once, 0 means unlimited
+	MaxFreeOpenConversations int         `mapstructure:"max_free_open_conversations"`
+	
+	// Longest client_message_id accepted on send, at most 255; 0 ignores client message IDs
+	ClientMessageIDMaxLength int         `mapstructure:"client_message_id_max_length"`
+	
 	// Encryption
 	EncryptionEnabled      bool          `mapstructure:"encryption_enabled"`
 	EncryptionKey          string        `mapstructure:"encryption_key"`
+	
+	// Automatic translation for users who opt in
+	Translation            MessageTranslationConfig `mapstructure:"translation"`
+	
+	// Conversation streaks and match anniversaries
+	Engagement             ConversationEngagementConfig `mapstructure:"engagement"`
+	
+	// Messages scheduled to be sent later
+	Scheduled              ScheduledMessageConfig `mapstructure:"scheduled"`
+}
+
+// ScheduledMessageConfig represents scheduled message configuration
+type ScheduledMessageConfig struct {
+	Enabled            bool          `mapstructure:"enabled"`
+	MinLeadTime        time.Duration `mapstructure:"min_lead_time"`        // How soon after scheduling a message can be sent
+	MaxLeadTime        time.Duration `mapstructure:"max_lead_time"`        // How far ahead a message can be scheduled
+	MaxPerConversation int           `mapstructure:"max_per_conversation"` // Messages a user can have scheduled in one conversation
+	CheckInterval      time.Duration `mapstructure:"check_interval"`       // How often due messages are looked for
+}
+
+// ConversationEngagementConfig represents conversation streak and match anniversary configuration
+type ConversationEngagementConfig struct {
+	Enabled           bool  `mapstructure:"enabled"`
+	StreakMilestones  []int `mapstructure:"streak_milestones"`  // Streak lengths, in days, that are celebrated
+	AnniversaryMonths []int `mapstructure:"anniversary_months"` // Months since the match that are celebrated
+}
+
+// MessageTranslationConfig represents automatic message translation configuration
+type MessageTranslationConfig struct {
+	Enabled  bool          `mapstructure:"enabled"`
+	Timeout  time.Duration `mapstructure:"timeout"`   // How long delivery waits for the provider before sending the original only
+	CacheTTL time.Duration `mapstructure:"cache_ttl"` // How long a message's translation into a language is reused
 }
 
 // ChatSecurityConfig represents chat security configuration
@@ -351,9 +468,11 @@ type ChatSecurityConfig struct {
 	LinkPreviewEnabled     bool          `mapstructure:"link_preview_enabled"`
 	AllowedLinkDomains     []string      `mapstructure:"allowed_link_domains"`
 	BlockedLinkDomains     []string      `mapstructure:"blocked_link_domains"`
+	LinkPreviewTimeout     time.Duration `mapstructure:"link_preview_timeout"` // How long fetching a page for a preview may take
 	
 	// PII detection
 	PIIDetectionEnabled     bool          `mapstructure:"pii_detection_enabled"`
+	PIIPatterns            []string      `mapstructure:"pii_patterns"` // Matches are masked in stored text messages, which are flagged for review
 	
 	// Reporting
 	ReportThreshold        int           `mapstructure:"report_threshold"`
@@ -387,6 +506,7 @@ type ChatRateLimitConfig struct {
 	MessagesPerMinute      int           `mapstructure:"messages_per_minute"`
 	MessagesPerHour        int           `mapstructure:"messages_per_hour"`
 	MessagesPerDay         int           `mapstructure:"messages_per_day"`
+	MessagesPerConversationPerMinute int `mapstructure:"messages_per_conversation_per_minute"` // Per sender in a single conversation, 0 for no limit
 	
 	// Conversation rate limits
 	ConversationsPerDay    int           `mapstructure:"conversations_per_day"`
@@ -406,7 +526,8 @@ type EphemeralPhotoConfig struct {
 	// Photo settings
 	MaxFileSize        int64         `mapstructure:"max_file_size"`         // Max file size in bytes
 	AllowedTypes      []string      `mapstructure:"allowed_types"`         // Allowed file types
-	MaxPhotosPerUser  int           `mapstructure:"max_photos_per_user"`   // Max photos per user
+	MaxPhotosPerUser  int           `mapstructure:"max_photos_per_user"`   // Max active photos on the free plan, 0 for no limit
+	MaxPhotosPerUserPaid int        `mapstructure:"max_photos_per_user_paid"` // Max active photos on a premium or platinum plan, 0 for no limit
 	
 	// Expiration settings
 	DefaultDuration   time.Duration `mapstructure:"default_duration"`       // Default expiration time
@@ -417,6 +538,7 @@ type EphemeralPhotoConfig struct {
 	AccessKeyLength  int           `mapstructure:"access_key_length"`      // Length of access keys
 	EnableWatermark  bool          `mapstructure:"enable_watermark"`       // Enable watermarking
 	WatermarkText    string        `mapstructure:"watermark_text"`        // Watermark text
+	WatermarkViewerID bool         `mapstructure:"watermark_viewer_id"`   // Add the viewer's user ID to the watermark for leak tracing
 	PreventDownload bool          `mapstructure:"prevent_download"`      // Prevent downloads
 	
 	// Storage settings
@@ -441,6 +563,8 @@ type EphemeralPhotoConfig struct {
 	JobBatchSize    int           `mapstructure:"job_batch_size"`        // Batch size for cleanup jobs
 	EnableJobRetry  bool          `mapstructure:"enable_job_retry"`      // Enable job retry on failure
 	MaxJobRetries   int           `mapstructure:"max_job_retries"`       // Max job retry attempts
+	JobRetryDelay   time.Duration `mapstructure:"job_retry_delay"`       // Delay before the first retry, doubled for each later one
+	JobRunHistory   int           `mapstructure:"job_run_history"`       // Cleanup runs kept for the stats endpoint
 }
 
 // ModerationConfig represents moderation configuration
@@ -465,12 +589,19 @@ type ModerationConfig struct {
 	
 	// Rate limiting configuration
 	RateLimit ModerationRateLimitConfig `mapstructure:"rate_limit"`
+	
+	// Multi-account detection configuration
+	MultiAccount MultiAccountConfig `mapstructure:"multi_account"`
+	
+	// Profile content moderation configuration
+	Profile ProfileModerationConfig `mapstructure:"profile"`
 }
 
 // AIModerationConfig represents AI moderation configuration
 type AIModerationConfig struct {
 	// AWS Rekognition settings
 	Enabled           bool    `mapstructure:"enabled"`
+	Provider          string  `mapstructure:"provider"` // "aws" or "mock"
 	Region            string  `mapstructure:"region"`
 	AccessKeyID       string  `mapstructure:"access_key_id"`
 	SecretAccessKey   string  `mapstructure:"secret_access_key"`
@@ -481,12 +612,14 @@ type AIModerationConfig struct {
 	NSFWThreshold      float64 `mapstructure:"nsfw_threshold"`
 	ViolenceThreshold  float64 `mapstructure:"violence_threshold"`
 	AdultThreshold     float64 `mapstructure:"adult_threshold"`
+	ReviewThreshold    float64 `mapstructure:"review_threshold"` // Photos scoring at least this, but under the thresholds above, go to human review
 	
 	// Processing settings
 	BatchSize    int           `mapstructure:"batch_size"`
 	MaxRetries   int           `mapstructure:"max_retries"`
 	RetryDelay   time.Duration `mapstructure:"retry_delay"`
 	Timeout      time.Duration `mapstructure:"timeout"`
+	PollInterval time.Duration `mapstructure:"poll_interval"` // How often the photo moderation worker checks its queue
 	
 	// Fallback settings
 	EnableFallback     bool    `mapstructure:"enable_fallback"`
@@ -537,10 +670,21 @@ type ModerationRulesConfig struct {
 	InitialReputation   int     `mapstructure:"initial_reputation"`
 	MinReputation       int     `mapstructure:"min_reputation"`
 	MaxReputation       int     `mapstructure:"max_reputation"`
+	ReputationReportPenalty      int           `mapstructure:"reputation_report_penalty"`      // Lost when a report against the user is confirmed
+	ReputationViolationPenalty   int           `mapstructure:"reputation_violation_penalty"`   // Lost when moderation removes the user's content
+	ReputationRecoveryPoints     int           `mapstructure:"reputation_recovery_points"`     // Regained per recovery interval without changes, up to InitialReputation
+	ReputationRecoveryInterval   time.Duration `mapstructure:"reputation_recovery_interval"`
+	ReputationSuspendThreshold   int           `mapstructure:"reputation_suspend_threshold"`   // Users dropping below this are suspended if AutoSuspendEnabled
+	ReputationDiscoveryThreshold int           `mapstructure:"reputation_discovery_threshold"` // Users below this are shown last in discovery
 	
 	// Report thresholds
 	ReportThreshold     int     `mapstructure:"report_threshold"`
 	SeverityThreshold   int     `mapstructure:"severity_threshold"`
+	PhotoReportHideThreshold int `mapstructure:"photo_report_hide_threshold"` // Pending reports after which a photo is hidden until re-moderated
+	
+	// Ban cascade
+	UnmatchOnBan        bool    `mapstructure:"unmatch_on_ban"`         // Deactivate matches and close conversations of banned users
+	NotifyMatchesOnBan  bool    `mapstructure:"notify_matches_on_ban"`  // Tell the other participants their match has ended
 	
 	// Custom rules
 	CustomRules         []CustomRule `mapstructure:"custom_rules"`
@@ -613,6 +757,7 @@ type ModerationRateLimitConfig struct {
 	ReportsPerMinute  int           `mapstructure:"reports_per_minute"`
 	ReportsPerHour    int           `mapstructure:"reports_per_hour"`
 	ReportsPerDay     int           `mapstructure:"reports_per_day"`
+	ReportCooldown    time.Duration `mapstructure:"report_cooldown"` // How long before a reporter may report the same user again
 	
 	// Block rate limits
 	BlocksPerMinute   int           `mapstructure:"blocks_per_minute"`
@@ -629,6 +774,24 @@ type ModerationRateLimitConfig struct {
 	AdminActionsPerHour   int       `mapstructure:"admin_actions_per_hour"`
 }
 
+// MultiAccountConfig configures detection of one person running many accounts, e.g. to evade a ban.
+// Devices and IP addresses are only correlated while verification.security device and IP tracking are enabled.
+type MultiAccountConfig struct {
+	Enabled               bool          `mapstructure:"enabled"`
+	MaxAccountsPerDevice  int           `mapstructure:"max_accounts_per_device"` // Flag a device used by more accounts than this; 0 disables
+	MaxAccountsPerIP      int           `mapstructure:"max_accounts_per_ip"`     // Flag an IP address used by more accounts than this; 0 disables
+	Window                time.Duration `mapstructure:"window"`                  // Only accounts seen within this window are linked
+	RestrictBannedDevices bool          `mapstructure:"restrict_banned_devices"` // Deactivate new accounts that share a device with a banned account
+}
+
+// ProfileModerationConfig configures moderation of profile bios when they are saved.
+// Borderline content is always held for review; Action decides what happens to clear violations.
+type ProfileModerationConfig struct {
+	Enabled      bool   `mapstructure:"enabled"`
+	Action       string `mapstructure:"action"`        // "reject" refuses the save, "flag" saves it and hides the profile from discovery until reviewed
+	AIModeration bool   `mapstructure:"ai_moderation"` // Also run the bio through AI text moderation
+}
+
 // MonitoringConfig represents monitoring configuration
 type MonitoringConfig struct {
 	// Health check configuration
@@ -722,6 +885,9 @@ type AlertingConfig struct {
 	
 	// Notification settings
 	NotificationChannels []NotificationChannel `mapstructure:"notification_channels"`
+	NotificationTimeout      time.Duration `mapstructure:"notification_timeout"`       // Limit for one delivery, retries included
+	NotificationRetries      int           `mapstructure:"notification_retries"`       // Retries after a transient failure
+	NotificationRetryBackoff time.Duration `mapstructure:"notification_retry_backoff"` // Wait before the first retry, doubled after
 	
 	// Alert rules
 	Rules []AlertRule `mapstructure:"rules"`
@@ -731,7 +897,7 @@ type AlertingConfig struct {
 type NotificationChannel struct {
 	Type     string                 `mapstructure:"type"`     // email, slack, webhook, etc.
 	Enabled  bool                   `mapstructure:"enabled"`
-	Config   map[string]interface{} `mapstructure:"config"`
+	Config   map[string]interface{} `mapstructure:"config"`   // slack: webhook_url; webhook: url and optional secret
 }
 
 // AlertRule represents an alert rule
@@ -809,6 +975,198 @@ type MonitoringStorageConfig struct {
 	BackupLocation string        `mapstructure:"backup_location"`
 }
 
+// GeofencingConfig controls which countries and regions the service refuses to serve
+type GeofencingConfig struct {
+	Enabled             bool     `mapstructure:"enabled"`
+	RestrictedCountries []string `mapstructure:"restricted_countries"` // ISO 3166-1 alpha-2 codes, e.g. "KP"
+	RestrictedRegions   []string `mapstructure:"restricted_regions"`   // ISO 3166-2 codes, e.g. "UA-43"
+	CountryHeader       string   `mapstructure:"country_header"`       // Set by the edge proxy from the client IP
+	RegionHeader        string   `mapstructure:"region_header"`        // Set by the edge proxy from the client IP
+	AllowedUserIDs      []string `mapstructure:"allowed_user_ids"`     // Accounts that always bypass geofencing
+}
+
+// MatchingConfig represents matching algorithm configuration
+type MatchingConfig struct {
+	Personalization MatchingPersonalizationConfig `mapstructure:"personalization"`
+	Fairness        MatchingFairnessConfig        `mapstructure:"fairness"`
+	Performance     MatchingPerformanceConfig     `mapstructure:"performance"`
+	Favorites       MatchingFavoritesConfig       `mapstructure:"favorites"`
+	BlockContacts   MatchingBlockContactsConfig   `mapstructure:"block_contacts"`
+	BatchSwipe      MatchingBatchSwipeConfig      `mapstructure:"batch_swipe"`
+	Filters         MatchingFiltersConfig         `mapstructure:"filters"`
+	Passport        MatchingPassportConfig        `mapstructure:"passport"`
+	Photos          MatchingPhotosConfig          `mapstructure:"photos"`
+	PhotoReorder    MatchingPhotoReorderConfig    `mapstructure:"photo_reorder"`
+	PageSize        MatchingPageSizeConfig        `mapstructure:"page_size"`
+	Boost           DiscoveryBoostConfig          `mapstructure:"boost"`
+	Opener          MatchOpenerConfig             `mapstructure:"opener"`
+	Scoring         MatchScoringConfig            `mapstructure:"scoring"`
+}
+
+// MatchScoringConfig weighs the parts of the score each discovery page is ranked by. Admins can change
+// the weights at runtime; these apply until they do.
+type MatchScoringConfig struct {
+	DistanceWeight          float64       `mapstructure:"distance_weight"`
+	SharedInterestsWeight   float64       `mapstructure:"shared_interests_weight"`
+	ActivityRecencyWeight   float64       `mapstructure:"activity_recency_weight"`
+	ProfileCompletionWeight float64       `mapstructure:"profile_completion_weight"`
+	DistanceScaleKm         float64       `mapstructure:"distance_scale_km"` // Distance at which the distance part drops to half
+	ActivityWindow          time.Duration `mapstructure:"activity_window"`   // Users last active this long ago or longer score 0 for activity
+	DebugEnabled            bool          `mapstructure:"debug_enabled"`     // Lets discovery requests return each user's part scores; reveals activity and distance, so keep off in production
+}
+
+// MatchingPersonalizationConfig controls the per-user preference vector learned from swipes
+type MatchingPersonalizationConfig struct {
+	Enabled      bool          `mapstructure:"enabled"`
+	LearningRate float64       `mapstructure:"learning_rate"` // How far each swipe moves an attribute weight towards like (+1) or pass (-1)
+	Decay        float64       `mapstructure:"decay"`         // Fraction of every weight forgotten per swipe so old taste fades
+	Weight       float64       `mapstructure:"weight"`        // Share of the compatibility score taken by the preference vector
+	MinSwipes    int           `mapstructure:"min_swipes"`    // Swipes needed before the vector affects scoring
+	VectorTTL    time.Duration `mapstructure:"vector_ttl"`    // Vectors of inactive users expire after this long
+}
+
+// MatchingFairnessConfig caps how often a profile is shown in discovery so exposure is spread more evenly
+type MatchingFairnessConfig struct {
+	Enabled          bool `mapstructure:"enabled"`
+	DailyExposureCap int  `mapstructure:"daily_exposure_cap"` // Impressions per UTC day after which a profile is shown after everyone else
+}
+
+// MatchingPerformanceConfig controls the like/pass feedback users get on their own profile
+type MatchingPerformanceConfig struct {
+	Window         time.Duration `mapstructure:"window"`           // Incoming swipes older than this are not counted
+	MinSwipes      int           `mapstructure:"min_swipes"`       // Incoming swipes needed before a ratio is shown, so single swipers cannot be inferred
+	CohortAgeRange int           `mapstructure:"cohort_age_range"` // Users of the same gender within this many years of age form the cohort
+	CacheTTL       time.Duration `mapstructure:"cache_ttl"`
+}
+
+// MatchingFavoritesConfig caps how many profiles a user can save as favorites
+type MatchingFavoritesConfig struct {
+	MaxFree int `mapstructure:"max_free"` // Favorites on the free plan, 0 makes favorites a paid feature
+	MaxPaid int `mapstructure:"max_paid"` // Favorites on a premium or platinum plan, 0 for no limit
+}
+
+// MatchingFiltersConfig controls which discovery filters are available to whom
+type MatchingFiltersConfig struct {
+	PremiumLifestyle bool `mapstructure:"premium_lifestyle"` // Smoking and drinking filters require a premium plan; height filters are free
+}
+
+// MatchingBlockContactsConfig limits contact imports that hide people from a user's discovery
+type MatchingBlockContactsConfig struct {
+	MaxPerImport int `mapstructure:"max_per_import"` // Contact hashes accepted in one request
+}
+
+// MatchingBatchSwipeConfig controls submitting swipes queued offline in one request
+type MatchingBatchSwipeConfig struct {
+	MaxSize int `mapstructure:"max_size"` // Swipes accepted in one batch, larger batches are rejected
+}
+
+// MatchingPassportConfig controls browsing discovery from another location, a premium feature
+type MatchingPassportConfig struct {
+	MaxRadiusKm int `mapstructure:"max_radius_km"` // Largest search radius around the chosen location, 0 for no maximum
+}
+
+// MatchingPhotosConfig keeps users without approved photos out of discovery
+type MatchingPhotosConfig struct {
+	RequireApproved bool `mapstructure:"require_approved"` // Users below MinApproved cannot discover or swipe and are not shown to others
+	MinApproved     int  `mapstructure:"min_approved"`     // Moderation-approved photos needed; pending and rejected photos do not count
+}
+
+// MatchingPhotoReorderConfig controls the opt-in job that puts a user's best performing photo first
+type MatchingPhotoReorderConfig struct {
+	Enabled        bool          `mapstructure:"enabled"`
+	MinImpressions int           `mapstructure:"min_impressions"` // Viewers a photo needs before it is ranked, so a handful of swipes cannot promote it
+	Interval       time.Duration `mapstructure:"interval"`        // How often opted-in users' photos are reordered
+	BatchSize      int           `mapstructure:"batch_size"`      // Opted-in users loaded per query
+}
+
+// MatchingPageSizeConfig sets how many profiles a discovery page returns for each client platform
+type MatchingPageSizeConfig struct {
+	PlatformHeader string                          `mapstructure:"platform_header"` // Names the client platform; a "platform" query parameter takes precedence
+	Default        DiscoveryPageSizeConfig            `mapstructure:"default"`         // For requests from an unknown or unlisted platform
+	Platforms      map[string]DiscoveryPageSizeConfig `mapstructure:"platforms"`       // Keyed by platform, e.g. web, ios, android
+}
+
+// DiscoveryPageSizeConfig is the page size used when no limit is requested, and the largest allowed
+type DiscoveryPageSizeConfig struct {
+	Default int `mapstructure:"default"`
+	Max     int `mapstructure:"max"` // Discovery never returns more than 100 profiles per page
+}
+
+// DiscoveryBoostConfig controls the premium boost that shows a profile first in others' discovery feeds
+type DiscoveryBoostConfig struct {
+	Enabled    bool          `mapstructure:"enabled"`
+	Duration   time.Duration `mapstructure:"duration"`    // How long a boost lasts
+	Multiplier float64       `mapstructure:"multiplier"`  // Boosts with a higher multiplier are shown before lower ones
+	MaxPerDay  int           `mapstructure:"max_per_day"` // Boosts a user may start per UTC day
+}
+
+// MatchOpenerConfig controls opening the conversation of a new match. The prompt suggests how to break
+// the ice, and auto-intros are openers users wrote ahead of time, sent as their first message.
+type MatchOpenerConfig struct {
+	Enabled      bool   `mapstructure:"enabled"`
+	SystemPrompt string `mapstructure:"system_prompt"` // Shown to both users when they match
+	AutoIntros   bool   `mapstructure:"auto_intros"`   // Send the auto-intros users set up
+}
+
+// LegalConfig lists the notices users must acknowledge and which actions wait for them
+type LegalConfig struct {
+	Notices      []LegalNoticeConfig `mapstructure:"notices"`
+	GatedActions []string            `mapstructure:"gated_actions"` // "messaging" and/or "discovery"; blocked until required notices are acknowledged
+}
+
+// DeepLinkConfig configures the signed links into the app that notifications carry
+type DeepLinkConfig struct {
+	Scheme string        `mapstructure:"scheme"` // e.g. "winkr" for app links or "https" for universal links
+	Host   string        `mapstructure:"host"`
+	Secret string        `mapstructure:"secret"`
+	TTL    time.Duration `mapstructure:"ttl"` // How long a link can be opened after it was sent
+}
+
+// WebhooksConfig configures the outbound webhooks that domain events are delivered to
+type WebhooksConfig struct {
+	Enabled   bool          `mapstructure:"enabled"`
+	Endpoints []string      `mapstructure:"endpoints"` // Webhook and analytics receivers, every event goes to each of them
+	Secret    string        `mapstructure:"secret"`    // Signs the payload so receivers can check it came from us
+	Timeout   time.Duration `mapstructure:"timeout"`
+}
+
+// EntitlementsConfig configures the consumable allotments granted with each subscription plan
+type EntitlementsConfig struct {
+	Plans map[string]PlanGrantConfig `mapstructure:"plans"` // Keyed by plan type: basic, premium, platinum
+}
+
+// PlanGrantConfig is what a plan grants on every renewal
+type PlanGrantConfig struct {
+	SuperLikes int `mapstructure:"super_likes"`
+	Rewinds    int `mapstructure:"rewinds"`
+	Boosts     int `mapstructure:"boosts"`
+}
+
+// SafetyConfig represents in-app safety feature configuration
+type SafetyConfig struct {
+	CheckIn SafetyCheckInConfig `mapstructure:"check_in"`
+}
+
+// SafetyCheckInConfig configures date check-ins. A check-in that is not confirmed by its due time
+// plus the grace period alerts the user and, if they consented, their trusted contact.
+type SafetyCheckInConfig struct {
+	Enabled       bool          `mapstructure:"enabled"`
+	MinLeadTime   time.Duration `mapstructure:"min_lead_time"`  // How soon after scheduling a check-in can be due
+	MaxLeadTime   time.Duration `mapstructure:"max_lead_time"`  // How far ahead a check-in can be scheduled
+	GracePeriod   time.Duration `mapstructure:"grace_period"`   // How late a check-in can be confirmed before alerts are sent
+	CheckInterval time.Duration `mapstructure:"check_interval"` // How often missed check-ins are looked for
+	MaxScheduled  int           `mapstructure:"max_scheduled"`  // Check-ins a user can have scheduled at once
+}
+
+// LegalNoticeConfig describes a community guideline or safety notice
+type LegalNoticeConfig struct {
+	ID       string `mapstructure:"id"`
+	Version  int    `mapstructure:"version"` // Bump to ask every user to acknowledge again
+	Title    string `mapstructure:"title"`
+	URL      string `mapstructure:"url"`
+	Required bool   `mapstructure:"required"` // Required notices gate the configured actions
+}
+
 // Load loads configuration from environment variables and config files
 func Load() (*Config, error) {
 	viper.SetConfigName(".env")
@@ -844,6 +1202,7 @@ func setDefaults() {
 	viper.SetDefault("app.env", "development")
 	viper.SetDefault("app.port", 8080)
 	viper.SetDefault("app.host", "localhost")
+	viper.SetDefault("app.read_only_mode", false)
 
 	// Database defaults
 	viper.SetDefault("database.host", "localhost")
@@ -891,14 +1250,20 @@ func setDefaults() {
 	viper.SetDefault("security.account_lockout_enabled", true)
 	viper.SetDefault("security.max_failed_attempts", 5)
 	viper.SetDefault("security.account_lockout_duration", "15m")
+	viper.SetDefault("security.account_lockout_max_duration", "24h")
+	viper.SetDefault("security.account_lockout_reset_after", "24h")
 	viper.SetDefault("security.password_min_length", 8)
 	viper.SetDefault("security.password_require_uppercase", true)
 	viper.SetDefault("security.password_require_lowercase", true)
 	viper.SetDefault("security.password_require_numbers", true)
 	viper.SetDefault("security.password_require_symbols", false)
+	viper.SetDefault("security.common_passwords_file", "")
 	viper.SetDefault("security.session_timeout", "168h")
 	viper.SetDefault("security.device_fingerprinting", true)
+	viper.SetDefault("security.device_fingerprint_tolerance", 1)
 	viper.SetDefault("security.csrf_protection", true)
+	viper.SetDefault("security.csrf_session_cookie", "session_id")
+	viper.SetDefault("security.csrf_token_rotation", "1h")
 
 	// AWS defaults
 	viper.SetDefault("aws.region", "us-east-1")
@@ -914,6 +1279,8 @@ func setDefaults() {
 	viper.SetDefault("storage.download_expiry", "1h")
 	viper.SetDefault("storage.max_file_size", 5242880) // 5MB in bytes
 	viper.SetDefault("storage.allowed_types", []string{"image/jpeg", "image/png", "image/webp"})
+	viper.SetDefault("storage.max_photos_per_user", 6)
+	viper.SetDefault("storage.max_photos_per_user_paid", 9)
 
 	// Stripe defaults
 	viper.SetDefault("stripe.secret_key", "")
@@ -925,22 +1292,43 @@ func setDefaults() {
 	viper.SetDefault("stripe.default_currency", "usd")
 	viper.SetDefault("stripe.success_url", "/payment/success")
 	viper.SetDefault("stripe.cancel_url", "/payment/cancel")
+	viper.SetDefault("stripe.supported_currencies", []string{"usd", "eur", "gbp"})
+	viper.SetDefault("stripe.localized_price_ids", map[string]map[string]string{})
 	viper.SetDefault("stripe.webhook_endpoint", "/api/v1/payment/webhook")
+	viper.SetDefault("stripe.webhook_tolerance_seconds", 300)
+	viper.SetDefault("stripe.webhook_max_age", "72h")
 	viper.SetDefault("stripe.enable_radar", true)
 	viper.SetDefault("stripe.fraud_level", "normal")
 	viper.SetDefault("stripe.payment_rate_limit", 10)
 	viper.SetDefault("stripe.cache_ttl", "15m")
+	viper.SetDefault("stripe.grace_period", "168h")
+	viper.SetDefault("stripe.grace_period_check_interval", "15m")
+	viper.SetDefault("stripe.cancellation_refund_window", "336h")
+	viper.SetDefault("stripe.account_deletion.cancel_at_period_end", false)
+	viper.SetDefault("stripe.account_deletion.cancel_at_period_end_with_open_invoices", true)
+	viper.SetDefault("stripe.account_deletion.delete_customer", true)
 
 	// Rate limiting defaults
 	viper.SetDefault("rate_limit.requests_per_minute", 1000)
 	viper.SetDefault("rate_limit.requests_per_hour", 10000)
+	viper.SetDefault("rate_limit.backend", "redis")
+	viper.SetDefault("rate_limit.cleanup_interval", "1m")
 	
 	// Discovery rate limits defaults
 	viper.SetDefault("rate_limit.swipes_per_hour", 100)
 	viper.SetDefault("rate_limit.swipes_per_day", 1000)
 	viper.SetDefault("rate_limit.super_likes_per_day", 5)
+	viper.SetDefault("rate_limit.swipe_reset_strategy", "rolling")
+	viper.SetDefault("rate_limit.undos_per_day", 10)
 	viper.SetDefault("rate_limit.discovery_per_hour", 50)
 	viper.SetDefault("rate_limit.discovery_per_day", 500)
+	viper.SetDefault("rate_limit.discovery_throttle.enabled", true)
+	viper.SetDefault("rate_limit.discovery_throttle.ip_per_hour", 200)
+	viper.SetDefault("rate_limit.discovery_throttle.degrade_after", 30)
+	viper.SetDefault("rate_limit.discovery_throttle.degraded_limit", 5)
+	viper.SetDefault("rate_limit.discovery_throttle.base_backoff", "1m")
+	viper.SetDefault("rate_limit.discovery_throttle.max_backoff", "1h")
+	viper.SetDefault("rate_limit.discovery_throttle.strike_window", "24h")
 
 	// Cache defaults
 	viper.SetDefault("cache.user_profile_ttl", "30m")
@@ -988,13 +1376,16 @@ func setDefaults() {
 	viper.SetDefault("verification.limits.max_file_size", 10485760)      // 10MB in bytes
 	viper.SetDefault("verification.limits.max_selfie_file_size", 5242880) // 5MB in bytes
 	viper.SetDefault("verification.limits.max_document_file_size", 10485760) // 10MB in bytes
+	viper.SetDefault("verification.limits.expiry_check_interval", "1h")
 
 	// Document processing defaults
 	viper.SetDefault("verification.document_processing.ocr_provider", "aws")
 	viper.SetDefault("verification.document_processing.min_confidence", 0.80)
-	viper.SetDefault("verification.document_processing.supported_document_types", []string{"id_card", "passport", "driver_license"})
+	viper.SetDefault("verification.document_processing.supported_document_types", []string{"id_card", "passport", "driver_license", "proof_of_address"})
 	viper.SetDefault("verification.document_processing.extraction_enabled", true)
 	viper.SetDefault("verification.document_processing.validation_enabled", true)
+	viper.SetDefault("verification.document_processing.default_required_documents", []string{})
+	viper.SetDefault("verification.document_processing.required_documents_by_region", map[string][]string{})
 
 	// Verification security defaults
 	viper.SetDefault("verification.security.encrypted_storage", true)
@@ -1020,6 +1411,9 @@ func setDefaults() {
 	viper.SetDefault("chat.websocket.connection_timeout", "30s")
 	viper.SetDefault("chat.websocket.reconnect_interval", "5s")
 	viper.SetDefault("chat.websocket.heartbeat_interval", "30s")
+	viper.SetDefault("chat.websocket.sync_max_messages", 200)
+	viper.SetDefault("chat.websocket.presence_max_subscriptions", 50)
+	viper.SetDefault("chat.websocket.presence_subscribe_interval", "5s")
 
 	// Message defaults
 	viper.SetDefault("chat.message.max_text_length", 2000)
@@ -1032,6 +1426,23 @@ func setDefaults() {
 	viper.SetDefault("chat.message.ephemeral_photo_duration", "10s")
 	viper.SetDefault("chat.message.location_accuracy", 100.0) // 100 meters
 	viper.SetDefault("chat.message.system_message_prefix", "[System]")
+	viper.SetDefault("chat.message.max_pinned_messages", 10)
+	viper.SetDefault("chat.message.allowed_reactions", []string{"❤️", "😂", "😮", "😢", "😡", "👍"})
+	viper.SetDefault("chat.message.edit_window", "15m")
+	viper.SetDefault("chat.message.delete_window", "24h")
+	viper.SetDefault("chat.message.max_free_open_conversations", 0) // Unlimited
+	viper.SetDefault("chat.message.client_message_id_max_length", 64)
+	viper.SetDefault("chat.message.translation.enabled", false)
+	viper.SetDefault("chat.message.translation.timeout", "2s")
+	viper.SetDefault("chat.message.translation.cache_ttl", "24h")
+	viper.SetDefault("chat.message.engagement.enabled", true)
+	viper.SetDefault("chat.message.engagement.streak_milestones", []int{3, 7, 14, 30, 100})
+	viper.SetDefault("chat.message.engagement.anniversary_months", []int{1, 6, 12})
+	viper.SetDefault("chat.message.scheduled.enabled", true)
+	viper.SetDefault("chat.message.scheduled.min_lead_time", "1m")
+	viper.SetDefault("chat.message.scheduled.max_lead_time", "168h") // 7 days
+	viper.SetDefault("chat.message.scheduled.max_per_conversation", 5)
+	viper.SetDefault("chat.message.scheduled.check_interval", "30s")
 	viper.SetDefault("chat.message.encryption_enabled", false)
 	viper.SetDefault("chat.message.encryption_key", "")
 
@@ -1047,7 +1458,14 @@ func setDefaults() {
 	viper.SetDefault("chat.security.link_preview_enabled", true)
 	viper.SetDefault("chat.security.allowed_link_domains", []string{})
 	viper.SetDefault("chat.security.blocked_link_domains", []string{})
+	viper.SetDefault("chat.security.link_preview_timeout", "3s")
 	viper.SetDefault("chat.security.pii_detection_enabled", true)
+	viper.SetDefault("chat.security.pii_patterns", []string{
+		`\b[A-Za-z0-9._%+-]+@[A-Za-z0-9.-]+\.[A-Za-z]{2,}\b`, // Email
+		`\b\d{3}[-. ]?\d{3}[-. ]?\d{4}\b`,                    // Phone number
+		`\b\d{4}[- ]?\d{4}[- ]?\d{4}[- ]?\d{4}\b`,           // Credit card
+		`\b\d{3}-\d{2}-\d{4}\b`,                               // SSN
+	})
 	viper.SetDefault("chat.security.report_threshold", 3)
 	viper.SetDefault("chat.security.auto_ban_threshold", 10)
 
@@ -1067,6 +1485,7 @@ func setDefaults() {
 	viper.SetDefault("chat.rate_limit.messages_per_minute", 30)
 	viper.SetDefault("chat.rate_limit.messages_per_hour", 500)
 	viper.SetDefault("chat.rate_limit.messages_per_day", 2000)
+	viper.SetDefault("chat.rate_limit.messages_per_conversation_per_minute", 20)
 	viper.SetDefault("chat.rate_limit.conversations_per_day", 50)
 	viper.SetDefault("chat.rate_limit.photos_per_day", 20)
 	viper.SetDefault("chat.rate_limit.connections_per_minute", 10)
@@ -1076,12 +1495,14 @@ func setDefaults() {
 	viper.SetDefault("ephemeral_photo.max_file_size", 5242880) // 5MB in bytes
 	viper.SetDefault("ephemeral_photo.allowed_types", []string{"image/jpeg", "image/png", "image/webp"})
 	viper.SetDefault("ephemeral_photo.max_photos_per_user", 10)
+	viper.SetDefault("ephemeral_photo.max_photos_per_user_paid", 25)
 	viper.SetDefault("ephemeral_photo.default_duration", "30s")
 	viper.SetDefault("ephemeral_photo.max_duration", "300s") // 5 minutes
 	viper.SetDefault("ephemeral_photo.view_duration", "30s")
 	viper.SetDefault("ephemeral_photo.access_key_length", 32)
 	viper.SetDefault("ephemeral_photo.enable_watermark", true)
 	viper.SetDefault("ephemeral_photo.watermark_text", "Ephemeral")
+	viper.SetDefault("ephemeral_photo.watermark_viewer_id", true)
 	viper.SetDefault("ephemeral_photo.prevent_download", true)
 	viper.SetDefault("ephemeral_photo.storage_tier", "hot")
 	viper.SetDefault("ephemeral_photo.cleanup_interval", "5m")
@@ -1096,20 +1517,25 @@ func setDefaults() {
 	viper.SetDefault("ephemeral_photo.job_batch_size", 100)
 	viper.SetDefault("ephemeral_photo.enable_job_retry", true)
 	viper.SetDefault("ephemeral_photo.max_job_retries", 3)
+	viper.SetDefault("ephemeral_photo.job_retry_delay", "500ms")
+	viper.SetDefault("ephemeral_photo.job_run_history", 100)
 
 	// Moderation defaults
 	// AI moderation defaults
 	viper.SetDefault("moderation.ai_moderation.enabled", true)
+	viper.SetDefault("moderation.ai_moderation.provider", "aws")
 	viper.SetDefault("moderation.ai_moderation.region", "us-east-1")
 	viper.SetDefault("moderation.ai_moderation.bucket", "dating-app-moderation")
 	viper.SetDefault("moderation.ai_moderation.confidence_threshold", 0.75)
 	viper.SetDefault("moderation.ai_moderation.nsfw_threshold", 0.70)
 	viper.SetDefault("moderation.ai_moderation.violence_threshold", 0.80)
 	viper.SetDefault("moderation.ai_moderation.adult_threshold", 0.75)
+	viper.SetDefault("moderation.ai_moderation.review_threshold", 0.50)
 	viper.SetDefault("moderation.ai_moderation.batch_size", 10)
 	viper.SetDefault("moderation.ai_moderation.max_retries", 3)
 	viper.SetDefault("moderation.ai_moderation.retry_delay", "1s")
 	viper.SetDefault("moderation.ai_moderation.timeout", "30s")
+	viper.SetDefault("moderation.ai_moderation.poll_interval", "5s")
 	viper.SetDefault("moderation.ai_moderation.enable_fallback", true)
 	viper.SetDefault("moderation.ai_moderation.fallback_threshold", 0.60)
 
@@ -1145,8 +1571,17 @@ func setDefaults() {
 	viper.SetDefault("moderation.rules.initial_reputation", 100)
 	viper.SetDefault("moderation.rules.min_reputation", 0)
 	viper.SetDefault("moderation.rules.max_reputation", 1000)
+	viper.SetDefault("moderation.rules.reputation_report_penalty", 10)
+	viper.SetDefault("moderation.rules.reputation_violation_penalty", 25)
+	viper.SetDefault("moderation.rules.reputation_recovery_points", 5)
+	viper.SetDefault("moderation.rules.reputation_recovery_interval", "168h")
+	viper.SetDefault("moderation.rules.reputation_suspend_threshold", 25)
+	viper.SetDefault("moderation.rules.reputation_discovery_threshold", 50)
 	viper.SetDefault("moderation.rules.report_threshold", 3)
 	viper.SetDefault("moderation.rules.severity_threshold", 7)
+	viper.SetDefault("moderation.rules.photo_report_hide_threshold", 3)
+	viper.SetDefault("moderation.rules.unmatch_on_ban", true)
+	viper.SetDefault("moderation.rules.notify_matches_on_ban", true)
 	viper.SetDefault("moderation.rules.custom_rules", []CustomRule{})
 
 	// Appeal process defaults
@@ -1184,6 +1619,7 @@ func setDefaults() {
 	viper.SetDefault("moderation.rate_limit.reports_per_minute", 5)
 	viper.SetDefault("moderation.rate_limit.reports_per_hour", 50)
 	viper.SetDefault("moderation.rate_limit.reports_per_day", 200)
+	viper.SetDefault("moderation.rate_limit.report_cooldown", "168h") // 7 days
 	viper.SetDefault("moderation.rate_limit.blocks_per_minute", 10)
 	viper.SetDefault("moderation.rate_limit.blocks_per_hour", 100)
 	viper.SetDefault("moderation.rate_limit.blocks_per_day", 500)
@@ -1192,6 +1628,14 @@ func setDefaults() {
 	viper.SetDefault("moderation.rate_limit.appeals_per_day", 20)
 	viper.SetDefault("moderation.rate_limit.admin_actions_per_minute", 20)
 	viper.SetDefault("moderation.rate_limit.admin_actions_per_hour", 500)
+	viper.SetDefault("moderation.multi_account.enabled", true)
+	viper.SetDefault("moderation.multi_account.max_accounts_per_device", 3)
+	viper.SetDefault("moderation.multi_account.max_accounts_per_ip", 10) // Households and mobile carriers share IP addresses
+	viper.SetDefault("moderation.multi_account.window", "720h")        // 30 days
+	viper.SetDefault("moderation.multi_account.restrict_banned_devices", false)
+	viper.SetDefault("moderation.profile.enabled", true)
+	viper.SetDefault("moderation.profile.action", "reject")
+	viper.SetDefault("moderation.profile.ai_moderation", true)
 
 	// Monitoring defaults
 	// Health check defaults
@@ -1233,6 +1677,9 @@ func setDefaults() {
 	viper.SetDefault("monitoring.alerting.memory_usage_threshold", 90.0)  // 90%
 	viper.SetDefault("monitoring.alerting.disk_usage_threshold", 95.0)    // 95%
 	viper.SetDefault("monitoring.alerting.notification_channels", []NotificationChannel{})
+	viper.SetDefault("monitoring.alerting.notification_timeout", "10s")
+	viper.SetDefault("monitoring.alerting.notification_retries", 3)
+	viper.SetDefault("monitoring.alerting.notification_retry_backoff", "1s")
 	viper.SetDefault("monitoring.alerting.rules", []AlertRule{})
 
 	// Logging defaults
@@ -1263,4 +1710,111 @@ func setDefaults() {
 	viper.SetDefault("monitoring.storage.backup_enabled", false)
 	viper.SetDefault("monitoring.storage.backup_interval", "24h")
 	viper.SetDefault("monitoring.storage.backup_location", "/backups/monitoring")
-}
\ No newline at end of file
+
+	// Geofencing defaults
+	viper.SetDefault("geofencing.enabled", false)
+	viper.SetDefault("geofencing.restricted_countries", []string{})
+	viper.SetDefault("geofencing.restricted_regions", []string{})
+	viper.SetDefault("geofencing.country_header", "CF-IPCountry")
+	viper.SetDefault("geofencing.region_header", "X-Geo-Region")
+	viper.SetDefault("geofencing.allowed_user_ids", []string{})
+
+	// Matching defaults
+	viper.SetDefault("matching.personalization.enabled", true)
+	viper.SetDefault("matching.personalization.learning_rate", 0.1)
+	viper.SetDefault("matching.personalization.decay", 0.01)
+	viper.SetDefault("matching.personalization.weight", 0.25)
+	viper.SetDefault("matching.personalization.min_swipes", 5)
+	viper.SetDefault("matching.personalization.vector_ttl", "720h") // 30 days
+	viper.SetDefault("matching.fairness.enabled", true)
+	viper.SetDefault("matching.fairness.daily_exposure_cap", 200)
+	viper.SetDefault("matching.performance.window", "720h") // 30 days
+	viper.SetDefault("matching.performance.min_swipes", 20)
+	viper.SetDefault("matching.performance.cohort_age_range", 3)
+	viper.SetDefault("matching.performance.cache_ttl", "6h")
+	viper.SetDefault("matching.favorites.max_free", 10)
+	viper.SetDefault("matching.favorites.max_paid", 500)
+	viper.SetDefault("matching.block_contacts.max_per_import", 1000)
+	viper.SetDefault("matching.batch_swipe.max_size", 100)
+	viper.SetDefault("matching.filters.premium_lifestyle", true)
+	viper.SetDefault("matching.passport.max_radius_km", 100)
+	viper.SetDefault("matching.photos.require_approved", true)
+	viper.SetDefault("matching.photos.min_approved", 1)
+	viper.SetDefault("matching.photo_reorder.enabled", true)
+	viper.SetDefault("matching.photo_reorder.min_impressions", 20)
+	viper.SetDefault("matching.photo_reorder.interval", "6h")
+	viper.SetDefault("matching.photo_reorder.batch_size", 100)
+	viper.SetDefault("matching.page_size.platform_header", "X-Client-Platform")
+	viper.SetDefault("matching.page_size.default.default", 10)
+	viper.SetDefault("matching.page_size.default.max", 50)
+	viper.SetDefault("matching.page_size.platforms.web.default", 20)
+	viper.SetDefault("matching.page_size.platforms.web.max", 100)
+	viper.SetDefault("matching.page_size.platforms.ios.default", 10)
+	viper.SetDefault("matching.page_size.platforms.ios.max", 50)
+	viper.SetDefault("matching.page_size.platforms.android.default", 10)
+	viper.SetDefault("matching.page_size.platforms.android.max", 50)
+	viper.SetDefault("matching.boost.enabled", true)
+	viper.SetDefault("matching.boost.duration", "30m")
+	viper.SetDefault("matching.boost.multiplier", 2.0)
+	viper.SetDefault("matching.boost.max_per_day", 1)
+	viper.SetDefault("matching.opener.enabled", false)
+	viper.SetDefault("matching.opener.system_prompt", "It's a match! Say hi and ask about something in their profile.")
+	viper.SetDefault("matching.opener.auto_intros", false)
+	viper.SetDefault("matching.scoring.distance_weight", 0.35)
+	viper.SetDefault("matching.scoring.shared_interests_weight", 0.35)
+	viper.SetDefault("matching.scoring.activity_recency_weight", 0.15)
+	viper.SetDefault("matching.scoring.profile_completion_weight", 0.15)
+	viper.SetDefault("matching.scoring.distance_scale_km", 10.0)
+	viper.SetDefault("matching.scoring.activity_window", "168h") // 7 days
+	viper.SetDefault("matching.scoring.debug_enabled", false)
+
+	// Legal notice defaults
+	viper.SetDefault("legal.notices", []map[string]interface{}{
+		{
+			"id":       "community_guidelines",
+			"version":  1,
+			"title":    "Community Guidelines",
+			"url":      "https://winkr.com/legal/community-guidelines",
+			"required": true,
+		},
+		{
+			"id":       "safety_tips",
+			"version":  1,
+			"title":    "Dating Safety Tips",
+			"url":      "https://winkr.com/legal/safety-tips",
+			"required": false,
+		},
+	})
+	viper.SetDefault("legal.gated_actions", []string{"messaging", "discovery"})
+
+	// Deep link defaults
+	viper.SetDefault("deep_link.scheme", "winkr")
+	viper.SetDefault("deep_link.host", "app")
+	viper.SetDefault("deep_link.secret", "your-deep-link-signing-key")
+	viper.SetDefault("deep_link.ttl", "72h")
+
+	// Webhook defaults
+	viper.SetDefault("webhooks.enabled", false)
+	viper.SetDefault("webhooks.endpoints", []string{})
+	viper.SetDefault("webhooks.secret", "")
+	viper.SetDefault("webhooks.timeout", "5s")
+
+	// Entitlement defaults, granted per billing period
+	viper.SetDefault("entitlements.plans.basic.super_likes", 0)
+	viper.SetDefault("entitlements.plans.basic.rewinds", 0)
+	viper.SetDefault("entitlements.plans.basic.boosts", 0)
+	viper.SetDefault("entitlements.plans.premium.super_likes", 150)
+	viper.SetDefault("entitlements.plans.premium.rewinds", 30)
+	viper.SetDefault("entitlements.plans.premium.boosts", 1)
+	viper.SetDefault("entitlements.plans.platinum.super_likes", 500)
+	viper.SetDefault("entitlements.plans.platinum.rewinds", 100)
+	viper.SetDefault("entitlements.plans.platinum.boosts", 4)
+
+	// Safety check-in defaults
+	viper.SetDefault("safety.check_in.enabled", true)
+	viper.SetDefault("safety.check_in.min_lead_time", "15m")
+	viper.SetDefault("safety.check_in.max_lead_time", "24h")
+	viper.SetDefault("safety.check_in.grace_period", "10m")
+	viper.SetDefault("safety.check_in.check_interval", "1m")
+	viper.SetDefault("safety.check_in.max_scheduled", 3)
+}
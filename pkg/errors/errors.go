@@ -4,6 +4,8 @@ import (
 	"errors"
 	"fmt"
 	"net/http"
+	"strings"
+	"time"
 )
 
 // AppError represents an application error with HTTP status code
@@ -41,6 +43,7 @@ var (
 	ErrValidationFailed = NewAppError(http.StatusBadRequest, "Validation failed", "")
 	ErrInvalidInput     = NewAppError(http.StatusBadRequest, "Invalid input", "")
 	ErrRequiredField     = NewAppError(http.StatusBadRequest, "Required field is missing", "")
+	ErrWeakPassword      = NewAppError(http.StatusBadRequest, "Password does not meet the requirements", "")
 
 	// Authentication errors
 	ErrUnauthorized      = NewAppError(http.StatusUnauthorized, "Unauthorized", "")
@@ -52,14 +55,19 @@ var (
 	ErrTooManyFailedAttempts = NewAppError(http.StatusTooManyRequests, "Too many failed attempts", "")
 	ErrEmailNotVerified = NewAppError(http.StatusForbidden, "Email is not verified", "")
 	ErrInvalidDevice    = NewAppError(http.StatusUnauthorized, "Invalid device", "")
+	ErrDeviceMismatch   = NewAppError(http.StatusUnauthorized, "Invalid device", "Refresh token was issued to a different device")
 	ErrSessionExpired   = NewAppError(http.StatusUnauthorized, "Session expired", "")
 	ErrInvalidPasswordReset = NewAppError(http.StatusBadRequest, "Invalid or expired password reset token", "")
 	ErrVerificationCodeInvalid = NewAppError(http.StatusBadRequest, "Invalid verification code", "")
 	ErrVerificationCodeExpired = NewAppError(http.StatusBadRequest, "Verification code expired", "")
+	ErrDeepLinkInvalid  = NewAppError(http.StatusBadRequest, "Invalid link", "")
+	ErrDeepLinkExpired  = NewAppError(http.StatusGone, "Link expired", "")
+	ErrVerificationCooldown = NewAppError(http.StatusTooManyRequests, "Verification attempted too soon", "")
 
 	// Authorization errors
 	ErrForbidden         = NewAppError(http.StatusForbidden, "Forbidden", "")
 	ErrInsufficientPerms = NewAppError(http.StatusForbidden, "Insufficient permissions", "")
+	ErrCSRFTokenInvalid  = NewAppError(http.StatusForbidden, "CSRF token validation failed", "Fetch a new token from GET /api/v1/auth/csrf")
 
 	// Not found errors
 	ErrNotFound          = NewAppError(http.StatusNotFound, "Resource not found", "")
@@ -85,9 +93,12 @@ var (
 	ErrInvalidOperation  = NewAppError(http.StatusUnprocessableEntity, "Invalid operation", "")
 	ErrAccountBanned     = NewAppError(http.StatusForbidden, "Account is banned", "")
 	ErrAccountInactive   = NewAppError(http.StatusForbidden, "Account is inactive", "")
+	ErrAccountRestricted = NewAppError(http.StatusForbidden, "Account is restricted pending review", "")
 	ErrPhotoLimitExceeded = NewAppError(http.StatusUnprocessableEntity, "Photo limit exceeded", "")
 	ErrPrimaryPhotoRequired = NewAppError(http.StatusUnprocessableEntity, "Primary photo is required", "")
 	ErrCannotDeletePrimaryPhoto = NewAppError(http.StatusUnprocessableEntity, "Cannot delete primary photo", "")
+	ErrBioRejected       = NewAppError(http.StatusUnprocessableEntity, "Bio violates community guidelines", "")
+	ErrFavoriteLimitExceeded = NewAppError(http.StatusUnprocessableEntity, "Favorite limit exceeded", "")
 
 	// File upload errors
 	ErrFileUpload        = NewAppError(http.StatusBadRequest, "File upload failed", "")
@@ -118,6 +129,126 @@ var (
 	ErrTimeout           = NewAppError(http.StatusRequestTimeout, "Request timeout", "")
 )
 
+// PhotoLimitError is returned when an upload would take a user past the photos their plan allows.
+// It unwraps to ErrPhotoLimitExceeded, so handlers that only know AppError still respond correctly.
+type PhotoLimitError struct {
+	PhotoType string // "profile" or "ephemeral"
+	Current   int
+	Max       int
+}
+
+// Error implements the error interface
+func (e *PhotoLimitError) Error() string {
+	return fmt.Sprintf("%s photo limit reached (%d of %d)", e.PhotoType, e.Current, e.Max)
+}
+
+// Unwrap returns ErrPhotoLimitExceeded
+func (e *PhotoLimitError) Unwrap() error {
+	return ErrPhotoLimitExceeded
+}
+
+// NewPhotoLimitError creates a photo limit error with the user's current and maximum photo count
+func NewPhotoLimitError(photoType string, current, max int) *PhotoLimitError {
+	return &PhotoLimitError{PhotoType: photoType, Current: current, Max: max}
+}
+
+// GetPhotoLimitError extracts a PhotoLimitError from error
+func GetPhotoLimitError(err error) (*PhotoLimitError, bool) {
+	var limitErr *PhotoLimitError
+	if errors.As(err, &limitErr) {
+		return limitErr, true
+	}
+	return nil, false
+}
+
+// VerificationCooldownError is returned when a user asks to verify again before they are allowed to.
+// It unwraps to ErrVerificationCooldown, so handlers that only know AppError still respond correctly.
+type VerificationCooldownError struct {
+	Reason    string // "cooldown", "daily_limit" or "monthly_limit"
+	RetryAt   time.Time
+	Remaining time.Duration
+}
+
+// Error implements the error interface
+func (e *VerificationCooldownError) Error() string {
+	return fmt.Sprintf("verification %s, retry in %s", e.Reason, e.Remaining.Round(time.Second))
+}
+
+// Unwrap returns ErrVerificationCooldown
+func (e *VerificationCooldownError) Unwrap() error {
+	return ErrVerificationCooldown
+}
+
+// NewVerificationCooldownError creates a verification cooldown error ending at retryAt
+func NewVerificationCooldownError(reason string, retryAt, now time.Time) *VerificationCooldownError {
+	return &VerificationCooldownError{Reason: reason, RetryAt: retryAt, Remaining: retryAt.Sub(now)}
+}
+
+// GetVerificationCooldownError extracts a VerificationCooldownError from error
+func GetVerificationCooldownError(err error) (*VerificationCooldownError, bool) {
+	var cooldownErr *VerificationCooldownError
+	if errors.As(err, &cooldownErr) {
+		return cooldownErr, true
+	}
+	return nil, false
+}
+
+// AccountLockedError is returned when logging in to an account locked after too many failed attempts.
+// It unwraps to ErrAccountLocked, so handlers that only know AppError still respond correctly.
+type AccountLockedError struct {
+	LockedUntil time.Time
+	Remaining   time.Duration
+}
+
+// Error implements the error interface
+func (e *AccountLockedError) Error() string {
+	return fmt.Sprintf("account locked, retry in %s", e.Remaining.Round(time.Second))
+}
+
+// Unwrap returns ErrAccountLocked
+func (e *AccountLockedError) Unwrap() error {
+	return ErrAccountLocked
+}
+
+// NewAccountLockedError creates an account locked error for a lock with the remaining duration
+func NewAccountLockedError(remaining time.Duration, now time.Time) *AccountLockedError {
+	return &AccountLockedError{LockedUntil: now.Add(remaining), Remaining: remaining}
+}
+
+// GetAccountLockedError extracts an AccountLockedError from error
+func GetAccountLockedError(err error) (*AccountLockedError, bool) {
+	var lockedErr *AccountLockedError
+	if errors.As(err, &lockedErr) {
+		return lockedErr, true
+	}
+	return nil, false
+}
+
+// WeakPasswordError is returned when a new password breaks the password policy. Unmet lists every
+// rule the password breaks, not just the first. It unwraps to ErrWeakPassword.
+type WeakPasswordError struct {
+	Unmet []string
+}
+
+// Error implements the error interface
+func (e *WeakPasswordError) Error() string {
+	return "password must " + strings.Join(e.Unmet, ", ")
+}
+
+// Unwrap returns ErrWeakPassword
+func (e *WeakPasswordError) Unwrap() error {
+	return ErrWeakPassword
+}
+
+// GetWeakPasswordError extracts a WeakPasswordError from error
+func GetWeakPasswordError(err error) (*WeakPasswordError, bool) {
+	var weakErr *WeakPasswordError
+	if errors.As(err, &weakErr) {
+		return weakErr, true
+	}
+	return nil, false
+}
+
 // IsAppError checks if an error is an AppError
 func IsAppError(err error) bool {
 	var appErr *AppError
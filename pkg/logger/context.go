@@ -0,0 +1,76 @@
+package logger
+
+import (
+	"context"
+
+	"github.com/sirupsen/logrus"
+)
+
+// CorrelationIDField is the log field carrying the correlation ID of a request
+const CorrelationIDField = "correlation_id"
+
+type correlationIDKey struct{}
+
+// ContextWithCorrelationID returns a copy of ctx carrying the correlation ID
+func ContextWithCorrelationID(ctx context.Context, correlationID string) context.Context {
+	return context.WithValue(ctx, correlationIDKey{}, correlationID)
+}
+
+// CorrelationIDFromContext returns the correlation ID carried by ctx, or an empty string
+func CorrelationIDFromContext(ctx context.Context) string {
+	if ctx == nil {
+		return ""
+	}
+	correlationID, _ := ctx.Value(correlationIDKey{}).(string)
+	return correlationID
+}
+
+// DetachContext returns a context for work that outlives the request it was started from, such
+// as a background job. It is never cancelled but keeps the request's correlation ID.
+func DetachContext(ctx context.Context) context.Context {
+	detached := context.Background()
+	if correlationID := CorrelationIDFromContext(ctx); correlationID != "" {
+		detached = ContextWithCorrelationID(detached, correlationID)
+	}
+	return detached
+}
+
+// WithContext returns a logger entry with the correlation ID carried by ctx, if any
+func WithContext(ctx context.Context) *logrus.Entry {
+	if log == nil {
+		return nil
+	}
+	entry := logrus.NewEntry(log)
+	if correlationID := CorrelationIDFromContext(ctx); correlationID != "" {
+		entry = entry.WithField(CorrelationIDField, correlationID)
+	}
+	return entry
+}
+
+// DebugContext logs a debug message with the correlation ID carried by ctx
+func DebugContext(ctx context.Context, args ...interface{}) {
+	if log != nil {
+		WithContext(ctx).Debug(args...)
+	}
+}
+
+// InfoContext logs an info message with the correlation ID carried by ctx
+func InfoContext(ctx context.Context, args ...interface{}) {
+	if log != nil {
+		WithContext(ctx).Info(args...)
+	}
+}
+
+// WarnContext logs a warning message with the correlation ID carried by ctx
+func WarnContext(ctx context.Context, args ...interface{}) {
+	if log != nil {
+		WithContext(ctx).Warn(args...)
+	}
+}
+
+// ErrorContext logs an error message with the correlation ID carried by ctx
+func ErrorContext(ctx context.Context, args ...interface{}) {
+	if log != nil {
+		WithContext(ctx).Error(args...)
+	}
+}
@@ -8,6 +8,9 @@ import (
 
 var log *logrus.Logger
 
+// Fields is a set of log fields
+type Fields = logrus.Fields
+
 // Init initializes the logger with the specified environment
 func Init(env string) {
 	log = logrus.New()
@@ -0,0 +1,114 @@
+package security
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/22smeargle/winkr-backend/pkg/config"
+	"github.com/22smeargle/winkr-backend/pkg/errors"
+)
+
+// defaultCommonPasswords are always rejected, whether or not a blocklist file is configured
+var defaultCommonPasswords = []string{
+	"password", "123456", "password123", "admin", "qwerty",
+	"letmein", "welcome", "monkey", "123456789", "password1",
+	"abc123", "111111", "123123", "dragon", "master",
+	"hello", "freedom", "whatever", "qazwsx", "trustno1",
+	"123qwe", "1q2w3e4r", "zxcvbnm", "123abc", "password!",
+}
+
+// PasswordPolicy enforces the password rules of the security config on new passwords
+type PasswordPolicy struct {
+	config *config.SecurityConfig
+	// common holds the blocklisted passwords, lowercased
+	common map[string]struct{}
+}
+
+// NewPasswordPolicy creates a password policy from the security config. The common password
+// blocklist file, if configured, is read once here so a missing file fails at startup.
+func NewPasswordPolicy(cfg *config.SecurityConfig) (*PasswordPolicy, error) {
+	p := &PasswordPolicy{
+		config: cfg,
+		common: make(map[string]struct{}, len(defaultCommonPasswords)),
+	}
+	for _, password := range defaultCommonPasswords {
+		p.common[password] = struct{}{}
+	}
+
+	if cfg.CommonPasswordsFile == "" {
+		return p, nil
+	}
+
+	file, err := os.Open(cfg.CommonPasswordsFile)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open common passwords file: %w", err)
+	}
+	defer file.Close()
+
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		if password := strings.TrimSpace(scanner.Text()); password != "" {
+			p.common[strings.ToLower(password)] = struct{}{}
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read common passwords file: %w", err)
+	}
+
+	return p, nil
+}
+
+// ValidatePassword checks a new password against every rule of the policy. It returns an
+// errors.WeakPasswordError listing all the rules the password breaks, or nil if it meets them.
+func (p *PasswordPolicy) ValidatePassword(password string) error {
+	var unmet []string
+
+	if utf8.RuneCountInString(password) < p.config.PasswordMinLength {
+		unmet = append(unmet, fmt.Sprintf("be at least %d characters long", p.config.PasswordMinLength))
+	}
+
+	var hasUpper, hasLower, hasNumber, hasSymbol bool
+	for _, char := range password {
+		switch {
+		case unicode.IsUpper(char):
+			hasUpper = true
+		case unicode.IsLower(char):
+			hasLower = true
+		case unicode.IsDigit(char):
+			hasNumber = true
+		case unicode.IsPunct(char) || unicode.IsSymbol(char):
+			hasSymbol = true
+		}
+	}
+
+	if p.config.PasswordRequireUppercase && !hasUpper {
+		unmet = append(unmet, "contain an uppercase letter")
+	}
+	if p.config.PasswordRequireLowercase && !hasLower {
+		unmet = append(unmet, "contain a lowercase letter")
+	}
+	if p.config.PasswordRequireNumbers && !hasNumber {
+		unmet = append(unmet, "contain a number")
+	}
+	if p.config.PasswordRequireSymbols && !hasSymbol {
+		unmet = append(unmet, "contain a symbol")
+	}
+	if p.IsCommonPassword(password) {
+		unmet = append(unmet, "not be a commonly used password")
+	}
+
+	if len(unmet) > 0 {
+		return &errors.WeakPasswordError{Unmet: unmet}
+	}
+	return nil
+}
+
+// IsCommonPassword reports whether the password is on the blocklist, ignoring case
+func (p *PasswordPolicy) IsCommonPassword(password string) bool {
+	_, common := p.common[strings.ToLower(strings.TrimSpace(password))]
+	return common
+}
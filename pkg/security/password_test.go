@@ -0,0 +1,80 @@
+package security
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/22smeargle/winkr-backend/pkg/config"
+	"github.com/22smeargle/winkr-backend/pkg/errors"
+)
+
+func testPasswordConfig() *config.SecurityConfig {
+	return &config.SecurityConfig{
+		PasswordMinLength:        10,
+		PasswordRequireUppercase: true,
+		PasswordRequireLowercase: true,
+		PasswordRequireNumbers:   true,
+		PasswordRequireSymbols:   true,
+	}
+}
+
+func TestPasswordPolicy_ValidatePassword(t *testing.T) {
+	policy, err := NewPasswordPolicy(testPasswordConfig())
+	require.NoError(t, err)
+
+	assert.NoError(t, policy.ValidatePassword("Correct-Horse-42"))
+	assert.NoError(t, policy.ValidatePassword("Ünïcode€Pass9"), "non-ASCII letters and symbols count")
+
+	weakErr, ok := errors.GetWeakPasswordError(policy.ValidatePassword("short"))
+	require.True(t, ok)
+	assert.Equal(t, []string{
+		"be at least 10 characters long",
+		"contain an uppercase letter",
+		"contain a number",
+		"contain a symbol",
+	}, weakErr.Unmet, "every unmet rule is listed")
+	assert.ErrorIs(t, weakErr, errors.ErrWeakPassword)
+	assert.Equal(t, "password must be at least 10 characters long, contain an uppercase letter, contain a number, contain a symbol", weakErr.Error())
+}
+
+func TestPasswordPolicy_OnlyEnforcesConfiguredRules(t *testing.T) {
+	policy, err := NewPasswordPolicy(&config.SecurityConfig{PasswordMinLength: 4})
+	require.NoError(t, err)
+
+	assert.NoError(t, policy.ValidatePassword("lowercase only"))
+
+	weakErr, ok := errors.GetWeakPasswordError(policy.ValidatePassword("abc"))
+	require.True(t, ok)
+	assert.Equal(t, []string{"be at least 4 characters long"}, weakErr.Unmet)
+}
+
+func TestPasswordPolicy_RejectsCommonPasswords(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "common.txt")
+	require.NoError(t, os.WriteFile(path, []byte("Summer2024!\n\n  Winkr#Love1  \n"), 0o600))
+
+	cfg := testPasswordConfig()
+	cfg.PasswordMinLength = 8
+	cfg.CommonPasswordsFile = path
+	policy, err := NewPasswordPolicy(cfg)
+	require.NoError(t, err)
+
+	for _, password := range []string{"Summer2024!", "summer2024!", "Winkr#Love1"} {
+		assert.True(t, policy.IsCommonPassword(password), password)
+	}
+	assert.True(t, policy.IsCommonPassword("PASSWORD"), "the built-in list is kept")
+
+	weakErr, ok := errors.GetWeakPasswordError(policy.ValidatePassword("SUMMER2024!"))
+	require.True(t, ok)
+	assert.Equal(t, []string{"contain a lowercase letter", "not be a commonly used password"}, weakErr.Unmet)
+}
+
+func TestNewPasswordPolicy_MissingBlocklist(t *testing.T) {
+	cfg := testPasswordConfig()
+	cfg.CommonPasswordsFile = filepath.Join(t.TempDir(), "missing.txt")
+
+	_, err := NewPasswordPolicy(cfg)
+	assert.Error(t, err)
+}
@@ -326,6 +326,28 @@ func (j *JWTUtils) GenerateDeviceFingerprint(deviceInfo *DeviceInfo) string {
 	return fmt.Sprintf("%x", hash)
 }
 
+// DeviceDrift counts the fingerprinted attributes that differ between two devices: user agent, IP
+// address, platform, device and browser. Devices with the same fingerprint have no drift.
+func DeviceDrift(a, b *DeviceInfo) int {
+	if a.Fingerprint != "" && a.Fingerprint == b.Fingerprint {
+		return 0
+	}
+
+	drift := 0
+	for _, pair := range [][2]string{
+		{a.UserAgent, b.UserAgent},
+		{a.IPAddress, b.IPAddress},
+		{a.Platform, b.Platform},
+		{a.Device, b.Device},
+		{a.Browser, b.Browser},
+	} {
+		if pair[0] != pair[1] {
+			drift++
+		}
+	}
+	return drift
+}
+
 // ParseDeviceInfo parses user agent string to extract device information
 func (j *JWTUtils) ParseDeviceInfo(userAgent, ipAddress string) *DeviceInfo {
 	// Simple parsing - in production, you might want to use a more sophisticated library
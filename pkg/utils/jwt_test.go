@@ -286,6 +286,18 @@ func TestJWTUtils_GenerateDeviceFingerprint(t *testing.T) {
 	assert.Equal(t, 32, len(fingerprint)) // MD5 hash length
 }
 
+func TestDeviceDrift(t *testing.T) {
+	jwtUtils := NewJWTUtils("test-secret", 15*time.Minute, 7*24*time.Hour, NewMockTokenBlacklist())
+	userAgent := "Mozilla/5.0 (iPhone; CPU iPhone OS 17_2 like Mac OS X) AppleWebKit/605.1.15 Version/17.2 Mobile/15E148 Safari/604.1"
+	loggedIn := jwtUtils.ParseDeviceInfo(userAgent, "203.0.113.7")
+
+	assert.Equal(t, 0, DeviceDrift(loggedIn, jwtUtils.ParseDeviceInfo(userAgent, "203.0.113.7")))
+	assert.Equal(t, 1, DeviceDrift(loggedIn, jwtUtils.ParseDeviceInfo(userAgent, "198.51.100.20")), "new network")
+
+	desktop := jwtUtils.ParseDeviceInfo("Mozilla/5.0 (Windows NT 10.0; Win64; x64) Chrome/120.0 Safari/537.36", "198.51.100.20")
+	assert.Greater(t, DeviceDrift(loggedIn, desktop), 1, "another device")
+}
+
 func TestJWTUtils_ParseDeviceInfo(t *testing.T) {
 	secret := "test-secret"
 	accessTokenExpiry := 15 * time.Minute
@@ -1,7 +1,11 @@
 package utils
 
 import (
+	"fmt"
+	"math"
 	"net/http"
+	"strconv"
+	"time"
 
 	"github.com/gin-gonic/gin"
 	"github.com/22smeargle/winkr-backend/pkg/errors"
@@ -20,6 +24,30 @@ type ErrorInfo struct {
 	Code    string `json:"code"`
 	Message string `json:"message"`
 	Details string `json:"details,omitempty"`
+	Limit   *LimitInfo `json:"limit,omitempty"`
+	Cooldown *CooldownInfo `json:"cooldown,omitempty"`
+	Lockout  *LockoutInfo  `json:"lockout,omitempty"`
+	UnmetRules []string    `json:"unmet_rules,omitempty"`
+}
+
+// LimitInfo describes the allowance a request ran into
+type LimitInfo struct {
+	Type    string `json:"type"`
+	Current int    `json:"current"`
+	Max     int    `json:"max"`
+}
+
+// CooldownInfo tells the client when it may try again
+type CooldownInfo struct {
+	Reason           string    `json:"reason"`
+	RetryAt          time.Time `json:"retry_at"`
+	RemainingSeconds int64     `json:"remaining_seconds"`
+}
+
+// LockoutInfo describes how long an account stays locked
+type LockoutInfo struct {
+	LockedUntil      time.Time `json:"locked_until"`
+	RemainingSeconds int64     `json:"remaining_seconds"`
 }
 
 // PaginationInfo represents pagination information
@@ -253,4 +281,157 @@ func PaymentRequired(c *gin.Context, message string) {
 			Message: message,
 		},
 	})
-}
\ No newline at end of file
+}
+// RegionRestricted sends a response for requests from a region the service is not offered in
+func RegionRestricted(c *gin.Context, message string) {
+	c.JSON(http.StatusUnavailableForLegalReasons, Response{
+		Success: false,
+		Error: &ErrorInfo{
+			Code:    "region_restricted",
+			Message: message,
+		},
+	})
+}
+
+// ConversationLimitReached sends an upsell response when a free user has reached the open conversation limit
+func ConversationLimitReached(c *gin.Context, message string) {
+	c.JSON(http.StatusPaymentRequired, Response{
+		Success: false,
+		Error: &ErrorInfo{
+			Code:    "conversation_limit_reached",
+			Message: message,
+		},
+	})
+}
+
+// ConversationNotFound sends a response for messages sent to a conversation that doesn't exist or the sender isn't part of
+func ConversationNotFound(c *gin.Context, message string) {
+	c.JSON(http.StatusNotFound, Response{
+		Success: false,
+		Error: &ErrorInfo{
+			Code:    "conversation_not_found",
+			Message: message,
+		},
+	})
+}
+
+// ContentBlocked sends a response for messages rejected by content moderation, with the rule that matched
+func ContentBlocked(c *gin.Context, message, rule string) {
+	c.JSON(http.StatusUnprocessableEntity, Response{
+		Success: false,
+		Error: &ErrorInfo{
+			Code:    "content_blocked",
+			Message: message,
+			Details: rule,
+		},
+	})
+}
+
+// ConversationClosed sends a response for messages sent to a conversation that was closed by an unmatch, block or ban
+func ConversationClosed(c *gin.Context, message string) {
+	c.JSON(http.StatusConflict, Response{
+		Success: false,
+		Error: &ErrorInfo{
+			Code:    "conversation_closed",
+			Message: message,
+		},
+	})
+}
+
+// UserBlocked sends a response for messages between users where either has blocked the other
+func UserBlocked(c *gin.Context, message string) {
+	c.JSON(http.StatusForbidden, Response{
+		Success: false,
+		Error: &ErrorInfo{
+			Code:    "user_blocked",
+			Message: message,
+		},
+	})
+}
+
+// NoticesRequired sends a response for actions that wait for the user to acknowledge the required legal notices
+func NoticesRequired(c *gin.Context, message string) {
+	c.JSON(http.StatusForbidden, Response{
+		Success: false,
+		Error: &ErrorInfo{
+			Code:    "notices_not_acknowledged",
+			Message: message,
+		},
+	})
+}
+
+// PhotosRequired sends a response for discovery actions that need the user to have approved photos first
+func PhotosRequired(c *gin.Context, message string) {
+	c.JSON(http.StatusForbidden, Response{
+		Success: false,
+		Error: &ErrorInfo{
+			Code:    "photos_required",
+			Message: message,
+		},
+	})
+}
+
+// PhotoLimitReached sends a response for uploads that would take the user past their photo allotment
+func PhotoLimitReached(c *gin.Context, err *errors.PhotoLimitError) {
+	c.JSON(errors.ErrPhotoLimitExceeded.StatusCode(), Response{
+		Success: false,
+		Error: &ErrorInfo{
+			Code:    "photo_limit_reached",
+			Message: fmt.Sprintf("You can have up to %d %s photos. Delete one to make room.", err.Max, err.PhotoType),
+			Limit: &LimitInfo{
+				Type:    err.PhotoType,
+				Current: err.Current,
+				Max:     err.Max,
+			},
+		},
+	})
+}
+
+// VerificationCooldown sends a response for verification requests made before the user may try again
+func VerificationCooldown(c *gin.Context, err *errors.VerificationCooldownError) {
+	remaining := int64(math.Ceil(err.Remaining.Seconds()))
+	c.Header("Retry-After", strconv.FormatInt(remaining, 10))
+	c.JSON(errors.ErrVerificationCooldown.StatusCode(), Response{
+		Success: false,
+		Error: &ErrorInfo{
+			Code:    "verification_cooldown",
+			Message: "You have tried to verify too often. Please wait before trying again.",
+			Cooldown: &CooldownInfo{
+				Reason:           err.Reason,
+				RetryAt:          err.RetryAt,
+				RemainingSeconds: remaining,
+			},
+		},
+	})
+}
+
+// AccountLocked sends a response for logins to an account locked after too many failed attempts
+func AccountLocked(c *gin.Context, err *errors.AccountLockedError) {
+	remaining := int64(math.Ceil(err.Remaining.Seconds()))
+	c.Header("Retry-After", strconv.FormatInt(remaining, 10))
+	c.JSON(errors.ErrAccountLocked.StatusCode(), Response{
+		Success: false,
+		Error: &ErrorInfo{
+			Code:    "account_locked",
+			Message: "Too many failed login attempts. Please wait before trying again.",
+			Lockout: &LockoutInfo{
+				LockedUntil:      err.LockedUntil,
+				RemainingSeconds: remaining,
+			},
+		},
+	})
+}
+
+// WeakPassword sends a response for a new password that breaks the password policy, listing every
+// rule it breaks
+func WeakPassword(c *gin.Context, err *errors.WeakPasswordError) {
+	c.JSON(errors.ErrWeakPassword.StatusCode(), Response{
+		Success: false,
+		Error: &ErrorInfo{
+			Code:       "weak_password",
+			Message:    errors.ErrWeakPassword.Message,
+			Details:    err.Error(),
+			UnmetRules: err.Unmet,
+		},
+	})
+}
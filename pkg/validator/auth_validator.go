@@ -8,17 +8,20 @@ import (
 	"time"
 
 	"github.com/22smeargle/winkr-backend/pkg/errors"
+	"github.com/22smeargle/winkr-backend/pkg/security"
 )
 
 // AuthValidator handles authentication-specific validation
 type AuthValidator struct {
 	validator *Validator
+	passwords *security.PasswordPolicy
 }
 
-// NewAuthValidator creates a new auth validator
-func NewAuthValidator() *AuthValidator {
+// NewAuthValidator creates a new auth validator checking new passwords against the password policy
+func NewAuthValidator(passwords *security.PasswordPolicy) *AuthValidator {
 	return &AuthValidator{
 		validator: &Validator{},
+		passwords: passwords,
 	}
 }
 
@@ -240,58 +243,14 @@ func (av *AuthValidator) validateEmailFormat(email string) error {
 	return nil
 }
 
-// validatePasswordStrength validates password strength
+// validatePasswordStrength validates password strength against the password policy. Weak passwords
+// return an errors.WeakPasswordError listing the unmet rules.
 func (av *AuthValidator) validatePasswordStrength(password string) error {
-	if len(password) < 8 {
-		return errors.NewValidationError("password", "Password must be at least 8 characters long")
-	}
-
 	if len(password) > 128 {
 		return errors.NewValidationError("password", "Password must be less than 128 characters long")
 	}
 
-	var (
-		hasUpper   = false
-		hasLower   = false
-		hasNumber  = false
-		hasSpecial = false
-	)
-
-	for _, char := range password {
-		switch {
-		case char >= 'A' && char <= 'Z':
-			hasUpper = true
-		case char >= 'a' && char <= 'z':
-			hasLower = true
-		case char >= '0' && char <= '9':
-			hasNumber = true
-		case strings.ContainsRune("!@#$%^&*()_+-=[]{}|;:,.<>?", char):
-			hasSpecial = true
-		}
-	}
-
-	if !hasUpper {
-		return errors.NewValidationError("password", "Password must contain at least one uppercase letter")
-	}
-
-	if !hasLower {
-		return errors.NewValidationError("password", "Password must contain at least one lowercase letter")
-	}
-
-	if !hasNumber {
-		return errors.NewValidationError("password", "Password must contain at least one number")
-	}
-
-	if !hasSpecial {
-		return errors.NewValidationError("password", "Password must contain at least one special character")
-	}
-
-	// Check for common passwords
-	if av.isCommonPassword(password) {
-		return errors.NewValidationError("password", "Password is too common, please choose a more secure password")
-	}
-
-	return nil
+	return av.passwords.ValidatePassword(password)
 }
 
 // validateDateOfBirth validates date of birth and age restrictions
@@ -344,23 +303,3 @@ func (av *AuthValidator) validateTokenFormat(token string) error {
 
 	return nil
 }
-
-// isCommonPassword checks if password is in common passwords list
-func (av *AuthValidator) isCommonPassword(password string) bool {
-	commonPasswords := []string{
-		"password", "123456", "password123", "admin", "qwerty",
-		"letmein", "welcome", "monkey", "123456789", "password1",
-		"abc123", "111111", "123123", "dragon", "master",
-		"hello", "freedom", "whatever", "qazwsx", "trustno1",
-		"123qwe", "1q2w3e4r", "zxcvbnm", "123abc", "password!",
-	}
-
-	lowerPassword := strings.ToLower(password)
-	for _, common := range commonPasswords {
-		if lowerPassword == common {
-			return true
-		}
-	}
-
-	return false
-}
\ No newline at end of file
@@ -6,10 +6,19 @@ import (
 	"strings"
 
 	"github.com/go-playground/validator/v10"
+	"github.com/22smeargle/winkr-backend/pkg/security"
 )
 
 var validate *validator.Validate
 
+// passwordPolicy backs the "password" tag once set, replacing the built-in rules
+var passwordPolicy *security.PasswordPolicy
+
+// SetPasswordPolicy makes the "password" tag enforce the configured password policy
+func SetPasswordPolicy(policy *security.PasswordPolicy) {
+	passwordPolicy = policy
+}
+
 // Init initializes the validator
 func Init() {
 	validate = validator.New()
@@ -79,6 +88,9 @@ func getErrorMessage(e validator.FieldError) string {
 	case "oneof":
 		return fmt.Sprintf("%s must be one of: %s", fieldName, e.Param())
 	case "password":
+		if passwordPolicy != nil {
+			return fmt.Sprintf("%s does not meet the password requirements", fieldName)
+		}
 		return fmt.Sprintf("%s must contain at least 8 characters, including uppercase, lowercase, number, and special character", fieldName)
 	default:
 		return fmt.Sprintf("%s is invalid", fieldName)
@@ -89,6 +101,10 @@ func getErrorMessage(e validator.FieldError) string {
 func validatePassword(fl validator.FieldLevel) bool {
 	password := fl.Field().String()
 	
+	if passwordPolicy != nil {
+		return passwordPolicy.ValidatePassword(password) == nil
+	}
+	
 	if len(password) < 8 {
 		return false
 	}
@@ -58,6 +58,7 @@ func (suite *AdminIntegrationTestSuite) SetupSuite() {
 		&MockPaymentRepository{},
 		&MockSubscriptionRepository{},
 		&MockVerificationRepository{},
+		nil, // ban cascader
 	)
 
 	// Create test users
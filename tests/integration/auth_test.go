@@ -23,6 +23,8 @@ import (
 	"github.com/22smeargle/winkr-backend/internal/infrastructure/external/sms"
 	"github.com/22smeargle/winkr-backend/internal/interfaces/http/handlers"
 	"github.com/22smeargle/winkr-backend/internal/interfaces/http/middleware"
+	"github.com/22smeargle/winkr-backend/pkg/config"
+	"github.com/22smeargle/winkr-backend/pkg/security"
 	"github.com/22smeargle/winkr-backend/pkg/validator"
 	"github.com/22smeargle/winkr-backend/pkg/utils"
 )
@@ -52,7 +54,14 @@ func (suite *AuthIntegrationTestSuite) SetupSuite() {
 	)
 
 	// Create auth validator
-	authValidator := validator.NewAuthValidator()
+	passwordPolicy, err := security.NewPasswordPolicy(&config.SecurityConfig{
+		PasswordMinLength:        8,
+		PasswordRequireUppercase: true,
+		PasswordRequireLowercase: true,
+		PasswordRequireNumbers:   true,
+	})
+	require.NoError(suite.T(), err)
+	authValidator := validator.NewAuthValidator(passwordPolicy)
 
 	// Create rate limiter
 	rateLimiter := middleware.NewAuthRateLimiter(suite.redisClient)
@@ -64,8 +73,8 @@ func (suite *AuthIntegrationTestSuite) SetupSuite() {
 	jwtUtils := utils.NewJWTUtils("test-secret", time.Hour, time.Hour*24*7)
 
 	// Create use cases
-	registerUseCase := auth.NewRegisterUseCase(nil) // TODO: Add auth service
-	loginUseCase := auth.NewLoginUseCase(nil, jwtUtils)
+	registerUseCase := auth.NewRegisterUseCase(nil, nil) // TODO: Add auth service
+	loginUseCase := auth.NewLoginUseCase(nil, jwtUtils, nil)
 	refreshUseCase := auth.NewRefreshTokenUseCase(nil) // TODO: Add auth service
 	logoutUseCase := auth.NewLogoutUseCase(nil, jwtUtils)
 	passwordResetUseCase := auth.NewPasswordResetUseCase(nil, verificationService)
@@ -73,6 +82,7 @@ func (suite *AuthIntegrationTestSuite) SetupSuite() {
 	emailVerificationUseCase := auth.NewEmailVerificationUseCase(nil, verificationService)
 	getProfileUseCase := auth.NewGetProfileUseCase(nil) // TODO: Add auth service
 	getSessionsUseCase := auth.NewGetSessionsUseCase(nil) // TODO: Add auth service
+	revokeSessionUseCase := auth.NewRevokeSessionUseCase(nil) // TODO: Add auth service
 
 	// Create auth handler
 	suite.authHandler = handlers.NewAuthHandler(
@@ -85,6 +95,7 @@ func (suite *AuthIntegrationTestSuite) SetupSuite() {
 		emailVerificationUseCase,
 		getProfileUseCase,
 		getSessionsUseCase,
+		revokeSessionUseCase,
 		jwtUtils,
 		authValidator,
 		rateLimiter,
@@ -51,8 +51,16 @@ func setupDiscoveryTestRouter() (*gin.Engine, *MockUserRepository, *MockMatchRep
 		likeUserUC,
 		dislikeUserUC,
 		superlikeUserUC,
+		nil,
+		nil,
 		getMatchesUC,
+		nil,
+		nil,
+		nil,
+		nil,
 		getDiscoveryStatsUC,
+		nil,
+		nil,
 	)
 	
 	// Create router
@@ -973,6 +981,14 @@ func (m *MockUserRepository) GetByLocation(ctx context.Context, lat, lng float64
 	return args.Get(0).([]*entities.User), args.Error(1)
 }
 
+func (m *MockUserRepository) GetActiveByLocation(ctx context.Context, lat, lng float64, radiusKm int, activeSince time.Time, limit, offset int) ([]*entities.User, error) {
+	args := m.Called(ctx, lat, lng, radiusKm, activeSince, limit, offset)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*entities.User), args.Error(1)
+}
+
 func (m *MockUserRepository) GetUsersByIDs(ctx context.Context, ids []uuid.UUID) ([]*entities.User, error) {
 	args := m.Called(ctx, ids)
 	if args.Get(0) == nil {
@@ -981,6 +997,14 @@ func (m *MockUserRepository) GetUsersByIDs(ctx context.Context, ids []uuid.UUID)
 	return args.Get(0).([]*entities.User), args.Error(1)
 }
 
+func (m *MockUserRepository) GetIDsByEmailHashes(ctx context.Context, hashes []string) ([]uuid.UUID, error) {
+	args := m.Called(ctx, hashes)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]uuid.UUID), args.Error(1)
+}
+
 func (m *MockUserRepository) UpdateLastActive(ctx context.Context, userID uuid.UUID) error {
 	args := m.Called(ctx, userID)
 	return args.Error(0)
@@ -22,6 +22,7 @@ import (
 	"github.com/22smeargle/winkr-backend/internal/infrastructure/external"
 	"github.com/22smeargle/winkr-backend/internal/interfaces/http/handlers"
 	"github.com/22smeargle/winkr-backend/internal/interfaces/http/middleware"
+	"github.com/22smeargle/winkr-backend/pkg/config"
 	"github.com/22smeargle/winkr-backend/pkg/validator"
 	"github.com/22smeargle/winkr-backend/pkg/utils"
 )
@@ -63,6 +64,8 @@ func (suite *ModerationIntegrationTestSuite) SetupSuite() {
 		suite.contentAnalysisService,
 		suite.moderationCacheService,
 		nil, // notification service
+		nil, // ban cascade
+		nil, // review queue
 		services.ModerationConfig{},
 	)
 
@@ -106,6 +109,10 @@ func (suite *ModerationIntegrationTestSuite) SetupSuite() {
 		suite.moderationService,
 		suite.moderationCacheService,
 		nil, // notification service
+		nil, // ban cascader
+		&config.AppealConfig{Enabled: true},
+		nil, // appeal assessor
+		nil, // appeal outcome recorder
 	)
 
 	// Create validators
@@ -118,12 +125,15 @@ func (suite *ModerationIntegrationTestSuite) SetupSuite() {
 		unblockUserUseCase,
 		getBlockedUsersUseCase,
 		getMyReportsUseCase,
+		banUserUseCase,
 		moderationValidator,
 	)
 
 	suite.adminModerationHandler = handlers.NewAdminModerationHandler(
 		reviewReportUseCase,
 		banUserUseCase,
+		nil,
+		nil,
 		moderationValidator,
 	)
 
@@ -2,6 +2,7 @@ package integration
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
 	"fmt"
 	"net/http"
@@ -55,6 +56,7 @@ func (suite *PaymentIntegrationTestSuite) SetupSuite() {
 		DefaultCurrency: "usd",
 		SuccessURL:      "/payment/success",
 		CancelURL:       "/payment/cancel",
+		SupportedCurrencies: []string{"usd", "eur"},
 		WebhookEndpoint: "/api/v1/payment/webhook",
 		EnableRadar:     true,
 		FraudLevel:      "normal",
@@ -77,15 +79,21 @@ func (suite *PaymentIntegrationTestSuite) SetupSuite() {
 	getPlansUseCase := payment.NewGetPlansUseCase(suite.stripeService)
 	getPlanByIDUseCase := payment.NewGetPlanByIDUseCase(suite.stripeService)
 	subscribeUseCase := payment.NewSubscribeUseCase(nil, nil, nil, suite.stripeService, suite.cacheService)
+	createPaymentIntentUseCase := payment.NewCreatePaymentIntentUseCase(nil, suite.stripeService, suite.cacheService)
+	createCheckoutSessionUseCase := payment.NewCreateCheckoutSessionUseCase(nil, suite.stripeService)
 	getSubscriptionUseCase := payment.NewGetSubscriptionUseCase(nil, nil, suite.cacheService)
 	getActiveSubscriptionUseCase := payment.NewGetActiveSubscriptionUseCase(nil, nil, suite.cacheService)
-	cancelSubscriptionUseCase := payment.NewCancelSubscriptionUseCase(nil, suite.stripeService, suite.cacheService)
+	cancelSubscriptionUseCase := payment.NewCancelSubscriptionUseCase(nil, suite.stripeService, suite.cacheService, 0)
+	previewSubscriptionChangeUseCase := payment.NewPreviewSubscriptionChangeUseCase(nil, nil, suite.stripeService)
 	updateSubscriptionUseCase := payment.NewUpdateSubscriptionUseCase(nil, suite.stripeService, suite.cacheService)
 	addPaymentMethodUseCase := payment.NewAddPaymentMethodUseCase(nil, nil, suite.stripeService, suite.cacheService)
 	getPaymentMethodsUseCase := payment.NewGetPaymentMethodsUseCase(nil, nil, suite.cacheService)
 	getDefaultPaymentMethodUseCase := payment.NewGetDefaultPaymentMethodUseCase(nil, nil, suite.cacheService)
 	deletePaymentMethodUseCase := payment.NewDeletePaymentMethodUseCase(nil, suite.stripeService, suite.cacheService)
-	processWebhookUseCase := payment.NewProcessWebhookUseCase(nil, nil, nil, nil, nil, nil, nil, nil, suite.stripeService, suite.cacheService)
+	setDefaultPaymentMethodUseCase := payment.NewSetDefaultPaymentMethodUseCase(nil, nil, suite.stripeService)
+	getInvoicePDFUseCase := payment.NewGetInvoicePDFUseCase(nil, suite.stripeService)
+	validatePromotionCodeUseCase := payment.NewValidatePromotionCodeUseCase(suite.stripeService)
+	processWebhookUseCase := payment.NewProcessWebhookUseCase(nil, nil, nil, nil, nil, nil, nil, nil, suite.stripeService, suite.cacheService, nil, nil, 0, 0)
 	
 	// Create subscription service
 	subscriptionService := services.NewSubscriptionService(nil, suite.stripeService, suite.cacheService)
@@ -95,14 +103,20 @@ func (suite *PaymentIntegrationTestSuite) SetupSuite() {
 		getPlansUseCase,
 		getPlanByIDUseCase,
 		subscribeUseCase,
+		createPaymentIntentUseCase,
+		createCheckoutSessionUseCase,
 		getSubscriptionUseCase,
 		getActiveSubscriptionUseCase,
 		cancelSubscriptionUseCase,
+		previewSubscriptionChangeUseCase,
 		updateSubscriptionUseCase,
 		addPaymentMethodUseCase,
 		getPaymentMethodsUseCase,
 		getDefaultPaymentMethodUseCase,
 		deletePaymentMethodUseCase,
+		setDefaultPaymentMethodUseCase,
+		getInvoicePDFUseCase,
+		validatePromotionCodeUseCase,
 		processWebhookUseCase,
 		subscriptionService,
 		jwtUtils,
@@ -229,6 +243,49 @@ func (suite *PaymentIntegrationTestSuite) TestSubscribe() {
 	assert.Equal(suite.T(), response.Data.Subscription.Status, "active")
 }
 
+// TestPaymentIntentIdempotency tests that repeated pay requests share one payment intent
+func (suite *PaymentIntegrationTestSuite) TestPaymentIntentIdempotency() {
+	userID := uuid.New()
+	key := stripe.PaymentIntentIdempotencyKey(userID, "premium", "nonce-123456")
+	
+	// The key only depends on the user, plan and nonce
+	assert.Equal(suite.T(), key, stripe.PaymentIntentIdempotencyKey(userID, "premium", "nonce-123456"))
+	assert.NotEqual(suite.T(), key, stripe.PaymentIntentIdempotencyKey(userID, "platinum", "nonce-123456"))
+	assert.NotEqual(suite.T(), key, stripe.PaymentIntentIdempotencyKey(userID, "premium", "nonce-654321"))
+	
+	newParams := func(idempotencyKey string) *stripe.PaymentIntentParams {
+		params := &stripe.PaymentIntentParams{
+			Amount:   stripe.Int64(999),
+			Currency: stripe.String("usd"),
+			Customer: stripe.String("cus_test123"),
+		}
+		params.SetIdempotencyKey(idempotencyKey)
+		return params
+	}
+	
+	first, err := suite.mockStripeService.CreatePaymentIntent(context.Background(), newParams(key))
+	require.NoError(suite.T(), err)
+	retried, err := suite.mockStripeService.CreatePaymentIntent(context.Background(), newParams(key))
+	require.NoError(suite.T(), err)
+	assert.Equal(suite.T(), first.ID, retried.ID)
+	
+	other, err := suite.mockStripeService.CreatePaymentIntent(context.Background(), newParams(stripe.PaymentIntentIdempotencyKey(userID, "premium", "nonce-654321")))
+	require.NoError(suite.T(), err)
+	assert.NotEqual(suite.T(), first.ID, other.ID)
+	
+	// The endpoint requires a nonce
+	reqBody, _ := json.Marshal(map[string]string{"plan_id": "premium"})
+	httpReq := httptest.NewRequest("POST", "/api/v1/payment/payment-intents", bytes.NewBuffer(reqBody))
+	httpReq.Header.Set("Content-Type", "application/json")
+	httpReq.Header.Set("Authorization", "Bearer mock-token")
+	httpReq.Header.Set("User-Agent", "test-agent")
+	
+	w := httptest.NewRecorder()
+	suite.router.ServeHTTP(w, httpReq)
+	
+	assert.NotEqual(suite.T(), http.StatusOK, w.Code)
+}
+
 // TestGetSubscription tests the GET /me/subscription endpoint
 func (suite *PaymentIntegrationTestSuite) TestGetSubscription() {
 	// Setup mock response
@@ -311,6 +368,124 @@ func (suite *PaymentIntegrationTestSuite) TestCancelSubscription() {
 	assert.NotEmpty(suite.T(), response.Message)
 }
 
+// TestCancelSubscriptionRefund tests prorated refunds for subscriptions canceled mid-period
+func (suite *PaymentIntegrationTestSuite) TestCancelSubscriptionRefund() {
+	periodStart := time.Date(2025, 6, 1, 0, 0, 0, 0, time.UTC)
+	periodEnd := periodStart.AddDate(0, 0, 30)
+	
+	charge, err := suite.mockStripeService.CreatePaymentIntent(context.Background(), &stripe.PaymentIntentParams{
+		Amount:   stripe.Int64(999),
+		Currency: stripe.String("usd"),
+		Customer: stripe.String("cus_test123"),
+	})
+	require.NoError(suite.T(), err)
+	
+	// Canceling a third of the way through the period refunds the other two thirds
+	amount := stripe.ProratedRefundAmount(charge.Amount, periodStart, periodEnd, periodStart.AddDate(0, 0, 10))
+	assert.Equal(suite.T(), int64(666), amount)
+	
+	refund, err := suite.mockStripeService.CreateRefund(context.Background(), &stripe.RefundParams{
+		PaymentIntent: stripe.String(charge.ID),
+		Amount:        stripe.Int64(amount),
+		Reason:        stripe.String("requested_by_customer"),
+	})
+	require.NoError(suite.T(), err)
+	assert.Equal(suite.T(), amount, refund.Amount)
+	assert.Equal(suite.T(), charge.Currency, refund.Currency)
+	
+	// A charge is never refunded more than it was paid
+	_, err = suite.mockStripeService.CreateRefund(context.Background(), &stripe.RefundParams{
+		PaymentIntent: stripe.String(charge.ID),
+		Amount:        stripe.Int64(charge.Amount),
+	})
+	assert.Error(suite.T(), err)
+	
+	rest, err := suite.mockStripeService.CreateRefund(context.Background(), &stripe.RefundParams{
+		PaymentIntent: stripe.String(charge.ID),
+	})
+	require.NoError(suite.T(), err)
+	assert.Equal(suite.T(), charge.Amount-amount, rest.Amount)
+	
+	// Refunds can't be combined with canceling at the end of the period
+	reqBody, _ := json.Marshal(map[string]interface{}{
+		"user_id":              uuid.New(),
+		"cancel_at_period_end": true,
+		"refund":               true,
+	})
+	httpReq := httptest.NewRequest("POST", "/api/v1/payment/cancel", bytes.NewBuffer(reqBody))
+	httpReq.Header.Set("Content-Type", "application/json")
+	httpReq.Header.Set("Authorization", "Bearer mock-token")
+	httpReq.Header.Set("User-Agent", "test-agent")
+	
+	w := httptest.NewRecorder()
+	suite.router.ServeHTTP(w, httpReq)
+	
+	assert.Equal(suite.T(), http.StatusBadRequest, w.Code)
+}
+
+// TestPreviewSubscriptionChange tests the proration preview for switching plans
+func (suite *PaymentIntegrationTestSuite) TestPreviewSubscriptionChange() {
+	periodStart := time.Date(2025, 6, 1, 0, 0, 0, 0, time.UTC)
+	periodEnd := periodStart.AddDate(0, 0, 30)
+	
+	suite.mockStripeService.SetGetSubscriptionResponse(&stripe.Subscription{
+		ID: "sub_preview123",
+		Customer: &stripe.Customer{
+			ID: "cus_test123",
+		},
+		Items: &stripe.SubscriptionItemList{
+			Data: []*stripe.SubscriptionItem{
+				{
+					Price: &stripe.Price{
+						ID:         "price_premium_monthly",
+						UnitAmount: 999,
+					},
+				},
+			},
+		},
+		Status:             "active",
+		CurrentPeriodStart: periodStart.Unix(),
+		CurrentPeriodEnd:   periodEnd.Unix(),
+	}, nil)
+	suite.mockStripeService.SetPlansResponse([]*stripe.Price{
+		{
+			ID:         "price_platinum_monthly",
+			UnitAmount: 1999,
+			Currency:   "usd",
+		},
+	}, nil)
+	
+	// Switching two thirds of the way through the period prorates the last ten days
+	suite.mockStripeService.SetProrationDate(periodStart.AddDate(0, 0, 20))
+	
+	preview, err := suite.mockStripeService.PreviewSubscriptionChange(context.Background(), "sub_preview123", "price_platinum_monthly")
+	require.NoError(suite.T(), err)
+	assert.Equal(suite.T(), int64(333), preview.ProrationAmount)
+	assert.Equal(suite.T(), int64(2332), preview.AmountDue)
+	assert.Equal(suite.T(), "usd", preview.Currency)
+	assert.Equal(suite.T(), periodEnd.Unix(), preview.NextBillingDate.Unix())
+	
+	// Without a proration date the change is prorated from the middle of the period
+	suite.mockStripeService.SetProrationDate(time.Time{})
+	
+	preview, err = suite.mockStripeService.PreviewSubscriptionChange(context.Background(), "sub_preview123", "price_platinum_monthly")
+	require.NoError(suite.T(), err)
+	assert.Equal(suite.T(), int64(500), preview.ProrationAmount)
+	
+	_, err = suite.mockStripeService.PreviewSubscriptionChange(context.Background(), "sub_preview123", "price_unknown")
+	assert.Error(suite.T(), err)
+	
+	// The endpoint requires a price
+	req := httptest.NewRequest("GET", "/api/v1/payment/subscription/preview", nil)
+	req.Header.Set("Authorization", "Bearer mock-token")
+	req.Header.Set("User-Agent", "test-agent")
+	
+	w := httptest.NewRecorder()
+	suite.router.ServeHTTP(w, req)
+	
+	assert.NotEqual(suite.T(), http.StatusOK, w.Code)
+}
+
 // TestAddPaymentMethod tests the POST /methods endpoint
 func (suite *PaymentIntegrationTestSuite) TestAddPaymentMethod() {
 	// Setup mock response
@@ -431,6 +606,118 @@ func (suite *PaymentIntegrationTestSuite) TestDeletePaymentMethod() {
 	assert.NotEmpty(suite.T(), response.Message)
 }
 
+// TestSetDefaultPaymentMethod tests switching the default payment method
+func (suite *PaymentIntegrationTestSuite) TestSetDefaultPaymentMethod() {
+	suite.mockStripeService.SetCreateCustomerResponse(&stripe.Customer{ID: "cus_default123"}, nil)
+	suite.mockStripeService.SetGetPaymentMethodResponse(&stripe.PaymentMethod{
+		ID:       "pm_first123",
+		Customer: &stripe.Customer{ID: "cus_default123"},
+	}, nil)
+	suite.mockStripeService.SetGetPaymentMethodResponse(&stripe.PaymentMethod{
+		ID:       "pm_other123",
+		Customer: &stripe.Customer{ID: "cus_other123"},
+	}, nil)
+	
+	defaultID, err := suite.mockStripeService.SetDefaultPaymentMethod(context.Background(), "cus_default123", "pm_first123")
+	require.NoError(suite.T(), err)
+	assert.Equal(suite.T(), "pm_first123", defaultID)
+	
+	customer, err := suite.mockStripeService.GetCustomer(context.Background(), "cus_default123")
+	require.NoError(suite.T(), err)
+	assert.Equal(suite.T(), "pm_first123", customer.InvoiceSettings.DefaultPaymentMethod.ID)
+	
+	// Another customer's card can't become the default
+	_, err = suite.mockStripeService.SetDefaultPaymentMethod(context.Background(), "cus_default123", "pm_other123")
+	assert.Error(suite.T(), err)
+	assert.Equal(suite.T(), "pm_first123", customer.InvoiceSettings.DefaultPaymentMethod.ID)
+	
+	// The endpoint requires authentication
+	httpReq := httptest.NewRequest("PUT", fmt.Sprintf("/api/v1/payment/payment-methods/%s/default", uuid.New().String()), nil)
+	httpReq.Header.Set("User-Agent", "test-agent")
+	w := httptest.NewRecorder()
+	suite.router.ServeHTTP(w, httpReq)
+	
+	assert.Equal(suite.T(), http.StatusUnauthorized, w.Code)
+}
+
+// TestInvoicePDF tests the invoice PDF download links
+func (suite *PaymentIntegrationTestSuite) TestInvoicePDF() {
+	suite.mockStripeService.SetGetInvoiceResponse(&stripe.Invoice{
+		ID:       "in_paid123",
+		Customer: &stripe.Customer{ID: "cus_test123"},
+		Status:   stripe.InvoiceStatusPaid,
+	}, nil)
+	suite.mockStripeService.SetGetInvoiceResponse(&stripe.Invoice{
+		ID:       "in_draft123",
+		Customer: &stripe.Customer{ID: "cus_test123"},
+		Status:   stripe.InvoiceStatusDraft,
+	}, nil)
+	
+	// The URL is stable per invoice
+	first, err := suite.mockStripeService.GetInvoicePDF(context.Background(), "in_paid123")
+	require.NoError(suite.T(), err)
+	again, err := suite.mockStripeService.GetInvoicePDF(context.Background(), "in_paid123")
+	require.NoError(suite.T(), err)
+	assert.Equal(suite.T(), first.URL, again.URL)
+	assert.Contains(suite.T(), first.URL, "in_paid123")
+	assert.Equal(suite.T(), "cus_test123", first.CustomerID)
+	
+	// Drafts have no PDF until they are finalized
+	draft, err := suite.mockStripeService.GetInvoicePDF(context.Background(), "in_draft123")
+	require.NoError(suite.T(), err)
+	assert.Empty(suite.T(), draft.URL)
+	
+	_, err = suite.mockStripeService.GetInvoicePDF(context.Background(), "in_missing")
+	assert.ErrorIs(suite.T(), err, stripe.ErrInvoiceNotFound)
+	
+	// The endpoint requires authentication
+	httpReq := httptest.NewRequest("GET", "/api/v1/payment/invoices/in_paid123/pdf", nil)
+	httpReq.Header.Set("User-Agent", "test-agent")
+	w := httptest.NewRecorder()
+	suite.router.ServeHTTP(w, httpReq)
+	
+	assert.Equal(suite.T(), http.StatusUnauthorized, w.Code)
+}
+
+// TestValidatePromotionCode tests promotion code validation before checkout
+func (suite *PaymentIntegrationTestSuite) TestValidatePromotionCode() {
+	suite.mockStripeService.SetPromotionCodeResponse(&stripe.PromotionCode{
+		ID:     "promo_summer123",
+		Code:   "SUMMER25",
+		Active: true,
+		Coupon: &stripe.Coupon{ID: "coupon_summer", Valid: true, PercentOff: 25, Duration: stripe.CouponDurationOnce},
+	})
+	suite.mockStripeService.SetPromotionCodeResponse(&stripe.PromotionCode{
+		ID:        "promo_spring123",
+		Code:      "SPRING10",
+		Active:    true,
+		ExpiresAt: time.Now().Add(-time.Hour).Unix(),
+		Coupon:    &stripe.Coupon{ID: "coupon_spring", Valid: true, AmountOff: 1000, Currency: stripe.CurrencyUSD},
+	})
+	
+	promotionCode, err := suite.mockStripeService.ValidatePromotionCode(context.Background(), "SUMMER25")
+	require.NoError(suite.T(), err)
+	assert.Equal(suite.T(), "promo_summer123", promotionCode.ID)
+	assert.Equal(suite.T(), "coupon_summer", promotionCode.CouponID)
+	assert.Equal(suite.T(), 25.0, promotionCode.PercentOff)
+	
+	_, err = suite.mockStripeService.ValidatePromotionCode(context.Background(), "SPRING10")
+	assert.ErrorIs(suite.T(), err, stripe.ErrPromotionCodeInactive)
+	
+	_, err = suite.mockStripeService.ValidatePromotionCode(context.Background(), "UNKNOWN")
+	assert.ErrorIs(suite.T(), err, stripe.ErrPromotionCodeNotFound)
+	
+	// The endpoint requires authentication
+	body, _ := json.Marshal(map[string]string{"code": "SUMMER25"})
+	httpReq := httptest.NewRequest("POST", "/api/v1/payment/promo/validate", bytes.NewBuffer(body))
+	httpReq.Header.Set("Content-Type", "application/json")
+	httpReq.Header.Set("User-Agent", "test-agent")
+	w := httptest.NewRecorder()
+	suite.router.ServeHTTP(w, httpReq)
+	
+	assert.Equal(suite.T(), http.StatusUnauthorized, w.Code)
+}
+
 // TestWebhookProcessing tests the POST /webhook endpoint
 func (suite *PaymentIntegrationTestSuite) TestWebhookProcessing() {
 	// Setup mock response
@@ -123,6 +123,8 @@ func (suite *PhotoIntegrationTestSuite) setupTestServer() {
 		getDownloadURLUseCase,
 		setPrimaryPhotoUseCase,
 		markPhotoViewedUseCase,
+		nil,
+		nil,
 		nil, // JWT utils not needed for integration tests
 	)
 
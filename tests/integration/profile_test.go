@@ -60,7 +60,7 @@ func (suite *ProfileTestSuite) SetupSuite() {
 	// In a real test, you would use mocks or test containers
 	profileHandler := handlers.NewProfileHandler(
 		// Mock use cases would be injected here
-		nil, nil, nil, nil, nil, nil, nil, nil,
+		nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil,
 	)
 	
 	// Setup routes
@@ -72,7 +72,7 @@ func (suite *VerificationIntegrationTestSuite) SetupSuite() {
 		ExtractionEnabled:     true,
 		ValidationEnabled:     true,
 	}
-	suite.documentService = services.NewDocumentService(documentConfig)
+	suite.documentService = services.NewDocumentService(suite.aiService, documentConfig)
 	
 	// Create verification workflow service
 	verificationConfig := &config.VerificationConfig{
@@ -114,8 +114,9 @@ func (suite *VerificationIntegrationTestSuite) SetupSuite() {
 		suite.aiService,
 		suite.documentService,
 		suite.storageService,
-		suite.cacheService,
-		verificationConfig,
+		&verificationConfig.Limits,
+		nil,
+		nil,
 	)
 	
 	// Create JWT utils
@@ -132,6 +133,7 @@ func (suite *VerificationIntegrationTestSuite) SetupSuite() {
 		suite.verificationRepo, suite.userRepo, verificationWorkflowService, suite.rateLimiter)
 	submitDocumentVerificationUseCase := verification.NewSubmitDocumentVerificationUseCase(
 		suite.verificationRepo, suite.userRepo, verificationWorkflowService, suite.storageService, suite.rateLimiter)
+	submitDocumentSessionUseCase := verification.NewSubmitDocumentSessionUseCase(verificationWorkflowService)
 	processVerificationResultUseCase := verification.NewProcessVerificationResultUseCase(
 		suite.verificationRepo, suite.userRepo, verificationWorkflowService)
 	getPendingVerificationsUseCase := verification.NewGetPendingVerificationsUseCase(
@@ -144,6 +146,7 @@ func (suite *VerificationIntegrationTestSuite) SetupSuite() {
 		getVerificationStatusUseCase,
 		requestDocumentVerificationUseCase,
 		submitDocumentVerificationUseCase,
+		submitDocumentSessionUseCase,
 		suite.jwtUtils,
 	)
 	
@@ -151,6 +154,7 @@ func (suite *VerificationIntegrationTestSuite) SetupSuite() {
 		processVerificationResultUseCase,
 		getPendingVerificationsUseCase,
 		suite.jwtUtils,
+		verification.NewOverrideVerificationLevelUseCase(verificationWorkflowService),
 	)
 	
 	// Create router